@@ -9,7 +9,7 @@ use oasis_core_runtime::{
         roothash::{AnnotatedBlock, Block},
         runtime::RuntimeId,
     },
-    transaction::types::TxnBatch,
+    transaction::{rwset::ReadWriteSet, types::TxnBatch},
 };
 
 /// Special round number always referring to the latest round.
@@ -22,6 +22,46 @@ pub struct SubmitTxRequest {
     pub data: Vec<u8>,
 }
 
+/// Response to a `SubmitTxMeta` call.
+#[derive(Clone, Debug, Serialize, Deserialize)]
+pub struct SubmitTxMetaResponse {
+    /// Output of the transaction.
+    #[serde(default)]
+    pub output: ByteBuf,
+    /// Round in which the transaction was executed.
+    pub round: u64,
+    /// Order of the transaction in the execution batch.
+    #[serde(default)]
+    pub batch_order: u32,
+}
+
+#[derive(Clone, Debug, Serialize, Deserialize)]
+pub struct CheckTxRequest {
+    pub runtime_id: RuntimeId,
+    #[serde(with = "serde_bytes")]
+    pub data: Vec<u8>,
+}
+
+/// Error returned by the runtime when it rejects a transaction.
+#[derive(Clone, Debug, Serialize, Deserialize)]
+pub struct CheckTxError {
+    /// Error message returned by the runtime.
+    pub message: String,
+}
+
+/// Result of a `CheckTx` call.
+#[derive(Clone, Debug, Serialize, Deserialize)]
+pub struct CheckTxResult {
+    /// Round of the runtime block the check was based on.
+    pub round: u64,
+    /// Error returned by the runtime in case it rejected the transaction.
+    #[serde(default)]
+    pub error: Option<CheckTxError>,
+    /// Read/write set predicted by the runtime in case the transaction is valid.
+    #[serde(default)]
+    pub predicted_rw_set: Option<ReadWriteSet>,
+}
+
 #[derive(Clone, Debug, Serialize, Deserialize)]
 pub struct GetBlockRequest {
     pub runtime_id: RuntimeId,
@@ -43,6 +83,8 @@ pub struct TxResult {
     pub input: Vec<u8>,
     #[serde(with = "serde_bytes")]
     pub output: Vec<u8>,
+    #[serde(default)]
+    pub gas_used: u64,
 }
 
 #[derive(Clone, Debug, Serialize, Deserialize)]
@@ -73,8 +115,22 @@ pub struct QueryTxRequest {
     pub key: Vec<u8>,
     #[serde(with = "serde_bytes")]
     pub value: Vec<u8>,
+    /// Optional additional query conditions.
+    ///
+    /// They are combined with the key/value condition using an AND query
+    /// which means that all of the conditions must be satisfied for a
+    /// transaction to match.
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub conditions: Vec<QueryCondition>,
 }
 
+/// Match tag values that are exactly equal to the given value.
+pub const QUERY_MATCH_EXACT: u8 = 0;
+/// Match tag values that start with the given value.
+pub const QUERY_MATCH_PREFIX: u8 = 1;
+/// Match tag values that contain all of the words in the given value.
+pub const QUERY_MATCH_FULL_TEXT: u8 = 2;
+
 /// A query condition.
 #[derive(Clone, Debug, Serialize, Deserialize)]
 pub struct QueryCondition {
@@ -85,6 +141,9 @@ pub struct QueryCondition {
     /// are combined using an OR query which means that any of the
     /// values will match.
     pub values: Vec<ByteBuf>,
+    /// Kind of matching used for the values (one of the `QUERY_MATCH_*` constants).
+    #[serde(default, rename = "match")]
+    pub match_kind: u8,
 }
 
 /// A complex query against the index.
@@ -101,6 +160,9 @@ pub struct Query {
     pub conditions: Vec<QueryCondition>,
     /// The maximum number of results to return.
     pub limit: u64,
+    /// An optional continuation cursor returned by a previous query.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub cursor: Option<ByteBuf>,
 }
 
 #[derive(Clone, Debug, Serialize, Deserialize)]
@@ -109,18 +171,84 @@ pub struct QueryTxsRequest {
     pub query: Query,
 }
 
+/// Response to a `QueryTxsPaginated` call.
+#[derive(Clone, Debug, Serialize, Deserialize)]
+pub struct QueryTxsResponse {
+    /// The matching transactions, ordered by round and index.
+    pub results: Vec<TxResult>,
+    /// The continuation cursor that can be used to fetch the next results.
+    #[serde(default)]
+    pub cursor: Option<ByteBuf>,
+}
+
 #[derive(Clone, Debug, Serialize, Deserialize)]
 pub struct WaitBlockIndexedRequest {
     pub runtime_id: RuntimeId,
     pub round: u64,
 }
 
+#[derive(Clone, Debug, Serialize, Deserialize)]
+pub struct GetTxStatusRequest {
+    pub runtime_id: RuntimeId,
+    pub tx_hash: Hash,
+}
+
+/// Status of a transaction that is not known to the client.
+pub const TX_STATUS_UNKNOWN: u8 = 0;
+/// Status of a transaction that has been submitted but not yet published to the transaction
+/// scheduler of the current committee.
+pub const TX_STATUS_PENDING: u8 = 1;
+/// Status of a transaction that has been included in a block.
+pub const TX_STATUS_INCLUDED: u8 = 2;
+/// Status of a transaction that could not be included in a block.
+pub const TX_STATUS_FAILED: u8 = 3;
+/// Status of a transaction that has been published to the transaction scheduler of the current
+/// committee but not yet included in a block.
+pub const TX_STATUS_SCHEDULED: u8 = 4;
+
+/// Transaction status.
+#[derive(Clone, Debug, Serialize, Deserialize)]
+pub struct TxStatus {
+    /// Kind of the transaction status (one of the `TX_STATUS_*` constants).
+    pub status: u8,
+    /// Round of the block that includes the transaction.
+    #[serde(default)]
+    pub round: u64,
+    /// Index of the transaction within the block.
+    #[serde(default)]
+    pub index: u32,
+    /// Output of the transaction.
+    #[serde(default)]
+    pub output: ByteBuf,
+    /// Reason why the transaction failed.
+    #[serde(default)]
+    pub reason: String,
+}
+
 grpc_method!(
     METHOD_SUBMIT_TX,
     "/oasis-core.RuntimeClient/SubmitTx",
     SubmitTxRequest,
     ByteBuf
 );
+grpc_method!(
+    METHOD_SUBMIT_TX_META,
+    "/oasis-core.RuntimeClient/SubmitTxMeta",
+    SubmitTxRequest,
+    SubmitTxMetaResponse
+);
+grpc_method!(
+    METHOD_SUBMIT_TX_NO_WAIT,
+    "/oasis-core.RuntimeClient/SubmitTxNoWait",
+    SubmitTxRequest,
+    ()
+);
+grpc_method!(
+    METHOD_CHECK_TX,
+    "/oasis-core.RuntimeClient/CheckTx",
+    CheckTxRequest,
+    CheckTxResult
+);
 grpc_method!(
     METHOD_GET_BLOCK,
     "/oasis-core.RuntimeClient/GetBlock",
@@ -163,6 +291,18 @@ grpc_method!(
     QueryTxsRequest,
     Vec<TxResult>
 );
+grpc_method!(
+    METHOD_QUERY_TXS_PAGINATED,
+    "/oasis-core.RuntimeClient/QueryTxsPaginated",
+    QueryTxsRequest,
+    QueryTxsResponse
+);
+grpc_method!(
+    METHOD_GET_TX_STATUS,
+    "/oasis-core.RuntimeClient/GetTxStatus",
+    GetTxStatusRequest,
+    TxStatus
+);
 grpc_method!(
     METHOD_WAIT_BLOCK_INDEXED,
     "/oasis-core.RuntimeClient/WaitBlockIndexed",
@@ -199,6 +339,32 @@ impl RuntimeClient {
             .unary_call_async(&METHOD_SUBMIT_TX, &request, opt)
     }
 
+    pub fn submit_tx_meta(
+        &self,
+        request: &SubmitTxRequest,
+        opt: CallOption,
+    ) -> Result<ClientUnaryReceiver<SubmitTxMetaResponse>> {
+        self.client
+            .unary_call_async(&METHOD_SUBMIT_TX_META, &request, opt)
+    }
+
+    pub fn submit_tx_no_wait(
+        &self,
+        request: &SubmitTxRequest,
+        opt: CallOption,
+    ) -> Result<ClientUnaryReceiver<()>> {
+        self.client
+            .unary_call_async(&METHOD_SUBMIT_TX_NO_WAIT, &request, opt)
+    }
+
+    pub fn check_tx(
+        &self,
+        request: &CheckTxRequest,
+        opt: CallOption,
+    ) -> Result<ClientUnaryReceiver<CheckTxResult>> {
+        self.client.unary_call_async(&METHOD_CHECK_TX, &request, opt)
+    }
+
     pub fn get_block(
         &self,
         request: &GetBlockRequest,
@@ -260,6 +426,24 @@ impl RuntimeClient {
             .unary_call_async(&METHOD_QUERY_TXS, &request, opt)
     }
 
+    pub fn query_txs_paginated(
+        &self,
+        request: &QueryTxsRequest,
+        opt: CallOption,
+    ) -> Result<ClientUnaryReceiver<QueryTxsResponse>> {
+        self.client
+            .unary_call_async(&METHOD_QUERY_TXS_PAGINATED, &request, opt)
+    }
+
+    pub fn get_tx_status(
+        &self,
+        request: &GetTxStatusRequest,
+        opt: CallOption,
+    ) -> Result<ClientUnaryReceiver<TxStatus>> {
+        self.client
+            .unary_call_async(&METHOD_GET_TX_STATUS, &request, opt)
+    }
+
     pub fn wait_block_indexed(
         &self,
         request: &WaitBlockIndexedRequest,
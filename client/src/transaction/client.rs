@@ -336,6 +336,7 @@ impl TxnClient {
             runtime_id: self.runtime_id,
             key: key.as_ref().into(),
             value: value.as_ref().into(),
+            conditions: vec![],
         };
 
         let result: BoxFuture<Option<TransactionSnapshot>> =
@@ -404,6 +405,27 @@ impl TxnClient {
         result
     }
 
+    /// Get the status of a transaction identified by its hash.
+    pub fn get_tx_status(&self, tx_hash: Hash) -> BoxFuture<api::client::TxStatus> {
+        let (span, options) = self.prepare_options("TxnClient::get_tx_status");
+        let request = api::client::GetTxStatusRequest {
+            runtime_id: self.runtime_id,
+            tx_hash,
+        };
+
+        let result: BoxFuture<api::client::TxStatus> =
+            match self.client.get_tx_status(&request, options) {
+                Ok(resp) => Box::new(
+                    resp.map_err(|error| TxnClientError::CallFailed(format!("{}", error)).into()),
+                ),
+                Err(error) => Box::new(future::err(
+                    TxnClientError::CallFailed(format!("{}", error)).into(),
+                )),
+            };
+        drop(span);
+        result
+    }
+
     /// Wait for a block to be indexed by the indexer.
     pub fn wait_block_indexed(&self, round: u64) -> BoxFuture<()> {
         let (span, options) = self.prepare_options("TxnClient::wait_block_indexed");
@@ -2,12 +2,15 @@
 package badger
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/dgraph-io/badger/v2"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 )
@@ -15,8 +18,50 @@ import (
 const (
 	gcInterval     = 5 * time.Minute
 	gcDiscardRatio = 0.5
+
+	gcTriggerScheduled = "scheduled"
+	gcTriggerManual    = "manual"
+)
+
+// ErrGCWorkerClosed is the error returned when triggering GC on a closed GC worker.
+var ErrGCWorkerClosed = errors.New("badger: GC worker closed")
+
+var (
+	gcRuns = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_badger_gc_runs",
+			Help: "Number of BadgerDB value log GC runs.",
+		},
+		[]string{"trigger"},
+	)
+	gcSkipped = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "oasis_badger_gc_skipped",
+			Help: "Number of periodic BadgerDB value log GC runs skipped outside the GC windows.",
+		},
+	)
+	gcReclaimedBytes = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "oasis_badger_gc_reclaimed_bytes",
+			Help: "Number of bytes reclaimed by BadgerDB value log GC.",
+		},
+	)
+
+	gcCollectors = []prometheus.Collector{
+		gcRuns,
+		gcSkipped,
+		gcReclaimedBytes,
+	}
+
+	metricsOnce sync.Once
 )
 
+func initMetrics() {
+	metricsOnce.Do(func() {
+		prometheus.MustRegister(gcCollectors...)
+	})
+}
+
 // NewLogAdapter returns a badger.Logger backed by an oasis-node logger.
 func NewLogAdapter(logger *logging.Logger) badger.Logger {
 	return &badgerLogger{
@@ -48,13 +93,25 @@ func (l *badgerLogger) Debugf(format string, a ...interface{}) {
 type GCWorker struct {
 	logger *logging.Logger
 
-	db *badger.DB
+	db      *badger.DB
+	windows []GCWindow
+
+	triggerCh chan *gcTrigger
 
 	closeOnce sync.Once
 	closeCh   chan struct{}
 	closedCh  chan struct{}
 }
 
+type gcTrigger struct {
+	ch chan *gcResult
+}
+
+type gcResult struct {
+	reclaimed int64
+	err       error
+}
+
 // Close halts the GC worker.
 func (gc *GCWorker) Close() {
 	gc.closeOnce.Do(func() {
@@ -63,47 +120,119 @@ func (gc *GCWorker) Close() {
 	})
 }
 
+// Trigger runs the value log GC immediately, regardless of the configured GC
+// windows, and returns the number of reclaimed bytes.
+func (gc *GCWorker) Trigger(ctx context.Context) (int64, error) {
+	t := &gcTrigger{
+		ch: make(chan *gcResult, 1),
+	}
+
+	select {
+	case gc.triggerCh <- t:
+	case <-gc.closeCh:
+		return 0, ErrGCWorkerClosed
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+
+	select {
+	case res := <-t.ch:
+		return res.reclaimed, res.err
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+func (gc *GCWorker) inWindow(t time.Time) bool {
+	if len(gc.windows) == 0 {
+		return true
+	}
+	for _, w := range gc.windows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (gc *GCWorker) doGC(trigger string) (int64, error) {
+	lsmBefore, vlogBefore := gc.db.Size()
+
+	var err error
+	for {
+		if err = gc.db.RunValueLogGC(gcDiscardRatio); err != nil {
+			break
+		}
+	}
+	switch err {
+	case badger.ErrNoRewrite:
+		err = nil
+	default:
+		gc.logger.Error("failed to GC value log",
+			"err", err,
+		)
+	}
+
+	lsmAfter, vlogAfter := gc.db.Size()
+	reclaimed := (lsmBefore + vlogBefore) - (lsmAfter + vlogAfter)
+	if reclaimed < 0 {
+		reclaimed = 0
+	}
+
+	gcRuns.With(prometheus.Labels{"trigger": trigger}).Inc()
+	gcReclaimedBytes.Add(float64(reclaimed))
+
+	return reclaimed, err
+}
+
 func (gc *GCWorker) worker() {
 	defer close(gc.closedCh)
 
 	ticker := time.NewTicker(gcInterval)
 	defer ticker.Stop()
 
-	doGC := func() error {
-		for {
-			if err := gc.db.RunValueLogGC(gcDiscardRatio); err != nil {
-				return err
-			}
-		}
-	}
-
 	for {
 		select {
 		case <-gc.closeCh:
 			return
+		case t := <-gc.triggerCh:
+			reclaimed, err := gc.doGC(gcTriggerManual)
+			t.ch <- &gcResult{reclaimed: reclaimed, err: err}
+			continue
 		case <-ticker.C:
 		}
 
-		// Run the value log GC.
-		err := doGC()
-		switch err {
-		case nil, badger.ErrNoRewrite:
-		default:
-			gc.logger.Error("failed to GC value log",
-				"err", err,
-			)
+		// Only run the periodic value log GC inside the configured windows.
+		if !gc.inWindow(time.Now()) {
+			gcSkipped.Inc()
+			continue
 		}
+
+		// Run the value log GC.
+		_, _ = gc.doGC(gcTriggerScheduled)
 	}
 }
 
 // NewGCWorker creates a new BadgerDB value log GC worker for the provided
 // db, logging to the specified logger.
 func NewGCWorker(logger *logging.Logger, db *badger.DB) *GCWorker {
+	return NewScheduledGCWorker(logger, db, nil)
+}
+
+// NewScheduledGCWorker creates a new BadgerDB value log GC worker for the
+// provided db that only runs periodic GC inside the given time windows.
+//
+// If no windows are given, periodic GC runs at any time.
+func NewScheduledGCWorker(logger *logging.Logger, db *badger.DB, windows []GCWindow) *GCWorker {
+	initMetrics()
+
 	gc := &GCWorker{
-		logger:   logger,
-		db:       db,
-		closeCh:  make(chan struct{}),
-		closedCh: make(chan struct{}),
+		logger:    logger,
+		db:        db,
+		windows:   windows,
+		triggerCh: make(chan *gcTrigger),
+		closeCh:   make(chan struct{}),
+		closedCh:  make(chan struct{}),
 	}
 
 	go gc.worker()
@@ -0,0 +1,86 @@
+package badger
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+// GCWindow is a daily UTC time window during which periodic value log GC is
+// allowed to run.
+type GCWindow struct {
+	// Start is the offset of the window start since midnight UTC.
+	Start time.Duration
+	// End is the offset of the window end since midnight UTC.
+	End time.Duration
+}
+
+// Contains returns true iff the given time falls inside the window.
+//
+// Windows where the end precedes the start wrap around midnight.
+func (w GCWindow) Contains(t time.Time) bool {
+	t = t.UTC()
+	offset := time.Duration(t.Hour())*time.Hour +
+		time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second
+	if w.Start <= w.End {
+		return offset >= w.Start && offset < w.End
+	}
+	return offset >= w.Start || offset < w.End
+}
+
+// String returns a string representation of the window.
+func (w GCWindow) String() string {
+	return fmt.Sprintf("%s-%s", formatOffset(w.Start), formatOffset(w.End))
+}
+
+// ParseGCWindow parses a GC window of the form HH:MM-HH:MM (UTC).
+func ParseGCWindow(s string) (GCWindow, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return GCWindow{}, fmt.Errorf("badger: malformed GC window '%s'", s)
+	}
+
+	var (
+		w   GCWindow
+		err error
+	)
+	if w.Start, err = parseOffset(parts[0]); err != nil {
+		return GCWindow{}, fmt.Errorf("badger: malformed GC window start '%s': %w", parts[0], err)
+	}
+	if w.End, err = parseOffset(parts[1]); err != nil {
+		return GCWindow{}, fmt.Errorf("badger: malformed GC window end '%s': %w", parts[1], err)
+	}
+	if w.Start == w.End {
+		return GCWindow{}, fmt.Errorf("badger: empty GC window '%s'", s)
+	}
+	return w, nil
+}
+
+// ParseGCWindows parses a list of GC windows of the form HH:MM-HH:MM (UTC).
+func ParseGCWindows(windows []string) ([]GCWindow, error) {
+	var result []GCWindow
+	for _, s := range windows {
+		w, err := ParseGCWindow(s)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, w)
+	}
+	return result, nil
+}
+
+func parseOffset(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func formatOffset(d time.Duration) string {
+	d = d % day
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int((d%time.Hour)/time.Minute))
+}
@@ -0,0 +1,48 @@
+package badger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGCWindow(t *testing.T) {
+	require := require.New(t)
+
+	at := func(hour, min int) time.Time {
+		return time.Date(2020, 10, 1, hour, min, 0, 0, time.UTC)
+	}
+
+	w, err := ParseGCWindow("01:30-05:00")
+	require.NoError(err, "ParseGCWindow")
+	require.Equal("01:30-05:00", w.String())
+	require.False(w.Contains(at(1, 29)))
+	require.True(w.Contains(at(1, 30)))
+	require.True(w.Contains(at(4, 59)))
+	require.False(w.Contains(at(5, 0)))
+
+	// Windows wrapping around midnight.
+	w, err = ParseGCWindow("22:00-02:00")
+	require.NoError(err, "ParseGCWindow")
+	require.True(w.Contains(at(23, 0)))
+	require.True(w.Contains(at(0, 30)))
+	require.False(w.Contains(at(2, 0)))
+	require.False(w.Contains(at(12, 0)))
+
+	for _, s := range []string{
+		"",
+		"01:00",
+		"01:00-",
+		"25:00-02:00",
+		"01:00-01:00",
+		"01:00-02:00-03:00",
+	} {
+		_, err = ParseGCWindow(s)
+		require.Error(err, "ParseGCWindow should fail for '%s'", s)
+	}
+
+	ws, err := ParseGCWindows([]string{"01:00-02:00", "13:00-14:00"})
+	require.NoError(err, "ParseGCWindows")
+	require.Len(ws, 2)
+}
@@ -0,0 +1,86 @@
+package signature
+
+import (
+	"crypto/rand"
+
+	"github.com/oasisprotocol/ed25519"
+)
+
+// BatchVerifier accumulates signatures so that they can be verified
+// together, which is considerably faster than verifying each signature
+// individually.
+type BatchVerifier struct {
+	pks     []ed25519.PublicKey
+	msgs    [][]byte
+	rawSigs [][]byte
+
+	// invalid marks entries which are known to be invalid before the
+	// batch is verified (e.g., blacklisted public keys).
+	invalid []bool
+	// anyInvalid is true iff at least one entry is known to be invalid.
+	anyInvalid bool
+}
+
+// Len returns the number of signatures added to the batch verifier.
+func (v *BatchVerifier) Len() int {
+	return len(v.pks)
+}
+
+// Add adds a signature over the given context and message to the batch.
+func (v *BatchVerifier) Add(context Context, message []byte, sig *Signature) {
+	msg, err := PrepareSignerMessage(context, message)
+	v.add(msg, sig, err != nil)
+}
+
+// AddManyToOne adds multiple signatures over the same context and message
+// to the batch.
+func (v *BatchVerifier) AddManyToOne(context Context, message []byte, sigs []Signature) {
+	msg, err := PrepareSignerMessage(context, message)
+	for i := range sigs {
+		v.add(msg, &sigs[i], err != nil)
+	}
+}
+
+func (v *BatchVerifier) add(msg []byte, sig *Signature, invalid bool) {
+	invalid = invalid || sig.PublicKey.IsBlacklisted()
+
+	v.pks = append(v.pks, ed25519.PublicKey(sig.PublicKey[:]))
+	v.msgs = append(v.msgs, msg)
+	v.rawSigs = append(v.rawSigs, sig.Signature[:])
+	v.invalid = append(v.invalid, invalid)
+	v.anyInvalid = v.anyInvalid || invalid
+}
+
+// Verify verifies all signatures in the batch, returning true iff every
+// signature is valid. In addition, the validity of each signature is
+// returned in the order in which the signatures were added.
+func (v *BatchVerifier) Verify() (bool, []bool) {
+	valid := make([]bool, len(v.pks))
+	allOk, batchValid, err := ed25519.VerifyBatch(rand.Reader, v.pks, v.msgs, v.rawSigs, defaultOptions)
+	if err != nil {
+		return false, valid
+	}
+	for i := range valid {
+		valid[i] = batchValid[i] && !v.invalid[i]
+	}
+
+	return allOk && !v.anyInvalid, valid
+}
+
+// VerifyAll verifies all signatures in the batch, returning true iff every
+// signature is valid.
+func (v *BatchVerifier) VerifyAll() bool {
+	// Avoid the batch verification in case an entry is already known to
+	// be invalid.
+	if v.anyInvalid {
+		return false
+	}
+
+	allOk, _ := v.Verify()
+	return allOk
+}
+
+// NewBatchVerifier creates a new batch verifier.
+func NewBatchVerifier() *BatchVerifier {
+	return &BatchVerifier{}
+}
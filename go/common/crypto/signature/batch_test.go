@@ -0,0 +1,132 @@
+package signature
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/oasisprotocol/ed25519"
+	"github.com/stretchr/testify/require"
+)
+
+var testBatchContext = NewContext("test: batch verifier")
+
+func newTestSignature(t testing.TB, context Context, message []byte) *Signature {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	data, err := PrepareSignerMessage(context, message)
+	if err != nil {
+		t.Fatalf("failed to prepare message: %v", err)
+	}
+
+	var sig Signature
+	copy(sig.PublicKey[:], pub)
+	copy(sig.Signature[:], ed25519.Sign(priv, data))
+	return &sig
+}
+
+func TestBatchVerifier(t *testing.T) {
+	require := require.New(t)
+
+	msg := []byte("batch verifier test message")
+
+	// All valid signatures.
+	v := NewBatchVerifier()
+	var sigs []Signature
+	for i := 0; i < 10; i++ {
+		sig := newTestSignature(t, testBatchContext, msg)
+		v.Add(testBatchContext, msg, sig)
+		sigs = append(sigs, *sig)
+	}
+	require.Equal(10, v.Len())
+	allOk, valid := v.Verify()
+	require.True(allOk, "batch with valid signatures should verify")
+	require.Len(valid, 10)
+	for i := range valid {
+		require.True(valid[i], "signature %d should be valid", i)
+	}
+	require.True(v.VerifyAll(), "batch with valid signatures should verify")
+
+	// Many to one.
+	v = NewBatchVerifier()
+	v.AddManyToOne(testBatchContext, msg, sigs)
+	require.Equal(10, v.Len())
+	require.True(v.VerifyAll(), "many to one batch with valid signatures should verify")
+
+	// Invalid signature.
+	v = NewBatchVerifier()
+	for i := range sigs {
+		sig := sigs[i]
+		if i == 3 {
+			sig.Signature[0] ^= 0xff
+		}
+		v.Add(testBatchContext, msg, &sig)
+	}
+	allOk, valid = v.Verify()
+	require.False(allOk, "batch with invalid signature should not verify")
+	for i := range valid {
+		require.Equal(i != 3, valid[i], "signature %d validity", i)
+	}
+	require.False(v.VerifyAll(), "batch with invalid signature should not verify")
+
+	// Wrong message.
+	v = NewBatchVerifier()
+	v.Add(testBatchContext, msg, &sigs[0])
+	v.Add(testBatchContext, []byte("other message"), &sigs[1])
+	allOk, valid = v.Verify()
+	require.False(allOk, "batch with wrong message should not verify")
+	require.Equal([]bool{true, false}, valid)
+
+	// Unregistered context.
+	v = NewBatchVerifier()
+	v.Add(testBatchContext, msg, &sigs[0])
+	v.Add(Context("test: unregistered batch context"), msg, &sigs[1])
+	allOk, valid = v.Verify()
+	require.False(allOk, "batch with unregistered context should not verify")
+	require.Equal([]bool{true, false}, valid)
+	require.False(v.VerifyAll(), "batch with unregistered context should not verify")
+
+	// Blacklisted public key.
+	err := sigs[2].PublicKey.Blacklist()
+	require.NoError(err, "Blacklist")
+	v = NewBatchVerifier()
+	v.AddManyToOne(testBatchContext, msg, sigs[:4])
+	allOk, valid = v.Verify()
+	require.False(allOk, "batch with blacklisted key should not verify")
+	require.Equal([]bool{true, true, false, true}, valid)
+	require.False(v.VerifyAll(), "batch with blacklisted key should not verify")
+}
+
+func BenchmarkVerify(b *testing.B) {
+	msg := []byte("batch verifier benchmark message")
+
+	for _, n := range []int{1, 8, 64, 256} {
+		sigs := make([]*Signature, 0, n)
+		for i := 0; i < n; i++ {
+			sigs = append(sigs, newTestSignature(b, testBatchContext, msg))
+		}
+
+		b.Run(fmt.Sprintf("Individual/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, sig := range sigs {
+					if !sig.Verify(testBatchContext, msg) {
+						b.Fatal("signature verification failed")
+					}
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Batch/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				v := NewBatchVerifier()
+				for _, sig := range sigs {
+					v.Add(testBatchContext, msg, sig)
+				}
+				if !v.VerifyAll() {
+					b.Fatal("batch verification failed")
+				}
+			}
+		})
+	}
+}
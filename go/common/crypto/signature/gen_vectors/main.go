@@ -0,0 +1,203 @@
+// gen_vectors generates versioned test vectors for signed structures and for
+// consensus transactions not covered by the per-service generators.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction/testvectors"
+	keymanager "github.com/oasisprotocol/oasis-core/go/keymanager/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
+)
+
+func main() {
+	// Configure chain context for all signatures using chain domain separation.
+	var chainContext hash.Hash
+	chainContext.FromBytes([]byte("signed structure test vectors"))
+	signature.SetChainContext(chainContext.String())
+
+	vectors := testvectors.VersionedTestVectors{
+		ProtocolVersions: testvectors.CurrentProtocolVersions(),
+	}
+
+	var runtimeID common.Namespace
+	if err := runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000"); err != nil {
+		panic(err)
+	}
+	var keyManagerID common.Namespace
+	if err := keyManagerID.UnmarshalHex("c000000000000000000000000000000000000000000000000000000000000000"); err != nil {
+		panic(err)
+	}
+
+	entitySigner := memorySigner.NewTestSigner("oasis-core signed structure test vectors: entity signer")
+	nodeSigner := memorySigner.NewTestSigner("oasis-core signed structure test vectors: node signer")
+	tlsSigner := memorySigner.NewTestSigner("oasis-core signed structure test vectors: node TLS signer")
+	p2pSigner := memorySigner.NewTestSigner("oasis-core signed structure test vectors: node P2P signer")
+	consensusSigner := memorySigner.NewTestSigner("oasis-core signed structure test vectors: node consensus signer")
+
+	// Entity descriptor.
+	ent := entity.Entity{
+		Versioned: cbor.NewVersioned(entity.LatestEntityDescriptorVersion),
+		ID:        entitySigner.Public(),
+		Nodes:     []signature.PublicKey{nodeSigner.Public()},
+	}
+	vectors.SignedStructures = append(vectors.SignedStructures, testvectors.MakeSignedStructureTestVectorWithSigner(
+		"EntityDescriptor", registry.RegisterEntitySignatureContext, &ent, entitySigner,
+	))
+
+	// Node descriptor.
+	nd := node.Node{
+		Versioned:  cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+		ID:         nodeSigner.Public(),
+		EntityID:   entitySigner.Public(),
+		Expiration: 42,
+		TLS: node.TLSInfo{
+			PubKey: tlsSigner.Public(),
+		},
+		P2P: node.P2PInfo{
+			ID: p2pSigner.Public(),
+		},
+		Consensus: node.ConsensusInfo{
+			ID: consensusSigner.Public(),
+		},
+		Runtimes: []*node.Runtime{
+			{ID: runtimeID},
+		},
+		Roles: node.RoleComputeWorker,
+	}
+	vectors.SignedStructures = append(vectors.SignedStructures, testvectors.MakeSignedStructureTestVectorWithSigner(
+		"NodeDescriptor", registry.RegisterNodeSignatureContext, &nd, nodeSigner,
+	))
+
+	// Runtime descriptor.
+	rt := registry.Runtime{
+		Versioned:  cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+		ID:         runtimeID,
+		EntityID:   entitySigner.Public(),
+		Kind:       registry.KindCompute,
+		KeyManager: &keyManagerID,
+		Executor: registry.ExecutorParameters{
+			GroupSize:    3,
+			RoundTimeout: 10,
+		},
+		AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+			AnyNode: &registry.AnyNodeRuntimeAdmissionPolicy{},
+		},
+	}
+	vectors.SignedStructures = append(vectors.SignedStructures, testvectors.MakeSignedStructureTestVectorWithSigner(
+		"RuntimeDescriptor", registry.RegisterRuntimeSignatureContext, &rt, entitySigner,
+	))
+
+	// Proposed batch.
+	var ioRoot hash.Hash
+	ioRoot.FromBytes([]byte("oasis-core signed structure test vectors: I/O root"))
+	genesisBlock := block.NewGenesisBlock(runtimeID, 0)
+	pb := commitment.ProposedBatch{
+		IORoot: ioRoot,
+		Header: genesisBlock.Header,
+	}
+	vectors.SignedStructures = append(vectors.SignedStructures, testvectors.MakeSignedStructureTestVectorWithSigner(
+		"ProposedBatch", commitment.ProposedBatchSignatureContext, &pb, nodeSigner,
+	))
+
+	// Executor commitment.
+	var stateRoot hash.Hash
+	stateRoot.FromBytes([]byte("oasis-core signed structure test vectors: state root"))
+	body := commitment.ComputeBody{
+		Header: commitment.ComputeResultsHeader{
+			Round:        genesisBlock.Header.Round + 1,
+			PreviousHash: genesisBlock.Header.EncodedHash(),
+			IORoot:       &ioRoot,
+			StateRoot:    &stateRoot,
+		},
+	}
+	vectors.SignedStructures = append(vectors.SignedStructures, testvectors.MakeSignedStructureTestVectorWithSigner(
+		"ExecutorCommitment", commitment.ExecutorSignatureContext, &body, nodeSigner,
+	))
+
+	// Key manager policy.
+	var enclaveID sgx.EnclaveIdentity
+	if err := enclaveID.MrEnclave.UnmarshalHex("9a3e28ea0fba8dc2d2f7a6e8c1f5ab5c9b2e6b7d1c3f4e5a6b7c8d9e0f1a2b3c"); err != nil {
+		panic(err)
+	}
+	if err := enclaveID.MrSigner.UnmarshalHex("4025f1d42b7a3c9e6d8f0b1a2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5"); err != nil {
+		panic(err)
+	}
+	policy := keymanager.PolicySGX{
+		Serial: 1,
+		ID:     keyManagerID,
+		Enclaves: map[sgx.EnclaveIdentity]*keymanager.EnclavePolicySGX{
+			enclaveID: {
+				MayQuery: map[common.Namespace][]sgx.EnclaveIdentity{
+					runtimeID: {enclaveID},
+				},
+				MayReplicate: []sgx.EnclaveIdentity{enclaveID},
+			},
+		},
+	}
+	policySignerVector := testvectors.MakeSignedStructureTestVector("KeyManagerPolicy", keymanager.PolicySGXSignatureContext, &policy)
+	vectors.SignedStructures = append(vectors.SignedStructures, policySignerVector)
+
+	sigNode, err := node.MultiSignNode([]signature.Signer{nodeSigner}, registry.RegisterNodeSignatureContext, &nd)
+	if err != nil {
+		panic(err)
+	}
+	sigRt, err := registry.SignRuntime(entitySigner, registry.RegisterRuntimeSignatureContext, &rt)
+	if err != nil {
+		panic(err)
+	}
+	commit, err := commitment.SignExecutorCommitment(nodeSigner, &body)
+	if err != nil {
+		panic(err)
+	}
+	sigPolicy := keymanager.SignedPolicySGX{
+		Policy:     policy,
+		Signatures: []signature.Signature{policySignerVector.Signature},
+	}
+
+	// Generate different gas fees.
+	for _, fee := range []*transaction.Fee{
+		{},
+		{Amount: *quantity.NewFromUint64(100000000), Gas: 1000},
+		{Amount: *quantity.NewFromUint64(0), Gas: 1000},
+		{Amount: *quantity.NewFromUint64(4242), Gas: 1000},
+	} {
+		// Generate different nonces.
+		for _, nonce := range []uint64{0, 1, 10, 42, 1000, 1_000_000, 10_000_000, math.MaxUint64} {
+			for _, v := range []struct {
+				kind   string
+				tx     *transaction.Transaction
+				signer signature.Signer
+			}{
+				{"DeregisterEntity", registry.NewDeregisterEntityTx(nonce, fee), entitySigner},
+				{"RegisterNode", registry.NewRegisterNodeTx(nonce, fee, sigNode), nodeSigner},
+				{"RegisterRuntime", registry.NewRegisterRuntimeTx(nonce, fee, sigRt), entitySigner},
+				{"DeregisterRuntime", registry.NewDeregisterRuntimeTx(nonce, fee, &registry.DeregisterRuntime{ID: runtimeID}), entitySigner},
+				{"ExecutorCommit", roothash.NewExecutorCommitTx(nonce, fee, runtimeID, []commitment.ExecutorCommitment{*commit}), nodeSigner},
+				{"RequestProposerTimeout", roothash.NewRequestProposerTimeoutTx(nonce, fee, runtimeID, 1), nodeSigner},
+				{"UpdatePolicy", keymanager.NewUpdatePolicyTx(nonce, fee, &sigPolicy), nodeSigner},
+			} {
+				vectors.Transactions = append(vectors.Transactions, testvectors.MakeTestVectorWithSigner(v.kind, v.tx, v.signer))
+			}
+		}
+	}
+
+	// Generate output.
+	jsonOut, _ := json.MarshalIndent(&vectors, "", "  ")
+	fmt.Printf("%s", jsonOut)
+}
@@ -4,7 +4,6 @@ package signature
 import (
 	"bytes"
 	"context"
-	"crypto/rand"
 	"encoding"
 	"encoding/base64"
 	"encoding/hex"
@@ -604,35 +603,9 @@ func (s *SignedPublicKey) Open(context Context, pub *PublicKey) error { // nolin
 // VerifyManyToOne verifies multiple signatures against a single context and
 // message, returning true iff every signature is valid.
 func VerifyManyToOne(context Context, message []byte, sigs []Signature) bool {
-	// Our batch verify supports doing Ed25519ph/Ed25519ctx in bulk,
-	// but we're stuck with this stupidity.
-	msg, err := PrepareSignerMessage(context, message)
-	if err != nil {
-		return false
-	}
-
-	// Adapt from our wrapper types to the types used by the library.
-	pks := make([]ed25519.PublicKey, 0, len(sigs))
-	rawSigs := make([][]byte, 0, len(sigs))
-	msgs := make([][]byte, 0, len(sigs))
-
-	for i := range sigs {
-		v := sigs[i] // This is deliberate.
-		if v.PublicKey.IsBlacklisted() {
-			return false
-		}
-
-		pks = append(pks, ed25519.PublicKey(v.PublicKey[:]))
-		rawSigs = append(rawSigs, v.Signature[:])
-		msgs = append(msgs, msg)
-	}
-
-	allOk, _, err := ed25519.VerifyBatch(rand.Reader, pks, msgs, rawSigs, defaultOptions)
-	if err != nil {
-		return false
-	}
-
-	return allOk
+	bv := NewBatchVerifier()
+	bv.AddManyToOne(context, message, sigs)
+	return bv.VerifyAll()
 }
 
 // VerifyBatch verifies multiple signatures, made by multiple public keys,
@@ -643,33 +616,11 @@ func VerifyBatch(context Context, messages [][]byte, sigs []Signature) bool {
 		panic("signature: VerifyBatch messages/signature count mismatch")
 	}
 
-	// Adapt from our wrapper types to the types used by the library.
-	pks := make([]ed25519.PublicKey, 0, len(sigs))
-	rawSigs := make([][]byte, 0, len(sigs))
-	msgs := make([][]byte, 0, len(sigs))
-
+	bv := NewBatchVerifier()
 	for i := range sigs {
-		v := sigs[i] // This is deliberate.
-		if v.PublicKey.IsBlacklisted() {
-			return false
-		}
-		pks = append(pks, ed25519.PublicKey(v.PublicKey[:]))
-		rawSigs = append(rawSigs, v.Signature[:])
-
-		// Sigh. :(
-		msg, err := PrepareSignerMessage(context, messages[i])
-		if err != nil {
-			return false
-		}
-		msgs = append(msgs, msg)
-	}
-
-	allOk, _, err := ed25519.VerifyBatch(rand.Reader, pks, msgs, rawSigs, defaultOptions)
-	if err != nil {
-		return false
+		bv.Add(context, messages[i], &sigs[i])
 	}
-
-	return allOk
+	return bv.VerifyAll()
 }
 
 // NewPublicKey creates a new public key from the given hex representation or
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 
@@ -116,6 +117,54 @@ func NewContext(rawContext string, opts ...ContextOption) Context {
 	return ctx
 }
 
+// ContextInfo describes a registered domain separation context.
+type ContextInfo struct {
+	// Name is the context as passed to NewContext.
+	Name Context `json:"name"`
+
+	// ChainSeparation is true iff the context requires chain domain
+	// separation.
+	ChainSeparation bool `json:"chain_separation"`
+
+	// Suffix is the suffix that is appended to the context name when
+	// signing.  It is only set for chain separated contexts and only once
+	// the chain context has been configured.
+	Suffix string `json:"suffix,omitempty"`
+}
+
+// ListContexts returns the descriptions of all registered contexts, sorted
+// by name.
+//
+// The list can be used by external signer implementations to validate that
+// they only produce signatures for approved domains.
+func ListContexts() []ContextInfo {
+	chainContextLock.RLock()
+	suffix := ""
+	if chainContext != "" {
+		suffix = chainContextSeparator + string(chainContext)
+	}
+	chainContextLock.RUnlock()
+
+	var contexts []ContextInfo
+	registeredContexts.Range(func(k, v interface{}) bool {
+		opts := v.(*contextOptions)
+		ci := ContextInfo{
+			Name:            k.(Context),
+			ChainSeparation: opts.chainSeparation,
+		}
+		if opts.chainSeparation {
+			ci.Suffix = suffix
+		}
+		contexts = append(contexts, ci)
+		return true
+	})
+	sort.Slice(contexts, func(i, j int) bool {
+		return contexts[i].Name < contexts[j].Name
+	})
+
+	return contexts
+}
+
 // UnsafeResetChainContext resets the chain context.
 //
 // This function should NOT be used during normal operation as changing
@@ -1,6 +1,7 @@
 package signature
 
 import (
+	"sort"
 	"strings"
 	"testing"
 
@@ -86,6 +87,54 @@ func TestContext(t *testing.T) {
 	require.NoError(err, "PrepareSignerMessage should work with unregisered context (bypassed)")
 }
 
+func TestListContexts(t *testing.T) {
+	require := require.New(t)
+
+	UnsafeResetChainContext()
+	defer UnsafeResetChainContext()
+
+	ctx := NewContext("test: list contexts 1")
+	chainCtx := NewContext("test: list contexts 2", WithChainSeparation())
+
+	findContext := func(contexts []ContextInfo, name Context) *ContextInfo {
+		for i := range contexts {
+			if contexts[i].Name == name {
+				return &contexts[i]
+			}
+		}
+		return nil
+	}
+
+	// Make sure registered contexts are listed in order.
+	contexts := ListContexts()
+	require.True(sort.SliceIsSorted(contexts, func(i, j int) bool {
+		return contexts[i].Name < contexts[j].Name
+	}), "contexts should be sorted by name")
+
+	ci := findContext(contexts, ctx)
+	require.NotNil(ci, "registered context should be listed")
+	require.False(ci.ChainSeparation)
+	require.Empty(ci.Suffix)
+
+	ci = findContext(contexts, chainCtx)
+	require.NotNil(ci, "registered context should be listed")
+	require.True(ci.ChainSeparation)
+	require.Empty(ci.Suffix, "suffix should be empty without chain context")
+
+	// Unregistered contexts should not be listed.
+	require.Nil(findContext(contexts, Context("test: unregistered")))
+
+	// The suffix should match what is used during signing.
+	SetChainContext("test: oasis-core tests")
+	ci = findContext(ListContexts(), chainCtx)
+	require.NotNil(ci, "registered context should be listed")
+	require.Equal(" for chain test: oasis-core tests", ci.Suffix)
+
+	rawCtx, err := PrepareSignerContext(chainCtx)
+	require.NoError(err, "PrepareSignerContext")
+	require.EqualValues(string(ci.Name)+ci.Suffix, rawCtx)
+}
+
 func TestSignerRoles(t *testing.T) {
 	require := require.New(t)
 
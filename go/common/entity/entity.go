@@ -13,6 +13,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
 )
 
@@ -32,11 +33,15 @@ var (
 const (
 	// LatestEntityDescriptorVersion is the latest entity descriptor version that should be used for
 	// all new descriptors. Using earlier versions may be rejected.
-	LatestEntityDescriptorVersion = 1
+	LatestEntityDescriptorVersion = 2
 
 	// Minimum and maximum descriptor versions that are allowed.
 	minEntityDescriptorVersion = 1
 	maxEntityDescriptorVersion = LatestEntityDescriptorVersion
+
+	// minNodeRolesDescriptorVersion is the minimum descriptor version that supports declaring
+	// the roles of the entity's nodes.
+	minNodeRolesDescriptorVersion = 2
 )
 
 // Entity represents an entity that controls one or more Nodes and or
@@ -52,6 +57,12 @@ type Entity struct { // nolint: maligned
 	// entity signing key.
 	Nodes []signature.PublicKey `json:"nodes,omitempty"`
 
+	// NodeRoles are the roles that the nodes in the Nodes list are allowed to register with. Nodes
+	// without an entry may register with any role.
+	//
+	// This field is only supported by descriptor version 2 and later.
+	NodeRoles map[signature.PublicKey]node.RolesMask `json:"node_roles,omitempty"`
+
 	// AllowEntitySignedNodes is true iff nodes belonging to this entity
 	// may be signed with the entity signing key.
 	AllowEntitySignedNodes bool `json:"allow_entity_signed_nodes,omitempty"`
@@ -78,9 +89,35 @@ func (e *Entity) ValidateBasic(strictVersion bool) error {
 			)
 		}
 	}
+
+	if len(e.NodeRoles) > 0 && v < minNodeRolesDescriptorVersion {
+		return fmt.Errorf("node roles require entity descriptor version %d or later", minNodeRolesDescriptorVersion)
+	}
+	nodes := make(map[signature.PublicKey]bool, len(e.Nodes))
+	for _, id := range e.Nodes {
+		nodes[id] = true
+	}
+	for id, roles := range e.NodeRoles {
+		if !nodes[id] {
+			return fmt.Errorf("node roles declared for node not in node list: %s", id)
+		}
+		if roles == 0 || roles&node.RoleReserved != 0 {
+			return fmt.Errorf("invalid roles declared for node %s: %d", id, roles)
+		}
+	}
 	return nil
 }
 
+// NodeRolesAllowed returns true iff the node with the given identity key is allowed to register
+// with the given roles according to the entity's declared node roles.
+func (e *Entity) NodeRolesAllowed(id signature.PublicKey, roles node.RolesMask) bool {
+	allowed, ok := e.NodeRoles[id]
+	if !ok {
+		return true
+	}
+	return roles&^allowed == 0
+}
+
 // String returns a string representation of itself.
 func (e Entity) String() string {
 	return "<Entity id=" + e.ID.String() + ">"
@@ -147,6 +184,7 @@ func GenerateWithSigner(baseDir string, signer signature.Signer, template *Entit
 	}
 	if template != nil {
 		ent.Nodes = template.Nodes
+		ent.NodeRoles = template.NodeRoles
 		ent.AllowEntitySignedNodes = template.AllowEntitySignedNodes
 	}
 
@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	_ ServerAuth = AuthenticationFunction(nil)
+	_ ServerAuth = (*CompositeAuthenticator)(nil)
+	_ ServerAuth = (*MethodAuthenticator)(nil)
+)
+
+// AuthFunc implements ServerAuth so that plain authentication functions can be used as part of
+// composite authenticators.
+func (fn AuthenticationFunction) AuthFunc(ctx context.Context, fullMethodName string, req interface{}) error {
+	return fn(ctx, fullMethodName, req)
+}
+
+// Authenticator is a named authenticator that is part of a composite authenticator.
+type Authenticator struct {
+	// Name is the name of the authenticator that is used to attribute authentication errors.
+	Name string
+	// Auth is the authenticator.
+	Auth ServerAuth
+}
+
+type compositeMode uint8
+
+const (
+	compositeAll compositeMode = iota
+	compositeAny
+)
+
+// CompositeAuthenticator is a server side gRPC authentication function that combines multiple
+// authenticators.
+//
+// Composite authenticators can be nested to build more complex policies, for example to allow
+// access to a set of read-only methods to everyone while requiring both a known client
+// certificate and a bearer token for all other methods:
+//
+//	auth.Any(
+//	  auth.Authenticator{Name: "public", Auth: auth.NewMethodAuthenticator(readOnlyMethods...)},
+//	  auth.Authenticator{Name: "restricted", Auth: auth.All(
+//	    auth.Authenticator{Name: "tls", Auth: peerPubkeyAuth},
+//	    auth.Authenticator{Name: "token", Auth: tokenAuth},
+//	  )},
+//	)
+type CompositeAuthenticator struct {
+	mode           compositeMode
+	authenticators []Authenticator
+}
+
+// AuthFunc is an AuthenticationFunction backed by the CompositeAuthenticator.
+func (auth *CompositeAuthenticator) AuthFunc(ctx context.Context, fullMethodName string, req interface{}) error {
+	if len(auth.authenticators) == 0 {
+		return status.Errorf(codes.PermissionDenied, "grpc: no authenticators configured")
+	}
+
+	switch auth.mode {
+	case compositeAll:
+		for _, a := range auth.authenticators {
+			if err := a.Auth.AuthFunc(ctx, fullMethodName, req); err != nil {
+				code, msg := authErrorDetails(err)
+				return status.Errorf(code, "grpc: %s authentication failed: %s", a.Name, msg)
+			}
+		}
+		return nil
+	default:
+		// All authenticators failing with Unauthenticated means that no credentials were
+		// provided, otherwise at least some credentials were rejected.
+		code := codes.Unauthenticated
+		failures := make([]string, 0, len(auth.authenticators))
+		for _, a := range auth.authenticators {
+			err := a.Auth.AuthFunc(ctx, fullMethodName, req)
+			if err == nil {
+				return nil
+			}
+
+			errCode, msg := authErrorDetails(err)
+			if errCode != codes.Unauthenticated {
+				code = codes.PermissionDenied
+			}
+			failures = append(failures, a.Name+": "+msg)
+		}
+		return status.Errorf(code, "grpc: all authenticators failed (%s)", strings.Join(failures, "; "))
+	}
+}
+
+// authErrorDetails returns the gRPC status code and message of an authentication error.
+func authErrorDetails(err error) (codes.Code, string) {
+	s, ok := status.FromError(err)
+	if !ok {
+		return codes.PermissionDenied, err.Error()
+	}
+	switch s.Code() {
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return s.Code(), s.Message()
+	default:
+		return codes.PermissionDenied, s.Message()
+	}
+}
+
+// All creates a composite authenticator that only allows access in case all of the given
+// authenticators allow access. The authenticators are evaluated in order.
+//
+// A composite authenticator without any authenticators denies all access.
+func All(authenticators ...Authenticator) *CompositeAuthenticator {
+	return &CompositeAuthenticator{
+		mode:           compositeAll,
+		authenticators: authenticators,
+	}
+}
+
+// Any creates a composite authenticator that allows access in case any of the given
+// authenticators allows access. The authenticators are evaluated in order.
+//
+// A composite authenticator without any authenticators denies all access.
+func Any(authenticators ...Authenticator) *CompositeAuthenticator {
+	return &CompositeAuthenticator{
+		mode:           compositeAny,
+		authenticators: authenticators,
+	}
+}
+
+// MethodAuthenticator is a server side gRPC authentication function that restricts access to
+// a fixed set of methods.
+type MethodAuthenticator struct {
+	methods map[string]bool
+}
+
+// AuthFunc is an AuthenticationFunction backed by the MethodAuthenticator.
+func (auth *MethodAuthenticator) AuthFunc(ctx context.Context, fullMethodName string, req interface{}) error {
+	if auth.methods[fullMethodName] {
+		return nil
+	}
+	if idx := strings.LastIndex(fullMethodName, "/"); idx > 0 && auth.methods[fullMethodName[:idx+1]+"*"] {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "grpc: method %s not allowed", fullMethodName)
+}
+
+// NewMethodAuthenticator creates a new MethodAuthenticator allowing access to the given methods.
+//
+// Methods are specified by full method name (e.g., "/oasis-core.Consensus/GetStatus") or by
+// service name (e.g., "/oasis-core.Consensus/*").
+func NewMethodAuthenticator(methods ...string) *MethodAuthenticator {
+	auth := &MethodAuthenticator{
+		methods: make(map[string]bool),
+	}
+	for _, m := range methods {
+		auth.methods[m] = true
+	}
+	return auth
+}
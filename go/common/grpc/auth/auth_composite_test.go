@@ -0,0 +1,127 @@
+package auth_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/oasisprotocol/oasis-core/go/common/grpc/auth"
+)
+
+const (
+	testToken        = "composite test token"
+	publicMethod     = "/oasis-core.Consensus/GetStatus"
+	restrictedMethod = "/oasis-core.NodeController/RequestShutdown"
+)
+
+// allowAll is an auth function that allows all requests.
+func allowAll(ctx context.Context, fullMethodName string, req interface{}) error {
+	return nil
+}
+
+func tokenContext(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), auth.TokenMetadata(token))
+}
+
+func requireCode(t *testing.T, expected codes.Code, err error, msgAndArgs ...interface{}) {
+	require.Error(t, err, msgAndArgs...)
+	require.Equal(t, expected, status.Code(err), msgAndArgs...)
+}
+
+func TestTokenAuthenticator(t *testing.T) {
+	require := require.New(t)
+
+	_, err := auth.NewTokenAuthenticator("")
+	require.Error(err, "empty tokens should be rejected")
+
+	tokenAuth, err := auth.NewTokenAuthenticator(testToken)
+	require.NoError(err, "NewTokenAuthenticator")
+
+	err = tokenAuth.AuthFunc(tokenContext(testToken), publicMethod, nil)
+	require.NoError(err, "valid token should be accepted")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.TokenMetadataKey, "bearer "+testToken))
+	err = tokenAuth.AuthFunc(ctx, publicMethod, nil)
+	require.NoError(err, "token scheme should be case insensitive")
+
+	err = tokenAuth.AuthFunc(tokenContext("invalid"), publicMethod, nil)
+	requireCode(t, codes.PermissionDenied, err, "invalid token should be rejected")
+
+	err = tokenAuth.AuthFunc(context.Background(), publicMethod, nil)
+	requireCode(t, codes.Unauthenticated, err, "missing metadata should be rejected")
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.TokenMetadataKey, testToken))
+	err = tokenAuth.AuthFunc(ctx, publicMethod, nil)
+	requireCode(t, codes.Unauthenticated, err, "malformed token should be rejected")
+}
+
+func TestCompositeAuthenticator(t *testing.T) {
+	require := require.New(t)
+
+	tokenAuth, err := auth.NewTokenAuthenticator(testToken)
+	require.NoError(err, "NewTokenAuthenticator")
+
+	allow := auth.Authenticator{Name: "allow", Auth: auth.AuthenticationFunction(allowAll)}
+	reject := auth.Authenticator{Name: "reject", Auth: auth.AuthenticationFunction(rejectAll)}
+	token := auth.Authenticator{Name: "token", Auth: tokenAuth}
+	failing := auth.Authenticator{Name: "failing", Auth: auth.AuthenticationFunction(
+		func(ctx context.Context, fullMethodName string, req interface{}) error {
+			return fmt.Errorf("internal error")
+		},
+	)}
+
+	// Empty composite authenticators should deny all access.
+	requireCode(t, codes.PermissionDenied, auth.All().AuthFunc(context.Background(), publicMethod, nil), "empty All")
+	requireCode(t, codes.PermissionDenied, auth.Any().AuthFunc(context.Background(), publicMethod, nil), "empty Any")
+
+	// All.
+	err = auth.All(allow, token).AuthFunc(tokenContext(testToken), publicMethod, nil)
+	require.NoError(err, "All should allow access when all authenticators allow access")
+	err = auth.All(allow, reject, token).AuthFunc(tokenContext(testToken), publicMethod, nil)
+	requireCode(t, codes.PermissionDenied, err, "All should deny access when any authenticator denies access")
+	require.Contains(err.Error(), "reject authentication failed", "error should be attributed to the failing authenticator")
+	err = auth.All(allow, token).AuthFunc(context.Background(), publicMethod, nil)
+	requireCode(t, codes.Unauthenticated, err, "All should preserve the Unauthenticated code")
+	require.Contains(err.Error(), "token authentication failed", "error should be attributed to the failing authenticator")
+	err = auth.All(failing).AuthFunc(context.Background(), publicMethod, nil)
+	requireCode(t, codes.PermissionDenied, err, "non-status errors should deny access")
+
+	// Any.
+	err = auth.Any(reject, token).AuthFunc(tokenContext(testToken), publicMethod, nil)
+	require.NoError(err, "Any should allow access when any authenticator allows access")
+	err = auth.Any(reject, token).AuthFunc(tokenContext("invalid"), publicMethod, nil)
+	requireCode(t, codes.PermissionDenied, err, "Any should deny access when all authenticators deny access")
+	require.Contains(err.Error(), "reject: rejecting all", "error should include all failures")
+	require.Contains(err.Error(), "token: grpc: invalid bearer token", "error should include all failures")
+	err = auth.Any(token).AuthFunc(context.Background(), publicMethod, nil)
+	requireCode(t, codes.Unauthenticated, err, "Any should report Unauthenticated when no credentials were provided")
+
+	// Public read-only methods with restricted access to everything else.
+	policy := auth.Any(
+		auth.Authenticator{Name: "public", Auth: auth.NewMethodAuthenticator(publicMethod, "/oasis-core.Registry/*")},
+		auth.Authenticator{Name: "restricted", Auth: auth.All(allow, token)},
+	)
+	for _, tc := range []struct {
+		ctx     context.Context
+		method  string
+		allowed bool
+	}{
+		{context.Background(), publicMethod, true},
+		{context.Background(), "/oasis-core.Registry/GetNodes", true},
+		{context.Background(), restrictedMethod, false},
+		{tokenContext("invalid"), restrictedMethod, false},
+		{tokenContext(testToken), restrictedMethod, true},
+	} {
+		err = policy.AuthFunc(tc.ctx, tc.method, nil)
+		if tc.allowed {
+			require.NoError(err, "AuthFunc(%s)", tc.method)
+		} else {
+			require.Error(err, "AuthFunc(%s)", tc.method)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// PeerCredAuthType is the authentication type of local peer credentials.
+const PeerCredAuthType = "peercred"
+
+var _ credentials.TransportCredentials = (*peerCredTransportCredentials)(nil)
+
+// PeerCredentials are the credentials of a local peer connected via a unix
+// socket, as reported by the kernel (SO_PEERCRED).
+type PeerCredentials struct {
+	// PID is the process identifier of the peer.
+	PID int32
+	// UID is the effective user identifier of the peer.
+	UID uint32
+	// GID is the effective (primary) group identifier of the peer.
+	GID uint32
+}
+
+// PeerCredAuthInfo is the gRPC authentication information of a connection
+// established via a unix socket.
+type PeerCredAuthInfo struct {
+	// Credentials are the peer credentials, nil in case the connection is
+	// not a unix socket connection.
+	Credentials *PeerCredentials
+}
+
+// AuthType implements credentials.AuthInfo.
+func (PeerCredAuthInfo) AuthType() string {
+	return PeerCredAuthType
+}
+
+// PeerCredentialsFromContext returns the credentials of the local peer that
+// initiated the call.
+func PeerCredentialsFromContext(ctx context.Context) (*PeerCredentials, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	info, ok := p.AuthInfo.(PeerCredAuthInfo)
+	if !ok || info.Credentials == nil {
+		return nil, false
+	}
+	return info.Credentials, true
+}
+
+type peerCredTransportCredentials struct{}
+
+func (c *peerCredTransportCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, fmt.Errorf("grpc/auth: peer credentials are server-only")
+}
+
+func (c *peerCredTransportCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	if _, ok := conn.(*net.UnixConn); !ok {
+		// Not a unix socket connection (e.g., the debug TCP listener), the
+		// connection will not be able to satisfy any access lists.
+		return conn, PeerCredAuthInfo{}, nil
+	}
+
+	creds, err := getPeerCredentials(conn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("grpc/auth: failed to obtain peer credentials: %w", err)
+	}
+	return conn, PeerCredAuthInfo{Credentials: creds}, nil
+}
+
+func (c *peerCredTransportCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{
+		SecurityProtocol: PeerCredAuthType,
+	}
+}
+
+func (c *peerCredTransportCredentials) Clone() credentials.TransportCredentials {
+	return &peerCredTransportCredentials{}
+}
+
+func (c *peerCredTransportCredentials) OverrideServerName(string) error {
+	return nil
+}
+
+// NewPeerCredTransportCredentials creates new server transport credentials
+// that obtain the credentials of peers connecting via unix sockets.
+//
+// The credentials do not secure the connection in any way and must only be
+// used for local servers.
+func NewPeerCredTransportCredentials() credentials.TransportCredentials {
+	return &peerCredTransportCredentials{}
+}
+
+// PeerCredAccessList is a list of local users and groups.
+type PeerCredAccessList struct {
+	// UIDs are the allowed user identifiers.
+	UIDs []uint32 `json:"uids,omitempty"`
+	// GIDs are the allowed group identifiers.
+	//
+	// Note that only the peer's primary group is considered.
+	GIDs []uint32 `json:"gids,omitempty"`
+}
+
+func (l *PeerCredAccessList) allows(creds *PeerCredentials) bool {
+	for _, uid := range l.UIDs {
+		if creds.UID == uid {
+			return true
+		}
+	}
+	for _, gid := range l.GIDs {
+		if creds.GID == gid {
+			return true
+		}
+	}
+	return false
+}
+
+// PeerCredPolicy is a local server access policy based on the credentials
+// of peers connecting via unix sockets.
+//
+// Peers running as the same user as the server are always allowed.
+type PeerCredPolicy struct {
+	// Default is the access list for methods without a method-specific
+	// access list. If nil, such methods are not restricted.
+	Default *PeerCredAccessList `json:"default,omitempty"`
+
+	// Methods are the method-specific access lists, keyed by full method
+	// name (e.g., "/oasis-core.NodeController/RequestShutdown") or by
+	// service name (e.g., "/oasis-core.NodeController/*").
+	Methods map[string]*PeerCredAccessList `json:"methods,omitempty"`
+}
+
+func (p *PeerCredPolicy) accessList(fullMethodName string) *PeerCredAccessList {
+	if l, ok := p.Methods[fullMethodName]; ok {
+		return l
+	}
+	if idx := strings.LastIndex(fullMethodName, "/"); idx > 0 {
+		if l, ok := p.Methods[fullMethodName[:idx+1]+"*"]; ok {
+			return l
+		}
+	}
+	return p.Default
+}
+
+// AuthFunc is an AuthenticationFunction backed by the PeerCredPolicy.
+func (p *PeerCredPolicy) AuthFunc(ctx context.Context, fullMethodName string, req interface{}) error {
+	l := p.accessList(fullMethodName)
+	if l == nil {
+		return nil
+	}
+
+	creds, ok := PeerCredentialsFromContext(ctx)
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "grpc: failed to obtain peer credentials")
+	}
+	if creds.UID == uint32(os.Geteuid()) || l.allows(creds) {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "grpc: calling %s not allowed for uid %d gid %d", fullMethodName, creds.UID, creds.GID)
+}
+
+// PeerCredUnaryServerInterceptor returns a unary server interceptor enforcing
+// the given peer credential policy.
+//
+// Unlike UnaryServerInterceptor, the policy can not be overridden by services.
+func PeerCredUnaryServerInterceptor(policy *PeerCredPolicy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		if err := policy.AuthFunc(ctx, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// PeerCredStreamServerInterceptor returns a stream server interceptor enforcing
+// the given peer credential policy.
+//
+// Unlike StreamServerInterceptor, the policy can not be overridden by services.
+func PeerCredStreamServerInterceptor(policy *PeerCredPolicy) grpc.StreamServerInterceptor {
+	return func(srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		if err := policy.AuthFunc(stream.Context(), info.FullMethod, nil); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
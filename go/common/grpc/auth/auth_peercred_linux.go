@@ -0,0 +1,39 @@
+// +build linux
+
+package auth
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+)
+
+func getPeerCredentials(conn net.Conn) (*PeerCredentials, error) {
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return nil, fmt.Errorf("not a unix socket connection")
+	}
+	raw, err := uc.SyscallConn()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		ucred    *syscall.Ucred
+		ucredErr error
+	)
+	if err = raw.Control(func(fd uintptr) {
+		ucred, ucredErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	}); err != nil {
+		return nil, err
+	}
+	if ucredErr != nil {
+		return nil, ucredErr
+	}
+
+	return &PeerCredentials{
+		PID: ucred.Pid,
+		UID: ucred.Uid,
+		GID: ucred.Gid,
+	}, nil
+}
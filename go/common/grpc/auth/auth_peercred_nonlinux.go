@@ -0,0 +1,12 @@
+// +build !linux
+
+package auth
+
+import (
+	"errors"
+	"net"
+)
+
+func getPeerCredentials(conn net.Conn) (*PeerCredentials, error) {
+	return nil, errors.New("getPeerCredentials only implemented for Linux")
+}
@@ -0,0 +1,117 @@
+package auth_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	commonGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/common/grpc/auth"
+	commonTesting "github.com/oasisprotocol/oasis-core/go/common/grpc/testing"
+)
+
+func peerCredContext(uid, gid uint32) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: auth.PeerCredAuthInfo{
+			Credentials: &auth.PeerCredentials{UID: uid, GID: gid},
+		},
+	})
+}
+
+func TestPeerCredPolicy(t *testing.T) {
+	require := require.New(t)
+
+	// Pick identifiers that differ from the user running the test.
+	otherUID := uint32(os.Geteuid()) + 1
+	policy := &auth.PeerCredPolicy{
+		Default: &auth.PeerCredAccessList{
+			UIDs: []uint32{otherUID},
+			GIDs: []uint32{1000},
+		},
+		Methods: map[string]*auth.PeerCredAccessList{
+			"/oasis-core.NodeController/RequestShutdown": {},
+			"/oasis-core.Registry/*":                     {GIDs: []uint32{2000}},
+		},
+	}
+
+	for _, tc := range []struct {
+		method  string
+		uid     uint32
+		gid     uint32
+		allowed bool
+	}{
+		{"/oasis-core.Consensus/GetStatus", otherUID, 0, true},
+		{"/oasis-core.Consensus/GetStatus", otherUID + 1, 1000, true},
+		{"/oasis-core.Consensus/GetStatus", otherUID + 1, 0, false},
+		{"/oasis-core.NodeController/RequestShutdown", otherUID, 1000, false},
+		{"/oasis-core.NodeController/RequestShutdown", uint32(os.Geteuid()), 0, true},
+		{"/oasis-core.Registry/GetNodes", otherUID, 1000, false},
+		{"/oasis-core.Registry/GetNodes", otherUID + 1, 2000, true},
+	} {
+		err := policy.AuthFunc(peerCredContext(tc.uid, tc.gid), tc.method, nil)
+		switch tc.allowed {
+		case true:
+			require.NoError(err, "%s should be allowed for uid %d gid %d", tc.method, tc.uid, tc.gid)
+		case false:
+			require.Error(err, "%s should not be allowed for uid %d gid %d", tc.method, tc.uid, tc.gid)
+			require.Equal(codes.PermissionDenied, status.Code(err))
+		}
+	}
+
+	// Calls without peer credentials should only be allowed for unrestricted methods.
+	err := policy.AuthFunc(context.Background(), "/oasis-core.Consensus/GetStatus", nil)
+	require.Error(err, "calls without peer credentials should not be allowed")
+	err = (&auth.PeerCredPolicy{}).AuthFunc(context.Background(), "/oasis-core.Consensus/GetStatus", nil)
+	require.NoError(err, "calls to unrestricted methods should be allowed")
+}
+
+func TestPeerCredLocalServer(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("peer credentials are only supported on Linux")
+	}
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "oasis-grpc-peercred")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "internal.sock")
+	grpcServer, err := commonGrpc.NewServer(&commonGrpc.ServerConfig{
+		Name:     "peercred",
+		Path:     path,
+		PathMode: 0600,
+		PeerCredPolicy: &auth.PeerCredPolicy{
+			Default: &auth.PeerCredAccessList{},
+		},
+	})
+	require.NoError(err, "NewServer")
+	commonTesting.RegisterService(grpcServer.Server(), commonTesting.NewPingServer(auth.NoAuth))
+	err = grpcServer.Start()
+	require.NoError(err, "Start")
+	defer func() {
+		grpcServer.Stop()
+		grpcServer.Cleanup()
+	}()
+
+	fi, err := os.Stat(path)
+	require.NoError(err, "Stat")
+	require.EqualValues(0600, fi.Mode().Perm(), "socket should have the configured file mode")
+
+	conn, err := commonGrpc.Dial("unix:"+path, grpc.WithInsecure())
+	require.NoError(err, "Dial")
+	defer conn.Close()
+
+	// The node's own user should always be allowed.
+	client := commonTesting.NewPingClient(conn)
+	_, err = client.Ping(context.Background(), &commonTesting.PingQuery{})
+	require.NoError(err, "Ping")
+}
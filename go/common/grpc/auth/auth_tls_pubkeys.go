@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"crypto/ed25519"
 	"fmt"
 	"sync"
 
@@ -52,6 +53,32 @@ func (auth *PeerPubkeyAuthenticator) AuthFunc(ctx context.Context, fullMethodNam
 	return nil
 }
 
+// PeerPublicKeyFromContext returns the public key of the client certificate
+// presented in the TLS handshake of the connection in the gRPC context.
+func PeerPublicKeyFromContext(ctx context.Context) (signature.PublicKey, error) {
+	var pk signature.PublicKey
+
+	peer, ok := peer.FromContext(ctx)
+	if !ok {
+		return pk, fmt.Errorf("grpc: failed to obtain connection peer from context")
+	}
+	tlsAuth, ok := peer.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return pk, fmt.Errorf("grpc: unexpected peer authentication credentials")
+	}
+	if nPeerCerts := len(tlsAuth.State.PeerCertificates); nPeerCerts != 1 {
+		return pk, fmt.Errorf("grpc: unexpected number of peer certificates: %d", nPeerCerts)
+	}
+	rawPk, ok := tlsAuth.State.PeerCertificates[0].PublicKey.(ed25519.PublicKey)
+	if !ok {
+		return pk, fmt.Errorf("grpc: bad peer public key type (expected: Ed25519 got: %T)", tlsAuth.State.PeerCertificates[0].PublicKey)
+	}
+	if err := pk.UnmarshalBinary(rawPk[:]); err != nil {
+		return pk, fmt.Errorf("grpc: bad peer public key: %w", err)
+	}
+	return pk, nil
+}
+
 // AllowPeerPublicKey allows a peer public key access.
 func (auth *PeerPubkeyAuthenticator) AllowPeerPublicKey(key signature.PublicKey) {
 	auth.Lock()
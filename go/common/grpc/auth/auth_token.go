@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// TokenMetadataKey is the gRPC metadata key carrying the bearer token.
+	TokenMetadataKey = "authorization"
+
+	tokenPrefix = "bearer "
+)
+
+var _ ServerAuth = (*TokenAuthenticator)(nil)
+
+// TokenAuthenticator is a server side gRPC authentication function that restricts access to
+// clients presenting a static bearer token in the request metadata.
+type TokenAuthenticator struct {
+	token []byte
+}
+
+// AuthFunc is an AuthenticationFunction backed by the TokenAuthenticator.
+func (auth *TokenAuthenticator) AuthFunc(ctx context.Context, fullMethodName string, req interface{}) error {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return status.Errorf(codes.Unauthenticated, "grpc: failed getting metadata from context")
+	}
+	values := md.Get(TokenMetadataKey)
+	switch len(values) {
+	case 0:
+		return status.Errorf(codes.Unauthenticated, "grpc: missing bearer token")
+	case 1:
+	default:
+		return status.Errorf(codes.Unauthenticated, "grpc: multiple bearer tokens")
+	}
+
+	value := values[0]
+	if len(value) < len(tokenPrefix) || !strings.EqualFold(value[:len(tokenPrefix)], tokenPrefix) {
+		return status.Errorf(codes.Unauthenticated, "grpc: malformed bearer token")
+	}
+	if subtle.ConstantTimeCompare([]byte(value[len(tokenPrefix):]), auth.token) != 1 {
+		return status.Errorf(codes.PermissionDenied, "grpc: invalid bearer token")
+	}
+	return nil
+}
+
+// NewTokenAuthenticator creates a new TokenAuthenticator accepting the given bearer token.
+func NewTokenAuthenticator(token string) (*TokenAuthenticator, error) {
+	if token == "" {
+		return nil, fmt.Errorf("grpc/auth: empty bearer token")
+	}
+	return &TokenAuthenticator{
+		token: []byte(token),
+	}, nil
+}
+
+// TokenMetadata returns the outgoing gRPC metadata that authenticates requests using the given
+// bearer token.
+func TokenMetadata(token string) metadata.MD {
+	return metadata.Pairs(TokenMetadataKey, "Bearer "+token)
+}
@@ -411,6 +411,7 @@ type Server struct {
 
 	listenerCfgs     []listenerConfig
 	startedListeners []net.Listener
+	pathMode         os.FileMode
 	server           *grpc.Server
 	errCh            chan error
 
@@ -435,8 +436,15 @@ type ServerConfig struct { // nolint: maligned
 	// InstallWrapper specifies whether intercepting facilities should be enabled on this server,
 	// to enable intercepting RPC calls with a wrapper.
 	InstallWrapper bool
+	// PathMode is the file mode of the local server socket. Leave zero to use the
+	// default file mode.
+	PathMode os.FileMode
 	// AuthFunc is the authentication function for access control.
 	AuthFunc auth.AuthenticationFunction
+	// PeerCredPolicy is the optional local server access policy based on the
+	// credentials of peers connecting via the local socket. Unlike AuthFunc, the
+	// policy can not be overridden by services.
+	PeerCredPolicy *auth.PeerCredPolicy
 	// ClientCommonName is the expected common name on client TLS certificates. If not specified,
 	// the default identity.CommonName will be used.
 	ClientCommonName string
@@ -475,6 +483,15 @@ func (s *Server) Start() error {
 			)
 			return err
 		}
+		if cfg.network == "unix" && s.pathMode != 0 {
+			if err = os.Chmod(cfg.address, s.pathMode); err != nil {
+				s.Logger.Error("error setting gRPC server socket file mode",
+					"error", err,
+				)
+				_ = ln.Close()
+				return err
+			}
+		}
 		s.Logger.Info("gRPC server started", "network", cfg.network, "address", cfg.address)
 
 		s.startedListeners = append(s.startedListeners, ln)
@@ -593,14 +610,21 @@ func NewServer(config *ServerConfig) (*Server, error) {
 		logAdapter.unaryLogger,
 		grpc_opentracing.UnaryServerInterceptor(),
 		serverUnaryErrorMapper,
-		auth.UnaryServerInterceptor(config.AuthFunc),
 	}
 	streamInterceptors := []grpc.StreamServerInterceptor{
 		logAdapter.streamLogger,
 		grpc_opentracing.StreamServerInterceptor(),
 		serverStreamErrorMapper,
-		auth.StreamServerInterceptor(config.AuthFunc),
 	}
+	if config.PeerCredPolicy != nil {
+		if config.Path == "" {
+			return nil, fmt.Errorf("grpc: peer credential policy requires a local server")
+		}
+		unaryInterceptors = append(unaryInterceptors, auth.PeerCredUnaryServerInterceptor(config.PeerCredPolicy))
+		streamInterceptors = append(streamInterceptors, auth.PeerCredStreamServerInterceptor(config.PeerCredPolicy))
+	}
+	unaryInterceptors = append(unaryInterceptors, auth.UnaryServerInterceptor(config.AuthFunc))
+	streamInterceptors = append(streamInterceptors, auth.StreamServerInterceptor(config.AuthFunc))
 	if config.InstallWrapper {
 		wrapper = newWrapper()
 		unaryInterceptors = append(unaryInterceptors, wrapper.unaryInterceptor)
@@ -630,6 +654,8 @@ func NewServer(config *ServerConfig) (*Server, error) {
 		}
 
 		sOpts = append(sOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	} else if config.PeerCredPolicy != nil {
+		sOpts = append(sOpts, grpc.Creds(auth.NewPeerCredTransportCredentials()))
 	}
 	sOpts = append(sOpts, config.CustomOptions...)
 
@@ -637,6 +663,7 @@ func NewServer(config *ServerConfig) (*Server, error) {
 		BaseBackgroundService: svc,
 		listenerCfgs:          listenerParams,
 		startedListeners:      []net.Listener{},
+		pathMode:              config.PathMode,
 		server:                grpc.NewServer(sOpts...),
 		errCh:                 make(chan error, len(listenerParams)),
 		unsafeDebug:           unsafeDebug,
@@ -5,6 +5,7 @@ package proxy
 
 import (
 	"context"
+	"errors"
 	"io"
 
 	"google.golang.org/grpc"
@@ -18,6 +19,16 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 )
 
+// RequestIDMD is the gRPC metadata key carrying the request identifier
+// which is forwarded to the upstream server.
+const RequestIDMD = "x-request-id"
+
+// forwardedMD is the list of incoming metadata keys that are forwarded to the
+// upstream server.
+var forwardedMD = []string{
+	RequestIDMD,
+}
+
 // Dialer should return a gRPC ClientConn that will be used
 // to forward calls to.
 type Dialer func(ctx context.Context) (*grpc.ClientConn, error)
@@ -56,7 +67,9 @@ func (p *proxy) handler(srv interface{}, stream grpc.ServerStream) error {
 		return status.Errorf(codes.Internal, "missing method in client request")
 	}
 
-	// Upstream stream.
+	// Upstream stream. The upstream context is derived from the downstream
+	// context so the client deadline (if any) and cancellation are propagated
+	// to the upstream server.
 	upstreamCtx, upstreamCancel := context.WithCancel(stream.Context())
 	defer upstreamCancel()
 	desc := &grpc.StreamDesc{
@@ -78,6 +91,15 @@ func (p *proxy) handler(srv interface{}, stream grpc.ServerStream) error {
 	// Pass subject header upstream.
 	upstreamCtx = metadata.AppendToOutgoingContext(upstreamCtx, policy.ForwardedSubjectMD, sub)
 
+	// Pass selected client metadata upstream.
+	if md, ok := metadata.FromIncomingContext(stream.Context()); ok {
+		for _, key := range forwardedMD {
+			for _, value := range md.Get(key) {
+				upstreamCtx = metadata.AppendToOutgoingContext(upstreamCtx, key, value)
+			}
+		}
+	}
+
 	// Check if upstream connection was disconnected.
 	if p.upstreamConn != nil && p.upstreamConn.GetState() == connectivity.Shutdown {
 		// We need to redial if the connection was shut down.
@@ -89,7 +111,10 @@ func (p *proxy) handler(srv interface{}, stream grpc.ServerStream) error {
 		var grr error
 		p.upstreamConn, grr = p.dialer(stream.Context())
 		if grr != nil {
-			return grr
+			p.logger.Error("failed to dial upstream",
+				"err", grr,
+			)
+			return toStatusError(upstreamCtx, grr, codes.Unavailable)
 		}
 	}
 
@@ -100,7 +125,7 @@ func (p *proxy) handler(srv interface{}, stream grpc.ServerStream) error {
 		method,
 	)
 	if err != nil {
-		return err
+		return toStatusError(upstreamCtx, err, codes.Unavailable)
 	}
 
 	// Proxy upstream.
@@ -123,7 +148,7 @@ func (p *proxy) handler(srv interface{}, stream grpc.ServerStream) error {
 					)
 				}
 			} else {
-				return err
+				return toStatusError(upstreamCtx, err, codes.Internal)
 			}
 			break
 		case err := <-downErrCh:
@@ -134,11 +159,31 @@ func (p *proxy) handler(srv interface{}, stream grpc.ServerStream) error {
 				p.logger.Debug("upstream EOF")
 				return nil
 			}
-			return err
+			return toStatusError(upstreamCtx, err, codes.Internal)
 		}
 	}
 }
 
+// toStatusError converts the given error into a gRPC status error. Errors
+// that already carry a gRPC status (e.g., errors returned by the upstream
+// server) are returned unchanged so the original status code is preserved,
+// context errors are mapped to the corresponding status codes and all other
+// errors are mapped to the given fallback code.
+func toStatusError(ctx context.Context, err error, fallback codes.Code) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) || ctx.Err() == context.DeadlineExceeded:
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled) || ctx.Err() == context.Canceled:
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		return status.Error(fallback, err.Error())
+	}
+}
+
 // Client -> Upstream
 func (p *proxy) proxyUpstream(downstream grpc.ServerStream, upstream grpc.ClientStream) <-chan error {
 	errCh := make(chan error, 1)
@@ -164,9 +209,14 @@ func (p *proxy) proxyUpstream(downstream grpc.ServerStream, upstream grpc.Client
 			)
 
 			if err := upstream.SendMsg(m); err != nil {
-				p.logger.Error("failure forwarding message upstream",
-					"err", err,
-				)
+				// In case the upstream stream was aborted, SendMsg returns io.EOF
+				// and the actual status is returned by RecvMsg, which is
+				// propagated downstream.
+				if err != io.EOF {
+					p.logger.Error("failure forwarding message upstream",
+						"err", err,
+					)
+				}
 				errCh <- err
 				return
 			}
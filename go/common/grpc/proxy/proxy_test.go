@@ -11,6 +11,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	commonGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
@@ -166,3 +167,102 @@ func TestGRPCProxy(t *testing.T) {
 		}
 	}
 }
+
+func TestGRPCProxyPropagation(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	host := "localhost"
+	var port uint16 = 51125
+
+	serverTLSCert, serverX509Cert := cmnTesting.CreateCertificate(t)
+	clientTLSCert, _ := cmnTesting.CreateCertificate(t)
+
+	// Create a new gRPC server which records the context of incoming calls.
+	callCtxCh := make(chan context.Context, 1)
+	serverConfig := &commonGrpc.ServerConfig{
+		Name:     host,
+		Port:     port,
+		Identity: &identity.Identity{},
+		CustomOptions: []grpc.ServerOption{
+			grpc.CustomCodec(&commonGrpc.CBORCodec{}), // nolint: staticcheck
+			grpc.ChainUnaryInterceptor(func(
+				ctx context.Context,
+				req interface{},
+				info *grpc.UnaryServerInfo,
+				handler grpc.UnaryHandler,
+			) (interface{}, error) {
+				callCtxCh <- ctx
+				return handler(ctx, req)
+			}),
+		},
+	}
+	serverConfig.Identity.SetTLSCertificate(serverTLSCert)
+	grpcServer, err := commonGrpc.NewServer(serverConfig)
+	require.NoErrorf(err, "Failed to create a new gRPC server: %v", err)
+	cmnTesting.RegisterService(grpcServer.Server(), cmnTesting.NewPingServer(auth.NoAuth))
+	err = grpcServer.Start()
+	require.NoErrorf(err, "Failed to start the gRPC server: %v", err)
+
+	clientTLSCreds, err := commonGrpc.NewClientCreds(&commonGrpc.ClientOptions{
+		Certificates:     []tls.Certificate{*clientTLSCert},
+		GetServerPubKeys: commonGrpc.ServerPubKeysGetterFromCertificate(serverX509Cert),
+		CommonName:       "oasis-node",
+	})
+	require.NoError(err, "NewClientCreds")
+
+	newProxy := func(port uint16, dialer Dialer) {
+		proxyServerConfig := &commonGrpc.ServerConfig{
+			Name:     host,
+			Port:     port,
+			Identity: &identity.Identity{},
+			CustomOptions: []grpc.ServerOption{
+				grpc.UnknownServiceHandler(Handler(dialer)),
+			},
+		}
+		proxyServerConfig.Identity.SetTLSCertificate(serverTLSCert)
+		proxyGrpcServer, perr := commonGrpc.NewServer(proxyServerConfig)
+		require.NoErrorf(perr, "Failed to create a proxy gRPC server: %v", perr)
+		perr = proxyGrpcServer.Start()
+		require.NoErrorf(perr, "Failed to start the proxy gRPC server: %v", perr)
+	}
+
+	// Create a proxy to the upstream server.
+	newProxy(port+1, func(ctx context.Context) (*grpc.ClientConn, error) {
+		return connectToGrpcServer(ctx, t, fmt.Sprintf("%s:%d", host, port), clientTLSCreds), nil
+	})
+	proxyConn := connectToGrpcServer(ctx, t, fmt.Sprintf("%s:%d", host, port+1), clientTLSCreds)
+	defer proxyConn.Close()
+	proxyClient := cmnTesting.NewPingClient(proxyConn)
+
+	// Deadline and request ID should be propagated upstream.
+	deadline := time.Now().Add(recvTimeout)
+	callCtx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+	callCtx = metadata.AppendToOutgoingContext(callCtx, RequestIDMD, "test-request-id")
+	_, err = proxyClient.Ping(callCtx, &cmnTesting.PingQuery{})
+	require.NoError(err, "Calling Ping through the proxy should succeed")
+
+	var upstreamCtx context.Context
+	select {
+	case upstreamCtx = <-callCtxCh:
+	case <-time.After(recvTimeout):
+		t.Fatalf("failed to receive upstream call")
+	}
+	upstreamDeadline, ok := upstreamCtx.Deadline()
+	require.True(ok, "upstream call should have a deadline")
+	require.WithinDuration(deadline, upstreamDeadline, time.Second, "upstream deadline should match client deadline")
+	md, ok := metadata.FromIncomingContext(upstreamCtx)
+	require.True(ok, "upstream call should have metadata")
+	require.EqualValues([]string{"test-request-id"}, md.Get(RequestIDMD), "request ID should be forwarded")
+
+	// Upstream dial failures should be reported as unavailable.
+	newProxy(port+2, func(ctx context.Context) (*grpc.ClientConn, error) {
+		return nil, fmt.Errorf("upstream not available")
+	})
+	failingConn := connectToGrpcServer(ctx, t, fmt.Sprintf("%s:%d", host, port+2), clientTLSCreds)
+	defer failingConn.Close()
+	_, err = cmnTesting.NewPingClient(failingConn).Ping(ctx, &cmnTesting.PingQuery{})
+	require.Error(err, "Calling Ping through a proxy without upstream should fail")
+	require.Equal(codes.Unavailable, status.Code(err), "Unavailable error")
+}
@@ -230,7 +230,8 @@ func Initialize(w io.Writer, format Format, defaultLvl Level, moduleLvls map[str
 
 	var logger log.Logger = backend.baseLogger
 	if w != nil {
-		w = log.NewSyncWriter(w)
+		// Retain recent log output so that it can be included in diagnostics.
+		w = log.NewSyncWriter(io.MultiWriter(w, recentLogs))
 		switch format {
 		case FmtLogfmt:
 			logger = log.NewLogfmtLogger(w)
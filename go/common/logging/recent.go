@@ -0,0 +1,60 @@
+package logging
+
+import (
+	"bytes"
+	"sync"
+)
+
+// recentLogsSize is the maximum amount of recent log output retained in memory.
+const recentLogsSize = 1024 * 1024
+
+var recentLogs = newRecentBuffer(recentLogsSize)
+
+// recentBuffer is an io.Writer that retains the most recent output written
+// to it, up to a maximum size.
+type recentBuffer struct {
+	sync.Mutex
+
+	buf     []byte
+	maxSize int
+}
+
+func (b *recentBuffer) Write(p []byte) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.buf = append(b.buf, p...)
+	if excess := len(b.buf) - b.maxSize; excess > 0 {
+		// Keep the underlying array from growing unboundedly.
+		b.buf = append(b.buf[:0], b.buf[excess:]...)
+	}
+	return len(p), nil
+}
+
+// Bytes returns a copy of the retained output, starting at the first full
+// line.
+func (b *recentBuffer) Bytes() []byte {
+	b.Lock()
+	defer b.Unlock()
+
+	buf := b.buf
+	if len(buf) == b.maxSize {
+		// The buffer has wrapped, skip the (likely) partial first line.
+		if idx := bytes.IndexByte(buf, '\n'); idx >= 0 {
+			buf = buf[idx+1:]
+		}
+	}
+	return append([]byte{}, buf...)
+}
+
+func newRecentBuffer(maxSize int) *recentBuffer {
+	return &recentBuffer{
+		maxSize: maxSize,
+	}
+}
+
+// RecentLogs returns the most recent log output of the initialized logging
+// backend.
+func RecentLogs() []byte {
+	return recentLogs.Bytes()
+}
@@ -79,6 +79,9 @@ type Node struct { // nolint: maligned
 
 	// Roles is a bitmask representing the node roles.
 	Roles RolesMask `json:"roles"`
+
+	// SoftwareVersion contains information about the software the node is running.
+	SoftwareVersion *SoftwareVersion `json:"software_version,omitempty"`
 }
 
 // RolesMask is Oasis node roles bitmask.
@@ -153,6 +156,11 @@ func (n *Node) ValidateBasic(strictVersion bool) error {
 			)
 		}
 	}
+	if n.SoftwareVersion != nil {
+		if err := n.SoftwareVersion.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid software version: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -218,6 +226,44 @@ type Runtime struct {
 	ExtraInfo []byte `json:"extra_info"`
 }
 
+// MaxSoftwareVersionFieldLength is the maximum length of the free-form software version fields.
+const MaxSoftwareVersionFieldLength = 128
+
+// SoftwareVersion contains information about the software and platform an Oasis node is running.
+//
+// This information is self-reported by the node and is only meant to be used for reporting (e.g.,
+// to measure upgrade adoption) as it cannot be verified.
+type SoftwareVersion struct {
+	// OasisCore is the Oasis Core software version.
+	OasisCore string `json:"oasis_core"`
+
+	// ConsensusProtocol is the consensus protocol version supported by the node.
+	ConsensusProtocol version.Version `json:"consensus_protocol"`
+
+	// RuntimeHostProtocol is the runtime host protocol version supported by the node.
+	RuntimeHostProtocol version.Version `json:"runtime_host_protocol"`
+
+	// RuntimeLoader is the runtime loader software version (if any).
+	RuntimeLoader string `json:"runtime_loader,omitempty"`
+
+	// TEEHardware is the TEE hardware platform available to the node (if any).
+	TEEHardware TEEHardware `json:"tee_hardware,omitempty"`
+}
+
+// ValidateBasic performs basic software version validity checks.
+func (sv *SoftwareVersion) ValidateBasic() error {
+	if len(sv.OasisCore) > MaxSoftwareVersionFieldLength {
+		return fmt.Errorf("oasis core version too long")
+	}
+	if len(sv.RuntimeLoader) > MaxSoftwareVersionFieldLength {
+		return fmt.Errorf("runtime loader version too long")
+	}
+	if sv.TEEHardware >= TEEHardwareReserved {
+		return ErrInvalidTEEHardware
+	}
+	return nil
+}
+
 // TLSInfo contains information for connecting to this node via TLS.
 type TLSInfo struct {
 	// PubKey is the public key used for establishing TLS connections.
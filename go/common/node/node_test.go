@@ -1,11 +1,13 @@
 package node
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 )
 
 func TestNodeDescriptor(t *testing.T) {
@@ -33,3 +35,25 @@ func TestNodeDescriptor(t *testing.T) {
 	require.Equal(&rt1, &rt2, "AddOrUpdateRuntime should return the same reference for same id")
 	require.Len(n.Runtimes, 1)
 }
+
+func TestSoftwareVersion(t *testing.T) {
+	require := require.New(t)
+
+	n := Node{
+		Versioned: cbor.NewVersioned(LatestNodeDescriptorVersion),
+	}
+	require.NoError(n.ValidateBasic(true), "descriptor without software version should be valid")
+
+	n.SoftwareVersion = &SoftwareVersion{
+		OasisCore:   "20.12",
+		TEEHardware: TEEHardwareIntelSGX,
+	}
+	require.NoError(n.ValidateBasic(true), "descriptor with software version should be valid")
+
+	n.SoftwareVersion.OasisCore = strings.Repeat("x", MaxSoftwareVersionFieldLength+1)
+	require.Error(n.ValidateBasic(true), "too long software version should be rejected")
+
+	n.SoftwareVersion.OasisCore = "20.12"
+	n.SoftwareVersion.TEEHardware = TEEHardwareReserved
+	require.Error(n.ValidateBasic(true), "invalid TEE hardware should be rejected")
+}
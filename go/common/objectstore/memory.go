@@ -0,0 +1,54 @@
+package objectstore
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type memoryBucket struct {
+	sync.RWMutex
+
+	objects map[string][]byte
+}
+
+func (b *memoryBucket) Put(ctx context.Context, key string, data []byte) error {
+	b.Lock()
+	defer b.Unlock()
+
+	b.objects[key] = append([]byte{}, data...)
+	return nil
+}
+
+func (b *memoryBucket) Get(ctx context.Context, key string) ([]byte, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	data, ok := b.objects[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]byte{}, data...), nil
+}
+
+func (b *memoryBucket) List(ctx context.Context, prefix string) ([]string, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	var keys []string
+	for key := range b.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// NewMemory creates a new in-memory bucket, useful for testing.
+func NewMemory() Bucket {
+	return &memoryBucket{
+		objects: make(map[string][]byte),
+	}
+}
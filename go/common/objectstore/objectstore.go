@@ -0,0 +1,119 @@
+// Package objectstore implements a minimal client for S3-compatible object storage buckets.
+//
+// Both Amazon S3 and Google Cloud Storage (via its S3-compatible XML API using HMAC keys) are
+// supported, as well as any other service implementing the S3 API (e.g., MinIO).
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const (
+	// SchemeS3 is the URL scheme for Amazon S3 buckets.
+	SchemeS3 = "s3"
+	// SchemeGCS is the URL scheme for Google Cloud Storage buckets.
+	SchemeGCS = "gs"
+
+	// EnvAccessKeyID is the environment variable holding the access key identifier.
+	EnvAccessKeyID = "AWS_ACCESS_KEY_ID"
+	// EnvSecretAccessKey is the environment variable holding the secret access key.
+	EnvSecretAccessKey = "AWS_SECRET_ACCESS_KEY" // nolint: gosec
+
+	defaultS3Region = "us-east-1"
+	gcsEndpoint     = "https://storage.googleapis.com"
+	gcsRegion       = "auto"
+)
+
+// ErrNotFound is the error returned when an object does not exist.
+var ErrNotFound = errors.New("objectstore: object not found")
+
+// Bucket is an object storage bucket.
+//
+// All object keys are relative to the bucket prefix (if any).
+type Bucket interface {
+	// Put stores an object under the given key, replacing any existing object.
+	Put(ctx context.Context, key string, data []byte) error
+
+	// Get retrieves the object stored under the given key.
+	//
+	// In case the object does not exist, ErrNotFound is returned.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// List returns the keys of all objects with the given key prefix.
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// Config is the object storage client configuration.
+type Config struct {
+	// URL is the bucket URL in the form <scheme>://<bucket>[/<prefix>] where scheme is either
+	// s3 or gs.
+	URL string
+
+	// Endpoint is an optional endpoint override (e.g., for S3-compatible services).
+	Endpoint string
+
+	// Region is the optional bucket region.
+	Region string
+
+	// AccessKeyID is the access key identifier. If empty, it is taken from the environment.
+	AccessKeyID string
+
+	// SecretAccessKey is the secret access key. If empty, it is taken from the environment.
+	SecretAccessKey string
+}
+
+// New creates a new bucket client from the given configuration.
+func New(cfg *Config) (Bucket, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("objectstore: malformed bucket URL: %w", err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("objectstore: missing bucket name in URL '%s'", cfg.URL)
+	}
+
+	b := &s3Bucket{
+		endpoint:        cfg.Endpoint,
+		region:          cfg.Region,
+		bucket:          u.Host,
+		prefix:          strings.Trim(u.Path, "/"),
+		accessKeyID:     cfg.AccessKeyID,
+		secretAccessKey: cfg.SecretAccessKey,
+	}
+	switch u.Scheme {
+	case SchemeS3:
+		if b.region == "" {
+			b.region = defaultS3Region
+		}
+		if b.endpoint == "" {
+			b.endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", b.region)
+		}
+	case SchemeGCS:
+		if b.region == "" {
+			b.region = gcsRegion
+		}
+		if b.endpoint == "" {
+			b.endpoint = gcsEndpoint
+		}
+	default:
+		return nil, fmt.Errorf("objectstore: unsupported bucket URL scheme: '%s'", u.Scheme)
+	}
+	b.endpoint = strings.TrimSuffix(b.endpoint, "/")
+
+	if b.accessKeyID == "" {
+		b.accessKeyID = os.Getenv(EnvAccessKeyID)
+	}
+	if b.secretAccessKey == "" {
+		b.secretAccessKey = os.Getenv(EnvSecretAccessKey)
+	}
+	if b.accessKeyID == "" || b.secretAccessKey == "" {
+		return nil, fmt.Errorf("objectstore: missing credentials (set %s and %s)", EnvAccessKeyID, EnvSecretAccessKey)
+	}
+
+	return b, nil
+}
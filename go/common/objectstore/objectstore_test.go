@@ -0,0 +1,159 @@
+package objectstore
+
+import (
+	"context"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 is a minimal fake S3 server supporting path-style PUT, GET and ListObjectsV2.
+type fakeS3 struct {
+	sync.Mutex
+
+	bucket  string
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), signatureAlgorithm+" Credential=key/") ||
+		r.Header.Get("x-amz-date") == "" ||
+		r.Header.Get("x-amz-content-sha256") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/"+f.bucket)
+	key := strings.TrimPrefix(path, "/")
+	switch {
+	case r.Method == http.MethodPut:
+		data, _ := ioutil.ReadAll(r.Body)
+		if sha256Hex(data) != r.Header.Get("x-amz-content-sha256") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.objects[key] = data
+	case r.Method == http.MethodGet && key != "":
+		data, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	case r.Method == http.MethodGet && r.URL.Query().Get("list-type") == "2":
+		prefix := r.URL.Query().Get("prefix")
+		var keys []string
+		for k := range f.objects {
+			if strings.HasPrefix(k, prefix) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		// Return a single key per page to exercise pagination.
+		var start int
+		if token := r.URL.Query().Get("continuation-token"); token != "" {
+			start = sort.SearchStrings(keys, token)
+		}
+		var result listBucketResult
+		if start < len(keys) {
+			result.Contents = append(result.Contents, struct {
+				Key string `xml:"Key"`
+			}{Key: keys[start]})
+		}
+		if start+1 < len(keys) {
+			result.IsTruncated = true
+			result.NextContinuationToken = keys[start+1]
+		}
+		_ = xml.NewEncoder(w).Encode(&result)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestS3Bucket(t *testing.T) {
+	require := require.New(t)
+
+	fake := &fakeS3{
+		bucket:  "test-bucket",
+		objects: make(map[string][]byte),
+	}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	_, err := New(&Config{URL: "ftp://test-bucket"})
+	require.Error(err, "New should fail with an unsupported scheme")
+	_, err = New(&Config{URL: "s3://test-bucket", Endpoint: srv.URL, AccessKeyID: "key"})
+	require.Error(err, "New should fail without credentials")
+
+	b, err := New(&Config{
+		URL:             "s3://test-bucket/some/prefix/",
+		Endpoint:        srv.URL,
+		AccessKeyID:     "key",
+		SecretAccessKey: "secret",
+	})
+	require.NoError(err, "New")
+
+	ctx := context.Background()
+	_, err = b.Get(ctx, "missing")
+	require.Equal(ErrNotFound, err, "Get should return ErrNotFound for missing objects")
+
+	require.NoError(b.Put(ctx, "a/1", []byte("one")), "Put")
+	require.NoError(b.Put(ctx, "a/2 with space", []byte("two")), "Put")
+	require.NoError(b.Put(ctx, "b/1", []byte("three")), "Put")
+	require.Contains(fake.objects, "some/prefix/a/2 with space", "objects should be stored under the prefix")
+
+	data, err := b.Get(ctx, "a/2 with space")
+	require.NoError(err, "Get")
+	require.Equal([]byte("two"), data)
+
+	keys, err := b.List(ctx, "a/")
+	require.NoError(err, "List")
+	require.Equal([]string{"a/1", "a/2 with space"}, keys)
+
+	keys, err = b.List(ctx, "")
+	require.NoError(err, "List")
+	require.Len(keys, 3)
+}
+
+func TestMemoryBucket(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	b := NewMemory()
+
+	_, err := b.Get(ctx, "missing")
+	require.Equal(ErrNotFound, err, "Get should return ErrNotFound for missing objects")
+
+	require.NoError(b.Put(ctx, "a/1", []byte("one")), "Put")
+	require.NoError(b.Put(ctx, "b/1", []byte("two")), "Put")
+
+	data, err := b.Get(ctx, "a/1")
+	require.NoError(err, "Get")
+	require.Equal([]byte("one"), data)
+
+	keys, err := b.List(ctx, "a/")
+	require.NoError(err, "List")
+	require.Equal([]string{"a/1"}, keys)
+}
+
+func TestURIEncode(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("/bucket/a%20b/c~d", uriEncode("/bucket/a b/c~d", false))
+	require.Equal("a%2Fb%3D%2B", uriEncode("a/b=+", true))
+	require.Equal("list-type=2&prefix=a%2Fb", canonicalQueryString(map[string]string{
+		"prefix":    "a/b",
+		"list-type": "2",
+	}))
+}
@@ -0,0 +1,266 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	signatureAlgorithm = "AWS4-HMAC-SHA256"
+	signatureService   = "s3"
+	signedHeaders      = "host;x-amz-content-sha256;x-amz-date"
+
+	amzDateFormat   = "20060102T150405Z"
+	amzDayFormat    = "20060102"
+	maxErrorBodyLen = 1024
+)
+
+type s3Bucket struct {
+	client *http.Client
+
+	endpoint string
+	region   string
+	bucket   string
+	prefix   string
+
+	accessKeyID     string
+	secretAccessKey string
+
+	// now is used to override the current time in tests.
+	now func() time.Time
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (b *s3Bucket) objectKey(key string) string {
+	if b.prefix == "" {
+		return key
+	}
+	return b.prefix + "/" + key
+}
+
+func (b *s3Bucket) Put(ctx context.Context, key string, data []byte) error {
+	rsp, err := b.do(ctx, http.MethodPut, b.objectKey(key), nil, data)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return b.responseError(rsp, key)
+	}
+	return nil
+}
+
+func (b *s3Bucket) Get(ctx context.Context, key string) ([]byte, error) {
+	rsp, err := b.do(ctx, http.MethodGet, b.objectKey(key), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	switch rsp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, b.responseError(rsp, key)
+	}
+
+	data, err := ioutil.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("objectstore: failed to read object '%s': %w", key, err)
+	}
+	return data, nil
+}
+
+func (b *s3Bucket) List(ctx context.Context, prefix string) ([]string, error) {
+	var (
+		keys  []string
+		token string
+	)
+	for {
+		query := map[string]string{
+			"list-type": "2",
+			"prefix":    b.objectKey(prefix),
+		}
+		if token != "" {
+			query["continuation-token"] = token
+		}
+
+		result, err := func() (*listBucketResult, error) {
+			rsp, err := b.do(ctx, http.MethodGet, "", query, nil)
+			if err != nil {
+				return nil, err
+			}
+			defer rsp.Body.Close()
+
+			if rsp.StatusCode != http.StatusOK {
+				return nil, b.responseError(rsp, prefix)
+			}
+
+			var result listBucketResult
+			if err = xml.NewDecoder(rsp.Body).Decode(&result); err != nil {
+				return nil, fmt.Errorf("objectstore: malformed list response: %w", err)
+			}
+			return &result, nil
+		}()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, obj := range result.Contents {
+			key := obj.Key
+			if b.prefix != "" {
+				key = strings.TrimPrefix(key, b.prefix+"/")
+			}
+			keys = append(keys, key)
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+	return keys, nil
+}
+
+func (b *s3Bucket) responseError(rsp *http.Response, key string) error {
+	body, _ := ioutil.ReadAll(io.LimitReader(rsp.Body, maxErrorBodyLen))
+	return fmt.Errorf("objectstore: request for '%s' failed with status %d: %s",
+		key,
+		rsp.StatusCode,
+		strings.TrimSpace(string(body)),
+	)
+}
+
+// do performs a path-style request signed using AWS Signature Version 4.
+func (b *s3Bucket) do(ctx context.Context, method, key string, query map[string]string, body []byte) (*http.Response, error) {
+	path := "/" + b.bucket
+	if key != "" {
+		path += "/" + key
+	}
+	canonicalURI := uriEncode(path, false)
+	canonicalQuery := canonicalQueryString(query)
+
+	rawURL := b.endpoint + canonicalURI
+	if canonicalQuery != "" {
+		rawURL += "?" + canonicalQuery
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("objectstore: failed to create request: %w", err)
+	}
+	req.ContentLength = int64(len(body))
+
+	now := time.Now
+	if b.now != nil {
+		now = b.now
+	}
+	t := now().UTC()
+	payloadHash := sha256Hex(body)
+	req.Header.Set("x-amz-date", t.Format(amzDateFormat))
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		canonicalURI,
+		canonicalQuery,
+		"host:" + req.URL.Host + "\n" +
+			"x-amz-content-sha256:" + payloadHash + "\n" +
+			"x-amz-date:" + t.Format(amzDateFormat) + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := strings.Join([]string{t.Format(amzDayFormat), b.region, signatureService, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		signatureAlgorithm,
+		t.Format(amzDateFormat),
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+b.secretAccessKey), []byte(t.Format(amzDayFormat)))
+	for _, part := range []string{b.region, signatureService, "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, []byte(part))
+	}
+	signature := hex.EncodeToString(hmacSHA256(signingKey, []byte(stringToSign)))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signatureAlgorithm,
+		b.accessKeyID,
+		scope,
+		signedHeaders,
+		signature,
+	))
+
+	client := b.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	rsp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("objectstore: request failed: %w", err)
+	}
+	return rsp, nil
+}
+
+func canonicalQueryString(query map[string]string) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, uriEncode(k, true)+"="+uriEncode(query[k], true))
+	}
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode encodes the given string as required by AWS Signature Version 4.
+func uriEncode(s string, encodeSlash bool) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9'),
+			c == '-', c == '_', c == '.', c == '~':
+			sb.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			sb.WriteByte(c)
+		default:
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+	return sb.String()
+}
+
+func sha256Hex(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSHA256(key, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write(data)
+	return h.Sum(nil)
+}
@@ -118,6 +118,34 @@ func (ss *ServiceStore) Delete(key []byte) error {
 	})
 }
 
+// IterateCBOR calls the given function for each key with the given prefix in
+// ascending key order. The passed key does not include the service name and
+// the passed decode function unmarshals the CBOR-serialized value. Iteration
+// stops at the first error returned by the callback.
+func (ss *ServiceStore) IterateCBOR(prefix []byte, fn func(key []byte, decode func(value interface{}) error) error) error {
+	return ss.store.db.View(func(tx *badger.Txn) error {
+		dbPrefix := ss.dbKey(prefix)
+		it := tx.NewIterator(badger.IteratorOptions{
+			Prefix: dbPrefix,
+		})
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			key := item.KeyCopy(nil)[len(dbPrefix)-len(prefix):]
+			decode := func(value interface{}) error {
+				return item.Value(func(val []byte) error {
+					return cbor.Unmarshal(val, value)
+				})
+			}
+			if err := fn(key, decode); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (ss *ServiceStore) dbKey(key []byte) []byte {
 	return bytes.Join([][]byte{ss.name, key}, []byte{'.'})
 }
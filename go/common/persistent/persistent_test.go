@@ -32,4 +32,23 @@ func TestPersistent(t *testing.T) {
 	nonexistentKey := []byte("baz")
 	err = svc.GetCBOR(nonexistentKey, &valOut)
 	assert.Equal(t, ErrNotFound, err, "GetCBOR(nonexistent)")
+
+	// Prefix iteration.
+	for _, k := range []string{"prefix.2", "prefix.1", "other"} {
+		err = svc.PutCBOR([]byte(k), &k)
+		assert.NoError(t, err, "PutCBOR")
+	}
+	var keys, vals []string
+	err = svc.IterateCBOR([]byte("prefix."), func(key []byte, decode func(interface{}) error) error {
+		var v string
+		if derr := decode(&v); derr != nil {
+			return derr
+		}
+		keys = append(keys, string(key))
+		vals = append(vals, v)
+		return nil
+	})
+	assert.NoError(t, err, "IterateCBOR")
+	assert.Equal(t, []string{"prefix.1", "prefix.2"}, keys, "IterateCBOR keys")
+	assert.Equal(t, keys, vals, "IterateCBOR values")
 }
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"fmt"
+)
+
+// HealthState is the health state of a service.
+type HealthState uint8
+
+const (
+	// HealthInitializing means that the service has not yet been started or that it is still
+	// initializing.
+	HealthInitializing HealthState = 0
+	// HealthReady means that the service is running and fully initialized.
+	HealthReady HealthState = 1
+	// HealthDegraded means that the service is running but is not fully functional.
+	HealthDegraded HealthState = 2
+	// HealthStopped means that the service has terminated.
+	HealthStopped HealthState = 3
+
+	healthInitializingName = "initializing"
+	healthReadyName        = "ready"
+	healthDegradedName     = "degraded"
+	healthStoppedName      = "stopped"
+)
+
+// String returns a string representation of a health state.
+func (h HealthState) String() string {
+	switch h {
+	case HealthInitializing:
+		return healthInitializingName
+	case HealthReady:
+		return healthReadyName
+	case HealthDegraded:
+		return healthDegradedName
+	case HealthStopped:
+		return healthStoppedName
+	default:
+		return fmt.Sprintf("[unknown health state: %d]", h)
+	}
+}
+
+// MarshalText encodes a health state into text form.
+func (h HealthState) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+// UnmarshalText decodes a text slice into a health state.
+func (h *HealthState) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case healthInitializingName:
+		*h = HealthInitializing
+	case healthReadyName:
+		*h = HealthReady
+	case healthDegradedName:
+		*h = HealthDegraded
+	case healthStoppedName:
+		*h = HealthStopped
+	default:
+		return fmt.Errorf("service: invalid health state: '%s'", string(text))
+	}
+	return nil
+}
+
+// HealthReporter is an optional interface that a BackgroundService can implement in order to
+// report its health state while it is running.
+type HealthReporter interface {
+	// Health returns the current health state of the service.
+	Health() HealthState
+}
+
+// Initializer is an optional interface that a BackgroundService can implement in case it
+// performs asynchronous initialization after being started.
+type Initializer interface {
+	// Initialized returns a channel that will be closed once the service is initialized.
+	Initialized() <-chan struct{}
+}
+
+// ServiceHealth is the health of a single service.
+type ServiceHealth struct { // nolint: golint
+	// Name is the service name.
+	Name string `json:"name"`
+	// State is the service health state.
+	State HealthState `json:"state"`
+}
+
+// Health is the aggregate health of a group of services.
+type Health struct {
+	// State is the aggregate health state.
+	State HealthState `json:"state"`
+	// Services are the health states of individual services.
+	Services []ServiceHealth `json:"services,omitempty"`
+}
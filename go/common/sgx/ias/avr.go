@@ -194,6 +194,33 @@ func (b *AVRBundle) Open(trustRoots *x509.CertPool, ts time.Time) (*AttestationV
 	return DecodeAVR(b.Body, b.Signature, b.CertificateChain, trustRoots, ts)
 }
 
+// OpenWithValidity is like Open, but it also returns the time interval
+// during which the AVR signature verification would succeed.
+func (b *AVRBundle) OpenWithValidity(trustRoots *x509.CertPool, ts time.Time) (*AttestationVerificationReport, *Validity, error) {
+	return decodeAVR(b.Body, b.Signature, b.CertificateChain, trustRoots, ts)
+}
+
+// Validity is the time interval during which an AVR signature verification
+// result remains valid.
+//
+// A zero NotBefore or NotAfter value means that the interval is unbounded
+// in that direction.
+type Validity struct {
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// Contains returns true iff the given timestamp is within the interval.
+func (v *Validity) Contains(ts time.Time) bool {
+	if !v.NotBefore.IsZero() && ts.Before(v.NotBefore) {
+		return false
+	}
+	if !v.NotAfter.IsZero() && ts.After(v.NotAfter) {
+		return false
+	}
+	return true
+}
+
 // AttestationVerificationReport is a deserialized Attestation Verification
 // Report (AVR).
 type AttestationVerificationReport struct {
@@ -324,9 +351,16 @@ func (a *AttestationVerificationReport) validate() error { // nolint: gocyclo
 
 // DecodeAVR decodes and validates an Attestation Verification Report.
 func DecodeAVR(data, encodedSignature, encodedCertChain []byte, trustRoots *x509.CertPool, ts time.Time) (*AttestationVerificationReport, error) {
+	avr, _, err := decodeAVR(data, encodedSignature, encodedCertChain, trustRoots, ts)
+	return avr, err
+}
+
+func decodeAVR(data, encodedSignature, encodedCertChain []byte, trustRoots *x509.CertPool, ts time.Time) (*AttestationVerificationReport, *Validity, error) {
+	validity := &Validity{}
 	if !unsafeSkipVerify {
-		if err := validateAVRSignature(data, encodedSignature, encodedCertChain, trustRoots, ts); err != nil {
-			return nil, err
+		var err error
+		if validity, err = validateAVRSignature(data, encodedSignature, encodedCertChain, trustRoots, ts); err != nil {
+			return nil, nil, err
 		}
 	}
 
@@ -337,20 +371,20 @@ func DecodeAVR(data, encodedSignature, encodedCertChain []byte, trustRoots *x509
 	}
 
 	if err := json.Unmarshal(data, a); err != nil {
-		return nil, fmt.Errorf("ias/avr: failed to parse JSON: %w", err)
+		return nil, nil, fmt.Errorf("ias/avr: failed to parse JSON: %w", err)
 	}
 
 	if err := a.validate(); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return a, nil
+	return a, validity, nil
 }
 
-func validateAVRSignature(data, encodedSignature, encodedCertChain []byte, trustRoots *x509.CertPool, ts time.Time) error {
+func validateAVRSignature(data, encodedSignature, encodedCertChain []byte, trustRoots *x509.CertPool, ts time.Time) (*Validity, error) {
 	decoded, err := url.QueryUnescape(string(encodedCertChain))
 	if err != nil {
-		return fmt.Errorf("ias/avr: failed to decode certificate chain: %w", err)
+		return nil, fmt.Errorf("ias/avr: failed to decode certificate chain: %w", err)
 	}
 	pemCerts := []byte(decoded)
 
@@ -359,7 +393,7 @@ func validateAVRSignature(data, encodedSignature, encodedCertChain []byte, trust
 		var cert *x509.Certificate
 		cert, pemCerts, err = CertFromPEM(pemCerts)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if cert == nil {
 			break
@@ -367,7 +401,7 @@ func validateAVRSignature(data, encodedSignature, encodedCertChain []byte, trust
 		certs = append(certs, cert)
 	}
 	if len(certs) != 2 {
-		return fmt.Errorf("ias/avr: unexpected certificate chain length: %d", len(certs))
+		return nil, fmt.Errorf("ias/avr: unexpected certificate chain length: %d", len(certs))
 	}
 
 	signingCert, rootCert := certs[0], certs[1]
@@ -376,22 +410,37 @@ func validateAVRSignature(data, encodedSignature, encodedCertChain []byte, trust
 		CurrentTime: ts,
 	})
 	if err != nil {
-		return fmt.Errorf("ias/avr: failed to verify certificate chain: %w", err)
+		return nil, fmt.Errorf("ias/avr: failed to verify certificate chain: %w", err)
 	}
 	if !certRootsAChain(rootCert, certChains) {
-		return fmt.Errorf("ias/avr: unexpected root in certificate chain")
+		return nil, fmt.Errorf("ias/avr: unexpected root in certificate chain")
+	}
+
+	// The verification result remains valid for as long as all of the
+	// certificates in the chain are valid.
+	validity := &Validity{
+		NotBefore: signingCert.NotBefore,
+		NotAfter:  signingCert.NotAfter,
+	}
+	for _, cert := range certChains[0] {
+		if cert.NotBefore.After(validity.NotBefore) {
+			validity.NotBefore = cert.NotBefore
+		}
+		if cert.NotAfter.Before(validity.NotAfter) {
+			validity.NotAfter = cert.NotAfter
+		}
 	}
 
 	signature, err := base64.StdEncoding.DecodeString(string(encodedSignature))
 	if err != nil {
-		return fmt.Errorf("ias/avr: failed to decode signature: %w", err)
+		return nil, fmt.Errorf("ias/avr: failed to decode signature: %w", err)
 	}
 
 	if err = signingCert.CheckSignature(x509.SHA256WithRSA, data, signature); err != nil {
-		return fmt.Errorf("ias/avr: failed to verify AVR signature: %w", err)
+		return nil, fmt.Errorf("ias/avr: failed to verify AVR signature: %w", err)
 	}
 
-	return nil
+	return validity, nil
 }
 
 // SetSkipVerify will disable AVR signature verification for the remainder
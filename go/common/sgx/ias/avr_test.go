@@ -11,6 +11,7 @@ import (
 
 func TestAVR(t *testing.T) {
 	t.Run("Version_4", testAVRv4)
+	t.Run("Validity", testAVRValidity)
 }
 
 func testAVRv4(t *testing.T) {
@@ -41,6 +42,31 @@ func testAVRv4(t *testing.T) {
 	require.EqualValues(t, avr.AdvisoryIDs, []string{"INTEL-SA-00334"}, "advisoryIDs")
 }
 
+func testAVRValidity(t *testing.T) {
+	SetAllowDebugEnclaves()
+	defer UnsetAllowDebugEnclaves()
+
+	raw, sig, certs := loadAVRv4(t)
+	bundle := AVRBundle{
+		Body:             raw,
+		CertificateChain: certs,
+		Signature:        sig,
+	}
+
+	now := time.Now()
+	_, validity, err := bundle.OpenWithValidity(IntelTrustRoots, now)
+	require.NoError(t, err, "OpenWithValidity")
+	require.True(t, validity.Contains(now), "validity should contain the verification timestamp")
+	require.False(t, validity.Contains(validity.NotAfter.Add(time.Second)), "validity should end when the chain expires")
+	require.False(t, validity.Contains(validity.NotBefore.Add(-time.Second)), "validity should start when the chain is valid")
+
+	_, err = bundle.Open(IntelTrustRoots, validity.NotAfter.Add(time.Second))
+	require.Error(t, err, "Open should fail outside of the validity interval")
+
+	var unbounded Validity
+	require.True(t, unbounded.Contains(now), "zero validity should be unbounded")
+}
+
 func loadAVRv4(t *testing.T) (raw, sig, certs []byte) {
 	var err error
 	raw, err = ioutil.ReadFile("testdata/avr_v4_body_sw_hardening_needed.json")
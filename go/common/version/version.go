@@ -120,7 +120,10 @@ var Versions = struct {
 	Toolchain,
 }
 
-func parseSemVerStr(s string) Version {
+// FromString parses a version from its string representation (e.g., "1.2.3").
+//
+// Missing minor and patch segments are treated as zero and any pre-release suffix is ignored.
+func FromString(s string) (Version, error) {
 	// Trim potential pre-release suffix.
 	s = strings.Split(s, "-")[0]
 	split := strings.SplitN(s, ".", 4)
@@ -132,10 +135,18 @@ func parseSemVerStr(s string) Version {
 		}
 		ver, err := strconv.ParseUint(v, 10, 16)
 		if err != nil {
-			panic("version: failed to parse SemVer: " + err.Error())
+			return Version{}, fmt.Errorf("version: failed to parse SemVer: %w", err)
 		}
 		semVers[i] = uint16(ver)
 	}
 
-	return Version{Major: semVers[0], Minor: semVers[1], Patch: semVers[2]}
+	return Version{Major: semVers[0], Minor: semVers[1], Patch: semVers[2]}, nil
+}
+
+func parseSemVerStr(s string) Version {
+	v, err := FromString(s)
+	if err != nil {
+		panic(err.Error())
+	}
+	return v
 }
@@ -33,6 +33,9 @@ func TestParseSemVer(t *testing.T) {
 	} {
 		require.Equal(parseSemVerStr(v.semver), v.expected, "parseSemVerStr()")
 	}
+
+	_, err := FromString("1.x.0")
+	require.Error(err, "FromString should fail on malformed versions")
 }
 
 func TestFromToU64(t *testing.T) {
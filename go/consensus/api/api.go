@@ -9,10 +9,12 @@ import (
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/common/service"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction/results"
@@ -24,6 +26,7 @@ import (
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	mkvsNode "github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
 
 const (
@@ -52,6 +55,17 @@ var (
 
 	// ErrDuplicateTx is the error returned when the transaction already exists in the mempool.
 	ErrDuplicateTx = errors.New(moduleName, 5, "consensus: duplicate transaction")
+
+	// ErrStateAttestationNotFound is the error returned when a state attestation is not available
+	// for the requested height.
+	ErrStateAttestationNotFound = errors.New(moduleName, 6, "consensus: state attestation not found")
+
+	// ErrSignerStandby is the error returned when the local consensus signer is in standby and
+	// the operation requires it to be active (or the other way around).
+	ErrSignerStandby = errors.New(moduleName, 7, "consensus: signer in standby")
+
+	// ErrInvalidSignerHandoff is the error returned when a consensus signer handoff is invalid.
+	ErrInvalidSignerHandoff = errors.New(moduleName, 8, "consensus: invalid signer handoff")
 )
 
 // FeatureMask is the consensus backend feature bitmask.
@@ -110,6 +124,9 @@ type ClientBackend interface {
 	// GetEpoch returns the current epoch.
 	GetEpoch(ctx context.Context, height int64) (epochtime.EpochTime, error)
 
+	// GetEpochBlock returns the consensus block height at which the given epoch started.
+	GetEpochBlock(ctx context.Context, epoch epochtime.EpochTime) (int64, error)
+
 	// GetBlock returns a consensus block at a specific height.
 	GetBlock(ctx context.Context, height int64) (*Block, error)
 
@@ -137,6 +154,89 @@ type ClientBackend interface {
 
 	// GetStatus returns the current status overview.
 	GetStatus(ctx context.Context) (*Status, error)
+
+	// WatchFeeStatistics returns a channel that produces a stream of per-block
+	// transaction fee statistics as blocks are being finalized.
+	//
+	// This is a debug endpoint intended for calibrating fee market changes.
+	WatchFeeStatistics(ctx context.Context) (<-chan *FeeStatistics, pubsub.ClosableSubscription, error)
+
+	// GetStateAttestation returns the local node's state attestation made at the given height.
+	//
+	// Passing HeightLatest returns the most recent attestation.
+	GetStateAttestation(ctx context.Context, height int64) (*StateAttestation, error)
+
+	// GetAddressBook returns an export of the local node's consensus P2P address book.
+	//
+	// This is currently only supported by seed nodes.
+	GetAddressBook(ctx context.Context) (*AddressBook, error)
+}
+
+// FeeStatistics are the transaction fee statistics for a single block.
+type FeeStatistics struct {
+	// Height is the block height.
+	Height int64 `json:"height"`
+	// NumTxs is the number of transactions included in the block.
+	NumTxs uint64 `json:"num_txs"`
+	// GasUsed is the total amount of gas used by transactions in the block.
+	GasUsed transaction.Gas `json:"gas_used"`
+	// GasWanted is the total amount of gas requested by transactions in the block.
+	GasWanted transaction.Gas `json:"gas_wanted"`
+	// FeeTotal is the total amount of fees paid by transactions in the block.
+	FeeTotal quantity.Quantity `json:"fee_total"`
+	// NumRejectedForFee is the number of transactions included in the block that were rejected
+	// due to fees.
+	NumRejectedForFee uint64 `json:"num_rejected_for_fee"`
+}
+
+// StateAttestation is a periodic attestation of the full consensus application state made by the
+// local node. Besides the application state root it also commits to data that is not covered by
+// the per-block application state hash (e.g., emitted events), so that comparing attestations of
+// different nodes enables cheap detection of state divergence.
+//
+// Attestations are only kept by the local node and are not committed to the consensus state or
+// block headers, so divergence is only detected when attestations of different nodes are compared.
+type StateAttestation struct {
+	// Height is the block height at which the attestation was made.
+	Height int64 `json:"height"`
+	// StateRoot is the application state root hash at the given height.
+	StateRoot hash.Hash `json:"state_root"`
+	// StateVersion is the version of the application state store.
+	StateVersion uint64 `json:"state_version"`
+	// AppVersion is the ABCI application protocol version.
+	AppVersion uint64 `json:"app_version"`
+	// FromHeight is the first height covered by the events digest.
+	FromHeight int64 `json:"from_height"`
+	// EventsDigest is the digest of all events emitted by the application in blocks from
+	// FromHeight up to and including Height.
+	EventsDigest hash.Hash `json:"events_digest"`
+}
+
+// Hash returns the hash of the state attestation.
+func (a *StateAttestation) Hash() hash.Hash {
+	return hash.NewFrom(a)
+}
+
+// AddressBook is an export of a node's consensus P2P address book.
+type AddressBook struct {
+	// Addresses are the known peer addresses.
+	Addresses []*AddressBookEntry `json:"addresses"`
+}
+
+// AddressBookEntry is a single consensus P2P address book entry.
+type AddressBookEntry struct {
+	// Address is the peer address in the ID@host:port form.
+	Address string `json:"address"`
+	// Source is the address of the peer that advertised this address.
+	Source string `json:"source,omitempty"`
+	// Good is true iff a connection to the peer was successfully established.
+	Good bool `json:"good"`
+	// Attempts is the number of failed connection attempts since the last success.
+	Attempts int32 `json:"attempts"`
+	// LastAttempt is the time of the last connection attempt.
+	LastAttempt time.Time `json:"last_attempt"`
+	// LastSuccess is the time of the last successful connection.
+	LastSuccess time.Time `json:"last_success"`
 }
 
 // Block is a consensus block.
@@ -165,6 +265,11 @@ type Status struct { // nolint: maligned
 	// Features are the indicated consensus backend features.
 	Features FeatureMask `json:"features"`
 
+	// ChainID is the ID of the chain.
+	ChainID string `json:"chain_id"`
+	// ChainContext is the chain domain separation context.
+	ChainContext string `json:"chain_context"`
+
 	// NodePeers is a list of node's peers.
 	NodePeers []string `json:"node_peers"`
 
@@ -187,14 +292,27 @@ type Status struct { // nolint: maligned
 	// LastRetainedHash is the hash of the oldest retained block.
 	LastRetainedHash []byte `json:"last_retained_hash"`
 
+	// HaltEpoch is the epoch at which the network will stop processing
+	// any transactions as specified in the genesis document.
+	HaltEpoch epochtime.EpochTime `json:"halt_epoch"`
+	// PendingUpgrades are the upgrades pending on the local node.
+	PendingUpgrades []*upgrade.PendingUpgrade `json:"pending_upgrades,omitempty"`
+
+	// IsSynced returns whether the node has finished initial synchronization.
+	IsSynced bool `json:"is_synced"`
 	// IsValidator returns whether the current node is part of the validator set.
 	IsValidator bool `json:"is_validator"`
+
+	// Signing is the signing performance overview of the local validator.
+	Signing *SigningStatus `json:"signing,omitempty"`
 }
 
 // Backend is an interface that a consensus backend must provide.
 type Backend interface {
 	service.BackgroundService
 	ServicesBackend
+	PeerManager
+	SignerManager
 
 	// SupportedFeatures returns the features supported by this consensus backend.
 	SupportedFeatures() FeatureMask
@@ -221,6 +339,10 @@ type ServicesBackend interface {
 	// consensus Halt epoch height is reached.
 	RegisterHaltHook(func(ctx context.Context, blockHeight int64, epoch epochtime.EpochTime))
 
+	// RegisterSigningAlertHandler registers a handler to be notified when
+	// the local validator misses too many blocks.
+	RegisterSigningAlertHandler(handler SigningAlertHandler)
+
 	// SubmissionManager returns the transaction submission manager.
 	SubmissionManager() SubmissionManager
 
@@ -29,6 +29,8 @@ var (
 	methodGetSignerNonce = serviceName.NewMethod("GetSignerNonce", &GetSignerNonceRequest{})
 	// methodGetEpoch is the GetEpoch method.
 	methodGetEpoch = serviceName.NewMethod("GetEpoch", int64(0))
+	// methodGetEpochBlock is the GetEpochBlock method.
+	methodGetEpochBlock = serviceName.NewMethod("GetEpochBlock", epochtime.EpochTime(0))
 	// methodWaitEpoch is the WaitEpoch method.
 	methodWaitEpoch = serviceName.NewMethod("WaitEpoch", epochtime.EpochTime(0))
 	// methodGetBlock is the GetBlock method.
@@ -43,9 +45,15 @@ var (
 	methodGetGenesisDocument = serviceName.NewMethod("GetGenesisDocument", nil)
 	// methodGetStatus is the GetStatus method.
 	methodGetStatus = serviceName.NewMethod("GetStatus", nil)
+	// methodGetStateAttestation is the GetStateAttestation method.
+	methodGetStateAttestation = serviceName.NewMethod("GetStateAttestation", int64(0))
+	// methodGetAddressBook is the GetAddressBook method.
+	methodGetAddressBook = serviceName.NewMethod("GetAddressBook", nil)
 
 	// methodWatchBlocks is the WatchBlocks method.
 	methodWatchBlocks = serviceName.NewMethod("WatchBlocks", nil)
+	// methodWatchFeeStatistics is the WatchFeeStatistics method.
+	methodWatchFeeStatistics = serviceName.NewMethod("WatchFeeStatistics", nil)
 
 	// methodGetLightBlock is the GetLightBlock method.
 	methodGetLightBlock = lightServiceName.NewMethod("GetLightBlock", int64(0))
@@ -87,6 +95,10 @@ var (
 				MethodName: methodGetEpoch.ShortName(),
 				Handler:    handlerGetEpoch,
 			},
+			{
+				MethodName: methodGetEpochBlock.ShortName(),
+				Handler:    handlerGetEpochBlock,
+			},
 			{
 				MethodName: methodWaitEpoch.ShortName(),
 				Handler:    handlerWaitEpoch,
@@ -115,6 +127,14 @@ var (
 				MethodName: methodGetStatus.ShortName(),
 				Handler:    handlerGetStatus,
 			},
+			{
+				MethodName: methodGetStateAttestation.ShortName(),
+				Handler:    handlerGetStateAttestation,
+			},
+			{
+				MethodName: methodGetAddressBook.ShortName(),
+				Handler:    handlerGetAddressBook,
+			},
 		},
 		Streams: []grpc.StreamDesc{
 			{
@@ -122,6 +142,11 @@ var (
 				Handler:       handlerWatchBlocks,
 				ServerStreams: true,
 			},
+			{
+				StreamName:    methodWatchFeeStatistics.ShortName(),
+				Handler:       handlerWatchFeeStatistics,
+				ServerStreams: true,
+			},
 		},
 	}
 
@@ -277,6 +302,29 @@ func handlerGetEpoch( // nolint: golint
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerGetEpochBlock( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var epoch epochtime.EpochTime
+	if err := dec(&epoch); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientBackend).GetEpochBlock(ctx, epoch)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetEpochBlock.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientBackend).GetEpochBlock(ctx, req.(epochtime.EpochTime))
+	}
+	return interceptor(ctx, epoch, info, handler)
+}
+
 func handlerWaitEpoch( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -426,6 +474,48 @@ func handlerGetStatus( // nolint: golint
 	return interceptor(ctx, nil, info, handler)
 }
 
+func handlerGetStateAttestation( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var height int64
+	if err := dec(&height); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientBackend).GetStateAttestation(ctx, height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetStateAttestation.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientBackend).GetStateAttestation(ctx, req.(int64))
+	}
+	return interceptor(ctx, height, info, handler)
+}
+
+func handlerGetAddressBook( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return srv.(ClientBackend).GetAddressBook(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetAddressBook.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientBackend).GetAddressBook(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
 func handlerWatchBlocks(srv interface{}, stream grpc.ServerStream) error {
 	if err := stream.RecvMsg(nil); err != nil {
 		return err
@@ -454,6 +544,34 @@ func handlerWatchBlocks(srv interface{}, stream grpc.ServerStream) error {
 	}
 }
 
+func handlerWatchFeeStatistics(srv interface{}, stream grpc.ServerStream) error {
+	if err := stream.RecvMsg(nil); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(ClientBackend).WatchFeeStatistics(ctx)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case stats, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.SendMsg(stats); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 func handlerGetLightBlock( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -740,6 +858,14 @@ func (c *consensusClient) GetEpoch(ctx context.Context, height int64) (epochtime
 	return epoch, nil
 }
 
+func (c *consensusClient) GetEpochBlock(ctx context.Context, epoch epochtime.EpochTime) (int64, error) {
+	var height int64
+	if err := c.conn.Invoke(ctx, methodGetEpochBlock.FullName(), epoch, &height); err != nil {
+		return 0, err
+	}
+	return height, nil
+}
+
 func (c *consensusClient) GetBlock(ctx context.Context, height int64) (*Block, error) {
 	var rsp Block
 	if err := c.conn.Invoke(ctx, methodGetBlock.FullName(), height, &rsp); err != nil {
@@ -788,6 +914,22 @@ func (c *consensusClient) GetStatus(ctx context.Context) (*Status, error) {
 	return &rsp, nil
 }
 
+func (c *consensusClient) GetStateAttestation(ctx context.Context, height int64) (*StateAttestation, error) {
+	var rsp StateAttestation
+	if err := c.conn.Invoke(ctx, methodGetStateAttestation.FullName(), height, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *consensusClient) GetAddressBook(ctx context.Context) (*AddressBook, error) {
+	var rsp AddressBook
+	if err := c.conn.Invoke(ctx, methodGetAddressBook.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *consensusClient) WatchBlocks(ctx context.Context) (<-chan *Block, pubsub.ClosableSubscription, error) {
 	ctx, sub := pubsub.NewContextSubscription(ctx)
 
@@ -823,6 +965,41 @@ func (c *consensusClient) WatchBlocks(ctx context.Context) (<-chan *Block, pubsu
 	return ch, sub, nil
 }
 
+func (c *consensusClient) WatchFeeStatistics(ctx context.Context) (<-chan *FeeStatistics, pubsub.ClosableSubscription, error) {
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[1], methodWatchFeeStatistics.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(nil); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *FeeStatistics)
+	go func() {
+		defer close(ch)
+
+		for {
+			var stats FeeStatistics
+			if serr := stream.RecvMsg(&stats); serr != nil {
+				return
+			}
+
+			select {
+			case ch <- &stats:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
 // NewConsensusClient creates a new gRPC consensus client service.
 func NewConsensusClient(c *grpc.ClientConn) ClientBackend {
 	return &consensusClient{
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+)
+
+// SignerHandoffSignatureContext is the context used for signing consensus signer handoffs.
+var SignerHandoffSignatureContext = signature.NewContext(
+	"oasis-core/consensus: signer handoff",
+	signature.WithChainSeparation(),
+)
+
+// SignerHandoff is a handoff of the consensus signing role between two running nodes that use
+// the same consensus key (e.g., a primary and a standby validator node of the same entity).
+//
+// The handoff carries the double-sign guard state of the releasing node so that the acquiring
+// node never signs a consensus message conflicting with one already signed by the releasing node.
+type SignerHandoff struct {
+	// Sequence is the handoff sequence number. A node only acquires a handoff with a sequence
+	// number greater than the one of any handoff it has previously acquired or released.
+	Sequence uint64 `json:"sequence"`
+
+	// Height is the height of the last consensus message signed by the releasing node.
+	Height int64 `json:"height"`
+	// Round is the round of the last consensus message signed by the releasing node.
+	Round int32 `json:"round"`
+	// Step is the step of the last consensus message signed by the releasing node.
+	Step int8 `json:"step"`
+
+	// SignBytes are the bytes of the last consensus message signed by the releasing node.
+	SignBytes []byte `json:"sign_bytes,omitempty"`
+	// Signature is the signature of the last consensus message signed by the releasing node.
+	Signature []byte `json:"signature,omitempty"`
+}
+
+// SignedSignerHandoff is a consensus signer handoff signed by the consensus key.
+type SignedSignerHandoff struct {
+	signature.Signed
+}
+
+// Open first verifies the blob signature and then unmarshals the blob.
+func (s *SignedSignerHandoff) Open(handoff *SignerHandoff) error { // nolint: interfacer
+	return s.Signed.Open(SignerHandoffSignatureContext, handoff)
+}
+
+// SignSignerHandoff serializes the signer handoff and signs the result.
+func SignSignerHandoff(signer signature.Signer, handoff *SignerHandoff) (*SignedSignerHandoff, error) {
+	signed, err := signature.SignSigned(signer, SignerHandoffSignatureContext, handoff)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedSignerHandoff{
+		Signed: *signed,
+	}, nil
+}
+
+// SignerStatus is the status of the local consensus signer.
+type SignerStatus struct {
+	// Standby is true iff the local consensus signer is in standby and does not sign any
+	// consensus messages.
+	Standby bool `json:"standby"`
+
+	// Sequence is the sequence number of the last acquired or released signer handoff.
+	Sequence uint64 `json:"sequence"`
+
+	// Height is the height of the last signed consensus message.
+	Height int64 `json:"height"`
+	// Round is the round of the last signed consensus message.
+	Round int32 `json:"round"`
+	// Step is the step of the last signed consensus message.
+	Step int8 `json:"step"`
+}
+
+// SignerManager is the interface for handing off the consensus signing role between two
+// running nodes that use the same consensus key.
+type SignerManager interface {
+	// GetSignerStatus returns the status of the local consensus signer.
+	GetSignerStatus(ctx context.Context) (*SignerStatus, error)
+
+	// ReleaseSigner puts the local consensus signer into standby and returns a signed handoff
+	// that can be used to activate the signer on another node.
+	//
+	// After this method returns, the local node will not sign any further consensus messages
+	// until it acquires a newer handoff.
+	ReleaseSigner(ctx context.Context) (*SignedSignerHandoff, error)
+
+	// AcquireSigner verifies the given handoff, updates the local double-sign guard state and
+	// activates the local consensus signer.
+	AcquireSigner(ctx context.Context, handoff *SignedSignerHandoff) error
+}
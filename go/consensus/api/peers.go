@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"time"
+)
+
+// PeerStatus is the status of a connected consensus peer.
+type PeerStatus struct {
+	// ID is the peer's P2P node ID.
+	ID string `json:"id"`
+	// Address is the peer's remote address.
+	Address string `json:"address"`
+	// Moniker is the name the peer advertises.
+	Moniker string `json:"moniker,omitempty"`
+
+	// Outbound is true iff the connection was dialed by the local node.
+	Outbound bool `json:"outbound"`
+	// Persistent is true iff the peer is a persistent peer.
+	Persistent bool `json:"persistent"`
+
+	// ConnectedFor is the duration of the connection.
+	ConnectedFor time.Duration `json:"connected_for"`
+	// BytesSent is the number of bytes sent to the peer.
+	BytesSent int64 `json:"bytes_sent"`
+	// BytesReceived is the number of bytes received from the peer.
+	BytesReceived int64 `json:"bytes_received"`
+	// SendRate is the current send rate in bytes per second.
+	SendRate int64 `json:"send_rate"`
+	// ReceiveRate is the current receive rate in bytes per second.
+	ReceiveRate int64 `json:"receive_rate"`
+}
+
+// Peers is the overview of the consensus peers of the local node.
+type Peers struct {
+	// Connected are the currently connected peers.
+	Connected []*PeerStatus `json:"connected"`
+	// Banned are the IDs of peers banned at runtime.
+	Banned []string `json:"banned"`
+}
+
+// PeerManager is the interface for managing consensus peers at runtime.
+type PeerManager interface {
+	// GetPeers returns the overview of the consensus peers of the local node.
+	GetPeers(ctx context.Context) (*Peers, error)
+
+	// AddPersistentPeer adds a persistent peer of the form ID@ip:port and dials it.
+	AddPersistentPeer(ctx context.Context, address string) error
+
+	// BanPeer disconnects the peer with the given ID (if connected) and rejects any further
+	// connections from or to the peer.
+	BanPeer(ctx context.Context, id string) error
+
+	// UnbanPeer removes the ban on the peer with the given ID.
+	UnbanPeer(ctx context.Context, id string) error
+}
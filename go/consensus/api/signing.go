@@ -0,0 +1,42 @@
+package api
+
+// SigningStatus is the signing performance overview of the local validator.
+type SigningStatus struct {
+	// ProposedBlocks is the number of blocks proposed by the local validator since the node
+	// was started.
+	ProposedBlocks uint64 `json:"proposed_blocks"`
+	// SignedBlocks is the number of blocks that included a vote by the local validator since
+	// the node was started.
+	SignedBlocks uint64 `json:"signed_blocks"`
+	// MissedBlocks is the number of blocks that did not include a vote by the local validator
+	// while it was part of the validator set since the node was started.
+	MissedBlocks uint64 `json:"missed_blocks"`
+
+	// LastSignedHeight is the height of the last block signed by the local validator.
+	LastSignedHeight int64 `json:"last_signed_height,omitempty"`
+	// LastMissedHeight is the height of the last block missed by the local validator.
+	LastMissedHeight int64 `json:"last_missed_height,omitempty"`
+
+	// WindowSize is the size (in blocks) of the sliding window used to track missed blocks.
+	WindowSize uint64 `json:"window_size"`
+	// WindowMissedBlocks is the number of blocks missed by the local validator in the
+	// current window.
+	WindowMissedBlocks uint64 `json:"window_missed_blocks"`
+	// AlertThreshold is the number of blocks missed in the current window at which signing
+	// alerts are raised. Zero means that alerting is disabled.
+	AlertThreshold uint64 `json:"alert_threshold,omitempty"`
+	// Alerting is true iff the number of blocks missed in the current window has reached the
+	// alert threshold.
+	Alerting bool `json:"alerting,omitempty"`
+}
+
+// SigningAlertHandler is the interface for handlers of local validator signing alerts.
+type SigningAlertHandler interface {
+	// MissedBlocksAlert is called when the number of blocks missed by the local validator in
+	// the current window reaches the alert threshold.
+	MissedBlocksAlert(status *SigningStatus)
+
+	// MissedBlocksRecovered is called when the number of blocks missed by the local validator
+	// in the current window drops back below the alert threshold.
+	MissedBlocksRecovered(status *SigningStatus)
+}
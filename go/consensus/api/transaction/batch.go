@@ -0,0 +1,117 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+)
+
+// MaxBatchCalls is the maximum number of calls in a batch transaction.
+const MaxBatchCalls = 16
+
+var (
+	// ErrInvalidBatch is the error returned when a batch transaction is invalid.
+	ErrInvalidBatch = errors.New(moduleName, 2, "transaction: invalid batch")
+
+	// MethodBatch is the method name for batch transactions.
+	MethodBatch = NewMethodName("consensus", "Batch", Batch{})
+
+	_ prettyprint.PrettyPrinter = (*Batch)(nil)
+)
+
+// Call is a method call that is part of a batch transaction.
+type Call struct {
+	// Method is the method that should be called.
+	Method MethodName `json:"method"`
+	// Body is the method call body.
+	Body cbor.RawMessage `json:"body,omitempty"`
+}
+
+// Batch is the body of a batch transaction.
+//
+// The calls of a batch are executed in order on behalf of the transaction signer. Either all calls
+// succeed or the effects of none of them are applied. Gas used by all calls is accounted together
+// and is paid for by the fee of the batch transaction.
+type Batch struct {
+	// Calls are the calls that should be executed.
+	Calls []Call `json:"calls"`
+}
+
+// SanityCheck performs a basic sanity check on the batch.
+func (b *Batch) SanityCheck() error {
+	switch n := len(b.Calls); {
+	case n == 0:
+		return fmt.Errorf("%w: no calls", ErrInvalidBatch)
+	case n > MaxBatchCalls:
+		return fmt.Errorf("%w: too many calls (%d > %d)", ErrInvalidBatch, n, MaxBatchCalls)
+	}
+
+	for i, call := range b.Calls {
+		if err := call.Method.SanityCheck(); err != nil {
+			return fmt.Errorf("%w: call %d: %s", ErrInvalidBatch, i, err)
+		}
+		if call.Method == MethodBatch {
+			return fmt.Errorf("%w: call %d: nested batches are not allowed", ErrInvalidBatch, i)
+		}
+	}
+	return nil
+}
+
+// PrettyPrint writes a pretty-printed representation of the batch to the given writer.
+func (b Batch) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	for i, call := range b.Calls {
+		fmt.Fprintf(w, "%sCall %d:\n", prefix, i)
+		fmt.Fprintf(w, "%s  Method: %s\n", prefix, call.Method)
+		fmt.Fprintf(w, "%s  Body:\n", prefix)
+		Transaction{Method: call.Method, Body: call.Body}.PrettyPrintBody(ctx, prefix+"    ", w)
+	}
+}
+
+// PrettyType returns a representation of the type that can be used for pretty printing.
+func (b Batch) PrettyType() (interface{}, error) {
+	calls := make([]*PrettyTransaction, 0, len(b.Calls))
+	for i, call := range b.Calls {
+		tx := Transaction{Method: call.Method, Body: call.Body}
+		pt, err := tx.PrettyType()
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w", i, err)
+		}
+		calls = append(calls, pt.(*PrettyTransaction))
+	}
+	return calls, nil
+}
+
+// NewCall creates a new batch call.
+func NewCall(method MethodName, body interface{}) Call {
+	var rawBody []byte
+	if body != nil {
+		rawBody = cbor.Marshal(body)
+	}
+
+	return Call{
+		Method: method,
+		Body:   cbor.RawMessage(rawBody),
+	}
+}
+
+// NewBatchTransaction creates a new batch transaction executing the given calls.
+func NewBatchTransaction(nonce uint64, fee *Fee, calls []Call) *Transaction {
+	return NewTransaction(nonce, fee, MethodBatch, &Batch{Calls: calls})
+}
+
+// NewBatchTransactionFrom creates a new batch transaction executing the method calls of the given
+// transactions. The nonces and fees of the given transactions are ignored.
+func NewBatchTransactionFrom(nonce uint64, fee *Fee, txs []*Transaction) *Transaction {
+	calls := make([]Call, 0, len(txs))
+	for _, tx := range txs {
+		calls = append(calls, Call{
+			Method: tx.Method,
+			Body:   tx.Body,
+		})
+	}
+	return NewBatchTransaction(nonce, fee, calls)
+}
@@ -0,0 +1,32 @@
+package transaction
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchSanityCheck(t *testing.T) {
+	require := require.New(t)
+
+	call := Call{Method: MethodName("test.Method")}
+
+	var batch Batch
+	require.True(errors.Is(batch.SanityCheck(), ErrInvalidBatch), "empty batch should be invalid")
+
+	batch.Calls = []Call{call, call}
+	require.NoError(batch.SanityCheck(), "valid batch should pass sanity check")
+
+	batch.Calls = make([]Call, MaxBatchCalls+1)
+	for i := range batch.Calls {
+		batch.Calls[i] = call
+	}
+	require.True(errors.Is(batch.SanityCheck(), ErrInvalidBatch), "batch with too many calls should be invalid")
+
+	batch.Calls = []Call{call, {Method: MethodName("")}}
+	require.True(errors.Is(batch.SanityCheck(), ErrInvalidBatch), "batch with an invalid method should be invalid")
+
+	batch.Calls = []Call{call, {Method: MethodBatch}}
+	require.True(errors.Is(batch.SanityCheck(), ErrInvalidBatch), "nested batches should be invalid")
+}
@@ -6,11 +6,36 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 )
 
 const keySeedPrefix = "oasis-core test vectors: "
 
+// ProtocolVersions are the protocol versions a set of test vectors was generated for.
+type ProtocolVersions struct {
+	ConsensusProtocol        version.Version `json:"consensus_protocol"`
+	RuntimeCommitteeProtocol version.Version `json:"runtime_committee_protocol"`
+	RuntimeHostProtocol      version.Version `json:"runtime_host_protocol"`
+}
+
+// CurrentProtocolVersions returns the protocol versions of this implementation.
+func CurrentProtocolVersions() ProtocolVersions {
+	return ProtocolVersions{
+		ConsensusProtocol:        version.ConsensusProtocol,
+		RuntimeCommitteeProtocol: version.RuntimeCommitteeProtocol,
+		RuntimeHostProtocol:      version.RuntimeHostProtocol,
+	}
+}
+
+// VersionedTestVectors is a set of test vectors, versioned by the protocol
+// versions they were generated for.
+type VersionedTestVectors struct {
+	ProtocolVersions ProtocolVersions            `json:"protocol_versions"`
+	Transactions     []TestVector                `json:"transactions,omitempty"`
+	SignedStructures []SignedStructureTestVector `json:"signed_structures,omitempty"`
+}
+
 // TestVector is a staking message test vector.
 type TestVector struct {
 	Kind             string                        `json:"kind"`
@@ -42,10 +67,11 @@ func MakeTestVectorWithSigner(kind string, tx *transaction.Transaction, signer s
 		panic(err)
 	}
 
-	bodyType := tx.Method.BodyType()
-	v := reflect.New(reflect.TypeOf(bodyType)).Interface()
-	if err = cbor.Unmarshal(tx.Body, v); err != nil {
-		panic(err)
+	if bodyType := tx.Method.BodyType(); bodyType != nil {
+		v := reflect.New(reflect.TypeOf(bodyType)).Interface()
+		if err = cbor.Unmarshal(tx.Body, v); err != nil {
+			panic(err)
+		}
 	}
 
 	prettyTx, err := tx.PrettyType()
@@ -65,3 +91,53 @@ func MakeTestVectorWithSigner(kind string, tx *transaction.Transaction, signer s
 		SignerPublicKey:  signer.Public(),
 	}
 }
+
+// SignedStructureTestVector is a signed structure test vector.
+type SignedStructureTestVector struct {
+	Kind             string              `json:"kind"`
+	SignatureContext string              `json:"signature_context"`
+	Structure        interface{}         `json:"structure"`
+	EncodedStructure []byte              `json:"encoded_structure"`
+	Signature        signature.Signature `json:"signature"`
+	Valid            bool                `json:"valid"`
+	SignerPrivateKey []byte              `json:"signer_private_key"`
+	SignerPublicKey  signature.PublicKey `json:"signer_public_key"`
+}
+
+// MakeSignedStructureTestVector generates a new test vector from a structure signed using the
+// given signature context.
+func MakeSignedStructureTestVector(kind string, context signature.Context, v interface{}) SignedStructureTestVector {
+	signer := memorySigner.NewTestSigner(keySeedPrefix + kind)
+	return MakeSignedStructureTestVectorWithSigner(kind, context, v, signer)
+}
+
+// MakeSignedStructureTestVectorWithSigner generates a new test vector from a structure signed
+// using the given signature context and a specific signer.
+func MakeSignedStructureTestVectorWithSigner(
+	kind string,
+	context signature.Context,
+	v interface{},
+	signer signature.Signer,
+) SignedStructureTestVector {
+	encoded := cbor.Marshal(v)
+	sig, err := signature.Sign(signer, context, encoded)
+	if err != nil {
+		panic(err)
+	}
+
+	sigCtx, err := signature.PrepareSignerContext(context)
+	if err != nil {
+		panic(err)
+	}
+
+	return SignedStructureTestVector{
+		Kind:             kind,
+		SignatureContext: string(sigCtx),
+		Structure:        v,
+		EncodedStructure: encoded,
+		Signature:        *sig,
+		Valid:            true,
+		SignerPrivateKey: signer.(signature.UnsafeSigner).UnsafeBytes(),
+		SignerPublicKey:  signer.Public(),
+	}
+}
@@ -14,6 +14,13 @@ var (
 		},
 		[]string{"backend"},
 	)
+	MissedBlocks = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_consensus_missed_blocks",
+			Help: "Number of blocks missed by the node while being a validator.",
+		},
+		[]string{"backend"},
+	)
 	ProposedBlocks = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "oasis_consensus_proposed_blocks",
@@ -24,6 +31,7 @@ var (
 
 	consensusCollectors = []prometheus.Collector{
 		SignedBlocks,
+		MissedBlocks,
 		ProposedBlocks,
 	}
 
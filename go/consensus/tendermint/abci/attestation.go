@@ -0,0 +1,180 @@
+package abci
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+)
+
+// stateAttestationsFilename is the name of the file (in the ABCI state data
+// directory) used for persisting state attestations.
+const stateAttestationsFilename = "state-attestations.cbor"
+
+// stateAttestor periodically attests to the full ABCI application state.
+//
+// Besides the state root, each attestation includes a digest of all events
+// emitted since the previous attestation as events are not committed to by
+// the application state hash.
+type stateAttestor struct {
+	sync.RWMutex
+
+	interval uint64
+	numKept  uint64
+	path     string
+
+	fromHeight   int64
+	eventsDigest hash.Hash
+
+	attestations []*consensus.StateAttestation
+
+	logger *logging.Logger
+}
+
+func (sa *stateAttestor) enabled() bool {
+	return sa.interval > 0
+}
+
+// recordEvents folds the events emitted by the application into the current
+// events digest.
+func (sa *stateAttestor) recordEvents(events []types.Event) {
+	if !sa.enabled() {
+		return
+	}
+
+	sa.eventsDigest = hash.NewFromBytes(sa.eventsDigest[:], cbor.Marshal(events))
+}
+
+// commit makes a new attestation in case an attestation is due at the given
+// (just committed) height.
+func (sa *stateAttestor) commit(height int64, stateRoot storage.Root) {
+	if !sa.enabled() {
+		return
+	}
+
+	if sa.fromHeight == 0 {
+		sa.fromHeight = height
+	}
+	if uint64(height)%sa.interval != 0 {
+		return
+	}
+
+	att := &consensus.StateAttestation{
+		Height:       height,
+		StateRoot:    stateRoot.Hash,
+		StateVersion: stateRoot.Version,
+		AppVersion:   version.TendermintAppVersion,
+		FromHeight:   sa.fromHeight,
+		EventsDigest: sa.eventsDigest,
+	}
+	sa.fromHeight = 0
+	sa.eventsDigest.Empty()
+
+	sa.Lock()
+	sa.attestations = append(sa.attestations, att)
+	if uint64(len(sa.attestations)) > sa.numKept {
+		sa.attestations = sa.attestations[uint64(len(sa.attestations))-sa.numKept:]
+	}
+	err := sa.persistLocked()
+	sa.Unlock()
+
+	if err != nil {
+		sa.logger.Error("failed to persist state attestations",
+			"err", err,
+		)
+	}
+
+	sa.logger.Debug("made state attestation",
+		"height", att.Height,
+		"from_height", att.FromHeight,
+		"attestation_hash", att.Hash(),
+	)
+}
+
+// get returns the attestation made at the given height.
+func (sa *stateAttestor) get(height int64) (*consensus.StateAttestation, error) {
+	if !sa.enabled() {
+		return nil, consensus.ErrUnsupported
+	}
+
+	sa.RLock()
+	defer sa.RUnlock()
+
+	if len(sa.attestations) == 0 {
+		return nil, consensus.ErrStateAttestationNotFound
+	}
+	if height == consensus.HeightLatest {
+		return sa.attestations[len(sa.attestations)-1], nil
+	}
+	for _, att := range sa.attestations {
+		if att.Height == height {
+			return att, nil
+		}
+	}
+	return nil, consensus.ErrStateAttestationNotFound
+}
+
+func (sa *stateAttestor) persistLocked() error {
+	if sa.path == "" {
+		return nil
+	}
+
+	tmpPath := sa.path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, cbor.Marshal(sa.attestations), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, sa.path)
+}
+
+func (sa *stateAttestor) load() error {
+	if sa.path == "" {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(sa.path)
+	switch {
+	case err == nil:
+	case os.IsNotExist(err):
+		return nil
+	default:
+		return err
+	}
+
+	if err = cbor.Unmarshal(data, &sa.attestations); err != nil {
+		return fmt.Errorf("malformed state attestations: %w", err)
+	}
+	return nil
+}
+
+func newStateAttestor(cfg *ApplicationConfig) (*stateAttestor, error) {
+	sa := &stateAttestor{
+		interval: cfg.StateAttestationInterval,
+		numKept:  cfg.StateAttestationNumKept,
+		logger:   logging.GetLogger("abci-mux/attestation"),
+	}
+	sa.eventsDigest.Empty()
+	if sa.numKept == 0 {
+		sa.numKept = 1
+	}
+	if !sa.enabled() {
+		return sa, nil
+	}
+	if !cfg.MemoryOnlyStorage && !cfg.ReadOnlyStorage {
+		sa.path = filepath.Join(cfg.DataDir, stateAttestationsFilename)
+	}
+
+	if err := sa.load(); err != nil {
+		return nil, fmt.Errorf("state: failed to load state attestations: %w", err)
+	}
+	return sa, nil
+}
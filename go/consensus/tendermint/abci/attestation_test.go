@@ -0,0 +1,94 @@
+package abci
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+)
+
+func TestStateAttestor(t *testing.T) {
+	require := require.New(t)
+
+	dataDir, err := ioutil.TempDir("", "oasis-abci-attestation-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir)
+
+	cfg := &ApplicationConfig{
+		DataDir:                  dataDir,
+		StateAttestationInterval: 5,
+		StateAttestationNumKept:  2,
+	}
+	sa, err := newStateAttestor(cfg)
+	require.NoError(err, "newStateAttestor")
+
+	_, err = sa.get(consensus.HeightLatest)
+	require.Equal(consensus.ErrStateAttestationNotFound, err, "no attestations should be available initially")
+
+	events := []types.Event{{Type: "test", Attributes: []types.EventAttribute{{Key: []byte("key"), Value: []byte("value")}}}}
+	commitBlocks := func(from, to int64) {
+		for height := from; height <= to; height++ {
+			sa.recordEvents(events)
+			sa.commit(height, storage.Root{Version: uint64(height), Hash: hash.NewFromBytes([]byte{byte(height)})})
+		}
+	}
+
+	// Start in the middle of an interval.
+	commitBlocks(3, 10)
+	att5, err := sa.get(5)
+	require.NoError(err, "get")
+	require.EqualValues(5, att5.Height)
+	require.EqualValues(3, att5.FromHeight, "partial interval should be reflected")
+	att10, err := sa.get(consensus.HeightLatest)
+	require.NoError(err, "get")
+	require.EqualValues(10, att10.Height)
+	require.EqualValues(6, att10.FromHeight)
+	require.EqualValues(10, att10.StateVersion)
+	require.NotEqual(att5.EventsDigest, att10.EventsDigest, "events digest should cover a different number of events")
+
+	// Another node with the same events for a full interval should produce the same attestation.
+	other, err := newStateAttestor(&ApplicationConfig{StateAttestationInterval: 5, StateAttestationNumKept: 2, MemoryOnlyStorage: true})
+	require.NoError(err, "newStateAttestor")
+	for height := int64(6); height <= 10; height++ {
+		other.recordEvents(events)
+		other.commit(height, storage.Root{Version: uint64(height), Hash: hash.NewFromBytes([]byte{byte(height)})})
+	}
+	otherAtt10, err := other.get(10)
+	require.NoError(err, "get")
+	require.Equal(att10.Hash(), otherAtt10.Hash(), "attestations of identical state should match")
+
+	// Diverging events should result in a different attestation.
+	other.recordEvents(nil)
+	for height := int64(11); height <= 15; height++ {
+		other.commit(height, storage.Root{Version: uint64(height), Hash: hash.NewFromBytes([]byte{byte(height)})})
+	}
+	commitBlocks(11, 15)
+	att15, err := sa.get(15)
+	require.NoError(err, "get")
+	otherAtt15, err := other.get(15)
+	require.NoError(err, "get")
+	require.NotEqual(att15.Hash(), otherAtt15.Hash(), "attestations of diverging events should differ")
+
+	// Only the configured number of attestations should be kept.
+	_, err = sa.get(5)
+	require.Equal(consensus.ErrStateAttestationNotFound, err, "old attestations should be pruned")
+
+	// Attestations should be persisted.
+	sa, err = newStateAttestor(cfg)
+	require.NoError(err, "newStateAttestor")
+	att, err := sa.get(15)
+	require.NoError(err, "get after reload")
+	require.Equal(att15.Hash(), att.Hash())
+
+	// Disabled attestor should not support queries.
+	sa, err = newStateAttestor(&ApplicationConfig{})
+	require.NoError(err, "newStateAttestor")
+	_, err = sa.get(consensus.HeightLatest)
+	require.Equal(consensus.ErrUnsupported, err)
+}
@@ -0,0 +1,68 @@
+package abci
+
+import (
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+)
+
+// feeStatsCollector collects per-block transaction fee statistics.
+type feeStatsCollector struct {
+	current  consensus.FeeStatistics
+	notifier *pubsub.Broker
+}
+
+// isFeeRejection returns true iff the given error indicates that a transaction has been rejected
+// due to fees.
+func isFeeRejection(err error) bool {
+	return errors.Is(err, transaction.ErrInsufficientFeeBalance) || errors.Is(err, transaction.ErrGasPriceTooLow)
+}
+
+func (fs *feeStatsCollector) recordFee(ctx *api.Context, fee *transaction.Fee) {
+	if ctx.Mode() != api.ContextDeliverTx || fee == nil {
+		return
+	}
+	_ = fs.current.FeeTotal.Add(&fee.Amount)
+}
+
+func (fs *feeStatsCollector) recordDeliverTx(ctx *api.Context, err error) {
+	fs.current.NumTxs++
+	fs.current.GasUsed += ctx.Gas().GasUsed()
+	fs.current.GasWanted += ctx.Gas().GasWanted()
+	if err != nil && isFeeRejection(err) {
+		fs.current.NumRejectedForFee++
+	}
+}
+
+// recordCheckTx records a transaction rejected by the local mempool. As such rejections are
+// specific to the local node, they are only tracked by a local metric and are not part of the
+// block statistics.
+func (fs *feeStatsCollector) recordCheckTx(err error) {
+	if isFeeRejection(err) {
+		checkTxRejectedForFee.Inc()
+	}
+}
+
+func (fs *feeStatsCollector) commit(height int64) {
+	stats := fs.current
+	stats.Height = height
+	fs.notifier.Broadcast(&stats)
+
+	fs.current = consensus.FeeStatistics{}
+}
+
+func (fs *feeStatsCollector) watch() (<-chan *consensus.FeeStatistics, pubsub.ClosableSubscription) {
+	sub := fs.notifier.Subscribe()
+	ch := make(chan *consensus.FeeStatistics)
+	sub.Unwrap(ch)
+
+	return ch, sub
+}
+
+func newFeeStatsCollector() *feeStatsCollector {
+	return &feeStatsCollector{
+		notifier: pubsub.NewBroker(false),
+	}
+}
@@ -0,0 +1,78 @@
+package abci
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+)
+
+func TestFeeStatsCollector(t *testing.T) {
+	require := require.New(t)
+
+	fs := newFeeStatsCollector()
+	ch, sub := fs.watch()
+	defer sub.Close()
+
+	now := time.Unix(1580461674, 0)
+	appState := api.NewMockApplicationState(&api.MockApplicationStateConfig{})
+
+	const testOp = transaction.Op("test")
+	deliverTx := func(mode api.ContextMode, gas transaction.Gas, fee uint64, err error) {
+		ctx := appState.NewContext(mode, now)
+		defer ctx.Close()
+
+		ctx.SetGasAccountant(api.NewGasAccountant(gas))
+		require.NoError(ctx.Gas().UseGas(1, testOp, transaction.Costs{testOp: gas / 2}), "UseGas")
+
+		fs.recordFee(ctx, &transaction.Fee{Amount: *quantity.NewFromUint64(fee), Gas: gas})
+		if mode == api.ContextDeliverTx {
+			fs.recordDeliverTx(ctx, err)
+		}
+	}
+
+	rejectedBefore := testutil.ToFloat64(checkTxRejectedForFee)
+
+	// Transactions checked by the local mempool should not be part of the block statistics.
+	fs.recordCheckTx(transaction.ErrGasPriceTooLow)
+	fs.recordCheckTx(fmt.Errorf("wrapped: %w", transaction.ErrInsufficientFeeBalance))
+	fs.recordCheckTx(fmt.Errorf("some other error"))
+	deliverTx(api.ContextCheckTx, 1000, 10, nil)
+
+	// Transactions included in the block.
+	deliverTx(api.ContextDeliverTx, 100, 1, nil)
+	deliverTx(api.ContextDeliverTx, 200, 2, transaction.ErrGasPriceTooLow)
+	deliverTx(api.ContextDeliverTx, 300, 3, fmt.Errorf("some other error"))
+	fs.commit(10)
+
+	require.EqualValues(2, testutil.ToFloat64(checkTxRejectedForFee)-rejectedBefore, "mempool fee rejections should be tracked locally")
+
+	var stats *consensus.FeeStatistics
+	select {
+	case stats = <-ch:
+	case <-time.After(time.Second):
+		require.FailNow("failed to receive fee statistics")
+	}
+	require.EqualValues(10, stats.Height)
+	require.EqualValues(3, stats.NumTxs, "only delivered transactions should be counted")
+	require.EqualValues(300, stats.GasUsed)
+	require.EqualValues(600, stats.GasWanted)
+	require.Equal(*quantity.NewFromUint64(6), stats.FeeTotal, "only fees of delivered transactions should be counted")
+	require.EqualValues(1, stats.NumRejectedForFee, "only delivered transactions rejected due to fees should be counted")
+
+	// Statistics should be reset for the next block.
+	fs.commit(11)
+	select {
+	case stats = <-ch:
+	case <-time.After(time.Second):
+		require.FailNow("failed to receive fee statistics")
+	}
+	require.Equal(&consensus.FeeStatistics{Height: 11}, stats, "statistics should be reset after commit")
+}
@@ -56,8 +56,15 @@ var (
 			Help: "Total size of the ABCI database (MiB).",
 		},
 	)
+	checkTxRejectedForFee = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "oasis_abci_checktx_rejected_for_fee",
+			Help: "Number of transactions rejected by the local mempool due to fees.",
+		},
+	)
 	abciCollectors = []prometheus.Collector{
 		abciSize,
+		checkTxRejectedForFee,
 	}
 
 	metricsOnce sync.Once
@@ -84,8 +91,19 @@ type ApplicationConfig struct { // nolint: maligned
 	// ReadOnlyStorage forces read-only access for the state storage.
 	ReadOnlyStorage bool
 
+	// VerifyStateOnStartup verifies the consistency of the latest state root when opening the
+	// state storage.
+	VerifyStateOnStartup bool
+
 	// InitialHeight is the height of the initial block.
 	InitialHeight uint64
+
+	// StateAttestationInterval is the interval (in blocks) at which state attestations are made.
+	// Zero disables state attestations.
+	StateAttestationInterval uint64
+
+	// StateAttestationNumKept is the number of most recent state attestations to keep.
+	StateAttestationNumKept uint64
 }
 
 // ApplicationServer implements a tendermint ABCI application + socket server,
@@ -178,6 +196,16 @@ func (a *ApplicationServer) WatchInvalidatedTx(txHash hash.Hash) (<-chan error,
 	return a.mux.watchInvalidatedTx(txHash)
 }
 
+// WatchFeeStatistics returns a stream of per-block transaction fee statistics.
+func (a *ApplicationServer) WatchFeeStatistics() (<-chan *consensus.FeeStatistics, pubsub.ClosableSubscription) {
+	return a.mux.feeStats.watch()
+}
+
+// GetStateAttestation returns the state attestation made at the given height.
+func (a *ApplicationServer) GetStateAttestation(height int64) (*consensus.StateAttestation, error) {
+	return a.mux.attestor.get(height)
+}
+
 // EstimateGas calculates the amount of gas required to execute the given transaction.
 func (a *ApplicationServer) EstimateGas(caller signature.PublicKey, tx *transaction.Transaction) (transaction.Gas, error) {
 	return a.mux.EstimateGas(caller, tx)
@@ -230,6 +258,9 @@ type abciMux struct {
 	// debugExpiringTxs maps transaction hashes to the time at which they were created. This is only
 	// used in case CheckTx is disabled (for debug purposes only).
 	debugExpiringTxs map[hash.Hash]time.Time
+
+	feeStats *feeStatsCollector
+	attestor *stateAttestor
 }
 
 type invalidatedTxSubscription struct {
@@ -479,6 +510,7 @@ func (mux *abciMux) BeginBlock(req types.RequestBeginBlock) types.ResponseBeginB
 			}
 		}
 	}
+	mux.attestor.recordEvents(response.Events)
 
 	return response
 }
@@ -537,6 +569,7 @@ func (mux *abciMux) processTx(ctx *api.Context, tx *transaction.Transaction, txS
 			return err
 		}
 	}
+	mux.feeStats.recordFee(ctx, tx.Fee)
 
 	// Charge gas based on the size of the transaction.
 	params := mux.state.ConsensusParameters()
@@ -544,7 +577,51 @@ func (mux *abciMux) processTx(ctx *api.Context, tx *transaction.Transaction, txS
 		return err
 	}
 
-	// Route to correct handler.
+	if tx.Method == transaction.MethodBatch {
+		return mux.dispatchBatch(ctx, tx)
+	}
+	return mux.dispatchTx(ctx, tx)
+}
+
+// dispatchBatch dispatches all calls of a batch transaction in order. The effects of the calls are
+// only applied in case all of them succeed.
+func (mux *abciMux) dispatchBatch(ctx *api.Context, tx *transaction.Transaction) error {
+	var batch transaction.Batch
+	if err := cbor.Unmarshal(tx.Body, &batch); err != nil {
+		ctx.Logger().Error("malformed batch transaction",
+			"tx", tx,
+			"err", err,
+		)
+		return fmt.Errorf("%w: malformed batch: %s", transaction.ErrInvalidBatch, err)
+	}
+	if err := batch.SanityCheck(); err != nil {
+		return err
+	}
+
+	// Execute the calls in a child context so that state updates and events are discarded in
+	// case any of the calls fails. All calls share the gas accountant and transaction signer.
+	childCtx := ctx.NewChild()
+	defer childCtx.Close()
+
+	for i, call := range batch.Calls {
+		callTx := &transaction.Transaction{
+			Nonce:  tx.Nonce,
+			Fee:    tx.Fee,
+			Method: call.Method,
+			Body:   call.Body,
+		}
+		if err := mux.dispatchTx(childCtx, callTx); err != nil {
+			return fmt.Errorf("mux: batch call %d (%s) failed: %w", i, call.Method, err)
+		}
+	}
+
+	childCtx.CommitChild()
+
+	return nil
+}
+
+// dispatchTx routes the transaction to the application handling its method.
+func (mux *abciMux) dispatchTx(ctx *api.Context, tx *transaction.Transaction) error {
 	app := mux.appsByMethod[tx.Method]
 	if app == nil {
 		ctx.Logger().Error("unknown method",
@@ -669,6 +746,9 @@ func (mux *abciMux) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx {
 	if err := mux.executeTx(ctx, req.Tx); err != nil {
 		module, code := errors.Code(err)
 
+		if req.Type == types.CheckTxType_New {
+			mux.feeStats.recordCheckTx(err)
+		}
 		if req.Type == types.CheckTxType_Recheck {
 			// This is a re-check and the transaction just failed validation. Since
 			// the mempool provides no way of getting notified when a previously
@@ -701,7 +781,9 @@ func (mux *abciMux) DeliverTx(req types.RequestDeliverTx) types.ResponseDeliverT
 	ctx := mux.state.NewContext(api.ContextDeliverTx, mux.currentTime)
 	defer ctx.Close()
 
-	if err := mux.executeTx(ctx, req.Tx); err != nil {
+	err := mux.executeTx(ctx, req.Tx)
+	mux.feeStats.recordDeliverTx(ctx, err)
+	if err != nil {
 		if api.IsUnavailableStateError(err) {
 			// Make sure to not commit any transactions which include results based on unavailable
 			// and/or corrupted state -- doing so can further corrupt state.
@@ -711,6 +793,7 @@ func (mux *abciMux) DeliverTx(req types.RequestDeliverTx) types.ResponseDeliverT
 			panic(err)
 		}
 		module, code := errors.Code(err)
+		mux.attestor.recordEvents(ctx.GetEvents())
 
 		return types.ResponseDeliverTx{
 			Codespace: module,
@@ -721,6 +804,7 @@ func (mux *abciMux) DeliverTx(req types.RequestDeliverTx) types.ResponseDeliverT
 			GasUsed:   int64(ctx.Gas().GasUsed()),
 		}
 	}
+	mux.attestor.recordEvents(ctx.GetEvents())
 
 	return types.ResponseDeliverTx{
 		Code:      types.CodeTypeOK,
@@ -763,6 +847,7 @@ func (mux *abciMux) EndBlock(req types.RequestEndBlock) types.ResponseEndBlock {
 
 	// Update tags.
 	resp.Events = ctx.GetEvents()
+	mux.attestor.recordEvents(resp.Events)
 
 	// Update version to what we are actually running.
 	resp.ConsensusParamUpdates = &types.ConsensusParams{
@@ -789,6 +874,9 @@ func (mux *abciMux) Commit() types.ResponseCommit {
 		panic(err)
 	}
 
+	mux.feeStats.commit(mux.state.BlockHeight())
+	mux.attestor.commit(mux.state.BlockHeight(), mux.state.stateRoot)
+
 	mux.logger.Debug("Commit",
 		"block_height", mux.state.BlockHeight(),
 		"block_hash", hex.EncodeToString(mux.state.BlockHash()),
@@ -1091,6 +1179,10 @@ func newABCIMux(ctx context.Context, upgrader upgrade.Backend, cfg *ApplicationC
 	if err != nil {
 		return nil, err
 	}
+	attestor, err := newStateAttestor(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	mux := &abciMux{
 		logger:         logging.GetLogger("abci-mux"),
@@ -1099,6 +1191,8 @@ func newABCIMux(ctx context.Context, upgrader upgrade.Backend, cfg *ApplicationC
 		appsByName:     make(map[string]api.Application),
 		appsByMethod:   make(map[transaction.MethodName]api.Application),
 		lastBeginBlock: -1,
+		feeStats:       newFeeStatsCollector(),
+		attestor:       attestor,
 	}
 
 	// Create a map of expiring transactions if CheckTx is disabled (debug only).
@@ -13,6 +13,7 @@ import (
 	"github.com/tendermint/tendermint/abci/types"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
@@ -31,8 +32,22 @@ import (
 
 var _ api.ApplicationState = (*applicationState)(nil)
 
-// appStateDir is the subdirectory which contains ABCI state.
-const appStateDir = "abci-state"
+// AppStateDir is the subdirectory which contains ABCI state.
+const AppStateDir = "abci-state"
+
+const (
+	crashPointCommitBefore  = "abci.state.commit.before"
+	crashPointCommitAfter   = "abci.state.commit.after"
+	crashPointFinalizeAfter = "abci.state.finalize.after"
+)
+
+func init() {
+	crash.RegisterCrashPoints(
+		crashPointCommitBefore,
+		crashPointCommitAfter,
+		crashPointFinalizeAfter,
+	)
+}
 
 type applicationState struct { // nolint: maligned
 	logger *logging.Logger
@@ -295,14 +310,21 @@ func (s *applicationState) doCommit(now time.Time) (uint64, error) {
 	s.blockLock.Lock()
 	defer s.blockLock.Unlock()
 
+	crash.Here(crashPointCommitBefore)
+
 	_, stateRootHash, err := s.deliverTxTree.Commit(s.ctx, s.stateRoot.Namespace, s.stateRoot.Version+1)
 	if err != nil {
 		return 0, fmt.Errorf("failed to commit: %w", err)
 	}
+
+	crash.Here(crashPointCommitAfter)
+
 	if err = s.storage.NodeDB().Finalize(s.ctx, s.stateRoot.Version+1, []hash.Hash{stateRootHash}); err != nil {
 		return 0, fmt.Errorf("failed to finalize height %d: %w", s.stateRoot.Version+1, err)
 	}
 
+	crash.Here(crashPointFinalizeAfter)
+
 	s.stateRoot.Hash = stateRootHash
 	s.stateRoot.Version++
 
@@ -416,9 +438,23 @@ func (s *applicationState) pruneWorker() {
 	}
 }
 
+// StateStorageConfig returns the storage configuration of the internal ABCI state storage.
+func StateStorageConfig(cfg *ApplicationConfig) *storage.Config {
+	return &storage.Config{
+		Backend:          cfg.StorageBackend,
+		DB:               filepath.Join(cfg.DataDir, AppStateDir, storageDB.DefaultFileName(cfg.StorageBackend)),
+		MaxCacheSize:     64 * 1024 * 1024, // TODO: Make this configurable.
+		DiscardWriteLogs: true,
+		NoFsync:          true, // This is safe as Tendermint will replay on crash.
+		MemoryOnly:       cfg.MemoryOnlyStorage,
+		ReadOnly:         cfg.ReadOnlyStorage,
+		VerifyOnStartup:  cfg.VerifyStateOnStartup,
+	}
+}
+
 // InitStateStorage initializes the internal ABCI state storage.
 func InitStateStorage(ctx context.Context, cfg *ApplicationConfig) (storage.LocalBackend, storage.NodeDB, *storage.Root, error) {
-	baseDir := filepath.Join(cfg.DataDir, appStateDir)
+	baseDir := filepath.Join(cfg.DataDir, AppStateDir)
 	switch cfg.ReadOnlyStorage {
 	case true:
 		// Note: I'm not sure what badger does when given a path that
@@ -443,15 +479,7 @@ func InitStateStorage(ctx context.Context, cfg *ApplicationConfig) (storage.Loca
 		return nil, nil, nil, fmt.Errorf("unsupported storage backend: %s", cfg.StorageBackend)
 	}
 
-	db, err := storageDB.New(&storage.Config{
-		Backend:          cfg.StorageBackend,
-		DB:               filepath.Join(baseDir, storageDB.DefaultFileName(cfg.StorageBackend)),
-		MaxCacheSize:     64 * 1024 * 1024, // TODO: Make this configurable.
-		DiscardWriteLogs: true,
-		NoFsync:          true, // This is safe as Tendermint will replay on crash.
-		MemoryOnly:       cfg.MemoryOnlyStorage,
-		ReadOnly:         cfg.ReadOnlyStorage,
-	})
+	db, err := storageDB.New(StateStorageConfig(cfg))
 	if err != nil {
 		return nil, nil, nil, err
 	}
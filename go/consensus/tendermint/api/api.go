@@ -144,13 +144,34 @@ type BlockMeta struct {
 	Header *tmtypes.Header `json:"header"`
 	// LastCommit is the Tendermint last commit info.
 	LastCommit *tmtypes.Commit `json:"last_commit"`
+
+	// ValidatorsHash is the hash of the validator set that committed the block.
+	ValidatorsHash []byte `json:"validators_hash"`
+	// ProposerNodeID is the identifier of the node that proposed the block.
+	//
+	// It is only set in case the proposer could be resolved against the
+	// validator set.
+	ProposerNodeID *signature.PublicKey `json:"proposer_node_id,omitempty"`
+	// ProposerEntityID is the identifier of the entity controlling the node
+	// that proposed the block.
+	//
+	// It is only set in case the proposer could be resolved against the
+	// validator set.
+	ProposerEntityID *signature.PublicKey `json:"proposer_entity_id,omitempty"`
 }
 
 // NewBlock creates a new consensus.Block from a Tendermint block.
-func NewBlock(blk *tmtypes.Block) *consensus.Block {
+//
+// The proposer node descriptor may be nil in case it could not be resolved.
+func NewBlock(blk *tmtypes.Block, proposer *node.Node) *consensus.Block {
 	meta := BlockMeta{
-		Header:     &blk.Header,
-		LastCommit: blk.LastCommit,
+		Header:         &blk.Header,
+		LastCommit:     blk.LastCommit,
+		ValidatorsHash: blk.Header.ValidatorsHash,
+	}
+	if proposer != nil {
+		meta.ProposerNodeID = &proposer.ID
+		meta.ProposerEntityID = &proposer.EntityID
 	}
 	rawMeta := cbor.Marshal(meta)
 
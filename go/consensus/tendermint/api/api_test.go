@@ -4,9 +4,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
-
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
 )
 
 func TestServiceDescriptor(t *testing.T) {
@@ -22,3 +26,39 @@ func TestServiceDescriptor(t *testing.T) {
 	_, ok := <-sd.Queries()
 	require.False(ok, "query channel must be closed")
 }
+
+func TestNewBlock(t *testing.T) {
+	require := require.New(t)
+
+	blk := &tmtypes.Block{
+		Header: tmtypes.Header{
+			Height:          5,
+			ValidatorsHash:  []byte("validators hash"),
+			ProposerAddress: []byte("proposer address"),
+		},
+	}
+	proposer := &node.Node{
+		ID:       memorySigner.NewTestSigner("block meta test node").Public(),
+		EntityID: memorySigner.NewTestSigner("block meta test entity").Public(),
+	}
+
+	b := NewBlock(blk, proposer)
+	require.EqualValues(5, b.Height)
+	var meta BlockMeta
+	err := cbor.Unmarshal(b.Meta, &meta)
+	require.NoError(err, "Unmarshal")
+	require.EqualValues(blk.Header.ProposerAddress, meta.Header.ProposerAddress)
+	require.EqualValues(blk.Header.ValidatorsHash, meta.ValidatorsHash)
+	require.NotNil(meta.ProposerNodeID, "proposer node should be set")
+	require.Equal(proposer.ID, *meta.ProposerNodeID)
+	require.NotNil(meta.ProposerEntityID, "proposer entity should be set")
+	require.Equal(proposer.EntityID, *meta.ProposerEntityID)
+
+	// Unresolved proposer.
+	b = NewBlock(blk, nil)
+	meta = BlockMeta{}
+	err = cbor.Unmarshal(b.Meta, &meta)
+	require.NoError(err, "Unmarshal")
+	require.Nil(meta.ProposerNodeID, "proposer node should not be set")
+	require.Nil(meta.ProposerEntityID, "proposer entity should not be set")
+}
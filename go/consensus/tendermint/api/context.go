@@ -10,6 +10,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
 )
 
@@ -66,16 +67,26 @@ type Context struct {
 	events        []types.Event
 	gasAccountant GasAccountant
 
+	// persistentEvents are events that are propagated to the parent context even when this
+	// child context is discarded.
+	persistentEvents []types.Event
+
 	txSigner signature.PublicKey
 
+	isMessageExecution bool
+	callerAddress      staking.Address
+
 	appState      ApplicationState
-	state         mkvs.Tree
+	state         mkvs.KeyValueTree
 	blockHeight   int64
 	blockCtx      *BlockContext
 	initialHeight int64
 
 	stateCheckpoint *StateCheckpoint
 
+	parent       *Context
+	childOverlay mkvs.OverlayTree
+
 	logger *logging.Logger
 }
 
@@ -122,8 +133,20 @@ func (c *Context) Close() {
 			tree.Close()
 		}
 	}
+	if c.childOverlay != nil {
+		// Persistent events must be propagated even when the child context is discarded.
+		if len(c.persistentEvents) > 0 {
+			c.parent.events = append(c.parent.events, c.persistentEvents...)
+			c.parent.persistentEvents = append(c.parent.persistentEvents, c.persistentEvents...)
+		}
+
+		c.childOverlay.Close()
+		c.childOverlay = nil
+		c.parent = nil
+	}
 
 	c.events = nil
+	c.persistentEvents = nil
 	c.appState = nil
 	c.state = nil
 	c.blockCtx = nil
@@ -177,6 +200,37 @@ func (c *Context) SetTxSigner(txSigner signature.PublicKey) {
 	}
 }
 
+// CallerAddress returns the authenticated address of the caller.
+//
+// In case of runtime message execution, this is the address of the runtime account that emitted
+// the message. Otherwise it is the address of the transaction signer.
+//
+// In case the method is called on a non-transaction context which is not executing runtime
+// messages, this method will panic.
+func (c *Context) CallerAddress() staking.Address {
+	if c.isMessageExecution {
+		return c.callerAddress
+	}
+	return staking.NewAddress(c.TxSigner())
+}
+
+// IsMessageExecution returns true if this is a runtime message execution context.
+func (c *Context) IsMessageExecution() bool {
+	return c.isMessageExecution
+}
+
+// NewMessageExecutionChild creates a new child context for executing a runtime message on behalf
+// of the given caller address. No gas is charged for operations performed by runtime messages.
+//
+// The caller should commit the child context if the message has been successfully executed.
+func (c *Context) NewMessageExecutionChild(callerAddress staking.Address) *Context {
+	child := c.NewChild()
+	child.isMessageExecution = true
+	child.callerAddress = callerAddress
+	child.gasAccountant = NewNopGasAccountant()
+	return child
+}
+
 // IsInitChain returns true if this ia an init chain context.
 func (c *Context) IsInitChain() bool {
 	return c.mode == ContextInitChain
@@ -208,6 +262,18 @@ func (c *Context) EmitEvent(bld *EventBuilder) {
 	}
 }
 
+// EmitPersistentEvent emits an ABCI event for the current transaction that is retained even in
+// case any enclosing child context is discarded (e.g., because a later call in a batch failed).
+//
+// This should only be used for events that explain why a transaction has failed.
+func (c *Context) EmitPersistentEvent(bld *EventBuilder) {
+	if bld.Dirty() {
+		ev := bld.Event()
+		c.events = append(c.events, ev)
+		c.persistentEvents = append(c.persistentEvents, ev)
+	}
+}
+
 // GetEvents returns the ABCI event vector corresponding to the tags.
 func (c *Context) GetEvents() []types.Event {
 	return c.events
@@ -302,6 +368,65 @@ func (c *Context) StartCheckpoint() *StateCheckpoint {
 	return c.stateCheckpoint
 }
 
+// NewChild creates a new child context.
+//
+// The child context shares the mode, time, gas accountant, transaction signer and block context
+// with this context. State updates performed in the child context are isolated in an overlay over
+// this context's current state and emitted events are collected separately. Both are only
+// propagated to this context in case the child context is explicitly committed.
+//
+// Unlike checkpoints, child contexts can be used when the code running in the child context needs
+// to create its own checkpoints.
+//
+// The caller must make sure to call either Close or CommitChild on the child context, otherwise
+// this will leak resources. This context should not be used while the child context is open.
+func (c *Context) NewChild() *Context {
+	overlay := mkvs.NewOverlay(c.State())
+	child := &Context{
+		mode:          c.mode,
+		currentTime:   c.currentTime,
+		gasAccountant: c.gasAccountant,
+		txSigner:      c.txSigner,
+		appState:      c.appState,
+		state:         overlay,
+		blockHeight:   c.blockHeight,
+		blockCtx:      c.blockCtx,
+		initialHeight: c.initialHeight,
+		parent:        c,
+		childOverlay:  overlay,
+		logger:        c.logger,
+
+		isMessageExecution: c.isMessageExecution,
+		callerAddress:      c.callerAddress,
+	}
+	child.Context = context.WithValue(c.Context, contextKey{}, child)
+	return child
+}
+
+// CommitChild commits any state updates, emitted events and emitted data of a child context to
+// its parent context and closes the child context.
+//
+// After calling this method, the child context should no longer be used.
+func (c *Context) CommitChild() {
+	if c.parent == nil {
+		panic("context: not a child context")
+	}
+	if c.stateCheckpoint != nil {
+		panic("context: open checkpoint was never committed or discarded")
+	}
+	if err := c.childOverlay.Commit(c.parent); err != nil {
+		panic(fmt.Errorf("context: failed to commit child context: %w", err))
+	}
+
+	c.parent.events = append(c.parent.events, c.events...)
+	c.parent.persistentEvents = append(c.parent.persistentEvents, c.persistentEvents...)
+	c.persistentEvents = nil
+	if c.data != nil {
+		c.parent.data = c.data
+	}
+	c.Close()
+}
+
 // StateCheckpoint is a state checkpoint that can be used to rollback state.
 type StateCheckpoint struct {
 	ctx     *Context
@@ -74,6 +74,82 @@ func TestContext(t *testing.T) {
 	ctx.Close()
 }
 
+func TestChildContext(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := NewMockApplicationState(&MockApplicationStateConfig{})
+	ctx := appState.NewContext(ContextDeliverTx, now)
+	defer ctx.Close()
+
+	tree := ctx.State()
+	err := tree.Insert(ctx, []byte("key"), []byte("value"))
+	require.NoError(err, "Insert")
+
+	// Test discarding a child context.
+	child := ctx.NewChild()
+	require.Panics(func() { ctx.CommitChild() }, "CommitChild should panic on non-child contexts")
+	err = child.State().Insert(child, []byte("key"), []byte("discarded"))
+	require.NoError(err, "Insert")
+	child.EmitEvent(NewEventBuilder("test").Attribute([]byte("key"), []byte("discarded")))
+	child.Close()
+
+	value, err := tree.Get(ctx, []byte("key"))
+	require.NoError(err, "Get")
+	require.EqualValues([]byte("value"), value, "updates should have been discarded")
+	require.Empty(ctx.GetEvents(), "events should have been discarded")
+
+	// Test committing a child context with checkpoints.
+	child = ctx.NewChild()
+	cp := child.StartCheckpoint()
+	err = child.State().Insert(child, []byte("blah"), []byte("rollback"))
+	require.NoError(err, "Insert")
+	cp.Close()
+
+	cp = child.StartCheckpoint()
+	err = child.State().Insert(child, []byte("blah"), []byte("value2"))
+	require.NoError(err, "Insert")
+	require.Panics(func() { child.CommitChild() }, "CommitChild should panic with an open checkpoint")
+	cp.Commit()
+
+	err = child.State().Remove(child, []byte("key"))
+	require.NoError(err, "Remove")
+	child.EmitEvent(NewEventBuilder("test").Attribute([]byte("key"), []byte("committed")))
+
+	// Make sure updates didn't leak before commit.
+	value, err = tree.Get(ctx, []byte("blah"))
+	require.NoError(err, "Get")
+	require.Nil(value, "updates should not leak outside child context")
+
+	child.CommitChild()
+
+	value, err = tree.Get(ctx, []byte("key"))
+	require.NoError(err, "Get")
+	require.Nil(value, "updates should have been applied")
+	value, err = tree.Get(ctx, []byte("blah"))
+	require.NoError(err, "Get")
+	require.EqualValues([]byte("value2"), value, "updates should have been applied")
+	require.Len(ctx.GetEvents(), 1, "events should have been propagated")
+
+	// Test persistent events in nested child contexts.
+	numEvents := len(ctx.GetEvents())
+	child = ctx.NewChild()
+	nested := child.NewChild()
+	nested.EmitEvent(NewEventBuilder("test").Attribute([]byte("key"), []byte("discarded")))
+	nested.EmitPersistentEvent(NewEventBuilder("test").Attribute([]byte("key"), []byte("persistent")))
+	nested.CommitChild()
+	require.Len(child.GetEvents(), 2, "events should have been propagated")
+	child.Close()
+	require.Len(ctx.GetEvents(), numEvents+1, "only persistent events should be retained")
+	require.True(ctx.HasEvent("test", []byte("key")))
+	require.EqualValues([]byte("persistent"), ctx.GetEvents()[numEvents].Attributes[0].GetValue())
+
+	child = ctx.NewChild()
+	child.EmitPersistentEvent(NewEventBuilder("test").Attribute([]byte("key"), []byte("persistent")))
+	child.CommitChild()
+	require.Len(ctx.GetEvents(), numEvents+2, "persistent events should not be duplicated on commit")
+}
+
 type testBlockContextKey struct{}
 
 func (k testBlockContextKey) NewDefault() interface{} {
@@ -258,6 +258,21 @@ func NewImmutableState(ctx context.Context, state ApplicationQueryState, version
 	}
 
 	// Handle a regular (external) query where we need to create a new tree.
+	root, err := GetStateRoot(ctx, state, version)
+	if err != nil {
+		return nil, err
+	}
+	tree := mkvs.NewWithRoot(nil, state.Storage().NodeDB(), *root, mkvs.WithoutWriteLog())
+
+	return &ImmutableState{tree}, nil
+}
+
+// GetStateRoot returns the committed application state root at the given version. In case the
+// version is not positive or is in the future, the root for the latest version is returned.
+func GetStateRoot(ctx context.Context, state ApplicationQueryState, version int64) (*storage.Root, error) {
+	if state == nil {
+		return nil, ErrNoState
+	}
 	if state.BlockHeight() == 0 {
 		return nil, consensus.ErrNoCommittedBlocks
 	}
@@ -265,8 +280,7 @@ func NewImmutableState(ctx context.Context, state ApplicationQueryState, version
 		version = state.BlockHeight()
 	}
 
-	ndb := state.Storage().NodeDB()
-	roots, err := ndb.GetRootsForVersion(ctx, uint64(version))
+	roots, err := state.Storage().NodeDB().GetRootsForVersion(ctx, uint64(version))
 	if err != nil {
 		return nil, err
 	}
@@ -280,10 +294,8 @@ func NewImmutableState(ctx context.Context, state ApplicationQueryState, version
 		// Unexpected number of roots.
 		return nil, fmt.Errorf("state: incorrect number of roots (%d): %+v", version, roots)
 	}
-	tree := mkvs.NewWithRoot(nil, ndb, storage.Root{
+	return &storage.Root{
 		Version: uint64(version),
 		Hash:    roots[0],
-	}, mkvs.WithoutWriteLog())
-
-	return &ImmutableState{tree}, nil
+	}, nil
 }
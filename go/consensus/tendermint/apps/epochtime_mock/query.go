@@ -10,6 +10,7 @@ import (
 // Query is the mock epochtime query interface.
 type Query interface {
 	Epoch(context.Context) (epochtime.EpochTime, int64, error)
+	EpochBlock(context.Context, epochtime.EpochTime) (int64, error)
 }
 
 // QueryFactory is the mock epochtime query factory.
@@ -34,6 +35,10 @@ func (eq *epochtimeMockQuerier) Epoch(ctx context.Context) (epochtime.EpochTime,
 	return eq.state.getEpoch(ctx)
 }
 
+func (eq *epochtimeMockQuerier) EpochBlock(ctx context.Context, epoch epochtime.EpochTime) (int64, error) {
+	return eq.state.getEpochBlock(ctx, epoch)
+}
+
 func (app *epochTimeMockApplication) QueryFactory() interface{} {
 	return &QueryFactory{app.state}
 }
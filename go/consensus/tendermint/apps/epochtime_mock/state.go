@@ -20,6 +20,11 @@ var (
 	//
 	// Value is CBOR-serialized mock epoch time state.
 	epochFutureKeyFmt = keyformat.New(0x31)
+	// epochBlockKeyFmt is the epoch start height key format.
+	//
+	// Key format is: 0x32 <epoch (uint64)>.
+	// Value is CBOR-serialized block height at which the epoch started.
+	epochBlockKeyFmt = keyformat.New(0x32, uint64(0))
 )
 
 type mockEpochTimeState struct {
@@ -47,6 +52,34 @@ func (s *immutableState) getEpoch(ctx context.Context) (api.EpochTime, int64, er
 	return state.Epoch, state.Height, nil
 }
 
+func (s *immutableState) getEpochBlock(ctx context.Context, epoch api.EpochTime) (int64, error) {
+	current, height, err := s.getEpoch(ctx)
+	if err != nil {
+		return -1, err
+	}
+	if epoch == current {
+		return height, nil
+	}
+
+	data, err := s.is.Get(ctx, epochBlockKeyFmt.Encode(uint64(epoch)))
+	if err != nil {
+		return -1, abciAPI.UnavailableStateError(err)
+	}
+	if data == nil {
+		if epoch == 0 {
+			// The initial epoch is never explicitly set, see getEpoch.
+			return 0, nil
+		}
+		return -1, fmt.Errorf("epochtime_mock: no start height for epoch %d", epoch)
+	}
+
+	var blockHeight int64
+	if err = cbor.Unmarshal(data, &blockHeight); err != nil {
+		return -1, abciAPI.UnavailableStateError(err)
+	}
+	return blockHeight, nil
+}
+
 func (s *immutableState) getFutureEpoch(ctx context.Context) (*mockEpochTimeState, error) {
 	data, err := s.is.Get(ctx, epochFutureKeyFmt.Encode())
 	if err != nil {
@@ -80,7 +113,12 @@ type mutableState struct {
 
 func (s *mutableState) setEpoch(ctx context.Context, epoch api.EpochTime, height int64) error {
 	state := mockEpochTimeState{Epoch: epoch, Height: height}
-	err := s.ms.Insert(ctx, epochCurrentKeyFmt.Encode(), cbor.Marshal(state))
+	if err := s.ms.Insert(ctx, epochCurrentKeyFmt.Encode(), cbor.Marshal(state)); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+
+	// Also record the start height so that it can be looked up after later epoch transitions.
+	err := s.ms.Insert(ctx, epochBlockKeyFmt.Encode(uint64(epoch)), cbor.Marshal(height))
 	return abciAPI.UnavailableStateError(err)
 }
 
@@ -193,11 +193,8 @@ func (app *keymanagerApplication) generateStatus(ctx *tmapi.Context, kmrt *regis
 		Policy:        oldStatus.Policy,
 	}
 
-	var rawPolicy []byte
-	if status.Policy != nil {
-		rawPolicy = cbor.Marshal(status.Policy)
-	}
-	policyHash := sha3.Sum256(rawPolicy)
+	var policyHash [api.ChecksumSize]byte
+	copy(policyHash[:], api.PolicyChecksum(status.Policy))
 
 	for _, n := range nodes {
 		if !n.HasRoles(node.RoleKeyManager) {
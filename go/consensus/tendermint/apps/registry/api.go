@@ -26,6 +26,10 @@ var (
 	// descriptor).
 	KeyRuntimeRegistered = []byte("runtime.registered")
 
+	// KeyRuntimeDeregistered is the ABCI event attribute for runtime
+	// deregistrations (value is the CBOR serialized runtime descriptor).
+	KeyRuntimeDeregistered = []byte("runtime.deregistered")
+
 	// KeyEntityRegistered is the ABCI event attribute for new entity
 	// registrations (value is the CBOR serialized entity descriptor).
 	KeyEntityRegistered = []byte("entity.registered")
@@ -47,6 +51,11 @@ var (
 	// become unfrozen (value is CBOR serialized node ID).
 	KeyNodeUnfrozen = []byte("nodes.unfrozen")
 
+	// KeyStakeClaimReleased is the ABCI event attribute for when stake
+	// claims of deregistered entities or runtimes are released (value is
+	// a CBOR serialized StakeClaimReleasedEvent).
+	KeyStakeClaimReleased = []byte("stake_claim.released")
+
 	// KeyRegistryNodeListEpoch is the ABCI event attribute for
 	// registry epochs.
 	KeyRegistryNodeListEpoch = []byte("nodes.epoch")
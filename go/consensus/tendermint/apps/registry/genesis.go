@@ -12,6 +12,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
 	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 )
@@ -121,6 +122,26 @@ func (app *registryApplication) InitChain(ctx *abciAPI.Context, request types.Re
 		}
 	}
 
+	// Restore pending stake claim releases. The claims must remain in effect until released.
+	for i, v := range st.StakeClaimReleases {
+		if v == nil {
+			return fmt.Errorf("registry: genesis stake claim release index %d is nil", i)
+		}
+		if !st.Parameters.DebugBypassStake {
+			if err := stakingState.AddStakeClaim(ctx, v.Account, v.Claim, v.Thresholds); err != nil {
+				ctx.Logger().Error("InitChain: failed to add stake claim pending release",
+					"err", err,
+					"account", v.Account,
+					"claim", v.Claim,
+				)
+				return fmt.Errorf("registry: genesis stake claim release failure: %w", err)
+			}
+		}
+		if err := state.SetStakeClaimRelease(ctx, v); err != nil {
+			return fmt.Errorf("registry: genesis stake claim release failure: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -169,18 +190,24 @@ func (rq *registryQuerier) Genesis(ctx context.Context) (*registry.Genesis, erro
 		nodeStatuses[n.ID] = status
 	}
 
+	stakeClaimReleases, err := rq.state.StakeClaimReleases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	params, err := rq.state.ConsensusParameters(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	gen := registry.Genesis{
-		Parameters:        *params,
-		Entities:          signedEntities,
-		Runtimes:          signedRuntimes,
-		SuspendedRuntimes: suspendedRuntimes,
-		Nodes:             validatorNodes,
-		NodeStatuses:      nodeStatuses,
+		Parameters:         *params,
+		Entities:           signedEntities,
+		Runtimes:           signedRuntimes,
+		SuspendedRuntimes:  suspendedRuntimes,
+		Nodes:              validatorNodes,
+		NodeStatuses:       nodeStatuses,
+		StakeClaimReleases: stakeClaimReleases,
 	}
 	return &gen, nil
 }
@@ -24,6 +24,7 @@ type Query interface {
 	Runtime(context.Context, common.Namespace) (*registry.Runtime, error)
 	Runtimes(ctx context.Context, includeSuspended bool) ([]*registry.Runtime, error)
 	Genesis(context.Context) (*registry.Genesis, error)
+	ValidateNode(context.Context, *registry.ValidateNodeRequest) (*registry.NodeValidationResult, error)
 }
 
 // QueryFactory is the registry query factory.
@@ -22,8 +22,13 @@ import (
 
 var _ api.Application = (*registryApplication)(nil)
 
+// teeVerificationCacheSize is the maximum number of cached TEE attestation verification results.
+const teeVerificationCacheSize = 1024
+
 type registryApplication struct {
 	state api.ApplicationState
+
+	teeCache *registry.TEEVerificationCache
 }
 
 func (app *registryApplication) Name() string {
@@ -95,6 +100,13 @@ func (app *registryApplication) ExecuteTx(ctx *api.Context, tx *transaction.Tran
 		}
 
 		return app.registerRuntime(ctx, state, &sigRt)
+	case registry.MethodDeregisterRuntime:
+		var dereg registry.DeregisterRuntime
+		if err := cbor.Unmarshal(tx.Body, &dereg); err != nil {
+			return err
+		}
+
+		return app.deregisterRuntime(ctx, state, &dereg)
 	default:
 		return registry.ErrInvalidArgument
 	}
@@ -194,6 +206,36 @@ func (app *registryApplication) onRegistryEpochChanged(ctx *api.Context, registr
 		}
 	}
 
+	// Release any stake claims of deregistered entities and runtimes once the release delay has
+	// passed.
+	releases, err := state.StakeClaimReleases(ctx)
+	if err != nil {
+		return fmt.Errorf("registry: onRegistryEpochChanged: failed to get stake claim releases: %w", err)
+	}
+	var released []*registry.StakeClaimReleasedEvent
+	for _, release := range releases {
+		if release.Epoch > registryEpoch {
+			continue
+		}
+
+		ctx.Logger().Debug("releasing stake claim",
+			"account", release.Account,
+			"claim", release.Claim,
+		)
+		if !params.DebugBypassStake {
+			if err = stakeAcc.RemoveStakeClaim(release.Account, release.Claim); err != nil {
+				return fmt.Errorf("registry: onRegistryEpochChanged: couldn't remove stake claim: %w", err)
+			}
+		}
+		if err = state.RemoveStakeClaimRelease(ctx, release.Account, release.Claim); err != nil {
+			return fmt.Errorf("registry: onRegistryEpochChanged: couldn't remove stake claim release: %w", err)
+		}
+		released = append(released, &registry.StakeClaimReleasedEvent{
+			Account: release.Account,
+			Claim:   release.Claim,
+		})
+	}
+
 	if !params.DebugBypassStake {
 		if err = stakeAcc.Commit(); err != nil {
 			return fmt.Errorf("registry: onRegistryEpochChanged: failed to commit stake accumulator: %w", err)
@@ -210,6 +252,9 @@ func (app *registryApplication) onRegistryEpochChanged(ctx *api.Context, registr
 		// so the change is picked up.
 		evb = evb.Attribute(KeyNodesExpired, cbor.Marshal(expiredNodes))
 	}
+	for _, ev := range released {
+		evb = evb.Attribute(KeyStakeClaimReleased, cbor.Marshal(ev))
+	}
 
 	ctx.EmitEvent(evb)
 
@@ -218,5 +263,7 @@ func (app *registryApplication) onRegistryEpochChanged(ctx *api.Context, registr
 
 // New constructs a new registry application instance.
 func New() api.Application {
-	return &registryApplication{}
+	return &registryApplication{
+		teeCache: registry.NewTEEVerificationCache(teeVerificationCacheSize),
+	}
 }
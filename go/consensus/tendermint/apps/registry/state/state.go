@@ -13,6 +13,7 @@ import (
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	tmcrypto "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/crypto"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
 )
 
@@ -69,6 +70,10 @@ var (
 	//
 	// Value is empty.
 	signedRuntimeByEntityKeyFmt = keyformat.New(0x19, keyformat.H(&signature.PublicKey{}), keyformat.H(&common.Namespace{}))
+	// stakeClaimReleaseKeyFmt is the key format used for pending stake claim releases.
+	//
+	// The format is (account address, stake claim). Value is CBOR-serialized stake claim release.
+	stakeClaimReleaseKeyFmt = keyformat.New(0x1a, &staking.Address{}, []byte{})
 )
 
 // ImmutableState is the immutable registry state wrapper.
@@ -509,6 +514,29 @@ func (s *ImmutableState) NodeBySubKey(ctx context.Context, key signature.PublicK
 	return s.Node(ctx, id)
 }
 
+// StakeClaimReleases returns the list of all pending stake claim releases.
+func (s *ImmutableState) StakeClaimReleases(ctx context.Context) ([]*registry.StakeClaimRelease, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var releases []*registry.StakeClaimRelease
+	for it.Seek(stakeClaimReleaseKeyFmt.Encode()); it.Valid(); it.Next() {
+		if !stakeClaimReleaseKeyFmt.Decode(it.Key()) {
+			break
+		}
+
+		var release registry.StakeClaimRelease
+		if err := cbor.Unmarshal(it.Value(), &release); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+		releases = append(releases, &release)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return releases, nil
+}
+
 func NewImmutableState(ctx context.Context, state abciAPI.ApplicationQueryState, version int64) (*ImmutableState, error) {
 	is, err := abciAPI.NewImmutableState(ctx, state, version)
 	if err != nil {
@@ -682,6 +710,31 @@ func (s *MutableState) ResumeRuntime(ctx context.Context, id common.Namespace) e
 	return abciAPI.UnavailableStateError(err)
 }
 
+// RemoveRuntime removes a previously suspended runtime.
+func (s *MutableState) RemoveRuntime(ctx context.Context, rt *registry.Runtime) error {
+	data, err := s.ms.RemoveExisting(ctx, suspendedRuntimeKeyFmt.Encode(&rt.ID))
+	if err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	if data == nil {
+		return registry.ErrNoSuchRuntime
+	}
+	err = s.ms.Remove(ctx, signedRuntimeByEntityKeyFmt.Encode(&rt.EntityID, &rt.ID))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// SetStakeClaimRelease sets a pending stake claim release.
+func (s *MutableState) SetStakeClaimRelease(ctx context.Context, release *registry.StakeClaimRelease) error {
+	err := s.ms.Insert(ctx, stakeClaimReleaseKeyFmt.Encode(&release.Account, []byte(release.Claim)), cbor.Marshal(release))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// RemoveStakeClaimRelease removes a pending stake claim release (if any).
+func (s *MutableState) RemoveStakeClaimRelease(ctx context.Context, addr staking.Address, claim staking.StakeClaim) error {
+	err := s.ms.Remove(ctx, stakeClaimReleaseKeyFmt.Encode(&addr, []byte(claim)))
+	return abciAPI.UnavailableStateError(err)
+}
+
 // SetNodeStatus sets a status for a registered node.
 func (s *MutableState) SetNodeStatus(ctx context.Context, id signature.PublicKey, status *registry.NodeStatus) error {
 	err := s.ms.Insert(ctx, nodeStatusKeyFmt.Encode(&id), cbor.Marshal(status))
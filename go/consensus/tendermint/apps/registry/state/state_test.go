@@ -13,6 +13,7 @@ import (
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	tmcrypto "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/crypto"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
 
 var (
@@ -144,3 +145,49 @@ func TestNodeUpdate(t *testing.T) {
 	require.Error(err, "TLS mapping should be gone")
 	require.Equal(registry.ErrNoSuchNode, err, "TLS mapping should be gone")
 }
+
+func TestStakeClaimReleases(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextBeginBlock, now)
+	defer ctx.Close()
+
+	s := NewMutableState(ctx.State())
+
+	releases, err := s.StakeClaimReleases(ctx)
+	require.NoError(err, "StakeClaimReleases")
+	require.Empty(releases, "there should be no pending releases")
+
+	addr := staking.NewAddress(nodeSigner.Public())
+	release1 := registry.StakeClaimRelease{
+		Account: addr,
+		Claim:   registry.StakeClaimRegisterEntity,
+		Thresholds: []staking.StakeThreshold{
+			staking.GlobalStakeThreshold(staking.KindEntity),
+		},
+		Epoch: 10,
+	}
+	release2 := registry.StakeClaimRelease{
+		Account: addr,
+		Claim:   staking.StakeClaim("registry.RegisterRuntime.test"),
+		Epoch:   11,
+	}
+	err = s.SetStakeClaimRelease(ctx, &release1)
+	require.NoError(err, "SetStakeClaimRelease")
+	err = s.SetStakeClaimRelease(ctx, &release2)
+	require.NoError(err, "SetStakeClaimRelease")
+
+	releases, err = s.StakeClaimReleases(ctx)
+	require.NoError(err, "StakeClaimReleases")
+	require.Len(releases, 2, "there should be two pending releases")
+
+	err = s.RemoveStakeClaimRelease(ctx, addr, release1.Claim)
+	require.NoError(err, "RemoveStakeClaimRelease")
+
+	releases, err = s.StakeClaimReleases(ctx)
+	require.NoError(err, "StakeClaimReleases")
+	require.Len(releases, 1, "there should be one pending release")
+	require.EqualValues(release2, *releases[0], "remaining release should be correct")
+}
@@ -8,8 +8,11 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
+	roothashState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash/state"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	epochtime "github.com/oasisprotocol/oasis-core/go/epochtime/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
 
@@ -64,6 +67,10 @@ func (app *registryApplication) registerEntity(
 			)
 			return err
 		}
+		// In case the entity was previously deregistered, make sure the claim is not released.
+		if err = state.RemoveStakeClaimRelease(ctx, acctAddr, registry.StakeClaimRegisterEntity); err != nil {
+			return fmt.Errorf("failed to remove stake claim release: %w", err)
+		}
 	}
 
 	if err = state.SetEntity(ctx, ent, sigEnt); err != nil {
@@ -127,6 +134,11 @@ func (app *registryApplication) deregisterEntity(ctx *api.Context, state *regist
 		return registry.ErrEntityHasRuntimes
 	}
 
+	epoch, err := app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
+	if err != nil {
+		return err
+	}
+
 	removedEntity, err := state.RemoveEntity(ctx, id)
 	switch err {
 	case nil:
@@ -138,8 +150,9 @@ func (app *registryApplication) deregisterEntity(ctx *api.Context, state *regist
 
 	if !params.DebugBypassStake {
 		acctAddr := staking.NewAddress(id)
-		if err = stakingState.RemoveStakeClaim(ctx, acctAddr, registry.StakeClaimRegisterEntity); err != nil {
-			panic(fmt.Errorf("DeregisterEntity: failed to remove stake claim: %w", err))
+		thresholds := staking.GlobalStakeThresholds(staking.KindEntity)
+		if err = app.releaseStakeClaim(ctx, state, params, epoch, acctAddr, registry.StakeClaimRegisterEntity, thresholds); err != nil {
+			panic(fmt.Errorf("DeregisterEntity: failed to release stake claim: %w", err))
 		}
 	}
 
@@ -210,6 +223,7 @@ func (app *registryApplication) registerNode( // nolint: gocyclo
 		epoch,
 		state,
 		state,
+		app.teeCache,
 	)
 	if err != nil {
 		return err
@@ -524,6 +538,25 @@ func (app *registryApplication) registerRuntime( // nolint: gocyclo
 		}
 	}
 
+	// Make sure the runtime does not emit more runtime messages than allowed.
+	// NOTE: If this is invoked during InitChain then the roothash consensus parameters may not
+	//       be available yet, so the check is skipped
+	//       and performed by the genesis document sanity checks instead.
+	if rt.Executor.MaxMessages > 0 && !ctx.IsInitChain() {
+		var rhParams *roothash.ConsensusParameters
+		rhParams, err = roothashState.NewMutableState(ctx.State()).ConsensusParameters(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to fetch roothash consensus parameters: %w", err)
+		}
+		if rt.Executor.MaxMessages > rhParams.MaxRuntimeMessages {
+			ctx.Logger().Error("RegisterRuntime: too many runtime messages",
+				"max_messages", rt.Executor.MaxMessages,
+				"max_runtime_messages", rhParams.MaxRuntimeMessages,
+			)
+			return fmt.Errorf("%w: too many runtime messages", registry.ErrInvalidArgument)
+		}
+	}
+
 	if ctx.IsCheckOnly() {
 		return nil
 	}
@@ -580,6 +613,10 @@ func (app *registryApplication) registerRuntime( // nolint: gocyclo
 			)
 			return err
 		}
+		// In case the runtime was previously deregistered, make sure the claim is not released.
+		if err = state.RemoveStakeClaimRelease(ctx, acctAddr, claim); err != nil {
+			return fmt.Errorf("failed to remove stake claim release: %w", err)
+		}
 	}
 
 	if err = state.SetRuntime(ctx, rt, sigRt, suspended); err != nil {
@@ -601,3 +638,141 @@ func (app *registryApplication) registerRuntime( // nolint: gocyclo
 
 	return nil
 }
+
+func (app *registryApplication) deregisterRuntime(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	dereg *registry.DeregisterRuntime,
+) error {
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("DeregisterRuntime: failed to fetch consensus parameters",
+			"err", err,
+		)
+		return err
+	}
+	if err = ctx.Gas().UseGas(1, registry.GasOpDeregisterRuntime, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Only suspended runtimes can be deregistered as those do not have any active rounds.
+	rt, err := state.SuspendedRuntime(ctx, dereg.ID)
+	switch err {
+	case nil:
+	case registry.ErrNoSuchRuntime:
+		if _, err = state.Runtime(ctx, dereg.ID); err == nil {
+			return registry.ErrRuntimeNotSuspended
+		}
+		return registry.ErrNoSuchRuntime
+	default:
+		return fmt.Errorf("DeregisterRuntime: failed to fetch runtime: %w", err)
+	}
+
+	// Make sure the signer of the transaction matches the owner of the runtime.
+	if !rt.EntityID.Equal(ctx.TxSigner()) {
+		return registry.ErrIncorrectTxSigner
+	}
+
+	// Prevent runtime deregistration if there are any nodes registered for the runtime.
+	nodes, err := state.Nodes(ctx)
+	if err != nil {
+		return fmt.Errorf("DeregisterRuntime: failed to fetch nodes: %w", err)
+	}
+	for _, n := range nodes {
+		if n.GetRuntime(rt.ID) != nil {
+			ctx.Logger().Error("DeregisterRuntime: runtime still has nodes",
+				"runtime_id", rt.ID,
+				"node_id", n.ID,
+			)
+			return registry.ErrRuntimeInUse
+		}
+	}
+	// Prevent runtime deregistration if there are any runtimes using it as a key manager.
+	runtimes, err := state.AllRuntimes(ctx)
+	if err != nil {
+		return fmt.Errorf("DeregisterRuntime: failed to fetch runtimes: %w", err)
+	}
+	for _, other := range runtimes {
+		if other.KeyManager != nil && other.KeyManager.Equal(&rt.ID) {
+			ctx.Logger().Error("DeregisterRuntime: runtime is still used as a key manager",
+				"runtime_id", rt.ID,
+				"other_runtime_id", other.ID,
+			)
+			return registry.ErrRuntimeInUse
+		}
+	}
+
+	epoch, err := app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
+	if err != nil {
+		return err
+	}
+
+	if err = state.RemoveRuntime(ctx, rt); err != nil {
+		return fmt.Errorf("DeregisterRuntime: failed to remove runtime: %w", err)
+	}
+
+	if !params.DebugBypassStake {
+		acctAddr := staking.NewAddress(rt.EntityID)
+		claim := registry.StakeClaimForRuntime(rt.ID)
+		thresholds := registry.StakeThresholdsForRuntime(rt)
+		if err = app.releaseStakeClaim(ctx, state, params, epoch, acctAddr, claim, thresholds); err != nil {
+			panic(fmt.Errorf("DeregisterRuntime: failed to release stake claim: %w", err))
+		}
+	}
+
+	ctx.Logger().Debug("DeregisterRuntime: complete",
+		"runtime_id", rt.ID,
+	)
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyRuntimeDeregistered, cbor.Marshal(rt)))
+
+	return nil
+}
+
+// releaseStakeClaim releases a stake claim held for a deregistered entity or runtime.
+//
+// In case a stake claim release delay is configured, the release is scheduled for the epoch
+// following the delay, otherwise the claim is released immediately.
+func (app *registryApplication) releaseStakeClaim(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	params *registry.ConsensusParameters,
+	epoch epochtime.EpochTime,
+	addr staking.Address,
+	claim staking.StakeClaim,
+	thresholds []staking.StakeThreshold,
+) error {
+	if params.StakeClaimReleaseDelay == 0 {
+		if err := stakingState.RemoveStakeClaim(ctx, addr, claim); err != nil {
+			return fmt.Errorf("failed to remove stake claim: %w", err)
+		}
+
+		ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(
+			KeyStakeClaimReleased,
+			cbor.Marshal(&registry.StakeClaimReleasedEvent{Account: addr, Claim: claim}),
+		))
+		return nil
+	}
+
+	release := &registry.StakeClaimRelease{
+		Account:    addr,
+		Claim:      claim,
+		Thresholds: thresholds,
+		Epoch:      epoch + params.StakeClaimReleaseDelay,
+	}
+	if err := state.SetStakeClaimRelease(ctx, release); err != nil {
+		return fmt.Errorf("failed to schedule stake claim release: %w", err)
+	}
+
+	ctx.Logger().Debug("scheduled stake claim release",
+		"account", addr,
+		"claim", claim,
+		"epoch", release.Epoch,
+	)
+	return nil
+}
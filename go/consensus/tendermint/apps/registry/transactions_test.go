@@ -115,6 +115,48 @@ func TestRegisterNode(t *testing.T) {
 			false,
 			false,
 		},
+		// Validator with roles allowed by the entity.
+		{
+			"ValidatorRolesAllowedByEntity",
+			func(tcd *testCaseData) {
+				ent := entity.Entity{
+					Versioned: cbor.NewVersioned(entity.LatestEntityDescriptorVersion),
+					ID:        tcd.entitySigner.Public(),
+					Nodes:     []signature.PublicKey{tcd.nodeSigner.Public()},
+					NodeRoles: map[signature.PublicKey]node.RolesMask{
+						tcd.nodeSigner.Public(): node.RoleValidator | node.RoleComputeWorker,
+					},
+				}
+				sigEnt, _ := entity.SignEntity(tcd.entitySigner, registry.RegisterEntitySignatureContext, &ent)
+				_ = state.SetEntity(ctx, &ent, sigEnt)
+
+				tcd.node.AddRoles(node.RoleValidator)
+			},
+			nil,
+			true,
+			true,
+		},
+		// Validator with roles not allowed by the entity.
+		{
+			"ValidatorRolesNotAllowedByEntity",
+			func(tcd *testCaseData) {
+				ent := entity.Entity{
+					Versioned: cbor.NewVersioned(entity.LatestEntityDescriptorVersion),
+					ID:        tcd.entitySigner.Public(),
+					Nodes:     []signature.PublicKey{tcd.nodeSigner.Public()},
+					NodeRoles: map[signature.PublicKey]node.RolesMask{
+						tcd.nodeSigner.Public(): node.RoleComputeWorker,
+					},
+				}
+				sigEnt, _ := entity.SignEntity(tcd.entitySigner, registry.RegisterEntitySignatureContext, &ent)
+				_ = state.SetEntity(ctx, &ent, sigEnt)
+
+				tcd.node.AddRoles(node.RoleValidator)
+			},
+			nil,
+			false,
+			false,
+		},
 		// Compute node.
 		{
 			"ComputeNode",
@@ -0,0 +1,226 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+var validateLogger = logging.GetLogger("tendermint/registry/validate")
+
+type nodeValidator struct {
+	result *registry.NodeValidationResult
+}
+
+func (v *nodeValidator) fail(check registry.NodeValidationCheck, runtimeID *common.Namespace, err error) {
+	v.result.Failures = append(v.result.Failures, registry.NodeValidationFailure{
+		Check:     check,
+		RuntimeID: runtimeID,
+		Error:     err.Error(),
+	})
+}
+
+func (rq *registryQuerier) ValidateNode( // nolint: gocyclo
+	ctx context.Context,
+	req *registry.ValidateNodeRequest,
+) (*registry.NodeValidationResult, error) {
+	if (req.Node == nil) == (req.SignedNode == nil) {
+		return nil, fmt.Errorf("%w: exactly one of node and signed node must be set", registry.ErrInvalidArgument)
+	}
+
+	epoch, err := rq.queryState.GetEpoch(ctx, rq.height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epoch: %w", err)
+	}
+	params, err := rq.state.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &nodeValidator{
+		result: &registry.NodeValidationResult{Epoch: epoch},
+	}
+
+	var n node.Node
+	switch req.SignedNode {
+	case nil:
+		n = *req.Node
+	default:
+		// Peek into the to-be-verified node, signatures are checked below.
+		if err = cbor.Unmarshal(req.SignedNode.Blob, &n); err != nil {
+			v.fail(registry.NodeCheckDescriptor, nil, fmt.Errorf("%w: malformed node descriptor", registry.ErrInvalidArgument))
+			return v.result, nil
+		}
+	}
+	if err = n.ValidateBasic(true); err != nil {
+		v.fail(registry.NodeCheckDescriptor, nil, err)
+	}
+
+	// Check the owning entity.
+	var ent *entity.Entity
+	var inEntityNodeList bool
+	ent, err = rq.state.Entity(ctx, n.EntityID)
+	switch err {
+	case nil:
+		for _, id := range ent.Nodes {
+			if n.ID.Equal(id) {
+				inEntityNodeList = true
+				break
+			}
+		}
+		if !inEntityNodeList && (!params.DebugAllowEntitySignedNodeRegistration || !ent.AllowEntitySignedNodes) {
+			v.fail(registry.NodeCheckEntity, nil, fmt.Errorf("%w: node not in entity's node list", registry.ErrForbidden))
+		}
+		if inEntityNodeList && !ent.NodeRolesAllowed(n.ID, n.Roles) {
+			v.fail(registry.NodeCheckEntity, nil, fmt.Errorf("%w: roles not allowed by entity", registry.ErrForbidden))
+		}
+	case registry.ErrNoSuchEntity:
+		v.fail(registry.NodeCheckEntity, nil, err)
+	default:
+		return nil, err
+	}
+
+	// Check signatures.
+	if req.SignedNode != nil {
+		var opened node.Node
+		switch err = req.SignedNode.Open(registry.RegisterNodeSignatureContext, &opened); err {
+		case nil:
+			expectedSigners := []signature.PublicKey{n.ID, n.Consensus.ID, n.TLS.PubKey, n.P2P.ID}
+			if ent != nil && !inEntityNodeList {
+				expectedSigners = append(expectedSigners, ent.ID)
+			}
+			for _, signer := range expectedSigners {
+				if !req.SignedNode.MultiSigned.IsSignedBy(signer) {
+					v.fail(registry.NodeCheckSignatures, nil, fmt.Errorf("%w: registration not signed by %s", registry.ErrInvalidArgument, signer))
+				}
+			}
+		default:
+			v.fail(registry.NodeCheckSignatures, nil, registry.ErrInvalidSignature)
+		}
+	}
+
+	// Check expiration.
+	if n.Expiration <= uint64(epoch) {
+		v.fail(registry.NodeCheckExpiration, nil, registry.ErrNodeExpired)
+	}
+	if maxExpiration := uint64(epoch) + params.MaxNodeExpiration; params.MaxNodeExpiration > 0 && n.Expiration > maxExpiration {
+		v.fail(registry.NodeCheckExpiration, nil, fmt.Errorf("%w: expiration period greater than allowed", registry.ErrInvalidArgument))
+	}
+
+	// Check roles.
+	switch {
+	case n.Roles == 0:
+		v.fail(registry.NodeCheckRoles, nil, fmt.Errorf("%w: no roles specified", registry.ErrInvalidArgument))
+	case n.HasRoles(node.RoleReserved):
+		v.fail(registry.NodeCheckRoles, nil, fmt.Errorf("%w: invalid role specified", registry.ErrInvalidArgument))
+	}
+
+	// Check runtimes. As block time is not available to queries, TEE attestations are verified
+	// against the local time.
+	now := time.Now()
+	if len(n.Runtimes) == 0 && n.HasRoles(registry.RuntimesRequiredRoles) {
+		v.fail(registry.NodeCheckRuntimes, nil, fmt.Errorf("%w: missing runtimes", registry.ErrInvalidArgument))
+	}
+	var runtimes []*registry.Runtime
+	seenRuntimes := make(map[common.Namespace]bool)
+	for _, nrt := range n.Runtimes {
+		rtID := nrt.ID
+		if seenRuntimes[rtID] {
+			v.fail(registry.NodeCheckRuntimes, &rtID, fmt.Errorf("%w: duplicate runtime IDs", registry.ErrInvalidArgument))
+			continue
+		}
+		seenRuntimes[rtID] = true
+
+		var rt *registry.Runtime
+		rt, err = rq.state.AnyRuntime(ctx, rtID)
+		switch err {
+		case nil:
+		case registry.ErrNoSuchRuntime:
+			v.fail(registry.NodeCheckRuntimes, &rtID, err)
+			continue
+		default:
+			return nil, err
+		}
+		runtimes = append(runtimes, rt)
+
+		if err = registry.VerifyNodeRuntimeEnclaveIDs(validateLogger, nrt, rt, now); err != nil {
+			v.fail(registry.NodeCheckTEE, &rtID, err)
+		}
+		if rt.Kind == registry.KindKeyManager && !n.HasRoles(registry.KeyManagerRuntimeAllowedRoles) {
+			v.fail(registry.NodeCheckRuntimes, &rtID, fmt.Errorf("%w: key manager runtime not allowed", registry.ErrInvalidArgument))
+		}
+		if rt.Kind == registry.KindCompute && !n.HasRoles(registry.ComputeRuntimeAllowedRoles) {
+			v.fail(registry.NodeCheckRuntimes, &rtID, fmt.Errorf("%w: compute runtime not allowed", registry.ErrInvalidArgument))
+		}
+		if rt.AdmissionPolicy.EntityWhitelist != nil && !rt.AdmissionPolicy.EntityWhitelist.Entities[n.EntityID] {
+			v.fail(registry.NodeCheckRuntimes, &rtID, fmt.Errorf("%w: entity not in runtime's whitelist", registry.ErrForbidden))
+		}
+	}
+
+	// Check addresses.
+	if err = registry.VerifyNodeAddresses(params, &n); err != nil {
+		v.fail(registry.NodeCheckAddresses, nil, err)
+	}
+
+	// Check that keys are unique.
+	if n.Consensus.ID.Equal(n.P2P.ID) || n.Consensus.ID.Equal(n.TLS.PubKey) || n.P2P.ID.Equal(n.TLS.PubKey) {
+		v.fail(registry.NodeCheckKeys, nil, fmt.Errorf("%w: P2P, consensus and TLS keys not unique", registry.ErrInvalidArgument))
+	}
+	for _, key := range []signature.PublicKey{n.Consensus.ID, n.P2P.ID, n.TLS.PubKey} {
+		var existingNode *node.Node
+		existingNode, err = rq.state.NodeBySubKey(ctx, key)
+		switch err {
+		case nil:
+			if !existingNode.ID.Equal(n.ID) {
+				v.fail(registry.NodeCheckKeys, nil, fmt.Errorf("%w: key %s already used by node %s", registry.ErrInvalidArgument, key, existingNode.ID))
+			}
+		case registry.ErrNoSuchNode:
+		default:
+			return nil, fmt.Errorf("failed to lookup node by subkey: %w", err)
+		}
+	}
+
+	// Check that the entity has enough stake for this node registration.
+	if !params.DebugBypassStake && ent != nil {
+		var stakeState *stakingState.ImmutableState
+		if stakeState, err = stakingState.NewImmutableState(ctx, rq.queryState, rq.height); err != nil {
+			return nil, err
+		}
+		thresholds, terr := stakeState.Thresholds(ctx)
+		if terr != nil {
+			return nil, fmt.Errorf("failed to get staking thresholds: %w", terr)
+		}
+		acct, aerr := stakeState.Account(ctx, staking.NewAddress(n.EntityID))
+		if aerr != nil {
+			return nil, fmt.Errorf("failed to get entity account: %w", aerr)
+		}
+		claim := registry.StakeClaimForNode(n.ID)
+		if err = acct.Escrow.AddStakeClaim(thresholds, claim, registry.StakeThresholdsForNode(&n, runtimes)); err != nil {
+			v.fail(registry.NodeCheckStake, nil, err)
+		}
+	}
+
+	// If the node already exists make sure that this is a valid update.
+	existingNode, err := rq.state.Node(ctx, n.ID)
+	switch err {
+	case nil:
+		if err = registry.VerifyNodeUpdate(validateLogger, existingNode, &n); err != nil {
+			v.fail(registry.NodeCheckUpdate, nil, err)
+		}
+	case registry.ErrNoSuchNode:
+	default:
+		return nil, err
+	}
+
+	return v.result, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 )
 
 const (
@@ -47,8 +48,23 @@ var (
 	// KeyFinalized is an ABCI event attribute key for finalized blocks
 	// (value is a CBOR serialized ValueFinalized).
 	KeyFinalized = []byte("finalized")
+	// KeyRoundFailed is an ABCI event attribute key for failed rounds
+	// (value is a CBOR serialized ValueRoundFailed).
+	KeyRoundFailed = []byte("round-failed")
+	// KeyLiveness is an ABCI event attribute key for runtime liveness
+	// events (value is a CBOR serialized ValueLiveness).
+	KeyLiveness = []byte("liveness")
+	// KeyTagPrefix is an ABCI event attribute key prefix for runtime round
+	// tags emitted together with finalized blocks (key is the prefix followed
+	// by the tag key, value is the tag value).
+	KeyTagPrefix = []byte("tag.")
 )
 
+// KeyTag returns the ABCI event attribute key for the given runtime round tag key.
+func KeyTag(key string) []byte {
+	return append(append([]byte{}, KeyTagPrefix...), key...)
+}
+
 // QueryForRuntime returns a query for filtering transactions processed by the roothash application
 // limited to a specific runtime.
 func QueryForRuntime(runtimeID common.Namespace) tmpubsub.Query {
@@ -72,6 +88,7 @@ type ValueExecutorCommitted struct {
 type ValueFinalized struct {
 	ID    common.Namespace `json:"id"`
 	Round uint64           `json:"round"`
+	Tags  []block.Tag      `json:"tags,omitempty"`
 }
 
 // ValueExecutionDiscrepancyDetected is the value component of a KeyMergeDiscrepancyDetected.
@@ -79,3 +96,15 @@ type ValueExecutionDiscrepancyDetected struct {
 	ID    common.Namespace                           `json:"id"`
 	Event roothash.ExecutionDiscrepancyDetectedEvent `json:"event"`
 }
+
+// ValueRoundFailed is the value component of a KeyRoundFailed.
+type ValueRoundFailed struct {
+	ID    common.Namespace          `json:"id"`
+	Event roothash.RoundFailedEvent `json:"event"`
+}
+
+// ValueLiveness is the value component of a KeyLiveness.
+type ValueLiveness struct {
+	ID    common.Namespace       `json:"id"`
+	Event roothash.LivenessEvent `json:"event"`
+}
@@ -13,6 +13,8 @@ import (
 // Query is the roothash query interface.
 type Query interface {
 	LatestBlock(context.Context, common.Namespace) (*block.Block, error)
+	LatestBlockTags(context.Context, common.Namespace) ([]block.Tag, error)
+	LastRoundResults(context.Context, common.Namespace) (*roothash.RoundResults, error)
 	GenesisBlock(context.Context, common.Namespace) (*block.Block, error)
 	Genesis(context.Context) (*roothash.Genesis, error)
 }
@@ -43,6 +45,22 @@ func (rq *rootHashQuerier) LatestBlock(ctx context.Context, id common.Namespace)
 	return runtime.CurrentBlock, nil
 }
 
+func (rq *rootHashQuerier) LatestBlockTags(ctx context.Context, id common.Namespace) ([]block.Tag, error) {
+	runtime, err := rq.state.RuntimeState(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return runtime.CurrentBlockTags, nil
+}
+
+func (rq *rootHashQuerier) LastRoundResults(ctx context.Context, id common.Namespace) (*roothash.RoundResults, error) {
+	runtime, err := rq.state.RuntimeState(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return runtime.LastRoundResults, nil
+}
+
 func (rq *rootHashQuerier) GenesisBlock(ctx context.Context, id common.Namespace) (*block.Block, error) {
 	runtime, err := rq.state.RuntimeState(ctx, id)
 	if err != nil {
@@ -3,14 +3,15 @@ package roothash
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 
 	"github.com/tendermint/tendermint/abci/types"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	registryapp "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry"
@@ -110,6 +111,11 @@ func (app *rootHashApplication) onCommitteeChanged(ctx *tmapi.Context, epoch epo
 		// Since the runtime is in the list of active runtimes in the registry we
 		// can safely clear the suspended flag.
 		rtState.Suspended = false
+		if rtState.LivenessSuspended {
+			// The runtime has been resumed, give it a fresh start.
+			rtState.LivenessSuspended = false
+			rtState.FailedRounds = 0
+		}
 
 		// Prepare new runtime committees based on what the scheduler did.
 		executorPool, empty, err := app.prepareNewCommittees(ctx, epoch, rtState, schedState, regState)
@@ -171,6 +177,15 @@ func (app *rootHashApplication) onCommitteeChanged(ctx *tmapi.Context, epoch epo
 		}
 	}
 
+	// Resume any runtimes that have been suspended due to liveness in case a sufficient committee
+	// can re-form. This is done after processing the active runtimes as the scheduler will only
+	// elect committees for resumed runtimes on the next epoch transition.
+	if params.MaxFailedRounds > 0 && !params.DebugDoNotSuspendRuntimes {
+		if err = app.resumeLiveRuntimes(ctx, epoch, stakeAcc, state, regState); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -183,6 +198,14 @@ func (app *rootHashApplication) suspendUnpaidRuntime(
 		"runtime_id", rtState.Runtime.ID,
 	)
 
+	return app.suspendRuntime(ctx, rtState, regState)
+}
+
+func (app *rootHashApplication) suspendRuntime(
+	ctx *tmapi.Context,
+	rtState *roothashState.RuntimeState,
+	regState *registryState.MutableState,
+) error {
 	if err := regState.SuspendRuntime(ctx, rtState.Runtime.ID); err != nil {
 		return err
 	}
@@ -198,6 +221,156 @@ func (app *rootHashApplication) suspendUnpaidRuntime(
 	return nil
 }
 
+// checkRuntimeLiveness suspends the runtime in case it has failed too many consecutive rounds.
+//
+// The caller must take care of persisting the runtime state.
+func (app *rootHashApplication) checkRuntimeLiveness(ctx *tmapi.Context, rtState *roothashState.RuntimeState) error {
+	state := roothashState.NewMutableState(ctx.State())
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get consensus parameters: %w", err)
+	}
+	if params.MaxFailedRounds == 0 || params.DebugDoNotSuspendRuntimes {
+		return nil
+	}
+	if rtState.Suspended || rtState.FailedRounds < params.MaxFailedRounds {
+		return nil
+	}
+
+	ctx.Logger().Warn("too many consecutive failed rounds for runtime, suspending",
+		"runtime_id", rtState.Runtime.ID,
+		"failed_rounds", rtState.FailedRounds,
+	)
+
+	// Clear timeout if there was one scheduled.
+	if rtState.ExecutorPool != nil && rtState.ExecutorPool.NextTimeout != commitment.TimeoutNever {
+		if err = state.ClearRoundTimeout(ctx, rtState.Runtime.ID, rtState.ExecutorPool.NextTimeout); err != nil {
+			return fmt.Errorf("failed to clear round timeout: %w", err)
+		}
+		rtState.ExecutorPool.NextTimeout = commitment.TimeoutNever
+	}
+
+	failedRounds := rtState.FailedRounds
+	regState := registryState.NewMutableState(ctx.State())
+	if err = app.suspendRuntime(ctx, rtState, regState); err != nil {
+		return err
+	}
+	rtState.LivenessSuspended = true
+
+	tagV := ValueLiveness{
+		ID: rtState.Runtime.ID,
+		Event: roothash.LivenessEvent{
+			Suspended:    true,
+			FailedRounds: failedRounds,
+		},
+	}
+	ctx.EmitEvent(
+		tmapi.NewEventBuilder(app.Name()).
+			Attribute(KeyLiveness, cbor.Marshal(tagV)).
+			Attribute(KeyRuntimeID, ValueRuntimeID(rtState.Runtime.ID)),
+	)
+	return nil
+}
+
+// resumeLiveRuntimes resumes runtimes suspended due to liveness in case there are enough eligible
+// compute nodes registered for them to form an executor committee.
+func (app *rootHashApplication) resumeLiveRuntimes(
+	ctx *tmapi.Context,
+	epoch epochtime.EpochTime,
+	stakeAcc *stakingState.StakeAccumulatorCache,
+	state *roothashState.MutableState,
+	regState *registryState.MutableState,
+) error {
+	rtStates, err := state.Runtimes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch runtime states: %w", err)
+	}
+
+	var nodes []*node.Node
+	for _, rtState := range rtStates {
+		if !rtState.LivenessSuspended {
+			continue
+		}
+
+		var rt *registry.Runtime
+		rt, err = regState.SuspendedRuntime(ctx, rtState.Runtime.ID)
+		switch err {
+		case nil:
+		case registry.ErrNoSuchRuntime:
+			// Runtime has been resumed or removed in the meantime.
+			continue
+		default:
+			return fmt.Errorf("failed to fetch suspended runtime: %w", err)
+		}
+
+		// Only resume a runtime if the entity has enough stake to avoid having the runtime be
+		// suspended again on the next epoch transition.
+		if stakeAcc != nil {
+			if err = stakeAcc.CheckStakeClaims(staking.NewAddress(rt.EntityID)); err != nil {
+				continue
+			}
+		}
+
+		if nodes == nil {
+			if nodes, err = regState.Nodes(ctx); err != nil {
+				return fmt.Errorf("failed to fetch nodes: %w", err)
+			}
+		}
+		var available uint64
+		for _, n := range nodes {
+			if n.IsExpired(uint64(epoch)) || !n.HasRoles(node.RoleComputeWorker) || n.GetRuntime(rt.ID) == nil {
+				continue
+			}
+			var status *registry.NodeStatus
+			if status, err = regState.NodeStatus(ctx, n.ID); err != nil {
+				return fmt.Errorf("failed to fetch node status: %w", err)
+			}
+			if status.IsFrozen() {
+				continue
+			}
+			available++
+		}
+		if available < rt.Executor.GroupSize+rt.Executor.GroupBackupSize {
+			ctx.Logger().Debug("not enough compute nodes to resume runtime",
+				"runtime_id", rt.ID,
+				"available", available,
+			)
+			continue
+		}
+
+		ctx.Logger().Info("sufficient committee available for runtime, resuming",
+			"runtime_id", rt.ID,
+			"available", available,
+		)
+
+		if err = regState.ResumeRuntime(ctx, rt.ID); err != nil {
+			return fmt.Errorf("failed to resume runtime: %w", err)
+		}
+
+		// The runtime will remain suspended in the roothash until the scheduler elects its
+		// committees on the next epoch transition.
+		rtState.Runtime = rt
+		rtState.LivenessSuspended = false
+		rtState.FailedRounds = 0
+		if err = state.SetRuntimeState(ctx, rtState); err != nil {
+			return fmt.Errorf("failed to set runtime state: %w", err)
+		}
+
+		ctx.EmitEvent(tmapi.NewEventBuilder(registryapp.AppName).Attribute(registryapp.KeyRuntimeRegistered, cbor.Marshal(rt)))
+
+		tagV := ValueLiveness{
+			ID:    rt.ID,
+			Event: roothash.LivenessEvent{Suspended: false},
+		}
+		ctx.EmitEvent(
+			tmapi.NewEventBuilder(app.Name()).
+				Attribute(KeyLiveness, cbor.Marshal(tagV)).
+				Attribute(KeyRuntimeID, ValueRuntimeID(rt.ID)),
+		)
+	}
+	return nil
+}
+
 func (app *rootHashApplication) prepareNewCommittees(
 	ctx *tmapi.Context,
 	epoch epochtime.EpochTime,
@@ -236,9 +409,20 @@ func (app *rootHashApplication) prepareNewCommittees(
 
 func (app *rootHashApplication) emitEmptyBlock(ctx *tmapi.Context, runtime *roothashState.RuntimeState, hdrType block.HeaderType) error {
 	blk := block.NewEmptyBlock(runtime.CurrentBlock, uint64(ctx.Now().Unix()), hdrType)
+	blk.Header.MessageResults = runtime.MessageResults
 
 	runtime.CurrentBlock = blk
 	runtime.CurrentBlockHeight = ctx.BlockHeight()
+	runtime.CurrentBlockTags = nil
+	runtime.MessageResults = nil
+	runtime.LastRoundResults = &roothash.RoundResults{
+		Round:  blk.Header.Round,
+		Failed: hdrType == block.RoundFailed,
+		Empty:  true,
+	}
+	if hdrType == block.RoundFailed {
+		runtime.FailedRounds++
+	}
 	if runtime.ExecutorPool != nil {
 		// Clear timeout if there was one scheduled.
 		if runtime.ExecutorPool.NextTimeout != commitment.TimeoutNever {
@@ -254,11 +438,19 @@ func (app *rootHashApplication) emitEmptyBlock(ctx *tmapi.Context, runtime *root
 		ID:    runtime.Runtime.ID,
 		Round: blk.Header.Round,
 	}
-	ctx.EmitEvent(
-		tmapi.NewEventBuilder(app.Name()).
-			Attribute(KeyFinalized, cbor.Marshal(tagV)).
-			Attribute(KeyRuntimeID, ValueRuntimeID(runtime.Runtime.ID)),
-	)
+	evb := tmapi.NewEventBuilder(app.Name()).
+		Attribute(KeyFinalized, cbor.Marshal(tagV)).
+		Attribute(KeyRuntimeID, ValueRuntimeID(runtime.Runtime.ID))
+	if hdrType == block.RoundFailed {
+		evb = evb.Attribute(KeyRoundFailed, cbor.Marshal(ValueRoundFailed{
+			ID: runtime.Runtime.ID,
+			Event: roothash.RoundFailedEvent{
+				Round:        blk.Header.Round,
+				FailedRounds: runtime.FailedRounds,
+			},
+		}))
+	}
+	ctx.EmitEvent(evb)
 	return nil
 }
 
@@ -425,6 +617,9 @@ func (app *rootHashApplication) processRoundTimeout(ctx *tmapi.Context, state *r
 		)
 		return fmt.Errorf("failed to finalize block: %w", err)
 	}
+	if err = app.checkRuntimeLiveness(ctx, rtState); err != nil {
+		return fmt.Errorf("failed to check runtime liveness: %w", err)
+	}
 
 	if err = state.SetRuntimeState(ctx, rtState); err != nil {
 		return fmt.Errorf("failed to set runtime state: %w", err)
@@ -438,7 +633,7 @@ func (app *rootHashApplication) tryFinalizeExecutorCommits(
 	ctx *tmapi.Context,
 	rtState *roothashState.RuntimeState,
 	forced bool,
-) (*block.Block, error) {
+) (*block.Block, []block.Tag, error) {
 	runtime := rtState.Runtime
 	blockNr := rtState.CurrentBlock.Header.Round
 
@@ -456,19 +651,26 @@ func (app *rootHashApplication) tryFinalizeExecutorCommits(
 		blk := block.NewEmptyBlock(rtState.CurrentBlock, uint64(ctx.Now().Unix()), block.Normal)
 		blk.Header.IORoot = *hdr.IORoot
 		blk.Header.StateRoot = *hdr.StateRoot
-		// Messages omitted on purpose.
+		blk.Header.Messages = hdr.Messages
+		blk.Header.MessageResults = rtState.MessageResults
+
+		// Message results are filled in once the block has been post-processed.
+		rtState.LastRoundResults = &roothash.RoundResults{
+			Round:           blk.Header.Round,
+			BadComputeNodes: rtState.ExecutorPool.GetBadComputeNodes(commit),
+		}
 
 		// Timeout will be cleared by caller.
 		rtState.ExecutorPool.ResetCommitments()
 
-		return blk, nil
+		return blk, hdr.Tags, nil
 	case commitment.ErrStillWaiting:
 		// Need more commits.
 		ctx.Logger().Debug("insufficient commitments for finality, waiting",
 			"round", blockNr,
 		)
 
-		return nil, nil
+		return nil, nil, nil
 	case commitment.ErrDiscrepancyDetected:
 		// Discrepancy has been detected.
 		ctx.Logger().Warn("executor discrepancy detected",
@@ -479,7 +681,8 @@ func (app *rootHashApplication) tryFinalizeExecutorCommits(
 		tagV := ValueExecutionDiscrepancyDetected{
 			ID: runtime.ID,
 			Event: roothash.ExecutionDiscrepancyDetectedEvent{
-				Timeout: forced,
+				Timeout:         forced,
+				ProposerTimeout: rtState.ExecutorPool.BackupScheduling,
 			},
 		}
 		ctx.EmitEvent(
@@ -487,7 +690,7 @@ func (app *rootHashApplication) tryFinalizeExecutorCommits(
 				Attribute(KeyExecutionDiscrepancyDetected, cbor.Marshal(tagV)).
 				Attribute(KeyRuntimeID, ValueRuntimeID(runtime.ID)),
 		)
-		return nil, nil
+		return nil, nil, nil
 	default:
 	}
 
@@ -499,23 +702,22 @@ func (app *rootHashApplication) tryFinalizeExecutorCommits(
 	)
 
 	if err := app.emitEmptyBlock(ctx, rtState, block.RoundFailed); err != nil {
-		return nil, fmt.Errorf("failed to emit empty block: %w", err)
+		return nil, nil, fmt.Errorf("failed to emit empty block: %w", err)
 	}
 
-	return nil, nil
+	return nil, nil, nil
 }
 
-func (app *rootHashApplication) postProcessFinalizedBlock(ctx *tmapi.Context, rtState *roothashState.RuntimeState, blk *block.Block) error {
-	sc := ctx.StartCheckpoint()
-	defer sc.Close()
-
+func (app *rootHashApplication) postProcessFinalizedBlock(
+	ctx *tmapi.Context,
+	rtState *roothashState.RuntimeState,
+	blk *block.Block,
+	tags []block.Tag,
+) error {
 	for _, message := range blk.Header.Messages {
-		// Currently there are no valid roothash messages, so any message
-		// is treated as unsatisfactory. This is the place which would
-		// otherwise contain message handlers.
-		unsat := errors.New("tendermint/roothash: message is invalid")
-
-		if unsat != nil {
+		// Messages should have already been validated by the commitment pool, but make sure
+		// to not execute any messages in case any of them is malformed.
+		if unsat := message.ValidateBasic(); unsat != nil {
 			ctx.Logger().Error("handler not satisfied with message",
 				"err", unsat,
 				"message", message,
@@ -531,24 +733,86 @@ func (app *rootHashApplication) postProcessFinalizedBlock(ctx *tmapi.Context, rt
 		}
 	}
 
-	sc.Commit()
+	// Execute messages on behalf of the runtime account. Failed messages do not cause the round
+	// to fail, instead their results are reported to the runtime in the next block.
+	var results []*block.MessageResult
+	if len(blk.Header.Messages) > 0 {
+		rtAddr := staking.NewRuntimeAddress(rtState.Runtime.ID)
+		results = make([]*block.MessageResult, 0, len(blk.Header.Messages))
+		for _, message := range blk.Header.Messages {
+			result, err := app.executeMessage(ctx, rtAddr, message)
+			if err != nil {
+				return fmt.Errorf("failed to execute message: %w", err)
+			}
+			results = append(results, result)
+		}
+	}
 
 	// All good. Hook up the new block.
 	rtState.CurrentBlock = blk
 	rtState.CurrentBlockHeight = ctx.BlockHeight()
+	rtState.CurrentBlockTags = tags
+	rtState.MessageResults = results
+	rtState.LastRoundResults.Messages = results
+	rtState.FailedRounds = 0
 
 	tagV := ValueFinalized{
 		ID:    rtState.Runtime.ID,
 		Round: blk.Header.Round,
+		Tags:  tags,
 	}
-	ctx.EmitEvent(
-		tmapi.NewEventBuilder(app.Name()).
-			Attribute(KeyFinalized, cbor.Marshal(tagV)).
-			Attribute(KeyRuntimeID, ValueRuntimeID(rtState.Runtime.ID)),
-	)
+	evb := tmapi.NewEventBuilder(app.Name()).
+		Attribute(KeyFinalized, cbor.Marshal(tagV)).
+		Attribute(KeyRuntimeID, ValueRuntimeID(rtState.Runtime.ID))
+	// Also emit each tag as a separate attribute so that it can be indexed.
+	for _, tag := range tags {
+		evb = evb.Attribute(KeyTag(tag.Key), []byte(tag.Value))
+	}
+	ctx.EmitEvent(evb)
 	return nil
 }
 
+// executeMessage executes a single runtime message on behalf of the given runtime account.
+//
+// Any state changes made by a failed message are discarded. Failures to access state are not
+// reported as message results and are instead returned as errors.
+func (app *rootHashApplication) executeMessage(
+	ctx *tmapi.Context,
+	rtAddr staking.Address,
+	message *block.Message,
+) (*block.MessageResult, error) {
+	child := ctx.NewMessageExecutionChild(rtAddr)
+
+	var err error
+	switch {
+	case message.Staking != nil:
+		err = stakingapp.ExecuteMessage(child, message.Staking)
+	}
+	if err != nil {
+		child.Close()
+
+		if tmapi.IsUnavailableStateError(err) {
+			// Do not record state access failures as failed messages as that would cause the
+			// block to be committed on top of unavailable/corrupted state.
+			return nil, err
+		}
+
+		ctx.Logger().Debug("failed to execute runtime message",
+			"err", err,
+			"runtime_address", rtAddr,
+		)
+
+		module, code := errors.Code(err)
+		return &block.MessageResult{
+			Module: module,
+			Code:   code,
+		}, nil
+	}
+	child.CommitChild()
+
+	return &block.MessageResult{}, nil
+}
+
 func (app *rootHashApplication) tryFinalizeBlock(
 	ctx *tmapi.Context,
 	rtState *roothashState.RuntimeState,
@@ -590,7 +854,7 @@ func (app *rootHashApplication) tryFinalizeBlock(
 		}
 	}(rtState.ExecutorPool.NextTimeout)
 
-	finalizedBlock, err := app.tryFinalizeExecutorCommits(ctx, rtState, forced)
+	finalizedBlock, tags, err := app.tryFinalizeExecutorCommits(ctx, rtState, forced)
 	if err != nil {
 		return fmt.Errorf("failed to finalize executor commits: %w", err)
 	}
@@ -598,7 +862,7 @@ func (app *rootHashApplication) tryFinalizeBlock(
 		return nil
 	}
 
-	if err = app.postProcessFinalizedBlock(ctx, rtState, finalizedBlock); err != nil {
+	if err = app.postProcessFinalizedBlock(ctx, rtState, finalizedBlock, tags); err != nil {
 		return fmt.Errorf("failed to post process finalized block: %w", err)
 	}
 	return nil
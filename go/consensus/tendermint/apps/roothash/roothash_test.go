@@ -0,0 +1,101 @@
+package roothash
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	roothashState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+)
+
+// failingTree is a state tree that fails all reads once failing is set.
+type failingTree struct {
+	mkvs.Tree
+
+	failing bool
+}
+
+func (t *failingTree) Get(ctx context.Context, key []byte) ([]byte, error) {
+	if t.failing {
+		return nil, fmt.Errorf("state unavailable")
+	}
+	return t.Tree.Get(ctx, key)
+}
+
+func TestExecuteMessage(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := tmapi.NewMockApplicationState(&tmapi.MockApplicationStateConfig{})
+	tree := &failingTree{Tree: mkvs.New(nil, nil)}
+	defer tree.Close()
+	ctx := tmapi.NewContext(context.Background(), tmapi.ContextEndBlock, now, tmapi.NewNopGasAccountant(), appState, tree, 1, tmapi.NewBlockContext(), 1)
+	defer ctx.Close()
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("roothash execute message test"), 0)
+	rtAddr := staking.NewRuntimeAddress(runtimeID)
+	toAddr := staking.NewAddress(memorySigner.NewTestSigner("roothash execute message test to").Public())
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+	err := stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{})
+	require.NoError(err, "SetConsensusParameters")
+	var rtAcct staking.Account
+	rtAcct.General.Balance = *quantity.NewFromUint64(100)
+	err = stakeState.SetAccount(ctx, rtAddr, &rtAcct)
+	require.NoError(err, "SetAccount")
+
+	app := &rootHashApplication{}
+	transfer := func(amount uint64) *block.Message {
+		return &block.Message{
+			Staking: &block.StakingMessage{
+				Transfer: &staking.Transfer{To: toAddr, Amount: *quantity.NewFromUint64(amount)},
+			},
+		}
+	}
+
+	// Successful message.
+	result, err := app.executeMessage(ctx, rtAddr, transfer(10))
+	require.NoError(err, "executeMessage")
+	require.Equal(&block.MessageResult{}, result, "message should succeed")
+	acct, err := stakeState.Account(ctx, toAddr)
+	require.NoError(err, "Account")
+	require.Equal(*quantity.NewFromUint64(10), acct.General.Balance, "message state changes should be committed")
+
+	// Failed message.
+	result, err = app.executeMessage(ctx, rtAddr, transfer(1000))
+	require.NoError(err, "executeMessage")
+	require.NotZero(result.Code, "failed message should be reported in the result")
+	acct, err = stakeState.Account(ctx, toAddr)
+	require.NoError(err, "Account")
+	require.Equal(*quantity.NewFromUint64(10), acct.General.Balance, "failed message state changes should be discarded")
+
+	// Failures to access state should not be reported as message results.
+	tree.failing = true
+	result, err = app.executeMessage(ctx, rtAddr, transfer(10))
+	require.Error(err, "executeMessage should fail on unavailable state")
+	require.True(tmapi.IsUnavailableStateError(err), "error should be an unavailable state error")
+	require.Nil(result)
+
+	rtState := &roothashState.RuntimeState{
+		Runtime: &registry.Runtime{ID: runtimeID},
+	}
+	blk := block.NewGenesisBlock(runtimeID, 0)
+	blk.Header.Messages = []*block.Message{transfer(10)}
+	err = app.postProcessFinalizedBlock(ctx, rtState, blk, nil)
+	require.Error(err, "postProcessFinalizedBlock should fail on unavailable state")
+	require.True(tmapi.IsUnavailableStateError(err), "error should be an unavailable state error")
+	require.Nil(rtState.CurrentBlock, "block should not be finalized on unavailable state")
+	require.Nil(rtState.MessageResults, "no message results should be recorded on unavailable state")
+}
@@ -34,11 +34,23 @@ var (
 type RuntimeState struct {
 	Runtime   *registry.Runtime `json:"runtime"`
 	Suspended bool              `json:"suspended,omitempty"`
+	// LivenessSuspended is true iff the runtime has been suspended due to
+	// too many consecutive failed rounds.
+	LivenessSuspended bool `json:"liveness_suspended,omitempty"`
+	// FailedRounds is the number of consecutive failed rounds.
+	FailedRounds uint64 `json:"failed_rounds,omitempty"`
 
 	GenesisBlock *block.Block `json:"genesis_block"`
 
 	CurrentBlock       *block.Block `json:"current_block"`
 	CurrentBlockHeight int64        `json:"current_block_height"`
+	// CurrentBlockTags are the tags attached by the runtime to the current block.
+	CurrentBlockTags []block.Tag `json:"current_block_tags,omitempty"`
+	// MessageResults are the results of executing the messages emitted in the current block. They
+	// are included in the header of the next block.
+	MessageResults []*block.MessageResult `json:"message_results,omitempty"`
+	// LastRoundResults are the results of the round of the current block.
+	LastRoundResults *roothash.RoundResults `json:"last_round_results,omitempty"`
 
 	ExecutorPool *commitment.Pool `json:"executor_pool"`
 }
@@ -161,6 +161,30 @@ func (app *rootHashApplication) executorProposerTimeout(
 		return err
 	}
 
+	if rtState.Runtime.TxnScheduler.IsProposerTimeoutEscalated() {
+		// Timeout triggered by executor node, activate the backup workers to produce the round.
+		ctx.Logger().Warn("proposer round timeout, escalating to backup workers",
+			"round", rpt.Round,
+			logging.LogEvent, roothash.LogEventRoundEscalated,
+		)
+		if err = rtState.ExecutorPool.EscalateProposerTimeout(); err != nil {
+			return fmt.Errorf("failed to escalate proposer timeout: %w", err)
+		}
+		if err = app.tryFinalizeBlock(ctx, rtState, true); err != nil {
+			return fmt.Errorf("failed to activate backup workers: %w", err)
+		}
+		if err = app.checkRuntimeLiveness(ctx, rtState); err != nil {
+			return fmt.Errorf("failed to check runtime liveness: %w", err)
+		}
+
+		// Update runtime state.
+		if err = state.SetRuntimeState(ctx, rtState); err != nil {
+			return fmt.Errorf("failed to set runtime state: %w", err)
+		}
+
+		return nil
+	}
+
 	// Timeout triggered by executor node, emit empty error block.
 	ctx.Logger().Error("proposer round timeout",
 		"round", rpt.Round,
@@ -170,6 +194,9 @@ func (app *rootHashApplication) executorProposerTimeout(
 	if err = app.emitEmptyBlock(ctx, rtState, block.RoundFailed); err != nil {
 		return fmt.Errorf("failed to emit empty block: %w", err)
 	}
+	if err = app.checkRuntimeLiveness(ctx, rtState); err != nil {
+		return fmt.Errorf("failed to check runtime liveness: %w", err)
+	}
 
 	// Update runtime state.
 	if err = state.SetRuntimeState(ctx, rtState); err != nil {
@@ -205,14 +232,12 @@ func (app *rootHashApplication) executorCommit(
 		return err
 	}
 
-	for _, commit := range cc.Commits {
-		if err = rtState.ExecutorPool.AddExecutorCommitment(ctx, rtState.CurrentBlock, sv, nl, &commit); err != nil { // nolint: gosec
-			ctx.Logger().Error("failed to add compute commitment to round",
-				"err", err,
-				"round", rtState.CurrentBlock.Header.Round,
-			)
-			return err
-		}
+	if err = rtState.ExecutorPool.AddExecutorCommitments(ctx, rtState.CurrentBlock, sv, nl, cc.Commits); err != nil {
+		ctx.Logger().Error("failed to add compute commitments to round",
+			"err", err,
+			"round", rtState.CurrentBlock.Header.Round,
+		)
+		return err
 	}
 
 	// Try to finalize round.
@@ -222,6 +247,9 @@ func (app *rootHashApplication) executorCommit(
 		)
 		return err
 	}
+	if err = app.checkRuntimeLiveness(ctx, rtState); err != nil {
+		return fmt.Errorf("failed to check runtime liveness: %w", err)
+	}
 
 	// Update runtime state.
 	if err = state.SetRuntimeState(ctx, rtState); err != nil {
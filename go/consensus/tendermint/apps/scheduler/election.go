@@ -0,0 +1,177 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
+	epochtime "github.com/oasisprotocol/oasis-core/go/epochtime/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+var (
+	// errCommitteeNotElectable is the error returned when a committee can not be elected
+	// from the set of eligible nodes.
+	errCommitteeNotElectable = errors.New("committee not electable")
+
+	// errInvalidTEEAttestation is the error returned when a node's TEE attestation fails
+	// to verify.
+	errInvalidTEEAttestation = errors.New("invalid TEE attestation")
+)
+
+// committeeParameters are the parameters used when electing a committee of a given kind.
+type committeeParameters struct {
+	rngCtx          []byte
+	checkSuitableFn func(*node.Node, *registry.Runtime, time.Time) error
+
+	workerSize, backupSize int
+}
+
+func getCommitteeParameters(rt *registry.Runtime, kind scheduler.CommitteeKind) (*committeeParameters, error) {
+	switch kind {
+	case scheduler.KindComputeExecutor:
+		return &committeeParameters{
+			rngCtx:          RNGContextExecutor,
+			checkSuitableFn: checkSuitableExecutorWorker,
+			workerSize:      int(rt.Executor.GroupSize),
+			backupSize:      int(rt.Executor.GroupBackupSize),
+		}, nil
+	case scheduler.KindStorage:
+		return &committeeParameters{
+			rngCtx:          RNGContextStorage,
+			checkSuitableFn: checkSuitableStorageWorker,
+			workerSize:      int(rt.Storage.GroupSize),
+		}, nil
+	default:
+		return nil, fmt.Errorf("tendermint/scheduler: invalid committee type: %v", kind)
+	}
+}
+
+func checkSuitableExecutorWorker(n *node.Node, rt *registry.Runtime, now time.Time) error {
+	if !n.HasRoles(node.RoleComputeWorker) {
+		return fmt.Errorf("node does not have the compute worker role")
+	}
+	for _, nrt := range n.Runtimes {
+		if !nrt.ID.Equal(&rt.ID) {
+			continue
+		}
+		switch rt.TEEHardware {
+		case node.TEEHardwareInvalid:
+			if nrt.Capabilities.TEE != nil {
+				return fmt.Errorf("node has TEE capabilities but the runtime does not use a TEE")
+			}
+			return nil
+		default:
+			if nrt.Capabilities.TEE == nil {
+				return fmt.Errorf("node has no TEE capabilities")
+			}
+			if nrt.Capabilities.TEE.Hardware != rt.TEEHardware {
+				return fmt.Errorf("node TEE hardware %s does not match the runtime TEE hardware %s",
+					nrt.Capabilities.TEE.Hardware,
+					rt.TEEHardware,
+				)
+			}
+			if err := nrt.Capabilities.TEE.Verify(now); err != nil {
+				return fmt.Errorf("%w: %s", errInvalidTEEAttestation, err)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("node does not support the runtime")
+}
+
+func checkSuitableStorageWorker(n *node.Node, rt *registry.Runtime, now time.Time) error {
+	if !n.HasRoles(node.RoleStorageWorker) {
+		return fmt.Errorf("node does not have the storage worker role")
+	}
+	for _, nrt := range n.Runtimes {
+		if !nrt.ID.Equal(&rt.ID) {
+			continue
+		}
+		return nil
+	}
+	return fmt.Errorf("node does not support the runtime")
+}
+
+// electCommitteeMembers elects the committee members from the given list of eligible nodes.
+//
+// In case it is not possible to elect a valid committee, an error wrapping
+// errCommitteeNotElectable is returned.
+func electCommitteeMembers(
+	beacon []byte,
+	rt *registry.Runtime,
+	cp *committeeParameters,
+	nodeList []*node.Node,
+) ([]*scheduler.CommitteeNode, error) {
+	// Ensure that it is theoretically possible to elect a valid committee.
+	if cp.workerSize == 0 {
+		return nil, fmt.Errorf("%w: empty committee not allowed", errCommitteeNotElectable)
+	}
+
+	nrNodes, wantedNodes := len(nodeList), cp.workerSize+cp.backupSize
+	if wantedNodes > nrNodes {
+		return nil, fmt.Errorf("%w: committee size %d exceeds available nodes %d",
+			errCommitteeNotElectable,
+			wantedNodes,
+			nrNodes,
+		)
+	}
+
+	idxs, err := GetPerm(beacon, rt.ID, cp.rngCtx, nrNodes)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []*scheduler.CommitteeNode
+	for i := 0; i < len(idxs); i++ {
+		role := scheduler.RoleWorker
+		if i >= cp.workerSize {
+			role = scheduler.RoleBackupWorker
+		}
+		members = append(members, &scheduler.CommitteeNode{
+			Role:      role,
+			PublicKey: nodeList[idxs[i]].ID,
+		})
+		if len(members) >= wantedNodes {
+			break
+		}
+	}
+
+	if len(members) != wantedNodes {
+		return nil, fmt.Errorf("%w: insufficient nodes with adequate stake to elect", errCommitteeNotElectable)
+	}
+	return members, nil
+}
+
+// schedulableNodes returns the registered nodes that can be scheduled in the given epoch.
+func schedulableNodes(ctx context.Context, regState *registryState.ImmutableState, epoch epochtime.EpochTime) ([]*node.Node, error) {
+	allNodes, err := regState.Nodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("tendermint/scheduler: couldn't get nodes: %w", err)
+	}
+
+	var nodes []*node.Node
+	for _, n := range allNodes {
+		var status *registry.NodeStatus
+		status, err = regState.NodeStatus(ctx, n.ID)
+		if err != nil {
+			return nil, fmt.Errorf("tendermint/scheduler: couldn't get node status: %w", err)
+		}
+
+		// Nodes which are currently frozen cannot be scheduled.
+		if status.IsFrozen() {
+			continue
+		}
+		// Expired nodes cannot be scheduled (nodes can be expired and not yet removed).
+		if n.IsExpired(uint64(epoch)) {
+			continue
+		}
+
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
@@ -2,12 +2,21 @@ package scheduler
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/beacon/state"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
 	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/scheduler/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
 
 // Query is the scheduler query interface.
@@ -16,6 +25,7 @@ type Query interface {
 	AllCommittees(context.Context) ([]*scheduler.Committee, error)
 	KindsCommittees(context.Context, []scheduler.CommitteeKind) ([]*scheduler.Committee, error)
 	Genesis(context.Context) (*scheduler.Genesis, error)
+	SimulateElection(context.Context, common.Namespace, scheduler.CommitteeKind) (*scheduler.ElectionSimulation, error)
 }
 
 // QueryFactory is the scheduler query factory.
@@ -36,12 +46,14 @@ func (sf *QueryFactory) QueryAt(ctx context.Context, height int64) (Query, error
 		return nil, err
 	}
 
-	return &schedulerQuerier{state, regState}, nil
+	return &schedulerQuerier{sf.state, state, regState, height}, nil
 }
 
 type schedulerQuerier struct {
-	state    *schedulerState.ImmutableState
-	regState *registryState.ImmutableState
+	queryState abciAPI.ApplicationQueryState
+	state      *schedulerState.ImmutableState
+	regState   *registryState.ImmutableState
+	height     int64
 }
 
 func (sq *schedulerQuerier) Validators(ctx context.Context) ([]*scheduler.Validator, error) {
@@ -98,6 +110,119 @@ func (sq *schedulerQuerier) KindsCommittees(ctx context.Context, kinds []schedul
 	return sq.state.KindsCommittees(ctx, kinds)
 }
 
+func (sq *schedulerQuerier) SimulateElection(
+	ctx context.Context,
+	runtimeID common.Namespace,
+	kind scheduler.CommitteeKind,
+) (*scheduler.ElectionSimulation, error) {
+	epoch, err := sq.queryState.GetEpoch(ctx, sq.height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epoch: %w", err)
+	}
+	rt, err := sq.regState.Runtime(ctx, runtimeID)
+	if err != nil {
+		return nil, err
+	}
+	cp, err := getCommitteeParameters(rt, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	sim := &scheduler.ElectionSimulation{
+		Epoch:      epoch,
+		Candidates: []*scheduler.ElectionCandidate{},
+	}
+	// Only generic compute runtimes elect all the committees.
+	if !rt.IsCompute() && kind != scheduler.KindComputeExecutor {
+		sim.Reason = fmt.Sprintf("runtime does not elect %s committees", kind)
+		return sim, nil
+	}
+
+	params, err := sq.state.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	beacState, err := beaconState.NewImmutableState(ctx, sq.queryState, sq.height)
+	if err != nil {
+		return nil, err
+	}
+	beacon, err := beacState.Beacon(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get beacon: %w", err)
+	}
+	var stakeState *stakingState.ImmutableState
+	var thresholds map[staking.ThresholdKind]quantity.Quantity
+	if !params.DebugBypassStake {
+		if stakeState, err = stakingState.NewImmutableState(ctx, sq.queryState, sq.height); err != nil {
+			return nil, err
+		}
+		if thresholds, err = stakeState.Thresholds(ctx); err != nil {
+			return nil, fmt.Errorf("couldn't get staking thresholds: %w", err)
+		}
+	}
+	nodes, err := schedulableNodes(ctx, sq.regState, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	// Determine eligibility the same way as during elections. As block time is not available
+	// to queries, TEE attestations are verified against the local time.
+	now := time.Now()
+	candidates := make(map[signature.PublicKey]*scheduler.ElectionCandidate)
+	var nodeList []*node.Node
+	for _, n := range nodes {
+		if n.GetRuntime(runtimeID) == nil {
+			continue
+		}
+
+		candidate := &scheduler.ElectionCandidate{
+			ID:       n.ID,
+			EntityID: n.EntityID,
+		}
+		sim.Candidates = append(sim.Candidates, candidate)
+		candidates[n.ID] = candidate
+
+		if stakeState != nil {
+			acct, aerr := stakeState.Account(ctx, staking.NewAddress(n.EntityID))
+			if aerr != nil {
+				return nil, fmt.Errorf("couldn't get entity account: %w", aerr)
+			}
+			if aerr = acct.Escrow.CheckStakeClaims(thresholds); aerr != nil {
+				candidate.Reason = fmt.Sprintf("insufficient entity stake: %s", aerr)
+				continue
+			}
+		}
+		if serr := cp.checkSuitableFn(n, rt, now); serr != nil {
+			candidate.Reason = serr.Error()
+			continue
+		}
+
+		candidate.Eligible = true
+		nodeList = append(nodeList, n)
+	}
+
+	members, err := electCommitteeMembers(beacon, rt, cp, nodeList)
+	switch {
+	case err == nil:
+	case errors.Is(err, errCommitteeNotElectable):
+		sim.Reason = err.Error()
+		return sim, nil
+	default:
+		return nil, err
+	}
+
+	for _, m := range members {
+		candidates[m.PublicKey].Role = m.Role
+	}
+	sim.Committee = &scheduler.Committee{
+		Kind:      kind,
+		RuntimeID: runtimeID,
+		Members:   members,
+		ValidFor:  epoch,
+	}
+	return sim, nil
+}
+
 func (app *schedulerApplication) QueryFactory() interface{} {
 	return &QueryFactory{app.state}
 }
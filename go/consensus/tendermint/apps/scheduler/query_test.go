@@ -0,0 +1,149 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/beacon/state"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
+	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/scheduler/state"
+	epochtime "github.com/oasisprotocol/oasis-core/go/epochtime/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+func TestSimulateElection(t *testing.T) {
+	require := require.New(t)
+
+	const epoch = epochtime.EpochTime(2)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{
+		BlockHeight:  1,
+		CurrentEpoch: epoch,
+	})
+	ctx := appState.NewContext(abciAPI.ContextBeginBlock, now)
+	defer ctx.Close()
+
+	app := &schedulerApplication{state: appState}
+
+	err := schedulerState.NewMutableState(ctx.State()).SetConsensusParameters(ctx, &scheduler.ConsensusParameters{
+		DebugBypassStake: true,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	beaconValue := make([]byte, beacon.BeaconSize)
+	copy(beaconValue, []byte("scheduler simulate election test"))
+	err = beaconState.NewMutableState(ctx.State()).SetBeacon(ctx, beaconValue)
+	require.NoError(err, "SetBeacon")
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("scheduler simulate election test"), 0)
+	rt := &registry.Runtime{
+		ID:   runtimeID,
+		Kind: registry.KindCompute,
+		Executor: registry.ExecutorParameters{
+			GroupSize:       3,
+			GroupBackupSize: 2,
+		},
+		Storage: registry.StorageParameters{
+			GroupSize: 2,
+		},
+	}
+	regState := registryState.NewMutableState(ctx.State())
+	err = regState.SetRuntime(ctx, rt, &registry.SignedRuntime{Signed: signature.Signed{Blob: cbor.Marshal(rt)}}, false)
+	require.NoError(err, "SetRuntime")
+
+	// Register a set of eligible nodes together with some nodes that are not eligible for
+	// election for various reasons.
+	var ineligible []signature.PublicKey
+	for i := 0; i < 10; i++ {
+		var nodeID, entityID signature.PublicKey
+		nodeID[0], entityID[0] = byte(i), byte(i)
+		n := &node.Node{
+			ID:         nodeID,
+			EntityID:   entityID,
+			Expiration: uint64(epoch) + 1,
+			Roles:      node.RoleComputeWorker | node.RoleStorageWorker,
+			Runtimes: []*node.Runtime{
+				{ID: runtimeID},
+			},
+		}
+		switch i {
+		case 0:
+			// Node without any roles.
+			n.Roles = 0
+			ineligible = append(ineligible, nodeID)
+		case 1:
+			// Node without the runtime (not a candidate).
+			n.Runtimes = nil
+		case 2:
+			// Expired node.
+			n.Expiration = uint64(epoch) - 1
+		}
+
+		err = regState.SetNode(ctx, nil, n, &node.MultiSignedNode{MultiSigned: signature.MultiSigned{Blob: cbor.Marshal(n)}})
+		require.NoError(err, "SetNode")
+		err = regState.SetNodeStatus(ctx, n.ID, &registry.NodeStatus{})
+		require.NoError(err, "SetNodeStatus")
+	}
+
+	// Perform the real election.
+	nodes, err := schedulableNodes(ctx, regState.ImmutableState, epoch)
+	require.NoError(err, "schedulableNodes")
+	require.Len(nodes, 9, "expired nodes should not be schedulable")
+
+	query, err := NewQueryFactory(appState).QueryAt(ctx, ctx.BlockHeight()+1)
+	require.NoError(err, "QueryAt")
+
+	for _, kind := range []scheduler.CommitteeKind{
+		scheduler.KindComputeExecutor,
+		scheduler.KindStorage,
+	} {
+		err = app.electCommittee(ctx, epoch, beaconValue, nil, nil, rt, nodes, kind)
+		require.NoError(err, "electCommittee")
+
+		var committee *scheduler.Committee
+		committee, err = schedulerState.NewMutableState(ctx.State()).Committee(ctx, kind, runtimeID)
+		require.NoError(err, "Committee")
+		require.NotNil(committee, "committee should be elected")
+
+		// Simulating the election should result in the same committee.
+		var sim *scheduler.ElectionSimulation
+		sim, err = query.SimulateElection(ctx, runtimeID, kind)
+		require.NoError(err, "SimulateElection")
+		require.Equal(epoch, sim.Epoch, "simulation should be for the current epoch")
+		require.Empty(sim.Reason, "simulation should elect a committee")
+		require.NotNil(sim.Committee, "simulation should elect a committee")
+		require.EqualValues(committee, sim.Committee, "simulated committee should match the elected committee")
+
+		// Candidates should reflect eligibility and elected roles.
+		require.Len(sim.Candidates, 8, "only schedulable nodes supporting the runtime should be candidates")
+		roles := make(map[signature.PublicKey]scheduler.Role)
+		for _, member := range committee.Members {
+			roles[member.PublicKey] = member.Role
+		}
+		for _, candidate := range sim.Candidates {
+			require.Equal(roles[candidate.ID], candidate.Role, "candidate role should match the elected role")
+
+			var isIneligible bool
+			for _, id := range ineligible {
+				if candidate.ID.Equal(id) {
+					isIneligible = true
+					break
+				}
+			}
+			require.Equal(!isIneligible, candidate.Eligible, "candidate eligibility should be correct")
+			if isIneligible {
+				require.NotEmpty(candidate.Reason, "ineligible candidates should have a reason")
+			}
+		}
+	}
+}
@@ -3,6 +3,7 @@ package scheduler
 import (
 	"bytes"
 	"crypto"
+	"errors"
 	"fmt"
 	"math/rand"
 	"sort"
@@ -103,30 +104,9 @@ func (app *schedulerApplication) BeginBlock(ctx *api.Context, request types.Requ
 		if err != nil {
 			return fmt.Errorf("tendermint/scheduler: couldn't get runtimes: %w", err)
 		}
-		allNodes, err := regState.Nodes(ctx)
+		nodes, err := schedulableNodes(ctx, regState.ImmutableState, epoch)
 		if err != nil {
-			return fmt.Errorf("tendermint/scheduler: couldn't get nodes: %w", err)
-		}
-
-		// Filter nodes.
-		var nodes []*node.Node
-		for _, node := range allNodes {
-			var status *registry.NodeStatus
-			status, err = regState.NodeStatus(ctx, node.ID)
-			if err != nil {
-				return fmt.Errorf("tendermint/scheduler: couldn't get node status: %w", err)
-			}
-
-			// Nodes which are currently frozen cannot be scheduled.
-			if status.IsFrozen() {
-				continue
-			}
-			// Expired nodes cannot be scheduled (nodes can be expired and not yet removed).
-			if node.IsExpired(uint64(epoch)) {
-				continue
-			}
-
-			nodes = append(nodes, node)
+			return err
 		}
 
 		state := schedulerState.NewMutableState(ctx.State())
@@ -276,53 +256,17 @@ func (app *schedulerApplication) EndBlock(ctx *api.Context, req types.RequestEnd
 	return resp, nil
 }
 
-func (app *schedulerApplication) isSuitableExecutorWorker(ctx *api.Context, n *node.Node, rt *registry.Runtime) bool {
-	if !n.HasRoles(node.RoleComputeWorker) {
-		return false
-	}
-	for _, nrt := range n.Runtimes {
-		if !nrt.ID.Equal(&rt.ID) {
-			continue
-		}
-		switch rt.TEEHardware {
-		case node.TEEHardwareInvalid:
-			if nrt.Capabilities.TEE != nil {
-				return false
-			}
-			return true
-		default:
-			if nrt.Capabilities.TEE == nil {
-				return false
-			}
-			if nrt.Capabilities.TEE.Hardware != rt.TEEHardware {
-				return false
-			}
-			if err := nrt.Capabilities.TEE.Verify(ctx.Now()); err != nil {
-				ctx.Logger().Warn("failed to verify node TEE attestaion",
-					"err", err,
-					"node", n,
-					"time_stamp", ctx.Now(),
-					"runtime", rt.ID,
-				)
-				return false
-			}
-			return true
-		}
-	}
-	return false
-}
-
-func (app *schedulerApplication) isSuitableStorageWorker(ctx *api.Context, n *node.Node, rt *registry.Runtime) bool {
-	if !n.HasRoles(node.RoleStorageWorker) {
-		return false
-	}
-	for _, nrt := range n.Runtimes {
-		if !nrt.ID.Equal(&rt.ID) {
-			continue
-		}
-		return true
+func (app *schedulerApplication) isSuitableNode(ctx *api.Context, cp *committeeParameters, n *node.Node, rt *registry.Runtime) bool {
+	err := cp.checkSuitableFn(n, rt, ctx.Now())
+	if errors.Is(err, errInvalidTEEAttestation) {
+		ctx.Logger().Warn("failed to verify node TEE attestaion",
+			"err", err,
+			"node", n,
+			"time_stamp", ctx.Now(),
+			"runtime", rt.ID,
+		)
 	}
-	return false
+	return err == nil
 }
 
 // GetPerm generates a permutation that we use to choose nodes from a list of eligible nodes to elect.
@@ -355,30 +299,12 @@ func (app *schedulerApplication) electCommittee(
 
 	// Determine the context, committee size, and pre-filter the node-list
 	// based on eligibility and entity stake.
-	var (
-		err      error
-		nodeList []*node.Node
-
-		rngCtx       []byte
-		isSuitableFn func(*api.Context, *node.Node, *registry.Runtime) bool
-
-		workerSize, backupSize int
-	)
-
-	switch kind {
-	case scheduler.KindComputeExecutor:
-		rngCtx = RNGContextExecutor
-		isSuitableFn = app.isSuitableExecutorWorker
-		workerSize = int(rt.Executor.GroupSize)
-		backupSize = int(rt.Executor.GroupBackupSize)
-	case scheduler.KindStorage:
-		rngCtx = RNGContextStorage
-		isSuitableFn = app.isSuitableStorageWorker
-		workerSize = int(rt.Storage.GroupSize)
-	default:
-		return fmt.Errorf("tendermint/scheduler: invalid committee type: %v", kind)
+	cp, err := getCommitteeParameters(rt, kind)
+	if err != nil {
+		return err
 	}
 
+	var nodeList []*node.Node
 	for _, n := range nodes {
 		// Check if an entity has enough stake.
 		entAddr := staking.NewAddress(n.EntityID)
@@ -387,7 +313,7 @@ func (app *schedulerApplication) electCommittee(
 				continue
 			}
 		}
-		if isSuitableFn(ctx, n, rt) {
+		if app.isSuitableNode(ctx, cp, n, rt) {
 			nodeList = append(nodeList, n)
 			if entitiesEligibleForReward != nil {
 				entitiesEligibleForReward[entAddr] = true
@@ -395,66 +321,25 @@ func (app *schedulerApplication) electCommittee(
 		}
 	}
 
-	// Ensure that it is theoretically possible to elect a valid committee.
-	if workerSize == 0 {
-		ctx.Logger().Error("empty committee not allowed",
-			"kind", kind,
-			"runtime_id", rt.ID,
-		)
-		if err = schedulerState.NewMutableState(ctx.State()).DropCommittee(ctx, kind, rt.ID); err != nil {
-			return fmt.Errorf("failed to drop committee: %w", err)
-		}
-		return nil
-	}
-
-	nrNodes, wantedNodes := len(nodeList), workerSize+backupSize
-	if wantedNodes > nrNodes {
-		ctx.Logger().Error("committee size exceeds available nodes (pre-stake)",
-			"kind", kind,
-			"runtime_id", rt.ID,
-			"worker_size", workerSize,
-			"backup_size", backupSize,
-			"nr_nodes", nrNodes,
-		)
-		if err = schedulerState.NewMutableState(ctx.State()).DropCommittee(ctx, kind, rt.ID); err != nil {
-			return fmt.Errorf("failed to drop committee: %w", err)
-		}
-		return nil
-	}
-
 	// Do the actual election.
-	idxs, err := GetPerm(beacon, rt.ID, rngCtx, nrNodes)
-	if err != nil {
-		return err
-	}
-
-	var members []*scheduler.CommitteeNode
-	for i := 0; i < len(idxs); i++ {
-		role := scheduler.RoleWorker
-		if i >= workerSize {
-			role = scheduler.RoleBackupWorker
-		}
-		members = append(members, &scheduler.CommitteeNode{
-			Role:      role,
-			PublicKey: nodeList[idxs[i]].ID,
-		})
-		if len(members) >= wantedNodes {
-			break
-		}
-	}
-
-	if len(members) != wantedNodes {
-		ctx.Logger().Error("insufficient nodes with adequate stake to elect",
+	members, err := electCommitteeMembers(beacon, rt, cp, nodeList)
+	switch {
+	case err == nil:
+	case errors.Is(err, errCommitteeNotElectable):
+		ctx.Logger().Error("failed to elect committee",
+			"err", err,
 			"kind", kind,
 			"runtime_id", rt.ID,
-			"worker_size", workerSize,
-			"backup_size", backupSize,
-			"available", len(members),
+			"worker_size", cp.workerSize,
+			"backup_size", cp.backupSize,
+			"nr_nodes", len(nodeList),
 		)
 		if err = schedulerState.NewMutableState(ctx.State()).DropCommittee(ctx, kind, rt.ID); err != nil {
 			return fmt.Errorf("failed to drop committee: %w", err)
 		}
 		return nil
+	default:
+		return err
 	}
 
 	err = schedulerState.NewMutableState(ctx.State()).PutCommittee(ctx, &scheduler.Committee{
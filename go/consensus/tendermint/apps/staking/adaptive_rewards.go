@@ -0,0 +1,52 @@
+package staking
+
+import (
+	"fmt"
+
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// adjustRewardScale adjusts the reward scale of the adaptive reward schedule
+// toward the target staked ratio.
+func (app *stakingApplication) adjustRewardScale(ctx *abciAPI.Context, stakeState *stakingState.MutableState) error {
+	params, err := stakeState.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("loading consensus parameters: %w", err)
+	}
+	if params.AdaptiveRewards == nil {
+		return nil
+	}
+
+	totalSupply, err := stakeState.TotalSupply(ctx)
+	if err != nil {
+		return fmt.Errorf("loading total supply: %w", err)
+	}
+	staked, err := stakeState.TotalStaked(ctx)
+	if err != nil {
+		return fmt.Errorf("loading total staked: %w", err)
+	}
+
+	current, err := stakeState.AdaptiveRewardScale(ctx)
+	if err != nil {
+		return fmt.Errorf("loading adaptive reward scale: %w", err)
+	}
+	if current == nil {
+		current = params.AdaptiveRewards.InitialScale.Clone()
+	}
+	stakedRatio := staking.StakedRatio(staked, totalSupply)
+	next := params.AdaptiveRewards.NextScale(current, stakedRatio)
+
+	ctx.Logger().Debug("adjusting adaptive reward scale",
+		"staked_ratio", stakedRatio,
+		"target_staked_ratio", params.AdaptiveRewards.TargetStakedRatio,
+		"scale", current,
+		"next_scale", next,
+	)
+
+	if err = stakeState.SetAdaptiveRewardScale(ctx, next); err != nil {
+		return fmt.Errorf("failed to set adaptive reward scale: %w", err)
+	}
+	return nil
+}
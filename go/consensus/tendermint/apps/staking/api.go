@@ -43,4 +43,22 @@ var (
 
 	// KeyAllowanceChange is an ABCI event attribute key for AllowanceChangeEvents.
 	KeyAllowanceChange = []byte("allowance_change")
+
+	// KeyCommissionDestinationChange is an ABCI event attribute key for
+	// CommissionDestinationChangeEvents.
+	KeyCommissionDestinationChange = []byte("commission_destination_change")
+
+	// KeyPolicyViolation is an ABCI event attribute key for PolicyViolationEvents.
+	KeyPolicyViolation = []byte("policy_violation")
+
+	// KeyEscrowThreshold is an ABCI event attribute key for EscrowThresholdEvents.
+	KeyEscrowThreshold = stakingState.KeyEscrowThreshold
+
+	// KeyCommissionScheduleAmendment is an ABCI event attribute key for
+	// CommissionScheduleAmendmentEvents.
+	KeyCommissionScheduleAmendment = []byte("commission_schedule_amendment")
+
+	// KeyEpochRewards is an ABCI event attribute key for epoch rewards
+	// reports (value is an api.EpochRewards).
+	KeyEpochRewards = []byte("epoch_rewards")
 )
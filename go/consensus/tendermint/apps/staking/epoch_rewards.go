@@ -0,0 +1,57 @@
+package staking
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	epochtime "github.com/oasisprotocol/oasis-core/go/epochtime/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// updateEpochRewards finalizes the rewards report of the previous epoch in
+// case this is the first block of a new epoch and starts accumulating the
+// rewards report of the current epoch.
+//
+// As this is done at the start of the block, the report of an epoch covers
+// everything that was disbursed in the blocks belonging to that epoch.
+func (app *stakingApplication) updateEpochRewards(ctx *abciAPI.Context, stakeState *stakingState.MutableState) error {
+	epoch, err := app.state.GetCurrentEpoch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current epoch: %w", err)
+	}
+	if epoch == epochtime.EpochInvalid {
+		return nil
+	}
+
+	pending, err := stakeState.PendingEpochRewards(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query pending epoch rewards: %w", err)
+	}
+	if pending != nil && pending.Epoch == epoch {
+		return nil
+	}
+
+	commonPool, err := stakeState.CommonPool(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query common pool: %w", err)
+	}
+
+	if pending != nil {
+		pending.CommonPoolEnd = *commonPool.Clone()
+		if err = stakeState.SetEpochRewards(ctx, pending); err != nil {
+			return fmt.Errorf("failed to set epoch rewards: %w", err)
+		}
+
+		ctx.EmitEvent(abciAPI.NewEventBuilder(app.Name()).Attribute(KeyEpochRewards, cbor.Marshal(pending)))
+	}
+
+	if err = stakeState.SetPendingEpochRewards(ctx, &staking.EpochRewards{
+		Epoch:           epoch,
+		CommonPoolStart: *commonPool.Clone(),
+	}); err != nil {
+		return fmt.Errorf("failed to set pending epoch rewards: %w", err)
+	}
+	return nil
+}
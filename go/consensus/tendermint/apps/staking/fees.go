@@ -61,6 +61,9 @@ func (app *stakingApplication) disburseFeesP(
 		return fmt.Errorf("failed to set last block fees: %w", err)
 	}
 
+	// Keep track of disbursed fees for the epoch rewards report.
+	var disbursed staking.EpochFees
+
 	// Pay the proposer.
 	feeProposerAmt := totalFees.Clone()
 	if proposerEntity != nil && !feeProposerAmt.IsZero() {
@@ -75,6 +78,7 @@ func (app *stakingApplication) disburseFeesP(
 		if err = stakeState.SetAccount(ctx, proposerAddr, proposerAcct); err != nil {
 			return fmt.Errorf("failed to set account: %w", err)
 		}
+		disbursed.Propose = *feeProposerAmt
 
 		// Emit transfer event.
 		evt := &staking.TransferEvent{
@@ -98,6 +102,7 @@ func (app *stakingApplication) disburseFeesP(
 		if err = stakeState.SetCommonPool(ctx, commonPool); err != nil {
 			return fmt.Errorf("failed to set common pool: %w", err)
 		}
+		disbursed.CommonPool = *remaining
 
 		// Emit transfer event.
 		evt := &staking.TransferEvent{
@@ -108,6 +113,10 @@ func (app *stakingApplication) disburseFeesP(
 		ctx.EmitEvent(abciAPI.NewEventBuilder(app.Name()).Attribute(KeyTransfer, cbor.Marshal(evt)))
 	}
 
+	if err = stakeState.RecordEpochFees(ctx, &disbursed); err != nil {
+		return fmt.Errorf("failed to record epoch fees: %w", err)
+	}
+
 	return nil
 }
 
@@ -178,6 +187,9 @@ func (app *stakingApplication) disburseFeesVQ(
 		return fmt.Errorf("multiply nextProposerTotal: %w", err)
 	}
 
+	// Keep track of disbursed fees for the epoch rewards report.
+	var disbursed staking.EpochFees
+
 	// Pay the next proposer.
 	if !nextProposerTotal.IsZero() && proposerEntity != nil {
 		proposerAddr := staking.NewAddress(*proposerEntity)
@@ -191,6 +203,7 @@ func (app *stakingApplication) disburseFeesVQ(
 		if err = stakeState.SetAccount(ctx, proposerAddr, proposerAcct); err != nil {
 			return fmt.Errorf("failed to set next proposer account: %w", err)
 		}
+		disbursed.NextPropose = *nextProposerTotal
 
 		// Emit transfer event.
 		evt := &staking.TransferEvent{
@@ -215,6 +228,9 @@ func (app *stakingApplication) disburseFeesVQ(
 			if err = stakeState.SetAccount(ctx, voterAddr, voterAcct); err != nil {
 				return fmt.Errorf("failed to set voter account %s: %w", voterAddr, err)
 			}
+			if err = disbursed.Vote.Add(shareVote); err != nil {
+				return fmt.Errorf("add shareVote: %w", err)
+			}
 
 			// Emit transfer event.
 			evt := &staking.TransferEvent{
@@ -239,6 +255,7 @@ func (app *stakingApplication) disburseFeesVQ(
 		if err = stakeState.SetCommonPool(ctx, commonPool); err != nil {
 			return fmt.Errorf("failed to set common pool: %w", err)
 		}
+		disbursed.CommonPool = *remaining
 
 		// Emit transfer event.
 		evt := &staking.TransferEvent{
@@ -249,5 +266,9 @@ func (app *stakingApplication) disburseFeesVQ(
 		ctx.EmitEvent(abciAPI.NewEventBuilder(app.Name()).Attribute(KeyTransfer, cbor.Marshal(evt)))
 	}
 
+	if err = stakeState.RecordEpochFees(ctx, &disbursed); err != nil {
+		return fmt.Errorf("failed to record epoch fees: %w", err)
+	}
+
 	return nil
 }
@@ -21,6 +21,11 @@ func (app *stakingApplication) initParameters(ctx *abciAPI.Context, state *staki
 	if err := state.SetConsensusParameters(ctx, &st.Parameters); err != nil {
 		return fmt.Errorf("tendermint/staking: failed to set consensus parameters: %w", err)
 	}
+	if st.AdaptiveRewardScale != nil {
+		if err := state.SetAdaptiveRewardScale(ctx, st.AdaptiveRewardScale); err != nil {
+			return fmt.Errorf("tendermint/staking: failed to set adaptive reward scale: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -361,11 +366,19 @@ func (sq *stakingQuerier) Genesis(ctx context.Context) (*staking.Genesis, error)
 		return nil, err
 	}
 
+	var adaptiveRewardScale *quantity.Quantity
+	if params.AdaptiveRewards != nil {
+		if adaptiveRewardScale, err = sq.state.AdaptiveRewardScale(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	gen := staking.Genesis{
 		Parameters:           *params,
 		TotalSupply:          *totalSupply,
 		CommonPool:           *commonPool,
 		LastBlockFees:        *lastBlockFees,
+		AdaptiveRewardScale:  adaptiveRewardScale,
 		Ledger:               ledger,
 		Delegations:          delegations,
 		DebondingDelegations: debondingDelegations,
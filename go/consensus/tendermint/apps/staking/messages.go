@@ -0,0 +1,54 @@
+package staking
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// ExecuteMessage executes a staking runtime message on behalf of the caller address configured
+// in the given message execution context (see api.Context.NewMessageExecutionChild).
+//
+// In case execution fails, the caller is responsible for discarding any state changes.
+func ExecuteMessage(ctx *api.Context, msg *block.StakingMessage) error {
+	if !ctx.IsMessageExecution() {
+		return fmt.Errorf("staking: runtime messages can only be executed in message execution contexts")
+	}
+	if err := msg.ValidateBasic(); err != nil {
+		return fmt.Errorf("%w: %s", staking.ErrInvalidArgument, err)
+	}
+
+	app := &stakingApplication{
+		state: ctx.AppState(),
+	}
+	state := stakingState.NewMutableState(ctx.State())
+
+	var err error
+	switch {
+	case msg.Transfer != nil:
+		err = app.transfer(ctx, state, msg.Transfer)
+	case msg.AddEscrow != nil:
+		err = app.addEscrow(ctx, state, msg.AddEscrow)
+	case msg.ReclaimEscrow != nil:
+		err = app.reclaimEscrow(ctx, state, msg.ReclaimEscrow)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Bump the nonce of the caller's account as messages are not signed transactions and the
+	// nonce is used to disambiguate debonding delegations.
+	callerAddr := ctx.CallerAddress()
+	caller, err := state.Account(ctx, callerAddr)
+	if err != nil {
+		return fmt.Errorf("failed to fetch account: %w", err)
+	}
+	caller.General.Nonce++
+	if err = state.SetAccount(ctx, callerAddr, caller); err != nil {
+		return fmt.Errorf("failed to set account: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package staking
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestExecuteMessage(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock, now)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	var rtID common.Namespace
+	_ = rtID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000")
+	rtAddr := staking.NewRuntimeAddress(rtID)
+	addr1 := staking.NewAddress(signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	addr2 := staking.NewAddress(signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+
+	err = stakeState.SetAccount(ctx, rtAddr, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(100),
+		},
+	})
+	require.NoError(err, "SetAccount")
+
+	// Messages can only be executed in message execution contexts.
+	err = ExecuteMessage(ctx, &block.StakingMessage{Transfer: &staking.Transfer{To: addr1, Amount: *quantity.NewFromUint64(10)}})
+	require.Error(err, "ExecuteMessage should fail outside message execution contexts")
+
+	// Malformed messages should be rejected.
+	msgCtx := ctx.NewMessageExecutionChild(rtAddr)
+	err = ExecuteMessage(msgCtx, &block.StakingMessage{})
+	require.True(errors.Is(err, staking.ErrInvalidArgument), "malformed message should be rejected")
+	msgCtx.Close()
+
+	// Transfers should be performed from the runtime account.
+	msgCtx = ctx.NewMessageExecutionChild(rtAddr)
+	err = ExecuteMessage(msgCtx, &block.StakingMessage{Transfer: &staking.Transfer{To: addr1, Amount: *quantity.NewFromUint64(10)}})
+	require.NoError(err, "ExecuteMessage(Transfer)")
+	msgCtx.CommitChild()
+
+	acct, err := stakeState.Account(ctx, rtAddr)
+	require.NoError(err, "Account")
+	require.EqualValues(*quantity.NewFromUint64(90), acct.General.Balance, "transfer should be debited from the runtime account")
+	require.EqualValues(1, acct.General.Nonce, "runtime account nonce should be incremented")
+	acct, err = stakeState.Account(ctx, addr1)
+	require.NoError(err, "Account")
+	require.EqualValues(*quantity.NewFromUint64(10), acct.General.Balance, "transfer should be credited to the destination")
+
+	// Escrow should be added on behalf of the runtime account.
+	msgCtx = ctx.NewMessageExecutionChild(rtAddr)
+	err = ExecuteMessage(msgCtx, &block.StakingMessage{AddEscrow: &staking.Escrow{Account: addr2, Amount: *quantity.NewFromUint64(50)}})
+	require.NoError(err, "ExecuteMessage(AddEscrow)")
+	msgCtx.CommitChild()
+
+	dlg, err := stakeState.Delegation(ctx, rtAddr, addr2)
+	require.NoError(err, "Delegation")
+	require.EqualValues(*quantity.NewFromUint64(50), dlg.Shares, "shares should be credited to the runtime account")
+
+	// Failed messages should not modify state when the child context is discarded.
+	msgCtx = ctx.NewMessageExecutionChild(rtAddr)
+	err = ExecuteMessage(msgCtx, &block.StakingMessage{Transfer: &staking.Transfer{To: addr1, Amount: *quantity.NewFromUint64(1000)}})
+	require.Error(err, "ExecuteMessage should fail with insufficient balance")
+	msgCtx.Close()
+
+	acct, err = stakeState.Account(ctx, rtAddr)
+	require.NoError(err, "Account")
+	require.EqualValues(*quantity.NewFromUint64(40), acct.General.Balance, "failed message should not be applied")
+	require.EqualValues(2, acct.General.Nonce, "failed message should not increment the nonce")
+}
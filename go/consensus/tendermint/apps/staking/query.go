@@ -2,6 +2,7 @@ package staking
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
@@ -15,12 +16,20 @@ type Query interface {
 	TotalSupply(context.Context) (*quantity.Quantity, error)
 	CommonPool(context.Context) (*quantity.Quantity, error)
 	LastBlockFees(context.Context) (*quantity.Quantity, error)
+	RewardScale(context.Context) (*quantity.Quantity, error)
 	Threshold(context.Context, staking.ThresholdKind) (*quantity.Quantity, error)
+	Thresholds(context.Context) (map[staking.ThresholdKind]quantity.Quantity, error)
 	DebondingInterval(context.Context) (epochtime.EpochTime, error)
 	Addresses(context.Context) ([]staking.Address, error)
+	BelowThresholdAccounts(context.Context) ([]staking.Address, error)
 	Account(context.Context, staking.Address) (*staking.Account, error)
 	Delegations(context.Context, staking.Address) (map[staking.Address]*staking.Delegation, error)
+	DelegationsTo(context.Context, staking.Address) (map[staking.Address]*staking.Delegation, error)
+	DelegationsAt(context.Context, staking.Address, epochtime.EpochTime) (map[staking.Address]*staking.Delegation, error)
 	DebondingDelegations(context.Context, staking.Address) (map[staking.Address][]*staking.DebondingDelegation, error)
+	EpochRewards(context.Context, epochtime.EpochTime) (*staking.EpochRewards, error)
+	DebondingDelegationsTo(context.Context, staking.Address) (map[staking.Address][]*staking.DebondingDelegation, error)
+	AccountStateProof(context.Context, staking.Address) (*staking.AccountStateProof, error)
 	Genesis(context.Context) (*staking.Genesis, error)
 	ConsensusParameters(context.Context) (*staking.ConsensusParameters, error)
 }
@@ -36,11 +45,13 @@ func (sf *QueryFactory) QueryAt(ctx context.Context, height int64) (Query, error
 	if err != nil {
 		return nil, err
 	}
-	return &stakingQuerier{state}, nil
+	return &stakingQuerier{sf.state, state, height}, nil
 }
 
 type stakingQuerier struct {
-	state *stakingState.ImmutableState
+	queryState abciAPI.ApplicationQueryState
+	state      *stakingState.ImmutableState
+	height     int64
 }
 
 func (sq *stakingQuerier) TotalSupply(ctx context.Context) (*quantity.Quantity, error) {
@@ -55,6 +66,21 @@ func (sq *stakingQuerier) LastBlockFees(ctx context.Context) (*quantity.Quantity
 	return sq.state.LastBlockFees(ctx)
 }
 
+func (sq *stakingQuerier) RewardScale(ctx context.Context) (*quantity.Quantity, error) {
+	epoch, err := sq.queryState.GetEpoch(ctx, sq.height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epoch: %w", err)
+	}
+	scale, err := sq.state.RewardScale(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+	if scale == nil {
+		return quantity.NewQuantity(), nil
+	}
+	return scale, nil
+}
+
 func (sq *stakingQuerier) Threshold(ctx context.Context, kind staking.ThresholdKind) (*quantity.Quantity, error) {
 	thresholds, err := sq.state.Thresholds(ctx)
 	if err != nil {
@@ -68,6 +94,10 @@ func (sq *stakingQuerier) Threshold(ctx context.Context, kind staking.ThresholdK
 	return &threshold, nil
 }
 
+func (sq *stakingQuerier) Thresholds(ctx context.Context) (map[staking.ThresholdKind]quantity.Quantity, error) {
+	return sq.state.Thresholds(ctx)
+}
+
 func (sq *stakingQuerier) DebondingInterval(ctx context.Context) (epochtime.EpochTime, error) {
 	return sq.state.DebondingInterval(ctx)
 }
@@ -76,6 +106,10 @@ func (sq *stakingQuerier) Addresses(ctx context.Context) ([]staking.Address, err
 	return sq.state.Addresses(ctx)
 }
 
+func (sq *stakingQuerier) BelowThresholdAccounts(ctx context.Context) ([]staking.Address, error) {
+	return sq.state.BelowThresholdAccounts(ctx)
+}
+
 func (sq *stakingQuerier) Account(ctx context.Context, addr staking.Address) (*staking.Account, error) {
 	switch {
 	case addr.Equal(staking.CommonPoolAddress):
@@ -107,10 +141,34 @@ func (sq *stakingQuerier) Delegations(ctx context.Context, addr staking.Address)
 	return sq.state.DelegationsFor(ctx, addr)
 }
 
+func (sq *stakingQuerier) DelegationsTo(ctx context.Context, addr staking.Address) (map[staking.Address]*staking.Delegation, error) {
+	return sq.state.DelegationsTo(ctx, addr)
+}
+
+func (sq *stakingQuerier) DelegationsAt(ctx context.Context, addr staking.Address, epoch epochtime.EpochTime) (map[staking.Address]*staking.Delegation, error) {
+	return sq.state.DelegationsAt(ctx, addr, epoch)
+}
+
+func (sq *stakingQuerier) EpochRewards(ctx context.Context, epoch epochtime.EpochTime) (*staking.EpochRewards, error) {
+	return sq.state.EpochRewards(ctx, epoch)
+}
+
 func (sq *stakingQuerier) DebondingDelegations(ctx context.Context, addr staking.Address) (map[staking.Address][]*staking.DebondingDelegation, error) {
 	return sq.state.DebondingDelegationsFor(ctx, addr)
 }
 
+func (sq *stakingQuerier) DebondingDelegationsTo(ctx context.Context, addr staking.Address) (map[staking.Address][]*staking.DebondingDelegation, error) {
+	return sq.state.DebondingDelegationsTo(ctx, addr)
+}
+
+func (sq *stakingQuerier) AccountStateProof(ctx context.Context, addr staking.Address) (*staking.AccountStateProof, error) {
+	root, err := abciAPI.GetStateRoot(ctx, sq.queryState, sq.height)
+	if err != nil {
+		return nil, err
+	}
+	return sq.state.AccountStateProof(ctx, *root, addr)
+}
+
 func (sq *stakingQuerier) ConsensusParameters(ctx context.Context) (*staking.ConsensusParameters, error) {
 	return sq.state.ConsensusParameters(ctx)
 }
@@ -62,6 +62,11 @@ func (app *stakingApplication) BeginBlock(ctx *api.Context, request types.Reques
 	numEligibleValidators := len(request.GetLastCommitInfo().Votes)
 	votingEntities := app.resolveEntityIDsFromVotes(ctx, regState, request.GetLastCommitInfo())
 
+	// Roll over the epoch rewards report in case a new epoch has started.
+	if err := app.updateEpochRewards(ctx, stakeState); err != nil {
+		return fmt.Errorf("staking: failed to update epoch rewards: %w", err)
+	}
+
 	// Disburse fees from previous block.
 	if err := app.disburseFeesVQ(ctx, stakeState, proposingEntity, numEligibleValidators, votingEntities); err != nil {
 		return fmt.Errorf("disburse fees voters and next proposer: %w", err)
@@ -109,6 +114,13 @@ func (app *stakingApplication) ExecuteTx(ctx *api.Context, tx *transaction.Trans
 		}
 
 		return app.transfer(ctx, state, &xfer)
+	case staking.MethodTransferBatch:
+		var batch staking.TransferBatch
+		if err := cbor.Unmarshal(tx.Body, &batch); err != nil {
+			return err
+		}
+
+		return app.transferBatch(ctx, &batch)
 	case staking.MethodBurn:
 		var burn staking.Burn
 		if err := cbor.Unmarshal(tx.Body, &burn); err != nil {
@@ -151,6 +163,13 @@ func (app *stakingApplication) ExecuteTx(ctx *api.Context, tx *transaction.Trans
 		}
 
 		return app.withdraw(ctx, state, &withdraw)
+	case staking.MethodSetCommissionDestination:
+		var setCommissionDestination staking.SetCommissionDestination
+		if err := cbor.Unmarshal(tx.Body, &setCommissionDestination); err != nil {
+			return err
+		}
+
+		return app.setCommissionDestination(ctx, state, &setCommissionDestination)
 	default:
 		return staking.ErrInvalidArgument
 	}
@@ -260,6 +279,69 @@ func (app *stakingApplication) onEpochChange(ctx *api.Context, epoch epochtime.E
 		return fmt.Errorf("staking/tendermint: failed to add signing rewards: %w", err)
 	}
 
+	// Adjust the reward scale for the next epoch.
+	if err := app.adjustRewardScale(ctx, state); err != nil {
+		return fmt.Errorf("staking/tendermint: failed to adjust reward scale: %w", err)
+	}
+
+	// Snapshot delegations for off-chain consumers.
+	if err := app.snapshotDelegations(ctx, state, epoch); err != nil {
+		return fmt.Errorf("staking/tendermint: failed to snapshot delegations: %w", err)
+	}
+
+	// Remove empty accounts from the ledger.
+	if err := app.reapEmptyAccounts(ctx, state, epoch); err != nil {
+		return fmt.Errorf("staking/tendermint: failed to reap empty accounts: %w", err)
+	}
+
+	return nil
+}
+
+func (app *stakingApplication) snapshotDelegations(ctx *api.Context, state *stakingState.MutableState, epoch epochtime.EpochTime) error {
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query consensus parameters: %w", err)
+	}
+
+	// Snapshot before pruning as the entries of pruned snapshots which are still
+	// needed are moved to the oldest retained snapshot.
+	pruneBefore := epoch + 1
+	if retain := params.DelegationSnapshotEpochs; retain > 0 {
+		if err = state.SnapshotDelegations(ctx, epoch); err != nil {
+			return fmt.Errorf("failed to snapshot delegations: %w", err)
+		}
+		pruneBefore = 0
+		if uint64(epoch) >= retain {
+			pruneBefore = epoch - epochtime.EpochTime(retain) + 1
+		}
+	}
+
+	// Prune snapshots that should no longer be retained. This also removes all
+	// snapshots in case snapshotting has been disabled.
+	if err = state.PruneDelegationSnapshots(ctx, pruneBefore); err != nil {
+		return fmt.Errorf("failed to prune delegation snapshots: %w", err)
+	}
+	return nil
+}
+
+func (app *stakingApplication) reapEmptyAccounts(ctx *api.Context, state *stakingState.MutableState, epoch epochtime.EpochTime) error {
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query consensus parameters: %w", err)
+	}
+	if params.AccountReapingInterval == 0 || epoch%params.AccountReapingInterval != 0 {
+		return nil
+	}
+
+	reaped, err := state.ReapEmptyAccounts(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctx.Logger().Debug("reaped empty accounts",
+		"epoch", epoch,
+		"num_accounts", reaped,
+	)
 	return nil
 }
 
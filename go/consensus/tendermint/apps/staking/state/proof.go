@@ -0,0 +1,188 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+)
+
+// ErrInvalidAccountStateProof is the error returned when an account state proof does not
+// match the proven state.
+var ErrInvalidAccountStateProof = errors.New("tendermint/staking: invalid account state proof")
+
+// staticProofSyncer is a read syncer that serves all requests from a single proof.
+type staticProofSyncer struct {
+	proof *syncer.Proof
+}
+
+func (sp *staticProofSyncer) SyncGet(ctx context.Context, request *syncer.GetRequest) (*syncer.ProofResponse, error) {
+	return &syncer.ProofResponse{Proof: *sp.proof}, nil
+}
+
+func (sp *staticProofSyncer) SyncGetPrefixes(ctx context.Context, request *syncer.GetPrefixesRequest) (*syncer.ProofResponse, error) {
+	return &syncer.ProofResponse{Proof: *sp.proof}, nil
+}
+
+func (sp *staticProofSyncer) SyncIterate(ctx context.Context, request *syncer.IterateRequest) (*syncer.ProofResponse, error) {
+	return &syncer.ProofResponse{Proof: *sp.proof}, nil
+}
+
+// accountState extracts the full state of the given account using the given iterator.
+//
+// The same sequence of iterator operations is used when exporting and when verifying the
+// account state, so that the exported proof contains exactly the nodes needed for verification.
+func accountState(it mkvs.Iterator, addr staking.Address) (*staking.AccountStateProof, error) {
+	p := &staking.AccountStateProof{
+		Owner:                addr,
+		Delegations:          make(map[staking.Address]*staking.Delegation),
+		DebondingDelegations: make(map[staking.Address][]*staking.DebondingDelegation),
+	}
+
+	// Account.
+	accountKey := accountKeyFmt.Encode(&addr)
+	if it.Seek(accountKey); it.Valid() && bytes.Equal(it.Key(), accountKey) {
+		if err := cbor.Unmarshal(it.Value(), &p.Account); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+
+	// Delegations. As delegations are keyed by escrow account, this needs to iterate over all
+	// delegations.
+	for it.Seek(delegationKeyFmt.Encode()); it.Valid(); it.Next() {
+		var escrowAddr staking.Address
+		var delegatorAddr staking.Address
+		if !delegationKeyFmt.Decode(it.Key(), &escrowAddr, &delegatorAddr) {
+			break
+		}
+		if !delegatorAddr.Equal(addr) {
+			continue
+		}
+
+		var del staking.Delegation
+		if err := cbor.Unmarshal(it.Value(), &del); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+
+		p.Delegations[escrowAddr] = &del
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+
+	// Debonding delegations.
+	for it.Seek(debondingDelegationKeyFmt.Encode(&addr)); it.Valid(); it.Next() {
+		var escrowAddr staking.Address
+		var delegatorAddr staking.Address
+		if !debondingDelegationKeyFmt.Decode(it.Key(), &delegatorAddr, &escrowAddr) {
+			break
+		}
+		if !delegatorAddr.Equal(addr) {
+			break
+		}
+
+		var deb staking.DebondingDelegation
+		if err := cbor.Unmarshal(it.Value(), &deb); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+
+		p.DebondingDelegations[escrowAddr] = append(p.DebondingDelegations[escrowAddr], &deb)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+
+	return p, nil
+}
+
+// AccountStateProof returns the full state of the given account together with a proof of that
+// state against the given state root.
+//
+// The state root must be the root of the state tree backing this immutable state.
+func (s *ImmutableState) AccountStateProof(
+	ctx context.Context,
+	root storage.Root,
+	addr staking.Address,
+) (*staking.AccountStateProof, error) {
+	if !addr.IsValid() {
+		return nil, fmt.Errorf("tendermint/staking: invalid account address: %s", addr)
+	}
+
+	it := s.is.NewIterator(ctx, mkvs.WithProof(root.Hash))
+	defer it.Close()
+
+	p, err := accountState(it, addr)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := it.GetProof()
+	if err != nil {
+		return nil, fmt.Errorf("tendermint/staking: failed to build account state proof: %w", err)
+	}
+
+	p.Height = int64(root.Version)
+	p.StateRoot = root
+	p.Proof = *proof
+	return p, nil
+}
+
+// VerifyAccountStateProof verifies that the account state contained in the given account state
+// proof matches the state proven against its state root.
+//
+// This does not verify the state root itself, which must be checked against a trusted source.
+func VerifyAccountStateProof(ctx context.Context, p *staking.AccountStateProof) error {
+	if !p.Owner.IsValid() {
+		return fmt.Errorf("%w: invalid account address: %s", ErrInvalidAccountStateProof, p.Owner)
+	}
+	if !p.Proof.UntrustedRoot.Equal(&p.StateRoot.Hash) {
+		return fmt.Errorf("%w: proof root mismatch (expected: %s got: %s)",
+			ErrInvalidAccountStateProof,
+			p.StateRoot.Hash,
+			p.Proof.UntrustedRoot,
+		)
+	}
+
+	tree := mkvs.NewWithRoot(&staticProofSyncer{proof: &p.Proof}, nil, p.StateRoot)
+	defer tree.Close()
+	it := tree.NewIterator(ctx)
+	defer it.Close()
+
+	proven, err := accountState(it, p.Owner)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidAccountStateProof, err)
+	}
+
+	// Treat missing maps the same as empty ones.
+	delegations := p.Delegations
+	if delegations == nil {
+		delegations = make(map[staking.Address]*staking.Delegation)
+	}
+	debDelegations := p.DebondingDelegations
+	if debDelegations == nil {
+		debDelegations = make(map[staking.Address][]*staking.DebondingDelegation)
+	}
+
+	for _, v := range []struct {
+		name            string
+		claimed, proven interface{}
+	}{
+		{"account", &p.Account, &proven.Account},
+		{"delegations", delegations, proven.Delegations},
+		{"debonding delegations", debDelegations, proven.DebondingDelegations},
+	} {
+		if !bytes.Equal(cbor.Marshal(v.claimed), cbor.Marshal(v.proven)) {
+			return fmt.Errorf("%w: %s mismatch", ErrInvalidAccountStateProof, v.name)
+		}
+	}
+	return nil
+}
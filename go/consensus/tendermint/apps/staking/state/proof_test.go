@@ -0,0 +1,93 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+)
+
+func TestAccountStateProof(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	tree := mkvs.New(nil, nil)
+	defer tree.Close()
+	s := NewMutableState(tree)
+
+	newAddr := func(seed string) staking.Address {
+		return staking.NewAddress(memorySigner.NewTestSigner(seed).Public())
+	}
+	addr := newAddr("account state proof test: account")
+	escrowAddr1 := newAddr("account state proof test: escrow 1")
+	escrowAddr2 := newAddr("account state proof test: escrow 2")
+	otherAddr := newAddr("account state proof test: other")
+
+	acct := staking.Account{
+		General: staking.GeneralAccount{
+			Balance: mustInitQuantity(t, 1000),
+			Nonce:   42,
+		},
+	}
+	require.NoError(s.SetAccount(ctx, addr, &acct), "SetAccount")
+	require.NoError(s.SetAccount(ctx, otherAddr, &acct), "SetAccount")
+
+	del := staking.Delegation{Shares: mustInitQuantity(t, 100)}
+	require.NoError(s.SetDelegation(ctx, addr, escrowAddr1, &del), "SetDelegation")
+	require.NoError(s.SetDelegation(ctx, addr, escrowAddr2, &del), "SetDelegation")
+	require.NoError(s.SetDelegation(ctx, otherAddr, escrowAddr1, &del), "SetDelegation")
+
+	deb := staking.DebondingDelegation{Shares: mustInitQuantity(t, 50), DebondEndTime: 10}
+	require.NoError(s.SetDebondingDelegation(ctx, addr, escrowAddr1, 1, &deb), "SetDebondingDelegation")
+	require.NoError(s.SetDebondingDelegation(ctx, addr, escrowAddr1, 2, &deb), "SetDebondingDelegation")
+	require.NoError(s.SetDebondingDelegation(ctx, otherAddr, escrowAddr2, 3, &deb), "SetDebondingDelegation")
+
+	_, rootHash, err := tree.Commit(ctx, common.Namespace{}, 1)
+	require.NoError(err, "Commit")
+	root := storage.Root{Version: 1, Hash: rootHash}
+
+	p, err := s.AccountStateProof(ctx, root, addr)
+	require.NoError(err, "AccountStateProof")
+	require.EqualValues(1, p.Height, "proof height should match the root version")
+	require.Equal(acct, p.Account, "exported account should match")
+	require.Len(p.Delegations, 2, "all delegations should be exported")
+	require.Equal(&del, p.Delegations[escrowAddr1], "exported delegation should match")
+	require.Len(p.DebondingDelegations, 1, "all debonding delegations should be exported")
+	require.Len(p.DebondingDelegations[escrowAddr1], 2, "all debonding delegations should be exported")
+
+	require.NoError(VerifyAccountStateProof(ctx, p), "VerifyAccountStateProof")
+
+	// Tampering with the exported state should be detected.
+	tampered := *p
+	tampered.Account.General.Balance = mustInitQuantity(t, 2000)
+	err = VerifyAccountStateProof(ctx, &tampered)
+	require.True(errors.Is(err, ErrInvalidAccountStateProof), "tampered account should be rejected")
+
+	tampered = *p
+	tampered.Delegations = map[staking.Address]*staking.Delegation{escrowAddr1: &del}
+	err = VerifyAccountStateProof(ctx, &tampered)
+	require.True(errors.Is(err, ErrInvalidAccountStateProof), "omitted delegation should be rejected")
+
+	tampered = *p
+	tampered.DebondingDelegations = nil
+	err = VerifyAccountStateProof(ctx, &tampered)
+	require.True(errors.Is(err, ErrInvalidAccountStateProof), "omitted debonding delegations should be rejected")
+
+	tampered = *p
+	tampered.StateRoot.Hash.FromBytes([]byte("account state proof test: bad root"))
+	err = VerifyAccountStateProof(ctx, &tampered)
+	require.True(errors.Is(err, ErrInvalidAccountStateProof), "proof for a different root should be rejected")
+
+	// A proof for a different account should not verify.
+	tampered = *p
+	tampered.Owner = otherAddr
+	err = VerifyAccountStateProof(ctx, &tampered)
+	require.Error(err, "proof for a different account should be rejected")
+}
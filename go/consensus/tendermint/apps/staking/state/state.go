@@ -31,6 +31,9 @@ var (
 	// KeyTransfer is an ABCI event attribute key for Transfers (value is
 	// an app.TransferEvent).
 	KeyTransfer = []byte("transfer")
+	// KeyEscrowThreshold is an ABCI event attribute key for escrow threshold
+	// status changes (value is an api.EscrowThresholdEvent).
+	KeyEscrowThreshold = []byte("escrow_threshold")
 
 	// accountKeyFmt is the key format used for accounts (account addresses).
 	//
@@ -71,6 +74,45 @@ var (
 	//
 	// Value is CBOR-serialized EpochSigning.
 	epochSigningKeyFmt = keyformat.New(0x58)
+	// delegationSnapshotKeyFmt is the key format used for per-epoch delegation
+	// snapshots (escrow address, epoch). An entry is only stored for epochs
+	// in which the delegations to the escrow account have changed since its
+	// previous entry.
+	//
+	// Value is a CBOR-serialized map of delegator addresses to delegations.
+	delegationSnapshotKeyFmt = keyformat.New(0x59, &staking.Address{}, uint64(0))
+	// delegationSnapshotEpochKeyFmt is the key format used to mark the epochs
+	// for which delegation snapshots are available (epoch).
+	//
+	// Value is empty.
+	delegationSnapshotEpochKeyFmt = keyformat.New(0x5a, uint64(0))
+	// adaptiveRewardScaleKeyFmt is the key format used for the current reward
+	// scale under the adaptive reward schedule.
+	//
+	// Value is CBOR-serialized quantity.
+	adaptiveRewardScaleKeyFmt = keyformat.New(0x5b)
+	// belowThresholdKeyFmt is the key format used to mark escrow accounts
+	// whose active balance is below the total of their stake claims
+	// (account address).
+	//
+	// Value is the CBOR-serialized staking.EscrowThresholdEvent emitted
+	// when the account fell below the threshold.
+	belowThresholdKeyFmt = keyformat.New(0x5c, &staking.Address{})
+	// epochRewardsKeyFmt is the key format used for the rewards reports of
+	// ended epochs (epoch).
+	//
+	// Value is CBOR-serialized staking.EpochRewards.
+	epochRewardsKeyFmt = keyformat.New(0x5d, uint64(0))
+	// pendingEpochRewardsKeyFmt is the key format used for the rewards
+	// report of the current epoch, which is being accumulated.
+	//
+	// Value is CBOR-serialized staking.EpochRewards.
+	pendingEpochRewardsKeyFmt = keyformat.New(0x5e)
+	// totalStakedKeyFmt is the key format used for the total active escrow
+	// balance of all accounts.
+	//
+	// Value is a CBOR-serialized quantity.
+	totalStakedKeyFmt = keyformat.New(0x5f)
 
 	logger = logging.GetLogger("tendermint/staking")
 )
@@ -96,6 +138,23 @@ func (s *ImmutableState) TotalSupply(ctx context.Context) (*quantity.Quantity, e
 	return &q, nil
 }
 
+// TotalStaked returns the total active escrow balance of all accounts.
+func (s *ImmutableState) TotalStaked(ctx context.Context) (*quantity.Quantity, error) {
+	value, err := s.is.Get(ctx, totalStakedKeyFmt.Encode())
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if value == nil {
+		return &quantity.Quantity{}, nil
+	}
+
+	var q quantity.Quantity
+	if err = cbor.Unmarshal(value, &q); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &q, nil
+}
+
 // CommonPool returns the balance of the global common pool.
 func (s *ImmutableState) CommonPool(ctx context.Context) (*quantity.Quantity, error) {
 	value, err := s.is.Get(ctx, commonPoolKeyFmt.Encode())
@@ -147,6 +206,53 @@ func (s *ImmutableState) RewardSchedule(ctx context.Context) ([]staking.RewardSt
 	return params.RewardSchedule, nil
 }
 
+// AdaptiveRewardScale returns the current reward scale under the adaptive
+// reward schedule. In case the scale has not yet been adjusted, nil is
+// returned.
+func (s *ImmutableState) AdaptiveRewardScale(ctx context.Context) (*quantity.Quantity, error) {
+	value, err := s.is.Get(ctx, adaptiveRewardScaleKeyFmt.Encode())
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if value == nil {
+		return nil, nil
+	}
+
+	var q quantity.Quantity
+	if err = cbor.Unmarshal(value, &q); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &q, nil
+}
+
+// RewardScale returns the effective reward scale at the given epoch. In case
+// no rewards are to be distributed at the given epoch, nil is returned.
+func (s *ImmutableState) RewardScale(ctx context.Context, epoch epochtime.EpochTime) (*quantity.Quantity, error) {
+	params, err := s.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.AdaptiveRewards != nil {
+		var scale *quantity.Quantity
+		if scale, err = s.AdaptiveRewardScale(ctx); err != nil {
+			return nil, err
+		}
+		if scale == nil {
+			scale = params.AdaptiveRewards.InitialScale.Clone()
+		}
+		return scale, nil
+	}
+
+	for _, step := range params.RewardSchedule {
+		if epoch < step.Until {
+			return step.Scale.Clone(), nil
+		}
+	}
+	// We're past the end of the schedule.
+	return nil, nil
+}
+
 func (s *ImmutableState) CommissionScheduleRules(ctx context.Context) (*staking.CommissionScheduleRules, error) {
 	params, err := s.ConsensusParameters(ctx)
 	if err != nil {
@@ -206,6 +312,27 @@ func (s *ImmutableState) Account(ctx context.Context, address staking.Address) (
 	return &ent, nil
 }
 
+// BelowThresholdAccounts returns the addresses of all escrow accounts whose
+// active balance is below the total of their stake claims.
+func (s *ImmutableState) BelowThresholdAccounts(ctx context.Context) ([]staking.Address, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var addresses []staking.Address
+	for it.Seek(belowThresholdKeyFmt.Encode()); it.Valid(); it.Next() {
+		var addr staking.Address
+		if !belowThresholdKeyFmt.Decode(it.Key(), &addr) {
+			break
+		}
+
+		addresses = append(addresses, addr)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return addresses, nil
+}
+
 // EscrowBalance returns the escrow balance for the given account address.
 func (s *ImmutableState) EscrowBalance(ctx context.Context, address staking.Address) (*quantity.Quantity, error) {
 	account, err := s.Account(ctx, address)
@@ -295,6 +422,131 @@ func (s *ImmutableState) DelegationsFor(
 	return delegations, nil
 }
 
+// DelegationsTo returns all delegations to the given escrow account, keyed by
+// delegator address.
+func (s *ImmutableState) DelegationsTo(
+	ctx context.Context,
+	escrowAddr staking.Address,
+) (map[staking.Address]*staking.Delegation, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	delegations := make(map[staking.Address]*staking.Delegation)
+	for it.Seek(delegationKeyFmt.Encode(&escrowAddr)); it.Valid(); it.Next() {
+		var decEscrowAddr staking.Address
+		var delegatorAddr staking.Address
+		if !delegationKeyFmt.Decode(it.Key(), &decEscrowAddr, &delegatorAddr) || !decEscrowAddr.Equal(escrowAddr) {
+			break
+		}
+
+		var del staking.Delegation
+		if err := cbor.Unmarshal(it.Value(), &del); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+
+		delegations[delegatorAddr] = &del
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return delegations, nil
+}
+
+// DelegationsAt returns the delegations to the given escrow account as
+// snapshotted at the transition to the given epoch.
+func (s *ImmutableState) DelegationsAt(
+	ctx context.Context,
+	escrowAddr staking.Address,
+	epoch epochtime.EpochTime,
+) (map[staking.Address]*staking.Delegation, error) {
+	marker, err := s.is.Get(ctx, delegationSnapshotEpochKeyFmt.Encode(uint64(epoch)))
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if marker == nil {
+		return nil, staking.ErrNoDelegationSnapshot
+	}
+
+	// Find the latest entry at or before the given epoch.
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var value []byte
+	for it.Seek(delegationSnapshotKeyFmt.Encode(&escrowAddr)); it.Valid(); it.Next() {
+		var decEscrowAddr staking.Address
+		var decEpoch uint64
+		if !delegationSnapshotKeyFmt.Decode(it.Key(), &decEscrowAddr, &decEpoch) || !decEscrowAddr.Equal(escrowAddr) {
+			break
+		}
+		if decEpoch > uint64(epoch) {
+			break
+		}
+		value = it.Value()
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+
+	delegations := make(map[staking.Address]*staking.Delegation)
+	if value == nil {
+		return delegations, nil
+	}
+	if err = cbor.Unmarshal(value, &delegations); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return delegations, nil
+}
+
+// delegationSnapshotEntry is a delegation snapshot entry of an escrow account.
+type delegationSnapshotEntry struct {
+	escrowAddr staking.Address
+	epoch      epochtime.EpochTime
+	value      []byte
+}
+
+// delegationSnapshotEntries returns all delegation snapshot entries, ordered
+// by escrow address and epoch.
+func (s *ImmutableState) delegationSnapshotEntries(ctx context.Context) ([]*delegationSnapshotEntry, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var entries []*delegationSnapshotEntry
+	for it.Seek(delegationSnapshotKeyFmt.Encode()); it.Valid(); it.Next() {
+		var entry delegationSnapshotEntry
+		var epoch uint64
+		if !delegationSnapshotKeyFmt.Decode(it.Key(), &entry.escrowAddr, &epoch) {
+			break
+		}
+		entry.epoch = epochtime.EpochTime(epoch)
+		entry.value = it.Value()
+		entries = append(entries, &entry)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return entries, nil
+}
+
+// DelegationSnapshotEpochs returns the epochs for which delegation snapshots
+// are available.
+func (s *ImmutableState) DelegationSnapshotEpochs(ctx context.Context) ([]epochtime.EpochTime, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var epochs []epochtime.EpochTime
+	for it.Seek(delegationSnapshotEpochKeyFmt.Encode()); it.Valid(); it.Next() {
+		var epoch uint64
+		if !delegationSnapshotEpochKeyFmt.Decode(it.Key(), &epoch) {
+			break
+		}
+		epochs = append(epochs, epochtime.EpochTime(epoch))
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return epochs, nil
+}
+
 func (s *ImmutableState) DebondingDelegations(
 	ctx context.Context,
 ) (map[staking.Address]map[staking.Address][]*staking.DebondingDelegation, error) {
@@ -356,6 +608,42 @@ func (s *ImmutableState) DebondingDelegationsFor(
 	return delegations, nil
 }
 
+// DebondingDelegationsTo returns all debonding delegations to the given escrow
+// account, keyed by delegator address.
+//
+// As debonding delegations are keyed by delegator, this needs to iterate over
+// all debonding delegations.
+func (s *ImmutableState) DebondingDelegationsTo(
+	ctx context.Context,
+	escrowAddr staking.Address,
+) (map[staking.Address][]*staking.DebondingDelegation, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	delegations := make(map[staking.Address][]*staking.DebondingDelegation)
+	for it.Seek(debondingDelegationKeyFmt.Encode()); it.Valid(); it.Next() {
+		var delegatorAddr staking.Address
+		var decEscrowAddr staking.Address
+		if !debondingDelegationKeyFmt.Decode(it.Key(), &delegatorAddr, &decEscrowAddr) {
+			break
+		}
+		if !decEscrowAddr.Equal(escrowAddr) {
+			continue
+		}
+
+		var deb staking.DebondingDelegation
+		if err := cbor.Unmarshal(it.Value(), &deb); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+
+		delegations[delegatorAddr] = append(delegations[delegatorAddr], &deb)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return delegations, nil
+}
+
 func (s *ImmutableState) DebondingDelegation(
 	ctx context.Context,
 	delegatorAddr, escrowAddr staking.Address,
@@ -440,6 +728,42 @@ func (s *ImmutableState) LastBlockFees(ctx context.Context) (*quantity.Quantity,
 	return &q, nil
 }
 
+// EpochRewards returns the rewards report of the given (ended) epoch.
+func (s *ImmutableState) EpochRewards(ctx context.Context, epoch epochtime.EpochTime) (*staking.EpochRewards, error) {
+	value, err := s.is.Get(ctx, epochRewardsKeyFmt.Encode(uint64(epoch)))
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if value == nil {
+		return nil, staking.ErrNoEpochRewards
+	}
+
+	var r staking.EpochRewards
+	if err = cbor.Unmarshal(value, &r); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &r, nil
+}
+
+// PendingEpochRewards returns the rewards report of the current epoch, which
+// is being accumulated. In case no report is being accumulated, nil is
+// returned.
+func (s *ImmutableState) PendingEpochRewards(ctx context.Context) (*staking.EpochRewards, error) {
+	value, err := s.is.Get(ctx, pendingEpochRewardsKeyFmt.Encode())
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if value == nil {
+		return nil, nil
+	}
+
+	var r staking.EpochRewards
+	if err = cbor.Unmarshal(value, &r); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &r, nil
+}
+
 type EpochSigning struct {
 	Total    uint64
 	ByEntity map[signature.PublicKey]uint64
@@ -520,10 +844,128 @@ type MutableState struct {
 }
 
 func (s *MutableState) SetAccount(ctx context.Context, addr staking.Address, account *staking.Account) error {
+	if err := s.updateThresholdStatus(ctx, addr, account); err != nil {
+		return fmt.Errorf("tendermint/staking: failed to update threshold status: %w", err)
+	}
+	if err := s.updateTotalStaked(ctx, addr, account); err != nil {
+		return fmt.Errorf("tendermint/staking: failed to update total staked: %w", err)
+	}
+
 	err := s.ms.Insert(ctx, accountKeyFmt.Encode(&addr), cbor.Marshal(account))
 	return abciAPI.UnavailableStateError(err)
 }
 
+// updateThresholdStatus tracks whether the active escrow balance of the given
+// account covers the total of its stake claims and emits an event whenever
+// this changes.
+func (s *MutableState) updateThresholdStatus(ctx context.Context, addr staking.Address, account *staking.Account) error {
+	key := belowThresholdKeyFmt.Encode(&addr)
+	raw, err := s.ms.Get(ctx, key)
+	if err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	wasBelow := raw != nil
+	if !wasBelow && len(account.Escrow.StakeAccumulator.Claims) == 0 {
+		// Accounts without any stake claims can never be below the threshold.
+		return nil
+	}
+
+	thresholds, err := s.Thresholds(ctx)
+	if err != nil {
+		return err
+	}
+	totalClaims, err := account.Escrow.StakeAccumulator.TotalClaims(thresholds, nil)
+	if err != nil {
+		return err
+	}
+	isBelow := account.Escrow.Active.Balance.Cmp(totalClaims) < 0
+	if isBelow == wasBelow {
+		return nil
+	}
+
+	ev := cbor.Marshal(&staking.EscrowThresholdEvent{
+		Owner:          addr,
+		BelowThreshold: isBelow,
+		Balance:        account.Escrow.Active.Balance,
+		TotalClaims:    *totalClaims,
+	})
+	if isBelow {
+		err = s.ms.Insert(ctx, key, ev)
+	} else {
+		err = s.ms.Remove(ctx, key)
+	}
+	if err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+
+	if abciCtx := abciAPI.FromCtx(ctx); abciCtx != nil && !abciCtx.IsCheckOnly() {
+		abciCtx.EmitEvent(api.NewEventBuilder(AppName).Attribute(KeyEscrowThreshold, ev))
+	}
+	return nil
+}
+
+// updateTotalStaked updates the total active escrow balance of all accounts
+// with the change of the active escrow balance of the given account.
+func (s *MutableState) updateTotalStaked(ctx context.Context, addr staking.Address, account *staking.Account) error {
+	prev, err := s.Account(ctx, addr)
+	if err != nil {
+		return err
+	}
+	if prev.Escrow.Active.Balance.Cmp(&account.Escrow.Active.Balance) == 0 {
+		return nil
+	}
+
+	total, err := s.TotalStaked(ctx)
+	if err != nil {
+		return err
+	}
+	if err = total.Add(&account.Escrow.Active.Balance); err != nil {
+		return err
+	}
+	if err = total.Sub(&prev.Escrow.Active.Balance); err != nil {
+		return err
+	}
+
+	err = s.ms.Insert(ctx, totalStakedKeyFmt.Encode(), cbor.Marshal(total))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// ReapEmptyAccounts removes all empty accounts (see staking.Account.IsEmpty)
+// from the ledger and returns the number of removed accounts.
+func (s *MutableState) ReapEmptyAccounts(ctx context.Context) (int, error) {
+	var empty []staking.Address
+	err := func() error {
+		it := s.is.NewIterator(ctx)
+		defer it.Close()
+
+		for it.Seek(accountKeyFmt.Encode()); it.Valid(); it.Next() {
+			var addr staking.Address
+			if !accountKeyFmt.Decode(it.Key(), &addr) {
+				break
+			}
+
+			var account staking.Account
+			if err := cbor.Unmarshal(it.Value(), &account); err != nil {
+				return err
+			}
+			if account.IsEmpty() {
+				empty = append(empty, addr)
+			}
+		}
+		return it.Err()
+	}()
+	if err != nil {
+		return 0, abciAPI.UnavailableStateError(err)
+	}
+
+	for i := range empty {
+		if err = s.ms.Remove(ctx, accountKeyFmt.Encode(&empty[i])); err != nil {
+			return 0, abciAPI.UnavailableStateError(err)
+		}
+	}
+	return len(empty), nil
+}
+
 func (s *MutableState) SetTotalSupply(ctx context.Context, q *quantity.Quantity) error {
 	err := s.ms.Insert(ctx, totalSupplyKeyFmt.Encode(), cbor.Marshal(q))
 	return abciAPI.UnavailableStateError(err)
@@ -597,11 +1039,168 @@ func (s *MutableState) RemoveFromDebondingQueue(
 	return abciAPI.UnavailableStateError(err)
 }
 
+// SnapshotDelegations stores a snapshot of all current delegations for the
+// given epoch.
+//
+// Only the delegations to escrow accounts which have changed since the
+// previous snapshot are stored.
+func (s *MutableState) SnapshotDelegations(ctx context.Context, epoch epochtime.EpochTime) error {
+	delegations, err := s.Delegations(ctx)
+	if err != nil {
+		return err
+	}
+	entries, err := s.delegationSnapshotEntries(ctx)
+	if err != nil {
+		return err
+	}
+
+	latest := make(map[staking.Address][]byte)
+	for _, entry := range entries {
+		latest[entry.escrowAddr] = entry.value
+	}
+	for escrowAddr := range latest {
+		// Escrow accounts which no longer have any delegations.
+		if _, ok := delegations[escrowAddr]; !ok {
+			delegations[escrowAddr] = make(map[staking.Address]*staking.Delegation)
+		}
+	}
+
+	for escrowAddr, escrowDelegations := range delegations {
+		addr := escrowAddr
+		value := cbor.Marshal(escrowDelegations)
+		if bytes.Equal(value, latest[addr]) {
+			continue
+		}
+		if err = s.ms.Insert(ctx, delegationSnapshotKeyFmt.Encode(&addr, uint64(epoch)), value); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+	}
+	err = s.ms.Insert(ctx, delegationSnapshotEpochKeyFmt.Encode(uint64(epoch)), []byte{})
+	return abciAPI.UnavailableStateError(err)
+}
+
+// PruneDelegationSnapshots removes all delegation snapshots for epochs
+// before the given epoch.
+//
+// Entries of pruned snapshots which are still needed to resolve the oldest
+// retained snapshot are moved to that snapshot.
+func (s *MutableState) PruneDelegationSnapshots(ctx context.Context, before epochtime.EpochTime) error {
+	epochs, err := s.DelegationSnapshotEpochs(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		pruned  []epochtime.EpochTime
+		base    epochtime.EpochTime
+		hasBase bool
+	)
+	for _, epoch := range epochs {
+		if epoch >= before {
+			base, hasBase = epoch, true
+			break
+		}
+		pruned = append(pruned, epoch)
+	}
+	if len(pruned) == 0 {
+		return nil
+	}
+
+	entries, err := s.delegationSnapshotEntries(ctx)
+	if err != nil {
+		return err
+	}
+	for i, entry := range entries {
+		if entry.epoch >= before {
+			continue
+		}
+		if err = s.ms.Remove(ctx, delegationSnapshotKeyFmt.Encode(&entry.escrowAddr, uint64(entry.epoch))); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+
+		// Entries are ordered by escrow address and epoch, so the next entry
+		// tells whether this is the latest pruned entry of the escrow account
+		// and whether the oldest retained snapshot has its own entry.
+		var next *delegationSnapshotEntry
+		if i+1 < len(entries) && entries[i+1].escrowAddr.Equal(entry.escrowAddr) {
+			next = entries[i+1]
+		}
+		if !hasBase || (next != nil && next.epoch <= base) {
+			continue
+		}
+		if err = s.ms.Insert(ctx, delegationSnapshotKeyFmt.Encode(&entry.escrowAddr, uint64(base)), entry.value); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+	}
+
+	for _, epoch := range pruned {
+		if err = s.ms.Remove(ctx, delegationSnapshotEpochKeyFmt.Encode(uint64(epoch))); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+	}
+	return nil
+}
+
 func (s *MutableState) SetLastBlockFees(ctx context.Context, q *quantity.Quantity) error {
 	err := s.ms.Insert(ctx, lastBlockFeesKeyFmt.Encode(), cbor.Marshal(q))
 	return abciAPI.UnavailableStateError(err)
 }
 
+// SetAdaptiveRewardScale sets the current reward scale under the adaptive
+// reward schedule.
+func (s *MutableState) SetAdaptiveRewardScale(ctx context.Context, q *quantity.Quantity) error {
+	err := s.ms.Insert(ctx, adaptiveRewardScaleKeyFmt.Encode(), cbor.Marshal(q))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// SetPendingEpochRewards sets the rewards report of the current epoch.
+func (s *MutableState) SetPendingEpochRewards(ctx context.Context, r *staking.EpochRewards) error {
+	err := s.ms.Insert(ctx, pendingEpochRewardsKeyFmt.Encode(), cbor.Marshal(r))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// SetEpochRewards stores the rewards report of an ended epoch.
+func (s *MutableState) SetEpochRewards(ctx context.Context, r *staking.EpochRewards) error {
+	err := s.ms.Insert(ctx, epochRewardsKeyFmt.Encode(uint64(r.Epoch)), cbor.Marshal(r))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// updatePendingEpochRewards applies the given update to the rewards report
+// of the current epoch. In case no report is being accumulated, nothing is
+// recorded.
+func (s *MutableState) updatePendingEpochRewards(ctx context.Context, fn func(*staking.EpochRewards) error) error {
+	r, err := s.PendingEpochRewards(ctx)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return nil
+	}
+	if err = fn(r); err != nil {
+		return err
+	}
+	return s.SetPendingEpochRewards(ctx, r)
+}
+
+// RecordEpochFees adds the given disbursed fees to the rewards report of
+// the current epoch.
+func (s *MutableState) RecordEpochFees(ctx context.Context, fees *staking.EpochFees) error {
+	return s.updatePendingEpochRewards(ctx, func(r *staking.EpochRewards) error {
+		return r.Fees.Add(fees)
+	})
+}
+
+// recordEpochRewards adds the given amount of paid rewards to the rewards
+// report of the current epoch.
+func (s *MutableState) recordEpochRewards(ctx context.Context, amount *quantity.Quantity) error {
+	if amount.IsZero() {
+		return nil
+	}
+	return s.updatePendingEpochRewards(ctx, func(r *staking.EpochRewards) error {
+		return r.Rewards.Add(amount)
+	})
+}
+
 func (s *MutableState) SetEpochSigning(ctx context.Context, es *EpochSigning) error {
 	err := s.ms.Insert(ctx, epochSigningKeyFmt.Encode(), cbor.Marshal(es))
 	return abciAPI.UnavailableStateError(err)
@@ -752,18 +1351,11 @@ func (s *MutableState) AddRewards(
 	factor *quantity.Quantity,
 	addresses []staking.Address,
 ) error {
-	steps, err := s.RewardSchedule(ctx)
+	scale, err := s.RewardScale(ctx, time)
 	if err != nil {
 		return err
 	}
-	var activeStep *staking.RewardStep
-	for i, step := range steps {
-		if time < step.Until {
-			activeStep = &steps[i]
-			break
-		}
-	}
-	if activeStep == nil {
+	if scale == nil {
 		// We're past the end of the schedule.
 		return nil
 	}
@@ -773,6 +1365,7 @@ func (s *MutableState) AddRewards(
 		return fmt.Errorf("tendermint/staking: loading common pool: %w", err)
 	}
 
+	var total quantity.Quantity
 	for _, addr := range addresses {
 		var ent *staking.Account
 		ent, err = s.Account(ctx, addr)
@@ -785,8 +1378,8 @@ func (s *MutableState) AddRewards(
 		if err = q.Mul(factor); err != nil {
 			return fmt.Errorf("tendermint/staking: failed multiplying by reward factor: %w", err)
 		}
-		if err = q.Mul(&activeStep.Scale); err != nil {
-			return fmt.Errorf("tendermint/staking: failed multiplying by reward step scale: %w", err)
+		if err = q.Mul(scale); err != nil {
+			return fmt.Errorf("tendermint/staking: failed multiplying by reward scale: %w", err)
 		}
 		if err = q.Quo(staking.RewardAmountDenominator); err != nil {
 			return fmt.Errorf("tendermint/staking: failed dividing by reward amount denominator: %w", err)
@@ -795,6 +1388,9 @@ func (s *MutableState) AddRewards(
 		if q.IsZero() {
 			continue
 		}
+		if err = total.Add(q); err != nil {
+			return fmt.Errorf("tendermint/staking: failed adding to total rewards: %w", err)
+		}
 
 		var com *quantity.Quantity
 		rate := ent.Escrow.CommissionSchedule.CurrentRate(time)
@@ -826,26 +1422,9 @@ func (s *MutableState) AddRewards(
 		}
 
 		if com != nil && !com.IsZero() {
-			var delegation *staking.Delegation
-			delegation, err = s.Delegation(ctx, addr, addr)
-			if err != nil {
-				return fmt.Errorf("tendermint/staking: failed to query delegation: %w", err)
-			}
-
-			if err = ent.Escrow.Active.Deposit(&delegation.Shares, commonPool, com); err != nil {
-				return fmt.Errorf("tendermint/staking: depositing commission: %w", err)
+			if err = s.disburseCommission(ctx, addr, ent, commonPool, com); err != nil {
+				return err
 			}
-
-			if err = s.SetDelegation(ctx, addr, addr, delegation); err != nil {
-				return fmt.Errorf("tendermint/staking: failed to set delegation: %w", err)
-			}
-
-			ev := cbor.Marshal(&staking.AddEscrowEvent{
-				Owner:  staking.CommonPoolAddress,
-				Escrow: addr,
-				Amount: *com,
-			})
-			ctx.EmitEvent(api.NewEventBuilder(AppName).Attribute(KeyAddEscrow, ev))
 		}
 
 		if err = s.SetAccount(ctx, addr, ent); err != nil {
@@ -856,6 +1435,9 @@ func (s *MutableState) AddRewards(
 	if err = s.SetCommonPool(ctx, commonPool); err != nil {
 		return fmt.Errorf("tendermint/staking: failed to set common pool: %w", err)
 	}
+	if err = s.recordEpochRewards(ctx, &total); err != nil {
+		return fmt.Errorf("tendermint/staking: failed to record epoch rewards: %w", err)
+	}
 
 	return nil
 }
@@ -868,18 +1450,11 @@ func (s *MutableState) AddRewardSingleAttenuated(
 	attenuationNumerator, attenuationDenominator int,
 	address staking.Address,
 ) error {
-	steps, err := s.RewardSchedule(ctx)
+	scale, err := s.RewardScale(ctx, time)
 	if err != nil {
-		return fmt.Errorf("failed to query reward schedule: %w", err)
+		return fmt.Errorf("failed to query reward scale: %w", err)
 	}
-	var activeStep *staking.RewardStep
-	for i, step := range steps {
-		if time < step.Until {
-			activeStep = &steps[i]
-			break
-		}
-	}
-	if activeStep == nil {
+	if scale == nil {
 		// We're past the end of the schedule.
 		return nil
 	}
@@ -907,8 +1482,8 @@ func (s *MutableState) AddRewardSingleAttenuated(
 	if err = q.Mul(factor); err != nil {
 		return fmt.Errorf("tendermint/staking: failed multiplying by reward factor: %w", err)
 	}
-	if err = q.Mul(&activeStep.Scale); err != nil {
-		return fmt.Errorf("tendermint/staking: failed multiplying by reward step scale: %w", err)
+	if err = q.Mul(scale); err != nil {
+		return fmt.Errorf("tendermint/staking: failed multiplying by reward scale: %w", err)
 	}
 	if err = q.Mul(&numQ); err != nil {
 		return fmt.Errorf("tendermint/staking: failed multiplying by attenuation numerator: %w", err)
@@ -923,6 +1498,7 @@ func (s *MutableState) AddRewardSingleAttenuated(
 	if q.IsZero() {
 		return nil
 	}
+	total := q.Clone()
 
 	var com *quantity.Quantity
 	rate := acct.Escrow.CommissionSchedule.CurrentRate(time)
@@ -954,36 +1530,80 @@ func (s *MutableState) AddRewardSingleAttenuated(
 	}
 
 	if com != nil && !com.IsZero() {
-		var delegation *staking.Delegation
-		delegation, err = s.Delegation(ctx, address, address)
+		if err = s.disburseCommission(ctx, address, acct, commonPool, com); err != nil {
+			return err
+		}
+	}
+
+	if err = s.SetAccount(ctx, address, acct); err != nil {
+		return fmt.Errorf("tendermint/staking: failed to set account: %w", err)
+	}
+
+	if err = s.SetCommonPool(ctx, commonPool); err != nil {
+		return fmt.Errorf("tendermint/staking: failed to set common pool: %w", err)
+	}
+	if err = s.recordEpochRewards(ctx, total); err != nil {
+		return fmt.Errorf("tendermint/staking: failed to record epoch rewards: %w", err)
+	}
+
+	return nil
+}
+
+// disburseCommission transfers the given commission from the common pool to the commission
+// destination of the given escrow account.
+//
+// In case no commission destination is configured, the commission is self-delegated to the
+// escrow account. Otherwise it is transferred to the general balance of the destination account.
+// The caller is responsible for persisting the escrow account and the common pool.
+func (s *MutableState) disburseCommission(
+	ctx *abciAPI.Context,
+	addr staking.Address,
+	acct *staking.Account,
+	commonPool *quantity.Quantity,
+	com *quantity.Quantity,
+) error {
+	if dst := acct.Escrow.CommissionDestination; dst != nil && !dst.Equal(addr) {
+		dstAcct, err := s.Account(ctx, *dst)
 		if err != nil {
-			return fmt.Errorf("tendermint/staking: failed to query delegation: %w", err)
+			return fmt.Errorf("tendermint/staking: failed to query commission destination account %s: %w", dst, err)
 		}
 
-		if err = acct.Escrow.Active.Deposit(&delegation.Shares, commonPool, com); err != nil {
-			return fmt.Errorf("tendermint/staking: failed depositing commission: %w", err)
+		if err = quantity.Move(&dstAcct.General.Balance, commonPool, com); err != nil {
+			return fmt.Errorf("tendermint/staking: failed transferring commission from common pool: %w", err)
 		}
 
-		if err = s.SetDelegation(ctx, address, address, delegation); err != nil {
-			return fmt.Errorf("tendermint/staking: failed to set delegation: %w", err)
+		if err = s.SetAccount(ctx, *dst, dstAcct); err != nil {
+			return fmt.Errorf("tendermint/staking: failed to set commission destination account: %w", err)
 		}
 
-		ev := cbor.Marshal(&staking.AddEscrowEvent{
-			Owner:  staking.CommonPoolAddress,
-			Escrow: address,
+		ev := cbor.Marshal(&staking.TransferEvent{
+			From:   staking.CommonPoolAddress,
+			To:     *dst,
 			Amount: *com,
 		})
-		ctx.EmitEvent(api.NewEventBuilder(AppName).Attribute(KeyAddEscrow, ev))
+		ctx.EmitEvent(api.NewEventBuilder(AppName).Attribute(KeyTransfer, ev))
+		return nil
 	}
 
-	if err = s.SetAccount(ctx, address, acct); err != nil {
-		return fmt.Errorf("tendermint/staking: failed to set account: %w", err)
+	delegation, err := s.Delegation(ctx, addr, addr)
+	if err != nil {
+		return fmt.Errorf("tendermint/staking: failed to query delegation: %w", err)
 	}
 
-	if err = s.SetCommonPool(ctx, commonPool); err != nil {
-		return fmt.Errorf("tendermint/staking: failed to set common pool: %w", err)
+	if err = acct.Escrow.Active.Deposit(&delegation.Shares, commonPool, com); err != nil {
+		return fmt.Errorf("tendermint/staking: failed depositing commission: %w", err)
 	}
 
+	if err = s.SetDelegation(ctx, addr, addr, delegation); err != nil {
+		return fmt.Errorf("tendermint/staking: failed to set delegation: %w", err)
+	}
+
+	ev := cbor.Marshal(&staking.AddEscrowEvent{
+		Owner:  staking.CommonPoolAddress,
+		Escrow: addr,
+		Amount: *com,
+	})
+	ctx.EmitEvent(api.NewEventBuilder(AppName).Attribute(KeyAddEscrow, ev))
 	return nil
 }
 
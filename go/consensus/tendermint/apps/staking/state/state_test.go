@@ -102,6 +102,12 @@ func TestDelegationQueries(t *testing.T) {
 		}
 		require.EqualValues(expectedDelegation, accDelegations, "DelegationsFor account should match expected delegations")
 	}
+	toDelegations, err := s.DelegationsTo(ctx, escrowAddr)
+	require.NoError(err, "DelegationsTo")
+	require.EqualValues(expectedDelegations[escrowAddr], toDelegations, "DelegationsTo should match expected delegations")
+	toDelegations, err = s.DelegationsTo(ctx, delegatorAddrs[0])
+	require.NoError(err, "DelegationsTo")
+	require.Empty(toDelegations, "DelegationsTo should be empty for accounts without delegations")
 	delegations, err := s.Delegations(ctx)
 	require.NoError(err, "state.Delegations")
 	require.EqualValues(expectedDelegations, delegations, "Delegations should match expected delegations")
@@ -115,11 +121,113 @@ func TestDelegationQueries(t *testing.T) {
 		}
 		require.EqualValues(expectedDebDelegation, accDebDelegations, "DebondingDelegationsFor account should match expected")
 	}
+	toDebDelegations, err := s.DebondingDelegationsTo(ctx, escrowAddr)
+	require.NoError(err, "DebondingDelegationsTo")
+	require.EqualValues(expectedDebDelegations[escrowAddr], toDebDelegations, "DebondingDelegationsTo should match expected")
 	debDelegations, err := s.DebondingDelegations(ctx)
 	require.NoError(err, "state.DebondingDelegations")
 	require.EqualValues(expectedDebDelegations, debDelegations, "DebondingDelegations should match expected")
 }
 
+func TestDelegationSnapshots(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock, now)
+	defer ctx.Close()
+
+	s := NewMutableState(ctx.State())
+
+	fac := memorySigner.NewFactory()
+	escrowSigner, err := fac.Generate(signature.SignerEntity, rand.Reader)
+	require.NoError(err, "generating escrow signer")
+	escrowAddr := staking.NewAddress(escrowSigner.Public())
+	otherEscrowSigner, err := fac.Generate(signature.SignerEntity, rand.Reader)
+	require.NoError(err, "generating other escrow signer")
+	otherEscrowAddr := staking.NewAddress(otherEscrowSigner.Public())
+	delegatorSigner, err := fac.Generate(signature.SignerEntity, rand.Reader)
+	require.NoError(err, "generating delegator signer")
+	delegatorAddr := staking.NewAddress(delegatorSigner.Public())
+
+	_, err = s.DelegationsAt(ctx, escrowAddr, 1)
+	require.Equal(staking.ErrNoDelegationSnapshot, err, "DelegationsAt without a snapshot should fail")
+
+	// Snapshot delegations at epoch 1.
+	del1 := &staking.Delegation{Shares: mustInitQuantity(t, 100)}
+	err = s.SetDelegation(ctx, delegatorAddr, escrowAddr, del1)
+	require.NoError(err, "SetDelegation")
+	otherDel := &staking.Delegation{Shares: mustInitQuantity(t, 50)}
+	err = s.SetDelegation(ctx, delegatorAddr, otherEscrowAddr, otherDel)
+	require.NoError(err, "SetDelegation")
+	err = s.SnapshotDelegations(ctx, 1)
+	require.NoError(err, "SnapshotDelegations")
+
+	// Change the delegation and snapshot again at epoch 2.
+	del2 := &staking.Delegation{Shares: mustInitQuantity(t, 300)}
+	err = s.SetDelegation(ctx, delegatorAddr, escrowAddr, del2)
+	require.NoError(err, "SetDelegation")
+	err = s.SnapshotDelegations(ctx, 2)
+	require.NoError(err, "SnapshotDelegations")
+
+	// Remove the delegation and snapshot again at epoch 3.
+	err = s.SetDelegation(ctx, delegatorAddr, escrowAddr, &staking.Delegation{})
+	require.NoError(err, "SetDelegation")
+	err = s.SnapshotDelegations(ctx, 3)
+	require.NoError(err, "SnapshotDelegations")
+
+	// Only changes should be stored.
+	entries, err := s.delegationSnapshotEntries(ctx)
+	require.NoError(err, "delegationSnapshotEntries")
+	require.Len(entries, 4, "only changed delegations should be stored")
+
+	for _, tc := range []struct {
+		escrowAddr staking.Address
+		epoch      epochtime.EpochTime
+		expected   map[staking.Address]*staking.Delegation
+	}{
+		{escrowAddr, 1, map[staking.Address]*staking.Delegation{delegatorAddr: del1}},
+		{escrowAddr, 2, map[staking.Address]*staking.Delegation{delegatorAddr: del2}},
+		{escrowAddr, 3, map[staking.Address]*staking.Delegation{}},
+		{otherEscrowAddr, 1, map[staking.Address]*staking.Delegation{delegatorAddr: otherDel}},
+		{otherEscrowAddr, 3, map[staking.Address]*staking.Delegation{delegatorAddr: otherDel}},
+		{delegatorAddr, 2, map[staking.Address]*staking.Delegation{}},
+	} {
+		delegations, derr := s.DelegationsAt(ctx, tc.escrowAddr, tc.epoch)
+		require.NoError(derr, "DelegationsAt")
+		require.EqualValues(tc.expected, delegations, "DelegationsAt epoch %d", tc.epoch)
+	}
+
+	epochs, err := s.DelegationSnapshotEpochs(ctx)
+	require.NoError(err, "DelegationSnapshotEpochs")
+	require.Equal([]epochtime.EpochTime{1, 2, 3}, epochs, "snapshots should be available for epochs 1, 2 and 3")
+
+	// Prune the snapshot at epoch 1.
+	err = s.PruneDelegationSnapshots(ctx, 2)
+	require.NoError(err, "PruneDelegationSnapshots")
+	_, err = s.DelegationsAt(ctx, escrowAddr, 1)
+	require.Equal(staking.ErrNoDelegationSnapshot, err, "DelegationsAt for a pruned snapshot should fail")
+	delegations, err := s.DelegationsAt(ctx, escrowAddr, 2)
+	require.NoError(err, "DelegationsAt")
+	require.EqualValues(map[staking.Address]*staking.Delegation{delegatorAddr: del2}, delegations, "DelegationsAt epoch 2 after pruning")
+	delegations, err = s.DelegationsAt(ctx, otherEscrowAddr, 2)
+	require.NoError(err, "DelegationsAt")
+	require.EqualValues(map[staking.Address]*staking.Delegation{delegatorAddr: otherDel}, delegations, "unchanged delegations should be retained after pruning")
+	epochs, err = s.DelegationSnapshotEpochs(ctx)
+	require.NoError(err, "DelegationSnapshotEpochs")
+	require.Equal([]epochtime.EpochTime{2, 3}, epochs, "only the snapshots for epochs 2 and 3 should remain")
+
+	// Prune all snapshots.
+	err = s.PruneDelegationSnapshots(ctx, 4)
+	require.NoError(err, "PruneDelegationSnapshots")
+	epochs, err = s.DelegationSnapshotEpochs(ctx)
+	require.NoError(err, "DelegationSnapshotEpochs")
+	require.Empty(epochs, "no snapshots should remain")
+	entries, err = s.delegationSnapshotEntries(ctx)
+	require.NoError(err, "delegationSnapshotEntries")
+	require.Empty(entries, "no snapshot entries should remain")
+}
+
 func TestRewardAndSlash(t *testing.T) {
 	require := require.New(t)
 
@@ -279,6 +387,249 @@ func TestRewardAndSlash(t *testing.T) {
 	require.Equal(mustInitQuantityP(t, 9827), commonPool, "reward attenuated - common pool")
 }
 
+func TestEpochRewards(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock, now)
+	defer ctx.Close()
+
+	s := NewMutableState(ctx.State())
+
+	err := s.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		RewardSchedule: []staking.RewardStep{
+			{
+				Until: 30,
+				Scale: mustInitQuantity(t, 1000),
+			},
+		},
+	})
+	require.NoError(err, "SetConsensusParameters")
+	err = s.SetCommonPool(ctx, mustInitQuantityP(t, 10000))
+	require.NoError(err, "SetCommonPool")
+
+	escrowSigner, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "generating escrow signer")
+	escrowAddr := staking.NewAddress(escrowSigner.Public())
+	escrowAccount := &staking.Account{}
+	escrowAccount.Escrow.Active.Balance = mustInitQuantity(t, 100)
+	escrowAccount.Escrow.Active.TotalShares = mustInitQuantity(t, 100)
+	err = s.SetAccount(ctx, escrowAddr, escrowAccount)
+	require.NoError(err, "SetAccount")
+
+	// Nothing should be recorded while no report is being accumulated.
+	err = s.RecordEpochFees(ctx, &staking.EpochFees{Propose: mustInitQuantity(t, 10)})
+	require.NoError(err, "RecordEpochFees")
+	pending, err := s.PendingEpochRewards(ctx)
+	require.NoError(err, "PendingEpochRewards")
+	require.Nil(pending, "no report should be accumulated")
+
+	err = s.SetPendingEpochRewards(ctx, &staking.EpochRewards{
+		Epoch:           10,
+		CommonPoolStart: mustInitQuantity(t, 10000),
+	})
+	require.NoError(err, "SetPendingEpochRewards")
+
+	// 100% gain.
+	err = s.AddRewards(ctx, 10, mustInitQuantityP(t, 100_000), []staking.Address{escrowAddr})
+	require.NoError(err, "AddRewards")
+	// 50% attenuated gain.
+	err = s.AddRewardSingleAttenuated(ctx, 10, mustInitQuantityP(t, 100_000), 1, 2, escrowAddr)
+	require.NoError(err, "AddRewardSingleAttenuated")
+	err = s.RecordEpochFees(ctx, &staking.EpochFees{
+		Propose:    mustInitQuantity(t, 10),
+		CommonPool: mustInitQuantity(t, 1),
+	})
+	require.NoError(err, "RecordEpochFees")
+	err = s.RecordEpochFees(ctx, &staking.EpochFees{
+		Vote:        mustInitQuantity(t, 6),
+		NextPropose: mustInitQuantity(t, 3),
+		CommonPool:  mustInitQuantity(t, 1),
+	})
+	require.NoError(err, "RecordEpochFees")
+
+	pending, err = s.PendingEpochRewards(ctx)
+	require.NoError(err, "PendingEpochRewards")
+	require.NotNil(pending, "report should be accumulated")
+	require.EqualValues(10, pending.Epoch, "report epoch")
+	require.Equal(mustInitQuantity(t, 200), pending.Rewards, "paid rewards")
+	require.Equal(mustInitQuantity(t, 10), pending.Fees.Propose, "proposer fees")
+	require.Equal(mustInitQuantity(t, 6), pending.Fees.Vote, "voter fees")
+	require.Equal(mustInitQuantity(t, 3), pending.Fees.NextPropose, "next proposer fees")
+	require.Equal(mustInitQuantity(t, 2), pending.Fees.CommonPool, "common pool fees")
+
+	_, err = s.EpochRewards(ctx, 10)
+	require.Equal(staking.ErrNoEpochRewards, err, "EpochRewards for an unfinished epoch should fail")
+
+	commonPool, err := s.CommonPool(ctx)
+	require.NoError(err, "CommonPool")
+	pending.CommonPoolEnd = *commonPool
+	err = s.SetEpochRewards(ctx, pending)
+	require.NoError(err, "SetEpochRewards")
+
+	report, err := s.EpochRewards(ctx, 10)
+	require.NoError(err, "EpochRewards")
+	require.EqualValues(pending, report, "EpochRewards should return the stored report")
+	delta, decreased := report.CommonPoolDelta()
+	require.True(decreased, "common pool should decrease")
+	require.Equal(mustInitQuantityP(t, 200), delta, "common pool delta")
+}
+
+func TestEscrowThreshold(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer ctx.Close()
+
+	s := NewMutableState(ctx.State())
+
+	err := s.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		Thresholds: map[staking.ThresholdKind]quantity.Quantity{
+			staking.KindEntity: mustInitQuantity(t, 100),
+		},
+	})
+	require.NoError(err, "SetConsensusParameters")
+	err = s.SetCommonPool(ctx, mustInitQuantityP(t, 0))
+	require.NoError(err, "SetCommonPool")
+
+	escrowSigner, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "generating escrow signer")
+	escrowAddr := staking.NewAddress(escrowSigner.Public())
+	escrowAccount := &staking.Account{}
+	escrowAccount.Escrow.Active.Balance = mustInitQuantity(t, 150)
+	escrowAccount.Escrow.Active.TotalShares = mustInitQuantity(t, 150)
+	escrowAccount.Escrow.StakeAccumulator.AddClaimUnchecked(staking.StakeClaim("entity"), staking.GlobalStakeThresholds(staking.KindEntity))
+	err = s.SetAccount(ctx, escrowAddr, escrowAccount)
+	require.NoError(err, "SetAccount")
+
+	addrs, err := s.BelowThresholdAccounts(ctx)
+	require.NoError(err, "BelowThresholdAccounts")
+	require.Empty(addrs, "no accounts should be below threshold")
+	require.False(ctx.HasEvent(AppName, KeyEscrowThreshold), "no threshold event should be emitted")
+
+	// Slashing should bring the account below the threshold.
+	_, err = s.SlashEscrow(ctx, escrowAddr, mustInitQuantityP(t, 100))
+	require.NoError(err, "SlashEscrow")
+
+	addrs, err = s.BelowThresholdAccounts(ctx)
+	require.NoError(err, "BelowThresholdAccounts")
+	require.EqualValues([]staking.Address{escrowAddr}, addrs, "slashed account should be below threshold")
+	require.True(ctx.HasEvent(AppName, KeyEscrowThreshold), "threshold breach event should be emitted")
+
+	// Adding more stake should recover the account.
+	numEvents := len(ctx.GetEvents())
+	escrowAccount, err = s.Account(ctx, escrowAddr)
+	require.NoError(err, "Account")
+	escrowAccount.Escrow.Active.Balance = mustInitQuantity(t, 100)
+	err = s.SetAccount(ctx, escrowAddr, escrowAccount)
+	require.NoError(err, "SetAccount")
+
+	addrs, err = s.BelowThresholdAccounts(ctx)
+	require.NoError(err, "BelowThresholdAccounts")
+	require.Empty(addrs, "recovered account should not be below threshold")
+	require.Len(ctx.GetEvents(), numEvents+1, "threshold recovery event should be emitted")
+}
+
+func TestRewardCommissionDestination(t *testing.T) {
+	require := require.New(t)
+
+	delegatorSigner, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "generating delegator signer")
+	delegatorAddr := staking.NewAddress(delegatorSigner.Public())
+	delegatorAccount := &staking.Account{}
+	err = delegatorAccount.General.Balance.FromBigInt(big.NewInt(100))
+	require.NoError(err, "initialize delegator account general balance")
+
+	destinationSigner, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "generating destination signer")
+	destinationAddr := staking.NewAddress(destinationSigner.Public())
+
+	escrowSigner, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "generating escrow signer")
+	escrowAddr := staking.NewAddress(escrowSigner.Public())
+	escrowAccount := &staking.Account{}
+	escrowAccount.Escrow.CommissionSchedule = staking.CommissionSchedule{
+		Rates: []staking.CommissionRateStep{
+			{
+				Start: 0,
+				Rate:  mustInitQuantity(t, 20_000), // 20%
+			},
+		},
+		Bounds: []staking.CommissionRateBoundStep{
+			{
+				Start:   0,
+				RateMin: mustInitQuantity(t, 0),
+				RateMax: mustInitQuantity(t, 100_000),
+			},
+		},
+	}
+	escrowAccount.Escrow.CommissionDestination = &destinationAddr
+
+	del := &staking.Delegation{}
+	err = escrowAccount.Escrow.Active.Deposit(&del.Shares, &delegatorAccount.General.Balance, mustInitQuantityP(t, 100))
+	require.NoError(err, "active escrow deposit")
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextBeginBlock, now)
+	defer ctx.Close()
+
+	s := NewMutableState(ctx.State())
+
+	err = s.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		RewardSchedule: []staking.RewardStep{
+			{
+				Until: 30,
+				Scale: mustInitQuantity(t, 1000),
+			},
+		},
+	})
+	require.NoError(err, "SetConsensusParameters")
+	err = s.SetCommonPool(ctx, mustInitQuantityP(t, 10000))
+	require.NoError(err, "SetCommonPool")
+
+	err = s.SetAccount(ctx, delegatorAddr, delegatorAccount)
+	require.NoError(err, "SetAccount")
+	err = s.SetAccount(ctx, escrowAddr, escrowAccount)
+	require.NoError(err, "SetAccount")
+	err = s.SetDelegation(ctx, delegatorAddr, escrowAddr, del)
+	require.NoError(err, "SetDelegation")
+
+	require.NoError(s.AddRewards(ctx, 10, mustInitQuantityP(t, 100_000), []staking.Address{escrowAddr}), "add rewards")
+
+	// Reward is 100 base units, with 80 added to the pool and 20 transferred to the destination.
+	escrowAccount, err = s.Account(ctx, escrowAddr)
+	require.NoError(err, "Account")
+	require.Equal(mustInitQuantity(t, 180), escrowAccount.Escrow.Active.Balance, "escrow active escrow")
+	require.Equal(mustInitQuantity(t, 100), escrowAccount.Escrow.Active.TotalShares, "escrow active shares")
+	escrowSelfDel, err := s.Delegation(ctx, escrowAddr, escrowAddr)
+	require.NoError(err, "Delegation")
+	require.True(escrowSelfDel.Shares.IsZero(), "escrow self delegation shares")
+	destinationAccount, err := s.Account(ctx, destinationAddr)
+	require.NoError(err, "Account")
+	require.Equal(mustInitQuantity(t, 20), destinationAccount.General.Balance, "destination general")
+	commonPool, err := s.CommonPool(ctx)
+	require.NoError(err, "load common pool")
+	require.Equal(mustInitQuantityP(t, 9900), commonPool, "common pool")
+
+	// Attenuated rewards should also be routed to the destination.
+	require.NoError(s.AddRewardSingleAttenuated(ctx, 10, mustInitQuantityP(t, 10_000), 5, 10, escrowAddr), "add attenuated rewards")
+
+	// Reward is 9 base units, with 8 added to the pool and 1 transferred to the destination.
+	escrowAccount, err = s.Account(ctx, escrowAddr)
+	require.NoError(err, "Account")
+	require.Equal(mustInitQuantity(t, 188), escrowAccount.Escrow.Active.Balance, "attenuated reward - escrow active escrow")
+	destinationAccount, err = s.Account(ctx, destinationAddr)
+	require.NoError(err, "Account")
+	require.Equal(mustInitQuantity(t, 21), destinationAccount.General.Balance, "attenuated reward - destination general")
+	commonPool, err = s.CommonPool(ctx)
+	require.NoError(err, "load common pool")
+	require.Equal(mustInitQuantityP(t, 9891), commonPool, "attenuated reward - common pool")
+}
+
 func TestEpochSigning(t *testing.T) {
 	require := require.New(t)
 
@@ -333,3 +684,73 @@ func TestEpochSigning(t *testing.T) {
 	require.Zero(esClear.Total, "cleared epoch signing info total")
 	require.Empty(esClear.ByEntity, "cleared epoch signing info by entity")
 }
+
+func TestTotalStaked(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer ctx.Close()
+
+	s := NewMutableState(ctx.State())
+
+	err := s.SetConsensusParameters(ctx, &staking.ConsensusParameters{})
+	require.NoError(err, "SetConsensusParameters")
+	err = s.SetCommonPool(ctx, mustInitQuantityP(t, 0))
+	require.NoError(err, "SetCommonPool")
+
+	requireTotalStaked := func(expected int64, msg string) {
+		total, terr := s.TotalStaked(ctx)
+		require.NoError(terr, "TotalStaked")
+		require.Equal(mustInitQuantityP(t, expected), total, msg)
+	}
+	requireTotalStaked(0, "total staked should be zero initially")
+
+	addr1 := staking.NewAddress(memorySigner.NewTestSigner("total staked test 1").Public())
+	addr2 := staking.NewAddress(memorySigner.NewTestSigner("total staked test 2").Public())
+
+	acct1 := &staking.Account{}
+	acct1.General.Balance = mustInitQuantity(t, 1000)
+	acct1.Escrow.Active.Balance = mustInitQuantity(t, 100)
+	acct1.Escrow.Active.TotalShares = mustInitQuantity(t, 100)
+	err = s.SetAccount(ctx, addr1, acct1)
+	require.NoError(err, "SetAccount")
+	acct2 := &staking.Account{}
+	acct2.Escrow.Active.Balance = mustInitQuantity(t, 300)
+	acct2.Escrow.Active.TotalShares = mustInitQuantity(t, 300)
+	err = s.SetAccount(ctx, addr2, acct2)
+	require.NoError(err, "SetAccount")
+	requireTotalStaked(400, "total staked should include new accounts")
+
+	// Transfers between general balances should not affect the total.
+	acct1.General.Balance = mustInitQuantity(t, 500)
+	err = s.SetAccount(ctx, addr1, acct1)
+	require.NoError(err, "SetAccount")
+	acct2.General.Balance = mustInitQuantity(t, 500)
+	err = s.SetAccount(ctx, addr2, acct2)
+	require.NoError(err, "SetAccount")
+	requireTotalStaked(400, "transfers should not affect total staked")
+
+	// Escrow changes should be reflected in the total.
+	acct1.Escrow.Active.Balance = mustInitQuantity(t, 250)
+	err = s.SetAccount(ctx, addr1, acct1)
+	require.NoError(err, "SetAccount")
+	acct2.Escrow.Active.Balance = mustInitQuantity(t, 50)
+	err = s.SetAccount(ctx, addr2, acct2)
+	require.NoError(err, "SetAccount")
+	requireTotalStaked(300, "escrow changes should be reflected in total staked")
+
+	// Slashing should be reflected in the total.
+	_, err = s.SlashEscrow(ctx, addr1, mustInitQuantityP(t, 50))
+	require.NoError(err, "SlashEscrow")
+	acct1, err = s.Account(ctx, addr1)
+	require.NoError(err, "Account")
+	acct2, err = s.Account(ctx, addr2)
+	require.NoError(err, "Account")
+	expected := acct1.Escrow.Active.Balance.Clone()
+	require.NoError(expected.Add(&acct2.Escrow.Active.Balance), "Add")
+	total, err := s.TotalStaked(ctx)
+	require.NoError(err, "TotalStaked")
+	require.Equal(expected, total, "slashing should be reflected in total staked")
+}
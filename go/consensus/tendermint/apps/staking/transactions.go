@@ -21,6 +21,27 @@ func isTransferPermitted(params *staking.ConsensusParameters, fromAddr staking.A
 	return
 }
 
+// enforceAddressPolicy emits a policy violation event and returns the policy violation error in
+// case the address policy check failed.
+//
+// The event is emitted as a persistent event so that it is not lost in case the transaction is
+// executed in a child context (e.g., as part of a batch) which is discarded due to the failure.
+func (app *stakingApplication) enforceAddressPolicy(ctx *api.Context, violation *staking.PolicyViolationEvent, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	ctx.Logger().Debug("rejected transaction due to address policy",
+		"err", err,
+		"kind", violation.Kind,
+		"address", violation.Address,
+		"counterparty", violation.Counterparty,
+	)
+	ctx.EmitPersistentEvent(api.NewEventBuilder(app.Name()).Attribute(KeyPolicyViolation, cbor.Marshal(violation)))
+
+	return err
+}
+
 func (app *stakingApplication) transfer(ctx *api.Context, state *stakingState.MutableState, xfer *staking.Transfer) error {
 	if ctx.IsCheckOnly() {
 		return nil
@@ -35,10 +56,18 @@ func (app *stakingApplication) transfer(ctx *api.Context, state *stakingState.Mu
 		return err
 	}
 
-	fromAddr := staking.NewAddress(ctx.TxSigner())
+	// Check if sender provided at least a minimum amount to transfer.
+	if xfer.Amount.Cmp(&params.MinTransferAmount) < 0 {
+		return staking.ErrInvalidArgument
+	}
+
+	fromAddr := ctx.CallerAddress()
 	if fromAddr.IsReserved() || !isTransferPermitted(params, fromAddr) {
 		return staking.ErrForbidden
 	}
+	if err = app.enforceAddressPolicy(ctx, params.AddressPolicy.CheckTransfer(fromAddr, xfer.To)); err != nil {
+		return err
+	}
 
 	from, err := state.Account(ctx, fromAddr)
 	if err != nil {
@@ -100,6 +129,31 @@ func (app *stakingApplication) transfer(ctx *api.Context, state *stakingState.Mu
 	return nil
 }
 
+func (app *stakingApplication) transferBatch(ctx *api.Context, batch *staking.TransferBatch) error {
+	if err := batch.SanityCheck(); err != nil {
+		return err
+	}
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Execute the transfers in a child context so that no transfers are applied in case any of
+	// them fails. Gas is charged for each transfer.
+	childCtx := ctx.NewChild()
+	defer childCtx.Close()
+	state := stakingState.NewMutableState(childCtx.State())
+
+	for i := range batch.Transfers {
+		if err := app.transfer(childCtx, state, &batch.Transfers[i]); err != nil {
+			return fmt.Errorf("transfer %d failed: %w", i, err)
+		}
+	}
+
+	childCtx.CommitChild()
+
+	return nil
+}
+
 func (app *stakingApplication) burn(ctx *api.Context, state *stakingState.MutableState, burn *staking.Burn) error {
 	if ctx.IsCheckOnly() {
 		return nil
@@ -180,10 +234,16 @@ func (app *stakingApplication) addEscrow(ctx *api.Context, state *stakingState.M
 		return staking.ErrInvalidArgument
 	}
 
-	fromAddr := staking.NewAddress(ctx.TxSigner())
+	fromAddr := ctx.CallerAddress()
 	if fromAddr.IsReserved() {
 		return staking.ErrForbidden
 	}
+	// The delegator is credited with the resulting shares and may differ from the account that
+	// provides the escrowed stake.
+	delegatorAddr := escrow.Delegator(fromAddr)
+	if delegatorAddr.IsReserved() {
+		return staking.ErrForbidden
+	}
 
 	from, err := state.Account(ctx, fromAddr)
 	if err != nil {
@@ -198,17 +258,22 @@ func (app *stakingApplication) addEscrow(ctx *api.Context, state *stakingState.M
 	if fromAddr.Equal(escrow.Account) {
 		to = from
 	} else {
-		if params.DisableDelegation {
-			return staking.ErrForbidden
-		}
 		to, err = state.Account(ctx, escrow.Account)
 		if err != nil {
 			return fmt.Errorf("failed to fetch account: %w", err)
 		}
 	}
+	if !fromAddr.Equal(escrow.Account) || !delegatorAddr.Equal(escrow.Account) {
+		if params.DisableDelegation {
+			return staking.ErrForbidden
+		}
+		if err = app.enforceAddressPolicy(ctx, params.AddressPolicy.CheckDelegation(delegatorAddr, escrow.Account)); err != nil {
+			return err
+		}
+	}
 
 	// Fetch delegation.
-	delegation, err := state.Delegation(ctx, fromAddr, escrow.Account)
+	delegation, err := state.Delegation(ctx, delegatorAddr, escrow.Account)
 	if err != nil {
 		return fmt.Errorf("failed to fetch delegation: %w", err)
 	}
@@ -218,6 +283,7 @@ func (app *stakingApplication) addEscrow(ctx *api.Context, state *stakingState.M
 			"err", err,
 			"from", fromAddr,
 			"to", escrow.Account,
+			"delegator", delegatorAddr,
 			"amount", escrow.Amount,
 		)
 		return err
@@ -233,13 +299,14 @@ func (app *stakingApplication) addEscrow(ctx *api.Context, state *stakingState.M
 		}
 	}
 	// Commit delegation descriptor.
-	if err = state.SetDelegation(ctx, fromAddr, escrow.Account, delegation); err != nil {
+	if err = state.SetDelegation(ctx, delegatorAddr, escrow.Account, delegation); err != nil {
 		return fmt.Errorf("failed to set delegation: %w", err)
 	}
 
 	ctx.Logger().Debug("AddEscrow: escrowed stake",
 		"from", fromAddr,
 		"to", escrow.Account,
+		"delegator", delegatorAddr,
 		"amount", escrow.Amount,
 	)
 
@@ -248,6 +315,9 @@ func (app *stakingApplication) addEscrow(ctx *api.Context, state *stakingState.M
 		Escrow: escrow.Account,
 		Amount: escrow.Amount,
 	}
+	if !delegatorAddr.Equal(fromAddr) {
+		evt.Beneficiary = &delegatorAddr
+	}
 	ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyAddEscrow, cbor.Marshal(evt)))
 
 	return nil
@@ -272,7 +342,7 @@ func (app *stakingApplication) reclaimEscrow(ctx *api.Context, state *stakingSta
 		return err
 	}
 
-	toAddr := staking.NewAddress(ctx.TxSigner())
+	toAddr := ctx.CallerAddress()
 	if toAddr.IsReserved() {
 		return staking.ErrForbidden
 	}
@@ -406,6 +476,10 @@ func (app *stakingApplication) amendCommissionSchedule(
 		return fmt.Errorf("failed to fetch account: %w", err)
 	}
 
+	oldSchedule := staking.CommissionSchedule{
+		Rates:  append([]staking.CommissionRateStep(nil), from.Escrow.CommissionSchedule.Rates...),
+		Bounds: append([]staking.CommissionRateBoundStep(nil), from.Escrow.CommissionSchedule.Bounds...),
+	}
 	if err = from.Escrow.CommissionSchedule.AmendAndPruneAndValidate(&amendCommissionSchedule.Amendment, &params.CommissionScheduleRules, epoch); err != nil {
 		ctx.Logger().Error("AmendCommissionSchedule: amendment not acceptable",
 			"err", err,
@@ -418,6 +492,13 @@ func (app *stakingApplication) amendCommissionSchedule(
 		return fmt.Errorf("failed to set account: %w", err)
 	}
 
+	evt := &staking.CommissionScheduleAmendmentEvent{
+		Owner: fromAddr,
+		Old:   oldSchedule,
+		New:   from.Escrow.CommissionSchedule,
+	}
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyCommissionScheduleAmendment, cbor.Marshal(evt)))
+
 	return nil
 }
 
@@ -528,6 +609,11 @@ func (app *stakingApplication) withdraw(
 		return staking.ErrForbidden
 	}
 
+	// Check if sender provided at least a minimum amount to withdraw.
+	if withdraw.Amount.Cmp(&params.MinTransferAmount) < 0 {
+		return staking.ErrInvalidArgument
+	}
+
 	// Validate addresses -- if either is reserved or both are equal, the method should fail.
 	toAddr := staking.NewAddress(ctx.TxSigner())
 	if toAddr.IsReserved() || withdraw.From.IsReserved() {
@@ -536,6 +622,9 @@ func (app *stakingApplication) withdraw(
 	if toAddr.Equal(withdraw.From) {
 		return staking.ErrInvalidArgument
 	}
+	if err = app.enforceAddressPolicy(ctx, params.AddressPolicy.CheckTransfer(withdraw.From, toAddr)); err != nil {
+		return err
+	}
 
 	from, err := state.Account(ctx, withdraw.From)
 	if err != nil {
@@ -595,3 +684,53 @@ func (app *stakingApplication) withdraw(
 
 	return nil
 }
+
+func (app *stakingApplication) setCommissionDestination(
+	ctx *api.Context,
+	state *stakingState.MutableState,
+	setCommissionDestination *staking.SetCommissionDestination,
+) error {
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch consensus parameters: %w", err)
+	}
+	if err = ctx.Gas().UseGas(1, staking.GasOpSetCommissionDestination, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Validate addresses -- if either is reserved, the method should fail.
+	addr := staking.NewAddress(ctx.TxSigner())
+	if addr.IsReserved() || setCommissionDestination.Destination.IsReserved() {
+		return staking.ErrForbidden
+	}
+
+	acct, err := state.Account(ctx, addr)
+	if err != nil {
+		return fmt.Errorf("failed to fetch account: %w", err)
+	}
+
+	if addr.Equal(setCommissionDestination.Destination) {
+		// Setting the destination to the account itself restores the default.
+		acct.Escrow.CommissionDestination = nil
+	} else {
+		dst := setCommissionDestination.Destination
+		acct.Escrow.CommissionDestination = &dst
+	}
+
+	if err = state.SetAccount(ctx, addr, acct); err != nil {
+		return fmt.Errorf("failed to set account: %w", err)
+	}
+
+	evt := &staking.CommissionDestinationChangeEvent{
+		Owner:       addr,
+		Destination: setCommissionDestination.Destination,
+	}
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyCommissionDestinationChange, cbor.Marshal(evt)))
+
+	return nil
+}
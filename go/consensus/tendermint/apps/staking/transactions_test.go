@@ -1,11 +1,13 @@
 package staking
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
@@ -112,6 +114,292 @@ func TestReservedAddresses(t *testing.T) {
 
 	err = app.withdraw(ctx, stakeState, &staking.Withdraw{})
 	require.EqualError(err, "staking: forbidden by policy", "withdraw for reserved address should error")
+
+	err = app.setCommissionDestination(ctx, stakeState, &staking.SetCommissionDestination{})
+	require.EqualError(err, "staking: forbidden by policy", "set commission destination for reserved address should error")
+}
+
+func TestAddressPolicy(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	pk1 := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr1 := staking.NewAddress(pk1)
+	pk2 := signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr2 := staking.NewAddress(pk2)
+	addr3 := staking.NewAddress(signature.NewPublicKey("cccfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		AddressPolicy: &staking.AddressPolicy{
+			Send: &staking.AddressList{
+				Mode:      staking.AddressListAllow,
+				Addresses: map[staking.Address]bool{addr1: true},
+			},
+			Receive: &staking.AddressList{
+				Mode:      staking.AddressListDeny,
+				Addresses: map[staking.Address]bool{addr3: true},
+			},
+			ReceiveDelegation: &staking.AddressList{
+				Mode:      staking.AddressListAllow,
+				Addresses: map[staking.Address]bool{addr1: true},
+			},
+		},
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	err = stakeState.SetAccount(ctx, addr1, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(100),
+		},
+	})
+	require.NoError(err, "SetAccount")
+	err = stakeState.SetAccount(ctx, addr2, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(100),
+		},
+	})
+	require.NoError(err, "SetAccount")
+
+	// Transfers from permitted senders to permitted receivers should succeed.
+	ctx.SetTxSigner(pk1)
+	err = app.transfer(ctx, stakeState, &staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(10)})
+	require.NoError(err, "transfer between permitted addresses")
+
+	// Transfers to denied receivers should fail.
+	numEvents := len(ctx.GetEvents())
+	err = app.transfer(ctx, stakeState, &staking.Transfer{To: addr3, Amount: *quantity.NewFromUint64(10)})
+	require.Equal(staking.ErrReceiveForbiddenByPolicy, err, "transfer to denied receiver")
+	require.Len(ctx.GetEvents(), numEvents+1, "policy violation should emit an event")
+
+	// Transfers from senders that are not allowed should fail.
+	ctx.SetTxSigner(pk2)
+	err = app.transfer(ctx, stakeState, &staking.Transfer{To: addr1, Amount: *quantity.NewFromUint64(10)})
+	require.Equal(staking.ErrSendForbiddenByPolicy, err, "transfer from sender that is not allowed")
+
+	// Delegations to escrow accounts that are not allowed should fail.
+	ctx.SetTxSigner(pk1)
+	err = app.addEscrow(ctx, stakeState, &staking.Escrow{Account: addr2, Amount: *quantity.NewFromUint64(10)})
+	require.Equal(staking.ErrReceiveDelegationForbiddenByPolicy, err, "delegation to escrow account that is not allowed")
+
+	acct, err := stakeState.Account(ctx, addr1)
+	require.NoError(err, "Account")
+	require.EqualValues(*quantity.NewFromUint64(90), acct.General.Balance, "only the permitted transfer should be executed")
+
+	// Policy violations in a failed transfer batch should still emit an event.
+	numEvents = len(ctx.GetEvents())
+	err = app.transferBatch(ctx, &staking.TransferBatch{
+		Transfers: []staking.Transfer{
+			{To: addr2, Amount: *quantity.NewFromUint64(10)},
+			{To: addr3, Amount: *quantity.NewFromUint64(10)},
+		},
+	})
+	require.True(errors.Is(err, staking.ErrReceiveForbiddenByPolicy), "batch with transfer to denied receiver")
+	require.Len(ctx.GetEvents(), numEvents+1, "only the policy violation event should be emitted")
+	require.True(ctx.HasEvent(app.Name(), KeyPolicyViolation), "policy violation event should be emitted")
+
+	acct, err = stakeState.Account(ctx, addr1)
+	require.NoError(err, "Account")
+	require.EqualValues(*quantity.NewFromUint64(90), acct.General.Balance, "failed batch should not be applied")
+}
+
+func TestAddEscrowBeneficiary(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	pk1 := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr1 := staking.NewAddress(pk1)
+	addr2 := staking.NewAddress(signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	addr3 := staking.NewAddress(signature.NewPublicKey("cccfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+
+	err = stakeState.SetAccount(ctx, addr1, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(100),
+		},
+	})
+	require.NoError(err, "SetAccount")
+
+	// Escrow on behalf of a beneficiary should credit the shares to the beneficiary.
+	ctx.SetTxSigner(pk1)
+	numEvents := len(ctx.GetEvents())
+	err = app.addEscrow(ctx, stakeState, &staking.Escrow{Account: addr2, Amount: *quantity.NewFromUint64(10), Beneficiary: &addr3})
+	require.NoError(err, "addEscrow")
+
+	acct, err := stakeState.Account(ctx, addr1)
+	require.NoError(err, "Account")
+	require.EqualValues(*quantity.NewFromUint64(90), acct.General.Balance, "escrowed stake should be debited from the signer")
+	acct, err = stakeState.Account(ctx, addr2)
+	require.NoError(err, "Account")
+	require.EqualValues(*quantity.NewFromUint64(10), acct.Escrow.Active.Balance, "stake should be escrowed")
+
+	dlg, err := stakeState.Delegation(ctx, addr3, addr2)
+	require.NoError(err, "Delegation")
+	require.EqualValues(*quantity.NewFromUint64(10), dlg.Shares, "shares should be credited to the beneficiary")
+	dlg, err = stakeState.Delegation(ctx, addr1, addr2)
+	require.NoError(err, "Delegation")
+	require.True(dlg.Shares.IsZero(), "no shares should be credited to the signer")
+
+	events := ctx.GetEvents()
+	require.Len(events, numEvents+1, "escrow should emit an event")
+	attrs := events[len(events)-1].GetAttributes()
+	require.Len(attrs, 1)
+	require.Equal(KeyAddEscrow, attrs[0].GetKey())
+
+	var ev staking.AddEscrowEvent
+	err = cbor.Unmarshal(attrs[0].GetValue(), &ev)
+	require.NoError(err, "event should deserialize")
+	require.Equal(addr1, ev.Owner, "event owner should be the signer")
+	require.Equal(&addr3, ev.Beneficiary, "event should include the beneficiary")
+
+	// Escrow into own account on behalf of another account is a delegation.
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{DisableDelegation: true})
+	require.NoError(err, "SetConsensusParameters")
+	err = app.addEscrow(ctx, stakeState, &staking.Escrow{Account: addr1, Amount: *quantity.NewFromUint64(10), Beneficiary: &addr3})
+	require.Equal(staking.ErrForbidden, err, "escrow on behalf of others should be forbidden with delegation disabled")
+	err = app.addEscrow(ctx, stakeState, &staking.Escrow{Account: addr1, Amount: *quantity.NewFromUint64(10), Beneficiary: &addr1})
+	require.NoError(err, "self-escrow with explicit beneficiary should be allowed")
+}
+
+func TestTransferBatch(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	pk1 := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr1 := staking.NewAddress(pk1)
+	addr2 := staking.NewAddress(signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	addr3 := staking.NewAddress(signature.NewPublicKey("cccfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+
+	err = stakeState.SetAccount(ctx, addr1, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(100),
+		},
+	})
+	require.NoError(err, "SetAccount")
+
+	balanceOf := func(addr staking.Address) quantity.Quantity {
+		acct, aerr := stakeState.Account(ctx, addr)
+		require.NoError(aerr, "Account")
+		return acct.General.Balance
+	}
+
+	ctx.SetTxSigner(pk1)
+
+	// Empty batches should be rejected.
+	err = app.transferBatch(ctx, &staking.TransferBatch{})
+	require.True(errors.Is(err, staking.ErrInvalidArgument), "empty batch should be rejected")
+
+	// A batch of valid transfers should apply all of them.
+	numEvents := len(ctx.GetEvents())
+	err = app.transferBatch(ctx, &staking.TransferBatch{
+		Transfers: []staking.Transfer{
+			{To: addr2, Amount: *quantity.NewFromUint64(10)},
+			{To: addr3, Amount: *quantity.NewFromUint64(20)},
+			{To: addr2, Amount: *quantity.NewFromUint64(30)},
+		},
+	})
+	require.NoError(err, "transferBatch")
+	require.EqualValues(*quantity.NewFromUint64(40), balanceOf(addr1))
+	require.EqualValues(*quantity.NewFromUint64(40), balanceOf(addr2))
+	require.EqualValues(*quantity.NewFromUint64(20), balanceOf(addr3))
+	require.Len(ctx.GetEvents(), numEvents+3, "each transfer should emit an event")
+
+	// A batch with any failing transfer should not apply any of them.
+	numEvents = len(ctx.GetEvents())
+	err = app.transferBatch(ctx, &staking.TransferBatch{
+		Transfers: []staking.Transfer{
+			{To: addr2, Amount: *quantity.NewFromUint64(30)},
+			{To: addr3, Amount: *quantity.NewFromUint64(30)},
+		},
+	})
+	require.True(errors.Is(err, quantity.ErrInsufficientBalance), "batch exceeding the balance should fail")
+	require.EqualValues(*quantity.NewFromUint64(40), balanceOf(addr1), "failed batch should not be applied")
+	require.EqualValues(*quantity.NewFromUint64(40), balanceOf(addr2), "failed batch should not be applied")
+	require.EqualValues(*quantity.NewFromUint64(20), balanceOf(addr3), "failed batch should not be applied")
+	require.Len(ctx.GetEvents(), numEvents, "failed batch should not emit transfer events")
+}
+
+func TestMinTransferAmount(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		MinTransferAmount: *quantity.NewFromUint64(10),
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	pk1 := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr1 := staking.NewAddress(pk1)
+	addr2 := staking.NewAddress(signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+
+	err = stakeState.SetAccount(ctx, addr1, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(100),
+		},
+	})
+	require.NoError(err, "SetAccount")
+
+	ctx.SetTxSigner(pk1)
+
+	err = app.transfer(ctx, stakeState, &staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(9)})
+	require.True(errors.Is(err, staking.ErrInvalidArgument), "transfer below the minimum amount should fail")
+	addresses, err := stakeState.Addresses(ctx)
+	require.NoError(err, "Addresses")
+	require.NotContains(addresses, addr2, "failed transfer should not create the destination account")
+
+	err = app.transfer(ctx, stakeState, &staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(10)})
+	require.NoError(err, "transfer of the minimum amount should succeed")
+	acct, err := stakeState.Account(ctx, addr2)
+	require.NoError(err, "Account")
+	require.EqualValues(*quantity.NewFromUint64(10), acct.General.Balance)
 }
 
 func TestAllow(t *testing.T) {
@@ -516,3 +804,127 @@ func TestWithdraw(t *testing.T) {
 		require.Equal(expectedBalance, afterAcct.General.Balance, "general balance should be correct after withdraw")
 	}
 }
+
+func TestSetCommissionDestination(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{})
+	require.NoError(err, "setting staking consensus parameters should not error")
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	pk1 := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr1 := staking.NewAddress(pk1)
+	pk2 := signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr2 := staking.NewAddress(pk2)
+
+	reservedPK := signature.NewPublicKey("badaffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	reservedAddr := staking.NewReservedAddress(reservedPK)
+
+	ctx.SetTxSigner(pk1)
+
+	for _, tc := range []struct {
+		msg                 string
+		destination         staking.Address
+		err                 error
+		expectedDestination *staking.Address
+	}{
+		{"should fail with reserved destination", reservedAddr, staking.ErrForbidden, nil},
+		{"should set a separate destination", addr2, nil, &addr2},
+		{"should restore the default with own address", addr1, nil, nil},
+	} {
+		err = app.setCommissionDestination(ctx, stakeState, &staking.SetCommissionDestination{
+			Destination: tc.destination,
+		})
+		require.Equal(tc.err, err, tc.msg)
+
+		var acct *staking.Account
+		acct, err = stakeState.Account(ctx, addr1)
+		require.NoError(err, tc.msg)
+		require.Equal(tc.expectedDestination, acct.Escrow.CommissionDestination, tc.msg)
+	}
+}
+
+func TestAmendCommissionScheduleEvent(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		CommissionScheduleRules: staking.CommissionScheduleRules{
+			RateChangeInterval: 10,
+			RateBoundLead:      10,
+			MaxRateSteps:       4,
+			MaxBoundSteps:      4,
+		},
+	})
+	require.NoError(err, "setting staking consensus parameters should not error")
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	pk1 := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr1 := staking.NewAddress(pk1)
+	ctx.SetTxSigner(pk1)
+
+	bound1 := staking.CommissionRateBoundStep{
+		Start:   10,
+		RateMin: *quantity.NewFromUint64(0),
+		RateMax: *quantity.NewFromUint64(50000),
+	}
+	err = app.amendCommissionSchedule(ctx, stakeState, &staking.AmendCommissionSchedule{
+		Amendment: staking.CommissionSchedule{
+			Bounds: []staking.CommissionRateBoundStep{bound1},
+		},
+	})
+	require.NoError(err, "amendCommissionSchedule")
+
+	bound2 := staking.CommissionRateBoundStep{
+		Start:   20,
+		RateMin: *quantity.NewFromUint64(0),
+		RateMax: *quantity.NewFromUint64(40000),
+	}
+	numEvents := len(ctx.GetEvents())
+	err = app.amendCommissionSchedule(ctx, stakeState, &staking.AmendCommissionSchedule{
+		Amendment: staking.CommissionSchedule{
+			Bounds: []staking.CommissionRateBoundStep{bound2},
+		},
+	})
+	require.NoError(err, "amendCommissionSchedule")
+
+	events := ctx.GetEvents()
+	require.Len(events, numEvents+1, "amendment should emit an event")
+	attrs := events[len(events)-1].GetAttributes()
+	require.Len(attrs, 1)
+	require.Equal(KeyCommissionScheduleAmendment, attrs[0].GetKey())
+
+	var ev staking.CommissionScheduleAmendmentEvent
+	err = cbor.Unmarshal(attrs[0].GetValue(), &ev)
+	require.NoError(err, "event should deserialize")
+	require.Equal(addr1, ev.Owner)
+	require.Len(ev.Old.Bounds, 1, "old schedule should contain the original bound")
+	require.Len(ev.New.Bounds, 2, "new schedule should contain both bounds")
+	for i, bound := range []staking.CommissionRateBoundStep{bound1, bound2} {
+		require.Equal(bound.Start, ev.New.Bounds[i].Start)
+		require.Zero(bound.RateMax.Cmp(&ev.New.Bounds[i].RateMax))
+	}
+	require.Equal(bound1.Start, ev.Old.Bounds[0].Start)
+	require.Zero(bound1.RateMax.Cmp(&ev.Old.Bounds[0].RateMax))
+}
@@ -287,6 +287,11 @@ func checkStakeClaims(ctx *abciAPI.Context, now epochtime.EpochTime) error {
 	if err != nil {
 		return fmt.Errorf("failed to get runtime registrations: %w", err)
 	}
+	// Get pending stake claim releases.
+	releases, err := regSt.StakeClaimReleases(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get stake claim releases: %w", err)
+	}
 	// Get staking accounts.
 	accounts := make(map[staking.Address]*staking.Account)
 	addresses, err := stakingSt.Addresses(ctx)
@@ -300,5 +305,5 @@ func checkStakeClaims(ctx *abciAPI.Context, now epochtime.EpochTime) error {
 		}
 	}
 
-	return registry.SanityCheckStake(entities, accounts, nodes, runtimes, stakingParams.Thresholds, false)
+	return registry.SanityCheckStake(entities, accounts, nodes, runtimes, releases, stakingParams.Thresholds, false)
 }
@@ -197,10 +197,12 @@ package crypto
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 	_ "unsafe" // For go:linkname.
 
@@ -228,6 +230,54 @@ func checkProposalsOnlyDifferByTimestamp(lastSignBytes, newSignBytes []byte) (ti
 
 const privValFileName = "oasis_priv_validator.json"
 
+var (
+	// ErrStandby is the error returned when the private validator is in standby.
+	ErrStandby = errors.New("tendermint/crypto: private validator in standby")
+
+	// ErrNotStandby is the error returned when the private validator is not in standby.
+	ErrNotStandby = errors.New("tendermint/crypto: private validator not in standby")
+
+	// ErrStaleHandoff is the error returned when the sequence number of a handed off private
+	// validator state is not greater than the local sequence number.
+	ErrStaleHandoff = errors.New("tendermint/crypto: stale handoff")
+)
+
+// SignerState is the double-sign guard state of a private validator.
+type SignerState struct {
+	// Standby is true iff the private validator is in standby and refuses to sign anything.
+	Standby bool
+	// Sequence is the sequence number of the last acquired or released handoff.
+	Sequence uint64
+
+	// Height is the height of the last signed message.
+	Height int64
+	// Round is the round of the last signed message.
+	Round int32
+	// Step is the step of the last signed message.
+	Step int8
+	// SignBytes are the bytes of the last signed message.
+	SignBytes []byte
+	// Signature is the signature of the last signed message.
+	Signature []byte
+}
+
+// PrivValidator is a Tendermint private validator backed by an Oasis node signature signer,
+// which supports handing off the signing role to another node using the same signer.
+type PrivValidator interface {
+	tmtypes.PrivValidator
+
+	// State returns the current double-sign guard state.
+	State() *SignerState
+
+	// Release puts the private validator into standby, increments the handoff sequence number
+	// and returns the resulting state which can be handed off to another node.
+	Release() (*SignerState, error)
+
+	// Acquire updates the double-sign guard state from a state handed off by another node and
+	// activates the private validator.
+	Acquire(state *SignerState) error
+}
+
 const (
 	// stepNone      int8 = 0
 	stepPropose   int8 = 1
@@ -250,6 +300,10 @@ type privVal struct {
 	privval.FilePVLastSignState
 	PublicKey signature.PublicKey `json:"public_key"`
 
+	Standby         bool   `json:"standby,omitempty"`
+	HandoffSequence uint64 `json:"handoff_sequence,omitempty"`
+
+	mu       sync.Mutex
 	filePath string
 	signer   signature.Signer
 }
@@ -259,6 +313,13 @@ func (pv *privVal) GetPubKey() (tmcrypto.PubKey, error) {
 }
 
 func (pv *privVal) SignVote(chainID string, vote *tmproto.Vote) error {
+	pv.mu.Lock()
+	defer pv.mu.Unlock()
+
+	if pv.Standby {
+		return ErrStandby
+	}
+
 	height, round, step := vote.Height, vote.Round, voteToStep(vote)
 
 	doubleSigned, err := pv.CheckHRS(height, round, step)
@@ -292,6 +353,13 @@ func (pv *privVal) SignVote(chainID string, vote *tmproto.Vote) error {
 }
 
 func (pv *privVal) SignProposal(chainID string, proposal *tmproto.Proposal) error {
+	pv.mu.Lock()
+	defer pv.mu.Unlock()
+
+	if pv.Standby {
+		return ErrStandby
+	}
+
 	height, round, step := proposal.Height, proposal.Round, stepPropose
 
 	doubleSigned, err := pv.CheckHRS(height, round, step)
@@ -324,6 +392,79 @@ func (pv *privVal) SignProposal(chainID string, proposal *tmproto.Proposal) erro
 	return nil
 }
 
+func (pv *privVal) State() *SignerState {
+	pv.mu.Lock()
+	defer pv.mu.Unlock()
+
+	return pv.stateLocked()
+}
+
+func (pv *privVal) stateLocked() *SignerState {
+	return &SignerState{
+		Standby:   pv.Standby,
+		Sequence:  pv.HandoffSequence,
+		Height:    pv.Height,
+		Round:     pv.Round,
+		Step:      pv.Step,
+		SignBytes: append([]byte{}, pv.SignBytes...),
+		Signature: append([]byte{}, pv.Signature...),
+	}
+}
+
+func (pv *privVal) Release() (*SignerState, error) {
+	pv.mu.Lock()
+	defer pv.mu.Unlock()
+
+	if pv.Standby {
+		return nil, ErrStandby
+	}
+
+	pv.Standby = true
+	pv.HandoffSequence++
+	if err := pv.save(); err != nil {
+		// Remain in standby, as it is not known whether the state has been persisted.
+		return nil, err
+	}
+
+	return pv.stateLocked(), nil
+}
+
+func (pv *privVal) Acquire(state *SignerState) error {
+	pv.mu.Lock()
+	defer pv.mu.Unlock()
+
+	if !pv.Standby {
+		return ErrNotStandby
+	}
+	if state.Sequence <= pv.HandoffSequence {
+		return ErrStaleHandoff
+	}
+
+	// Never move the double-sign guard backwards.
+	switch {
+	case state.Height > pv.Height,
+		state.Height == pv.Height && state.Round > pv.Round,
+		state.Height == pv.Height && state.Round == pv.Round && state.Step > pv.Step:
+		pv.Height = state.Height
+		pv.Round = state.Round
+		pv.Step = state.Step
+		pv.SignBytes = state.SignBytes
+		pv.Signature = state.Signature
+	default:
+	}
+
+	prevSequence := pv.HandoffSequence
+	pv.HandoffSequence = state.Sequence
+	pv.Standby = false
+	if err := pv.save(); err != nil {
+		// Remain in standby, as it is not known whether the state has been persisted.
+		pv.HandoffSequence = prevSequence
+		pv.Standby = true
+		return err
+	}
+	return nil
+}
+
 func (pv *privVal) update(height int64, round int32, step int8, signBytes, sig []byte) error {
 	pv.Height = height
 	pv.Round = round
@@ -347,7 +488,10 @@ func (pv *privVal) save() error {
 
 // LoadOrGeneratePrivVal loads or generates a tendermint PrivValidator for an
 // Oasis node signature signer.
-func LoadOrGeneratePrivVal(baseDir string, signer signature.Signer) (tmtypes.PrivValidator, error) {
+//
+// If standby is true, the private validator is put into standby and will not sign anything
+// until it acquires a handoff from another node.
+func LoadOrGeneratePrivVal(baseDir string, signer signature.Signer, standby bool) (PrivValidator, error) {
 	fn := filepath.Join(baseDir, privValFileName)
 
 	pv := &privVal{
@@ -375,5 +519,12 @@ func LoadOrGeneratePrivVal(baseDir string, signer signature.Signer) (tmtypes.Pri
 		return nil, fmt.Errorf("tendermint/crypto: failed to load private validator file: %w", err)
 	}
 
+	if standby && !pv.Standby {
+		pv.Standby = true
+		if err = pv.save(); err != nil {
+			return nil, err
+		}
+	}
+
 	return pv, nil
 }
@@ -0,0 +1,108 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+)
+
+const testChainID = "test-chain"
+
+func newTestVote(height int64, blockHash byte) *tmproto.Vote {
+	return &tmproto.Vote{
+		Type:   tmproto.PrecommitType,
+		Height: height,
+		Round:  0,
+		BlockID: tmproto.BlockID{
+			Hash: []byte{blockHash},
+		},
+	}
+}
+
+func TestPrivValHandoff(t *testing.T) {
+	require := require.New(t)
+
+	tmpDir, err := ioutil.TempDir("", "oasis-tendermint-privval-test")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(tmpDir)
+
+	signer, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "NewSigner")
+
+	primaryDir := filepath.Join(tmpDir, "primary")
+	standbyDir := filepath.Join(tmpDir, "standby")
+	require.NoError(os.Mkdir(primaryDir, 0o700), "Mkdir")
+	require.NoError(os.Mkdir(standbyDir, 0o700), "Mkdir")
+
+	primary, err := LoadOrGeneratePrivVal(primaryDir, signer, false)
+	require.NoError(err, "LoadOrGeneratePrivVal(primary)")
+	standby, err := LoadOrGeneratePrivVal(standbyDir, signer, true)
+	require.NoError(err, "LoadOrGeneratePrivVal(standby)")
+	require.True(standby.State().Standby, "standby should start in standby")
+
+	// Only the primary should sign.
+	err = primary.SignVote(testChainID, newTestVote(10, 0x01))
+	require.NoError(err, "primary SignVote")
+	err = standby.SignVote(testChainID, newTestVote(10, 0x01))
+	require.True(errors.Is(err, ErrStandby), "standby SignVote should fail")
+
+	// Acquiring without a handoff from the primary should fail.
+	err = standby.Acquire(&SignerState{})
+	require.True(errors.Is(err, ErrStaleHandoff), "Acquire with a stale handoff should fail")
+
+	// Hand off the signing role.
+	state, err := primary.Release()
+	require.NoError(err, "Release")
+	require.True(state.Standby, "released state should be in standby")
+	require.EqualValues(1, state.Sequence, "released state sequence number")
+	require.EqualValues(10, state.Height, "released state height")
+
+	_, err = primary.Release()
+	require.True(errors.Is(err, ErrStandby), "releasing twice should fail")
+	err = primary.SignVote(testChainID, newTestVote(11, 0x01))
+	require.True(errors.Is(err, ErrStandby), "released primary SignVote should fail")
+
+	err = standby.Acquire(state)
+	require.NoError(err, "Acquire")
+	require.False(standby.State().Standby, "standby should be active after acquire")
+	err = standby.Acquire(state)
+	require.True(errors.Is(err, ErrNotStandby), "acquiring twice should fail")
+
+	// The double-sign guard must prevent conflicting votes with what the primary signed, but
+	// allow returning the same signature.
+	err = standby.SignVote(testChainID, newTestVote(10, 0x02))
+	require.Error(err, "conflicting SignVote should fail")
+	vote := newTestVote(10, 0x01)
+	err = standby.SignVote(testChainID, vote)
+	require.NoError(err, "same SignVote should succeed")
+	require.EqualValues(state.Signature, vote.Signature, "same SignVote should return the same signature")
+	err = standby.SignVote(testChainID, newTestVote(11, 0x01))
+	require.NoError(err, "SignVote at a greater height should succeed")
+
+	// A replayed handoff must not activate the primary again after a round trip.
+	state2, err := standby.Release()
+	require.NoError(err, "Release")
+	require.EqualValues(2, state2.Sequence, "released state sequence number")
+	err = primary.Acquire(state2)
+	require.NoError(err, "Acquire")
+	_, err = primary.Release()
+	require.NoError(err, "Release")
+	err = standby.Acquire(state)
+	require.True(errors.Is(err, ErrStaleHandoff), "Acquire with a replayed handoff should fail")
+
+	// The state should be persisted.
+	reloaded, err := LoadOrGeneratePrivVal(primaryDir, signer, false)
+	require.NoError(err, "LoadOrGeneratePrivVal(primary)")
+	st := reloaded.State()
+	require.True(st.Standby, "reloaded state should be in standby")
+	require.EqualValues(3, st.Sequence, "reloaded state sequence number")
+	require.EqualValues(11, st.Height, "reloaded state height")
+}
@@ -64,37 +64,23 @@ func (sc *serviceClient) GetEpoch(ctx context.Context, height int64) (api.EpochT
 
 func (sc *serviceClient) GetEpochBlock(ctx context.Context, epoch api.EpochTime) (int64, error) {
 	sc.RLock()
-	defer sc.RUnlock()
+	current, currentBlock := sc.epoch, sc.currentBlock
+	sc.RUnlock()
 
-	if epoch == sc.epoch {
-		return sc.currentBlock, nil
+	if epoch == current {
+		return currentBlock, nil
 	}
 
-	// Find historic epoch -- it is fine if this is not optimal as mock epochtime is only for tests
-	// where the number of epoch transitions shoud be low.
-	height := consensus.HeightLatest
-	for {
-		q, err := sc.querier.QueryAt(ctx, height)
-		if err != nil {
-			return -1, fmt.Errorf("failed to query epoch: %w", err)
-		}
-
-		var pastEpoch api.EpochTime
-		pastEpoch, height, err = q.Epoch(ctx)
-		if err != nil {
-			return -1, fmt.Errorf("failed to query epoch: %w", err)
-		}
-
-		if epoch == pastEpoch {
-			return height, nil
-		}
-
-		height--
+	q, err := sc.querier.QueryAt(ctx, consensus.HeightLatest)
+	if err != nil {
+		return -1, fmt.Errorf("failed to query epoch block: %w", err)
+	}
 
-		if pastEpoch == 0 || height <= 1 {
-			return -1, fmt.Errorf("failed to find historic epoch (minimum: %d requested: %d)", pastEpoch, epoch)
-		}
+	height, err := q.EpochBlock(ctx, epoch)
+	if err != nil {
+		return -1, fmt.Errorf("failed to query epoch block: %w", err)
 	}
+	return height, nil
 }
 
 func (sc *serviceClient) WatchEpochs() (<-chan api.EpochTime, *pubsub.Subscription) {
@@ -107,6 +107,10 @@ const (
 	// CfgDebugDisableCheckTx disables CheckTx.
 	CfgDebugDisableCheckTx = "consensus.tendermint.debug.disable_check_tx"
 
+	// CfgDebugVerifyStateOnStartup enables verification of the consistency of the latest state
+	// root on startup.
+	CfgDebugVerifyStateOnStartup = "consensus.tendermint.debug.verify_state_on_startup"
+
 	// CfgSupplementarySanityEnabled is the supplementary sanity enabled flag.
 	CfgSupplementarySanityEnabled = "consensus.tendermint.supplementarysanity.enabled"
 	// CfgSupplementarySanityInterval configures the supplementary sanity check interval.
@@ -123,6 +127,23 @@ const (
 	CfgConsensusStateSyncTrustHeight = "consensus.tendermint.state_sync.trust_height"
 	// CfgConsensusStateSyncTrustHash is the known trusted block header hash for the light client.
 	CfgConsensusStateSyncTrustHash = "consensus.tendermint.state_sync.trust_hash"
+
+	// CfgSigningMonitorWindow configures the size (in blocks) of the window used to track
+	// blocks missed by the local validator.
+	CfgSigningMonitorWindow = "consensus.tendermint.signing_monitor.window"
+	// CfgSigningMonitorAlertThreshold configures the number of blocks missed in the window at
+	// which signing alerts are raised.
+	CfgSigningMonitorAlertThreshold = "consensus.tendermint.signing_monitor.alert_threshold"
+
+	// CfgStateAttestationInterval configures the interval (in blocks) at which ABCI state
+	// attestations are made.
+	CfgStateAttestationInterval = "consensus.tendermint.state_attestation.interval"
+	// CfgStateAttestationNumKept configures the number of kept ABCI state attestations.
+	CfgStateAttestationNumKept = "consensus.tendermint.state_attestation.num_kept"
+
+	// CfgSignerStandby starts the consensus signer in standby so that the node does not sign any
+	// consensus messages until it acquires a signer handoff from another node.
+	CfgSignerStandby = "consensus.tendermint.signer.standby"
 )
 
 const (
@@ -160,6 +181,10 @@ type fullService struct { // nolint: maligned
 	blockNotifier *pubsub.Broker
 	failMonitor   *failMonitor
 
+	signingMonitor *signingMonitor
+	peerBans       *peerBans
+	privVal        crypto.PrivValidator
+
 	stateStore tmstate.Store
 
 	beacon        beaconAPI.Backend
@@ -231,6 +256,7 @@ func (t *fullService) Start() error {
 		// Optionally start metrics updater.
 		if cmmetrics.Enabled() {
 			go t.metrics()
+			go t.peerMetrics()
 		}
 	case false:
 		close(t.syncedCh)
@@ -409,6 +435,10 @@ func (t *fullService) RegisterHaltHook(hook func(context.Context, int64, epochti
 	t.mux.RegisterHaltHook(hook)
 }
 
+func (t *fullService) RegisterSigningAlertHandler(handler consensusAPI.SigningAlertHandler) {
+	t.signingMonitor.registerHandler(handler)
+}
+
 func (t *fullService) SubmitTx(ctx context.Context, tx *transaction.SignedTransaction) error {
 	// Subscribe to the transaction being included in a block.
 	data := cbor.Marshal(tx)
@@ -619,6 +649,13 @@ func (t *fullService) GetEpoch(ctx context.Context, height int64) (epochtimeAPI.
 	return t.epochtime.GetEpoch(ctx, height)
 }
 
+func (t *fullService) GetEpochBlock(ctx context.Context, epoch epochtimeAPI.EpochTime) (int64, error) {
+	if t.epochtime == nil {
+		return -1, consensusAPI.ErrUnsupported
+	}
+	return t.epochtime.GetEpochBlock(ctx, epoch)
+}
+
 func (t *fullService) WaitEpoch(ctx context.Context, epoch epochtimeAPI.EpochTime) error {
 	if t.epochtime == nil {
 		return consensusAPI.ErrUnsupported
@@ -651,7 +688,63 @@ func (t *fullService) GetBlock(ctx context.Context, height int64) (*consensusAPI
 		return nil, consensusAPI.ErrNoCommittedBlocks
 	}
 
-	return api.NewBlock(blk), nil
+	return t.newBlock(ctx, blk), nil
+}
+
+// newBlock creates a new consensus block from a Tendermint block, resolving the
+// block proposer via the registry.
+func (t *fullService) newBlock(ctx context.Context, blk *tmtypes.Block) *consensusAPI.Block {
+	var proposer *node.Node
+	if t.registry != nil && t.scheduler != nil {
+		var err error
+		proposer, err = resolveBlockProposer(ctx, t.registry, t.scheduler, blk, t.genesis.Height)
+		if err != nil {
+			t.Logger.Debug("failed to resolve block proposer",
+				"err", err,
+				"height", blk.Header.Height,
+				"proposer_address", blk.Header.ProposerAddress,
+			)
+			proposer = nil
+		}
+	}
+	return api.NewBlock(blk, proposer)
+}
+
+// resolveBlockProposer resolves the node that proposed the given block.
+//
+// Tendermint applies validator updates returned at height H starting with
+// block H+2, so the proposer is resolved against the validator set in the state
+// two heights before the block.
+func resolveBlockProposer(
+	ctx context.Context,
+	registry registryAPI.Backend,
+	scheduler schedulerAPI.Backend,
+	blk *tmtypes.Block,
+	initialHeight int64,
+) (*node.Node, error) {
+	height := blk.Header.Height - 2
+	if height < initialHeight {
+		// There is no state before the initial height, use the genesis state.
+		height = initialHeight
+	}
+
+	proposer, err := registry.GetNodeByConsensusAddress(ctx, &registryAPI.ConsensusAddressQuery{
+		Address: blk.Header.ProposerAddress,
+		Height:  height,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up proposer node: %w", err)
+	}
+	validators, err := scheduler.GetValidators(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query validators: %w", err)
+	}
+	for _, v := range validators {
+		if v.ID.Equal(proposer.ID) {
+			return proposer, nil
+		}
+	}
+	return nil, fmt.Errorf("proposer node %s is not in the validator set at height %d", proposer.ID, height)
 }
 
 func (t *fullService) GetSignerNonce(ctx context.Context, req *consensusAPI.GetSignerNonceRequest) (uint64, error) {
@@ -759,6 +852,9 @@ func (t *fullService) GetStatus(ctx context.Context) (*consensusAPI.Status, erro
 		ConsensusVersion: version.ConsensusProtocol.String(),
 		Backend:          api.BackendName,
 		Features:         t.SupportedFeatures(),
+		ChainID:          t.genesis.ChainID,
+		ChainContext:     t.genesis.ChainContext(),
+		HaltEpoch:        t.genesis.HaltEpoch,
 	}
 
 	status.GenesisHeight = t.genesis.Height
@@ -814,6 +910,19 @@ func (t *fullService) GetStatus(ctx context.Context) (*consensusAPI.Status, erro
 	}
 	status.NodePeers = peers
 
+	// Pending upgrades.
+	pendingUpgrades, err := t.upgrader.PendingUpgrades(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending upgrades: %w", err)
+	}
+	status.PendingUpgrades = pendingUpgrades
+
+	select {
+	case <-t.syncedCh:
+		status.IsSynced = true
+	default:
+	}
+
 	// Check if the local node is in the validator set for the latest (uncommitted) block.
 	vals, err := t.stateStore.LoadValidators(status.LatestHeight + 1)
 	if err != nil {
@@ -822,10 +931,27 @@ func (t *fullService) GetStatus(ctx context.Context) (*consensusAPI.Status, erro
 	consensusPk := t.identity.ConsensusSigner.Public()
 	consensusAddr := []byte(crypto.PublicKeyToTendermint(&consensusPk).Address())
 	status.IsValidator = vals.HasAddress(consensusAddr)
+	status.Signing = t.signingMonitor.getStatus()
 
 	return status, nil
 }
 
+func (t *fullService) WatchFeeStatistics(ctx context.Context) (<-chan *consensusAPI.FeeStatistics, pubsub.ClosableSubscription, error) {
+	ch, sub := t.mux.WatchFeeStatistics()
+	return ch, sub, nil
+}
+
+func (t *fullService) GetStateAttestation(ctx context.Context, height int64) (*consensusAPI.StateAttestation, error) {
+	if err := t.ensureStarted(ctx); err != nil {
+		return nil, err
+	}
+	return t.mux.GetStateAttestation(height)
+}
+
+func (t *fullService) GetAddressBook(ctx context.Context) (*consensusAPI.AddressBook, error) {
+	return nil, consensusAPI.ErrUnsupported
+}
+
 func (t *fullService) WatchBlocks(ctx context.Context) (<-chan *consensusAPI.Block, pubsub.ClosableSubscription, error) {
 	ch, sub := t.WatchTendermintBlocks()
 	mapCh := make(chan *consensusAPI.Block)
@@ -839,7 +965,7 @@ func (t *fullService) WatchBlocks(ctx context.Context) (<-chan *consensusAPI.Blo
 					return
 				}
 
-				mapCh <- api.NewBlock(tmBlk)
+				mapCh <- t.newBlock(ctx, tmBlk)
 			case <-ctx.Done():
 				return
 			}
@@ -930,7 +1056,7 @@ func (t *fullService) initialize() error {
 	t.svcMgr.RegisterCleanupOnly(t.registry, "registry backend")
 
 	var scStaking tmstaking.ServiceClient
-	if scStaking, err = tmstaking.New(t.ctx, t); err != nil {
+	if scStaking, err = tmstaking.New(t.ctx, t.dataDir, t); err != nil {
 		t.Logger.Error("staking: failed to initialize staking backend",
 			"err", err,
 		)
@@ -1091,9 +1217,12 @@ func (t *fullService) lazyInit() error {
 		MinGasPrice:               viper.GetUint64(CfgMinGasPrice),
 		OwnTxSigner:               t.identity.NodeSigner.Public(),
 		DisableCheckTx:            viper.GetBool(CfgDebugDisableCheckTx) && cmflags.DebugDontBlameOasis(),
+		VerifyStateOnStartup:      viper.GetBool(CfgDebugVerifyStateOnStartup),
 		DisableCheckpointer:       viper.GetBool(CfgCheckpointerDisabled),
 		CheckpointerCheckInterval: viper.GetDuration(CfgCheckpointerCheckInterval),
 		InitialHeight:             uint64(t.genesis.Height),
+		StateAttestationInterval:  viper.GetUint64(CfgStateAttestationInterval),
+		StateAttestationNumKept:   viper.GetUint64(CfgStateAttestationNumKept),
 	}
 	t.mux, err = abci.NewApplicationServer(t.ctx, t.upgrader, appConfig)
 	if err != nil {
@@ -1148,6 +1277,8 @@ func (t *fullService) lazyInit() error {
 	tenderConfig.P2P.AddrBookStrict = !(viper.GetBool(tmcommon.CfgDebugP2PAddrBookLenient) && cmflags.DebugDontBlameOasis())
 	tenderConfig.P2P.AllowDuplicateIP = viper.GetBool(tmcommon.CfgDebugP2PAllowDuplicateIP) && cmflags.DebugDontBlameOasis()
 	tenderConfig.RPC.ListenAddress = ""
+	// Enable peer filtering so that peers banned at runtime are rejected.
+	tenderConfig.FilterPeers = true
 
 	sentryUpstreamAddrs := viper.GetStringSlice(CfgSentryUpstreamAddress)
 	if len(sentryUpstreamAddrs) > 0 {
@@ -1179,10 +1310,13 @@ func (t *fullService) lazyInit() error {
 		)
 	}
 
-	tendermintPV, err := crypto.LoadOrGeneratePrivVal(tendermintDataDir, t.identity.ConsensusSigner)
+	tendermintPV, err := crypto.LoadOrGeneratePrivVal(tendermintDataDir, t.identity.ConsensusSigner, viper.GetBool(CfgSignerStandby))
 	if err != nil {
 		return err
 	}
+	if tendermintPV.State().Standby {
+		t.Logger.Warn("consensus signer in standby, not signing until a signer handoff is acquired")
+	}
 
 	tmGenDoc, err := api.GetTendermintGenesisDocument(t.genesisProvider)
 	if err != nil {
@@ -1280,7 +1414,7 @@ func (t *fullService) lazyInit() error {
 		t.node, err = tmnode.NewNode(tenderConfig,
 			tendermintPV,
 			&tmp2p.NodeKey{PrivKey: crypto.SignerToTendermint(t.identity.P2PSigner)},
-			tmproxy.NewLocalClientCreator(t.mux.Mux()),
+			tmproxy.NewLocalClientCreator(&peerFilterApplication{Application: t.mux.Mux(), bans: t.peerBans}),
 			tendermintGenesisProvider,
 			wrapDbProvider,
 			tmnode.DefaultMetricsProvider(tenderConfig.Instrumentation),
@@ -1295,6 +1429,7 @@ func (t *fullService) lazyInit() error {
 			return fmt.Errorf("tendermint: internal error: state database not set")
 		}
 		t.client = tmcli.New(t.node)
+		t.privVal = tendermintPV
 		t.failMonitor = newFailMonitor(t.ctx, t.Logger, t.node.ConsensusState().Wait)
 
 		return nil
@@ -1404,25 +1539,55 @@ func (t *fullService) metrics() {
 		// Was block proposed by our node.
 		if bytes.Equal(myAddr, blk.ProposerAddress) {
 			metrics.ProposedBlocks.With(labelTendermint).Inc()
+			t.signingMonitor.recordProposal()
 		}
 
 		// Was block voted for by our node. Ignore if there was no previous block.
-		if blk.LastCommit != nil {
-			for _, sig := range blk.LastCommit.Signatures {
-				if sig.Absent() || sig.BlockIDFlag == tmtypes.BlockIDFlagNil {
-					// Vote is missing, ignore.
-					continue
-				}
-
-				if bytes.Equal(myAddr, sig.ValidatorAddress) {
-					metrics.SignedBlocks.With(labelTendermint).Inc()
-					break
-				}
-			}
+		if blk.LastCommit == nil || blk.LastCommit.Height < 1 {
+			continue
+		}
+		signed, isValidator, err := t.lastCommitSigned(myAddr, blk)
+		if err != nil {
+			t.Logger.Debug("failed to check last commit signatures",
+				"err", err,
+				"height", blk.Height,
+			)
+			continue
+		}
+		if !isValidator {
+			continue
 		}
+		switch signed {
+		case true:
+			metrics.SignedBlocks.With(labelTendermint).Inc()
+		case false:
+			metrics.MissedBlocks.With(labelTendermint).Inc()
+		}
+		t.signingMonitor.recordVote(blk.LastCommit.Height, signed)
 	}
 }
 
+// lastCommitSigned checks whether the last commit included in the given block contains a vote
+// by the validator with the given address. It also returns whether the validator was part of
+// the validator set for the committed height.
+func (t *fullService) lastCommitSigned(addr []byte, blk *tmtypes.Block) (bool, bool, error) {
+	vals, err := t.stateStore.LoadValidators(blk.LastCommit.Height)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to load validator set: %w", err)
+	}
+	idx, _ := vals.GetByAddress(addr)
+	if idx < 0 {
+		return false, false, nil
+	}
+	if int(idx) >= len(blk.LastCommit.Signatures) {
+		return false, false, fmt.Errorf("malformed last commit")
+	}
+
+	sig := blk.LastCommit.Signatures[idx]
+	signed := !sig.Absent() && sig.BlockIDFlag != tmtypes.BlockIDFlagNil && bytes.Equal(addr, sig.ValidatorAddress)
+	return signed, true, nil
+}
+
 // New creates a new Tendermint consensus backend.
 func New(
 	ctx context.Context,
@@ -1458,6 +1623,12 @@ func New(
 		dataDir:               dataDir,
 		startedCh:             make(chan struct{}),
 		syncedCh:              make(chan struct{}),
+		signingMonitor: newSigningMonitor(
+			logging.GetLogger("tendermint/signing"),
+			viper.GetUint64(CfgSigningMonitorWindow),
+			viper.GetUint64(CfgSigningMonitorAlertThreshold),
+		),
+		peerBans: newPeerBans(),
 	}
 
 	t.Logger.Info("starting a full consensus node")
@@ -1485,6 +1656,7 @@ func init() {
 	Flags.Uint64(CfgMinGasPrice, 0, "minimum gas price")
 	Flags.Bool(CfgDebugDisableCheckTx, false, "do not perform CheckTx on incoming transactions (UNSAFE)")
 	Flags.Bool(CfgDebugUnsafeReplayRecoverCorruptedWAL, false, "Enable automatic recovery from corrupted WAL during replay (UNSAFE).")
+	Flags.Bool(CfgDebugVerifyStateOnStartup, false, "verify consistency of the latest state root on startup")
 
 	Flags.Bool(CfgSupplementarySanityEnabled, false, "enable supplementary sanity checks (slows down consensus)")
 	Flags.Uint64(CfgSupplementarySanityInterval, 10, "supplementary sanity check interval (in blocks)")
@@ -1496,8 +1668,15 @@ func init() {
 	Flags.Uint64(CfgConsensusStateSyncTrustHeight, 0, "state sync: light client trusted height")
 	Flags.String(CfgConsensusStateSyncTrustHash, "", "state sync: light client trusted consensus header hash")
 
+	Flags.Uint64(CfgSigningMonitorWindow, 100, "signing monitor: missed block tracking window (in blocks)")
+	Flags.Uint64(CfgSigningMonitorAlertThreshold, 10, "signing monitor: missed blocks in window at which alerts are raised (0 disables alerts)")
+	Flags.Uint64(CfgStateAttestationInterval, 1000, "ABCI state attestation interval in blocks (0 disables attestations)")
+	Flags.Uint64(CfgStateAttestationNumKept, 100, "number of kept ABCI state attestations")
+	Flags.Bool(CfgSignerStandby, false, "start the consensus signer in standby until a signer handoff is acquired")
+
 	_ = Flags.MarkHidden(CfgDebugDisableCheckTx)
 	_ = Flags.MarkHidden(CfgDebugUnsafeReplayRecoverCorruptedWAL)
+	_ = Flags.MarkHidden(CfgDebugVerifyStateOnStartup)
 
 	_ = Flags.MarkHidden(CfgSupplementarySanityEnabled)
 	_ = Flags.MarkHidden(CfgSupplementarySanityInterval)
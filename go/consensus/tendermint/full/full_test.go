@@ -0,0 +1,109 @@
+package full
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/crypto"
+	registryAPI "github.com/oasisprotocol/oasis-core/go/registry/api"
+	schedulerAPI "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+type testProposerRegistry struct {
+	registryAPI.Backend
+
+	nodes map[int64][]*node.Node
+}
+
+func (r *testProposerRegistry) GetNodeByConsensusAddress(ctx context.Context, query *registryAPI.ConsensusAddressQuery) (*node.Node, error) {
+	for _, n := range r.nodes[query.Height] {
+		if bytes.Equal(crypto.PublicKeyToTendermint(&n.Consensus.ID).Address(), query.Address) {
+			return n, nil
+		}
+	}
+	return nil, registryAPI.ErrNoSuchNode
+}
+
+type testProposerScheduler struct {
+	schedulerAPI.Backend
+
+	validators map[int64][]*schedulerAPI.Validator
+}
+
+func (s *testProposerScheduler) GetValidators(ctx context.Context, height int64) ([]*schedulerAPI.Validator, error) {
+	return s.validators[height], nil
+}
+
+func newTestProposerNode(name string) *node.Node {
+	return &node.Node{
+		ID:        memorySigner.NewTestSigner("proposer test node " + name).Public(),
+		EntityID:  memorySigner.NewTestSigner("proposer test entity " + name).Public(),
+		Consensus: node.ConsensusInfo{ID: memorySigner.NewTestSigner("proposer test consensus " + name).Public()},
+	}
+}
+
+func TestResolveBlockProposer(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	nodeA := newTestProposerNode("a")
+	nodeB := newTestProposerNode("b")
+	validator := func(n *node.Node) *schedulerAPI.Validator {
+		return &schedulerAPI.Validator{ID: n.ID, VotingPower: 1}
+	}
+	newBlock := func(height int64, proposer *node.Node) *tmtypes.Block {
+		return &tmtypes.Block{
+			Header: tmtypes.Header{
+				Height:          height,
+				ProposerAddress: crypto.PublicKeyToTendermint(&proposer.Consensus.ID).Address(),
+			},
+		}
+	}
+
+	registry := &testProposerRegistry{
+		nodes: map[int64][]*node.Node{
+			1: {nodeA},
+			3: {nodeA, nodeB},
+			4: {nodeA, nodeB},
+		},
+	}
+	scheduler := &testProposerScheduler{
+		validators: map[int64][]*schedulerAPI.Validator{
+			1: {validator(nodeA)},
+			3: {validator(nodeA)},
+			// The validator set changes at height 4 and only takes effect at height 6.
+			4: {validator(nodeB)},
+		},
+	}
+
+	// The proposer should be resolved against the validator set two heights before the block,
+	// even when the validator set has changed at the preceding height.
+	proposer, err := resolveBlockProposer(ctx, registry, scheduler, newBlock(5, nodeA), 1)
+	require.NoError(err, "resolveBlockProposer")
+	require.Equal(nodeA, proposer, "previous validator should be resolved before the update takes effect")
+
+	proposer, err = resolveBlockProposer(ctx, registry, scheduler, newBlock(6, nodeB), 1)
+	require.NoError(err, "resolveBlockProposer")
+	require.Equal(nodeB, proposer, "new validator should be resolved after the update takes effect")
+
+	// There is no state before the initial height.
+	for _, height := range []int64{1, 2} {
+		proposer, err = resolveBlockProposer(ctx, registry, scheduler, newBlock(height, nodeA), 1)
+		require.NoError(err, "resolveBlockProposer")
+		require.Equal(nodeA, proposer, "proposer should be resolved at the initial height")
+	}
+
+	// Registered nodes which are not in the validator set should not be resolved.
+	_, err = resolveBlockProposer(ctx, registry, scheduler, newBlock(6, nodeA), 1)
+	require.Error(err, "resolveBlockProposer should fail for non-validators")
+
+	// Unknown proposers should not be resolved.
+	_, err = resolveBlockProposer(ctx, registry, scheduler, newBlock(5, newTestProposerNode("c")), 1)
+	require.Error(err, "resolveBlockProposer should fail for unknown proposers")
+}
@@ -0,0 +1,107 @@
+package full
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/crypto"
+)
+
+// Implements consensusAPI.SignerManager.
+func (t *fullService) GetSignerStatus(ctx context.Context) (*consensusAPI.SignerStatus, error) {
+	if !t.started() {
+		return nil, fmt.Errorf("tendermint: service not started")
+	}
+
+	st := t.privVal.State()
+	return &consensusAPI.SignerStatus{
+		Standby:  st.Standby,
+		Sequence: st.Sequence,
+		Height:   st.Height,
+		Round:    st.Round,
+		Step:     st.Step,
+	}, nil
+}
+
+// Implements consensusAPI.SignerManager.
+func (t *fullService) ReleaseSigner(ctx context.Context) (*consensusAPI.SignedSignerHandoff, error) {
+	if !t.started() {
+		return nil, fmt.Errorf("tendermint: service not started")
+	}
+
+	st, err := t.privVal.Release()
+	switch {
+	case err == nil:
+	case errors.Is(err, crypto.ErrStandby):
+		return nil, consensusAPI.ErrSignerStandby
+	default:
+		return nil, fmt.Errorf("tendermint: failed to release signer: %w", err)
+	}
+
+	handoff := &consensusAPI.SignerHandoff{
+		Sequence:  st.Sequence,
+		Height:    st.Height,
+		Round:     st.Round,
+		Step:      st.Step,
+		SignBytes: st.SignBytes,
+		Signature: st.Signature,
+	}
+	signed, err := consensusAPI.SignSignerHandoff(t.identity.ConsensusSigner, handoff)
+	if err != nil {
+		// The signer remains in standby, a subsequent acquire of a newer handoff (e.g., one
+		// released by the other node) is required to activate it again.
+		return nil, fmt.Errorf("tendermint: failed to sign signer handoff: %w", err)
+	}
+
+	t.Logger.Info("released consensus signer",
+		"sequence", handoff.Sequence,
+		"height", handoff.Height,
+		"round", handoff.Round,
+		"step", handoff.Step,
+	)
+	return signed, nil
+}
+
+// Implements consensusAPI.SignerManager.
+func (t *fullService) AcquireSigner(ctx context.Context, signed *consensusAPI.SignedSignerHandoff) error {
+	if !t.started() {
+		return fmt.Errorf("tendermint: service not started")
+	}
+
+	// Only accept handoffs signed by our own consensus key.
+	if !signed.Signature.PublicKey.Equal(t.identity.ConsensusSigner.Public()) {
+		return fmt.Errorf("%w: not signed by the local consensus key", consensusAPI.ErrInvalidSignerHandoff)
+	}
+	var handoff consensusAPI.SignerHandoff
+	if err := signed.Open(&handoff); err != nil {
+		return fmt.Errorf("%w: %s", consensusAPI.ErrInvalidSignerHandoff, err)
+	}
+
+	err := t.privVal.Acquire(&crypto.SignerState{
+		Sequence:  handoff.Sequence,
+		Height:    handoff.Height,
+		Round:     handoff.Round,
+		Step:      handoff.Step,
+		SignBytes: handoff.SignBytes,
+		Signature: handoff.Signature,
+	})
+	switch {
+	case err == nil:
+	case errors.Is(err, crypto.ErrNotStandby):
+		return fmt.Errorf("%w: signer already active", consensusAPI.ErrInvalidSignerHandoff)
+	case errors.Is(err, crypto.ErrStaleHandoff):
+		return fmt.Errorf("%w: stale handoff sequence number", consensusAPI.ErrInvalidSignerHandoff)
+	default:
+		return fmt.Errorf("tendermint: failed to acquire signer: %w", err)
+	}
+
+	t.Logger.Info("acquired consensus signer",
+		"sequence", handoff.Sequence,
+		"height", handoff.Height,
+		"round", handoff.Round,
+		"step", handoff.Step,
+	)
+	return nil
+}
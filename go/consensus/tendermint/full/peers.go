@@ -0,0 +1,279 @@
+package full
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	tmabcitypes "github.com/tendermint/tendermint/abci/types"
+	tmp2p "github.com/tendermint/tendermint/p2p"
+
+	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
+)
+
+const (
+	// p2pFilterIDPath is the ABCI query path used by Tendermint to check whether a peer with
+	// the given ID should be accepted (when peer filtering is enabled).
+	p2pFilterIDPath = "/p2p/filter/id/"
+
+	// peerMetricsInterval is the interval at which peer metrics are updated.
+	peerMetricsInterval = 10 * time.Second
+)
+
+var (
+	peerCount = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "oasis_consensus_peers",
+			Help: "Number of connected consensus peers.",
+		},
+	)
+	peerConnects = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "oasis_consensus_peer_connects",
+			Help: "Number of observed consensus peer connections.",
+		},
+	)
+	peerDisconnects = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "oasis_consensus_peer_disconnects",
+			Help: "Number of observed consensus peer disconnections.",
+		},
+	)
+
+	peerCollectors = []prometheus.Collector{
+		peerCount,
+		peerConnects,
+		peerDisconnects,
+	}
+
+	peerMetricsOnce sync.Once
+)
+
+func init() {
+	peerMetricsOnce.Do(func() {
+		prometheus.MustRegister(peerCollectors...)
+	})
+}
+
+// normalizePeerID validates the given peer ID and converts it to the lowercase form used by
+// Tendermint.
+func normalizePeerID(id string) (tmp2p.ID, error) {
+	id = strings.ToLower(id)
+	raw, err := hex.DecodeString(id)
+	if err != nil || len(raw) != tmp2p.IDByteLength {
+		return "", fmt.Errorf("tendermint: malformed peer ID: %s", id)
+	}
+	return tmp2p.ID(id), nil
+}
+
+// peerBans is the set of peers banned at runtime.
+type peerBans struct {
+	sync.RWMutex
+
+	banned map[tmp2p.ID]bool
+}
+
+func (b *peerBans) ban(id tmp2p.ID) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.banned[id] = true
+}
+
+func (b *peerBans) unban(id tmp2p.ID) bool {
+	b.Lock()
+	defer b.Unlock()
+
+	if !b.banned[id] {
+		return false
+	}
+	delete(b.banned, id)
+	return true
+}
+
+func (b *peerBans) isBanned(id tmp2p.ID) bool {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.banned[id]
+}
+
+func (b *peerBans) list() []string {
+	b.RLock()
+	defer b.RUnlock()
+
+	ids := make([]string, 0, len(b.banned))
+	for id := range b.banned {
+		ids = append(ids, string(id))
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func newPeerBans() *peerBans {
+	return &peerBans{
+		banned: make(map[tmp2p.ID]bool),
+	}
+}
+
+// peerFilterApplication wraps an ABCI application and rejects banned peers when queried by
+// Tendermint's peer filter.
+type peerFilterApplication struct {
+	tmabcitypes.Application
+
+	bans *peerBans
+}
+
+func (app *peerFilterApplication) Query(req tmabcitypes.RequestQuery) tmabcitypes.ResponseQuery {
+	if strings.HasPrefix(req.Path, p2pFilterIDPath) {
+		id := tmp2p.ID(strings.ToLower(strings.TrimPrefix(req.Path, p2pFilterIDPath)))
+		if app.bans.isBanned(id) {
+			return tmabcitypes.ResponseQuery{
+				Code: 1,
+				Log:  "peer banned",
+			}
+		}
+		return tmabcitypes.ResponseQuery{}
+	}
+	return app.Application.Query(req)
+}
+
+// Implements consensusAPI.PeerManager.
+func (t *fullService) GetPeers(ctx context.Context) (*consensusAPI.Peers, error) {
+	if !t.started() {
+		return nil, fmt.Errorf("tendermint: service not started")
+	}
+
+	tmpeers := t.node.Switch().Peers().List()
+	peers := &consensusAPI.Peers{
+		Connected: make([]*consensusAPI.PeerStatus, 0, len(tmpeers)),
+		Banned:    t.peerBans.list(),
+	}
+	for _, tmpeer := range tmpeers {
+		status := tmpeer.Status()
+		ps := &consensusAPI.PeerStatus{
+			ID:            string(tmpeer.ID()),
+			Address:       tmpeer.RemoteAddr().String(),
+			Outbound:      tmpeer.IsOutbound(),
+			Persistent:    tmpeer.IsPersistent(),
+			ConnectedFor:  status.Duration,
+			BytesSent:     status.SendMonitor.Bytes,
+			BytesReceived: status.RecvMonitor.Bytes,
+			SendRate:      status.SendMonitor.CurRate,
+			ReceiveRate:   status.RecvMonitor.CurRate,
+		}
+		if nodeInfo, ok := tmpeer.NodeInfo().(tmp2p.DefaultNodeInfo); ok {
+			ps.Moniker = nodeInfo.Moniker
+		}
+		peers.Connected = append(peers.Connected, ps)
+	}
+	sort.Slice(peers.Connected, func(i, j int) bool {
+		return peers.Connected[i].ID < peers.Connected[j].ID
+	})
+	return peers, nil
+}
+
+// Implements consensusAPI.PeerManager.
+func (t *fullService) AddPersistentPeer(ctx context.Context, address string) error {
+	if !t.started() {
+		return fmt.Errorf("tendermint: service not started")
+	}
+
+	// Peer IDs need to be lowercase, see the persistent peers configuration.
+	address = strings.ToLower(address)
+	addr, err := tmp2p.NewNetAddressString(address)
+	if err != nil {
+		return fmt.Errorf("tendermint: malformed peer address: %w", err)
+	}
+	if t.peerBans.isBanned(addr.ID) {
+		return fmt.Errorf("tendermint: peer is banned: %s", addr.ID)
+	}
+
+	sw := t.node.Switch()
+	if err = sw.AddPersistentPeers([]string{address}); err != nil {
+		return fmt.Errorf("tendermint: failed to add persistent peer: %w", err)
+	}
+	if err = sw.DialPeersAsync([]string{address}); err != nil {
+		return fmt.Errorf("tendermint: failed to dial peer: %w", err)
+	}
+
+	t.Logger.Info("added persistent peer",
+		"peer", address,
+	)
+	return nil
+}
+
+// Implements consensusAPI.PeerManager.
+func (t *fullService) BanPeer(ctx context.Context, id string) error {
+	if !t.started() {
+		return fmt.Errorf("tendermint: service not started")
+	}
+
+	peerID, err := normalizePeerID(id)
+	if err != nil {
+		return err
+	}
+	t.peerBans.ban(peerID)
+
+	sw := t.node.Switch()
+	if peer := sw.Peers().Get(peerID); peer != nil {
+		sw.StopPeerGracefully(peer)
+	}
+
+	t.Logger.Info("banned peer",
+		"peer_id", peerID,
+	)
+	return nil
+}
+
+// Implements consensusAPI.PeerManager.
+func (t *fullService) UnbanPeer(ctx context.Context, id string) error {
+	peerID, err := normalizePeerID(id)
+	if err != nil {
+		return err
+	}
+	if !t.peerBans.unban(peerID) {
+		return fmt.Errorf("tendermint: peer not banned: %s", peerID)
+	}
+
+	t.Logger.Info("unbanned peer",
+		"peer_id", peerID,
+	)
+	return nil
+}
+
+// peerMetrics periodically updates consensus peer metrics.
+func (t *fullService) peerMetrics() {
+	ticker := time.NewTicker(peerMetricsInterval)
+	defer ticker.Stop()
+
+	known := make(map[tmp2p.ID]bool)
+	for {
+		select {
+		case <-t.node.Quit():
+			return
+		case <-ticker.C:
+		}
+
+		current := make(map[tmp2p.ID]bool)
+		for _, tmpeer := range t.node.Switch().Peers().List() {
+			current[tmpeer.ID()] = true
+			if !known[tmpeer.ID()] {
+				peerConnects.Inc()
+			}
+		}
+		for id := range known {
+			if !current[id] {
+				peerDisconnects.Inc()
+			}
+		}
+		known = current
+
+		peerCount.Set(float64(len(current)))
+	}
+}
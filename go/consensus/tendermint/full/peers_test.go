@@ -0,0 +1,40 @@
+package full
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tmabcitypes "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestPeerFilterApplication(t *testing.T) {
+	require := require.New(t)
+
+	_, err := normalizePeerID("not a peer id")
+	require.Error(err, "normalizePeerID should fail for malformed IDs")
+	_, err = normalizePeerID("abcd")
+	require.Error(err, "normalizePeerID should fail for short IDs")
+	id, err := normalizePeerID("0123456789ABCDEF0123456789ABCDEF01234567")
+	require.NoError(err, "normalizePeerID")
+	require.EqualValues("0123456789abcdef0123456789abcdef01234567", id, "peer IDs should be lowercased")
+
+	bans := newPeerBans()
+	app := &peerFilterApplication{
+		Application: tmabcitypes.NewBaseApplication(),
+		bans:        bans,
+	}
+	query := tmabcitypes.RequestQuery{Path: p2pFilterIDPath + string(id)}
+
+	rsp := app.Query(query)
+	require.True(rsp.IsOK(), "peers should be accepted when not banned")
+
+	bans.ban(id)
+	rsp = app.Query(query)
+	require.False(rsp.IsOK(), "banned peers should be rejected")
+	require.Equal([]string{string(id)}, bans.list(), "banned peers should be listed")
+
+	require.True(bans.unban(id), "unban should succeed for banned peers")
+	require.False(bans.unban(id), "unban should fail for peers that are not banned")
+	rsp = app.Query(query)
+	require.True(rsp.IsOK(), "peers should be accepted after being unbanned")
+}
@@ -0,0 +1,448 @@
+package full
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
+	tmsm "github.com/tendermint/tendermint/state"
+	tmstore "github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/version"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/abci"
+	tmcommon "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/common"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/db"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+	storageDB "github.com/oasisprotocol/oasis-core/go/storage/database"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+)
+
+const (
+	// tendermintDBDir is the name of the directory containing Tendermint databases, relative to
+	// the Tendermint state directory.
+	tendermintDBDir = "data"
+
+	rollbackAppStateSuffix    = ".rollback"
+	rollbackOldAppStateSuffix = ".rollback-old"
+)
+
+// StateHeights are the heights of the local consensus state stores.
+type StateHeights struct {
+	// BlockStore is the height of the Tendermint block store.
+	BlockStore int64
+	// State is the height of the Tendermint consensus state.
+	State int64
+	// AppState is the height of the ABCI application state.
+	AppState int64
+}
+
+// Validate checks whether the state heights are consistent, meaning that Tendermint is able to
+// resume from the local state via the handshake with the ABCI application.
+func (h *StateHeights) Validate() error {
+	if h.BlockStore != h.State && h.BlockStore != h.State+1 {
+		return fmt.Errorf("consensus state height (%d) is not one below or equal to block store height (%d)",
+			h.State,
+			h.BlockStore,
+		)
+	}
+	if h.AppState > h.BlockStore {
+		return fmt.Errorf("ABCI state height (%d) is ahead of block store height (%d)",
+			h.AppState,
+			h.BlockStore,
+		)
+	}
+	return nil
+}
+
+// GetStateHeights returns the heights of the local consensus state stores in the given node
+// data directory.
+//
+// The node must not be running while the state heights are being queried.
+func GetStateHeights(ctx context.Context, dataDir string) (*StateHeights, error) {
+	stateDir := filepath.Join(dataDir, tmcommon.StateDir)
+
+	blockStoreDB, err := db.New(filepath.Join(stateDir, tendermintDBDir, "blockstore"), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open block store: %w", err)
+	}
+	defer blockStoreDB.Close()
+
+	stateDB, err := db.New(filepath.Join(stateDir, tendermintDBDir, "state"), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state store: %w", err)
+	}
+	defer stateDB.Close()
+
+	state, err := tmsm.NewStore(stateDB).Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load consensus state: %w", err)
+	}
+
+	ldb, _, stateRoot, err := abci.InitStateStorage(ctx, &abci.ApplicationConfig{
+		DataDir:         stateDir,
+		StorageBackend:  storageDB.BackendNameBadgerDB,
+		ReadOnlyStorage: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ABCI state storage: %w", err)
+	}
+	defer ldb.Cleanup()
+
+	return &StateHeights{
+		BlockStore: tmstore.NewBlockStore(blockStoreDB).Height(),
+		State:      state.LastBlockHeight,
+		AppState:   int64(stateRoot.Version),
+	}, nil
+}
+
+// RollbackResult is the result of a consensus state rollback.
+type RollbackResult struct {
+	// Height is the height of the consensus state after the rollback.
+	Height int64
+	// AppHash is the application state hash expected at the rolled back height.
+	AppHash []byte
+	// AppStateHeight is the height of the ABCI application state after the rollback. It may be
+	// lower than Height in case the application state was restored from a checkpoint in which
+	// case Tendermint will replay the missing blocks on startup.
+	AppStateHeight int64
+}
+
+// Rollback rolls back the Tendermint consensus state and the ABCI application state stored in
+// the given node data directory by one height.
+//
+// As the ABCI application state database does not support removing versions, the application
+// state is restored from the latest local state checkpoint below the target height. No blocks
+// are removed so after restart, the blocks after the restored application state height will be
+// replayed.
+//
+// The node must not be running while the rollback is in progress.
+func Rollback(ctx context.Context, dataDir string, dryRun bool) (*RollbackResult, error) {
+	logger := logging.GetLogger("consensus/tendermint/rollback")
+	stateDir := filepath.Join(dataDir, tmcommon.StateDir)
+
+	blockStoreDB, err := db.New(filepath.Join(stateDir, tendermintDBDir, "blockstore"), false)
+	if err != nil {
+		return nil, fmt.Errorf("rollback: failed to open block store: %w", err)
+	}
+	defer blockStoreDB.Close()
+	blockStore := tmstore.NewBlockStore(blockStoreDB)
+
+	stateDB, err := db.New(filepath.Join(stateDir, tendermintDBDir, "state"), false)
+	if err != nil {
+		return nil, fmt.Errorf("rollback: failed to open state store: %w", err)
+	}
+	defer stateDB.Close()
+	stateStore := tmsm.NewStore(stateDB)
+
+	invalidState, err := stateStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("rollback: failed to load consensus state: %w", err)
+	}
+	if invalidState.IsEmpty() {
+		return nil, fmt.Errorf("rollback: no consensus state found")
+	}
+
+	// Persistence of state and blocks does not happen atomically. In case the block store is one
+	// height ahead, the state has not yet been updated and there is nothing to roll back.
+	height := blockStore.Height()
+	if height == invalidState.LastBlockHeight+1 {
+		return nil, fmt.Errorf("rollback: consensus state is already one height behind the block store (height: %d)",
+			invalidState.LastBlockHeight,
+		)
+	}
+	if height != invalidState.LastBlockHeight {
+		return nil, fmt.Errorf("rollback: consensus state height (%d) is not one below or equal to block store height (%d)",
+			invalidState.LastBlockHeight,
+			height,
+		)
+	}
+
+	rollbackHeight := invalidState.LastBlockHeight - 1
+	if rollbackHeight < invalidState.InitialHeight {
+		return nil, fmt.Errorf("rollback: cannot roll back past the initial height")
+	}
+
+	if !dryRun {
+		if err = recoverAppState(stateDir); err != nil {
+			return nil, err
+		}
+	}
+
+	// Perform pre-flight checks against the ABCI application state. In dry-run mode the state is
+	// opened read-only so that it is guaranteed to not be modified.
+	appCfg := &abci.ApplicationConfig{
+		DataDir:         stateDir,
+		StorageBackend:  storageDB.BackendNameBadgerDB,
+		ReadOnlyStorage: dryRun,
+	}
+	ldb, _, stateRoot, err := abci.InitStateStorage(ctx, appCfg)
+	if err != nil {
+		return nil, fmt.Errorf("rollback: failed to open ABCI state storage: %w", err)
+	}
+	appStateHeight := int64(stateRoot.Version)
+	var cp *checkpoint.Metadata
+	switch {
+	case appStateHeight > invalidState.LastBlockHeight:
+		ldb.Cleanup()
+		return nil, fmt.Errorf("rollback: ABCI state height (%d) is ahead of consensus state height (%d)",
+			appStateHeight,
+			invalidState.LastBlockHeight,
+		)
+	case appStateHeight <= rollbackHeight:
+		// Application state does not need to be rolled back.
+		ldb.Cleanup()
+	default:
+		// Find the latest checkpoint at or below the rollback height.
+		cp, err = findRollbackCheckpoint(ctx, ldb, uint64(rollbackHeight))
+		if err != nil {
+			ldb.Cleanup()
+			return nil, err
+		}
+		appStateHeight = int64(cp.Root.Version)
+
+		logger.Info("restoring ABCI state from checkpoint",
+			"checkpoint_height", cp.Root.Version,
+			"checkpoint_root", cp.Root.Hash,
+		)
+
+		if !dryRun {
+			err = restoreAppState(ctx, ldb, appCfg, cp)
+		}
+		ldb.Cleanup()
+		if err != nil {
+			return nil, err
+		}
+		if !dryRun {
+			if err = swapAppState(stateDir); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	rollbackBlock := blockStore.LoadBlockMeta(rollbackHeight)
+	if rollbackBlock == nil {
+		return nil, fmt.Errorf("rollback: block at height %d not found", rollbackHeight)
+	}
+	// The app hash and last results hash are only agreed upon in the following block.
+	latestBlock := blockStore.LoadBlockMeta(invalidState.LastBlockHeight)
+	if latestBlock == nil {
+		return nil, fmt.Errorf("rollback: block at height %d not found", invalidState.LastBlockHeight)
+	}
+
+	previousLastValidatorSet, err := stateStore.LoadValidators(rollbackHeight)
+	if err != nil {
+		return nil, fmt.Errorf("rollback: failed to load validators: %w", err)
+	}
+	previousParams, err := stateStore.LoadConsensusParams(rollbackHeight + 1)
+	if err != nil {
+		return nil, fmt.Errorf("rollback: failed to load consensus parameters: %w", err)
+	}
+
+	valChangeHeight := invalidState.LastHeightValidatorsChanged
+	if valChangeHeight > rollbackHeight {
+		valChangeHeight = rollbackHeight + 1
+	}
+	paramsChangeHeight := invalidState.LastHeightConsensusParamsChanged
+	if paramsChangeHeight > rollbackHeight {
+		paramsChangeHeight = rollbackHeight + 1
+	}
+
+	rolledBackState := tmsm.State{
+		Version: tmstate.Version{
+			Consensus: tmversion.Consensus{
+				Block: version.BlockProtocol,
+				App:   previousParams.Version.AppVersion,
+			},
+			Software: version.TMCoreSemVer,
+		},
+		ChainID:       invalidState.ChainID,
+		InitialHeight: invalidState.InitialHeight,
+
+		LastBlockHeight: rollbackBlock.Header.Height,
+		LastBlockID:     rollbackBlock.BlockID,
+		LastBlockTime:   rollbackBlock.Header.Time,
+
+		NextValidators:              invalidState.Validators,
+		Validators:                  invalidState.LastValidators,
+		LastValidators:              previousLastValidatorSet,
+		LastHeightValidatorsChanged: valChangeHeight,
+
+		ConsensusParams:                  previousParams,
+		LastHeightConsensusParamsChanged: paramsChangeHeight,
+
+		LastResultsHash: latestBlock.Header.LastResultsHash,
+		AppHash:         latestBlock.Header.AppHash,
+	}
+
+	if !dryRun {
+		if err = stateStore.Save(rolledBackState); err != nil {
+			return nil, fmt.Errorf("rollback: failed to save rolled back state: %w", err)
+		}
+	}
+
+	return &RollbackResult{
+		Height:         rolledBackState.LastBlockHeight,
+		AppHash:        rolledBackState.AppHash,
+		AppStateHeight: appStateHeight,
+	}, nil
+}
+
+func findRollbackCheckpoint(ctx context.Context, ldb storage.LocalBackend, height uint64) (*checkpoint.Metadata, error) {
+	cps, err := ldb.GetCheckpoints(ctx, &checkpoint.GetCheckpointsRequest{Version: 1})
+	if err != nil {
+		return nil, fmt.Errorf("rollback: failed to get ABCI state checkpoints: %w", err)
+	}
+
+	var best *checkpoint.Metadata
+	for _, cp := range cps {
+		if cp.Root.Version > height {
+			continue
+		}
+		if best == nil || cp.Root.Version > best.Root.Version {
+			best = cp
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("rollback: no ABCI state checkpoint at or below height %d", height)
+	}
+	return best, nil
+}
+
+// restoreAppState restores the given checkpoint into a fresh ABCI state database so that a
+// failed restore does not leave the node without any application state.
+//
+// The restored database uses the same configuration as the ABCI state database.
+func restoreAppState(ctx context.Context, ldb storage.LocalBackend, appCfg *abci.ApplicationConfig, cp *checkpoint.Metadata) error {
+	restoreDir := filepath.Join(appCfg.DataDir, abci.AppStateDir) + rollbackAppStateSuffix
+	if err := os.RemoveAll(restoreDir); err != nil {
+		return fmt.Errorf("rollback: failed to remove stale restore directory: %w", err)
+	}
+	if err := os.MkdirAll(restoreDir, 0o700); err != nil {
+		return fmt.Errorf("rollback: failed to create restore directory: %w", err)
+	}
+
+	err := func() error {
+		restoreCfg := abci.StateStorageConfig(appCfg)
+		restoreCfg.DB = filepath.Join(restoreDir, storageDB.DefaultFileName(restoreCfg.Backend))
+		restoreDB, err := storageDB.New(restoreCfg)
+		if err != nil {
+			return fmt.Errorf("rollback: failed to create restore database: %w", err)
+		}
+		defer restoreDB.Cleanup()
+		rdb := restoreDB.(storage.LocalBackend)
+
+		restorer := rdb.Checkpointer()
+		if err = restorer.StartRestore(ctx, cp); err != nil {
+			return fmt.Errorf("rollback: failed to start checkpoint restore: %w", err)
+		}
+		for i := range cp.Chunks {
+			chunk, cerr := cp.GetChunkMetadata(uint64(i))
+			if cerr != nil {
+				return fmt.Errorf("rollback: failed to get chunk metadata: %w", cerr)
+			}
+			if cerr = restoreChunk(ctx, ldb, restorer, chunk); cerr != nil {
+				_ = restorer.AbortRestore(ctx)
+				return cerr
+			}
+		}
+		if err = rdb.NodeDB().Finalize(ctx, cp.Root.Version, []hash.Hash{cp.Root.Hash}); err != nil {
+			return fmt.Errorf("rollback: failed to finalize restored state: %w", err)
+		}
+		return rdb.NodeDB().Sync()
+	}()
+	if err != nil {
+		_ = os.RemoveAll(restoreDir)
+		return err
+	}
+	return nil
+}
+
+// swapAppState replaces the ABCI state database with the restored one. Existing checkpoints are
+// carried over so that they remain available for subsequent rollbacks.
+//
+// The existing state is first moved aside and only removed after the restored state is in place,
+// so that an interrupted swap never leaves the node without any application state (see
+// recoverAppState).
+func swapAppState(stateDir string) error {
+	appStateDir := filepath.Join(stateDir, abci.AppStateDir)
+	restoreDir := appStateDir + rollbackAppStateSuffix
+	oldDir := appStateDir + rollbackOldAppStateSuffix
+	dbName := storageDB.DefaultFileName(storageDB.BackendNameBadgerDB)
+
+	if err := os.Rename(
+		filepath.Join(appStateDir, dbName, storageDB.CheckpointDir),
+		filepath.Join(restoreDir, dbName, storageDB.CheckpointDir),
+	); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("rollback: failed to move ABCI state checkpoints: %w", err)
+	}
+	if err := os.Rename(appStateDir, oldDir); err != nil {
+		return fmt.Errorf("rollback: failed to move ABCI state aside: %w", err)
+	}
+	if err := os.Rename(restoreDir, appStateDir); err != nil {
+		// Put the previous state back so that the node can still be started.
+		_ = os.Rename(oldDir, appStateDir)
+		return fmt.Errorf("rollback: failed to replace ABCI state: %w", err)
+	}
+	if err := os.RemoveAll(oldDir); err != nil {
+		return fmt.Errorf("rollback: failed to remove previous ABCI state: %w", err)
+	}
+	return nil
+}
+
+// recoverAppState recovers from an interrupted swapAppState.
+//
+// In case the restored state has already been put in place, the previous state is removed.
+// Otherwise the previous state is moved back in place.
+func recoverAppState(stateDir string) error {
+	appStateDir := filepath.Join(stateDir, abci.AppStateDir)
+	oldDir := appStateDir + rollbackOldAppStateSuffix
+
+	if _, err := os.Lstat(oldDir); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("rollback: failed to stat previous ABCI state: %w", err)
+	}
+
+	_, err := os.Lstat(appStateDir)
+	switch {
+	case err == nil:
+		if err = os.RemoveAll(oldDir); err != nil {
+			return fmt.Errorf("rollback: failed to remove previous ABCI state: %w", err)
+		}
+	case os.IsNotExist(err):
+		if err = os.Rename(oldDir, appStateDir); err != nil {
+			return fmt.Errorf("rollback: failed to recover previous ABCI state: %w", err)
+		}
+	default:
+		return fmt.Errorf("rollback: failed to stat ABCI state: %w", err)
+	}
+	return nil
+}
+
+func restoreChunk(ctx context.Context, ldb storage.LocalBackend, restorer checkpoint.Restorer, chunk *checkpoint.ChunkMetadata) error {
+	r, w := io.Pipe()
+	errCh := make(chan error, 1)
+	go func() {
+		_, rerr := restorer.RestoreChunk(ctx, chunk.Index, r)
+		_ = r.CloseWithError(rerr)
+		errCh <- rerr
+	}()
+
+	err := ldb.GetCheckpointChunk(ctx, chunk, w)
+	_ = w.CloseWithError(err)
+	rerr := <-errCh
+	if err != nil {
+		return fmt.Errorf("rollback: failed to read checkpoint chunk %d: %w", chunk.Index, err)
+	}
+	if rerr != nil {
+		return fmt.Errorf("rollback: failed to restore checkpoint chunk %d: %w", chunk.Index, rerr)
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+package full
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	tmed "github.com/tendermint/tendermint/crypto/ed25519"
+	tmsm "github.com/tendermint/tendermint/state"
+	tmstore "github.com/tendermint/tendermint/store"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/abci"
+	tmcommon "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/common"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/db"
+	storageDB "github.com/oasisprotocol/oasis-core/go/storage/database"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+// rollbackTestAppHash returns the (fake) application state hash after the given height.
+func rollbackTestAppHash(height int64) []byte {
+	return []byte(fmt.Sprintf("app hash %d", height))
+}
+
+// setupRollbackTestState creates a node data directory with Tendermint state and blocks up to
+// the given consensus height, and ABCI state up to the given application state height with
+// checkpoints at the given heights.
+func setupRollbackTestState(t *testing.T, height, appStateHeight int64, checkpoints []uint64) string {
+	require := require.New(t)
+
+	dataDir, err := ioutil.TempDir("", "oasis-tendermint-rollback-test")
+	require.NoError(err, "TempDir")
+	t.Cleanup(func() {
+		os.RemoveAll(dataDir)
+	})
+	stateDir := filepath.Join(dataDir, tmcommon.StateDir)
+
+	// Tendermint state and blocks.
+	blockStoreDB, err := db.New(filepath.Join(stateDir, tendermintDBDir, "blockstore"), false)
+	require.NoError(err, "db.New")
+	defer blockStoreDB.Close()
+	blockStore := tmstore.NewBlockStore(blockStoreDB)
+
+	stateDB, err := db.New(filepath.Join(stateDir, tendermintDBDir, "state"), false)
+	require.NoError(err, "db.New")
+	defer stateDB.Close()
+	stateStore := tmsm.NewStore(stateDB)
+
+	state, err := tmsm.MakeGenesisState(&tmtypes.GenesisDoc{
+		ChainID:         "rollback-test",
+		GenesisTime:     time.Unix(1580461674, 0),
+		InitialHeight:   1,
+		ConsensusParams: tmtypes.DefaultConsensusParams(),
+		Validators: []tmtypes.GenesisValidator{
+			{PubKey: tmed.GenPrivKey().PubKey(), Power: 1, Name: "validator"},
+		},
+	})
+	require.NoError(err, "MakeGenesisState")
+	err = stateStore.Save(state)
+	require.NoError(err, "Save")
+
+	lastCommit := tmtypes.NewCommit(0, 0, tmtypes.BlockID{}, nil)
+	for h := state.InitialHeight; h <= height; h++ {
+		state.AppHash = rollbackTestAppHash(h - 1)
+		blk, parts := state.MakeBlock(h, nil, lastCommit, nil, state.Validators.GetProposer().Address)
+		blockID := tmtypes.BlockID{Hash: blk.Hash(), PartSetHeader: parts.Header()}
+		seenCommit := tmtypes.NewCommit(h, 0, blockID, []tmtypes.CommitSig{tmtypes.NewCommitSigAbsent()})
+		blockStore.SaveBlock(blk, parts, seenCommit)
+
+		state.LastBlockHeight = h
+		state.LastBlockID = blockID
+		state.LastBlockTime = blk.Time
+		state.LastValidators = state.Validators.Copy()
+		state.Validators = state.NextValidators.Copy()
+		state.NextValidators = state.NextValidators.CopyIncrementProposerPriority(1)
+		err = stateStore.Save(state)
+		require.NoError(err, "Save")
+
+		lastCommit = seenCommit
+	}
+
+	// ABCI application state.
+	ctx := context.Background()
+	ldb, ndb, _, err := abci.InitStateStorage(ctx, &abci.ApplicationConfig{
+		DataDir:        stateDir,
+		StorageBackend: storageDB.BackendNameBadgerDB,
+	})
+	require.NoError(err, "InitStateStorage")
+	defer ldb.Cleanup()
+
+	tree := mkvs.New(nil, ndb)
+	defer tree.Close()
+	for v := uint64(1); v <= uint64(appStateHeight); v++ {
+		err = tree.Insert(ctx, []byte(fmt.Sprintf("key %d", v)), []byte(fmt.Sprintf("value %d", v)))
+		require.NoError(err, "Insert")
+
+		var rootHash hash.Hash
+		_, rootHash, err = tree.Commit(ctx, common.Namespace{}, v)
+		require.NoError(err, "Commit")
+		err = ndb.Finalize(ctx, v, []hash.Hash{rootHash})
+		require.NoError(err, "Finalize")
+
+		for _, cpVersion := range checkpoints {
+			if cpVersion != v {
+				continue
+			}
+			_, err = ldb.Checkpointer().CreateCheckpoint(ctx, node.Root{Version: v, Hash: rootHash}, 1024)
+			require.NoError(err, "CreateCheckpoint")
+		}
+	}
+
+	return dataDir
+}
+
+func TestRollback(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	dataDir := setupRollbackTestState(t, 5, 5, []uint64{2, 3})
+
+	heights, err := GetStateHeights(ctx, dataDir)
+	require.NoError(err, "GetStateHeights")
+	require.Equal(&StateHeights{BlockStore: 5, State: 5, AppState: 5}, heights)
+
+	// Roll back from height 5 to height 4, restoring the ABCI state from the checkpoint at 3.
+	res, err := Rollback(ctx, dataDir, false)
+	require.NoError(err, "Rollback")
+	require.EqualValues(4, res.Height, "consensus state should be rolled back by one height")
+	require.EqualValues(3, res.AppStateHeight, "ABCI state should be restored from the latest checkpoint")
+	require.EqualValues(rollbackTestAppHash(4), res.AppHash, "app hash should be the one agreed in the next block")
+
+	heights, err = GetStateHeights(ctx, dataDir)
+	require.NoError(err, "GetStateHeights")
+	require.Equal(&StateHeights{BlockStore: 5, State: 4, AppState: 3}, heights)
+	require.NoError(heights.Validate(), "rolled back state heights should be consistent")
+
+	// No intermediate state should be left behind.
+	appStateDir := filepath.Join(dataDir, tmcommon.StateDir, abci.AppStateDir)
+	for _, dir := range []string{
+		appStateDir + rollbackAppStateSuffix,
+		appStateDir + rollbackOldAppStateSuffix,
+	} {
+		_, err = os.Lstat(dir)
+		require.True(os.IsNotExist(err), "intermediate ABCI state should be removed")
+	}
+
+	// Checkpoints should be carried over to the restored ABCI state.
+	ldb, _, _, err := abci.InitStateStorage(ctx, &abci.ApplicationConfig{
+		DataDir:         filepath.Join(dataDir, tmcommon.StateDir),
+		StorageBackend:  storageDB.BackendNameBadgerDB,
+		ReadOnlyStorage: true,
+	})
+	require.NoError(err, "InitStateStorage")
+	cps, err := ldb.GetCheckpoints(ctx, &checkpoint.GetCheckpointsRequest{Version: 1})
+	ldb.Cleanup()
+	require.NoError(err, "GetCheckpoints")
+	require.Len(cps, 2, "checkpoints should be carried over")
+
+	// The consensus state is now behind the block store so it cannot be rolled back again.
+	_, err = Rollback(ctx, dataDir, false)
+	require.Error(err, "Rollback should fail when the state is behind the block store")
+}
+
+func TestRollbackDryRun(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	dataDir := setupRollbackTestState(t, 5, 5, []uint64{2, 3})
+
+	res, err := Rollback(ctx, dataDir, true)
+	require.NoError(err, "Rollback")
+	require.EqualValues(4, res.Height, "dry run should report the rolled back height")
+	require.EqualValues(3, res.AppStateHeight, "dry run should report the restored ABCI state height")
+
+	heights, err := GetStateHeights(ctx, dataDir)
+	require.NoError(err, "GetStateHeights")
+	require.Equal(&StateHeights{BlockStore: 5, State: 5, AppState: 5}, heights, "dry run should not modify state")
+
+	_, err = os.Lstat(filepath.Join(dataDir, tmcommon.StateDir, abci.AppStateDir) + rollbackAppStateSuffix)
+	require.True(os.IsNotExist(err), "dry run should not restore the ABCI state")
+}
+
+func TestRollbackInvalidHeight(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name           string
+		height         int64
+		appStateHeight int64
+		checkpoints    []uint64
+	}{
+		{
+			// Rolling back would go past the initial height.
+			name:           "InitialHeight",
+			height:         1,
+			appStateHeight: 1,
+		},
+		{
+			// There is no checkpoint at or below the target height.
+			name:           "NoCheckpoint",
+			height:         5,
+			appStateHeight: 5,
+			checkpoints:    []uint64{5},
+		},
+		{
+			// The ABCI state is ahead of the consensus state.
+			name:           "AppStateAhead",
+			height:         3,
+			appStateHeight: 4,
+			checkpoints:    []uint64{2},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+
+			dataDir := setupRollbackTestState(t, tc.height, tc.appStateHeight, tc.checkpoints)
+			heights, err := GetStateHeights(ctx, dataDir)
+			require.NoError(err, "GetStateHeights")
+
+			_, err = Rollback(ctx, dataDir, false)
+			require.Error(err, "Rollback should fail")
+
+			newHeights, err := GetStateHeights(ctx, dataDir)
+			require.NoError(err, "GetStateHeights")
+			require.Equal(heights, newHeights, "failed rollback should not modify state")
+		})
+	}
+}
+
+func TestRecoverAppState(t *testing.T) {
+	require := require.New(t)
+
+	stateDir, err := ioutil.TempDir("", "oasis-tendermint-rollback-test")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(stateDir)
+
+	appStateDir := filepath.Join(stateDir, abci.AppStateDir)
+	oldDir := appStateDir + rollbackOldAppStateSuffix
+
+	// Nothing to recover.
+	err = recoverAppState(stateDir)
+	require.NoError(err, "recoverAppState")
+
+	// Interrupted before the restored state was put in place.
+	require.NoError(os.MkdirAll(oldDir, 0o700))
+	err = recoverAppState(stateDir)
+	require.NoError(err, "recoverAppState")
+	_, err = os.Lstat(appStateDir)
+	require.NoError(err, "previous ABCI state should be moved back in place")
+	_, err = os.Lstat(oldDir)
+	require.True(os.IsNotExist(err), "previous ABCI state should be moved back in place")
+
+	// Interrupted after the restored state was put in place.
+	require.NoError(os.MkdirAll(oldDir, 0o700))
+	err = recoverAppState(stateDir)
+	require.NoError(err, "recoverAppState")
+	_, err = os.Lstat(appStateDir)
+	require.NoError(err, "restored ABCI state should be kept")
+	_, err = os.Lstat(oldDir)
+	require.True(os.IsNotExist(err), "previous ABCI state should be removed")
+}
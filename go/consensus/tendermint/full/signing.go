@@ -0,0 +1,119 @@
+package full
+
+import (
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
+)
+
+// signingMonitor tracks the signing performance of the local validator.
+type signingMonitor struct {
+	sync.Mutex
+
+	logger *logging.Logger
+
+	status consensusAPI.SigningStatus
+
+	// window is a ring buffer of the most recent blocks where each entry
+	// indicates whether the local validator missed the block.
+	window    []bool
+	windowPos int
+
+	handlers []consensusAPI.SigningAlertHandler
+}
+
+func (m *signingMonitor) registerHandler(handler consensusAPI.SigningAlertHandler) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.handlers = append(m.handlers, handler)
+}
+
+// getStatus returns the current signing status.
+func (m *signingMonitor) getStatus() *consensusAPI.SigningStatus {
+	m.Lock()
+	defer m.Unlock()
+
+	status := m.status
+	return &status
+}
+
+// recordProposal records a block proposed by the local validator.
+func (m *signingMonitor) recordProposal() {
+	m.Lock()
+	defer m.Unlock()
+
+	m.status.ProposedBlocks++
+}
+
+// recordVote records whether the local validator signed the block at the
+// given height, while it was part of the validator set.
+func (m *signingMonitor) recordVote(height int64, signed bool) {
+	m.Lock()
+
+	switch signed {
+	case true:
+		m.status.SignedBlocks++
+		m.status.LastSignedHeight = height
+	case false:
+		m.status.MissedBlocks++
+		m.status.LastMissedHeight = height
+	}
+
+	if len(m.window) > 0 {
+		// Evict the oldest entry from the window.
+		if m.window[m.windowPos] {
+			m.status.WindowMissedBlocks--
+		}
+		m.window[m.windowPos] = !signed
+		m.windowPos = (m.windowPos + 1) % len(m.window)
+		if !signed {
+			m.status.WindowMissedBlocks++
+		}
+	}
+
+	var notifyFn func(consensusAPI.SigningAlertHandler, *consensusAPI.SigningStatus)
+	if m.status.AlertThreshold > 0 {
+		switch {
+		case !m.status.Alerting && m.status.WindowMissedBlocks >= m.status.AlertThreshold:
+			m.status.Alerting = true
+			m.logger.Warn("local validator is missing blocks",
+				"height", height,
+				"window_missed_blocks", m.status.WindowMissedBlocks,
+				"window_size", m.status.WindowSize,
+			)
+			notifyFn = consensusAPI.SigningAlertHandler.MissedBlocksAlert
+		case m.status.Alerting && m.status.WindowMissedBlocks < m.status.AlertThreshold:
+			m.status.Alerting = false
+			m.logger.Info("local validator recovered from missing blocks",
+				"height", height,
+				"window_missed_blocks", m.status.WindowMissedBlocks,
+				"window_size", m.status.WindowSize,
+			)
+			notifyFn = consensusAPI.SigningAlertHandler.MissedBlocksRecovered
+		}
+	}
+
+	status := m.status
+	handlers := append([]consensusAPI.SigningAlertHandler{}, m.handlers...)
+	m.Unlock()
+
+	// Notify handlers without holding the lock.
+	if notifyFn != nil {
+		for _, handler := range handlers {
+			notifyFn(handler, &status)
+		}
+	}
+}
+
+func newSigningMonitor(logger *logging.Logger, windowSize, alertThreshold uint64) *signingMonitor {
+	return &signingMonitor{
+		logger: logger,
+		status: consensusAPI.SigningStatus{
+			WindowSize:     windowSize,
+			AlertThreshold: alertThreshold,
+		},
+		window: make([]bool, windowSize),
+	}
+}
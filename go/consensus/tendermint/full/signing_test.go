@@ -0,0 +1,63 @@
+package full
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
+)
+
+type testSigningAlertHandler struct {
+	alerts    []consensusAPI.SigningStatus
+	recovered []consensusAPI.SigningStatus
+}
+
+func (h *testSigningAlertHandler) MissedBlocksAlert(status *consensusAPI.SigningStatus) {
+	h.alerts = append(h.alerts, *status)
+}
+
+func (h *testSigningAlertHandler) MissedBlocksRecovered(status *consensusAPI.SigningStatus) {
+	h.recovered = append(h.recovered, *status)
+}
+
+func TestSigningMonitor(t *testing.T) {
+	require := require.New(t)
+
+	m := newSigningMonitor(logging.GetLogger("tendermint/signing/test"), 4, 2)
+	var handler testSigningAlertHandler
+	m.registerHandler(&handler)
+
+	m.recordProposal()
+	m.recordVote(1, true)
+	m.recordVote(2, false)
+	require.Empty(handler.alerts, "no alert should be raised below the threshold")
+
+	m.recordVote(3, false)
+	require.Len(handler.alerts, 1, "an alert should be raised when reaching the threshold")
+	require.EqualValues(2, handler.alerts[0].WindowMissedBlocks)
+
+	m.recordVote(4, false)
+	require.Len(handler.alerts, 1, "alerts should only be raised once")
+
+	status := m.getStatus()
+	require.EqualValues(1, status.ProposedBlocks)
+	require.EqualValues(1, status.SignedBlocks)
+	require.EqualValues(3, status.MissedBlocks)
+	require.EqualValues(1, status.LastSignedHeight)
+	require.EqualValues(4, status.LastMissedHeight)
+	require.EqualValues(3, status.WindowMissedBlocks)
+	require.True(status.Alerting)
+
+	// Missed blocks should eventually drop out of the window.
+	m.recordVote(5, true)
+	m.recordVote(6, true)
+	require.Empty(handler.recovered, "should not recover while at the threshold")
+	m.recordVote(7, true)
+	require.Len(handler.recovered, 1, "should recover when dropping below the threshold")
+
+	status = m.getStatus()
+	require.EqualValues(1, status.WindowMissedBlocks)
+	require.False(status.Alerting)
+}
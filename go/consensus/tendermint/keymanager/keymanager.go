@@ -43,7 +43,13 @@ func (sc *serviceClient) GetStatus(ctx context.Context, query *registry.Namespac
 		return nil, err
 	}
 
-	return q.Status(ctx, query.ID)
+	status, err := q.Status(ctx, query.ID)
+	if err != nil {
+		return nil, err
+	}
+	status.PolicyChecksum = api.PolicyChecksum(status.Policy)
+
+	return status, nil
 }
 
 func (sc *serviceClient) GetStatuses(ctx context.Context, height int64) ([]*api.Status, error) {
@@ -52,7 +58,15 @@ func (sc *serviceClient) GetStatuses(ctx context.Context, height int64) ([]*api.
 		return nil, err
 	}
 
-	return q.Statuses(ctx)
+	statuses, err := q.Statuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range statuses {
+		status.PolicyChecksum = api.PolicyChecksum(status.Policy)
+	}
+
+	return statuses, nil
 }
 
 func (sc *serviceClient) WatchStatuses() (<-chan *api.Status, *pubsub.Subscription) {
@@ -90,6 +104,7 @@ func (sc *serviceClient) DeliverEvent(ctx context.Context, height int64, tx tmty
 			}
 
 			for _, status := range statuses {
+				status.PolicyChecksum = api.PolicyChecksum(status.Policy)
 				sc.notifier.Broadcast(status)
 			}
 		}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/eapache/channels"
 	"github.com/hashicorp/go-multierror"
@@ -23,6 +24,7 @@ import (
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	app "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry"
+	epochtime "github.com/oasisprotocol/oasis-core/go/epochtime/api"
 	"github.com/oasisprotocol/oasis-core/go/registry/api"
 )
 
@@ -40,10 +42,14 @@ type serviceClient struct {
 	backend tmapi.Backend
 	querier *app.QueryFactory
 
-	entityNotifier   *pubsub.Broker
-	nodeNotifier     *pubsub.Broker
-	nodeListNotifier *pubsub.Broker
-	runtimeNotifier  *pubsub.Broker
+	entityNotifier       *pubsub.Broker
+	nodeNotifier         *pubsub.Broker
+	nodeListNotifier     *pubsub.Broker
+	nodeListDiffNotifier *pubsub.Broker
+	runtimeNotifier      *pubsub.Broker
+
+	lastNodeListLock sync.Mutex
+	lastNodeList     *api.NodeList
 }
 
 // NodeListEpochInternalEvent is the per-epoch node list event.
@@ -99,13 +105,24 @@ func (sc *serviceClient) GetNodeStatus(ctx context.Context, query *api.IDQuery)
 	return q.NodeStatus(ctx, query.ID)
 }
 
-func (sc *serviceClient) GetNodes(ctx context.Context, height int64) ([]*node.Node, error) {
-	q, err := sc.querier.QueryAt(ctx, height)
+func (sc *serviceClient) GetNodes(ctx context.Context, query *api.GetNodesQuery) ([]*node.Node, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := q.Nodes(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return q.Nodes(ctx)
+	var filtered []*node.Node
+	for _, n := range nodes {
+		if query.Matches(n) {
+			filtered = append(filtered, n)
+		}
+	}
+	return filtered, nil
 }
 
 func (sc *serviceClient) GetNodeByConsensusAddress(ctx context.Context, query *api.ConsensusAddressQuery) (*node.Node, error) {
@@ -117,6 +134,15 @@ func (sc *serviceClient) GetNodeByConsensusAddress(ctx context.Context, query *a
 	return q.NodeByConsensusAddress(ctx, query.Address)
 }
 
+func (sc *serviceClient) ValidateNode(ctx context.Context, req *api.ValidateNodeRequest) (*api.NodeValidationResult, error) {
+	q, err := sc.querier.QueryAt(ctx, req.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.ValidateNode(ctx, req)
+}
+
 func (sc *serviceClient) WatchNodes(ctx context.Context) (<-chan *api.NodeEvent, pubsub.ClosableSubscription, error) {
 	typedCh := make(chan *api.NodeEvent)
 	sub := sc.nodeNotifier.Subscribe()
@@ -133,6 +159,14 @@ func (sc *serviceClient) WatchNodeList(ctx context.Context) (<-chan *api.NodeLis
 	return typedCh, sub, nil
 }
 
+func (sc *serviceClient) WatchNodeListDiffs(ctx context.Context) (<-chan *api.NodeListDiff, pubsub.ClosableSubscription, error) {
+	typedCh := make(chan *api.NodeListDiff)
+	sub := sc.nodeListDiffNotifier.Subscribe()
+	sub.Unwrap(typedCh)
+
+	return typedCh, sub, nil
+}
+
 func (sc *serviceClient) GetRuntime(ctx context.Context, query *api.NamespaceQuery) (*api.Runtime, error) {
 	q, err := sc.querier.QueryAt(ctx, query.Height)
 	if err != nil {
@@ -161,6 +195,18 @@ func (sc *serviceClient) GetRuntimes(ctx context.Context, query *api.GetRuntimes
 	return q.Runtimes(ctx, query.IncludeSuspended)
 }
 
+func (sc *serviceClient) ListRuntimesWithMetadata(ctx context.Context, query *api.ListRuntimesQuery) ([]*api.Runtime, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+	runtimes, err := q.Runtimes(ctx, query.IncludeSuspended)
+	if err != nil {
+		return nil, err
+	}
+	return api.FilterRuntimes(runtimes, query), nil
+}
+
 func (sc *serviceClient) StateToGenesis(ctx context.Context, height int64) (*api.Genesis, error) {
 	q, err := sc.querier.QueryAt(ctx, height)
 	if err != nil {
@@ -243,6 +289,16 @@ func (sc *serviceClient) DeliverEvent(ctx context.Context, height int64, tx tmty
 			continue
 		}
 		sc.nodeListNotifier.Broadcast(nl)
+
+		diff, err := sc.getNodeListDiff(ctx, height, nl)
+		if err != nil {
+			sc.logger.Error("failed to compute node list diff",
+				"height", ev.Height,
+				"err", err,
+			)
+			continue
+		}
+		sc.nodeListDiffNotifier.Broadcast(diff)
 	}
 
 	// Notify subscribers of events.
@@ -322,6 +378,34 @@ func EventsFromTendermint(
 					RuntimeEvent: &api.RuntimeEvent{Runtime: &rt},
 				}
 				events = append(events, evt)
+			case bytes.Equal(key, app.KeyRuntimeDeregistered):
+				// Runtime deregistered event.
+				var rt api.Runtime
+				if err := cbor.Unmarshal(val, &rt); err != nil {
+					errs = multierror.Append(errs, fmt.Errorf("registry: corrupt RuntimeDeregistered event: %w", err))
+					continue
+				}
+
+				evt := &api.Event{
+					Height:                   height,
+					TxHash:                   txHash,
+					RuntimeDeregisteredEvent: &api.RuntimeDeregisteredEvent{Runtime: &rt},
+				}
+				events = append(events, evt)
+			case bytes.Equal(key, app.KeyStakeClaimReleased):
+				// Stake claim released event.
+				var e api.StakeClaimReleasedEvent
+				if err := cbor.Unmarshal(val, &e); err != nil {
+					errs = multierror.Append(errs, fmt.Errorf("registry: corrupt StakeClaimReleased event: %w", err))
+					continue
+				}
+
+				evt := &api.Event{
+					Height:                  height,
+					TxHash:                  txHash,
+					StakeClaimReleasedEvent: &e,
+				}
+				events = append(events, evt)
 			case bytes.Equal(key, app.KeyEntityRegistered):
 				// Entity registered event.
 				var ent entity.Entity
@@ -401,6 +485,60 @@ func (sc *serviceClient) getNodeList(ctx context.Context, height int64) (*api.No
 	}, nil
 }
 
+// getNodeListDiff computes the difference between the given node list and the
+// node list of the previous epoch.
+func (sc *serviceClient) getNodeListDiff(ctx context.Context, height int64, nl *api.NodeList) (*api.NodeListDiff, error) {
+	epoch, err := sc.backend.EpochTime().GetEpoch(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("registry: failed to query epoch: %w", err)
+	}
+
+	sc.lastNodeListLock.Lock()
+	defer sc.lastNodeListLock.Unlock()
+
+	prev := sc.lastNodeList
+	if prev == nil {
+		// No node list has been seen yet, try to reconstruct the node list
+		// of the previous epoch.
+		prev = sc.getPreviousNodeList(ctx, epoch)
+	}
+	sc.lastNodeList = nl
+
+	diff := api.NewNodeListDiff(prev, nl)
+	diff.Height = height
+	diff.Epoch = epoch
+	return diff, nil
+}
+
+// getPreviousNodeList returns the node list at the start of the epoch preceding
+// the given epoch. In case the node list is not available, an empty node list
+// is returned.
+func (sc *serviceClient) getPreviousNodeList(ctx context.Context, epoch epochtime.EpochTime) *api.NodeList {
+	empty := &api.NodeList{}
+	if epoch == 0 {
+		return empty
+	}
+
+	height, err := sc.backend.EpochTime().GetEpochBlock(ctx, epoch-1)
+	if err != nil {
+		sc.logger.Warn("failed to query previous epoch height, assuming empty node list",
+			"epoch", epoch-1,
+			"err", err,
+		)
+		return empty
+	}
+	nl, err := sc.getNodeList(ctx, height)
+	if err != nil {
+		sc.logger.Warn("failed to query previous node list, assuming empty node list",
+			"epoch", epoch-1,
+			"height", height,
+			"err", err,
+		)
+		return empty
+	}
+	return nl
+}
+
 // New constructs a new tendermint backed registry Backend instance.
 func New(ctx context.Context, backend tmapi.Backend) (ServiceClient, error) {
 	// Initialize and register the tendermint service component.
@@ -428,6 +566,35 @@ func New(ctx context.Context, backend tmapi.Backend) (ServiceClient, error) {
 
 		wr <- nodeList
 	})
+	sc.nodeListDiffNotifier = pubsub.NewBrokerEx(func(ch channels.Channel) {
+		wr := ch.In()
+		blk, err := sc.backend.GetBlock(ctx, consensus.HeightLatest)
+		if err != nil {
+			sc.logger.Error("node list diff notifier: unable to get latest block",
+				"err", err,
+			)
+			return
+		}
+		nodeList, err := sc.getNodeList(ctx, blk.Height)
+		if err != nil {
+			sc.logger.Error("node list diff notifier: unable to get a list of nodes",
+				"err", err,
+			)
+			return
+		}
+		epoch, err := sc.backend.EpochTime().GetEpoch(ctx, blk.Height)
+		if err != nil {
+			sc.logger.Error("node list diff notifier: unable to get current epoch",
+				"err", err,
+			)
+			return
+		}
+
+		diff := api.NewNodeListDiff(nil, nodeList)
+		diff.Height = blk.Height
+		diff.Epoch = epoch
+		wr <- diff
+	})
 	sc.runtimeNotifier = pubsub.NewBrokerEx(func(ch channels.Channel) {
 		wr := ch.In()
 		runtimes, err := sc.GetRuntimes(ctx, &api.GetRuntimesQuery{Height: consensus.HeightLatest, IncludeSuspended: true})
@@ -21,6 +21,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	app "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api"
@@ -72,6 +73,7 @@ type serviceClient struct {
 	allBlockNotifier *pubsub.Broker
 	runtimeNotifiers map[common.Namespace]*runtimeBrokers
 	genesisBlocks    map[common.Namespace]*block.Block
+	blockHistories   map[common.Namespace]api.BlockHistory
 
 	queryCh        chan tmpubsub.Query
 	cmdCh          chan interface{}
@@ -110,6 +112,60 @@ func (sc *serviceClient) GetLatestBlock(ctx context.Context, id common.Namespace
 	return sc.getLatestBlockAt(ctx, id, height)
 }
 
+func (sc *serviceClient) GetLatestBlockTags(ctx context.Context, id common.Namespace, height int64) ([]block.Tag, error) {
+	q, err := sc.querier.QueryAt(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.LatestBlockTags(ctx, id)
+}
+
+func (sc *serviceClient) GetRoundResults(ctx context.Context, id common.Namespace, round uint64) (*api.RoundResults, error) {
+	results, err := sc.getRoundResultsAt(ctx, id, consensus.HeightLatest)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case results == nil || round > results.Round:
+		return nil, api.ErrNotFound
+	case round == results.Round:
+		return results, nil
+	default:
+	}
+
+	// Results of earlier rounds need to be looked up in the consensus state at the height at
+	// which the round has been finalized, which requires the runtime's block history.
+	sc.RLock()
+	bh := sc.blockHistories[id]
+	sc.RUnlock()
+	if bh == nil {
+		return nil, api.ErrNoBlockHistory
+	}
+
+	blk, err := bh.GetAnnotatedBlock(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+	results, err = sc.getRoundResultsAt(ctx, id, blk.Height)
+	if err != nil {
+		return nil, err
+	}
+	if results == nil || results.Round != round {
+		return nil, api.ErrNotFound
+	}
+	return results, nil
+}
+
+func (sc *serviceClient) getRoundResultsAt(ctx context.Context, id common.Namespace, height int64) (*api.RoundResults, error) {
+	q, err := sc.querier.QueryAt(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.LastRoundResults(ctx, id)
+}
+
 func (sc *serviceClient) getLatestBlockAt(ctx context.Context, id common.Namespace, height int64) (*block.Block, error) {
 	q, err := sc.querier.QueryAt(ctx, height)
 	if err != nil {
@@ -167,6 +223,88 @@ func (sc *serviceClient) WatchBlocks(id common.Namespace) (<-chan *api.Annotated
 	return monotonicCh, sub, nil
 }
 
+func (sc *serviceClient) WatchBlocksFrom(ctx context.Context, id common.Namespace, round uint64) (<-chan *api.AnnotatedBlock, *pubsub.Subscription, error) {
+	sc.RLock()
+	bh := sc.blockHistories[id]
+	sc.RUnlock()
+	if bh == nil {
+		return nil, nil, api.ErrNoBlockHistory
+	}
+
+	// Make sure that the requested round has not been pruned.
+	latestBlk, err := bh.GetLatestBlock(ctx)
+	switch err {
+	case nil:
+		if round <= latestBlk.Header.Round {
+			if _, err = bh.GetAnnotatedBlock(ctx, round); err != nil {
+				return nil, nil, fmt.Errorf("roothash: failed to fetch block at round %d: %w", round, err)
+			}
+		}
+	case api.ErrNotFound:
+	default:
+		return nil, nil, fmt.Errorf("roothash: failed to fetch latest block: %w", err)
+	}
+
+	// Subscribe to new blocks before replaying history so that no blocks are missed.
+	notifiers := sc.getRuntimeNotifiers(id)
+	sub := notifiers.blockNotifier.Subscribe()
+	ch := make(chan *api.AnnotatedBlock)
+	sub.Unwrap(ch)
+
+	backfillCh := make(chan *api.AnnotatedBlock)
+	go func() {
+		defer close(backfillCh)
+
+		emit := func(blk *api.AnnotatedBlock) bool {
+			select {
+			case backfillCh <- blk:
+				round = blk.Block.Header.Round + 1
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		// Replay historical blocks.
+		for {
+			blk, bhErr := bh.GetAnnotatedBlock(ctx, round)
+			switch bhErr {
+			case nil:
+			case api.ErrNotFound:
+			default:
+				sc.logger.Error("failed to fetch historic block",
+					"err", bhErr,
+					"runtime_id", id,
+					"round", round,
+				)
+				return
+			}
+			if blk == nil {
+				break
+			}
+			if !emit(blk) {
+				return
+			}
+		}
+
+		// Continue with new blocks, skipping any that have already been replayed.
+		for {
+			blk, ok := <-ch
+			if !ok {
+				return
+			}
+			if blk.Block.Header.Round < round {
+				continue
+			}
+			if !emit(blk) {
+				return
+			}
+		}
+	}()
+
+	return backfillCh, sub, nil
+}
+
 func (sc *serviceClient) WatchAllBlocks() (<-chan *block.Block, *pubsub.Subscription) {
 	sub := sc.allBlockNotifier.Subscribe()
 	ch := make(chan *block.Block)
@@ -414,6 +552,11 @@ func (sc *serviceClient) DeliverCommand(ctx context.Context, height int64, cmd i
 			blockHistory: c.blockHistory,
 		}
 		sc.trackedRuntime[c.runtimeID] = tr
+		if tr.blockHistory != nil {
+			sc.Lock()
+			sc.blockHistories[c.runtimeID] = tr.blockHistory
+			sc.Unlock()
+		}
 		// Request subscription to events for this runtime.
 		sc.queryCh <- app.QueryForRuntime(tr.runtimeID)
 
@@ -601,7 +744,7 @@ func EventsFromTendermint(
 					continue
 				}
 
-				ev := &api.Event{RuntimeID: value.ID, Height: height, TxHash: txHash, FinalizedEvent: &api.FinalizedEvent{Round: value.Round}}
+				ev := &api.Event{RuntimeID: value.ID, Height: height, TxHash: txHash, FinalizedEvent: &api.FinalizedEvent{Round: value.Round, Tags: value.Tags}}
 				events = append(events, ev)
 			case bytes.Equal(key, app.KeyExecutionDiscrepancyDetected):
 				// An execution discrepancy has been detected.
@@ -623,8 +766,31 @@ func EventsFromTendermint(
 
 				ev := &api.Event{RuntimeID: value.ID, Height: height, TxHash: txHash, ExecutorCommitted: &value.Event}
 				events = append(events, ev)
+			case bytes.Equal(key, app.KeyRoundFailed):
+				// A round has failed.
+				var value app.ValueRoundFailed
+				if err := cbor.Unmarshal(val, &value); err != nil {
+					errs = multierror.Append(errs, fmt.Errorf("roothash: corrupt ValueRoundFailed event: %w", err))
+					continue
+				}
+
+				ev := &api.Event{RuntimeID: value.ID, Height: height, TxHash: txHash, RoundFailed: &value.Event}
+				events = append(events, ev)
+			case bytes.Equal(key, app.KeyLiveness):
+				// A runtime has been suspended or resumed due to liveness.
+				var value app.ValueLiveness
+				if err := cbor.Unmarshal(val, &value); err != nil {
+					errs = multierror.Append(errs, fmt.Errorf("roothash: corrupt ValueLiveness event: %w", err))
+					continue
+				}
+
+				ev := &api.Event{RuntimeID: value.ID, Height: height, TxHash: txHash, Liveness: &value.Event}
+				events = append(events, ev)
 			case bytes.Equal(key, app.KeyRuntimeID):
 				// Runtime ID attribute (Base64-encoded to allow queries).
+			case bytes.HasPrefix(key, app.KeyTagPrefix):
+				// Runtime round tag attribute (included in the Finalized event, emitted separately
+				// to allow queries).
 			default:
 				errs = multierror.Append(errs, fmt.Errorf("roothash: unknown event type: key: %s, val: %s", key, val))
 			}
@@ -653,6 +819,7 @@ func New(
 		allBlockNotifier: pubsub.NewBroker(false),
 		runtimeNotifiers: make(map[common.Namespace]*runtimeBrokers),
 		genesisBlocks:    make(map[common.Namespace]*block.Block),
+		blockHistories:   make(map[common.Namespace]api.BlockHistory),
 		queryCh:          make(chan tmpubsub.Query, runtimeRegistry.MaxRuntimeCount),
 		cmdCh:            make(chan interface{}, runtimeRegistry.MaxRuntimeCount),
 		trackedRuntime:   make(map[common.Namespace]*trackedRuntime),
@@ -76,6 +76,15 @@ func (sc *serviceClient) GetCommittees(ctx context.Context, request *api.GetComm
 	return runtimeCommittees, nil
 }
 
+func (sc *serviceClient) SimulateElection(ctx context.Context, request *api.SimulateElectionRequest) (*api.ElectionSimulation, error) {
+	q, err := sc.querier.QueryAt(ctx, request.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.SimulateElection(ctx, request.RuntimeID, request.Kind)
+}
+
 func (sc *serviceClient) WatchCommittees(ctx context.Context) (<-chan *api.Committee, pubsub.ClosableSubscription, error) {
 	typedCh := make(chan *api.Committee)
 	sub := sc.notifier.Subscribe()
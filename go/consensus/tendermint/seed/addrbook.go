@@ -0,0 +1,65 @@
+package seed
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/tendermint/tendermint/p2p"
+
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+)
+
+// addrBookBucketTypeOld is the Tendermint address book bucket type of
+// addresses that were successfully connected to.
+const addrBookBucketTypeOld = 0x02
+
+// addrBookJSON is the on-disk format of the Tendermint address book.
+type addrBookJSON struct {
+	Addrs []*addrBookKnownAddress `json:"addrs"`
+}
+
+type addrBookKnownAddress struct {
+	Addr        *p2p.NetAddress `json:"addr"`
+	Src         *p2p.NetAddress `json:"src"`
+	Attempts    int32           `json:"attempts"`
+	BucketType  byte            `json:"bucket_type"`
+	LastAttempt time.Time       `json:"last_attempt"`
+	LastSuccess time.Time       `json:"last_success"`
+}
+
+// exportAddrBook exports the Tendermint address book stored at the given path.
+func exportAddrBook(path string) (*consensus.AddressBook, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("tendermint/seed: failed to read address book: %w", err)
+	}
+
+	var ab addrBookJSON
+	if err = json.Unmarshal(data, &ab); err != nil {
+		return nil, fmt.Errorf("tendermint/seed: malformed address book: %w", err)
+	}
+
+	book := &consensus.AddressBook{
+		Addresses: []*consensus.AddressBookEntry{},
+	}
+	for _, ka := range ab.Addrs {
+		if ka.Addr == nil {
+			continue
+		}
+
+		entry := &consensus.AddressBookEntry{
+			Address:     ka.Addr.String(),
+			Good:        ka.BucketType == addrBookBucketTypeOld,
+			Attempts:    ka.Attempts,
+			LastAttempt: ka.LastAttempt,
+			LastSuccess: ka.LastSuccess,
+		}
+		if ka.Src != nil {
+			entry.Source = ka.Src.String()
+		}
+		book.Addresses = append(book.Addresses, entry)
+	}
+	return book, nil
+}
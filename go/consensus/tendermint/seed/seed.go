@@ -59,10 +59,11 @@ type seedService struct {
 
 	doc *genesis.Document
 
-	addr      *p2p.NetAddress
-	transport *p2p.MultiplexTransport
-	addrBook  pex.AddrBook
-	p2pSwitch *p2p.Switch
+	addr         *p2p.NetAddress
+	transport    *p2p.MultiplexTransport
+	addrBook     pex.AddrBook
+	addrBookPath string
+	p2pSwitch    *p2p.Switch
 
 	stopOnce sync.Once
 	quitCh   chan struct{}
@@ -133,6 +134,11 @@ func (srv *seedService) GetStatus(ctx context.Context) (*consensus.Status, error
 		ConsensusVersion: version.ConsensusProtocol.String(),
 		Backend:          api.BackendName,
 		Features:         srv.SupportedFeatures(),
+		ChainID:          srv.doc.ChainID,
+		ChainContext:     srv.doc.ChainContext(),
+		HaltEpoch:        srv.doc.HaltEpoch,
+		// Seed is always considered synced.
+		IsSynced: true,
 	}
 
 	// List of consensus peers.
@@ -198,6 +204,11 @@ func (srv *seedService) GetEpoch(ctx context.Context, height int64) (epochtime.E
 	return 0, consensus.ErrUnsupported
 }
 
+// Implements Backend.
+func (srv *seedService) GetEpochBlock(ctx context.Context, epoch epochtime.EpochTime) (int64, error) {
+	return 0, consensus.ErrUnsupported
+}
+
 // Implements Backend.
 func (srv *seedService) GetBlock(ctx context.Context, height int64) (*consensus.Block, error) {
 	return nil, consensus.ErrUnsupported
@@ -223,6 +234,24 @@ func (srv *seedService) WatchBlocks(ctx context.Context) (<-chan *consensus.Bloc
 	return nil, nil, consensus.ErrUnsupported
 }
 
+// Implements Backend.
+func (srv *seedService) WatchFeeStatistics(ctx context.Context) (<-chan *consensus.FeeStatistics, pubsub.ClosableSubscription, error) {
+	return nil, nil, consensus.ErrUnsupported
+}
+
+// Implements Backend.
+func (srv *seedService) GetStateAttestation(ctx context.Context, height int64) (*consensus.StateAttestation, error) {
+	return nil, consensus.ErrUnsupported
+}
+
+// Implements Backend.
+func (srv *seedService) GetAddressBook(ctx context.Context) (*consensus.AddressBook, error) {
+	// Make sure the on-disk address book is up to date.
+	srv.addrBook.Save()
+
+	return exportAddrBook(srv.addrBookPath)
+}
+
 // Implements Backend.
 func (srv *seedService) GetSignerNonce(ctx context.Context, req *consensus.GetSignerNonceRequest) (uint64, error) {
 	return 0, consensus.ErrUnsupported
@@ -258,6 +287,11 @@ func (srv *seedService) RegisterHaltHook(func(ctx context.Context, blockHeight i
 	panic(consensus.ErrUnsupported)
 }
 
+// Implements Backend.
+func (srv *seedService) RegisterSigningAlertHandler(consensus.SigningAlertHandler) {
+	panic(consensus.ErrUnsupported)
+}
+
 // Note: SupportedFeatures() indicates that the backend does not support
 // consensus services so the caller is at fault for not adhering to the
 // SupportedFeatures flag, in case any of the following methods is called.
@@ -358,8 +392,8 @@ func New(dataDir string, identity *identity.Identity, genesisProvider genesis.Pr
 	}
 	srv.transport = p2p.NewMultiplexTransport(nodeInfo, *nodeKey, p2p.MConnConfig(p2pCfg))
 
-	addrBookPath := filepath.Join(seedDataDir, tmcommon.ConfigDir, "addrbook.json")
-	srv.addrBook = pex.NewAddrBook(addrBookPath, p2pCfg.AddrBookStrict)
+	srv.addrBookPath = filepath.Join(seedDataDir, tmcommon.ConfigDir, "addrbook.json")
+	srv.addrBook = pex.NewAddrBook(srv.addrBookPath, p2pCfg.AddrBookStrict)
 	srv.addrBook.SetLogger(logger.With("module", "book"))
 	if err = srv.addrBook.Start(); err != nil {
 		return nil, fmt.Errorf("tendermint/seed: failed to start address book: %w", err)
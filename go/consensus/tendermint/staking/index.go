@@ -0,0 +1,331 @@
+package staking
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/dgraph-io/badger/v2/options"
+
+	cmnBadger "github.com/oasisprotocol/oasis-core/go/common/badger"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+const (
+	eventIndexDBVersion = 1
+
+	// eventIndexFilename is the name of the staking event index database.
+	eventIndexFilename = "staking-events.badger.db"
+
+	cursorSize = 12
+)
+
+var (
+	// eventIndexMetadataKeyFmt is the metadata key format.
+	//
+	// Value is CBOR-serialized eventIndexMetadata.
+	eventIndexMetadataKeyFmt = keyformat.New(0x01)
+	// eventKeyFmt is the event key format (height, index within block).
+	//
+	// Value is CBOR-serialized api.Event.
+	eventKeyFmt = keyformat.New(0x02, uint64(0), uint32(0))
+	// eventAddressKeyFmt is the per-account event key format (address, height,
+	// index within block).
+	//
+	// Value is empty.
+	eventAddressKeyFmt = keyformat.New(0x03, &api.Address{}, uint64(0), uint32(0))
+)
+
+type eventIndexMetadata struct {
+	// Version is the database schema version.
+	Version uint64 `json:"version"`
+
+	// FirstHeight is the first indexed height.
+	FirstHeight int64 `json:"first_height"`
+	// LastHeight is the last indexed height.
+	LastHeight int64 `json:"last_height"`
+}
+
+// eventIndex is an index of staking events that supports querying events by
+// account, kind and height range.
+//
+// The index always covers a contiguous range of heights.
+type eventIndex struct {
+	logger *logging.Logger
+
+	db *badger.DB
+	gc *cmnBadger.GCWorker
+}
+
+func (ei *eventIndex) queryGetMetadata(tx *badger.Txn) (*eventIndexMetadata, error) {
+	item, err := tx.Get(eventIndexMetadataKeyFmt.Encode())
+	switch err {
+	case nil:
+	case badger.ErrKeyNotFound:
+		return &eventIndexMetadata{Version: eventIndexDBVersion}, nil
+	default:
+		return nil, err
+	}
+
+	var meta eventIndexMetadata
+	err = item.Value(func(val []byte) error {
+		return cbor.Unmarshal(val, &meta)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+func (ei *eventIndex) metadata() (*eventIndexMetadata, error) {
+	var meta *eventIndexMetadata
+	err := ei.db.View(func(tx *badger.Txn) error {
+		var err error
+		meta, err = ei.queryGetMetadata(tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// indexBlock indexes the events emitted at the given height.
+//
+// Heights must be indexed in order without any gaps. Indexing an already
+// indexed height is a no-op.
+func (ei *eventIndex) indexBlock(height int64, events []*api.Event) error {
+	return ei.db.Update(func(tx *badger.Txn) error {
+		meta, err := ei.queryGetMetadata(tx)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case meta.LastHeight == 0:
+			meta.FirstHeight = height
+		case height <= meta.LastHeight:
+			return nil
+		case height != meta.LastHeight+1:
+			return fmt.Errorf("staking: non-contiguous event index height (last: %d wanted: %d)",
+				meta.LastHeight,
+				height,
+			)
+		}
+
+		for idx, ev := range events {
+			h, i := uint64(height), uint32(idx)
+			if err = tx.Set(eventKeyFmt.Encode(h, i), cbor.Marshal(ev)); err != nil {
+				return err
+			}
+
+			seen := make(map[api.Address]bool)
+			for _, addr := range ev.RelatedAddresses() {
+				if seen[addr] {
+					continue
+				}
+				seen[addr] = true
+
+				if err = tx.Set(eventAddressKeyFmt.Encode(&addr, h, i), []byte{}); err != nil { // nolint: gosec
+					return err
+				}
+			}
+		}
+
+		meta.LastHeight = height
+		return tx.Set(eventIndexMetadataKeyFmt.Encode(), cbor.Marshal(meta))
+	})
+}
+
+// reset removes all indexed events.
+func (ei *eventIndex) reset() error {
+	return ei.db.DropAll()
+}
+
+// query returns the indexed events matching the given filter.
+func (ei *eventIndex) query(filter *api.EventFilter) (*api.EventQueryResult, error) {
+	limit := filter.Limit
+	switch {
+	case limit == 0:
+		limit = api.DefaultEventQueryLimit
+	case limit > api.MaxEventQueryLimit:
+		return nil, fmt.Errorf("%w: limit exceeds maximum (%d)", api.ErrInvalidArgument, api.MaxEventQueryLimit)
+	}
+	if filter.FromHeight < 0 || filter.ToHeight < 0 {
+		return nil, fmt.Errorf("%w: negative height", api.ErrInvalidArgument)
+	}
+	if filter.ToHeight != 0 && filter.ToHeight < filter.FromHeight {
+		return nil, fmt.Errorf("%w: invalid height range", api.ErrInvalidArgument)
+	}
+
+	kinds := make(map[api.EventKind]bool)
+	for _, kind := range filter.Kinds {
+		kinds[kind] = true
+	}
+
+	// Determine the starting position.
+	startHeight, startIndex := uint64(filter.FromHeight), uint32(0)
+	if len(filter.Cursor) > 0 {
+		if len(filter.Cursor) != cursorSize {
+			return nil, fmt.Errorf("%w: malformed cursor", api.ErrInvalidArgument)
+		}
+		startHeight = binary.BigEndian.Uint64(filter.Cursor[:8])
+		startIndex = binary.BigEndian.Uint32(filter.Cursor[8:])
+		if startIndex == ^uint32(0) {
+			return nil, fmt.Errorf("%w: malformed cursor", api.ErrInvalidArgument)
+		}
+		// The cursor points at the last returned event.
+		startIndex++
+	}
+
+	result := &api.EventQueryResult{
+		Events: []*api.Event{},
+	}
+	err := ei.db.View(func(tx *badger.Txn) error {
+		meta, err := ei.queryGetMetadata(tx)
+		if err != nil {
+			return err
+		}
+		if meta.LastHeight == 0 {
+			return api.ErrEventIndexUnavailable
+		}
+		if filter.FromHeight != 0 && filter.FromHeight < meta.FirstHeight {
+			return api.ErrEventIndexUnavailable
+		}
+		toHeight := uint64(meta.LastHeight)
+		if filter.ToHeight != 0 && filter.ToHeight < meta.LastHeight {
+			toHeight = uint64(filter.ToHeight)
+		}
+
+		var prefix, start []byte
+		switch filter.Address {
+		case nil:
+			prefix = eventKeyFmt.Encode()
+			start = eventKeyFmt.Encode(startHeight, startIndex)
+		default:
+			prefix = eventAddressKeyFmt.Encode(filter.Address)
+			start = eventAddressKeyFmt.Encode(filter.Address, startHeight, startIndex)
+		}
+
+		it := tx.NewIterator(badger.IteratorOptions{Prefix: prefix})
+		defer it.Close()
+
+		var (
+			lastHeight uint64
+			lastIndex  uint32
+		)
+		for it.Seek(start); it.ValidForPrefix(prefix); it.Next() {
+			var (
+				height uint64
+				index  uint32
+				addr   api.Address
+				ok     bool
+			)
+			switch filter.Address {
+			case nil:
+				ok = eventKeyFmt.Decode(it.Item().Key(), &height, &index)
+			default:
+				ok = eventAddressKeyFmt.Decode(it.Item().Key(), &addr, &height, &index)
+			}
+			if !ok || height > toHeight {
+				break
+			}
+
+			ev, err := ei.queryGetEvent(tx, height, index)
+			if err != nil {
+				return err
+			}
+			if len(kinds) > 0 && !kinds[ev.Kind()] {
+				continue
+			}
+
+			if uint64(len(result.Events)) == limit {
+				// There are more matching events, return a cursor pointing at
+				// the last returned event.
+				result.NextCursor = encodeCursor(lastHeight, lastIndex)
+				break
+			}
+			result.Events = append(result.Events, ev)
+			lastHeight, lastIndex = height, index
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (ei *eventIndex) queryGetEvent(tx *badger.Txn, height uint64, index uint32) (*api.Event, error) {
+	item, err := tx.Get(eventKeyFmt.Encode(height, index))
+	if err != nil {
+		return nil, fmt.Errorf("staking: corrupted event index: %w", err)
+	}
+
+	var ev api.Event
+	err = item.Value(func(val []byte) error {
+		return cbor.Unmarshal(val, &ev)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("staking: corrupted event index: %w", err)
+	}
+	return &ev, nil
+}
+
+func (ei *eventIndex) close() {
+	ei.gc.Close()
+	ei.db.Close()
+}
+
+func encodeCursor(height uint64, index uint32) []byte {
+	var cursor [cursorSize]byte
+	binary.BigEndian.PutUint64(cursor[:8], height)
+	binary.BigEndian.PutUint32(cursor[8:], index)
+	return cursor[:]
+}
+
+// newEventIndex opens (or creates) the staking event index at the given path.
+// In case the path is empty, an in-memory index is used.
+func newEventIndex(fn string) (*eventIndex, error) {
+	logger := logging.GetLogger("staking/tendermint/index").With("path", fn)
+
+	opts := badger.DefaultOptions(fn)
+	opts = opts.WithLogger(cmnBadger.NewLogAdapter(logger))
+	opts = opts.WithSyncWrites(true)
+	// Allow value log truncation if required (this is needed to recover the
+	// value log file which can get corrupted in crashes).
+	opts = opts.WithTruncate(true)
+	opts = opts.WithCompression(options.None)
+	if fn == "" {
+		opts = opts.WithInMemory(true)
+	}
+
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, fmt.Errorf("staking: failed to open event index: %w", err)
+	}
+
+	ei := &eventIndex{
+		logger: logger,
+		db:     db,
+		gc:     cmnBadger.NewGCWorker(logger, db),
+	}
+
+	meta, err := ei.metadata()
+	if err != nil {
+		ei.close()
+		return nil, err
+	}
+	if meta.Version != eventIndexDBVersion {
+		ei.close()
+		return nil, fmt.Errorf("staking: unsupported event index version (expected: %d got: %d)",
+			eventIndexDBVersion,
+			meta.Version,
+		)
+	}
+
+	return ei, nil
+}
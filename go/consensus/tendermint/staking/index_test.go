@@ -0,0 +1,121 @@
+package staking
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestEventIndex(t *testing.T) {
+	require := require.New(t)
+
+	ei, err := newEventIndex("")
+	require.NoError(err, "newEventIndex")
+	defer ei.close()
+
+	addr1 := api.NewAddress(signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000001"))
+	addr2 := api.NewAddress(signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000002"))
+	addr3 := api.NewAddress(signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000003"))
+	amount := *quantity.NewFromUint64(10)
+
+	_, err = ei.query(&api.EventFilter{})
+	require.True(errors.Is(err, api.ErrEventIndexUnavailable), "empty index should not be queryable")
+
+	// Index a few blocks, starting at a non-zero height.
+	for height := int64(10); height < 20; height++ {
+		events := []*api.Event{
+			{Height: height, Transfer: &api.TransferEvent{From: addr1, To: addr2, Amount: amount}},
+			{Height: height, Burn: &api.BurnEvent{Owner: addr3, Amount: amount}},
+		}
+		if height%2 == 0 {
+			events = append(events, &api.Event{Height: height, Escrow: &api.EscrowEvent{
+				Add: &api.AddEscrowEvent{Owner: addr1, Escrow: addr3, Amount: amount},
+			}})
+		}
+		err = ei.indexBlock(height, events)
+		require.NoError(err, "indexBlock")
+	}
+	err = ei.indexBlock(15, nil)
+	require.NoError(err, "re-indexing an indexed height should be a no-op")
+	err = ei.indexBlock(25, nil)
+	require.Error(err, "indexing with a gap should fail")
+
+	// All events.
+	res, err := ei.query(&api.EventFilter{})
+	require.NoError(err, "query")
+	require.Len(res.Events, 25)
+	require.Nil(res.NextCursor)
+	require.EqualValues(10, res.Events[0].Height)
+	require.EqualValues(19, res.Events[len(res.Events)-1].Height)
+
+	// Filter by address.
+	res, err = ei.query(&api.EventFilter{Address: &addr3})
+	require.NoError(err, "query")
+	require.Len(res.Events, 15)
+	for _, ev := range res.Events {
+		require.Contains(ev.RelatedAddresses(), addr3)
+	}
+
+	// Filter by kind.
+	res, err = ei.query(&api.EventFilter{Kinds: []api.EventKind{api.EventKindAddEscrow, api.EventKindBurn}})
+	require.NoError(err, "query")
+	require.Len(res.Events, 15)
+	for _, ev := range res.Events {
+		require.NotEqual(api.EventKindTransfer, ev.Kind())
+	}
+
+	// Filter by address, kind and height range.
+	res, err = ei.query(&api.EventFilter{
+		Address:    &addr1,
+		Kinds:      []api.EventKind{api.EventKindAddEscrow},
+		FromHeight: 12,
+		ToHeight:   16,
+	})
+	require.NoError(err, "query")
+	require.Len(res.Events, 3)
+	for i, ev := range res.Events {
+		require.EqualValues(12+2*i, ev.Height)
+		require.Equal(api.EventKindAddEscrow, ev.Kind())
+	}
+
+	// Pagination.
+	var (
+		paged  []*api.Event
+		cursor []byte
+	)
+	for {
+		res, err = ei.query(&api.EventFilter{Address: &addr1, Limit: 4, Cursor: cursor})
+		require.NoError(err, "query")
+		require.True(len(res.Events) <= 4, "limit should be respected")
+		paged = append(paged, res.Events...)
+		if res.NextCursor == nil {
+			break
+		}
+		cursor = res.NextCursor
+	}
+	all, err := ei.query(&api.EventFilter{Address: &addr1})
+	require.NoError(err, "query")
+	require.Len(all.Events, 15)
+	require.EqualValues(all.Events, paged, "paginated results should match")
+
+	// Invalid queries.
+	_, err = ei.query(&api.EventFilter{FromHeight: 5})
+	require.True(errors.Is(err, api.ErrEventIndexUnavailable), "heights before the index should not be queryable")
+	_, err = ei.query(&api.EventFilter{FromHeight: 15, ToHeight: 12})
+	require.True(errors.Is(err, api.ErrInvalidArgument), "invalid height range should fail")
+	_, err = ei.query(&api.EventFilter{Limit: api.MaxEventQueryLimit + 1})
+	require.True(errors.Is(err, api.ErrInvalidArgument), "excessive limit should fail")
+	_, err = ei.query(&api.EventFilter{Cursor: []byte("bad")})
+	require.True(errors.Is(err, api.ErrInvalidArgument), "malformed cursor should fail")
+
+	// Reset.
+	err = ei.reset()
+	require.NoError(err, "reset")
+	_, err = ei.query(&api.EventFilter{})
+	require.True(errors.Is(err, api.ErrEventIndexUnavailable), "reset index should not be queryable")
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"path/filepath"
 
 	"github.com/hashicorp/go-multierror"
 	tmabcitypes "github.com/tendermint/tendermint/abci/types"
@@ -17,6 +18,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	app "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking"
 	"github.com/oasisprotocol/oasis-core/go/staking/api"
@@ -35,8 +37,10 @@ type serviceClient struct {
 
 	backend tmapi.Backend
 	querier *app.QueryFactory
+	index   *eventIndex
 
-	eventNotifier *pubsub.Broker
+	eventNotifier     *pubsub.Broker
+	amendmentNotifier *pubsub.Broker
 }
 
 func (sc *serviceClient) TokenSymbol(ctx context.Context) (string, error) {
@@ -84,6 +88,15 @@ func (sc *serviceClient) LastBlockFees(ctx context.Context, height int64) (*quan
 	return q.LastBlockFees(ctx)
 }
 
+func (sc *serviceClient) RewardScale(ctx context.Context, height int64) (*quantity.Quantity, error) {
+	q, err := sc.querier.QueryAt(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.RewardScale(ctx)
+}
+
 func (sc *serviceClient) Threshold(ctx context.Context, query *api.ThresholdQuery) (*quantity.Quantity, error) {
 	q, err := sc.querier.QueryAt(ctx, query.Height)
 	if err != nil {
@@ -93,6 +106,15 @@ func (sc *serviceClient) Threshold(ctx context.Context, query *api.ThresholdQuer
 	return q.Threshold(ctx, query.Kind)
 }
 
+func (sc *serviceClient) Thresholds(ctx context.Context, height int64) (map[api.ThresholdKind]quantity.Quantity, error) {
+	q, err := sc.querier.QueryAt(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.Thresholds(ctx)
+}
+
 func (sc *serviceClient) Addresses(ctx context.Context, height int64) ([]api.Address, error) {
 	q, err := sc.querier.QueryAt(ctx, height)
 	if err != nil {
@@ -102,6 +124,15 @@ func (sc *serviceClient) Addresses(ctx context.Context, height int64) ([]api.Add
 	return q.Addresses(ctx)
 }
 
+func (sc *serviceClient) BelowThresholdAccounts(ctx context.Context, height int64) ([]api.Address, error) {
+	q, err := sc.querier.QueryAt(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.BelowThresholdAccounts(ctx)
+}
+
 func (sc *serviceClient) Account(ctx context.Context, query *api.OwnerQuery) (*api.Account, error) {
 	q, err := sc.querier.QueryAt(ctx, query.Height)
 	if err != nil {
@@ -120,6 +151,33 @@ func (sc *serviceClient) Delegations(ctx context.Context, query *api.OwnerQuery)
 	return q.Delegations(ctx, query.Owner)
 }
 
+func (sc *serviceClient) DelegationsTo(ctx context.Context, query *api.OwnerQuery) (map[api.Address]*api.Delegation, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.DelegationsTo(ctx, query.Owner)
+}
+
+func (sc *serviceClient) DelegationsAt(ctx context.Context, query *api.DelegationsAtQuery) (map[api.Address]*api.Delegation, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.DelegationsAt(ctx, query.Escrow, query.Epoch)
+}
+
+func (sc *serviceClient) RewardsAt(ctx context.Context, query *api.RewardsAtQuery) (*api.EpochRewards, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.EpochRewards(ctx, query.Epoch)
+}
+
 func (sc *serviceClient) DebondingDelegations(ctx context.Context, query *api.OwnerQuery) (map[api.Address][]*api.DebondingDelegation, error) {
 	q, err := sc.querier.QueryAt(ctx, query.Height)
 	if err != nil {
@@ -129,6 +187,24 @@ func (sc *serviceClient) DebondingDelegations(ctx context.Context, query *api.Ow
 	return q.DebondingDelegations(ctx, query.Owner)
 }
 
+func (sc *serviceClient) DebondingDelegationsTo(ctx context.Context, query *api.OwnerQuery) (map[api.Address][]*api.DebondingDelegation, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.DebondingDelegationsTo(ctx, query.Owner)
+}
+
+func (sc *serviceClient) AccountStateProof(ctx context.Context, query *api.OwnerQuery) (*api.AccountStateProof, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.AccountStateProof(ctx, query.Owner)
+}
+
 func (sc *serviceClient) Allowance(ctx context.Context, query *api.AllowanceQuery) (*quantity.Quantity, error) {
 	acct, err := sc.Account(ctx, &api.OwnerQuery{
 		Height: query.Height,
@@ -142,6 +218,23 @@ func (sc *serviceClient) Allowance(ctx context.Context, query *api.AllowanceQuer
 	return &allowance, nil
 }
 
+func (sc *serviceClient) CommissionAt(ctx context.Context, query *api.CommissionAtQuery) (*quantity.Quantity, error) {
+	acct, err := sc.Account(ctx, &api.OwnerQuery{
+		Height: query.Height,
+		Owner:  query.Owner,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// In case no rate step has started, no commission is charged.
+	rate := acct.Escrow.CommissionSchedule.CurrentRate(query.Epoch)
+	if rate == nil {
+		return quantity.NewQuantity(), nil
+	}
+	return rate.Clone(), nil
+}
+
 func (sc *serviceClient) StateToGenesis(ctx context.Context, height int64) (*api.Genesis, error) {
 	// Query the staking genesis state.
 	q, err := sc.querier.QueryAt(ctx, height)
@@ -217,6 +310,10 @@ func (sc *serviceClient) GetEvents(ctx context.Context, height int64) ([]*api.Ev
 	return events, nil
 }
 
+func (sc *serviceClient) QueryEvents(ctx context.Context, filter *api.EventFilter) (*api.EventQueryResult, error) {
+	return sc.index.query(filter)
+}
+
 func (sc *serviceClient) WatchEvents(ctx context.Context) (<-chan *api.Event, pubsub.ClosableSubscription, error) {
 	typedCh := make(chan *api.Event)
 	sub := sc.eventNotifier.Subscribe()
@@ -225,6 +322,91 @@ func (sc *serviceClient) WatchEvents(ctx context.Context) (<-chan *api.Event, pu
 	return typedCh, sub, nil
 }
 
+func (sc *serviceClient) WatchEventsFrom(ctx context.Context, startHeight int64) (<-chan *api.Event, pubsub.ClosableSubscription, error) {
+	// Subscribe before determining the latest height so that no events are
+	// missed when switching from replay to live streaming.
+	sub := sc.eventNotifier.Subscribe()
+
+	blk, err := sc.backend.GetBlock(ctx, consensus.HeightLatest)
+	if err != nil {
+		sub.Close()
+		return nil, nil, fmt.Errorf("staking: failed to query latest block: %w", err)
+	}
+	lastRetained, err := sc.backend.GetLastRetainedVersion(ctx)
+	if err != nil {
+		sub.Close()
+		return nil, nil, fmt.Errorf("staking: failed to query last retained version: %w", err)
+	}
+	if startHeight < lastRetained || startHeight > blk.Height+1 {
+		sub.Close()
+		return nil, nil, api.ErrEventsUnavailable
+	}
+
+	typedCh := make(chan *api.Event)
+	watchCtx, watchSub := pubsub.NewContextSubscription(context.Background())
+	go func() {
+		defer close(typedCh)
+		defer sub.Close()
+
+		send := func(ev *api.Event) bool {
+			select {
+			case typedCh <- ev:
+				return true
+			case <-watchCtx.Done():
+				return false
+			}
+		}
+
+		// Replay events up to and including the latest height.
+		for height := startHeight; height <= blk.Height; height++ {
+			events, gerr := sc.GetEvents(watchCtx, height)
+			if gerr != nil {
+				sc.logger.Error("failed to replay events",
+					"err", gerr,
+					"height", height,
+				)
+				return
+			}
+			for _, ev := range events {
+				if !send(ev) {
+					return
+				}
+			}
+		}
+
+		// Switch to live events, skipping any that have already been replayed.
+		for {
+			select {
+			case v, ok := <-sub.Untyped():
+				if !ok {
+					return
+				}
+				ev := v.(*api.Event)
+				if ev.Height <= blk.Height {
+					continue
+				}
+				if !send(ev) {
+					return
+				}
+			case <-watchCtx.Done():
+				return
+			}
+		}
+	}()
+
+	return typedCh, watchSub, nil
+}
+
+func (sc *serviceClient) WatchCommissionScheduleAmendments(
+	ctx context.Context,
+) (<-chan *api.CommissionScheduleAmendmentEvent, pubsub.ClosableSubscription, error) {
+	typedCh := make(chan *api.CommissionScheduleAmendmentEvent)
+	sub := sc.amendmentNotifier.Subscribe()
+	sub.Unwrap(typedCh)
+
+	return typedCh, sub, nil
+}
+
 func (sc *serviceClient) ConsensusParameters(ctx context.Context, height int64) (*api.ConsensusParameters, error) {
 	q, err := sc.querier.QueryAt(ctx, height)
 	if err != nil {
@@ -235,6 +417,7 @@ func (sc *serviceClient) ConsensusParameters(ctx context.Context, height int64)
 }
 
 func (sc *serviceClient) Cleanup() {
+	sc.index.close()
 }
 
 // Implements api.ServiceClient.
@@ -242,6 +425,51 @@ func (sc *serviceClient) ServiceDescriptor() tmapi.ServiceDescriptor {
 	return tmapi.NewStaticServiceDescriptor(api.ModuleName, app.EventType, []tmpubsub.Query{app.QueryApp})
 }
 
+// Implements api.ServiceClient.
+func (sc *serviceClient) DeliverBlock(ctx context.Context, height int64) error {
+	meta, err := sc.index.metadata()
+	if err != nil {
+		return fmt.Errorf("staking: failed to query event index metadata: %w", err)
+	}
+
+	// Catch up with any blocks that were not indexed (e.g., while the node
+	// was stopped). Start indexing at the current height in case the index
+	// is empty.
+	fromHeight := meta.LastHeight + 1
+	switch {
+	case meta.LastHeight == 0:
+		fromHeight = height
+	case fromHeight < height:
+		var lastRetained int64
+		if lastRetained, err = sc.backend.GetLastRetainedVersion(ctx); err != nil {
+			return fmt.Errorf("staking: failed to query last retained version: %w", err)
+		}
+		if fromHeight < lastRetained {
+			// Events for the missing heights are no longer available, so the
+			// index can no longer be contiguous.
+			sc.logger.Warn("missing heights have been pruned, resetting event index",
+				"last_indexed_height", meta.LastHeight,
+				"last_retained_height", lastRetained,
+			)
+			if err = sc.index.reset(); err != nil {
+				return fmt.Errorf("staking: failed to reset event index: %w", err)
+			}
+			fromHeight = height
+		}
+	}
+
+	for h := fromHeight; h <= height; h++ {
+		events, err := sc.GetEvents(ctx, h)
+		if err != nil {
+			return fmt.Errorf("staking: failed to get events at height %d: %w", h, err)
+		}
+		if err = sc.index.indexBlock(h, events); err != nil {
+			return fmt.Errorf("staking: failed to index events at height %d: %w", h, err)
+		}
+	}
+	return nil
+}
+
 // Implements api.ServiceClient.
 func (sc *serviceClient) DeliverEvent(ctx context.Context, height int64, tx tmtypes.Tx, ev *tmabcitypes.Event) error {
 	events, err := EventsFromTendermint(tx, height, []tmabcitypes.Event{*ev})
@@ -252,6 +480,9 @@ func (sc *serviceClient) DeliverEvent(ctx context.Context, height int64, tx tmty
 	// Notify subscribers of events.
 	for _, ev := range events {
 		sc.eventNotifier.Broadcast(ev)
+		if ev.CommissionScheduleAmendment != nil {
+			sc.amendmentNotifier.Broadcast(ev.CommissionScheduleAmendment)
+		}
 	}
 
 	return nil
@@ -344,6 +575,56 @@ func EventsFromTendermint(
 
 				evt := &api.Event{Height: height, TxHash: txHash, AllowanceChange: &e}
 				events = append(events, evt)
+			case bytes.Equal(key, app.KeyCommissionDestinationChange):
+				// Commission destination change event.
+				var e api.CommissionDestinationChangeEvent
+				if err := cbor.Unmarshal(val, &e); err != nil {
+					errs = multierror.Append(errs, fmt.Errorf("staking: corrupt CommissionDestinationChange event: %w", err))
+					continue
+				}
+
+				evt := &api.Event{Height: height, TxHash: txHash, CommissionDestinationChange: &e}
+				events = append(events, evt)
+			case bytes.Equal(key, app.KeyPolicyViolation):
+				// Policy violation event.
+				var e api.PolicyViolationEvent
+				if err := cbor.Unmarshal(val, &e); err != nil {
+					errs = multierror.Append(errs, fmt.Errorf("staking: corrupt PolicyViolation event: %w", err))
+					continue
+				}
+
+				evt := &api.Event{Height: height, TxHash: txHash, PolicyViolation: &e}
+				events = append(events, evt)
+			case bytes.Equal(key, app.KeyCommissionScheduleAmendment):
+				// Commission schedule amendment event.
+				var e api.CommissionScheduleAmendmentEvent
+				if err := cbor.Unmarshal(val, &e); err != nil {
+					errs = multierror.Append(errs, fmt.Errorf("staking: corrupt CommissionScheduleAmendment event: %w", err))
+					continue
+				}
+
+				evt := &api.Event{Height: height, TxHash: txHash, CommissionScheduleAmendment: &e}
+				events = append(events, evt)
+			case bytes.Equal(key, app.KeyEscrowThreshold):
+				// Escrow threshold event.
+				var e api.EscrowThresholdEvent
+				if err := cbor.Unmarshal(val, &e); err != nil {
+					errs = multierror.Append(errs, fmt.Errorf("staking: corrupt EscrowThreshold event: %w", err))
+					continue
+				}
+
+				evt := &api.Event{Height: height, TxHash: txHash, EscrowThreshold: &e}
+				events = append(events, evt)
+			case bytes.Equal(key, app.KeyEpochRewards):
+				// Epoch rewards event.
+				var e api.EpochRewards
+				if err := cbor.Unmarshal(val, &e); err != nil {
+					errs = multierror.Append(errs, fmt.Errorf("staking: corrupt EpochRewards event: %w", err))
+					continue
+				}
+
+				evt := &api.Event{Height: height, TxHash: txHash, EpochRewards: &e}
+				events = append(events, evt)
 			default:
 				errs = multierror.Append(errs, fmt.Errorf("staking: unknown event type: key: %s, val: %s", key, val))
 			}
@@ -354,7 +635,7 @@ func EventsFromTendermint(
 }
 
 // New constructs a new tendermint backed staking Backend instance.
-func New(ctx context.Context, backend tmapi.Backend) (ServiceClient, error) {
+func New(ctx context.Context, dataDir string, backend tmapi.Backend) (ServiceClient, error) {
 	// Initialize and register the tendermint service component.
 	a := app.New()
 	if err := backend.RegisterApplication(a); err != nil {
@@ -366,10 +647,17 @@ func New(ctx context.Context, backend tmapi.Backend) (ServiceClient, error) {
 		return nil, err
 	}
 
+	index, err := newEventIndex(filepath.Join(dataDir, eventIndexFilename))
+	if err != nil {
+		return nil, err
+	}
+
 	return &serviceClient{
-		logger:        logging.GetLogger("staking/tendermint"),
-		backend:       backend,
-		querier:       a.QueryFactory().(*app.QueryFactory),
-		eventNotifier: pubsub.NewBroker(false),
+		logger:            logging.GetLogger("staking/tendermint"),
+		backend:           backend,
+		querier:           a.QueryFactory().(*app.QueryFactory),
+		index:             index,
+		eventNotifier:     pubsub.NewBroker(false),
+		amendmentNotifier: pubsub.NewBroker(false),
 	}, nil
 }
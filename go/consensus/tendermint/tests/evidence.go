@@ -31,12 +31,12 @@ func MakeDoubleSignEvidence(t *testing.T, ident *identity.Identity, blk *consens
 	pv1Path := filepath.Join(tmpDir, "pv1")
 	err = os.Mkdir(pv1Path, 0o700)
 	require.NoError(err, "Mkdir")
-	pv1, err := tmcrypto.LoadOrGeneratePrivVal(pv1Path, ident.ConsensusSigner)
+	pv1, err := tmcrypto.LoadOrGeneratePrivVal(pv1Path, ident.ConsensusSigner, false)
 	require.NoError(err, "LoadOrGeneratePrivVal")
 	pv2Path := filepath.Join(tmpDir, "pv2")
 	err = os.Mkdir(pv2Path, 0o700)
 	require.NoError(err, "Mkdir")
-	pv2, err := tmcrypto.LoadOrGeneratePrivVal(pv2Path, ident.ConsensusSigner)
+	pv2, err := tmcrypto.LoadOrGeneratePrivVal(pv2Path, ident.ConsensusSigner, false)
 	require.NoError(err, "LoadOrGeneratePrivVal")
 
 	// Generate fake Tendermint-specific double-signing evidence for the
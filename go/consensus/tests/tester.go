@@ -74,6 +74,10 @@ func ConsensusImplementationTests(t *testing.T, backend consensus.ClientBackend)
 	_, err = backend.GetUnconfirmedTransactions(ctx)
 	require.NoError(err, "GetUnconfirmedTransactions")
 
+	feeStatsCh, feeStatsSub, err := backend.WatchFeeStatistics(ctx)
+	require.NoError(err, "WatchFeeStatistics")
+	defer feeStatsSub.Close()
+
 	blockCh, blockSub, err := backend.WatchBlocks(ctx)
 	require.NoError(err, "WatchBlocks")
 	defer blockSub.Close()
@@ -90,10 +94,25 @@ func ConsensusImplementationTests(t *testing.T, backend consensus.ClientBackend)
 		}
 	}
 
+	select {
+	case feeStats := <-feeStatsCh:
+		require.NotNil(feeStats, "returned fee statistics should not be nil")
+		require.True(feeStats.Height > 0, "fee statistics height should be greater than zero")
+	case <-time.After(recvTimeout):
+		t.Fatalf("failed to receive fee statistics")
+	}
+
 	epoch, err := backend.GetEpoch(ctx, consensus.HeightLatest)
 	require.NoError(err, "GetEpoch")
 	require.True(epoch > 0, "epoch height should be greater than zero")
 
+	epochHeight, err := backend.GetEpochBlock(ctx, epoch)
+	require.NoError(err, "GetEpochBlock")
+	require.True(epochHeight <= blk.Height, "epoch should start at or before the latest block")
+	startEpoch, err := backend.GetEpoch(ctx, epochHeight)
+	require.NoError(err, "GetEpoch(GetEpochBlock)")
+	require.Equal(epoch, startEpoch, "GetEpoch should return the epoch at its start height")
+
 	_, err = backend.EstimateGas(ctx, &consensus.EstimateGasRequest{
 		Signer:      memorySigner.NewTestSigner("estimate gas signer").Public(),
 		Transaction: transaction.NewTransaction(0, nil, staking.MethodTransfer, &staking.Transfer{}),
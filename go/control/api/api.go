@@ -11,9 +11,11 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/service"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	epochtime "github.com/oasisprotocol/oasis-core/go/epochtime/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 	commonWorker "github.com/oasisprotocol/oasis-core/go/worker/common/api"
@@ -50,6 +52,83 @@ type NodeController interface {
 
 	// GetStatus returns the current status overview of the node.
 	GetStatus(ctx context.Context) (*Status, error)
+
+	// GetSignatureContexts returns the list of all signature domain separation
+	// contexts registered by the node.
+	GetSignatureContexts(ctx context.Context) ([]signature.ContextInfo, error)
+
+	// CaptureDiagnostics captures a bundle of diagnostics (goroutine dump, profiles, recent logs,
+	// node status and database sizes) and writes it to a file in the node's data directory.
+	CaptureDiagnostics(ctx context.Context) (*DiagnosticsBundle, error)
+
+	// GetWatchedAddresses returns the list of staking account addresses for which the node retains
+	// full history regardless of consensus state and event pruning.
+	GetWatchedAddresses(ctx context.Context) ([]staking.Address, error)
+
+	// AddWatchedAddress adds a staking account address to the list of watched addresses.
+	AddWatchedAddress(ctx context.Context, addr staking.Address) error
+
+	// RemoveWatchedAddress removes a staking account address from the list of watched addresses.
+	//
+	// History retained for the address so far is kept.
+	RemoveWatchedAddress(ctx context.Context, addr staking.Address) error
+
+	// GetWatchedAccountHistory returns the retained history of a watched staking account.
+	GetWatchedAccountHistory(ctx context.Context, query *WatchedAccountHistoryQuery) ([]*staking.AccountHistoryEntry, error)
+
+	// GetConsensusPeers returns the overview of the node's consensus peers.
+	GetConsensusPeers(ctx context.Context) (*consensus.Peers, error)
+
+	// AddConsensusPersistentPeer adds a consensus persistent peer of the form ID@ip:port and dials it.
+	AddConsensusPersistentPeer(ctx context.Context, address string) error
+
+	// BanConsensusPeer disconnects the consensus peer with the given ID and rejects any further
+	// connections from or to the peer until the node is restarted or the peer is unbanned.
+	BanConsensusPeer(ctx context.Context, id string) error
+
+	// UnbanConsensusPeer removes the ban on the consensus peer with the given ID.
+	UnbanConsensusPeer(ctx context.Context, id string) error
+
+	// GetConsensusSignerStatus returns the status of the node's consensus signer.
+	GetConsensusSignerStatus(ctx context.Context) (*consensus.SignerStatus, error)
+
+	// ReleaseConsensusSigner puts the node's consensus signer into standby and returns a signed
+	// handoff that can be used to activate the consensus signer of another node that uses the
+	// same consensus key.
+	ReleaseConsensusSigner(ctx context.Context) (*consensus.SignedSignerHandoff, error)
+
+	// AcquireConsensusSigner activates the node's consensus signer using a signed handoff
+	// released by another node that uses the same consensus key.
+	AcquireConsensusSigner(ctx context.Context, handoff *consensus.SignedSignerHandoff) error
+
+	// GetCommitteePeerScores returns the overview of peer scores and bans in the runtime
+	// committee P2P network.
+	GetCommitteePeerScores(ctx context.Context) (*commonWorker.PeerScores, error)
+
+	// UnbanCommitteePeer removes the ban on the runtime committee P2P peer with the given libp2p
+	// ID and resets its penalty score.
+	UnbanCommitteePeer(ctx context.Context, id string) error
+}
+
+// WatchedAccountHistoryQuery is a watched staking account history query.
+type WatchedAccountHistoryQuery struct {
+	// Address is the address of the watched account.
+	Address staking.Address `json:"address"`
+
+	// FromHeight is the first height (inclusive) to return history for.
+	FromHeight int64 `json:"from_height,omitempty"`
+
+	// ToHeight is the last height (inclusive) to return history for. Zero means no upper bound.
+	ToHeight int64 `json:"to_height,omitempty"`
+}
+
+// DiagnosticsBundle is a captured diagnostics bundle.
+type DiagnosticsBundle struct {
+	// Path is the path of the bundle file on the node's filesystem.
+	Path string `json:"path"`
+
+	// Size is the size of the bundle file in bytes.
+	Size int64 `json:"size"`
 }
 
 // Status is the current status overview.
@@ -68,6 +147,9 @@ type Status struct {
 
 	// Registration is the node's registration status.
 	Registration RegistrationStatus `json:"registration"`
+
+	// Health is the aggregate health of the node's services.
+	Health service.Health `json:"health"`
 }
 
 // IdentityStatus is the current node identity status, listing all the public keys that identify
@@ -139,8 +221,32 @@ type ControlledNode interface {
 
 	// GetRuntimeStatus returns the node's current per-runtime status.
 	GetRuntimeStatus(ctx context.Context) (map[common.Namespace]RuntimeStatus, error)
+
+	// GetHealth returns the aggregate health of the node's services.
+	GetHealth() *service.Health
+
+	// GetDataDir returns the node's data directory.
+	GetDataDir() string
+
+	// GetCommitteePeerScores returns the overview of peer scores and bans in the runtime
+	// committee P2P network.
+	GetCommitteePeerScores() (*commonWorker.PeerScores, error)
+
+	// UnbanCommitteePeer removes the ban on the runtime committee P2P peer with the given ID.
+	UnbanCommitteePeer(id string) error
 }
 
+// ModuleName is the module name for the node controller service.
+const ModuleName = "control"
+
+// ErrAccountWatcherUnavailable is the error raised when the staking account watcher is not
+// available (e.g., because the consensus backend does not support consensus services).
+var ErrAccountWatcherUnavailable = errors.New(ModuleName, 1, "control: staking account watcher not available")
+
+// ErrCommitteeP2PUnavailable is the error raised when the runtime committee P2P network is not
+// available (e.g., because the node is not a runtime committee node).
+var ErrCommitteeP2PUnavailable = errors.New(ModuleName, 2, "control: committee P2P not available")
+
 // DebugModuleName is the module name for the debug controller service.
 const DebugModuleName = "control/debug"
 
@@ -5,8 +5,12 @@ import (
 
 	"google.golang.org/grpc"
 
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	upgradeApi "github.com/oasisprotocol/oasis-core/go/upgrade/api"
+	commonWorker "github.com/oasisprotocol/oasis-core/go/worker/common/api"
 )
 
 var (
@@ -29,6 +33,36 @@ var (
 	methodCancelUpgrade = serviceName.NewMethod("CancelUpgrade", nil)
 	// methodGetStatus is the GetStatus method.
 	methodGetStatus = serviceName.NewMethod("GetStatus", nil)
+	// methodGetSignatureContexts is the GetSignatureContexts method.
+	methodGetSignatureContexts = serviceName.NewMethod("GetSignatureContexts", nil)
+	// methodCaptureDiagnostics is the CaptureDiagnostics method.
+	methodCaptureDiagnostics = serviceName.NewMethod("CaptureDiagnostics", nil)
+	// methodGetWatchedAddresses is the GetWatchedAddresses method.
+	methodGetWatchedAddresses = serviceName.NewMethod("GetWatchedAddresses", nil)
+	// methodAddWatchedAddress is the AddWatchedAddress method.
+	methodAddWatchedAddress = serviceName.NewMethod("AddWatchedAddress", staking.Address{})
+	// methodRemoveWatchedAddress is the RemoveWatchedAddress method.
+	methodRemoveWatchedAddress = serviceName.NewMethod("RemoveWatchedAddress", staking.Address{})
+	// methodGetWatchedAccountHistory is the GetWatchedAccountHistory method.
+	methodGetWatchedAccountHistory = serviceName.NewMethod("GetWatchedAccountHistory", WatchedAccountHistoryQuery{})
+	// methodGetConsensusPeers is the GetConsensusPeers method.
+	methodGetConsensusPeers = serviceName.NewMethod("GetConsensusPeers", nil)
+	// methodAddConsensusPersistentPeer is the AddConsensusPersistentPeer method.
+	methodAddConsensusPersistentPeer = serviceName.NewMethod("AddConsensusPersistentPeer", "")
+	// methodBanConsensusPeer is the BanConsensusPeer method.
+	methodBanConsensusPeer = serviceName.NewMethod("BanConsensusPeer", "")
+	// methodUnbanConsensusPeer is the UnbanConsensusPeer method.
+	methodUnbanConsensusPeer = serviceName.NewMethod("UnbanConsensusPeer", "")
+	// methodGetConsensusSignerStatus is the GetConsensusSignerStatus method.
+	methodGetConsensusSignerStatus = serviceName.NewMethod("GetConsensusSignerStatus", nil)
+	// methodReleaseConsensusSigner is the ReleaseConsensusSigner method.
+	methodReleaseConsensusSigner = serviceName.NewMethod("ReleaseConsensusSigner", nil)
+	// methodAcquireConsensusSigner is the AcquireConsensusSigner method.
+	methodAcquireConsensusSigner = serviceName.NewMethod("AcquireConsensusSigner", consensus.SignedSignerHandoff{})
+	// methodGetCommitteePeerScores is the GetCommitteePeerScores method.
+	methodGetCommitteePeerScores = serviceName.NewMethod("GetCommitteePeerScores", nil)
+	// methodUnbanCommitteePeer is the UnbanCommitteePeer method.
+	methodUnbanCommitteePeer = serviceName.NewMethod("UnbanCommitteePeer", "")
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -67,6 +101,66 @@ var (
 				MethodName: methodGetStatus.ShortName(),
 				Handler:    handlerGetStatus,
 			},
+			{
+				MethodName: methodGetSignatureContexts.ShortName(),
+				Handler:    handlerGetSignatureContexts,
+			},
+			{
+				MethodName: methodCaptureDiagnostics.ShortName(),
+				Handler:    handlerCaptureDiagnostics,
+			},
+			{
+				MethodName: methodGetWatchedAddresses.ShortName(),
+				Handler:    handlerGetWatchedAddresses,
+			},
+			{
+				MethodName: methodAddWatchedAddress.ShortName(),
+				Handler:    handlerAddWatchedAddress,
+			},
+			{
+				MethodName: methodRemoveWatchedAddress.ShortName(),
+				Handler:    handlerRemoveWatchedAddress,
+			},
+			{
+				MethodName: methodGetWatchedAccountHistory.ShortName(),
+				Handler:    handlerGetWatchedAccountHistory,
+			},
+			{
+				MethodName: methodGetConsensusPeers.ShortName(),
+				Handler:    handlerGetConsensusPeers,
+			},
+			{
+				MethodName: methodAddConsensusPersistentPeer.ShortName(),
+				Handler:    handlerAddConsensusPersistentPeer,
+			},
+			{
+				MethodName: methodBanConsensusPeer.ShortName(),
+				Handler:    handlerBanConsensusPeer,
+			},
+			{
+				MethodName: methodUnbanConsensusPeer.ShortName(),
+				Handler:    handlerUnbanConsensusPeer,
+			},
+			{
+				MethodName: methodGetConsensusSignerStatus.ShortName(),
+				Handler:    handlerGetConsensusSignerStatus,
+			},
+			{
+				MethodName: methodReleaseConsensusSigner.ShortName(),
+				Handler:    handlerReleaseConsensusSigner,
+			},
+			{
+				MethodName: methodAcquireConsensusSigner.ShortName(),
+				Handler:    handlerAcquireConsensusSigner,
+			},
+			{
+				MethodName: methodGetCommitteePeerScores.ShortName(),
+				Handler:    handlerGetCommitteePeerScores,
+			},
+			{
+				MethodName: methodUnbanCommitteePeer.ShortName(),
+				Handler:    handlerUnbanCommitteePeer,
+			},
 		},
 		Streams: []grpc.StreamDesc{},
 	}
@@ -232,6 +326,323 @@ func handlerGetStatus( // nolint: golint
 	return interceptor(ctx, nil, info, handler)
 }
 
+func handlerGetSignatureContexts( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return srv.(NodeController).GetSignatureContexts(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetSignatureContexts.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).GetSignatureContexts(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
+func handlerCaptureDiagnostics( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return srv.(NodeController).CaptureDiagnostics(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodCaptureDiagnostics.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).CaptureDiagnostics(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
+func handlerGetWatchedAddresses( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return srv.(NodeController).GetWatchedAddresses(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetWatchedAddresses.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).GetWatchedAddresses(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
+func handlerAddWatchedAddress( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var addr staking.Address
+	if err := dec(&addr); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(NodeController).AddWatchedAddress(ctx, addr)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodAddWatchedAddress.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(NodeController).AddWatchedAddress(ctx, *req.(*staking.Address))
+	}
+	return interceptor(ctx, &addr, info, handler)
+}
+
+func handlerRemoveWatchedAddress( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var addr staking.Address
+	if err := dec(&addr); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(NodeController).RemoveWatchedAddress(ctx, addr)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodRemoveWatchedAddress.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(NodeController).RemoveWatchedAddress(ctx, *req.(*staking.Address))
+	}
+	return interceptor(ctx, &addr, info, handler)
+}
+
+func handlerGetWatchedAccountHistory( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query WatchedAccountHistoryQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeController).GetWatchedAccountHistory(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetWatchedAccountHistory.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).GetWatchedAccountHistory(ctx, req.(*WatchedAccountHistoryQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
+func handlerGetConsensusPeers( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return srv.(NodeController).GetConsensusPeers(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetConsensusPeers.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).GetConsensusPeers(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
+func handlerAddConsensusPersistentPeer( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var address string
+	if err := dec(&address); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(NodeController).AddConsensusPersistentPeer(ctx, address)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodAddConsensusPersistentPeer.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(NodeController).AddConsensusPersistentPeer(ctx, *req.(*string))
+	}
+	return interceptor(ctx, &address, info, handler)
+}
+
+func handlerBanConsensusPeer( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var id string
+	if err := dec(&id); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(NodeController).BanConsensusPeer(ctx, id)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodBanConsensusPeer.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(NodeController).BanConsensusPeer(ctx, *req.(*string))
+	}
+	return interceptor(ctx, &id, info, handler)
+}
+
+func handlerUnbanConsensusPeer( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var id string
+	if err := dec(&id); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(NodeController).UnbanConsensusPeer(ctx, id)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodUnbanConsensusPeer.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(NodeController).UnbanConsensusPeer(ctx, *req.(*string))
+	}
+	return interceptor(ctx, &id, info, handler)
+}
+
+func handlerGetConsensusSignerStatus( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return srv.(NodeController).GetConsensusSignerStatus(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetConsensusSignerStatus.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).GetConsensusSignerStatus(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
+func handlerReleaseConsensusSigner( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return srv.(NodeController).ReleaseConsensusSigner(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodReleaseConsensusSigner.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).ReleaseConsensusSigner(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
+func handlerAcquireConsensusSigner( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var handoff consensus.SignedSignerHandoff
+	if err := dec(&handoff); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(NodeController).AcquireConsensusSigner(ctx, &handoff)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodAcquireConsensusSigner.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(NodeController).AcquireConsensusSigner(ctx, req.(*consensus.SignedSignerHandoff))
+	}
+	return interceptor(ctx, &handoff, info, handler)
+}
+
+func handlerGetCommitteePeerScores( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return srv.(NodeController).GetCommitteePeerScores(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetCommitteePeerScores.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).GetCommitteePeerScores(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
+func handlerUnbanCommitteePeer( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var id string
+	if err := dec(&id); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(NodeController).UnbanCommitteePeer(ctx, id)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodUnbanCommitteePeer.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(NodeController).UnbanCommitteePeer(ctx, *req.(*string))
+	}
+	return interceptor(ctx, &id, info, handler)
+}
+
 // RegisterService registers a new node controller service with the given gRPC server.
 func RegisterService(server *grpc.Server, service NodeController) {
 	server.RegisterService(&serviceDesc, service)
@@ -285,6 +696,98 @@ func (c *nodeControllerClient) GetStatus(ctx context.Context) (*Status, error) {
 	return &rsp, nil
 }
 
+func (c *nodeControllerClient) GetSignatureContexts(ctx context.Context) ([]signature.ContextInfo, error) {
+	var rsp []signature.ContextInfo
+	if err := c.conn.Invoke(ctx, methodGetSignatureContexts.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *nodeControllerClient) CaptureDiagnostics(ctx context.Context) (*DiagnosticsBundle, error) {
+	var rsp DiagnosticsBundle
+	if err := c.conn.Invoke(ctx, methodCaptureDiagnostics.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *nodeControllerClient) GetWatchedAddresses(ctx context.Context) ([]staking.Address, error) {
+	var rsp []staking.Address
+	if err := c.conn.Invoke(ctx, methodGetWatchedAddresses.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *nodeControllerClient) AddWatchedAddress(ctx context.Context, addr staking.Address) error {
+	return c.conn.Invoke(ctx, methodAddWatchedAddress.FullName(), addr, nil)
+}
+
+func (c *nodeControllerClient) RemoveWatchedAddress(ctx context.Context, addr staking.Address) error {
+	return c.conn.Invoke(ctx, methodRemoveWatchedAddress.FullName(), addr, nil)
+}
+
+func (c *nodeControllerClient) GetWatchedAccountHistory(ctx context.Context, query *WatchedAccountHistoryQuery) ([]*staking.AccountHistoryEntry, error) {
+	var rsp []*staking.AccountHistoryEntry
+	if err := c.conn.Invoke(ctx, methodGetWatchedAccountHistory.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *nodeControllerClient) GetConsensusPeers(ctx context.Context) (*consensus.Peers, error) {
+	var rsp consensus.Peers
+	if err := c.conn.Invoke(ctx, methodGetConsensusPeers.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *nodeControllerClient) AddConsensusPersistentPeer(ctx context.Context, address string) error {
+	return c.conn.Invoke(ctx, methodAddConsensusPersistentPeer.FullName(), address, nil)
+}
+
+func (c *nodeControllerClient) BanConsensusPeer(ctx context.Context, id string) error {
+	return c.conn.Invoke(ctx, methodBanConsensusPeer.FullName(), id, nil)
+}
+
+func (c *nodeControllerClient) UnbanConsensusPeer(ctx context.Context, id string) error {
+	return c.conn.Invoke(ctx, methodUnbanConsensusPeer.FullName(), id, nil)
+}
+
+func (c *nodeControllerClient) GetConsensusSignerStatus(ctx context.Context) (*consensus.SignerStatus, error) {
+	var rsp consensus.SignerStatus
+	if err := c.conn.Invoke(ctx, methodGetConsensusSignerStatus.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *nodeControllerClient) ReleaseConsensusSigner(ctx context.Context) (*consensus.SignedSignerHandoff, error) {
+	var rsp consensus.SignedSignerHandoff
+	if err := c.conn.Invoke(ctx, methodReleaseConsensusSigner.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *nodeControllerClient) AcquireConsensusSigner(ctx context.Context, handoff *consensus.SignedSignerHandoff) error {
+	return c.conn.Invoke(ctx, methodAcquireConsensusSigner.FullName(), handoff, nil)
+}
+
+func (c *nodeControllerClient) GetCommitteePeerScores(ctx context.Context) (*commonWorker.PeerScores, error) {
+	var rsp commonWorker.PeerScores
+	if err := c.conn.Invoke(ctx, methodGetCommitteePeerScores.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *nodeControllerClient) UnbanCommitteePeer(ctx context.Context, id string) error {
+	return c.conn.Invoke(ctx, methodUnbanCommitteePeer.FullName(), id, nil)
+}
+
 // NewNodeControllerClient creates a new gRPC node controller client service.
 func NewNodeControllerClient(c *grpc.ClientConn) NodeController {
 	return &nodeControllerClient{c}
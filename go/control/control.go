@@ -5,16 +5,21 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	control "github.com/oasisprotocol/oasis-core/go/control/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/staking/watcher"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
+	commonWorker "github.com/oasisprotocol/oasis-core/go/worker/common/api"
 )
 
 type nodeController struct {
 	node      control.ControlledNode
 	consensus consensus.Backend
 	upgrader  upgrade.Backend
+	watcher   *watcher.Watcher
 }
 
 func (c *nodeController) RequestShutdown(ctx context.Context, wait bool) error {
@@ -110,14 +115,94 @@ func (c *nodeController) GetStatus(ctx context.Context) (*control.Status, error)
 		Consensus:    *cs,
 		Runtimes:     runtimes,
 		Registration: *rs,
+		Health:       *c.node.GetHealth(),
 	}, nil
 }
 
+func (c *nodeController) GetSignatureContexts(ctx context.Context) ([]signature.ContextInfo, error) {
+	return signature.ListContexts(), nil
+}
+
+func (c *nodeController) GetWatchedAddresses(ctx context.Context) ([]staking.Address, error) {
+	if c.watcher == nil {
+		return nil, control.ErrAccountWatcherUnavailable
+	}
+	return c.watcher.Addresses(), nil
+}
+
+func (c *nodeController) AddWatchedAddress(ctx context.Context, addr staking.Address) error {
+	if c.watcher == nil {
+		return control.ErrAccountWatcherUnavailable
+	}
+	return c.watcher.AddAddress(ctx, addr)
+}
+
+func (c *nodeController) RemoveWatchedAddress(ctx context.Context, addr staking.Address) error {
+	if c.watcher == nil {
+		return control.ErrAccountWatcherUnavailable
+	}
+	return c.watcher.RemoveAddress(ctx, addr)
+}
+
+func (c *nodeController) GetWatchedAccountHistory(
+	ctx context.Context,
+	query *control.WatchedAccountHistoryQuery,
+) ([]*staking.AccountHistoryEntry, error) {
+	if c.watcher == nil {
+		return nil, control.ErrAccountWatcherUnavailable
+	}
+	return c.watcher.GetHistory(ctx, query.Address, query.FromHeight, query.ToHeight)
+}
+
+func (c *nodeController) GetConsensusPeers(ctx context.Context) (*consensus.Peers, error) {
+	return c.consensus.GetPeers(ctx)
+}
+
+func (c *nodeController) AddConsensusPersistentPeer(ctx context.Context, address string) error {
+	return c.consensus.AddPersistentPeer(ctx, address)
+}
+
+func (c *nodeController) BanConsensusPeer(ctx context.Context, id string) error {
+	return c.consensus.BanPeer(ctx, id)
+}
+
+func (c *nodeController) UnbanConsensusPeer(ctx context.Context, id string) error {
+	return c.consensus.UnbanPeer(ctx, id)
+}
+
+func (c *nodeController) GetConsensusSignerStatus(ctx context.Context) (*consensus.SignerStatus, error) {
+	return c.consensus.GetSignerStatus(ctx)
+}
+
+func (c *nodeController) ReleaseConsensusSigner(ctx context.Context) (*consensus.SignedSignerHandoff, error) {
+	return c.consensus.ReleaseSigner(ctx)
+}
+
+func (c *nodeController) AcquireConsensusSigner(ctx context.Context, handoff *consensus.SignedSignerHandoff) error {
+	return c.consensus.AcquireSigner(ctx, handoff)
+}
+
+func (c *nodeController) GetCommitteePeerScores(ctx context.Context) (*commonWorker.PeerScores, error) {
+	return c.node.GetCommitteePeerScores()
+}
+
+func (c *nodeController) UnbanCommitteePeer(ctx context.Context, id string) error {
+	return c.node.UnbanCommitteePeer(id)
+}
+
 // New creates a new oasis-node controller.
-func New(node control.ControlledNode, consensus consensus.Backend, upgrader upgrade.Backend) control.NodeController {
+//
+// The staking account watcher may be nil in case it is not available.
+func New(
+	node control.ControlledNode,
+	consensus consensus.Backend,
+	upgrader upgrade.Backend,
+	watcher *watcher.Watcher,
+) control.NodeController {
 	return &nodeController{
 		node:      node,
 		consensus: consensus,
 		upgrader:  upgrader,
+		watcher:   watcher,
 	}
 }
@@ -33,7 +33,7 @@ func (c *debugController) WaitNodesRegistered(ctx context.Context, count int) er
 
 	// Check if there is already enough nodes registered. Note that this request may
 	// fail if there is nothing committed yet, so ignore the error.
-	nodes, err := c.registry.GetNodes(ctx, 0)
+	nodes, err := c.registry.GetNodes(ctx, &registry.GetNodesQuery{Height: consensus.HeightLatest})
 	if err == nil {
 		if len(nodes) >= count {
 			return nil
@@ -46,7 +46,7 @@ Loop:
 		select {
 		case ev := <-ch:
 			if ev.IsRegistration {
-				nodes, err = c.registry.GetNodes(ctx, 0)
+				nodes, err = c.registry.GetNodes(ctx, &registry.GetNodesQuery{Height: consensus.HeightLatest})
 				if err != nil {
 					return err
 				}
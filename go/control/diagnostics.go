@@ -0,0 +1,197 @@
+package control
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	control "github.com/oasisprotocol/oasis-core/go/control/api"
+)
+
+const (
+	// diagnosticsDir is the name of the data directory subdirectory that
+	// diagnostics bundles are written to.
+	diagnosticsDir = "diagnostics"
+
+	diagnosticsDirPerm  = 0o700
+	diagnosticsFilePerm = 0o600
+)
+
+// diagnosticsProfiles are the runtime profiles included in diagnostics bundles.
+var diagnosticsProfiles = []string{"heap", "mutex", "block"}
+
+type runtimeInfo struct {
+	SoftwareVersion string           `json:"software_version"`
+	GoVersion       string           `json:"go_version"`
+	NumCPU          int              `json:"num_cpu"`
+	NumGoroutine    int              `json:"num_goroutine"`
+	MemStats        runtime.MemStats `json:"mem_stats"`
+}
+
+type diagnosticsWriter struct {
+	tw *tar.Writer
+	ts time.Time
+}
+
+func (w *diagnosticsWriter) add(name string, data []byte) error {
+	if err := w.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    diagnosticsFilePerm,
+		Size:    int64(len(data)),
+		ModTime: w.ts,
+	}); err != nil {
+		return err
+	}
+	_, err := w.tw.Write(data)
+	return err
+}
+
+func (w *diagnosticsWriter) addJSON(name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return w.add(name, data)
+}
+
+// dataDirSizes returns the on-disk size of each top-level entry of the data
+// directory, skipping the diagnostics directory itself.
+func dataDirSizes(dataDir string) (map[string]int64, error) {
+	entries, err := ioutil.ReadDir(dataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	sizes := make(map[string]int64)
+	for _, entry := range entries {
+		if entry.Name() == diagnosticsDir {
+			continue
+		}
+
+		var size int64
+		err = filepath.Walk(filepath.Join(dataDir, entry.Name()), func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				// Files may disappear while walking, ignore.
+				return nil
+			}
+			if info.Mode().IsRegular() {
+				size += info.Size()
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		sizes[entry.Name()] = size
+	}
+	return sizes, nil
+}
+
+func (c *nodeController) writeDiagnostics(ctx context.Context, w *diagnosticsWriter) error {
+	// Goroutine dump.
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 2); err != nil {
+		return fmt.Errorf("failed to dump goroutines: %w", err)
+	}
+	if err := w.add("goroutines.txt", buf.Bytes()); err != nil {
+		return err
+	}
+
+	// Profiles.
+	runtime.GC()
+	for _, name := range diagnosticsProfiles {
+		buf.Reset()
+		if err := pprof.Lookup(name).WriteTo(&buf, 0); err != nil {
+			return fmt.Errorf("failed to write %s profile: %w", name, err)
+		}
+		if err := w.add(name+".pb.gz", buf.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	// Runtime information.
+	ri := runtimeInfo{
+		SoftwareVersion: version.SoftwareVersion,
+		GoVersion:       runtime.Version(),
+		NumCPU:          runtime.NumCPU(),
+		NumGoroutine:    runtime.NumGoroutine(),
+	}
+	runtime.ReadMemStats(&ri.MemStats)
+	if err := w.addJSON("runtime.json", &ri); err != nil {
+		return err
+	}
+
+	// Recent logs.
+	if err := w.add("logs.txt", logging.RecentLogs()); err != nil {
+		return err
+	}
+
+	// Node status. The node may be stuck, so failing to obtain the status
+	// should not prevent the rest of the diagnostics from being collected.
+	status, err := c.GetStatus(ctx)
+	if err != nil {
+		if err = w.add("status.err", []byte(err.Error())); err != nil {
+			return err
+		}
+	} else if err = w.addJSON("status.json", status); err != nil {
+		return err
+	}
+
+	// Database sizes.
+	sizes, err := dataDirSizes(c.node.GetDataDir())
+	if err != nil {
+		return fmt.Errorf("failed to determine database sizes: %w", err)
+	}
+	return w.addJSON("database_sizes.json", sizes)
+}
+
+func (c *nodeController) CaptureDiagnostics(ctx context.Context) (*control.DiagnosticsBundle, error) {
+	dir := filepath.Join(c.node.GetDataDir(), diagnosticsDir)
+	if err := os.MkdirAll(dir, diagnosticsDirPerm); err != nil {
+		return nil, fmt.Errorf("failed to create diagnostics directory: %w", err)
+	}
+
+	ts := time.Now().UTC()
+	path := filepath.Join(dir, fmt.Sprintf("diagnostics-%s.tar.gz", ts.Format("20060102T150405Z")))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, diagnosticsFilePerm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create diagnostics bundle: %w", err)
+	}
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	if err = c.writeDiagnostics(ctx, &diagnosticsWriter{tw: tw, ts: ts}); err != nil {
+		_ = os.Remove(path)
+		return nil, err
+	}
+	if err = tw.Close(); err != nil {
+		_ = os.Remove(path)
+		return nil, fmt.Errorf("failed to write diagnostics bundle: %w", err)
+	}
+	if err = gw.Close(); err != nil {
+		_ = os.Remove(path)
+		return nil, fmt.Errorf("failed to write diagnostics bundle: %w", err)
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat diagnostics bundle: %w", err)
+	}
+
+	return &control.DiagnosticsBundle{
+		Path: path,
+		Size: fi.Size(),
+	}, nil
+}
@@ -67,6 +67,17 @@ func EpochtimeSetableImplementationTest(t *testing.T, backend api.Backend) {
 	e, err = timeSource.GetEpoch(context.Background(), consensus.HeightLatest)
 	require.NoError(err, "GetEpoch after set")
 	require.Equal(epoch, e, "GetEpoch after set, epoch")
+
+	height, err := timeSource.GetEpochBlock(context.Background(), epoch)
+	require.NoError(err, "GetEpochBlock after set")
+	e, err = timeSource.GetEpoch(context.Background(), height)
+	require.NoError(err, "GetEpoch(GetEpochBlock) after set")
+	require.Equal(epoch, e, "GetEpoch at the epoch start height")
+
+	// Start heights of previous epochs should remain available.
+	prevHeight, err := timeSource.GetEpochBlock(context.Background(), epoch-1)
+	require.NoError(err, "GetEpochBlock for previous epoch")
+	require.True(prevHeight < height, "previous epoch should start before the current one")
 }
 
 // MustAdvanceEpoch advances the epoch by the specified increment, and returns
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 )
 
 // SanityCheck does basic sanity checking on the contents of the genesis document.
@@ -34,6 +36,9 @@ func (d *Document) SanityCheck() error {
 	if err := d.RootHash.SanityCheck(); err != nil {
 		return err
 	}
+	if err := d.sanityCheckRuntimeMessages(); err != nil {
+		return err
+	}
 	if err := d.Staking.SanityCheck(d.EpochTime.Base); err != nil {
 		return err
 	}
@@ -53,3 +58,25 @@ func (d *Document) SanityCheck() error {
 
 	return nil
 }
+
+// sanityCheckRuntimeMessages checks that no runtime is allowed to emit more runtime messages
+// than permitted by the roothash consensus parameters.
+func (d *Document) sanityCheckRuntimeMessages() error {
+	maxMessages := d.RootHash.Parameters.MaxRuntimeMessages
+	for _, sigRts := range [][]*registry.SignedRuntime{d.Registry.Runtimes, d.Registry.SuspendedRuntimes} {
+		for _, sigRt := range sigRts {
+			var rt registry.Runtime
+			if err := cbor.Unmarshal(sigRt.Blob, &rt); err != nil {
+				return fmt.Errorf("genesis: sanity check failed: malformed runtime descriptor: %w", err)
+			}
+			if rt.Executor.MaxMessages > maxMessages {
+				return fmt.Errorf("genesis: sanity check failed: runtime %s allows too many runtime messages (%d > %d)",
+					rt.ID,
+					rt.Executor.MaxMessages,
+					maxMessages,
+				)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package migrations
+
+import (
+	"reflect"
+	"sort"
+)
+
+// ChangeKind is the kind of a change between two genesis documents.
+type ChangeKind string
+
+const (
+	// ChangeAdded is a field that is only present in the new document.
+	ChangeAdded ChangeKind = "+"
+	// ChangeRemoved is a field that is only present in the old document.
+	ChangeRemoved ChangeKind = "-"
+	// ChangeModified is a field whose value differs between documents.
+	ChangeModified ChangeKind = "~"
+)
+
+// Change is a single change between two genesis documents.
+type Change struct {
+	// Kind is the kind of change.
+	Kind ChangeKind
+	// Path is the dot-separated path of the changed field.
+	Path string
+}
+
+// Diff returns the changes between two genesis documents, sorted by path.
+//
+// Objects are compared field by field while all other values (including
+// arrays) are compared as a whole.
+func Diff(old, migrated Document) []Change {
+	var changes []Change
+	diffObjects("", old, migrated, &changes)
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+func diffObjects(prefix string, old, migrated map[string]interface{}, changes *[]Change) {
+	for k, ov := range old {
+		path := prefix + k
+		nv, ok := migrated[k]
+		if !ok {
+			*changes = append(*changes, Change{Kind: ChangeRemoved, Path: path})
+			continue
+		}
+
+		oo, oIsObj := ov.(map[string]interface{})
+		no, nIsObj := nv.(map[string]interface{})
+		switch {
+		case oIsObj && nIsObj:
+			diffObjects(path+".", oo, no, changes)
+		case !reflect.DeepEqual(ov, nv):
+			*changes = append(*changes, Change{Kind: ChangeModified, Path: path})
+		}
+	}
+	for k := range migrated {
+		if _, ok := old[k]; !ok {
+			*changes = append(*changes, Change{Kind: ChangeAdded, Path: prefix + k})
+		}
+	}
+}
@@ -0,0 +1,246 @@
+// Package migrations implements genesis document migrations between
+// consensus protocol versions.
+package migrations
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+)
+
+var (
+	logger = logging.GetLogger("genesis/migrations")
+
+	defaultRegistry = NewRegistry()
+)
+
+// Document is a generic (untyped) genesis document.
+//
+// Migrations operate on untyped documents as documents of older versions
+// can generally not be decoded into the current genesis document type.
+type Document map[string]interface{}
+
+// Module returns the section of the genesis document belonging to the given
+// module, creating it in case it does not yet exist.
+func (d Document) Module(name string) (map[string]interface{}, error) {
+	raw, ok := d[name]
+	if !ok {
+		section := make(map[string]interface{})
+		d[name] = section
+		return section, nil
+	}
+	section, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("genesis/migrations: module '%s' is not an object", name)
+	}
+	return section, nil
+}
+
+// Clone returns a deep copy of the document.
+func (d Document) Clone() (Document, error) {
+	raw, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeDocument(raw)
+}
+
+// DecodeDocument decodes a JSON-encoded genesis document.
+func DecodeDocument(raw []byte) (Document, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	// Make sure that large numbers are preserved as-is.
+	dec.UseNumber()
+
+	var doc Document
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("genesis/migrations: malformed genesis document: %w", err)
+	}
+	if doc == nil {
+		return nil, fmt.Errorf("genesis/migrations: malformed genesis document: not an object")
+	}
+	return doc, nil
+}
+
+// ToGenesis converts the generic document into a genesis document of the
+// current version.
+func (d Document) ToGenesis() (*genesis.Document, error) {
+	raw, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+
+	var doc genesis.Document
+	if err = dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("genesis/migrations: failed to decode genesis document: %w", err)
+	}
+	return &doc, nil
+}
+
+// Step is a single migration step of a given module.
+type Step struct {
+	// Module is the name of the genesis document section (e.g., "staking")
+	// that the step migrates.
+	Module string
+	// Description is a human readable description of the step.
+	Description string
+
+	// Apply performs the migration in-place.
+	Apply func(doc Document) error
+	// Validate optionally validates the document after the step has been
+	// applied.
+	Validate func(doc Document) error
+}
+
+// Migration is a set of migration steps that migrate a genesis document
+// between two consensus protocol versions.
+type Migration struct {
+	// From is the consensus protocol version that the migration applies to.
+	From version.Version
+	// To is the consensus protocol version of the migrated document.
+	To version.Version
+	// Steps are the migration steps, applied in order.
+	Steps []Step
+}
+
+// Registry is a registry of genesis document migrations.
+type Registry struct {
+	migrations map[version.Version]*Migration
+}
+
+// Register registers a new migration.
+func (r *Registry) Register(m *Migration) error {
+	if m.From.ToU64() >= m.To.ToU64() {
+		return fmt.Errorf("genesis/migrations: migration must go from an older to a newer version")
+	}
+	if _, exists := r.migrations[m.From]; exists {
+		return fmt.Errorf("genesis/migrations: migration from version %s already registered", m.From)
+	}
+	for _, step := range m.Steps {
+		if step.Module == "" || step.Apply == nil {
+			return fmt.Errorf("genesis/migrations: malformed migration step")
+		}
+	}
+
+	r.migrations[m.From] = m
+	return nil
+}
+
+// Path returns the sequence of migrations needed to migrate a document from
+// the given version to the target version.
+func (r *Registry) Path(from, to version.Version) ([]*Migration, error) {
+	var path []*Migration
+	for current := from; current != to; {
+		m, ok := r.migrations[current]
+		if !ok || m.To.ToU64() > to.ToU64() {
+			return nil, fmt.Errorf("genesis/migrations: no migration path from version %s to %s", from, to)
+		}
+		path = append(path, m)
+		current = m.To
+	}
+	return path, nil
+}
+
+// Migrate migrates the document from the given version to the target version,
+// returning the migrated document. The passed document is not modified.
+//
+// Step-specific validation is performed after each step. In case the target
+// version is the current consensus protocol version, the migrated document is
+// additionally sanity checked.
+func (r *Registry) Migrate(doc Document, from, to version.Version) (Document, error) {
+	path, err := r.Path(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if doc, err = doc.Clone(); err != nil {
+		return nil, err
+	}
+	for _, m := range path {
+		for i, step := range m.Steps {
+			logger.Info("applying migration step",
+				"from", m.From,
+				"to", m.To,
+				"module", step.Module,
+				"description", step.Description,
+			)
+
+			if err = step.Apply(doc); err != nil {
+				return nil, fmt.Errorf("genesis/migrations: %s -> %s: step %d (%s) failed: %w", m.From, m.To, i, step.Module, err)
+			}
+			if _, err = doc.Module(step.Module); err != nil {
+				return nil, fmt.Errorf("genesis/migrations: %s -> %s: step %d (%s) produced a malformed document: %w", m.From, m.To, i, step.Module, err)
+			}
+			if step.Validate != nil {
+				if err = step.Validate(doc); err != nil {
+					return nil, fmt.Errorf("genesis/migrations: %s -> %s: step %d (%s) validation failed: %w", m.From, m.To, i, step.Module, err)
+				}
+			}
+		}
+	}
+
+	if to.MaskNonMajor() == version.ConsensusProtocol.MaskNonMajor() {
+		var gen *genesis.Document
+		if gen, err = doc.ToGenesis(); err != nil {
+			return nil, err
+		}
+		if err = gen.SanityCheck(); err != nil {
+			return nil, fmt.Errorf("genesis/migrations: migrated document sanity check failed: %w", err)
+		}
+	}
+
+	return doc, nil
+}
+
+// NewRegistry creates a new empty migration registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		migrations: make(map[version.Version]*Migration),
+	}
+}
+
+// Register registers a new migration in the default registry.
+//
+// This method panics in case the migration is malformed or a migration
+// from the same version has already been registered.
+func Register(m *Migration) {
+	if err := defaultRegistry.Register(m); err != nil {
+		panic(err)
+	}
+}
+
+// Migrate migrates the document using the default registry.
+func Migrate(doc Document, from, to version.Version) (Document, error) {
+	return defaultRegistry.Migrate(doc, from, to)
+}
+
+// ParseVersion parses a version of the form "major.minor.patch", where the
+// minor and patch components are optional.
+func ParseVersion(s string) (version.Version, error) {
+	var v version.Version
+
+	parts := strings.Split(strings.TrimPrefix(s, "v"), ".")
+	if len(parts) > 3 {
+		return v, fmt.Errorf("genesis/migrations: malformed version: '%s'", s)
+	}
+
+	var components [3]uint16
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 16)
+		if err != nil {
+			return v, fmt.Errorf("genesis/migrations: malformed version: '%s'", s)
+		}
+		components[i] = uint16(n)
+	}
+
+	v.Major, v.Minor, v.Patch = components[0], components[1], components[2]
+	return v, nil
+}
@@ -0,0 +1,188 @@
+package migrations
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+)
+
+var (
+	testV1 = version.Version{Major: 0, Minor: 1}
+	testV2 = version.Version{Major: 0, Minor: 2}
+	testV3 = version.Version{Major: 0, Minor: 3}
+)
+
+const testDocument = `{
+	"height": 1,
+	"chain_id": "test",
+	"staking": {
+		"params": {
+			"debonding_interval": 10
+		},
+		"total_supply": "100000000000000000000000"
+	},
+	"registry": {
+		"entities": []
+	}
+}`
+
+func newTestRegistry(t *testing.T) *Registry {
+	require := require.New(t)
+
+	r := NewRegistry()
+	err := r.Register(&Migration{
+		From: testV1,
+		To:   testV2,
+		Steps: []Step{
+			{
+				Module:      "staking",
+				Description: "rename params to parameters",
+				Apply: func(doc Document) error {
+					staking, err := doc.Module("staking")
+					if err != nil {
+						return err
+					}
+					staking["parameters"] = staking["params"]
+					delete(staking, "params")
+					return nil
+				},
+				Validate: func(doc Document) error {
+					staking, _ := doc.Module("staking")
+					if staking["parameters"] == nil {
+						return fmt.Errorf("missing parameters")
+					}
+					return nil
+				},
+			},
+		},
+	})
+	require.NoError(err, "Register")
+	err = r.Register(&Migration{
+		From: testV2,
+		To:   testV3,
+		Steps: []Step{
+			{
+				Module:      "beacon",
+				Description: "add beacon parameters",
+				Apply: func(doc Document) error {
+					beacon, err := doc.Module("beacon")
+					if err != nil {
+						return err
+					}
+					beacon["params"] = map[string]interface{}{"debug_deterministic": false}
+					return nil
+				},
+			},
+		},
+	})
+	require.NoError(err, "Register")
+
+	return r
+}
+
+func TestMigrate(t *testing.T) {
+	require := require.New(t)
+
+	r := newTestRegistry(t)
+
+	doc, err := DecodeDocument([]byte(testDocument))
+	require.NoError(err, "DecodeDocument")
+
+	// Migrate across multiple versions.
+	migrated, err := r.Migrate(doc, testV1, testV3)
+	require.NoError(err, "Migrate")
+
+	staking, err := migrated.Module("staking")
+	require.NoError(err, "Module")
+	require.Contains(staking, "parameters")
+	require.NotContains(staking, "params")
+	require.Contains(migrated, "beacon")
+
+	// Make sure large numbers are preserved.
+	require.EqualValues("100000000000000000000000", staking["total_supply"])
+
+	// The source document must not be modified.
+	staking, err = doc.Module("staking")
+	require.NoError(err, "Module")
+	require.Contains(staking, "params")
+	require.NotContains(doc, "beacon")
+
+	changes := Diff(doc, migrated)
+	require.Equal([]Change{
+		{Kind: ChangeAdded, Path: "beacon"},
+		{Kind: ChangeRemoved, Path: "staking.params"},
+		{Kind: ChangeAdded, Path: "staking.parameters"},
+	}, changes)
+
+	// Migrate a single version.
+	migrated, err = r.Migrate(migrated, testV2, testV3)
+	require.NoError(err, "Migrate")
+	require.Len(Diff(doc, migrated), 3)
+
+	// Migrating to the same version is a no-op.
+	migrated, err = r.Migrate(doc, testV1, testV1)
+	require.NoError(err, "Migrate")
+	require.Empty(Diff(doc, migrated))
+
+	// Unknown migration paths should fail.
+	_, err = r.Migrate(doc, testV3, testV1)
+	require.Error(err, "Migrate should fail without a migration path")
+	_, err = r.Migrate(doc, testV1, version.Version{Major: 0, Minor: 2, Patch: 1})
+	require.Error(err, "Migrate should fail without a migration path")
+}
+
+func TestMigrateValidation(t *testing.T) {
+	require := require.New(t)
+
+	r := newTestRegistry(t)
+
+	// Validation after the step should fail.
+	doc, err := DecodeDocument([]byte(`{"staking": {}}`))
+	require.NoError(err, "DecodeDocument")
+	_, err = r.Migrate(doc, testV1, testV2)
+	require.Error(err, "Migrate should fail when step validation fails")
+
+	// Malformed module sections should fail.
+	doc, err = DecodeDocument([]byte(`{"staking": []}`))
+	require.NoError(err, "DecodeDocument")
+	_, err = r.Migrate(doc, testV1, testV2)
+	require.Error(err, "Migrate should fail with a malformed module")
+
+	// Duplicate and malformed migrations should be rejected.
+	err = r.Register(&Migration{From: testV1, To: testV3})
+	require.Error(err, "Register should fail for duplicate migrations")
+	err = r.Register(&Migration{From: testV3, To: testV1})
+	require.Error(err, "Register should fail for downgrades")
+	err = r.Register(&Migration{From: testV3, To: version.Version{Major: 1}, Steps: []Step{{Module: "staking"}}})
+	require.Error(err, "Register should fail for malformed steps")
+}
+
+func TestParseVersion(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		s        string
+		expected version.Version
+		valid    bool
+	}{
+		{"1.2.3", version.Version{Major: 1, Minor: 2, Patch: 3}, true},
+		{"v1.2.3", version.Version{Major: 1, Minor: 2, Patch: 3}, true},
+		{"2.0", version.Version{Major: 2}, true},
+		{"2", version.Version{Major: 2}, true},
+		{"", version.Version{}, false},
+		{"1.2.3.4", version.Version{}, false},
+		{"1.x", version.Version{}, false},
+		{"70000", version.Version{}, false},
+	} {
+		v, err := ParseVersion(tc.s)
+		if !tc.valid {
+			require.Error(err, "ParseVersion(%s) should fail", tc.s)
+			continue
+		}
+		require.NoError(err, "ParseVersion(%s)", tc.s)
+		require.Equal(tc.expected, v, "ParseVersion(%s)", tc.s)
+	}
+}
@@ -50,6 +50,7 @@ require (
 	github.com/spf13/pflag v1.0.5
 	github.com/spf13/viper v1.7.1
 	github.com/stretchr/testify v1.6.1
+	github.com/tecbot/gorocksdb v0.0.0-20191217155057-f0fad39f321c
 	github.com/tendermint/tendermint v0.33.6
 	github.com/tendermint/tm-db v0.6.2
 	github.com/thepudds/fzgo v0.2.2
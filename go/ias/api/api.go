@@ -3,11 +3,25 @@ package api
 
 import (
 	"context"
+	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
 )
 
+// ModuleName is the IAS module name.
+const ModuleName = "ias"
+
+var (
+	// ErrUnknownTenant is the error returned when no IAS proxy tenant is configured to serve
+	// requests for a given runtime.
+	ErrUnknownTenant = errors.New(ModuleName, 1, "ias: no tenant configured for runtime")
+	// ErrQuotaExceeded is the error returned when the request quota of an IAS proxy tenant has
+	// been exhausted for the current quota period.
+	ErrQuotaExceeded = errors.New(ModuleName, 2, "ias: tenant request quota exceeded")
+)
+
 // Endpoint is an attestation validation endpoint, likely remote.
 type Endpoint interface {
 	// VerifyEvidence takes the provided quote, (optional) PSE manifest, and
@@ -18,6 +32,10 @@ type Endpoint interface {
 	// GetSPID returns the SPID and associated info used by the endpoint.
 	GetSPIDInfo(ctx context.Context) (*SPIDInfo, error)
 
+	// GetRuntimeSPIDInfo returns the SPID and associated info used by the
+	// endpoint when verifying evidence for the given runtime.
+	GetRuntimeSPIDInfo(ctx context.Context, runtimeID common.Namespace) (*SPIDInfo, error)
+
 	// GetSigRL returns the Signature Revocation List for a given EPID group.
 	GetSigRL(ctx context.Context, epidGID uint32) ([]byte, error)
 
@@ -38,3 +56,42 @@ type Evidence struct {
 	PSEManifest []byte           `json:"pse_manifest"`
 	Nonce       string           `json:"nonce"`
 }
+
+// Operator is the IAS proxy operator interface.
+type Operator interface {
+	// GetUsage returns the request usage of all configured IAS proxy tenants.
+	GetUsage(ctx context.Context) ([]*TenantUsage, error)
+}
+
+// TenantUsage is the request usage of an IAS proxy tenant.
+type TenantUsage struct {
+	// Name is the tenant name.
+	Name string `json:"name"`
+
+	// Requests is the total number of evidence verification requests forwarded to IAS.
+	Requests uint64 `json:"requests"`
+	// Rejected is the total number of evidence verification requests rejected due to the
+	// tenant's quota being exceeded.
+	Rejected uint64 `json:"rejected"`
+
+	// Runtimes is the number of forwarded requests broken down by runtime.
+	Runtimes []RuntimeUsage `json:"runtimes,omitempty"`
+
+	// QuotaRequests is the maximum number of requests allowed per quota period (zero means
+	// that the tenant is not subject to a quota).
+	QuotaRequests uint64 `json:"quota_requests,omitempty"`
+	// QuotaPeriod is the quota period.
+	QuotaPeriod time.Duration `json:"quota_period,omitempty"`
+	// PeriodStart is the start of the current quota period.
+	PeriodStart time.Time `json:"period_start,omitempty"`
+	// PeriodRequests is the number of requests forwarded in the current quota period.
+	PeriodRequests uint64 `json:"period_requests,omitempty"`
+}
+
+// RuntimeUsage is the request usage of a runtime.
+type RuntimeUsage struct {
+	// RuntimeID is the runtime identifier.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// Requests is the number of forwarded evidence verification requests.
+	Requests uint64 `json:"requests"`
+}
@@ -5,6 +5,7 @@ import (
 
 	"google.golang.org/grpc"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
 )
@@ -12,14 +13,21 @@ import (
 var (
 	// serviceName is the gRPC service name.
 	serviceName = cmnGrpc.NewServiceName("IAS")
+	// operatorServiceName is the gRPC service name for the IAS proxy operator interface.
+	operatorServiceName = cmnGrpc.NewServiceName("IASOperator")
 
 	// methodVerifyEvidence is the VerifyEvidence method.
 	methodVerifyEvidence = serviceName.NewMethod("VerifyEvidence", Evidence{})
 	// methodGetSPIDInfo is the GetSPIDInfo method.
 	methodGetSPIDInfo = serviceName.NewMethod("GetSPIDInfo", nil)
+	// methodGetRuntimeSPIDInfo is the GetRuntimeSPIDInfo method.
+	methodGetRuntimeSPIDInfo = serviceName.NewMethod("GetRuntimeSPIDInfo", common.Namespace{})
 	// methodGetSigRL is the GetSigRL method.
 	methodGetSigRL = serviceName.NewMethod("GetSigRL", uint32(0))
 
+	// methodGetUsage is the GetUsage method.
+	methodGetUsage = operatorServiceName.NewMethod("GetUsage", nil)
+
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
 		ServiceName: string(serviceName),
@@ -33,6 +41,10 @@ var (
 				MethodName: methodGetSPIDInfo.ShortName(),
 				Handler:    handlerGetSPIDInfo,
 			},
+			{
+				MethodName: methodGetRuntimeSPIDInfo.ShortName(),
+				Handler:    handlerGetRuntimeSPIDInfo,
+			},
 			{
 				MethodName: methodGetSigRL.ShortName(),
 				Handler:    handlerGetSigRL,
@@ -40,6 +52,19 @@ var (
 		},
 		Streams: []grpc.StreamDesc{},
 	}
+
+	// operatorServiceDesc is the gRPC service descriptor for the IAS proxy operator service.
+	operatorServiceDesc = grpc.ServiceDesc{
+		ServiceName: string(operatorServiceName),
+		HandlerType: (*Operator)(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: methodGetUsage.ShortName(),
+				Handler:    handlerGetUsage,
+			},
+		},
+		Streams: []grpc.StreamDesc{},
+	}
 )
 
 func handlerVerifyEvidence( // nolint: golint
@@ -84,6 +109,29 @@ func handlerGetSPIDInfo( // nolint: golint
 	return interceptor(ctx, nil, info, handler)
 }
 
+func handlerGetRuntimeSPIDInfo( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var runtimeID common.Namespace
+	if err := dec(&runtimeID); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Endpoint).GetRuntimeSPIDInfo(ctx, runtimeID)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetRuntimeSPIDInfo.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Endpoint).GetRuntimeSPIDInfo(ctx, req.(common.Namespace))
+	}
+	return interceptor(ctx, runtimeID, info, handler)
+}
+
 func handlerGetSigRL( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -107,11 +155,35 @@ func handlerGetSigRL( // nolint: golint
 	return interceptor(ctx, epidGID, info, handler)
 }
 
+func handlerGetUsage( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return srv.(Operator).GetUsage(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetUsage.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Operator).GetUsage(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
 // RegisterService registers a new IAS service with the given gRPC server.
 func RegisterService(server *grpc.Server, service Endpoint) {
 	server.RegisterService(&serviceDesc, service)
 }
 
+// RegisterOperatorService registers a new IAS proxy operator service with the given gRPC server.
+func RegisterOperatorService(server *grpc.Server, service Operator) {
+	server.RegisterService(&operatorServiceDesc, service)
+}
+
 type endpointClient struct {
 	conn *grpc.ClientConn
 }
@@ -132,6 +204,14 @@ func (c *endpointClient) GetSPIDInfo(ctx context.Context) (*SPIDInfo, error) {
 	return &rsp, nil
 }
 
+func (c *endpointClient) GetRuntimeSPIDInfo(ctx context.Context, runtimeID common.Namespace) (*SPIDInfo, error) {
+	var rsp SPIDInfo
+	if err := c.conn.Invoke(ctx, methodGetRuntimeSPIDInfo.FullName(), runtimeID, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *endpointClient) GetSigRL(ctx context.Context, epidGID uint32) ([]byte, error) {
 	var rsp []byte
 	if err := c.conn.Invoke(ctx, methodGetSigRL.FullName(), epidGID, &rsp); err != nil {
@@ -147,3 +227,20 @@ func (c *endpointClient) Cleanup() {
 func NewEndpointClient(c *grpc.ClientConn) Endpoint {
 	return &endpointClient{c}
 }
+
+type operatorClient struct {
+	conn *grpc.ClientConn
+}
+
+func (c *operatorClient) GetUsage(ctx context.Context) ([]*TenantUsage, error) {
+	var rsp []*TenantUsage
+	if err := c.conn.Invoke(ctx, methodGetUsage.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// NewOperatorClient creates a new gRPC IAS proxy operator client service.
+func NewOperatorClient(c *grpc.ClientConn) Operator {
+	return &operatorClient{c}
+}
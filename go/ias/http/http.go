@@ -19,6 +19,7 @@ import (
 
 	"golang.org/x/net/context/ctxhttp"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
 	"github.com/oasisprotocol/oasis-core/go/ias/api"
@@ -137,6 +138,10 @@ func (e *httpEndpoint) GetSPIDInfo(ctx context.Context) (*api.SPIDInfo, error) {
 	return &e.spidInfo, nil
 }
 
+func (e *httpEndpoint) GetRuntimeSPIDInfo(ctx context.Context, runtimeID common.Namespace) (*api.SPIDInfo, error) {
+	return e.GetSPIDInfo(ctx)
+}
+
 func (e *httpEndpoint) GetSigRL(ctx context.Context, epidGID uint32) ([]byte, error) {
 	var gid [4]byte
 	binary.BigEndian.PutUint32(gid[:], epidGID)
@@ -198,6 +203,10 @@ func (e *mockEndpoint) GetSPIDInfo(ctx context.Context) (*api.SPIDInfo, error) {
 	return &e.spidInfo, nil
 }
 
+func (e *mockEndpoint) GetRuntimeSPIDInfo(ctx context.Context, runtimeID common.Namespace) (*api.SPIDInfo, error) {
+	return e.GetSPIDInfo(ctx)
+}
+
 func (e *mockEndpoint) GetSigRL(ctx context.Context, epidGID uint32) ([]byte, error) {
 	return nil, nil
 }
@@ -6,9 +6,13 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
+	"sync"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	tlsCert "github.com/oasisprotocol/oasis-core/go/common/crypto/tls"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
@@ -28,6 +32,9 @@ type proxyClient struct {
 
 	spidInfo *api.SPIDInfo
 
+	runtimeSPIDInfoLock sync.Mutex
+	runtimeSPIDInfo     map[common.Namespace]*api.SPIDInfo
+
 	logger *logging.Logger
 }
 
@@ -77,6 +84,33 @@ func (c *proxyClient) GetSPIDInfo(ctx context.Context) (*api.SPIDInfo, error) {
 	return c.spidInfo, nil
 }
 
+func (c *proxyClient) GetRuntimeSPIDInfo(ctx context.Context, runtimeID common.Namespace) (*api.SPIDInfo, error) {
+	if c.endpoint == nil {
+		return c.spidInfo, nil
+	}
+
+	c.runtimeSPIDInfoLock.Lock()
+	defer c.runtimeSPIDInfoLock.Unlock()
+
+	if spidInfo, ok := c.runtimeSPIDInfo[runtimeID]; ok {
+		return spidInfo, nil
+	}
+
+	spidInfo, err := c.endpoint.GetRuntimeSPIDInfo(ctx, runtimeID)
+	switch {
+	case err == nil:
+	case status.Code(err) == codes.Unimplemented:
+		// Older proxies only support a single SPID for all runtimes.
+		c.logger.Debug("IAS proxy does not support per-runtime SPIDs, using default SPID")
+		return c.GetSPIDInfo(ctx)
+	default:
+		return nil, err
+	}
+	c.runtimeSPIDInfo[runtimeID] = spidInfo
+
+	return spidInfo, nil
+}
+
 func (c *proxyClient) GetSigRL(ctx context.Context, epidGID uint32) ([]byte, error) {
 	return c.endpoint.GetSigRL(ctx, epidGID)
 }
@@ -90,8 +124,9 @@ func (c *proxyClient) Cleanup() {
 // New creates a new IAS proxy client endpoint.
 func New(identity *identity.Identity, proxyAddr, tlsCertFile string) (api.Endpoint, error) {
 	c := &proxyClient{
-		identity: identity,
-		logger:   logging.GetLogger("ias/proxyclient"),
+		identity:        identity,
+		runtimeSPIDInfo: make(map[common.Namespace]*api.SPIDInfo),
+		logger:          logging.GetLogger("ias/proxyclient"),
 	}
 
 	if proxyAddr == "" {
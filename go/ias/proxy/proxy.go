@@ -3,7 +3,11 @@ package proxy
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
 	"github.com/oasisprotocol/oasis-core/go/ias/api"
@@ -12,7 +16,13 @@ import (
 // CommonName is the CommonName for the IAS proxy TLS certificate.
 const CommonName = "ias-proxy"
 
-var _ api.Endpoint = (*proxyEndpoint)(nil)
+var _ Proxy = (*proxyEndpoint)(nil)
+
+// Proxy is the IAS proxy endpoint.
+type Proxy interface {
+	api.Endpoint
+	api.Operator
+}
 
 // Authenticator is the interface used to authenticate gRPC requests.
 type Authenticator interface {
@@ -23,6 +33,17 @@ type Authenticator interface {
 	VerifyEvidence(ctx context.Context, evidence *api.Evidence) error
 }
 
+// RuntimeOwnerResolver is the interface used to resolve the entity that
+// owns a runtime.
+//
+// Authenticators that implement this interface enable routing requests to
+// tenants by runtime owner.
+type RuntimeOwnerResolver interface {
+	// GetRuntimeOwner returns the entity that owns the given runtime
+	// if the runtime is known.
+	GetRuntimeOwner(runtimeID common.Namespace) (signature.PublicKey, bool)
+}
+
 type noOpAuthenticator struct{}
 
 func (n *noOpAuthenticator) VerifyEvidence(ctx context.Context, evidence *api.Evidence) error {
@@ -30,12 +51,37 @@ func (n *noOpAuthenticator) VerifyEvidence(ctx context.Context, evidence *api.Ev
 }
 
 type proxyEndpoint struct {
-	endpoint      api.Endpoint
 	authenticator Authenticator
+	owners        RuntimeOwnerResolver
+
+	tenants       []*tenantState
+	byRuntime     map[common.Namespace]*tenantState
+	byEntity      map[signature.PublicKey]*tenantState
+	defaultTenant *tenantState
+
+	// now is used to override the current time in tests.
+	now func() time.Time
 
 	logger *logging.Logger
 }
 
+func (p *proxyEndpoint) resolveTenant(runtimeID common.Namespace) (*tenantState, error) {
+	if ts, ok := p.byRuntime[runtimeID]; ok {
+		return ts, nil
+	}
+	if p.owners != nil && len(p.byEntity) > 0 {
+		if entityID, ok := p.owners.GetRuntimeOwner(runtimeID); ok {
+			if ts, ok := p.byEntity[entityID]; ok {
+				return ts, nil
+			}
+		}
+	}
+	if p.defaultTenant != nil {
+		return p.defaultTenant, nil
+	}
+	return nil, api.ErrUnknownTenant
+}
+
 func (p *proxyEndpoint) VerifyEvidence(ctx context.Context, evidence *api.Evidence) (*ias.AVRBundle, error) {
 	if err := p.authenticator.VerifyEvidence(ctx, evidence); err != nil {
 		p.logger.Warn("failed to authenticate IAS VerifyEvidence request",
@@ -44,30 +90,115 @@ func (p *proxyEndpoint) VerifyEvidence(ctx context.Context, evidence *api.Eviden
 		return nil, err
 	}
 
-	return p.endpoint.VerifyEvidence(ctx, evidence)
+	ts, err := p.resolveTenant(evidence.RuntimeID)
+	if err != nil {
+		p.logger.Warn("rejecting IAS VerifyEvidence request, no tenant for runtime",
+			"runtime_id", evidence.RuntimeID,
+		)
+		return nil, err
+	}
+	if err = ts.acquire(evidence.RuntimeID, p.now()); err != nil {
+		p.logger.Warn("rejecting IAS VerifyEvidence request, tenant quota exceeded",
+			"tenant", ts.tenant.Name,
+			"runtime_id", evidence.RuntimeID,
+		)
+		return nil, err
+	}
+
+	return ts.tenant.Endpoint.VerifyEvidence(ctx, evidence)
 }
 
 func (p *proxyEndpoint) GetSPIDInfo(ctx context.Context) (*api.SPIDInfo, error) {
-	return p.endpoint.GetSPIDInfo(ctx)
+	if p.defaultTenant == nil {
+		return nil, api.ErrUnknownTenant
+	}
+	return p.defaultTenant.tenant.Endpoint.GetSPIDInfo(ctx)
+}
+
+func (p *proxyEndpoint) GetRuntimeSPIDInfo(ctx context.Context, runtimeID common.Namespace) (*api.SPIDInfo, error) {
+	ts, err := p.resolveTenant(runtimeID)
+	if err != nil {
+		return nil, err
+	}
+	return ts.tenant.Endpoint.GetSPIDInfo(ctx)
 }
 
 func (p *proxyEndpoint) GetSigRL(ctx context.Context, epidGID uint32) ([]byte, error) {
 	// TODO: Validate the EPID group ID.
-	return p.endpoint.GetSigRL(ctx, epidGID)
+
+	// The SigRL only depends on the EPID group so any tenant's subscription
+	// can be used to retrieve it.
+	ts := p.defaultTenant
+	if ts == nil {
+		ts = p.tenants[0]
+	}
+	return ts.tenant.Endpoint.GetSigRL(ctx, epidGID)
+}
+
+func (p *proxyEndpoint) GetUsage(ctx context.Context) ([]*api.TenantUsage, error) {
+	usage := make([]*api.TenantUsage, 0, len(p.tenants))
+	for _, ts := range p.tenants {
+		usage = append(usage, ts.usage())
+	}
+	return usage, nil
 }
 
 func (p *proxyEndpoint) Cleanup() {
 }
 
-// New creates a new proxy endpoint.
-func New(endpoint api.Endpoint, authenticator Authenticator) api.Endpoint {
+// New creates a new proxy endpoint serving the given tenants.
+func New(tenants []*Tenant, authenticator Authenticator) (Proxy, error) {
+	if len(tenants) == 0 {
+		return nil, fmt.Errorf("ias/proxy: no tenants configured")
+	}
 	if authenticator == nil {
 		authenticator = &noOpAuthenticator{}
 	}
 
-	return &proxyEndpoint{
-		endpoint:      endpoint,
+	p := &proxyEndpoint{
 		authenticator: authenticator,
+		byRuntime:     make(map[common.Namespace]*tenantState),
+		byEntity:      make(map[signature.PublicKey]*tenantState),
+		now:           time.Now,
 		logger:        logging.GetLogger("ias/proxy"),
 	}
+	p.owners, _ = authenticator.(RuntimeOwnerResolver)
+
+	names := make(map[string]bool)
+	for _, t := range tenants {
+		if err := t.validate(); err != nil {
+			return nil, err
+		}
+		if names[t.Name] {
+			return nil, fmt.Errorf("ias/proxy: duplicate tenant '%s'", t.Name)
+		}
+		names[t.Name] = true
+
+		ts := newTenantState(t)
+		p.tenants = append(p.tenants, ts)
+
+		if t.Default {
+			if p.defaultTenant != nil {
+				return nil, fmt.Errorf("ias/proxy: multiple default tenants configured")
+			}
+			p.defaultTenant = ts
+		}
+		for _, id := range t.Runtimes {
+			if other, ok := p.byRuntime[id]; ok {
+				return nil, fmt.Errorf("ias/proxy: runtime %s served by tenants '%s' and '%s'", id, other.tenant.Name, t.Name)
+			}
+			p.byRuntime[id] = ts
+		}
+		for _, id := range t.Entities {
+			if other, ok := p.byEntity[id]; ok {
+				return nil, fmt.Errorf("ias/proxy: entity %s served by tenants '%s' and '%s'", id, other.tenant.Name, t.Name)
+			}
+			p.byEntity[id] = ts
+		}
+	}
+	if len(p.byEntity) > 0 && p.owners == nil {
+		return nil, fmt.Errorf("ias/proxy: tenants served by entity require a runtime owner resolver")
+	}
+
+	return p, nil
 }
@@ -0,0 +1,177 @@
+package proxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
+	"github.com/oasisprotocol/oasis-core/go/ias/api"
+)
+
+type testEndpoint struct {
+	spidInfo api.SPIDInfo
+	requests int
+}
+
+func (e *testEndpoint) VerifyEvidence(ctx context.Context, evidence *api.Evidence) (*ias.AVRBundle, error) {
+	e.requests++
+	return &ias.AVRBundle{}, nil
+}
+
+func (e *testEndpoint) GetSPIDInfo(ctx context.Context) (*api.SPIDInfo, error) {
+	return &e.spidInfo, nil
+}
+
+func (e *testEndpoint) GetRuntimeSPIDInfo(ctx context.Context, runtimeID common.Namespace) (*api.SPIDInfo, error) {
+	return e.GetSPIDInfo(ctx)
+}
+
+func (e *testEndpoint) GetSigRL(ctx context.Context, epidGID uint32) ([]byte, error) {
+	return nil, nil
+}
+
+func (e *testEndpoint) Cleanup() {
+}
+
+type testAuthenticator struct {
+	owners map[common.Namespace]signature.PublicKey
+}
+
+func (a *testAuthenticator) VerifyEvidence(ctx context.Context, evidence *api.Evidence) error {
+	return nil
+}
+
+func (a *testAuthenticator) GetRuntimeOwner(runtimeID common.Namespace) (signature.PublicKey, bool) {
+	owner, ok := a.owners[runtimeID]
+	return owner, ok
+}
+
+func newTestEndpoint(spid byte) *testEndpoint {
+	var e testEndpoint
+	e.spidInfo.SPID[0] = spid
+	return &e
+}
+
+func TestProxyTenants(t *testing.T) {
+	require := require.New(t)
+
+	var rt1, rt2, rt3, rt4 common.Namespace
+	rt1[0], rt2[0], rt3[0], rt4[0] = 1, 2, 3, 4
+	entity := signature.NewPublicKey("5555555555555555555555555555555555555555555555555555555555555555")
+
+	epDefault, epA, epB := newTestEndpoint(1), newTestEndpoint(2), newTestEndpoint(3)
+	tenants := []*Tenant{
+		{Name: "default", Endpoint: epDefault, Default: true},
+		{
+			Name:     "a",
+			Endpoint: epA,
+			Runtimes: []common.Namespace{rt1},
+			Quota:    &Quota{Requests: 2, Period: time.Hour},
+		},
+		{Name: "b", Endpoint: epB, Entities: []signature.PublicKey{entity}},
+	}
+	auth := &testAuthenticator{
+		owners: map[common.Namespace]signature.PublicKey{rt2: entity},
+	}
+
+	p, err := New(tenants, auth)
+	require.NoError(err, "New")
+	now := time.Unix(1000, 0)
+	p.(*proxyEndpoint).now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	// Requests should be routed by runtime, by runtime owner and to the default tenant.
+	spidInfo, err := p.GetRuntimeSPIDInfo(ctx, rt1)
+	require.NoError(err, "GetRuntimeSPIDInfo")
+	require.EqualValues(2, spidInfo.SPID[0], "runtime should use the SPID of its tenant")
+	spidInfo, err = p.GetRuntimeSPIDInfo(ctx, rt2)
+	require.NoError(err, "GetRuntimeSPIDInfo")
+	require.EqualValues(3, spidInfo.SPID[0], "runtime should use the SPID of its owner's tenant")
+	spidInfo, err = p.GetRuntimeSPIDInfo(ctx, rt3)
+	require.NoError(err, "GetRuntimeSPIDInfo")
+	require.EqualValues(1, spidInfo.SPID[0], "unknown runtime should use the default SPID")
+
+	for _, id := range []common.Namespace{rt1, rt2, rt3} {
+		_, err = p.VerifyEvidence(ctx, &api.Evidence{RuntimeID: id})
+		require.NoError(err, "VerifyEvidence")
+	}
+	require.Equal(1, epA.requests)
+	require.Equal(1, epB.requests)
+	require.Equal(1, epDefault.requests)
+
+	// Quota should be enforced and reset in the next period.
+	_, err = p.VerifyEvidence(ctx, &api.Evidence{RuntimeID: rt1})
+	require.NoError(err, "VerifyEvidence")
+	_, err = p.VerifyEvidence(ctx, &api.Evidence{RuntimeID: rt1})
+	require.Error(err, "VerifyEvidence should fail when quota is exceeded")
+	require.Equal(api.ErrQuotaExceeded, err)
+	require.Equal(2, epA.requests, "requests over quota should not be forwarded")
+
+	now = now.Add(time.Hour)
+	_, err = p.VerifyEvidence(ctx, &api.Evidence{RuntimeID: rt1})
+	require.NoError(err, "VerifyEvidence in the next quota period")
+
+	usage, err := p.GetUsage(ctx)
+	require.NoError(err, "GetUsage")
+	require.Len(usage, 3)
+	require.Equal("a", usage[1].Name)
+	require.EqualValues(3, usage[1].Requests)
+	require.EqualValues(1, usage[1].Rejected)
+	require.EqualValues(1, usage[1].PeriodRequests)
+	require.EqualValues(2, usage[1].QuotaRequests)
+	require.Equal(now, usage[1].PeriodStart)
+	require.Equal([]api.RuntimeUsage{{RuntimeID: rt1, Requests: 3}}, usage[1].Runtimes)
+	require.EqualValues(1, usage[0].Requests)
+	require.Zero(usage[0].QuotaRequests, "default tenant should not have a quota")
+
+	// Without a default tenant, requests for unknown runtimes should be rejected.
+	p, err = New(tenants[1:], auth)
+	require.NoError(err, "New")
+	_, err = p.VerifyEvidence(ctx, &api.Evidence{RuntimeID: rt4})
+	require.Equal(api.ErrUnknownTenant, err)
+	_, err = p.GetSPIDInfo(ctx)
+	require.Equal(api.ErrUnknownTenant, err)
+}
+
+func TestProxyTenantsInvalid(t *testing.T) {
+	require := require.New(t)
+
+	var rt common.Namespace
+	ep := newTestEndpoint(1)
+
+	_, err := New(nil, nil)
+	require.Error(err, "New should fail without tenants")
+	_, err = New([]*Tenant{
+		{Name: "a", Endpoint: ep, Runtimes: []common.Namespace{rt}},
+		{Name: "b", Endpoint: ep, Runtimes: []common.Namespace{rt}},
+	}, nil)
+	require.Error(err, "New should fail with a runtime served by multiple tenants")
+	_, err = New([]*Tenant{
+		{Name: "a", Endpoint: ep, Default: true},
+		{Name: "a", Endpoint: ep, Runtimes: []common.Namespace{rt}},
+	}, nil)
+	require.Error(err, "New should fail with duplicate tenant names")
+	_, err = New([]*Tenant{
+		{Name: "a", Endpoint: ep, Default: true},
+		{Name: "b", Endpoint: ep, Default: true},
+	}, nil)
+	require.Error(err, "New should fail with multiple default tenants")
+	_, err = New([]*Tenant{
+		{Name: "a", Endpoint: ep},
+	}, nil)
+	require.Error(err, "New should fail with a tenant serving no runtimes")
+	_, err = New([]*Tenant{
+		{Name: "a", Endpoint: ep, Default: true, Quota: &Quota{Requests: 1}},
+	}, nil)
+	require.Error(err, "New should fail with an invalid quota period")
+	_, err = New([]*Tenant{
+		{Name: "a", Endpoint: ep, Entities: []signature.PublicKey{{}}},
+	}, nil)
+	require.Error(err, "New should fail with entity tenants but no runtime owner resolver")
+}
@@ -0,0 +1,134 @@
+package proxy
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/ias/api"
+)
+
+// Quota is an IAS proxy tenant request quota.
+type Quota struct {
+	// Requests is the maximum number of evidence verification requests
+	// that may be forwarded during a single quota period.
+	Requests uint64
+
+	// Period is the quota period.
+	Period time.Duration
+}
+
+// Tenant is an IAS proxy tenant, holding its own IAS subscription.
+type Tenant struct {
+	// Name is the tenant name used when reporting usage.
+	Name string
+
+	// Endpoint is the IAS endpoint configured with the tenant's
+	// subscription.
+	Endpoint api.Endpoint
+
+	// Runtimes are the runtimes served by the tenant.
+	Runtimes []common.Namespace
+
+	// Entities are the entities whose runtimes are served by the tenant.
+	Entities []signature.PublicKey
+
+	// Quota is the optional tenant request quota.
+	Quota *Quota
+
+	// Default is true iff the tenant serves all runtimes that are not
+	// explicitly served by other tenants.
+	Default bool
+}
+
+func (t *Tenant) validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("ias/proxy: tenant name not set")
+	}
+	if t.Endpoint == nil {
+		return fmt.Errorf("ias/proxy: tenant '%s' has no endpoint", t.Name)
+	}
+	if !t.Default && len(t.Runtimes) == 0 && len(t.Entities) == 0 {
+		return fmt.Errorf("ias/proxy: tenant '%s' serves no runtimes", t.Name)
+	}
+	if t.Quota != nil && t.Quota.Requests > 0 && t.Quota.Period <= 0 {
+		return fmt.Errorf("ias/proxy: tenant '%s' has an invalid quota period", t.Name)
+	}
+	return nil
+}
+
+type tenantState struct {
+	sync.Mutex
+
+	tenant *Tenant
+
+	requests       uint64
+	rejected       uint64
+	runtimes       map[common.Namespace]uint64
+	periodStart    time.Time
+	periodRequests uint64
+}
+
+// acquire accounts for a new evidence verification request for the given
+// runtime, failing with ErrQuotaExceeded if the tenant's quota has already
+// been exhausted for the current quota period.
+func (ts *tenantState) acquire(runtimeID common.Namespace, now time.Time) error {
+	ts.Lock()
+	defer ts.Unlock()
+
+	if q := ts.tenant.Quota; q != nil && q.Requests > 0 {
+		if now.Sub(ts.periodStart) >= q.Period {
+			ts.periodStart = now
+			ts.periodRequests = 0
+		}
+		if ts.periodRequests >= q.Requests {
+			ts.rejected++
+			return api.ErrQuotaExceeded
+		}
+	}
+
+	ts.requests++
+	ts.periodRequests++
+	ts.runtimes[runtimeID]++
+
+	return nil
+}
+
+func (ts *tenantState) usage() *api.TenantUsage {
+	ts.Lock()
+	defer ts.Unlock()
+
+	u := &api.TenantUsage{
+		Name:     ts.tenant.Name,
+		Requests: ts.requests,
+		Rejected: ts.rejected,
+	}
+	for id, requests := range ts.runtimes {
+		u.Runtimes = append(u.Runtimes, api.RuntimeUsage{
+			RuntimeID: id,
+			Requests:  requests,
+		})
+	}
+	sort.Slice(u.Runtimes, func(i, j int) bool {
+		return bytes.Compare(u.Runtimes[i].RuntimeID[:], u.Runtimes[j].RuntimeID[:]) < 0
+	})
+	if q := ts.tenant.Quota; q != nil && q.Requests > 0 {
+		u.QuotaRequests = q.Requests
+		u.QuotaPeriod = q.Period
+		u.PeriodStart = ts.periodStart
+		u.PeriodRequests = ts.periodRequests
+	}
+
+	return u
+}
+
+func newTenantState(tenant *Tenant) *tenantState {
+	return &tenantState{
+		tenant:   tenant,
+		runtimes: make(map[common.Namespace]uint64),
+	}
+}
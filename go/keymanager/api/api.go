@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"time"
 
+	"golang.org/x/crypto/sha3"
+
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
@@ -72,6 +74,23 @@ type Status struct {
 
 	// Policy is the key manager policy.
 	Policy *SignedPolicySGX `json:"policy"`
+
+	// PolicyChecksum is the checksum of the key manager policy.
+	//
+	// It is derived from the policy when the status is queried and is not
+	// stored in consensus state.
+	PolicyChecksum []byte `json:"policy_checksum,omitempty"`
+}
+
+// PolicyChecksum computes the checksum of the given (possibly missing) key
+// manager policy in the same way as key manager enclaves do.
+func PolicyChecksum(policy *SignedPolicySGX) []byte {
+	var rawPolicy []byte
+	if policy != nil {
+		rawPolicy = cbor.Marshal(policy)
+	}
+	checksum := sha3.Sum256(rawPolicy)
+	return checksum[:]
 }
 
 // Backend is a key manager management implementation.
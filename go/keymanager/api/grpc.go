@@ -8,6 +8,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	enclaverpc "github.com/oasisprotocol/oasis-core/go/runtime/enclaverpc/api"
 )
@@ -24,6 +25,8 @@ var (
 	methodGetStatus = serviceName.NewMethod("GetStatus", registry.NamespaceQuery{})
 	// methodGetStatuses is the GetStatuses method.
 	methodGetStatuses = serviceName.NewMethod("GetStatuses", int64(0))
+	// methodWatchStatuses is the WatchStatuses method.
+	methodWatchStatuses = serviceName.NewMethod("WatchStatuses", nil)
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -39,6 +42,13 @@ var (
 				Handler:    handlerGetStatuses,
 			},
 		},
+		Streams: []grpc.StreamDesc{
+			{
+				StreamName:    methodWatchStatuses.ShortName(),
+				Handler:       handlerWatchStatuses,
+				ServerStreams: true,
+			},
+		},
 	}
 )
 
@@ -115,6 +125,31 @@ func handlerGetStatuses( //nolint: golint
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerWatchStatuses(srv interface{}, stream grpc.ServerStream) error {
+	if err := stream.RecvMsg(nil); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub := srv.(Backend).WatchStatuses()
+	defer sub.Close()
+
+	for {
+		select {
+		case status, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.SendMsg(status); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 // RegisterService registers a new keymanager backend service with the given gRPC server.
 func RegisterService(server *grpc.Server, service Backend) {
 	server.RegisterService(&serviceDesc, service)
@@ -141,6 +176,43 @@ func (c *KeymanagerClient) GetStatuses(ctx context.Context, height int64) ([]*St
 	return resp, nil
 }
 
+// WatchStatuses returns a channel that produces a stream of messages containing the key manager
+// statuses as it changes over time.
+func (c *KeymanagerClient) WatchStatuses(ctx context.Context) (<-chan *Status, pubsub.ClosableSubscription, error) {
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[0], methodWatchStatuses.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(nil); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *Status)
+	go func() {
+		defer close(ch)
+
+		for {
+			var status Status
+			if serr := stream.RecvMsg(&status); serr != nil {
+				return
+			}
+
+			select {
+			case ch <- &status:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
 // NewKeymanagerClient creates a new gRPC keymanager client service.
 func NewKeymanagerClient(c *grpc.ClientConn) *KeymanagerClient {
 	return &KeymanagerClient{c}
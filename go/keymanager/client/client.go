@@ -14,6 +14,9 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cache/lru"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
@@ -27,6 +30,10 @@ import (
 const (
 	retryInterval = 1 * time.Second
 	maxRetries    = 15
+
+	// sessionCacheSize is the maximum number of EnclaveRPC sessions for which the key manager
+	// node that the session has been established with is remembered.
+	sessionCacheSize = 1024
 )
 
 // ErrKeyManagerNotAvailable is the error when a key manager is not available.
@@ -46,6 +53,10 @@ type Client struct {
 	committeeNodes  committee.NodeDescriptorWatcher
 	committeeClient committee.Client
 
+	// sessions maps EnclaveRPC session identifiers to the key manager nodes that the sessions
+	// have been established with.
+	sessions *lru.Cache
+
 	logger *logging.Logger
 }
 
@@ -55,6 +66,11 @@ func (c *Client) Initialized() <-chan struct{} {
 }
 
 // CallRemote calls the key manager via remote EnclaveRPC.
+//
+// Calls belonging to an already established EnclaveRPC session are always routed to the key
+// manager node that the session has been established with. New sessions are established with
+// the node selected by the node selection policy, failing over to other nodes in case the
+// selected node is unavailable.
 func (c *Client) CallRemote(ctx context.Context, data []byte) ([]byte, error) {
 	select {
 	case <-ctx.Done():
@@ -66,14 +82,35 @@ func (c *Client) CallRemote(ctx context.Context, data []byte) ([]byte, error) {
 		"data", base64.StdEncoding.EncodeToString(data),
 	)
 
+	// Determine the session the call belongs to. Frames that cannot be decoded are passed through
+	// unchanged and are not bound to any key manager node.
+	var (
+		frame     enclaverpc.Frame
+		sessionID string
+	)
+	if err := cbor.Unmarshal(data, &frame); err == nil {
+		sessionID = string(frame.Session)
+	}
+
 	var resp []byte
 	call := func() error {
-		conn := c.committeeClient.GetConnection()
+		conn, bound := c.getConnection(sessionID)
 		if conn == nil {
+			if bound {
+				// The node that the session has been established with is no longer available so
+				// the session is lost. Forget about it so the caller can establish a new session
+				// with another key manager node.
+				c.logger.Warn("key manager node for session not available",
+					"session_id", base64.StdEncoding.EncodeToString(frame.Session),
+				)
+				c.sessions.Remove(sessionID)
+				return backoff.Permanent(ErrKeyManagerNotAvailable)
+			}
+
 			c.logger.Warn("no key manager connection for runtime")
 			return ErrKeyManagerNotAvailable
 		}
-		client := enclaverpc.NewTransportClient(conn)
+		client := enclaverpc.NewTransportClient(conn.ClientConn)
 
 		var err error
 		resp, err = client.CallEnclave(ctx, &enclaverpc.CallEnclaveRequest{
@@ -83,6 +120,10 @@ func (c *Client) CallRemote(ctx context.Context, data []byte) ([]byte, error) {
 		})
 		switch {
 		case err == nil:
+			if !bound && sessionID != "" {
+				// Remember the node that the session has been established with.
+				_ = c.sessions.Put(sessionID, conn.Node.ID)
+			}
 		case status.Code(err) == codes.PermissionDenied:
 			// Calls can fail around epoch transitions, as the access policy
 			// is being updated, so we must retry.
@@ -99,10 +140,26 @@ func (c *Client) CallRemote(ctx context.Context, data []byte) ([]byte, error) {
 			default:
 			}
 
-			fallthrough
+			// The node is unreachable, communicate that to the node selection policy.
+			c.committeeClient.UpdateNodeSelectionPolicy(committee.NodeSelectionFeedback{
+				ID:  conn.Node.ID,
+				Bad: err,
+			})
+			if !bound {
+				// No session state has been established with the node yet, so we can retry
+				// with another key manager node.
+				return err
+			}
+
+			c.sessions.Remove(sessionID)
+			return backoff.Permanent(err)
 		default:
 			// Request failed, communicate that to the node selection policy.
-			c.committeeClient.UpdateNodeSelectionPolicy(committee.NodeSelectionFeedback{Bad: err})
+			c.committeeClient.UpdateNodeSelectionPolicy(committee.NodeSelectionFeedback{
+				ID:  conn.Node.ID,
+				Bad: err,
+			})
+			c.sessions.Remove(sessionID)
 			return backoff.Permanent(err)
 		}
 		return nil
@@ -114,6 +171,17 @@ func (c *Client) CallRemote(ctx context.Context, data []byte) ([]byte, error) {
 	return resp, err
 }
 
+// getConnection returns the connection that should be used for a call belonging to the given
+// session and whether the session is bound to a specific key manager node.
+func (c *Client) getConnection(sessionID string) (*committee.ClientConnWithMeta, bool) {
+	if sessionID != "" {
+		if nodeID, ok := c.sessions.Get(sessionID); ok {
+			return c.committeeClient.GetConnectionsMap()[nodeID.(signature.PublicKey)], true
+		}
+	}
+	return c.committeeClient.GetConnectionWithMeta(), false
+}
+
 func (c *Client) worker() {
 	stCh, stSub := c.backend.WatchStatuses()
 	defer stSub.Close()
@@ -225,6 +293,11 @@ func New(
 		return nil, fmt.Errorf("keymanager/client: failed to create committee client: %w", err)
 	}
 
+	sessions, err := lru.New(lru.Capacity(sessionCacheSize, false))
+	if err != nil {
+		return nil, fmt.Errorf("keymanager/client: failed to create session cache: %w", err)
+	}
+
 	c := &Client{
 		runtime:         runtime,
 		backend:         backend,
@@ -233,6 +306,7 @@ func New(
 		initCh:          make(chan struct{}),
 		committeeNodes:  committeeNodes,
 		committeeClient: committeeClient,
+		sessions:        sessions,
 		logger:          logging.GetLogger("keymanager/client").With("runtime_id", runtime.ID()),
 	}
 	go c.worker()
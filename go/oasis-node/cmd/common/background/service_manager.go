@@ -4,32 +4,86 @@ package background
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/service"
 )
 
+type managedService struct {
+	svc         service.BackgroundService
+	deps        []service.BackgroundService
+	cleanupOnly bool
+
+	started bool
+	stopped bool
+}
+
+func (ms *managedService) health() service.HealthState {
+	switch {
+	case ms.stopped:
+		return service.HealthStopped
+	case !ms.started:
+		return service.HealthInitializing
+	}
+
+	if init, ok := ms.svc.(service.Initializer); ok {
+		select {
+		case <-init.Initialized():
+		default:
+			return service.HealthInitializing
+		}
+	}
+	if hr, ok := ms.svc.(service.HealthReporter); ok {
+		return hr.Health()
+	}
+	return service.HealthReady
+}
+
 // ServiceManager manages a group of background services.
+//
+// Services are started in dependency order, with services that do not depend on each other
+// being started in registration order, and are stopped in reverse order.
 type ServiceManager struct {
+	sync.Mutex
+
 	Ctx      context.Context
 	cancelFn context.CancelFunc
 	logger   *logging.Logger
 
-	services []service.BackgroundService
+	services []*managedService
 	termCh   chan service.BackgroundService
 	termSvc  service.BackgroundService
 
-	stopCh chan struct{}
+	stopping bool
+	stopCh   chan struct{}
 }
 
-// Register registers a background service.
-func (m *ServiceManager) Register(srv service.BackgroundService) {
-	m.services = append(m.services, srv)
+// Register registers a background service which depends on the given services.
+//
+// Dependencies need not be registered before the service, but must be registered by the time
+// Start is called.
+func (m *ServiceManager) Register(srv service.BackgroundService, deps ...service.BackgroundService) {
+	m.Lock()
+	defer m.Unlock()
+
+	ms := &managedService{
+		svc:  srv,
+		deps: deps,
+	}
+	m.services = append(m.services, ms)
+
 	go func() {
 		<-srv.Quit()
+
+		m.Lock()
+		ms.stopped = true
+		m.Unlock()
+
 		select {
 		case m.termCh <- srv:
 		default:
@@ -37,9 +91,174 @@ func (m *ServiceManager) Register(srv service.BackgroundService) {
 	}()
 }
 
+// AddDependencies declares additional dependencies of an already registered background service.
+//
+// This is useful in case a dependency can only be constructed after the service itself.
+func (m *ServiceManager) AddDependencies(srv service.BackgroundService, deps ...service.BackgroundService) error {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, ms := range m.services {
+		if ms.svc == srv && !ms.cleanupOnly {
+			ms.deps = append(ms.deps, deps...)
+			return nil
+		}
+	}
+	return fmt.Errorf("background: service '%s' not registered", srv.Name())
+}
+
 // RegisterCleanupOnly registers a cleanup only background service.
 func (m *ServiceManager) RegisterCleanupOnly(svc service.CleanupAble, name string) {
-	m.services = append(m.services, service.NewCleanupOnlyService(svc, name))
+	m.Lock()
+	defer m.Unlock()
+
+	m.services = append(m.services, &managedService{
+		svc:         service.NewCleanupOnlyService(svc, name),
+		cleanupOnly: true,
+	})
+}
+
+// startOrder returns the registered services in dependency order.
+//
+// Must be called with the lock held.
+func (m *ServiceManager) startOrder() ([]*managedService, error) {
+	index := make(map[service.BackgroundService]*managedService, len(m.services))
+	for _, ms := range m.services {
+		index[ms.svc] = ms
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	var (
+		order = make([]*managedService, 0, len(m.services))
+		state = make(map[*managedService]int, len(m.services))
+		visit func(ms *managedService) error
+	)
+	visit = func(ms *managedService) error {
+		switch state[ms] {
+		case visiting:
+			return fmt.Errorf("background: dependency cycle involving service '%s'", ms.svc.Name())
+		case visited:
+			return nil
+		}
+		state[ms] = visiting
+		for _, dep := range ms.deps {
+			md := index[dep]
+			if md == nil {
+				return fmt.Errorf("background: service '%s' depends on unregistered service '%s'",
+					ms.svc.Name(), dep.Name(),
+				)
+			}
+			if err := visit(md); err != nil {
+				return err
+			}
+		}
+		state[ms] = visited
+		order = append(order, ms)
+		return nil
+	}
+	for _, ms := range m.services {
+		if err := visit(ms); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// stopOrder returns the registered services in the order in which they should be stopped.
+//
+// Must be called with the lock held.
+func (m *ServiceManager) stopOrder() []*managedService {
+	order, err := m.startOrder()
+	if err != nil {
+		// Start would have failed, fall back to registration order.
+		order = append([]*managedService{}, m.services...)
+	}
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order
+}
+
+// Start starts all registered services that have not yet been started in dependency order.
+//
+// In case any of the services fails to start, no further services are started and an error
+// is returned.
+func (m *ServiceManager) Start() error {
+	m.Lock()
+	order, err := m.startOrder()
+	m.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for _, ms := range order {
+		m.Lock()
+		skip := ms.cleanupOnly || ms.started
+		m.Unlock()
+		if skip {
+			continue
+		}
+
+		m.logger.Debug("starting service",
+			"svc", ms.svc.Name(),
+		)
+		if err = ms.svc.Start(); err != nil {
+			m.logger.Error("failed to start service",
+				"err", err,
+				"svc", ms.svc.Name(),
+			)
+			return fmt.Errorf("background: failed to start service '%s': %w", ms.svc.Name(), err)
+		}
+
+		m.Lock()
+		ms.started = true
+		m.Unlock()
+	}
+	return nil
+}
+
+// Health returns the aggregate health of all registered services.
+//
+// The aggregate state is stopped once the manager is shutting down, degraded in case any of the
+// services is degraded or has terminated, initializing in case any of the services is still
+// initializing and ready otherwise.
+func (m *ServiceManager) Health() *service.Health {
+	m.Lock()
+	defer m.Unlock()
+
+	var initializing, degraded bool
+	health := &service.Health{State: service.HealthReady}
+	for _, ms := range m.services {
+		if ms.cleanupOnly {
+			continue
+		}
+
+		state := ms.health()
+		switch state {
+		case service.HealthInitializing:
+			initializing = true
+		case service.HealthDegraded, service.HealthStopped:
+			degraded = true
+		}
+		health.Services = append(health.Services, service.ServiceHealth{
+			Name:  ms.svc.Name(),
+			State: state,
+		})
+	}
+
+	switch {
+	case m.stopping:
+		health.State = service.HealthStopped
+	case degraded:
+		health.State = service.HealthDegraded
+	case initializing:
+		health.State = service.HealthInitializing
+	}
+	return health
 }
 
 // Wait waits for interruption via Stop, SIGINT, SIGTERM, or any of
@@ -60,9 +279,15 @@ func (m *ServiceManager) Wait() {
 	// Cancel the context before stopping the services.
 	m.cancelFn()
 
-	for _, svc := range m.services {
-		if svc != m.termSvc {
-			svc.Stop()
+	m.Lock()
+	m.stopping = true
+	order := m.stopOrder()
+	m.Unlock()
+
+	// Stop dependent services before their dependencies.
+	for _, ms := range order {
+		if ms.svc != m.termSvc {
+			ms.svc.Stop()
 		}
 	}
 }
@@ -77,11 +302,15 @@ func (m *ServiceManager) Stop() {
 func (m *ServiceManager) Cleanup() {
 	m.logger.Debug("beginning cleanup")
 
-	for _, svc := range m.services {
+	m.Lock()
+	services := append([]*managedService{}, m.services...)
+	m.Unlock()
+
+	for _, ms := range services {
 		m.logger.Debug("cleaning up",
-			"svc", svc.Name(),
+			"svc", ms.svc.Name(),
 		)
-		svc.Cleanup()
+		ms.svc.Cleanup()
 	}
 
 	m.logger.Debug("finished cleanup")
@@ -0,0 +1,130 @@
+package background
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/service"
+)
+
+type testService struct {
+	service.BaseBackgroundService
+
+	log    *[]string
+	initCh chan struct{}
+	health service.HealthState
+}
+
+func (s *testService) Start() error {
+	*s.log = append(*s.log, "start "+s.Name())
+	return nil
+}
+
+func (s *testService) Stop() {
+	*s.log = append(*s.log, "stop "+s.Name())
+}
+
+func (s *testService) Initialized() <-chan struct{} {
+	return s.initCh
+}
+
+func (s *testService) Health() service.HealthState {
+	return s.health
+}
+
+func newTestService(name string, log *[]string) *testService {
+	initCh := make(chan struct{})
+	close(initCh)
+
+	return &testService{
+		BaseBackgroundService: *service.NewBaseBackgroundService(name),
+		log:                   log,
+		initCh:                initCh,
+		health:                service.HealthReady,
+	}
+}
+
+func TestServiceManagerOrder(t *testing.T) {
+	require := require.New(t)
+
+	var log []string
+	a := newTestService("a", &log)
+	b := newTestService("b", &log)
+	c := newTestService("c", &log)
+	d := newTestService("d", &log)
+
+	m := NewServiceManager(logging.GetLogger("background/test"))
+	m.Register(a, b)
+	m.Register(b)
+	m.Register(c)
+	m.Register(d, c)
+	require.NoError(m.AddDependencies(c, b), "AddDependencies")
+
+	require.NoError(m.Start(), "Start")
+	require.Equal([]string{"start b", "start a", "start c", "start d"}, log, "services should start in dependency order")
+
+	// Starting again should not restart services.
+	require.NoError(m.Start(), "Start (again)")
+	require.Len(log, 4)
+
+	log = nil
+	m.Stop()
+	m.Wait()
+	require.Equal([]string{"stop d", "stop c", "stop a", "stop b"}, log, "services should stop in reverse order")
+}
+
+func TestServiceManagerInvalidDependencies(t *testing.T) {
+	require := require.New(t)
+
+	var log []string
+	a := newTestService("a", &log)
+	b := newTestService("b", &log)
+	c := newTestService("c", &log)
+
+	m := NewServiceManager(logging.GetLogger("background/test"))
+	m.Register(a, c)
+	require.Error(m.Start(), "Start should fail with unregistered dependency")
+	require.Empty(log, "no services should be started")
+
+	m = NewServiceManager(logging.GetLogger("background/test"))
+	m.Register(a, b)
+	m.Register(b, a)
+	require.Error(m.Start(), "Start should fail with dependency cycle")
+	require.Empty(log, "no services should be started")
+
+	require.Error(m.AddDependencies(c, a), "AddDependencies should fail for unregistered service")
+}
+
+func TestServiceManagerHealth(t *testing.T) {
+	require := require.New(t)
+
+	var log []string
+	a := newTestService("a", &log)
+	b := newTestService("b", &log)
+	b.initCh = make(chan struct{})
+
+	m := NewServiceManager(logging.GetLogger("background/test"))
+	m.Register(a)
+	m.Register(b)
+	m.RegisterCleanupOnly(service.NewBaseBackgroundService("cleanup"), "cleanup")
+
+	health := m.Health()
+	require.Equal(service.HealthInitializing, health.State, "services should be initializing before start")
+	require.Len(health.Services, 2, "cleanup only services should not be reported")
+
+	require.NoError(m.Start(), "Start")
+	health = m.Health()
+	require.Equal(service.HealthInitializing, health.State)
+	require.Equal(service.HealthReady, health.Services[0].State)
+	require.Equal(service.HealthInitializing, health.Services[1].State)
+
+	close(b.initCh)
+	require.Equal(service.HealthReady, m.Health().State, "all services should be ready")
+
+	a.health = service.HealthDegraded
+	health = m.Health()
+	require.Equal(service.HealthDegraded, health.State, "degraded services should degrade the aggregate")
+	require.Equal(service.HealthDegraded, health.Services[0].State)
+}
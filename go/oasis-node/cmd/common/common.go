@@ -138,10 +138,23 @@ func init() {
 // WARNING: This is exposed for the benefit of tests and the interface
 // is not guaranteed to be stable.
 func InitConfig() {
+	// Allow all options to be overridden via environment variables.
+	viper.SetEnvPrefix(ConfigEnvPrefix)
+	viper.SetEnvKeyReplacer(configEnvKeyReplacer)
+	viper.AutomaticEnv()
+
 	if cfgFile != "" {
 		// Read the config file if one is provided, otherwise
 		// it is assumed that the combination of default values,
 		// command line flags and env vars is sufficient.
+		//
+		// Unknown or malformed options are rejected so that typos
+		// do not silently result in default values being used.
+		if configRootCmd != nil {
+			if err := ValidateConfigFile(cfgFile); err != nil {
+				EarlyLogAndExit(err)
+			}
+		}
 		viper.SetConfigFile(cfgFile)
 		if err := viper.ReadInConfig(); err != nil {
 			EarlyLogAndExit(err)
@@ -0,0 +1,388 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+const (
+	// ConfigEnvPrefix is the prefix of environment variables that override configuration
+	// options, e.g., OASIS_NODE_DATADIR overrides the datadir option.
+	ConfigEnvPrefix = "OASIS_NODE"
+
+	// ConfigMapAnnotation is the flag annotation which marks flags that may also be specified
+	// as a map in the configuration file (e.g., per-module log levels).
+	ConfigMapAnnotation = "oasis_config_map"
+)
+
+var (
+	configRootCmd *cobra.Command
+
+	configEnvKeyReplacer = strings.NewReplacer(".", "_", "-", "_")
+)
+
+// SetConfigRootCommand sets the root command, the flags of which (including the flags of all
+// sub-commands) define the set of valid configuration options.
+func SetConfigRootCommand(cmd *cobra.Command) {
+	configRootCmd = cmd
+}
+
+// ConfigEnvName returns the name of the environment variable that overrides the given
+// configuration option.
+func ConfigEnvName(key string) string {
+	return ConfigEnvPrefix + "_" + strings.ToUpper(configEnvKeyReplacer.Replace(key))
+}
+
+// ConfigSchema returns all configuration options supported by the given command and its
+// sub-commands, keyed by option name.
+func ConfigSchema(root *cobra.Command) map[string]*flag.Flag {
+	schema := make(map[string]*flag.Flag)
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		for _, fs := range []*flag.FlagSet{cmd.PersistentFlags(), cmd.Flags()} {
+			fs.VisitAll(func(f *flag.Flag) {
+				schema[f.Name] = f
+			})
+		}
+		for _, sub := range cmd.Commands() {
+			walk(sub)
+		}
+	}
+	walk(root)
+
+	// Meta options do not make sense in a configuration file.
+	for _, name := range []string{cfgConfigFile, "help", "version"} {
+		delete(schema, name)
+	}
+	return schema
+}
+
+// ValidateConfigFile validates the given configuration file against the configuration schema of
+// the root command. All detected problems are reported.
+func ValidateConfigFile(fn string) error {
+	if configRootCmd == nil {
+		return fmt.Errorf("config: root command not configured")
+	}
+
+	v := viper.New()
+	v.SetConfigFile(fn)
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("config: failed to read config file: %w", err)
+	}
+	return ValidateConfig(ConfigSchema(configRootCmd), v.AllSettings())
+}
+
+// ValidateConfig validates the given (nested) configuration settings against the given
+// configuration schema. All detected problems are reported.
+func ValidateConfig(schema map[string]*flag.Flag, settings map[string]interface{}) error {
+	var errs *multierror.Error
+	validateConfigMap(schema, "", settings, &errs)
+	if errs != nil {
+		errs.ErrorFormat = func(es []error) string {
+			points := make([]string, len(es))
+			for i, err := range es {
+				points[i] = "  * " + err.Error()
+			}
+			return fmt.Sprintf("config: %d invalid option(s):\n%s", len(es), strings.Join(points, "\n"))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+func validateConfigMap(schema map[string]*flag.Flag, prefix string, settings map[string]interface{}, errs **multierror.Error) {
+	keys := make([]string, 0, len(settings))
+	for k := range settings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		value := settings[k]
+
+		if f := schema[key]; f != nil {
+			if err := validateConfigValue(f, value); err != nil {
+				*errs = multierror.Append(*errs, fmt.Errorf("%s: %w", key, err))
+			}
+			continue
+		}
+
+		nested, ok := toStringMap(value)
+		if !ok || !hasConfigPrefix(schema, key) {
+			*errs = multierror.Append(*errs, fmt.Errorf("%s: unknown option", key))
+			continue
+		}
+		validateConfigMap(schema, key, nested, errs)
+	}
+}
+
+func hasConfigPrefix(schema map[string]*flag.Flag, prefix string) bool {
+	prefix += "."
+	for name := range schema {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func toStringMap(value interface{}) (map[string]interface{}, bool) {
+	switch m := value.(type) {
+	case map[string]interface{}:
+		return m, true
+	case map[interface{}]interface{}:
+		res := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			res[fmt.Sprintf("%v", k)] = v
+		}
+		return res, true
+	default:
+		return nil, false
+	}
+}
+
+func isConfigScalar(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		return false
+	default:
+		return true
+	}
+}
+
+func validateConfigValue(f *flag.Flag, value interface{}) error {
+	typ := f.Value.Type()
+
+	if m, ok := toStringMap(value); ok {
+		if strings.HasPrefix(typ, "stringTo") || len(f.Annotations[ConfigMapAnnotation]) > 0 {
+			for k, v := range m {
+				if !isConfigScalar(v) {
+					return fmt.Errorf("entry '%s' must be a scalar value", k)
+				}
+			}
+			return nil
+		}
+		return fmt.Errorf("expected a %s value, got a map", typ)
+	}
+
+	switch {
+	case strings.HasPrefix(typ, "stringTo"):
+		// Maps can also be specified in flag format (k1=v1,k2=v2).
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected a map")
+		}
+		for _, pair := range splitListDefault(s) {
+			if !strings.Contains(pair, "=") {
+				return fmt.Errorf("invalid map entry '%s' (expected key=value)", pair)
+			}
+		}
+		return nil
+	case strings.HasSuffix(typ, "Slice") || strings.HasSuffix(typ, "Array"):
+		// Slices can be specified as a list or as a string.
+		switch reflect.ValueOf(value).Kind() {
+		case reflect.Slice, reflect.Array:
+			elemTyp := strings.TrimSuffix(strings.TrimSuffix(typ, "Slice"), "Array")
+			rv := reflect.ValueOf(value)
+			for i := 0; i < rv.Len(); i++ {
+				if err := validateConfigScalar(elemTyp, rv.Index(i).Interface()); err != nil {
+					return fmt.Errorf("element %d: %w", i, err)
+				}
+			}
+			return nil
+		case reflect.String:
+			return nil
+		default:
+			return fmt.Errorf("expected a list")
+		}
+	default:
+		return validateConfigScalar(typ, value)
+	}
+}
+
+func validateConfigScalar(typ string, value interface{}) error {
+	if !isConfigScalar(value) {
+		return fmt.Errorf("expected a %s value", typ)
+	}
+	s := fmt.Sprintf("%v", value)
+
+	var err error
+	switch typ {
+	case "bool":
+		_, err = strconv.ParseBool(s)
+	case "int", "int8", "int16", "int32", "int64":
+		_, err = strconv.ParseInt(s, 0, bitSize(typ, "int"))
+	case "uint", "uint8", "uint16", "uint32", "uint64":
+		_, err = strconv.ParseUint(s, 0, bitSize(typ, "uint"))
+	case "float32", "float64":
+		var fv float64
+		fv, err = strconv.ParseFloat(s, bitSize(typ, "float"))
+		if err == nil && (math.IsNaN(fv) || math.IsInf(fv, 0)) {
+			err = fmt.Errorf("not a finite number")
+		}
+	case "duration":
+		if _, ok := value.(string); ok {
+			_, err = time.ParseDuration(s)
+		} else {
+			// Plain numbers are interpreted as nanoseconds.
+			_, err = strconv.ParseInt(s, 10, 64)
+		}
+	default:
+		// Strings and custom flag types accept any scalar value, the latter are validated by the
+		// corresponding subsystem during initialization.
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s value '%s'", typ, s)
+	}
+	return nil
+}
+
+func bitSize(typ, prefix string) int {
+	switch strings.TrimPrefix(typ, prefix) {
+	case "8":
+		return 8
+	case "16":
+		return 16
+	case "32":
+		return 32
+	default:
+		return 64
+	}
+}
+
+// WriteConfigTemplate writes a YAML configuration file template containing all non-hidden
+// options of the given flag sets together with their default values and descriptions.
+func WriteConfigTemplate(w io.Writer, flagSets ...*flag.FlagSet) error {
+	root := &configTemplateNode{}
+	for _, fs := range flagSets {
+		fs.VisitAll(func(f *flag.Flag) {
+			if f.Hidden || f.Deprecated != "" || f.Name == cfgConfigFile || f.Name == "help" {
+				return
+			}
+			root.insert(strings.Split(f.Name, "."), f)
+		})
+	}
+
+	fmt.Fprintln(w, "# Oasis node configuration file.")
+	fmt.Fprintln(w, "#")
+	fmt.Fprintln(w, "# All options are set to their default values. Options can also be overridden")
+	fmt.Fprintf(w, "# via environment variables (e.g., %s) and command line flags.\n", ConfigEnvName(CfgDataDir))
+	return root.write(w, 0)
+}
+
+type configTemplateNode struct {
+	flag     *flag.Flag
+	children map[string]*configTemplateNode
+}
+
+func (n *configTemplateNode) insert(path []string, f *flag.Flag) {
+	if len(path) == 0 {
+		n.flag = f
+		return
+	}
+	if n.children == nil {
+		n.children = make(map[string]*configTemplateNode)
+	}
+	child := n.children[path[0]]
+	if child == nil {
+		child = &configTemplateNode{}
+		n.children[path[0]] = child
+	}
+	child.insert(path[1:], f)
+}
+
+func (n *configTemplateNode) write(w io.Writer, depth int) error {
+	indent := strings.Repeat("  ", depth)
+
+	names := make([]string, 0, len(n.children))
+	for name := range n.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		child := n.children[name]
+		if child.flag != nil {
+			fmt.Fprintf(w, "\n%s# %s\n", indent, child.flag.Usage)
+			if child.children != nil {
+				// Options that are also a prefix of other options cannot be represented in the
+				// nested form, so these can only be set via flags or environment variables.
+				fmt.Fprintf(w, "%s# %s: %s (only configurable via %s)\n", indent, name, configTemplateValue(child.flag), ConfigEnvName(child.flag.Name))
+			} else {
+				fmt.Fprintf(w, "%s%s: %s\n", indent, name, configTemplateValue(child.flag))
+			}
+		}
+		if child.children != nil {
+			if depth == 0 {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintf(w, "%s%s:\n", indent, name)
+			if err := child.write(w, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func configTemplateValue(f *flag.Flag) string {
+	typ := f.Value.Type()
+	def := f.DefValue
+
+	switch {
+	case strings.HasPrefix(typ, "stringTo"):
+		items := splitListDefault(def)
+		if len(items) == 0 {
+			return "{}"
+		}
+		pairs := make([]string, 0, len(items))
+		for _, item := range items {
+			kv := strings.SplitN(item, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			pairs = append(pairs, fmt.Sprintf("%s: %s", strconv.Quote(kv[0]), strconv.Quote(kv[1])))
+		}
+		return "{" + strings.Join(pairs, ", ") + "}"
+	case strings.HasSuffix(typ, "Slice") || strings.HasSuffix(typ, "Array"):
+		items := splitListDefault(def)
+		if strings.HasPrefix(typ, "string") {
+			for i := range items {
+				items[i] = strconv.Quote(items[i])
+			}
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+
+	switch typ {
+	case "bool", "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
+		return def
+	default:
+		return strconv.Quote(def)
+	}
+}
+
+func splitListDefault(def string) []string {
+	def = strings.TrimSuffix(strings.TrimPrefix(def, "["), "]")
+	if def == "" {
+		return nil
+	}
+	return strings.Split(def, ",")
+}
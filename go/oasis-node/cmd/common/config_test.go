@@ -0,0 +1,109 @@
+package common
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfigFlags() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.String("datadir", "", "data directory")
+	fs.Bool("consensus.validator", false, "node is a consensus validator")
+	fs.Uint16("grpc.port", 9001, "gRPC port")
+	fs.Duration("worker.timeout", 5*time.Second, "worker timeout")
+	fs.StringSlice("p2p.seeds", []string{"a@127.0.0.1:26656"}, "seed nodes")
+	fs.StringToString("worker.runtime.paths", nil, "runtime paths")
+	fs.String("log.level", "info", "log level")
+	_ = fs.SetAnnotation("log.level", ConfigMapAnnotation, []string{"true"})
+	return fs
+}
+
+func testConfigSchema(fs *flag.FlagSet) map[string]*flag.Flag {
+	schema := make(map[string]*flag.Flag)
+	fs.VisitAll(func(f *flag.Flag) {
+		schema[f.Name] = f
+	})
+	return schema
+}
+
+func loadTestConfig(t *testing.T, raw string) map[string]interface{} {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	require.NoError(t, v.ReadConfig(bytes.NewBufferString(raw)), "ReadConfig")
+	return v.AllSettings()
+}
+
+func TestValidateConfig(t *testing.T) {
+	require := require.New(t)
+
+	schema := testConfigSchema(testConfigFlags())
+
+	for _, tc := range []struct {
+		msg   string
+		raw   string
+		valid bool
+	}{
+		{"empty", "", true},
+		{"valid", `
+datadir: /node/data
+consensus:
+  validator: true
+grpc:
+  port: 1234
+worker:
+  timeout: 10s
+  runtime:
+    paths:
+      "8000000000000000000000000000000000000000000000000000000000000000": /runtime
+p2p:
+  seeds:
+    - b@127.0.0.1:26656
+log:
+  level:
+    default: info
+    tendermint: warn
+`, true},
+		{"unknown option", "datadirr: /node/data", false},
+		{"unknown nested option", "consensus:\n  validatorr: true", false},
+		{"malformed bool", "consensus:\n  validator: maybe", false},
+		{"out of range integer", "grpc:\n  port: 100000", false},
+		{"malformed duration", "worker:\n  timeout: soon", false},
+		{"map for scalar", "datadir:\n  foo: bar", false},
+		{"list for scalar", "datadir: [a, b]", false},
+		{"scalar for list", "p2p:\n  seeds: a@127.0.0.1:26656", true},
+		{"malformed map string", "worker:\n  runtime:\n    paths: foo", false},
+	} {
+		err := ValidateConfig(schema, loadTestConfig(t, tc.raw))
+		if tc.valid {
+			require.NoError(err, tc.msg)
+		} else {
+			require.Error(err, tc.msg)
+		}
+	}
+}
+
+func TestWriteConfigTemplate(t *testing.T) {
+	require := require.New(t)
+
+	fs := testConfigFlags()
+
+	var buf bytes.Buffer
+	err := WriteConfigTemplate(&buf, fs)
+	require.NoError(err, "WriteConfigTemplate")
+
+	// The generated template must be valid and must contain the defaults.
+	settings := loadTestConfig(t, buf.String())
+	require.NoError(ValidateConfig(testConfigSchema(fs), settings), "generated template should be valid")
+
+	v := viper.New()
+	require.NoError(v.MergeConfigMap(settings), "MergeConfigMap")
+	require.EqualValues(9001, v.GetInt("grpc.port"))
+	require.Equal(5*time.Second, v.GetDuration("worker.timeout"))
+	require.Equal([]string{"a@127.0.0.1:26656"}, v.GetStringSlice("p2p.seeds"))
+	require.Equal("info", v.GetString("log.level"))
+}
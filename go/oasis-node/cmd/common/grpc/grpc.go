@@ -3,10 +3,13 @@ package grpc
 
 import (
 	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
@@ -14,6 +17,7 @@ import (
 	"google.golang.org/grpc"
 
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/common/grpc/auth"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
@@ -29,6 +33,14 @@ const (
 	CfgWait = "wait"
 	// CfgDebugGrpcInternalSocketPath sets custom internal socket path.
 	CfgDebugGrpcInternalSocketPath = "debug.grpc.internal.socket_path"
+	// CfgInternalSocketMode configures the file mode of the internal socket.
+	CfgInternalSocketMode = "grpc.internal.socket_mode"
+	// CfgInternalAllowedUIDs configures the local users allowed to use the internal socket.
+	CfgInternalAllowedUIDs = "grpc.internal.allowed_uids"
+	// CfgInternalAllowedGIDs configures the local groups allowed to use the internal socket.
+	CfgInternalAllowedGIDs = "grpc.internal.allowed_gids"
+	// CfgInternalPolicy configures the path to the per-method internal socket access policy.
+	CfgInternalPolicy = "grpc.internal.policy"
 
 	// LocalSocketFilename is the filename of the unix socket in node datadir.
 	LocalSocketFilename = "internal.sock"
@@ -84,10 +96,54 @@ func NewServerLocal(installWrapper bool) (*cmnGrpc.Server, error) {
 		Path:           path,
 		InstallWrapper: installWrapper,
 	}
+	if mode := viper.GetString(CfgInternalSocketMode); mode != "" {
+		m, err := strconv.ParseUint(mode, 8, 32)
+		if err != nil {
+			return nil, fmt.Errorf("malformed internal socket mode: %w", err)
+		}
+		config.PathMode = os.FileMode(m)
+	}
+	policy, err := internalPeerCredPolicy()
+	if err != nil {
+		return nil, err
+	}
+	config.PeerCredPolicy = policy
 
 	return cmnGrpc.NewServer(config)
 }
 
+func internalPeerCredPolicy() (*auth.PeerCredPolicy, error) {
+	var policy auth.PeerCredPolicy
+	if fn := viper.GetString(CfgInternalPolicy); fn != "" {
+		raw, err := ioutil.ReadFile(fn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read internal socket policy: %w", err)
+		}
+		if err = json.Unmarshal(raw, &policy); err != nil {
+			return nil, fmt.Errorf("malformed internal socket policy: %w", err)
+		}
+	}
+
+	// The allowed users and groups configure the default access list.
+	uids, gids := viper.GetIntSlice(CfgInternalAllowedUIDs), viper.GetIntSlice(CfgInternalAllowedGIDs)
+	if len(uids) > 0 || len(gids) > 0 {
+		if policy.Default == nil {
+			policy.Default = &auth.PeerCredAccessList{}
+		}
+		for _, uid := range uids {
+			policy.Default.UIDs = append(policy.Default.UIDs, uint32(uid))
+		}
+		for _, gid := range gids {
+			policy.Default.GIDs = append(policy.Default.GIDs, uint32(gid))
+		}
+	}
+
+	if policy.Default == nil && len(policy.Methods) == 0 {
+		return nil, nil
+	}
+	return &policy, nil
+}
+
 func NewClient(cmd *cobra.Command) (*grpc.ClientConn, error) {
 	addr, _ := cmd.Flags().GetString(CfgAddress)
 
@@ -119,6 +175,10 @@ func init() {
 
 	ServerLocalFlags.String(CfgDebugGrpcInternalSocketPath, "", "use custom internal unix socket path")
 	_ = ServerLocalFlags.MarkHidden(CfgDebugGrpcInternalSocketPath)
+	ServerLocalFlags.String(CfgInternalSocketMode, "", "internal unix socket file mode in octal (e.g., 0660)")
+	ServerLocalFlags.IntSlice(CfgInternalAllowedUIDs, []int{}, "local users (besides the node's user) allowed to use the internal socket")
+	ServerLocalFlags.IntSlice(CfgInternalAllowedGIDs, []int{}, "local groups allowed to use the internal socket")
+	ServerLocalFlags.String(CfgInternalPolicy, "", "path to the per-method internal socket access policy (JSON)")
 	_ = viper.BindPFlags(ServerLocalFlags)
 	ServerLocalFlags.AddFlagSet(cmnGrpc.Flags)
 
@@ -69,6 +69,8 @@ func initLoggingFlags() {
 	loggingFlags.String(cfgLogFile, "", "log file")
 	loggingFlags.Var(&logFmt, cfgLogFmt, "log format")
 	loggingFlags.Var(&logLevel, cfgLogLevel, "log level")
+	// Per-module log levels can be configured by specifying a map.
+	_ = loggingFlags.SetAnnotation(cfgLogLevel, ConfigMapAnnotation, []string{"true"})
 
 	_ = viper.BindPFlags(loggingFlags)
 }
@@ -3,6 +3,7 @@ package pprof
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -18,7 +19,11 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/service"
 )
 
-const cfgPprofBind = "pprof.bind"
+const (
+	cfgPprofBind                 = "pprof.bind"
+	cfgPprofMutexProfileFraction = "pprof.mutex_profile_fraction"
+	cfgPprofBlockProfileRate     = "pprof.block_profile_rate"
+)
 
 // Flags has the flags used by the pprof service.
 var Flags = flag.NewFlagSet("", flag.ContinueOnError)
@@ -79,6 +84,7 @@ func (p *pprofService) Start() error {
 	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
 	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
 	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
 
 	p.listener = listener
 	p.server = &http.Server{Handler: mux}
@@ -120,6 +126,12 @@ func (p *pprofService) Cleanup() {
 func New(ctx context.Context) (service.BackgroundService, error) {
 	address := viper.GetString(cfgPprofBind)
 
+	// Mutex and block profiles are only populated when enabled. Do this
+	// even if the profiling endpoint is disabled, as the profiles can also
+	// be collected via diagnostics bundles.
+	runtime.SetMutexProfileFraction(viper.GetInt(cfgPprofMutexProfileFraction))
+	runtime.SetBlockProfileRate(viper.GetInt(cfgPprofBlockProfileRate))
+
 	return &pprofService{
 		BaseBackgroundService: *service.NewBaseBackgroundService("pprof"),
 		address:               address,
@@ -130,6 +142,8 @@ func New(ctx context.Context) (service.BackgroundService, error) {
 
 func init() {
 	Flags.String(cfgPprofBind, "", "enable profiling endpoint at given address")
+	Flags.Int(cfgPprofMutexProfileFraction, 0, "report 1/n of mutex contention events in the mutex profile (0 disables)")
+	Flags.Int(cfgPprofBlockProfileRate, 0, "sample one blocking event per n nanoseconds spent blocked in the block profile (0 disables)")
 
 	_ = viper.BindPFlags(Flags)
 }
@@ -0,0 +1,69 @@
+// Package config implements the configuration file sub-commands.
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+)
+
+var (
+	rootCmd *cobra.Command
+
+	configCmd = &cobra.Command{
+		Use:   "config",
+		Short: "configuration file utilities",
+	}
+
+	configInitCmd = &cobra.Command{
+		Use:   "init [<config-file>]",
+		Short: "generate a configuration file with all node options set to defaults",
+		Long: `Generate a YAML configuration file containing all node options set to
+their default values. The file is written to standard output unless a path is
+given.`,
+		Args: cobra.MaximumNArgs(1),
+		Run:  doInit,
+	}
+
+	configValidateCmd = &cobra.Command{
+		Use:   "validate <config-file>",
+		Short: "validate a configuration file",
+		Args:  cobra.ExactArgs(1),
+		Run:   doValidate,
+	}
+)
+
+func doInit(cmd *cobra.Command, args []string) {
+	w := os.Stdout
+	if len(args) > 0 {
+		f, err := os.OpenFile(args[0], os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err != nil {
+			cmdCommon.EarlyLogAndExit(fmt.Errorf("config: failed to create config file: %w", err))
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := cmdCommon.WriteConfigTemplate(w, rootCmd.PersistentFlags(), rootCmd.Flags()); err != nil {
+		cmdCommon.EarlyLogAndExit(fmt.Errorf("config: failed to write config file: %w", err))
+	}
+}
+
+func doValidate(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.ValidateConfigFile(args[0]); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+	fmt.Println("Configuration file is valid.")
+}
+
+// Register registers the config sub-command and all of it's children.
+func Register(parentCmd *cobra.Command) {
+	rootCmd = parentCmd
+
+	configCmd.AddCommand(configInitCmd)
+	configCmd.AddCommand(configValidateCmd)
+	parentCmd.AddCommand(configCmd)
+}
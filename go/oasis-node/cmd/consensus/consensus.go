@@ -54,6 +54,12 @@ var (
 		Run:   doEstimateGas,
 	}
 
+	exportAddressBookCmd = &cobra.Command{
+		Use:   "export_address_book",
+		Short: "Export the consensus P2P address book of a seed node",
+		Run:   doExportAddressBook,
+	}
+
 	logger = logging.GetLogger("cmd/consensus")
 )
 
@@ -172,12 +178,39 @@ func doEstimateGas(cmd *cobra.Command, args []string) {
 	fmt.Println(gas)
 }
 
+func doExportAddressBook(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	conn, client := doConnect(cmd)
+	defer conn.Close()
+
+	book, err := client.GetAddressBook(context.Background())
+	if err != nil {
+		logger.Error("failed to export address book",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	data, err := json.MarshalIndent(book, "", "  ")
+	if err != nil {
+		logger.Error("failed to marshal address book",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(data))
+}
+
 // Register registers the consensus sub-command and all of it's children.
 func Register(parentCmd *cobra.Command) {
 	for _, v := range []*cobra.Command{
 		submitTxCmd,
 		showTxCmd,
 		estimateGasCmd,
+		exportAddressBookCmd,
 	} {
 		consensusCmd.AddCommand(v)
 	}
@@ -192,5 +225,7 @@ func Register(parentCmd *cobra.Command) {
 	estimateGasCmd.Flags().AddFlagSet(cmdConsensus.TxFileFlags)
 	estimateGasCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
 
+	exportAddressBookCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
+
 	parentCmd.AddCommand(consensusCmd)
 }
@@ -0,0 +1,60 @@
+package control
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	controlCommitteePeersCmd = &cobra.Command{
+		Use:   "committee-peers",
+		Short: "manage the node's runtime committee P2P peer bans",
+	}
+
+	controlCommitteePeersListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "list penalized and banned runtime committee P2P peers",
+		Run:   doCommitteePeersList,
+	}
+
+	controlCommitteePeersUnbanCmd = &cobra.Command{
+		Use:   "unban <ID>",
+		Short: "remove the ban on a runtime committee P2P peer",
+		Args:  cobra.ExactArgs(1),
+		Run:   doCommitteePeersUnban,
+	}
+)
+
+func doCommitteePeersList(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	scores, err := client.GetCommitteePeerScores(context.Background())
+	if err != nil {
+		logger.Error("failed to get committee peer scores",
+			"err", err,
+		)
+		os.Exit(128)
+	}
+	printJSON(scores)
+}
+
+func doCommitteePeersUnban(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	if err := client.UnbanCommitteePeer(context.Background(), args[0]); err != nil {
+		logger.Error("failed to unban committee peer",
+			"err", err,
+		)
+		os.Exit(128)
+	}
+}
+
+func registerCommitteePeersCmd() {
+	controlCommitteePeersCmd.AddCommand(controlCommitteePeersListCmd)
+	controlCommitteePeersCmd.AddCommand(controlCommitteePeersUnbanCmd)
+	controlCmd.AddCommand(controlCommitteePeersCmd)
+}
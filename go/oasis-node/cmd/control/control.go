@@ -63,6 +63,18 @@ var (
 		Run:   doStatus,
 	}
 
+	controlSignatureContextsCmd = &cobra.Command{
+		Use:   "signature-contexts",
+		Short: "list signature contexts registered by the node",
+		Run:   doSignatureContexts,
+	}
+
+	controlCaptureDiagnosticsCmd = &cobra.Command{
+		Use:   "capture-diagnostics",
+		Short: "capture a diagnostics bundle on the node",
+		Run:   doCaptureDiagnostics,
+	}
+
 	logger = logging.GetLogger("cmd/control")
 )
 
@@ -85,6 +97,17 @@ func DoConnect(cmd *cobra.Command) (*grpc.ClientConn, control.NodeController) {
 	return conn, client
 }
 
+func printJSON(v interface{}) {
+	formatted, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		logger.Error("failed to format result",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(formatted))
+}
+
 func doIsSynced(cmd *cobra.Command, args []string) {
 	conn, client := DoConnect(cmd)
 	defer conn.Close()
@@ -207,6 +230,54 @@ func doStatus(cmd *cobra.Command, args []string) {
 	fmt.Println(string(formatted))
 }
 
+func doSignatureContexts(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	logger.Debug("querying signature contexts")
+
+	// Use background context to block until the result comes in.
+	contexts, err := client.GetSignatureContexts(context.Background())
+	if err != nil {
+		logger.Error("failed to query signature contexts",
+			"err", err,
+		)
+		os.Exit(128)
+	}
+	formatted, err := json.MarshalIndent(contexts, "", "  ")
+	if err != nil {
+		logger.Error("failed to format signature contexts",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(formatted))
+}
+
+func doCaptureDiagnostics(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	logger.Debug("capturing diagnostics")
+
+	// Use background context to block until the result comes in.
+	bundle, err := client.CaptureDiagnostics(context.Background())
+	if err != nil {
+		logger.Error("failed to capture diagnostics",
+			"err", err,
+		)
+		os.Exit(128)
+	}
+	formatted, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		logger.Error("failed to format diagnostics bundle",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(formatted))
+}
+
 // Register registers the client sub-command and all of it's children.
 func Register(parentCmd *cobra.Command) {
 	controlCmd.PersistentFlags().AddFlagSet(cmdGrpc.ClientFlags)
@@ -219,5 +290,11 @@ func Register(parentCmd *cobra.Command) {
 	controlCmd.AddCommand(controlUpgradeBinaryCmd)
 	controlCmd.AddCommand(controlCancelUpgradeCmd)
 	controlCmd.AddCommand(controlStatusCmd)
+	controlCmd.AddCommand(controlSignatureContextsCmd)
+	controlCmd.AddCommand(controlCaptureDiagnosticsCmd)
+	registerWatchCmd()
+	registerPeersCmd()
+	registerSignerCmd()
+	registerCommitteePeersCmd()
 	parentCmd.AddCommand(controlCmd)
 }
@@ -0,0 +1,100 @@
+package control
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	controlPeersCmd = &cobra.Command{
+		Use:   "peers",
+		Short: "manage the node's consensus peers",
+	}
+
+	controlPeersListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "list connected and banned consensus peers",
+		Run:   doPeersList,
+	}
+
+	controlPeersAddCmd = &cobra.Command{
+		Use:   "add <ID@ip:port>",
+		Short: "add and dial a persistent consensus peer",
+		Args:  cobra.ExactArgs(1),
+		Run:   doPeersAdd,
+	}
+
+	controlPeersBanCmd = &cobra.Command{
+		Use:   "ban <ID>",
+		Short: "disconnect and ban a consensus peer",
+		Args:  cobra.ExactArgs(1),
+		Run:   doPeersBan,
+	}
+
+	controlPeersUnbanCmd = &cobra.Command{
+		Use:   "unban <ID>",
+		Short: "remove the ban on a consensus peer",
+		Args:  cobra.ExactArgs(1),
+		Run:   doPeersUnban,
+	}
+)
+
+func doPeersList(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	peers, err := client.GetConsensusPeers(context.Background())
+	if err != nil {
+		logger.Error("failed to get consensus peers",
+			"err", err,
+		)
+		os.Exit(128)
+	}
+	printJSON(peers)
+}
+
+func doPeersAdd(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	if err := client.AddConsensusPersistentPeer(context.Background(), args[0]); err != nil {
+		logger.Error("failed to add consensus persistent peer",
+			"err", err,
+		)
+		os.Exit(128)
+	}
+}
+
+func doPeersBan(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	if err := client.BanConsensusPeer(context.Background(), args[0]); err != nil {
+		logger.Error("failed to ban consensus peer",
+			"err", err,
+		)
+		os.Exit(128)
+	}
+}
+
+func doPeersUnban(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	if err := client.UnbanConsensusPeer(context.Background(), args[0]); err != nil {
+		logger.Error("failed to unban consensus peer",
+			"err", err,
+		)
+		os.Exit(128)
+	}
+}
+
+func registerPeersCmd() {
+	controlPeersCmd.AddCommand(controlPeersListCmd)
+	controlPeersCmd.AddCommand(controlPeersAddCmd)
+	controlPeersCmd.AddCommand(controlPeersBanCmd)
+	controlPeersCmd.AddCommand(controlPeersUnbanCmd)
+	controlCmd.AddCommand(controlPeersCmd)
+}
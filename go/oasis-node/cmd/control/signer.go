@@ -0,0 +1,101 @@
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+)
+
+var (
+	controlSignerCmd = &cobra.Command{
+		Use:   "signer",
+		Short: "hand off the node's consensus signing role",
+	}
+
+	controlSignerStatusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "show the consensus signer status",
+		Run:   doSignerStatus,
+	}
+
+	controlSignerReleaseCmd = &cobra.Command{
+		Use:   "release",
+		Short: "put the consensus signer into standby and output a signed handoff",
+		Run:   doSignerRelease,
+	}
+
+	controlSignerAcquireCmd = &cobra.Command{
+		Use:   "acquire <handoff.json>",
+		Short: "activate the consensus signer using a signed handoff",
+		Args:  cobra.ExactArgs(1),
+		Run:   doSignerAcquire,
+	}
+)
+
+func doSignerStatus(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	status, err := client.GetConsensusSignerStatus(context.Background())
+	if err != nil {
+		logger.Error("failed to get consensus signer status",
+			"err", err,
+		)
+		os.Exit(128)
+	}
+	printJSON(status)
+}
+
+func doSignerRelease(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	handoff, err := client.ReleaseConsensusSigner(context.Background())
+	if err != nil {
+		logger.Error("failed to release consensus signer",
+			"err", err,
+		)
+		os.Exit(128)
+	}
+	printJSON(handoff)
+}
+
+func doSignerAcquire(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	raw, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		logger.Error("failed to read signer handoff",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	var handoff consensus.SignedSignerHandoff
+	if err = json.Unmarshal(raw, &handoff); err != nil {
+		logger.Error("can't parse signer handoff",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	if err = client.AcquireConsensusSigner(context.Background(), &handoff); err != nil {
+		logger.Error("failed to acquire consensus signer",
+			"err", err,
+		)
+		os.Exit(128)
+	}
+}
+
+func registerSignerCmd() {
+	controlSignerCmd.AddCommand(controlSignerStatusCmd)
+	controlSignerCmd.AddCommand(controlSignerReleaseCmd)
+	controlSignerCmd.AddCommand(controlSignerAcquireCmd)
+	controlCmd.AddCommand(controlSignerCmd)
+}
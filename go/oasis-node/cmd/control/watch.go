@@ -0,0 +1,132 @@
+package control
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	control "github.com/oasisprotocol/oasis-core/go/control/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+var (
+	watchHistoryFromHeight int64
+	watchHistoryToHeight   int64
+
+	controlWatchCmd = &cobra.Command{
+		Use:   "watch",
+		Short: "manage staking accounts whose history is retained regardless of pruning",
+	}
+
+	controlWatchListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "list watched staking account addresses",
+		Run:   doWatchList,
+	}
+
+	controlWatchAddCmd = &cobra.Command{
+		Use:   "add <address>",
+		Short: "start watching a staking account address",
+		Args:  cobra.ExactArgs(1),
+		Run:   doWatchAdd,
+	}
+
+	controlWatchRemoveCmd = &cobra.Command{
+		Use:   "remove <address>",
+		Short: "stop watching a staking account address",
+		Args:  cobra.ExactArgs(1),
+		Run:   doWatchRemove,
+	}
+
+	controlWatchHistoryCmd = &cobra.Command{
+		Use:   "history <address>",
+		Short: "show the retained history of a watched staking account",
+		Args:  cobra.ExactArgs(1),
+		Run:   doWatchHistory,
+	}
+)
+
+func parseWatchAddress(raw string) staking.Address {
+	var addr staking.Address
+	if err := addr.UnmarshalText([]byte(raw)); err != nil {
+		logger.Error("failed to parse staking account address",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	return addr
+}
+
+func doWatchList(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	addrs, err := client.GetWatchedAddresses(context.Background())
+	if err != nil {
+		logger.Error("failed to get watched addresses",
+			"err", err,
+		)
+		os.Exit(128)
+	}
+	printJSON(addrs)
+}
+
+func doWatchAdd(cmd *cobra.Command, args []string) {
+	addr := parseWatchAddress(args[0])
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	if err := client.AddWatchedAddress(context.Background(), addr); err != nil {
+		logger.Error("failed to add watched address",
+			"err", err,
+		)
+		os.Exit(128)
+	}
+}
+
+func doWatchRemove(cmd *cobra.Command, args []string) {
+	addr := parseWatchAddress(args[0])
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	if err := client.RemoveWatchedAddress(context.Background(), addr); err != nil {
+		logger.Error("failed to remove watched address",
+			"err", err,
+		)
+		os.Exit(128)
+	}
+}
+
+func doWatchHistory(cmd *cobra.Command, args []string) {
+	addr := parseWatchAddress(args[0])
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	history, err := client.GetWatchedAccountHistory(context.Background(), &control.WatchedAccountHistoryQuery{
+		Address:    addr,
+		FromHeight: watchHistoryFromHeight,
+		ToHeight:   watchHistoryToHeight,
+	})
+	if err != nil {
+		logger.Error("failed to get watched account history",
+			"err", err,
+		)
+		os.Exit(128)
+	}
+	printJSON(history)
+}
+
+func registerWatchCmd() {
+	controlWatchHistoryCmd.Flags().Int64Var(&watchHistoryFromHeight, "from", 0, "first height to show history for")
+	controlWatchHistoryCmd.Flags().Int64Var(&watchHistoryToHeight, "to", 0, "last height to show history for (0 means latest)")
+
+	controlWatchCmd.AddCommand(controlWatchListCmd)
+	controlWatchCmd.AddCommand(controlWatchAddCmd)
+	controlWatchCmd.AddCommand(controlWatchRemoveCmd)
+	controlWatchCmd.AddCommand(controlWatchHistoryCmd)
+	controlCmd.AddCommand(controlWatchCmd)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/consim"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/control"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/dumpdb"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/executor"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/fixgenesis"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/storage"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/txsource"
@@ -27,6 +28,7 @@ func Register(parentCmd *cobra.Command) {
 	control.Register(debugCmd)
 	consim.Register(debugCmd)
 	dumpdb.Register(debugCmd)
+	executor.Register(debugCmd)
 
 	parentCmd.AddCommand(debugCmd)
 }
@@ -53,7 +53,8 @@ var (
 		Run:   doDumpDB,
 	}
 
-	dumpDBFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	dumpDBFlags  = flag.NewFlagSet("", flag.ContinueOnError)
+	stateDBFlags = flag.NewFlagSet("", flag.ContinueOnError)
 
 	logger = logging.GetLogger("cmd/debug/dumpdb")
 )
@@ -93,43 +94,15 @@ func doDumpDB(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	// Initialize the ABCI state storage for access.
-	//
-	// Note: While it would be great to always use read-only DB access,
-	// badger will refuse to open a DB that isn't closed properly in
-	// read-only mode because it needs to truncate the value log.
-	//
-	// Hope you have backups if you ever run into this.
 	ctx := context.Background()
-	ldb, _, stateRoot, err := abci.InitStateStorage(
-		ctx,
-		&abci.ApplicationConfig{
-			DataDir:             filepath.Join(dataDir, tendermintCommon.StateDir),
-			StorageBackend:      storageDB.BackendNameBadgerDB, // No other backend for now.
-			MemoryOnlyStorage:   false,
-			ReadOnlyStorage:     viper.GetBool(cfgDumpReadOnlyDB),
-			DisableCheckpointer: true,
-		},
-	)
+	qs, err := openQueryState(ctx, dataDir)
 	if err != nil {
-		logger.Error("failed to initialize ABCI storage backend",
+		logger.Error("failed to open ABCI state",
 			"err", err,
 		)
-	}
-	defer ldb.Cleanup()
-
-	latestVersion := int64(stateRoot.Version)
-	dumpVersion := viper.GetInt64(cfgDumpVersion)
-	if dumpVersion == 0 {
-		dumpVersion = latestVersion
-	}
-	if dumpVersion <= 0 || dumpVersion > latestVersion {
-		logger.Error("dump requested for version that does not exist",
-			"dump_version", dumpVersion,
-			"latest_version", latestVersion,
-		)
 		return
 	}
+	defer qs.ldb.Cleanup()
 
 	// Generate the dump by querying all of the relevant backends, and
 	// extracting the immutable parameters from the current genesis
@@ -139,10 +112,6 @@ func doDumpDB(cmd *cobra.Command, args []string) {
 	// document without manual intervention, and only the state that
 	// would be exported by the normal dump process will be present
 	// in the dump.
-	qs := &dumpQueryState{
-		ldb:    ldb,
-		height: dumpVersion,
-	}
 	doc := &genesis.Document{
 		Height:    qs.BlockHeight(),
 		Time:      time.Now(), // XXX: Make this deterministic?
@@ -260,6 +229,46 @@ func doDumpDB(cmd *cobra.Command, args []string) {
 	ok = true
 }
 
+// openQueryState opens the on-disk ABCI state storage and returns a query state for the version
+// configured via flags.
+func openQueryState(ctx context.Context, dataDir string) (*dumpQueryState, error) {
+	// Initialize the ABCI state storage for access.
+	//
+	// Note: While it would be great to always use read-only DB access,
+	// badger will refuse to open a DB that isn't closed properly in
+	// read-only mode because it needs to truncate the value log.
+	//
+	// Hope you have backups if you ever run into this.
+	ldb, _, stateRoot, err := abci.InitStateStorage(
+		ctx,
+		&abci.ApplicationConfig{
+			DataDir:             filepath.Join(dataDir, tendermintCommon.StateDir),
+			StorageBackend:      storageDB.BackendNameBadgerDB, // No other backend for now.
+			MemoryOnlyStorage:   false,
+			ReadOnlyStorage:     viper.GetBool(cfgDumpReadOnlyDB),
+			DisableCheckpointer: true,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("dumpdb: failed to initialize ABCI storage backend: %w", err)
+	}
+
+	latestVersion := int64(stateRoot.Version)
+	dumpVersion := viper.GetInt64(cfgDumpVersion)
+	if dumpVersion == 0 {
+		dumpVersion = latestVersion
+	}
+	if dumpVersion <= 0 || dumpVersion > latestVersion {
+		ldb.Cleanup()
+		return nil, fmt.Errorf("dumpdb: version %d does not exist (latest version: %d)", dumpVersion, latestVersion)
+	}
+
+	return &dumpQueryState{
+		ldb:    ldb,
+		height: dumpVersion,
+	}, nil
+}
+
 func dumpRegistry(ctx context.Context, qs *dumpQueryState) (*registry.Genesis, error) {
 	qf := registryApp.NewQueryFactory(qs)
 	q, err := qf.QueryAt(ctx, qs.BlockHeight())
@@ -384,12 +393,18 @@ func (qs *dumpQueryState) LastRetainedVersion() (int64, error) {
 func Register(parentCmd *cobra.Command) {
 	dumpDBCmd.Flags().AddFlagSet(flags.GenesisFileFlags)
 	dumpDBCmd.Flags().AddFlagSet(dumpDBFlags)
+	dumpDBCmd.Flags().AddFlagSet(stateDBFlags)
+
+	registerInspectCmds(dumpDBCmd)
+
 	parentCmd.AddCommand(dumpDBCmd)
 }
 
 func init() {
 	dumpDBFlags.String(cfgDumpOutput, "dump.json", "path to dumped ABCI state")
-	dumpDBFlags.Bool(cfgDumpReadOnlyDB, false, "read-only DB access")
-	dumpDBFlags.Int64(cfgDumpVersion, 0, "ABCI state version to dump (0 = most recent)")
 	_ = viper.BindPFlags(dumpDBFlags)
+
+	stateDBFlags.Bool(cfgDumpReadOnlyDB, false, "read-only DB access")
+	stateDBFlags.Int64(cfgDumpVersion, 0, "ABCI state version to dump (0 = most recent)")
+	_ = viper.BindPFlags(stateDBFlags)
 }
@@ -0,0 +1,327 @@
+package dumpdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
+	roothashState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+const (
+	cfgInspectOutput = "inspect.output"
+	cfgInspectPrefix = "inspect.prefix"
+)
+
+var (
+	inspectPrefixCmd = &cobra.Command{
+		Use:   "prefix",
+		Short: "dump raw ABCI state entries with the given key prefix",
+		Run:   doInspectPrefix,
+	}
+
+	inspectStakingCmd = &cobra.Command{
+		Use:   "staking",
+		Short: "dump and decode the staking ABCI state",
+		Run: func(cmd *cobra.Command, args []string) {
+			doInspect(cmd, inspectStaking)
+		},
+	}
+
+	inspectRegistryCmd = &cobra.Command{
+		Use:   "registry",
+		Short: "dump and decode the registry ABCI state",
+		Run: func(cmd *cobra.Command, args []string) {
+			doInspect(cmd, inspectRegistry)
+		},
+	}
+
+	inspectRootHashCmd = &cobra.Command{
+		Use:   "roothash",
+		Short: "dump and decode the root hash ABCI state",
+		Run: func(cmd *cobra.Command, args []string) {
+			doInspect(cmd, inspectRootHash)
+		},
+	}
+
+	inspectFlags       = flag.NewFlagSet("", flag.ContinueOnError)
+	inspectPrefixFlags = flag.NewFlagSet("", flag.ContinueOnError)
+)
+
+// rawEntry is a raw ABCI state entry.
+type rawEntry struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+type stakingDump struct {
+	Height               int64                                                                  `json:"height"`
+	Parameters           *staking.ConsensusParameters                                           `json:"params"`
+	TotalSupply          *quantity.Quantity                                                     `json:"total_supply"`
+	CommonPool           *quantity.Quantity                                                     `json:"common_pool"`
+	LastBlockFees        *quantity.Quantity                                                     `json:"last_block_fees"`
+	Ledger               map[staking.Address]*staking.Account                                   `json:"ledger"`
+	Delegations          map[staking.Address]map[staking.Address]*staking.Delegation            `json:"delegations"`
+	DebondingDelegations map[staking.Address]map[staking.Address][]*staking.DebondingDelegation `json:"debonding_delegations"`
+}
+
+type registryDump struct {
+	Height       int64                                        `json:"height"`
+	Parameters   *registry.ConsensusParameters                `json:"params"`
+	Entities     []*entity.Entity                             `json:"entities"`
+	Nodes        []*node.Node                                 `json:"nodes"`
+	NodeStatuses map[signature.PublicKey]*registry.NodeStatus `json:"node_statuses"`
+	Runtimes     []*registry.Runtime                          `json:"runtimes"`
+	Suspended    []*registry.SignedRuntime                    `json:"suspended_runtimes"`
+}
+
+type rootHashDump struct {
+	Height     int64                         `json:"height"`
+	Parameters *roothash.ConsensusParameters `json:"params"`
+	Runtimes   []*roothashState.RuntimeState `json:"runtime_states"`
+}
+
+func doInspect(cmd *cobra.Command, fn func(context.Context, *dumpQueryState) (interface{}, error)) {
+	var ok bool
+	defer func() {
+		if !ok {
+			os.Exit(1)
+		}
+	}()
+
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	dataDir := cmdCommon.DataDir()
+	if dataDir == "" {
+		logger.Error("data directory must be set")
+		return
+	}
+
+	ctx := context.Background()
+	qs, err := openQueryState(ctx, dataDir)
+	if err != nil {
+		logger.Error("failed to open ABCI state",
+			"err", err,
+		)
+		return
+	}
+	defer qs.ldb.Cleanup()
+
+	dump, err := fn(ctx, qs)
+	if err != nil {
+		logger.Error("failed to inspect ABCI state",
+			"err", err,
+			"height", qs.BlockHeight(),
+		)
+		return
+	}
+
+	w, shouldClose, err := cmdCommon.GetOutputWriter(cmd, cfgInspectOutput)
+	if err != nil {
+		logger.Error("failed to get output writer",
+			"err", err,
+		)
+		return
+	}
+	if shouldClose {
+		defer w.Close()
+	}
+	raw, err := json.MarshalIndent(dump, "", "  ")
+	if err != nil {
+		logger.Error("failed to marshal ABCI state into JSON",
+			"err", err,
+		)
+		return
+	}
+	if _, err = w.Write(raw); err != nil {
+		logger.Error("failed to write ABCI state",
+			"err", err,
+		)
+		return
+	}
+
+	ok = true
+}
+
+func doInspectPrefix(cmd *cobra.Command, args []string) {
+	prefix, err := hex.DecodeString(viper.GetString(cfgInspectPrefix))
+	if err != nil {
+		cmdCommon.EarlyLogAndExit(fmt.Errorf("malformed key prefix: %w", err))
+	}
+
+	doInspect(cmd, func(ctx context.Context, qs *dumpQueryState) (interface{}, error) {
+		return inspectPrefix(ctx, qs, prefix)
+	})
+}
+
+func inspectPrefix(ctx context.Context, qs *dumpQueryState, prefix []byte) (interface{}, error) {
+	is, err := abciAPI.NewImmutableState(ctx, qs, qs.BlockHeight())
+	if err != nil {
+		return nil, fmt.Errorf("dumpdb: failed to get state: %w", err)
+	}
+	defer is.Close()
+
+	it := is.NewIterator(ctx)
+	defer it.Close()
+
+	entries := []*rawEntry{}
+	for it.Seek(prefix); it.Valid(); it.Next() {
+		key := it.Key()
+		if !bytes.HasPrefix(key, prefix) {
+			break
+		}
+		entries = append(entries, &rawEntry{
+			Key:   hex.EncodeToString(key),
+			Value: it.Value(),
+		})
+	}
+	if it.Err() != nil {
+		return nil, fmt.Errorf("dumpdb: failed to iterate state: %w", it.Err())
+	}
+	return entries, nil
+}
+
+func inspectStaking(ctx context.Context, qs *dumpQueryState) (interface{}, error) {
+	st, err := stakingState.NewImmutableState(ctx, qs, qs.BlockHeight())
+	if err != nil {
+		return nil, fmt.Errorf("dumpdb: failed to get staking state: %w", err)
+	}
+
+	dump := stakingDump{
+		Height: qs.BlockHeight(),
+		Ledger: make(map[staking.Address]*staking.Account),
+	}
+	if dump.Parameters, err = st.ConsensusParameters(ctx); err != nil {
+		return nil, fmt.Errorf("dumpdb: failed to get staking consensus parameters: %w", err)
+	}
+	if dump.TotalSupply, err = st.TotalSupply(ctx); err != nil {
+		return nil, fmt.Errorf("dumpdb: failed to get total supply: %w", err)
+	}
+	if dump.CommonPool, err = st.CommonPool(ctx); err != nil {
+		return nil, fmt.Errorf("dumpdb: failed to get common pool: %w", err)
+	}
+	if dump.LastBlockFees, err = st.LastBlockFees(ctx); err != nil {
+		return nil, fmt.Errorf("dumpdb: failed to get last block fees: %w", err)
+	}
+
+	addresses, err := st.Addresses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("dumpdb: failed to get account addresses: %w", err)
+	}
+	for _, addr := range addresses {
+		var acct *staking.Account
+		if acct, err = st.Account(ctx, addr); err != nil {
+			return nil, fmt.Errorf("dumpdb: failed to get account %s: %w", addr, err)
+		}
+		dump.Ledger[addr] = acct
+	}
+
+	if dump.Delegations, err = st.Delegations(ctx); err != nil {
+		return nil, fmt.Errorf("dumpdb: failed to get delegations: %w", err)
+	}
+	if dump.DebondingDelegations, err = st.DebondingDelegations(ctx); err != nil {
+		return nil, fmt.Errorf("dumpdb: failed to get debonding delegations: %w", err)
+	}
+	return &dump, nil
+}
+
+func inspectRegistry(ctx context.Context, qs *dumpQueryState) (interface{}, error) {
+	st, err := registryState.NewImmutableState(ctx, qs, qs.BlockHeight())
+	if err != nil {
+		return nil, fmt.Errorf("dumpdb: failed to get registry state: %w", err)
+	}
+
+	dump := registryDump{
+		Height:       qs.BlockHeight(),
+		NodeStatuses: make(map[signature.PublicKey]*registry.NodeStatus),
+	}
+	if dump.Parameters, err = st.ConsensusParameters(ctx); err != nil {
+		return nil, fmt.Errorf("dumpdb: failed to get registry consensus parameters: %w", err)
+	}
+	if dump.Entities, err = st.Entities(ctx); err != nil {
+		return nil, fmt.Errorf("dumpdb: failed to get entities: %w", err)
+	}
+	if dump.Nodes, err = st.Nodes(ctx); err != nil {
+		return nil, fmt.Errorf("dumpdb: failed to get nodes: %w", err)
+	}
+	for _, n := range dump.Nodes {
+		var status *registry.NodeStatus
+		switch status, err = st.NodeStatus(ctx, n.ID); {
+		case err == nil:
+		case errors.Is(err, registry.ErrNoSuchNode):
+			continue
+		default:
+			return nil, fmt.Errorf("dumpdb: failed to get status of node %s: %w", n.ID, err)
+		}
+		dump.NodeStatuses[n.ID] = status
+	}
+	if dump.Runtimes, err = st.Runtimes(ctx); err != nil {
+		return nil, fmt.Errorf("dumpdb: failed to get runtimes: %w", err)
+	}
+	if dump.Suspended, err = st.SuspendedRuntimes(ctx); err != nil {
+		return nil, fmt.Errorf("dumpdb: failed to get suspended runtimes: %w", err)
+	}
+	return &dump, nil
+}
+
+func inspectRootHash(ctx context.Context, qs *dumpQueryState) (interface{}, error) {
+	st, err := roothashState.NewImmutableState(ctx, qs, qs.BlockHeight())
+	if err != nil {
+		return nil, fmt.Errorf("dumpdb: failed to get root hash state: %w", err)
+	}
+
+	dump := rootHashDump{
+		Height: qs.BlockHeight(),
+	}
+	if dump.Parameters, err = st.ConsensusParameters(ctx); err != nil {
+		return nil, fmt.Errorf("dumpdb: failed to get root hash consensus parameters: %w", err)
+	}
+	if dump.Runtimes, err = st.Runtimes(ctx); err != nil {
+		return nil, fmt.Errorf("dumpdb: failed to get runtime states: %w", err)
+	}
+	return &dump, nil
+}
+
+func registerInspectCmds(parentCmd *cobra.Command) {
+	inspectPrefixCmd.Flags().AddFlagSet(inspectPrefixFlags)
+
+	for _, v := range []*cobra.Command{
+		inspectPrefixCmd,
+		inspectStakingCmd,
+		inspectRegistryCmd,
+		inspectRootHashCmd,
+	} {
+		v.Flags().AddFlagSet(inspectFlags)
+		v.Flags().AddFlagSet(stateDBFlags)
+		parentCmd.AddCommand(v)
+	}
+}
+
+func init() {
+	inspectFlags.String(cfgInspectOutput, "", "path to the decoded ABCI state output (default: stdout)")
+	_ = viper.BindPFlags(inspectFlags)
+
+	inspectPrefixFlags.String(cfgInspectPrefix, "", "hex-encoded key prefix (default: all keys)")
+	_ = viper.BindPFlags(inspectPrefixFlags)
+}
@@ -0,0 +1,83 @@
+// Package executor implements the executor debug sub-commands.
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
+	cmdControl "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/control"
+	executorAPI "github.com/oasisprotocol/oasis-core/go/worker/compute/executor/api"
+)
+
+var (
+	replayRound uint64
+
+	executorCmd = &cobra.Command{
+		Use:   "executor",
+		Short: "executor worker utilities",
+	}
+
+	replayBatchCmd = &cobra.Command{
+		Use:   "replay-batch runtime-id (hex)",
+		Short: "replay a historical round's batch through the local runtime and report divergence",
+		Args: func(cmd *cobra.Command, args []string) error {
+			nrFn := cobra.ExactArgs(1)
+			if err := nrFn(cmd, args); err != nil {
+				return err
+			}
+			var id common.Namespace
+			if err := id.UnmarshalHex(args[0]); err != nil {
+				return fmt.Errorf("malformed runtime id '%v': %w", args[0], err)
+			}
+
+			return nil
+		},
+		Run: doReplayBatch,
+	}
+
+	logger = logging.GetLogger("cmd/debug/executor")
+)
+
+func doReplayBatch(cmd *cobra.Command, args []string) {
+	var id common.Namespace
+	_ = id.UnmarshalHex(args[0])
+
+	conn, _ := cmdControl.DoConnect(cmd)
+	defer conn.Close()
+	client := executorAPI.NewExecutorWorkerClient(conn)
+
+	rsp, err := client.ReplayBatch(context.Background(), &executorAPI.ReplayBatchRequest{
+		RuntimeID: id,
+		Round:     replayRound,
+	})
+	if err != nil {
+		logger.Error("failed to replay batch",
+			"err", err,
+			"round", replayRound,
+		)
+		os.Exit(1)
+	}
+
+	b, _ := json.MarshalIndent(rsp, "", "  ")
+	fmt.Println(string(b))
+	if rsp.Diverged() {
+		fmt.Println("replayed batch diverged from recorded results")
+		os.Exit(1)
+	}
+}
+
+// Register registers the executor sub-command and all of its children.
+func Register(parentCmd *cobra.Command) {
+	replayBatchCmd.Flags().Uint64Var(&replayRound, "round", 0, "the round to replay")
+	replayBatchCmd.PersistentFlags().AddFlagSet(cmdGrpc.ClientFlags)
+
+	executorCmd.AddCommand(replayBatchCmd)
+	parentCmd.AddCommand(executorCmd)
+}
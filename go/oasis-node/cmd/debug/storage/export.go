@@ -164,11 +164,12 @@ func newDirectStorageBackend(dataDir string, namespace common.Namespace) (storag
 		ApplyLockLRUSlots: uint64(viper.GetInt(storage.CfgLRUSlots)),
 		Namespace:         namespace,
 		MaxCacheSize:      int64(viper.GetSizeInBytes(storage.CfgMaxCacheSize)),
+		RocksDB:           storage.RocksDBConfig(),
 	}
 
 	b := strings.ToLower(viper.GetString(storage.CfgBackend))
 	switch b {
-	case storageDatabase.BackendNameBadgerDB:
+	case storageDatabase.BackendNameBadgerDB, storageDatabase.BackendNameRocksDB:
 		cfg.DB = filepath.Join(cfg.DB, storageDatabase.DefaultFileName(cfg.Backend))
 		return storageDatabase.New(cfg)
 	case storageClient.BackendName:
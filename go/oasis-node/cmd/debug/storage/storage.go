@@ -74,6 +74,25 @@ var (
 		Run: doForceFinalize,
 	}
 
+	storageCompactCmd = &cobra.Command{
+		Use:   "compact runtime-id (hex)...",
+		Short: "force the node to run storage garbage collection and report reclaimed space",
+		Args: func(cmd *cobra.Command, args []string) error {
+			nrFn := cobra.MinimumNArgs(1)
+			if err := nrFn(cmd, args); err != nil {
+				return err
+			}
+			for _, arg := range args {
+				if err := ValidateRuntimeIDStr(arg); err != nil {
+					return fmt.Errorf("malformed runtime id '%v': %w", arg, err)
+				}
+			}
+
+			return nil
+		},
+		Run: doCompact,
+	}
+
 	logger = logging.GetLogger("cmd/storage")
 )
 
@@ -270,6 +289,42 @@ func doForceFinalize(cmd *cobra.Command, args []string) {
 	}
 }
 
+func doCompact(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	conn, _ := cmdControl.DoConnect(cmd)
+	storageWorkerClient := storageWorkerAPI.NewStorageWorkerClient(conn)
+	defer conn.Close()
+
+	failed := false
+	for _, arg := range args {
+		var id common.Namespace
+		if err := id.UnmarshalHex(arg); err != nil {
+			logger.Error("failed to decode runtime id",
+				"err", err,
+			)
+			failed = true
+			continue
+		}
+
+		rsp, err := storageWorkerClient.Compact(ctx, &storageWorkerAPI.CompactRequest{
+			RuntimeID: id,
+		})
+		if err != nil {
+			logger.Error("failed to compact storage",
+				"err", err,
+				"runtime_id", id,
+			)
+			failed = true
+			continue
+		}
+		fmt.Printf("%s: reclaimed %d bytes\n", id, rsp.Reclaimed)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
 // Register registers the storage sub-command and all of its children.
 func Register(parentCmd *cobra.Command) {
 	storageCheckRootsCmd.PersistentFlags().AddFlagSet(cmdGrpc.ClientFlags)
@@ -279,6 +334,8 @@ func Register(parentCmd *cobra.Command) {
 	storageForceFinalizeCmd.PersistentFlags().AddFlagSet(cmdGrpc.ClientFlags)
 	storageForceFinalizeCmd.PersistentFlags().AddFlagSet(cmdFlags.DebugDontBlameOasisFlag)
 
+	storageCompactCmd.PersistentFlags().AddFlagSet(cmdGrpc.ClientFlags)
+
 	storageExportCmd.Flags().AddFlagSet(storage.Flags)
 	storageExportCmd.Flags().AddFlagSet(cmdFlags.GenesisFileFlags)
 	storageExportCmd.Flags().AddFlagSet(cmdFlags.DebugDontBlameOasisFlag)
@@ -288,6 +345,7 @@ func Register(parentCmd *cobra.Command) {
 
 	storageCmd.AddCommand(storageCheckRootsCmd)
 	storageCmd.AddCommand(storageForceFinalizeCmd)
+	storageCmd.AddCommand(storageCompactCmd)
 	storageCmd.AddCommand(storageExportCmd)
 	storageCmd.AddCommand(storageBenchmarkCmd)
 	parentCmd.AddCommand(storageCmd)
@@ -405,7 +405,7 @@ func (q *queries) doRegistryQueries(ctx context.Context, rng *rand.Rand, height
 	}
 
 	// Nodes.
-	nodes, err := q.registry.GetNodes(ctx, height)
+	nodes, err := q.registry.GetNodes(ctx, &registry.GetNodesQuery{Height: height})
 	if err != nil {
 		return fmt.Errorf("GetNodes error at height %d: %w", height, err)
 	}
@@ -499,6 +499,10 @@ func (q *queries) doStakingQueries(ctx context.Context, rng *rand.Rand, height i
 		return fmt.Errorf("staking.LastBLockFees: %w", err)
 	}
 
+	if _, err = q.staking.RewardScale(ctx, height); err != nil {
+		return fmt.Errorf("staking.RewardScale: %w", err)
+	}
+
 	thKind := staking.ThresholdKind(rng.Intn(int(staking.KindMax)))
 	threshold, err := q.staking.Threshold(ctx, &staking.ThresholdQuery{
 		Height: height,
@@ -24,6 +24,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
@@ -31,6 +32,7 @@ import (
 	epochtime "github.com/oasisprotocol/oasis-core/go/epochtime/api"
 	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
 	genesisFile "github.com/oasisprotocol/oasis-core/go/genesis/file"
+	genesisMigrations "github.com/oasisprotocol/oasis-core/go/genesis/migrations"
 	keymanager "github.com/oasisprotocol/oasis-core/go/keymanager/api"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
@@ -76,6 +78,8 @@ const (
 	cfgEpochTimeTendermintInterval = "epochtime.tendermint.interval"
 
 	// Roothash config flags.
+	cfgRoothashMaxFailedRounds           = "roothash.max_failed_rounds"
+	cfgRoothashMaxRuntimeMessages        = "roothash.max_runtime_messages"
 	cfgRoothashDebugDoNotSuspendRuntimes = "roothash.debug.do_not_suspend_runtimes"
 	cfgRoothashDebugBypassStake          = "roothash.debug.bypass_stake" // nolint: gosec
 
@@ -107,12 +111,20 @@ const (
 	// Check command.
 	// Number of lines to print if document not in canonical form.
 	checkNotCanonicalLines = 10
+
+	// Migrate command.
+	cfgMigrateFrom   = "from"
+	cfgMigrateTo     = "to"
+	cfgMigrateOutput = "output"
+	// Number of changed fields to print per module in the diff summary.
+	migrateDiffLines = 10
 )
 
 var (
-	checkGenesisFlags = flag.NewFlagSet("", flag.ContinueOnError)
-	dumpGenesisFlags  = flag.NewFlagSet("", flag.ContinueOnError)
-	initGenesisFlags  = flag.NewFlagSet("", flag.ContinueOnError)
+	checkGenesisFlags   = flag.NewFlagSet("", flag.ContinueOnError)
+	dumpGenesisFlags    = flag.NewFlagSet("", flag.ContinueOnError)
+	initGenesisFlags    = flag.NewFlagSet("", flag.ContinueOnError)
+	migrateGenesisFlags = flag.NewFlagSet("", flag.ContinueOnError)
 
 	genesisCmd = &cobra.Command{
 		Use:   "genesis",
@@ -137,6 +149,12 @@ var (
 		Run:   doCheckGenesis,
 	}
 
+	migrateGenesisCmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "migrate the genesis file between consensus protocol versions",
+		Run:   doMigrateGenesis,
+	}
+
 	logger = logging.GetLogger("cmd/genesis")
 )
 
@@ -428,6 +446,8 @@ func AppendRootHashState(doc *genesis.Document, exports []string, l *logging.Log
 		RuntimeStates: make(map[common.Namespace]*registry.RuntimeGenesis),
 
 		Parameters: roothash.ConsensusParameters{
+			MaxFailedRounds:           viper.GetUint64(cfgRoothashMaxFailedRounds),
+			MaxRuntimeMessages:        viper.GetUint32(cfgRoothashMaxRuntimeMessages),
 			DebugDoNotSuspendRuntimes: viper.GetBool(cfgRoothashDebugDoNotSuspendRuntimes),
 			DebugBypassStake:          viper.GetBool(cfgRoothashDebugBypassStake),
 			// TODO: Make these configurable.
@@ -652,17 +672,104 @@ func doCheckGenesis(cmd *cobra.Command, args []string) {
 	}
 }
 
+func doMigrateGenesis(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	from, err := genesisMigrations.ParseVersion(viper.GetString(cfgMigrateFrom))
+	if err != nil {
+		logger.Error("invalid source version", "err", err)
+		os.Exit(1)
+	}
+	to, err := genesisMigrations.ParseVersion(viper.GetString(cfgMigrateTo))
+	if err != nil {
+		logger.Error("invalid target version", "err", err)
+		os.Exit(1)
+	}
+
+	rawFile, err := ioutil.ReadFile(flags.GenesisFile())
+	if err != nil {
+		logger.Error("failed to read genesis file", "err", err)
+		os.Exit(1)
+	}
+	doc, err := genesisMigrations.DecodeDocument(rawFile)
+	if err != nil {
+		logger.Error("failed to decode genesis file", "err", err)
+		os.Exit(1)
+	}
+
+	migrated, err := genesisMigrations.Migrate(doc, from, to)
+	if err != nil {
+		logger.Error("failed to migrate genesis document", "err", err)
+		os.Exit(1)
+	}
+
+	// Print a summary of the changes per module.
+	changes := genesisMigrations.Diff(doc, migrated)
+	fmt.Fprintf(os.Stderr, "Migrated genesis document from version %s to %s (%d changes).\n", from, to, len(changes))
+	var (
+		modules       []string
+		moduleChanges = make(map[string][]genesisMigrations.Change)
+	)
+	for _, change := range changes {
+		module := strings.SplitN(change.Path, ".", 2)[0]
+		if _, ok := moduleChanges[module]; !ok {
+			modules = append(modules, module)
+		}
+		moduleChanges[module] = append(moduleChanges[module], change)
+	}
+	for _, module := range modules {
+		fmt.Fprintf(os.Stderr, "\n%s:\n", module)
+		for i, change := range moduleChanges[module] {
+			if i >= migrateDiffLines {
+				fmt.Fprintf(os.Stderr, "  ... %d more\n", len(moduleChanges[module])-i)
+				break
+			}
+			fmt.Fprintf(os.Stderr, "  %s %s\n", change.Kind, change.Path)
+		}
+	}
+
+	// Output the migrated document in the canonical form in case it can be
+	// decoded as a genesis document of the current version.
+	var data []byte
+	if gen, gerr := migrated.ToGenesis(); gerr == nil {
+		data, err = json.MarshalIndent(gen, "", "  ")
+	} else {
+		data, err = json.MarshalIndent(migrated, "", "  ")
+	}
+	if err != nil {
+		logger.Error("failed to marshal genesis document into JSON", "err", err)
+		os.Exit(1)
+	}
+
+	w, shouldClose, err := cmdCommon.GetOutputWriter(cmd, cfgMigrateOutput)
+	if err != nil {
+		logger.Error("failed to get writer for migrated genesis file", "err", err)
+		os.Exit(1)
+	}
+	if shouldClose {
+		defer w.Close()
+	}
+	if _, err = w.Write(data); err != nil {
+		logger.Error("failed to write migrated genesis file", "err", err)
+		os.Exit(1)
+	}
+}
+
 // Register registers the genesis sub-command and all of it's children.
 func Register(parentCmd *cobra.Command) {
 	initGenesisCmd.Flags().AddFlagSet(initGenesisFlags)
 	dumpGenesisCmd.Flags().AddFlagSet(dumpGenesisFlags)
 	dumpGenesisCmd.PersistentFlags().AddFlagSet(cmdGrpc.ClientFlags)
 	checkGenesisCmd.Flags().AddFlagSet(checkGenesisFlags)
+	migrateGenesisCmd.Flags().AddFlagSet(migrateGenesisFlags)
 
 	for _, v := range []*cobra.Command{
 		initGenesisCmd,
 		dumpGenesisCmd,
 		checkGenesisCmd,
+		migrateGenesisCmd,
 	} {
 		genesisCmd.AddCommand(v)
 	}
@@ -674,6 +781,12 @@ func init() {
 	_ = viper.BindPFlags(checkGenesisFlags)
 	checkGenesisFlags.AddFlagSet(flags.GenesisFileFlags)
 
+	migrateGenesisFlags.String(cfgMigrateFrom, "", "consensus protocol version of the genesis file")
+	migrateGenesisFlags.String(cfgMigrateTo, version.ConsensusProtocol.String(), "consensus protocol version to migrate to")
+	migrateGenesisFlags.String(cfgMigrateOutput, "", "path to the migrated genesis file (default: stdout)")
+	_ = viper.BindPFlags(migrateGenesisFlags)
+	migrateGenesisFlags.AddFlagSet(flags.GenesisFileFlags)
+
 	dumpGenesisFlags.Int64(cfgBlockHeight, consensus.HeightLatest, "block height at which to dump state")
 	_ = viper.BindPFlags(dumpGenesisFlags)
 	dumpGenesisFlags.AddFlagSet(flags.GenesisFileFlags)
@@ -718,6 +831,8 @@ func init() {
 	_ = initGenesisFlags.MarkHidden(cfgEpochTimeDebugMockBackend)
 
 	// Roothash config flags.
+	initGenesisFlags.Uint64(cfgRoothashMaxFailedRounds, 0, "consecutive failed rounds after which a runtime is suspended (0 disables)")
+	initGenesisFlags.Uint32(cfgRoothashMaxRuntimeMessages, 0, "maximum number of runtime messages a runtime may emit per round (0 disables)")
 	initGenesisFlags.Bool(cfgRoothashDebugDoNotSuspendRuntimes, false, "do not suspend runtimes (UNSAFE)")
 	initGenesisFlags.Bool(cfgRoothashDebugBypassStake, false, "bypass all roothash stake checks and operations (UNSAFE)")
 	_ = initGenesisFlags.MarkHidden(cfgRoothashDebugDoNotSuspendRuntimes)
@@ -6,6 +6,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx"
 	cmnIAS "github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
@@ -17,6 +18,15 @@ type enclaveStore struct {
 	sync.RWMutex
 
 	enclaves map[common.Namespace][]sgx.EnclaveIdentity
+	owners   map[common.Namespace]signature.PublicKey
+}
+
+func (st *enclaveStore) getRuntimeOwner(runtimeID common.Namespace) (signature.PublicKey, bool) {
+	st.RLock()
+	defer st.RUnlock()
+
+	owner, ok := st.owners[runtimeID]
+	return owner, ok
 }
 
 func (st *enclaveStore) verifyEvidence(evidence *ias.Evidence) error {
@@ -61,6 +71,7 @@ func (st *enclaveStore) addRuntime(runtime *registry.Runtime) (int, error) {
 	}
 
 	st.enclaves[runtime.ID] = vi.Enclaves
+	st.owners[runtime.ID] = runtime.EntityID
 
 	return len(st.enclaves), nil
 }
@@ -68,5 +79,6 @@ func (st *enclaveStore) addRuntime(runtime *registry.Runtime) (int, error) {
 func newEnclaveStore() *enclaveStore {
 	return &enclaveStore{
 		enclaves: make(map[common.Namespace][]sgx.EnclaveIdentity),
+		owners:   make(map[common.Namespace]signature.PublicKey),
 	}
 }
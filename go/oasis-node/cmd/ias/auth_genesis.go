@@ -3,6 +3,8 @@ package ias
 import (
 	"context"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	genesis "github.com/oasisprotocol/oasis-core/go/genesis/file"
 	ias "github.com/oasisprotocol/oasis-core/go/ias/api"
@@ -36,6 +38,10 @@ func (auth *genesisAuthenticator) VerifyEvidence(ctx context.Context, evidence *
 	return nil
 }
 
+func (auth *genesisAuthenticator) GetRuntimeOwner(runtimeID common.Namespace) (signature.PublicKey, bool) {
+	return auth.enclaves.getRuntimeOwner(runtimeID)
+}
+
 func newGenesisAuthenticator() (iasProxy.Authenticator, error) {
 	genesisProvider, err := genesis.DefaultFileProvider()
 	if err != nil {
@@ -11,6 +11,8 @@ import (
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	ias "github.com/oasisprotocol/oasis-core/go/ias/api"
@@ -57,6 +59,10 @@ func (auth *registryAuthenticator) VerifyEvidence(ctx context.Context, evidence
 	return nil
 }
 
+func (auth *registryAuthenticator) GetRuntimeOwner(runtimeID common.Namespace) (signature.PublicKey, bool) {
+	return auth.enclaves.getRuntimeOwner(runtimeID)
+}
+
 func (auth *registryAuthenticator) watchRuntimes(ctx context.Context, conn *grpc.ClientConn) (
 	ch <-chan *registry.Runtime,
 	sub pubsub.ClosableSubscription,
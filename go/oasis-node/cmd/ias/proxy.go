@@ -3,10 +3,10 @@ package ias
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
@@ -15,9 +15,7 @@ import (
 	tlsCert "github.com/oasisprotocol/oasis-core/go/common/crypto/tls"
 	"github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
-	cmnIAS "github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
 	ias "github.com/oasisprotocol/oasis-core/go/ias/api"
-	iasHTTP "github.com/oasisprotocol/oasis-core/go/ias/http"
 	iasProxy "github.com/oasisprotocol/oasis-core/go/ias/proxy"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/background"
@@ -34,6 +32,7 @@ const (
 	envSPID          = "OASIS_IAS_SPID"
 	cfgSPID          = "ias.spid"
 	cfgQuoteSigType  = "ias.quote.signature_type"
+	cfgTenants       = "ias.tenants"
 	cfgDebugMock     = "ias.debug.mock"
 	cfgDebugSkipAuth = "ias.debug.skip_auth"
 	cfgUseGenesis    = "ias.use_genesis"
@@ -57,12 +56,19 @@ var (
 		Run:   doProxy,
 	}
 
+	iasUsageCmd = &cobra.Command{
+		Use:   "usage",
+		Short: "show IAS proxy per-tenant request usage",
+		Run:   doUsage,
+	}
+
 	logger = logging.GetLogger("cmd/ias/proxy")
 )
 
 type proxyEnv struct {
-	svcMgr  *background.ServiceManager
-	grpcSrv *grpc.Server
+	svcMgr          *background.ServiceManager
+	grpcSrv         *grpc.Server
+	grpcInternalSrv *grpc.Server
 }
 
 // TLSCertPaths returns the TLS certificate and private key paths for
@@ -108,9 +114,9 @@ func doProxy(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	endpoint, err := iasEndpointFromFlags()
+	tenants, err := tenantsFromFlags()
 	if err != nil {
-		logger.Error("failed to initialize IAS endpoint",
+		logger.Error("failed to initialize IAS tenants",
 			"err", err,
 		)
 		return
@@ -126,6 +132,16 @@ func doProxy(cmd *cobra.Command, args []string) {
 	}
 	env.svcMgr.Register(env.grpcSrv)
 
+	// Initialize the internal gRPC server used for the operator interface.
+	env.grpcInternalSrv, err = cmdGrpc.NewServerLocal(false)
+	if err != nil {
+		logger.Error("failed to initialize internal gRPC server",
+			"err", err,
+		)
+		return
+	}
+	env.svcMgr.Register(env.grpcInternalSrv)
+
 	// Initialize the metrics server.
 	metrics, err := metrics.New(env.svcMgr.Ctx)
 	if err != nil {
@@ -164,8 +180,15 @@ func doProxy(cmd *cobra.Command, args []string) {
 	}
 
 	// Initialize the IAS proxy.
-	proxy := iasProxy.New(endpoint, authenticator)
+	proxy, err := iasProxy.New(tenants, authenticator)
+	if err != nil {
+		logger.Error("failed to initialize IAS proxy",
+			"err", err,
+		)
+		return
+	}
 	ias.RegisterService(env.grpcSrv.Server(), proxy)
+	ias.RegisterOperatorService(env.grpcInternalSrv.Server(), proxy)
 
 	// Start metric server.
 	if err = metrics.Start(); err != nil {
@@ -183,6 +206,14 @@ func doProxy(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	// Start the internal gRPC server.
+	if err = env.grpcInternalSrv.Start(); err != nil {
+		logger.Error("failed to start internal gRPC server",
+			"err", err,
+		)
+		return
+	}
+
 	startOk = true
 	logger.Info("initialization complete: ready to serve")
 
@@ -191,36 +222,37 @@ func doProxy(cmd *cobra.Command, args []string) {
 	env.svcMgr.Wait()
 }
 
-func iasEndpointFromFlags() (ias.Endpoint, error) {
-	cfg := &iasHTTP.Config{
-		SPID: viper.GetString(cfgSPID),
+func doUsage(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
 	}
 
-	quoteSigType := viper.GetString(cfgQuoteSigType)
-	switch strings.ToLower(quoteSigType) {
-	case "unlinkable":
-		cfg.QuoteSignatureType = cmnIAS.SignatureUnlinkable
-	case "linkable":
-		cfg.QuoteSignatureType = cmnIAS.SignatureLinkable
-	default:
-		return nil, fmt.Errorf("ias: invalid signature type: %s", quoteSigType)
+	conn, err := cmdGrpc.NewClient(cmd)
+	if err != nil {
+		logger.Error("failed to establish connection with IAS proxy",
+			"err", err,
+		)
+		os.Exit(1)
 	}
+	defer conn.Close()
 
-	if viper.GetBool(cfgDebugMock) {
-		if !flags.DebugDontBlameOasis() {
-			return nil, fmt.Errorf("ias: refusing to mock IAS responses")
-		}
-		cfg.DebugIsMock = true
-	} else {
-		apiKey := viper.GetString(cfgAuthAPIKey)
-		if apiKey == "" {
-			return nil, fmt.Errorf("ias: missing IAS Client API key")
-		}
-		cfg.SubscriptionKey = apiKey
-		cfg.IsProduction = viper.GetBool(cfgIsProduction)
+	client := ias.NewOperatorClient(conn)
+	usage, err := client.GetUsage(context.Background())
+	if err != nil {
+		logger.Error("failed to query IAS proxy usage",
+			"err", err,
+		)
+		os.Exit(1)
 	}
 
-	return iasHTTP.New(cfg)
+	formatted, err := json.MarshalIndent(usage, "", "  ")
+	if err != nil {
+		logger.Error("failed to format IAS proxy usage",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(formatted))
 }
 
 func grpcAuthenticatorFromFlags(ctx context.Context, cmd *cobra.Command) (iasProxy.Authenticator, error) {
@@ -241,8 +273,10 @@ func grpcAuthenticatorFromFlags(ctx context.Context, cmd *cobra.Command) (iasPro
 // Register registers the ias sub-command and all of it's children.
 func Register(parentCmd *cobra.Command) {
 	iasProxyCmd.Flags().AddFlagSet(proxyFlags)
+	iasUsageCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
 
 	iasCmd.AddCommand(iasProxyCmd)
+	iasCmd.AddCommand(iasUsageCmd)
 	parentCmd.AddCommand(iasCmd)
 }
 
@@ -251,6 +285,7 @@ func init() {
 	proxyFlags.String(cfgSPID, "", "SPID associated with the client certificate")
 	proxyFlags.String(cfgQuoteSigType, "linkable", "quote signature type associated with the SPID")
 	proxyFlags.Bool(cfgIsProduction, false, "use the production IAS endpoint")
+	proxyFlags.String(cfgTenants, "", "path to a JSON file configuring additional IAS subscriptions (tenants)")
 	proxyFlags.Bool(cfgDebugMock, false, "generate mock IAS AVR responses (UNSAFE)")
 	proxyFlags.Bool(cfgDebugSkipAuth, false, "disable proxy authentication (UNSAFE)")
 	proxyFlags.Bool(cfgUseGenesis, false, "use a genesis document instead of the registry")
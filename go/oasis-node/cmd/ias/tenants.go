@@ -0,0 +1,176 @@
+package ias
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	cmnIAS "github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
+	ias "github.com/oasisprotocol/oasis-core/go/ias/api"
+	iasHTTP "github.com/oasisprotocol/oasis-core/go/ias/http"
+	iasProxy "github.com/oasisprotocol/oasis-core/go/ias/proxy"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+)
+
+// defaultTenantName is the name of the tenant configured via the single
+// subscription flags.
+const defaultTenantName = "default"
+
+// tenantConfig is the configuration of an IAS proxy tenant as specified
+// in the tenants file.
+type tenantConfig struct {
+	// Name is the tenant name.
+	Name string `json:"name"`
+
+	// APIKey is the tenant's IAS subscription API key.
+	APIKey string `json:"api_key"`
+	// SPID is the SPID associated with the tenant's subscription.
+	SPID string `json:"spid"`
+	// QuoteSignatureType is the quote signature type associated with the SPID.
+	QuoteSignatureType string `json:"quote_signature_type"`
+	// IsProduction is true iff the production IAS endpoint should be used.
+	IsProduction bool `json:"production"`
+
+	// Runtimes are the runtimes served by the tenant.
+	Runtimes []common.Namespace `json:"runtimes"`
+	// Entities are the entities whose runtimes are served by the tenant.
+	Entities []signature.PublicKey `json:"entities"`
+	// Default is true iff the tenant serves all runtimes that are not
+	// explicitly served by other tenants.
+	Default bool `json:"default"`
+
+	// Quota is the optional tenant request quota.
+	Quota *quotaConfig `json:"quota"`
+}
+
+// quotaConfig is the configuration of an IAS proxy tenant request quota.
+type quotaConfig struct {
+	// Requests is the maximum number of requests per period.
+	Requests uint64 `json:"requests"`
+	// Period is the quota period (e.g., "24h").
+	Period string `json:"period"`
+}
+
+func (cfg *tenantConfig) toTenant() (*iasProxy.Tenant, error) {
+	quoteSigType := cfg.QuoteSignatureType
+	if quoteSigType == "" {
+		quoteSigType = "linkable"
+	}
+	endpoint, err := newIASEndpoint(cfg.APIKey, cfg.SPID, quoteSigType, cfg.IsProduction)
+	if err != nil {
+		return nil, fmt.Errorf("ias: failed to initialize endpoint for tenant '%s': %w", cfg.Name, err)
+	}
+
+	tenant := &iasProxy.Tenant{
+		Name:     cfg.Name,
+		Endpoint: endpoint,
+		Runtimes: cfg.Runtimes,
+		Entities: cfg.Entities,
+		Default:  cfg.Default,
+	}
+	if cfg.Quota != nil {
+		period, err := time.ParseDuration(cfg.Quota.Period)
+		if err != nil {
+			return nil, fmt.Errorf("ias: malformed quota period for tenant '%s': %w", cfg.Name, err)
+		}
+		tenant.Quota = &iasProxy.Quota{
+			Requests: cfg.Quota.Requests,
+			Period:   period,
+		}
+	}
+
+	return tenant, nil
+}
+
+func newIASEndpoint(apiKey, spid, quoteSigType string, isProduction bool) (ias.Endpoint, error) {
+	cfg := &iasHTTP.Config{
+		SPID: spid,
+	}
+
+	switch strings.ToLower(quoteSigType) {
+	case "unlinkable":
+		cfg.QuoteSignatureType = cmnIAS.SignatureUnlinkable
+	case "linkable":
+		cfg.QuoteSignatureType = cmnIAS.SignatureLinkable
+	default:
+		return nil, fmt.Errorf("ias: invalid signature type: %s", quoteSigType)
+	}
+
+	if viper.GetBool(cfgDebugMock) {
+		if !flags.DebugDontBlameOasis() {
+			return nil, fmt.Errorf("ias: refusing to mock IAS responses")
+		}
+		cfg.DebugIsMock = true
+	} else {
+		if apiKey == "" {
+			return nil, fmt.Errorf("ias: missing IAS Client API key")
+		}
+		cfg.SubscriptionKey = apiKey
+		cfg.IsProduction = isProduction
+	}
+
+	return iasHTTP.New(cfg)
+}
+
+func loadTenants(fn string) ([]*iasProxy.Tenant, error) {
+	raw, err := ioutil.ReadFile(fn)
+	if err != nil {
+		return nil, fmt.Errorf("ias: failed to read tenants file: %w", err)
+	}
+
+	var cfgs []*tenantConfig
+	if err = json.Unmarshal(raw, &cfgs); err != nil {
+		return nil, fmt.Errorf("ias: malformed tenants file: %w", err)
+	}
+
+	var tenants []*iasProxy.Tenant
+	for _, cfg := range cfgs {
+		tenant, err := cfg.toTenant()
+		if err != nil {
+			return nil, err
+		}
+		tenants = append(tenants, tenant)
+	}
+	return tenants, nil
+}
+
+func tenantsFromFlags() ([]*iasProxy.Tenant, error) {
+	var tenants []*iasProxy.Tenant
+
+	// The single subscription flags configure the default tenant.
+	if viper.GetString(cfgAuthAPIKey) != "" || viper.GetBool(cfgDebugMock) {
+		endpoint, err := newIASEndpoint(
+			viper.GetString(cfgAuthAPIKey),
+			viper.GetString(cfgSPID),
+			viper.GetString(cfgQuoteSigType),
+			viper.GetBool(cfgIsProduction),
+		)
+		if err != nil {
+			return nil, err
+		}
+		tenants = append(tenants, &iasProxy.Tenant{
+			Name:     defaultTenantName,
+			Endpoint: endpoint,
+			Default:  true,
+		})
+	}
+
+	if fn := viper.GetString(cfgTenants); fn != "" {
+		fileTenants, err := loadTenants(fn)
+		if err != nil {
+			return nil, err
+		}
+		tenants = append(tenants, fileTenants...)
+	}
+
+	if len(tenants) == 0 {
+		return nil, fmt.Errorf("ias: missing IAS Client API key")
+	}
+	return tenants, nil
+}
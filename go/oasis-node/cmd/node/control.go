@@ -6,9 +6,12 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
+	"github.com/oasisprotocol/oasis-core/go/common/service"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	control "github.com/oasisprotocol/oasis-core/go/control/api"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+	commonWorker "github.com/oasisprotocol/oasis-core/go/worker/common/api"
 	"github.com/oasisprotocol/oasis-core/go/worker/registration"
 )
 
@@ -62,6 +65,16 @@ func (n *Node) GetRegistrationStatus(ctx context.Context) (*control.Registration
 	return n.RegistrationWorker.GetRegistrationStatus(ctx)
 }
 
+// Implements control.ControlledNode.
+func (n *Node) GetHealth() *service.Health {
+	return n.svcMgr.Health()
+}
+
+// Implements control.ControlledNode.
+func (n *Node) GetDataDir() string {
+	return cmdCommon.DataDir()
+}
+
 // Implements control.ControlledNode.
 func (n *Node) GetRuntimeStatus(ctx context.Context) (map[common.Namespace]control.RuntimeStatus, error) {
 	runtimes := make(map[common.Namespace]control.RuntimeStatus)
@@ -148,3 +161,19 @@ func (n *Node) GetRuntimeStatus(ctx context.Context) (map[common.Namespace]contr
 	}
 	return runtimes, nil
 }
+
+// Implements control.ControlledNode.
+func (n *Node) GetCommitteePeerScores() (*commonWorker.PeerScores, error) {
+	if n.P2P == nil {
+		return nil, control.ErrCommitteeP2PUnavailable
+	}
+	return n.P2P.PeerScores(), nil
+}
+
+// Implements control.ControlledNode.
+func (n *Node) UnbanCommitteePeer(id string) error {
+	if n.P2P == nil {
+		return control.ErrCommitteeP2PUnavailable
+	}
+	return n.P2P.UnbanPeer(id)
+}
@@ -43,6 +43,7 @@ import (
 	cmdSigner "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/signer"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/tracing"
 	registryAPI "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothashAPI "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client"
 	runtimeClientAPI "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
 	enclaverpc "github.com/oasisprotocol/oasis-core/go/runtime/enclaverpc/api"
@@ -51,6 +52,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/sentry"
 	sentryAPI "github.com/oasisprotocol/oasis-core/go/sentry/api"
 	stakingAPI "github.com/oasisprotocol/oasis-core/go/staking/api"
+	stakingWatcher "github.com/oasisprotocol/oasis-core/go/staking/watcher"
 	storageAPI "github.com/oasisprotocol/oasis-core/go/storage/api"
 	"github.com/oasisprotocol/oasis-core/go/upgrade"
 	upgradeAPI "github.com/oasisprotocol/oasis-core/go/upgrade/api"
@@ -115,6 +117,8 @@ type Node struct {
 	RuntimeRegistry runtimeRegistry.Registry
 	RuntimeClient   runtimeClientAPI.RuntimeClient
 
+	AccountWatcher *stakingWatcher.Watcher
+
 	CommonWorker       *workerCommon.Worker
 	ExecutorWorker     *executor.Worker
 	StorageWorker      *workerStorage.Worker
@@ -200,6 +204,7 @@ func (n *Node) startRuntimeServices() error {
 	registryAPI.RegisterService(grpcSrv, n.Consensus.Registry())
 	stakingAPI.RegisterService(grpcSrv, n.Consensus.Staking())
 	keymanagerAPI.RegisterService(grpcSrv, n.Consensus.KeyManager())
+	roothashAPI.RegisterService(grpcSrv, n.Consensus.RootHash())
 
 	// Register dump genesis halt hook.
 	n.Consensus.RegisterHaltHook(func(ctx context.Context, blockHeight int64, epoch epochtime.EpochTime) {
@@ -250,15 +255,21 @@ func (n *Node) startRuntimeServices() error {
 	runtimeClientAPI.RegisterService(n.grpcInternal.Server(), n.RuntimeClient)
 	enclaverpc.RegisterService(n.grpcInternal.Server(), n.RuntimeClient)
 
-	// Start workers (requires NodeController for checking, if nodes are synced).
-	if err = n.startRuntimeWorkers(); err != nil {
-		n.logger.Error("failed to start workers",
-			"err", err,
-		)
+	// The consensus backend should only be started once all the workers that subscribe to it
+	// have been started.
+	if err = n.svcMgr.AddDependencies(n.Consensus,
+		n.StorageWorker,
+		n.ExecutorWorker,
+		n.CommonWorker,
+		n.KeymanagerWorker,
+		n.RegistrationWorker,
+		n.SentryWorker,
+		n.ConsensusWorker,
+	); err != nil {
 		return err
 	}
 
-	n.logger.Debug("runtime services started")
+	n.logger.Debug("runtime services initialized")
 
 	return nil
 }
@@ -284,7 +295,7 @@ func (n *Node) initRuntimeWorkers() error {
 		if genesisDoc.Registry.Parameters.DebugAllowUnroutableAddresses {
 			p2p.DebugForceAllowUnroutableAddresses()
 		}
-		n.P2P, err = p2p.New(p2pCtx, n.Identity, n.Consensus)
+		n.P2P, err = p2p.New(p2pCtx, n.Identity, n.Consensus, n.commonStore)
 		if err != nil {
 			return err
 		}
@@ -318,7 +329,6 @@ func (n *Node) initRuntimeWorkers() error {
 		)
 		return err
 	}
-	n.svcMgr.Register(n.CommonWorker.Grpc)
 	n.svcMgr.Register(n.CommonWorker)
 
 	workerCommonCfg := n.CommonWorker.GetConfig()
@@ -383,6 +393,7 @@ func (n *Node) initRuntimeWorkers() error {
 	// Initialize the executor worker.
 	n.ExecutorWorker, err = executor.New(
 		dataDir,
+		n.grpcInternal,
 		n.CommonWorker,
 		n.RegistrationWorker,
 	)
@@ -406,62 +417,27 @@ func (n *Node) initRuntimeWorkers() error {
 	if err != nil {
 		return err
 	}
-	n.svcMgr.Register(n.ConsensusWorker)
-
-	return nil
-}
-
-func (n *Node) startRuntimeWorkers() error {
-	// Start the storage worker.
-	if err := n.StorageWorker.Start(); err != nil {
-		return err
-	}
-
-	// Start the executor worker.
-	if err := n.ExecutorWorker.Start(); err != nil {
-		return err
-	}
-
-	// Start the common worker.
-	if err := n.CommonWorker.Start(); err != nil {
-		return err
-	}
-
-	// Start the key manager worker.
-	if err := n.KeymanagerWorker.Start(); err != nil {
-		return err
-	}
+	n.svcMgr.Register(n.ConsensusWorker, n.RegistrationWorker)
 
-	// Start the worker registration service.
-	if err := n.RegistrationWorker.Start(); err != nil {
+	// The common worker dispatches runtime events to the hooks of the other workers, so these
+	// must be started first.
+	if err = n.svcMgr.AddDependencies(n.CommonWorker, n.StorageWorker, n.ExecutorWorker); err != nil {
 		return err
 	}
 
-	// Start the sentry worker.
-	if err := n.SentryWorker.Start(); err != nil {
-		return err
-	}
-
-	// Start the public consensus services worker.
-	if err := n.ConsensusWorker.Start(); err != nil {
-		return fmt.Errorf("consensus worker: %w", err)
-	}
-
 	// Only start the external gRPC server if any workers are enabled.
 	if n.StorageWorker.Enabled() ||
 		n.KeymanagerWorker.Enabled() ||
 		n.ConsensusWorker.Enabled() {
-		if err := n.CommonWorker.Grpc.Start(); err != nil {
-			n.logger.Error("failed to start external gRPC server",
-				"err", err,
-			)
-			return err
-		}
+		n.svcMgr.Register(n.CommonWorker.Grpc,
+			n.StorageWorker,
+			n.KeymanagerWorker,
+			n.ConsensusWorker,
+		)
+	} else {
+		n.svcMgr.RegisterCleanupOnly(n.CommonWorker.Grpc, "external gRPC server")
 	}
 
-	// Close readyCh once all workers and runtimes are initialized.
-	go n.waitReady()
-
 	return nil
 }
 
@@ -654,14 +630,6 @@ func newNode(testNode bool) (node *Node, err error) { // nolint: gocyclo
 	}
 	node.svcMgr.Register(metrics)
 
-	// Start the metrics reporting server.
-	if err = metrics.Start(); err != nil {
-		logger.Error("failed to start metrics reporting server",
-			"err", err,
-		)
-		return nil, err
-	}
-
 	// Initialize the profiling server.
 	profiling, err := pprof.New(node.svcMgr.Ctx)
 	if err != nil {
@@ -672,14 +640,6 @@ func newNode(testNode bool) (node *Node, err error) { // nolint: gocyclo
 	}
 	node.svcMgr.Register(profiling)
 
-	// Start the profiling server.
-	if err = profiling.Start(); err != nil {
-		logger.Error("failed to start pprof server",
-			"err", err,
-		)
-		return nil, err
-	}
-
 	// Initialize the genesis provider.
 	if err = node.initGenesis(testNode); err != nil {
 		logger.Error("failed to initialize the genesis provider",
@@ -698,11 +658,23 @@ func newNode(testNode bool) (node *Node, err error) { // nolint: gocyclo
 		)
 		return nil, err
 	}
-	node.svcMgr.Register(node.Consensus)
+	node.svcMgr.Register(node.Consensus, node.grpcInternal)
 	consensusAPI.RegisterService(node.grpcInternal.Server(), node.Consensus)
 
+	// Initialize the staking account watcher, which requires consensus services.
+	if node.Consensus.SupportedFeatures().Has(consensusAPI.FeatureServices) {
+		node.AccountWatcher, err = stakingWatcher.New(node.commonStore, node.Consensus)
+		if err != nil {
+			logger.Error("failed to initialize staking account watcher",
+				"err", err,
+			)
+			return nil, err
+		}
+		node.svcMgr.Register(node.AccountWatcher)
+	}
+
 	// Initialize the node controller.
-	node.NodeController = control.New(node, node.Consensus, node.Upgrader)
+	node.NodeController = control.New(node, node.Consensus, node.Upgrader, node.AccountWatcher)
 	controlAPI.RegisterService(node.grpcInternal.Server(), node.NodeController)
 
 	// If the consensus backend supports communicating with consensus services, we can also start
@@ -722,20 +694,17 @@ func newNode(testNode bool) (node *Node, err error) { // nolint: gocyclo
 		}
 	}
 
-	// Start the internal gRPC server.
-	if err = node.grpcInternal.Start(); err != nil {
-		logger.Error("failed to start internal gRPC server",
+	// Start all services in dependency order.
+	if err = node.svcMgr.Start(); err != nil {
+		logger.Error("failed to start services",
 			"err", err,
 		)
 		return nil, err
 	}
 
-	// Start the consensus backend service.
-	if err = node.Consensus.Start(); err != nil {
-		logger.Error("failed to start consensus backend service",
-			"err", err,
-		)
-		return nil, err
+	// Close readyCh once all workers and runtimes are initialized.
+	if node.RuntimeRegistry != nil {
+		go node.waitReady()
 	}
 
 	logger.Info("initialization complete: ready to serve")
@@ -750,7 +719,10 @@ func Register(parentCmd *cobra.Command) {
 	unsafeResetCmd.Flags().AddFlagSet(flags.DryRunFlag)
 	unsafeResetCmd.Flags().AddFlagSet(unsafeResetFlags)
 
+	unsafeRollbackCmd.Flags().AddFlagSet(flags.DryRunFlag)
+
 	parentCmd.AddCommand(unsafeResetCmd)
+	parentCmd.AddCommand(unsafeRollbackCmd)
 }
 
 func init() {
@@ -780,6 +752,7 @@ func init() {
 		workerStorage.Flags,
 		workerSentry.Flags,
 		workerConsensusRPC.Flags,
+		stakingWatcher.Flags,
 		crash.InitFlags(),
 	} {
 		Flags.AddFlagSet(v)
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 
@@ -10,6 +11,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	tendermintCommon "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/common"
+	tendermintFull "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/full"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	"github.com/oasisprotocol/oasis-core/go/runtime/history"
@@ -24,6 +26,11 @@ const (
 	// CfgPreserveMKVSDatabase exempts the MKVS database from the unsafe-reset
 	// sub-command.
 	CfgPreserveMKVSDatabase = "preserve.mkvs_database"
+
+	// CfgPreserveConsensusState exempts the consensus block store, the
+	// consensus state and the ABCI application state from the unsafe-reset
+	// sub-command.
+	CfgPreserveConsensusState = "preserve.consensus_state"
 )
 
 var (
@@ -44,6 +51,15 @@ var (
 		filepath.Join(runtimesGlob, history.DbFilename),
 	}
 
+	// consensusTransientGlobs are the Tendermint state locations that are
+	// removed when the consensus state is being preserved.
+	consensusTransientGlobs = []string{
+		filepath.Join(tendermintCommon.StateDir, "data", "cs.wal"),
+		filepath.Join(tendermintCommon.StateDir, "data", "evidence.*.db"),
+		filepath.Join(tendermintCommon.StateDir, "data", "tx_index.*.db"),
+		filepath.Join(tendermintCommon.StateDir, tendermintCommon.ConfigDir, "addrbook.json"),
+	}
+
 	runtimeLocalStorageGlob = filepath.Join(runtimesGlob, "worker-local-storage.*.db")
 	runtimeMkvsDatabaseGlob = filepath.Join(runtimesGlob, "mkvs_storage.*.db")
 
@@ -73,7 +89,35 @@ func doUnsafeReset(cmd *cobra.Command, args []string) {
 		logger.Info("dry run, no modifications will be made to files")
 	}
 
-	globs := append([]string{}, nodeStateGlobs...)
+	var globs []string
+	if viper.GetBool(CfgPreserveConsensusState) {
+		// Make sure that Tendermint will be able to resume from the preserved state.
+		heights, err := tendermintFull.GetStateHeights(context.Background(), dataDir)
+		if err != nil {
+			logger.Error("failed to query consensus state heights",
+				"err", err,
+			)
+			return
+		}
+		if err = heights.Validate(); err != nil {
+			logger.Error("inconsistent consensus state, refusing to preserve",
+				"err", err,
+				"block_store_height", heights.BlockStore,
+				"state_height", heights.State,
+				"app_state_height", heights.AppState,
+			)
+			return
+		}
+
+		logger.Info("preserving consensus state",
+			"block_store_height", heights.BlockStore,
+			"state_height", heights.State,
+			"app_state_height", heights.AppState,
+		)
+		globs = append(globs, consensusTransientGlobs...)
+	} else {
+		globs = append(globs, nodeStateGlobs...)
+	}
 	if viper.GetBool(CfgPreserveLocalStorage) {
 		logger.Info("preserving untrusted local storage")
 	} else {
@@ -131,5 +175,6 @@ func doUnsafeReset(cmd *cobra.Command, args []string) {
 func init() {
 	unsafeResetFlags.Bool(CfgPreserveLocalStorage, false, "preserve per-runtime untrusted local storage")
 	unsafeResetFlags.Bool(CfgPreserveMKVSDatabase, false, "preserve per-runtime MKVS database")
+	unsafeResetFlags.Bool(CfgPreserveConsensusState, false, "preserve consensus and ABCI application state")
 	_ = viper.BindPFlags(unsafeResetFlags)
 }
@@ -0,0 +1,66 @@
+package node
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	tendermintFull "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/full"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+)
+
+var unsafeRollbackCmd = &cobra.Command{
+	Use:   "unsafe-rollback",
+	Short: "roll back the consensus state by one height (UNSAFE)",
+	Long: `Roll back the consensus state by one height.
+
+The Tendermint consensus state is rolled back by one height and in case the
+ABCI application state is already at the latest height, it is restored from
+the latest local checkpoint. Blocks are retained and will be replayed on the
+next node start. This can be used to recover from an application state hash
+mismatch.`,
+	Run: doUnsafeRollback,
+}
+
+func doUnsafeRollback(cmd *cobra.Command, args []string) {
+	var ok bool
+	defer func() {
+		if !ok {
+			os.Exit(1)
+		}
+	}()
+
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	dataDir := cmdCommon.DataDir()
+	if dataDir == "" {
+		logger.Error("data directory must be set")
+		return
+	}
+
+	isDryRun := cmdFlags.DryRun()
+	if isDryRun {
+		logger.Info("dry run, no modifications will be made to files")
+	}
+
+	res, err := tendermintFull.Rollback(context.Background(), dataDir, isDryRun)
+	if err != nil {
+		logger.Error("failed to roll back consensus state",
+			"err", err,
+		)
+		return
+	}
+
+	logger.Info("consensus state rolled back",
+		"height", res.Height,
+		"app_hash", hex.EncodeToString(res.AppHash),
+		"app_state_height", res.AppStateHeight,
+	)
+
+	ok = true
+}
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
@@ -33,9 +34,16 @@ const (
 	cfgAllowEntitySignedNodes = "entity.debug.allow_entity_signed_nodes"
 	CfgNodeID                 = "entity.node.id"
 	CfgNodeDescriptor         = "entity.node.descriptor"
+	CfgNodeRoles              = "entity.node.roles"
 	CfgReuseSigner            = "entity.reuse_signer"
 
 	entityGenesisFilename = "entity_genesis.json"
+
+	optRoleComputeWorker = "compute-worker"
+	optRoleStorageWorker = "storage-worker"
+	optRoleKeyManager    = "key-manager"
+	optRoleValidator     = "validator"
+	optRoleConsensusRPC  = "consensus-rpc"
 )
 
 var (
@@ -144,6 +152,38 @@ func doInit(cmd *cobra.Command, args []string) {
 	)
 }
 
+// parseNodeRoles parses a node roles declaration of the form <node_id>=<role>[+<role>...].
+func parseNodeRoles(raw string) (signature.PublicKey, node.RolesMask, error) {
+	var (
+		nodeID signature.PublicKey
+		roles  node.RolesMask
+	)
+	parts := strings.SplitN(raw, "=", 2)
+	if len(parts) != 2 {
+		return nodeID, 0, fmt.Errorf("malformed node roles declaration: '%s'", raw)
+	}
+	if err := nodeID.UnmarshalText([]byte(parts[0])); err != nil {
+		return nodeID, 0, fmt.Errorf("malformed node ID: %w", err)
+	}
+	for _, v := range strings.Split(parts[1], "+") {
+		switch strings.ToLower(v) {
+		case optRoleComputeWorker:
+			roles |= node.RoleComputeWorker
+		case optRoleStorageWorker:
+			roles |= node.RoleStorageWorker
+		case optRoleKeyManager:
+			roles |= node.RoleKeyManager
+		case optRoleValidator:
+			roles |= node.RoleValidator
+		case optRoleConsensusRPC:
+			roles |= node.RoleConsensusRPC
+		default:
+			return nodeID, 0, fmt.Errorf("unsupported role: '%s'", v)
+		}
+	}
+	return nodeID, roles, nil
+}
+
 func doUpdate(cmd *cobra.Command, args []string) {
 	if err := cmdCommon.Init(); err != nil {
 		cmdCommon.EarlyLogAndExit(err)
@@ -166,7 +206,8 @@ func doUpdate(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	// Update the entity.
+	// Update the entity, upgrading the descriptor to the latest version.
+	ent.Versioned = cbor.NewVersioned(entity.LatestEntityDescriptorVersion)
 	ent.AllowEntitySignedNodes = viper.GetBool(cfgAllowEntitySignedNodes)
 
 	ent.Nodes = nil
@@ -237,6 +278,32 @@ func doUpdate(cmd *cobra.Command, args []string) {
 		ent.Nodes = append(ent.Nodes, k)
 	}
 
+	// Declare the roles of the entity's nodes.
+	ent.NodeRoles = nil
+	for _, v := range viper.GetStringSlice(CfgNodeRoles) {
+		var (
+			nodeID signature.PublicKey
+			roles  node.RolesMask
+		)
+		if nodeID, roles, err = parseNodeRoles(v); err != nil {
+			logger.Error("failed to parse node roles",
+				"err", err,
+				"node_roles", v,
+			)
+			os.Exit(1)
+		}
+		if !nodeMap[nodeID] {
+			logger.Error("node roles declared for node not associated with this entity",
+				"node_id", nodeID,
+			)
+			os.Exit(1)
+		}
+		if ent.NodeRoles == nil {
+			ent.NodeRoles = make(map[signature.PublicKey]node.RolesMask)
+		}
+		ent.NodeRoles[nodeID] |= roles
+	}
+
 	// Save the entity descriptor.
 	if err = ent.Save(dataDir); err != nil {
 		logger.Error("failed to persist entity descriptor",
@@ -440,6 +507,7 @@ func init() {
 
 	updateFlags.StringSlice(CfgNodeID, nil, "ID(s) of nodes associated with this entity")
 	updateFlags.StringSlice(CfgNodeDescriptor, nil, "Node genesis descriptor(s) of nodes associated with this entity")
+	updateFlags.StringSlice(CfgNodeRoles, nil, "Roles nodes associated with this entity may register with (<node_id>=<role>[+<role>...])")
 	_ = viper.BindPFlags(updateFlags)
 	updateFlags.AddFlagSet(cmdFlags.DebugTestEntityFlags)
 	updateFlags.AddFlagSet(cmdFlags.DebugDontBlameOasisFlag)
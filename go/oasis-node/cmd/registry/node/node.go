@@ -41,6 +41,9 @@ const (
 	CfgSelfSigned       = "node.is_self_signed"
 	CfgNodeRuntimeID    = "node.runtime.id"
 
+	// CfgSoftwareVersion configures the software version filter when listing nodes.
+	CfgSoftwareVersion = "node.software_version"
+
 	optRoleComputeWorker = "compute-worker"
 	optRoleStorageWorker = "storage-worker"
 	optRoleKeyManager    = "key-manager"
@@ -52,7 +55,8 @@ const (
 )
 
 var (
-	flags = flag.NewFlagSet("", flag.ContinueOnError)
+	flags     = flag.NewFlagSet("", flag.ContinueOnError)
+	listFlags = flag.NewFlagSet("", flag.ContinueOnError)
 
 	nodeCmd = &cobra.Command{
 		Use:   "node",
@@ -77,6 +81,13 @@ var (
 		Run:   doIsRegistered,
 	}
 
+	validateCmd = &cobra.Command{
+		Use:   "validate <node_descriptor.json>",
+		Short: "validate a (signed) node descriptor against the current consensus state",
+		Args:  cobra.ExactArgs(1),
+		Run:   doValidate,
+	}
+
 	logger = logging.GetLogger("cmd/registry/node")
 )
 
@@ -329,7 +340,11 @@ func doList(cmd *cobra.Command, args []string) {
 	conn, client := doConnect(cmd)
 	defer conn.Close()
 
-	nodes, err := client.GetNodes(context.Background(), consensus.HeightLatest)
+	query := registry.GetNodesQuery{
+		Height:          consensus.HeightLatest,
+		SoftwareVersion: viper.GetString(CfgSoftwareVersion),
+	}
+	nodes, err := client.GetNodes(context.Background(), &query)
 	if err != nil {
 		logger.Error("failed to query nodes",
 			"err", err,
@@ -383,7 +398,7 @@ func doIsRegistered(cmd *cobra.Command, args []string) {
 	conn, client := doConnect(cmd)
 	defer conn.Close()
 
-	nodes, err := client.GetNodes(context.Background(), consensus.HeightLatest)
+	nodes, err := client.GetNodes(context.Background(), &registry.GetNodesQuery{Height: consensus.HeightLatest})
 	if err != nil {
 		logger.Error("failed to query nodes",
 			"err", err,
@@ -401,6 +416,71 @@ func doIsRegistered(cmd *cobra.Command, args []string) {
 	os.Exit(1)
 }
 
+func doValidate(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	raw, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		logger.Error("failed to read node descriptor",
+			"err", err,
+			"filename", args[0],
+		)
+		os.Exit(1)
+	}
+
+	req := registry.ValidateNodeRequest{Height: consensus.HeightLatest}
+	if req.SignedNode, req.Node, err = parseNodeDescriptor(raw); err != nil {
+		logger.Error("failed to parse node descriptor",
+			"err", err,
+			"filename", args[0],
+		)
+		os.Exit(1)
+	}
+	if req.SignedNode != nil {
+		// Let the node validate the signatures.
+		req.Node = nil
+	}
+
+	conn, client := doConnect(cmd)
+	defer conn.Close()
+
+	result, err := client.ValidateNode(context.Background(), &req)
+	if err != nil {
+		logger.Error("failed to validate node descriptor",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	b, _ := json.MarshalIndent(result, "", "  ")
+	fmt.Println(string(b))
+	if !result.IsValid() {
+		os.Exit(1)
+	}
+}
+
+// parseNodeDescriptor parses a JSON-encoded node descriptor, accepting both signed and unsigned
+// node descriptors. In case the descriptor is signed, the signed descriptor is returned together
+// with the (unverified) node descriptor that it contains.
+func parseNodeDescriptor(raw []byte) (*node.MultiSignedNode, *node.Node, error) {
+	var signedNode node.MultiSignedNode
+	if err := json.Unmarshal(raw, &signedNode); err == nil && len(signedNode.Blob) > 0 {
+		var n node.Node
+		if err = cbor.Unmarshal(signedNode.Blob, &n); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode signed node descriptor: %w", err)
+		}
+		return &signedNode, &n, nil
+	}
+
+	var n node.Node
+	if err := json.Unmarshal(raw, &n); err != nil {
+		return nil, nil, err
+	}
+	return nil, &n, nil
+}
+
 // Register registers the node sub-command and all of it's children.
 func Register(parentCmd *cobra.Command) {
 	initCmd.Flags().AddFlagSet(flags)
@@ -410,13 +490,20 @@ func Register(parentCmd *cobra.Command) {
 
 	listCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
 	listCmd.Flags().AddFlagSet(cmdFlags.VerboseFlags)
+	listCmd.Flags().AddFlagSet(listFlags)
 
 	isRegisteredCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
 
+	validateCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
+
+	probeCmd.Flags().AddFlagSet(probeFlags)
+
 	for _, subCmd := range []*cobra.Command{
 		initCmd,
 		listCmd,
 		isRegisteredCmd,
+		validateCmd,
+		probeCmd,
 	} {
 		nodeCmd.AddCommand(subCmd)
 	}
@@ -434,4 +521,7 @@ func init() {
 	flags.StringSlice(CfgNodeRuntimeID, nil, "Hex Encoded Runtime ID(s) of the node.")
 
 	_ = viper.BindPFlags(flags)
+
+	listFlags.String(CfgSoftwareVersion, "", "Only list nodes reporting the given Oasis Core version")
+	_ = viper.BindPFlags(listFlags)
 }
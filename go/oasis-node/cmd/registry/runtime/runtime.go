@@ -52,22 +52,31 @@ const (
 	CfgExecutorGroupBackupSize   = "runtime.executor.group_backup_size"
 	CfgExecutorAllowedStragglers = "runtime.executor.allowed_stragglers"
 	CfgExecutorRoundTimeout      = "runtime.executor.round_timeout"
+	CfgExecutorMaxMessages       = "runtime.executor.max_messages"
 
 	// Storage committee flags.
 	CfgStorageGroupSize               = "runtime.storage.group_size"
 	CfgStorageMinWriteReplication     = "runtime.storage.min_write_replication"
 	CfgStorageMaxApplyWriteLogEntries = "runtime.storage.max_apply_write_log_entries"
 	CfgStorageMaxApplyOps             = "runtime.storage.max_apply_ops"
+	CfgStorageMaxApplyKeySize         = "runtime.storage.max_apply_key_size"
+	CfgStorageMaxApplyValueSize       = "runtime.storage.max_apply_value_size"
 	CfgStorageCheckpointInterval      = "runtime.storage.checkpoint_interval"
 	CfgStorageCheckpointNumKept       = "runtime.storage.checkpoint_num_kept"
 	CfgStorageCheckpointChunkSize     = "runtime.storage.checkpoint_chunk_size"
 
 	// Transaction scheduler flags.
-	CfgTxnSchedulerAlgorithm         = "runtime.txn_scheduler.algorithm"
-	CfgTxnSchedulerBatchFlushTimeout = "runtime.txn_scheduler.flush_timeout"
-	CfgTxnSchedulerMaxBatchSize      = "runtime.txn_scheduler.max_batch_size"
-	CfgTxnSchedulerMaxBatchSizeBytes = "runtime.txn_scheduler.max_batch_size_bytes"
-	CfgTxnSchedulerProposerTimeout   = "runtime.txn_scheduler.proposer_timeout"
+	CfgTxnSchedulerAlgorithm             = "runtime.txn_scheduler.algorithm"
+	CfgTxnSchedulerBatchFlushTimeout     = "runtime.txn_scheduler.flush_timeout"
+	CfgTxnSchedulerMaxBatchSize          = "runtime.txn_scheduler.max_batch_size"
+	CfgTxnSchedulerMaxBatchSizeBytes     = "runtime.txn_scheduler.max_batch_size_bytes"
+	CfgTxnSchedulerProposerTimeout       = "runtime.txn_scheduler.proposer_timeout"
+	CfgTxnSchedulerProposerTimeoutPolicy = "runtime.txn_scheduler.proposer_timeout_policy"
+	CfgTxnSchedulerBatchFlushBytes       = "runtime.txn_scheduler.flush_bytes"
+	CfgTxnSchedulerBatchFlushTxAge       = "runtime.txn_scheduler.flush_tx_age"
+	CfgTxnSchedulerBatchFlushGas         = "runtime.txn_scheduler.flush_gas"
+	CfgTxnSchedulerTxGasBase             = "runtime.txn_scheduler.tx_gas_base"
+	CfgTxnSchedulerTxGasPerByte          = "runtime.txn_scheduler.tx_gas_per_byte"
 
 	// Admission policy flags.
 	CfgAdmissionPolicy                 = "runtime.admission_policy"
@@ -78,8 +87,14 @@ const (
 	// Staking parameters flags.
 	CfgStakingThreshold = "runtime.staking.threshold"
 
+	// Metadata flags.
+	CfgMetadata = "runtime.metadata"
+
 	// List runtimes flags.
 	CfgIncludeSuspended = "include_suspended"
+	CfgListMetadata     = "metadata"
+	CfgListSortBy       = "sort_by"
+	CfgListDescending   = "descending"
 
 	runtimeGenesisFilename = "runtime_genesis.json"
 )
@@ -204,11 +219,14 @@ func doList(cmd *cobra.Command, args []string) {
 	conn, client := doConnect(cmd)
 	defer conn.Close()
 
-	query := &registry.GetRuntimesQuery{
+	query := &registry.ListRuntimesQuery{
 		Height:           consensus.HeightLatest,
 		IncludeSuspended: viper.GetBool(CfgIncludeSuspended),
+		Metadata:         viper.GetStringMapString(CfgListMetadata),
+		SortBy:           viper.GetString(CfgListSortBy),
+		Descending:       viper.GetBool(CfgListDescending),
 	}
-	runtimes, err := client.GetRuntimes(context.Background(), query)
+	runtimes, err := client.ListRuntimesWithMetadata(context.Background(), query)
 	if err != nil {
 		logger.Error("failed to query runtimes",
 			"err", err,
@@ -374,19 +392,28 @@ func runtimeFromFlags() (*registry.Runtime, signature.Signer, error) { // nolint
 			GroupBackupSize:   viper.GetUint64(CfgExecutorGroupBackupSize),
 			AllowedStragglers: viper.GetUint64(CfgExecutorAllowedStragglers),
 			RoundTimeout:      viper.GetInt64(CfgExecutorRoundTimeout),
+			MaxMessages:       viper.GetUint32(CfgExecutorMaxMessages),
 		},
 		TxnScheduler: registry.TxnSchedulerParameters{
-			Algorithm:         viper.GetString(CfgTxnSchedulerAlgorithm),
-			BatchFlushTimeout: viper.GetDuration(CfgTxnSchedulerBatchFlushTimeout),
-			MaxBatchSize:      viper.GetUint64(CfgTxnSchedulerMaxBatchSize),
-			MaxBatchSizeBytes: uint64(viper.GetSizeInBytes(CfgTxnSchedulerMaxBatchSizeBytes)),
-			ProposerTimeout:   viper.GetInt64(CfgTxnSchedulerProposerTimeout),
+			Algorithm:             viper.GetString(CfgTxnSchedulerAlgorithm),
+			BatchFlushTimeout:     viper.GetDuration(CfgTxnSchedulerBatchFlushTimeout),
+			MaxBatchSize:          viper.GetUint64(CfgTxnSchedulerMaxBatchSize),
+			MaxBatchSizeBytes:     uint64(viper.GetSizeInBytes(CfgTxnSchedulerMaxBatchSizeBytes)),
+			ProposerTimeout:       viper.GetInt64(CfgTxnSchedulerProposerTimeout),
+			ProposerTimeoutPolicy: viper.GetString(CfgTxnSchedulerProposerTimeoutPolicy),
+			BatchFlushBytes:       uint64(viper.GetSizeInBytes(CfgTxnSchedulerBatchFlushBytes)),
+			BatchFlushTxAge:       viper.GetDuration(CfgTxnSchedulerBatchFlushTxAge),
+			BatchFlushGas:         viper.GetUint64(CfgTxnSchedulerBatchFlushGas),
+			TxGasBase:             viper.GetUint64(CfgTxnSchedulerTxGasBase),
+			TxGasPerByte:          viper.GetUint64(CfgTxnSchedulerTxGasPerByte),
 		},
 		Storage: registry.StorageParameters{
 			GroupSize:               viper.GetUint64(CfgStorageGroupSize),
 			MinWriteReplication:     viper.GetUint64(CfgStorageMinWriteReplication),
 			MaxApplyWriteLogEntries: viper.GetUint64(CfgStorageMaxApplyWriteLogEntries),
 			MaxApplyOps:             viper.GetUint64(CfgStorageMaxApplyOps),
+			MaxApplyKeySize:         viper.GetUint64(CfgStorageMaxApplyKeySize),
+			MaxApplyValueSize:       viper.GetUint64(CfgStorageMaxApplyValueSize),
 			CheckpointInterval:      viper.GetUint64(CfgStorageCheckpointInterval),
 			CheckpointNumKept:       viper.GetUint64(CfgStorageCheckpointNumKept),
 			CheckpointChunkSize:     viper.GetUint64(CfgStorageCheckpointChunkSize),
@@ -455,6 +482,11 @@ func runtimeFromFlags() (*registry.Runtime, signature.Signer, error) { // nolint
 		}
 	}
 
+	// Metadata.
+	if md := viper.GetStringMapString(CfgMetadata); len(md) > 0 {
+		rt.Metadata = md
+	}
+
 	// Validate descriptor.
 	if err = rt.ValidateBasic(true); err != nil {
 		return nil, nil, fmt.Errorf("invalid runtime descriptor: %w", err)
@@ -532,6 +564,7 @@ func init() {
 	runtimeFlags.Uint64(CfgExecutorGroupBackupSize, 0, "Number of backup workers in the runtime executor group/committee")
 	runtimeFlags.Uint64(CfgExecutorAllowedStragglers, 0, "Number of stragglers allowed per round in the runtime executor group")
 	runtimeFlags.Int64(CfgExecutorRoundTimeout, 5, "Executor committee round timeout for this runtime (in consensus blocks)")
+	runtimeFlags.Uint32(CfgExecutorMaxMessages, 0, "Maximum number of runtime messages the runtime can emit per round")
 
 	// Init Transaction scheduler flags.
 	runtimeFlags.String(CfgTxnSchedulerAlgorithm, registry.TxnSchedulerSimple, "Transaction scheduling algorithm")
@@ -539,12 +572,20 @@ func init() {
 	runtimeFlags.Uint64(CfgTxnSchedulerMaxBatchSize, 1000, "Maximum size of a batch of runtime requests")
 	runtimeFlags.String(CfgTxnSchedulerMaxBatchSizeBytes, "16mb", "Maximum size (in bytes) of a batch of runtime requests")
 	runtimeFlags.Int64(CfgTxnSchedulerProposerTimeout, 5, "Timeout (in consensus blocks) before a round can be timeouted due to proposer not proposing")
+	runtimeFlags.String(CfgTxnSchedulerProposerTimeoutPolicy, "", "Policy applied after a proposer timeout (fail_round (default), activate_backup)")
+	runtimeFlags.String(CfgTxnSchedulerBatchFlushBytes, "0", "Total size (in bytes) of queued runtime requests that triggers a batch flush (0 uses max batch size bytes)")
+	runtimeFlags.Duration(CfgTxnSchedulerBatchFlushTxAge, 0, "Age of the oldest queued runtime request that triggers a batch flush (0 disables)")
+	runtimeFlags.Uint64(CfgTxnSchedulerBatchFlushGas, 0, "Total estimated gas of queued runtime requests that triggers a batch flush (0 disables)")
+	runtimeFlags.Uint64(CfgTxnSchedulerTxGasBase, 0, "Estimated gas of each runtime request")
+	runtimeFlags.Uint64(CfgTxnSchedulerTxGasPerByte, 0, "Estimated gas per byte of each runtime request")
 
 	// Init Storage committee flags.
 	runtimeFlags.Uint64(CfgStorageGroupSize, 1, "Number of storage nodes for the runtime")
 	runtimeFlags.Uint64(CfgStorageMinWriteReplication, 1, "Minimum required storage write replication")
 	runtimeFlags.Uint64(CfgStorageMaxApplyWriteLogEntries, 100_000, "Maximum number of write log entries")
 	runtimeFlags.Uint64(CfgStorageMaxApplyOps, 2, "Maximum number of apply operations in a batch")
+	runtimeFlags.Uint64(CfgStorageMaxApplyKeySize, 0, "Maximum size of a write log key (0 = no limit)")
+	runtimeFlags.Uint64(CfgStorageMaxApplyValueSize, 0, "Maximum size of a write log value (0 = no limit)")
 	runtimeFlags.Uint64(CfgStorageCheckpointInterval, 0, "Storage checkpoint interval (in rounds)")
 	runtimeFlags.Uint64(CfgStorageCheckpointNumKept, 0, "Number of storage checkpoints to keep")
 	runtimeFlags.Uint64(CfgStorageCheckpointChunkSize, 0, "Storage checkpoint chunk size")
@@ -556,6 +597,9 @@ func init() {
 	// Init Staking flags.
 	runtimeFlags.StringToString(CfgStakingThreshold, nil, "Additional staking threshold for this runtime (<kind>=<value>)")
 
+	// Init Metadata flags.
+	runtimeFlags.StringToString(CfgMetadata, nil, "Runtime metadata (<key>=<value>), e.g. name, homepage, genesis_docs")
+
 	_ = viper.BindPFlags(runtimeFlags)
 	runtimeFlags.AddFlagSet(cmdSigner.Flags)
 	runtimeFlags.AddFlagSet(cmdSigner.CLIFlags)
@@ -566,5 +610,8 @@ func init() {
 
 	// List Runtimes flags.
 	runtimeListFlags.Bool(CfgIncludeSuspended, false, "Use to include suspended runtimes")
+	runtimeListFlags.StringToString(CfgListMetadata, nil, "Only list runtimes with matching metadata (<key>=<value>, empty value matches any)")
+	runtimeListFlags.String(CfgListSortBy, "", "Metadata key to sort runtimes by (default: runtime ID)")
+	runtimeListFlags.Bool(CfgListDescending, false, "Sort runtimes in descending order")
 	_ = viper.BindPFlags(runtimeListFlags)
 }
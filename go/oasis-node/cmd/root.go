@@ -9,6 +9,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/config"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/consensus"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/control"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug"
@@ -72,6 +73,7 @@ func init() {
 
 	// Register all of the sub-commands.
 	for _, v := range []func(*cobra.Command){
+		config.Register,
 		control.Register,
 		debug.Register,
 		genesis.Register,
@@ -86,4 +88,7 @@ func init() {
 	} {
 		v(rootCmd)
 	}
+
+	// The flags of all registered commands define the valid configuration options.
+	cmdCommon.SetConfigRootCommand(rootCmd)
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
@@ -35,19 +36,28 @@ const (
 	// CfgEscrowAccount configures the escrow address.
 	CfgEscrowAccount = "stake.escrow.account"
 
+	// CfgEscrowBeneficiary configures the address of the account credited with escrow shares.
+	CfgEscrowBeneficiary = "stake.escrow.beneficiary"
+
 	// CfgCommissionScheduleRates configures the commission schedule rate steps.
 	CfgCommissionScheduleRates = "stake.commission_schedule.rates"
 
 	// CfgCommissionScheduleBounds configures the commission schedule rate bound steps.
 	CfgCommissionScheduleBounds = "stake.commission_schedule.bounds"
+
+	// CfgCommissionDestination configures the commission destination address.
+	CfgCommissionDestination = "stake.commission_destination"
 )
 
 var (
+	accountAddressFlags     = flag.NewFlagSet("", flag.ContinueOnError)
 	accountInfoFlags        = flag.NewFlagSet("", flag.ContinueOnError)
 	amountFlags             = flag.NewFlagSet("", flag.ContinueOnError)
 	sharesFlags             = flag.NewFlagSet("", flag.ContinueOnError)
 	commonEscrowFlags       = flag.NewFlagSet("", flag.ContinueOnError)
+	addEscrowFlags          = flag.NewFlagSet("", flag.ContinueOnError)
 	commissionScheduleFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	commissionDestFlags     = flag.NewFlagSet("", flag.ContinueOnError)
 	accountTransferFlags    = flag.NewFlagSet("", flag.ContinueOnError)
 	accountBurnFlags        = flag.NewFlagSet("", flag.ContinueOnError)
 
@@ -62,6 +72,18 @@ var (
 		Run:   doAccountInfo,
 	}
 
+	accountNonceCmd = &cobra.Command{
+		Use:   "nonce",
+		Short: "query account nonce",
+		Run:   doAccountNonce,
+	}
+
+	accountValidateAddressCmd = &cobra.Command{
+		Use:   "validate_address",
+		Short: "validate account address and print its canonical form",
+		Run:   doAccountValidateAddress,
+	}
+
 	accountTransferCmd = &cobra.Command{
 		Use:   "gen_transfer",
 		Short: "generate a transfer transaction",
@@ -91,6 +113,12 @@ var (
 		Short: "Generate an amend_commission_schedule transaction",
 		Run:   doAccountAmendCommissionSchedule,
 	}
+
+	accountSetCommissionDestinationCmd = &cobra.Command{
+		Use:   "gen_set_commission_destination",
+		Short: "Generate a set_commission_destination transaction",
+		Run:   doAccountSetCommissionDestination,
+	}
 )
 
 // getCtxWithInfo returns a new context with values that contain additional
@@ -128,6 +156,60 @@ func doAccountInfo(cmd *cobra.Command, args []string) {
 	acct.PrettyPrint(ctx, "", os.Stdout)
 }
 
+func doAccountNonce(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	var addr api.Address
+	if err := addr.UnmarshalText([]byte(viper.GetString(CfgAccountAddr))); err != nil {
+		logger.Error("failed to parse account address",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	conn, client := doConnect(cmd)
+	defer conn.Close()
+
+	acct := getAccount(context.Background(), cmd, addr, client)
+	fmt.Println(acct.General.Nonce)
+}
+
+func doAccountValidateAddress(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	addr, err := parseAddress(viper.GetString(CfgAccountAddr))
+	if err != nil {
+		logger.Error("invalid account address",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	fmt.Println(addr)
+}
+
+// parseAddress parses a Bech32-encoded account address, ignoring any surrounding whitespace. As
+// permitted by Bech32, the address may be in either all lowercase or all uppercase form.
+func parseAddress(text string) (api.Address, error) {
+	text = strings.TrimSpace(text)
+	if text == strings.ToUpper(text) {
+		text = strings.ToLower(text)
+	}
+
+	var addr api.Address
+	if err := addr.UnmarshalText([]byte(text)); err != nil {
+		return api.Address{}, err
+	}
+	if !addr.IsValid() {
+		return api.Address{}, fmt.Errorf("invalid address")
+	}
+	return addr, nil
+}
+
 func doAccountTransfer(cmd *cobra.Command, args []string) {
 	if err := cmdCommon.Init(); err != nil {
 		cmdCommon.EarlyLogAndExit(err)
@@ -199,6 +281,16 @@ func doAccountEscrow(cmd *cobra.Command, args []string) {
 		)
 		os.Exit(1)
 	}
+	if b := viper.GetString(CfgEscrowBeneficiary); b != "" {
+		var beneficiary api.Address
+		if err := beneficiary.UnmarshalText([]byte(b)); err != nil {
+			logger.Error("failed to parse escrow beneficiary",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		escrow.Beneficiary = &beneficiary
+	}
 
 	nonce, fee := cmdConsensus.GetTxNonceAndFee()
 	tx := api.NewAddEscrowTx(nonce, fee, &escrow)
@@ -312,31 +404,70 @@ func doAccountAmendCommissionSchedule(cmd *cobra.Command, args []string) {
 	cmdConsensus.SignAndSaveTx(getCtxWithInfo(genesis), tx)
 }
 
+func doAccountSetCommissionDestination(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	genesis := cmdConsensus.InitGenesis()
+	cmdConsensus.AssertTxFileOK()
+
+	var setCommissionDestination api.SetCommissionDestination
+	if err := setCommissionDestination.Destination.UnmarshalText([]byte(viper.GetString(CfgCommissionDestination))); err != nil {
+		logger.Error("failed to parse commission destination account address",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	nonce, fee := cmdConsensus.GetTxNonceAndFee()
+	tx := api.NewSetCommissionDestinationTx(nonce, fee, &setCommissionDestination)
+
+	cmdConsensus.SignAndSaveTx(getCtxWithInfo(genesis), tx)
+}
+
 func registerAccountCmd() {
 	for _, v := range []*cobra.Command{
 		accountInfoCmd,
+		accountNonceCmd,
+		accountValidateAddressCmd,
 		accountTransferCmd,
 		accountBurnCmd,
 		accountEscrowCmd,
 		accountReclaimEscrowCmd,
 		accountAmendCommissionScheduleCmd,
+		accountSetCommissionDestinationCmd,
+		accountHistoryCmd,
+		accountExportProofCmd,
+		accountVerifyProofCmd,
+		accountWizardCmd,
 	} {
 		accountCmd.AddCommand(v)
 	}
 
 	accountInfoCmd.Flags().AddFlagSet(accountInfoFlags)
+	accountNonceCmd.Flags().AddFlagSet(accountInfoFlags)
+	accountValidateAddressCmd.Flags().AddFlagSet(accountAddressFlags)
 	accountTransferCmd.Flags().AddFlagSet(accountTransferFlags)
 	accountBurnCmd.Flags().AddFlagSet(accountBurnFlags)
 	accountEscrowCmd.Flags().AddFlagSet(commonEscrowFlags)
 	accountEscrowCmd.Flags().AddFlagSet(amountFlags)
+	accountEscrowCmd.Flags().AddFlagSet(addEscrowFlags)
 	accountReclaimEscrowCmd.Flags().AddFlagSet(commonEscrowFlags)
 	accountReclaimEscrowCmd.Flags().AddFlagSet(sharesFlags)
 	accountAmendCommissionScheduleCmd.Flags().AddFlagSet(commissionScheduleFlags)
+	accountSetCommissionDestinationCmd.Flags().AddFlagSet(commissionDestFlags)
+	accountHistoryCmd.Flags().AddFlagSet(accountHistoryFlags)
+	accountExportProofCmd.Flags().AddFlagSet(accountExportProofFlags)
+	accountVerifyProofCmd.Flags().AddFlagSet(accountVerifyProofFlags)
+	accountWizardCmd.Flags().AddFlagSet(accountWizardFlags)
 }
 
 func init() {
-	accountInfoFlags.String(CfgAccountAddr, "", "account address")
-	_ = viper.BindPFlags(accountInfoFlags)
+	accountAddressFlags.String(CfgAccountAddr, "", "account address")
+	_ = viper.BindPFlags(accountAddressFlags)
+
+	accountInfoFlags.AddFlagSet(accountAddressFlags)
 	accountInfoFlags.AddFlagSet(cmdGrpc.ClientFlags)
 
 	amountFlags.String(CfgAmount, "0", "amount of stake (in base units) for the transaction")
@@ -360,6 +491,9 @@ func init() {
 	commonEscrowFlags.AddFlagSet(cmdConsensus.TxFlags)
 	commonEscrowFlags.AddFlagSet(cmdFlags.AssumeYesFlag)
 
+	addEscrowFlags.String(CfgEscrowBeneficiary, "", "address of the account credited with the delegation shares (default: signer)")
+	_ = viper.BindPFlags(addEscrowFlags)
+
 	commissionScheduleFlags.StringSlice(CfgCommissionScheduleRates, nil, fmt.Sprintf(
 		"commission rate step. Multiple of this flag is allowed. "+
 			"Each step is in the format start_epoch/rate_numerator. "+
@@ -374,4 +508,10 @@ func init() {
 	_ = viper.BindPFlags(commissionScheduleFlags)
 	commissionScheduleFlags.AddFlagSet(cmdConsensus.TxFlags)
 	commissionScheduleFlags.AddFlagSet(cmdFlags.AssumeYesFlag)
+
+	commissionDestFlags.String(CfgCommissionDestination, "", "commission destination account address "+
+		"(use the escrow account's own address to restore self-delegation of commission)")
+	_ = viper.BindPFlags(commissionDestFlags)
+	commissionDestFlags.AddFlagSet(cmdConsensus.TxFlags)
+	commissionDestFlags.AddFlagSet(cmdFlags.AssumeYesFlag)
 }
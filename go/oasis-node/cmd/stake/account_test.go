@@ -0,0 +1,44 @@
+package stake
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestParseAddress(t *testing.T) {
+	require := require.New(t)
+
+	addr := api.NewAddress(signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	canonical := addr.String()
+
+	for _, text := range []string{
+		canonical,
+		"  " + canonical + "\n",
+		strings.ToUpper(canonical),
+	} {
+		parsed, err := parseAddress(text)
+		require.NoError(err, "parseAddress(%q)", text)
+		require.Equal(canonical, parsed.String(), "parsed address should be canonical")
+	}
+
+	mixedCase := strings.ToUpper(canonical[:10]) + canonical[10:]
+	badChecksum := canonical[:len(canonical)-1] + "q"
+	if strings.HasSuffix(canonical, "q") {
+		badChecksum = canonical[:len(canonical)-1] + "p"
+	}
+	for _, text := range []string{
+		"",
+		"oasis1",
+		mixedCase,
+		badChecksum,
+		strings.Replace(canonical, "oasis", "cosmos", 1),
+	} {
+		_, err := parseAddress(text)
+		require.Error(err, "parseAddress(%q) should fail", text)
+	}
+}
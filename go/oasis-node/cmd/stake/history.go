@@ -0,0 +1,395 @@
+package stake
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+const (
+	// CfgHistoryFromHeight configures the first height of the exported history.
+	CfgHistoryFromHeight = "stake.history.from_height"
+
+	// CfgHistoryToHeight configures the last height of the exported history.
+	CfgHistoryToHeight = "stake.history.to_height"
+
+	// CfgHistoryFormat configures the format of the exported history.
+	CfgHistoryFormat = "stake.history.format"
+
+	// CfgHistoryOutput configures the file the history is exported to.
+	CfgHistoryOutput = "stake.history.output"
+
+	// CfgHistoryResume configures resuming an interrupted export.
+	CfgHistoryResume = "stake.history.resume"
+
+	// CfgHistoryPageSize configures the number of heights queried per page.
+	CfgHistoryPageSize = "stake.history.page_size"
+
+	historyFormatCSV  = "csv"
+	historyFormatJSON = "json"
+)
+
+var (
+	accountHistoryFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	accountHistoryCmd = &cobra.Command{
+		Use:   "history",
+		Short: "export the event history of an account",
+		Run:   doAccountHistory,
+	}
+
+	historyCSVHeader = []string{"height", "tx_hash", "type", "from", "to", "amount"}
+)
+
+// historyWriter writes account history entries in a specific format.
+type historyWriter interface {
+	// WriteHeader writes the header (if any).
+	WriteHeader() error
+
+	// WriteEvent writes a single event.
+	WriteEvent(ev *api.Event) error
+
+	// Flush flushes any buffered entries.
+	Flush() error
+}
+
+type csvHistoryWriter struct {
+	w *csv.Writer
+}
+
+func (h *csvHistoryWriter) WriteHeader() error {
+	return h.w.Write(historyCSVHeader)
+}
+
+func (h *csvHistoryWriter) WriteEvent(ev *api.Event) error {
+	kind, from, to, amount := describeEvent(ev)
+	return h.w.Write([]string{
+		strconv.FormatInt(ev.Height, 10),
+		ev.TxHash.String(),
+		kind,
+		from,
+		to,
+		amount,
+	})
+}
+
+func (h *csvHistoryWriter) Flush() error {
+	h.w.Flush()
+	return h.w.Error()
+}
+
+type jsonHistoryWriter struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func (h *jsonHistoryWriter) WriteHeader() error {
+	return nil
+}
+
+func (h *jsonHistoryWriter) WriteEvent(ev *api.Event) error {
+	return h.enc.Encode(ev)
+}
+
+func (h *jsonHistoryWriter) Flush() error {
+	return h.w.Flush()
+}
+
+func newHistoryWriter(format string, w io.Writer) (historyWriter, error) {
+	switch format {
+	case historyFormatCSV:
+		return &csvHistoryWriter{w: csv.NewWriter(w)}, nil
+	case historyFormatJSON:
+		bw := bufio.NewWriter(w)
+		return &jsonHistoryWriter{w: bw, enc: json.NewEncoder(bw)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported history format: '%s'", format)
+	}
+}
+
+// describeEvent returns the type, source, destination and amount of an event
+// for tabular export.
+func describeEvent(ev *api.Event) (kind, from, to, amount string) {
+	switch {
+	case ev.Transfer != nil:
+		return "transfer", ev.Transfer.From.String(), ev.Transfer.To.String(), ev.Transfer.Amount.String()
+	case ev.Burn != nil:
+		return "burn", ev.Burn.Owner.String(), "", ev.Burn.Amount.String()
+	case ev.Escrow != nil && ev.Escrow.Add != nil:
+		return "add_escrow", ev.Escrow.Add.Owner.String(), ev.Escrow.Add.Escrow.String(), ev.Escrow.Add.Amount.String()
+	case ev.Escrow != nil && ev.Escrow.Take != nil:
+		return "take_escrow", ev.Escrow.Take.Owner.String(), "", ev.Escrow.Take.Amount.String()
+	case ev.Escrow != nil && ev.Escrow.Reclaim != nil:
+		return "reclaim_escrow", ev.Escrow.Reclaim.Escrow.String(), ev.Escrow.Reclaim.Owner.String(), ev.Escrow.Reclaim.Amount.String()
+	case ev.AllowanceChange != nil:
+		amount = ev.AllowanceChange.AmountChange.String()
+		if ev.AllowanceChange.Negative {
+			amount = "-" + amount
+		}
+		return "allowance_change", ev.AllowanceChange.Owner.String(), ev.AllowanceChange.Beneficiary.String(), amount
+	case ev.CommissionDestinationChange != nil:
+		return "commission_destination_change", ev.CommissionDestinationChange.Owner.String(), ev.CommissionDestinationChange.Destination.String(), ""
+	case ev.PolicyViolation != nil:
+		return "policy_violation", ev.PolicyViolation.Address.String(), ev.PolicyViolation.Counterparty.String(), ""
+	case ev.EscrowThreshold != nil:
+		return "escrow_threshold", ev.EscrowThreshold.Owner.String(), "", ev.EscrowThreshold.Balance.String()
+	case ev.CommissionScheduleAmendment != nil:
+		return "commission_schedule_amendment", ev.CommissionScheduleAmendment.Owner.String(), "", ""
+	default:
+		return "unknown", "", "", ""
+	}
+}
+
+func eventInvolves(ev *api.Event, addr api.Address) bool {
+	for _, related := range ev.RelatedAddresses() {
+		if related.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// resumeHistory truncates an existing (possibly partial) history export to
+// the entries preceding its last exported height and returns that height, so
+// the export can continue from it. Zero is returned in case the export
+// contains no entries.
+func resumeHistory(path, format string) (int64, error) {
+	data, err := ioutil.ReadFile(path)
+	switch {
+	case err == nil:
+	case os.IsNotExist(err):
+		return 0, nil
+	default:
+		return 0, err
+	}
+
+	lines := strings.SplitAfter(string(data), "\n")
+	heightOf := func(line string) (int64, bool) {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			return 0, false
+		}
+		switch format {
+		case historyFormatCSV:
+			field := strings.SplitN(line, ",", 2)[0]
+			height, perr := strconv.ParseInt(field, 10, 64)
+			return height, perr == nil
+		default:
+			var ev api.Event
+			if jerr := json.Unmarshal([]byte(line), &ev); jerr != nil {
+				return 0, false
+			}
+			return ev.Height, true
+		}
+	}
+
+	// Find the last complete entry.
+	var lastHeight int64
+	for i := len(lines) - 1; i >= 0; i-- {
+		if !strings.HasSuffix(lines[i], "\n") {
+			continue
+		}
+		if height, ok := heightOf(lines[i]); ok {
+			lastHeight = height
+			break
+		}
+	}
+	if lastHeight == 0 {
+		return 0, nil
+	}
+
+	// Drop all entries at the last height (which may be incomplete), as well as any partially
+	// written trailing line.
+	var kept []string
+	for _, line := range lines {
+		if !strings.HasSuffix(line, "\n") {
+			continue
+		}
+		if height, ok := heightOf(line); ok && height >= lastHeight {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	if err = ioutil.WriteFile(path, []byte(strings.Join(kept, "")), 0o600); err != nil {
+		return 0, err
+	}
+	return lastHeight, nil
+}
+
+func doAccountHistory(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	var addr api.Address
+	if err := addr.UnmarshalText([]byte(viper.GetString(CfgAccountAddr))); err != nil {
+		logger.Error("failed to parse account address",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	format := strings.ToLower(viper.GetString(CfgHistoryFormat))
+	output := viper.GetString(CfgHistoryOutput)
+	pageSize := viper.GetInt64(CfgHistoryPageSize)
+	if pageSize <= 0 {
+		logger.Error("invalid page size",
+			"page_size", pageSize,
+		)
+		os.Exit(1)
+	}
+
+	conn, client := doConnect(cmd)
+	defer conn.Close()
+	consensusClient := consensus.NewConsensusClient(conn)
+
+	ctx := context.Background()
+	status, err := consensusClient.GetStatus(ctx)
+	if err != nil {
+		logger.Error("failed to query consensus status",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	fromHeight := viper.GetInt64(CfgHistoryFromHeight)
+	if fromHeight < status.LastRetainedHeight {
+		fromHeight = status.LastRetainedHeight
+	}
+	toHeight := viper.GetInt64(CfgHistoryToHeight)
+	if toHeight == 0 || toHeight > status.LatestHeight {
+		toHeight = status.LatestHeight
+	}
+
+	// Set up the output.
+	var (
+		w           io.Writer = os.Stdout
+		writeHeader           = true
+	)
+	if output != "" {
+		if viper.GetBool(CfgHistoryResume) {
+			var lastHeight int64
+			if lastHeight, err = resumeHistory(output, format); err != nil {
+				logger.Error("failed to resume history export",
+					"err", err,
+					"output", output,
+				)
+				os.Exit(1)
+			}
+			if lastHeight > 0 {
+				logger.Info("resuming history export",
+					"height", lastHeight,
+				)
+				fromHeight = lastHeight
+			}
+		}
+
+		var fi os.FileInfo
+		if fi, err = os.Stat(output); err == nil && fi.Size() > 0 && viper.GetBool(CfgHistoryResume) {
+			writeHeader = false
+		}
+
+		flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+		if !writeHeader {
+			flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+		}
+		var f *os.File
+		if f, err = os.OpenFile(output, flags, 0o600); err != nil {
+			logger.Error("failed to open output file",
+				"err", err,
+				"output", output,
+			)
+			os.Exit(1)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	hw, err := newHistoryWriter(format, w)
+	if err != nil {
+		logger.Error("failed to create history writer",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	if writeHeader {
+		if err = hw.WriteHeader(); err != nil {
+			logger.Error("failed to write history header",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+	}
+
+	// Query events page by page, flushing after each page so that an interrupted export can be
+	// resumed.
+	for pageStart := fromHeight; pageStart <= toHeight; pageStart += pageSize {
+		pageEnd := pageStart + pageSize - 1
+		if pageEnd > toHeight {
+			pageEnd = toHeight
+		}
+
+		for height := pageStart; height <= pageEnd; height++ {
+			var events []*api.Event
+			if events, err = client.GetEvents(ctx, height); err != nil {
+				logger.Error("failed to query events",
+					"err", err,
+					"height", height,
+				)
+				_ = hw.Flush()
+				os.Exit(1)
+			}
+			for _, ev := range events {
+				if !eventInvolves(ev, addr) {
+					continue
+				}
+				if err = hw.WriteEvent(ev); err != nil {
+					logger.Error("failed to write event",
+						"err", err,
+						"height", height,
+					)
+					os.Exit(1)
+				}
+			}
+		}
+
+		if err = hw.Flush(); err != nil {
+			logger.Error("failed to flush history",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		logger.Debug("exported account history page",
+			"from_height", pageStart,
+			"to_height", pageEnd,
+		)
+	}
+}
+
+func init() {
+	accountHistoryFlags.Int64(CfgHistoryFromHeight, 0, "first height to export history for (defaults to the earliest retained height)")
+	accountHistoryFlags.Int64(CfgHistoryToHeight, 0, "last height to export history for (0 for the latest height)")
+	accountHistoryFlags.String(CfgHistoryFormat, historyFormatCSV, "export format (csv or json)")
+	accountHistoryFlags.String(CfgHistoryOutput, "", "file to export history to (defaults to standard output)")
+	accountHistoryFlags.Bool(CfgHistoryResume, false, "resume an interrupted export to the output file")
+	accountHistoryFlags.Int64(CfgHistoryPageSize, 1000, "number of heights to query between flushing the output")
+	_ = viper.BindPFlags(accountHistoryFlags)
+	accountHistoryFlags.AddFlagSet(accountAddressFlags)
+	accountHistoryFlags.AddFlagSet(cmdGrpc.ClientFlags)
+}
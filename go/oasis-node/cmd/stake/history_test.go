@@ -0,0 +1,146 @@
+package stake
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+var (
+	historyTestAddr1 = api.NewAddress(signature.NewPublicKey("badadd1e55ffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	historyTestAddr2 = api.NewAddress(signature.NewPublicKey("badbadadd1e55fffffffffffffffffffffffffffffffffffffffffffffffffff"))
+)
+
+func historyTestEvents() []*api.Event {
+	return []*api.Event{
+		{
+			Height: 10,
+			Transfer: &api.TransferEvent{
+				From:   historyTestAddr1,
+				To:     historyTestAddr2,
+				Amount: *quantity.NewFromUint64(100),
+			},
+		},
+		{
+			Height: 11,
+			AllowanceChange: &api.AllowanceChangeEvent{
+				Owner:        historyTestAddr1,
+				Beneficiary:  historyTestAddr2,
+				Negative:     true,
+				AmountChange: *quantity.NewFromUint64(5),
+			},
+		},
+		{
+			Height: 12,
+			Burn: &api.BurnEvent{
+				Owner:  historyTestAddr1,
+				Amount: *quantity.NewFromUint64(7),
+			},
+		},
+	}
+}
+
+func TestCSVHistoryWriter(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	hw, err := newHistoryWriter(historyFormatCSV, &buf)
+	require.NoError(err, "newHistoryWriter")
+	require.NoError(hw.WriteHeader(), "WriteHeader")
+	for _, ev := range historyTestEvents() {
+		require.NoError(hw.WriteEvent(ev), "WriteEvent")
+	}
+	require.NoError(hw.Flush(), "Flush")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(lines, 4, "header and one line per event")
+	require.Equal(strings.Join(historyCSVHeader, ","), lines[0])
+
+	fields := strings.Split(lines[1], ",")
+	require.Equal([]string{"10", fields[1], "transfer", historyTestAddr1.String(), historyTestAddr2.String(), "100"}, fields)
+	fields = strings.Split(lines[2], ",")
+	require.Equal("allowance_change", fields[2])
+	require.Equal("-5", fields[5], "negative allowance changes should be signed")
+	fields = strings.Split(lines[3], ",")
+	require.Equal([]string{"12", fields[1], "burn", historyTestAddr1.String(), "", "7"}, fields)
+}
+
+func TestJSONHistoryWriter(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	hw, err := newHistoryWriter(historyFormatJSON, &buf)
+	require.NoError(err, "newHistoryWriter")
+	require.NoError(hw.WriteHeader(), "WriteHeader")
+	events := historyTestEvents()
+	for _, ev := range events {
+		require.NoError(hw.WriteEvent(ev), "WriteEvent")
+	}
+	require.NoError(hw.Flush(), "Flush")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(lines, len(events), "one line per event")
+	for i, line := range lines {
+		var ev api.Event
+		require.NoError(json.Unmarshal([]byte(line), &ev), "Unmarshal")
+		require.Equal(events[i].Height, ev.Height)
+		require.EqualValues(events[i].RelatedAddresses(), ev.RelatedAddresses())
+	}
+
+	_, err = newHistoryWriter("xml", &buf)
+	require.Error(err, "unsupported formats should be rejected")
+}
+
+func TestResumeHistory(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "oasis-stake-history-test")
+	require.NoError(err, "MkdirTemp")
+	defer os.RemoveAll(dir)
+
+	for _, format := range []string{historyFormatCSV, historyFormatJSON} {
+		path := filepath.Join(dir, "history."+format)
+
+		// Resuming a non-existent export should start from scratch.
+		height, err := resumeHistory(path, format)
+		require.NoError(err, "resumeHistory")
+		require.EqualValues(0, height)
+
+		var buf bytes.Buffer
+		hw, err := newHistoryWriter(format, &buf)
+		require.NoError(err, "newHistoryWriter")
+		require.NoError(hw.WriteHeader(), "WriteHeader")
+		for _, ev := range historyTestEvents() {
+			require.NoError(hw.WriteEvent(ev), "WriteEvent")
+		}
+		require.NoError(hw.Flush(), "Flush")
+		// Simulate an interrupted write.
+		buf.WriteString("13,partial")
+		require.NoError(ioutil.WriteFile(path, buf.Bytes(), 0o600), "WriteFile")
+
+		height, err = resumeHistory(path, format)
+		require.NoError(err, "resumeHistory")
+		require.EqualValues(12, height, "export should resume at the last complete height")
+
+		data, err := ioutil.ReadFile(path)
+		require.NoError(err, "ReadFile")
+		require.NotContains(string(data), "partial", "partial lines should be dropped")
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		switch format {
+		case historyFormatCSV:
+			require.Len(lines, 3, "header and entries below the resume height should be kept")
+		default:
+			require.Len(lines, 2, "entries below the resume height should be kept")
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package stake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+const (
+	// CfgProofHeight configures the height at which the account state is exported.
+	CfgProofHeight = "stake.proof.height"
+
+	// CfgProofOutput configures the file the account state proof is exported to.
+	CfgProofOutput = "stake.proof.output"
+
+	// CfgProofFile configures the account state proof file to verify.
+	CfgProofFile = "stake.proof.file"
+)
+
+var (
+	accountExportProofFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	accountVerifyProofFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	accountExportProofCmd = &cobra.Command{
+		Use:   "export_proof",
+		Short: "export the account state together with a proof against the consensus state root",
+		Run:   doAccountExportProof,
+	}
+
+	accountVerifyProofCmd = &cobra.Command{
+		Use:   "verify_proof",
+		Short: "verify an exported account state proof (does not require a node)",
+		Run:   doAccountVerifyProof,
+	}
+)
+
+func doAccountExportProof(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	var addr api.Address
+	if err := addr.UnmarshalText([]byte(viper.GetString(CfgAccountAddr))); err != nil {
+		logger.Error("failed to parse account address",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	conn, client := doConnect(cmd)
+	defer conn.Close()
+
+	ctx := context.Background()
+	proof, err := client.AccountStateProof(ctx, &api.OwnerQuery{
+		Height: viper.GetInt64(CfgProofHeight),
+		Owner:  addr,
+	})
+	if err != nil {
+		logger.Error("failed to export account state proof",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	data, err := json.MarshalIndent(proof, "", "  ")
+	if err != nil {
+		logger.Error("failed to marshal account state proof",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	output := viper.GetString(CfgProofOutput)
+	if output == "" {
+		fmt.Printf("%s\n", data)
+		return
+	}
+	if err = ioutil.WriteFile(output, data, 0o600); err != nil {
+		logger.Error("failed to write account state proof",
+			"err", err,
+			"output", output,
+		)
+		os.Exit(1)
+	}
+}
+
+func doAccountVerifyProof(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	path := viper.GetString(CfgProofFile)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		logger.Error("failed to read account state proof",
+			"err", err,
+			"file", path,
+		)
+		os.Exit(1)
+	}
+
+	var proof api.AccountStateProof
+	if err = json.Unmarshal(data, &proof); err != nil {
+		logger.Error("failed to unmarshal account state proof",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	if err = stakingState.VerifyAccountStateProof(context.Background(), &proof); err != nil {
+		logger.Error("failed to verify account state proof",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Account state proof is valid.\n")
+	fmt.Printf("NOTE: The state root must be checked against a trusted block header.\n")
+	fmt.Printf("Height: %d\n", proof.Height)
+	fmt.Printf("State root: %s\n", proof.StateRoot.Hash)
+	fmt.Printf("Address: %s\n", proof.Owner)
+	out, _ := json.MarshalIndent(struct {
+		Account              api.Account                                `json:"account"`
+		Delegations          map[api.Address]*api.Delegation            `json:"delegations"`
+		DebondingDelegations map[api.Address][]*api.DebondingDelegation `json:"debonding_delegations"`
+	}{proof.Account, proof.Delegations, proof.DebondingDelegations}, "", "  ")
+	fmt.Printf("%s\n", out)
+}
+
+func init() {
+	accountExportProofFlags.Int64(CfgProofHeight, consensus.HeightLatest, "height to export the account state at (0 for the latest height)")
+	accountExportProofFlags.String(CfgProofOutput, "", "file to export the account state proof to (defaults to standard output)")
+	_ = viper.BindPFlags(accountExportProofFlags)
+	accountExportProofFlags.AddFlagSet(accountAddressFlags)
+	accountExportProofFlags.AddFlagSet(cmdGrpc.ClientFlags)
+
+	accountVerifyProofFlags.String(CfgProofFile, "", "account state proof file to verify")
+	_ = viper.BindPFlags(accountVerifyProofFlags)
+}
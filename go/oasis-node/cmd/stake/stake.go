@@ -13,7 +13,6 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
-	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
@@ -157,29 +156,30 @@ func doInfo(cmd *cobra.Command, args []string) {
 	token.PrettyPrintAmount(ctx, *lastBlockFees, os.Stdout)
 	fmt.Println()
 
-	thresholdsToQuery := []api.ThresholdKind{
-		api.KindEntity,
-		api.KindNodeValidator,
-		api.KindNodeCompute,
-		api.KindNodeStorage,
-		api.KindNodeKeyManager,
-		api.KindRuntimeCompute,
-		api.KindRuntimeKeyManager,
+	rewardScale, err := client.RewardScale(ctx, consensus.HeightLatest)
+	if err != nil {
+		logger.Error("failed to query reward scale",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Printf("Reward scale: %s/%s\n", rewardScale, api.RewardAmountDenominator)
+
+	thresholds, err := client.Thresholds(ctx, consensus.HeightLatest)
+	if err != nil {
+		logger.Error("failed to query staking thresholds",
+			"err", err,
+		)
+		os.Exit(1)
 	}
-	for _, kind := range thresholdsToQuery {
-		thres, err := client.Threshold(ctx, &api.ThresholdQuery{Kind: kind, Height: consensus.HeightLatest})
-		if err != nil {
-			if errors.Is(err, api.ErrInvalidThreshold) {
-				logger.Warn(fmt.Sprintf("invalid staking threshold kind: %s", kind))
-				continue
-			}
-			logger.Error("failed to query staking threshold",
-				"err", err,
-			)
-			os.Exit(1)
+	for kind := api.KindEntity; kind <= api.KindMax; kind++ {
+		thres, ok := thresholds[kind]
+		if !ok {
+			logger.Warn(fmt.Sprintf("missing staking threshold kind: %s", kind))
+			continue
 		}
 		fmt.Printf("Staking threshold (%s): ", kind)
-		token.PrettyPrintAmount(ctx, *thres, os.Stdout)
+		token.PrettyPrintAmount(ctx, thres, os.Stdout)
 		fmt.Println()
 	}
 }
@@ -0,0 +1,411 @@
+package stake
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdConsensus "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/consensus"
+	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
+	cmdSigner "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/signer"
+	"github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
+)
+
+const (
+	wizardKindTransfer      = "transfer"
+	wizardKindEscrow        = "escrow"
+	wizardKindReclaimEscrow = "reclaim_escrow"
+
+	// wizardGasPriceBlocks is the number of recent blocks used for gas price suggestions.
+	wizardGasPriceBlocks = 10
+)
+
+var (
+	accountWizardFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	accountWizardCmd = &cobra.Command{
+		Use:   "wizard",
+		Short: "interactively generate a transfer, escrow or reclaim_escrow transaction",
+		Run:   doAccountWizard,
+	}
+
+	wizardKinds = []string{wizardKindTransfer, wizardKindEscrow, wizardKindReclaimEscrow}
+)
+
+// wizardPrompter reads and validates interactive user input.
+type wizardPrompter struct {
+	r *bufio.Reader
+	w io.Writer
+}
+
+// prompt asks the user for a value until the given validation function accepts it. In case the
+// user enters an empty line, the default value is used (if any).
+func (p *wizardPrompter) prompt(label, defaultValue string, validate func(string) error) (string, error) {
+	for {
+		if defaultValue != "" {
+			fmt.Fprintf(p.w, "%s [%s]: ", label, defaultValue)
+		} else {
+			fmt.Fprintf(p.w, "%s: ", label)
+		}
+
+		line, err := p.r.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			return "", err
+		}
+		value := strings.TrimSpace(line)
+		if value == "" {
+			value = defaultValue
+		}
+
+		if verr := validate(value); verr != nil {
+			fmt.Fprintf(p.w, "Invalid value: %s\n", verr)
+			if err == io.EOF {
+				return "", err
+			}
+			continue
+		}
+		return value, nil
+	}
+}
+
+// promptQuantity asks the user for a quantity in base units within the given (inclusive) bounds.
+// A nil bound is not enforced.
+func (p *wizardPrompter) promptQuantity(label, defaultValue string, min, max *quantity.Quantity) (*quantity.Quantity, error) {
+	var q quantity.Quantity
+	_, err := p.prompt(label, defaultValue, func(value string) error {
+		return validateQuantity(&q, value, min, max)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
+
+// promptAddress asks the user for an account address.
+func (p *wizardPrompter) promptAddress(label string) (api.Address, error) {
+	var addr api.Address
+	_, err := p.prompt(label, "", func(value string) (err error) {
+		addr, err = parseAddress(value)
+		return
+	})
+	return addr, err
+}
+
+// validateQuantity parses a quantity in base units and checks it against the given (inclusive)
+// bounds. A nil bound is not enforced.
+func validateQuantity(dst *quantity.Quantity, value string, min, max *quantity.Quantity) error {
+	var q quantity.Quantity
+	if err := q.UnmarshalText([]byte(value)); err != nil {
+		return fmt.Errorf("malformed amount: %w", err)
+	}
+	if min != nil && q.Cmp(min) < 0 {
+		return fmt.Errorf("amount must be at least %s", min)
+	}
+	if max != nil && q.Cmp(max) > 0 {
+		return fmt.Errorf("amount must be at most %s", max)
+	}
+	*dst = q
+	return nil
+}
+
+// suggestGasPrice suggests a gas price based on the median gas price paid by the given raw
+// transactions. Malformed transactions are ignored.
+func suggestGasPrice(rawTxs [][]byte) *quantity.Quantity {
+	var prices []*quantity.Quantity
+	for _, raw := range rawTxs {
+		var sigTx transaction.SignedTransaction
+		if err := cbor.Unmarshal(raw, &sigTx); err != nil {
+			continue
+		}
+		var tx transaction.Transaction
+		if err := cbor.Unmarshal(sigTx.Blob, &tx); err != nil || tx.Fee == nil {
+			continue
+		}
+		prices = append(prices, tx.Fee.GasPrice())
+	}
+	if len(prices) == 0 {
+		return quantity.NewQuantity()
+	}
+
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].Cmp(prices[j]) < 0
+	})
+	return prices[len(prices)/2]
+}
+
+func getRecentGasPrice(ctx context.Context, client consensus.ClientBackend) *quantity.Quantity {
+	blk, err := client.GetBlock(ctx, consensus.HeightLatest)
+	if err != nil {
+		logger.Error("failed to query latest block",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	var rawTxs [][]byte
+	for height := blk.Height; height > 0 && height > blk.Height-wizardGasPriceBlocks; height-- {
+		txs, err := client.GetTransactions(ctx, height)
+		if err != nil {
+			// Older blocks may not be available, use what we have.
+			break
+		}
+		rawTxs = append(rawTxs, txs...)
+	}
+	return suggestGasPrice(rawTxs)
+}
+
+// wizardState is the state queried from the node that is used to guide the user.
+type wizardState struct {
+	addr    api.Address
+	account *api.Account
+	params  *api.ConsensusParameters
+}
+
+// buildTx asks the user for the details of a transaction of the given kind.
+func (p *wizardPrompter) buildTx(ctx context.Context, kind string, state *wizardState, client api.Backend) (*transaction.Transaction, *quantity.Quantity, error) {
+	balance := state.account.General.Balance.Clone()
+	switch kind {
+	case wizardKindTransfer:
+		var xfer api.Transfer
+		var err error
+		if xfer.To, err = p.promptAddress("Destination account address"); err != nil {
+			return nil, nil, err
+		}
+		amount, err := p.promptQuantity("Amount (in base units)", "", &state.params.MinTransferAmount, balance)
+		if err != nil {
+			return nil, nil, err
+		}
+		xfer.Amount = *amount
+		return api.NewTransferTx(0, nil, &xfer), amount, nil
+	case wizardKindEscrow:
+		var escrow api.Escrow
+		var err error
+		if escrow.Account, err = p.promptAddress("Escrow account address"); err != nil {
+			return nil, nil, err
+		}
+		amount, err := p.promptQuantity("Amount (in base units)", "", &state.params.MinDelegationAmount, balance)
+		if err != nil {
+			return nil, nil, err
+		}
+		escrow.Amount = *amount
+		return api.NewAddEscrowTx(0, nil, &escrow), amount, nil
+	case wizardKindReclaimEscrow:
+		delegations, err := client.Delegations(ctx, &api.OwnerQuery{Owner: state.addr, Height: consensus.HeightLatest})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to query delegations: %w", err)
+		}
+		if len(delegations) == 0 {
+			return nil, nil, fmt.Errorf("account does not have any delegations")
+		}
+		fmt.Fprintf(p.w, "Delegations:\n")
+		for to, d := range delegations {
+			fmt.Fprintf(p.w, "  %s: %s shares\n", to, d.Shares)
+		}
+
+		var reclaim api.ReclaimEscrow
+		_, err = p.prompt("Escrow account address", "", func(value string) error {
+			addr, aerr := parseAddress(value)
+			if aerr != nil {
+				return aerr
+			}
+			if _, ok := delegations[addr]; !ok {
+				return fmt.Errorf("no delegation to %s", addr)
+			}
+			reclaim.Account = addr
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		shares, err := p.promptQuantity("Shares", delegations[reclaim.Account].Shares.String(), quantity.NewFromUint64(1), &delegations[reclaim.Account].Shares)
+		if err != nil {
+			return nil, nil, err
+		}
+		reclaim.Shares = *shares
+		return api.NewReclaimEscrowTx(0, nil, &reclaim), quantity.NewQuantity(), nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported transaction kind: %s", kind)
+	}
+}
+
+func doAccountWizard(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	genesis := cmdConsensus.InitGenesis()
+	cmdConsensus.AssertTxFileOK()
+
+	// Determine the signing account.
+	entityDir, err := cmdSigner.CLIDirOrPwd()
+	if err != nil {
+		logger.Error("failed to retrieve signer dir",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	ent, signer, err := cmdCommon.LoadEntity(cmdSigner.Backend(), entityDir)
+	if err != nil {
+		logger.Error("failed to load account entity",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	signerPk := signer.Public()
+	signer.Reset()
+
+	conn, client := doConnect(cmd)
+	defer conn.Close()
+	consensusClient := consensus.NewConsensusClient(conn)
+
+	ctx := getCtxWithInfo(genesis)
+	state := &wizardState{
+		addr: api.NewAddress(ent.ID),
+	}
+	state.account = getAccount(ctx, cmd, state.addr, client)
+	if state.params, err = client.ConsensusParameters(ctx, consensus.HeightLatest); err != nil {
+		logger.Error("failed to query staking consensus parameters",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	nonce, err := consensusClient.GetSignerNonce(ctx, &consensus.GetSignerNonceRequest{
+		AccountAddress: state.addr,
+		Height:         consensus.HeightLatest,
+	})
+	if err != nil {
+		logger.Error("failed to query signer nonce",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	p := &wizardPrompter{
+		r: bufio.NewReader(os.Stdin),
+		w: os.Stdout,
+	}
+	fmt.Printf("Account: %s\n", state.addr)
+	fmt.Printf("Balance: ")
+	token.PrettyPrintAmount(ctx, state.account.General.Balance, os.Stdout)
+	fmt.Printf("\nNonce: %d\n", nonce)
+	if len(state.params.Thresholds) > 0 {
+		fmt.Printf("Staking thresholds:\n")
+		for kind := api.KindEntity; kind <= api.KindMax; kind++ {
+			if q, ok := state.params.Thresholds[kind]; ok {
+				fmt.Printf("  %s: ", kind)
+				token.PrettyPrintAmount(ctx, q, os.Stdout)
+				fmt.Println()
+			}
+		}
+	}
+	fmt.Println()
+
+	kind, err := p.prompt(fmt.Sprintf("Transaction kind (%s)", strings.Join(wizardKinds, ", ")), wizardKindTransfer, func(value string) error {
+		for _, k := range wizardKinds {
+			if value == k {
+				return nil
+			}
+		}
+		return fmt.Errorf("unsupported transaction kind: %s", value)
+	})
+	if err != nil {
+		logger.Error("failed to read transaction kind",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	tx, amount, err := p.buildTx(ctx, kind, state, client)
+	if err != nil {
+		logger.Error("failed to build transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	tx.Nonce = nonce
+
+	// Estimate gas and suggest a fee.
+	gas, err := consensusClient.EstimateGas(ctx, &consensus.EstimateGasRequest{
+		Signer:      signerPk,
+		Transaction: tx,
+	})
+	if err != nil {
+		logger.Error("failed to estimate gas",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	gasStr, err := p.prompt("Gas limit", strconv.FormatUint(uint64(gas), 10), func(value string) error {
+		g, perr := strconv.ParseUint(value, 10, 64)
+		if perr != nil {
+			return perr
+		}
+		if g < uint64(gas) {
+			return fmt.Errorf("gas limit must be at least the estimated %d", gas)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed to read gas limit",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	gasLimit, _ := strconv.ParseUint(gasStr, 10, 64)
+
+	// The remaining balance must cover the fee.
+	maxFee := state.account.General.Balance.Clone()
+	if err = maxFee.Sub(amount); err != nil {
+		logger.Error("insufficient balance",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	gasPrice := getRecentGasPrice(ctx, consensusClient)
+	suggestedFee := gasPrice.Clone()
+	if err = suggestedFee.Mul(quantity.NewFromUint64(gasLimit)); err != nil {
+		logger.Error("failed to compute suggested fee",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Printf("Suggested gas price (median of recent blocks): %s\n", gasPrice)
+	fee, err := p.promptQuantity("Fee (in base units)", suggestedFee.String(), nil, maxFee)
+	if err != nil {
+		logger.Error("failed to read fee",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	tx.Fee = &transaction.Fee{
+		Amount: *fee,
+		Gas:    transaction.Gas(gasLimit),
+	}
+
+	cmdConsensus.SignAndSaveTx(ctx, tx)
+}
+
+func init() {
+	accountWizardFlags.AddFlagSet(cmdGrpc.ClientFlags)
+	accountWizardFlags.AddFlagSet(cmdConsensus.TxFileFlags)
+	accountWizardFlags.AddFlagSet(cmdFlags.DebugTestEntityFlags)
+	accountWizardFlags.AddFlagSet(cmdSigner.Flags)
+	accountWizardFlags.AddFlagSet(cmdSigner.CLIFlags)
+	accountWizardFlags.AddFlagSet(cmdFlags.GenesisFileFlags)
+	accountWizardFlags.AddFlagSet(cmdFlags.AssumeYesFlag)
+}
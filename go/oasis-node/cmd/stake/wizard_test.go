@@ -0,0 +1,91 @@
+package stake
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func newTestPrompter(input string) (*wizardPrompter, *bytes.Buffer) {
+	var out bytes.Buffer
+	return &wizardPrompter{
+		r: bufio.NewReader(strings.NewReader(input)),
+		w: &out,
+	}, &out
+}
+
+func TestWizardPrompt(t *testing.T) {
+	require := require.New(t)
+
+	addr := api.NewAddress(signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+
+	// Invalid values should be rejected until a valid one is entered.
+	p, out := newTestPrompter("invalid\n" + addr.String() + "\n")
+	parsed, err := p.promptAddress("Address")
+	require.NoError(err, "promptAddress")
+	require.Equal(addr, parsed, "promptAddress should return the valid address")
+	require.Contains(out.String(), "Invalid value", "invalid values should be reported")
+
+	// Empty input should use the default value.
+	p, _ = newTestPrompter("\n")
+	q, err := p.promptQuantity("Amount", "42", nil, nil)
+	require.NoError(err, "promptQuantity")
+	require.EqualValues(0, q.Cmp(quantity.NewFromUint64(42)), "promptQuantity should use the default value")
+
+	// Values out of bounds should be rejected.
+	p, out = newTestPrompter("5\n200\n100\n")
+	q, err = p.promptQuantity("Amount", "", quantity.NewFromUint64(10), quantity.NewFromUint64(100))
+	require.NoError(err, "promptQuantity")
+	require.EqualValues(0, q.Cmp(quantity.NewFromUint64(100)), "promptQuantity should return the valid amount")
+	require.Contains(out.String(), "amount must be at least 10")
+	require.Contains(out.String(), "amount must be at most 100")
+
+	// A final line without a trailing newline should be accepted.
+	p, _ = newTestPrompter("7")
+	q, err = p.promptQuantity("Amount", "", nil, nil)
+	require.NoError(err, "promptQuantity")
+	require.EqualValues(0, q.Cmp(quantity.NewFromUint64(7)), "promptQuantity should accept input without newline")
+
+	// Running out of input should fail.
+	p, _ = newTestPrompter("invalid\n")
+	_, err = p.promptAddress("Address")
+	require.Equal(io.EOF, err, "promptAddress should fail on EOF")
+}
+
+func TestSuggestGasPrice(t *testing.T) {
+	require := require.New(t)
+
+	rawTx := func(amount, gas uint64) []byte {
+		tx := transaction.Transaction{
+			Fee: &transaction.Fee{
+				Amount: *quantity.NewFromUint64(amount),
+				Gas:    transaction.Gas(gas),
+			},
+			Method: api.MethodTransfer,
+		}
+		sigTx := transaction.SignedTransaction{
+			Signed: signature.Signed{Blob: cbor.Marshal(tx)},
+		}
+		return cbor.Marshal(sigTx)
+	}
+
+	require.True(suggestGasPrice(nil).IsZero(), "suggested gas price without transactions should be zero")
+
+	price := suggestGasPrice([][]byte{
+		rawTx(1000, 1000), // Price 1.
+		[]byte("malformed"),
+		rawTx(5000, 1000), // Price 5.
+		rawTx(3000, 1000), // Price 3.
+	})
+	require.EqualValues(0, price.Cmp(quantity.NewFromUint64(3)), "suggested gas price should be the median")
+}
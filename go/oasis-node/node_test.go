@@ -31,6 +31,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/node"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	registryTests "github.com/oasisprotocol/oasis-core/go/registry/tests"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	roothashTests "github.com/oasisprotocol/oasis-core/go/roothash/tests"
 	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
 	clientTests "github.com/oasisprotocol/oasis-core/go/runtime/client/tests"
@@ -251,6 +252,7 @@ func TestNode(t *testing.T) {
 		{"Scheduler", testScheduler},
 		{"SchedulerClient", testSchedulerClient},
 		{"RootHash", testRootHash},
+		{"RootHashClient", testRootHashClient},
 
 		// TestStorageClientWithoutNode runs client tests that use a mock storage
 		// node and mock committees.
@@ -426,6 +428,16 @@ func testRootHash(t *testing.T, node *testNode) {
 	roothashTests.RootHashImplementationTests(t, node.Consensus.RootHash(), node.Consensus, node.Identity)
 }
 
+func testRootHashClient(t *testing.T, node *testNode) {
+	// Create a client backend connected to the local node's internal socket.
+	conn, err := cmnGrpc.Dial("unix:"+filepath.Join(node.dataDir, "internal.sock"), grpc.WithInsecure())
+	require.NoError(t, err, "Dial")
+	defer conn.Close()
+
+	client := roothash.NewRootHashClient(conn)
+	roothashTests.RootHashClientImplementationTests(t, client, node.Consensus.RootHash(), node.Consensus, node.runtimeID)
+}
+
 func testExecutorWorker(t *testing.T, node *testNode) {
 	timeSource := (node.Consensus.EpochTime()).(epochtime.SetableBackend)
 
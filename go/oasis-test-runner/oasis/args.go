@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -59,6 +60,22 @@ func (args *argBuilder) debugDontBlameOasis() *argBuilder {
 	return args
 }
 
+func (args *argBuilder) debugCrashPoints(crashPoints map[string]float64) *argBuilder {
+	if len(crashPoints) == 0 {
+		return args
+	}
+
+	ids := make([]string, 0, len(crashPoints))
+	for id := range crashPoints {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		args.vec = append(args.vec, "--debug.crash."+id, strconv.FormatFloat(crashPoints[id], 'f', -1, 64))
+	}
+	return args
+}
+
 func (args *argBuilder) debugAllowTestKeys() *argBuilder {
 	args.vec = append(args.vec, "--"+cmdCommon.CfgDebugAllowTestKeys)
 	return args
@@ -137,6 +154,13 @@ func (args *argBuilder) tendermintRecoverCorruptedWAL(enable bool) *argBuilder {
 	return args
 }
 
+func (args *argBuilder) tendermintDebugVerifyStateOnStartup(enable bool) *argBuilder {
+	if enable {
+		args.vec = append(args.vec, "--"+tendermintFull.CfgDebugVerifyStateOnStartup)
+	}
+	return args
+}
+
 func (args *argBuilder) tendermintCoreAddress(port uint16) *argBuilder {
 	args.vec = append(args.vec, []string{
 		"--" + tendermintCommon.CfgCoreListenAddress, "tcp://0.0.0.0:" + strconv.Itoa(int(port)),
@@ -396,6 +420,13 @@ func (args *argBuilder) workerStorageCheckpointCheckInterval(interval time.Durat
 	return args
 }
 
+func (args *argBuilder) workerStorageDebugVerifyOnStartup(enable bool) *argBuilder {
+	if enable {
+		args.vec = append(args.vec, "--"+workerStorage.CfgWorkerDebugVerifyOnStartup)
+	}
+	return args
+}
+
 func (args *argBuilder) workerCertificateRotation(enabled bool) *argBuilder {
 	switch enabled {
 	case false:
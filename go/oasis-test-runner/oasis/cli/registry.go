@@ -70,6 +70,7 @@ func (r *RegistryHelpers) runRegistryRuntimeSubcommand(
 			"--"+cmdRegRt.CfgTxnSchedulerMaxBatchSize, strconv.FormatUint(runtime.TxnScheduler.MaxBatchSize, 10),
 			"--"+cmdRegRt.CfgTxnSchedulerMaxBatchSizeBytes, strconv.FormatUint(runtime.TxnScheduler.MaxBatchSizeBytes, 10),
 			"--"+cmdRegRt.CfgTxnSchedulerProposerTimeout, strconv.FormatInt(runtime.TxnScheduler.ProposerTimeout, 10),
+			"--"+cmdRegRt.CfgTxnSchedulerProposerTimeoutPolicy, runtime.TxnScheduler.ProposerTimeoutPolicy,
 		)
 	}
 	if runtime.KeyManager != nil {
@@ -0,0 +1,298 @@
+package oasis
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	epochtime "github.com/oasisprotocol/oasis-core/go/epochtime/api"
+)
+
+// clockPollInterval is the interval at which nodes are polled when waiting for them to observe
+// an epoch transition.
+const clockPollInterval = 100 * time.Millisecond
+
+// ClockCondition is a condition checked by the network clock after each epoch transition.
+//
+// It should return true once the expected state has been reached.
+type ClockCondition func(ctx context.Context, epoch epochtime.EpochTime) (bool, error)
+
+// Clock is the network clock that deterministically drives the mock epochtime of all the
+// network's nodes.
+//
+// The clock requires the network to be configured with EpochtimeMock. All epoch transitions are
+// made through the network controller, and each transition only completes once every running
+// node has observed the new epoch.
+type Clock struct {
+	sync.Mutex
+
+	net    *Network
+	logger *logging.Logger
+
+	epoch       epochtime.EpochTime
+	controllers map[string]*Controller
+
+	autoCancel context.CancelFunc
+	autoDoneCh chan struct{}
+}
+
+// Epoch returns the last epoch set by the clock.
+func (c *Clock) Epoch() epochtime.EpochTime {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.epoch
+}
+
+// SetEpoch transitions the network to the given epoch and waits for all running nodes to observe
+// the transition.
+func (c *Clock) SetEpoch(ctx context.Context, epoch epochtime.EpochTime) error {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.setEpochLocked(ctx, epoch)
+}
+
+// Advance advances the network by the given number of epochs, one epoch at a time.
+func (c *Clock) Advance(ctx context.Context, epochs uint64) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if err := c.syncEpochLocked(ctx); err != nil {
+		return err
+	}
+	for i := uint64(0); i < epochs; i++ {
+		if err := c.setEpochLocked(ctx, c.epoch+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AdvanceUntil advances the network one epoch at a time until the given condition holds, and
+// returns the epoch at which it did. The condition is first checked at the current epoch.
+//
+// An error is returned if the condition does not hold after advancing by maxEpochs epochs. The
+// condition must not use the clock.
+func (c *Clock) AdvanceUntil(ctx context.Context, maxEpochs uint64, cond ClockCondition) (epochtime.EpochTime, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	if err := c.syncEpochLocked(ctx); err != nil {
+		return c.epoch, err
+	}
+
+	for i := uint64(0); ; i++ {
+		ok, err := cond(ctx, c.epoch)
+		if err != nil {
+			return c.epoch, fmt.Errorf("oasis/clock: condition failed at epoch %d: %w", c.epoch, err)
+		}
+		if ok {
+			return c.epoch, nil
+		}
+		if i == maxEpochs {
+			return c.epoch, fmt.Errorf("oasis/clock: condition not met after advancing %d epochs", maxEpochs)
+		}
+		if err = c.setEpochLocked(ctx, c.epoch+1); err != nil {
+			return c.epoch, err
+		}
+	}
+}
+
+// AssertAt transitions the network to the given epoch and checks that the given condition holds.
+// The condition must not use the clock.
+func (c *Clock) AssertAt(ctx context.Context, epoch epochtime.EpochTime, cond ClockCondition) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if err := c.setEpochLocked(ctx, epoch); err != nil {
+		return err
+	}
+	ok, err := cond(ctx, epoch)
+	switch {
+	case err != nil:
+		return fmt.Errorf("oasis/clock: condition failed at epoch %d: %w", epoch, err)
+	case !ok:
+		return fmt.Errorf("oasis/clock: condition does not hold at epoch %d", epoch)
+	default:
+		return nil
+	}
+}
+
+// StartAuto starts advancing the network by one epoch each interval, accelerating time for all
+// nodes. Explicit epoch transitions remain possible while the clock is advancing automatically.
+//
+// Failures to advance the epoch are reported via the network's error channel.
+func (c *Clock) StartAuto(interval time.Duration) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.autoCancel != nil {
+		return fmt.Errorf("oasis/clock: already advancing automatically")
+	}
+	if interval <= 0 {
+		return fmt.Errorf("oasis/clock: invalid interval: %s", interval)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.autoCancel = cancel
+	c.autoDoneCh = make(chan struct{})
+
+	go c.autoWorker(ctx, interval, c.autoDoneCh)
+
+	c.logger.Info("started advancing epochs automatically",
+		"interval", interval,
+	)
+	return nil
+}
+
+// StopAuto stops automatically advancing the network and waits for any in-progress epoch
+// transition to complete.
+func (c *Clock) StopAuto() {
+	c.Lock()
+	cancel, doneCh := c.autoCancel, c.autoDoneCh
+	c.autoCancel, c.autoDoneCh = nil, nil
+	c.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-doneCh
+
+	c.logger.Info("stopped advancing epochs automatically")
+}
+
+func (c *Clock) autoWorker(ctx context.Context, interval time.Duration, doneCh chan struct{}) {
+	defer close(doneCh)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := c.Advance(ctx, 1); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			c.logger.Error("failed to advance epoch",
+				"err", err,
+			)
+			select {
+			case c.net.errCh <- fmt.Errorf("oasis/clock: failed to advance epoch: %w", err):
+			default:
+			}
+			return
+		}
+	}
+}
+
+// syncEpochLocked updates the clock's epoch from the network, in case the epoch has been set
+// without using the clock.
+func (c *Clock) syncEpochLocked(ctx context.Context) error {
+	ctrl := c.net.Controller()
+	if ctrl == nil {
+		return fmt.Errorf("oasis/clock: network not started")
+	}
+	epoch, err := ctrl.Consensus.GetEpoch(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("oasis/clock: failed to query current epoch: %w", err)
+	}
+	c.epoch = epoch
+	return nil
+}
+
+func (c *Clock) setEpochLocked(ctx context.Context, epoch epochtime.EpochTime) error {
+	if !c.net.cfg.EpochtimeMock {
+		return fmt.Errorf("oasis/clock: network not configured with mock epochtime")
+	}
+	ctrl := c.net.Controller()
+	if ctrl == nil {
+		return fmt.Errorf("oasis/clock: network not started")
+	}
+
+	c.logger.Info("setting epoch",
+		"epoch", epoch,
+	)
+
+	if err := ctrl.SetEpoch(ctx, epoch); err != nil {
+		return fmt.Errorf("oasis/clock: failed to set epoch %d: %w", epoch, err)
+	}
+	if err := c.waitNodesLocked(ctx, epoch); err != nil {
+		return err
+	}
+	c.epoch = epoch
+
+	return nil
+}
+
+// waitNodesLocked waits for all running nodes to observe the given epoch.
+func (c *Clock) waitNodesLocked(ctx context.Context, epoch epochtime.EpochTime) error {
+	for _, n := range c.net.Nodes() {
+		if n.cmd == nil {
+			// Node is not running.
+			continue
+		}
+
+		ctrl, err := c.controllerLocked(n)
+		if err != nil {
+			return err
+		}
+		for {
+			if current, gerr := ctrl.Consensus.GetEpoch(ctx, consensus.HeightLatest); gerr == nil && current >= epoch {
+				break
+			}
+
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("oasis/clock: node %s did not observe epoch %d: %w", n.Name, epoch, ctx.Err())
+			case <-time.After(clockPollInterval):
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Clock) controllerLocked(n *Node) (*Controller, error) {
+	socketPath := n.SocketPath()
+	if ctrl, ok := c.controllers[socketPath]; ok {
+		return ctrl, nil
+	}
+
+	ctrl, err := NewController(socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("oasis/clock: failed to create controller for node %s: %w", n.Name, err)
+	}
+	c.controllers[socketPath] = ctrl
+	return ctrl, nil
+}
+
+// close stops the clock and releases its resources.
+func (c *Clock) close() {
+	c.StopAuto()
+
+	c.Lock()
+	defer c.Unlock()
+
+	for _, ctrl := range c.controllers {
+		ctrl.Close()
+	}
+	c.controllers = make(map[string]*Controller)
+}
+
+func newClock(net *Network) *Clock {
+	return &Clock{
+		net:         net,
+		logger:      net.logger.With("component", "clock"),
+		controllers: make(map[string]*Controller),
+	}
+}
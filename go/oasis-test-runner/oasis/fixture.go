@@ -143,6 +143,10 @@ type ConsensusFixture struct { // nolint: maligned
 
 	// EnableConsensusRPCWorker enables the public consensus RPC services worker.
 	EnableConsensusRPCWorker bool `json:"enable_consensusrpc_worker,omitempty"`
+
+	// VerifyStateOnStartup enables verification of the consistency of the consensus state on
+	// startup.
+	VerifyStateOnStartup bool `json:"verify_state_on_startup,omitempty"`
 }
 
 // TEEFixture is a TEE configuration fixture.
@@ -166,6 +170,9 @@ type ValidatorFixture struct { // nolint: maligned
 
 	// Consensus contains configuration for the consensus backend.
 	Consensus ConsensusFixture `json:"consensus"`
+
+	// CrashPoints are the crash point probabilities the node is started with.
+	CrashPoints map[string]float64 `json:"crash_points,omitempty"`
 }
 
 // Create instantiates the validator described by the fixture.
@@ -186,6 +193,7 @@ func (f *ValidatorFixture) Create(net *Network) (*Validator, error) {
 			LogWatcherHandlerFactories: f.LogWatcherHandlerFactories,
 			Consensus:                  f.Consensus,
 			NoAutoStart:                f.NoAutoStart,
+			CrashPoints:                f.CrashPoints,
 		},
 		Entity:   entity,
 		Sentries: sentries,
@@ -346,6 +354,10 @@ type StorageWorkerFixture struct { // nolint: maligned
 	CheckpointCheckInterval time.Duration `json:"checkpoint_check_interval,omitempty"`
 	IgnoreApplies           bool          `json:"ignore_applies,omitempty"`
 	CheckpointSyncEnabled   bool          `json:"checkpoint_sync_enabled,omitempty"`
+	VerifyOnStartup         bool          `json:"verify_on_startup,omitempty"`
+
+	// CrashPoints are the crash point probabilities the node is started with.
+	CrashPoints map[string]float64 `json:"crash_points,omitempty"`
 
 	// Runtimes contains the indexes of the runtimes to enable. Leave
 	// empty or nil for the default behaviour (i.e. include all runtimes).
@@ -366,6 +378,7 @@ func (f *StorageWorkerFixture) Create(net *Network) (*Storage, error) {
 			NoAutoStart:                f.NoAutoStart,
 			LogWatcherHandlerFactories: f.LogWatcherHandlerFactories,
 			Consensus:                  f.Consensus,
+			CrashPoints:                f.CrashPoints,
 		},
 		Backend:                 f.Backend,
 		Entity:                  entity,
@@ -376,6 +389,7 @@ func (f *StorageWorkerFixture) Create(net *Network) (*Storage, error) {
 		// Syncing should normally be enabled, but normally disabled in tests.
 		CheckpointSyncDisabled: !f.CheckpointSyncEnabled,
 		DisableCertRotation:    f.DisableCertRotation,
+		VerifyOnStartup:        f.VerifyOnStartup,
 		Runtimes:               f.Runtimes,
 	})
 }
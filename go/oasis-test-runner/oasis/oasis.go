@@ -91,6 +91,8 @@ type Node struct { // nolint: maligned
 	consensus            ConsensusFixture
 	consensusStateSync   *ConsensusStateSyncCfg
 	customGrpcSocketPath string
+
+	crashPoints map[string]float64
 }
 
 // Exit returns a channel that will close once the node shuts down.
@@ -229,11 +231,22 @@ func (n *Node) SetConsensusStateSync(cfg *ConsensusStateSyncCfg) {
 	n.consensusStateSync = cfg
 }
 
+// SetCrashPoints configures the crash point probabilities used the next time the node is started.
+func (n *Node) SetCrashPoints(crashPoints map[string]float64) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.crashPoints = crashPoints
+}
+
 // NodeCfg defines the common node configuration options.
 type NodeCfg struct { // nolint: maligned
 	AllowEarlyTermination bool
 	AllowErrorTermination bool
 
+	// CrashPoints are the crash point probabilities the node is started with.
+	CrashPoints map[string]float64
+
 	NoAutoStart bool
 
 	DisableDefaultLogWatcherHandlerFactories bool
@@ -273,6 +286,8 @@ type Network struct {
 	controller       *Controller
 	clientController *Controller
 
+	clock *Clock
+
 	errCh chan error
 }
 
@@ -309,6 +324,10 @@ type NetworkCfg struct { // nolint: maligned
 	// EpochtimeMock is the mock epochtime flag.
 	EpochtimeMock bool `json:"epochtime_mock"`
 
+	// EpochtimeMockInterval is the interval at which the network clock automatically advances the
+	// mock epochtime once the network is started (0 means only advance explicitly).
+	EpochtimeMockInterval time.Duration `json:"epochtime_mock_interval,omitempty"`
+
 	// EpochtimeTendermintInterval is the tendermint epochtime block interval.
 	EpochtimeTendermintInterval int64 `json:"epochtime_tendermint_interval"`
 
@@ -339,6 +358,11 @@ func (net *Network) Config() *NetworkCfg {
 	return net.cfg
 }
 
+// Clock returns the network clock which drives the mock epochtime of all nodes.
+func (net *Network) Clock() *Clock {
+	return net.clock
+}
+
 // Entities returns the entities associated with the network.
 func (net *Network) Entities() []*Entity {
 	return net.entities
@@ -705,6 +729,13 @@ func (net *Network) Start() error { // nolint: gocyclo
 		break
 	}
 
+	net.env.AddOnCleanup(net.clock.close)
+	if net.cfg.EpochtimeMock && net.cfg.EpochtimeMockInterval > 0 {
+		if err = net.clock.StartAuto(net.cfg.EpochtimeMockInterval); err != nil {
+			return fmt.Errorf("oasis: failed to start network clock: %w", err)
+		}
+	}
+
 	net.logger.Info("network started")
 
 	return nil
@@ -1052,14 +1083,17 @@ func New(env *env.Env, cfg *NetworkCfg) (*Network, error) {
 		cfgCopy.HaltEpoch = defaultHaltEpoch
 	}
 
-	return &Network{
+	net := &Network{
 		logger:       logging.GetLogger("oasis/" + env.Name()),
 		env:          env,
 		baseDir:      baseDir,
 		cfg:          &cfgCopy,
 		nextNodePort: baseNodePort,
 		errCh:        make(chan error, maxNodes),
-	}, nil
+	}
+	net.clock = newClock(net)
+
+	return net, nil
 }
 
 func nodeLogPath(dir *env.Dir) string {
@@ -27,6 +27,7 @@ type Storage struct { // nolint: maligned
 	ignoreApplies           bool
 	checkpointSyncDisabled  bool
 	checkpointCheckInterval time.Duration
+	verifyOnStartup         bool
 
 	sentryPubKey  signature.PublicKey
 	tmAddress     string
@@ -49,6 +50,7 @@ type StorageCfg struct { // nolint: maligned
 	IgnoreApplies           bool
 	CheckpointSyncDisabled  bool
 	CheckpointCheckInterval time.Duration
+	VerifyOnStartup         bool
 
 	Runtimes []int
 }
@@ -114,6 +116,8 @@ func (worker *Storage) startNode() error {
 		tendermintSubmissionGasPrice(worker.consensus.SubmissionGasPrice).
 		tendermintPrune(worker.consensus.PruneNumKept).
 		tendermintRecoverCorruptedWAL(worker.consensus.TendermintRecoverCorruptedWAL).
+		tendermintDebugVerifyStateOnStartup(worker.consensus.VerifyStateOnStartup).
+		debugCrashPoints(worker.crashPoints).
 		storageBackend(worker.backend).
 		workerClientPort(worker.clientPort).
 		workerP2pPort(worker.p2pPort).
@@ -121,6 +125,7 @@ func (worker *Storage) startNode() error {
 		workerStorageDebugIgnoreApplies(worker.ignoreApplies).
 		workerStorageDebugDisableCheckpointSync(worker.checkpointSyncDisabled).
 		workerStorageCheckpointCheckInterval(worker.checkpointCheckInterval).
+		workerStorageDebugVerifyOnStartup(worker.verifyOnStartup).
 		appendNetwork(worker.net).
 		appendEntity(worker.entity)
 
@@ -191,10 +196,13 @@ func (net *Network) NewStorage(cfg *StorageCfg) (*Storage, error) {
 			Name:                                     storageName,
 			net:                                      net,
 			dir:                                      storageDir,
+			termEarlyOk:                              cfg.AllowEarlyTermination,
+			termErrorOk:                              cfg.AllowErrorTermination,
 			noAutoStart:                              cfg.NoAutoStart,
 			disableDefaultLogWatcherHandlerFactories: cfg.DisableDefaultLogWatcherHandlerFactories,
 			logWatcherHandlerFactories:               cfg.LogWatcherHandlerFactories,
 			consensus:                                cfg.Consensus,
+			crashPoints:                              cfg.CrashPoints,
 		},
 		backend:                 cfg.Backend,
 		entity:                  cfg.Entity,
@@ -203,6 +211,7 @@ func (net *Network) NewStorage(cfg *StorageCfg) (*Storage, error) {
 		ignoreApplies:           cfg.IgnoreApplies,
 		checkpointSyncDisabled:  cfg.CheckpointSyncDisabled,
 		checkpointCheckInterval: cfg.CheckpointCheckInterval,
+		verifyOnStartup:         cfg.VerifyOnStartup,
 		sentryPubKey:            sentryPubKey,
 		tmAddress:               crypto.PublicKeyToTendermint(&p2pKey).Address().String(),
 		consensusPort:           net.nextNodePort,
@@ -89,6 +89,8 @@ func (val *Validator) startNode() error {
 		tendermintSubmissionGasPrice(val.consensus.SubmissionGasPrice).
 		tendermintPrune(val.consensus.PruneNumKept).
 		tendermintRecoverCorruptedWAL(val.consensus.TendermintRecoverCorruptedWAL).
+		tendermintDebugVerifyStateOnStartup(val.consensus.VerifyStateOnStartup).
+		debugCrashPoints(val.crashPoints).
 		appendNetwork(val.net).
 		appendEntity(val.entity)
 
@@ -138,6 +140,7 @@ func (net *Network) NewValidator(cfg *ValidatorCfg) (*Validator, error) {
 			logWatcherHandlerFactories:               cfg.LogWatcherHandlerFactories,
 			consensus:                                cfg.Consensus,
 			noAutoStart:                              cfg.NoAutoStart,
+			crashPoints:                              cfg.CrashPoints,
 		},
 		entity:        cfg.Entity,
 		sentries:      cfg.Sentries,
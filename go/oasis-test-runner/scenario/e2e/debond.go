@@ -6,6 +6,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	epochtime "github.com/oasisprotocol/oasis-core/go/epochtime/api"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
@@ -103,42 +104,39 @@ func (s *debondImpl) Run(*env.Env) error {
 	}
 	s.Logger.Info("balance ok")
 
+	// Check the lockup account balance after each debonding period ends.
+	checkBalance := func(expected int64) oasis.ClockCondition {
+		return func(ctx context.Context, epoch epochtime.EpochTime) (bool, error) {
+			s.Logger.Info("checking balance",
+				"epoch", epoch,
+			)
+			var q quantity.Quantity
+			if qerr := q.FromInt64(expected); qerr != nil {
+				return false, fmt.Errorf("import expected balance: %w", qerr)
+			}
+			a, aerr := s.Net.Controller().Staking.Account(ctx, &lockupQuery)
+			if aerr != nil {
+				return false, fmt.Errorf("Account: %w", aerr)
+			}
+			if a.General.Balance.Cmp(&q) != 0 {
+				return false, fmt.Errorf("balance %v should be %v", a.General.Balance, q)
+			}
+			s.Logger.Info("balance ok")
+			return true, nil
+		}
+	}
+
 	// First debonding: 500 base units at epoch 1.
 	s.Logger.Info("advancing to first debonding")
-	if err = s.Net.Controller().SetEpoch(ctx, 1); err != nil {
-		return fmt.Errorf("first SetEpoch: %w", err)
-	}
-	var expected quantity.Quantity
-	if err = expected.FromInt64(500); err != nil {
-		return fmt.Errorf("import first debonding expected balance: %w", err)
-	}
-	s.Logger.Info("checking balance at first debonding")
-	acct, err = s.Net.Controller().Staking.Account(ctx, &lockupQuery)
-	if err != nil {
-		return fmt.Errorf("first debonding Account: %w", err)
-	}
-	if acct.General.Balance.Cmp(&expected) != 0 {
-		return fmt.Errorf("first debonding balance %v should be %v", acct.General.Balance, expected)
+	if err = s.Net.Clock().AssertAt(ctx, 1, checkBalance(500)); err != nil {
+		return fmt.Errorf("first debonding: %w", err)
 	}
-	s.Logger.Info("balance ok")
 
 	// Second debonding: 500 more base units at epoch 2.
 	s.Logger.Info("advancing to second debonding")
-	if err = s.Net.Controller().SetEpoch(ctx, 2); err != nil {
-		return fmt.Errorf("second SetEpoch: %w", err)
-	}
-	if err = expected.FromInt64(1000); err != nil {
-		return fmt.Errorf("import second debonding expected balance: %w", err)
-	}
-	s.Logger.Info("checking balance at second debonding")
-	acct, err = s.Net.Controller().Staking.Account(ctx, &lockupQuery)
-	if err != nil {
-		return fmt.Errorf("second debonding Account: %w", err)
-	}
-	if acct.General.Balance.Cmp(&expected) != 0 {
-		return fmt.Errorf("second debonding balance %v should be %v", acct.General.Balance, expected)
+	if err = s.Net.Clock().AssertAt(ctx, 2, checkBalance(1000)); err != nil {
+		return fmt.Errorf("second debonding: %w", err)
 	}
-	s.Logger.Info("balance ok")
 
 	return nil
 }
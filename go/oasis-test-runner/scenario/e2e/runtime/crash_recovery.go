@@ -0,0 +1,122 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis/cli"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+)
+
+const (
+	crashPointStorageCommitAfter   = "storage.apply.commit.after"
+	crashPointConsensusCommitAfter = "abci.state.commit.after"
+)
+
+// CrashRecovery is the crash recovery scenario.
+//
+// It crashes a storage node and a validator immediately after they commit state and makes sure
+// that the persisted state is consistent when the nodes are restarted.
+var CrashRecovery scenario.Scenario = newCrashRecoveryImpl()
+
+type crashRecoveryImpl struct {
+	runtimeImpl
+}
+
+func newCrashRecoveryImpl() scenario.Scenario {
+	return &crashRecoveryImpl{
+		runtimeImpl: *newRuntimeImpl("crash-recovery", "simple-keyvalue-client", nil),
+	}
+}
+
+func (sc *crashRecoveryImpl) Clone() scenario.Scenario {
+	return &crashRecoveryImpl{
+		runtimeImpl: *sc.runtimeImpl.Clone().(*runtimeImpl),
+	}
+}
+
+func (sc *crashRecoveryImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.runtimeImpl.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	// Configure runtime to allow a smaller replication factor as otherwise execution will fail
+	// while the crashed storage node is down.
+	f.Runtimes[1].Storage.MinWriteReplication = 1
+	// Make the first storage node crash after the first committed apply and verify its state
+	// on every startup.
+	f.StorageWorkers[0].AllowErrorTermination = true
+	f.StorageWorkers[0].VerifyOnStartup = true
+	f.StorageWorkers[0].CrashPoints = map[string]float64{
+		crashPointStorageCommitAfter: 1.0,
+	}
+	// Make sure the first validator verifies its consensus state on every startup.
+	f.Validators[0].AllowErrorTermination = true
+	f.Validators[0].Consensus.VerifyStateOnStartup = true
+
+	return f, nil
+}
+
+func (sc *crashRecoveryImpl) Run(childEnv *env.Env) error {
+	ctx := context.Background()
+
+	clientErrCh, cmd, err := sc.runtimeImpl.start(childEnv)
+	if err != nil {
+		return err
+	}
+
+	// Wait for the storage node to crash and restart it without any crash points.
+	storageNode := sc.Net.StorageWorkers()[0]
+	sc.Logger.Info("waiting for the storage node to crash")
+	if err = <-storageNode.Exit(); err == nil {
+		return fmt.Errorf("storage node exited without an error")
+	}
+	sc.Logger.Info("restarting the storage node")
+	storageNode.SetCrashPoints(nil)
+	if err = storageNode.Restart(ctx); err != nil {
+		return fmt.Errorf("failed to restart storage node: %w", err)
+	}
+
+	// Wait for the client to exit.
+	if err = sc.waitClient(childEnv, cmd, clientErrCh); err != nil {
+		return err
+	}
+
+	// Crash the first validator after it commits consensus state and restart it.
+	validator := sc.Net.Validators()[0]
+	sc.Logger.Info("restarting the validator with a crash point")
+	validator.SetCrashPoints(map[string]float64{
+		crashPointConsensusCommitAfter: 1.0,
+	})
+	if err = validator.Restart(ctx); err != nil {
+		return fmt.Errorf("failed to restart validator: %w", err)
+	}
+	if err = <-validator.Exit(); err == nil {
+		return fmt.Errorf("validator exited without an error")
+	}
+	sc.Logger.Info("restarting the validator")
+	validator.SetCrashPoints(nil)
+	if err = validator.Restart(ctx); err != nil {
+		return fmt.Errorf("failed to restart validator: %w", err)
+	}
+	if err = validator.WaitReady(ctx); err != nil {
+		return fmt.Errorf("failed to wait for validator to become ready: %w", err)
+	}
+
+	// Make sure the storage node has all the roots.
+	sc.Logger.Info("checking storage node roots")
+	args := []string{
+		"debug", "storage", "check-roots",
+		"--log.level", "debug",
+		"--address", "unix:" + storageNode.SocketPath(),
+		sc.Net.Runtimes()[1].ID().String(),
+	}
+	if err = cli.RunSubCommand(childEnv, sc.Logger, "storage-check-roots", sc.Net.Config().NodeBinary, args); err != nil {
+		return fmt.Errorf("root check failed after crash recovery: %w", err)
+	}
+
+	return nil
+}
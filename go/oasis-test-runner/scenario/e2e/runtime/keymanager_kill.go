@@ -0,0 +1,110 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+)
+
+// KeymanagerKill is the scenario where the keymanager is killed and
+// restarted while the runtime is processing requests.
+var KeymanagerKill scenario.Scenario = newKmKillImpl()
+
+type kmKillImpl struct {
+	runtimeImpl
+}
+
+func newKmKillImpl() scenario.Scenario {
+	return &kmKillImpl{
+		runtimeImpl: *newRuntimeImpl(
+			"keymanager-kill",
+			"simple-keyvalue-enc-client",
+			[]string{
+				"--key", "key1",
+				"--seed", "first_seed",
+				"--mode", "insert",
+			},
+		),
+	}
+}
+
+func (sc *kmKillImpl) Clone() scenario.Scenario {
+	return &kmKillImpl{
+		runtimeImpl: *sc.runtimeImpl.Clone().(*runtimeImpl),
+	}
+}
+
+func (sc *kmKillImpl) Run(childEnv *env.Env) error {
+	ctx := context.Background()
+	clientErrCh, cmd, err := sc.runtimeImpl.start(childEnv)
+	if err != nil {
+		return err
+	}
+	if err = sc.waitClient(childEnv, cmd, clientErrCh); err != nil {
+		return err
+	}
+
+	// Start the second client on a different key so that it will require
+	// a trip to the keymanager while it is being killed.
+	sc.Logger.Info("starting a second client")
+	sc.runtimeImpl.clientArgs = []string{
+		"--key", "key2",
+		"--seed", "second_seed",
+		"--mode", "insert",
+	}
+	cmd, err = sc.startClient(childEnv)
+	if err != nil {
+		return err
+	}
+	client2ErrCh := make(chan error)
+	go func() {
+		client2ErrCh <- cmd.Wait()
+	}()
+
+	// Kill and restart the key manager while the client is running.
+	sc.Logger.Info("killing the key manager")
+	km := sc.Net.Keymanagers()[0]
+	if err = km.Restart(ctx); err != nil {
+		_ = cmd.Process.Kill()
+		return fmt.Errorf("failed to restart key manager: %w", err)
+	}
+
+	sc.Logger.Info("waiting for the key manager to become ready")
+	kmCtrl, err := oasis.NewController(km.SocketPath())
+	if err != nil {
+		_ = cmd.Process.Kill()
+		return err
+	}
+	if err = kmCtrl.WaitReady(ctx); err != nil {
+		_ = cmd.Process.Kill()
+		return err
+	}
+
+	sc.Logger.Info("waiting for the second client to exit")
+	if err = sc.waitClient(childEnv, cmd, client2ErrCh); err != nil {
+		return err
+	}
+
+	// Ensure that state written before and during the key manager failure
+	// can still be decrypted.
+	sc.Logger.Info("checking that previously stored keys are still accessible")
+	if err = sc.runClient(childEnv, []string{
+		"--key", "key1",
+		"--seed", "check_seed1",
+		"--mode", "get",
+	}); err != nil {
+		return fmt.Errorf("failed to read key stored before key manager restart: %w", err)
+	}
+	if err = sc.runClient(childEnv, []string{
+		"--key", "key2",
+		"--seed", "check_seed2",
+		"--mode", "get",
+	}); err != nil {
+		return fmt.Errorf("failed to read key stored during key manager restart: %w", err)
+	}
+
+	return sc.Net.CheckLogWatchers()
+}
@@ -0,0 +1,172 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	keymanager "github.com/oasisprotocol/oasis-core/go/keymanager/api"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis/cli"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+// KeymanagerPolicyRotation is the keymanager policy rotation scenario.
+var KeymanagerPolicyRotation scenario.Scenario = newKmPolicyRotationImpl()
+
+// kmRotatedPolicySerial is the serial of the rotated key manager policy. The
+// genesis policy uses serial 1.
+const kmRotatedPolicySerial = 2
+
+type kmPolicyRotationImpl struct {
+	runtimeImpl
+}
+
+func newKmPolicyRotationImpl() scenario.Scenario {
+	return &kmPolicyRotationImpl{
+		runtimeImpl: *newRuntimeImpl(
+			"keymanager-policy-rotation",
+			"simple-keyvalue-enc-client",
+			[]string{
+				"--key", "key1",
+				"--seed", "first_seed",
+				"--mode", "insert",
+			},
+		),
+	}
+}
+
+func (sc *kmPolicyRotationImpl) Clone() scenario.Scenario {
+	return &kmPolicyRotationImpl{
+		runtimeImpl: *sc.runtimeImpl.Clone().(*runtimeImpl),
+	}
+}
+
+func (sc *kmPolicyRotationImpl) rotatePolicy(childEnv *env.Env, kmRt *oasis.Runtime) error {
+	cli := cli.New(childEnv, sc.Net, sc.Logger)
+
+	kmPolicyPath := filepath.Join(childEnv.Dir(), "km_policy_rotated.cbor")
+	kmUpdateTxPath := filepath.Join(childEnv.Dir(), "km_gen_update_rotated.json")
+
+	kmEncID := kmRt.GetEnclaveIdentity()
+	enclavePolicies := map[sgx.EnclaveIdentity]*keymanager.EnclavePolicySGX{
+		*kmEncID: {
+			MayQuery: make(map[common.Namespace][]sgx.EnclaveIdentity),
+		},
+	}
+	for _, rt := range sc.Net.Runtimes() {
+		if rt.Kind() != registry.KindCompute {
+			continue
+		}
+		if eid := rt.GetEnclaveIdentity(); eid != nil {
+			enclavePolicies[*kmEncID].MayQuery[rt.ID()] = []sgx.EnclaveIdentity{*eid}
+		}
+	}
+
+	sc.Logger.Info("initing rotated KM policy")
+	if err := cli.Keymanager.InitPolicy(kmRt.ID(), kmRotatedPolicySerial, enclavePolicies, kmPolicyPath); err != nil {
+		return err
+	}
+
+	sc.Logger.Info("signing rotated KM policy")
+	var sigPaths []string
+	for _, key := range []string{"1", "2", "3"} {
+		sigPath := filepath.Join(childEnv.Dir(), fmt.Sprintf("km_policy_rotated_sig%s.pem", key))
+		if err := cli.Keymanager.SignPolicy(key, kmPolicyPath, sigPath); err != nil {
+			return err
+		}
+		sigPaths = append(sigPaths, sigPath)
+	}
+
+	sc.Logger.Info("updating KM policy")
+	if err := cli.Keymanager.GenUpdate(0, kmPolicyPath, sigPaths, kmUpdateTxPath); err != nil {
+		return err
+	}
+	if err := cli.Consensus.SubmitTx(kmUpdateTxPath); err != nil {
+		return fmt.Errorf("failed to update KM policy: %w", err)
+	}
+
+	return nil
+}
+
+func (sc *kmPolicyRotationImpl) Run(childEnv *env.Env) error {
+	ctx := context.Background()
+	clientErrCh, cmd, err := sc.runtimeImpl.start(childEnv)
+	if err != nil {
+		return err
+	}
+	if err = sc.waitClient(childEnv, cmd, clientErrCh); err != nil {
+		return err
+	}
+
+	kmRt := sc.Net.Runtimes()[0]
+	if kmRt.Kind() != registry.KindKeyManager {
+		return fmt.Errorf("expected first runtime in network to be keymanager runtime, got: %s", kmRt.Kind())
+	}
+
+	switch kmRt.GetEnclaveIdentity() {
+	case nil:
+		sc.Logger.Info("No SGX runtimes, skipping policy rotation")
+	default:
+		if err = sc.rotatePolicy(childEnv, kmRt); err != nil {
+			return fmt.Errorf("rotating policy: %w", err)
+		}
+
+		var status *keymanager.Status
+		status, err = sc.Net.Controller().Keymanager.GetStatus(ctx, &registry.NamespaceQuery{
+			Height: consensus.HeightLatest,
+			ID:     kmRt.ID(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to query key manager status: %w", err)
+		}
+		if status.Policy == nil || status.Policy.Policy.Serial != kmRotatedPolicySerial {
+			return fmt.Errorf("key manager policy not rotated")
+		}
+	}
+
+	// Restart the key manager so that it needs to initialize under the
+	// rotated policy.
+	sc.Logger.Info("restarting the key manager")
+	km := sc.Net.Keymanagers()[0]
+	if err = km.Restart(ctx); err != nil {
+		return err
+	}
+	sc.Logger.Info("waiting for the key manager to become ready")
+	kmCtrl, err := oasis.NewController(km.SocketPath())
+	if err != nil {
+		return err
+	}
+	if err = kmCtrl.WaitReady(ctx); err != nil {
+		return err
+	}
+
+	// The master secret must not change when the policy is rotated.
+	if err = sc.ensureReplicationWorked(ctx, km, kmRt); err != nil {
+		return err
+	}
+
+	sc.Logger.Info("checking that previously stored keys are still accessible")
+	if err = sc.runClient(childEnv, []string{
+		"--key", "key1",
+		"--seed", "check_seed1",
+		"--mode", "get",
+	}); err != nil {
+		return fmt.Errorf("failed to read key stored before policy rotation: %w", err)
+	}
+
+	sc.Logger.Info("starting a second client to check if key manager works")
+	if err = sc.runClient(childEnv, []string{
+		"--key", "key2",
+		"--seed", "second_seed",
+	}); err != nil {
+		return err
+	}
+
+	return sc.Net.CheckLogWatchers()
+}
@@ -0,0 +1,104 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+)
+
+// KeymanagerReplicateLate is the scenario where a new keymanager node joins
+// the network after the runtime has already stored encrypted state and takes
+// over from the original keymanager node.
+var KeymanagerReplicateLate scenario.Scenario = newKmReplicateLateImpl()
+
+type kmReplicateLateImpl struct {
+	runtimeImpl
+}
+
+func newKmReplicateLateImpl() scenario.Scenario {
+	return &kmReplicateLateImpl{
+		runtimeImpl: *newRuntimeImpl(
+			"keymanager-replication-late",
+			"simple-keyvalue-enc-client",
+			[]string{
+				"--key", "key1",
+				"--seed", "first_seed",
+				"--mode", "insert",
+			},
+		),
+	}
+}
+
+func (sc *kmReplicateLateImpl) Clone() scenario.Scenario {
+	return &kmReplicateLateImpl{
+		runtimeImpl: *sc.runtimeImpl.Clone().(*runtimeImpl),
+	}
+}
+
+func (sc *kmReplicateLateImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.runtimeImpl.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	// Add the new keymanager, will be started later.
+	f.Keymanagers = append(f.Keymanagers, oasis.KeymanagerFixture{Runtime: 0, Entity: 1, NoAutoStart: true})
+
+	return f, nil
+}
+
+func (sc *kmReplicateLateImpl) Run(childEnv *env.Env) error {
+	ctx := context.Background()
+	clientErrCh, cmd, err := sc.runtimeImpl.start(childEnv)
+	if err != nil {
+		return err
+	}
+	if err = sc.waitClient(childEnv, cmd, clientErrCh); err != nil {
+		return err
+	}
+
+	// Start the new keymanager.
+	sc.Logger.Info("starting new keymanager")
+	oldKm, newKm := sc.Net.Keymanagers()[0], sc.Net.Keymanagers()[1]
+	if err = newKm.Start(); err != nil {
+		return fmt.Errorf("starting new key-manager: %w", err)
+	}
+
+	sc.Logger.Info("waiting for new keymanager node to become ready")
+	if err = newKm.WaitReady(ctx); err != nil {
+		return fmt.Errorf("error waiting for new keymanager to be ready: %w", err)
+	}
+
+	// Ensure replication succeeded.
+	if err = sc.ensureReplicationWorked(ctx, newKm, sc.Net.Runtimes()[0]); err != nil {
+		return err
+	}
+
+	// Shutdown old km so that all requests need to go to the replica.
+	sc.Logger.Info("shutting down old keymanager")
+	if err = oldKm.Stop(); err != nil {
+		return fmt.Errorf("old keymanager node shutdown: %w", err)
+	}
+
+	sc.Logger.Info("checking that previously stored keys are still accessible")
+	if err = sc.runClient(childEnv, []string{
+		"--key", "key1",
+		"--seed", "check_seed1",
+		"--mode", "get",
+	}); err != nil {
+		return fmt.Errorf("failed to read key stored before replication: %w", err)
+	}
+
+	sc.Logger.Info("starting a second client to check if key manager works")
+	if err = sc.runClient(childEnv, []string{
+		"--key", "key2",
+		"--seed", "second_seed",
+	}); err != nil {
+		return err
+	}
+
+	return sc.Net.CheckLogWatchers()
+}
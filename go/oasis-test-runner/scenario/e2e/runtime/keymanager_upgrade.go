@@ -165,7 +165,9 @@ func (sc *kmUpgradeImpl) applyUpgradePolicy(childEnv *env.Env) error {
 	return nil
 }
 
-func (sc *kmUpgradeImpl) ensureReplicationWorked(ctx context.Context, km *oasis.Keymanager, rt *oasis.Runtime) error {
+// ensureReplicationWorked checks that the given key manager node has been
+// initialized with the same master secret checksum as recorded in consensus.
+func (sc *runtimeImpl) ensureReplicationWorked(ctx context.Context, km *oasis.Keymanager, rt *oasis.Runtime) error {
 	ctrl, err := oasis.NewController(km.SocketPath())
 	if err != nil {
 		return err
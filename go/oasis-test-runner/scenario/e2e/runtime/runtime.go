@@ -328,6 +328,21 @@ func (sc *runtimeImpl) wait(childEnv *env.Env, cmd *exec.Cmd, clientErrCh <-chan
 	return sc.Net.CheckLogWatchers()
 }
 
+// runClient runs the client with the given arguments and waits for it to exit.
+func (sc *runtimeImpl) runClient(childEnv *env.Env, clientArgs []string) error {
+	sc.clientArgs = clientArgs
+	cmd, err := sc.startClient(childEnv)
+	if err != nil {
+		return err
+	}
+
+	clientErrCh := make(chan error)
+	go func() {
+		clientErrCh <- cmd.Wait()
+	}()
+	return sc.waitClient(childEnv, cmd, clientErrCh)
+}
+
 func (sc *runtimeImpl) Run(childEnv *env.Env) error {
 	clientErrCh, cmd, err := sc.start(childEnv)
 	if err != nil {
@@ -525,13 +540,20 @@ func RegisterScenarios() error {
 		ByzantineStorageFailRead,
 		// Storage sync test.
 		StorageSync,
+		// Storage churn test.
+		StorageChurn,
 		// Sentry test.
 		Sentry,
 		SentryEncryption,
+		SentryChurn,
 		// Keymanager restart test.
 		KeymanagerRestart,
 		// Keymanager replicate test.
 		KeymanagerReplicate,
+		// Keymanager failure and rotation tests.
+		KeymanagerKill,
+		KeymanagerPolicyRotation,
+		KeymanagerReplicateLate,
 		// Dump/restore test.
 		DumpRestore,
 		// Halt test.
@@ -540,6 +562,8 @@ func RegisterScenarios() error {
 		MultipleRuntimes,
 		// Node shutdown test.
 		NodeShutdown,
+		// Crash recovery test.
+		CrashRecovery,
 		// Gas fees tests.
 		GasFeesRuntimes,
 		// Runtime prune test.
@@ -171,12 +171,18 @@ func (s *sentryImpl) dial(address string, clientOpts *cmnGrpc.ClientOptions) (*g
 	return conn, nil
 }
 
-func (s *sentryImpl) Run(childEnv *env.Env) error { // nolint: gocyclo
+func (s *sentryImpl) Run(childEnv *env.Env) error {
 	// Run the basic runtime test.
 	if err := s.runtimeImpl.Run(childEnv); err != nil {
 		return err
 	}
 
+	return s.checkAccessControl()
+}
+
+// checkAccessControl sanity checks that sentry and upstream node endpoints are only reachable
+// as permitted by the access policies, and that validators are only peered with their sentries.
+func (s *sentryImpl) checkAccessControl() error { // nolint: gocyclo
 	ctx, cancel := context.WithTimeout(context.Background(), sentryChecksContextTimeout)
 	defer cancel()
 
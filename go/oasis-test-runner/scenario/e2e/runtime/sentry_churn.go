@@ -0,0 +1,151 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+)
+
+// SentryChurn is the Sentry node churn scenario.
+//
+// Validators, storage nodes and key managers are only reachable through
+// sentry nodes. Upstream nodes and sentry nodes are restarted mid-run and
+// policies are updated via epoch transitions, after which the network must
+// remain live and the access policies must still be enforced.
+var SentryChurn scenario.Scenario = newSentryChurnImpl()
+
+type sentryChurnImpl struct {
+	sentryImpl
+}
+
+func newSentryChurnImpl() scenario.Scenario {
+	return &sentryChurnImpl{
+		sentryImpl: sentryImpl{
+			runtimeImpl: *newRuntimeImpl(
+				"sentry-churn",
+				"simple-keyvalue-enc-client",
+				[]string{
+					"--key", "key1",
+					"--seed", "first_seed",
+				},
+			),
+		},
+	}
+}
+
+func (sc *sentryChurnImpl) Clone() scenario.Scenario {
+	return &sentryChurnImpl{
+		sentryImpl: *sc.sentryImpl.Clone().(*sentryImpl),
+	}
+}
+
+func (sc *sentryChurnImpl) epochTransition(ctx context.Context) error {
+	epoch, err := sc.Net.Controller().Consensus.GetEpoch(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("failed to get current epoch: %w", err)
+	}
+
+	sc.Logger.Info("triggering epoch transition",
+		"epoch", epoch+1,
+	)
+	if err = sc.Net.Controller().SetEpoch(ctx, epoch+1); err != nil {
+		return fmt.Errorf("failed to set epoch: %w", err)
+	}
+	return nil
+}
+
+func (sc *sentryChurnImpl) restartAndWaitReady(ctx context.Context, name string, n *oasis.Node) error {
+	sc.Logger.Info("restarting upstream node",
+		"node", name,
+	)
+	if err := n.Restart(ctx); err != nil {
+		return fmt.Errorf("failed to restart %s: %w", name, err)
+	}
+	if err := n.WaitReady(ctx); err != nil {
+		return fmt.Errorf("failed to wait for %s to become ready: %w", name, err)
+	}
+	return nil
+}
+
+func (sc *sentryChurnImpl) restartAndWaitSynced(ctx context.Context, name string, n *oasis.Node) error {
+	sc.Logger.Info("restarting sentry node",
+		"node", name,
+	)
+	if err := n.Restart(ctx); err != nil {
+		return fmt.Errorf("failed to restart %s: %w", name, err)
+	}
+
+	ctrl, err := oasis.NewController(n.SocketPath())
+	if err != nil {
+		return fmt.Errorf("failed to create controller for %s: %w", name, err)
+	}
+	defer ctrl.Close()
+	if err = ctrl.WaitSync(ctx); err != nil {
+		return fmt.Errorf("failed to wait for %s to sync: %w", name, err)
+	}
+	return nil
+}
+
+func (sc *sentryChurnImpl) Run(childEnv *env.Env) error {
+	ctx := context.Background()
+	clientErrCh, cmd, err := sc.runtimeImpl.start(childEnv)
+	if err != nil {
+		return err
+	}
+	if err = sc.waitClient(childEnv, cmd, clientErrCh); err != nil {
+		return err
+	}
+
+	// Restart upstream nodes, one of each kind. They are only reachable
+	// through their sentry nodes, so they need to re-establish connections
+	// and re-register through them.
+	if err = sc.restartAndWaitReady(ctx, "validator-2", &sc.Net.Validators()[2].Node); err != nil {
+		return err
+	}
+	if err = sc.restartAndWaitReady(ctx, "storage-1", &sc.Net.StorageWorkers()[1].Node); err != nil {
+		return err
+	}
+	if err = sc.restartAndWaitReady(ctx, "keymanager-0", &sc.Net.Keymanagers()[0].Node); err != nil {
+		return err
+	}
+
+	// Trigger an epoch transition so that the sentry access policies get
+	// updated with any changed committees.
+	if err = sc.epochTransition(ctx); err != nil {
+		return err
+	}
+
+	// Restart sentry nodes. Upstream nodes need to reconnect and push their
+	// access policies to the restarted sentry nodes.
+	for _, idx := range []int{1, 2, 4, 5} {
+		name := fmt.Sprintf("sentry-%d", idx)
+		if err = sc.restartAndWaitSynced(ctx, name, &sc.Net.Sentries()[idx].Node); err != nil {
+			return err
+		}
+	}
+
+	if err = sc.epochTransition(ctx); err != nil {
+		return err
+	}
+
+	// Make sure that the network is still live. Use a different key so that
+	// the key manager needs to be queried through its sentry node again.
+	sc.Logger.Info("starting a second client to check if the network is live")
+	if err = sc.runClient(childEnv, []string{
+		"--key", "key2",
+		"--seed", "second_seed",
+	}); err != nil {
+		return err
+	}
+
+	// Make sure that the access policies are still enforced.
+	if err = sc.checkAccessControl(); err != nil {
+		return err
+	}
+
+	return sc.Net.CheckLogWatchers()
+}
@@ -0,0 +1,217 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	epochtime "github.com/oasisprotocol/oasis-core/go/epochtime/api"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/log"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/database"
+)
+
+// StorageChurn is the storage node churn scenario.
+//
+// It adds a new storage node and removes an existing one while the runtime is running, forcing
+// storage committee re-election and checkpoint-based catch-up on the new node, and makes sure that
+// no rounds fail and no data is lost in the process.
+var StorageChurn scenario.Scenario = newStorageChurnImpl()
+
+const storageChurnNumInserts = 15
+
+type storageChurnImpl struct {
+	runtimeImpl
+}
+
+func newStorageChurnImpl() scenario.Scenario {
+	return &storageChurnImpl{
+		runtimeImpl: *newRuntimeImpl("storage-churn", "", nil),
+	}
+}
+
+func (sc *storageChurnImpl) Clone() scenario.Scenario {
+	return &storageChurnImpl{
+		runtimeImpl: *sc.runtimeImpl.Clone().(*runtimeImpl),
+	}
+}
+
+func (sc *storageChurnImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.runtimeImpl.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	// Use mock epochtime so that committee changes happen exactly when we want them to.
+	f.Network.EpochtimeMock = true
+
+	// Configure runtime for storage checkpointing.
+	f.Runtimes[1].Storage.CheckpointInterval = 10
+	f.Runtimes[1].Storage.CheckpointNumKept = 2
+	f.Runtimes[1].Storage.CheckpointChunkSize = 1 * 1024
+	for i := range f.StorageWorkers {
+		f.StorageWorkers[i].CheckpointCheckInterval = 1 * time.Second
+	}
+	// The first storage worker will be removed during the test.
+	f.StorageWorkers[0].AllowEarlyTermination = true
+
+	// Provision another storage node which is started later and must catch up using checkpoints.
+	f.StorageWorkers = append(f.StorageWorkers, oasis.StorageWorkerFixture{
+		Backend:                    database.BackendNameBadgerDB,
+		Entity:                     1,
+		NoAutoStart:                true,
+		CheckpointSyncEnabled:      true,
+		CheckpointCheckInterval:    1 * time.Second,
+		LogWatcherHandlerFactories: []log.WatcherHandlerFactory{oasis.LogAssertCheckpointSync()},
+	})
+
+	return f, nil
+}
+
+func (sc *storageChurnImpl) insertKeys(ctx context.Context, prefix string, n int) error {
+	for i := 0; i < n; i++ {
+		sc.Logger.Info("submitting transaction to runtime",
+			"prefix", prefix,
+			"seq", i,
+		)
+		key, value := fmt.Sprintf("%s key %d", prefix, i), fmt.Sprintf("%s value %d", prefix, i)
+		if err := sc.submitKeyValueRuntimeInsertTx(ctx, runtimeID, key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sc *storageChurnImpl) checkKeys(ctx context.Context, prefix string, n int) error {
+	for i := 0; i < n; i++ {
+		key, value := fmt.Sprintf("%s key %d", prefix, i), fmt.Sprintf("%s value %d", prefix, i)
+		rsp, err := sc.submitRuntimeTx(ctx, runtimeID, "get", struct {
+			Key   string `json:"key"`
+			Nonce uint64 `json:"nonce"`
+		}{
+			Key:   key,
+			Nonce: uint64(time.Now().UnixNano()),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get key '%s': %w", key, err)
+		}
+		var got *string
+		if err = cbor.Unmarshal(rsp, &got); err != nil {
+			return fmt.Errorf("malformed get response for key '%s': %w", key, err)
+		}
+		if got == nil || *got != value {
+			return fmt.Errorf("unexpected value for key '%s' (expected: %s got: %v)", key, value, got)
+		}
+	}
+	return nil
+}
+
+func (sc *storageChurnImpl) checkNoFailedRounds(ctx context.Context) error {
+	c := sc.Net.ClientController().RuntimeClient
+
+	latestBlk, err := c.GetBlock(ctx, &runtimeClient.GetBlockRequest{
+		RuntimeID: runtimeID,
+		Round:     runtimeClient.RoundLatest,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+	for round := uint64(1); round <= latestBlk.Header.Round; round++ {
+		var blk *block.Block
+		blk, err = c.GetBlock(ctx, &runtimeClient.GetBlockRequest{
+			RuntimeID: runtimeID,
+			Round:     round,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get block %d: %w", round, err)
+		}
+		if blk.Header.HeaderType == block.RoundFailed {
+			return fmt.Errorf("round %d failed", round)
+		}
+	}
+	return nil
+}
+
+func (sc *storageChurnImpl) Run(childEnv *env.Env) error {
+	ctx := context.Background()
+
+	if err := sc.Net.Start(); err != nil {
+		return err
+	}
+
+	fixture, err := sc.Fixture()
+	if err != nil {
+		return err
+	}
+	if err = sc.initialEpochTransitions(fixture); err != nil {
+		return err
+	}
+
+	// Generate enough rounds for the existing storage nodes to create checkpoints.
+	if err = sc.insertKeys(ctx, "before", storageChurnNumInserts); err != nil {
+		return err
+	}
+
+	// Add a new storage node and trigger an epoch transition so that it becomes eligible for the
+	// storage committee.
+	newWorker := sc.Net.StorageWorkers()[2]
+	sc.Logger.Info("starting new storage node")
+	if err = newWorker.Start(); err != nil {
+		return fmt.Errorf("failed to start new storage node: %w", err)
+	}
+	if err = newWorker.WaitReady(ctx); err != nil {
+		return fmt.Errorf("failed to wait for new storage node to become ready: %w", err)
+	}
+	// Initial epoch transitions leave us at epoch 2.
+	epoch := epochtime.EpochTime(3)
+	sc.Logger.Info("triggering epoch transition after adding a storage node",
+		"epoch", epoch,
+	)
+	if err = sc.Net.Controller().SetEpoch(ctx, epoch); err != nil {
+		return fmt.Errorf("failed to set epoch: %w", err)
+	}
+
+	// Remove the first storage node, making sure it deregisters, and trigger another epoch
+	// transition so that it is removed from the storage committee.
+	oldWorker := sc.Net.StorageWorkers()[0]
+	sc.Logger.Info("removing storage node")
+	if err = oldWorker.RequestShutdown(ctx, false); err != nil {
+		return fmt.Errorf("failed to request storage node shutdown: %w", err)
+	}
+	if err = <-oldWorker.Exit(); err != env.ErrEarlyTerm {
+		return fmt.Errorf("storage node exited with error: %w", err)
+	}
+	epoch++
+	sc.Logger.Info("triggering epoch transition after removing a storage node",
+		"epoch", epoch,
+	)
+	if err = sc.Net.Controller().SetEpoch(ctx, epoch); err != nil {
+		return fmt.Errorf("failed to set epoch: %w", err)
+	}
+
+	// The storage committee now must include the new node, so any further rounds require it to
+	// have caught up.
+	if err = sc.insertKeys(ctx, "after", storageChurnNumInserts); err != nil {
+		return err
+	}
+
+	sc.Logger.Info("checking that no rounds failed")
+	if err = sc.checkNoFailedRounds(ctx); err != nil {
+		return err
+	}
+
+	sc.Logger.Info("checking that no data was lost")
+	if err = sc.checkKeys(ctx, "before", storageChurnNumInserts); err != nil {
+		return err
+	}
+	if err = sc.checkKeys(ctx, "after", storageChurnNumInserts); err != nil {
+		return err
+	}
+
+	return sc.Net.CheckLogWatchers()
+}
@@ -131,6 +131,14 @@ var (
 	// has runtimes.
 	ErrEntityHasRuntimes = errors.New(ModuleName, 19, "registry: entity still has runtimes")
 
+	// ErrRuntimeNotSuspended is the error returned when a runtime cannot be deregistered as it is
+	// not suspended.
+	ErrRuntimeNotSuspended = errors.New(ModuleName, 20, "registry: runtime not suspended")
+
+	// ErrRuntimeInUse is the error returned when a runtime cannot be deregistered as there are
+	// still nodes or other runtimes that depend on it.
+	ErrRuntimeInUse = errors.New(ModuleName, 21, "registry: runtime still in use")
+
 	// MethodRegisterEntity is the method name for entity registrations.
 	MethodRegisterEntity = transaction.NewMethodName(ModuleName, "RegisterEntity", entity.SignedEntity{})
 	// MethodDeregisterEntity is the method name for entity deregistrations.
@@ -141,6 +149,8 @@ var (
 	MethodUnfreezeNode = transaction.NewMethodName(ModuleName, "UnfreezeNode", UnfreezeNode{})
 	// MethodRegisterRuntime is the method name for registering runtimes.
 	MethodRegisterRuntime = transaction.NewMethodName(ModuleName, "RegisterRuntime", SignedRuntime{})
+	// MethodDeregisterRuntime is the method name for deregistering runtimes.
+	MethodDeregisterRuntime = transaction.NewMethodName(ModuleName, "DeregisterRuntime", DeregisterRuntime{})
 
 	// Methods is the list of all methods supported by the registry backend.
 	Methods = []transaction.MethodName{
@@ -149,6 +159,7 @@ var (
 		MethodRegisterNode,
 		MethodUnfreezeNode,
 		MethodRegisterRuntime,
+		MethodDeregisterRuntime,
 	}
 
 	// RuntimesRequiredRoles are the Node roles that require runtimes.
@@ -194,14 +205,19 @@ type Backend interface {
 	// GetNodeStatus returns a node's status.
 	GetNodeStatus(context.Context, *IDQuery) (*NodeStatus, error)
 
-	// GetNodes gets a list of all registered nodes.
-	GetNodes(context.Context, int64) ([]*node.Node, error)
+	// GetNodes gets a list of all registered nodes matching the given query.
+	GetNodes(context.Context, *GetNodesQuery) ([]*node.Node, error)
 
 	// GetNodeByConsensusAddress looks up a node by its consensus address at the
 	// specified block height. The nature and format of the consensus address depends
 	// on the specific consensus backend implementation used.
 	GetNodeByConsensusAddress(context.Context, *ConsensusAddressQuery) (*node.Node, error)
 
+	// ValidateNode runs all registry checks for a candidate node descriptor
+	// against the consensus state at the given height without registering
+	// the node, and returns the list of failed checks.
+	ValidateNode(context.Context, *ValidateNodeRequest) (*NodeValidationResult, error)
+
 	// WatchNodes returns a channel that produces a stream of
 	// NodeEvent on node registration changes.
 	WatchNodes(context.Context) (<-chan *NodeEvent, pubsub.ClosableSubscription, error)
@@ -214,6 +230,13 @@ type Backend interface {
 	// order.
 	WatchNodeList(context.Context) (<-chan *NodeList, pubsub.ClosableSubscription, error)
 
+	// WatchNodeListDiffs returns a channel that produces, at each epoch
+	// transition, the difference between the node list of the new epoch and
+	// the node list of the previous epoch. Upon subscription, the node list
+	// for the current epoch will be sent immediately with all nodes marked
+	// as added.
+	WatchNodeListDiffs(context.Context) (<-chan *NodeListDiff, pubsub.ClosableSubscription, error)
+
 	// GetRuntime gets a runtime by ID.
 	GetRuntime(context.Context, *NamespaceQuery) (*Runtime, error)
 
@@ -221,6 +244,10 @@ type Backend interface {
 	// block height.
 	GetRuntimes(context.Context, *GetRuntimesQuery) ([]*Runtime, error)
 
+	// ListRuntimesWithMetadata returns the registered Runtimes at the
+	// specified block height, filtered and sorted by runtime metadata.
+	ListRuntimesWithMetadata(context.Context, *ListRuntimesQuery) ([]*Runtime, error)
+
 	// WatchRuntimes returns a stream of Runtime.  Upon subscription,
 	// all runtimes will be sent immediately.
 	WatchRuntimes(context.Context) (<-chan *Runtime, pubsub.ClosableSubscription, error)
@@ -253,6 +280,47 @@ type GetRuntimesQuery struct {
 	IncludeSuspended bool  `json:"include_suspended"`
 }
 
+// ListRuntimesQuery is a registry list runtimes with metadata query.
+type ListRuntimesQuery struct {
+	Height           int64 `json:"height"`
+	IncludeSuspended bool  `json:"include_suspended"`
+
+	// Metadata restricts the results to runtimes whose metadata contains all
+	// of the given entries. An empty value matches any value of the key.
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// SortBy is the metadata key used to sort the results. Runtimes without
+	// the key are sorted last. If empty, results are sorted by runtime ID.
+	SortBy string `json:"sort_by,omitempty"`
+	// Descending reverses the sort order.
+	Descending bool `json:"descending,omitempty"`
+}
+
+// GetNodesQuery is a registry get nodes query.
+type GetNodesQuery struct {
+	Height int64 `json:"height"`
+
+	// Roles, if non-zero, only matches nodes that have any of the given roles.
+	Roles node.RolesMask `json:"roles,omitempty"`
+	// SoftwareVersion, if non-empty, only matches nodes reporting the given Oasis Core version.
+	SoftwareVersion string `json:"software_version,omitempty"`
+	// TEEHardware, if non-zero, only matches nodes reporting the given TEE hardware.
+	TEEHardware node.TEEHardware `json:"tee_hardware,omitempty"`
+}
+
+// Matches returns true iff the given node matches the query filters.
+func (q *GetNodesQuery) Matches(n *node.Node) bool {
+	if q.Roles != 0 && !n.HasRoles(q.Roles) {
+		return false
+	}
+	if q.SoftwareVersion != "" && (n.SoftwareVersion == nil || n.SoftwareVersion.OasisCore != q.SoftwareVersion) {
+		return false
+	}
+	if q.TEEHardware != node.TEEHardwareInvalid && (n.SoftwareVersion == nil || n.SoftwareVersion.TEEHardware != q.TEEHardware) {
+		return false
+	}
+	return true
+}
+
 // ConsensusAddressQuery is a registry query by consensus address.
 // The nature and format of the consensus address depends on the specific
 // consensus backend implementation used.
@@ -286,6 +354,11 @@ func NewRegisterRuntimeTx(nonce uint64, fee *transaction.Fee, sigRt *SignedRunti
 	return transaction.NewTransaction(nonce, fee, MethodRegisterRuntime, sigRt)
 }
 
+// NewDeregisterRuntimeTx creates a new deregister runtime transaction.
+func NewDeregisterRuntimeTx(nonce uint64, fee *transaction.Fee, dereg *DeregisterRuntime) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodDeregisterRuntime, dereg)
+}
+
 // EntityEvent is the event that is returned via WatchEntities to signify
 // entity registration changes and updates.
 type EntityEvent struct {
@@ -305,20 +378,34 @@ type RuntimeEvent struct {
 	Runtime *Runtime `json:"runtime"`
 }
 
+// RuntimeDeregisteredEvent signifies runtime deregistration.
+type RuntimeDeregisteredEvent struct {
+	Runtime *Runtime `json:"runtime"`
+}
+
 // NodeUnfrozenEvent signifies when node becomes unfrozen.
 type NodeUnfrozenEvent struct {
 	NodeID signature.PublicKey `json:"node_id"`
 }
 
+// StakeClaimReleasedEvent signifies that a stake claim held for a deregistered entity or runtime
+// has been released.
+type StakeClaimReleasedEvent struct {
+	Account staking.Address    `json:"account"`
+	Claim   staking.StakeClaim `json:"claim"`
+}
+
 // Event is a registry event returned via GetEvents.
 type Event struct {
 	Height int64     `json:"height,omitempty"`
 	TxHash hash.Hash `json:"tx_hash,omitempty"`
 
-	RuntimeEvent      *RuntimeEvent      `json:"runtime,omitempty"`
-	EntityEvent       *EntityEvent       `json:"entity,omitempty"`
-	NodeEvent         *NodeEvent         `json:"node,omitempty"`
-	NodeUnfrozenEvent *NodeUnfrozenEvent `json:"node_unfrozen,omitempty"`
+	RuntimeEvent             *RuntimeEvent             `json:"runtime,omitempty"`
+	RuntimeDeregisteredEvent *RuntimeDeregisteredEvent `json:"runtime_deregistered,omitempty"`
+	EntityEvent              *EntityEvent              `json:"entity,omitempty"`
+	NodeEvent                *NodeEvent                `json:"node,omitempty"`
+	NodeUnfrozenEvent        *NodeUnfrozenEvent        `json:"node_unfrozen,omitempty"`
+	StakeClaimReleasedEvent  *StakeClaimReleasedEvent  `json:"stake_claim_released,omitempty"`
 }
 
 // NodeList is a per-epoch immutable node list.
@@ -326,6 +413,67 @@ type NodeList struct {
 	Nodes []*node.Node `json:"nodes"`
 }
 
+// Hash returns the cryptographic hash of the node list.
+func (nl *NodeList) Hash() hash.Hash {
+	return hash.NewFrom(nl.Nodes)
+}
+
+// NodeListDiff is the difference between the node lists of two consecutive
+// epochs.
+type NodeListDiff struct {
+	// Height is the block height at which the node list was generated.
+	Height int64 `json:"height"`
+	// Epoch is the epoch of the node list.
+	Epoch epochtime.EpochTime `json:"epoch"`
+	// Hash is the hash of the full node list.
+	Hash hash.Hash `json:"hash"`
+
+	// Added are the nodes that were added since the previous node list.
+	Added []*node.Node `json:"added,omitempty"`
+	// Removed are the nodes that were removed since the previous node list.
+	Removed []*node.Node `json:"removed,omitempty"`
+	// Updated are the nodes whose descriptors changed since the previous
+	// node list.
+	Updated []*node.Node `json:"updated,omitempty"`
+}
+
+// NewNodeListDiff computes the difference between the previous and the
+// current node list. In case the previous node list is nil, all nodes are
+// considered added.
+//
+// The nodes in each of the resulting lists are sorted by node ID.
+func NewNodeListDiff(prev, current *NodeList) *NodeListDiff {
+	diff := &NodeListDiff{
+		Hash: current.Hash(),
+	}
+
+	prevNodes := make(map[signature.PublicKey]*node.Node)
+	if prev != nil {
+		for _, n := range prev.Nodes {
+			prevNodes[n.ID] = n
+		}
+	}
+	for _, n := range current.Nodes {
+		prevNode, ok := prevNodes[n.ID]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, n)
+		case !bytes.Equal(cbor.Marshal(prevNode), cbor.Marshal(n)):
+			diff.Updated = append(diff.Updated, n)
+		}
+		delete(prevNodes, n.ID)
+	}
+	for _, n := range prevNodes {
+		diff.Removed = append(diff.Removed, n)
+	}
+
+	SortNodeList(diff.Added)
+	SortNodeList(diff.Removed)
+	SortNodeList(diff.Updated)
+
+	return diff
+}
+
 // NodeLookup interface implements various ways for the verification
 // functions to look-up nodes in the registry's state.
 type NodeLookup interface {
@@ -429,6 +577,7 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 	epoch epochtime.EpochTime,
 	runtimeLookup RuntimeLookup,
 	nodeLookup NodeLookup,
+	teeCache *TEEVerificationCache,
 ) (*node.Node, []*Runtime, error) {
 	var n node.Node
 	if sigNode == nil {
@@ -537,6 +686,15 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 		return nil, nil, fmt.Errorf("%w: invalid role specified", ErrInvalidArgument)
 	}
 
+	// Make sure that the node only has roles allowed by its entity.
+	if inEntityNodeList && !entity.NodeRolesAllowed(n.ID, n.Roles) {
+		logger.Error("RegisterNode: roles not allowed by entity",
+			"node", n,
+			"entity", entity,
+		)
+		return nil, nil, fmt.Errorf("%w: roles not allowed by entity", ErrForbidden)
+	}
+
 	// TODO: Key manager nodes maybe should be restricted to only being a
 	// key manager at the expense of breaking some of our test configs.
 
@@ -573,7 +731,7 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 
 			// If the node indicates TEE support for any of it's runtimes,
 			// validate the attestation evidence.
-			if err := VerifyNodeRuntimeEnclaveIDs(logger, rt, regRt, now); err != nil {
+			if err := teeCache.VerifyNodeRuntimeEnclaveIDs(logger, rt, regRt, now); err != nil {
 				return nil, nil, err
 			}
 
@@ -740,29 +898,38 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 
 // VerifyNodeRuntimeEnclaveIDs verifies TEE-specific attributes of the node's runtime.
 func VerifyNodeRuntimeEnclaveIDs(logger *logging.Logger, rt *node.Runtime, regRt *Runtime, ts time.Time) error {
+	_, err := verifyNodeRuntimeEnclaveIDs(logger, rt, regRt, ts)
+	return err
+}
+
+// verifyNodeRuntimeEnclaveIDs verifies TEE-specific attributes of the node's runtime and returns
+// the validity of the attestation verification result (if any).
+func verifyNodeRuntimeEnclaveIDs(logger *logging.Logger, rt *node.Runtime, regRt *Runtime, ts time.Time) (*ias.Validity, error) {
 	// If no TEE available, do nothing.
 	if rt.Capabilities.TEE == nil {
-		return nil
+		return nil, nil
 	}
 
+	var validity *ias.Validity
 	switch rt.Capabilities.TEE.Hardware {
 	case node.TEEHardwareInvalid:
 	case node.TEEHardwareIntelSGX:
 		// Check MRENCLAVE/MRSIGNER.
 		var avrBundle ias.AVRBundle
 		if err := cbor.Unmarshal(rt.Capabilities.TEE.Attestation, &avrBundle); err != nil {
-			return err
+			return nil, err
 		}
 
-		avr, err := avrBundle.Open(ias.IntelTrustRoots, ts)
+		avr, avrValidity, err := avrBundle.OpenWithValidity(ias.IntelTrustRoots, ts)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		validity = avrValidity
 
 		// Extract the original ISV quote.
 		q, err := avr.Quote()
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if regRt.TEEHardware != rt.Capabilities.TEE.Hardware {
@@ -772,12 +939,12 @@ func VerifyNodeRuntimeEnclaveIDs(logger *logging.Logger, rt *node.Runtime, regRt
 				"registry_runtime", regRt,
 				"ts", ts,
 			)
-			return ErrTEEHardwareMismatch
+			return nil, ErrTEEHardwareMismatch
 		}
 
 		var vi VersionInfoIntelSGX
 		if err := cbor.Unmarshal(regRt.Version.TEE, &vi); err != nil {
-			return err
+			return nil, err
 		}
 		var eidValid bool
 		for _, eid := range vi.Enclaves {
@@ -797,10 +964,10 @@ func VerifyNodeRuntimeEnclaveIDs(logger *logging.Logger, rt *node.Runtime, regRt
 				"registry_runtime", regRt,
 				"ts", ts,
 			)
-			return ErrBadEnclaveIdentity
+			return nil, ErrBadEnclaveIdentity
 		}
 	default:
-		return ErrBadCapabilitiesTEEHardware
+		return nil, ErrBadCapabilitiesTEEHardware
 	}
 
 	if err := rt.Capabilities.TEE.Verify(ts); err != nil {
@@ -809,10 +976,10 @@ func VerifyNodeRuntimeEnclaveIDs(logger *logging.Logger, rt *node.Runtime, regRt
 			"ts", ts,
 			"err", err,
 		)
-		return err
+		return nil, err
 	}
 
-	return nil
+	return validity, nil
 }
 
 // VerifyAddress verifies a node address.
@@ -1193,6 +1360,9 @@ type Genesis struct {
 
 	// NodeStatuses is a set of node statuses.
 	NodeStatuses map[signature.PublicKey]*NodeStatus `json:"node_statuses,omitempty"`
+
+	// StakeClaimReleases is the list of pending stake claim releases.
+	StakeClaimReleases []*StakeClaimRelease `json:"stake_claim_releases,omitempty"`
 }
 
 // ConsensusParameters are the registry consensus parameters.
@@ -1227,6 +1397,11 @@ type ConsensusParameters struct {
 	// MaxNodeExpiration is the maximum number of epochs relative to the epoch
 	// at registration time that a single node registration is valid for.
 	MaxNodeExpiration uint64 `json:"max_node_expiration,omitempty"`
+
+	// StakeClaimReleaseDelay is the number of epochs after the deregistration of an entity or a
+	// runtime before the associated stake claim is released. Zero means that the stake claim is
+	// released immediately.
+	StakeClaimReleaseDelay epochtime.EpochTime `json:"stake_claim_release_delay,omitempty"`
 }
 
 const (
@@ -1240,6 +1415,8 @@ const (
 	GasOpUnfreezeNode transaction.Op = "unfreeze_node"
 	// GasOpRegisterRuntime is the gas operation identifier for runtime registration.
 	GasOpRegisterRuntime transaction.Op = "register_runtime"
+	// GasOpDeregisterRuntime is the gas operation identifier for runtime deregistration.
+	GasOpDeregisterRuntime transaction.Op = "deregister_runtime"
 	// GasOpRuntimeEpochMaintenance is the gas operation identifier for per-epoch
 	// runtime maintenance costs.
 	GasOpRuntimeEpochMaintenance transaction.Op = "runtime_epoch_maintenance"
@@ -1257,6 +1434,7 @@ var DefaultGasCosts = transaction.Costs{
 	GasOpRegisterNode:            1000,
 	GasOpUnfreezeNode:            1000,
 	GasOpRegisterRuntime:         1000,
+	GasOpDeregisterRuntime:       1000,
 	GasOpRuntimeEpochMaintenance: 1000,
 	GasOpUpdateKeyManager:        1000,
 }
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+)
+
+func TestNewNodeListDiff(t *testing.T) {
+	newNode := func(id byte, expiration uint64) *node.Node {
+		var nodeID signature.PublicKey
+		nodeID[0] = id
+		return &node.Node{
+			ID:         nodeID,
+			Expiration: expiration,
+		}
+	}
+
+	for _, tc := range []struct {
+		name    string
+		prev    []*node.Node
+		current []*node.Node
+		added   []*node.Node
+		removed []*node.Node
+		updated []*node.Node
+	}{
+		{
+			name:    "NoPrevious",
+			prev:    nil,
+			current: []*node.Node{newNode(2, 1), newNode(1, 1)},
+			added:   []*node.Node{newNode(1, 1), newNode(2, 1)},
+		},
+		{
+			name:    "Added",
+			prev:    []*node.Node{newNode(1, 1)},
+			current: []*node.Node{newNode(1, 1), newNode(3, 1), newNode(2, 1)},
+			added:   []*node.Node{newNode(2, 1), newNode(3, 1)},
+		},
+		{
+			name:    "Removed",
+			prev:    []*node.Node{newNode(1, 1), newNode(2, 1), newNode(3, 1)},
+			current: []*node.Node{newNode(2, 1)},
+			removed: []*node.Node{newNode(1, 1), newNode(3, 1)},
+		},
+		{
+			name:    "Updated",
+			prev:    []*node.Node{newNode(1, 1), newNode(2, 1)},
+			current: []*node.Node{newNode(1, 2), newNode(2, 1)},
+			updated: []*node.Node{newNode(1, 2)},
+		},
+		{
+			name:    "Unchanged",
+			prev:    []*node.Node{newNode(1, 1), newNode(2, 1)},
+			current: []*node.Node{newNode(1, 1), newNode(2, 1)},
+		},
+		{
+			name:    "Mixed",
+			prev:    []*node.Node{newNode(1, 1), newNode(2, 1), newNode(3, 1)},
+			current: []*node.Node{newNode(4, 1), newNode(3, 2), newNode(2, 1)},
+			added:   []*node.Node{newNode(4, 1)},
+			removed: []*node.Node{newNode(1, 1)},
+			updated: []*node.Node{newNode(3, 2)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+
+			var prev *NodeList
+			if tc.prev != nil {
+				prev = &NodeList{Nodes: tc.prev}
+			}
+			current := &NodeList{Nodes: tc.current}
+
+			diff := NewNodeListDiff(prev, current)
+			require.Equal(current.Hash(), diff.Hash, "diff hash should be the hash of the current node list")
+			require.EqualValues(tc.added, diff.Added, "added nodes should be correct")
+			require.EqualValues(tc.removed, diff.Removed, "removed nodes should be correct")
+			require.EqualValues(tc.updated, diff.Updated, "updated nodes should be correct")
+		})
+	}
+}
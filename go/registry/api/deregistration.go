@@ -0,0 +1,29 @@
+package api
+
+import (
+	"github.com/oasisprotocol/oasis-core/go/common"
+	epochtime "github.com/oasisprotocol/oasis-core/go/epochtime/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// DeregisterRuntime is a request to deregister a suspended runtime.
+type DeregisterRuntime struct {
+	// ID is the identifier of the runtime that should be deregistered.
+	ID common.Namespace `json:"id"`
+}
+
+// StakeClaimRelease is a pending release of a stake claim that was held for a deregistered entity
+// or runtime.
+//
+// The claim stays in effect until the release epoch so that the stake remains available in case
+// any misbehavior related to the deregistered entity or runtime is discovered in the meantime.
+type StakeClaimRelease struct {
+	// Account is the address of the account holding the claim.
+	Account staking.Address `json:"account"`
+	// Claim is the stake claim identifier.
+	Claim staking.StakeClaim `json:"claim"`
+	// Thresholds are the stake thresholds of the claim.
+	Thresholds []staking.StakeThreshold `json:"thresholds,omitempty"`
+	// Epoch is the epoch at which the claim will be released.
+	Epoch epochtime.EpochTime `json:"epoch"`
+}
@@ -26,11 +26,15 @@ var (
 	// methodGetNodeStatus is the GetNodeStatus method.
 	methodGetNodeStatus = serviceName.NewMethod("GetNodeStatus", IDQuery{})
 	// methodGetNodes is the GetNodes method.
-	methodGetNodes = serviceName.NewMethod("GetNodes", int64(0))
+	methodGetNodes = serviceName.NewMethod("GetNodes", GetNodesQuery{})
+	// methodValidateNode is the ValidateNode method.
+	methodValidateNode = serviceName.NewMethod("ValidateNode", ValidateNodeRequest{})
 	// methodGetRuntime is the GetRuntime method.
 	methodGetRuntime = serviceName.NewMethod("GetRuntime", NamespaceQuery{})
 	// methodGetRuntimes is the GetRuntimes method.
 	methodGetRuntimes = serviceName.NewMethod("GetRuntimes", int64(0))
+	// methodListRuntimesWithMetadata is the ListRuntimesWithMetadata method.
+	methodListRuntimesWithMetadata = serviceName.NewMethod("ListRuntimesWithMetadata", ListRuntimesQuery{})
 	// methodStateToGenesis is the StateToGenesis method.
 	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
 	// methodGetEvents is the GetEvents method.
@@ -42,6 +46,8 @@ var (
 	methodWatchNodes = serviceName.NewMethod("WatchNodes", nil)
 	// methodWatchNodeList is the WatchNodeList method.
 	methodWatchNodeList = serviceName.NewMethod("WatchNodeList", nil)
+	// methodWatchNodeListDiffs is the WatchNodeListDiffs method.
+	methodWatchNodeListDiffs = serviceName.NewMethod("WatchNodeListDiffs", nil)
 	// methodWatchRuntimes is the WatchRuntimes method.
 	methodWatchRuntimes = serviceName.NewMethod("WatchRuntimes", nil)
 
@@ -74,6 +80,10 @@ var (
 				MethodName: methodGetNodes.ShortName(),
 				Handler:    handlerGetNodes,
 			},
+			{
+				MethodName: methodValidateNode.ShortName(),
+				Handler:    handlerValidateNode,
+			},
 			{
 				MethodName: methodGetRuntime.ShortName(),
 				Handler:    handlerGetRuntime,
@@ -82,6 +92,10 @@ var (
 				MethodName: methodGetRuntimes.ShortName(),
 				Handler:    handlerGetRuntimes,
 			},
+			{
+				MethodName: methodListRuntimesWithMetadata.ShortName(),
+				Handler:    handlerListRuntimesWithMetadata,
+			},
 			{
 				MethodName: methodStateToGenesis.ShortName(),
 				Handler:    handlerStateToGenesis,
@@ -112,6 +126,11 @@ var (
 				Handler:       handlerWatchRuntimes,
 				ServerStreams: true,
 			},
+			{
+				StreamName:    methodWatchNodeListDiffs.ShortName(),
+				Handler:       handlerWatchNodeListDiffs,
+				ServerStreams: true,
+			},
 		},
 	}
 )
@@ -237,21 +256,44 @@ func handlerGetNodes( // nolint: golint
 	dec func(interface{}) error,
 	interceptor grpc.UnaryServerInterceptor,
 ) (interface{}, error) {
-	var height int64
-	if err := dec(&height); err != nil {
+	var query GetNodesQuery
+	if err := dec(&query); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(Backend).GetNodes(ctx, height)
+		return srv.(Backend).GetNodes(ctx, &query)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
 		FullMethod: methodGetNodes.FullName(),
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(Backend).GetNodes(ctx, req.(int64))
+		return srv.(Backend).GetNodes(ctx, req.(*GetNodesQuery))
 	}
-	return interceptor(ctx, height, info, handler)
+	return interceptor(ctx, &query, info, handler)
+}
+
+func handlerValidateNode( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req ValidateNodeRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).ValidateNode(ctx, &req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodValidateNode.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).ValidateNode(ctx, req.(*ValidateNodeRequest))
+	}
+	return interceptor(ctx, &req, info, handler)
 }
 
 func handlerGetRuntime( // nolint: golint
@@ -300,6 +342,29 @@ func handlerGetRuntimes( // nolint: golint
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerListRuntimesWithMetadata( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query ListRuntimesQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).ListRuntimesWithMetadata(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodListRuntimesWithMetadata.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).ListRuntimesWithMetadata(ctx, req.(*ListRuntimesQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerStateToGenesis( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -430,6 +495,34 @@ func handlerWatchNodeList(srv interface{}, stream grpc.ServerStream) error {
 	}
 }
 
+func handlerWatchNodeListDiffs(srv interface{}, stream grpc.ServerStream) error {
+	if err := stream.RecvMsg(nil); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(Backend).WatchNodeListDiffs(ctx)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.SendMsg(ev); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 func handlerWatchRuntimes(srv interface{}, stream grpc.ServerStream) error {
 	if err := stream.RecvMsg(nil); err != nil {
 		return err
@@ -542,9 +635,9 @@ func (c *registryClient) GetNodeStatus(ctx context.Context, query *IDQuery) (*No
 	return &rsp, nil
 }
 
-func (c *registryClient) GetNodes(ctx context.Context, height int64) ([]*node.Node, error) {
+func (c *registryClient) GetNodes(ctx context.Context, query *GetNodesQuery) ([]*node.Node, error) {
 	var rsp []*node.Node
-	if err := c.conn.Invoke(ctx, methodGetNodes.FullName(), height, &rsp); err != nil {
+	if err := c.conn.Invoke(ctx, methodGetNodes.FullName(), query, &rsp); err != nil {
 		return nil, err
 	}
 	return rsp, nil
@@ -620,6 +713,49 @@ func (c *registryClient) WatchNodeList(ctx context.Context) (<-chan *NodeList, p
 	return ch, sub, nil
 }
 
+func (c *registryClient) WatchNodeListDiffs(ctx context.Context) (<-chan *NodeListDiff, pubsub.ClosableSubscription, error) {
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[4], methodWatchNodeListDiffs.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(nil); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *NodeListDiff)
+	go func() {
+		defer close(ch)
+
+		for {
+			var ev NodeListDiff
+			if serr := stream.RecvMsg(&ev); serr != nil {
+				return
+			}
+
+			select {
+			case ch <- &ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
+func (c *registryClient) ValidateNode(ctx context.Context, req *ValidateNodeRequest) (*NodeValidationResult, error) {
+	var rsp NodeValidationResult
+	if err := c.conn.Invoke(ctx, methodValidateNode.FullName(), req, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *registryClient) GetRuntime(ctx context.Context, query *NamespaceQuery) (*Runtime, error) {
 	var rsp Runtime
 	if err := c.conn.Invoke(ctx, methodGetRuntime.FullName(), query, &rsp); err != nil {
@@ -636,6 +772,14 @@ func (c *registryClient) GetRuntimes(ctx context.Context, query *GetRuntimesQuer
 	return rsp, nil
 }
 
+func (c *registryClient) ListRuntimesWithMetadata(ctx context.Context, query *ListRuntimesQuery) ([]*Runtime, error) {
+	var rsp []*Runtime
+	if err := c.conn.Invoke(ctx, methodListRuntimesWithMetadata.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 func (c *registryClient) WatchRuntimes(ctx context.Context) (<-chan *Runtime, pubsub.ClosableSubscription, error) {
 	ctx, sub := pubsub.NewContextSubscription(ctx)
 
@@ -1,12 +1,16 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
@@ -49,6 +53,13 @@ const (
 
 	// TxnSchedulerSimple is the name of the simple batching algorithm.
 	TxnSchedulerSimple = "simple"
+
+	// ProposerTimeoutPolicyFailRound is the name of the proposer timeout policy which
+	// finalizes the round as failed after a proposer timeout.
+	ProposerTimeoutPolicyFailRound = "fail_round"
+	// ProposerTimeoutPolicyActivateBackup is the name of the proposer timeout policy which
+	// activates the backup workers to produce the round after a proposer timeout.
+	ProposerTimeoutPolicyActivateBackup = "activate_backup"
 )
 
 // String returns a string representation of a runtime kind.
@@ -92,6 +103,11 @@ type ExecutorParameters struct {
 
 	// RoundTimeout is the round timeout in consensus blocks.
 	RoundTimeout int64 `json:"round_timeout"`
+
+	// MaxMessages is the maximum number of runtime messages that can be
+	// emitted in a single round. It is bounded by the roothash
+	// MaxRuntimeMessages consensus parameter.
+	MaxMessages uint32 `json:"max_messages,omitempty"`
 }
 
 // ValidateBasic performs basic executor parameter validity checks.
@@ -127,6 +143,37 @@ type TxnSchedulerParameters struct {
 	// ProposerTimeout denotes the timeout (in consensus blocks) for scheduler
 	// to propose a batch.
 	ProposerTimeout int64 `json:"propose_batch_timeout"`
+
+	// ProposerTimeoutPolicy is the policy applied after a proposer timeout. If not set,
+	// the round is finalized as failed.
+	ProposerTimeoutPolicy string `json:"proposer_timeout_policy,omitempty"`
+
+	// BatchFlushBytes denotes the total size (in bytes) of queued transactions at which a batch
+	// is flushed without waiting for the batch flush timeout. If not set, MaxBatchSizeBytes is
+	// used.
+	BatchFlushBytes uint64 `json:"batch_flush_bytes,omitempty"`
+
+	// BatchFlushTxAge denotes the age of the oldest queued transaction at which a batch is
+	// flushed without waiting for the batch flush timeout. If not set, transaction age is not
+	// used as a flush trigger.
+	BatchFlushTxAge time.Duration `json:"batch_flush_tx_age,omitempty"`
+
+	// BatchFlushGas denotes the total estimated runtime gas of queued transactions at which a
+	// batch is flushed without waiting for the batch flush timeout. If not set, estimated gas is
+	// not used as a flush trigger.
+	BatchFlushGas uint64 `json:"batch_flush_gas,omitempty"`
+
+	// TxGasBase is the estimated runtime gas of each queued transaction.
+	TxGasBase uint64 `json:"tx_gas_base,omitempty"`
+
+	// TxGasPerByte is the estimated runtime gas per byte of each queued transaction.
+	TxGasPerByte uint64 `json:"tx_gas_per_byte,omitempty"`
+}
+
+// IsProposerTimeoutEscalated returns true iff proposer timeouts should activate the backup
+// workers instead of failing the round.
+func (t *TxnSchedulerParameters) IsProposerTimeoutEscalated() bool {
+	return t.ProposerTimeoutPolicy == ProposerTimeoutPolicyActivateBackup
 }
 
 // ValidateBasic performs basic transaction scheduler parameter validity checks.
@@ -147,6 +194,20 @@ func (t *TxnSchedulerParameters) ValidateBasic() error {
 	if t.ProposerTimeout < 5 {
 		return fmt.Errorf("transaction scheduler proposer timeout parameter too small")
 	}
+	switch t.ProposerTimeoutPolicy {
+	case "", ProposerTimeoutPolicyFailRound, ProposerTimeoutPolicyActivateBackup:
+	default:
+		return fmt.Errorf("invalid transaction scheduler proposer timeout policy")
+	}
+	if t.BatchFlushBytes > t.MaxBatchSizeBytes {
+		return fmt.Errorf("transaction scheduler batch flush bytes parameter exceeds max batch bytes size")
+	}
+	if t.BatchFlushTxAge != 0 && t.BatchFlushTxAge < 10*time.Millisecond {
+		return fmt.Errorf("transaction scheduler batch flush transaction age parameter too small")
+	}
+	if t.BatchFlushGas > 0 && t.TxGasBase == 0 && t.TxGasPerByte == 0 {
+		return fmt.Errorf("transaction scheduler batch flush gas parameter requires a gas estimate")
+	}
 
 	return nil
 }
@@ -167,6 +228,14 @@ type StorageParameters struct {
 	// MaxApplyOps is the maximum number of apply operations in a batch.
 	MaxApplyOps uint64 `json:"max_apply_ops"`
 
+	// MaxApplyKeySize is the maximum size of a write log key (in bytes) when performing an Apply
+	// operation. Zero means no limit.
+	MaxApplyKeySize uint64 `json:"max_apply_key_size,omitempty"`
+
+	// MaxApplyValueSize is the maximum size of a write log value (in bytes) when performing an
+	// Apply operation. Zero means no limit.
+	MaxApplyValueSize uint64 `json:"max_apply_value_size,omitempty"`
+
 	// CheckpointInterval is the expected runtime state checkpoint interval (in rounds).
 	CheckpointInterval uint64 `json:"checkpoint_interval"`
 
@@ -260,6 +329,52 @@ func (s *RuntimeStakingParameters) ValidateBasic(runtimeKind RuntimeKind) error
 	return nil
 }
 
+const (
+	// RuntimeMetadataName is the runtime metadata key for the human-readable runtime name.
+	RuntimeMetadataName = "name"
+	// RuntimeMetadataHomepage is the runtime metadata key for the runtime homepage URL.
+	RuntimeMetadataHomepage = "homepage"
+	// RuntimeMetadataGenesisDocs is the runtime metadata key for the URL of the runtime genesis
+	// round documentation.
+	RuntimeMetadataGenesisDocs = "genesis_docs"
+
+	// MaxRuntimeMetadataEntries is the maximum number of runtime metadata entries.
+	MaxRuntimeMetadataEntries = 16
+	// MaxRuntimeMetadataKeySize is the maximum size of a runtime metadata key in bytes.
+	MaxRuntimeMetadataKeySize = 64
+	// MaxRuntimeMetadataValueSize is the maximum size of a runtime metadata value in bytes.
+	MaxRuntimeMetadataValueSize = 512
+)
+
+// ValidateRuntimeMetadata performs basic runtime metadata validity checks.
+func ValidateRuntimeMetadata(metadata map[string]string) error {
+	if len(metadata) > MaxRuntimeMetadataEntries {
+		return fmt.Errorf("too many metadata entries (max: %d)", MaxRuntimeMetadataEntries)
+	}
+	for k, v := range metadata {
+		if len(k) == 0 || len(k) > MaxRuntimeMetadataKeySize {
+			return fmt.Errorf("invalid metadata key size: %d", len(k))
+		}
+		for _, c := range k {
+			if !(c >= 'a' && c <= 'z') && !(c >= '0' && c <= '9') && c != '_' && c != '.' && c != '-' {
+				return fmt.Errorf("invalid metadata key: %s", k)
+			}
+		}
+		if len(v) > MaxRuntimeMetadataValueSize {
+			return fmt.Errorf("metadata value too large for key %s (max: %d)", k, MaxRuntimeMetadataValueSize)
+		}
+		if !utf8.ValidString(v) {
+			return fmt.Errorf("metadata value for key %s is not valid UTF-8", k)
+		}
+		for _, c := range v {
+			if unicode.IsControl(c) {
+				return fmt.Errorf("metadata value for key %s contains control characters", k)
+			}
+		}
+	}
+	return nil
+}
+
 const (
 	// LatestRuntimeDescriptorVersion is the latest entity descriptor version that should be used
 	// for all new descriptors. Using earlier versions may be rejected.
@@ -312,6 +427,10 @@ type Runtime struct { // nolint: maligned
 
 	// Staking stores the runtime's staking-related parameters.
 	Staking RuntimeStakingParameters `json:"staking,omitempty"`
+
+	// Metadata is a small map of human-meaningful information about the runtime
+	// (e.g., its name and homepage), see the RuntimeMetadata* keys.
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 // ValidateBasic performs basic descriptor validity checks.
@@ -352,6 +471,9 @@ func (r *Runtime) ValidateBasic(strictVersion bool) error {
 		if err := r.TxnScheduler.ValidateBasic(); err != nil {
 			return fmt.Errorf("bad txn scheduler parameters: %w", err)
 		}
+		if r.TxnScheduler.IsProposerTimeoutEscalated() && r.Executor.GroupBackupSize == 0 {
+			return fmt.Errorf("bad txn scheduler parameters: proposer timeout policy requires backup workers")
+		}
 		if err := r.Storage.ValidateBasic(); err != nil {
 			return fmt.Errorf("bad storage parameters: %w", err)
 		}
@@ -376,6 +498,9 @@ func (r *Runtime) ValidateBasic(strictVersion bool) error {
 	if err := r.Staking.ValidateBasic(r.Kind); err != nil {
 		return fmt.Errorf("bad staking parameters: %w", err)
 	}
+	if err := ValidateRuntimeMetadata(r.Metadata); err != nil {
+		return fmt.Errorf("bad metadata: %w", err)
+	}
 	return nil
 }
 
@@ -389,6 +514,45 @@ func (r *Runtime) IsCompute() bool {
 	return r.Kind == KindCompute
 }
 
+// FilterRuntimes returns the runtimes matching the metadata filter of the
+// given query, sorted as requested by the query.
+//
+// The IncludeSuspended and Height query fields are not considered.
+func FilterRuntimes(runtimes []*Runtime, query *ListRuntimesQuery) []*Runtime {
+	result := []*Runtime{}
+RuntimeLoop:
+	for _, rt := range runtimes {
+		for k, v := range query.Metadata {
+			rv, ok := rt.Metadata[k]
+			if !ok || (v != "" && rv != v) {
+				continue RuntimeLoop
+			}
+		}
+		result = append(result, rt)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if query.SortBy != "" {
+			av, aok := a.Metadata[query.SortBy]
+			bv, bok := b.Metadata[query.SortBy]
+			switch {
+			case aok != bok:
+				// Runtimes without the sort key are always last.
+				return aok
+			case av != bv:
+				return (av < bv) != query.Descending
+			}
+		}
+		// Fall back to ordering by runtime ID.
+		if cmp := bytes.Compare(a.ID[:], b.ID[:]); cmp != 0 {
+			return (cmp < 0) != query.Descending
+		}
+		return false
+	})
+	return result
+}
+
 // SignedRuntime is a signed blob containing a CBOR-serialized Runtime.
 type SignedRuntime struct {
 	signature.Signed
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+)
+
+func TestValidateRuntimeMetadata(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(ValidateRuntimeMetadata(nil), "empty metadata should be valid")
+	require.NoError(ValidateRuntimeMetadata(map[string]string{
+		RuntimeMetadataName:        "My Runtime",
+		RuntimeMetadataHomepage:    "https://example.com",
+		RuntimeMetadataGenesisDocs: "https://example.com/genesis",
+	}))
+
+	tooMany := make(map[string]string)
+	for i := 0; i <= MaxRuntimeMetadataEntries; i++ {
+		tooMany[string(rune('a'+i))] = "value"
+	}
+	for _, md := range []map[string]string{
+		tooMany,
+		{"": "value"},
+		{"Name": "value"},
+		{"name with spaces": "value"},
+		{strings.Repeat("a", MaxRuntimeMetadataKeySize+1): "value"},
+		{RuntimeMetadataName: strings.Repeat("a", MaxRuntimeMetadataValueSize+1)},
+		{RuntimeMetadataName: "line\nbreak"},
+		{RuntimeMetadataName: "\xff"},
+	} {
+		require.Error(ValidateRuntimeMetadata(md), "invalid metadata should be rejected")
+	}
+}
+
+func TestFilterRuntimes(t *testing.T) {
+	require := require.New(t)
+
+	newRuntime := func(id byte, md map[string]string) *Runtime {
+		var ns common.Namespace
+		ns[31] = id
+		return &Runtime{ID: ns, Metadata: md}
+	}
+	rt1 := newRuntime(1, map[string]string{RuntimeMetadataName: "bravo", RuntimeMetadataHomepage: "https://b.example.com"})
+	rt2 := newRuntime(2, map[string]string{RuntimeMetadataName: "alpha"})
+	rt3 := newRuntime(3, nil)
+	rt4 := newRuntime(4, map[string]string{RuntimeMetadataName: "charlie", RuntimeMetadataHomepage: "https://c.example.com"})
+	runtimes := []*Runtime{rt4, rt3, rt2, rt1}
+
+	for _, tc := range []struct {
+		name     string
+		query    ListRuntimesQuery
+		expected []*Runtime
+	}{
+		{"ByID", ListRuntimesQuery{}, []*Runtime{rt1, rt2, rt3, rt4}},
+		{"ByIDDescending", ListRuntimesQuery{Descending: true}, []*Runtime{rt4, rt3, rt2, rt1}},
+		{"ByName", ListRuntimesQuery{SortBy: RuntimeMetadataName}, []*Runtime{rt2, rt1, rt4, rt3}},
+		{"ByNameDescending", ListRuntimesQuery{SortBy: RuntimeMetadataName, Descending: true}, []*Runtime{rt4, rt1, rt2, rt3}},
+		{"HasKey", ListRuntimesQuery{Metadata: map[string]string{RuntimeMetadataHomepage: ""}}, []*Runtime{rt1, rt4}},
+		{"MatchValue", ListRuntimesQuery{Metadata: map[string]string{RuntimeMetadataName: "alpha"}}, []*Runtime{rt2}},
+		{"NoMatch", ListRuntimesQuery{Metadata: map[string]string{RuntimeMetadataName: "delta"}}, []*Runtime{}},
+	} {
+		require.Equal(tc.expected, FilterRuntimes(runtimes, &tc.query), tc.name) // nolint: gosec
+	}
+}
@@ -46,6 +46,13 @@ func (g *Genesis) SanityCheck(
 		return err
 	}
 
+	// Check pending stake claim releases.
+	for _, release := range g.StakeClaimReleases {
+		if release == nil {
+			return fmt.Errorf("registry: sanity check failed: nil stake claim release")
+		}
+	}
+
 	// Check nodes.
 	nodeLookup, err := SanityCheckNodes(logger, &g.Parameters, g.Nodes, seenEntities, runtimesLookup, true, baseEpoch)
 	if err != nil {
@@ -81,7 +88,7 @@ func (g *Genesis) SanityCheck(
 			return fmt.Errorf("registry: sanity check failed: could not obtain node list from nodeLookup: %w", err)
 		}
 		// Check stake.
-		return SanityCheckStake(entities, stakeLedger, nodes, runtimes, stakeThresholds, true)
+		return SanityCheckStake(entities, stakeLedger, nodes, runtimes, g.StakeClaimReleases, stakeThresholds, true)
 	}
 
 	return nil
@@ -192,6 +199,7 @@ func SanityCheckNodes(
 			epoch,
 			runtimesLookup,
 			nodeLookup,
+			nil,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("registry: node sanity check failed: ID: %s, error: %w", n.ID.String(), err)
@@ -209,12 +217,13 @@ func SanityCheckNodes(
 
 // SanityCheckStake ensures entities' stake accumulator claims are consistent
 // with general state and entities have enough stake for themselves and all
-// their registered nodes and runtimes.
+// their registered nodes and runtimes (including any claims pending release).
 func SanityCheckStake(
 	entities []*entity.Entity,
 	accounts map[staking.Address]*staking.Account,
 	nodes []*node.Node,
 	runtimes []*Runtime,
+	releases []*StakeClaimRelease,
 	stakeThresholds map[staking.ThresholdKind]quantity.Quantity,
 	isGenesis bool,
 ) error {
@@ -263,6 +272,14 @@ func SanityCheckStake(
 		addr := staking.NewAddress(rt.EntityID)
 		generatedEscrows[addr].StakeAccumulator.AddClaimUnchecked(StakeClaimForRuntime(rt.ID), StakeThresholdsForRuntime(rt))
 	}
+	for _, release := range releases {
+		// Add claims pending release. Claims of deregistered entities are not checked.
+		escrow, ok := generatedEscrows[release.Account]
+		if !ok {
+			continue
+		}
+		escrow.StakeAccumulator.AddClaimUnchecked(release.Claim, release.Thresholds)
+	}
 
 	// Compare entities' generated escrow accounts with actual ones.
 	for _, entity := range entities {
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cache/lru"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
+)
+
+// TEEVerificationCache caches successful TEE attestation verification results so that repeated
+// node registrations using the same attestation do not need to be re-verified.
+//
+// Entries are keyed by the attestation and the runtime's enclave identity policy and are only
+// used while the verification timestamp is within the validity of the original verification,
+// so the cache never changes the outcome of the verification.
+type TEEVerificationCache struct {
+	cache *lru.Cache
+}
+
+type teeVerificationKey struct {
+	RuntimeID   common.Namespace    `json:"runtime_id"`
+	Hardware    node.TEEHardware    `json:"hardware"`
+	RAK         signature.PublicKey `json:"rak"`
+	Attestation []byte              `json:"attestation"`
+	Policy      []byte              `json:"policy"`
+}
+
+// VerifyNodeRuntimeEnclaveIDs verifies TEE-specific attributes of the node's runtime, using a
+// cached result when available.
+//
+// It is safe to call this method on a nil cache in which case no caching is performed.
+func (c *TEEVerificationCache) VerifyNodeRuntimeEnclaveIDs(logger *logging.Logger, rt *node.Runtime, regRt *Runtime, ts time.Time) error {
+	if c == nil || rt.Capabilities.TEE == nil {
+		return VerifyNodeRuntimeEnclaveIDs(logger, rt, regRt, ts)
+	}
+
+	key := hash.NewFrom(&teeVerificationKey{
+		RuntimeID:   regRt.ID,
+		Hardware:    rt.Capabilities.TEE.Hardware,
+		RAK:         rt.Capabilities.TEE.RAK,
+		Attestation: rt.Capabilities.TEE.Attestation,
+		Policy:      regRt.Version.TEE,
+	})
+	if v, ok := c.cache.Get(key); ok && v.(*ias.Validity).Contains(ts) {
+		return nil
+	}
+
+	validity, err := verifyNodeRuntimeEnclaveIDs(logger, rt, regRt, ts)
+	if err != nil {
+		return err
+	}
+	if validity != nil {
+		_ = c.cache.Put(key, validity)
+	}
+	return nil
+}
+
+// NewTEEVerificationCache creates a new TEE verification cache holding up to the given number
+// of entries.
+func NewTEEVerificationCache(capacity uint64) *TEEVerificationCache {
+	cache, _ := lru.New(lru.Capacity(capacity, false))
+	return &TEEVerificationCache{
+		cache: cache,
+	}
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	epochtime "github.com/oasisprotocol/oasis-core/go/epochtime/api"
+)
+
+// NodeValidationCheck is the kind of check performed when validating a
+// candidate node descriptor.
+type NodeValidationCheck string
+
+const (
+	// NodeCheckDescriptor is the check of the basic node descriptor validity.
+	NodeCheckDescriptor NodeValidationCheck = "descriptor"
+	// NodeCheckSignatures is the check of the node descriptor signatures.
+	NodeCheckSignatures NodeValidationCheck = "signatures"
+	// NodeCheckEntity is the check that the owning entity exists and allows
+	// the node to be registered.
+	NodeCheckEntity NodeValidationCheck = "entity"
+	// NodeCheckExpiration is the check of the node descriptor expiration.
+	NodeCheckExpiration NodeValidationCheck = "expiration"
+	// NodeCheckRoles is the check of the node roles.
+	NodeCheckRoles NodeValidationCheck = "roles"
+	// NodeCheckRuntimes is the check of the runtimes the node registers for,
+	// including runtime admission policies.
+	NodeCheckRuntimes NodeValidationCheck = "runtimes"
+	// NodeCheckTEE is the check of the TEE attestations of the node's runtimes.
+	NodeCheckTEE NodeValidationCheck = "tee"
+	// NodeCheckAddresses is the check of the node's consensus, TLS and P2P
+	// addresses.
+	NodeCheckAddresses NodeValidationCheck = "addresses"
+	// NodeCheckKeys is the check that the node's keys are unique.
+	NodeCheckKeys NodeValidationCheck = "keys"
+	// NodeCheckStake is the check that the owning entity has sufficient stake
+	// to register the node.
+	NodeCheckStake NodeValidationCheck = "stake"
+	// NodeCheckUpdate is the check that the descriptor is a valid update of
+	// an already registered node.
+	NodeCheckUpdate NodeValidationCheck = "update"
+)
+
+// ValidateNodeRequest is a request to validate a candidate node descriptor
+// against the current consensus state.
+//
+// Exactly one of Node and SignedNode must be set. Signatures are only
+// checked for signed node descriptors.
+type ValidateNodeRequest struct {
+	Height int64 `json:"height"`
+
+	// Node is the unsigned candidate node descriptor.
+	Node *node.Node `json:"node,omitempty"`
+	// SignedNode is the signed candidate node descriptor.
+	SignedNode *node.MultiSignedNode `json:"signed_node,omitempty"`
+}
+
+// NodeValidationFailure is a failed node descriptor validation check.
+type NodeValidationFailure struct {
+	// Check is the kind of the failed check.
+	Check NodeValidationCheck `json:"check"`
+	// RuntimeID is the identifier of the runtime the failure refers to, if any.
+	RuntimeID *common.Namespace `json:"runtime_id,omitempty"`
+	// Error is the reason for the failure.
+	Error string `json:"error"`
+}
+
+// NodeValidationResult is the result of validating a candidate node
+// descriptor.
+type NodeValidationResult struct {
+	// Epoch is the epoch used when validating the node descriptor.
+	Epoch epochtime.EpochTime `json:"epoch"`
+	// Failures is the list of failed checks.
+	Failures []NodeValidationFailure `json:"failures,omitempty"`
+}
+
+// IsValid returns true iff the node descriptor passed all checks.
+func (r *NodeValidationResult) IsValid() bool {
+	return len(r.Failures) == 0
+}
+
+// VerifyNodeAddresses verifies the consensus, TLS and P2P addresses of the
+// given node descriptor.
+func VerifyNodeAddresses(params *ConsensusParameters, n *node.Node) error {
+	if err := verifyAddresses(params, n.HasRoles(ConsensusAddressRequiredRoles), n.Consensus.Addresses); err != nil {
+		return err
+	}
+	if err := verifyAddresses(params, n.HasRoles(TLSAddressRequiredRoles), n.TLS.Addresses); err != nil {
+		return err
+	}
+	return verifyAddresses(params, n.HasRoles(P2PAddressRequiredRoles), n.P2P.Addresses)
+}
@@ -79,7 +79,7 @@ func (m *MetricsUpdater) worker(ctx context.Context) {
 }
 
 func (m *MetricsUpdater) updatePeriodicMetrics(ctx context.Context) {
-	nodes, err := m.backend.GetNodes(ctx, consensus.HeightLatest)
+	nodes, err := m.backend.GetNodes(ctx, &api.GetNodesQuery{Height: consensus.HeightLatest})
 	if err == nil {
 		registryNodes.Set(float64(len(nodes)))
 	}
@@ -192,6 +192,14 @@ func testRegistryEntityNodes( // nolint: gocyclo
 					require.Error(err, v.descr)
 				}
 
+				var result *api.NodeValidationResult
+				result, err = backend.ValidateNode(ctx, &api.ValidateNodeRequest{
+					Height:     consensusAPI.HeightLatest,
+					SignedNode: tn.SignedRegistration,
+				})
+				require.NoError(err, "ValidateNode")
+				require.True(result.IsValid(), "node descriptor should be valid: %+v", result.Failures)
+
 				err = tn.Register(consensus, tn.SignedRegistration)
 				require.NoError(err, "RegisterNode")
 
@@ -271,6 +279,20 @@ func testRegistryEntityNodes( // nolint: gocyclo
 			}
 		}
 		for _, tn := range nonWhitelistedNodes {
+			result, verr := backend.ValidateNode(ctx, &api.ValidateNodeRequest{
+				Height:     consensusAPI.HeightLatest,
+				SignedNode: tn.SignedRegistration,
+			})
+			require.NoError(verr, "ValidateNode, non whitelisted")
+			require.False(result.IsValid(), "node descriptor from non whitelisted entity should be invalid")
+			var gotRuntimesFailure bool
+			for _, f := range result.Failures {
+				if f.Check == api.NodeCheckRuntimes {
+					gotRuntimesFailure = true
+				}
+			}
+			require.True(gotRuntimesFailure, "validation should report a runtimes failure")
+
 			require.Error(tn.Register(consensus, tn.SignedRegistration), "register node from non whitelisted entity")
 		}
 	})
@@ -294,12 +316,43 @@ func testRegistryEntityNodes( // nolint: gocyclo
 	t.Run("NodeList", func(t *testing.T) {
 		require := require.New(t)
 
+		diffCh, diffSub, derr := backend.WatchNodeListDiffs(ctx)
+		require.NoError(derr, "WatchNodeListDiffs")
+		defer diffSub.Close()
+
+		// The initial diff should contain the current node list.
+		select {
+		case diff := <-diffCh:
+			require.Empty(diff.Removed, "initial node list diff should not contain removed nodes")
+			require.Empty(diff.Updated, "initial node list diff should not contain updated nodes")
+			require.Equal((&api.NodeList{Nodes: diff.Added}).Hash(), diff.Hash, "initial node list diff hash")
+		case <-time.After(recvTimeout):
+			t.Fatalf("failed to receive initial node list diff")
+		}
+
 		expectedNodeList := getExpectedNodeList()
 		epoch = epochtimeTests.MustAdvanceEpoch(t, timeSource, 1)
 
-		registeredNodes, nerr := backend.GetNodes(ctx, consensusAPI.HeightLatest)
+		registeredNodes, nerr := backend.GetNodes(ctx, &api.GetNodesQuery{Height: consensusAPI.HeightLatest})
 		require.NoError(nerr, "GetNodes")
 		require.EqualValues(expectedNodeList, registeredNodes, "node list")
+
+		// The diff should contain all nodes registered during the previous epoch.
+		select {
+		case diff := <-diffCh:
+			require.Equal(epoch, diff.Epoch, "node list diff epoch")
+			require.Equal((&api.NodeList{Nodes: registeredNodes}).Hash(), diff.Hash, "node list diff hash")
+
+			added := make(map[signature.PublicKey]bool)
+			for _, n := range diff.Added {
+				added[n.ID] = true
+			}
+			for _, n := range expectedNodeList {
+				require.True(added[n.ID], "node list diff should contain registered node %s", n.ID)
+			}
+		case <-time.After(recvTimeout):
+			t.Fatalf("failed to receive node list diff")
+		}
 	})
 
 	t.Run("NodeUnfreeze", func(t *testing.T) {
@@ -393,7 +446,7 @@ func testRegistryEntityNodes( // nolint: gocyclo
 
 		// Ensure the node list doesn't have the expired nodes.
 		expectedNodeList := getExpectedNodeList()
-		registeredNodes, nerr := backend.GetNodes(ctx, consensusAPI.HeightLatest)
+		registeredNodes, nerr := backend.GetNodes(ctx, &api.GetNodesQuery{Height: consensusAPI.HeightLatest})
 		require.NoError(nerr, "GetNodes")
 		require.EqualValues(expectedNodeList, registeredNodes, "node list")
 
@@ -809,7 +862,7 @@ func EnsureRegistryEmpty(t *testing.T, backend api.Backend) {
 	require.Len(t, registeredEntities, 1, "registered entities")
 	require.Equal(t, testEntity.ID, registeredEntities[0].ID, "only the test entity can remain registered")
 
-	registeredNodes, err := backend.GetNodes(context.Background(), consensusAPI.HeightLatest)
+	registeredNodes, err := backend.GetNodes(context.Background(), &api.GetNodesQuery{Height: consensusAPI.HeightLatest})
 	require.NoError(t, err, "GetNodes")
 	require.Len(t, registeredNodes, 0, "registered nodes")
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
@@ -28,6 +29,9 @@ const (
 	LogEventTimerFired = "roothash/timer_fired"
 	// LogEventRoundFailed is a log event value that signals a round has failed.
 	LogEventRoundFailed = "roothash/round_failed"
+	// LogEventRoundEscalated is a log event value that signals a round has been escalated to
+	// the backup workers after a proposer timeout.
+	LogEventRoundEscalated = "roothash/round_escalated"
 	// LogEventMessageUnsat is a log event value that signals a roothash message was not satisfactory.
 	LogEventMessageUnsat = "roothash/message_unsat"
 	// LogEventHistoryReindexing is a log event value that signals a roothash runtime reindexing
@@ -54,6 +58,10 @@ var (
 	// ErrProposerTimeoutNotAllowed is the error returned when proposer timeout is not allowed.
 	ErrProposerTimeoutNotAllowed = errors.New(ModuleName, 6, "roothash: proposer timeout not allowed")
 
+	// ErrNoBlockHistory is the error returned when block history is required
+	// but is not being tracked for the given runtime.
+	ErrNoBlockHistory = errors.New(ModuleName, 7, "roothash: block history not available")
+
 	// MethodExecutorCommit is the method name for executor commit submission.
 	MethodExecutorCommit = transaction.NewMethodName(ModuleName, "ExecutorCommit", ExecutorCommit{})
 
@@ -78,6 +86,17 @@ type Backend interface {
 	// the latest state from the storage backend.
 	GetLatestBlock(ctx context.Context, runtimeID common.Namespace, height int64) (*block.Block, error)
 
+	// GetLatestBlockTags returns the tags attached by the runtime to the latest block.
+	GetLatestBlockTags(ctx context.Context, runtimeID common.Namespace, height int64) ([]block.Tag, error)
+
+	// GetRoundResults returns the outcome of the given runtime round.
+	//
+	// Results of the latest round are always available, results of earlier
+	// rounds are only available for runtimes that have their block history
+	// tracked and only as long as the corresponding consensus state has not
+	// been pruned.
+	GetRoundResults(ctx context.Context, runtimeID common.Namespace, round uint64) (*RoundResults, error)
+
 	// WatchBlocks returns a channel that produces a stream of
 	// annotated blocks.
 	//
@@ -86,6 +105,14 @@ type Backend interface {
 	// confirmed.
 	WatchBlocks(runtimeID common.Namespace) (<-chan *AnnotatedBlock, *pubsub.Subscription, error)
 
+	// WatchBlocksFrom returns a channel that produces a stream of
+	// annotated blocks starting at the given round.
+	//
+	// Historical blocks are replayed from the runtime's block history
+	// before any new blocks are pushed into the stream, so this is only
+	// supported for runtimes that have their block history tracked.
+	WatchBlocksFrom(ctx context.Context, runtimeID common.Namespace, round uint64) (<-chan *AnnotatedBlock, *pubsub.Subscription, error)
+
 	// WatchEvents returns a stream of protocol events.
 	WatchEvents(runtimeID common.Namespace) (<-chan *Event, *pubsub.Subscription, error)
 
@@ -140,6 +167,25 @@ type AnnotatedBlock struct {
 	Block *block.Block `json:"block"`
 }
 
+// RoundResults are the results of a runtime round.
+type RoundResults struct {
+	// Round is the runtime round.
+	Round uint64 `json:"round"`
+	// Failed is true iff the round has failed and an empty RoundFailed block
+	// has been emitted instead of a block with the computed results.
+	Failed bool `json:"failed,omitempty"`
+	// Empty is true iff no batch has been executed in the round, either
+	// because the round has failed or because an empty block (e.g., on
+	// epoch transition or runtime suspension) has been emitted.
+	Empty bool `json:"empty,omitempty"`
+	// Messages are the results of executing the messages emitted by the
+	// runtime in the round.
+	Messages []*block.MessageResult `json:"messages,omitempty"`
+	// BadComputeNodes are the public keys of the compute nodes that submitted
+	// commitments not matching the finalized results of the round.
+	BadComputeNodes []signature.PublicKey `json:"bad_compute_nodes,omitempty"`
+}
+
 // ExecutorCommittedEvent is an event emitted each time an executor node commits.
 type ExecutorCommittedEvent struct {
 	// Commit is the executor commitment.
@@ -150,11 +196,37 @@ type ExecutorCommittedEvent struct {
 type ExecutionDiscrepancyDetectedEvent struct {
 	// Timeout signals whether the discrepancy was due to a timeout.
 	Timeout bool `json:"timeout"`
+	// ProposerTimeout signals whether the discrepancy resolution was activated due to a
+	// proposer timeout escalation, in which case the backup transaction scheduler should
+	// propose the batch.
+	ProposerTimeout bool `json:"proposer_timeout,omitempty"`
 }
 
 // FinalizedEvent is a finalized event.
 type FinalizedEvent struct {
 	Round uint64 `json:"round"`
+	// Tags are the tags attached by the runtime to the finalized round.
+	Tags []block.Tag `json:"tags,omitempty"`
+}
+
+// RoundFailedEvent is a round failed event, emitted when a round fails and an empty
+// RoundFailed block is emitted instead of a normal block.
+type RoundFailedEvent struct {
+	// Round is the round of the emitted RoundFailed block.
+	Round uint64 `json:"round"`
+	// FailedRounds is the number of consecutive failed rounds, including this one.
+	FailedRounds uint64 `json:"failed_rounds"`
+}
+
+// LivenessEvent is a runtime liveness event, emitted when a runtime is
+// automatically suspended due to consecutive failed rounds or automatically
+// resumed after a sufficient committee can re-form.
+type LivenessEvent struct {
+	// Suspended signals whether the runtime has been suspended (or resumed).
+	Suspended bool `json:"suspended"`
+	// FailedRounds is the number of consecutive failed rounds that caused
+	// the runtime to be suspended.
+	FailedRounds uint64 `json:"failed_rounds,omitempty"`
 }
 
 // Event is a roothash event.
@@ -167,6 +239,8 @@ type Event struct {
 	ExecutorCommitted            *ExecutorCommittedEvent            `json:"executor_committed,omitempty"`
 	ExecutionDiscrepancyDetected *ExecutionDiscrepancyDetectedEvent `json:"execution_discrepancy,omitempty"`
 	FinalizedEvent               *FinalizedEvent                    `json:"finalized,omitempty"`
+	RoundFailed                  *RoundFailedEvent                  `json:"round_failed,omitempty"`
+	Liveness                     *LivenessEvent                     `json:"liveness,omitempty"`
 }
 
 // MetricsMonitorable is the interface exposed by backends capable of
@@ -193,8 +267,22 @@ type ConsensusParameters struct {
 	// GasCosts are the roothash transaction gas costs.
 	GasCosts transaction.Costs `json:"gas_costs,omitempty"`
 
+	// MaxFailedRounds is the number of consecutive failed rounds after which
+	// a runtime is considered dead and is automatically suspended. A runtime
+	// suspended this way is automatically resumed once enough compute nodes
+	// are registered for it to form an executor committee.
+	//
+	// Zero disables liveness-based suspension.
+	MaxFailedRounds uint64 `json:"max_failed_rounds,omitempty"`
+
+	// MaxRuntimeMessages is the maximum number of runtime messages that a
+	// runtime is allowed to configure to be emitted in a single round.
+	//
+	// Zero disables runtime messages.
+	MaxRuntimeMessages uint32 `json:"max_runtime_messages,omitempty"`
+
 	// DebugDoNotSuspendRuntimes is true iff runtimes should not be suspended
-	// for lack of paying maintenance fees.
+	// for lack of paying maintenance fees or liveness.
 	DebugDoNotSuspendRuntimes bool `json:"debug_do_not_suspend_runtimes,omitempty"`
 
 	// DebugBypassStake is true iff the roothash should bypass all of the staking
@@ -74,6 +74,10 @@ type Header struct { // nolint: maligned
 	// Messages are the roothash messages sent in this round.
 	Messages []*Message `json:"messages"`
 
+	// MessageResults are the results of executing the roothash messages sent
+	// in the previous round.
+	MessageResults []*MessageResult `json:"message_results,omitempty"`
+
 	// StorageSignatures are the storage receipt signatures for the merkle
 	// roots.
 	StorageSignatures []signature.Signature `json:"storage_signatures"`
@@ -1,6 +1,76 @@
 package block
 
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
 // Message is a roothash message that can be sent by a runtime.
+//
+// Exactly one of the fields must be set.
 type Message struct {
-	// No valid messages are currently defined.
+	// Staking is a staking operation performed on behalf of the runtime account.
+	Staking *StakingMessage `json:"staking,omitempty"`
+}
+
+// ValidateBasic performs basic validation of the runtime message.
+func (m *Message) ValidateBasic() error {
+	switch {
+	case m.Staking != nil:
+		return m.Staking.ValidateBasic()
+	default:
+		return fmt.Errorf("runtime message has no fields set")
+	}
+}
+
+// StakingMessage is a runtime message that performs a staking operation on behalf of the runtime
+// account (see staking.NewRuntimeAddress).
+//
+// Exactly one of the operation fields must be set.
+type StakingMessage struct {
+	cbor.Versioned
+
+	// Transfer transfers tokens from the runtime account.
+	Transfer *staking.Transfer `json:"transfer,omitempty"`
+	// AddEscrow escrows tokens from the runtime account.
+	AddEscrow *staking.Escrow `json:"add_escrow,omitempty"`
+	// ReclaimEscrow reclaims escrowed tokens to the runtime account.
+	ReclaimEscrow *staking.ReclaimEscrow `json:"reclaim_escrow,omitempty"`
+}
+
+// ValidateBasic performs basic validation of the staking runtime message.
+func (sm *StakingMessage) ValidateBasic() error {
+	if sm.V != 0 {
+		return fmt.Errorf("staking runtime message has invalid version (%d)", sm.V)
+	}
+
+	var numFields int
+	for _, set := range []bool{
+		sm.Transfer != nil,
+		sm.AddEscrow != nil,
+		sm.ReclaimEscrow != nil,
+	} {
+		if set {
+			numFields++
+		}
+	}
+	if numFields != 1 {
+		return fmt.Errorf("staking runtime message has %d operations set (expected exactly one)", numFields)
+	}
+	return nil
+}
+
+// MessageResult is the result of executing a runtime message.
+type MessageResult struct {
+	// Module is the module of the error in case message execution failed.
+	Module string `json:"module,omitempty"`
+	// Code is the error code in case message execution failed. Zero indicates success.
+	Code uint32 `json:"code,omitempty"`
+}
+
+// IsSuccess returns true iff the message was executed successfully.
+func (r *MessageResult) IsSuccess() bool {
+	return r.Code == 0
 }
@@ -0,0 +1,71 @@
+package block
+
+import (
+	"fmt"
+)
+
+const (
+	// MaxTags is the maximum number of tags that a runtime can attach to a round.
+	MaxTags = 16
+	// MaxTagKeySize is the maximum size of a tag key in bytes.
+	MaxTagKeySize = 64
+	// MaxTagValueSize is the maximum size of a tag value in bytes.
+	MaxTagValueSize = 256
+)
+
+// Tag is a key/value tag attached by a runtime to the results of a round.
+//
+// Tags are stored in roothash state and emitted with the finalized event so that consensus-level
+// tools can link runtime activity without understanding the runtime.
+//
+// Keep this in sync with /runtime/src/common/roothash.rs.
+type Tag struct {
+	// Key is the tag key. It may only contain ASCII letters, digits, '_', '-' and '.'.
+	Key string `json:"key"`
+	// Value is the tag value. It may only contain printable ASCII characters.
+	Value string `json:"value"`
+}
+
+// ValidateBasic performs basic tag validity checks.
+func (t *Tag) ValidateBasic() error {
+	if len(t.Key) == 0 || len(t.Key) > MaxTagKeySize {
+		return fmt.Errorf("invalid tag key size: %d", len(t.Key))
+	}
+	for _, c := range []byte(t.Key) {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '_', c == '-', c == '.':
+		default:
+			return fmt.Errorf("invalid character in tag key: %q", c)
+		}
+	}
+
+	if len(t.Value) > MaxTagValueSize {
+		return fmt.Errorf("invalid tag value size: %d", len(t.Value))
+	}
+	for _, c := range []byte(t.Value) {
+		if c < 0x20 || c > 0x7e {
+			return fmt.Errorf("invalid character in tag value: %q", c)
+		}
+	}
+	return nil
+}
+
+// ValidateTags performs basic validity checks on a set of round tags.
+func ValidateTags(tags []Tag) error {
+	if len(tags) > MaxTags {
+		return fmt.Errorf("too many tags: %d (max: %d)", len(tags), MaxTags)
+	}
+
+	keys := make(map[string]bool, len(tags))
+	for i := range tags {
+		if err := tags[i].ValidateBasic(); err != nil {
+			return fmt.Errorf("tag %d: %w", i, err)
+		}
+		if keys[tags[i].Key] {
+			return fmt.Errorf("duplicate tag key: %s", tags[i].Key)
+		}
+		keys[tags[i].Key] = true
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package block
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTags(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(ValidateTags(nil), "no tags should be valid")
+	require.NoError(ValidateTags([]Tag{
+		{Key: "tx-count", Value: "42"},
+		{Key: "last_sender.v1", Value: "oasis1qzzd6khm3acqskpxlk9vd5044cmmcce78y5l6000"},
+		{Key: "empty", Value: ""},
+	}), "valid tags should be valid")
+
+	for _, tc := range []struct {
+		name string
+		tags []Tag
+	}{
+		{"EmptyKey", []Tag{{Key: "", Value: "value"}}},
+		{"LongKey", []Tag{{Key: strings.Repeat("k", MaxTagKeySize+1), Value: "value"}}},
+		{"InvalidKey", []Tag{{Key: "key with spaces", Value: "value"}}},
+		{"LongValue", []Tag{{Key: "key", Value: strings.Repeat("v", MaxTagValueSize+1)}}},
+		{"InvalidValue", []Tag{{Key: "key", Value: "line\nbreak"}}},
+		{"DuplicateKey", []Tag{{Key: "key", Value: "a"}, {Key: "key", Value: "b"}}},
+	} {
+		require.Error(ValidateTags(tc.tags), "ValidateTags(%s)", tc.name)
+	}
+
+	var tooMany []Tag
+	for i := 0; i <= MaxTags; i++ {
+		tooMany = append(tooMany, Tag{Key: fmt.Sprintf("key%d", i)})
+	}
+	require.Error(ValidateTags(tooMany), "too many tags should be invalid")
+	require.NoError(ValidateTags(tooMany[:MaxTags]), "maximum number of tags should be valid")
+}
@@ -41,6 +41,7 @@ type ComputeResultsHeader struct {
 	IORoot    *hash.Hash       `json:"io_root,omitempty"`
 	StateRoot *hash.Hash       `json:"state_root,omitempty"`
 	Messages  []*block.Message `json:"messages,omitempty"`
+	Tags      []block.Tag      `json:"tags,omitempty"`
 }
 
 // IsParentOf returns true iff the header is the parent of a child header.
@@ -91,6 +92,7 @@ type ComputeBody struct {
 func (m *ComputeBody) SetFailure(failure ExecutorCommitmentFailure) {
 	m.Header.IORoot = nil
 	m.Header.StateRoot = nil
+	m.Header.Tags = nil
 	m.StorageSignatures = nil
 	m.RakSig = nil
 	m.Failure = failure
@@ -138,6 +140,9 @@ func (m *ComputeBody) ValidateBasic() error {
 		if header.StateRoot == nil {
 			return fmt.Errorf("missing StateRoot")
 		}
+		if err := block.ValidateTags(header.Tags); err != nil {
+			return fmt.Errorf("invalid tags: %w", err)
+		}
 	case FailureStorageUnavailable, FailureUnknown:
 		// In case of failure indicating commitment make sure storage signatures are empty.
 		if len(m.StorageSignatures) > 0 {
@@ -151,6 +156,9 @@ func (m *ComputeBody) ValidateBasic() error {
 		if header.StateRoot != nil {
 			return fmt.Errorf("failure indicating commitment includes StateRoot")
 		}
+		if len(header.Tags) > 0 {
+			return fmt.Errorf("failure indicating commitment includes Tags")
+		}
 		// In case of failure indicating commitment make sure RAK signature is empty.
 		if m.RakSig != nil {
 			return fmt.Errorf("failure indicating body includes RAK signature")
@@ -165,21 +173,13 @@ func (m *ComputeBody) ValidateBasic() error {
 // VerifyStorageReceiptSignatures validates that the storage receipt signatures
 // match the signatures for the current merkle roots.
 //
+// Signatures are verified in a batch and already verified signatures for the
+// same round are not verified again.
+//
 // Note: Ensuring that the signature is signed by the keypair(s) that are
 // expected is the responsibility of the caller.
 func (m *ComputeBody) VerifyStorageReceiptSignatures(ns common.Namespace) error {
-	receiptBody := storage.ReceiptBody{
-		Version:   1,
-		Namespace: ns,
-		Round:     m.Header.Round,
-		Roots:     m.RootsForStorageReceipt(),
-	}
-
-	if !signature.VerifyManyToOne(storage.ReceiptSignatureContext, cbor.Marshal(receiptBody), m.StorageSignatures) {
-		return signature.ErrVerifyFailed
-	}
-
-	return nil
+	return VerifyStorageReceiptSignatures(ns, m.Header.Round, m.RootsForStorageReceipt(), m.StorageSignatures)
 }
 
 // VerifyStorageReceipt validates that the provided storage receipt
@@ -278,6 +278,34 @@ func (c *ExecutorCommitment) Open() (*OpenExecutorCommitment, error) {
 	}, nil
 }
 
+// OpenExecutorCommitments validates the signatures of multiple executor
+// commitments using batch verification, and de-serializes the messages.
+// This does not validate the RAK signatures.
+func OpenExecutorCommitments(commits []ExecutorCommitment) ([]*OpenExecutorCommitment, error) {
+	v := signature.NewBatchVerifier()
+	for i := range commits {
+		v.Add(ExecutorSignatureContext, commits[i].Blob, &commits[i].Signature)
+	}
+	if !v.VerifyAll() {
+		return nil, errors.New("roothash/commitment: commitment has invalid signature")
+	}
+
+	openComs := make([]*OpenExecutorCommitment, 0, len(commits))
+	for i := range commits {
+		var body ComputeBody
+		if err := cbor.Unmarshal(commits[i].Blob, &body); err != nil {
+			return nil, errors.New("roothash/commitment: commitment has invalid signature")
+		}
+
+		openComs = append(openComs, &OpenExecutorCommitment{
+			ExecutorCommitment: commits[i],
+			Body:               &body,
+		})
+	}
+
+	return openComs, nil
+}
+
 // SignExecutorCommitment serializes the message and signs the commitment.
 func SignExecutorCommitment(signer signature.Signer, body *ComputeBody) (*ExecutorCommitment, error) {
 	signed, err := signature.SignSigned(signer, ExecutorSignatureContext, body)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 )
 
 func TestConsistentHash(t *testing.T) {
@@ -104,6 +105,31 @@ func TestValidateBasic(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"Ok Tags",
+			func(b ComputeBody) ComputeBody {
+				b.Header.Tags = []block.Tag{{Key: "tx-count", Value: "42"}}
+				return b
+			},
+			false,
+		},
+		{
+			"Bad Tags",
+			func(b ComputeBody) ComputeBody {
+				b.Header.Tags = []block.Tag{{Key: "", Value: "42"}}
+				return b
+			},
+			true,
+		},
+		{
+			"Bad Failure with Tags",
+			func(b ComputeBody) ComputeBody {
+				b.SetFailure(FailureStorageUnavailable)
+				b.Header.Tags = []block.Tag{{Key: "tx-count", Value: "42"}}
+				return b
+			},
+			true,
+		},
 	} {
 		b := tc.fn(body)
 		err := b.ValidateBasic()
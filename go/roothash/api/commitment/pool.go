@@ -36,6 +36,8 @@ var (
 	ErrTimeoutNotCorrectRound = errors.New(moduleName, 15, "roothash/commitment: timeout not for correct round")
 	ErrNodeIsScheduler        = errors.New(moduleName, 16, "roothash/commitment: node is scheduler")
 	ErrMajorityFailure        = errors.New(moduleName, 17, "roothash/commitment: majority commitments indicated failure")
+	ErrRoundEscalated         = errors.New(moduleName, 18, "roothash/commitment: round already escalated to backup workers")
+	ErrNoBackupWorkers        = errors.New(moduleName, 19, "roothash/commitment: no backup workers in committee")
 )
 
 const (
@@ -88,6 +90,10 @@ type Pool struct {
 	// NextTimeout is the time when the next call to TryFinalize(true) should
 	// be scheduled to be executed. Zero means that no timeout is to be scheduled.
 	NextTimeout int64 `json:"next_timeout"`
+	// BackupScheduling is a flag signalling that the round has been escalated to the backup
+	// workers after a proposer timeout and that the batch is proposed by the backup
+	// transaction scheduler.
+	BackupScheduling bool `json:"backup_scheduling,omitempty"`
 
 	// memberSet is a cached committee member set. It will be automatically
 	// constructed based on the passed Committee.
@@ -149,14 +155,15 @@ func (p *Pool) isScheduler(id signature.PublicKey, round uint64) bool {
 	return scheduler.PublicKey.Equal(id)
 }
 
-// ResetCommitments resets the commitments in the pool, clears the discrepancy flag and the next
-// timeout height.
+// ResetCommitments resets the commitments in the pool, clears the discrepancy and backup
+// scheduling flags and the next timeout height.
 func (p *Pool) ResetCommitments() {
 	if p.ExecuteCommitments == nil || len(p.ExecuteCommitments) > 0 {
 		p.ExecuteCommitments = make(map[signature.PublicKey]OpenExecutorCommitment)
 	}
 	p.Discrepancy = false
 	p.NextTimeout = TimeoutNever
+	p.BackupScheduling = false
 }
 
 func (p *Pool) getCommitment(id signature.PublicKey) (OpenCommitment, bool) {
@@ -214,11 +221,25 @@ func (p *Pool) addOpenExecutorCommitment(
 		return ErrNoRuntime
 	}
 
-	// Make sure the commitment does not contain any messages as these are currently
-	// not supported and should be rejected.
-	if len(header.Messages) > 0 {
+	// Make sure the commitment does not contain more messages than the runtime is allowed to
+	// emit and that all of the messages are well-formed.
+	if len(header.Messages) > int(p.Runtime.Executor.MaxMessages) {
+		logger.Debug("executor commitment contains too many messages",
+			"node_id", id,
+			"num_messages", len(header.Messages),
+			"max_messages", p.Runtime.Executor.MaxMessages,
+		)
 		return ErrInvalidMessages
 	}
+	for _, msg := range header.Messages {
+		if err := msg.ValidateBasic(); err != nil {
+			logger.Debug("executor commitment contains an invalid message",
+				"node_id", id,
+				"err", err,
+			)
+			return ErrInvalidMessages
+		}
+	}
 
 	// Check if the block is based on the previous block.
 	if !header.IsParentOf(&blk.Header) {
@@ -238,10 +259,11 @@ func (p *Pool) addOpenExecutorCommitment(
 		return ErrBadExecutorCommitment
 	}
 
-	if err := sv.VerifyTxnSchedulerSignature(body.TxnSchedSig, blk.Header.Round); err != nil {
+	if err := p.verifyTxnSchedulerSignature(sv, body.TxnSchedSig, blk.Header.Round); err != nil {
 		logger.Debug("executor commitment has bad transaction scheduler signer",
 			"node_id", id,
 			"round", blk.Header.Round,
+			"backup_scheduling", p.BackupScheduling,
 			"err", err,
 		)
 		return err
@@ -319,6 +341,23 @@ func (p *Pool) addOpenExecutorCommitment(
 	return nil
 }
 
+func (p *Pool) verifyTxnSchedulerSignature(sv SignatureVerifier, sig signature.Signature, round uint64) error {
+	if !p.BackupScheduling {
+		return sv.VerifyTxnSchedulerSignature(sig, round)
+	}
+
+	// In case the round has been escalated, the batch must be proposed by the backup
+	// transaction scheduler.
+	scheduler, err := GetBackupTransactionScheduler(p.Committee, round)
+	if err != nil {
+		return err
+	}
+	if !scheduler.PublicKey.Equal(sig.PublicKey) {
+		return ErrTxnSchedSigInvalid
+	}
+	return nil
+}
+
 // AddExecutorCommitment verifies and adds a new executor commitment to the pool.
 func (p *Pool) AddExecutorCommitment(
 	ctx context.Context,
@@ -336,6 +375,29 @@ func (p *Pool) AddExecutorCommitment(
 	return p.addOpenExecutorCommitment(ctx, blk, sv, nl, openCom)
 }
 
+// AddExecutorCommitments verifies and adds multiple executor commitments to
+// the pool. Commitment signatures are verified as a batch.
+func (p *Pool) AddExecutorCommitments(
+	ctx context.Context,
+	blk *block.Block,
+	sv SignatureVerifier,
+	nl NodeLookup,
+	commitments []ExecutorCommitment,
+) error {
+	// Check the commitment signatures and de-serialize into headers.
+	openComs, err := OpenExecutorCommitments(commitments)
+	if err != nil {
+		return p2pError.Permanent(err)
+	}
+
+	for _, openCom := range openComs {
+		if err = p.addOpenExecutorCommitment(ctx, blk, sv, nl, openCom); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // CheckEnoughCommitments checks if there are enough commitments in the pool to be
 // able to perform discrepancy detection.
 func (p *Pool) CheckEnoughCommitments(didTimeout bool) error {
@@ -402,6 +464,11 @@ func (p *Pool) CheckProposerTimeout(
 		return ErrTimeoutNotCorrectRound
 	}
 
+	// Ensure the round has not already been escalated to the backup workers.
+	if p.BackupScheduling {
+		return ErrRoundEscalated
+	}
+
 	// Ensure there is no commitments yet.
 	if len(p.ExecuteCommitments) != 0 {
 		return ErrAlreadyCommitted
@@ -422,6 +489,27 @@ func (p *Pool) CheckProposerTimeout(
 	return nil
 }
 
+// EscalateProposerTimeout escalates the current round to the backup workers after a proposer
+// timeout. The batch for the round is then proposed by the backup transaction scheduler and
+// the round is finalized via discrepancy resolution.
+//
+// The caller must verify the proposer timeout request via CheckProposerTimeout and should then
+// call TryFinalize with didTimeout set to arm the backup worker round timeout.
+func (p *Pool) EscalateProposerTimeout() error {
+	if p.Committee == nil {
+		return ErrNoCommittee
+	}
+	if p.Committee.Kind != scheduler.KindComputeExecutor {
+		return ErrInvalidCommitteeKind
+	}
+	if len(p.Committee.BackupWorkers()) == 0 {
+		return ErrNoBackupWorkers
+	}
+
+	p.BackupScheduling = true
+	return nil
+}
+
 // DetectDiscrepancy performs discrepancy detection on the current commitments in
 // the pool.
 //
@@ -615,6 +703,29 @@ func (p *Pool) GetExecutorCommitments() (result []ExecutorCommitment) {
 	return
 }
 
+// GetBadComputeNodes returns the public keys of committee members that submitted commitments
+// which do not match the given finalized commitment (including commitments indicating failure).
+//
+// The returned list is ordered as in the committee.
+func (p *Pool) GetBadComputeNodes(commit OpenCommitment) []signature.PublicKey {
+	if p.Committee == nil || commit == nil {
+		return nil
+	}
+
+	var bad []signature.PublicKey
+	for _, n := range p.Committee.Members {
+		c, ok := p.getCommitment(n.PublicKey)
+		if !ok {
+			continue
+		}
+
+		if c.IsIndicatingFailure() || !commit.MostlyEqual(c) {
+			bad = append(bad, n.PublicKey)
+		}
+	}
+	return bad
+}
+
 // IsTimeout returns true if the time is up for pool's TryFinalize to be called.
 func (p *Pool) IsTimeout(height int64) bool {
 	return p.NextTimeout != TimeoutNever && height >= p.NextTimeout
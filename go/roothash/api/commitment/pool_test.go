@@ -18,6 +18,7 @@ import (
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 )
 
@@ -131,6 +132,9 @@ func TestPoolSingleCommitment(t *testing.T) {
 			GroupSize:           1,
 			MinWriteReplication: 1,
 		},
+		Executor: registry.ExecutorParameters{
+			MaxMessages: 1,
+		},
 	}
 
 	// Generate a commitment signing key.
@@ -189,6 +193,16 @@ func TestPoolSingleCommitment(t *testing.T) {
 			b.StorageSignatures = nil
 			b.Header.IORoot = nil
 		}, ErrBadExecutorCommitment},
+		{"TooManyMessages", func(b *ComputeBody) {
+			b.Header.Messages = []*block.Message{
+				&block.Message{Staking: &block.StakingMessage{Transfer: &staking.Transfer{}}},
+				&block.Message{Staking: &block.StakingMessage{Transfer: &staking.Transfer{}}},
+			}
+		}, ErrInvalidMessages},
+		{"InvalidMessage", func(b *ComputeBody) { b.Header.Messages = []*block.Message{{}} }, ErrInvalidMessages},
+		{"InvalidStakingMessage", func(b *ComputeBody) {
+			b.Header.Messages = []*block.Message{{Staking: &block.StakingMessage{}}}
+		}, ErrInvalidMessages},
 	} {
 		_, _, invalidBody := generateComputeBody(t)
 		invalidBody.StorageSignatures = append([]signature.Signature{}, body.StorageSignatures...)
@@ -405,6 +419,7 @@ func TestPoolTwoCommitments(t *testing.T) {
 		require.Equal(t, false, pool.Discrepancy)
 		header := dc.ToDDResult().(ComputeResultsHeader)
 		require.EqualValues(t, &body.Header, &header, "DD should return the same header")
+		require.Empty(t, pool.GetBadComputeNodes(dc), "GetBadComputeNodes")
 	})
 
 	t.Run("Discrepancy", func(t *testing.T) {
@@ -427,6 +442,11 @@ func TestPoolTwoCommitments(t *testing.T) {
 		require.Equal(t, true, pool.Discrepancy)
 		header := dc.ToDDResult().(ComputeResultsHeader)
 		require.EqualValues(t, &correctBody.Header, &header, "DR should return the same header")
+
+		// The node that submitted the incorrect commitment should be reported.
+		bad := pool.GetBadComputeNodes(dc)
+		require.Len(t, bad, 1, "GetBadComputeNodes")
+		require.EqualValues(t, sk2.Public(), bad[0], "GetBadComputeNodes")
 	})
 
 	t.Run("DiscrepancyResolutionFailure", func(t *testing.T) {
@@ -813,6 +833,86 @@ func TestExecutorTimeoutRequest(t *testing.T) {
 	})
 }
 
+func TestProposerTimeoutEscalation(t *testing.T) {
+	genesisTestHelpers.SetTestChainContext()
+
+	rt, sks, committee, nl := generateMockCommittee(t)
+	sk2 := sks[1]
+	sk3 := sks[2]
+
+	require := require.New(t)
+	ctx := context.Background()
+	now := int64(1)
+	roundTimeout := int64(10)
+
+	// Create a pool.
+	pool := Pool{
+		Runtime:   rt,
+		Committee: committee,
+	}
+
+	childBlk, _, body := generateComputeBody(t)
+
+	// Escalate the round after a proposer timeout.
+	err := pool.CheckProposerTimeout(ctx, childBlk, nopSV, nl, sk2.Public(), 0)
+	require.NoError(err, "CheckProposerTimeout")
+	err = pool.EscalateProposerTimeout()
+	require.NoError(err, "EscalateProposerTimeout")
+	require.True(pool.BackupScheduling, "round should use backup scheduling")
+
+	_, err = pool.TryFinalize(now, roundTimeout, true, true)
+	require.Error(err, "TryFinalize")
+	require.Equal(ErrDiscrepancyDetected, err, "TryFinalize should activate the backup workers")
+	require.True(pool.Discrepancy)
+	require.EqualValues(now+(15*roundTimeout)/10, pool.NextTimeout, "NextTimeout should be set to 1.5*RoundTimeout")
+
+	// Further proposer timeouts should not be allowed.
+	err = pool.CheckProposerTimeout(ctx, childBlk, nopSV, nl, sk2.Public(), 0)
+	require.Equal(ErrRoundEscalated, err, "CheckProposerTimeout after escalation")
+
+	// Commitments for batches not proposed by the backup scheduler should be rejected.
+	commit3, err := SignExecutorCommitment(sk3, &body)
+	require.NoError(err, "SignExecutorCommitment")
+	err = pool.AddExecutorCommitment(ctx, childBlk, nopSV, nl, commit3)
+	require.Equal(ErrTxnSchedSigInvalid, err, "AddExecutorCommitment with primary scheduler batch")
+
+	// Commitments for batches proposed by the backup scheduler should be accepted.
+	backupScheduler, err := GetBackupTransactionScheduler(committee, childBlk.Header.Round)
+	require.NoError(err, "GetBackupTransactionScheduler")
+	require.Equal(sk3.Public(), backupScheduler.PublicKey)
+	signedDispatch, err := SignProposedBatch(sk3, &ProposedBatch{
+		IORoot:            body.InputRoot,
+		StorageSignatures: body.InputStorageSigs,
+		Header:            childBlk.Header,
+	})
+	require.NoError(err, "SignProposedBatch")
+	body.TxnSchedSig = signedDispatch.Signature
+
+	commit3, err = SignExecutorCommitment(sk3, &body)
+	require.NoError(err, "SignExecutorCommitment")
+	err = pool.AddExecutorCommitment(ctx, childBlk, nopSV, nl, commit3)
+	require.NoError(err, "AddExecutorCommitment")
+
+	dc, err := pool.TryFinalize(now, roundTimeout, false, true)
+	require.NoError(err, "TryFinalize")
+	header := dc.ToDDResult().(ComputeResultsHeader)
+	require.EqualValues(&body.Header, &header, "DR should return the backup worker header")
+
+	pool.ResetCommitments()
+	require.False(pool.BackupScheduling, "ResetCommitments should clear backup scheduling")
+
+	// Escalation requires backup workers.
+	pool = Pool{
+		Runtime: rt,
+		Committee: &scheduler.Committee{
+			Kind:    scheduler.KindComputeExecutor,
+			Members: committee.Workers(),
+		},
+	}
+	err = pool.EscalateProposerTimeout()
+	require.Equal(ErrNoBackupWorkers, err, "EscalateProposerTimeout without backup workers")
+}
+
 func generateMockCommittee(t *testing.T) (
 	rt *registry.Runtime,
 	sks []signature.Signer,
@@ -0,0 +1,120 @@
+package commitment
+
+import (
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+)
+
+// defaultReceiptVerifier is the storage receipt verifier used by the commitment package.
+var defaultReceiptVerifier = NewStorageReceiptVerifier()
+
+// verifiedReceiptKey identifies a verified storage receipt signature.
+type verifiedReceiptKey struct {
+	signer    signature.PublicKey
+	signature signature.RawSignature
+	// body is the hash of the storage receipt body, which commits to the signed roots.
+	body hash.Hash
+}
+
+// receiptRoundCache is the cache of verified storage receipt signatures of a single round.
+type receiptRoundCache struct {
+	round    uint64
+	verified map[verifiedReceiptKey]bool
+}
+
+// StorageReceiptVerifier verifies storage receipt signatures using batched Ed25519 verification.
+//
+// Already verified signatures are cached for the current round of each runtime, so that the same
+// storage receipts attached to multiple commitments (or batches) only need to be verified once.
+// The cache for a runtime is discarded as soon as receipts for a different round are verified.
+type StorageReceiptVerifier struct {
+	sync.Mutex
+
+	rounds map[common.Namespace]*receiptRoundCache
+}
+
+// Verify verifies that all of the given storage receipt signatures are valid signatures of a
+// storage receipt for the given namespace, round and roots.
+//
+// Note: Ensuring that the signatures are signed by the keypair(s) that are expected is the
+// responsibility of the caller.
+func (v *StorageReceiptVerifier) Verify(
+	ns common.Namespace,
+	round uint64,
+	roots []hash.Hash,
+	sigs []signature.Signature,
+) error {
+	receiptBody := storage.ReceiptBody{
+		Version:   1,
+		Namespace: ns,
+		Round:     round,
+		Roots:     roots,
+	}
+	rawBody := cbor.Marshal(receiptBody)
+	bodyHash := hash.NewFromBytes(rawBody)
+
+	v.Lock()
+	defer v.Unlock()
+
+	rc := v.rounds[ns]
+	if rc == nil || rc.round != round {
+		rc = &receiptRoundCache{
+			round:    round,
+			verified: make(map[verifiedReceiptKey]bool),
+		}
+		v.rounds[ns] = rc
+	}
+
+	bv := signature.NewBatchVerifier()
+	var pending []verifiedReceiptKey
+	for i := range sigs {
+		key := verifiedReceiptKey{
+			signer:    sigs[i].PublicKey,
+			signature: sigs[i].Signature,
+			body:      bodyHash,
+		}
+		if rc.verified[key] {
+			continue
+		}
+
+		bv.Add(storage.ReceiptSignatureContext, rawBody, &sigs[i])
+		pending = append(pending, key)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	if !bv.VerifyAll() {
+		return signature.ErrVerifyFailed
+	}
+
+	for _, key := range pending {
+		rc.verified[key] = true
+	}
+	return nil
+}
+
+// NewStorageReceiptVerifier creates a new storage receipt verifier.
+func NewStorageReceiptVerifier() *StorageReceiptVerifier {
+	return &StorageReceiptVerifier{
+		rounds: make(map[common.Namespace]*receiptRoundCache),
+	}
+}
+
+// VerifyStorageReceiptSignatures verifies storage receipt signatures for the given namespace,
+// round and roots, using a shared cache of already verified signatures.
+//
+// Note: Ensuring that the signatures are signed by the keypair(s) that are expected is the
+// responsibility of the caller.
+func VerifyStorageReceiptSignatures(
+	ns common.Namespace,
+	round uint64,
+	roots []hash.Hash,
+	sigs []signature.Signature,
+) error {
+	return defaultReceiptVerifier.Verify(ns, round, roots, sigs)
+}
@@ -0,0 +1,77 @@
+package commitment
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+)
+
+func TestStorageReceiptVerifier(t *testing.T) {
+	require := require.New(t)
+
+	var ns common.Namespace
+	var root hash.Hash
+	root.FromBytes([]byte("storage receipt verifier test root"))
+	roots := []hash.Hash{root}
+
+	signReceipt := func(round uint64) []signature.Signature {
+		body := storage.ReceiptBody{
+			Version:   1,
+			Namespace: ns,
+			Round:     round,
+			Roots:     roots,
+		}
+
+		var sigs []signature.Signature
+		for i := 0; i < 3; i++ {
+			sk, err := memorySigner.NewSigner(rand.Reader)
+			require.NoError(err, "NewSigner")
+			sig, err := signature.Sign(sk, storage.ReceiptSignatureContext, cbor.Marshal(body))
+			require.NoError(err, "Sign")
+			sigs = append(sigs, *sig)
+		}
+		return sigs
+	}
+
+	v := NewStorageReceiptVerifier()
+	sigs := signReceipt(1)
+
+	err := v.Verify(ns, 1, roots, sigs)
+	require.NoError(err, "Verify")
+	require.Len(v.rounds[ns].verified, len(sigs), "verified signatures should be cached")
+
+	// Verifying the same signatures again should succeed.
+	err = v.Verify(ns, 1, roots, sigs)
+	require.NoError(err, "Verify (cached)")
+
+	// Cached signatures should not be accepted for different roots.
+	var otherRoot hash.Hash
+	otherRoot.FromBytes([]byte("storage receipt verifier test other root"))
+	err = v.Verify(ns, 1, []hash.Hash{otherRoot}, sigs)
+	require.Error(err, "Verify should fail for different roots")
+
+	// A tampered signature by a cached signer should be rejected.
+	tampered := append([]signature.Signature{}, sigs...)
+	tampered[0].Signature[0] ^= 0xff
+	err = v.Verify(ns, 1, roots, tampered)
+	require.Error(err, "Verify should fail for tampered signatures")
+
+	// Signatures for a different round should be rejected.
+	err = v.Verify(ns, 2, roots, sigs)
+	require.Error(err, "Verify should fail for a different round")
+
+	// Moving to a new round should discard the cache.
+	sigs = signReceipt(2)
+	err = v.Verify(ns, 2, roots, sigs)
+	require.NoError(err, "Verify")
+	require.EqualValues(2, v.rounds[ns].round, "cache should be for the new round")
+	require.Len(v.rounds[ns].verified, len(sigs), "cache should only contain the new round")
+}
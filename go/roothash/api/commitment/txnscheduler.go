@@ -65,3 +65,16 @@ func GetTransactionScheduler(committee *scheduler.Committee, round uint64) (*sch
 	schedulerIdx := round % numNodes
 	return workers[schedulerIdx], nil
 }
+
+// GetBackupTransactionScheduler returns the backup transaction scheduler of the provided
+// committee based on the provided round. The backup transaction scheduler proposes the batch
+// in rounds that have been escalated to the backup workers after a proposer timeout.
+func GetBackupTransactionScheduler(committee *scheduler.Committee, round uint64) (*scheduler.CommitteeNode, error) {
+	workers := committee.BackupWorkers()
+	numNodes := uint64(len(workers))
+	if numNodes == 0 {
+		return nil, fmt.Errorf("GetBackupTransactionScheduler: no backup workers in commmittee")
+	}
+	schedulerIdx := round % numNodes
+	return workers[schedulerIdx], nil
+}
@@ -0,0 +1,296 @@
+package api
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+)
+
+var (
+	// serviceName is the gRPC service name.
+	serviceName = cmnGrpc.NewServiceName("RootHash")
+
+	// methodGetGenesisBlock is the GetGenesisBlock method.
+	methodGetGenesisBlock = serviceName.NewMethod("GetGenesisBlock", RuntimeRequest{})
+	// methodGetLatestBlock is the GetLatestBlock method.
+	methodGetLatestBlock = serviceName.NewMethod("GetLatestBlock", RuntimeRequest{})
+	// methodGetRoundResults is the GetRoundResults method.
+	methodGetRoundResults = serviceName.NewMethod("GetRoundResults", RoundResultsRequest{})
+	// methodGetEvents is the GetEvents method.
+	methodGetEvents = serviceName.NewMethod("GetEvents", int64(0))
+
+	// methodWatchEvents is the WatchEvents method.
+	methodWatchEvents = serviceName.NewMethod("WatchEvents", common.Namespace{})
+
+	// serviceDesc is the gRPC service descriptor.
+	serviceDesc = grpc.ServiceDesc{
+		ServiceName: string(serviceName),
+		HandlerType: (*Backend)(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: methodGetGenesisBlock.ShortName(),
+				Handler:    handlerGetGenesisBlock,
+			},
+			{
+				MethodName: methodGetLatestBlock.ShortName(),
+				Handler:    handlerGetLatestBlock,
+			},
+			{
+				MethodName: methodGetRoundResults.ShortName(),
+				Handler:    handlerGetRoundResults,
+			},
+			{
+				MethodName: methodGetEvents.ShortName(),
+				Handler:    handlerGetEvents,
+			},
+		},
+		Streams: []grpc.StreamDesc{
+			{
+				StreamName:    methodWatchEvents.ShortName(),
+				Handler:       handlerWatchEvents,
+				ServerStreams: true,
+			},
+		},
+	}
+)
+
+// RuntimeRequest is a request for runtime-specific roothash state.
+type RuntimeRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	Height    int64            `json:"height"`
+}
+
+// RoundResultsRequest is a request for the results of a runtime round.
+type RoundResultsRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	Round     uint64           `json:"round"`
+}
+
+// ClientBackend is a limited roothash backend interface, available to remote clients.
+type ClientBackend interface {
+	// GetGenesisBlock returns the genesis block.
+	GetGenesisBlock(ctx context.Context, request *RuntimeRequest) (*block.Block, error)
+
+	// GetLatestBlock returns the latest block.
+	GetLatestBlock(ctx context.Context, request *RuntimeRequest) (*block.Block, error)
+
+	// GetRoundResults returns the outcome of the given runtime round.
+	GetRoundResults(ctx context.Context, request *RoundResultsRequest) (*RoundResults, error)
+
+	// GetEvents returns the events at specified block height.
+	GetEvents(ctx context.Context, height int64) ([]*Event, error)
+
+	// WatchEvents returns a channel that produces a stream of protocol events for the given
+	// runtime.
+	WatchEvents(ctx context.Context, runtimeID common.Namespace) (<-chan *Event, pubsub.ClosableSubscription, error)
+}
+
+func handlerGetGenesisBlock( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req RuntimeRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetGenesisBlock(ctx, req.RuntimeID, req.Height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetGenesisBlock.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		r := req.(*RuntimeRequest)
+		return srv.(Backend).GetGenesisBlock(ctx, r.RuntimeID, r.Height)
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
+func handlerGetLatestBlock( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req RuntimeRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetLatestBlock(ctx, req.RuntimeID, req.Height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetLatestBlock.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		r := req.(*RuntimeRequest)
+		return srv.(Backend).GetLatestBlock(ctx, r.RuntimeID, r.Height)
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
+func handlerGetRoundResults( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req RoundResultsRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetRoundResults(ctx, req.RuntimeID, req.Round)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetRoundResults.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		r := req.(*RoundResultsRequest)
+		return srv.(Backend).GetRoundResults(ctx, r.RuntimeID, r.Round)
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
+func handlerGetEvents( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var height int64
+	if err := dec(&height); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetEvents(ctx, height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetEvents.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetEvents(ctx, req.(int64))
+	}
+	return interceptor(ctx, height, info, handler)
+}
+
+func handlerWatchEvents(srv interface{}, stream grpc.ServerStream) error {
+	var runtimeID common.Namespace
+	if err := stream.RecvMsg(&runtimeID); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(Backend).WatchEvents(runtimeID)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.SendMsg(ev); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// RegisterService registers a new roothash service with the given gRPC server.
+func RegisterService(server *grpc.Server, service Backend) {
+	server.RegisterService(&serviceDesc, service)
+}
+
+type roothashClient struct {
+	conn *grpc.ClientConn
+}
+
+func (c *roothashClient) GetGenesisBlock(ctx context.Context, request *RuntimeRequest) (*block.Block, error) {
+	var rsp block.Block
+	if err := c.conn.Invoke(ctx, methodGetGenesisBlock.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *roothashClient) GetLatestBlock(ctx context.Context, request *RuntimeRequest) (*block.Block, error) {
+	var rsp block.Block
+	if err := c.conn.Invoke(ctx, methodGetLatestBlock.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *roothashClient) GetRoundResults(ctx context.Context, request *RoundResultsRequest) (*RoundResults, error) {
+	var rsp RoundResults
+	if err := c.conn.Invoke(ctx, methodGetRoundResults.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *roothashClient) GetEvents(ctx context.Context, height int64) ([]*Event, error) {
+	var rsp []*Event
+	if err := c.conn.Invoke(ctx, methodGetEvents.FullName(), height, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *roothashClient) WatchEvents(ctx context.Context, runtimeID common.Namespace) (<-chan *Event, pubsub.ClosableSubscription, error) {
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[0], methodWatchEvents.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(runtimeID); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *Event)
+	go func() {
+		defer close(ch)
+
+		for {
+			var ev Event
+			if serr := stream.RecvMsg(&ev); serr != nil {
+				return
+			}
+
+			select {
+			case ch <- &ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
+// NewRootHashClient creates a new gRPC roothash client service.
+func NewRootHashClient(c *grpc.ClientConn) ClientBackend {
+	return &roothashClient{c}
+}
@@ -33,6 +33,9 @@ type BlockHistory interface {
 	// GetBlock returns the block at a specific round.
 	GetBlock(ctx context.Context, round uint64) (*block.Block, error)
 
+	// GetAnnotatedBlock returns the annotated block at a specific round.
+	GetAnnotatedBlock(ctx context.Context, round uint64) (*AnnotatedBlock, error)
+
 	// GetLatestBlock returns the block at latest round.
 	GetLatestBlock(ctx context.Context) (*block.Block, error)
 }
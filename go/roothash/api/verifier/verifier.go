@@ -0,0 +1,145 @@
+// Package verifier implements roothash block header verification helpers.
+//
+// The helpers are intended for consumers that follow runtime blocks without
+// running the consensus roothash application (e.g., bridges and light
+// clients). They only perform checks that can be done based on the headers
+// themselves and a known storage committee -- establishing trust in the
+// initial header is the responsibility of the caller.
+package verifier
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+var (
+	// ErrInvalidHeaderType is the error returned when a header has an invalid
+	// header type.
+	ErrInvalidHeaderType = errors.New("roothash/verifier: invalid header type")
+
+	// ErrNamespaceMismatch is the error returned when a header's namespace
+	// does not match the namespace of its parent.
+	ErrNamespaceMismatch = errors.New("roothash/verifier: namespace mismatch")
+
+	// ErrNonSequentialRound is the error returned when a header's round does
+	// not directly follow the round of its parent.
+	ErrNonSequentialRound = errors.New("roothash/verifier: non-sequential round")
+
+	// ErrPreviousHashMismatch is the error returned when a header's previous
+	// hash does not match the hash of its parent.
+	ErrPreviousHashMismatch = errors.New("roothash/verifier: previous hash mismatch")
+
+	// ErrInsufficientStorageReceipts is the error returned when a header does
+	// not contain enough storage receipts.
+	ErrInsufficientStorageReceipts = errors.New("roothash/verifier: insufficient storage receipts")
+
+	// ErrUnexpectedStorageReceipt is the error returned when a header contains
+	// a storage receipt that was not signed by a storage committee member.
+	ErrUnexpectedStorageReceipt = errors.New("roothash/verifier: storage receipt not signed by committee member")
+)
+
+// VerifyHeader verifies that the header is a valid direct successor of the
+// given parent header.
+//
+// The header must be of a valid type, must be in the same namespace as its
+// parent, must have a round that directly follows the round of its parent and
+// must reference the hash of its parent.
+func VerifyHeader(parent, header *block.Header) error {
+	switch header.HeaderType {
+	case block.Normal, block.RoundFailed, block.EpochTransition, block.Suspended:
+	default:
+		return fmt.Errorf("%w: %d", ErrInvalidHeaderType, header.HeaderType)
+	}
+
+	if !header.Namespace.Equal(&parent.Namespace) {
+		return fmt.Errorf("%w: expected %s, got %s", ErrNamespaceMismatch, parent.Namespace, header.Namespace)
+	}
+
+	if header.Round != parent.Round+1 {
+		return fmt.Errorf("%w: expected %d, got %d", ErrNonSequentialRound, parent.Round+1, header.Round)
+	}
+
+	parentHash := parent.EncodedHash()
+	if !header.PreviousHash.Equal(&parentHash) {
+		return fmt.Errorf("%w: expected %s, got %s", ErrPreviousHashMismatch, parentHash, header.PreviousHash)
+	}
+
+	return nil
+}
+
+// VerifyHeaderChain verifies that the headers form a valid chain starting at
+// the given trusted header.
+//
+// The trusted header itself is not verified and must not be included in the
+// list of headers.
+func VerifyHeaderChain(trusted *block.Header, headers []*block.Header) error {
+	parent := trusted
+	for _, header := range headers {
+		if err := VerifyHeader(parent, header); err != nil {
+			return fmt.Errorf("round %d: %w", header.Round, err)
+		}
+		parent = header
+	}
+	return nil
+}
+
+// StorageCommittee is a known storage committee that storage receipts
+// embedded in headers are validated against.
+type StorageCommittee struct {
+	// Members are the public keys of the storage committee members.
+	Members map[signature.PublicKey]bool
+
+	// MinWriteReplication is the minimum number of distinct storage
+	// committee members that must have signed the storage receipt.
+	MinWriteReplication uint64
+}
+
+// VerifyStorageReceipts verifies that the storage receipts embedded in the
+// header are valid and signed by enough storage committee members.
+//
+// Only normal headers are required to contain storage receipts, other header
+// types are accepted as long as all of the embedded receipts (if any) are
+// valid.
+func (sc *StorageCommittee) VerifyStorageReceipts(header *block.Header) error {
+	signers := make(map[signature.PublicKey]bool)
+	for _, sig := range header.StorageSignatures {
+		if !sc.Members[sig.PublicKey] {
+			return fmt.Errorf("%w: %s", ErrUnexpectedStorageReceipt, sig.PublicKey)
+		}
+		signers[sig.PublicKey] = true
+	}
+
+	if header.HeaderType == block.Normal && uint64(len(signers)) < sc.MinWriteReplication {
+		return fmt.Errorf("%w: expected at least %d, got %d",
+			ErrInsufficientStorageReceipts,
+			sc.MinWriteReplication,
+			len(signers),
+		)
+	}
+
+	if len(header.StorageSignatures) == 0 {
+		return nil
+	}
+	return header.VerifyStorageReceiptSignatures()
+}
+
+// NewStorageCommittee creates a new storage committee from the given
+// scheduler committee and the runtime's minimum write replication factor.
+func NewStorageCommittee(committee *scheduler.Committee, minWriteReplication uint64) (*StorageCommittee, error) {
+	if committee.Kind != scheduler.KindStorage {
+		return nil, fmt.Errorf("roothash/verifier: not a storage committee: %s", committee.Kind)
+	}
+
+	sc := &StorageCommittee{
+		Members:             make(map[signature.PublicKey]bool),
+		MinWriteReplication: minWriteReplication,
+	}
+	for _, member := range committee.Members {
+		sc.Members[member.PublicKey] = true
+	}
+	return sc, nil
+}
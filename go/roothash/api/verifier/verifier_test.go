@@ -0,0 +1,141 @@
+package verifier
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+)
+
+func newTestNormalBlock(t *testing.T, parent *block.Block, signers []signature.Signer) *block.Block {
+	blk := block.NewEmptyBlock(parent, parent.Header.Timestamp+1, block.Normal)
+	blk.Header.IORoot = hash.NewFromBytes([]byte("io root"))
+	blk.Header.StateRoot = hash.NewFromBytes([]byte("state root"))
+
+	receiptBody := storage.ReceiptBody{
+		Version:   1,
+		Namespace: blk.Header.Namespace,
+		Round:     blk.Header.Round,
+		Roots:     blk.Header.RootsForStorageReceipt(),
+	}
+	for _, signer := range signers {
+		sig, err := signature.Sign(signer, storage.ReceiptSignatureContext, cbor.Marshal(receiptBody))
+		require.NoError(t, err, "Sign")
+		blk.Header.StorageSignatures = append(blk.Header.StorageSignatures, *sig)
+	}
+	return blk
+}
+
+func TestVerifyHeaderChain(t *testing.T) {
+	require := require.New(t)
+
+	var ns common.Namespace
+	_ = ns.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000")
+
+	signer := memorySigner.NewTestSigner("roothash/verifier: storage node")
+
+	genesis := block.NewGenesisBlock(ns, 0)
+	blk1 := newTestNormalBlock(t, genesis, []signature.Signer{signer})
+	blk2 := block.NewEmptyBlock(blk1, 2, block.RoundFailed)
+	blk3 := newTestNormalBlock(t, blk2, []signature.Signer{signer})
+
+	headers := []*block.Header{&blk1.Header, &blk2.Header, &blk3.Header}
+	err := VerifyHeaderChain(&genesis.Header, headers)
+	require.NoError(err, "VerifyHeaderChain")
+	err = VerifyHeaderChain(&genesis.Header, nil)
+	require.NoError(err, "VerifyHeaderChain (empty chain)")
+
+	// Missing header.
+	err = VerifyHeaderChain(&genesis.Header, []*block.Header{&blk1.Header, &blk3.Header})
+	require.True(errors.Is(err, ErrNonSequentialRound), "VerifyHeaderChain should fail with missing header")
+
+	// Modified parent.
+	modified := blk1.Header
+	modified.StateRoot = hash.NewFromBytes([]byte("other state root"))
+	err = VerifyHeader(&modified, &blk2.Header)
+	require.True(errors.Is(err, ErrPreviousHashMismatch), "VerifyHeader should fail with modified parent")
+
+	// Different namespace.
+	modified = blk2.Header
+	_ = modified.Namespace.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000001")
+	err = VerifyHeader(&blk1.Header, &modified)
+	require.True(errors.Is(err, ErrNamespaceMismatch), "VerifyHeader should fail with different namespace")
+
+	// Invalid header type.
+	modified = blk2.Header
+	modified.HeaderType = block.Invalid
+	err = VerifyHeader(&blk1.Header, &modified)
+	require.True(errors.Is(err, ErrInvalidHeaderType), "VerifyHeader should fail with invalid header type")
+}
+
+func TestVerifyStorageReceipts(t *testing.T) {
+	require := require.New(t)
+
+	var ns common.Namespace
+	_ = ns.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000")
+
+	var signers []signature.Signer
+	committee := &scheduler.Committee{
+		Kind:      scheduler.KindStorage,
+		RuntimeID: ns,
+	}
+	for _, name := range []string{"storage node 1", "storage node 2"} {
+		signer := memorySigner.NewTestSigner("roothash/verifier: " + name)
+		signers = append(signers, signer)
+		committee.Members = append(committee.Members, &scheduler.CommitteeNode{
+			Role:      scheduler.RoleWorker,
+			PublicKey: signer.Public(),
+		})
+	}
+	outsider := memorySigner.NewTestSigner("roothash/verifier: other node")
+
+	sc, err := NewStorageCommittee(committee, 2)
+	require.NoError(err, "NewStorageCommittee")
+
+	genesis := block.NewGenesisBlock(ns, 0)
+
+	// Enough valid receipts.
+	blk := newTestNormalBlock(t, genesis, signers)
+	err = sc.VerifyStorageReceipts(&blk.Header)
+	require.NoError(err, "VerifyStorageReceipts")
+
+	// Not enough receipts.
+	blk = newTestNormalBlock(t, genesis, signers[:1])
+	err = sc.VerifyStorageReceipts(&blk.Header)
+	require.True(errors.Is(err, ErrInsufficientStorageReceipts), "VerifyStorageReceipts should fail with too few receipts")
+
+	// Duplicate receipts count once.
+	blk = newTestNormalBlock(t, genesis, []signature.Signer{signers[0], signers[0]})
+	err = sc.VerifyStorageReceipts(&blk.Header)
+	require.True(errors.Is(err, ErrInsufficientStorageReceipts), "VerifyStorageReceipts should fail with duplicate receipts")
+
+	// Receipt from a non-member.
+	blk = newTestNormalBlock(t, genesis, []signature.Signer{signers[0], outsider})
+	err = sc.VerifyStorageReceipts(&blk.Header)
+	require.True(errors.Is(err, ErrUnexpectedStorageReceipt), "VerifyStorageReceipts should fail with non-member receipt")
+
+	// Receipt for different roots.
+	blk = newTestNormalBlock(t, genesis, signers)
+	blk.Header.IORoot = hash.NewFromBytes([]byte("other io root"))
+	err = sc.VerifyStorageReceipts(&blk.Header)
+	require.Error(err, "VerifyStorageReceipts should fail with receipts for different roots")
+
+	// Empty blocks do not require receipts.
+	blk = block.NewEmptyBlock(genesis, 1, block.EpochTransition)
+	err = sc.VerifyStorageReceipts(&blk.Header)
+	require.NoError(err, "VerifyStorageReceipts (empty block)")
+
+	// Only storage committees are accepted.
+	committee.Kind = scheduler.KindComputeExecutor
+	_, err = NewStorageCommittee(committee, 2)
+	require.Error(err, "NewStorageCommittee should fail for non-storage committees")
+}
@@ -1,6 +1,7 @@
 package roothash
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -43,6 +44,10 @@ func (w *metricsWrapper) WatchBlocks(id common.Namespace) (<-chan *api.Annotated
 	return w.Backend.WatchBlocks(id)
 }
 
+func (w *metricsWrapper) WatchBlocksFrom(ctx context.Context, id common.Namespace, round uint64) (<-chan *api.AnnotatedBlock, *pubsub.Subscription, error) {
+	return w.Backend.WatchBlocksFrom(ctx, id, round)
+}
+
 func (w *metricsWrapper) worker() {
 	backend, ok := w.Backend.(api.MetricsMonitorable)
 	if !ok {
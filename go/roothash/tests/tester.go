@@ -112,6 +112,53 @@ func RootHashImplementationTests(t *testing.T, backend api.Backend, consensus co
 	})
 }
 
+// RootHashClientImplementationTests exercises the basic functionality of a roothash client
+// backend against the given runtime.
+func RootHashClientImplementationTests(
+	t *testing.T,
+	client api.ClientBackend,
+	backend api.Backend,
+	consensus consensusAPI.Backend,
+	runtimeID common.Namespace,
+) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	req := &api.RuntimeRequest{RuntimeID: runtimeID, Height: consensusAPI.HeightLatest}
+	genBlk, err := client.GetGenesisBlock(ctx, req)
+	require.NoError(err, "GetGenesisBlock")
+	expectedGenBlk, err := backend.GetGenesisBlock(ctx, runtimeID, consensusAPI.HeightLatest)
+	require.NoError(err, "GetGenesisBlock")
+	require.EqualValues(expectedGenBlk, genBlk, "GetGenesisBlock should return the same block")
+
+	blk, err := client.GetLatestBlock(ctx, req)
+	require.NoError(err, "GetLatestBlock")
+	require.EqualValues(runtimeID, blk.Header.Namespace, "latest block should be for the requested runtime")
+
+	rrReq := &api.RoundResultsRequest{RuntimeID: runtimeID, Round: blk.Header.Round}
+	results, err := client.GetRoundResults(ctx, rrReq)
+	expectedResults, expectedErr := backend.GetRoundResults(ctx, runtimeID, blk.Header.Round)
+	if expectedErr != nil {
+		require.Error(err, "GetRoundResults should fail when the backend fails")
+	} else {
+		require.NoError(err, "GetRoundResults")
+		require.EqualValues(expectedResults, results, "GetRoundResults should return the same results")
+	}
+
+	cs, err := consensus.GetStatus(ctx)
+	require.NoError(err, "GetStatus")
+	evs, err := client.GetEvents(ctx, cs.LatestHeight)
+	require.NoError(err, "GetEvents")
+	expectedEvs, err := backend.GetEvents(ctx, cs.LatestHeight)
+	require.NoError(err, "GetEvents")
+	require.Len(evs, len(expectedEvs), "GetEvents should return the same events")
+
+	ch, sub, err := client.WatchEvents(ctx, runtimeID)
+	require.NoError(err, "WatchEvents")
+	require.NotNil(ch, "WatchEvents should return a channel")
+	sub.Close()
+}
+
 func testGenesisBlock(t *testing.T, backend api.Backend, state *runtimeState) {
 	require := require.New(t)
 
@@ -412,6 +459,14 @@ func (s *runtimeState) testSuccessfulRound(t *testing.T, backend api.Backend, co
 				}
 			}
 
+			// The round should be reported as successful.
+			results, err := backend.GetRoundResults(ctx, header.Namespace, header.Round)
+			require.NoError(err, "GetRoundResults")
+			require.EqualValues(header.Round, results.Round, "round results should be for the right round")
+			require.False(results.Failed, "round should not have failed")
+			require.False(results.Empty, "round should not be empty")
+			require.Empty(results.BadComputeNodes, "there should be no bad compute nodes")
+
 			// Nothing more to do after the block was received.
 			return
 		case <-time.After(recvTimeout):
@@ -486,6 +541,13 @@ WaitForRoundTimeoutBlocks:
 			require.EqualValues(child.Header.Round+1, header.Round, "block round")
 			require.EqualValues(block.RoundFailed, header.HeaderType, "block header type must be RoundFailed")
 
+			// The round should be reported as failed.
+			results, err := backend.GetRoundResults(ctx, header.Namespace, header.Round)
+			require.NoError(err, "GetRoundResults")
+			require.EqualValues(header.Round, results.Round, "round results should be for the right round")
+			require.True(results.Failed, "round should have failed")
+			require.True(results.Empty, "failed round should be empty")
+
 			// Nothing more to do after the block was received.
 			return
 		case <-time.After(recvTimeout):
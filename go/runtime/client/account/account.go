@@ -0,0 +1,151 @@
+// Package account contains helpers for funding runtime staking accounts and for following the
+// execution of staking runtime messages.
+//
+// Each runtime has a staking account (see staking.NewRuntimeAddress) which is not controlled by
+// any signer. Tokens are moved into the runtime account by regular consensus-layer transfers
+// (deposits) and are moved out by the runtime emitting staking runtime messages (withdrawals).
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// ErrMessageNotFound is the error returned when the runtime did not emit the requested message.
+var ErrMessageNotFound = fmt.Errorf("account: message not found")
+
+// Balance returns the general and escrow balance of the given runtime's staking account at the
+// given consensus height.
+func Balance(ctx context.Context, backend staking.Backend, runtimeID common.Namespace, height int64) (*staking.Account, error) {
+	acct, err := backend.Account(ctx, &staking.OwnerQuery{
+		Height: height,
+		Owner:  staking.NewRuntimeAddress(runtimeID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("account: failed to query runtime account: %w", err)
+	}
+	return acct, nil
+}
+
+// NewDepositTx creates a new transaction which transfers the given amount from the signer's
+// account into the given runtime's staking account.
+func NewDepositTx(nonce uint64, fee *transaction.Fee, runtimeID common.Namespace, amount *quantity.Quantity) *transaction.Transaction {
+	return staking.NewTransferTx(nonce, fee, &staking.Transfer{
+		To:     staking.NewRuntimeAddress(runtimeID),
+		Amount: *amount.Clone(),
+	})
+}
+
+// Deposit signs and submits a transaction which transfers the given amount from the signer's
+// account into the given runtime's staking account.
+//
+// The nonce and fee are filled in automatically and the method returns once the transaction has
+// been included in a consensus block.
+func Deposit(
+	ctx context.Context,
+	backend consensus.Backend,
+	signer signature.Signer,
+	runtimeID common.Namespace,
+	amount *quantity.Quantity,
+) error {
+	tx := NewDepositTx(0, nil, runtimeID, amount)
+	if err := consensus.SignAndSubmitTx(ctx, backend, signer, tx); err != nil {
+		return fmt.Errorf("account: failed to submit deposit: %w", err)
+	}
+	return nil
+}
+
+// NewWithdrawMessage creates a new staking runtime message which transfers the given amount from
+// the runtime's staking account to the given address.
+//
+// The message needs to be emitted by the runtime in order to be executed, after which its
+// result can be obtained via WaitMessageResult.
+func NewWithdrawMessage(to staking.Address, amount *quantity.Quantity) *block.Message {
+	return &block.Message{
+		Staking: &block.StakingMessage{
+			Transfer: &staking.Transfer{
+				To:     to,
+				Amount: *amount.Clone(),
+			},
+		},
+	}
+}
+
+// WaitMessageResult waits for the result of the message with the given index emitted by the
+// runtime in the given round.
+//
+// Message results are reported in the header of the next runtime block, so the method blocks
+// until that block has been finalized. In case the message has been executed but has failed,
+// the returned error is the error reported by the consensus layer.
+func WaitMessageResult(
+	ctx context.Context,
+	backend roothash.Backend,
+	runtimeID common.Namespace,
+	round uint64,
+	index int,
+) error {
+	blkCh, sub, err := backend.WatchBlocksFrom(ctx, runtimeID, round)
+	if err != nil {
+		return fmt.Errorf("account: failed to watch runtime blocks: %w", err)
+	}
+	defer sub.Close()
+
+	var (
+		seenRound   bool
+		numMessages int
+	)
+	for {
+		var (
+			blk *roothash.AnnotatedBlock
+			ok  bool
+		)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case blk, ok = <-blkCh:
+			if !ok {
+				return fmt.Errorf("account: runtime block watch terminated")
+			}
+		}
+
+		hdr := blk.Block.Header
+		switch {
+		case hdr.Round < round:
+			continue
+		case hdr.Round == round:
+			seenRound = true
+			numMessages = len(hdr.Messages)
+			if index < 0 || index >= numMessages {
+				return ErrMessageNotFound
+			}
+			continue
+		case !seenRound || hdr.Round > round+1 || len(hdr.MessageResults) != numMessages:
+			// Either we have missed the block at the given round or the results do not
+			// correspond to the emitted messages.
+			return fmt.Errorf("account: unexpected message results in round %d", hdr.Round)
+		}
+
+		return resultError(hdr.MessageResults[index])
+	}
+}
+
+// resultError converts a message result into an error, returning nil on success.
+func resultError(result *block.MessageResult) error {
+	if result.IsSuccess() {
+		return nil
+	}
+	if err := errors.FromCode(result.Module, result.Code); err != nil {
+		return err
+	}
+	return fmt.Errorf("account: message failed (module: %s code: %d)", result.Module, result.Code)
+}
@@ -0,0 +1,148 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+var testRuntimeID = common.NewTestNamespaceFromSeed([]byte("runtime account test"), 0)
+
+type testStakingBackend struct {
+	staking.Backend
+
+	accounts map[staking.Address]*staking.Account
+}
+
+func (b *testStakingBackend) Account(ctx context.Context, query *staking.OwnerQuery) (*staking.Account, error) {
+	if acct, ok := b.accounts[query.Owner]; ok {
+		return acct, nil
+	}
+	return &staking.Account{}, nil
+}
+
+type testRootHashBackend struct {
+	roothash.Backend
+
+	blocks []*block.Block
+}
+
+func (b *testRootHashBackend) WatchBlocksFrom(ctx context.Context, runtimeID common.Namespace, round uint64) (<-chan *roothash.AnnotatedBlock, *pubsub.Subscription, error) {
+	notifier := pubsub.NewBroker(false)
+	sub := notifier.Subscribe()
+	ch := make(chan *roothash.AnnotatedBlock)
+	sub.Unwrap(ch)
+
+	for _, blk := range b.blocks {
+		if blk.Header.Round < round {
+			continue
+		}
+		notifier.Broadcast(&roothash.AnnotatedBlock{Block: blk})
+	}
+	return ch, sub, nil
+}
+
+func TestBalance(t *testing.T) {
+	require := require.New(t)
+
+	backend := &testStakingBackend{
+		accounts: map[staking.Address]*staking.Account{
+			staking.NewRuntimeAddress(testRuntimeID): {
+				General: staking.GeneralAccount{Balance: *quantity.NewFromUint64(100)},
+			},
+		},
+	}
+
+	acct, err := Balance(context.Background(), backend, testRuntimeID, consensus.HeightLatest)
+	require.NoError(err, "Balance")
+	require.EqualValues(*quantity.NewFromUint64(100), acct.General.Balance)
+
+	otherID := common.NewTestNamespaceFromSeed([]byte("runtime account test"), 1)
+	acct, err = Balance(context.Background(), backend, otherID, consensus.HeightLatest)
+	require.NoError(err, "Balance")
+	require.True(acct.General.Balance.IsZero(), "other runtime accounts should be separate")
+}
+
+func TestNewDepositTx(t *testing.T) {
+	require := require.New(t)
+
+	tx := NewDepositTx(1, nil, testRuntimeID, quantity.NewFromUint64(10))
+	require.EqualValues(staking.MethodTransfer, tx.Method)
+
+	var xfer staking.Transfer
+	err := cbor.Unmarshal(tx.Body, &xfer)
+	require.NoError(err, "transfer body should decode")
+	require.Equal(staking.NewRuntimeAddress(testRuntimeID), xfer.To, "deposit should go to the runtime account")
+	require.EqualValues(*quantity.NewFromUint64(10), xfer.Amount)
+}
+
+func TestNewWithdrawMessage(t *testing.T) {
+	require := require.New(t)
+
+	to := staking.NewRuntimeAddress(common.NewTestNamespaceFromSeed([]byte("runtime account test"), 1))
+	msg := NewWithdrawMessage(to, quantity.NewFromUint64(10))
+	require.NoError(msg.ValidateBasic(), "withdraw message should be valid")
+	require.NotNil(msg.Staking.Transfer)
+	require.Equal(to, msg.Staking.Transfer.To)
+	require.EqualValues(*quantity.NewFromUint64(10), msg.Staking.Transfer.Amount)
+}
+
+func TestWaitMessageResult(t *testing.T) {
+	require := require.New(t)
+
+	newBlock := func(round uint64, messages []*block.Message, results []*block.MessageResult) *block.Block {
+		var blk block.Block
+		blk.Header.Round = round
+		blk.Header.Messages = messages
+		blk.Header.MessageResults = results
+		return &blk
+	}
+
+	msg := NewWithdrawMessage(staking.Address{}, quantity.NewFromUint64(10))
+	backend := &testRootHashBackend{
+		blocks: []*block.Block{
+			newBlock(1, nil, nil),
+			newBlock(2, []*block.Message{msg, msg}, nil),
+			newBlock(3, nil, []*block.MessageResult{
+				{},
+				// Code of staking.ErrInsufficientBalance.
+				{Module: staking.ModuleName, Code: 3},
+			}),
+		},
+	}
+
+	ctx := context.Background()
+	err := WaitMessageResult(ctx, backend, testRuntimeID, 2, 0)
+	require.NoError(err, "WaitMessageResult should succeed for successful messages")
+
+	err = WaitMessageResult(ctx, backend, testRuntimeID, 2, 1)
+	require.True(errors.Is(err, staking.ErrInsufficientBalance), "WaitMessageResult should return the message error")
+
+	err = WaitMessageResult(ctx, backend, testRuntimeID, 2, 2)
+	require.True(errors.Is(err, ErrMessageNotFound), "WaitMessageResult should fail for invalid indices")
+
+	err = WaitMessageResult(ctx, backend, testRuntimeID, 1, 0)
+	require.True(errors.Is(err, ErrMessageNotFound), "WaitMessageResult should fail for rounds without messages")
+
+	// Inconsistent results should be detected.
+	backend.blocks[2].Header.MessageResults = backend.blocks[2].Header.MessageResults[:1]
+	err = WaitMessageResult(ctx, backend, testRuntimeID, 2, 0)
+	require.Error(err, "WaitMessageResult should fail with inconsistent results")
+
+	// Waiting should be aborted when the context is canceled.
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	err = WaitMessageResult(cancelCtx, backend, testRuntimeID, 10, 0)
+	require.True(errors.Is(err, context.Canceled), "WaitMessageResult should fail with canceled context")
+}
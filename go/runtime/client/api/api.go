@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"math"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
@@ -11,6 +12,9 @@ import (
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	enclaverpc "github.com/oasisprotocol/oasis-core/go/runtime/enclaverpc/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 )
 
 const (
@@ -28,6 +32,12 @@ var (
 	ErrInternal = errors.New(ModuleName, 2, "client: internal error")
 	// ErrTransactionExpired is an error returned when transaction expired.
 	ErrTransactionExpired = errors.New(ModuleName, 3, "client: transaction expired")
+	// ErrNotHosted is an error returned when an operation requires the runtime to be hosted by
+	// the local node and it is not.
+	ErrNotHosted = errors.New(ModuleName, 4, "client: runtime not hosted locally")
+	// ErrTooManyInFlightTxs is an error returned when the maximum number of transactions waiting
+	// for inclusion has been reached for the given runtime.
+	ErrTooManyInFlightTxs = errors.New(ModuleName, 5, "client: too many transactions in flight")
 )
 
 // RuntimeClient is the runtime client interface.
@@ -37,6 +47,27 @@ type RuntimeClient interface {
 	// SubmitTx submits a transaction to the runtime transaction scheduler.
 	SubmitTx(ctx context.Context, request *SubmitTxRequest) ([]byte, error)
 
+	// SubmitTxMeta submits a transaction to the runtime transaction scheduler and waits for
+	// transaction execution results, including the round and the batch order of the
+	// transaction.
+	SubmitTxMeta(ctx context.Context, request *SubmitTxRequest) (*SubmitTxMetaResponse, error)
+
+	// SubmitTxNoWait submits a transaction to the runtime transaction scheduler but does not
+	// wait for transaction execution.
+	//
+	// The method returns as soon as the transaction has been published to the transaction
+	// scheduler of the current committee. The client keeps (re)submitting the transaction
+	// until it is included in a block or expires, and its status can be queried using
+	// GetTxStatus.
+	SubmitTxNoWait(ctx context.Context, request *SubmitTxRequest) error
+
+	// CheckTx asks the locally hosted runtime to check the given transaction without submitting
+	// it, based on the latest runtime block.
+	//
+	// In case the runtime rejects the transaction, the reason is returned in the Error field of
+	// the result. In case the runtime is not hosted by the local node, ErrNotHosted is returned.
+	CheckTx(ctx context.Context, request *CheckTxRequest) (*CheckTxResult, error)
+
 	// GetGenesisBlock returns the genesis block.
 	GetGenesisBlock(ctx context.Context, runtimeID common.Namespace) (*block.Block, error)
 
@@ -56,15 +87,34 @@ type RuntimeClient interface {
 	// GetTxs fetches all runtime transactions in a given block.
 	GetTxs(ctx context.Context, request *GetTxsRequest) ([][]byte, error)
 
+	// GetTxProof generates a Merkle proof that the given runtime transactions
+	// and their outputs are included in the IO root of a given block.
+	GetTxProof(ctx context.Context, request *GetTxProofRequest) (*TxProof, error)
+
 	// QueryTx queries the indexer for a specific runtime transaction.
 	QueryTx(ctx context.Context, request *QueryTxRequest) (*TxResult, error)
 
 	// QueryTxs queries the indexer for specific runtime transactions.
 	QueryTxs(ctx context.Context, request *QueryTxsRequest) ([]*TxResult, error)
 
+	// QueryTxsPaginated queries the indexer for specific runtime transactions and also returns a
+	// continuation cursor that can be used to fetch the next results.
+	QueryTxsPaginated(ctx context.Context, request *QueryTxsRequest) (*QueryTxsResponse, error)
+
+	// GetTxStatus returns the status of a runtime transaction identified by its hash.
+	//
+	// Only transactions submitted via this client can be reported as pending, scheduled or
+	// failed. Included transactions are reported together with their round, index and output.
+	GetTxStatus(ctx context.Context, request *GetTxStatusRequest) (*TxStatus, error)
+
 	// WatchBlocks subscribes to blocks for a specific runtimes.
 	WatchBlocks(ctx context.Context, runtimeID common.Namespace) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error)
 
+	// WatchBlocksFrom subscribes to blocks for a specific runtime starting at the given round.
+	//
+	// Historical blocks are delivered from the runtime's block history before any new blocks.
+	WatchBlocksFrom(ctx context.Context, request *WatchBlocksFromRequest) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error)
+
 	// WaitBlockIndexed waits for a runtime block to be indexed by the indexer.
 	WaitBlockIndexed(ctx context.Context, request *WaitBlockIndexedRequest) error
 
@@ -78,6 +128,47 @@ type SubmitTxRequest struct {
 	Data      []byte           `json:"data"`
 }
 
+// SubmitTxMetaResponse is the SubmitTxMeta response.
+type SubmitTxMetaResponse struct {
+	// Output is the transaction output.
+	Output []byte `json:"output,omitempty"`
+	// Round is the roothash round in which the transaction was executed.
+	Round uint64 `json:"round,omitempty"`
+	// BatchOrder is the order of the transaction in the execution batch.
+	BatchOrder uint32 `json:"batch_order,omitempty"`
+}
+
+// CheckTxRequest is a CheckTx request.
+type CheckTxRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	Data      []byte           `json:"data"`
+}
+
+// CheckTxError is the error returned by the runtime when it rejects a transaction.
+type CheckTxError struct {
+	// Message is the error message returned by the runtime.
+	Message string `json:"message"`
+}
+
+// CheckTxResult is the CheckTx result.
+type CheckTxResult struct {
+	// Round is the round of the runtime block the check was based on.
+	Round uint64 `json:"round"`
+
+	// Error is the error returned by the runtime in case it rejected the transaction. In case
+	// the transaction is valid this field is nil.
+	Error *CheckTxError `json:"error,omitempty"`
+
+	// PredictedReadWriteSet is the read/write set predicted by the runtime in case the
+	// transaction is valid.
+	PredictedReadWriteSet *transaction.ReadWriteSet `json:"predicted_rw_set,omitempty"`
+}
+
+// IsValid returns true iff the runtime accepted the transaction.
+func (r *CheckTxResult) IsValid() bool {
+	return r.Error == nil
+}
+
 // GetBlockRequest is a GetBlock request.
 type GetBlockRequest struct {
 	RuntimeID common.Namespace `json:"runtime_id"`
@@ -96,6 +187,9 @@ type TxResult struct {
 	Index  uint32       `json:"index"`
 	Input  []byte       `json:"input"`
 	Output []byte       `json:"output"`
+	// GasUsed is the amount of gas used by the transaction as reported by
+	// the runtime. It is zero in case the runtime does not report gas usage.
+	GasUsed uint64 `json:"gas_used,omitempty"`
 }
 
 // GetTxRequest is a GetTx request.
@@ -119,11 +213,70 @@ type GetTxsRequest struct {
 	IORoot    hash.Hash        `json:"io_root"`
 }
 
+// GetTxProofRequest is a GetTxProof request.
+type GetTxProofRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	Round     uint64           `json:"round"`
+	TxHashes  []hash.Hash      `json:"tx_hashes"`
+}
+
+// TxProof is a Merkle proof of inclusion of runtime transactions in a block.
+type TxProof struct {
+	// Block is the block the proof was generated for.
+	//
+	// The block is provided for convenience and proof verification MUST use an
+	// independently obtained (e.g., from the roothash header) IO root.
+	Block *block.Block `json:"block"`
+	// Proof is the Merkle proof of the transaction artifacts.
+	Proof syncer.Proof `json:"proof"`
+}
+
+// Verify verifies the proof against the given (trusted) IO root and returns
+// the artifacts of the proven transactions.
+func (p *TxProof) Verify(ctx context.Context, ioRoot node.Root, txHashes []hash.Hash) (map[hash.Hash]*transaction.Transaction, error) {
+	return transaction.VerifyProof(ctx, ioRoot, txHashes, &p.Proof)
+}
+
 // QueryTxRequest is a QueryTx request.
 type QueryTxRequest struct {
 	RuntimeID common.Namespace `json:"runtime_id"`
 	Key       []byte           `json:"key"`
 	Value     []byte           `json:"value"`
+
+	// Conditions are optional additional query conditions.
+	//
+	// They are combined with the key/value condition (if any) using an
+	// AND query which means that all of the conditions must be satisfied
+	// for a transaction to match.
+	Conditions []QueryCondition `json:"conditions,omitempty"`
+}
+
+// QueryMatch is the kind of matching used for a query condition.
+type QueryMatch uint8
+
+const (
+	// QueryMatchExact matches tag values that are exactly equal to the
+	// given value.
+	QueryMatchExact QueryMatch = 0
+	// QueryMatchPrefix matches tag values that start with the given value.
+	QueryMatchPrefix QueryMatch = 1
+	// QueryMatchFullText matches tag values that contain all of the words
+	// in the given value.
+	QueryMatchFullText QueryMatch = 2
+)
+
+// String returns a string representation of the query match kind.
+func (m QueryMatch) String() string {
+	switch m {
+	case QueryMatchExact:
+		return "exact"
+	case QueryMatchPrefix:
+		return "prefix"
+	case QueryMatchFullText:
+		return "full-text"
+	default:
+		return fmt.Sprintf("[unknown match kind: %d]", m)
+	}
 }
 
 // QueryCondition is a query condition.
@@ -134,6 +287,10 @@ type QueryCondition struct {
 	// have. They are combined using an OR query which means that any
 	// of the values will match.
 	Values [][]byte `json:"values"`
+	// Match is the kind of matching used for the values.
+	//
+	// Not all tag indexer backends support all kinds of matching.
+	Match QueryMatch `json:"match,omitempty"`
 }
 
 // Query is a complex query against the index.
@@ -155,6 +312,11 @@ type Query struct {
 	//
 	// A zero value means that the `maxQueryLimit` limit is used.
 	Limit uint64 `json:"limit"`
+
+	// Cursor is an optional continuation cursor returned by a previous
+	// query. If set, only results following the ones returned by the
+	// previous query are returned.
+	Cursor []byte `json:"cursor,omitempty"`
 }
 
 // QueryTxsRequest is a QueryTxs request.
@@ -163,8 +325,85 @@ type QueryTxsRequest struct {
 	Query     Query            `json:"query"`
 }
 
+// QueryTxsResponse is a QueryTxsPaginated response.
+type QueryTxsResponse struct {
+	// Results are the matching transactions, ordered by round and index.
+	Results []*TxResult `json:"results"`
+
+	// Cursor is the continuation cursor that can be used to fetch the next
+	// results. It is nil in case there are no more results.
+	Cursor []byte `json:"cursor,omitempty"`
+}
+
 // WaitBlockIndexedRequest is a WaitBlockIndexed request.
 type WaitBlockIndexedRequest struct {
 	RuntimeID common.Namespace `json:"runtime_id"`
 	Round     uint64           `json:"round"`
 }
+
+// WatchBlocksFromRequest is a WatchBlocksFrom request.
+type WatchBlocksFromRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	Round     uint64           `json:"round"`
+}
+
+// GetTxStatusRequest is a GetTxStatus request.
+type GetTxStatusRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	TxHash    hash.Hash        `json:"tx_hash"`
+}
+
+// TxStatusKind is the kind of a transaction status.
+type TxStatusKind uint8
+
+const (
+	// TxStatusUnknown is the status of a transaction that is not known to the client.
+	TxStatusUnknown TxStatusKind = 0
+	// TxStatusPending is the status of a transaction that has been submitted but has not yet
+	// been published to the transaction scheduler of the current committee (e.g., because the
+	// client is waiting for the committee to be elected after an epoch transition).
+	TxStatusPending TxStatusKind = 1
+	// TxStatusIncluded is the status of a transaction that has been included in a block.
+	TxStatusIncluded TxStatusKind = 2
+	// TxStatusFailed is the status of a transaction that could not be included in a block.
+	TxStatusFailed TxStatusKind = 3
+	// TxStatusScheduled is the status of a transaction that has been published to the
+	// transaction scheduler of the current committee but has not yet been included in a block.
+	TxStatusScheduled TxStatusKind = 4
+)
+
+// String returns a string representation of a transaction status kind.
+func (k TxStatusKind) String() string {
+	switch k {
+	case TxStatusUnknown:
+		return "unknown"
+	case TxStatusPending:
+		return "pending"
+	case TxStatusScheduled:
+		return "scheduled"
+	case TxStatusIncluded:
+		return "included"
+	case TxStatusFailed:
+		return "failed"
+	default:
+		return fmt.Sprintf("[unknown tx status: %d]", uint8(k))
+	}
+}
+
+// TxStatus is the status of a runtime transaction.
+type TxStatus struct {
+	// Status is the kind of the transaction status.
+	Status TxStatusKind `json:"status"`
+	// Round is the round of the block that includes the transaction. It is only set in case
+	// the transaction has been included.
+	Round uint64 `json:"round,omitempty"`
+	// Index is the index of the transaction within the block. It is only set in case the
+	// transaction has been included.
+	Index uint32 `json:"index,omitempty"`
+	// Output is the output of the transaction. It is only set in case the transaction has been
+	// included.
+	Output []byte `json:"output,omitempty"`
+	// Reason is the reason why the transaction failed. It is only set in case the transaction
+	// has failed.
+	Reason string `json:"reason,omitempty"`
+}
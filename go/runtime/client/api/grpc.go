@@ -22,6 +22,12 @@ var (
 
 	// methodSubmitTx is the SubmitTx method.
 	methodSubmitTx = serviceName.NewMethod("SubmitTx", SubmitTxRequest{})
+	// methodSubmitTxMeta is the SubmitTxMeta method.
+	methodSubmitTxMeta = serviceName.NewMethod("SubmitTxMeta", SubmitTxRequest{})
+	// methodSubmitTxNoWait is the SubmitTxNoWait method.
+	methodSubmitTxNoWait = serviceName.NewMethod("SubmitTxNoWait", SubmitTxRequest{})
+	// methodCheckTx is the CheckTx method.
+	methodCheckTx = serviceName.NewMethod("CheckTx", CheckTxRequest{})
 	// methodGetGenesisBlock is the GetGenesisBlock method.
 	methodGetGenesisBlock = serviceName.NewMethod("GetGenesisBlock", common.Namespace{})
 	// methodGetBlock is the GetBlock method.
@@ -34,15 +40,23 @@ var (
 	methodGetTxByBlockHash = serviceName.NewMethod("GetTxByBlockHash", GetTxByBlockHashRequest{})
 	// methodGetTxs is the GetTxs method.
 	methodGetTxs = serviceName.NewMethod("GetTxs", GetTxsRequest{})
+	// methodGetTxProof is the GetTxProof method.
+	methodGetTxProof = serviceName.NewMethod("GetTxProof", GetTxProofRequest{})
 	// methodQueryTx is the QueryTx method.
 	methodQueryTx = serviceName.NewMethod("QueryTx", QueryTxRequest{})
 	// methodQueryTxs is the QueryTxs method.
 	methodQueryTxs = serviceName.NewMethod("QueryTxs", QueryTxsRequest{})
+	// methodQueryTxsPaginated is the QueryTxsPaginated method.
+	methodQueryTxsPaginated = serviceName.NewMethod("QueryTxsPaginated", QueryTxsRequest{})
+	// methodGetTxStatus is the GetTxStatus method.
+	methodGetTxStatus = serviceName.NewMethod("GetTxStatus", GetTxStatusRequest{})
 	// methodWaitBlockIndexed is the WaitBlockIndexed method.
 	methodWaitBlockIndexed = serviceName.NewMethod("WaitBlockIndexed", WaitBlockIndexedRequest{})
 
 	// methodWatchBlocks is the WatchBlocks method.
 	methodWatchBlocks = serviceName.NewMethod("WatchBlocks", common.Namespace{})
+	// methodWatchBlocksFrom is the WatchBlocksFrom method.
+	methodWatchBlocksFrom = serviceName.NewMethod("WatchBlocksFrom", WatchBlocksFromRequest{})
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -53,6 +67,18 @@ var (
 				MethodName: methodSubmitTx.ShortName(),
 				Handler:    handlerSubmitTx,
 			},
+			{
+				MethodName: methodSubmitTxMeta.ShortName(),
+				Handler:    handlerSubmitTxMeta,
+			},
+			{
+				MethodName: methodSubmitTxNoWait.ShortName(),
+				Handler:    handlerSubmitTxNoWait,
+			},
+			{
+				MethodName: methodCheckTx.ShortName(),
+				Handler:    handlerCheckTx,
+			},
 			{
 				MethodName: methodGetGenesisBlock.ShortName(),
 				Handler:    handlerGetGenesisBlock,
@@ -77,6 +103,10 @@ var (
 				MethodName: methodGetTxs.ShortName(),
 				Handler:    handlerGetTxs,
 			},
+			{
+				MethodName: methodGetTxProof.ShortName(),
+				Handler:    handlerGetTxProof,
+			},
 			{
 				MethodName: methodQueryTx.ShortName(),
 				Handler:    handlerQueryTx,
@@ -85,6 +115,14 @@ var (
 				MethodName: methodQueryTxs.ShortName(),
 				Handler:    handlerQueryTxs,
 			},
+			{
+				MethodName: methodQueryTxsPaginated.ShortName(),
+				Handler:    handlerQueryTxsPaginated,
+			},
+			{
+				MethodName: methodGetTxStatus.ShortName(),
+				Handler:    handlerGetTxStatus,
+			},
 			{
 				MethodName: methodWaitBlockIndexed.ShortName(),
 				Handler:    handlerWaitBlockIndexed,
@@ -96,6 +134,11 @@ var (
 				Handler:       handlerWatchBlocks,
 				ServerStreams: true,
 			},
+			{
+				StreamName:    methodWatchBlocksFrom.ShortName(),
+				Handler:       handlerWatchBlocksFrom,
+				ServerStreams: true,
+			},
 		},
 	}
 )
@@ -123,6 +166,75 @@ func handlerSubmitTx( // nolint: golint
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerSubmitTxMeta( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq SubmitTxRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeClient).SubmitTxMeta(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodSubmitTxMeta.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeClient).SubmitTxMeta(ctx, req.(*SubmitTxRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
+func handlerSubmitTxNoWait( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq SubmitTxRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(RuntimeClient).SubmitTxNoWait(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodSubmitTxNoWait.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(RuntimeClient).SubmitTxNoWait(ctx, req.(*SubmitTxRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
+func handlerCheckTx( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq CheckTxRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeClient).CheckTx(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodCheckTx.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeClient).CheckTx(ctx, req.(*CheckTxRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 // wrappedErrNotFound is a wrapped ErrNotFound error so that it corresponds
 // to the gRPC NotFound error code. It is required because Rust's gRPC bindings
 // do not support fetching error details.
@@ -298,6 +410,29 @@ func handlerGetTxs( // nolint: golint
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerGetTxProof( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq GetTxProofRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeClient).GetTxProof(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetTxProof.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeClient).GetTxProof(ctx, req.(*GetTxProofRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 func handlerQueryTx( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -346,6 +481,52 @@ func handlerQueryTxs( // nolint: golint
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerQueryTxsPaginated( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq QueryTxsRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeClient).QueryTxsPaginated(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodQueryTxsPaginated.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeClient).QueryTxsPaginated(ctx, req.(*QueryTxsRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
+func handlerGetTxStatus( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq GetTxStatusRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeClient).GetTxStatus(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetTxStatus.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeClient).GetTxStatus(ctx, req.(*GetTxStatusRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 func handlerWaitBlockIndexed( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -398,6 +579,35 @@ func handlerWatchBlocks(srv interface{}, stream grpc.ServerStream) error {
 	}
 }
 
+func handlerWatchBlocksFrom(srv interface{}, stream grpc.ServerStream) error {
+	var rq WatchBlocksFromRequest
+	if err := stream.RecvMsg(&rq); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(RuntimeClient).WatchBlocksFrom(ctx, &rq)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case blk, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.SendMsg(blk); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 // RegisterService registers a new runtime client service with the given gRPC server.
 func RegisterService(server *grpc.Server, service RuntimeClient) {
 	server.RegisterService(&serviceDesc, service)
@@ -417,6 +627,26 @@ func (c *runtimeClient) SubmitTx(ctx context.Context, request *SubmitTxRequest)
 	return rsp, nil
 }
 
+func (c *runtimeClient) SubmitTxMeta(ctx context.Context, request *SubmitTxRequest) (*SubmitTxMetaResponse, error) {
+	var rsp SubmitTxMetaResponse
+	if err := c.conn.Invoke(ctx, methodSubmitTxMeta.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *runtimeClient) SubmitTxNoWait(ctx context.Context, request *SubmitTxRequest) error {
+	return c.conn.Invoke(ctx, methodSubmitTxNoWait.FullName(), request, nil)
+}
+
+func (c *runtimeClient) CheckTx(ctx context.Context, request *CheckTxRequest) (*CheckTxResult, error) {
+	var rsp CheckTxResult
+	if err := c.conn.Invoke(ctx, methodCheckTx.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *runtimeClient) GetGenesisBlock(ctx context.Context, runtimeID common.Namespace) (*block.Block, error) {
 	var rsp block.Block
 	if err := c.conn.Invoke(ctx, methodGetGenesisBlock.FullName(), runtimeID, &rsp); err != nil {
@@ -465,6 +695,14 @@ func (c *runtimeClient) GetTxs(ctx context.Context, request *GetTxsRequest) ([][
 	return rsp, nil
 }
 
+func (c *runtimeClient) GetTxProof(ctx context.Context, request *GetTxProofRequest) (*TxProof, error) {
+	var rsp TxProof
+	if err := c.conn.Invoke(ctx, methodGetTxProof.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *runtimeClient) QueryTx(ctx context.Context, request *QueryTxRequest) (*TxResult, error) {
 	var rsp TxResult
 	if err := c.conn.Invoke(ctx, methodQueryTx.FullName(), request, &rsp); err != nil {
@@ -481,6 +719,22 @@ func (c *runtimeClient) QueryTxs(ctx context.Context, request *QueryTxsRequest)
 	return rsp, nil
 }
 
+func (c *runtimeClient) QueryTxsPaginated(ctx context.Context, request *QueryTxsRequest) (*QueryTxsResponse, error) {
+	var rsp QueryTxsResponse
+	if err := c.conn.Invoke(ctx, methodQueryTxsPaginated.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *runtimeClient) GetTxStatus(ctx context.Context, request *GetTxStatusRequest) (*TxStatus, error) {
+	var rsp TxStatus
+	if err := c.conn.Invoke(ctx, methodGetTxStatus.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *runtimeClient) WaitBlockIndexed(ctx context.Context, request *WaitBlockIndexedRequest) error {
 	return c.conn.Invoke(ctx, methodWaitBlockIndexed.FullName(), request, nil)
 }
@@ -520,6 +774,41 @@ func (c *runtimeClient) WatchBlocks(ctx context.Context, runtimeID common.Namesp
 	return ch, sub, nil
 }
 
+func (c *runtimeClient) WatchBlocksFrom(ctx context.Context, request *WatchBlocksFromRequest) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[1], methodWatchBlocksFrom.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(request); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *roothash.AnnotatedBlock)
+	go func() {
+		defer close(ch)
+
+		for {
+			var blk roothash.AnnotatedBlock
+			if serr := stream.RecvMsg(&blk); serr != nil {
+				return
+			}
+
+			select {
+			case ch <- &blk:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
 func (c *runtimeClient) Cleanup() {
 }
 
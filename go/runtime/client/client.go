@@ -3,13 +3,16 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"sync"
 
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
@@ -21,6 +24,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/runtime/client/api"
 	enclaverpc "github.com/oasisprotocol/oasis-core/go/runtime/enclaverpc/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
 	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
 	"github.com/oasisprotocol/oasis-core/go/runtime/tagindexer"
 	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
@@ -55,19 +59,50 @@ type clientCommon struct {
 	ctx context.Context
 }
 
+// clientRuntime is the per-runtime client state. It is created lazily on first use of the
+// runtime and is protected by the runtime client's lock.
+type clientRuntime struct {
+	// watcher is the block watcher, created on first transaction submission.
+	watcher *blockWatcher
+	// km is the key manager client, created on first EnclaveRPC call.
+	km *keymanager.Client
+
+	// maxInFlightTxs is the maximum number of transactions waiting for inclusion, zero means
+	// no limit.
+	maxInFlightTxs uint64
+	// inFlightTxs is the number of transactions currently waiting for inclusion.
+	inFlightTxs uint64
+}
+
 type runtimeClient struct {
 	sync.Mutex
 
 	common *clientCommon
 
-	watchers  map[common.Namespace]*blockWatcher
-	kmClients map[common.Namespace]*keymanager.Client
+	runtimes map[common.Namespace]*clientRuntime
 
 	maxTransactionAge int64
 
 	logger *logging.Logger
 }
 
+// getRuntimeLocked returns the per-runtime client state, creating it if needed.
+//
+// The caller must hold the runtime client's lock.
+func (c *runtimeClient) getRuntimeLocked(runtimeID common.Namespace) *clientRuntime {
+	if cr, ok := c.runtimes[runtimeID]; ok {
+		return cr
+	}
+
+	cr := &clientRuntime{}
+	// Runtimes not configured in the runtime registry use the default (unlimited) settings.
+	if rt, err := c.common.runtimeRegistry.GetRuntime(runtimeID); err == nil && rt.Config() != nil {
+		cr.maxInFlightTxs = rt.Config().MaxInFlightTxs
+	}
+	c.runtimes[runtimeID] = cr
+	return cr
+}
+
 func (c *runtimeClient) tagIndexer(runtimeID common.Namespace) (tagindexer.QueryableBackend, error) {
 	rt, err := c.common.runtimeRegistry.GetRuntime(runtimeID)
 	if err != nil {
@@ -79,16 +114,130 @@ func (c *runtimeClient) tagIndexer(runtimeID common.Namespace) (tagindexer.Query
 
 // Implements api.RuntimeClient.
 func (c *runtimeClient) SubmitTx(ctx context.Context, request *api.SubmitTxRequest) ([]byte, error) {
+	resp, err := c.submitTx(ctx, request, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Output, nil
+}
+
+// Implements api.RuntimeClient.
+func (c *runtimeClient) SubmitTxMeta(ctx context.Context, request *api.SubmitTxRequest) (*api.SubmitTxMetaResponse, error) {
+	return c.submitTx(ctx, request, nil)
+}
+
+// Implements api.RuntimeClient.
+func (c *runtimeClient) SubmitTxNoWait(ctx context.Context, request *api.SubmitTxRequest) error {
+	// Keep submitting the transaction in the background after this method returns, so that it
+	// gets resubmitted on committee changes, until it is either included or expires.
+	submitCtx, cancel := context.WithCancel(c.common.ctx)
+	publishedCh := make(chan struct{})
+	errCh := make(chan error, 1)
+	go func() {
+		defer cancel()
+		_, err := c.submitTx(submitCtx, request, publishedCh)
+		errCh <- err
+	}()
+
+	select {
+	case <-ctx.Done():
+		// The context we're working in was canceled, abort.
+		cancel()
+		return ctx.Err()
+	case <-publishedCh:
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+// Implements api.RuntimeClient.
+func (c *runtimeClient) CheckTx(ctx context.Context, request *api.CheckTxRequest) (*api.CheckTxResult, error) {
+	rt, err := c.common.runtimeRegistry.GetRuntime(request.RuntimeID)
+	if err != nil {
+		return nil, err
+	}
+	hrt := rt.HostedRuntime()
+	if hrt == nil {
+		return nil, api.ErrNotHosted
+	}
+
+	blk, err := rt.History().GetLatestBlock(ctx)
+	if err != nil {
+		c.logger.Error("failed to get latest block",
+			"err", err,
+			"runtime_id", request.RuntimeID,
+		)
+		return nil, api.ErrInternal
+	}
+
+	resp, err := hrt.Call(ctx, &protocol.Body{
+		RuntimeCheckTxBatchRequest: &protocol.RuntimeCheckTxBatchRequest{
+			Inputs: transaction.RawBatch{request.Data},
+			Block:  *blk,
+		},
+	})
+	switch {
+	case err != nil:
+		c.logger.Error("CheckTx: runtime call error",
+			"err", err,
+			"runtime_id", request.RuntimeID,
+		)
+		return nil, api.ErrInternal
+	case resp.RuntimeCheckTxBatchResponse == nil:
+		c.logger.Error("CheckTx: runtime response is nil",
+			"runtime_id", request.RuntimeID,
+		)
+		return nil, api.ErrInternal
+	case len(resp.RuntimeCheckTxBatchResponse.Results) != 1:
+		c.logger.Error("CheckTx: runtime response doesn't contain exactly one result",
+			"num_results", len(resp.RuntimeCheckTxBatchResponse.Results),
+			"runtime_id", request.RuntimeID,
+		)
+		return nil, api.ErrInternal
+	}
+
+	var output transaction.TxnOutput
+	if err = cbor.Unmarshal(resp.RuntimeCheckTxBatchResponse.Results[0], &output); err != nil {
+		c.logger.Error("CheckTx: runtime response failed to deserialize",
+			"err", err,
+			"runtime_id", request.RuntimeID,
+		)
+		return nil, api.ErrInternal
+	}
+
+	result := &api.CheckTxResult{
+		Round: blk.Header.Round,
+	}
+	if output.Error != nil {
+		result.Error = &api.CheckTxError{
+			Message: *output.Error,
+		}
+		return result, nil
+	}
+
+	var checkResult transaction.TxnCheckResult
+	if err = cbor.Unmarshal(output.Success, &checkResult); err == nil {
+		result.PredictedReadWriteSet = &checkResult.PredictedReadWriteSet
+	}
+	return result, nil
+}
+
+// submitTx submits a transaction and waits for its execution results. In case publishedCh is
+// non-nil, it is closed once the transaction has been published for the first time.
+func (c *runtimeClient) submitTx(
+	ctx context.Context,
+	request *api.SubmitTxRequest,
+	publishedCh chan<- struct{},
+) (*api.SubmitTxMetaResponse, error) {
 	if c.common.p2p == nil {
 		return nil, fmt.Errorf("client: cannot submit transaction, p2p disabled")
 	}
 
-	var watcher *blockWatcher
-	var ok bool
-	var err error
 	c.Lock()
-	if watcher, ok = c.watchers[request.RuntimeID]; !ok {
-		watcher, err = newWatcher(c.common, request.RuntimeID, c.common.p2p, c.maxTransactionAge)
+	cr := c.getRuntimeLocked(request.RuntimeID)
+	if cr.watcher == nil {
+		watcher, err := newWatcher(c.common, request.RuntimeID, c.common.p2p, c.maxTransactionAge)
 		if err != nil {
 			c.Unlock()
 			return nil, err
@@ -97,10 +246,22 @@ func (c *runtimeClient) SubmitTx(ctx context.Context, request *api.SubmitTxReque
 			c.Unlock()
 			return nil, err
 		}
-		c.watchers[request.RuntimeID] = watcher
+		cr.watcher = watcher
 	}
+	if cr.maxInFlightTxs > 0 && cr.inFlightTxs >= cr.maxInFlightTxs {
+		c.Unlock()
+		return nil, api.ErrTooManyInFlightTxs
+	}
+	cr.inFlightTxs++
+	watcher := cr.watcher
 	c.Unlock()
 
+	defer func() {
+		c.Lock()
+		cr.inFlightTxs--
+		c.Unlock()
+	}()
+
 	// Send a request for watching a new runtime transaction.
 	respCh := make(chan *watchResult)
 	req := &watchRequest{
@@ -145,7 +306,11 @@ func (c *runtimeClient) SubmitTx(ctx context.Context, request *api.SubmitTxReque
 				break
 			}
 
-			return resp.result, nil
+			return &api.SubmitTxMetaResponse{
+				Output:     resp.result,
+				Round:      resp.round,
+				BatchOrder: resp.batchOrder,
+			}, nil
 		}
 
 		c.common.p2p.Publish(context.Background(), request.RuntimeID, &p2p.Message{
@@ -154,6 +319,10 @@ func (c *runtimeClient) SubmitTx(ctx context.Context, request *api.SubmitTxReque
 			},
 			GroupVersion: resp.groupVersion,
 		})
+		if publishedCh != nil {
+			close(publishedCh)
+			publishedCh = nil
+		}
 	}
 }
 
@@ -162,6 +331,11 @@ func (c *runtimeClient) WatchBlocks(ctx context.Context, runtimeID common.Namesp
 	return c.common.consensus.RootHash().WatchBlocks(runtimeID)
 }
 
+// Implements api.RuntimeClient.
+func (c *runtimeClient) WatchBlocksFrom(ctx context.Context, request *api.WatchBlocksFromRequest) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
+	return c.common.consensus.RootHash().WatchBlocksFrom(ctx, request.RuntimeID, request.Round)
+}
+
 // Implements api.RuntimeClient.
 func (c *runtimeClient) GetGenesisBlock(ctx context.Context, runtimeID common.Namespace) (*block.Block, error) {
 	return c.common.consensus.RootHash().GetGenesisBlock(ctx, runtimeID, consensus.HeightLatest)
@@ -222,10 +396,11 @@ func (c *runtimeClient) GetTx(ctx context.Context, request *api.GetTxRequest) (*
 	}
 
 	return &api.TxResult{
-		Block:  blk,
-		Index:  request.Index,
-		Input:  tx.Input,
-		Output: tx.Output,
+		Block:   blk,
+		Index:   request.Index,
+		Input:   tx.Input,
+		Output:  tx.Output,
+		GasUsed: tx.GasUsed,
 	}, nil
 }
 
@@ -252,10 +427,11 @@ func (c *runtimeClient) GetTxByBlockHash(ctx context.Context, request *api.GetTx
 	}
 
 	return &api.TxResult{
-		Block:  blk,
-		Index:  request.Index,
-		Input:  tx.Input,
-		Output: tx.Output,
+		Block:   blk,
+		Index:   request.Index,
+		Input:   tx.Input,
+		Output:  tx.Output,
+		GasUsed: tx.GasUsed,
 	}, nil
 }
 
@@ -287,6 +463,30 @@ func (c *runtimeClient) GetTxs(ctx context.Context, request *api.GetTxsRequest)
 	return inputs, nil
 }
 
+// Implements api.RuntimeClient.
+func (c *runtimeClient) GetTxProof(ctx context.Context, request *api.GetTxProofRequest) (*api.TxProof, error) {
+	blk, err := c.GetBlock(ctx, &api.GetBlockRequest{RuntimeID: request.RuntimeID, Round: request.Round})
+	if err != nil {
+		return nil, err
+	}
+	if blk.Header.IORoot.IsEmpty() {
+		return nil, api.ErrNotFound
+	}
+
+	tree := c.getTxnTree(blk)
+	defer tree.Close()
+
+	proof, err := tree.GetProof(ctx, request.TxHashes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.TxProof{
+		Block: blk,
+		Proof: *proof,
+	}, nil
+}
+
 // Implements api.RuntimeClient.
 func (c *runtimeClient) GetBlockByHash(ctx context.Context, request *api.GetBlockByHashRequest) (*block.Block, error) {
 	tagIndexer, err := c.tagIndexer(request.RuntimeID)
@@ -309,9 +509,38 @@ func (c *runtimeClient) QueryTx(ctx context.Context, request *api.QueryTxRequest
 		return nil, err
 	}
 
-	round, txHash, txIndex, err := tagIndexer.QueryTxn(ctx, request.Key, request.Value)
-	if err != nil {
-		return nil, err
+	var (
+		round   uint64
+		txHash  hash.Hash
+		txIndex uint32
+	)
+	if len(request.Conditions) == 0 {
+		round, txHash, txIndex, err = tagIndexer.QueryTxn(ctx, request.Key, request.Value)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Multiple conditions, use a complex query returning a single result.
+		query := api.Query{
+			Conditions: request.Conditions,
+			Limit:      1,
+		}
+		if request.Key != nil {
+			query.Conditions = append([]api.QueryCondition{
+				{Key: request.Key, Values: [][]byte{request.Value}},
+			}, query.Conditions...)
+		}
+
+		var results tagindexer.Results
+		if results, _, err = tagIndexer.QueryTxns(ctx, query); err != nil {
+			return nil, err
+		}
+		if len(results) == 0 {
+			return nil, api.ErrNotFound
+		}
+		for r, txResults := range results {
+			round, txHash, txIndex = r, txResults[0].TxHash, txResults[0].TxIndex
+		}
 	}
 
 	blk, err := c.GetBlock(ctx, &api.GetBlockRequest{RuntimeID: request.RuntimeID, Round: round})
@@ -325,27 +554,46 @@ func (c *runtimeClient) QueryTx(ctx context.Context, request *api.QueryTxRequest
 	}
 
 	return &api.TxResult{
-		Block:  blk,
-		Index:  txIndex,
-		Input:  tx.Input,
-		Output: tx.Output,
+		Block:   blk,
+		Index:   txIndex,
+		Input:   tx.Input,
+		Output:  tx.Output,
+		GasUsed: tx.GasUsed,
 	}, nil
 }
 
 // Implements api.RuntimeClient.
 func (c *runtimeClient) QueryTxs(ctx context.Context, request *api.QueryTxsRequest) ([]*api.TxResult, error) {
+	rsp, err := c.QueryTxsPaginated(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Results, nil
+}
+
+// Implements api.RuntimeClient.
+func (c *runtimeClient) QueryTxsPaginated(ctx context.Context, request *api.QueryTxsRequest) (*api.QueryTxsResponse, error) {
 	tagIndexer, err := c.tagIndexer(request.RuntimeID)
 	if err != nil {
 		return nil, err
 	}
 
-	results, err := tagIndexer.QueryTxns(ctx, request.Query)
+	results, cursor, err := tagIndexer.QueryTxns(ctx, request.Query)
 	if err != nil {
 		return nil, err
 	}
 
+	// Process rounds in order so that the output is ordered by round and index.
+	rounds := make([]uint64, 0, len(results))
+	for round := range results {
+		rounds = append(rounds, round)
+	}
+	sort.Slice(rounds, func(i, j int) bool { return rounds[i] < rounds[j] })
+
 	output := []*api.TxResult{}
-	for round, txResults := range results {
+	for _, round := range rounds {
+		txResults := results[round]
+
 		// Fetch block for the given round.
 		var blk *block.Block
 		blk, err = c.GetBlock(ctx, &api.GetBlockRequest{RuntimeID: request.RuntimeID, Round: round})
@@ -373,15 +621,75 @@ func (c *runtimeClient) QueryTxs(ctx context.Context, request *api.QueryTxsReque
 			}
 
 			output = append(output, &api.TxResult{
-				Block:  blk,
-				Index:  txResult.TxIndex,
-				Input:  tx.Input,
-				Output: tx.Output,
+				Block:   blk,
+				Index:   txResult.TxIndex,
+				Input:   tx.Input,
+				Output:  tx.Output,
+				GasUsed: tx.GasUsed,
 			})
 		}
 	}
 
-	return output, nil
+	return &api.QueryTxsResponse{
+		Results: output,
+		Cursor:  cursor,
+	}, nil
+}
+
+// Implements api.RuntimeClient.
+func (c *runtimeClient) GetTxStatus(ctx context.Context, request *api.GetTxStatusRequest) (*api.TxStatus, error) {
+	tagIndexer, err := c.tagIndexer(request.RuntimeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the block watcher first as it tracks transactions submitted via this client.
+	status := &api.TxStatus{Status: api.TxStatusUnknown}
+	var watcher *blockWatcher
+	c.Lock()
+	if cr, ok := c.runtimes[request.RuntimeID]; ok {
+		watcher = cr.watcher
+	}
+	c.Unlock()
+	if watcher != nil {
+		if status, err = watcher.TxStatus(ctx, request.TxHash); err != nil {
+			return nil, err
+		}
+	}
+	switch status.Status {
+	case api.TxStatusPending, api.TxStatusScheduled, api.TxStatusFailed:
+		return status, nil
+	case api.TxStatusIncluded:
+		// Make sure that the block has been indexed so that the transaction index is known.
+		if err = tagIndexer.WaitBlockIndexed(ctx, status.Round); err != nil {
+			return nil, err
+		}
+	}
+
+	round, index, err := tagIndexer.QueryTxnByHash(ctx, request.TxHash)
+	switch {
+	case err == nil:
+	case errors.Is(err, api.ErrNotFound):
+		return status, nil
+	default:
+		return nil, err
+	}
+
+	blk, err := c.GetBlock(ctx, &api.GetBlockRequest{RuntimeID: request.RuntimeID, Round: round})
+	if err != nil {
+		return nil, err
+	}
+	tx, err := c.getTxnByHash(ctx, blk, request.TxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.TxStatus{
+		Status: api.TxStatusIncluded,
+		Round:  round,
+		Index:  index,
+		Output: tx.Output,
+	}, nil
 }
 
 // Implements api.RuntimeClient.
@@ -404,21 +712,24 @@ func (c *runtimeClient) CallEnclave(ctx context.Context, request *enclaverpc.Cal
 			return nil, err
 		}
 
-		var km *keymanager.Client
 		c.Lock()
-		if km = c.kmClients[rt.ID()]; km == nil {
-			c.logger.Debug("creating new key manager client instance")
+		cr := c.getRuntimeLocked(rt.ID())
+		if cr.km == nil {
+			c.logger.Debug("creating new key manager client instance",
+				"runtime_id", rt.ID(),
+			)
 
-			km, err = keymanager.New(c.common.ctx, rt, c.common.consensus.KeyManager(), c.common.consensus.Registry(), nil)
+			cr.km, err = keymanager.New(c.common.ctx, rt, c.common.consensus.KeyManager(), c.common.consensus.Registry(), nil)
 			if err != nil {
 				c.Unlock()
 				c.logger.Error("failed to create key manager client instance",
 					"err", err,
+					"runtime_id", rt.ID(),
 				)
 				return nil, api.ErrInternal
 			}
-			c.kmClients[rt.ID()] = km
 		}
+		km := cr.km
 		c.Unlock()
 
 		return km.CallRemote(ctx, request.Payload)
@@ -432,11 +743,20 @@ func (c *runtimeClient) CallEnclave(ctx context.Context, request *enclaverpc.Cal
 
 // Cleanup stops all running block watchers and waits for them to finish.
 func (c *runtimeClient) Cleanup() {
+	c.Lock()
+	var watchers []*blockWatcher
+	for _, cr := range c.runtimes {
+		if cr.watcher != nil {
+			watchers = append(watchers, cr.watcher)
+		}
+	}
+	c.Unlock()
+
 	// Watchers.
-	for _, watcher := range c.watchers {
+	for _, watcher := range watchers {
 		watcher.Stop()
 	}
-	for _, watcher := range c.watchers {
+	for _, watcher := range watchers {
 		<-watcher.Quit()
 	}
 }
@@ -462,8 +782,7 @@ func New(
 			ctx:             ctx,
 			p2p:             p2p,
 		},
-		watchers:          make(map[common.Namespace]*blockWatcher),
-		kmClients:         make(map[common.Namespace]*keymanager.Client),
+		runtimes:          make(map[common.Namespace]*clientRuntime),
 		maxTransactionAge: maxTransactionAge,
 		logger:            logging.GetLogger("runtime/client"),
 	}
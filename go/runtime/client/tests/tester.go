@@ -14,6 +14,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 )
 
 // Keep this above the test network's max batch timeout.
@@ -38,6 +39,24 @@ func ClientImplementationTests(
 		defer cancelFunc()
 		testQuery(ctx, t, runtimeID, client, testInput)
 	})
+
+	t.Run("SubmitTxMeta", func(t *testing.T) {
+		ctx, cancelFunc := context.WithTimeout(context.Background(), timeout)
+		defer cancelFunc()
+		testSubmitTransactionMeta(ctx, t, runtimeID, client, "meta "+testInput)
+	})
+
+	t.Run("SubmitTxNoWait", func(t *testing.T) {
+		ctx, cancelFunc := context.WithTimeout(context.Background(), timeout)
+		defer cancelFunc()
+		testSubmitTransactionNoWait(ctx, t, runtimeID, client, "nowait "+testInput)
+	})
+
+	t.Run("CheckTx", func(t *testing.T) {
+		ctx, cancelFunc := context.WithTimeout(context.Background(), timeout)
+		defer cancelFunc()
+		testCheckTx(ctx, t, runtimeID, client, "check "+testInput)
+	})
 }
 
 func testSubmitTransaction(
@@ -56,6 +75,82 @@ func testSubmitTransaction(
 	require.EqualValues(t, testInput, testOutput)
 }
 
+func testSubmitTransactionMeta(
+	ctx context.Context,
+	t *testing.T,
+	runtimeID common.Namespace,
+	c api.RuntimeClient,
+	input string,
+) {
+	testInput := []byte(input)
+	// Submit a test transaction.
+	resp, err := c.SubmitTxMeta(ctx, &api.SubmitTxRequest{Data: testInput, RuntimeID: runtimeID})
+	require.NoError(t, err, "SubmitTxMeta")
+	require.EqualValues(t, testInput, resp.Output)
+
+	// The reported round should contain the transaction.
+	blk, err := c.GetBlock(ctx, &api.GetBlockRequest{RuntimeID: runtimeID, Round: resp.Round})
+	require.NoError(t, err, "GetBlock")
+	txs, err := c.GetTxs(ctx, &api.GetTxsRequest{RuntimeID: runtimeID, Round: resp.Round, IORoot: blk.Header.IORoot})
+	require.NoError(t, err, "GetTxs")
+	require.Contains(t, txs, testInput, "transaction should be included in the reported round")
+	require.True(t, int(resp.BatchOrder) < len(txs), "batch order should be within the batch")
+}
+
+func testSubmitTransactionNoWait(
+	ctx context.Context,
+	t *testing.T,
+	runtimeID common.Namespace,
+	c api.RuntimeClient,
+	input string,
+) {
+	testInput := []byte(input)
+	// Submit a test transaction without waiting for the results.
+	err := c.SubmitTxNoWait(ctx, &api.SubmitTxRequest{Data: testInput, RuntimeID: runtimeID})
+	require.NoError(t, err, "SubmitTxNoWait")
+
+	// The transaction should eventually be included.
+	txHash := hash.NewFromBytes(testInput)
+	for {
+		txStatus, err := c.GetTxStatus(ctx, &api.GetTxStatusRequest{RuntimeID: runtimeID, TxHash: txHash})
+		require.NoError(t, err, "GetTxStatus")
+		switch txStatus.Status {
+		case api.TxStatusPending, api.TxStatusScheduled:
+		case api.TxStatusIncluded:
+			require.EqualValues(t, testInput, txStatus.Output)
+			return
+		default:
+			t.Fatalf("unexpected transaction status: %s", txStatus.Status)
+		}
+
+		select {
+		case <-ctx.Done():
+			t.Fatalf("transaction was not included: %s", ctx.Err())
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+func testCheckTx(
+	ctx context.Context,
+	t *testing.T,
+	runtimeID common.Namespace,
+	c api.RuntimeClient,
+	input string,
+) {
+	// A valid transaction should be accepted.
+	result, err := c.CheckTx(ctx, &api.CheckTxRequest{Data: []byte(input), RuntimeID: runtimeID})
+	require.NoError(t, err, "CheckTx")
+	require.True(t, result.IsValid(), "CheckTx should accept a valid transaction")
+	require.Nil(t, result.Error, "CheckTx should not return an error for a valid transaction")
+
+	// An empty transaction should be rejected by the (mock) runtime.
+	result, err = c.CheckTx(ctx, &api.CheckTxRequest{Data: []byte{}, RuntimeID: runtimeID})
+	require.NoError(t, err, "CheckTx")
+	require.False(t, result.IsValid(), "CheckTx should reject an invalid transaction")
+	require.NotEmpty(t, result.Error.Message, "CheckTx should return the rejection reason")
+}
+
 func testQuery(
 	ctx context.Context,
 	t *testing.T,
@@ -101,6 +196,19 @@ func testQuery(
 	err = c.WaitBlockIndexed(ctx, &api.WaitBlockIndexedRequest{RuntimeID: runtimeID, Round: expectedLatestRound})
 	require.NoError(t, err, "WaitBlockIndexed")
 
+	// Watch blocks starting at a historic round.
+	blkCh, blkSub, err := c.WatchBlocksFrom(ctx, &api.WatchBlocksFromRequest{RuntimeID: runtimeID, Round: 1})
+	require.NoError(t, err, "WatchBlocksFrom")
+	for round := uint64(1); round <= expectedLatestRound; round++ {
+		select {
+		case annBlk := <-blkCh:
+			require.EqualValues(t, round, annBlk.Block.Header.Round, "WatchBlocksFrom should deliver blocks in order")
+		case <-ctx.Done():
+			t.Fatalf("failed to receive block for round %d", round)
+		}
+	}
+	blkSub.Close()
+
 	// Get transaction by latest round.
 	tx, err := c.GetTx(ctx, &api.GetTxRequest{RuntimeID: runtimeID, Round: api.RoundLatest, Index: 0})
 	require.NoError(t, err, "GetTx(RoundLatest)")
@@ -112,6 +220,19 @@ func testQuery(
 	_, err = c.GetTx(ctx, &api.GetTxRequest{RuntimeID: runtimeID, Round: api.RoundLatest, Index: 1})
 	require.Error(t, err, "GetTx(1)")
 
+	// Get transaction status by hash.
+	txStatus, err := c.GetTxStatus(ctx, &api.GetTxStatusRequest{RuntimeID: runtimeID, TxHash: hash.NewFromBytes(testInput)})
+	require.NoError(t, err, "GetTxStatus")
+	require.Equal(t, api.TxStatusIncluded, txStatus.Status, "GetTxStatus should report the transaction as included")
+	require.EqualValues(t, expectedLatestRound, txStatus.Round)
+	require.EqualValues(t, 0, txStatus.Index)
+	require.EqualValues(t, testOutput, txStatus.Output, "GetTxStatus should return the transaction output")
+
+	// Unknown transaction.
+	txStatus, err = c.GetTxStatus(ctx, &api.GetTxStatusRequest{RuntimeID: runtimeID, TxHash: hash.NewFromBytes([]byte("unknown transaction"))})
+	require.NoError(t, err, "GetTxStatus(unknown)")
+	require.Equal(t, api.TxStatusUnknown, txStatus.Status, "GetTxStatus should report the transaction as unknown")
+
 	// Get transaction by block hash and index.
 	tx, err = c.GetTxByBlockHash(ctx, &api.GetTxByBlockHashRequest{RuntimeID: runtimeID, BlockHash: blk.Header.EncodedHash(), Index: 0})
 	require.NoError(t, err, "GetTxByBlockHash")
@@ -139,6 +260,29 @@ func testQuery(
 	require.True(t, strings.HasPrefix(string(tx.Input), "hello world"))
 	require.True(t, strings.HasPrefix(string(tx.Output), "hello world"))
 
+	// Check that multiple conditions are supported.
+	txc, err := c.QueryTx(ctx, &api.QueryTxRequest{
+		RuntimeID: runtimeID,
+		Key:       []byte("txn_foo"),
+		Value:     []byte("txn_bar"),
+		Conditions: []api.QueryCondition{
+			{Key: []byte("txn_foo"), Values: [][]byte{[]byte("txn_b")}, Match: api.QueryMatchPrefix},
+		},
+	})
+	require.NoError(t, err, "QueryTx with conditions")
+	require.EqualValues(t, tx.Block.Header.Round, txc.Block.Header.Round)
+	require.EqualValues(t, tx.Index, txc.Index)
+
+	_, err = c.QueryTx(ctx, &api.QueryTxRequest{
+		RuntimeID: runtimeID,
+		Key:       []byte("txn_foo"),
+		Value:     []byte("txn_bar"),
+		Conditions: []api.QueryCondition{
+			{Key: []byte("txn_foo"), Values: [][]byte{[]byte("txn_c")}, Match: api.QueryMatchPrefix},
+		},
+	})
+	require.Equal(t, api.ErrNotFound, err, "QueryTx with non-matching conditions")
+
 	// Transactions (check the mock worker for content).
 	txns, err := c.GetTxs(ctx, &api.GetTxsRequest{RuntimeID: runtimeID, Round: blk.Header.Round, IORoot: blk.Header.IORoot})
 	require.NoError(t, err, "GetTxs")
@@ -146,6 +290,16 @@ func testQuery(
 	// Check for values from TestNode/Client/SubmitTx
 	require.EqualValues(t, testInput, txns[0])
 
+	// Transaction inclusion proofs.
+	testTxHash := hash.NewFromBytes(testInput)
+	txProof, err := c.GetTxProof(ctx, &api.GetTxProofRequest{RuntimeID: runtimeID, Round: blk.Header.Round, TxHashes: []hash.Hash{testTxHash}})
+	require.NoError(t, err, "GetTxProof")
+	ioRoot := storage.Root{Namespace: runtimeID, Version: blk.Header.Round, Hash: blk.Header.IORoot}
+	provenTxs, err := txProof.Verify(ctx, ioRoot, []hash.Hash{testTxHash})
+	require.NoError(t, err, "TxProof.Verify")
+	require.Len(t, provenTxs, 1, "transaction should be proven")
+	require.EqualValues(t, testInput, provenTxs[testTxHash].Input)
+
 	// Test advanced transaction queries.
 	query := api.Query{
 		RoundMin: 0,
@@ -167,6 +321,24 @@ func testQuery(
 	require.True(t, strings.HasPrefix(string(results[0].Input), "hello world"))
 	require.True(t, strings.HasPrefix(string(results[0].Output), "hello world"))
 
+	// Test paginated transaction queries.
+	query.Limit = 1
+	page1, err := c.QueryTxsPaginated(ctx, &api.QueryTxsRequest{RuntimeID: runtimeID, Query: query})
+	require.NoError(t, err, "QueryTxsPaginated (first page)")
+	require.Len(t, page1.Results, 1)
+	require.NotNil(t, page1.Cursor, "first page should have a cursor")
+
+	query.Cursor = page1.Cursor
+	page2, err := c.QueryTxsPaginated(ctx, &api.QueryTxsRequest{RuntimeID: runtimeID, Query: query})
+	require.NoError(t, err, "QueryTxsPaginated (second page)")
+	require.Len(t, page2.Results, 1)
+	require.True(t,
+		page1.Results[0].Block.Header.Round < page2.Results[0].Block.Header.Round ||
+			(page1.Results[0].Block.Header.Round == page2.Results[0].Block.Header.Round &&
+				page1.Results[0].Index < page2.Results[0].Index),
+		"results should be ordered by round and index",
+	)
+
 	// Query genesis block again.
 	genBlk2, err := c.GetGenesisBlock(ctx, runtimeID)
 	require.NoError(t, err, "GetGenesisBlock2")
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cache/lru"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/service"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
@@ -14,11 +15,25 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/worker/common/p2p"
 )
 
+// recentTxStatusCacheSize is the number of recently finished transactions whose status is
+// remembered by the watcher.
+const recentTxStatusCacheSize = 1024
+
+type txStatusRequest struct {
+	id     hash.Hash
+	respCh chan *api.TxStatus
+}
+
 type watchRequest struct {
 	id     hash.Hash
 	ctx    context.Context
 	respCh chan *watchResult
 	height int64
+
+	// groupVersion is the committee group version the transaction has last been published for.
+	groupVersion int64
+	// published is true iff the transaction has been published at least once.
+	published bool
 }
 
 func (w *watchRequest) send(res *watchResult, height int64) error {
@@ -28,6 +43,11 @@ func (w *watchRequest) send(res *watchResult, height int64) error {
 	case <-w.ctx.Done():
 		return w.ctx.Err()
 	case w.respCh <- res:
+		if res.result == nil && res.err == nil {
+			// The client publishes the transaction upon receiving a result without output.
+			w.groupVersion = res.groupVersion
+			w.published = true
+		}
 		return nil
 	}
 }
@@ -35,6 +55,8 @@ func (w *watchRequest) send(res *watchResult, height int64) error {
 type watchResult struct {
 	err          error
 	result       []byte
+	round        uint64
+	batchOrder   uint32
 	groupVersion int64
 }
 
@@ -44,10 +66,15 @@ type blockWatcher struct {
 	common *clientCommon
 	id     common.Namespace
 
-	watched map[hash.Hash]*watchRequest
-	newCh   chan *watchRequest
+	watched  map[hash.Hash]*watchRequest
+	newCh    chan *watchRequest
+	statusCh chan *txStatusRequest
+
+	recent *lru.Cache
 
 	maxTransactionAge int64
+	// latestGroupVersion is the latest known committee group version.
+	latestGroupVersion int64
 
 	toBeChecked []*block.Block
 
@@ -88,18 +115,60 @@ func (w *blockWatcher) checkBlock(blk *block.Block) error {
 	for txHash, tx := range matches {
 		watch := w.watched[txHash]
 		res := &watchResult{
-			result: tx.Output,
+			result:     tx.Output,
+			round:      blk.Header.Round,
+			batchOrder: tx.BatchOrder,
 		}
 
 		// Ignore errors, the watch is getting deleted anyway.
 		_ = watch.send(res, 0)
 		close(watch.respCh)
 		delete(w.watched, txHash)
+
+		_ = w.recent.Put(txHash, &api.TxStatus{
+			Status: api.TxStatusIncluded,
+			Round:  blk.Header.Round,
+		})
 	}
 
 	return nil
 }
 
+func (w *blockWatcher) getTxStatus(txHash hash.Hash) *api.TxStatus {
+	if watch, ok := w.watched[txHash]; ok {
+		if watch.published && watch.groupVersion == w.latestGroupVersion {
+			return &api.TxStatus{Status: api.TxStatusScheduled}
+		}
+		return &api.TxStatus{Status: api.TxStatusPending}
+	}
+	if status, ok := w.recent.Get(txHash); ok {
+		return status.(*api.TxStatus)
+	}
+	return &api.TxStatus{Status: api.TxStatusUnknown}
+}
+
+// TxStatus returns the status of a transaction as known to the watcher.
+func (w *blockWatcher) TxStatus(ctx context.Context, txHash hash.Hash) (*api.TxStatus, error) {
+	req := &txStatusRequest{
+		id:     txHash,
+		respCh: make(chan *api.TxStatus, 1),
+	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-w.Quit():
+		return &api.TxStatus{Status: api.TxStatusUnknown}, nil
+	case w.statusCh <- req:
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case status := <-req.respCh:
+		return status, nil
+	}
+}
+
 func (w *blockWatcher) getGroupVersion(height int64) (int64, error) {
 	epoch, err := w.common.consensus.EpochTime().GetEpoch(w.common.ctx, height)
 	if err != nil {
@@ -138,8 +207,6 @@ func (w *blockWatcher) watch() {
 
 	// latestHeight contains the latest known consensus block height.
 	var latestHeight int64
-	// latestGroupVersion contains the latest known committee group version.
-	var latestGroupVersion int64
 	// Wait for first consensus block before proceeding.
 	select {
 	case <-w.stopCh:
@@ -148,7 +215,7 @@ func (w *blockWatcher) watch() {
 		return
 	case blk := <-consensusBlocks:
 		latestHeight = blk.Height
-		latestGroupVersion, err = w.getGroupVersion(blk.Height)
+		w.latestGroupVersion, err = w.getGroupVersion(blk.Height)
 		if err != nil {
 			w.Logger.Error("failed querying for latest group version",
 				"err", err,
@@ -187,19 +254,21 @@ func (w *blockWatcher) watch() {
 			}
 
 			// Get group version.
-			latestGroupVersion, err = w.getGroupVersion(blk.Height)
+			var groupVersion int64
+			groupVersion, err = w.getGroupVersion(blk.Height)
 			if err != nil {
 				w.Logger.Error("failed querying for latest group version",
 					"err", err,
 				)
 				continue
 			}
+			w.latestGroupVersion = groupVersion
 
 			// Tell every client to resubmit as messages with old groupVersion
 			// will be discarded.
 			for key, watch := range w.watched {
 				res := &watchResult{
-					groupVersion: latestGroupVersion,
+					groupVersion: w.latestGroupVersion,
 				}
 				if watch.send(res, latestHeight) != nil {
 					delete(w.watched, key)
@@ -229,16 +298,23 @@ func (w *blockWatcher) watch() {
 				_ = watch.send(res, 0)
 				close(watch.respCh)
 				delete(w.watched, key)
+
+				_ = w.recent.Put(key, &api.TxStatus{
+					Status: api.TxStatusFailed,
+					Reason: api.ErrTransactionExpired.Error(),
+				})
 			}
 		case newWatch := <-w.newCh:
 			w.watched[newWatch.id] = newWatch
 
 			res := &watchResult{
-				groupVersion: latestGroupVersion,
+				groupVersion: w.latestGroupVersion,
 			}
 			if newWatch.send(res, latestHeight) != nil {
 				delete(w.watched, newWatch.id)
 			}
+		case statusReq := <-w.statusCh:
+			statusReq.respCh <- w.getTxStatus(statusReq.id)
 
 		case <-w.stopCh:
 			w.Logger.Info("stop requested, aborting watcher")
@@ -262,6 +338,11 @@ func (w *blockWatcher) Stop() {
 }
 
 func newWatcher(common *clientCommon, id common.Namespace, p2pSvc *p2p.P2P, maxTransactionAge int64) (*blockWatcher, error) {
+	recent, err := lru.New(lru.Capacity(recentTxStatusCacheSize, false))
+	if err != nil {
+		return nil, err
+	}
+
 	// Register handler.
 	p2pSvc.RegisterHandler(id, &p2p.BaseHandler{})
 
@@ -273,6 +354,8 @@ func newWatcher(common *clientCommon, id common.Namespace, p2pSvc *p2p.P2P, maxT
 		maxTransactionAge:     maxTransactionAge,
 		watched:               make(map[hash.Hash]*watchRequest),
 		newCh:                 make(chan *watchRequest),
+		statusCh:              make(chan *txStatusRequest),
+		recent:                recent,
 		stopCh:                make(chan struct{}),
 	}
 	return watcher, nil
@@ -35,6 +35,9 @@ const (
 // NodeSelectionFeedback is feedback to the node selection policy.
 type NodeSelectionFeedback struct {
 	// ID is the node identifier.
+	//
+	// If set, the feedback is only taken into account in case it refers to the currently
+	// selected node.
 	ID signature.PublicKey
 
 	// Bad being non-nil signals that the currently selected node is bad and contains the reason
@@ -111,6 +114,11 @@ func (rr *roundRobinNodeSelectionPolicy) UpdatePolicy(feedback NodeSelectionFeed
 	if len(rr.nodes) == 0 {
 		return
 	}
+	// Ignore feedback about nodes other than the currently selected one, as it could otherwise
+	// cause the policy to skip over a good node when multiple failures are reported.
+	if feedback.ID != (signature.PublicKey{}) && !feedback.ID.Equal(rr.nodes[rr.index]) {
+		return
+	}
 
 	// The round-robin policy ignores any bad feedback.
 	rr.index = (rr.index + 1) % len(rr.nodes)
@@ -147,6 +155,12 @@ type Client interface {
 	// If no connections are available this method will return nil.
 	GetConnection() *grpc.ClientConn
 
+	// GetConnectionWithMeta returns a connection based on the configured node selection policy
+	// including node metadata for the connection.
+	//
+	// If no connections are available this method will return nil.
+	GetConnectionWithMeta() *ClientConnWithMeta
+
 	// UpdateNodeSelectionPolicy submits feedback to the policy which can cause the policy to update
 	// its current node selection.
 	UpdateNodeSelectionPolicy(feedback NodeSelectionFeedback)
@@ -263,6 +277,14 @@ func (cc *committeeClient) GetConnectionsMap() map[signature.PublicKey]*ClientCo
 }
 
 func (cc *committeeClient) GetConnection() *grpc.ClientConn {
+	c := cc.GetConnectionWithMeta()
+	if c == nil {
+		return nil
+	}
+	return c.ClientConn
+}
+
+func (cc *committeeClient) GetConnectionWithMeta() *ClientConnWithMeta {
 	cc.RLock()
 	defer cc.RUnlock()
 
@@ -276,7 +298,10 @@ func (cc *committeeClient) GetConnection() *grpc.ClientConn {
 		// Node selection policy may not have been updated yet.
 		return nil
 	}
-	return c.conn
+	return &ClientConnWithMeta{
+		ClientConn: c.conn,
+		Node:       c.node,
+	}
 }
 
 func (cc *committeeClient) UpdateNodeSelectionPolicy(feedback NodeSelectionFeedback) {
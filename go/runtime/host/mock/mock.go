@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
@@ -86,6 +87,25 @@ func (r *runtime) Call(ctx context.Context, body *protocol.Body) (*protocol.Body
 			},
 			// No RakSig in mock response.
 		}}, nil
+	case body.RuntimeCheckTxBatchRequest != nil:
+		rq := body.RuntimeCheckTxBatchRequest
+
+		// All transactions are accepted except for empty ones.
+		var results transaction.RawBatch
+		for _, input := range rq.Inputs {
+			var output transaction.TxnOutput
+			if len(input) == 0 {
+				errMsg := "(mock) empty transaction"
+				output.Error = &errMsg
+			} else {
+				output.Success = cbor.Marshal(transaction.TxnCheckResult{})
+			}
+			results = append(results, cbor.Marshal(output))
+		}
+
+		return &protocol.Body{RuntimeCheckTxBatchResponse: &protocol.RuntimeCheckTxBatchResponse{
+			Results: results,
+		}}, nil
 	default:
 		return nil, fmt.Errorf("(mock) method not supported")
 	}
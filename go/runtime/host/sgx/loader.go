@@ -0,0 +1,31 @@
+package sgx
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// loaderVersionTimeout is the maximum amount of time to wait for the runtime loader to report
+// its version.
+const loaderVersionTimeout = 5 * time.Second
+
+// GetLoaderVersion queries the runtime loader binary at the given path for its version.
+func GetLoaderVersion(loaderPath string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), loaderVersionTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, loaderPath, "--version").Output() // nolint: gosec
+	if err != nil {
+		return "", fmt.Errorf("sgx: failed to query runtime loader version: %w", err)
+	}
+
+	// Output is in the form of "<name> <version>".
+	fields := strings.Fields(string(out))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("sgx: malformed runtime loader version")
+	}
+	return fields[len(fields)-1], nil
+}
@@ -224,7 +224,7 @@ func (s *sgxProvisioner) initCapabilityTEE(ctx context.Context, rt host.Runtime,
 	}
 	ts.epidGID = binary.LittleEndian.Uint32(qi.GID[:])
 
-	spidInfo, err := s.ias.GetSPIDInfo(ctx)
+	spidInfo, err := s.ias.GetRuntimeSPIDInfo(ctx, ts.runtimeID)
 	if err != nil {
 		return nil, fmt.Errorf("error while getting IAS SPID information: %w", err)
 	}
@@ -8,6 +8,8 @@ import (
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/runtime/history"
 	"github.com/oasisprotocol/oasis-core/go/runtime/tagindexer"
 )
@@ -26,8 +28,43 @@ const (
 
 	// CfgTagIndexerBackend configures the history tag indexer backend.
 	CfgTagIndexerBackend = "runtime.history.tag_indexer.backend"
+	// CfgTagIndexerNumKept configures the number of last rounds kept in the
+	// tag index.
+	CfgTagIndexerNumKept = "runtime.history.tag_indexer.num_kept"
+	// CfgTagIndexerCompactionInterval configures the tag index compaction
+	// interval.
+	CfgTagIndexerCompactionInterval = "runtime.history.tag_indexer.compaction_interval"
+
+	// CfgStorageNodeCacheMaxSize configures the maximum size of the persistent cache of
+	// storage nodes fetched by the storage client.
+	CfgStorageNodeCacheMaxSize = "runtime.storage.node_cache.max_size"
+	// CfgStorageNodes configures the storage nodes (hex-encoded node IDs) that the storage
+	// client should use instead of following the runtime's storage committee.
+	CfgStorageNodes = "runtime.storage.nodes"
+
+	// CfgClientMaxInFlightTxs configures the maximum number of transactions submitted via the
+	// runtime client that may be waiting for inclusion at the same time.
+	CfgClientMaxInFlightTxs = "runtime.client.max_in_flight_txs"
+
+	// CfgRuntimeConfig configures per-runtime overrides of runtime settings. Each item should be
+	// in the form <runtime-id>:<setting>=<value> where setting is the name of a per-runtime
+	// configuration flag without the "runtime." prefix (e.g., history.pruner.strategy).
+	CfgRuntimeConfig = "runtime.config"
 )
 
+// perRuntimeSettings is the list of configuration flags that can be overridden per runtime.
+var perRuntimeSettings = []string{
+	CfgHistoryPrunerStrategy,
+	CfgHistoryPrunerInterval,
+	CfgHistoryPrunerKeepLastNum,
+	CfgTagIndexerBackend,
+	CfgTagIndexerNumKept,
+	CfgTagIndexerCompactionInterval,
+	CfgStorageNodeCacheMaxSize,
+	CfgStorageNodes,
+	CfgClientMaxInFlightTxs,
+}
+
 // Flags has the configuration flags.
 var Flags = flag.NewFlagSet("", flag.ContinueOnError)
 
@@ -38,28 +75,98 @@ type RuntimeConfig struct {
 
 	// TagIndexer configures the tag indexer backend.
 	TagIndexer tagindexer.BackendFactory
+
+	// TagIndexerConfig configures the tag indexer retention and compaction.
+	TagIndexerConfig tagindexer.Config
+
+	// StorageNodeCacheMaxSize is the maximum size of the persistent storage node cache in
+	// bytes. Zero disables the cache.
+	StorageNodeCacheMaxSize uint64
+
+	// StorageNodes is the list of storage nodes the storage client should use. In case the
+	// list is empty, the storage client follows the runtime's storage committee.
+	StorageNodes []signature.PublicKey
+
+	// MaxInFlightTxs is the maximum number of transactions submitted via the runtime client
+	// that may be waiting for inclusion at the same time. Zero means no limit.
+	MaxInFlightTxs uint64
 }
 
-func newConfig() (*RuntimeConfig, error) {
+// parseRuntimeConfigOverrides parses per-runtime configuration overrides in the form of
+// <runtime-id>:<setting>=<value> items.
+func parseRuntimeConfigOverrides(rawItems []string) (map[common.Namespace]map[string]string, error) {
+	overrides := make(map[common.Namespace]map[string]string)
+	for _, rawItem := range rawItems {
+		atoms := strings.SplitN(rawItem, ":", 2)
+		if len(atoms) != 2 {
+			return nil, fmt.Errorf("runtime/registry: malformed runtime configuration override: %s", rawItem)
+		}
+
+		var id common.Namespace
+		if err := id.UnmarshalHex(atoms[0]); err != nil {
+			return nil, fmt.Errorf("runtime/registry: malformed runtime identifier '%s': %w", atoms[0], err)
+		}
+
+		kv := strings.SplitN(atoms[1], "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("runtime/registry: malformed runtime configuration override: %s", rawItem)
+		}
+		key := "runtime." + kv[0]
+
+		var supported bool
+		for _, setting := range perRuntimeSettings {
+			if key == setting {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return nil, fmt.Errorf("runtime/registry: setting '%s' cannot be configured per runtime", kv[0])
+		}
+
+		if overrides[id] == nil {
+			overrides[id] = make(map[string]string)
+		}
+		overrides[id][key] = kv[1]
+	}
+	return overrides, nil
+}
+
+// newConfig creates a new runtime configuration from the global configuration with the given
+// per-runtime overrides applied.
+func newConfig(overrides map[string]string) (*RuntimeConfig, error) {
+	v := viper.New()
+	for _, key := range perRuntimeSettings {
+		v.SetDefault(key, viper.Get(key))
+	}
+	for key, value := range overrides {
+		switch key {
+		case CfgStorageNodes:
+			v.Set(key, strings.Split(value, ","))
+		default:
+			v.Set(key, value)
+		}
+	}
+
 	var cfg RuntimeConfig
 
-	strategy := viper.GetString(CfgHistoryPrunerStrategy)
+	strategy := v.GetString(CfgHistoryPrunerStrategy)
 	switch strings.ToLower(strategy) {
 	case history.PrunerStrategyNone:
 		cfg.History.Pruner = history.NewNonePruner()
 	case history.PrunerStrategyKeepLast:
-		numKept := viper.GetUint64(CfgHistoryPrunerKeepLastNum)
+		numKept := v.GetUint64(CfgHistoryPrunerKeepLastNum)
 		cfg.History.Pruner = history.NewKeepLastPruner(numKept)
 	default:
 		return nil, fmt.Errorf("runtime/registry: unknown history pruner strategy: %s", strategy)
 	}
 
-	cfg.History.PruneInterval = viper.GetDuration(CfgHistoryPrunerInterval)
+	cfg.History.PruneInterval = v.GetDuration(CfgHistoryPrunerInterval)
 	if cfg.History.PruneInterval.Seconds() < 1.0 {
 		return nil, fmt.Errorf("runtime/registry: history prune interval must be >= 1s (got %s)", cfg.History.PruneInterval)
 	}
 
-	tagIndexer := viper.GetString(CfgTagIndexerBackend)
+	tagIndexer := v.GetString(CfgTagIndexerBackend)
 	switch strings.ToLower(tagIndexer) {
 	case "":
 		cfg.TagIndexer = tagindexer.NewNopBackend()
@@ -68,6 +175,24 @@ func newConfig() (*RuntimeConfig, error) {
 	default:
 		return nil, fmt.Errorf("runtime/registry: unknown tag indexer backend: %s", tagIndexer)
 	}
+	cfg.TagIndexerConfig.NumKept = v.GetUint64(CfgTagIndexerNumKept)
+	cfg.TagIndexerConfig.CompactionInterval = v.GetDuration(CfgTagIndexerCompactionInterval)
+
+	cfg.StorageNodeCacheMaxSize = uint64(v.GetSizeInBytes(CfgStorageNodeCacheMaxSize))
+
+	for _, rawID := range v.GetStringSlice(CfgStorageNodes) {
+		if rawID == "" {
+			continue
+		}
+
+		var nodeID signature.PublicKey
+		if err := nodeID.UnmarshalHex(rawID); err != nil {
+			return nil, fmt.Errorf("runtime/registry: malformed storage node ID '%s': %w", rawID, err)
+		}
+		cfg.StorageNodes = append(cfg.StorageNodes, nodeID)
+	}
+
+	cfg.MaxInFlightTxs = v.GetUint64(CfgClientMaxInFlightTxs)
 
 	return &cfg, nil
 }
@@ -80,6 +205,15 @@ func init() {
 	Flags.Uint64(CfgHistoryPrunerKeepLastNum, 600, "Keep last history pruner: number of last rounds to keep")
 
 	Flags.String(CfgTagIndexerBackend, "", "Runtime tag indexer backend (disabled by default)")
+	Flags.Uint64(CfgTagIndexerNumKept, 0, "Number of last rounds to keep in the tag index (0 to only prune with history)")
+	Flags.Duration(CfgTagIndexerCompactionInterval, 1*time.Hour, "Tag index compaction interval (0 to disable)")
+
+	Flags.String(CfgStorageNodeCacheMaxSize, "0", "Maximum size of the persistent storage node cache (disabled by default)")
+	Flags.StringSlice(CfgStorageNodes, nil, "Storage node IDs (hex-encoded) to use instead of the storage committee")
+
+	Flags.Uint64(CfgClientMaxInFlightTxs, 0, "Maximum number of runtime client transactions waiting for inclusion (0 for no limit)")
+
+	Flags.StringSlice(CfgRuntimeConfig, nil, "Per-runtime configuration override (<runtime-id>:<setting>=<value>)")
 
 	_ = viper.BindPFlags(Flags)
 }
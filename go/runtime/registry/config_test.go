@@ -0,0 +1,79 @@
+package registry
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/runtime/history"
+)
+
+func TestParseRuntimeConfigOverrides(t *testing.T) {
+	require := require.New(t)
+
+	var id1, id2 common.Namespace
+	require.NoError(id1.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000"))
+	require.NoError(id2.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000001"))
+
+	overrides, err := parseRuntimeConfigOverrides([]string{
+		id1.String() + ":history.pruner.strategy=keep_last",
+		id1.String() + ":client.max_in_flight_txs=10",
+		id2.String() + ":storage.nodes=a,b",
+	})
+	require.NoError(err, "parseRuntimeConfigOverrides")
+	require.Len(overrides, 2)
+	require.EqualValues(map[string]string{
+		CfgHistoryPrunerStrategy: "keep_last",
+		CfgClientMaxInFlightTxs:  "10",
+	}, overrides[id1])
+	require.EqualValues(map[string]string{
+		CfgStorageNodes: "a,b",
+	}, overrides[id2])
+
+	for _, item := range []string{
+		"not-a-runtime-id:history.pruner.strategy=keep_last",
+		id1.String(),
+		id1.String() + ":history.pruner.strategy",
+		id1.String() + ":supported=foo",
+		id1.String() + ":unknown.setting=foo",
+	} {
+		_, err = parseRuntimeConfigOverrides([]string{item})
+		require.Error(err, "parseRuntimeConfigOverrides should fail for '%s'", item)
+	}
+}
+
+func TestNewConfigOverrides(t *testing.T) {
+	require := require.New(t)
+
+	cfg, err := newConfig(nil)
+	require.NoError(err, "newConfig")
+	require.EqualValues(0, cfg.MaxInFlightTxs)
+	require.Empty(cfg.StorageNodes)
+
+	var node1, node2 signature.PublicKey
+	require.NoError(node1.UnmarshalHex("4000000000000000000000000000000000000000000000000000000000000000"))
+	require.NoError(node2.UnmarshalHex("4000000000000000000000000000000000000000000000000000000000000001"))
+
+	cfg, err = newConfig(map[string]string{
+		CfgHistoryPrunerStrategy:    history.PrunerStrategyKeepLast,
+		CfgHistoryPrunerKeepLastNum: "100",
+		CfgStorageNodes:             hex.EncodeToString(node1[:]) + "," + hex.EncodeToString(node2[:]),
+		CfgClientMaxInFlightTxs:     "10",
+	})
+	require.NoError(err, "newConfig")
+	require.EqualValues(10, cfg.MaxInFlightTxs)
+	require.Equal([]signature.PublicKey{node1, node2}, cfg.StorageNodes)
+
+	_, err = newConfig(map[string]string{
+		CfgHistoryPrunerStrategy: "not-a-strategy",
+	})
+	require.Error(err, "newConfig should fail with an unknown pruner strategy")
+
+	_, err = newConfig(map[string]string{
+		CfgStorageNodes: "not-a-node-id",
+	})
+	require.Error(err, "newConfig should fail with malformed storage node IDs")
+}
@@ -6,6 +6,7 @@ package registry
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"sync"
 
 	"github.com/spf13/viper"
@@ -16,9 +17,12 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/committee"
 	"github.com/oasisprotocol/oasis-core/go/runtime/history"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
 	"github.com/oasisprotocol/oasis-core/go/runtime/localstorage"
 	"github.com/oasisprotocol/oasis-core/go/runtime/tagindexer"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	storageAPI "github.com/oasisprotocol/oasis-core/go/storage/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/client"
 )
@@ -30,6 +34,9 @@ const (
 
 	// LocalStorageFile is the filename of the worker's local storage database.
 	LocalStorageFile = "worker-local-storage.badger.db"
+
+	// StorageNodeCacheFile is the filename of the persistent storage node cache database.
+	StorageNodeCacheFile = "storage-node-cache.badger.db"
 )
 
 // Registry is the running node's runtime registry interface.
@@ -62,6 +69,9 @@ type Runtime interface {
 	// ID is the runtime identifier.
 	ID() common.Namespace
 
+	// Config returns the per-runtime configuration.
+	Config() *RuntimeConfig
+
 	// RegistryDescriptor waits for the runtime to be registered and
 	// then returns its registry descriptor.
 	RegistryDescriptor(ctx context.Context) (*registry.Runtime, error)
@@ -72,6 +82,13 @@ type Runtime interface {
 	// RegisterStorage sets the given local storage backend for the runtime.
 	RegisterStorage(storage storageAPI.Backend)
 
+	// SetHostedRuntime sets the runtime instance hosted by the local node.
+	SetHostedRuntime(rt host.Runtime)
+
+	// HostedRuntime returns the runtime instance hosted by the local node or nil in case the
+	// runtime is not hosted locally.
+	HostedRuntime() host.Runtime
+
 	// History returns the history for this runtime.
 	History() history.History
 
@@ -83,17 +100,24 @@ type Runtime interface {
 
 	// LocalStorage returns the per-runtime local storage.
 	LocalStorage() localstorage.LocalStorage
+
+	// StorageNodeCache returns the per-runtime persistent cache of storage nodes fetched by
+	// storage clients or nil in case the cache is disabled.
+	StorageNodeCache() *client.NodeCache
 }
 
 type runtime struct {
 	sync.RWMutex
 
 	id         common.Namespace
+	cfg        *RuntimeConfig
 	descriptor *registry.Runtime
 
 	consensus    consensus.Backend
 	storage      storageAPI.Backend
 	localStorage localstorage.LocalStorage
+	hostedRT     host.Runtime
+	nodeCache    *client.NodeCache
 
 	history        history.History
 	tagIndexer     *tagindexer.Service
@@ -110,6 +134,10 @@ func (r *runtime) ID() common.Namespace {
 	return r.id
 }
 
+func (r *runtime) Config() *RuntimeConfig {
+	return r.cfg
+}
+
 func (r *runtime) RegistryDescriptor(ctx context.Context) (*registry.Runtime, error) {
 	// Wait for the descriptor to be ready.
 	select {
@@ -142,6 +170,20 @@ func (r *runtime) RegisterStorage(storage storageAPI.Backend) {
 	r.storage = storage
 }
 
+func (r *runtime) SetHostedRuntime(rt host.Runtime) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.hostedRT = rt
+}
+
+func (r *runtime) HostedRuntime() host.Runtime {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.hostedRT
+}
+
 func (r *runtime) History() history.History {
 	return r.history
 }
@@ -164,6 +206,10 @@ func (r *runtime) LocalStorage() localstorage.LocalStorage {
 	return r.localStorage
 }
 
+func (r *runtime) StorageNodeCache() *client.NodeCache {
+	return r.nodeCache
+}
+
 func (r *runtime) stop() {
 	// Stop watching runtime updates.
 	r.cancelCtx()
@@ -180,6 +226,10 @@ func (r *runtime) stop() {
 	}
 	// Close history keeper.
 	r.history.Close()
+	// Close storage node cache.
+	if r.nodeCache != nil {
+		r.nodeCache.Close()
+	}
 }
 
 func (r *runtime) watchUpdates(ctx context.Context, ch <-chan *registry.Runtime, sub pubsub.ClosableSubscription) {
@@ -218,7 +268,36 @@ func (r *runtime) finishInitialization(ctx context.Context, ident *identity.Iden
 	defer r.Unlock()
 
 	if r.storage == nil {
-		storageBackend, err := client.New(ctx, r.id, ident, r.consensus.Scheduler(), r.consensus.Registry(), r)
+		var opts []client.Option
+		if r.nodeCache != nil {
+			opts = append(opts, client.WithNodeCache(r.nodeCache))
+		}
+
+		var (
+			storageBackend storageAPI.Backend
+			err            error
+		)
+		switch len(r.cfg.StorageNodes) {
+		case 0:
+			// Follow the runtime's storage committee.
+			var committeeWatcher committee.Watcher
+			committeeWatcher, err = committee.NewWatcher(
+				ctx,
+				r.consensus.Scheduler(),
+				r.consensus.Registry(),
+				r.id,
+				scheduler.KindStorage,
+				committee.WithAutomaticEpochTransitions(),
+			)
+			if err != nil {
+				return fmt.Errorf("runtime/registry: cannot create storage committee watcher for runtime %s: %w", r.id, err)
+			}
+
+			storageBackend, err = client.NewForCommittee(ctx, r.id, ident, committeeWatcher.Nodes(), r, opts...)
+		default:
+			// Use the explicitly configured storage nodes.
+			storageBackend, err = client.NewForNodes(ctx, r.id, ident, r.consensus.Registry(), r.cfg.StorageNodes, r, opts...)
+		}
 		if err != nil {
 			return fmt.Errorf("runtime/registry: cannot create storage for runtime %s: %w", r.id, err)
 		}
@@ -241,6 +320,7 @@ type runtimeRegistry struct {
 	dataDir   string
 	consensus consensus.Backend
 	identity  *identity.Identity
+	overrides map[common.Namespace]map[string]string
 
 	runtimes map[common.Namespace]*runtime
 }
@@ -268,7 +348,11 @@ func (r *runtimeRegistry) Runtimes() []Runtime {
 }
 
 func (r *runtimeRegistry) NewUnmanagedRuntime(ctx context.Context, runtimeID common.Namespace) (Runtime, error) {
-	return newRuntime(ctx, runtimeID, r.consensus, r.logger)
+	cfg, err := newConfig(r.overrides[runtimeID])
+	if err != nil {
+		return nil, err
+	}
+	return newRuntime(ctx, runtimeID, cfg, r.consensus, r.logger)
 }
 
 func (r *runtimeRegistry) StorageRouter() storageAPI.Backend {
@@ -313,7 +397,7 @@ func (r *runtimeRegistry) addSupportedRuntime(ctx context.Context, id common.Nam
 		return err
 	}
 
-	rt, err := newRuntime(ctx, id, r.consensus, r.logger)
+	rt, err := newRuntime(ctx, id, cfg, r.consensus, r.logger)
 	if err != nil {
 		return err
 	}
@@ -344,8 +428,22 @@ func (r *runtimeRegistry) addSupportedRuntime(ctx context.Context, id common.Nam
 	var ns common.Namespace
 	copy(ns[:], id[:])
 
+	// Create runtime-specific persistent storage node cache.
+	var nodeCache *client.NodeCache
+	if cfg.StorageNodeCacheMaxSize > 0 {
+		nodeCache, err = client.NewNodeCache(filepath.Join(path, StorageNodeCacheFile), cfg.StorageNodeCacheMaxSize)
+		if err != nil {
+			return fmt.Errorf("runtime/registry: cannot create storage node cache for runtime %s: %w", id, err)
+		}
+		defer func() {
+			if rerr != nil {
+				nodeCache.Close()
+			}
+		}()
+	}
+
 	// Create runtime tag indexer (to be started later).
-	tagIndexer, err := tagindexer.New(path, cfg.TagIndexer, history, r.consensus.RootHash())
+	tagIndexer, err := tagindexer.New(path, cfg.TagIndexer, &cfg.TagIndexerConfig, history, r.consensus.RootHash())
 	if err != nil {
 		return fmt.Errorf("runtime/registry: cannot create tag indexer for runtime %s: %w", id, err)
 	}
@@ -356,6 +454,7 @@ func (r *runtimeRegistry) addSupportedRuntime(ctx context.Context, id common.Nam
 	}
 
 	rt.localStorage = localStorage
+	rt.nodeCache = nodeCache
 	rt.history = history
 	rt.tagIndexer = tagIndexer
 	r.runtimes[id] = rt
@@ -363,7 +462,7 @@ func (r *runtimeRegistry) addSupportedRuntime(ctx context.Context, id common.Nam
 	return nil
 }
 
-func newRuntime(ctx context.Context, id common.Namespace, consensus consensus.Backend, logger *logging.Logger) (*runtime, error) {
+func newRuntime(ctx context.Context, id common.Namespace, cfg *RuntimeConfig, consensus consensus.Backend, logger *logging.Logger) (*runtime, error) {
 	// Start watching this runtime's descriptor.
 	ch, sub, err := consensus.Registry().WatchRuntimes(ctx)
 	if err != nil {
@@ -373,6 +472,7 @@ func newRuntime(ctx context.Context, id common.Namespace, consensus consensus.Ba
 
 	rt := &runtime{
 		id:                 id,
+		cfg:                cfg,
 		consensus:          consensus,
 		cancelCtx:          cancel,
 		descriptorCh:       make(chan struct{}),
@@ -394,21 +494,32 @@ func New(ctx context.Context, dataDir string, consensus consensus.Backend, ident
 		runtimes:  make(map[common.Namespace]*runtime),
 	}
 
-	cfg, err := newConfig()
+	runtimes, err := ParseRuntimeMap(viper.GetStringSlice(CfgSupported))
 	if err != nil {
 		return nil, err
 	}
-
-	runtimes, err := ParseRuntimeMap(viper.GetStringSlice(CfgSupported))
+	r.overrides, err = parseRuntimeConfigOverrides(viper.GetStringSlice(CfgRuntimeConfig))
 	if err != nil {
 		return nil, err
 	}
+	for id := range r.overrides {
+		if _, ok := runtimes[id]; !ok {
+			return nil, fmt.Errorf("runtime/registry: configuration override for unsupported runtime %s", id)
+		}
+	}
+
 	for id := range runtimes {
 		r.logger.Info("adding supported runtime",
 			"id", id,
 		)
 
-		if err := r.addSupportedRuntime(ctx, id, cfg); err != nil {
+		// Each runtime gets its own configuration with any per-runtime overrides applied.
+		var cfg *RuntimeConfig
+		if cfg, err = newConfig(r.overrides[id]); err != nil {
+			return nil, fmt.Errorf("failed to configure runtime %s: %w", id, err)
+		}
+
+		if err = r.addSupportedRuntime(ctx, id, cfg); err != nil {
 			r.logger.Error("failed to add supported runtime",
 				"err", err,
 				"id", id,
@@ -129,11 +129,7 @@ func (s *scheduler) UpdateParameters(params registry.TxnSchedulerParameters) err
 	if params.Algorithm != Name {
 		return fmt.Errorf("unexpected transaction scheduling algorithm: %s", params.Algorithm)
 	}
-	if err := s.txPool.UpdateConfig(txpool.Config{
-		MaxBatchSize:      params.MaxBatchSize,
-		MaxBatchSizeBytes: params.MaxBatchSizeBytes,
-		MaxPoolSize:       s.maxTxPoolSize,
-	}); err != nil {
+	if err := s.txPool.UpdateConfig(poolConfig(s.maxTxPoolSize, params)); err != nil {
 		return fmt.Errorf("error updating parameters: %w", err)
 	}
 	return nil
@@ -143,17 +139,26 @@ func (s *scheduler) Name() string {
 	return Name
 }
 
+func poolConfig(maxTxPoolSize uint64, params registry.TxnSchedulerParameters) txpool.Config {
+	return txpool.Config{
+		MaxBatchSize:      params.MaxBatchSize,
+		MaxBatchSizeBytes: params.MaxBatchSizeBytes,
+		MaxPoolSize:       maxTxPoolSize,
+		FlushBytes:        params.BatchFlushBytes,
+		FlushTxAge:        params.BatchFlushTxAge,
+		FlushGas:          params.BatchFlushGas,
+		TxGasBase:         params.TxGasBase,
+		TxGasPerByte:      params.TxGasPerByte,
+	}
+}
+
 // New creates a new simple scheduler.
 func New(txPoolImpl string, maxTxPoolSize uint64, params registry.TxnSchedulerParameters) (api.Scheduler, error) {
 	if params.Algorithm != Name {
 		return nil, fmt.Errorf("unexpected transaction scheduling algorithm: %s", params.Algorithm)
 	}
 
-	poolCfg := txpool.Config{
-		MaxBatchSize:      params.MaxBatchSize,
-		MaxBatchSizeBytes: params.MaxBatchSizeBytes,
-		MaxPoolSize:       maxTxPoolSize,
-	}
+	poolCfg := poolConfig(maxTxPoolSize, params)
 	var pool txpool.TxPool
 	switch txPoolImpl {
 	case orderedmap.Name:
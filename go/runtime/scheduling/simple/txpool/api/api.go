@@ -3,6 +3,7 @@ package api
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	p2pError "github.com/oasisprotocol/oasis-core/go/worker/common/p2p/error"
@@ -19,6 +20,21 @@ type Config struct {
 	MaxPoolSize       uint64
 	MaxBatchSize      uint64
 	MaxBatchSizeBytes uint64
+
+	// FlushBytes is the total size of queued transactions at which a batch
+	// is ready. If zero, MaxBatchSizeBytes is used.
+	FlushBytes uint64
+	// FlushTxAge is the age of the oldest queued transaction at which a batch
+	// is ready. If zero, transaction age is ignored.
+	FlushTxAge time.Duration
+	// FlushGas is the total estimated gas of queued transactions at which a
+	// batch is ready. If zero, estimated gas is ignored.
+	FlushGas uint64
+
+	// TxGasBase is the estimated gas of each transaction.
+	TxGasBase uint64
+	// TxGasPerByte is the estimated gas per transaction byte.
+	TxGasPerByte uint64
 }
 
 // TxPool is the transaction pool interface.
@@ -33,6 +49,10 @@ type TxPool interface {
 	AddBatch(batch [][]byte) error
 
 	// GetBatch gets a transaction batch from the transaction pool.
+	//
+	// Unless forced, a batch is only returned in case any of the configured
+	// flush triggers (batch size, total size, oldest transaction age or
+	// estimated gas) fired.
 	GetBatch(force bool) [][]byte
 
 	// RemoveBatch removes a batch from the transaction pool.
@@ -5,6 +5,7 @@ import (
 	"container/list"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 
@@ -21,6 +22,8 @@ type pair struct {
 	Key   hash.Hash
 	Value []byte
 
+	addedAt time.Time
+	gas     uint64
 	element *list.Element
 }
 
@@ -30,10 +33,19 @@ type orderedMap struct {
 	transactions   map[hash.Hash]*pair
 	queue          *list.List
 	queueSizeBytes uint64
+	queueGas       uint64
 
 	maxTxPoolSize     uint64
 	maxBatchSize      uint64
 	maxBatchSizeBytes uint64
+
+	flushBytes   uint64
+	flushTxAge   time.Duration
+	flushGas     uint64
+	txGasBase    uint64
+	txGasPerByte uint64
+
+	now func() time.Time
 }
 
 // Implements api.TxPool.
@@ -100,8 +112,7 @@ func (q *orderedMap) GetBatch(force bool) [][]byte {
 	defer q.Unlock()
 
 	// Check if a batch is ready.
-	queueSize := uint64(q.queue.Len())
-	if queueSize < q.maxBatchSize && q.queueSizeBytes < q.maxBatchSizeBytes && !force {
+	if !force && !q.isBatchReadyLocked() {
 		return nil
 	}
 
@@ -148,6 +159,7 @@ func (q *orderedMap) RemoveBatch(batch [][]byte) error {
 			q.queue.Remove(pair.element)
 			delete(q.transactions, pair.Key)
 			q.queueSizeBytes -= uint64(len(pair.Value))
+			q.queueGas -= pair.gas
 		}
 	}
 
@@ -177,11 +189,12 @@ func (q *orderedMap) UpdateConfig(cfg api.Config) error {
 	q.maxBatchSize = cfg.MaxBatchSize
 	q.maxBatchSizeBytes = cfg.MaxBatchSizeBytes
 	q.maxTxPoolSize = cfg.MaxPoolSize
+	q.setFlushConfigLocked(cfg)
 
 	// Recheck the queue for any transactions that are bigger than the updated
 	// `maxBatchSizeBytes`.
 	newQueue := list.New()
-	var newMapSize uint64
+	var newMapSize, newGas uint64
 	newTxs := make(map[hash.Hash]*pair)
 	current := q.queue.Back()
 	for {
@@ -196,9 +209,11 @@ func (q *orderedMap) UpdateConfig(cfg api.Config) error {
 		if txSize > cfg.MaxBatchSizeBytes {
 			continue
 		}
+		el.gas = q.estimateGasLocked(el.Value)
 		newQueue.PushFront(el)
 		newTxs[el.Key] = el
 		newMapSize += txSize
+		newGas += el.gas
 
 		current = current.Prev()
 	}
@@ -207,6 +222,7 @@ func (q *orderedMap) UpdateConfig(cfg api.Config) error {
 	q.queue = newQueue
 	q.transactions = newTxs
 	q.queueSizeBytes = newMapSize
+	q.queueGas = newGas
 
 	return nil
 }
@@ -223,9 +239,45 @@ func (q *orderedMap) Clear() {
 
 	q.queue = list.New()
 	q.queueSizeBytes = 0
+	q.queueGas = 0
 	q.transactions = make(map[hash.Hash]*pair)
 }
 
+// NOTE: Assumes lock is held.
+func (q *orderedMap) setFlushConfigLocked(cfg api.Config) {
+	q.flushBytes = cfg.FlushBytes
+	if q.flushBytes == 0 {
+		q.flushBytes = cfg.MaxBatchSizeBytes
+	}
+	q.flushTxAge = cfg.FlushTxAge
+	q.flushGas = cfg.FlushGas
+	q.txGasBase = cfg.TxGasBase
+	q.txGasPerByte = cfg.TxGasPerByte
+}
+
+// NOTE: Assumes lock is held.
+func (q *orderedMap) estimateGasLocked(tx []byte) uint64 {
+	return q.txGasBase + q.txGasPerByte*uint64(len(tx))
+}
+
+// NOTE: Assumes lock is held.
+func (q *orderedMap) isBatchReadyLocked() bool {
+	switch {
+	case uint64(q.queue.Len()) >= q.maxBatchSize:
+		return true
+	case q.queueSizeBytes >= q.flushBytes:
+		return true
+	case q.flushGas > 0 && q.queueGas >= q.flushGas:
+		return true
+	case q.flushTxAge > 0 && q.queue.Len() > 0:
+		// The oldest transaction is at the back of the queue.
+		oldest := q.queue.Back().Value.(*pair)
+		return q.now().Sub(oldest.addedAt) >= q.flushTxAge
+	default:
+		return false
+	}
+}
+
 // NOTE: Assumes lock is held.
 func (q *orderedMap) isQueuedLocked(txHash hash.Hash) bool {
 	_, ok := q.transactions[txHash]
@@ -254,21 +306,27 @@ func (q *orderedMap) addTxLocked(tx []byte, txHash hash.Hash) {
 		return
 	}
 	p := &pair{
-		Key:   txHash,
-		Value: tx,
+		Key:     txHash,
+		Value:   tx,
+		addedAt: q.now(),
+		gas:     q.estimateGasLocked(tx),
 	}
 	p.element = q.queue.PushFront(p)
 	q.transactions[txHash] = p
 	q.queueSizeBytes += uint64(len(tx))
+	q.queueGas += p.gas
 }
 
 // New returns a new incoming queue.
 func New(cfg api.Config) api.TxPool {
-	return &orderedMap{
+	q := &orderedMap{
 		transactions:      make(map[hash.Hash]*pair),
 		queue:             list.New(),
 		maxTxPoolSize:     cfg.MaxPoolSize,
 		maxBatchSize:      cfg.MaxBatchSize,
 		maxBatchSizeBytes: cfg.MaxBatchSizeBytes,
+		now:               time.Now,
 	}
+	q.setFlushConfigLocked(cfg)
+	return q
 }
@@ -1,7 +1,11 @@
 package orderedmap
 
 import (
+	"fmt"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
 
 	tests "github.com/oasisprotocol/oasis-core/go/runtime/scheduling/simple/txpool"
 	"github.com/oasisprotocol/oasis-core/go/runtime/scheduling/simple/txpool/api"
@@ -16,6 +20,82 @@ func TestOrderedQueue(t *testing.T) {
 	tests.TxPoolImplementationTests(t, queue)
 }
 
+func TestOrderedQueueFlushTriggers(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1600000000, 0)
+	q := New(api.Config{
+		MaxPoolSize:       100,
+		MaxBatchSize:      10,
+		MaxBatchSizeBytes: 1000,
+	}).(*orderedMap)
+	q.now = func() time.Time { return now }
+
+	addTxs := func(n int, size int) {
+		for i := 0; i < n; i++ {
+			tx := make([]byte, size)
+			copy(tx, fmt.Sprintf("tx %d %d", q.Size(), size))
+			require.NoError(q.Add(tx), "Add")
+		}
+	}
+
+	// Batch size.
+	addTxs(9, 10)
+	require.Empty(q.GetBatch(false), "batch should not be ready below max batch size")
+	addTxs(1, 10)
+	require.Len(q.GetBatch(false), 10, "batch should be ready at max batch size")
+	q.Clear()
+
+	// Total size.
+	err := q.UpdateConfig(api.Config{
+		MaxPoolSize:       100,
+		MaxBatchSize:      10,
+		MaxBatchSizeBytes: 1000,
+		FlushBytes:        100,
+	})
+	require.NoError(err, "UpdateConfig")
+	addTxs(2, 40)
+	require.Empty(q.GetBatch(false), "batch should not be ready below flush bytes")
+	addTxs(1, 40)
+	require.Len(q.GetBatch(false), 3, "batch should be ready at flush bytes")
+	q.Clear()
+
+	// Estimated gas.
+	err = q.UpdateConfig(api.Config{
+		MaxPoolSize:       100,
+		MaxBatchSize:      10,
+		MaxBatchSizeBytes: 1000,
+		FlushGas:          1000,
+		TxGasBase:         100,
+		TxGasPerByte:      20,
+	})
+	require.NoError(err, "UpdateConfig")
+	addTxs(1, 20)
+	require.Empty(q.GetBatch(false), "batch should not be ready below flush gas")
+	addTxs(1, 20)
+	require.Len(q.GetBatch(false), 2, "batch should be ready at flush gas")
+	err = q.RemoveBatch(q.GetBatch(true)[:1])
+	require.NoError(err, "RemoveBatch")
+	require.Empty(q.GetBatch(false), "batch should not be ready after removing transactions")
+	q.Clear()
+
+	// Transaction age.
+	err = q.UpdateConfig(api.Config{
+		MaxPoolSize:       100,
+		MaxBatchSize:      10,
+		MaxBatchSizeBytes: 1000,
+		FlushTxAge:        time.Second,
+	})
+	require.NoError(err, "UpdateConfig")
+	require.Empty(q.GetBatch(false), "batch should not be ready for an empty pool")
+	addTxs(1, 10)
+	now = now.Add(500 * time.Millisecond)
+	addTxs(1, 10)
+	require.Empty(q.GetBatch(false), "batch should not be ready before the oldest transaction is too old")
+	now = now.Add(500 * time.Millisecond)
+	require.Len(q.GetBatch(false), 2, "batch should be ready once the oldest transaction is too old")
+}
+
 func BenchmarkOrderedQueue(b *testing.B) {
 	queue := New(api.Config{
 		MaxPoolSize:       10,
@@ -20,6 +20,8 @@ var (
 	ErrTagTooLong = errors.New("tagindexer: tag too long to process")
 	// ErrCorrupted is the error when index corruption is detected.
 	ErrCorrupted = errors.New("tagindexer: index corrupted")
+	// ErrMalformedCursor is the error when a query continuation cursor is malformed.
+	ErrMalformedCursor = errors.New("tagindexer: malformed cursor")
 
 	errNopBackend = errors.New("tagindexer: tag indexer is disabled")
 )
@@ -53,11 +55,18 @@ type QueryableBackend interface {
 	// identified by its block round and index.
 	QueryTxnByIndex(ctx context.Context, round uint64, index uint32) (hash.Hash, error)
 
+	// QueryTxnByHash queries the transaction tag index for the block round and index of a
+	// specific transaction identified by its hash.
+	QueryTxnByHash(ctx context.Context, txHash hash.Hash) (uint64, uint32, error)
+
 	// QueryTxns queries the transaction tag index of a given runtime with a complex
-	// query and returns multiple results.
+	// query and returns multiple results, ordered by round and transaction index.
+	//
+	// In case there may be more results than returned, a continuation cursor is also returned
+	// which can be used in a subsequent query to fetch the next results.
 	//
 	// If a backend does not support this method it may return ErrUnsupported.
-	QueryTxns(ctx context.Context, query api.Query) (Results, error)
+	QueryTxns(ctx context.Context, query api.Query) (Results, []byte, error)
 
 	// WaitBlockIndexed waits for a block to be indexed by the indexer.
 	WaitBlockIndexed(ctx context.Context, round uint64) error
@@ -84,6 +93,12 @@ type Backend interface {
 	// Prune removes entries associated with the given round.
 	Prune(ctx context.Context, round uint64) error
 
+	// PruneBefore removes entries associated with all rounds before the given round.
+	PruneBefore(ctx context.Context, round uint64) error
+
+	// Compact compacts the underlying index, reclaiming space used by pruned entries.
+	Compact(ctx context.Context) error
+
 	// Close closes the backend.
 	//
 	// After this method is called, no further operations should be done.
@@ -107,6 +122,14 @@ func (n *nopBackend) Prune(ctx context.Context, round uint64) error {
 	return nil
 }
 
+func (n *nopBackend) PruneBefore(ctx context.Context, round uint64) error {
+	return nil
+}
+
+func (n *nopBackend) Compact(ctx context.Context) error {
+	return nil
+}
+
 func (n *nopBackend) QueryBlock(ctx context.Context, blockHash hash.Hash) (uint64, error) {
 	return 0, errNopBackend
 }
@@ -119,8 +142,12 @@ func (n *nopBackend) QueryTxnByIndex(ctx context.Context, round uint64, index ui
 	return hash.Hash{}, errNopBackend
 }
 
-func (n *nopBackend) QueryTxns(ctx context.Context, query api.Query) (Results, error) {
-	return nil, errNopBackend
+func (n *nopBackend) QueryTxnByHash(ctx context.Context, txHash hash.Hash) (uint64, uint32, error) {
+	return 0, 0, errNopBackend
+}
+
+func (n *nopBackend) QueryTxns(ctx context.Context, query api.Query) (Results, []byte, error) {
+	return nil, nil, errNopBackend
 }
 
 func (n *nopBackend) WaitBlockIndexed(ctx context.Context, round uint64) error {
@@ -2,6 +2,7 @@ package tagindexer
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -87,6 +88,14 @@ func testOperations(t *testing.T, backend Backend) {
 	require.NoError(t, err, "QueryTxnByIndex")
 	require.EqualValues(t, tx2Hash, txnHash)
 
+	round, txnIndex, err = backend.QueryTxnByHash(ctx, tx2Hash)
+	require.NoError(t, err, "QueryTxnByHash")
+	require.EqualValues(t, 42, round)
+	require.EqualValues(t, 1, txnIndex)
+
+	_, _, err = backend.QueryTxnByHash(ctx, tx3Hash)
+	require.Equal(t, api.ErrNotFound, err, "QueryTxnByHash must return a not found error")
+
 	var blockHash2 hash.Hash
 	blockHash2.FromBytes([]byte("this is a fake block hash 2"))
 
@@ -101,6 +110,7 @@ func testOperations(t *testing.T, backend Backend) {
 		// Tags.
 		transaction.Tags{
 			transaction.Tag{Key: []byte("foo"), Value: []byte("bar"), TxHash: tx3Hash},
+			transaction.Tag{Key: []byte("memo"), Value: []byte("Payment for invoice 123"), TxHash: tx3Hash},
 		},
 	)
 	require.NoError(t, err, "Index")
@@ -127,7 +137,7 @@ func testOperations(t *testing.T, backend Backend) {
 			{Key: []byte("hello"), Values: [][]byte{[]byte("world")}},
 		},
 	}
-	results, err := backend.QueryTxns(ctx, query)
+	results, _, err := backend.QueryTxns(ctx, query)
 	require.NoError(t, err, "QueryTxns")
 	require.Len(t, results, 1)
 	require.Contains(t, results, uint64(42))
@@ -140,7 +150,7 @@ func testOperations(t *testing.T, backend Backend) {
 			{Key: []byte("hello"), Values: [][]byte{[]byte("worlx"), []byte("world")}},
 		},
 	}
-	results, err = backend.QueryTxns(ctx, query)
+	results, _, err = backend.QueryTxns(ctx, query)
 	require.NoError(t, err, "QueryTxns")
 	require.NoError(t, err, "QueryTxns")
 	require.Len(t, results, 1)
@@ -148,6 +158,91 @@ func testOperations(t *testing.T, backend Backend) {
 	require.Len(t, results[42], 2)
 	require.Contains(t, results[42], Result{TxHash: tx1Hash, TxIndex: 0})
 	require.Contains(t, results[42], Result{TxHash: tx2Hash, TxIndex: 1})
+
+	// Test prefix queries.
+	query = api.Query{
+		Conditions: []api.QueryCondition{
+			{Key: []byte("foo"), Values: [][]byte{[]byte("ba")}, Match: api.QueryMatchPrefix},
+		},
+	}
+	results, _, err = backend.QueryTxns(ctx, query)
+	require.NoError(t, err, "QueryTxns")
+	require.Len(t, results, 1)
+	require.Equal(t, []Result{{TxHash: tx3Hash, TxIndex: 0}}, results[43])
+
+	// Test full-text queries.
+	query = api.Query{
+		Conditions: []api.QueryCondition{
+			{Key: []byte("memo"), Values: [][]byte{[]byte("invoice payment")}, Match: api.QueryMatchFullText},
+		},
+	}
+	results, _, err = backend.QueryTxns(ctx, query)
+	require.NoError(t, err, "QueryTxns")
+	require.Len(t, results, 1)
+	require.Equal(t, []Result{{TxHash: tx3Hash, TxIndex: 0}}, results[43])
+
+	query.Conditions[0].Values = [][]byte{[]byte("invoice refund")}
+	results, _, err = backend.QueryTxns(ctx, query)
+	require.NoError(t, err, "QueryTxns")
+	require.Len(t, results, 0, "full-text queries should require all words to match")
+
+	// Exact queries should not match partial values.
+	query.Conditions[0].Match = api.QueryMatchExact
+	query.Conditions[0].Values = [][]byte{[]byte("invoice")}
+	results, _, err = backend.QueryTxns(ctx, query)
+	require.NoError(t, err, "QueryTxns")
+	require.Len(t, results, 0, "exact queries should not match partial values")
+
+	// Test conditions on multiple tags (combined using AND).
+	query = api.Query{
+		Conditions: []api.QueryCondition{
+			{Key: []byte("hello"), Values: [][]byte{[]byte("world")}},
+			{Key: []byte("some"), Values: [][]byte{[]byte("wo")}, Match: api.QueryMatchPrefix},
+		},
+	}
+	results, _, err = backend.QueryTxns(ctx, query)
+	require.NoError(t, err, "QueryTxns")
+	require.Len(t, results, 1)
+	require.Equal(t, []Result{{TxHash: tx1Hash, TxIndex: 0}}, results[42])
+
+	query.Conditions[0].Match = api.QueryMatch(0xff)
+	_, _, err = backend.QueryTxns(ctx, query)
+	require.Error(t, err, "QueryTxns should fail for unsupported match kinds")
+
+	// Test pagination.
+	query = api.Query{
+		Conditions: []api.QueryCondition{
+			{Key: []byte("hello"), Values: [][]byte{[]byte("world")}},
+		},
+		Limit: 1,
+	}
+	results, cursor, err := backend.QueryTxns(ctx, query)
+	require.NoError(t, err, "QueryTxns (first page)")
+	require.Equal(t, Results{42: {{TxHash: tx1Hash, TxIndex: 0}}}, results)
+	require.NotNil(t, cursor, "QueryTxns should return a cursor when the limit is reached")
+
+	query.Cursor = cursor
+	results, cursor, err = backend.QueryTxns(ctx, query)
+	require.NoError(t, err, "QueryTxns (second page)")
+	require.Equal(t, Results{42: {{TxHash: tx2Hash, TxIndex: 1}}}, results)
+	require.NotNil(t, cursor, "QueryTxns should return a cursor when the limit is reached")
+
+	query.Cursor = cursor
+	results, cursor, err = backend.QueryTxns(ctx, query)
+	require.NoError(t, err, "QueryTxns (last page)")
+	require.Len(t, results, 0)
+	require.Nil(t, cursor, "QueryTxns should not return a cursor when there are no more results")
+
+	query.Limit = 0
+	query.Cursor = nil
+	results, cursor, err = backend.QueryTxns(ctx, query)
+	require.NoError(t, err, "QueryTxns (no limit)")
+	require.Equal(t, Results{42: {{TxHash: tx1Hash, TxIndex: 0}, {TxHash: tx2Hash, TxIndex: 1}}}, results)
+	require.Nil(t, cursor, "QueryTxns should not return a cursor when all results fit")
+
+	query.Cursor = []byte("malformed")
+	_, _, err = backend.QueryTxns(ctx, query)
+	require.Equal(t, ErrMalformedCursor, err, "QueryTxns should fail for malformed cursors")
 }
 
 func testLoadIndex(t *testing.T, backend Backend) {
@@ -164,6 +259,64 @@ func testLoadIndex(t *testing.T, backend Backend) {
 	require.EqualValues(t, 42, round)
 }
 
+func testPrune(t *testing.T, backend Backend) {
+	ctx := context.Background()
+
+	// Index a round with more transactions than returned by a default search.
+	var blockHash3 hash.Hash
+	blockHash3.FromBytes([]byte("this is a fake block hash 3"))
+
+	var txs []*transaction.Transaction
+	var tags transaction.Tags
+	for i := 0; i < 50; i++ {
+		tx := []byte(fmt.Sprintf("i am transaction %d", i))
+		var txHash hash.Hash
+		txHash.FromBytes(tx)
+
+		txs = append(txs, &transaction.Transaction{Input: tx, Output: tx})
+		tags = append(tags, transaction.Tag{Key: []byte("prune"), Value: []byte("me"), TxHash: txHash})
+	}
+	err := backend.Index(ctx, 44, blockHash3, txs, tags)
+	require.NoError(t, err, "Index")
+
+	_, err = backend.QueryTxnByIndex(ctx, 44, 49)
+	require.NoError(t, err, "QueryTxnByIndex")
+
+	err = backend.Prune(ctx, 44)
+	require.NoError(t, err, "Prune")
+
+	_, err = backend.QueryBlock(ctx, blockHash3)
+	require.Equal(t, api.ErrNotFound, err, "QueryBlock must return a not found error after pruning")
+	for i := uint32(0); i < 50; i++ {
+		_, err = backend.QueryTxnByIndex(ctx, 44, i)
+		require.Equal(t, api.ErrNotFound, err, "QueryTxnByIndex must return a not found error after pruning")
+	}
+
+	// Prune everything before round 43.
+	err = backend.PruneBefore(ctx, 43)
+	require.NoError(t, err, "PruneBefore")
+
+	var blockHash1, blockHash2 hash.Hash
+	blockHash1.FromBytes([]byte("this is a fake block hash 1"))
+	blockHash2.FromBytes([]byte("this is a fake block hash 2"))
+
+	_, err = backend.QueryBlock(ctx, blockHash1)
+	require.Equal(t, api.ErrNotFound, err, "QueryBlock must return a not found error after pruning")
+	_, _, _, err = backend.QueryTxn(ctx, []byte("hello"), []byte("world"))
+	require.Equal(t, api.ErrNotFound, err, "QueryTxn must return a not found error after pruning")
+
+	round, err := backend.QueryBlock(ctx, blockHash2)
+	require.NoError(t, err, "QueryBlock")
+	require.EqualValues(t, 43, round)
+
+	err = backend.Compact(ctx)
+	require.NoError(t, err, "Compact")
+
+	round, err = backend.QueryBlock(ctx, blockHash2)
+	require.NoError(t, err, "QueryBlock after Compact")
+	require.EqualValues(t, 43, round)
+}
+
 func testBackend(t *testing.T, factory BackendFactory) {
 	// Create a new random temporary directory under /tmp.
 	dataDir, err := ioutil.TempDir("", "oasis-client-indexer-test_")
@@ -188,6 +341,14 @@ func testBackend(t *testing.T, factory BackendFactory) {
 
 		testLoadIndex(t, backend)
 	})
+	t.Run("Prune", func(t *testing.T) {
+		var backend Backend
+		backend, err = factory(dataDir, id)
+		require.NoError(t, err, "New")
+		defer backend.Close()
+
+		testPrune(t, backend)
+	})
 }
 
 func TestBleveBackend(t *testing.T) {
@@ -7,10 +7,13 @@ import (
 
 	"github.com/blevesearch/bleve"
 	bleveKeyword "github.com/blevesearch/bleve/analysis/analyzer/keyword"
+	bleveStandard "github.com/blevesearch/bleve/analysis/analyzer/standard"
 	"github.com/blevesearch/bleve/index/scorch"
+	"github.com/blevesearch/bleve/index/scorch/mergeplan"
 	bleveQuery "github.com/blevesearch/bleve/search/query"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
@@ -24,6 +27,12 @@ const (
 	BleveBackendName = "bleve"
 
 	bleveIndexFile = "tag-index.bleve.db"
+
+	// pruneBatchSize is the maximum number of documents removed in a single batch.
+	pruneBatchSize = 1000
+
+	// fieldDocID is the special field referring to the document ID.
+	fieldDocID = "_id"
 )
 
 var (
@@ -40,6 +49,11 @@ var (
 	_ Backend = (*bleveBackend)(nil)
 )
 
+// forceMerger is an index that supports merging all of its segments.
+type forceMerger interface {
+	ForceMerge(ctx context.Context, mo *mergeplan.MergePlanOptions) error
+}
+
 type bleveBackend struct {
 	logger *logging.Logger
 
@@ -59,8 +73,11 @@ const (
 	// docTypeTx is the transaction document type.
 	docTypeTx = "tx"
 
+	fieldTxHash  = "TxHash"
 	fieldTxIndex = "TxIndex"
 	fieldTags    = "Tags"
+	// fieldTagsText contains the same tags as fieldTags, but analyzed for full-text queries.
+	fieldTagsText = "TagsText"
 )
 
 // txDocument is a transction document in the bleve index.
@@ -73,6 +90,8 @@ type txDocument struct {
 	TxHash  string
 	TxIndex uint32
 	Tags    map[string][]string
+
+	TagsText map[string][]string
 }
 
 func (d txDocument) Type() string {
@@ -117,6 +136,55 @@ func queryByTag(key, value []byte) bleveQuery.Query {
 	return query
 }
 
+// queryByTagMatch returns a query matching documents with tags matching the given value using
+// the given kind of matching.
+func queryByTagMatch(key, value []byte, match api.QueryMatch) (bleveQuery.Query, error) {
+	switch match {
+	case api.QueryMatchExact:
+		return queryByTag(key, value), nil
+	case api.QueryMatchPrefix:
+		query := bleve.NewPrefixQuery(string(value))
+		query.SetField(fmt.Sprintf("%s.%s", fieldTags, string(key)))
+		return query, nil
+	case api.QueryMatchFullText:
+		query := bleve.NewMatchQuery(string(value))
+		query.SetField(fmt.Sprintf("%s.%s", fieldTagsText, string(key)))
+		query.Analyzer = bleveStandard.Name
+		query.SetOperator(bleveQuery.MatchQueryOperatorAnd)
+		return query, nil
+	default:
+		return nil, fmt.Errorf("tagindexer: unsupported query match kind: %s", match)
+	}
+}
+
+// queryByConditions returns a query matching documents satisfying all of the given conditions.
+func queryByConditions(conds []api.QueryCondition) ([]bleveQuery.Query, error) {
+	var qs []bleveQuery.Query
+	for _, cond := range conds {
+		var vals []bleveQuery.Query
+		for _, v := range cond.Values {
+			q, err := queryByTagMatch(cond.Key, v, cond.Match)
+			if err != nil {
+				return nil, err
+			}
+			vals = append(vals, q)
+		}
+
+		switch len(vals) {
+		case 0:
+			// No values (strange, but ok).
+			continue
+		case 1:
+			// Single value.
+			qs = append(qs, vals[0])
+		default:
+			// Multiple values.
+			qs = append(qs, bleve.NewDisjunctionQuery(vals...))
+		}
+	}
+	return qs, nil
+}
+
 func (b *bleveBackend) Index(
 	ctx context.Context,
 	round uint64,
@@ -131,19 +199,31 @@ func (b *bleveBackend) Index(
 		txIndices[tx.Hash()] = uint32(idx)
 	}
 
-	// Generate documents for transactions.
+	// Generate documents for transactions. Transactions without any tags are indexed as well so
+	// that they can be looked up by their hash.
 	txDocs := make(map[hash.Hash]txDocument)
+	newTxDoc := func(txHash hash.Hash) txDocument {
+		return txDocument{
+			ID:      string(txDocIDKeyFmt.Encode(round, &txHash, txIndices[txHash])),
+			Kind:    docTypeTx,
+			Round:   round,
+			TxHash:  string(txHash[:]),
+			TxIndex: txIndices[txHash],
+			Tags:    make(map[string][]string),
+
+			TagsText: make(map[string][]string),
+		}
+	}
+	for txHash := range txIndices {
+		txDocs[txHash] = newTxDoc(txHash)
+	}
 	for _, tag := range tags {
 		doc, ok := txDocs[tag.TxHash]
 		if !ok {
-			doc.Kind = docTypeTx
-			doc.ID = string(txDocIDKeyFmt.Encode(round, &tag.TxHash, txIndices[tag.TxHash]))
-			doc.Round = round
-			doc.TxHash = string(tag.TxHash[:])
-			doc.TxIndex = txIndices[tag.TxHash]
-			doc.Tags = make(map[string][]string)
+			doc = newTxDoc(tag.TxHash)
 		}
 		doc.Tags[string(tag.Key)] = append(doc.Tags[string(tag.Key)], string(tag.Value))
+		doc.TagsText[string(tag.Key)] = append(doc.TagsText[string(tag.Key)], string(tag.Value))
 		txDocs[tag.TxHash] = doc
 	}
 
@@ -255,7 +335,34 @@ func (b *bleveBackend) QueryTxnByIndex(ctx context.Context, round uint64, index
 	return decTxHash, nil
 }
 
-func (b *bleveBackend) QueryTxns(ctx context.Context, query api.Query) (Results, error) {
+func (b *bleveBackend) QueryTxnByHash(ctx context.Context, txHash hash.Hash) (uint64, uint32, error) {
+	// Filter by transaction hash.
+	qTxHash := bleve.NewTermQuery(string(txHash[:]))
+	qTxHash.SetField(fieldTxHash)
+
+	q := bleve.NewConjunctionQuery(queryByKindTx, qTxHash)
+	rq := bleve.NewSearchRequest(q)
+	rq.Size = 1
+
+	result, err := b.index.SearchInContext(ctx, rq)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(result.Hits) == 0 {
+		return 0, 0, api.ErrNotFound
+	}
+
+	var decRound uint64
+	var decTxHash hash.Hash
+	var decTxIndex uint32
+	if !txDocIDKeyFmt.Decode([]byte(result.Hits[0].ID), &decRound, &decTxHash, &decTxIndex) {
+		return 0, 0, ErrCorrupted
+	}
+
+	return decRound, decTxIndex, nil
+}
+
+func (b *bleveBackend) QueryTxns(ctx context.Context, query api.Query) (Results, []byte, error) {
 	qs := []bleveQuery.Query{queryByKindTx}
 
 	// Filter by round.
@@ -276,23 +383,11 @@ func (b *bleveBackend) QueryTxns(ctx context.Context, query api.Query) (Results,
 	}
 
 	// Filter by key/value tag conditions.
-	for _, cond := range query.Conditions {
-		switch len(cond.Values) {
-		case 0:
-			// No values (strange, but ok).
-			continue
-		case 1:
-			// Single value.
-			qs = append(qs, queryByTag(cond.Key, cond.Values[0]))
-		default:
-			// Multiple values.
-			var vals []bleveQuery.Query
-			for _, v := range cond.Values {
-				vals = append(vals, queryByTag(cond.Key, v))
-			}
-			qs = append(qs, bleve.NewDisjunctionQuery(vals...))
-		}
+	qConds, err := queryByConditions(query.Conditions)
+	if err != nil {
+		return nil, nil, err
 	}
+	qs = append(qs, qConds...)
 
 	q := bleve.NewConjunctionQuery(qs...)
 	rq := bleve.NewSearchRequest(q)
@@ -303,9 +398,20 @@ func (b *bleveBackend) QueryTxns(ctx context.Context, query api.Query) (Results,
 		rq.Size = maxQueryLimit
 	}
 
+	// Order results by round and transaction index. The document ID is included to make the order
+	// total, so that the sort values of the last hit can be used as a continuation cursor.
+	rq.SortBy([]string{fieldRound, fieldTxIndex, fieldDocID})
+	if query.Cursor != nil {
+		var after []string
+		if err = cbor.Unmarshal(query.Cursor, &after); err != nil || len(after) != len(rq.Sort) {
+			return nil, nil, ErrMalformedCursor
+		}
+		rq.SearchAfter = after
+	}
+
 	result, err := b.index.SearchInContext(ctx, rq)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	results := make(Results)
@@ -314,13 +420,19 @@ func (b *bleveBackend) QueryTxns(ctx context.Context, query api.Query) (Results,
 		var decTxHash hash.Hash
 		var decTxIndex uint32
 		if !txDocIDKeyFmt.Decode([]byte(hit.ID), &decRound, &decTxHash, &decTxIndex) {
-			return nil, ErrCorrupted
+			return nil, nil, ErrCorrupted
 		}
 
 		results[decRound] = append(results[decRound], Result{TxHash: decTxHash, TxIndex: decTxIndex})
 	}
 
-	return results, nil
+	// There may be more results in case the limit has been reached.
+	var cursor []byte
+	if len(result.Hits) == rq.Size {
+		cursor = cbor.Marshal(result.Hits[len(result.Hits)-1].Sort)
+	}
+
+	return results, cursor, nil
 }
 
 func (b *bleveBackend) WaitBlockIndexed(ctx context.Context, round uint64) error {
@@ -353,30 +465,85 @@ func (b *bleveBackend) WaitBlockIndexed(ctx context.Context, round uint64) error
 	}
 }
 
-func (b *bleveBackend) Prune(ctx context.Context, round uint64) error {
-	rq := bleve.NewSearchRequest(queryByRound(round))
-	result, err := b.index.SearchInContext(ctx, rq)
-	if err != nil {
-		return err
+// pruneQuery removes all documents matching the given query.
+func (b *bleveBackend) pruneQuery(ctx context.Context, query bleveQuery.Query) (uint64, error) {
+	var pruned uint64
+	for {
+		select {
+		case <-ctx.Done():
+			return pruned, ctx.Err()
+		default:
+		}
+
+		// As matching documents are deleted after each batch, always fetch the first page.
+		rq := bleve.NewSearchRequest(query)
+		rq.Size = pruneBatchSize
+		result, err := b.index.SearchInContext(ctx, rq)
+		if err != nil {
+			return pruned, err
+		}
+		if len(result.Hits) == 0 {
+			return pruned, nil
+		}
+
+		batch := b.index.NewBatch()
+		for _, hit := range result.Hits {
+			batch.Delete(hit.ID)
+		}
+		if err = b.index.Batch(batch); err != nil {
+			return pruned, err
+		}
+		pruned += uint64(len(result.Hits))
 	}
+}
 
-	b.logger.Debug("pruning items from index",
+func (b *bleveBackend) Prune(ctx context.Context, round uint64) error {
+	pruned, err := b.pruneQuery(ctx, queryByRound(round))
+
+	b.logger.Debug("pruned items from index",
 		"round", round,
-		"item_count", len(result.Hits),
+		"item_count", pruned,
 	)
 
-	batch := b.index.NewBatch()
-	for _, hit := range result.Hits {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-		}
+	return err
+}
 
-		batch.Delete(hit.ID)
+func (b *bleveBackend) PruneBefore(ctx context.Context, round uint64) error {
+	if round == 0 {
+		return nil
 	}
 
-	return b.index.Batch(batch)
+	minF := float64(0)
+	maxF := float64(round)
+	inclusiveMin, inclusiveMax := true, false
+	query := bleve.NewNumericRangeInclusiveQuery(&minF, &maxF, &inclusiveMin, &inclusiveMax)
+	query.SetField(fieldRound)
+
+	pruned, err := b.pruneQuery(ctx, query)
+	if pruned > 0 {
+		b.logger.Debug("pruned items from index",
+			"before_round", round,
+			"item_count", pruned,
+		)
+	}
+
+	return err
+}
+
+func (b *bleveBackend) Compact(ctx context.Context) error {
+	idx, _, err := b.index.Advanced()
+	if err != nil {
+		return err
+	}
+	merger, ok := idx.(forceMerger)
+	if !ok {
+		// Index does not support compaction.
+		return nil
+	}
+
+	b.logger.Debug("compacting index")
+
+	return merger.ForceMerge(ctx, &mergeplan.SingleSegmentMergePlanOptions)
 }
 
 func (b *bleveBackend) Close() {
@@ -398,6 +565,13 @@ func newBleveBackend(dataDir string, runtimeID common.Namespace) (Backend, error
 	mp.DefaultAnalyzer = bleveKeyword.Name
 	mp.StoreDynamic = false
 
+	// Tags used for full-text queries are tokenized using the standard analyzer.
+	tagsTextMapping := bleve.NewDocumentMapping()
+	tagsTextMapping.DefaultAnalyzer = bleveStandard.Name
+	txMapping := bleve.NewDocumentMapping()
+	txMapping.AddSubDocumentMapping(fieldTagsText, tagsTextMapping)
+	mp.AddDocumentMapping(docTypeTx, txMapping)
+
 	path := filepath.Join(dataDir, bleveIndexFile)
 	index, err := bleve.Open(path)
 	if err != nil {
@@ -23,12 +23,23 @@ const (
 
 var _ history.PruneHandler = (*pruneHandler)(nil)
 
+// Config is the tag indexer service configuration.
+type Config struct {
+	// NumKept is the number of last rounds to keep in the index. Zero means that index entries
+	// are only pruned together with the runtime history.
+	NumKept uint64
+
+	// CompactionInterval is the interval between index compactions. Zero disables compaction.
+	CompactionInterval time.Duration
+}
+
 // Service is an indexer service.
 type Service struct {
 	service.BaseBackgroundService
 	QueryableBackend
 
 	runtimeID common.Namespace
+	cfg       Config
 	backend   Backend
 	roothash  roothash.Backend
 
@@ -53,11 +64,24 @@ func (s *Service) worker(storageBackend storage.Backend) {
 	}
 	defer blocksSub.Close()
 
+	var compactCh <-chan time.Time
+	if s.cfg.CompactionInterval > 0 {
+		ticker := time.NewTicker(s.cfg.CompactionInterval)
+		defer ticker.Stop()
+		compactCh = ticker.C
+	}
+
 	for {
 		select {
 		case <-s.stopCh:
 			s.Logger.Info("stop requested, terminating indexer")
 			return
+		case <-compactCh:
+			if err = s.backend.Compact(s.ctx); err != nil {
+				s.Logger.Error("failed to compact index",
+					"err", err,
+				)
+			}
 		case annBlk := <-blocksCh:
 			// New blocks to index.
 			blk := annBlk.Block
@@ -119,6 +143,16 @@ func (s *Service) worker(storageBackend storage.Backend) {
 				)
 				continue
 			}
+
+			// Prune index entries outside the configured retention window.
+			if s.cfg.NumKept > 0 && blk.Header.Round >= s.cfg.NumKept {
+				if err = s.backend.PruneBefore(s.ctx, blk.Header.Round-s.cfg.NumKept+1); err != nil {
+					s.Logger.Error("failed to prune index",
+						"err", err,
+						"round", blk.Header.Round,
+					)
+				}
+			}
 		}
 	}
 }
@@ -149,6 +183,7 @@ func (s *Service) Stop() {
 func New(
 	dataDir string,
 	backendFactory BackendFactory,
+	cfg *Config,
 	history history.History,
 	roothash roothash.Backend,
 ) (*Service, error) {
@@ -164,6 +199,7 @@ func New(
 		BaseBackgroundService: *service.NewBaseBackgroundService("runtime/history/tagindexer"),
 		QueryableBackend:      backend,
 		runtimeID:             runtimeID,
+		cfg:                   *cfg,
 		backend:               backend,
 		roothash:              roothash,
 		ctx:                   ctx,
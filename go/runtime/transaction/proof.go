@@ -0,0 +1,104 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+)
+
+// MaxProofTransactions is the maximum number of transactions that can be
+// included in a single inclusion proof.
+const MaxProofTransactions = math.MaxUint16 / 2
+
+// ErrInvalidProof is the error returned when a transaction inclusion proof
+// is invalid.
+var ErrInvalidProof = errors.New("transaction: invalid proof")
+
+// GetProof generates a Merkle proof that the artifacts (input and output) of
+// the given transactions are included in the tree.
+//
+// The proof can be verified against an independently obtained IO root via
+// VerifyProof.
+func (t *Tree) GetProof(ctx context.Context, txHashes []hash.Hash) (*syncer.Proof, error) {
+	if len(txHashes) == 0 {
+		return nil, fmt.Errorf("transaction: no transactions given")
+	}
+	if len(txHashes) > MaxProofTransactions {
+		return nil, fmt.Errorf("transaction: too many transactions for a single proof (max: %d)", MaxProofTransactions)
+	}
+
+	var prefixes [][]byte
+	for _, txHash := range txHashes {
+		prefixes = append(prefixes, txnKeyFmt.Encode(&txHash)) // nolint: gosec
+	}
+
+	// Each transaction has at most two artifacts.
+	rsp, err := t.tree.SyncGetPrefixes(ctx, &syncer.GetPrefixesRequest{
+		Tree: syncer.TreeID{
+			Root:     t.ioRoot,
+			Position: t.ioRoot.Hash,
+		},
+		Prefixes: prefixes,
+		Limit:    uint16(2 * len(txHashes)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("transaction: failed to generate proof: %w", err)
+	}
+	return &rsp.Proof, nil
+}
+
+// VerifyProof verifies a transaction inclusion proof generated by GetProof
+// against the given (trusted) IO root and returns the artifacts of the proven
+// transactions.
+//
+// Transactions that the proof shows are not included in the tree are omitted
+// from the result.
+func VerifyProof(ctx context.Context, ioRoot node.Root, txHashes []hash.Hash, proof *syncer.Proof) (map[hash.Hash]*Transaction, error) {
+	if proof == nil {
+		return nil, ErrInvalidProof
+	}
+	var pv syncer.ProofVerifier
+	if _, err := pv.VerifyProof(ctx, ioRoot.Hash, proof); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidProof, err)
+	}
+
+	// Look up the transactions in a tree that is only backed by the (verified) proof so any
+	// attempt to access nodes not included in the proof fails.
+	tree := NewTree(&proofReadSyncer{proof: proof}, ioRoot)
+	defer tree.Close()
+
+	result := make(map[hash.Hash]*Transaction)
+	for _, txHash := range txHashes {
+		tx, err := tree.GetTransaction(ctx, txHash)
+		switch err {
+		case nil:
+			result[txHash] = tx
+		case ErrNotFound:
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrInvalidProof, err)
+		}
+	}
+	return result, nil
+}
+
+// proofReadSyncer is a read syncer that always returns the same proof.
+type proofReadSyncer struct {
+	proof *syncer.Proof
+}
+
+func (rs *proofReadSyncer) SyncGet(ctx context.Context, request *syncer.GetRequest) (*syncer.ProofResponse, error) {
+	return &syncer.ProofResponse{Proof: *rs.proof}, nil
+}
+
+func (rs *proofReadSyncer) SyncGetPrefixes(ctx context.Context, request *syncer.GetPrefixesRequest) (*syncer.ProofResponse, error) {
+	return &syncer.ProofResponse{Proof: *rs.proof}, nil
+}
+
+func (rs *proofReadSyncer) SyncIterate(ctx context.Context, request *syncer.IterateRequest) (*syncer.ProofResponse, error) {
+	return &syncer.ProofResponse{Proof: *rs.proof}, nil
+}
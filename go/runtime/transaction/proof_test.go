@@ -0,0 +1,86 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
+)
+
+func TestTransactionProof(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	store := mkvs.New(nil, nil)
+
+	var emptyRoot node.Root
+	emptyRoot.Empty()
+
+	tree := NewTree(store, emptyRoot)
+	var txns []Transaction
+	for i := 0; i < 50; i++ {
+		tx := Transaction{
+			Input:      []byte(fmt.Sprintf("this goes in (%d)", i)),
+			Output:     []byte(fmt.Sprintf("and this comes out (%d)", i)),
+			GasUsed:    uint64(i),
+			BatchOrder: uint32(i),
+		}
+		err := tree.AddTransaction(ctx, tx, Tags{Tag{Key: []byte("tag"), Value: []byte("value")}})
+		require.NoError(err, "AddTransaction")
+		txns = append(txns, tx)
+	}
+	writeLog, _, err := tree.Commit(ctx)
+	require.NoError(err, "Commit")
+	tree.Close()
+
+	err = store.ApplyWriteLog(ctx, writelog.NewStaticIterator(writeLog))
+	require.NoError(err, "ApplyWriteLog")
+	_, rootHash, err := store.Commit(ctx, emptyRoot.Namespace, emptyRoot.Version)
+	require.NoError(err, "Commit")
+	ioRoot := node.Root{Namespace: emptyRoot.Namespace, Version: emptyRoot.Version, Hash: rootHash}
+
+	tree = NewTree(store, ioRoot)
+	defer tree.Close()
+
+	_, err = tree.GetProof(ctx, nil)
+	require.Error(err, "GetProof should fail without transactions")
+
+	// Prove a batch of transactions, one of which is not in the tree.
+	missingHash := hash.NewFromBytes([]byte("this is not in the tree"))
+	txHashes := []hash.Hash{txns[3].Hash(), txns[17].Hash(), txns[42].Hash(), missingHash}
+	proof, err := tree.GetProof(ctx, txHashes)
+	require.NoError(err, "GetProof")
+
+	proven, err := VerifyProof(ctx, ioRoot, txHashes, proof)
+	require.NoError(err, "VerifyProof")
+	require.Len(proven, 3, "all included transactions should be proven")
+	for _, idx := range []int{3, 17, 42} {
+		tx := proven[txns[idx].Hash()]
+		require.NotNil(tx, "transaction %d should be proven", idx)
+		require.True(tx.Equal(&txns[idx]), "proven transaction %d should have correct artifacts", idx)
+	}
+	require.Nil(proven[missingHash], "missing transaction should not be proven")
+
+	// Transactions not covered by the proof should not be verifiable.
+	_, err = VerifyProof(ctx, ioRoot, []hash.Hash{txns[25].Hash()}, proof)
+	require.True(errors.Is(err, ErrInvalidProof), "VerifyProof should fail for transactions not in the proof")
+
+	// Proofs should not verify against a different root.
+	badRoot := ioRoot
+	badRoot.Hash = hash.NewFromBytes([]byte("bad root"))
+	_, err = VerifyProof(ctx, badRoot, txHashes, proof)
+	require.True(errors.Is(err, ErrInvalidProof), "VerifyProof should fail for a different root")
+
+	// Tampered proofs should not verify.
+	tampered := *proof
+	tampered.Entries = append([][]byte{}, proof.Entries...)
+	tampered.Entries[len(tampered.Entries)-1] = []byte{0x02, 0x00}
+	_, err = VerifyProof(ctx, ioRoot, txHashes, &tampered)
+	require.True(errors.Is(err, ErrInvalidProof), "VerifyProof should fail for a tampered proof")
+}
@@ -104,11 +104,43 @@ type inputArtifacts struct {
 // outputArtifacts are the output transaction artifacts.
 //
 // These are the artifacts that are stored CBOR-serialized in the Merkle tree.
+//
+// In order to remain compatible with existing trees, the gas used element is
+// only serialized when it is non-zero.
 type outputArtifacts struct {
-	_ struct{} `cbor:",toarray"` // nolint
-
 	// Output is the transaction output (if available).
 	Output []byte
+	// GasUsed is the amount of gas used by the transaction as reported by the
+	// runtime (if available).
+	GasUsed uint64
+}
+
+// MarshalCBOR encodes output artifacts into CBOR.
+func (oa outputArtifacts) MarshalCBOR() ([]byte, error) {
+	if oa.GasUsed == 0 {
+		return cbor.Marshal([]interface{}{oa.Output}), nil
+	}
+	return cbor.Marshal([]interface{}{oa.Output, oa.GasUsed}), nil
+}
+
+// UnmarshalCBOR decodes CBOR-encoded output artifacts.
+func (oa *outputArtifacts) UnmarshalCBOR(data []byte) error {
+	var elems []cbor.RawMessage
+	if err := cbor.Unmarshal(data, &elems); err != nil {
+		return err
+	}
+
+	switch len(elems) {
+	case 2:
+		if err := cbor.Unmarshal(elems[1], &oa.GasUsed); err != nil {
+			return err
+		}
+	case 1:
+		oa.GasUsed = 0
+	default:
+		return fmt.Errorf("transaction: malformed output artifacts (%d elements)", len(elems))
+	}
+	return cbor.Unmarshal(elems[0], &oa.Output)
 }
 
 // Transaction is an executed (or executing) transaction.
@@ -120,6 +152,9 @@ type Transaction struct {
 	Input []byte
 	// Output is the transaction output (if available).
 	Output []byte
+	// GasUsed is the amount of gas used by the transaction as reported by the
+	// runtime (if available).
+	GasUsed uint64
 	// BatchOrder is the transaction order within the batch.
 	//
 	// This is only relevant within the committee that is processing the batch
@@ -137,7 +172,10 @@ func (t Transaction) Hash() hash.Hash {
 
 // Equal checks whether the transaction is equal to another.
 func (t Transaction) Equal(other *Transaction) bool {
-	return bytes.Equal(t.Input, other.Input) && bytes.Equal(t.Output, other.Output) && t.BatchOrder == other.BatchOrder
+	return bytes.Equal(t.Input, other.Input) &&
+		bytes.Equal(t.Output, other.Output) &&
+		t.GasUsed == other.GasUsed &&
+		t.BatchOrder == other.BatchOrder
 }
 
 // asInputArtifacts returns the input artifacts of this transaction.
@@ -147,7 +185,7 @@ func (t Transaction) asInputArtifacts() inputArtifacts {
 
 // asOutputArtifacts returns the output artifacts of this transaction.
 func (t Transaction) asOutputArtifacts() outputArtifacts {
-	return outputArtifacts{Output: t.Output}
+	return outputArtifacts{Output: t.Output, GasUsed: t.GasUsed}
 }
 
 // Tree is a Merkle tree containing transaction artifacts.
@@ -312,6 +350,7 @@ func (t *Tree) GetTransactions(ctx context.Context) ([]*Transaction, error) {
 
 			tx := txs[len(txs)-1]
 			tx.Output = oa.Output
+			tx.GasUsed = oa.GasUsed
 		}
 
 	}
@@ -352,6 +391,7 @@ func (t *Tree) GetTransaction(ctx context.Context, txHash hash.Hash) (*Transacti
 			}
 
 			tx.Output = oa.Output
+			tx.GasUsed = oa.GasUsed
 		}
 
 	}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
@@ -180,3 +181,41 @@ func TestTransactionInvalidBatchOrder(t *testing.T) {
 	_, err = tree.GetInputBatch(ctx, 0, 0)
 	require.Error(t, err, "GetInputBatch should fail with inconsistent order")
 }
+
+func TestTransactionGasUsed(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	store := mkvs.New(nil, nil)
+
+	var emptyRoot node.Root
+	emptyRoot.Empty()
+
+	tree := NewTree(store, emptyRoot)
+
+	tx := Transaction{
+		Input:   []byte("this goes in"),
+		Output:  []byte("and this comes out"),
+		GasUsed: 42,
+	}
+	err := tree.AddTransaction(ctx, tx, nil)
+	require.NoError(err, "AddTransaction")
+
+	decTx, err := tree.GetTransaction(ctx, tx.Hash())
+	require.NoError(err, "GetTransaction")
+	require.True(decTx.Equal(&tx), "GetTransaction should return the gas used")
+
+	// Output artifacts without gas used should be encoded as before.
+	legacy := struct {
+		_ struct{} `cbor:",toarray"` // nolint
+
+		Output []byte
+	}{Output: tx.Output}
+	oa := outputArtifacts{Output: tx.Output}
+	require.EqualValues(cbor.Marshal(legacy), cbor.Marshal(oa), "output artifacts without gas used should be compatible")
+
+	var decOa outputArtifacts
+	err = cbor.Unmarshal(cbor.Marshal(legacy), &decOa)
+	require.NoError(err, "Unmarshal")
+	require.EqualValues(oa, decOa)
+}
@@ -143,6 +143,18 @@ func (c Committee) Workers() []*CommitteeNode {
 	return workers
 }
 
+// BackupWorkers returns committee nodes with BackupWorker role.
+func (c Committee) BackupWorkers() []*CommitteeNode {
+	var workers []*CommitteeNode
+	for _, member := range c.Members {
+		if member.Role != RoleBackupWorker {
+			continue
+		}
+		workers = append(workers, member)
+	}
+	return workers
+}
+
 // String returns a string representation of a Committee.
 func (c Committee) String() string {
 	members := make([]string, len(c.Members))
@@ -207,6 +219,14 @@ type Backend interface {
 	// Iff the callback is nil, `beacon.GetBlockBeacon` will be used.
 	GetCommittees(ctx context.Context, request *GetCommitteesRequest) ([]*Committee, error)
 
+	// SimulateElection simulates the election of a committee of the given
+	// kind for the given runtime using the registry and staking state and
+	// the beacon value at the specified block height.
+	//
+	// This is useful for predicting node eligibility and debugging why nodes
+	// are not being elected.
+	SimulateElection(ctx context.Context, request *SimulateElectionRequest) (*ElectionSimulation, error)
+
 	// WatchCommittees returns a channel that produces a stream of
 	// Committee.
 	//
@@ -227,6 +247,45 @@ type GetCommitteesRequest struct {
 	RuntimeID common.Namespace `json:"runtime_id"`
 }
 
+// SimulateElectionRequest is a SimulateElection request.
+type SimulateElectionRequest struct {
+	Height    int64            `json:"height"`
+	RuntimeID common.Namespace `json:"runtime_id"`
+	Kind      CommitteeKind    `json:"kind"`
+}
+
+// ElectionCandidate is a node considered in a simulated committee election.
+type ElectionCandidate struct {
+	// ID is the node identifier.
+	ID signature.PublicKey `json:"id"`
+	// EntityID is the identifier of the entity controlling the node.
+	EntityID signature.PublicKey `json:"entity_id"`
+
+	// Eligible is true iff the node is eligible for election.
+	Eligible bool `json:"eligible"`
+	// Reason describes why the node is not eligible for election.
+	Reason string `json:"reason,omitempty"`
+
+	// Role is the role the node would have in the elected committee or
+	// RoleInvalid if the node would not be elected.
+	Role Role `json:"role"`
+}
+
+// ElectionSimulation is the result of a simulated committee election.
+type ElectionSimulation struct {
+	// Epoch is the epoch at the height used for the simulation.
+	Epoch epochtime.EpochTime `json:"epoch"`
+
+	// Committee is the committee that would be elected or nil in case no
+	// committee could be elected.
+	Committee *Committee `json:"committee,omitempty"`
+	// Reason describes why no committee could be elected.
+	Reason string `json:"reason,omitempty"`
+
+	// Candidates are all the schedulable nodes registered for the runtime.
+	Candidates []*ElectionCandidate `json:"candidates"`
+}
+
 // Genesis is the committee scheduler genesis state.
 type Genesis struct {
 	// Parameters are the scheduler consensus parameters.
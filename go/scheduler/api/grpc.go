@@ -19,6 +19,8 @@ var (
 	methodGetCommittees = serviceName.NewMethod("GetCommittees", GetCommitteesRequest{})
 	// methodStateToGenesis is the StateToGenesis method.
 	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
+	// methodSimulateElection is the SimulateElection method.
+	methodSimulateElection = serviceName.NewMethod("SimulateElection", SimulateElectionRequest{})
 
 	// methodWatchCommittees is the WatchCommittees method.
 	methodWatchCommittees = serviceName.NewMethod("WatchCommittees", nil)
@@ -40,6 +42,10 @@ var (
 				MethodName: methodStateToGenesis.ShortName(),
 				Handler:    handlerStateToGenesis,
 			},
+			{
+				MethodName: methodSimulateElection.ShortName(),
+				Handler:    handlerSimulateElection,
+			},
 		},
 		Streams: []grpc.StreamDesc{
 			{
@@ -120,6 +126,29 @@ func handlerStateToGenesis( // nolint: golint
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerSimulateElection( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req SimulateElectionRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).SimulateElection(ctx, &req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodSimulateElection.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).SimulateElection(ctx, req.(*SimulateElectionRequest))
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
 func handlerWatchCommittees(srv interface{}, stream grpc.ServerStream) error {
 	if err := stream.RecvMsg(nil); err != nil {
 		return err
@@ -181,6 +210,14 @@ func (c *schedulerClient) StateToGenesis(ctx context.Context, height int64) (*Ge
 	return &rsp, nil
 }
 
+func (c *schedulerClient) SimulateElection(ctx context.Context, request *SimulateElectionRequest) (*ElectionSimulation, error) {
+	var rsp ElectionSimulation
+	if err := c.conn.Invoke(ctx, methodSimulateElection.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *schedulerClient) WatchCommittees(ctx context.Context) (<-chan *Committee, pubsub.ClosableSubscription, error) {
 	ctx, sub := pubsub.NewContextSubscription(ctx)
 
@@ -95,6 +95,29 @@ func SchedulerImplementationTests(t *testing.T, name string, backend api.Backend
 
 		require.Nil(executor, "fetched an executor committee")
 		require.Nil(storage, "fetched a storage committee")
+
+		// Simulating the election should result in the elected committees.
+		for _, committee := range committees {
+			var sim *api.ElectionSimulation
+			sim, err = backend.SimulateElection(context.Background(), &api.SimulateElectionRequest{
+				Height:    consensusAPI.HeightLatest,
+				RuntimeID: rt.Runtime.ID,
+				Kind:      committee.Kind,
+			})
+			require.NoError(err, "SimulateElection")
+			require.Equal(epoch, sim.Epoch, "simulation should be for the current epoch")
+			require.NotNil(sim.Committee, "simulation should elect a committee")
+			require.EqualValues(committee.Members, sim.Committee.Members, "simulated committee should match the elected committee")
+
+			var elected int
+			for _, candidate := range sim.Candidates {
+				if candidate.Role != api.RoleInvalid {
+					require.True(candidate.Eligible, "elected candidates should be eligible")
+					elected++
+				}
+			}
+			require.Len(committee.Members, elected, "all committee members should be candidates")
+		}
 	}
 
 	var nExecutor, nStorage int
@@ -41,10 +41,21 @@ type Backend interface {
 	UpdatePolicies(context.Context, ServicePolicies) error
 }
 
+// DefaultUpstream is the upstream identifier of the default upstream node.
+//
+// State of the default upstream node is updated by all upstream nodes that are allowed to connect
+// to the sentry control endpoint. Other upstream nodes are identified by the public key that they
+// use to authenticate to the sentry control endpoint and only track their own updates.
+var DefaultUpstream signature.PublicKey
+
 // LocalBackend is a local sentry backend implementation.
 type LocalBackend interface {
 	Backend
 
-	// GetPolicyChecker returns the current access policy checker for the given service.
-	GetPolicyChecker(context.Context, cmnGrpc.ServiceName) (*policy.DynamicRuntimePolicyChecker, error)
+	// GetUpstreamTLSPubKeysFor returns the TLS public keys of the given upstream node.
+	GetUpstreamTLSPubKeysFor(ctx context.Context, upstream signature.PublicKey) ([]signature.PublicKey, error)
+
+	// GetPolicyChecker returns the current access policy checker for the given service of the
+	// given upstream node.
+	GetPolicyChecker(ctx context.Context, upstream signature.PublicKey, service cmnGrpc.ServiceName) (*policy.DynamicRuntimePolicyChecker, error)
 }
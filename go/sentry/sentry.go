@@ -8,6 +8,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/common/grpc/auth"
 	"github.com/oasisprotocol/oasis-core/go/common/grpc/policy"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
@@ -19,6 +20,12 @@ import (
 
 var _ api.Backend = (*backend)(nil)
 
+type upstreamState struct {
+	tlsPubKeys []signature.PublicKey
+
+	grpcPolicyCheckers map[cmnGrpc.ServiceName]*policy.DynamicRuntimePolicyChecker
+}
+
 type backend struct {
 	sync.RWMutex
 
@@ -27,9 +34,29 @@ type backend struct {
 	consensus consensus.Backend
 	identity  *identity.Identity
 
-	upstreamTLSPubKeys []signature.PublicKey
+	upstreams map[signature.PublicKey]*upstreamState
+}
+
+// getUpstreamsLocked returns the state of upstream nodes that should be updated for a request
+// coming from the sentry control endpoint.
+func (b *backend) getUpstreamsLocked(ctx context.Context) []*upstreamState {
+	upstreamIDs := []signature.PublicKey{api.DefaultUpstream}
+	if pk, err := auth.PeerPublicKeyFromContext(ctx); err == nil && !pk.Equal(api.DefaultUpstream) {
+		upstreamIDs = append(upstreamIDs, pk)
+	}
 
-	grpcPolicyCheckers map[cmnGrpc.ServiceName]*policy.DynamicRuntimePolicyChecker
+	var upstreams []*upstreamState
+	for _, id := range upstreamIDs {
+		u, ok := b.upstreams[id]
+		if !ok {
+			u = &upstreamState{
+				grpcPolicyCheckers: make(map[cmnGrpc.ServiceName]*policy.DynamicRuntimePolicyChecker),
+			}
+			b.upstreams[id] = u
+		}
+		upstreams = append(upstreams, u)
+	}
+	return upstreams
 }
 
 func (b *backend) GetAddresses(ctx context.Context) (*api.SentryAddresses, error) {
@@ -74,35 +101,56 @@ func (b *backend) SetUpstreamTLSPubKeys(ctx context.Context, pubKeys []signature
 	b.Lock()
 	defer b.Unlock()
 
-	b.upstreamTLSPubKeys = pubKeys
+	for _, u := range b.getUpstreamsLocked(ctx) {
+		u.tlsPubKeys = pubKeys
+	}
 
 	return nil
 }
 
 func (b *backend) GetUpstreamTLSPubKeys(ctx context.Context) ([]signature.PublicKey, error) {
+	return b.GetUpstreamTLSPubKeysFor(ctx, api.DefaultUpstream)
+}
+
+func (b *backend) GetUpstreamTLSPubKeysFor(ctx context.Context, upstream signature.PublicKey) ([]signature.PublicKey, error) {
 	b.RLock()
 	defer b.RUnlock()
 
-	return b.upstreamTLSPubKeys, nil
+	u, ok := b.upstreams[upstream]
+	if !ok {
+		return nil, nil
+	}
+
+	return u.tlsPubKeys, nil
 }
 
 func (b *backend) UpdatePolicies(ctx context.Context, p api.ServicePolicies) error {
 	b.Lock()
 	defer b.Unlock()
 
-	b.grpcPolicyCheckers[p.Service] = policy.NewDynamicRuntimePolicyChecker(p.Service, nil)
-	for namespace, policy := range p.AccessPolicies {
-		b.grpcPolicyCheckers[p.Service].SetAccessPolicy(policy, namespace)
+	for _, u := range b.getUpstreamsLocked(ctx) {
+		u.grpcPolicyCheckers[p.Service] = policy.NewDynamicRuntimePolicyChecker(p.Service, nil)
+		for namespace, policy := range p.AccessPolicies {
+			u.grpcPolicyCheckers[p.Service].SetAccessPolicy(policy, namespace)
+		}
 	}
 
 	return nil
 }
 
-func (b *backend) GetPolicyChecker(ctx context.Context, service cmnGrpc.ServiceName) (*policy.DynamicRuntimePolicyChecker, error) {
+func (b *backend) GetPolicyChecker(
+	ctx context.Context,
+	upstream signature.PublicKey,
+	service cmnGrpc.ServiceName,
+) (*policy.DynamicRuntimePolicyChecker, error) {
 	b.RLock()
 	defer b.RUnlock()
 
-	p, ok := b.grpcPolicyCheckers[service]
+	u, ok := b.upstreams[upstream]
+	if !ok {
+		return nil, fmt.Errorf("no policy checker defined for given service")
+	}
+	p, ok := u.grpcPolicyCheckers[service]
 	if !ok {
 		return nil, fmt.Errorf("no policy checker defined for given service")
 	}
@@ -120,10 +168,10 @@ func New(
 	}
 
 	b := &backend{
-		logger:             logging.GetLogger("sentry"),
-		consensus:          consensusBackend,
-		identity:           identity,
-		grpcPolicyCheckers: make(map[cmnGrpc.ServiceName]*policy.DynamicRuntimePolicyChecker),
+		logger:    logging.GetLogger("sentry"),
+		consensus: consensusBackend,
+		identity:  identity,
+		upstreams: make(map[signature.PublicKey]*upstreamState),
 	}
 
 	return b, nil
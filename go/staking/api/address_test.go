@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 )
 
@@ -35,3 +36,20 @@ func TestReserved(t *testing.T) {
 	require.True(pk2.IsBlacklisted(), "public key for test address 2 should be blacklisted")
 	require.False(pk2.IsValid(), "public key for test address 2 should be invalid")
 }
+
+func TestRuntimeAddress(t *testing.T) {
+	require := require.New(t)
+
+	id1 := common.NewTestNamespaceFromSeed([]byte("runtime address test 1"), 0)
+	id2 := common.NewTestNamespaceFromSeed([]byte("runtime address test 2"), 0)
+
+	addr1 := NewRuntimeAddress(id1)
+	require.True(addr1.IsValid(), "runtime address should be valid")
+	require.True(addr1.Equal(NewRuntimeAddress(id1)), "runtime address should be deterministic")
+	require.False(addr1.Equal(NewRuntimeAddress(id2)), "runtime addresses should differ between runtimes")
+
+	// Runtime addresses must not collide with addresses derived from public keys.
+	var pk signature.PublicKey
+	copy(pk[:], id1[:])
+	require.False(addr1.Equal(NewAddress(pk)), "runtime address should differ from public key address")
+}
@@ -66,8 +66,42 @@ var (
 	// exceed the maximum allowed number.
 	ErrTooManyAllowances = errors.New(ModuleName, 7, "staking: too many allowances")
 
+	// ErrNoDelegationSnapshot is the error returned when a delegation snapshot
+	// is not available for the requested epoch.
+	ErrNoDelegationSnapshot = errors.New(ModuleName, 8, "staking: delegation snapshot not available")
+
+	// ErrSendForbiddenByPolicy is the error returned when the sender of a transfer is not
+	// permitted by the address policy.
+	ErrSendForbiddenByPolicy = errors.New(ModuleName, 9, "staking: sender forbidden by address policy")
+
+	// ErrReceiveForbiddenByPolicy is the error returned when the receiver of a transfer is not
+	// permitted by the address policy.
+	ErrReceiveForbiddenByPolicy = errors.New(ModuleName, 10, "staking: receiver forbidden by address policy")
+
+	// ErrDelegateForbiddenByPolicy is the error returned when the delegator is not permitted by
+	// the address policy.
+	ErrDelegateForbiddenByPolicy = errors.New(ModuleName, 11, "staking: delegator forbidden by address policy")
+
+	// ErrReceiveDelegationForbiddenByPolicy is the error returned when the escrow account of a
+	// delegation is not permitted by the address policy.
+	ErrReceiveDelegationForbiddenByPolicy = errors.New(ModuleName, 12, "staking: delegation receiver forbidden by address policy")
+
+	// ErrEventIndexUnavailable is the error returned when the requested height range is not
+	// covered by the staking event index.
+	ErrEventIndexUnavailable = errors.New(ModuleName, 13, "staking: event index not available for requested heights")
+
+	// ErrEventsUnavailable is the error returned when events for the requested height are no
+	// longer (or not yet) available.
+	ErrEventsUnavailable = errors.New(ModuleName, 14, "staking: events not available for requested height")
+
+	// ErrNoEpochRewards is the error returned when the rewards report is not
+	// available for the requested epoch.
+	ErrNoEpochRewards = errors.New(ModuleName, 15, "staking: epoch rewards not available")
+
 	// MethodTransfer is the method name for transfers.
 	MethodTransfer = transaction.NewMethodName(ModuleName, "Transfer", Transfer{})
+	// MethodTransferBatch is the method name for batch transfers.
+	MethodTransferBatch = transaction.NewMethodName(ModuleName, "TransferBatch", TransferBatch{})
 	// MethodBurn is the method name for burns.
 	MethodBurn = transaction.NewMethodName(ModuleName, "Burn", Burn{})
 	// MethodAddEscrow is the method name for escrows.
@@ -80,25 +114,31 @@ var (
 	MethodAllow = transaction.NewMethodName(ModuleName, "Allow", Allow{})
 	// MethodWithdraw is the method name for
 	MethodWithdraw = transaction.NewMethodName(ModuleName, "Withdraw", Withdraw{})
+	// MethodSetCommissionDestination is the method name for setting a commission destination.
+	MethodSetCommissionDestination = transaction.NewMethodName(ModuleName, "SetCommissionDestination", SetCommissionDestination{})
 
 	// Methods is the list of all methods supported by the staking backend.
 	Methods = []transaction.MethodName{
 		MethodTransfer,
+		MethodTransferBatch,
 		MethodBurn,
 		MethodAddEscrow,
 		MethodReclaimEscrow,
 		MethodAmendCommissionSchedule,
 		MethodAllow,
 		MethodWithdraw,
+		MethodSetCommissionDestination,
 	}
 
 	_ prettyprint.PrettyPrinter = (*Transfer)(nil)
+	_ prettyprint.PrettyPrinter = (*TransferBatch)(nil)
 	_ prettyprint.PrettyPrinter = (*Burn)(nil)
 	_ prettyprint.PrettyPrinter = (*Escrow)(nil)
 	_ prettyprint.PrettyPrinter = (*ReclaimEscrow)(nil)
 	_ prettyprint.PrettyPrinter = (*AmendCommissionSchedule)(nil)
 	_ prettyprint.PrettyPrinter = (*Allow)(nil)
 	_ prettyprint.PrettyPrinter = (*Withdraw)(nil)
+	_ prettyprint.PrettyPrinter = (*SetCommissionDestination)(nil)
 	_ prettyprint.PrettyPrinter = (*SharePool)(nil)
 	_ prettyprint.PrettyPrinter = (*StakeThreshold)(nil)
 	_ prettyprint.PrettyPrinter = (*StakeAccumulator)(nil)
@@ -125,13 +165,25 @@ type Backend interface {
 	// LastBlockFees returns the collected fees for previous block.
 	LastBlockFees(ctx context.Context, height int64) (*quantity.Quantity, error)
 
+	// RewardScale returns the effective reward scale at the given height,
+	// denominated in RewardAmountDenominator. Zero is returned in case no
+	// rewards are being distributed.
+	RewardScale(ctx context.Context, height int64) (*quantity.Quantity, error)
+
 	// Threshold returns the specific staking threshold by kind.
 	Threshold(ctx context.Context, query *ThresholdQuery) (*quantity.Quantity, error)
 
+	// Thresholds returns all staking thresholds, keyed by kind.
+	Thresholds(ctx context.Context, height int64) (map[ThresholdKind]quantity.Quantity, error)
+
 	// Addresses returns the addresses of all accounts with a non-zero general
 	// or escrow balance.
 	Addresses(ctx context.Context, height int64) ([]Address, error)
 
+	// BelowThresholdAccounts returns the addresses of all escrow accounts
+	// whose active balance is below the total of their stake claims.
+	BelowThresholdAccounts(ctx context.Context, height int64) ([]Address, error)
+
 	// Account returns the account descriptor for the given account.
 	Account(ctx context.Context, query *OwnerQuery) (*Account, error)
 
@@ -139,13 +191,47 @@ type Backend interface {
 	// (delegator).
 	Delegations(ctx context.Context, query *OwnerQuery) (map[Address]*Delegation, error)
 
+	// DelegationsTo returns the list of delegations to the given escrow
+	// account (owner), keyed by delegator.
+	DelegationsTo(ctx context.Context, query *OwnerQuery) (map[Address]*Delegation, error)
+
+	// DelegationsAt returns the delegations to the given escrow account as
+	// of the transition to the given epoch.
+	//
+	// Snapshots are only retained for the most recent epochs as configured
+	// by the DelegationSnapshotEpochs consensus parameter.
+	DelegationsAt(ctx context.Context, query *DelegationsAtQuery) (map[Address]*Delegation, error)
+
 	// DebondingDelegations returns the list of debonding delegations for
 	// the given owner (delegator).
 	DebondingDelegations(ctx context.Context, query *OwnerQuery) (map[Address][]*DebondingDelegation, error)
 
+	// DebondingDelegationsTo returns the list of debonding delegations to
+	// the given escrow account (owner), keyed by delegator.
+	DebondingDelegationsTo(ctx context.Context, query *OwnerQuery) (map[Address][]*DebondingDelegation, error)
+
 	// Allowance looks up the allowance for the given owner/beneficiary combination.
 	Allowance(ctx context.Context, query *AllowanceQuery) (*quantity.Quantity, error)
 
+	// CommissionAt returns the commission rate of the given escrow account
+	// at the given (possibly future) epoch, as evaluated by the consensus
+	// layer according to the account's commission schedule at the given
+	// block height.
+	//
+	// As already elapsed rate steps are pruned from the schedule, the result
+	// is only meaningful for epochs that are not in the past.
+	CommissionAt(ctx context.Context, query *CommissionAtQuery) (*quantity.Quantity, error)
+
+	// RewardsAt returns the report of all rewards and fees disbursed during
+	// the given epoch.
+	//
+	// The report only becomes available after the epoch has ended.
+	RewardsAt(ctx context.Context, query *RewardsAtQuery) (*EpochRewards, error)
+
+	// AccountStateProof returns the full state of the given account at the given block
+	// height together with a proof of that state against the consensus state root.
+	AccountStateProof(ctx context.Context, query *OwnerQuery) (*AccountStateProof, error)
+
 	// StateToGenesis returns the genesis state at specified block height.
 	StateToGenesis(ctx context.Context, height int64) (*Genesis, error)
 
@@ -155,9 +241,25 @@ type Backend interface {
 	// GetEvents returns the events at specified block height.
 	GetEvents(ctx context.Context, height int64) ([]*Event, error)
 
+	// QueryEvents returns the indexed events matching the given filter.
+	//
+	// Results are ordered by height and paginated; in case there are more
+	// matching events the result includes a cursor that can be used to fetch
+	// the next page.
+	QueryEvents(ctx context.Context, filter *EventFilter) (*EventQueryResult, error)
+
 	// WatchEvents returns a channel that produces a stream of Events.
 	WatchEvents(ctx context.Context) (<-chan *Event, pubsub.ClosableSubscription, error)
 
+	// WatchEventsFrom returns a channel that first produces all events
+	// starting at the given height and then continues with a stream of new
+	// events as they are emitted.
+	WatchEventsFrom(ctx context.Context, startHeight int64) (<-chan *Event, pubsub.ClosableSubscription, error)
+
+	// WatchCommissionScheduleAmendments returns a channel that produces a
+	// stream of commission schedule amendments of any escrow account.
+	WatchCommissionScheduleAmendments(ctx context.Context) (<-chan *CommissionScheduleAmendmentEvent, pubsub.ClosableSubscription, error)
+
 	// Cleanup cleans up the backend.
 	Cleanup()
 }
@@ -174,6 +276,64 @@ type OwnerQuery struct {
 	Owner  Address `json:"owner"`
 }
 
+// DelegationsAtQuery is a query for delegations to an escrow account as of
+// a given epoch.
+type DelegationsAtQuery struct {
+	Height int64               `json:"height"`
+	Escrow Address             `json:"escrow"`
+	Epoch  epochtime.EpochTime `json:"epoch"`
+}
+
+// DefaultEventQueryLimit is the number of events returned by QueryEvents
+// in case no limit is specified.
+const DefaultEventQueryLimit = 100
+
+// MaxEventQueryLimit is the maximum number of events returned by a single
+// QueryEvents call.
+const MaxEventQueryLimit = 1000
+
+// EventFilter is a filter for querying indexed staking events.
+type EventFilter struct {
+	// Address restricts the results to events involving the given account.
+	Address *Address `json:"address,omitempty"`
+	// Kinds restricts the results to events of the given kinds.
+	Kinds []EventKind `json:"kinds,omitempty"`
+	// FromHeight is the first (inclusive) height to return events for.
+	FromHeight int64 `json:"from_height,omitempty"`
+	// ToHeight is the last (inclusive) height to return events for. Zero
+	// means the latest indexed height.
+	ToHeight int64 `json:"to_height,omitempty"`
+
+	// Cursor is the opaque cursor returned by a previous query. When set,
+	// results continue after the last event returned by that query.
+	Cursor []byte `json:"cursor,omitempty"`
+	// Limit is the maximum number of events to return.
+	Limit uint64 `json:"limit,omitempty"`
+}
+
+// EventQueryResult is the result of an event query.
+type EventQueryResult struct {
+	// Events are the matching events.
+	Events []*Event `json:"events"`
+	// NextCursor is the cursor for fetching the next page of results. It is
+	// empty when there are no more matching events.
+	NextCursor []byte `json:"next_cursor,omitempty"`
+}
+
+// CommissionAtQuery is a query for the commission rate of an escrow account
+// at a given epoch.
+type CommissionAtQuery struct {
+	Height int64               `json:"height"`
+	Owner  Address             `json:"owner"`
+	Epoch  epochtime.EpochTime `json:"epoch"`
+}
+
+// RewardsAtQuery is a query for the rewards report of an epoch.
+type RewardsAtQuery struct {
+	Height int64               `json:"height"`
+	Epoch  epochtime.EpochTime `json:"epoch"`
+}
+
 // AllowanceQuery is an allowance query.
 type AllowanceQuery struct {
 	Height      int64   `json:"height"`
@@ -207,10 +367,93 @@ type Event struct {
 	Height int64     `json:"height,omitempty"`
 	TxHash hash.Hash `json:"tx_hash,omitempty"`
 
-	Transfer        *TransferEvent        `json:"transfer,omitempty"`
-	Burn            *BurnEvent            `json:"burn,omitempty"`
-	Escrow          *EscrowEvent          `json:"escrow,omitempty"`
-	AllowanceChange *AllowanceChangeEvent `json:"allowance_change,omitempty"`
+	Transfer                    *TransferEvent                    `json:"transfer,omitempty"`
+	Burn                        *BurnEvent                        `json:"burn,omitempty"`
+	Escrow                      *EscrowEvent                      `json:"escrow,omitempty"`
+	AllowanceChange             *AllowanceChangeEvent             `json:"allowance_change,omitempty"`
+	CommissionDestinationChange *CommissionDestinationChangeEvent `json:"commission_destination_change,omitempty"`
+	PolicyViolation             *PolicyViolationEvent             `json:"policy_violation,omitempty"`
+	EscrowThreshold             *EscrowThresholdEvent             `json:"escrow_threshold,omitempty"`
+	CommissionScheduleAmendment *CommissionScheduleAmendmentEvent `json:"commission_schedule_amendment,omitempty"`
+	EpochRewards                *EpochRewards                     `json:"epoch_rewards,omitempty"`
+}
+
+// EventKind is the kind of a staking event.
+type EventKind string
+
+const (
+	EventKindTransfer                    EventKind = "transfer"
+	EventKindBurn                        EventKind = "burn"
+	EventKindAddEscrow                   EventKind = "add_escrow"
+	EventKindTakeEscrow                  EventKind = "take_escrow"
+	EventKindReclaimEscrow               EventKind = "reclaim_escrow"
+	EventKindAllowanceChange             EventKind = "allowance_change"
+	EventKindCommissionDestinationChange EventKind = "commission_destination_change"
+	EventKindPolicyViolation             EventKind = "policy_violation"
+	EventKindEscrowThreshold             EventKind = "escrow_threshold"
+	EventKindCommissionScheduleAmendment EventKind = "commission_schedule_amendment"
+	EventKindEpochRewards                EventKind = "epoch_rewards"
+)
+
+// Kind returns the kind of the event.
+func (e *Event) Kind() EventKind {
+	switch {
+	case e.Transfer != nil:
+		return EventKindTransfer
+	case e.Burn != nil:
+		return EventKindBurn
+	case e.Escrow != nil && e.Escrow.Add != nil:
+		return EventKindAddEscrow
+	case e.Escrow != nil && e.Escrow.Take != nil:
+		return EventKindTakeEscrow
+	case e.Escrow != nil && e.Escrow.Reclaim != nil:
+		return EventKindReclaimEscrow
+	case e.AllowanceChange != nil:
+		return EventKindAllowanceChange
+	case e.CommissionDestinationChange != nil:
+		return EventKindCommissionDestinationChange
+	case e.PolicyViolation != nil:
+		return EventKindPolicyViolation
+	case e.EscrowThreshold != nil:
+		return EventKindEscrowThreshold
+	case e.CommissionScheduleAmendment != nil:
+		return EventKindCommissionScheduleAmendment
+	case e.EpochRewards != nil:
+		return EventKindEpochRewards
+	default:
+		return ""
+	}
+}
+
+// RelatedAddresses returns the addresses of all accounts involved in the event.
+func (e *Event) RelatedAddresses() []Address {
+	switch {
+	case e.Transfer != nil:
+		return []Address{e.Transfer.From, e.Transfer.To}
+	case e.Burn != nil:
+		return []Address{e.Burn.Owner}
+	case e.Escrow != nil && e.Escrow.Add != nil:
+		if e.Escrow.Add.Beneficiary != nil {
+			return []Address{e.Escrow.Add.Owner, e.Escrow.Add.Escrow, *e.Escrow.Add.Beneficiary}
+		}
+		return []Address{e.Escrow.Add.Owner, e.Escrow.Add.Escrow}
+	case e.Escrow != nil && e.Escrow.Take != nil:
+		return []Address{e.Escrow.Take.Owner}
+	case e.Escrow != nil && e.Escrow.Reclaim != nil:
+		return []Address{e.Escrow.Reclaim.Owner, e.Escrow.Reclaim.Escrow}
+	case e.AllowanceChange != nil:
+		return []Address{e.AllowanceChange.Owner, e.AllowanceChange.Beneficiary}
+	case e.CommissionDestinationChange != nil:
+		return []Address{e.CommissionDestinationChange.Owner, e.CommissionDestinationChange.Destination}
+	case e.PolicyViolation != nil:
+		return []Address{e.PolicyViolation.Address, e.PolicyViolation.Counterparty}
+	case e.EscrowThreshold != nil:
+		return []Address{e.EscrowThreshold.Owner}
+	case e.CommissionScheduleAmendment != nil:
+		return []Address{e.CommissionScheduleAmendment.Owner}
+	default:
+		return nil
+	}
 }
 
 // AddEscrowEvent is the event emitted when stake is transferred into an escrow
@@ -219,6 +462,11 @@ type AddEscrowEvent struct {
 	Owner  Address           `json:"owner"`
 	Escrow Address           `json:"escrow"`
 	Amount quantity.Quantity `json:"amount"`
+
+	// Beneficiary is the address of the account that was credited with the
+	// resulting delegation shares in case it differs from the owner whose
+	// general balance was debited.
+	Beneficiary *Address `json:"beneficiary,omitempty"`
 }
 
 // TakeEscrowEvent is the event emitted when stake is taken from an escrow
@@ -236,6 +484,18 @@ type ReclaimEscrowEvent struct {
 	Amount quantity.Quantity `json:"amount"`
 }
 
+// EscrowThresholdEvent is the event emitted when the active balance of an
+// escrow account falls below the total of its stake claims (e.g., after
+// slashing) or when it recovers.
+type EscrowThresholdEvent struct {
+	Owner Address `json:"owner"`
+	// BelowThreshold is true if the active balance fell below the total of
+	// the stake claims and false if it recovered.
+	BelowThreshold bool              `json:"below_threshold,omitempty"`
+	Balance        quantity.Quantity `json:"balance"`
+	TotalClaims    quantity.Quantity `json:"total_claims"`
+}
+
 // AllowanceChangeEvent is the event emitted when allowance is changed for a beneficiary.
 type AllowanceChangeEvent struct { // nolint: maligned
 	Owner        Address           `json:"owner"`
@@ -245,6 +505,86 @@ type AllowanceChangeEvent struct { // nolint: maligned
 	AmountChange quantity.Quantity `json:"amount_change"`
 }
 
+// CommissionDestinationChangeEvent is the event emitted when the commission destination of an
+// escrow account is changed.
+type CommissionDestinationChangeEvent struct {
+	Owner       Address `json:"owner"`
+	Destination Address `json:"destination"`
+}
+
+// CommissionScheduleAmendmentEvent is the event emitted when the commission
+// schedule of an escrow account is amended.
+type CommissionScheduleAmendmentEvent struct {
+	// Owner is the address of the escrow account whose commission schedule
+	// was amended.
+	Owner Address `json:"owner"`
+	// Old is the commission schedule before the amendment.
+	Old CommissionSchedule `json:"old"`
+	// New is the commission schedule after the amendment.
+	New CommissionSchedule `json:"new"`
+}
+
+// EpochFees is the breakdown of the transaction fees disbursed during an epoch
+// according to the fee split weights.
+type EpochFees struct {
+	// Propose is the amount of fees paid to block proposers.
+	Propose quantity.Quantity `json:"propose"`
+	// Vote is the amount of fees paid to validators that voted for blocks.
+	Vote quantity.Quantity `json:"vote"`
+	// NextPropose is the amount of fees paid to the proposers of the blocks
+	// following the blocks in which the fees were collected.
+	NextPropose quantity.Quantity `json:"next_propose"`
+	// CommonPool is the amount of fees that could not be paid out and was
+	// transferred into the common pool instead.
+	CommonPool quantity.Quantity `json:"common_pool"`
+}
+
+// Add adds the given fees to the fees.
+func (f *EpochFees) Add(other *EpochFees) error {
+	if err := f.Propose.Add(&other.Propose); err != nil {
+		return fmt.Errorf("staking: failed to add proposer fees: %w", err)
+	}
+	if err := f.Vote.Add(&other.Vote); err != nil {
+		return fmt.Errorf("staking: failed to add voter fees: %w", err)
+	}
+	if err := f.NextPropose.Add(&other.NextPropose); err != nil {
+		return fmt.Errorf("staking: failed to add next proposer fees: %w", err)
+	}
+	if err := f.CommonPool.Add(&other.CommonPool); err != nil {
+		return fmt.Errorf("staking: failed to add common pool fees: %w", err)
+	}
+	return nil
+}
+
+// EpochRewards is the report of all rewards and fees disbursed during an
+// epoch. It is also emitted as an event when the epoch ends.
+type EpochRewards struct {
+	// Epoch is the epoch that the report is for.
+	Epoch epochtime.EpochTime `json:"epoch"`
+	// Rewards is the total amount of staking rewards (including commission)
+	// paid out of the common pool.
+	Rewards quantity.Quantity `json:"rewards"`
+	// Fees is the breakdown of the disbursed transaction fees.
+	Fees EpochFees `json:"fees"`
+	// CommonPoolStart is the common pool balance at the start of the epoch.
+	CommonPoolStart quantity.Quantity `json:"common_pool_start"`
+	// CommonPoolEnd is the common pool balance at the end of the epoch.
+	CommonPoolEnd quantity.Quantity `json:"common_pool_end"`
+}
+
+// CommonPoolDelta returns the absolute change of the common pool balance
+// during the epoch and whether the balance decreased.
+func (r *EpochRewards) CommonPoolDelta() (*quantity.Quantity, bool) {
+	if r.CommonPoolEnd.Cmp(&r.CommonPoolStart) >= 0 {
+		delta := r.CommonPoolEnd.Clone()
+		_ = delta.Sub(&r.CommonPoolStart)
+		return delta, false
+	}
+	delta := r.CommonPoolStart.Clone()
+	_ = delta.Sub(&r.CommonPoolEnd)
+	return delta, true
+}
+
 // Transfer is a stake transfer.
 type Transfer struct {
 	To     Address           `json:"to"`
@@ -272,6 +612,50 @@ func NewTransferTx(nonce uint64, fee *transaction.Fee, xfer *Transfer) *transact
 	return transaction.NewTransaction(nonce, fee, MethodTransfer, xfer)
 }
 
+// MaxTransferBatchSize is the maximum number of transfers in a single batch
+// transfer.
+const MaxTransferBatchSize = 1000
+
+// TransferBatch is a batch of stake transfers from the same account.
+//
+// The transfers are executed in order and either all of them or none of them
+// are applied.
+type TransferBatch struct {
+	Transfers []Transfer `json:"transfers"`
+}
+
+// SanityCheck performs a sanity check on the batch transfer.
+func (tb *TransferBatch) SanityCheck() error {
+	if len(tb.Transfers) == 0 {
+		return fmt.Errorf("%w: empty transfer batch", ErrInvalidArgument)
+	}
+	if len(tb.Transfers) > MaxTransferBatchSize {
+		return fmt.Errorf("%w: too many transfers in batch (max: %d)", ErrInvalidArgument, MaxTransferBatchSize)
+	}
+	return nil
+}
+
+// PrettyPrint writes a pretty-printed representation of TransferBatch to the
+// given writer.
+func (tb TransferBatch) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sTransfers:\n", prefix)
+	for i, xfer := range tb.Transfers {
+		fmt.Fprintf(w, "%s  %d:\n", prefix, i+1)
+		xfer.PrettyPrint(ctx, prefix+"    ", w)
+	}
+}
+
+// PrettyType returns a representation of TransferBatch that can be used for
+// pretty printing.
+func (tb TransferBatch) PrettyType() (interface{}, error) {
+	return tb, nil
+}
+
+// NewTransferBatchTx creates a new batch transfer transaction.
+func NewTransferBatchTx(nonce uint64, fee *transaction.Fee, batch *TransferBatch) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodTransferBatch, batch)
+}
+
 // Burn is a stake burn (destruction).
 type Burn struct {
 	Amount quantity.Quantity `json:"amount"`
@@ -300,6 +684,21 @@ func NewBurnTx(nonce uint64, fee *transaction.Fee, burn *Burn) *transaction.Tran
 type Escrow struct {
 	Account Address           `json:"account"`
 	Amount  quantity.Quantity `json:"amount"`
+
+	// Beneficiary is the optional address of the account that is credited
+	// with the resulting delegation shares. If not set, the shares are
+	// credited to the transaction signer.
+	Beneficiary *Address `json:"beneficiary,omitempty"`
+}
+
+// Delegator returns the address of the account that is credited with the
+// delegation shares resulting from the escrow when signed by the given
+// signer.
+func (e *Escrow) Delegator(signer Address) Address {
+	if e.Beneficiary != nil {
+		return *e.Beneficiary
+	}
+	return signer
 }
 
 // PrettyPrint writes a pretty-printed representation of Escrow to the given
@@ -310,6 +709,10 @@ func (e Escrow) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
 	fmt.Fprintf(w, "%sAmount:  ", prefix)
 	token.PrettyPrintAmount(ctx, e.Amount, w)
 	fmt.Fprintln(w)
+
+	if e.Beneficiary != nil {
+		fmt.Fprintf(w, "%sBeneficiary: %s\n", prefix, e.Beneficiary)
+	}
 }
 
 // PrettyType returns a representation of Escrow that can be used for pretty
@@ -421,6 +824,35 @@ func NewWithdrawTx(nonce uint64, fee *transaction.Fee, withdraw *Withdraw) *tran
 	return transaction.NewTransaction(nonce, fee, MethodWithdraw, withdraw)
 }
 
+// SetCommissionDestination is a commission destination configuration.
+//
+// Setting the destination to the escrow account's own address restores the default behavior of
+// self-delegating earned commission.
+type SetCommissionDestination struct {
+	Destination Address `json:"destination"`
+}
+
+// PrettyPrint writes a pretty-printed representation of SetCommissionDestination to the given
+// writer.
+func (scd SetCommissionDestination) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sDestination: %s\n", prefix, scd.Destination)
+}
+
+// PrettyType returns a representation of SetCommissionDestination that can be used for pretty
+// printing.
+func (scd SetCommissionDestination) PrettyType() (interface{}, error) {
+	return scd, nil
+}
+
+// NewSetCommissionDestinationTx creates a new set commission destination transaction.
+func NewSetCommissionDestinationTx(
+	nonce uint64,
+	fee *transaction.Fee,
+	setCommissionDestination *SetCommissionDestination,
+) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodSetCommissionDestination, setCommissionDestination)
+}
+
 // SharePool is a combined balance of several entries, the relative sizes
 // of which are tracked through shares.
 type SharePool struct {
@@ -819,6 +1251,10 @@ type EscrowAccount struct {
 	Debonding          SharePool          `json:"debonding,omitempty"`
 	CommissionSchedule CommissionSchedule `json:"commission_schedule,omitempty"`
 	StakeAccumulator   StakeAccumulator   `json:"stake_accumulator,omitempty"`
+
+	// CommissionDestination is an optional address of the account that earned commission is
+	// transferred to. If not set, commission is self-delegated to the escrow account.
+	CommissionDestination *Address `json:"commission_destination,omitempty"`
 }
 
 // PrettyPrint writes a pretty-printed representation of EscrowAccount to the
@@ -835,6 +1271,10 @@ func (e EscrowAccount) PrettyPrint(ctx context.Context, prefix string, w io.Writ
 
 	fmt.Fprintf(w, "%sStake Accumulator:\n", prefix)
 	e.StakeAccumulator.PrettyPrint(ctx, prefix+"  ", w)
+
+	if e.CommissionDestination != nil {
+		fmt.Fprintf(w, "%sCommission Destination: %s\n", prefix, e.CommissionDestination)
+	}
 }
 
 // PrettyType returns a representation of EscrowAccount that can be used for
@@ -903,6 +1343,23 @@ type Account struct {
 	Escrow  EscrowAccount  `json:"escrow,omitempty"`
 }
 
+// IsEmpty returns true iff the account has a zero balance, a zero nonce and
+// does not hold any other state, making it equivalent to an account that does
+// not exist in the ledger.
+func (a *Account) IsEmpty() bool {
+	return a.General.Balance.IsZero() &&
+		a.General.Nonce == 0 &&
+		len(a.General.Allowances) == 0 &&
+		a.Escrow.Active.Balance.IsZero() &&
+		a.Escrow.Active.TotalShares.IsZero() &&
+		a.Escrow.Debonding.Balance.IsZero() &&
+		a.Escrow.Debonding.TotalShares.IsZero() &&
+		len(a.Escrow.CommissionSchedule.Rates) == 0 &&
+		len(a.Escrow.CommissionSchedule.Bounds) == 0 &&
+		len(a.Escrow.StakeAccumulator.Claims) == 0 &&
+		a.Escrow.CommissionDestination == nil
+}
+
 // PrettyPrint writes a pretty-printed representation of Account to the given
 // writer.
 func (a Account) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
@@ -918,6 +1375,17 @@ func (a Account) PrettyType() (interface{}, error) {
 	return a, nil
 }
 
+// AccountHistoryEntry is a retained snapshot of a watched account at a given
+// block height together with the events involving the account at that height.
+type AccountHistoryEntry struct {
+	// Height is the block height of the snapshot.
+	Height int64 `json:"height"`
+	// Account is the state of the account at the given height.
+	Account *Account `json:"account"`
+	// Events are the events involving the account at the given height.
+	Events []*Event `json:"events,omitempty"`
+}
+
 // Delegation is a delegation descriptor.
 type Delegation struct {
 	Shares quantity.Quantity `json:"shares"`
@@ -948,6 +1416,10 @@ type Genesis struct {
 	// LastBlockFees are the collected fees for previous block.
 	LastBlockFees quantity.Quantity `json:"last_block_fees"`
 
+	// AdaptiveRewardScale is the current reward scale under the adaptive
+	// reward schedule.
+	AdaptiveRewardScale *quantity.Quantity `json:"adaptive_reward_scale,omitempty"`
+
 	// Ledger is a map of staking accounts.
 	Ledger map[Address]*Account `json:"ledger,omitempty"`
 
@@ -970,6 +1442,7 @@ type ConsensusParameters struct { // nolint: maligned
 	Slashing                          map[SlashReason]Slash               `json:"slashing,omitempty"`
 	GasCosts                          transaction.Costs                   `json:"gas_costs,omitempty"`
 	MinDelegationAmount               quantity.Quantity                   `json:"min_delegation"`
+	MinTransferAmount                 quantity.Quantity                   `json:"min_transfer"`
 
 	DisableTransfers       bool             `json:"disable_transfers,omitempty"`
 	DisableDelegation      bool             `json:"disable_delegation,omitempty"`
@@ -991,6 +1464,23 @@ type ConsensusParameters struct { // nolint: maligned
 	// RewardFactorBlockProposed is the factor for a reward distributed per block
 	// to the entity that proposed the block.
 	RewardFactorBlockProposed quantity.Quantity `json:"reward_factor_block_proposed"`
+
+	// DelegationSnapshotEpochs is the number of most recent epochs for which
+	// snapshots of all delegations are retained. Zero means disabled.
+	DelegationSnapshotEpochs uint64 `json:"delegation_snapshot_epochs,omitempty"`
+
+	// AdaptiveRewards are the parameters of the adaptive reward schedule. If
+	// set, the reward scale is adjusted each epoch toward a target staked
+	// ratio and RewardSchedule is ignored.
+	AdaptiveRewards *AdaptiveRewardParameters `json:"adaptive_rewards,omitempty"`
+
+	// AccountReapingInterval is the interval (in epochs) at which empty accounts (with a zero
+	// balance, zero nonce and no other state) are removed from the ledger. Zero means disabled.
+	AccountReapingInterval epochtime.EpochTime `json:"account_reaping_interval,omitempty"`
+
+	// AddressPolicy is the optional policy constraining which addresses may take part in
+	// transfers and delegations.
+	AddressPolicy *AddressPolicy `json:"address_policy,omitempty"`
 }
 
 const (
@@ -1008,4 +1498,6 @@ const (
 	GasOpAllow transaction.Op = "allow"
 	// GasOpWithdraw is the gas operation identifier for withdraw.
 	GasOpWithdraw transaction.Op = "withdraw"
+	// GasOpSetCommissionDestination is the gas operation identifier for set commission destination.
+	GasOpSetCommissionDestination transaction.Op = "set_commission_destination"
 )
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 )
 
@@ -209,3 +210,26 @@ func TestStakeAccumulator(t *testing.T) {
 	require.Error(err, "escrow account should no longer check out")
 	require.Equal(err, ErrInsufficientStake)
 }
+
+func TestEventRelatedAddresses(t *testing.T) {
+	require := require.New(t)
+
+	addr1 := NewAddress(signature.NewPublicKey("badadd1e55ffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	addr2 := NewAddress(signature.NewPublicKey("badbadadd1e55fffffffffffffffffffffffffffffffffffffffffffffffffff"))
+
+	for _, tc := range []struct {
+		ev       Event
+		expected []Address
+	}{
+		{Event{Transfer: &TransferEvent{From: addr1, To: addr2}}, []Address{addr1, addr2}},
+		{Event{Burn: &BurnEvent{Owner: addr1}}, []Address{addr1}},
+		{Event{Escrow: &EscrowEvent{Add: &AddEscrowEvent{Owner: addr1, Escrow: addr2}}}, []Address{addr1, addr2}},
+		{Event{Escrow: &EscrowEvent{Take: &TakeEscrowEvent{Owner: addr2}}}, []Address{addr2}},
+		{Event{Escrow: &EscrowEvent{Reclaim: &ReclaimEscrowEvent{Owner: addr1, Escrow: addr2}}}, []Address{addr1, addr2}},
+		{Event{AllowanceChange: &AllowanceChangeEvent{Owner: addr1, Beneficiary: addr2}}, []Address{addr1, addr2}},
+		{Event{CommissionDestinationChange: &CommissionDestinationChangeEvent{Owner: addr2, Destination: addr1}}, []Address{addr2, addr1}},
+		{Event{}, nil},
+	} {
+		require.Equal(tc.expected, tc.ev.RelatedAddresses(), "RelatedAddresses")
+	}
+}
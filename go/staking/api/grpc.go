@@ -24,27 +24,51 @@ var (
 	methodCommonPool = serviceName.NewMethod("CommonPool", int64(0))
 	// methodLastBlockFees is the LastBlockFees method.
 	methodLastBlockFees = serviceName.NewMethod("LastBlockFees", int64(0))
+	// methodRewardScale is the RewardScale method.
+	methodRewardScale = serviceName.NewMethod("RewardScale", int64(0))
 	// methodThreshold is the Threshold method.
 	methodThreshold = serviceName.NewMethod("Threshold", ThresholdQuery{})
+	// methodThresholds is the Thresholds method.
+	methodThresholds = serviceName.NewMethod("Thresholds", int64(0))
 	// methodAddresses is the Addresses method.
 	methodAddresses = serviceName.NewMethod("Addresses", int64(0))
+	// methodBelowThresholdAccounts is the BelowThresholdAccounts method.
+	methodBelowThresholdAccounts = serviceName.NewMethod("BelowThresholdAccounts", int64(0))
 	// methodAccount is the Account method.
 	methodAccount = serviceName.NewMethod("Account", OwnerQuery{})
 	// methodDelegations is the Delegations method.
 	methodDelegations = serviceName.NewMethod("Delegations", OwnerQuery{})
+	// methodDelegationsTo is the DelegationsTo method.
+	methodDelegationsTo = serviceName.NewMethod("DelegationsTo", OwnerQuery{})
+	// methodDelegationsAt is the DelegationsAt method.
+	methodDelegationsAt = serviceName.NewMethod("DelegationsAt", DelegationsAtQuery{})
 	// methodDebondingDelegations is the DebondingDelegations method.
 	methodDebondingDelegations = serviceName.NewMethod("DebondingDelegations", OwnerQuery{})
+	// methodDebondingDelegationsTo is the DebondingDelegationsTo method.
+	methodDebondingDelegationsTo = serviceName.NewMethod("DebondingDelegationsTo", OwnerQuery{})
 	// methodAllowance is the Allowance method.
 	methodAllowance = serviceName.NewMethod("Allowance", AllowanceQuery{})
+	// methodCommissionAt is the CommissionAt method.
+	methodCommissionAt = serviceName.NewMethod("CommissionAt", CommissionAtQuery{})
+	// methodRewardsAt is the RewardsAt method.
+	methodRewardsAt = serviceName.NewMethod("RewardsAt", RewardsAtQuery{})
+	// methodAccountStateProof is the AccountStateProof method.
+	methodAccountStateProof = serviceName.NewMethod("AccountStateProof", OwnerQuery{})
 	// methodStateToGenesis is the StateToGenesis method.
 	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
 	// methodConsensusParameters is the ConsensusParameters method.
 	methodConsensusParameters = serviceName.NewMethod("ConsensusParameters", int64(0))
 	// methodGetEvents is the GetEvents method.
 	methodGetEvents = serviceName.NewMethod("GetEvents", int64(0))
+	// methodQueryEvents is the QueryEvents method.
+	methodQueryEvents = serviceName.NewMethod("QueryEvents", EventFilter{})
 
 	// methodWatchEvents is the WatchEvents method.
 	methodWatchEvents = serviceName.NewMethod("WatchEvents", nil)
+	// methodWatchEventsFrom is the WatchEventsFrom method.
+	methodWatchEventsFrom = serviceName.NewMethod("WatchEventsFrom", int64(0))
+	// methodWatchCommissionScheduleAmendments is the WatchCommissionScheduleAmendments method.
+	methodWatchCommissionScheduleAmendments = serviceName.NewMethod("WatchCommissionScheduleAmendments", nil)
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -71,14 +95,26 @@ var (
 				MethodName: methodLastBlockFees.ShortName(),
 				Handler:    handlerLastBlockFees,
 			},
+			{
+				MethodName: methodRewardScale.ShortName(),
+				Handler:    handlerRewardScale,
+			},
 			{
 				MethodName: methodThreshold.ShortName(),
 				Handler:    handlerThreshold,
 			},
+			{
+				MethodName: methodThresholds.ShortName(),
+				Handler:    handlerThresholds,
+			},
 			{
 				MethodName: methodAddresses.ShortName(),
 				Handler:    handlerAddresses,
 			},
+			{
+				MethodName: methodBelowThresholdAccounts.ShortName(),
+				Handler:    handlerBelowThresholdAccounts,
+			},
 			{
 				MethodName: methodAccount.ShortName(),
 				Handler:    handlerAccount,
@@ -87,14 +123,38 @@ var (
 				MethodName: methodDelegations.ShortName(),
 				Handler:    handlerDelegations,
 			},
+			{
+				MethodName: methodDelegationsTo.ShortName(),
+				Handler:    handlerDelegationsTo,
+			},
+			{
+				MethodName: methodDelegationsAt.ShortName(),
+				Handler:    handlerDelegationsAt,
+			},
 			{
 				MethodName: methodDebondingDelegations.ShortName(),
 				Handler:    handlerDebondingDelegations,
 			},
+			{
+				MethodName: methodDebondingDelegationsTo.ShortName(),
+				Handler:    handlerDebondingDelegationsTo,
+			},
 			{
 				MethodName: methodAllowance.ShortName(),
 				Handler:    handlerAllowance,
 			},
+			{
+				MethodName: methodCommissionAt.ShortName(),
+				Handler:    handlerCommissionAt,
+			},
+			{
+				MethodName: methodRewardsAt.ShortName(),
+				Handler:    handlerRewardsAt,
+			},
+			{
+				MethodName: methodAccountStateProof.ShortName(),
+				Handler:    handlerAccountStateProof,
+			},
 			{
 				MethodName: methodStateToGenesis.ShortName(),
 				Handler:    handlerStateToGenesis,
@@ -107,6 +167,10 @@ var (
 				MethodName: methodGetEvents.ShortName(),
 				Handler:    handlerGetEvents,
 			},
+			{
+				MethodName: methodQueryEvents.ShortName(),
+				Handler:    handlerQueryEvents,
+			},
 		},
 		Streams: []grpc.StreamDesc{
 			{
@@ -114,6 +178,16 @@ var (
 				Handler:       handlerWatchEvents,
 				ServerStreams: true,
 			},
+			{
+				StreamName:    methodWatchCommissionScheduleAmendments.ShortName(),
+				Handler:       handlerWatchCommissionScheduleAmendments,
+				ServerStreams: true,
+			},
+			{
+				StreamName:    methodWatchEventsFrom.ShortName(),
+				Handler:       handlerWatchEventsFrom,
+				ServerStreams: true,
+			},
 		},
 	}
 )
@@ -225,6 +299,29 @@ func handlerLastBlockFees( // nolint: golint
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerRewardScale( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var height int64
+	if err := dec(&height); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).RewardScale(ctx, height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodRewardScale.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).RewardScale(ctx, req.(int64))
+	}
+	return interceptor(ctx, height, info, handler)
+}
+
 func handlerThreshold( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -248,6 +345,29 @@ func handlerThreshold( // nolint: golint
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerThresholds( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var height int64
+	if err := dec(&height); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).Thresholds(ctx, height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodThresholds.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).Thresholds(ctx, req.(int64))
+	}
+	return interceptor(ctx, height, info, handler)
+}
+
 func handlerAddresses( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -271,6 +391,29 @@ func handlerAddresses( // nolint: golint
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerBelowThresholdAccounts( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var height int64
+	if err := dec(&height); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).BelowThresholdAccounts(ctx, height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodBelowThresholdAccounts.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).BelowThresholdAccounts(ctx, req.(int64))
+	}
+	return interceptor(ctx, height, info, handler)
+}
+
 func handlerAccount( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -317,6 +460,52 @@ func handlerDelegations( // nolint: golint
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerDelegationsTo( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query OwnerQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).DelegationsTo(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodDelegationsTo.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).DelegationsTo(ctx, req.(*OwnerQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
+func handlerDelegationsAt( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query DelegationsAtQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).DelegationsAt(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodDelegationsAt.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).DelegationsAt(ctx, req.(*DelegationsAtQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerDebondingDelegations( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -340,6 +529,29 @@ func handlerDebondingDelegations( // nolint: golint
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerDebondingDelegationsTo( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query OwnerQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).DebondingDelegationsTo(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodDebondingDelegationsTo.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).DebondingDelegationsTo(ctx, req.(*OwnerQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerAllowance( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -363,6 +575,75 @@ func handlerAllowance( // nolint: golint
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerCommissionAt( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query CommissionAtQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).CommissionAt(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodCommissionAt.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).CommissionAt(ctx, req.(*CommissionAtQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
+func handlerRewardsAt( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query RewardsAtQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).RewardsAt(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodRewardsAt.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).RewardsAt(ctx, req.(*RewardsAtQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
+func handlerAccountStateProof( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query OwnerQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).AccountStateProof(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodAccountStateProof.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).AccountStateProof(ctx, req.(*OwnerQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerStateToGenesis( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -432,6 +713,29 @@ func handlerGetEvents( // nolint: golint
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerQueryEvents( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var filter EventFilter
+	if err := dec(&filter); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).QueryEvents(ctx, &filter)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodQueryEvents.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).QueryEvents(ctx, req.(*EventFilter))
+	}
+	return interceptor(ctx, &filter, info, handler)
+}
+
 func handlerWatchEvents(srv interface{}, stream grpc.ServerStream) error {
 	if err := stream.RecvMsg(nil); err != nil {
 		return err
@@ -460,6 +764,63 @@ func handlerWatchEvents(srv interface{}, stream grpc.ServerStream) error {
 	}
 }
 
+func handlerWatchEventsFrom(srv interface{}, stream grpc.ServerStream) error {
+	var startHeight int64
+	if err := stream.RecvMsg(&startHeight); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(Backend).WatchEventsFrom(ctx, startHeight)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.SendMsg(ev); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func handlerWatchCommissionScheduleAmendments(srv interface{}, stream grpc.ServerStream) error {
+	if err := stream.RecvMsg(nil); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(Backend).WatchCommissionScheduleAmendments(ctx)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.SendMsg(ev); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 // RegisterService registers a new staking backend service with the given gRPC server.
 func RegisterService(server *grpc.Server, service Backend) {
 	server.RegisterService(&serviceDesc, service)
@@ -509,6 +870,14 @@ func (c *stakingClient) LastBlockFees(ctx context.Context, height int64) (*quant
 	return &rsp, nil
 }
 
+func (c *stakingClient) RewardScale(ctx context.Context, height int64) (*quantity.Quantity, error) {
+	var rsp quantity.Quantity
+	if err := c.conn.Invoke(ctx, methodRewardScale.FullName(), height, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *stakingClient) Threshold(ctx context.Context, query *ThresholdQuery) (*quantity.Quantity, error) {
 	var rsp quantity.Quantity
 	if err := c.conn.Invoke(ctx, methodThreshold.FullName(), query, &rsp); err != nil {
@@ -517,6 +886,14 @@ func (c *stakingClient) Threshold(ctx context.Context, query *ThresholdQuery) (*
 	return &rsp, nil
 }
 
+func (c *stakingClient) Thresholds(ctx context.Context, height int64) (map[ThresholdKind]quantity.Quantity, error) {
+	var rsp map[ThresholdKind]quantity.Quantity
+	if err := c.conn.Invoke(ctx, methodThresholds.FullName(), height, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 func (c *stakingClient) Addresses(ctx context.Context, height int64) ([]Address, error) {
 	var rsp []Address
 	if err := c.conn.Invoke(ctx, methodAddresses.FullName(), height, &rsp); err != nil {
@@ -525,6 +902,14 @@ func (c *stakingClient) Addresses(ctx context.Context, height int64) ([]Address,
 	return rsp, nil
 }
 
+func (c *stakingClient) BelowThresholdAccounts(ctx context.Context, height int64) ([]Address, error) {
+	var rsp []Address
+	if err := c.conn.Invoke(ctx, methodBelowThresholdAccounts.FullName(), height, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 func (c *stakingClient) Account(ctx context.Context, query *OwnerQuery) (*Account, error) {
 	var rsp Account
 	if err := c.conn.Invoke(ctx, methodAccount.FullName(), query, &rsp); err != nil {
@@ -541,6 +926,22 @@ func (c *stakingClient) Delegations(ctx context.Context, query *OwnerQuery) (map
 	return rsp, nil
 }
 
+func (c *stakingClient) DelegationsTo(ctx context.Context, query *OwnerQuery) (map[Address]*Delegation, error) {
+	var rsp map[Address]*Delegation
+	if err := c.conn.Invoke(ctx, methodDelegationsTo.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *stakingClient) DelegationsAt(ctx context.Context, query *DelegationsAtQuery) (map[Address]*Delegation, error) {
+	var rsp map[Address]*Delegation
+	if err := c.conn.Invoke(ctx, methodDelegationsAt.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 func (c *stakingClient) DebondingDelegations(ctx context.Context, query *OwnerQuery) (map[Address][]*DebondingDelegation, error) {
 	var rsp map[Address][]*DebondingDelegation
 	if err := c.conn.Invoke(ctx, methodDebondingDelegations.FullName(), query, &rsp); err != nil {
@@ -549,6 +950,14 @@ func (c *stakingClient) DebondingDelegations(ctx context.Context, query *OwnerQu
 	return rsp, nil
 }
 
+func (c *stakingClient) DebondingDelegationsTo(ctx context.Context, query *OwnerQuery) (map[Address][]*DebondingDelegation, error) {
+	var rsp map[Address][]*DebondingDelegation
+	if err := c.conn.Invoke(ctx, methodDebondingDelegationsTo.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 func (c *stakingClient) Allowance(ctx context.Context, query *AllowanceQuery) (*quantity.Quantity, error) {
 	var rsp quantity.Quantity
 	if err := c.conn.Invoke(ctx, methodAllowance.FullName(), query, &rsp); err != nil {
@@ -557,6 +966,30 @@ func (c *stakingClient) Allowance(ctx context.Context, query *AllowanceQuery) (*
 	return &rsp, nil
 }
 
+func (c *stakingClient) CommissionAt(ctx context.Context, query *CommissionAtQuery) (*quantity.Quantity, error) {
+	var rsp quantity.Quantity
+	if err := c.conn.Invoke(ctx, methodCommissionAt.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *stakingClient) RewardsAt(ctx context.Context, query *RewardsAtQuery) (*EpochRewards, error) {
+	var rsp EpochRewards
+	if err := c.conn.Invoke(ctx, methodRewardsAt.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *stakingClient) AccountStateProof(ctx context.Context, query *OwnerQuery) (*AccountStateProof, error) {
+	var rsp AccountStateProof
+	if err := c.conn.Invoke(ctx, methodAccountStateProof.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *stakingClient) StateToGenesis(ctx context.Context, height int64) (*Genesis, error) {
 	var rsp Genesis
 	if err := c.conn.Invoke(ctx, methodStateToGenesis.FullName(), height, &rsp); err != nil {
@@ -581,6 +1014,14 @@ func (c *stakingClient) GetEvents(ctx context.Context, height int64) ([]*Event,
 	return rsp, nil
 }
 
+func (c *stakingClient) QueryEvents(ctx context.Context, filter *EventFilter) (*EventQueryResult, error) {
+	var rsp EventQueryResult
+	if err := c.conn.Invoke(ctx, methodQueryEvents.FullName(), filter, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *stakingClient) WatchEvents(ctx context.Context) (<-chan *Event, pubsub.ClosableSubscription, error) {
 	ctx, sub := pubsub.NewContextSubscription(ctx)
 
@@ -616,6 +1057,78 @@ func (c *stakingClient) WatchEvents(ctx context.Context) (<-chan *Event, pubsub.
 	return ch, sub, nil
 }
 
+func (c *stakingClient) WatchEventsFrom(ctx context.Context, startHeight int64) (<-chan *Event, pubsub.ClosableSubscription, error) {
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[2], methodWatchEventsFrom.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(startHeight); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *Event)
+	go func() {
+		defer close(ch)
+
+		for {
+			var ev Event
+			if serr := stream.RecvMsg(&ev); serr != nil {
+				return
+			}
+
+			select {
+			case ch <- &ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
+func (c *stakingClient) WatchCommissionScheduleAmendments(
+	ctx context.Context,
+) (<-chan *CommissionScheduleAmendmentEvent, pubsub.ClosableSubscription, error) {
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[1], methodWatchCommissionScheduleAmendments.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(nil); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *CommissionScheduleAmendmentEvent)
+	go func() {
+		defer close(ch)
+
+		for {
+			var ev CommissionScheduleAmendmentEvent
+			if serr := stream.RecvMsg(&ev); serr != nil {
+				return
+			}
+
+			select {
+			case ch <- &ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
 func (c *stakingClient) Cleanup() {
 }
 
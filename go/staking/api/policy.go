@@ -0,0 +1,183 @@
+package api
+
+import (
+	"fmt"
+)
+
+// AddressListMode is the mode of an address list.
+type AddressListMode uint8
+
+const (
+	// AddressListAllow means that only the listed addresses are permitted.
+	AddressListAllow AddressListMode = 0
+	// AddressListDeny means that all but the listed addresses are permitted.
+	AddressListDeny AddressListMode = 1
+)
+
+// String returns a string representation of the address list mode.
+func (m AddressListMode) String() string {
+	switch m {
+	case AddressListAllow:
+		return "allow"
+	case AddressListDeny:
+		return "deny"
+	default:
+		return "[unknown address list mode]"
+	}
+}
+
+// AddressList is a list of addresses that are either permitted or denied.
+type AddressList struct {
+	// Mode is the address list mode.
+	Mode AddressListMode `json:"mode"`
+	// Addresses are the listed addresses.
+	Addresses map[Address]bool `json:"addresses,omitempty"`
+}
+
+// Permits returns true iff the given address is permitted by the address list. A nil address
+// list permits all addresses.
+func (l *AddressList) Permits(addr Address) bool {
+	if l == nil {
+		return true
+	}
+
+	listed := l.Addresses[addr]
+	switch l.Mode {
+	case AddressListAllow:
+		return listed
+	default:
+		return !listed
+	}
+}
+
+// SanityCheck performs a sanity check on the address list.
+func (l *AddressList) SanityCheck() error {
+	if l == nil {
+		return nil
+	}
+
+	switch l.Mode {
+	case AddressListAllow, AddressListDeny:
+	default:
+		return fmt.Errorf("invalid address list mode: %d", l.Mode)
+	}
+	for addr := range l.Addresses {
+		if !addr.IsValid() {
+			return fmt.Errorf("invalid address: %s", addr)
+		}
+	}
+	return nil
+}
+
+// AddressPolicy constrains which addresses may take part in transfers and delegations, e.g., in
+// permissioned (consortium) networks.
+//
+// Each list is optional and an unset list does not constrain the corresponding operation.
+type AddressPolicy struct {
+	// Send constrains which accounts may send transfers (including withdrawals from their
+	// account).
+	Send *AddressList `json:"send,omitempty"`
+	// Receive constrains which accounts may receive transfers (including withdrawals into their
+	// account).
+	Receive *AddressList `json:"receive,omitempty"`
+	// Delegate constrains which accounts may delegate to escrow accounts.
+	Delegate *AddressList `json:"delegate,omitempty"`
+	// ReceiveDelegation constrains which escrow accounts may receive delegations.
+	ReceiveDelegation *AddressList `json:"receive_delegation,omitempty"`
+}
+
+// SanityCheck performs a sanity check on the address policy.
+func (p *AddressPolicy) SanityCheck() error {
+	for _, v := range []struct {
+		name string
+		l    *AddressList
+	}{
+		{"send", p.Send},
+		{"receive", p.Receive},
+		{"delegate", p.Delegate},
+		{"receive delegation", p.ReceiveDelegation},
+	} {
+		if err := v.l.SanityCheck(); err != nil {
+			return fmt.Errorf("address policy: %s: %w", v.name, err)
+		}
+	}
+	return nil
+}
+
+// CheckTransfer checks whether a transfer between the given accounts is permitted by the policy.
+//
+// In case the transfer is not permitted, the returned event describes the violation.
+func (p *AddressPolicy) CheckTransfer(from, to Address) (*PolicyViolationEvent, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	switch {
+	case !p.Send.Permits(from):
+		return &PolicyViolationEvent{Kind: PolicyViolationSend, Address: from, Counterparty: to}, ErrSendForbiddenByPolicy
+	case !p.Receive.Permits(to):
+		return &PolicyViolationEvent{Kind: PolicyViolationReceive, Address: to, Counterparty: from}, ErrReceiveForbiddenByPolicy
+	default:
+		return nil, nil
+	}
+}
+
+// CheckDelegation checks whether a delegation from the given account to the given escrow account
+// is permitted by the policy.
+//
+// In case the delegation is not permitted, the returned event describes the violation.
+func (p *AddressPolicy) CheckDelegation(owner, escrow Address) (*PolicyViolationEvent, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	switch {
+	case !p.Delegate.Permits(owner):
+		return &PolicyViolationEvent{Kind: PolicyViolationDelegate, Address: owner, Counterparty: escrow}, ErrDelegateForbiddenByPolicy
+	case !p.ReceiveDelegation.Permits(escrow):
+		return &PolicyViolationEvent{Kind: PolicyViolationReceiveDelegation, Address: escrow, Counterparty: owner}, ErrReceiveDelegationForbiddenByPolicy
+	default:
+		return nil, nil
+	}
+}
+
+// PolicyViolationKind is the kind of an address policy violation.
+type PolicyViolationKind uint8
+
+const (
+	// PolicyViolationSend is a violation of the send address list.
+	PolicyViolationSend PolicyViolationKind = 1
+	// PolicyViolationReceive is a violation of the receive address list.
+	PolicyViolationReceive PolicyViolationKind = 2
+	// PolicyViolationDelegate is a violation of the delegate address list.
+	PolicyViolationDelegate PolicyViolationKind = 3
+	// PolicyViolationReceiveDelegation is a violation of the receive delegation address list.
+	PolicyViolationReceiveDelegation PolicyViolationKind = 4
+)
+
+// String returns a string representation of the policy violation kind.
+func (k PolicyViolationKind) String() string {
+	switch k {
+	case PolicyViolationSend:
+		return "send"
+	case PolicyViolationReceive:
+		return "receive"
+	case PolicyViolationDelegate:
+		return "delegate"
+	case PolicyViolationReceiveDelegation:
+		return "receive delegation"
+	default:
+		return "[unknown policy violation kind]"
+	}
+}
+
+// PolicyViolationEvent is the event emitted when a transaction is rejected due to the address
+// policy.
+type PolicyViolationEvent struct {
+	// Kind is the kind of the violation.
+	Kind PolicyViolationKind `json:"kind"`
+	// Address is the address that is not permitted.
+	Address Address `json:"address"`
+	// Counterparty is the other address involved in the rejected operation.
+	Counterparty Address `json:"counterparty"`
+}
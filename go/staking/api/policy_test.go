@@ -0,0 +1,62 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+)
+
+func TestAddressPolicy(t *testing.T) {
+	require := require.New(t)
+
+	alice := NewAddress(signature.NewPublicKey("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"))
+	bob := NewAddress(signature.NewPublicKey("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"))
+	carol := NewAddress(signature.NewPublicKey("cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc"))
+
+	var nilList *AddressList
+	require.True(nilList.Permits(alice), "nil address list should permit all addresses")
+	require.NoError(nilList.SanityCheck(), "nil address list should be valid")
+
+	allow := &AddressList{Mode: AddressListAllow, Addresses: map[Address]bool{alice: true}}
+	require.True(allow.Permits(alice), "allow list should permit listed addresses")
+	require.False(allow.Permits(bob), "allow list should not permit unlisted addresses")
+
+	deny := &AddressList{Mode: AddressListDeny, Addresses: map[Address]bool{carol: true}}
+	require.True(deny.Permits(alice), "deny list should permit unlisted addresses")
+	require.False(deny.Permits(carol), "deny list should not permit listed addresses")
+
+	require.Error((&AddressList{Mode: 42}).SanityCheck(), "invalid address list mode should be rejected")
+	require.Error((&AddressList{Addresses: map[Address]bool{FeeAccumulatorAddress: true}}).SanityCheck(), "reserved addresses should be rejected")
+
+	var nilPolicy *AddressPolicy
+	violation, err := nilPolicy.CheckTransfer(alice, bob)
+	require.NoError(err, "nil policy should permit transfers")
+	require.Nil(violation, "nil policy should not report violations")
+
+	policy := &AddressPolicy{
+		Send:              allow,
+		Receive:           deny,
+		ReceiveDelegation: allow,
+	}
+	require.NoError(policy.SanityCheck(), "address policy should be valid")
+
+	_, err = policy.CheckTransfer(alice, bob)
+	require.NoError(err, "transfer between permitted addresses should be permitted")
+
+	violation, err = policy.CheckTransfer(bob, alice)
+	require.Equal(ErrSendForbiddenByPolicy, err, "transfer from non-permitted sender should be rejected")
+	require.Equal(&PolicyViolationEvent{Kind: PolicyViolationSend, Address: bob, Counterparty: alice}, violation)
+
+	violation, err = policy.CheckTransfer(alice, carol)
+	require.Equal(ErrReceiveForbiddenByPolicy, err, "transfer to non-permitted receiver should be rejected")
+	require.Equal(&PolicyViolationEvent{Kind: PolicyViolationReceive, Address: carol, Counterparty: alice}, violation)
+
+	_, err = policy.CheckDelegation(bob, alice)
+	require.NoError(err, "delegation to permitted escrow account should be permitted")
+
+	violation, err = policy.CheckDelegation(alice, bob)
+	require.Equal(ErrReceiveDelegationForbiddenByPolicy, err, "delegation to non-permitted escrow account should be rejected")
+	require.Equal(&PolicyViolationEvent{Kind: PolicyViolationReceiveDelegation, Address: bob, Counterparty: alice}, violation)
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	mkvsNode "github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+)
+
+// AccountStateProof is the full state of an account at a given height together with a proof of
+// that state against the consensus state root.
+//
+// The proof covers the account itself, all of the delegations and all of the debonding
+// delegations, so that it also proves that no other delegations exist. To be meaningful, the
+// state root must be checked against a trusted source (e.g., a verified consensus block header).
+type AccountStateProof struct {
+	// Height is the block height at which the account state was exported.
+	Height int64 `json:"height"`
+	// StateRoot is the consensus state root the proof is for.
+	StateRoot mkvsNode.Root `json:"state_root"`
+
+	// Owner is the address of the account.
+	Owner Address `json:"owner"`
+	// Account is the account descriptor.
+	Account Account `json:"account"`
+	// Delegations are the delegations made by the account, keyed by escrow account.
+	Delegations map[Address]*Delegation `json:"delegations"`
+	// DebondingDelegations are the debonding delegations of the account, keyed by escrow account.
+	DebondingDelegations map[Address][]*DebondingDelegation `json:"debonding_delegations"`
+
+	// Proof is the MKVS proof of all the state entries above.
+	Proof syncer.Proof `json:"proof"`
+}
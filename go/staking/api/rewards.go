@@ -1,6 +1,7 @@
 package api
 
 import (
+	"fmt"
 	"math/big"
 
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
@@ -16,6 +17,122 @@ type RewardStep struct {
 	Scale quantity.Quantity   `json:"scale"`
 }
 
+// AdaptiveRewardParameters are the parameters of the adaptive reward schedule.
+//
+// Under the adaptive reward schedule the reward scale is adjusted at the end of
+// each epoch toward the scale that makes the ratio of actively escrowed stake
+// to the total supply reach the target ratio. If the staked ratio is below the
+// target the scale is increased, otherwise it is decreased.
+//
+// All scales and ratios are denominated in RewardAmountDenominator.
+type AdaptiveRewardParameters struct {
+	// TargetStakedRatio is the target ratio of actively escrowed stake to the
+	// total supply.
+	TargetStakedRatio quantity.Quantity `json:"target_staked_ratio"`
+
+	// InitialScale is the reward scale used until the first adjustment.
+	InitialScale quantity.Quantity `json:"initial_scale"`
+	// MinScale is the minimum reward scale.
+	MinScale quantity.Quantity `json:"min_scale"`
+	// MaxScale is the maximum reward scale.
+	MaxScale quantity.Quantity `json:"max_scale"`
+
+	// MaxScaleChange is the maximum change of the reward scale in a single
+	// epoch, which is applied when the staked ratio is furthest away from the
+	// target.
+	MaxScaleChange quantity.Quantity `json:"max_scale_change"`
+}
+
+// SanityCheck performs a sanity check on the adaptive reward parameters.
+func (p *AdaptiveRewardParameters) SanityCheck() error {
+	for _, v := range []struct {
+		name string
+		q    *quantity.Quantity
+	}{
+		{"target staked ratio", &p.TargetStakedRatio},
+		{"initial scale", &p.InitialScale},
+		{"min scale", &p.MinScale},
+		{"max scale", &p.MaxScale},
+		{"max scale change", &p.MaxScaleChange},
+	} {
+		if !v.q.IsValid() {
+			return fmt.Errorf("adaptive rewards: %s has invalid value", v.name)
+		}
+	}
+
+	if p.TargetStakedRatio.IsZero() || p.TargetStakedRatio.Cmp(RewardAmountDenominator) > 0 {
+		return fmt.Errorf("adaptive rewards: target staked ratio must be in (0, %s]", RewardAmountDenominator)
+	}
+	if p.MinScale.Cmp(&p.MaxScale) > 0 {
+		return fmt.Errorf("adaptive rewards: min scale must not be greater than max scale")
+	}
+	if p.InitialScale.Cmp(&p.MinScale) < 0 || p.InitialScale.Cmp(&p.MaxScale) > 0 {
+		return fmt.Errorf("adaptive rewards: initial scale must be between min and max scale")
+	}
+	return nil
+}
+
+// StakedRatio returns the ratio of the staked amount to the total supply,
+// denominated in RewardAmountDenominator.
+func StakedRatio(staked, totalSupply *quantity.Quantity) *quantity.Quantity {
+	if totalSupply.IsZero() {
+		return quantity.NewQuantity()
+	}
+
+	ratio := new(big.Int).Mul(staked.ToBigInt(), RewardAmountDenominator.ToBigInt())
+	ratio.Quo(ratio, totalSupply.ToBigInt())
+
+	var q quantity.Quantity
+	_ = q.FromBigInt(ratio)
+	return &q
+}
+
+// NextScale computes the reward scale for the next epoch given the current
+// scale and the current staked ratio.
+func (p *AdaptiveRewardParameters) NextScale(current, stakedRatio *quantity.Quantity) *quantity.Quantity {
+	target := p.TargetStakedRatio.ToBigInt()
+	ratio := stakedRatio.ToBigInt()
+	if ratio.Cmp(RewardAmountDenominator.ToBigInt()) > 0 {
+		ratio = RewardAmountDenominator.ToBigInt()
+	}
+
+	// The change is proportional to the distance from the target, relative to
+	// the largest possible distance in that direction.
+	var distance, maxDistance big.Int
+	increase := ratio.Cmp(target) < 0
+	if increase {
+		distance.Sub(target, ratio)
+		maxDistance.Set(target)
+	} else {
+		distance.Sub(ratio, target)
+		maxDistance.Sub(RewardAmountDenominator.ToBigInt(), target)
+	}
+
+	scale := current.ToBigInt()
+	if maxDistance.Sign() > 0 {
+		change := new(big.Int).Mul(p.MaxScaleChange.ToBigInt(), &distance)
+		change.Quo(change, &maxDistance)
+
+		if increase {
+			scale.Add(scale, change)
+		} else {
+			scale.Sub(scale, change)
+		}
+	}
+
+	// Clamp to the configured bounds.
+	if minScale := p.MinScale.ToBigInt(); scale.Cmp(minScale) < 0 {
+		scale = minScale
+	}
+	if maxScale := p.MaxScale.ToBigInt(); scale.Cmp(maxScale) > 0 {
+		scale = maxScale
+	}
+
+	var q quantity.Quantity
+	_ = q.FromBigInt(scale)
+	return &q
+}
+
 func init() {
 	// Denominated in one millionth of a percent.
 	RewardAmountDenominator = quantity.NewQuantity()
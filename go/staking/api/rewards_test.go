@@ -0,0 +1,71 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+func TestAdaptiveRewardParameters(t *testing.T) {
+	require := require.New(t)
+
+	params := AdaptiveRewardParameters{
+		TargetStakedRatio: mustInitQuantity(t, 50_000_000), // 50%.
+		InitialScale:      mustInitQuantity(t, 1000),
+		MinScale:          mustInitQuantity(t, 500),
+		MaxScale:          mustInitQuantity(t, 2000),
+		MaxScaleChange:    mustInitQuantity(t, 100),
+	}
+	require.NoError(params.SanityCheck(), "valid adaptive reward parameters")
+
+	// Invalid parameters.
+	invalid := params
+	invalid.TargetStakedRatio = mustInitQuantity(t, 0)
+	require.Error(invalid.SanityCheck(), "zero target staked ratio should be invalid")
+	invalid = params
+	invalid.TargetStakedRatio = mustInitQuantity(t, 100_000_001)
+	require.Error(invalid.SanityCheck(), "target staked ratio over 100% should be invalid")
+	invalid = params
+	invalid.MinScale = mustInitQuantity(t, 3000)
+	require.Error(invalid.SanityCheck(), "min scale greater than max scale should be invalid")
+	invalid = params
+	invalid.InitialScale = mustInitQuantity(t, 100)
+	require.Error(invalid.SanityCheck(), "initial scale out of bounds should be invalid")
+
+	for _, tc := range []struct {
+		current     int64
+		stakedRatio int64
+		expected    int64
+	}{
+		// Below target, increase proportionally.
+		{1000, 0, 1100},
+		{1000, 25_000_000, 1050},
+		// At target, no change.
+		{1000, 50_000_000, 1000},
+		// Above target, decrease proportionally.
+		{1000, 75_000_000, 950},
+		{1000, 100_000_000, 900},
+		{1000, 200_000_000, 900},
+		// Clamped to bounds.
+		{1990, 0, 2000},
+		{550, 100_000_000, 500},
+	} {
+		current := mustInitQuantity(t, tc.current)
+		stakedRatio := mustInitQuantity(t, tc.stakedRatio)
+		expected := mustInitQuantity(t, tc.expected)
+		next := params.NextScale(&current, &stakedRatio)
+		require.Zero(expected.Cmp(next), "NextScale(%d, %d) = %s, expected %d", tc.current, tc.stakedRatio, next, tc.expected)
+	}
+}
+
+func TestStakedRatio(t *testing.T) {
+	require := require.New(t)
+
+	ratio := StakedRatio(quantity.NewFromUint64(25), quantity.NewFromUint64(100))
+	require.Zero(quantity.NewFromUint64(25_000_000).Cmp(ratio), "StakedRatio")
+
+	ratio = StakedRatio(quantity.NewFromUint64(25), quantity.NewQuantity())
+	require.True(ratio.IsZero(), "StakedRatio with zero total supply")
+}
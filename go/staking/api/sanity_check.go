@@ -37,6 +37,20 @@ func (p *ConsensusParameters) SanityCheck() error {
 		return fmt.Errorf("fee split proportions are all zero")
 	}
 
+	// Adaptive rewards.
+	if p.AdaptiveRewards != nil {
+		if err := p.AdaptiveRewards.SanityCheck(); err != nil {
+			return err
+		}
+	}
+
+	// Address policy.
+	if p.AddressPolicy != nil {
+		if err := p.AddressPolicy.SanityCheck(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -81,6 +95,12 @@ func SanityCheckAccount(
 		)
 	}
 
+	if dst := acct.Escrow.CommissionDestination; dst != nil {
+		if !dst.IsValid() || dst.Equal(addr) {
+			return fmt.Errorf("staking: sanity check failed: account %s has invalid commission destination %s", addr, dst)
+		}
+	}
+
 	for beneficiary, allowance := range acct.General.Allowances {
 		if !beneficiary.IsValid() {
 			return fmt.Errorf("staking: sanity check failed: account %s allowance has invalid beneficiary address %s", addr, beneficiary)
@@ -255,6 +275,16 @@ func (g *Genesis) SanityCheck(now epochtime.EpochTime) error { // nolint: gocycl
 		return fmt.Errorf("staking: sanity check failed: token value exponent is invalid")
 	}
 
+	if g.AdaptiveRewardScale != nil {
+		ar := g.Parameters.AdaptiveRewards
+		if ar == nil {
+			return fmt.Errorf("staking: sanity check failed: adaptive reward scale set without adaptive rewards")
+		}
+		if !g.AdaptiveRewardScale.IsValid() || g.AdaptiveRewardScale.Cmp(&ar.MinScale) < 0 || g.AdaptiveRewardScale.Cmp(&ar.MaxScale) > 0 {
+			return fmt.Errorf("staking: sanity check failed: adaptive reward scale is invalid")
+		}
+	}
+
 	if !g.TotalSupply.IsValid() {
 		return fmt.Errorf("staking: sanity check failed: total supply is invalid")
 	}
@@ -47,6 +47,19 @@ func main() {
 				}
 			}
 
+			// Valid batch transfer transactions.
+			for _, amts := range [][]uint64{{1000}, {0, 1000, 10_000_000}} {
+				var batch staking.TransferBatch
+				for _, amt := range amts {
+					batch.Transfers = append(batch.Transfers, staking.Transfer{
+						To:     transferDstAddr,
+						Amount: *quantity.NewFromUint64(amt),
+					})
+				}
+				tx := staking.NewTransferBatchTx(nonce, fee, &batch)
+				vectors = append(vectors, testvectors.MakeTestVector("TransferBatch", tx))
+			}
+
 			// Valid burn transactions.
 			for _, amt := range []uint64{0, 1000, 10_000_000} {
 				for _, tx := range []*transaction.Transaction{
@@ -61,12 +74,19 @@ func main() {
 			// Valid escrow transactions.
 			escrowDst := memorySigner.NewTestSigner("oasis-core staking test vectors: Escrow dst")
 			escrowDstAddr := staking.NewAddress(escrowDst.Public())
+			escrowBeneficiary := memorySigner.NewTestSigner("oasis-core staking test vectors: Escrow beneficiary")
+			escrowBeneficiaryAddr := staking.NewAddress(escrowBeneficiary.Public())
 			for _, amt := range []uint64{0, 1000, 10_000_000} {
 				for _, tx := range []*transaction.Transaction{
 					staking.NewAddEscrowTx(nonce, fee, &staking.Escrow{
 						Account: escrowDstAddr,
 						Amount:  *quantity.NewFromUint64(amt),
 					}),
+					staking.NewAddEscrowTx(nonce, fee, &staking.Escrow{
+						Account:     escrowDstAddr,
+						Amount:      *quantity.NewFromUint64(amt),
+						Beneficiary: &escrowBeneficiaryAddr,
+					}),
 				} {
 					vectors = append(vectors, testvectors.MakeTestVector("Escrow", tx))
 				}
@@ -3,6 +3,7 @@ package tests
 
 import (
 	"context"
+	"errors"
 	"math"
 	"testing"
 	"time"
@@ -96,6 +97,9 @@ func StakingImplementationTests(
 	}{
 		{"Thresholds", testThresholds},
 		{"LastBlockFees", testLastBlockFees},
+		{"RewardScale", testRewardScale},
+		{"CommissionAt", testCommissionAt},
+		{"RewardsAt", testRewardsAt},
 		{"Transfer", testTransfer},
 		{"TransferSelf", testSelfTransfer},
 		{"Burn", testBurn},
@@ -123,6 +127,7 @@ func StakingClientImplementationTests(t *testing.T, backend api.Backend, consens
 	}{
 		{"Thresholds", testThresholds},
 		{"LastBlockFees", testLastBlockFees},
+		{"RewardScale", testRewardScale},
 		{"Transfer", testTransfer},
 		{"TransferSelf", testSelfTransfer},
 		{"Burn", testBurn},
@@ -151,6 +156,10 @@ func testThresholds(t *testing.T, state *stakingTestsState, backend api.Backend,
 		require.NotNil(qty, "Threshold != nil")
 		require.Equal(debugGenesisState.Parameters.Thresholds[kind], *qty, "Threshold - value")
 	}
+
+	thresholds, err := backend.Thresholds(context.Background(), consensusAPI.HeightLatest)
+	require.NoError(err, "Thresholds")
+	require.Equal(debugGenesisState.Parameters.Thresholds, thresholds, "Thresholds - value")
 }
 
 func testLastBlockFees(t *testing.T, state *stakingTestsState, backend api.Backend, consensus consensusAPI.Backend) {
@@ -161,6 +170,54 @@ func testLastBlockFees(t *testing.T, state *stakingTestsState, backend api.Backe
 	require.True(lastBlockFees.IsZero(), "LastBlockFees - initial value")
 }
 
+func testRewardScale(t *testing.T, state *stakingTestsState, backend api.Backend, consensus consensusAPI.Backend) {
+	require := require.New(t)
+
+	// The debug genesis state does not configure any rewards.
+	rewardScale, err := backend.RewardScale(context.Background(), consensusAPI.HeightLatest)
+	require.NoError(err, "RewardScale")
+	require.True(rewardScale.IsZero(), "RewardScale - no rewards")
+}
+
+func testCommissionAt(t *testing.T, state *stakingTestsState, backend api.Backend, consensus consensusAPI.Backend) {
+	require := require.New(t)
+
+	epoch, err := consensus.EpochTime().GetEpoch(context.Background(), consensusAPI.HeightLatest)
+	require.NoError(err, "GetEpoch")
+
+	acct, err := backend.Account(context.Background(), &api.OwnerQuery{Owner: SrcAddr, Height: consensusAPI.HeightLatest})
+	require.NoError(err, "Account")
+
+	for _, e := range []epochtime.EpochTime{epoch, epoch + 1, epoch + 1000} {
+		rate, cerr := backend.CommissionAt(context.Background(), &api.CommissionAtQuery{
+			Height: consensusAPI.HeightLatest,
+			Owner:  SrcAddr,
+			Epoch:  e,
+		})
+		require.NoError(cerr, "CommissionAt")
+
+		expected := quantity.NewQuantity()
+		if r := acct.Escrow.CommissionSchedule.CurrentRate(e); r != nil {
+			expected = r
+		}
+		require.Equal(expected, rate, "CommissionAt - rate at epoch %d", e)
+	}
+}
+
+func testRewardsAt(t *testing.T, state *stakingTestsState, backend api.Backend, consensus consensusAPI.Backend) {
+	require := require.New(t)
+
+	epoch, err := consensus.EpochTime().GetEpoch(context.Background(), consensusAPI.HeightLatest)
+	require.NoError(err, "GetEpoch")
+
+	// The report of the current epoch only becomes available after the epoch has ended.
+	_, err = backend.RewardsAt(context.Background(), &api.RewardsAtQuery{
+		Height: consensusAPI.HeightLatest,
+		Epoch:  epoch,
+	})
+	require.True(errors.Is(err, api.ErrNoEpochRewards), "RewardsAt - current epoch")
+}
+
 func testTransfer(t *testing.T, state *stakingTestsState, backend api.Backend, consensus consensusAPI.Backend) {
 	require := require.New(t)
 
@@ -182,7 +239,10 @@ func testTransfer(t *testing.T, state *stakingTestsState, backend api.Backend, c
 	err = consensusAPI.SignAndSubmitTx(context.Background(), consensus, srcSigner, tx)
 	require.NoError(err, "Transfer")
 
-	var gotTransfer bool
+	var (
+		gotTransfer    bool
+		transferHeight int64
+	)
 
 TransferWaitLoop:
 	for {
@@ -220,6 +280,7 @@ TransferWaitLoop:
 					}
 				}
 				require.True(gotTransfer, "GetEvents should return transfer event")
+				transferHeight = ev.Height
 			}
 
 			if gotTransfer {
@@ -230,6 +291,27 @@ TransferWaitLoop:
 		}
 	}
 
+	// Make sure that WatchEventsFrom replays the transfer event.
+	replayCh, replaySub, err := backend.WatchEventsFrom(context.Background(), transferHeight)
+	require.NoError(err, "WatchEventsFrom")
+	defer replaySub.Close()
+
+ReplayWaitLoop:
+	for {
+		select {
+		case ev, ok := <-replayCh:
+			require.True(ok, "WatchEventsFrom channel should not be closed")
+			require.True(ev.Height >= transferHeight, "replayed events should start at the requested height")
+			if ev.Transfer != nil && ev.Transfer.From.Equal(SrcAddr) && ev.Transfer.To.Equal(DestAddr) {
+				require.Equal(transferHeight, ev.Height, "replayed transfer event: height")
+				require.Equal(xfer.Amount, ev.Transfer.Amount, "replayed transfer event: amount")
+				break ReplayWaitLoop
+			}
+		case <-time.After(recvTimeout):
+			t.Fatalf("failed to receive replayed transfer event")
+		}
+	}
+
 	_ = srcAcc.General.Balance.Sub(&xfer.Amount)
 	newSrcAcc, err := backend.Account(context.Background(), &api.OwnerQuery{Owner: SrcAddr, Height: consensusAPI.HeightLatest})
 	require.NoError(err, "src: Account - after")
@@ -548,6 +630,11 @@ func testEscrowEx( // nolint: gocyclo
 	require.True(newDstAcc.Escrow.Debonding.Balance.IsZero(), "dst: debonding escrow balance == 0 - after 2nd")
 	require.True(newDstAcc.Escrow.Debonding.TotalShares.IsZero(), "dst: debonding escrow total shares == 0 - after 2nd")
 
+	delegationsTo, err := backend.DelegationsTo(context.Background(), &api.OwnerQuery{Owner: dstAddr, Height: consensusAPI.HeightLatest})
+	require.NoError(err, "DelegationsTo")
+	require.NotNil(delegationsTo[srcAddr], "DelegationsTo should include the delegation")
+	require.False(delegationsTo[srcAddr].Shares.IsZero(), "DelegationsTo: delegation shares")
+
 	srcAcc = newSrcAcc
 	dstAcc = newDstAcc
 	newSrcAcc = nil
@@ -571,6 +658,9 @@ func testEscrowEx( // nolint: gocyclo
 	require.NoError(err, "DebondingDelegations - after (in debonding)")
 	require.Len(debs, 1, "one debonding delegation after reclaiming escrow")
 	require.Len(debs[dstAddr], 1, "one debonding delegation after reclaiming escrow")
+	debsTo, err := backend.DebondingDelegationsTo(context.Background(), &api.OwnerQuery{Owner: dstAddr, Height: consensusAPI.HeightLatest})
+	require.NoError(err, "DebondingDelegationsTo - after (in debonding)")
+	require.EqualValues(debs[dstAddr], debsTo[srcAddr], "DebondingDelegationsTo should include the debonding delegation")
 
 	// Advance epoch to trigger debonding.
 	timeSource := consensus.EpochTime().(epochtime.SetableBackend)
@@ -0,0 +1,430 @@
+// Package watcher implements a node-local registry of watched staking
+// accounts.
+//
+// For each watched account the watcher retains a snapshot of the account
+// together with the events involving the account at every height at which
+// the account was involved in any events. The history is kept in the node's
+// common store and is therefore not affected by consensus state and event
+// pruning, allowing e.g., exchanges to run pruned nodes while keeping full
+// history for their own accounts.
+package watcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
+	"github.com/oasisprotocol/oasis-core/go/common/service"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+const (
+	// ModuleName is the module name used for error definitions.
+	ModuleName = "staking/watcher"
+
+	// CfgWatchedAddresses configures the staking account addresses to watch.
+	CfgWatchedAddresses = "staking.watcher.address"
+)
+
+// Flags has the configuration flags.
+var Flags = flag.NewFlagSet("", flag.ContinueOnError)
+
+// ErrNotWatched is the error returned when an address is not being watched.
+var ErrNotWatched = errors.New(ModuleName, 1, "staking/watcher: address not watched")
+
+var (
+	// processRetryInitialInterval is the initial interval between attempts to process a height.
+	processRetryInitialInterval = 1 * time.Second
+	// processRetryMaxInterval is the maximum interval between attempts to process a height.
+	processRetryMaxInterval = 30 * time.Second
+	// processRetryTimeout is the time after which processing of a height is abandoned until the
+	// next block.
+	processRetryTimeout = 5 * time.Minute
+)
+
+var (
+	addressesKey  = []byte("addresses")
+	lastHeightKey = []byte("last_height")
+	historyPrefix = []byte("history.")
+)
+
+func historyKey(addr staking.Address, height int64) []byte {
+	var rawHeight [8]byte
+	binary.BigEndian.PutUint64(rawHeight[:], uint64(height))
+	return bytes.Join([][]byte{historyPrefix, addr[:], rawHeight[:]}, nil)
+}
+
+// Watcher is a node-local registry of watched staking accounts.
+type Watcher struct {
+	sync.RWMutex
+	service.BaseBackgroundService
+
+	consensus consensus.Backend
+	store     *persistent.ServiceStore
+
+	addresses map[staking.Address]bool
+
+	ctx       context.Context
+	cancelCtx context.CancelFunc
+}
+
+// Addresses returns the list of watched addresses.
+func (w *Watcher) Addresses() []staking.Address {
+	w.RLock()
+	defer w.RUnlock()
+
+	return w.addressesLocked()
+}
+
+func (w *Watcher) addressesLocked() []staking.Address {
+	addrs := make([]staking.Address, 0, len(w.addresses))
+	for addr := range w.addresses {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i][:], addrs[j][:]) < 0
+	})
+	return addrs
+}
+
+// AddAddress starts watching the given address.
+//
+// A snapshot of the account at the latest height is recorded immediately so
+// that the history of the account has a known starting point.
+func (w *Watcher) AddAddress(ctx context.Context, addr staking.Address) error {
+	if !addr.IsValid() {
+		return fmt.Errorf("staking/watcher: invalid address: %s", addr)
+	}
+
+	w.RLock()
+	watched := w.addresses[addr]
+	w.RUnlock()
+	if watched {
+		return nil
+	}
+
+	// Query the snapshot before taking the lock to avoid blocking event processing.
+	blk, err := w.consensus.GetBlock(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("staking/watcher: failed to get latest block: %w", err)
+	}
+	entry, err := w.queryEntry(ctx, addr, blk.Height, nil)
+	if err != nil {
+		return err
+	}
+
+	w.Lock()
+	defer w.Unlock()
+
+	if w.addresses[addr] {
+		return nil
+	}
+	w.addresses[addr] = true
+	if err = w.store.PutCBOR(addressesKey, w.addressesLocked()); err != nil {
+		delete(w.addresses, addr)
+		return fmt.Errorf("staking/watcher: failed to persist watched addresses: %w", err)
+	}
+
+	// Do not overwrite an entry recorded by event processing in the meantime.
+	var existing staking.AccountHistoryEntry
+	switch err = w.store.GetCBOR(historyKey(addr, entry.Height), &existing); err {
+	case nil:
+		return nil
+	case persistent.ErrNotFound:
+		return w.putEntry(addr, entry)
+	default:
+		return fmt.Errorf("staking/watcher: failed to read history: %w", err)
+	}
+}
+
+// RemoveAddress stops watching the given address.
+//
+// Already retained history of the address is kept.
+func (w *Watcher) RemoveAddress(ctx context.Context, addr staking.Address) error {
+	w.Lock()
+	defer w.Unlock()
+
+	if !w.addresses[addr] {
+		return ErrNotWatched
+	}
+	delete(w.addresses, addr)
+	if err := w.store.PutCBOR(addressesKey, w.addressesLocked()); err != nil {
+		w.addresses[addr] = true
+		return fmt.Errorf("staking/watcher: failed to persist watched addresses: %w", err)
+	}
+	return nil
+}
+
+// GetHistory returns the retained history of the given address between the
+// given heights (inclusive). A zero toHeight means no upper bound.
+func (w *Watcher) GetHistory(ctx context.Context, addr staking.Address, fromHeight, toHeight int64) ([]*staking.AccountHistoryEntry, error) {
+	prefix := bytes.Join([][]byte{historyPrefix, addr[:]}, nil)
+
+	var entries []*staking.AccountHistoryEntry
+	err := w.store.IterateCBOR(prefix, func(key []byte, decode func(interface{}) error) error {
+		height := int64(binary.BigEndian.Uint64(key[len(prefix):]))
+		if height < fromHeight || (toHeight != 0 && height > toHeight) {
+			return nil
+		}
+
+		var entry staking.AccountHistoryEntry
+		if err := decode(&entry); err != nil {
+			return err
+		}
+		entries = append(entries, &entry)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("staking/watcher: failed to read history: %w", err)
+	}
+	return entries, nil
+}
+
+func (w *Watcher) queryEntry(ctx context.Context, addr staking.Address, height int64, events []*staking.Event) (*staking.AccountHistoryEntry, error) {
+	acct, err := w.consensus.Staking().Account(ctx, &staking.OwnerQuery{
+		Height: height,
+		Owner:  addr,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("staking/watcher: failed to query account: %w", err)
+	}
+
+	return &staking.AccountHistoryEntry{
+		Height:  height,
+		Account: acct,
+		Events:  events,
+	}, nil
+}
+
+func (w *Watcher) putEntry(addr staking.Address, entry *staking.AccountHistoryEntry) error {
+	if err := w.store.PutCBOR(historyKey(addr, entry.Height), entry); err != nil {
+		return fmt.Errorf("staking/watcher: failed to persist history: %w", err)
+	}
+	return nil
+}
+
+func (w *Watcher) processHeight(height int64) error {
+	evs, err := w.consensus.Staking().GetEvents(w.ctx, height)
+	if err != nil {
+		return fmt.Errorf("staking/watcher: failed to get events: %w", err)
+	}
+
+	w.RLock()
+	related := make(map[staking.Address][]*staking.Event)
+	for _, ev := range evs {
+		for _, addr := range ev.RelatedAddresses() {
+			if !w.addresses[addr] {
+				continue
+			}
+			// Avoid recording the same event twice (e.g., self-transfers).
+			if n := len(related[addr]); n > 0 && related[addr][n-1] == ev {
+				continue
+			}
+			related[addr] = append(related[addr], ev)
+		}
+	}
+	w.RUnlock()
+
+	for addr, addrEvs := range related {
+		var entry *staking.AccountHistoryEntry
+		if entry, err = w.queryEntry(w.ctx, addr, height, addrEvs); err != nil {
+			return err
+		}
+		if err = w.putEntry(addr, entry); err != nil {
+			return err
+		}
+	}
+	return w.store.PutCBOR(lastHeightKey, height)
+}
+
+// processHeights processes all heights after the last processed height up to and including the
+// given height, retrying failed heights with a backoff. It returns the last processed height,
+// which is not advanced past a height that could not be processed so that it is retried later.
+//
+// Heights that have already been pruned by the consensus backend are skipped.
+func (w *Watcher) processHeights(lastHeight, height int64) int64 {
+	if lastHeight == 0 {
+		// Nothing has been processed yet, start at the given height.
+		lastHeight = height - 1
+	}
+
+	// Catch up with any heights that were missed while the node was not
+	// running. This is only possible for heights that have not been pruned
+	// yet.
+	next := lastHeight + 1
+	if next < height {
+		next = w.skipPrunedHeights(next)
+	}
+	for ; next <= height; next++ {
+		off := backoff.NewExponentialBackOff()
+		off.InitialInterval = processRetryInitialInterval
+		off.MaxInterval = processRetryMaxInterval
+		off.MaxElapsedTime = processRetryTimeout
+
+		h := next
+		err := backoff.Retry(func() error {
+			perr := w.processHeight(h)
+			if perr != nil {
+				w.Logger.Warn("failed to process height, retrying",
+					"err", perr,
+					"height", h,
+				)
+			}
+			return perr
+		}, backoff.WithContext(off, w.ctx))
+		if err != nil {
+			// The height may have been pruned in the meantime, in which case it can never be
+			// processed and retrying it would stall the watcher forever.
+			if skipped := w.skipPrunedHeights(next); skipped > next {
+				next = skipped - 1
+				continue
+			}
+
+			w.Logger.Error("failed to process height, will retry on next block",
+				"err", err,
+				"height", next,
+			)
+			break
+		}
+		lastHeight = next
+	}
+	return lastHeight
+}
+
+// skipPrunedHeights returns the first height at or after the given height that has not been
+// pruned by the consensus backend, logging any skipped heights.
+func (w *Watcher) skipPrunedHeights(height int64) int64 {
+	status, err := w.consensus.GetStatus(w.ctx)
+	if err != nil {
+		w.Logger.Warn("failed to query consensus status, not skipping pruned heights",
+			"err", err,
+		)
+		return height
+	}
+	if height >= status.LastRetainedHeight {
+		return height
+	}
+
+	w.Logger.Warn("heights have been pruned, history of watched addresses will have a gap",
+		"from_height", height,
+		"to_height", status.LastRetainedHeight-1,
+	)
+	return status.LastRetainedHeight
+}
+
+func (w *Watcher) worker() {
+	defer w.BaseBackgroundService.Stop()
+
+	// Wait for the consensus backend to be synced, as events are only
+	// available for heights the node has seen.
+	select {
+	case <-w.ctx.Done():
+		return
+	case <-w.consensus.Synced():
+	}
+
+	blkCh, blkSub, err := w.consensus.WatchBlocks(w.ctx)
+	if err != nil {
+		w.Logger.Error("failed to watch blocks",
+			"err", err,
+		)
+		return
+	}
+	defer blkSub.Close()
+
+	var lastHeight int64
+	if err = w.store.GetCBOR(lastHeightKey, &lastHeight); err != nil && err != persistent.ErrNotFound {
+		w.Logger.Error("failed to load last processed height",
+			"err", err,
+		)
+		return
+	}
+
+	for {
+		var blk *consensus.Block
+		select {
+		case <-w.ctx.Done():
+			return
+		case blk = <-blkCh:
+		}
+
+		lastHeight = w.processHeights(lastHeight, blk.Height)
+	}
+}
+
+// Start starts the watcher.
+func (w *Watcher) Start() error {
+	go w.worker()
+	return nil
+}
+
+// Stop halts the watcher.
+func (w *Watcher) Stop() {
+	w.cancelCtx()
+}
+
+// New creates a new staking account watcher.
+//
+// The configured addresses are added to the set of watched addresses
+// persisted from previous runs.
+func New(store *persistent.CommonStore, consensus consensus.Backend) (*Watcher, error) {
+	var addresses []staking.Address
+	for _, rawAddr := range viper.GetStringSlice(CfgWatchedAddresses) {
+		var addr staking.Address
+		if err := addr.UnmarshalText([]byte(rawAddr)); err != nil {
+			return nil, fmt.Errorf("staking/watcher: malformed address '%s': %w", rawAddr, err)
+		}
+		addresses = append(addresses, addr)
+	}
+
+	svcStore, err := store.GetServiceStore(ModuleName)
+	if err != nil {
+		return nil, err
+	}
+
+	var persisted []staking.Address
+	if err = svcStore.GetCBOR(addressesKey, &persisted); err != nil && err != persistent.ErrNotFound {
+		return nil, fmt.Errorf("staking/watcher: failed to load watched addresses: %w", err)
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	w := &Watcher{
+		BaseBackgroundService: *service.NewBaseBackgroundService("staking/watcher"),
+		consensus:             consensus,
+		store:                 svcStore,
+		addresses:             make(map[staking.Address]bool),
+		ctx:                   ctx,
+		cancelCtx:             cancelCtx,
+	}
+	for _, addr := range append(persisted, addresses...) {
+		if !addr.IsValid() {
+			cancelCtx()
+			return nil, fmt.Errorf("staking/watcher: invalid address: %s", addr)
+		}
+		w.addresses[addr] = true
+	}
+	if err = svcStore.PutCBOR(addressesKey, w.addressesLocked()); err != nil {
+		cancelCtx()
+		return nil, fmt.Errorf("staking/watcher: failed to persist watched addresses: %w", err)
+	}
+
+	return w, nil
+}
+
+func init() {
+	Flags.StringSlice(CfgWatchedAddresses, []string{}, "staking account address to retain full history for regardless of pruning (can be specified multiple times)")
+
+	_ = viper.BindPFlags(Flags)
+}
@@ -0,0 +1,289 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+var (
+	testAddr1 = staking.NewAddress(memorySigner.NewTestSigner("staking watcher test 1").Public())
+	testAddr2 = staking.NewAddress(memorySigner.NewTestSigner("staking watcher test 2").Public())
+)
+
+type testStakingBackend struct {
+	staking.Backend
+
+	sync.Mutex
+
+	events        map[int64][]*staking.Event
+	eventFailures map[int64]int
+	accountHook   func()
+
+	// lastRetainedHeight is the last retained height, events for earlier heights have been pruned.
+	lastRetainedHeight int64
+	// pruneOnGetEvents prunes heights up to the given height once events for a height are queried.
+	pruneOnGetEvents map[int64]int64
+}
+
+func (b *testStakingBackend) Account(ctx context.Context, query *staking.OwnerQuery) (*staking.Account, error) {
+	if b.accountHook != nil {
+		b.accountHook()
+	}
+
+	var acct staking.Account
+	if err := acct.General.Balance.FromUint64(uint64(query.Height)); err != nil {
+		return nil, err
+	}
+	return &acct, nil
+}
+
+func (b *testStakingBackend) GetEvents(ctx context.Context, height int64) ([]*staking.Event, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	if retained, ok := b.pruneOnGetEvents[height]; ok {
+		b.lastRetainedHeight = retained
+	}
+	if height < b.lastRetainedHeight {
+		return nil, fmt.Errorf("height %d has been pruned", height)
+	}
+	if n := b.eventFailures[height]; n != 0 {
+		if n > 0 {
+			b.eventFailures[height] = n - 1
+		}
+		return nil, fmt.Errorf("events at height %d not available", height)
+	}
+	return b.events[height], nil
+}
+
+type testConsensusBackend struct {
+	consensus.Backend
+
+	height  int64
+	staking *testStakingBackend
+}
+
+func (b *testConsensusBackend) GetBlock(ctx context.Context, height int64) (*consensus.Block, error) {
+	return &consensus.Block{Height: b.height}, nil
+}
+
+func (b *testConsensusBackend) GetStatus(ctx context.Context) (*consensus.Status, error) {
+	b.staking.Lock()
+	defer b.staking.Unlock()
+
+	return &consensus.Status{LastRetainedHeight: b.staking.lastRetainedHeight}, nil
+}
+
+func (b *testConsensusBackend) Staking() staking.Backend {
+	return b.staking
+}
+
+func newTestWatcher(t *testing.T, backend consensus.Backend) *Watcher {
+	require := require.New(t)
+
+	dataDir, err := ioutil.TempDir("", "oasis-staking-watcher-test")
+	require.NoError(err, "TempDir")
+	t.Cleanup(func() {
+		os.RemoveAll(dataDir)
+	})
+
+	store, err := persistent.NewCommonStore(dataDir)
+	require.NoError(err, "NewCommonStore")
+	t.Cleanup(store.Close)
+
+	w, err := New(store, backend)
+	require.NoError(err, "New")
+	t.Cleanup(w.Stop)
+
+	return w
+}
+
+func TestAddAddress(t *testing.T) {
+	require := require.New(t)
+
+	stakingBackend := &testStakingBackend{}
+	w := newTestWatcher(t, &testConsensusBackend{height: 10, staking: stakingBackend})
+
+	// Queries should not be performed while holding the lock.
+	stakingBackend.accountHook = func() {
+		done := make(chan struct{})
+		go func() {
+			w.Addresses()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Error("watcher lock should not be held while querying the account")
+		}
+	}
+
+	ctx := context.Background()
+	err := w.AddAddress(ctx, testAddr1)
+	require.NoError(err, "AddAddress")
+	require.Equal([]staking.Address{testAddr1}, w.Addresses())
+
+	history, err := w.GetHistory(ctx, testAddr1, 0, 0)
+	require.NoError(err, "GetHistory")
+	require.Len(history, 1, "a snapshot should be recorded")
+	require.EqualValues(10, history[0].Height)
+	require.Equal(*quantity.NewFromUint64(10), history[0].Account.General.Balance)
+	require.Empty(history[0].Events)
+
+	// Adding an already watched address should not record another snapshot.
+	err = w.AddAddress(ctx, testAddr1)
+	require.NoError(err, "AddAddress")
+
+	err = w.AddAddress(ctx, staking.Address{})
+	require.Error(err, "AddAddress should fail for invalid addresses")
+
+	err = w.RemoveAddress(ctx, testAddr1)
+	require.NoError(err, "RemoveAddress")
+	require.Empty(w.Addresses())
+	err = w.RemoveAddress(ctx, testAddr1)
+	require.Equal(ErrNotWatched, err)
+
+	history, err = w.GetHistory(ctx, testAddr1, 0, 0)
+	require.NoError(err, "GetHistory")
+	require.Len(history, 1, "history should be retained after removal")
+}
+
+func TestProcessHeights(t *testing.T) {
+	require := require.New(t)
+
+	origInitialInterval, origMaxInterval, origTimeout := processRetryInitialInterval, processRetryMaxInterval, processRetryTimeout
+	processRetryInitialInterval = time.Millisecond
+	processRetryMaxInterval = time.Millisecond
+	processRetryTimeout = 100 * time.Millisecond
+	defer func() {
+		processRetryInitialInterval, processRetryMaxInterval, processRetryTimeout = origInitialInterval, origMaxInterval, origTimeout
+	}()
+
+	transfer := func(from, to staking.Address) *staking.Event {
+		return &staking.Event{Transfer: &staking.TransferEvent{From: from, To: to}}
+	}
+	stakingBackend := &testStakingBackend{
+		events: map[int64][]*staking.Event{
+			2: {transfer(testAddr1, testAddr2)},
+			3: {transfer(testAddr2, testAddr1), transfer(testAddr1, testAddr1)},
+			5: {transfer(testAddr1, testAddr2)},
+			6: {transfer(testAddr2, testAddr1)},
+		},
+		eventFailures: map[int64]int{
+			// Transient failure.
+			3: 2,
+			// Persistent failure.
+			6: -1,
+		},
+	}
+	w := newTestWatcher(t, &testConsensusBackend{staking: stakingBackend})
+	w.addresses[testAddr1] = true
+
+	ctx := context.Background()
+	heights := func() []int64 {
+		history, err := w.GetHistory(ctx, testAddr1, 0, 0)
+		require.NoError(err, "GetHistory")
+		var heights []int64
+		for _, entry := range history {
+			heights = append(heights, entry.Height)
+		}
+		return heights
+	}
+
+	// Nothing processed yet, processing should start at the given height.
+	lastHeight := w.processHeights(0, 2)
+	require.EqualValues(2, lastHeight)
+	require.Equal([]int64{2}, heights())
+
+	// Transient failures should be retried.
+	lastHeight = w.processHeights(lastHeight, 4)
+	require.EqualValues(4, lastHeight)
+	require.Equal([]int64{2, 3}, heights())
+
+	history, err := w.GetHistory(ctx, testAddr1, 3, 3)
+	require.NoError(err, "GetHistory")
+	require.Len(history, 1)
+	require.Len(history[0].Events, 2, "self-transfers should only be recorded once")
+
+	// Persistent failures should not advance the last processed height.
+	lastHeight = w.processHeights(lastHeight, 7)
+	require.EqualValues(5, lastHeight, "failed height should not be skipped")
+	require.Equal([]int64{2, 3, 5}, heights())
+
+	var persisted int64
+	err = w.store.GetCBOR(lastHeightKey, &persisted)
+	require.NoError(err, "GetCBOR")
+	require.EqualValues(5, persisted, "persisted last height should not be advanced")
+
+	// Once events become available, the failed height should be processed.
+	stakingBackend.Lock()
+	delete(stakingBackend.eventFailures, 6)
+	stakingBackend.Unlock()
+
+	lastHeight = w.processHeights(lastHeight, 7)
+	require.EqualValues(7, lastHeight)
+	require.Equal([]int64{2, 3, 5, 6}, heights())
+}
+
+func TestProcessHeightsPruned(t *testing.T) {
+	require := require.New(t)
+
+	origInitialInterval, origMaxInterval, origTimeout := processRetryInitialInterval, processRetryMaxInterval, processRetryTimeout
+	processRetryInitialInterval = time.Millisecond
+	processRetryMaxInterval = time.Millisecond
+	processRetryTimeout = 100 * time.Millisecond
+	defer func() {
+		processRetryInitialInterval, processRetryMaxInterval, processRetryTimeout = origInitialInterval, origMaxInterval, origTimeout
+	}()
+
+	transfer := &staking.Event{Transfer: &staking.TransferEvent{From: testAddr1, To: testAddr2}}
+	stakingBackend := &testStakingBackend{
+		events: map[int64][]*staking.Event{
+			3:  {transfer},
+			6:  {transfer},
+			8:  {transfer},
+			10: {transfer},
+		},
+		lastRetainedHeight: 5,
+		// Heights up to 9 are pruned while height 9 is being processed.
+		pruneOnGetEvents: map[int64]int64{9: 10},
+	}
+	w := newTestWatcher(t, &testConsensusBackend{staking: stakingBackend})
+	w.addresses[testAddr1] = true
+
+	ctx := context.Background()
+	heights := func() []int64 {
+		history, err := w.GetHistory(ctx, testAddr1, 0, 0)
+		require.NoError(err, "GetHistory")
+		var heights []int64
+		for _, entry := range history {
+			heights = append(heights, entry.Height)
+		}
+		return heights
+	}
+
+	// Pruned heights should be skipped instead of stalling the watcher.
+	lastHeight := w.processHeights(2, 8)
+	require.EqualValues(8, lastHeight, "pruned heights should be skipped")
+	require.Equal([]int64{6, 8}, heights())
+
+	// Heights pruned while being retried should be skipped as well.
+	lastHeight = w.processHeights(lastHeight, 9)
+	require.EqualValues(8, lastHeight, "pruned height should not be processed")
+	lastHeight = w.processHeights(lastHeight, 10)
+	require.EqualValues(10, lastHeight, "pruned heights should be skipped")
+	require.Equal([]int64{6, 8, 10}, heights())
+}
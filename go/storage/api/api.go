@@ -40,6 +40,9 @@ var (
 	ErrUnsupported = errors.New(ModuleName, 4, "storage: method not supported by backend")
 	// ErrLimitReached means that a configured limit has been reached.
 	ErrLimitReached = errors.New(ModuleName, 5, "storage: limit reached")
+	// ErrChecksumMismatch is the error returned when a response received from a remote storage
+	// node does not match its checksum.
+	ErrChecksumMismatch = errors.New(ModuleName, 6, "storage: response checksum mismatch")
 
 	// The following errors are reimports from NodeDB.
 
@@ -107,6 +110,18 @@ type Config struct { // nolint: maligned
 
 	// ReadOnly will make the storage read-only.
 	ReadOnly bool
+
+	// VerifyOnStartup will make the storage verify the consistency of all roots stored under the
+	// latest finalized version when opening the database.
+	VerifyOnStartup bool
+
+	// GCWindows are the daily UTC time windows of the form HH:MM-HH:MM during which periodic
+	// background garbage collection is allowed to run. If empty, garbage collection may run at
+	// any time.
+	GCWindows []string
+
+	// RocksDB is the RocksDB backend specific configuration.
+	RocksDB nodedb.RocksDBConfig
 }
 
 // ToNodeDB converts from a Config to a node DB Config.
@@ -119,6 +134,8 @@ func (cfg *Config) ToNodeDB() *nodedb.Config {
 		MemoryOnly:       cfg.MemoryOnly,
 		ReadOnly:         cfg.ReadOnly,
 		DiscardWriteLogs: cfg.DiscardWriteLogs,
+		GCWindows:        cfg.GCWindows,
+		RocksDB:          cfg.RocksDB,
 	}
 }
 
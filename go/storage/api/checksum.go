@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+)
+
+// checksumMetadataKey is the gRPC metadata key carrying the storage response checksum.
+//
+// For unary methods the checksum is sent in the response header and covers the CBOR-serialized
+// response. For streaming methods the checksum is sent in the trailer and covers the concatenation
+// of all CBOR-serialized messages sent over the stream.
+const checksumMetadataKey = "oasis-storage-checksum"
+
+// checksumBuilder computes a running checksum over a sequence of storage responses.
+type checksumBuilder struct {
+	builder *hash.Builder
+}
+
+func (b *checksumBuilder) add(msg interface{}) {
+	_, _ = b.builder.Write(cbor.Marshal(msg))
+}
+
+func (b *checksumBuilder) metadata() metadata.MD {
+	return checksumMetadata(b.builder.Build())
+}
+
+func (b *checksumBuilder) verify(md metadata.MD) error {
+	return verifyChecksum(md, b.builder.Build())
+}
+
+func newChecksumBuilder() *checksumBuilder {
+	return &checksumBuilder{
+		builder: hash.NewBuilder(),
+	}
+}
+
+func checksumMetadata(checksum hash.Hash) metadata.MD {
+	return metadata.Pairs(checksumMetadataKey, checksum.String())
+}
+
+// setResponseChecksum sets the checksum of the given unary response in the response header.
+func setResponseChecksum(ctx context.Context, rsp interface{}) error {
+	cb := newChecksumBuilder()
+	cb.add(rsp)
+	return grpc.SetHeader(ctx, cb.metadata())
+}
+
+// verifyResponseChecksum verifies the checksum of the given unary response against the one in the
+// response header.
+func verifyResponseChecksum(header metadata.MD, rsp interface{}) error {
+	cb := newChecksumBuilder()
+	cb.add(rsp)
+	return cb.verify(header)
+}
+
+func verifyChecksum(md metadata.MD, checksum hash.Hash) error {
+	values := md.Get(checksumMetadataKey)
+	if len(values) != 1 {
+		return fmt.Errorf("%w: missing checksum", ErrChecksumMismatch)
+	}
+
+	var expected hash.Hash
+	if err := expected.UnmarshalHex(values[0]); err != nil {
+		return fmt.Errorf("%w: malformed checksum: %s", ErrChecksumMismatch, err)
+	}
+	if !expected.Equal(&checksum) {
+		return ErrChecksumMismatch
+	}
+	return nil
+}
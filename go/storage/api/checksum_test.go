@@ -0,0 +1,71 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+)
+
+func TestResponseChecksum(t *testing.T) {
+	require := require.New(t)
+
+	rsp := &ProofResponse{
+		Proof: Proof{
+			UntrustedRoot: hash.NewFromBytes([]byte("root")),
+			Entries:       [][]byte{[]byte("entry 1"), nil, []byte("entry 2")},
+		},
+	}
+
+	cb := newChecksumBuilder()
+	cb.add(rsp)
+	header := cb.metadata()
+
+	// Decode the response as the client would.
+	var decoded ProofResponse
+	err := cbor.Unmarshal(cbor.Marshal(rsp), &decoded)
+	require.NoError(err, "Unmarshal")
+	err = verifyResponseChecksum(header, &decoded)
+	require.NoError(err, "verifyResponseChecksum should succeed for an intact response")
+
+	// Corrupt the response.
+	decoded.Proof.Entries[0][0] ^= 0xff
+	err = verifyResponseChecksum(header, &decoded)
+	require.True(errors.Is(err, ErrChecksumMismatch), "verifyResponseChecksum should fail for a corrupted response")
+
+	// Missing and malformed checksums.
+	err = verifyResponseChecksum(metadata.MD{}, rsp)
+	require.True(errors.Is(err, ErrChecksumMismatch), "verifyResponseChecksum should fail without a checksum")
+	err = verifyResponseChecksum(metadata.Pairs(checksumMetadataKey, "not a checksum"), rsp)
+	require.True(errors.Is(err, ErrChecksumMismatch), "verifyResponseChecksum should fail with a malformed checksum")
+}
+
+func TestStreamChecksum(t *testing.T) {
+	require := require.New(t)
+
+	chunks := []*SyncChunk{
+		{WriteLog: WriteLog{{Key: []byte("key 1"), Value: []byte("value 1")}}},
+		{Final: true, WriteLog: WriteLog{{Key: []byte("key 2"), Value: nil}}},
+	}
+
+	server := newChecksumBuilder()
+	for _, chunk := range chunks {
+		server.add(chunk)
+	}
+	trailer := server.metadata()
+
+	client := newChecksumBuilder()
+	for _, chunk := range chunks {
+		client.add(chunk)
+	}
+	require.NoError(client.verify(trailer), "verify should succeed for intact chunks")
+
+	// Missing chunk.
+	truncated := newChecksumBuilder()
+	truncated.add(chunks[0])
+	require.True(errors.Is(truncated.verify(trailer), ErrChecksumMismatch), "verify should fail for truncated stream")
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
@@ -147,14 +148,16 @@ func handlerSyncGet( // nolint: golint
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(Backend).SyncGet(ctx, &req)
+		rsp, err := srv.(Backend).SyncGet(ctx, &req)
+		return proofResponseWithChecksum(ctx, rsp, err)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
 		FullMethod: MethodSyncGet.FullName(),
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(Backend).SyncGet(ctx, req.(*GetRequest))
+		rsp, err := srv.(Backend).SyncGet(ctx, req.(*GetRequest))
+		return proofResponseWithChecksum(ctx, rsp, err)
 	}
 	return interceptor(ctx, &req, info, handler)
 }
@@ -170,14 +173,16 @@ func handlerSyncGetPrefixes( // nolint: golint
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(Backend).SyncGetPrefixes(ctx, &req)
+		rsp, err := srv.(Backend).SyncGetPrefixes(ctx, &req)
+		return proofResponseWithChecksum(ctx, rsp, err)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
 		FullMethod: MethodSyncGetPrefixes.FullName(),
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(Backend).SyncGetPrefixes(ctx, req.(*GetPrefixesRequest))
+		rsp, err := srv.(Backend).SyncGetPrefixes(ctx, req.(*GetPrefixesRequest))
+		return proofResponseWithChecksum(ctx, rsp, err)
 	}
 	return interceptor(ctx, &req, info, handler)
 }
@@ -193,18 +198,31 @@ func handlerSyncIterate( // nolint: golint
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(Backend).SyncIterate(ctx, &req)
+		rsp, err := srv.(Backend).SyncIterate(ctx, &req)
+		return proofResponseWithChecksum(ctx, rsp, err)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
 		FullMethod: MethodSyncIterate.FullName(),
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(Backend).SyncIterate(ctx, req.(*IterateRequest))
+		rsp, err := srv.(Backend).SyncIterate(ctx, req.(*IterateRequest))
+		return proofResponseWithChecksum(ctx, rsp, err)
 	}
 	return interceptor(ctx, &req, info, handler)
 }
 
+// proofResponseWithChecksum sets the checksum of a successful proof response.
+func proofResponseWithChecksum(ctx context.Context, rsp *ProofResponse, err error) (*ProofResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+	if err = setResponseChecksum(ctx, rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 func handlerApply( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -285,6 +303,8 @@ func sendWriteLogIterator(it WriteLogIterator, opts *SyncOptions, stream grpc.Se
 		skipping = false
 	}
 
+	checksum := newChecksumBuilder()
+
 	for {
 		var entryArray []LogEntry
 		for {
@@ -324,6 +344,7 @@ func sendWriteLogIterator(it WriteLogIterator, opts *SyncOptions, stream grpc.Se
 			WriteLog: entryArray,
 		}
 
+		checksum.add(chunk)
 		if err := stream.SendMsg(chunk); err != nil {
 			return err
 		}
@@ -333,6 +354,8 @@ func sendWriteLogIterator(it WriteLogIterator, opts *SyncOptions, stream grpc.Se
 		}
 	}
 
+	stream.SetTrailer(checksum.metadata())
+
 	return nil
 }
 
@@ -370,24 +393,42 @@ type storageClient struct {
 }
 
 func (c *storageClient) SyncGet(ctx context.Context, request *GetRequest) (*ProofResponse, error) {
-	var rsp ProofResponse
-	if err := c.conn.Invoke(ctx, MethodSyncGet.FullName(), request, &rsp); err != nil {
+	var (
+		rsp    ProofResponse
+		header metadata.MD
+	)
+	if err := c.conn.Invoke(ctx, MethodSyncGet.FullName(), request, &rsp, grpc.Header(&header)); err != nil {
+		return nil, err
+	}
+	if err := verifyResponseChecksum(header, &rsp); err != nil {
 		return nil, err
 	}
 	return &rsp, nil
 }
 
 func (c *storageClient) SyncGetPrefixes(ctx context.Context, request *GetPrefixesRequest) (*ProofResponse, error) {
-	var rsp ProofResponse
-	if err := c.conn.Invoke(ctx, MethodSyncGetPrefixes.FullName(), request, &rsp); err != nil {
+	var (
+		rsp    ProofResponse
+		header metadata.MD
+	)
+	if err := c.conn.Invoke(ctx, MethodSyncGetPrefixes.FullName(), request, &rsp, grpc.Header(&header)); err != nil {
+		return nil, err
+	}
+	if err := verifyResponseChecksum(header, &rsp); err != nil {
 		return nil, err
 	}
 	return &rsp, nil
 }
 
 func (c *storageClient) SyncIterate(ctx context.Context, request *IterateRequest) (*ProofResponse, error) {
-	var rsp ProofResponse
-	if err := c.conn.Invoke(ctx, MethodSyncIterate.FullName(), request, &rsp); err != nil {
+	var (
+		rsp    ProofResponse
+		header metadata.MD
+	)
+	if err := c.conn.Invoke(ctx, MethodSyncIterate.FullName(), request, &rsp, grpc.Header(&header)); err != nil {
+		return nil, err
+	}
+	if err := verifyResponseChecksum(header, &rsp); err != nil {
 		return nil, err
 	}
 	return &rsp, nil
@@ -423,6 +464,9 @@ func receiveWriteLogIterator(ctx context.Context, stream grpc.ClientStream) Writ
 	go func() {
 		defer pipe.Close()
 
+		// Keep receiving after the final chunk until the end of the stream, as the checksum is
+		// only available in the trailer.
+		checksum := newChecksumBuilder()
 		for {
 			var chunk SyncChunk
 			err := stream.RecvMsg(&chunk)
@@ -431,18 +475,21 @@ func receiveWriteLogIterator(ctx context.Context, stream grpc.ClientStream) Writ
 			}
 			if err != nil {
 				_ = pipe.PutError(err)
-				continue
+				return
 			}
+			checksum.add(&chunk)
 
 			for i := range chunk.WriteLog {
 				if err := pipe.Put(&chunk.WriteLog[i]); err != nil {
 					_ = pipe.PutError(err)
 				}
 			}
+		}
 
-			if chunk.Final {
-				break
-			}
+		// Entries are only verified once the stream is complete, so consumers must not use the
+		// received write log unless the iterator is exhausted without errors.
+		if err := checksum.verify(stream.Trailer()); err != nil {
+			_ = pipe.PutError(err)
 		}
 	}()
 
@@ -41,12 +41,31 @@ var (
 		[]string{"call"},
 	)
 
+	rootCacheApplies = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_storage_root_cache_applies",
+			Help: "Number of root cache applies by path taken.",
+		},
+		[]string{"result"},
+	)
+	rootCacheApplyLatency = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "oasis_storage_root_cache_apply_latency",
+			Help: "Root cache apply latency (seconds).",
+		},
+		[]string{"result"},
+	)
+
 	storageCollectors = []prometheus.Collector{
 		storageFailures,
 		storageCalls,
 		storageLatency,
 		storageValueSize,
 	}
+	rootCacheCollectors = []prometheus.Collector{
+		rootCacheApplies,
+		rootCacheApplyLatency,
+	}
 
 	labelApply           = prometheus.Labels{"call": "apply"}
 	labelApplyBatch      = prometheus.Labels{"call": "apply_batch"}
@@ -57,7 +76,11 @@ var (
 	_ LocalBackend  = (*metricsWrapper)(nil)
 	_ ClientBackend = (*metricsWrapper)(nil)
 
-	metricsOnce sync.Once
+	labelRootCacheHit  = prometheus.Labels{"result": "hit"}
+	labelRootCacheMiss = prometheus.Labels{"result": "miss"}
+
+	metricsOnce          sync.Once
+	rootCacheMetricsOnce sync.Once
 )
 
 type metricsWrapper struct {
@@ -173,6 +196,12 @@ func (w *metricsWrapper) NodeDB() NodeDB {
 	return localBackend.NodeDB()
 }
 
+func initRootCacheMetrics() {
+	rootCacheMetricsOnce.Do(func() {
+		prometheus.MustRegister(rootCacheCollectors...)
+	})
+}
+
 func NewMetricsWrapper(base Backend) LocalBackend {
 	metricsOnce.Do(func() {
 		prometheus.MustRegister(storageCollectors...)
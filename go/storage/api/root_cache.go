@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cache/lru"
+	"github.com/oasisprotocol/oasis-core/go/common/crash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
 	nodedb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
@@ -14,6 +16,18 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
 )
 
+const (
+	crashPointApplyCommitBefore = "storage.apply.commit.before"
+	crashPointApplyCommitAfter  = "storage.apply.commit.after"
+)
+
+func init() {
+	crash.RegisterCrashPoints(
+		crashPointApplyCommitBefore,
+		crashPointApplyCommitAfter,
+	)
+}
+
 // RootCache is a LRU based tree cache.
 type RootCache struct {
 	localDB      nodedb.NodeDB
@@ -66,10 +80,15 @@ func (rc *RootCache) Apply(
 
 	var r hash.Hash
 
+	start := time.Now()
+
 	// Check if we already have the expected new root in our local DB.
 	if rc.localDB.HasRoot(expectedNewRoot) {
 		// We do, don't apply anything.
 		r = dstRoot
+
+		rootCacheApplies.With(labelRootCacheHit).Inc()
+		rootCacheApplyLatency.With(labelRootCacheHit).Observe(time.Since(start).Seconds())
 	} else {
 		// We don't, apply operations.
 		tree := mkvs.NewWithRoot(rc.remoteSyncer, rc.localDB, root, rc.persistEverything)
@@ -79,6 +98,8 @@ func (rc *RootCache) Apply(
 			return nil, err
 		}
 
+		crash.Here(crashPointApplyCommitBefore)
+
 		var err error
 		if !rc.insecureSkipChecks {
 			_, err = tree.CommitKnown(ctx, expectedNewRoot)
@@ -96,6 +117,11 @@ func (rc *RootCache) Apply(
 		default:
 			return nil, err
 		}
+
+		crash.Here(crashPointApplyCommitAfter)
+
+		rootCacheApplies.With(labelRootCacheMiss).Inc()
+		rootCacheApplyLatency.With(labelRootCacheMiss).Observe(time.Since(start).Seconds())
 	}
 
 	return &r, nil
@@ -130,6 +156,8 @@ func NewRootCache(
 	applyLockLRUSlots uint64,
 	insecureSkipChecks bool,
 ) (*RootCache, error) {
+	initRootCacheMetrics()
+
 	applyLocks, err := lru.New(lru.Capacity(applyLockLRUSlots, false))
 	if err != nil {
 		return nil, fmt.Errorf("storage/rootcache: failed to create applyLocks: %w", err)
@@ -0,0 +1,329 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/dgraph-io/badger/v2/options"
+
+	cmnBadger "github.com/oasisprotocol/oasis-core/go/common/badger"
+	"github.com/oasisprotocol/oasis-core/go/common/cache/lru"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
+)
+
+var (
+	_ db.NodeDB    = (*NodeCache)(nil)
+	_ db.Batch     = (*nodeCacheBatch)(nil)
+	_ db.Subtree   = (*nodeCacheBatch)(nil)
+	_ lru.Sizeable = cachedNodeSize(0)
+)
+
+// nodeCacheKeyFmt is the node cache key format.
+//
+// Value is the serialized node.
+var nodeCacheKeyFmt = keyformat.New(0x01, &hash.Hash{})
+
+type cachedNodeSize uint64
+
+func (s cachedNodeSize) Size() uint64 {
+	return uint64(s)
+}
+
+// NodeCache is a persistent cache of MKVS nodes fetched from remote storage
+// nodes, keyed by node hash.
+//
+// As nodes are content-addressed they are immutable and the cache can be
+// shared across roots and rounds. When the cache size exceeds the configured
+// maximum size, least recently used nodes are evicted.
+type NodeCache struct {
+	db.NodeDB
+
+	logger *logging.Logger
+
+	db  *badger.DB
+	gc  *cmnBadger.GCWorker
+	lru *lru.Cache
+}
+
+// GetNode looks up a node in the cache.
+func (nc *NodeCache) GetNode(root node.Root, ptr *node.Pointer) (node.Node, error) {
+	if ptr == nil || !ptr.IsClean() {
+		panic("storage/client: attempted to get invalid pointer from node cache")
+	}
+	if _, ok := nc.lru.Get(ptr.Hash); !ok {
+		return nil, db.ErrNodeNotFound
+	}
+
+	var n node.Node
+	err := nc.db.View(func(tx *badger.Txn) error {
+		item, err := tx.Get(nodeCacheKeyFmt.Encode(&ptr.Hash))
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			var vErr error
+			n, vErr = node.UnmarshalBinary(val)
+			return vErr
+		})
+	})
+	if err == nil {
+		// Make sure that the cached node was not corrupted.
+		n.UpdateHash()
+		if h := n.GetHash(); !h.Equal(&ptr.Hash) {
+			err = fmt.Errorf("hash mismatch (expected: %s got: %s)", ptr.Hash, h)
+		}
+	}
+	if err != nil {
+		if err != badger.ErrKeyNotFound {
+			nc.logger.Error("failed to get node from cache, removing",
+				"err", err,
+				"node_hash", ptr.Hash,
+			)
+		}
+		nc.lru.Remove(ptr.Hash)
+		nc.removeNode(ptr.Hash)
+		return nil, db.ErrNodeNotFound
+	}
+	return n, nil
+}
+
+// NewBatch starts a new batch for caching nodes.
+func (nc *NodeCache) NewBatch(oldRoot node.Root, version uint64, chunk bool) (db.Batch, error) {
+	return &nodeCacheBatch{nc: nc}, nil
+}
+
+// Close closes the node cache.
+func (nc *NodeCache) Close() {
+	nc.gc.Close()
+	if err := nc.db.Close(); err != nil {
+		nc.logger.Error("failed to close node cache",
+			"err", err,
+		)
+	}
+}
+
+func (nc *NodeCache) putNodes(nodes []node.Node) {
+	if len(nodes) == 0 {
+		return
+	}
+
+	wb := nc.db.NewWriteBatch()
+	defer wb.Cancel()
+
+	var sizes []cachedNodeSize
+	for _, n := range nodes {
+		h := n.GetHash()
+		data, err := n.MarshalBinary()
+		if err != nil {
+			nc.logger.Error("failed to marshal node",
+				"err", err,
+				"node_hash", h,
+			)
+			return
+		}
+		if err = wb.Set(nodeCacheKeyFmt.Encode(&h), data); err != nil {
+			nc.logger.Error("failed to cache node",
+				"err", err,
+				"node_hash", h,
+			)
+			return
+		}
+		sizes = append(sizes, cachedNodeSize(len(data)))
+	}
+	if err := wb.Flush(); err != nil {
+		nc.logger.Error("failed to cache nodes",
+			"err", err,
+		)
+		return
+	}
+
+	// Only track the nodes after they have been persisted so that any evictions happen after
+	// the nodes have been written.
+	for i, n := range nodes {
+		_ = nc.lru.Put(n.GetHash(), sizes[i])
+	}
+}
+
+func (nc *NodeCache) removeNode(h hash.Hash) {
+	err := nc.db.Update(func(tx *badger.Txn) error {
+		return tx.Delete(nodeCacheKeyFmt.Encode(&h))
+	})
+	if err != nil {
+		nc.logger.Error("failed to remove node from cache",
+			"err", err,
+			"node_hash", h,
+		)
+	}
+}
+
+func (nc *NodeCache) load() error {
+	return nc.db.View(func(tx *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := tx.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			var h hash.Hash
+			if !nodeCacheKeyFmt.Decode(it.Item().Key(), &h) {
+				continue
+			}
+			// Evictions caused by loading nodes remove them from the database.
+			_ = nc.lru.Put(h, cachedNodeSize(it.Item().ValueSize()))
+		}
+		return nil
+	})
+}
+
+type nodeCacheBatch struct {
+	db.BaseBatch
+
+	nc    *NodeCache
+	nodes []node.Node
+}
+
+func (b *nodeCacheBatch) MaybeStartSubtree(subtree db.Subtree, depth node.Depth, subtreeRoot *node.Pointer) db.Subtree {
+	return b
+}
+
+func (b *nodeCacheBatch) PutWriteLog(writeLog writelog.WriteLog, logAnnotations writelog.Annotations) error {
+	return nil
+}
+
+func (b *nodeCacheBatch) RemoveNodes(nodes []node.Node) error {
+	return nil
+}
+
+func (b *nodeCacheBatch) Commit(root node.Root) error {
+	b.nc.putNodes(b.nodes)
+	b.nodes = nil
+	return b.BaseBatch.Commit(root)
+}
+
+func (b *nodeCacheBatch) Reset() {
+	b.nodes = nil
+}
+
+// Implements db.Subtree.
+func (b *nodeCacheBatch) PutNode(depth node.Depth, ptr *node.Pointer) error {
+	if ptr == nil || ptr.Node == nil {
+		return nil
+	}
+	b.nodes = append(b.nodes, ptr.Node)
+	return nil
+}
+
+// Implements db.Subtree.
+func (b *nodeCacheBatch) VisitCleanNode(depth node.Depth, ptr *node.Pointer) error {
+	return nil
+}
+
+// Implements db.Subtree.
+func (b *nodeCacheBatch) Commit() error {
+	return nil
+}
+
+// NewNodeCache opens (or creates) a persistent node cache at the given path,
+// limited to maxSize bytes of serialized nodes. In case the path is empty, an
+// in-memory cache is used.
+func NewNodeCache(fn string, maxSize uint64) (*NodeCache, error) {
+	logger := logging.GetLogger("storage/client/cache").With("path", fn)
+
+	opts := badger.DefaultOptions(fn)
+	opts = opts.WithLogger(cmnBadger.NewLogAdapter(logger))
+	// The cache can always be repopulated from remote storage nodes, so there is no need to
+	// sync writes.
+	opts = opts.WithSyncWrites(false)
+	// Allow value log truncation if required (this is needed to recover the
+	// value log file which can get corrupted in crashes).
+	opts = opts.WithTruncate(true)
+	opts = opts.WithCompression(options.None)
+	if fn == "" {
+		opts = opts.WithInMemory(true)
+	}
+
+	bdb, err := badger.Open(opts)
+	if err != nil {
+		return nil, fmt.Errorf("storage/client: failed to open node cache: %w", err)
+	}
+
+	nopDB, _ := db.NewNopNodeDB()
+	nc := &NodeCache{
+		NodeDB: nopDB,
+		logger: logger,
+		db:     bdb,
+		gc:     cmnBadger.NewGCWorker(logger, bdb),
+	}
+	nc.lru, err = lru.New(
+		lru.Capacity(maxSize, true),
+		lru.OnEvict(func(key, value interface{}) {
+			nc.removeNode(key.(hash.Hash))
+		}),
+	)
+	if err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("storage/client: failed to create node cache LRU: %w", err)
+	}
+	if err = nc.load(); err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("storage/client: failed to load node cache: %w", err)
+	}
+
+	return nc, nil
+}
+
+// cachedReadSyncer is a read syncer that serves requests from a local tree
+// backed by the node cache, fetching any missing nodes from remote storage
+// nodes.
+type cachedReadSyncer struct {
+	cache  *NodeCache
+	remote syncer.ReadSyncer
+}
+
+func (s *cachedReadSyncer) SyncGet(ctx context.Context, request *api.GetRequest) (*api.ProofResponse, error) {
+	tree := mkvs.NewWithRoot(s.remote, s.cache, request.Tree.Root, mkvs.PersistEverythingFromSyncer(true))
+	defer tree.Close()
+
+	return tree.SyncGet(ctx, request)
+}
+
+func (s *cachedReadSyncer) SyncGetPrefixes(ctx context.Context, request *api.GetPrefixesRequest) (*api.ProofResponse, error) {
+	tree := mkvs.NewWithRoot(s.remote, s.cache, request.Tree.Root, mkvs.PersistEverythingFromSyncer(true))
+	defer tree.Close()
+
+	return tree.SyncGetPrefixes(ctx, request)
+}
+
+func (s *cachedReadSyncer) SyncIterate(ctx context.Context, request *api.IterateRequest) (*api.ProofResponse, error) {
+	tree := mkvs.NewWithRoot(s.remote, s.cache, request.Tree.Root, mkvs.PersistEverythingFromSyncer(true))
+	defer tree.Close()
+
+	return tree.SyncIterate(ctx, request)
+}
+
+// remoteReadSyncer is a read syncer that always reads from remote storage
+// nodes, bypassing the node cache.
+type remoteReadSyncer struct {
+	b *storageClientBackend
+}
+
+func (s *remoteReadSyncer) SyncGet(ctx context.Context, request *api.GetRequest) (*api.ProofResponse, error) {
+	return s.b.remoteSyncGet(ctx, request)
+}
+
+func (s *remoteReadSyncer) SyncGetPrefixes(ctx context.Context, request *api.GetPrefixesRequest) (*api.ProofResponse, error) {
+	return s.b.remoteSyncGetPrefixes(ctx, request)
+}
+
+func (s *remoteReadSyncer) SyncIterate(ctx context.Context, request *api.IterateRequest) (*api.ProofResponse, error) {
+	return s.b.remoteSyncIterate(ctx, request)
+}
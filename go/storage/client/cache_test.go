@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+)
+
+func TestNodeCache(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "oasis-storage-client-cache-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dir)
+
+	var ns common.Namespace
+	tree := mkvs.New(nil, nil)
+	var keys, values [][]byte
+	for i := 0; i < 100; i++ {
+		keys = append(keys, []byte(fmt.Sprintf("key %d", i)))
+		values = append(values, []byte(fmt.Sprintf("value %d", i)))
+		err = tree.Insert(ctx, keys[i], values[i])
+		require.NoError(err, "Insert")
+	}
+	_, rootHash, err := tree.Commit(ctx, ns, 1)
+	require.NoError(err, "Commit")
+	root := node.Root{Namespace: ns, Version: 1, Hash: rootHash}
+
+	readAll := func(nc *NodeCache) *syncer.StatsCollector {
+		stats := syncer.NewStatsCollector(tree)
+		rs := &cachedReadSyncer{cache: nc, remote: stats}
+		clientTree := mkvs.NewWithRoot(rs, nil, root, mkvs.Capacity(0, 0))
+		defer clientTree.Close()
+
+		for i := range keys {
+			value, gerr := clientTree.Get(ctx, keys[i])
+			require.NoError(gerr, "Get")
+			require.Equal(values[i], value)
+		}
+		return stats
+	}
+
+	nc, err := NewNodeCache(dir, 16*1024*1024)
+	require.NoError(err, "NewNodeCache")
+
+	stats := readAll(nc)
+	require.True(stats.SyncGetCount > 0, "cold cache should fetch nodes from remote")
+	stats = readAll(nc)
+	require.Equal(0, stats.SyncGetCount, "warm cache should not fetch nodes from remote")
+
+	// The cache should be persisted.
+	nc.Close()
+	nc, err = NewNodeCache(dir, 16*1024*1024)
+	require.NoError(err, "NewNodeCache")
+	stats = readAll(nc)
+	require.Equal(0, stats.SyncGetCount, "reopened cache should not fetch nodes from remote")
+	nc.Close()
+
+	// The cache should not exceed the maximum size.
+	nc, err = NewNodeCache("", 512)
+	require.NoError(err, "NewNodeCache")
+	defer nc.Close()
+	stats = readAll(nc)
+	require.True(stats.SyncGetCount > 0, "cold cache should fetch nodes from remote")
+	require.True(nc.lru.Size() <= 512, "cache size should be limited")
+	stats = readAll(nc)
+	require.True(stats.SyncGetCount > 0, "evicted nodes should be fetched from remote")
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/mathrand"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
@@ -49,6 +50,14 @@ type storageClientBackend struct {
 
 	committeeClient committee.Client
 	runtime         registry.RuntimeDescriptorProvider
+
+	// writtenRoots are the recently written roots, used to route reads for those roots to the
+	// storage nodes that have them. Nil in case read-your-writes consistency is disabled.
+	writtenRoots *writtenRoots
+
+	// cache is the optional read syncer that serves reads from the persistent node cache. Nil in
+	// case the node cache is disabled.
+	cache *cachedReadSyncer
 }
 
 // Implements api.StorageClient.
@@ -227,6 +236,11 @@ func (b *storageClientBackend) writeWithClient(
 		}
 
 		receipts = append(receipts, receipt)
+		if b.writtenRoots != nil {
+			for _, root := range receiptBody.Roots {
+				b.writtenRoots.add(api.Root{Namespace: ns, Version: round, Hash: root}, response.node.ID)
+			}
+		}
 		if len(receipts) >= minWriteReplication {
 			break
 		}
@@ -286,8 +300,18 @@ func (b *storageClientBackend) ApplyBatch(ctx context.Context, request *api.Appl
 func (b *storageClientBackend) readWithClient(
 	ctx context.Context,
 	ns common.Namespace,
+	root *api.Root,
 	fn func(context.Context, api.Backend) (interface{}, error),
 ) (interface{}, error) {
+	// If the root has been recently written via this client, prioritize nodes that are known
+	// to have it, followed by any storage node priority hint.
+	priorityNodes := api.NodePriorityHintFromContext(ctx)
+	if root != nil && b.writtenRoots != nil {
+		if writtenNodes := b.writtenRoots.nodes(*root); len(writtenNodes) > 0 {
+			priorityNodes = append(append([]signature.PublicKey{}, writtenNodes...), priorityNodes...)
+		}
+	}
+
 	var resp interface{}
 	op := func() error {
 		conns := b.committeeClient.GetConnectionsMap()
@@ -300,7 +324,7 @@ func (b *storageClientBackend) readWithClient(
 
 		var nodes []*committee.ClientConnWithMeta
 		// If a storage node priority hint is set, prioritize overlapping nodes.
-		for _, nodeID := range api.NodePriorityHintFromContext(ctx) {
+		for _, nodeID := range priorityNodes {
 			c, ok := conns[nodeID]
 			if !ok {
 				continue
@@ -347,9 +371,17 @@ func (b *storageClientBackend) readWithClient(
 }
 
 func (b *storageClientBackend) SyncGet(ctx context.Context, request *api.GetRequest) (*api.ProofResponse, error) {
+	if b.cache != nil {
+		return b.cache.SyncGet(ctx, request)
+	}
+	return b.remoteSyncGet(ctx, request)
+}
+
+func (b *storageClientBackend) remoteSyncGet(ctx context.Context, request *api.GetRequest) (*api.ProofResponse, error) {
 	rsp, err := b.readWithClient(
 		ctx,
 		request.Tree.Root.Namespace,
+		&request.Tree.Root,
 		func(ctx context.Context, c api.Backend) (interface{}, error) {
 			return c.SyncGet(ctx, request)
 		},
@@ -361,9 +393,17 @@ func (b *storageClientBackend) SyncGet(ctx context.Context, request *api.GetRequ
 }
 
 func (b *storageClientBackend) SyncGetPrefixes(ctx context.Context, request *api.GetPrefixesRequest) (*api.ProofResponse, error) {
+	if b.cache != nil {
+		return b.cache.SyncGetPrefixes(ctx, request)
+	}
+	return b.remoteSyncGetPrefixes(ctx, request)
+}
+
+func (b *storageClientBackend) remoteSyncGetPrefixes(ctx context.Context, request *api.GetPrefixesRequest) (*api.ProofResponse, error) {
 	rsp, err := b.readWithClient(
 		ctx,
 		request.Tree.Root.Namespace,
+		&request.Tree.Root,
 		func(ctx context.Context, c api.Backend) (interface{}, error) {
 			return c.SyncGetPrefixes(ctx, request)
 		},
@@ -375,9 +415,17 @@ func (b *storageClientBackend) SyncGetPrefixes(ctx context.Context, request *api
 }
 
 func (b *storageClientBackend) SyncIterate(ctx context.Context, request *api.IterateRequest) (*api.ProofResponse, error) {
+	if b.cache != nil {
+		return b.cache.SyncIterate(ctx, request)
+	}
+	return b.remoteSyncIterate(ctx, request)
+}
+
+func (b *storageClientBackend) remoteSyncIterate(ctx context.Context, request *api.IterateRequest) (*api.ProofResponse, error) {
 	rsp, err := b.readWithClient(
 		ctx,
 		request.Tree.Root.Namespace,
+		&request.Tree.Root,
 		func(ctx context.Context, c api.Backend) (interface{}, error) {
 			return c.SyncIterate(ctx, request)
 		},
@@ -392,6 +440,7 @@ func (b *storageClientBackend) GetDiff(ctx context.Context, request *api.GetDiff
 	rsp, err := b.readWithClient(
 		ctx,
 		request.StartRoot.Namespace,
+		&request.EndRoot,
 		func(ctx context.Context, c api.Backend) (interface{}, error) {
 			return c.GetDiff(ctx, request)
 		},
@@ -406,6 +455,7 @@ func (b *storageClientBackend) GetCheckpoints(ctx context.Context, request *chec
 	rsp, err := b.readWithClient(
 		ctx,
 		request.Namespace,
+		nil,
 		func(ctx context.Context, c api.Backend) (interface{}, error) {
 			return c.GetCheckpoints(ctx, request)
 		},
@@ -420,6 +470,7 @@ func (b *storageClientBackend) GetCheckpointChunk(ctx context.Context, chunk *ch
 	_, err := b.readWithClient(
 		ctx,
 		chunk.Root.Namespace,
+		nil,
 		func(ctx context.Context, c api.Backend) (interface{}, error) {
 			return nil, c.GetCheckpointChunk(ctx, chunk, w)
 		},
@@ -17,6 +17,36 @@ import (
 // BackendName is the name of this implementation.
 const BackendName = "client"
 
+// Option is a storage client option.
+type Option func(b *storageClientBackend) error
+
+// WithReadYourWrites enables read-your-writes consistency.
+//
+// The client tracks up to maxRoots roots that were most recently written via the client
+// and routes subsequent reads for those roots to the storage nodes that have acknowledged
+// storing them, avoiding spurious retries on nodes that have not yet seen the writes.
+func WithReadYourWrites(maxRoots uint64) Option {
+	return func(b *storageClientBackend) (err error) {
+		b.writtenRoots, err = newWrittenRoots(maxRoots)
+		return
+	}
+}
+
+// WithNodeCache enables serving reads from the given persistent node cache.
+//
+// Nodes fetched from storage nodes via SyncGet, SyncGetPrefixes and SyncIterate are stored in
+// the cache and subsequent reads only fetch nodes that are not already cached. The cache may be
+// shared by multiple clients for the same runtime.
+func WithNodeCache(cache *NodeCache) Option {
+	return func(b *storageClientBackend) error {
+		b.cache = &cachedReadSyncer{
+			cache:  cache,
+			remote: &remoteReadSyncer{b},
+		}
+		return nil
+	}
+}
+
 // NewForCommittee creates a new storage client that tracks the specified committee.
 func NewForCommittee(
 	ctx context.Context,
@@ -24,6 +54,7 @@ func NewForCommittee(
 	ident *identity.Identity,
 	nodes committee.NodeDescriptorLookup,
 	runtime registry.RuntimeDescriptorProvider,
+	opts ...Option,
 ) (api.Backend, error) {
 	committeeClient, err := committee.NewClient(ctx, nodes, committee.WithClientAuthentication(ident))
 	if err != nil {
@@ -36,6 +67,11 @@ func NewForCommittee(
 		committeeClient: committeeClient,
 		runtime:         runtime,
 	}
+	for _, opt := range opts {
+		if err = opt(b); err != nil {
+			return nil, fmt.Errorf("storage/client: failed to apply option: %w", err)
+		}
+	}
 	return api.NewMetricsWrapper(b), nil
 }
 
@@ -65,31 +101,46 @@ func New(
 	return NewForCommittee(ctx, namespace, ident, committeeWatcher.Nodes(), runtime)
 }
 
-// NewStatic creates a new storage client that only follows a specific storage node. This is mostly
-// useful for tests.
-func NewStatic(
+// NewForNodes creates a new storage client that only follows the specified storage nodes instead
+// of tracking a storage committee.
+func NewForNodes(
 	ctx context.Context,
 	namespace common.Namespace,
 	ident *identity.Identity,
 	registryBackend registry.Backend,
-	nodeID signature.PublicKey,
+	nodeIDs []signature.PublicKey,
+	runtime registry.RuntimeDescriptorProvider,
+	opts ...Option,
 ) (api.Backend, error) {
 	nw, err := committee.NewNodeDescriptorWatcher(ctx, registryBackend)
 	if err != nil {
 		return nil, fmt.Errorf("storage/client: failed to create node descriptor watcher: %w", err)
 	}
 
-	client, err := NewForCommittee(ctx, namespace, ident, nw, nil)
+	client, err := NewForCommittee(ctx, namespace, ident, nw, runtime, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	nw.Reset()
-	_, err = nw.WatchNode(ctx, nodeID)
-	if err != nil {
-		return nil, fmt.Errorf("storage/client: failed to watch node %s: %w", nodeID, err)
+	for _, nodeID := range nodeIDs {
+		if _, err = nw.WatchNode(ctx, nodeID); err != nil {
+			return nil, fmt.Errorf("storage/client: failed to watch node %s: %w", nodeID, err)
+		}
 	}
 	nw.Freeze(0)
 
 	return client, nil
 }
+
+// NewStatic creates a new storage client that only follows a specific storage node. This is mostly
+// useful for tests.
+func NewStatic(
+	ctx context.Context,
+	namespace common.Namespace,
+	ident *identity.Identity,
+	registryBackend registry.Backend,
+	nodeID signature.PublicKey,
+) (api.Backend, error) {
+	return NewForNodes(ctx, namespace, ident, registryBackend, []signature.PublicKey{nodeID}, nil)
+}
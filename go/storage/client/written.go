@@ -0,0 +1,61 @@
+package client
+
+import (
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cache/lru"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/storage/api"
+)
+
+// writtenRoots tracks the roots recently written via the client together
+// with the storage nodes that have acknowledged storing them.
+type writtenRoots struct {
+	sync.Mutex
+
+	cache *lru.Cache
+}
+
+// add records that the given storage node has acknowledged storing the root.
+func (w *writtenRoots) add(root api.Root, nodeID signature.PublicKey) {
+	w.Lock()
+	defer w.Unlock()
+
+	var nodes []signature.PublicKey
+	if v, ok := w.cache.Peek(root); ok {
+		nodes = v.([]signature.PublicKey)
+	}
+	for _, id := range nodes {
+		if id.Equal(nodeID) {
+			return
+		}
+	}
+
+	newNodes := make([]signature.PublicKey, 0, len(nodes)+1)
+	newNodes = append(newNodes, nodes...)
+	newNodes = append(newNodes, nodeID)
+	_ = w.cache.Put(root, newNodes)
+}
+
+// nodes returns the storage nodes that have acknowledged storing the root
+// or nil if the root has not been written recently.
+func (w *writtenRoots) nodes(root api.Root) []signature.PublicKey {
+	w.Lock()
+	defer w.Unlock()
+
+	v, ok := w.cache.Get(root)
+	if !ok {
+		return nil
+	}
+	return v.([]signature.PublicKey)
+}
+
+func newWrittenRoots(maxRoots uint64) (*writtenRoots, error) {
+	cache, err := lru.New(lru.Capacity(maxRoots, false))
+	if err != nil {
+		return nil, err
+	}
+	return &writtenRoots{
+		cache: cache,
+	}, nil
+}
@@ -0,0 +1,41 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/storage/api"
+)
+
+func TestWrittenRoots(t *testing.T) {
+	require := require.New(t)
+
+	wr, err := newWrittenRoots(2)
+	require.NoError(err, "newWrittenRoots")
+
+	var node1, node2 signature.PublicKey
+	node1[0], node2[0] = 1, 2
+
+	roots := make([]api.Root, 3)
+	for i := range roots {
+		roots[i].Version = uint64(i)
+		roots[i].Hash = hash.NewFromBytes([]byte{byte(i)})
+	}
+
+	require.Nil(wr.nodes(roots[0]), "unknown roots should have no nodes")
+
+	wr.add(roots[0], node1)
+	wr.add(roots[0], node2)
+	wr.add(roots[0], node1)
+	require.Equal([]signature.PublicKey{node1, node2}, wr.nodes(roots[0]), "nodes should be tracked once")
+
+	// Older roots should be evicted.
+	wr.add(roots[1], node1)
+	wr.add(roots[2], node2)
+	require.Nil(wr.nodes(roots[0]), "least recently used roots should be evicted")
+	require.Equal([]signature.PublicKey{node1}, wr.nodes(roots[1]))
+	require.Equal([]signature.PublicKey{node2}, wr.nodes(roots[2]))
+}
@@ -11,19 +11,29 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
 	nodedb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
 	badgerNodedb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/badger"
+	rocksdbNodedb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/rocksdb"
 )
 
 const (
 	// BackendNameBadgerDB is the name of the BadgeDB backed database backend.
 	BackendNameBadgerDB = "badger"
+	// BackendNameRocksDB is the name of the RocksDB backed database backend.
+	BackendNameRocksDB = "rocksdb"
+	// BackendNameMemory is the name of the in-memory database backend. All data is lost when the
+	// backend is closed.
+	BackendNameMemory = "memory"
 
 	// DBFileBadgerDB is the default BadgerDB backing store filename.
 	DBFileBadgerDB = "mkvs_storage.badger.db"
+	// DBFileRocksDB is the default RocksDB backing store filename.
+	DBFileRocksDB = "mkvs_storage.rocksdb.db"
 
-	checkpointDir = "checkpoints"
+	// CheckpointDir is the name of the directory containing checkpoints, relative to the database.
+	CheckpointDir = "checkpoints"
 )
 
 // DefaultFileName returns the default database filename for the specified
@@ -32,6 +42,11 @@ func DefaultFileName(backend string) string {
 	switch backend {
 	case BackendNameBadgerDB:
 		return DBFileBadgerDB
+	case BackendNameRocksDB:
+		return DBFileRocksDB
+	case BackendNameMemory:
+		// The in-memory backend does not use any files.
+		return ""
 	default:
 		panic("storage/database: can't get default filename for unknown backend")
 	}
@@ -59,12 +74,26 @@ func New(cfg *api.Config) (api.Backend, error) {
 	switch cfg.Backend {
 	case BackendNameBadgerDB:
 		ndb, err = badgerNodedb.New(ndbCfg)
+	case BackendNameRocksDB:
+		ndb, err = rocksdbNodedb.New(ndbCfg)
+	case BackendNameMemory:
+		ndbCfg.MemoryOnly = true
+		ndb, err = badgerNodedb.New(ndbCfg)
 	default:
 		err = errors.New("storage/database: unsupported backend")
 	}
 	if err != nil {
 		return nil, fmt.Errorf("storage/database: failed to create node database: %w", err)
 	}
+	ndb = nodedb.NewMetricsWrapper(ndb)
+
+	// Verify root consistency after the node database has replayed any pending writes.
+	if cfg.VerifyOnStartup {
+		if err = mkvs.VerifyLatest(context.Background(), ndb, cfg.Namespace); err != nil {
+			ndb.Close()
+			return nil, fmt.Errorf("storage/database: failed to verify node database: %w", err)
+		}
+	}
 
 	rootCache, err := api.NewRootCache(ndb, nil, cfg.ApplyLockLRUSlots, cfg.InsecureSkipChecks)
 	if err != nil {
@@ -77,7 +106,13 @@ func New(cfg *api.Config) (api.Backend, error) {
 	close(initCh)
 
 	// Create the checkpointer.
-	creator, err := checkpoint.NewFileCreator(filepath.Join(cfg.DB, checkpointDir), ndb)
+	var creator checkpoint.Creator
+	switch cfg.Backend {
+	case BackendNameMemory:
+		creator, err = checkpoint.NewMemoryCreator(ndb)
+	default:
+		creator, err = checkpoint.NewFileCreator(filepath.Join(cfg.DB, CheckpointDir), ndb)
+	}
 	if err != nil {
 		ndb.Close()
 		return nil, fmt.Errorf("storage/database: failed to create checkpoint creator: %w", err)
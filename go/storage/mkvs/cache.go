@@ -53,6 +53,8 @@ type cache struct {
 const MaxPrefetchDepth = 255
 
 func newCache(ndb db.NodeDB, rs syncer.ReadSyncer) *cache {
+	initMetrics()
+
 	c := &cache{
 		db:                          ndb,
 		rs:                          rs,
@@ -348,6 +350,7 @@ func (c *cache) derefNodePtr(
 		}
 
 		if !refetch {
+			cacheNodeLookups.With(labelCacheHit).Inc()
 			return ptr.Node, nil
 		}
 	}
@@ -360,6 +363,7 @@ func (c *cache) derefNodePtr(
 	n, err := c.db.GetNode(c.syncRoot, ptr)
 	switch err {
 	case nil:
+		cacheNodeLookups.With(labelCacheNodeDB).Inc()
 		ptr.Node = n
 		// Commit node to cache.
 		c.commitNode(ptr)
@@ -369,6 +373,7 @@ func (c *cache) derefNodePtr(
 			return nil, err
 		}
 
+		cacheNodeLookups.With(labelCacheSyncer).Inc()
 		if err = c.remoteSync(ctx, ptr, fetcher); err != nil {
 			return nil, err
 		}
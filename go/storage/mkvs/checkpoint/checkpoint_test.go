@@ -257,6 +257,85 @@ func TestFileCheckpointCreator(t *testing.T) {
 	require.Error(err, "CreateCheckpoint should fail for invalid root")
 }
 
+func TestFileCheckpointCreatorDedup(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "mkvs.checkpoint")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dir)
+
+	ndb, err := badgerDb.New(&db.Config{
+		DB:           filepath.Join(dir, "db"),
+		Namespace:    testNs,
+		MaxCacheSize: 16 * 1024 * 1024,
+	})
+	require.NoError(err, "New")
+
+	ctx := context.Background()
+	tree := mkvs.New(nil, ndb)
+	for i := 0; i < 1000; i++ {
+		err = tree.Insert(ctx, []byte(strconv.Itoa(i)), []byte(strconv.Itoa(i)))
+		require.NoError(err, "Insert")
+	}
+	_, rootHash, err := tree.Commit(ctx, testNs, 0)
+	require.NoError(err, "Commit")
+
+	fc, err := NewFileCreator(filepath.Join(dir, "checkpoints"), ndb)
+	require.NoError(err, "NewFileCreator")
+
+	storedChunks := func() int {
+		entries, rerr := ioutil.ReadDir(filepath.Join(dir, "checkpoints", chunkStoreDir))
+		require.NoError(rerr, "ReadDir")
+		return len(entries)
+	}
+
+	// Create checkpoints of the same root at different versions.
+	root1 := node.Root{Namespace: testNs, Version: 1, Hash: rootHash}
+	cp1, err := fc.CreateCheckpoint(ctx, root1, 16*1024)
+	require.NoError(err, "CreateCheckpoint")
+	require.Equal(len(cp1.Chunks), storedChunks(), "all chunks should be stored")
+
+	root2 := node.Root{Namespace: testNs, Version: 2, Hash: rootHash}
+	cp2, err := fc.CreateCheckpoint(ctx, root2, 16*1024)
+	require.NoError(err, "CreateCheckpoint")
+	require.EqualValues(root2, cp2.Root, "checkpoint root should be correct")
+	require.EqualValues(cp1.Chunks, cp2.Chunks, "chunks should be the same")
+	require.Equal(len(cp1.Chunks), storedChunks(), "chunks should not be stored twice")
+
+	// A different chunk size should result in different chunks.
+	root3 := node.Root{Namespace: testNs, Version: 3, Hash: rootHash}
+	cp3, err := fc.CreateCheckpoint(ctx, root3, 8*1024)
+	require.NoError(err, "CreateCheckpoint")
+	require.NotEqualValues(cp1.Chunks, cp3.Chunks, "chunks should be different")
+
+	// Deleting a checkpoint should keep the chunks that are still referenced.
+	err = fc.DeleteCheckpoint(ctx, 1, root1)
+	require.NoError(err, "DeleteCheckpoint")
+	err = fc.DeleteCheckpoint(ctx, 1, root3)
+	require.NoError(err, "DeleteCheckpoint")
+	require.Equal(len(cp2.Chunks), storedChunks(), "unreferenced chunks should be removed")
+
+	for i := range cp2.Chunks {
+		var cm *ChunkMetadata
+		cm, err = cp2.GetChunkMetadata(uint64(i))
+		require.NoError(err, "GetChunkMetadata")
+
+		var buf bytes.Buffer
+		err = fc.GetCheckpointChunk(ctx, cm, &buf)
+		require.NoError(err, "GetChunk should work for shared chunks")
+	}
+
+	// Chunks should not be served for deleted checkpoints.
+	chunk0, err := cp1.GetChunkMetadata(0)
+	require.NoError(err, "GetChunkMetadata")
+	err = fc.GetCheckpointChunk(ctx, chunk0, ioutil.Discard)
+	require.Error(err, "GetChunk on a deleted checkpoint should fail")
+
+	err = fc.DeleteCheckpoint(ctx, 1, root2)
+	require.NoError(err, "DeleteCheckpoint")
+	require.Equal(0, storedChunks(), "all chunks should be removed")
+}
+
 func TestPruneGapAfterCheckpointRestore(t *testing.T) {
 	require := require.New(t)
 
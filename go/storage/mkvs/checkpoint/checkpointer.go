@@ -40,6 +40,9 @@ type CheckpointerConfig struct {
 	//
 	// This must return exactly RootsPerVersion roots.
 	GetRoots func(context.Context, uint64) ([]hash.Hash, error)
+
+	// Exporter is an optional exporter that all created checkpoints are exported to.
+	Exporter Exporter
 }
 
 // CreationParameters are the checkpoint creation parameters used by the checkpointer.
@@ -192,9 +195,37 @@ func (c *checkpointer) maybeCheckpoint(ctx context.Context, version uint64, para
 		}
 	}
 
+	// Export any checkpoints that have not yet been exported.
+	if c.cfg.Exporter != nil {
+		c.export(ctx)
+	}
+
 	return nil
 }
 
+func (c *checkpointer) export(ctx context.Context) {
+	cps, err := c.creator.GetCheckpoints(ctx, &GetCheckpointsRequest{
+		Version:   checkpointVersion,
+		Namespace: c.cfg.Namespace,
+	})
+	if err != nil {
+		c.logger.Warn("failed to get checkpoints for export",
+			"err", err,
+		)
+		return
+	}
+
+	// Export failures are not fatal as the export will be retried on the next check.
+	for _, cp := range cps {
+		if err = c.cfg.Exporter.Export(ctx, cp); err != nil {
+			c.logger.Warn("failed to export checkpoint",
+				"root", cp.Root,
+				"err", err,
+			)
+		}
+	}
+}
+
 func (c *checkpointer) worker(ctx context.Context) {
 	c.logger.Debug("storage checkpointer started",
 		"check_interval", c.cfg.CheckInterval,
@@ -0,0 +1,292 @@
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/objectstore"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+const manifestObject = "manifest"
+
+// Manifest is the integrity manifest of a checkpoint exported to object storage.
+//
+// The manifest is uploaded after all of the checkpoint chunks so its presence signals that the
+// checkpoint has been exported completely.
+type Manifest struct {
+	// Metadata is the checkpoint metadata.
+	Metadata Metadata `json:"metadata"`
+
+	// MetadataHash is the hash of the checkpoint metadata.
+	MetadataHash hash.Hash `json:"metadata_hash"`
+
+	// ChunkSizes are the sizes (in bytes) of the checkpoint chunks.
+	ChunkSizes []uint64 `json:"chunk_sizes"`
+}
+
+// Verify performs basic integrity checks of the manifest.
+func (m *Manifest) Verify() error {
+	if h := m.Metadata.EncodedHash(); !h.Equal(&m.MetadataHash) {
+		return fmt.Errorf("checkpoint: manifest metadata hash mismatch (expected: %s got: %s)",
+			m.MetadataHash,
+			h,
+		)
+	}
+	if len(m.ChunkSizes) != len(m.Metadata.Chunks) {
+		return fmt.Errorf("checkpoint: manifest has %d chunk sizes for %d chunks",
+			len(m.ChunkSizes),
+			len(m.Metadata.Chunks),
+		)
+	}
+	return nil
+}
+
+func exportPrefix(ns common.Namespace) string {
+	return ns.String() + "/"
+}
+
+func exportRootPrefix(root node.Root) string {
+	return path.Join(root.Namespace.String(), strconv.FormatUint(root.Version, 10), root.Hash.String())
+}
+
+func exportManifestKey(root node.Root) string {
+	return path.Join(exportRootPrefix(root), manifestObject)
+}
+
+func exportChunkKey(chunk *ChunkMetadata) string {
+	return path.Join(exportRootPrefix(chunk.Root), chunksDir, strconv.FormatUint(chunk.Index, 10))
+}
+
+// Exporter exports checkpoints to object storage.
+type Exporter interface {
+	// Export uploads the given checkpoint together with its integrity manifest unless the
+	// checkpoint has already been exported.
+	Export(ctx context.Context, cp *Metadata) error
+}
+
+type bucketExporter struct {
+	sync.Mutex
+
+	bucket   objectstore.Bucket
+	provider ChunkProvider
+	exported map[hash.Hash]bool
+
+	logger *logging.Logger
+}
+
+// Implements Exporter.
+func (e *bucketExporter) Export(ctx context.Context, cp *Metadata) error {
+	e.Lock()
+	defer e.Unlock()
+
+	cpHash := cp.EncodedHash()
+	if e.exported[cpHash] {
+		return nil
+	}
+
+	// Check if the checkpoint has already been exported (e.g., before a restart).
+	manifestKey := exportManifestKey(cp.Root)
+	data, err := e.bucket.Get(ctx, manifestKey)
+	switch err {
+	case nil:
+		var existing Manifest
+		if err = cbor.Unmarshal(data, &existing); err == nil && existing.MetadataHash.Equal(&cpHash) {
+			e.exported[cpHash] = true
+			return nil
+		}
+		e.logger.Warn("replacing invalid exported checkpoint manifest",
+			"root", cp.Root,
+		)
+	case objectstore.ErrNotFound:
+	default:
+		return fmt.Errorf("checkpoint: failed to check for exported manifest: %w", err)
+	}
+
+	e.logger.Info("exporting checkpoint",
+		"root", cp.Root,
+		"num_chunks", len(cp.Chunks),
+	)
+
+	manifest := Manifest{
+		Metadata:     *cp,
+		MetadataHash: cpHash,
+		ChunkSizes:   make([]uint64, 0, len(cp.Chunks)),
+	}
+	for idx := range cp.Chunks {
+		chunk, _ := cp.GetChunkMetadata(uint64(idx))
+
+		var buf bytes.Buffer
+		if err = e.provider.GetCheckpointChunk(ctx, chunk, &buf); err != nil {
+			return fmt.Errorf("checkpoint: failed to get chunk %d: %w", idx, err)
+		}
+		if h := hash.NewFromBytes(buf.Bytes()); !h.Equal(&chunk.Digest) {
+			return fmt.Errorf("%w: local chunk %d digest incorrect (expected: %s got: %s)",
+				ErrChunkCorrupted,
+				idx,
+				chunk.Digest,
+				h,
+			)
+		}
+		if err = e.bucket.Put(ctx, exportChunkKey(chunk), buf.Bytes()); err != nil {
+			return fmt.Errorf("checkpoint: failed to upload chunk %d: %w", idx, err)
+		}
+		manifest.ChunkSizes = append(manifest.ChunkSizes, uint64(buf.Len()))
+	}
+
+	if err = e.bucket.Put(ctx, manifestKey, cbor.Marshal(&manifest)); err != nil {
+		return fmt.Errorf("checkpoint: failed to upload manifest: %w", err)
+	}
+	e.exported[cpHash] = true
+
+	e.logger.Info("checkpoint exported",
+		"root", cp.Root,
+	)
+
+	return nil
+}
+
+// NewExporter creates a new checkpoint exporter that uploads checkpoints served by the given
+// chunk provider to the given bucket.
+func NewExporter(bucket objectstore.Bucket, provider ChunkProvider) Exporter {
+	return &bucketExporter{
+		bucket:   bucket,
+		provider: provider,
+		exported: make(map[hash.Hash]bool),
+		logger:   logging.GetLogger("storage/mkvs/checkpoint/exporter"),
+	}
+}
+
+type bucketChunkProvider struct {
+	bucket objectstore.Bucket
+
+	logger *logging.Logger
+}
+
+// Implements ChunkProvider.
+func (p *bucketChunkProvider) GetCheckpoints(ctx context.Context, request *GetCheckpointsRequest) ([]*Metadata, error) {
+	// Currently we only support a single version so we report no checkpoints for other versions.
+	if request.Version != checkpointVersion {
+		return []*Metadata{}, nil
+	}
+
+	prefix := exportPrefix(request.Namespace)
+	if request.RootVersion != nil {
+		prefix += strconv.FormatUint(*request.RootVersion, 10) + "/"
+	}
+	keys, err := p.bucket.List(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("checkpoint: failed to enumerate exported checkpoints: %w", err)
+	}
+
+	cps := []*Metadata{}
+	for _, key := range keys {
+		if path.Base(key) != manifestObject {
+			continue
+		}
+
+		data, err := p.bucket.Get(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("checkpoint: failed to fetch exported manifest at %s: %w", key, err)
+		}
+
+		// Skip any invalid manifests as the bucket may be shared and we should still be able to
+		// use the valid checkpoints.
+		var manifest Manifest
+		if err = cbor.Unmarshal(data, &manifest); err != nil {
+			p.logger.Warn("skipping malformed exported manifest",
+				"key", key,
+				"err", err,
+			)
+			continue
+		}
+		if err = manifest.Verify(); err != nil {
+			p.logger.Warn("skipping invalid exported manifest",
+				"key", key,
+				"err", err,
+			)
+			continue
+		}
+		if key != exportManifestKey(manifest.Metadata.Root) {
+			p.logger.Warn("skipping exported manifest at unexpected location",
+				"key", key,
+				"root", manifest.Metadata.Root,
+			)
+			continue
+		}
+
+		cp := manifest.Metadata
+		cps = append(cps, &cp)
+	}
+	return cps, nil
+}
+
+// Implements ChunkProvider.
+func (p *bucketChunkProvider) GetCheckpointChunk(ctx context.Context, chunk *ChunkMetadata, w io.Writer) error {
+	// Currently we only support a single version.
+	if chunk.Version != checkpointVersion {
+		return ErrChunkNotFound
+	}
+
+	data, err := p.bucket.Get(ctx, exportChunkKey(chunk))
+	switch {
+	case err == nil:
+	case errors.Is(err, objectstore.ErrNotFound):
+		return ErrChunkNotFound
+	default:
+		return fmt.Errorf("checkpoint: failed to fetch exported chunk: %w", err)
+	}
+
+	if h := hash.NewFromBytes(data); !h.Equal(&chunk.Digest) {
+		return fmt.Errorf("%w: exported chunk digest incorrect (expected: %s got: %s)",
+			ErrChunkCorrupted,
+			chunk.Digest,
+			h,
+		)
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// NewBucketChunkProvider creates a chunk provider serving checkpoints that have been exported
+// to the given bucket.
+func NewBucketChunkProvider(bucket objectstore.Bucket) ChunkProvider {
+	return &bucketChunkProvider{
+		bucket: bucket,
+		logger: logging.GetLogger("storage/mkvs/checkpoint/bucket"),
+	}
+}
+
+// RestoreFromProvider restores the given checkpoint by fetching all of its chunks from the given
+// chunk provider and restoring them using the given restorer.
+//
+// The caller is responsible for starting the restore before calling this method.
+func RestoreFromProvider(ctx context.Context, provider ChunkProvider, restorer Restorer, cp *Metadata) error {
+	for idx := range cp.Chunks {
+		chunk, _ := cp.GetChunkMetadata(uint64(idx))
+
+		var buf bytes.Buffer
+		if err := provider.GetCheckpointChunk(ctx, chunk, &buf); err != nil {
+			return fmt.Errorf("checkpoint: failed to fetch chunk %d: %w", idx, err)
+		}
+		done, err := restorer.RestoreChunk(ctx, uint64(idx), &buf)
+		if err != nil {
+			return fmt.Errorf("checkpoint: failed to restore chunk %d: %w", idx, err)
+		}
+		if done {
+			return nil
+		}
+	}
+	return fmt.Errorf("checkpoint: restore incomplete after all chunks")
+}
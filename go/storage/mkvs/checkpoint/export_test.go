@@ -0,0 +1,125 @@
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/objectstore"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	badgerDb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/badger"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+func TestExportRestore(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "mkvs.checkpoint.export")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dir)
+
+	ndb, err := badgerDb.New(&db.Config{
+		DB:           filepath.Join(dir, "db"),
+		Namespace:    testNs,
+		MaxCacheSize: 16 * 1024 * 1024,
+	})
+	require.NoError(err, "New")
+
+	ctx := context.Background()
+	tree := mkvs.New(nil, ndb)
+	for i := 0; i < 1000; i++ {
+		err = tree.Insert(ctx, []byte(strconv.Itoa(i)), []byte(strconv.Itoa(i)))
+		require.NoError(err, "Insert")
+	}
+	_, rootHash, err := tree.Commit(ctx, testNs, 0)
+	require.NoError(err, "Commit")
+	root := node.Root{
+		Namespace: testNs,
+		Version:   1,
+		Hash:      rootHash,
+	}
+
+	fc, err := NewFileCreator(filepath.Join(dir, "checkpoints"), ndb)
+	require.NoError(err, "NewFileCreator")
+	cp, err := fc.CreateCheckpoint(ctx, root, 16*1024)
+	require.NoError(err, "CreateCheckpoint")
+
+	// Export the checkpoint.
+	bucket := objectstore.NewMemory()
+	exporter := NewExporter(bucket, fc)
+	err = exporter.Export(ctx, cp)
+	require.NoError(err, "Export")
+	err = exporter.Export(ctx, cp)
+	require.NoError(err, "Export of an already exported checkpoint should work")
+
+	data, err := bucket.Get(ctx, exportManifestKey(root))
+	require.NoError(err, "manifest should be uploaded")
+	var manifest Manifest
+	err = cbor.Unmarshal(data, &manifest)
+	require.NoError(err, "manifest should be well-formed")
+	require.NoError(manifest.Verify(), "manifest should verify")
+	require.Equal(*cp, manifest.Metadata, "manifest should contain the checkpoint metadata")
+
+	// The exported checkpoint should be discoverable.
+	provider := NewBucketChunkProvider(bucket)
+	cps, err := provider.GetCheckpoints(ctx, &GetCheckpointsRequest{Version: 1, Namespace: testNs})
+	require.NoError(err, "GetCheckpoints")
+	require.Len(cps, 1, "there should be one exported checkpoint")
+	require.Equal(cp, cps[0], "exported checkpoint metadata should be correct")
+
+	otherVersion := uint64(2)
+	cps, err = provider.GetCheckpoints(ctx, &GetCheckpointsRequest{Version: 1, Namespace: testNs, RootVersion: &otherVersion})
+	require.NoError(err, "GetCheckpoints")
+	require.Len(cps, 0, "there should be no exported checkpoints for other versions")
+
+	// Restore the exported checkpoint into a fresh node database.
+	ndb2, err := badgerDb.New(&db.Config{
+		DB:           filepath.Join(dir, "db2"),
+		Namespace:    testNs,
+		MaxCacheSize: 16 * 1024 * 1024,
+	})
+	require.NoError(err, "New")
+	rs, err := NewRestorer(ndb2)
+	require.NoError(err, "NewRestorer")
+
+	err = rs.StartRestore(ctx, cps[0])
+	require.NoError(err, "StartRestore")
+	err = RestoreFromProvider(ctx, provider, rs, cps[0])
+	require.NoError(err, "RestoreFromProvider")
+	err = ndb2.Finalize(ctx, root.Version, []hash.Hash{root.Hash})
+	require.NoError(err, "Finalize")
+
+	tree2 := mkvs.NewWithRoot(nil, ndb2, root)
+	defer tree2.Close()
+	value, err := tree2.Get(ctx, []byte("42"))
+	require.NoError(err, "Get")
+	require.Equal([]byte("42"), value, "restored tree should contain the exported data")
+
+	// Corrupted chunks should be detected.
+	chunk0, err := cp.GetChunkMetadata(0)
+	require.NoError(err, "GetChunkMetadata")
+	err = bucket.Put(ctx, exportChunkKey(chunk0), []byte("corrupted"))
+	require.NoError(err, "Put")
+	var buf bytes.Buffer
+	err = provider.GetCheckpointChunk(ctx, chunk0, &buf)
+	require.Error(err, "GetCheckpointChunk should fail for corrupted chunks")
+	require.True(errors.Is(err, ErrChunkCorrupted))
+
+	// Invalid manifests should be skipped.
+	manifest.ChunkSizes = nil
+	err = bucket.Put(ctx, exportManifestKey(root), cbor.Marshal(&manifest))
+	require.NoError(err, "Put")
+	cps, err = provider.GetCheckpoints(ctx, &GetCheckpointsRequest{Version: 1, Namespace: testNs})
+	require.NoError(err, "GetCheckpoints")
+	require.Len(cps, 0, "invalid manifests should be skipped")
+}
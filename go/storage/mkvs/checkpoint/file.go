@@ -8,9 +8,11 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
 	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
@@ -20,15 +22,135 @@ import (
 const (
 	chunksDir              = "chunks"
 	checkpointMetadataFile = "meta"
+	checkpointInfoFile     = "info"
 	checkpointVersion      = 1
+
+	// chunkStoreDir is the directory (relative to the data directory) of the content-addressed
+	// chunk store which is shared between all checkpoints.
+	chunkStoreDir = "chunks"
+
+	crashPointCreateChunkAfter = "checkpoint.create.chunk.after"
 )
 
+func init() {
+	crash.RegisterCrashPoints(crashPointCreateChunkAfter)
+}
+
+// checkpointInfo is local information about how a checkpoint was created.
+type checkpointInfo struct {
+	// ChunkSize is the chunk size used when creating the checkpoint.
+	ChunkSize uint64 `json:"chunk_size"`
+}
+
 type fileCreator struct {
+	sync.Mutex
+
 	dataDir string
 	ndb     db.NodeDB
 }
 
+func (fc *fileCreator) chunkStorePath(digest hash.Hash) string {
+	return filepath.Join(fc.dataDir, chunkStoreDir, digest.String())
+}
+
+// storeChunk stores the chunk in the given temporary file under its digest in the chunk store.
+// In case a chunk with the same digest already exists, the existing chunk is referenced instead.
+func (fc *fileCreator) storeChunk(tmpFilename string, digest hash.Hash) error {
+	chunkFilename := fc.chunkStorePath(digest)
+	if _, err := os.Stat(chunkFilename); err == nil {
+		// Chunk already exists, no need to write it again.
+		return os.Remove(tmpFilename)
+	}
+	return os.Rename(tmpFilename, chunkFilename)
+}
+
+// findReusableChunks looks for an existing checkpoint of the same root hash (e.g., at a different
+// version) created with the same chunk size and returns its chunks in case all of them are
+// available in the chunk store.
+func (fc *fileCreator) findReusableChunks(root node.Root, chunkSize uint64) []hash.Hash {
+	matches, err := filepath.Glob(filepath.Join(fc.dataDir, "*", root.Hash.String(), checkpointInfoFile))
+	if err != nil {
+		return nil
+	}
+
+	for _, m := range matches {
+		data, err := ioutil.ReadFile(m)
+		if err != nil {
+			continue
+		}
+		var info checkpointInfo
+		if err = cbor.Unmarshal(data, &info); err != nil || info.ChunkSize != chunkSize {
+			continue
+		}
+
+		if data, err = ioutil.ReadFile(filepath.Join(filepath.Dir(m), checkpointMetadataFile)); err != nil {
+			continue
+		}
+		var cp Metadata
+		if err = cbor.Unmarshal(data, &cp); err != nil || !cp.Root.Namespace.Equal(&root.Namespace) {
+			continue
+		}
+
+		available := true
+		for _, digest := range cp.Chunks {
+			if _, err = os.Stat(fc.chunkStorePath(digest)); err != nil {
+				available = false
+				break
+			}
+		}
+		if available {
+			return cp.Chunks
+		}
+	}
+	return nil
+}
+
+// collectGarbage removes all chunks from the chunk store that are not referenced by any
+// checkpoint.
+func (fc *fileCreator) collectGarbage() error {
+	matches, err := filepath.Glob(filepath.Join(fc.dataDir, "*", "*", checkpointMetadataFile))
+	if err != nil {
+		return fmt.Errorf("checkpoint: failed to enumerate checkpoints: %w", err)
+	}
+
+	referenced := make(map[string]bool)
+	for _, m := range matches {
+		data, err := ioutil.ReadFile(m)
+		if err != nil {
+			return fmt.Errorf("checkpoint: failed to read checkpoint metadata at %s: %w", m, err)
+		}
+		var cp Metadata
+		if err = cbor.Unmarshal(data, &cp); err != nil {
+			return fmt.Errorf("checkpoint: corrupted checkpoint metadata at %s: %w", m, err)
+		}
+		for _, digest := range cp.Chunks {
+			referenced[digest.String()] = true
+		}
+	}
+
+	entries, err := ioutil.ReadDir(filepath.Join(fc.dataDir, chunkStoreDir))
+	switch {
+	case err == nil:
+	case os.IsNotExist(err):
+		return nil
+	default:
+		return fmt.Errorf("checkpoint: failed to read chunk store: %w", err)
+	}
+	for _, entry := range entries {
+		if referenced[entry.Name()] {
+			continue
+		}
+		if err = os.Remove(filepath.Join(fc.dataDir, chunkStoreDir, entry.Name())); err != nil {
+			return fmt.Errorf("checkpoint: failed to remove chunk: %w", err)
+		}
+	}
+	return nil
+}
+
 func (fc *fileCreator) CreateCheckpoint(ctx context.Context, root node.Root, chunkSize uint64) (meta *Metadata, err error) {
+	fc.Lock()
+	defer fc.Unlock()
+
 	tree := mkvs.NewWithRoot(nil, fc.ndb, root)
 	defer tree.Close()
 
@@ -58,36 +180,46 @@ func (fc *fileCreator) CreateCheckpoint(ctx context.Context, root node.Root, chu
 		return &existing, nil
 	}
 
-	// Create chunks directory.
-	chunksDir := filepath.Join(checkpointDir, chunksDir)
-	if err = common.Mkdir(chunksDir); err != nil {
-		return nil, fmt.Errorf("checkpoint: failed to create chunk directory: %w", err)
+	// Chunks are content-addressed and stored in a chunk store shared between all checkpoints so
+	// that chunks which are the same as in other checkpoints are only referenced.
+	storeDir := filepath.Join(fc.dataDir, chunkStoreDir)
+	if err = common.Mkdir(storeDir); err != nil {
+		return nil, fmt.Errorf("checkpoint: failed to create chunk store directory: %w", err)
 	}
 
-	// Create chunks until we are done.
-	var chunks []hash.Hash
-	var nextOffset node.Key
-	for chunkIndex := 0; ; chunkIndex++ {
-		dataFilename := filepath.Join(chunksDir, strconv.Itoa(chunkIndex))
-
-		// Generate chunk.
-		var f *os.File
-		if f, err = os.Create(dataFilename); err != nil {
-			return nil, fmt.Errorf("checkpoint: failed to create chunk file for chunk %d: %w", chunkIndex, err)
-		}
-
-		var chunkHash hash.Hash
-		chunkHash, nextOffset, err = createChunk(ctx, tree, root, nextOffset, chunkSize, f)
-		f.Close()
-		if err != nil {
-			return nil, fmt.Errorf("checkpoint: failed to create chunk %d: %w", chunkIndex, err)
-		}
-
-		chunks = append(chunks, chunkHash)
-
-		// Check if we are finished.
-		if nextOffset == nil {
-			break
+	// In case a checkpoint of the same root already exists at a different version, its chunks can
+	// be reused as they only depend on the root hash and chunk size.
+	chunks := fc.findReusableChunks(root, chunkSize)
+	if chunks == nil {
+		// Create chunks until we are done.
+		var nextOffset node.Key
+		for chunkIndex := 0; ; chunkIndex++ {
+			// Generate chunk into a temporary file as its digest is not known in advance.
+			var f *os.File
+			if f, err = ioutil.TempFile(storeDir, ".tmp-chunk-"); err != nil {
+				return nil, fmt.Errorf("checkpoint: failed to create chunk file for chunk %d: %w", chunkIndex, err)
+			}
+
+			var chunkHash hash.Hash
+			chunkHash, nextOffset, err = createChunk(ctx, tree, root, nextOffset, chunkSize, f)
+			f.Close()
+			if err != nil {
+				_ = os.Remove(f.Name())
+				return nil, fmt.Errorf("checkpoint: failed to create chunk %d: %w", chunkIndex, err)
+			}
+			if err = fc.storeChunk(f.Name(), chunkHash); err != nil {
+				_ = os.Remove(f.Name())
+				return nil, fmt.Errorf("checkpoint: failed to store chunk %d: %w", chunkIndex, err)
+			}
+
+			chunks = append(chunks, chunkHash)
+
+			crash.Here(crashPointCreateChunkAfter)
+
+			// Check if we are finished.
+			if nextOffset == nil {
+				break
+			}
 		}
 	}
 
@@ -98,6 +230,12 @@ func (fc *fileCreator) CreateCheckpoint(ctx context.Context, root node.Root, chu
 		Chunks:  chunks,
 	}
 
+	info := checkpointInfo{
+		ChunkSize: chunkSize,
+	}
+	if err = ioutil.WriteFile(filepath.Join(checkpointDir, checkpointInfoFile), cbor.Marshal(info), 0o600); err != nil {
+		return nil, fmt.Errorf("checkpoint: failed to create checkpoint info: %w", err)
+	}
 	if err = ioutil.WriteFile(filepath.Join(checkpointDir, checkpointMetadataFile), cbor.Marshal(meta), 0o600); err != nil {
 		return nil, fmt.Errorf("checkpoint: failed to create checkpoint metadata: %w", err)
 	}
@@ -168,6 +306,9 @@ func (fc *fileCreator) DeleteCheckpoint(ctx context.Context, version uint16, roo
 		return ErrCheckpointNotFound
 	}
 
+	fc.Lock()
+	defer fc.Unlock()
+
 	versionDir := filepath.Join(fc.dataDir, strconv.FormatUint(root.Version, 10))
 	checkpointDir := filepath.Join(versionDir, root.Hash.String())
 	checkpointFilename := filepath.Join(checkpointDir, checkpointMetadataFile)
@@ -198,7 +339,8 @@ func (fc *fileCreator) DeleteCheckpoint(ctx context.Context, version uint16, roo
 		return fmt.Errorf("checkpoint: failed to read directory: %w", err)
 	}
 
-	return nil
+	// Remove any chunks which are no longer referenced by other checkpoints.
+	return fc.collectGarbage()
 }
 
 func (fc *fileCreator) GetCheckpointChunk(ctx context.Context, chunk *ChunkMetadata, w io.Writer) error {
@@ -207,15 +349,27 @@ func (fc *fileCreator) GetCheckpointChunk(ctx context.Context, chunk *ChunkMetad
 		return ErrChunkNotFound
 	}
 
-	chunkFilename := filepath.Join(
-		fc.dataDir,
-		strconv.FormatUint(chunk.Root.Version, 10),
-		chunk.Root.Hash.String(),
-		chunksDir,
-		strconv.FormatUint(chunk.Index, 10),
-	)
+	// Make sure the chunk is part of the given checkpoint as chunks are shared.
+	cp, err := fc.GetCheckpoint(ctx, chunk.Version, chunk.Root)
+	if err != nil {
+		return ErrChunkNotFound
+	}
+	cm, err := cp.GetChunkMetadata(chunk.Index)
+	if err != nil || !cm.Digest.Equal(&chunk.Digest) {
+		return ErrChunkNotFound
+	}
 
-	f, err := os.Open(chunkFilename)
+	f, err := os.Open(fc.chunkStorePath(chunk.Digest))
+	if os.IsNotExist(err) {
+		// Checkpoints created before the chunk store was introduced store chunks by index.
+		f, err = os.Open(filepath.Join(
+			fc.dataDir,
+			strconv.FormatUint(chunk.Root.Version, 10),
+			chunk.Root.Hash.String(),
+			chunksDir,
+			strconv.FormatUint(chunk.Index, 10),
+		))
+	}
 	if err != nil {
 		return ErrChunkNotFound
 	}
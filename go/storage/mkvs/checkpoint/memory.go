@@ -0,0 +1,221 @@
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+// memoryCheckpoint is a checkpoint held by the in-memory checkpoint creator.
+type memoryCheckpoint struct {
+	meta      *Metadata
+	chunkSize uint64
+}
+
+type memoryCreator struct {
+	sync.RWMutex
+
+	ndb db.NodeDB
+
+	// checkpoints are the created checkpoints indexed by root version and hash.
+	checkpoints map[uint64]map[hash.Hash]*memoryCheckpoint
+	// chunks is the content-addressed chunk store shared between all checkpoints.
+	chunks map[hash.Hash][]byte
+}
+
+// findReusableChunks looks for an existing checkpoint of the same root hash (e.g., at a different
+// version) created with the same chunk size and returns its chunks.
+func (mc *memoryCreator) findReusableChunks(root node.Root, chunkSize uint64) []hash.Hash {
+	for _, cps := range mc.checkpoints {
+		cp, ok := cps[root.Hash]
+		if !ok || cp.chunkSize != chunkSize || !cp.meta.Root.Namespace.Equal(&root.Namespace) {
+			continue
+		}
+		return cp.meta.Chunks
+	}
+	return nil
+}
+
+// collectGarbage removes all chunks from the chunk store that are not referenced by any
+// checkpoint.
+func (mc *memoryCreator) collectGarbage() {
+	referenced := make(map[hash.Hash]bool)
+	for _, cps := range mc.checkpoints {
+		for _, cp := range cps {
+			for _, digest := range cp.meta.Chunks {
+				referenced[digest] = true
+			}
+		}
+	}
+
+	for digest := range mc.chunks {
+		if !referenced[digest] {
+			delete(mc.chunks, digest)
+		}
+	}
+}
+
+func (mc *memoryCreator) CreateCheckpoint(ctx context.Context, root node.Root, chunkSize uint64) (*Metadata, error) {
+	mc.Lock()
+	defer mc.Unlock()
+
+	// Check if the checkpoint already exists and just return the existing metadata in this case.
+	if cp, ok := mc.checkpoints[root.Version][root.Hash]; ok {
+		return cp.meta, nil
+	}
+
+	// In case a checkpoint of the same root already exists at a different version, its chunks can
+	// be reused as they only depend on the root hash and chunk size.
+	chunks := mc.findReusableChunks(root, chunkSize)
+	if chunks == nil {
+		tree := mkvs.NewWithRoot(nil, mc.ndb, root)
+		defer tree.Close()
+
+		// Create chunks until we are done. Chunks are only added to the chunk store once the
+		// whole checkpoint has been created.
+		newChunks := make(map[hash.Hash][]byte)
+		var nextOffset node.Key
+		for chunkIndex := 0; ; chunkIndex++ {
+			var (
+				buf       bytes.Buffer
+				chunkHash hash.Hash
+				err       error
+			)
+			chunkHash, nextOffset, err = createChunk(ctx, tree, root, nextOffset, chunkSize, &buf)
+			if err != nil {
+				return nil, fmt.Errorf("checkpoint: failed to create chunk %d: %w", chunkIndex, err)
+			}
+
+			newChunks[chunkHash] = buf.Bytes()
+			chunks = append(chunks, chunkHash)
+
+			// Check if we are finished.
+			if nextOffset == nil {
+				break
+			}
+		}
+		for digest, data := range newChunks {
+			mc.chunks[digest] = data
+		}
+	}
+
+	meta := &Metadata{
+		Version: checkpointVersion,
+		Root:    root,
+		Chunks:  chunks,
+	}
+
+	if mc.checkpoints[root.Version] == nil {
+		mc.checkpoints[root.Version] = make(map[hash.Hash]*memoryCheckpoint)
+	}
+	mc.checkpoints[root.Version][root.Hash] = &memoryCheckpoint{
+		meta:      meta,
+		chunkSize: chunkSize,
+	}
+	return meta, nil
+}
+
+func (mc *memoryCreator) GetCheckpoints(ctx context.Context, request *GetCheckpointsRequest) ([]*Metadata, error) {
+	// Currently we only support a single version so we report no checkpoints for other versions.
+	if request.Version != checkpointVersion {
+		return []*Metadata{}, nil
+	}
+
+	mc.RLock()
+	defer mc.RUnlock()
+
+	var cps []*Metadata
+	for version, versionCps := range mc.checkpoints {
+		// Apply optional root version filter.
+		if request.RootVersion != nil && *request.RootVersion != version {
+			continue
+		}
+		for _, cp := range versionCps {
+			cps = append(cps, cp.meta)
+		}
+	}
+	return cps, nil
+}
+
+func (mc *memoryCreator) GetCheckpoint(ctx context.Context, version uint16, root node.Root) (*Metadata, error) {
+	// Currently we only support a single version.
+	if version != checkpointVersion {
+		return nil, ErrCheckpointNotFound
+	}
+
+	mc.RLock()
+	defer mc.RUnlock()
+
+	cp, ok := mc.checkpoints[root.Version][root.Hash]
+	if !ok {
+		return nil, ErrCheckpointNotFound
+	}
+	return cp.meta, nil
+}
+
+func (mc *memoryCreator) DeleteCheckpoint(ctx context.Context, version uint16, root node.Root) error {
+	// Currently we only support a single version.
+	if version != checkpointVersion {
+		return ErrCheckpointNotFound
+	}
+
+	mc.Lock()
+	defer mc.Unlock()
+
+	if _, ok := mc.checkpoints[root.Version][root.Hash]; !ok {
+		return ErrCheckpointNotFound
+	}
+	delete(mc.checkpoints[root.Version], root.Hash)
+	if len(mc.checkpoints[root.Version]) == 0 {
+		delete(mc.checkpoints, root.Version)
+	}
+
+	// Remove any chunks which are no longer referenced by other checkpoints.
+	mc.collectGarbage()
+	return nil
+}
+
+func (mc *memoryCreator) GetCheckpointChunk(ctx context.Context, chunk *ChunkMetadata, w io.Writer) error {
+	// Currently we only support a single version.
+	if chunk.Version != checkpointVersion {
+		return ErrChunkNotFound
+	}
+
+	mc.RLock()
+	defer mc.RUnlock()
+
+	// Make sure the chunk is part of the given checkpoint as chunks are shared.
+	cp, ok := mc.checkpoints[chunk.Root.Version][chunk.Root.Hash]
+	if !ok {
+		return ErrChunkNotFound
+	}
+	cm, err := cp.meta.GetChunkMetadata(chunk.Index)
+	if err != nil || !cm.Digest.Equal(&chunk.Digest) {
+		return ErrChunkNotFound
+	}
+	data, ok := mc.chunks[chunk.Digest]
+	if !ok {
+		return ErrChunkNotFound
+	}
+
+	if _, err = w.Write(data); err != nil {
+		return fmt.Errorf("checkpoint: failed to read chunk: %w", err)
+	}
+	return nil
+}
+
+// NewMemoryCreator creates a new checkpoint creator that keeps created checkpoints in memory.
+func NewMemoryCreator(ndb db.NodeDB) (Creator, error) {
+	return &memoryCreator{
+		ndb:         ndb,
+		checkpoints: make(map[uint64]map[hash.Hash]*memoryCheckpoint),
+		chunks:      make(map[hash.Hash][]byte),
+	}, nil
+}
@@ -0,0 +1,146 @@
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	badgerDb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/badger"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+func TestMemoryCheckpointCreator(t *testing.T) {
+	require := require.New(t)
+
+	ndb, err := badgerDb.New(&db.Config{
+		MemoryOnly:   true,
+		Namespace:    testNs,
+		MaxCacheSize: 16 * 1024 * 1024,
+	})
+	require.NoError(err, "New")
+	defer ndb.Close()
+
+	ctx := context.Background()
+	tree := mkvs.New(nil, ndb)
+	for i := 0; i < 1000; i++ {
+		err = tree.Insert(ctx, []byte(strconv.Itoa(i)), []byte(strconv.Itoa(i)))
+		require.NoError(err, "Insert")
+	}
+	_, rootHash, err := tree.Commit(ctx, testNs, 0)
+	require.NoError(err, "Commit")
+	root := node.Root{Namespace: testNs, Version: 1, Hash: rootHash}
+
+	c, err := NewMemoryCreator(ndb)
+	require.NoError(err, "NewMemoryCreator")
+	mc := c.(*memoryCreator)
+
+	// There should be no checkpoints before one is created.
+	cps, err := mc.GetCheckpoints(ctx, &GetCheckpointsRequest{Version: 1})
+	require.NoError(err, "GetCheckpoints")
+	require.Len(cps, 0)
+
+	// Chunks should be the same as when using the file-based checkpoint creator.
+	cp, err := mc.CreateCheckpoint(ctx, root, 16*1024)
+	require.NoError(err, "CreateCheckpoint")
+	require.EqualValues(root, cp.Root, "checkpoint root should be correct")
+	var expectedChunks []hash.Hash
+	for _, hh := range []string{
+		"620f318c245858b351602a8b21e708663b03cd2befd982210ffbaa3c56bf9358",
+		"37d38a95492038df4afee65b5b91bf8499f522dea346c0e553e03d3333bff394",
+		"df608e9821dc8d248a0f0d0ff4cb998d51f329767dfc8a7520c15e38c47be1e9",
+	} {
+		var h hash.Hash
+		_ = h.UnmarshalHex(hh)
+		expectedChunks = append(expectedChunks, h)
+	}
+	require.EqualValues(expectedChunks, cp.Chunks, "chunk hashes should be correct")
+	require.Len(mc.chunks, len(cp.Chunks), "all chunks should be stored")
+
+	gcp, err := mc.GetCheckpoint(ctx, 1, root)
+	require.NoError(err, "GetCheckpoint")
+	require.Equal(cp, gcp)
+
+	// Checkpoints of the same root at a different version should share chunks.
+	root2 := node.Root{Namespace: testNs, Version: 2, Hash: rootHash}
+	cp2, err := mc.CreateCheckpoint(ctx, root2, 16*1024)
+	require.NoError(err, "CreateCheckpoint")
+	require.EqualValues(cp.Chunks, cp2.Chunks, "chunks should be the same")
+	require.Len(mc.chunks, len(cp.Chunks), "chunks should not be stored twice")
+
+	cps, err = mc.GetCheckpoints(ctx, &GetCheckpointsRequest{Version: 1, RootVersion: &root2.Version})
+	require.NoError(err, "GetCheckpoints")
+	require.Len(cps, 1, "there should be one checkpoint at the given root version")
+	require.Equal(cp2, cps[0])
+
+	// Restore the checkpoint into a fresh node database.
+	ndb2, err := badgerDb.New(&db.Config{
+		MemoryOnly:   true,
+		Namespace:    testNs,
+		MaxCacheSize: 16 * 1024 * 1024,
+	})
+	require.NoError(err, "New")
+	defer ndb2.Close()
+
+	rs, err := NewRestorer(ndb2)
+	require.NoError(err, "NewRestorer")
+	err = rs.StartRestore(ctx, cp)
+	require.NoError(err, "StartRestore")
+	for i := 0; i < len(cp.Chunks); i++ {
+		var cm *ChunkMetadata
+		cm, err = cp.GetChunkMetadata(uint64(i))
+		require.NoError(err, "GetChunkMetadata")
+
+		var buf bytes.Buffer
+		err = mc.GetCheckpointChunk(ctx, cm, &buf)
+		require.NoError(err, "GetChunk")
+
+		var done bool
+		done, err = rs.RestoreChunk(ctx, uint64(i), &buf)
+		require.NoError(err, "RestoreChunk")
+		require.Equal(i == len(cp.Chunks)-1, done, "RestoreChunk should signal completion when done")
+	}
+	err = ndb2.Finalize(ctx, root.Version, []hash.Hash{root.Hash})
+	require.NoError(err, "Finalize")
+
+	tree = mkvs.NewWithRoot(nil, ndb2, root)
+	defer tree.Close()
+	for i := 0; i < 1000; i++ {
+		var value []byte
+		value, err = tree.Get(ctx, []byte(strconv.Itoa(i)))
+		require.NoError(err, "Get")
+		require.Equal([]byte(strconv.Itoa(i)), value)
+	}
+
+	// Deleting a checkpoint should keep the chunks that are still referenced.
+	err = mc.DeleteCheckpoint(ctx, 1, root)
+	require.NoError(err, "DeleteCheckpoint")
+	require.Len(mc.chunks, len(cp2.Chunks), "referenced chunks should be kept")
+
+	_, err = mc.GetCheckpoint(ctx, 1, root)
+	require.Error(err, "GetCheckpoint should fail with non-existent checkpoint")
+	err = mc.DeleteCheckpoint(ctx, 1, root)
+	require.Error(err, "DeleteCheckpoint on a non-existent checkpoint should fail")
+
+	chunk0, err := cp.GetChunkMetadata(0)
+	require.NoError(err, "GetChunkMetadata")
+	var buf bytes.Buffer
+	err = mc.GetCheckpointChunk(ctx, chunk0, &buf)
+	require.Error(err, "GetChunk on a deleted checkpoint should fail")
+
+	err = mc.DeleteCheckpoint(ctx, 1, root2)
+	require.NoError(err, "DeleteCheckpoint")
+	require.Len(mc.chunks, 0, "all chunks should be removed")
+
+	// Create a checkpoint with unknown root.
+	invalidRoot := root
+	invalidRoot.Hash.FromBytes([]byte("mkvs checkpoint test invalid root"))
+	_, err = mc.CreateCheckpoint(ctx, invalidRoot, 16*1024)
+	require.Error(err, "CreateCheckpoint should fail for invalid root")
+	require.Len(mc.chunks, 0, "no chunks should be stored after a failed checkpoint")
+}
@@ -2,6 +2,7 @@ package mkvs
 
 import (
 	"context"
+	"sort"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
@@ -99,10 +100,17 @@ func (t *tree) commitWithHooks(
 		}
 	}
 
-	// Store write log summaries.
+	// Store write log summaries. Entries are sorted by key so that the write log is well-formed.
+	keys := make([]string, 0, len(t.pendingWriteLog))
+	for key := range t.pendingWriteLog {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
 	var log writelog.WriteLog
 	var logAnns writelog.Annotations
-	for _, entry := range t.pendingWriteLog {
+	for _, key := range keys {
+		entry := t.pendingWriteLog[key]
 		// Skip all entries that do not exist after all the updates and
 		// did not exist before.
 		if entry.value == nil && !entry.existed {
@@ -51,6 +51,9 @@ var (
 	// ErrInvalidMultipartVersion indicates that a Finalize, NewBatch or Commit was called with a version
 	// that doesn't match the current multipart restore as set with StartMultipartRestore.
 	ErrInvalidMultipartVersion = errors.New(ModuleName, 14, "mkvs: operation called with different version than current multipart version")
+	// ErrKeyHistoryUnavailable indicates that the key history index is not maintained by the
+	// node database (e.g., because write logs are being discarded).
+	ErrKeyHistoryUnavailable = errors.New(ModuleName, 15, "mkvs: key history not available")
 )
 
 // Config is the node database backend configuration.
@@ -75,6 +78,23 @@ type Config struct { // nolint: maligned
 
 	// DiscardWriteLogs will cause all write logs to be discarded.
 	DiscardWriteLogs bool
+
+	// GCWindows are the daily UTC time windows of the form HH:MM-HH:MM during which periodic
+	// background garbage collection is allowed to run (if supported by the backend). If empty,
+	// garbage collection may run at any time.
+	GCWindows []string
+
+	// RocksDB is the RocksDB backend specific configuration.
+	RocksDB RocksDBConfig
+}
+
+// RocksDBConfig is the RocksDB node database backend specific configuration.
+type RocksDBConfig struct {
+	// BlockCacheSize is the size of the block cache. If zero, MaxCacheSize is used.
+	BlockCacheSize int64
+
+	// CompactionStyle is the compaction style (level or universal).
+	CompactionStyle string
 }
 
 // NodeDB is the persistence layer used for persisting the in-memory tree.
@@ -94,6 +114,20 @@ type NodeDB interface {
 	// GetRootsForVersion returns a list of roots stored under the given version.
 	GetRootsForVersion(ctx context.Context, version uint64) ([]hash.Hash, error)
 
+	// GetKeyHistory returns the list of modifications of the given key at retained finalized
+	// versions, ordered by version.
+	//
+	// Only modifications applied via write logs are tracked, changes restored from checkpoints
+	// are not part of the history.
+	GetKeyHistory(ctx context.Context, key []byte) ([]KeyHistoryEntry, error)
+
+	// GetAt returns the value of the given key in the tree with the given finalized root. In case
+	// the key did not exist in the tree, nil is returned.
+	//
+	// Only modifications made in the given root and in the roots it has been derived from are
+	// considered, so other roots under the same versions (e.g., I/O roots) do not interfere.
+	GetAt(ctx context.Context, root node.Root, key []byte) ([]byte, error)
+
 	// StartMultipartInsert prepares the database for a batch insert job from multiple chunks.
 	// Batches from this call onwards will keep track of inserted nodes so that they can be
 	// deleted if the job fails for any reason.
@@ -130,6 +164,10 @@ type NodeDB interface {
 	// Size returns the size of the database in bytes.
 	Size() (int64, error)
 
+	// Compact immediately runs garbage collection on the database, regardless of any configured
+	// GC windows, and returns the number of reclaimed bytes.
+	Compact(ctx context.Context) (int64, error)
+
 	// Sync syncs the database to disk. This is useful if the NoFsync option is used to explicitly
 	// perform a sync.
 	Sync() error
@@ -138,6 +176,16 @@ type NodeDB interface {
 	Close()
 }
 
+// KeyHistoryEntry is a single modification of a key as recorded in the key history index.
+type KeyHistoryEntry struct {
+	// Version is the version in which the key was modified.
+	Version uint64 `json:"version"`
+	// Root is the hash of the root in which the key was modified.
+	Root hash.Hash `json:"root"`
+	// Removed is true iff the key was removed in this modification.
+	Removed bool `json:"removed,omitempty"`
+}
+
 // Subtree is a NodeDB-specific subtree implementation.
 type Subtree interface {
 	// PutNode persists a node in the NodeDB.
@@ -227,6 +275,14 @@ func (d *nopNodeDB) GetRootsForVersion(ctx context.Context, version uint64) ([]h
 	return nil, nil
 }
 
+func (d *nopNodeDB) GetKeyHistory(ctx context.Context, key []byte) ([]KeyHistoryEntry, error) {
+	return nil, nil
+}
+
+func (d *nopNodeDB) GetAt(ctx context.Context, root node.Root, key []byte) ([]byte, error) {
+	return nil, nil
+}
+
 func (d *nopNodeDB) HasRoot(root node.Root) bool {
 	return false
 }
@@ -251,6 +307,10 @@ func (d *nopNodeDB) Size() (int64, error) {
 	return 0, nil
 }
 
+func (d *nopNodeDB) Compact(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
 func (d *nopNodeDB) Sync() error {
 	return nil
 }
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
+)
+
+var (
+	nodeDBLatency = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "oasis_storage_nodedb_latency",
+			Help: "Node database operation latency (seconds).",
+		},
+		[]string{"call"},
+	)
+	nodeDBFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_storage_nodedb_failures",
+			Help: "Number of failed node database operations.",
+		},
+		[]string{"call"},
+	)
+	nodeDBNodeLookups = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_storage_nodedb_node_lookups",
+			Help: "Number of node database node lookups.",
+		},
+		[]string{"result"},
+	)
+	nodeDBWriteLogSize = prometheus.NewSummary(
+		prometheus.SummaryOpts{
+			Name: "oasis_storage_nodedb_write_log_size",
+			Help: "Size of write logs stored in the node database (number of entries).",
+		},
+	)
+	nodeDBWriteLogBytes = prometheus.NewSummary(
+		prometheus.SummaryOpts{
+			Name: "oasis_storage_nodedb_write_log_bytes",
+			Help: "Size of write logs stored in the node database (bytes).",
+		},
+	)
+
+	nodeDBCollectors = []prometheus.Collector{
+		nodeDBLatency,
+		nodeDBFailures,
+		nodeDBNodeLookups,
+		nodeDBWriteLogSize,
+		nodeDBWriteLogBytes,
+	}
+
+	labelGetNode     = prometheus.Labels{"call": "get_node"}
+	labelGetWriteLog = prometheus.Labels{"call": "get_write_log"}
+	labelGetAt       = prometheus.Labels{"call": "get_at"}
+	labelFinalize    = prometheus.Labels{"call": "finalize"}
+	labelPrune       = prometheus.Labels{"call": "prune"}
+	labelCommit      = prometheus.Labels{"call": "commit"}
+
+	labelHit  = prometheus.Labels{"result": "hit"}
+	labelMiss = prometheus.Labels{"result": "miss"}
+
+	_ NodeDB = (*metricsWrapper)(nil)
+	_ Batch  = (*metricsBatch)(nil)
+
+	metricsOnce sync.Once
+)
+
+// observe records the latency and the outcome of a node database operation.
+func observe(labels prometheus.Labels, start time.Time, err error) {
+	nodeDBLatency.With(labels).Observe(time.Since(start).Seconds())
+	if err != nil {
+		nodeDBFailures.With(labels).Inc()
+	}
+}
+
+type metricsWrapper struct {
+	NodeDB
+}
+
+func (w *metricsWrapper) GetNode(root node.Root, ptr *node.Pointer) (node.Node, error) {
+	start := time.Now()
+	n, err := w.NodeDB.GetNode(root, ptr)
+	nodeDBLatency.With(labelGetNode).Observe(time.Since(start).Seconds())
+
+	switch err {
+	case nil:
+		nodeDBNodeLookups.With(labelHit).Inc()
+	case ErrNodeNotFound:
+		nodeDBNodeLookups.With(labelMiss).Inc()
+	default:
+		nodeDBFailures.With(labelGetNode).Inc()
+	}
+	return n, err
+}
+
+func (w *metricsWrapper) GetWriteLog(ctx context.Context, startRoot, endRoot node.Root) (writelog.Iterator, error) {
+	start := time.Now()
+	it, err := w.NodeDB.GetWriteLog(ctx, startRoot, endRoot)
+	observe(labelGetWriteLog, start, err)
+	return it, err
+}
+
+func (w *metricsWrapper) GetAt(ctx context.Context, root node.Root, key []byte) ([]byte, error) {
+	start := time.Now()
+	value, err := w.NodeDB.GetAt(ctx, root, key)
+	observe(labelGetAt, start, err)
+	return value, err
+}
+
+func (w *metricsWrapper) NewBatch(oldRoot node.Root, version uint64, chunk bool) (Batch, error) {
+	batch, err := w.NodeDB.NewBatch(oldRoot, version, chunk)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsBatch{Batch: batch}, nil
+}
+
+func (w *metricsWrapper) Finalize(ctx context.Context, version uint64, roots []hash.Hash) error {
+	start := time.Now()
+	err := w.NodeDB.Finalize(ctx, version, roots)
+	observe(labelFinalize, start, err)
+	return err
+}
+
+func (w *metricsWrapper) Prune(ctx context.Context, version uint64) error {
+	start := time.Now()
+	err := w.NodeDB.Prune(ctx, version)
+	observe(labelPrune, start, err)
+	return err
+}
+
+type metricsBatch struct {
+	Batch
+}
+
+func (b *metricsBatch) PutWriteLog(writeLog writelog.WriteLog, logAnnotations writelog.Annotations) error {
+	var size int
+	for _, entry := range writeLog {
+		size += len(entry.Key) + len(entry.Value)
+	}
+	nodeDBWriteLogSize.Observe(float64(len(writeLog)))
+	nodeDBWriteLogBytes.Observe(float64(size))
+
+	return b.Batch.PutWriteLog(writeLog, logAnnotations)
+}
+
+func (b *metricsBatch) Commit(root node.Root) error {
+	start := time.Now()
+	err := b.Batch.Commit(root)
+	observe(labelCommit, start, err)
+	return err
+}
+
+// NewMetricsWrapper wraps the given node database so that operations are instrumented with
+// Prometheus metrics.
+func NewMetricsWrapper(db NodeDB) NodeDB {
+	metricsOnce.Do(func() {
+		prometheus.MustRegister(nodeDBCollectors...)
+	})
+
+	return &metricsWrapper{NodeDB: db}
+}
@@ -60,6 +60,15 @@ var (
 	//
 	// Value is empty.
 	multipartRestoreNodeLogKeyFmt = keyformat.New(0x05, &hash.Hash{})
+	// keyHistoryKeyFmt is the key format for the key history index (key hash, version, root).
+	//
+	// Value is CBOR-serialized hash of the inserted leaf node or nil if the key was removed.
+	keyHistoryKeyFmt = keyformat.New(0x06, &hash.Hash{}, uint64(0), &hash.Hash{})
+	// rootKeyHistoryKeyFmt is the key format for the reverse key history index used to
+	// discover index entries belonging to a given root (version, root, key hash).
+	//
+	// Value is empty.
+	rootKeyHistoryKeyFmt = keyformat.New(0x07, uint64(0), &hash.Hash{}, &hash.Hash{})
 )
 
 // New creates a new BadgerDB-backed node database.
@@ -107,7 +116,12 @@ func New(cfg *api.Config) (api.NodeDB, error) {
 		return nil, fmt.Errorf("mkvs/badger: failed to clean leftovers from multipart restore: %w", err)
 	}
 
-	db.gc = cmnBadger.NewGCWorker(db.logger, db.db)
+	gcWindows, err := cmnBadger.ParseGCWindows(cfg.GCWindows)
+	if err != nil {
+		_ = db.db.Close()
+		return nil, fmt.Errorf("mkvs/badger: %w", err)
+	}
+	db.gc = cmnBadger.NewScheduledGCWorker(db.logger, db.db, gcWindows)
 
 	return db, nil
 }
@@ -514,6 +528,9 @@ func (d *badgerNodeDB) Finalize(ctx context.Context, version uint64, roots []has
 	// Version batch collects removals at the version timestamp.
 	versionBatch := d.db.NewWriteBatchAt(versionToTs(version))
 	defer versionBatch.Cancel()
+	// Metadata batch collects key history index removals.
+	metaBatch := d.db.NewWriteBatchAt(tsMetadata)
+	defer metaBatch.Cancel()
 	// Transaction is used to read at the version timestamp.
 	tx := d.db.NewTransactionAt(versionToTs(version), true)
 	defer tx.Discard()
@@ -619,6 +636,11 @@ func (d *badgerNodeDB) Finalize(ctx context.Context, version uint64, roots []has
 				}(); err != nil {
 					return err
 				}
+
+				// Remove key history for the non-finalized root.
+				if err = removeRootKeyHistory(tx, metaBatch, version, rootHash); err != nil {
+					return err
+				}
 			}
 		}
 
@@ -640,10 +662,13 @@ func (d *badgerNodeDB) Finalize(ctx context.Context, version uint64, roots []has
 		}
 	}
 
-	// Commit batch.
+	// Commit batches.
 	if err := versionBatch.Flush(); err != nil {
 		return err
 	}
+	if err := metaBatch.Flush(); err != nil {
+		return err
+	}
 
 	// Save roots metadata if changed.
 	if rootsChanged {
@@ -745,7 +770,9 @@ func (d *badgerNodeDB) Prune(ctx context.Context, version uint64) error {
 		return fmt.Errorf("mkvs/badger: failed to remove roots metadata: %w", err)
 	}
 
-	// Prune all write logs in version.
+	// Prune all write logs and obsolete key history in version.
+	metaBatch := d.db.NewWriteBatchAt(tsMetadata)
+	defer metaBatch.Cancel()
 	if !d.discardWriteLogs {
 		wtx := d.db.NewTransactionAt(versionToTs(version), false)
 		defer wtx.Discard()
@@ -759,12 +786,19 @@ func (d *badgerNodeDB) Prune(ctx context.Context, version uint64) error {
 				return err
 			}
 		}
+
+		if err := pruneKeyHistory(wtx, metaBatch, rootsMeta); err != nil {
+			return fmt.Errorf("mkvs/badger: failed to prune key history: %w", err)
+		}
 	}
 
-	// Commit batch.
+	// Commit batches.
 	if err := batch.Flush(); err != nil {
 		return fmt.Errorf("mkvs/badger: failed to flush batch: %w", err)
 	}
+	if err := metaBatch.Flush(); err != nil {
+		return fmt.Errorf("mkvs/badger: failed to flush metadata batch: %w", err)
+	}
 
 	// Update metadata.
 	if err := d.meta.setEarliestVersion(tx, version+1); err != nil {
@@ -864,6 +898,13 @@ func (d *badgerNodeDB) Size() (int64, error) {
 	return lsm + vlog, nil
 }
 
+func (d *badgerNodeDB) Compact(ctx context.Context) (int64, error) {
+	if d.readOnly {
+		return 0, api.ErrReadOnly
+	}
+	return d.gc.Trigger(ctx)
+}
+
 func (d *badgerNodeDB) Sync() error {
 	return d.db.Sync()
 }
@@ -981,6 +1022,7 @@ func (ba *badgerBatch) Commit(root node.Root) error {
 		}
 	}
 
+	var historyBatch *badger.WriteBatch
 	if ba.chunk {
 		// Skip most of metadata updates if we are just importing chunks.
 		key := rootUpdatedNodesKeyFmt.Encode(root.Version, &root.Hash)
@@ -1016,7 +1058,7 @@ func (ba *badgerBatch) Commit(root node.Root) error {
 			return fmt.Errorf("mkvs/badger: set returned error: %w", err)
 		}
 
-		// Store write log.
+		// Store write log and update the key history index.
 		if ba.writeLog != nil && ba.annotations != nil {
 			log := api.MakeHashedDBWriteLog(ba.writeLog, ba.annotations)
 			bytes := cbor.Marshal(log)
@@ -1024,6 +1066,12 @@ func (ba *badgerBatch) Commit(root node.Root) error {
 			if err = ba.bat.Set(key, bytes); err != nil {
 				return fmt.Errorf("mkvs/badger: set new write log returned error: %w", err)
 			}
+
+			historyBatch = ba.db.db.NewWriteBatchAt(tsMetadata)
+			defer historyBatch.Cancel()
+			if err = putKeyHistory(historyBatch, root, log); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -1036,6 +1084,11 @@ func (ba *badgerBatch) Commit(root node.Root) error {
 	if err = ba.bat.Flush(); err != nil {
 		return fmt.Errorf("mkvs/badger: failed to flush batch: %w", err)
 	}
+	if historyBatch != nil {
+		if err = historyBatch.Flush(); err != nil {
+			return fmt.Errorf("mkvs/badger: failed to flush key history batch: %w", err)
+		}
+	}
 
 	// Commit root metadata updates. This is done last, so in case we fail, we can still retry.
 	if err = tx.CommitAt(tsMetadata, nil); err != nil {
@@ -325,3 +325,193 @@ func TestReadOnlyBatch(t *testing.T) {
 	_, err = badgerdb.NewBatch(node.Root{}, 13, false)
 	require.Error(err, "NewBatch()")
 }
+
+func TestKeyHistory(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	ndb, err := New(dbCfg)
+	require.NoError(err, "New()")
+	defer ndb.Close()
+
+	key := []byte("history key")
+	steps := []struct {
+		value  []byte
+		remove bool
+	}{
+		{value: testValues[0]},
+		{value: testValues[1]},
+		{remove: true},
+		{value: testValues[2]},
+	}
+
+	var (
+		root  node.Root
+		roots []node.Root
+	)
+	root.Namespace = testNs
+	root.Hash.Empty()
+	for version, step := range steps {
+		tree := mkvs.NewWithRoot(nil, ndb, root)
+		if step.remove {
+			err = tree.Remove(ctx, key)
+			require.NoError(err, "Remove()")
+		} else {
+			err = tree.Insert(ctx, key, step.value)
+			require.NoError(err, "Insert()")
+		}
+		// Also insert an unrelated key to make sure it is not part of the history.
+		if version == 0 {
+			err = tree.Insert(ctx, []byte("other key"), testValues[0])
+			require.NoError(err, "Insert()")
+		}
+
+		_, rootHash, cerr := tree.Commit(ctx, testNs, uint64(version))
+		require.NoError(cerr, "Commit()")
+		tree.Close()
+
+		err = ndb.Finalize(ctx, uint64(version), []hash.Hash{rootHash})
+		require.NoError(err, "Finalize()")
+
+		root = node.Root{Namespace: testNs, Version: uint64(version), Hash: rootHash}
+		roots = append(roots, root)
+	}
+
+	history, err := ndb.GetKeyHistory(ctx, key)
+	require.NoError(err, "GetKeyHistory()")
+	require.Len(history, len(steps), "history should contain all modifications")
+	for version, step := range steps {
+		require.EqualValues(version, history[version].Version, "history entry version")
+		require.Equal(step.remove, history[version].Removed, "history entry removal flag")
+	}
+
+	for version, step := range steps {
+		value, gerr := ndb.GetAt(ctx, roots[version], key)
+		require.NoError(gerr, "GetAt()")
+		require.Equal(step.value, value, "GetAt() should return the correct value")
+	}
+
+	_, err = ndb.GetAt(ctx, node.Root{Namespace: testNs, Version: uint64(len(steps)), Hash: root.Hash}, key)
+	require.Error(err, "GetAt() should fail for non-finalized versions")
+	require.Equal(api.ErrNotFinalized, err)
+
+	_, err = ndb.GetAt(ctx, node.Root{Namespace: testNs, Version: 1, Hash: root.Hash}, key)
+	require.Equal(api.ErrRootNotFound, err, "GetAt() should fail for unknown roots")
+
+	value, err := ndb.GetAt(ctx, roots[0], []byte("missing key"))
+	require.NoError(err, "GetAt()")
+	require.Nil(value, "GetAt() should return nil for missing keys")
+
+	// Prune the first two versions, history of later versions should still be available.
+	err = ndb.Prune(ctx, 0)
+	require.NoError(err, "Prune(0)")
+	err = ndb.Prune(ctx, 1)
+	require.NoError(err, "Prune(1)")
+
+	history, err = ndb.GetKeyHistory(ctx, key)
+	require.NoError(err, "GetKeyHistory()")
+	require.Len(history, 2, "history should only contain retained versions")
+	require.EqualValues(2, history[0].Version)
+	require.EqualValues(3, history[1].Version)
+
+	_, err = ndb.GetAt(ctx, roots[1], key)
+	require.Equal(api.ErrVersionNotFound, err, "GetAt() should fail for pruned versions")
+	value, err = ndb.GetAt(ctx, roots[3], key)
+	require.NoError(err, "GetAt()")
+	require.Equal(testValues[2], value)
+
+	// Values of keys not modified since the pruned versions should still be available.
+	err = ndb.Prune(ctx, 2)
+	require.NoError(err, "Prune(2)")
+	value, err = ndb.GetAt(ctx, roots[3], []byte("other key"))
+	require.NoError(err, "GetAt()")
+	require.Equal(testValues[0], value)
+}
+
+func TestKeyHistoryMultipleRoots(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	ndb, err := New(dbCfg)
+	require.NoError(err, "New()")
+	defer ndb.Close()
+
+	stateKey := []byte("state key")
+	sharedKey := []byte("shared key")
+
+	// Maintain a state tree and a fresh I/O tree in each version, both modifying the same key.
+	var (
+		stateRoot  node.Root
+		stateRoots []node.Root
+		ioRoots    []node.Root
+	)
+	stateRoot.Namespace = testNs
+	stateRoot.Hash.Empty()
+	for version := uint64(0); version < 3; version++ {
+		tree := mkvs.NewWithRoot(nil, ndb, stateRoot)
+		err = tree.Insert(ctx, sharedKey, []byte(fmt.Sprintf("state %d", version)))
+		require.NoError(err, "Insert()")
+		if version == 0 {
+			err = tree.Insert(ctx, stateKey, testValues[0])
+			require.NoError(err, "Insert()")
+		}
+		_, stateHash, cerr := tree.Commit(ctx, testNs, version)
+		require.NoError(cerr, "Commit()")
+		tree.Close()
+
+		tree = mkvs.New(nil, ndb)
+		err = tree.Insert(ctx, sharedKey, []byte(fmt.Sprintf("io %d", version)))
+		require.NoError(err, "Insert()")
+		_, ioHash, cerr := tree.Commit(ctx, testNs, version)
+		require.NoError(cerr, "Commit()")
+		tree.Close()
+
+		err = ndb.Finalize(ctx, version, []hash.Hash{stateHash, ioHash})
+		require.NoError(err, "Finalize()")
+
+		stateRoot = node.Root{Namespace: testNs, Version: version, Hash: stateHash}
+		stateRoots = append(stateRoots, stateRoot)
+		ioRoots = append(ioRoots, node.Root{Namespace: testNs, Version: version, Hash: ioHash})
+	}
+
+	history, err := ndb.GetKeyHistory(ctx, sharedKey)
+	require.NoError(err, "GetKeyHistory()")
+	require.Len(history, 6, "history should contain modifications in all roots")
+
+	for version := range stateRoots {
+		value, gerr := ndb.GetAt(ctx, stateRoots[version], sharedKey)
+		require.NoError(gerr, "GetAt(state)")
+		require.Equal([]byte(fmt.Sprintf("state %d", version)), value, "GetAt() should return the value in the state root")
+
+		value, gerr = ndb.GetAt(ctx, ioRoots[version], sharedKey)
+		require.NoError(gerr, "GetAt(io)")
+		require.Equal([]byte(fmt.Sprintf("io %d", version)), value, "GetAt() should return the value in the I/O root")
+
+		// Keys modified in earlier versions of other trees should not be visible.
+		value, gerr = ndb.GetAt(ctx, ioRoots[version], stateKey)
+		require.NoError(gerr, "GetAt(io)")
+		require.Nil(value, "GetAt() should not return values from other roots")
+
+		value, gerr = ndb.GetAt(ctx, stateRoots[version], stateKey)
+		require.NoError(gerr, "GetAt(state)")
+		require.Equal(testValues[0], value, "GetAt() should return values from earlier versions")
+	}
+
+	// Modifications in pruned versions should only be visible via the tree that continues them.
+	err = ndb.Prune(ctx, 0)
+	require.NoError(err, "Prune(0)")
+	err = ndb.Prune(ctx, 1)
+	require.NoError(err, "Prune(1)")
+
+	value, err := ndb.GetAt(ctx, stateRoots[2], stateKey)
+	require.NoError(err, "GetAt(state)")
+	require.Equal(testValues[0], value, "GetAt() should return values from pruned versions")
+
+	value, err = ndb.GetAt(ctx, ioRoots[2], stateKey)
+	require.NoError(err, "GetAt(io)")
+	require.Nil(value, "GetAt() should not return values from other roots in pruned versions")
+
+	value, err = ndb.GetAt(ctx, ioRoots[2], sharedKey)
+	require.NoError(err, "GetAt(io)")
+	require.Equal([]byte("io 2"), value, "GetAt() should return the value in the I/O root")
+}
@@ -0,0 +1,402 @@
+package badger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+// keyHistoryItem is a decoded key history index entry.
+type keyHistoryItem struct {
+	keyHash  hash.Hash
+	version  uint64
+	rootHash hash.Hash
+}
+
+func (it *keyHistoryItem) delete(batch *badger.WriteBatch) error {
+	if err := batch.Delete(keyHistoryKeyFmt.Encode(&it.keyHash, it.version, &it.rootHash)); err != nil {
+		return err
+	}
+	return batch.Delete(rootKeyHistoryKeyFmt.Encode(it.version, &it.rootHash, &it.keyHash))
+}
+
+// putKeyHistory adds key history index entries for all keys modified by the given write log.
+func putKeyHistory(batch *badger.WriteBatch, root node.Root, log api.HashedDBWriteLog) error {
+	for _, entry := range log {
+		keyHash := hash.NewFromBytes(entry.Key)
+		if err := batch.Set(keyHistoryKeyFmt.Encode(&keyHash, root.Version, &root.Hash), cbor.Marshal(entry.InsertedHash)); err != nil {
+			return fmt.Errorf("mkvs/badger: set key history returned error: %w", err)
+		}
+		if err := batch.Set(rootKeyHistoryKeyFmt.Encode(root.Version, &root.Hash, &keyHash), []byte{}); err != nil {
+			return fmt.Errorf("mkvs/badger: set root key history returned error: %w", err)
+		}
+	}
+	return nil
+}
+
+// removeRootKeyHistory removes all key history index entries for the given root.
+func removeRootKeyHistory(tx *badger.Txn, batch *badger.WriteBatch, version uint64, rootHash hash.Hash) error {
+	it := tx.NewIterator(badger.IteratorOptions{Prefix: rootKeyHistoryKeyFmt.Encode(version, &rootHash)})
+	defer it.Close()
+
+	for it.Rewind(); it.Valid(); it.Next() {
+		var item keyHistoryItem
+		if !rootKeyHistoryKeyFmt.Decode(it.Item().Key(), &item.version, &item.rootHash, &item.keyHash) {
+			// This should not happen as the Badger iterator should take care of it.
+			panic("mkvs/badger: bad iterator")
+		}
+		if err := item.delete(batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneKeyHistory removes key history index entries made obsolete by pruning the version of the
+// given roots metadata.
+//
+// For each key modified in the pruned version, the latest modification at or before the pruned
+// version in a root that has been continued in later versions is retained, so that values of
+// keys that have not changed since can still be resolved. Modifications in roots that have not
+// been continued (e.g., I/O roots) are removed as they cannot be part of any retained tree.
+func pruneKeyHistory(tx *badger.Txn, batch *badger.WriteBatch, rootsMeta *rootsMetadata) error {
+	version := rootsMeta.version
+	it := tx.NewIterator(badger.IteratorOptions{Prefix: rootKeyHistoryKeyFmt.Encode(version)})
+	defer it.Close()
+
+	seen := make(map[hash.Hash]bool)
+	for it.Rewind(); it.Valid(); it.Next() {
+		var decVersion uint64
+		var decRootHash, keyHash hash.Hash
+		if !rootKeyHistoryKeyFmt.Decode(it.Item().Key(), &decVersion, &decRootHash, &keyHash) {
+			// This should not happen as the Badger iterator should take care of it.
+			panic("mkvs/badger: bad iterator")
+		}
+		if seen[keyHash] {
+			continue
+		}
+		seen[keyHash] = true
+
+		items, err := loadKeyHistory(tx, keyHash)
+		if err != nil {
+			return err
+		}
+
+		// Entries from previously pruned versions have only been retained in case their roots
+		// were continued.
+		var (
+			obsolete []*keyHistoryItem
+			retained *keyHistoryItem
+		)
+		for _, item := range items {
+			if item.version > version {
+				break
+			}
+			obsolete = append(obsolete, item)
+			if item.version < version || len(rootsMeta.Roots[item.rootHash]) > 0 {
+				retained = item
+			}
+		}
+		for _, item := range obsolete {
+			if item == retained {
+				continue
+			}
+			if err = item.delete(batch); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// lineageRoot is a root that is part of a root lineage.
+type lineageRoot struct {
+	version  uint64
+	rootHash hash.Hash
+}
+
+// rootLineage is the lineage of a root, i.e. the root and all the (retained) roots it has been
+// derived from. The lineage is resolved lazily, going back in versions.
+type rootLineage struct {
+	db              *badgerNodeDB
+	tx              *badger.Txn
+	earliestVersion uint64
+
+	// roots is the set of resolved roots of the lineage.
+	roots map[lineageRoot]bool
+	// oldest is the oldest resolved root of the lineage.
+	oldest node.Root
+	// searchVersion is the highest version which has not yet been searched for the root that the
+	// oldest resolved root has been derived from.
+	searchVersion uint64
+	// complete is true iff the oldest resolved root has not been derived from any retained root.
+	complete bool
+}
+
+func newRootLineage(db *badgerNodeDB, tx *badger.Txn, root node.Root, earliestVersion uint64) *rootLineage {
+	return &rootLineage{
+		db:              db,
+		tx:              tx,
+		earliestVersion: earliestVersion,
+		roots:           map[lineageRoot]bool{{root.Version, root.Hash}: true},
+		oldest:          root,
+		searchVersion:   root.Version,
+	}
+}
+
+// resolve resolves all roots of the lineage at or after the given version.
+func (l *rootLineage) resolve(version uint64) error {
+	for !l.complete && l.searchVersion >= version {
+		rootsMeta, err := loadRootsMetadata(l.tx, l.searchVersion)
+		if err != nil {
+			return err
+		}
+
+		var (
+			prevHash hash.Hash
+			found    bool
+		)
+	RootsLoop:
+		for rootHash, derivedRoots := range rootsMeta.Roots {
+			if l.searchVersion == l.oldest.Version && rootHash.Equal(&l.oldest.Hash) {
+				continue
+			}
+			for _, derivedRoot := range derivedRoots {
+				if derivedRoot.Equal(&l.oldest.Hash) {
+					prevHash = rootHash
+					found = true
+					break RootsLoop
+				}
+			}
+		}
+
+		switch {
+		case found:
+			l.oldest = node.Root{Namespace: l.oldest.Namespace, Version: l.searchVersion, Hash: prevHash}
+			l.roots[lineageRoot{l.oldest.Version, l.oldest.Hash}] = true
+		case l.searchVersion <= l.earliestVersion:
+			l.complete = true
+		default:
+			l.searchVersion--
+		}
+	}
+	return nil
+}
+
+// contains checks whether the given root is part of the lineage.
+func (l *rootLineage) contains(version uint64, rootHash hash.Hash) (bool, error) {
+	if err := l.resolve(version); err != nil {
+		return false, err
+	}
+	return l.roots[lineageRoot{version, rootHash}], nil
+}
+
+// continuesInPrunedVersions checks whether the lineage continues in pruned versions, i.e. whether
+// its oldest retained root has been derived from a root that is no longer available.
+func (l *rootLineage) continuesInPrunedVersions() (bool, error) {
+	if err := l.resolve(l.earliestVersion); err != nil {
+		return false, err
+	}
+	if l.oldest.Version != l.earliestVersion {
+		return false, nil
+	}
+
+	// Roots metadata of pruned versions is gone, but write logs still reference the old root.
+	tx := l.db.db.NewTransactionAt(versionToTs(l.oldest.Version), false)
+	defer tx.Discard()
+
+	it := tx.NewIterator(badger.IteratorOptions{Prefix: writeLogKeyFmt.Encode(l.oldest.Version, &l.oldest.Hash)})
+	defer it.Close()
+
+	for it.Rewind(); it.Valid(); it.Next() {
+		var (
+			decVersion                       uint64
+			decEndRootHash, decStartRootHash hash.Hash
+		)
+		if !writeLogKeyFmt.Decode(it.Item().Key(), &decVersion, &decEndRootHash, &decStartRootHash) {
+			// This should not happen as the Badger iterator should take care of it.
+			panic("mkvs/badger: bad iterator")
+		}
+		if !decStartRootHash.IsEmpty() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// loadKeyHistory loads all key history index entries for the given key hash, ordered by version.
+func loadKeyHistory(tx *badger.Txn, keyHash hash.Hash) ([]*keyHistoryItem, error) {
+	it := tx.NewIterator(badger.IteratorOptions{Prefix: keyHistoryKeyFmt.Encode(&keyHash)})
+	defer it.Close()
+
+	var items []*keyHistoryItem
+	for it.Rewind(); it.Valid(); it.Next() {
+		var item keyHistoryItem
+		if !keyHistoryKeyFmt.Decode(it.Item().Key(), &item.keyHash, &item.version, &item.rootHash) {
+			// This should not happen as the Badger iterator should take care of it.
+			panic("mkvs/badger: bad iterator")
+		}
+		items = append(items, &item)
+	}
+	return items, nil
+}
+
+func (d *badgerNodeDB) GetKeyHistory(ctx context.Context, key []byte) ([]api.KeyHistoryEntry, error) {
+	if d.discardWriteLogs {
+		return nil, api.ErrKeyHistoryUnavailable
+	}
+
+	lastFinalizedVersion, exists := d.meta.getLastFinalizedVersion()
+	if !exists {
+		return nil, nil
+	}
+	earliestVersion := d.meta.getEarliestVersion()
+
+	tx := d.db.NewTransactionAt(tsMetadata, false)
+	defer tx.Discard()
+
+	keyHash := hash.NewFromBytes(key)
+	it := tx.NewIterator(badger.IteratorOptions{Prefix: keyHistoryKeyFmt.Encode(&keyHash)})
+	defer it.Close()
+
+	var history []api.KeyHistoryEntry
+	for it.Rewind(); it.Valid(); it.Next() {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		var item keyHistoryItem
+		if !keyHistoryKeyFmt.Decode(it.Item().Key(), &item.keyHash, &item.version, &item.rootHash) {
+			// This should not happen as the Badger iterator should take care of it.
+			panic("mkvs/badger: bad iterator")
+		}
+		if item.version < earliestVersion {
+			continue
+		}
+		if item.version > lastFinalizedVersion {
+			break
+		}
+
+		var leafHash *hash.Hash
+		if err := it.Item().Value(func(data []byte) error {
+			return cbor.UnmarshalTrusted(data, &leafHash)
+		}); err != nil {
+			return nil, fmt.Errorf("mkvs/badger: corrupted key history index: %w", err)
+		}
+
+		history = append(history, api.KeyHistoryEntry{
+			Version: item.version,
+			Root:    item.rootHash,
+			Removed: leafHash == nil,
+		})
+	}
+	return history, nil
+}
+
+func (d *badgerNodeDB) GetAt(ctx context.Context, root node.Root, key []byte) ([]byte, error) {
+	if d.discardWriteLogs {
+		return nil, api.ErrKeyHistoryUnavailable
+	}
+	if err := d.sanityCheckNamespace(root.Namespace); err != nil {
+		return nil, err
+	}
+	earliestVersion := d.meta.getEarliestVersion()
+	if root.Version < earliestVersion {
+		return nil, api.ErrVersionNotFound
+	}
+	lastFinalizedVersion, exists := d.meta.getLastFinalizedVersion()
+	if !exists || root.Version > lastFinalizedVersion {
+		return nil, api.ErrNotFinalized
+	}
+	if root.Hash.IsEmpty() {
+		return nil, nil
+	}
+	if !d.HasRoot(root) {
+		return nil, api.ErrRootNotFound
+	}
+
+	tx := d.db.NewTransactionAt(tsMetadata, false)
+	defer tx.Discard()
+
+	// Find the latest modification at or before the given version in the lineage of the given
+	// root, as other roots (e.g., I/O roots) under the same versions belong to different trees.
+	version := root.Version
+	lineage := newRootLineage(d, tx, root, earliestVersion)
+	keyHash := hash.NewFromBytes(key)
+	opts := badger.IteratorOptions{
+		Prefix:  keyHistoryKeyFmt.Encode(&keyHash),
+		Reverse: true,
+	}
+	it := tx.NewIterator(opts)
+	defer it.Close()
+
+	// Reverse iteration needs to seek past all entries at the given version.
+	var maxHash hash.Hash
+	for i := range maxHash {
+		maxHash[i] = 0xff
+	}
+	for it.Seek(keyHistoryKeyFmt.Encode(&keyHash, version, &maxHash)); it.Valid(); it.Next() {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		var item keyHistoryItem
+		if !keyHistoryKeyFmt.Decode(it.Item().Key(), &item.keyHash, &item.version, &item.rootHash) {
+			// This should not happen as the Badger iterator should take care of it.
+			panic("mkvs/badger: bad iterator")
+		}
+		if item.version > version {
+			continue
+		}
+
+		var (
+			inLineage bool
+			err       error
+		)
+		leafRoot := node.Root{Namespace: d.namespace, Version: item.version, Hash: item.rootHash}
+		switch {
+		case item.version < earliestVersion:
+			// The lineage in pruned versions is no longer known, but only modifications in roots
+			// that have been continued are retained (see pruneKeyHistory). The leaf is resolved
+			// via the oldest retained root of the lineage as the pruned root is gone.
+			inLineage, err = lineage.continuesInPrunedVersions()
+			leafRoot = lineage.oldest
+		default:
+			inLineage, err = lineage.contains(item.version, item.rootHash)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !inLineage {
+			continue
+		}
+
+		var leafHash *hash.Hash
+		if err = it.Item().Value(func(data []byte) error {
+			return cbor.UnmarshalTrusted(data, &leafHash)
+		}); err != nil {
+			return nil, fmt.Errorf("mkvs/badger: corrupted key history index: %w", err)
+		}
+		if leafHash == nil {
+			// Key was removed.
+			return nil, nil
+		}
+
+		n, err := d.GetNode(leafRoot, &node.Pointer{Hash: *leafHash, Clean: true})
+		if err != nil {
+			return nil, err
+		}
+		leaf, ok := n.(*node.LeafNode)
+		if !ok {
+			return nil, fmt.Errorf("mkvs/badger: corrupted key history index: not a leaf node")
+		}
+		return leaf.Value, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,12 @@
+// Package rocksdb provides a RocksDB-backed node database.
+//
+// The RocksDB backend requires cgo and a system RocksDB library and is only available when
+// building with the rocksdb build tag.
+package rocksdb
+
+const (
+	// CompactionStyleLevel is the level compaction style.
+	CompactionStyleLevel = "level"
+	// CompactionStyleUniversal is the universal compaction style.
+	CompactionStyleUniversal = "universal"
+)
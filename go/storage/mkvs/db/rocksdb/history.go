@@ -0,0 +1,378 @@
+// +build rocksdb
+
+package rocksdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tecbot/gorocksdb"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+// keyHistoryItem is a decoded key history index entry.
+type keyHistoryItem struct {
+	keyHash  hash.Hash
+	version  uint64
+	rootHash hash.Hash
+}
+
+func (it *keyHistoryItem) delete(batch *gorocksdb.WriteBatch) {
+	batch.Delete(keyHistoryKeyFmt.Encode(&it.keyHash, it.version, &it.rootHash))
+	batch.Delete(rootKeyHistoryKeyFmt.Encode(it.version, &it.rootHash, &it.keyHash))
+}
+
+// putKeyHistory adds key history index entries for all keys modified by the given write log.
+func putKeyHistory(batch *gorocksdb.WriteBatch, root node.Root, log api.HashedDBWriteLog) {
+	for _, entry := range log {
+		keyHash := hash.NewFromBytes(entry.Key)
+		batch.Put(keyHistoryKeyFmt.Encode(&keyHash, root.Version, &root.Hash), cbor.Marshal(entry.InsertedHash))
+		batch.Put(rootKeyHistoryKeyFmt.Encode(root.Version, &root.Hash, &keyHash), []byte{})
+	}
+}
+
+// removeRootKeyHistory removes all key history index entries for the given root.
+func (d *rocksdbNodeDB) removeRootKeyHistory(batch *gorocksdb.WriteBatch, version uint64, rootHash hash.Hash) error {
+	return d.iterate(rootKeyHistoryKeyFmt.Encode(version, &rootHash), func(key, value []byte) error {
+		var item keyHistoryItem
+		if !rootKeyHistoryKeyFmt.Decode(key, &item.version, &item.rootHash, &item.keyHash) {
+			// This should not happen as the prefix iteration should take care of it.
+			panic("mkvs/rocksdb: bad iterator")
+		}
+		item.delete(batch)
+		return nil
+	})
+}
+
+// pruneKeyHistory removes key history index entries made obsolete by pruning the version of the
+// given roots metadata.
+//
+// For each key modified in the pruned version, the latest modification at or before the pruned
+// version in a root that has been continued in later versions is retained, so that values of
+// keys that have not changed since can still be resolved. Modifications in roots that have not
+// been continued (e.g., I/O roots) are removed as they cannot be part of any retained tree.
+func (d *rocksdbNodeDB) pruneKeyHistory(batch *gorocksdb.WriteBatch, rootsMeta *rootsMetadata) error {
+	version := rootsMeta.version
+	seen := make(map[hash.Hash]bool)
+	return d.iterate(rootKeyHistoryKeyFmt.Encode(version), func(key, value []byte) error {
+		var decVersion uint64
+		var decRootHash, keyHash hash.Hash
+		if !rootKeyHistoryKeyFmt.Decode(key, &decVersion, &decRootHash, &keyHash) {
+			// This should not happen as the prefix iteration should take care of it.
+			panic("mkvs/rocksdb: bad iterator")
+		}
+		if seen[keyHash] {
+			return nil
+		}
+		seen[keyHash] = true
+
+		items, err := d.loadKeyHistory(keyHash)
+		if err != nil {
+			return err
+		}
+
+		// Entries from previously pruned versions have only been retained in case their roots
+		// were continued.
+		var (
+			obsolete []*keyHistoryItem
+			retained *keyHistoryItem
+		)
+		for _, item := range items {
+			if item.version > version {
+				break
+			}
+			obsolete = append(obsolete, item)
+			if item.version < version || len(rootsMeta.Roots[item.rootHash]) > 0 {
+				retained = item
+			}
+		}
+		for _, item := range obsolete {
+			if item == retained {
+				continue
+			}
+			item.delete(batch)
+		}
+		return nil
+	})
+}
+
+// lineageRoot is a root that is part of a root lineage.
+type lineageRoot struct {
+	version  uint64
+	rootHash hash.Hash
+}
+
+// rootLineage is the lineage of a root, i.e. the root and all the (retained) roots it has been
+// derived from. The lineage is resolved lazily, going back in versions.
+type rootLineage struct {
+	db              *rocksdbNodeDB
+	earliestVersion uint64
+
+	// roots is the set of resolved roots of the lineage.
+	roots map[lineageRoot]bool
+	// oldest is the oldest resolved root of the lineage.
+	oldest node.Root
+	// searchVersion is the highest version which has not yet been searched for the root that the
+	// oldest resolved root has been derived from.
+	searchVersion uint64
+	// complete is true iff the oldest resolved root has not been derived from any retained root.
+	complete bool
+}
+
+func newRootLineage(db *rocksdbNodeDB, root node.Root, earliestVersion uint64) *rootLineage {
+	return &rootLineage{
+		db:              db,
+		earliestVersion: earliestVersion,
+		roots:           map[lineageRoot]bool{{root.Version, root.Hash}: true},
+		oldest:          root,
+		searchVersion:   root.Version,
+	}
+}
+
+// resolve resolves all roots of the lineage at or after the given version.
+func (l *rootLineage) resolve(version uint64) error {
+	for !l.complete && l.searchVersion >= version {
+		rootsMeta, err := l.db.loadRootsMetadata(l.searchVersion)
+		if err != nil {
+			return err
+		}
+
+		var (
+			prevHash hash.Hash
+			found    bool
+		)
+	RootsLoop:
+		for rootHash, derivedRoots := range rootsMeta.Roots {
+			if l.searchVersion == l.oldest.Version && rootHash.Equal(&l.oldest.Hash) {
+				continue
+			}
+			for _, derivedRoot := range derivedRoots {
+				if derivedRoot.Equal(&l.oldest.Hash) {
+					prevHash = rootHash
+					found = true
+					break RootsLoop
+				}
+			}
+		}
+
+		switch {
+		case found:
+			l.oldest = node.Root{Namespace: l.oldest.Namespace, Version: l.searchVersion, Hash: prevHash}
+			l.roots[lineageRoot{l.oldest.Version, l.oldest.Hash}] = true
+		case l.searchVersion <= l.earliestVersion:
+			l.complete = true
+		default:
+			l.searchVersion--
+		}
+	}
+	return nil
+}
+
+// contains checks whether the given root is part of the lineage.
+func (l *rootLineage) contains(version uint64, rootHash hash.Hash) (bool, error) {
+	if err := l.resolve(version); err != nil {
+		return false, err
+	}
+	return l.roots[lineageRoot{version, rootHash}], nil
+}
+
+// continuesInPrunedVersions checks whether the lineage continues in pruned versions, i.e. whether
+// its oldest retained root has been derived from a root that is no longer available.
+func (l *rootLineage) continuesInPrunedVersions() (bool, error) {
+	if err := l.resolve(l.earliestVersion); err != nil {
+		return false, err
+	}
+	if l.oldest.Version != l.earliestVersion {
+		return false, nil
+	}
+
+	// Roots metadata of pruned versions is gone, but write logs still reference the old root.
+	var continues bool
+	err := l.db.iterate(writeLogKeyFmt.Encode(l.oldest.Version, &l.oldest.Hash), func(key, value []byte) error {
+		var (
+			decVersion                       uint64
+			decEndRootHash, decStartRootHash hash.Hash
+		)
+		if !writeLogKeyFmt.Decode(key, &decVersion, &decEndRootHash, &decStartRootHash) {
+			// This should not happen as the prefix iteration should take care of it.
+			panic("mkvs/rocksdb: bad iterator")
+		}
+		if !decStartRootHash.IsEmpty() {
+			continues = true
+			return errStopIteration
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return continues, nil
+}
+
+// loadKeyHistory loads all key history index entries for the given key hash, ordered by version.
+func (d *rocksdbNodeDB) loadKeyHistory(keyHash hash.Hash) ([]*keyHistoryItem, error) {
+	var items []*keyHistoryItem
+	err := d.iterate(keyHistoryKeyFmt.Encode(&keyHash), func(key, value []byte) error {
+		var item keyHistoryItem
+		if !keyHistoryKeyFmt.Decode(key, &item.keyHash, &item.version, &item.rootHash) {
+			// This should not happen as the prefix iteration should take care of it.
+			panic("mkvs/rocksdb: bad iterator")
+		}
+		items = append(items, &item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (d *rocksdbNodeDB) GetKeyHistory(ctx context.Context, key []byte) ([]api.KeyHistoryEntry, error) {
+	if d.discardWriteLogs {
+		return nil, api.ErrKeyHistoryUnavailable
+	}
+
+	lastFinalizedVersion, exists := d.meta.getLastFinalizedVersion()
+	if !exists {
+		return nil, nil
+	}
+	earliestVersion := d.meta.getEarliestVersion()
+
+	keyHash := hash.NewFromBytes(key)
+	var history []api.KeyHistoryEntry
+	err := d.iterate(keyHistoryKeyFmt.Encode(&keyHash), func(key, value []byte) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		var item keyHistoryItem
+		if !keyHistoryKeyFmt.Decode(key, &item.keyHash, &item.version, &item.rootHash) {
+			// This should not happen as the prefix iteration should take care of it.
+			panic("mkvs/rocksdb: bad iterator")
+		}
+		if item.version < earliestVersion {
+			return nil
+		}
+		if item.version > lastFinalizedVersion {
+			return errStopIteration
+		}
+
+		var leafHash *hash.Hash
+		if err := cbor.UnmarshalTrusted(value, &leafHash); err != nil {
+			return fmt.Errorf("mkvs/rocksdb: corrupted key history index: %w", err)
+		}
+
+		history = append(history, api.KeyHistoryEntry{
+			Version: item.version,
+			Root:    item.rootHash,
+			Removed: leafHash == nil,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+func (d *rocksdbNodeDB) GetAt(ctx context.Context, root node.Root, key []byte) ([]byte, error) {
+	if d.discardWriteLogs {
+		return nil, api.ErrKeyHistoryUnavailable
+	}
+	if err := d.sanityCheckNamespace(root.Namespace); err != nil {
+		return nil, err
+	}
+	earliestVersion := d.meta.getEarliestVersion()
+	if root.Version < earliestVersion {
+		return nil, api.ErrVersionNotFound
+	}
+	lastFinalizedVersion, exists := d.meta.getLastFinalizedVersion()
+	if !exists || root.Version > lastFinalizedVersion {
+		return nil, api.ErrNotFinalized
+	}
+	if root.Hash.IsEmpty() {
+		return nil, nil
+	}
+	if !d.HasRoot(root) {
+		return nil, api.ErrRootNotFound
+	}
+
+	// Find the latest modification at or before the given version in the lineage of the given
+	// root, as other roots (e.g., I/O roots) under the same versions belong to different trees.
+	version := root.Version
+	lineage := newRootLineage(d, root, earliestVersion)
+	keyHash := hash.NewFromBytes(key)
+	prefix := keyHistoryKeyFmt.Encode(&keyHash)
+
+	it := d.db.NewIterator(d.readOpts)
+	defer it.Close()
+
+	// Reverse iteration needs to seek past all entries at the given version.
+	var maxHash hash.Hash
+	for i := range maxHash {
+		maxHash[i] = 0xff
+	}
+	for it.SeekForPrev(keyHistoryKeyFmt.Encode(&keyHash, version, &maxHash)); it.ValidForPrefix(prefix); it.Prev() {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		var item keyHistoryItem
+		if !keyHistoryKeyFmt.Decode(copySlice(it.Key()), &item.keyHash, &item.version, &item.rootHash) {
+			// This should not happen as the prefix iteration should take care of it.
+			panic("mkvs/rocksdb: bad iterator")
+		}
+		if item.version > version {
+			continue
+		}
+
+		var (
+			inLineage bool
+			err       error
+		)
+		leafRoot := node.Root{Namespace: d.namespace, Version: item.version, Hash: item.rootHash}
+		switch {
+		case item.version < earliestVersion:
+			// The lineage in pruned versions is no longer known, but only modifications in roots
+			// that have been continued are retained (see pruneKeyHistory). The leaf is resolved
+			// via the oldest retained root of the lineage as the pruned root is gone.
+			inLineage, err = lineage.continuesInPrunedVersions()
+			leafRoot = lineage.oldest
+		default:
+			inLineage, err = lineage.contains(item.version, item.rootHash)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !inLineage {
+			continue
+		}
+
+		var leafHash *hash.Hash
+		if err = cbor.UnmarshalTrusted(copySlice(it.Value()), &leafHash); err != nil {
+			return nil, fmt.Errorf("mkvs/rocksdb: corrupted key history index: %w", err)
+		}
+		if leafHash == nil {
+			// Key was removed.
+			return nil, nil
+		}
+
+		n, err := d.GetNode(leafRoot, &node.Pointer{Hash: *leafHash, Clean: true})
+		if err != nil {
+			return nil, err
+		}
+		leaf, ok := n.(*node.LeafNode)
+		if !ok {
+			return nil, fmt.Errorf("mkvs/rocksdb: corrupted key history index: not a leaf node")
+		}
+		return leaf.Value, nil
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("mkvs/rocksdb: failed to iterate key history index: %w", err)
+	}
+	return nil, nil
+}
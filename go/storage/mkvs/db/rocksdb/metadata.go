@@ -0,0 +1,146 @@
+// +build rocksdb
+
+package rocksdb
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/tecbot/gorocksdb"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+)
+
+// serializedMetadata is the on-disk serialized metadata.
+type serializedMetadata struct {
+	// Version is the database schema version.
+	Version uint64 `json:"version"`
+	// Namespace is the namespace this database is for.
+	Namespace common.Namespace `json:"namespace"`
+
+	// EarliestVersion is the earliest version.
+	EarliestVersion uint64 `json:"earliest_version"`
+	// LastFinalizedVersion is the last finalized version.
+	LastFinalizedVersion *uint64 `json:"last_finalized_version"`
+	// MultipartVersion is the version for the in-progress multipart restore, or 0 if none was in progress.
+	MultipartVersion uint64 `json:"multipart_version"`
+}
+
+// metadata is the database metadata.
+type metadata struct {
+	sync.RWMutex
+
+	value serializedMetadata
+}
+
+func (m *metadata) getEarliestVersion() uint64 {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.value.EarliestVersion
+}
+
+func (m *metadata) setEarliestVersion(batch *gorocksdb.WriteBatch, version uint64) {
+	m.Lock()
+	defer m.Unlock()
+
+	// The earliest version can only increase, not decrease.
+	if version < m.value.EarliestVersion {
+		return
+	}
+
+	m.value.EarliestVersion = version
+	m.save(batch)
+}
+
+func (m *metadata) getLastFinalizedVersion() (uint64, bool) {
+	m.RLock()
+	defer m.RUnlock()
+
+	if m.value.LastFinalizedVersion == nil {
+		return 0, false
+	}
+	return *m.value.LastFinalizedVersion, true
+}
+
+func (m *metadata) setLastFinalizedVersion(batch *gorocksdb.WriteBatch, version uint64) {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.value.LastFinalizedVersion != nil && version <= *m.value.LastFinalizedVersion {
+		return
+	}
+
+	if m.value.LastFinalizedVersion == nil {
+		m.value.EarliestVersion = version
+	}
+
+	m.value.LastFinalizedVersion = &version
+	m.save(batch)
+}
+
+func (m *metadata) getMultipartVersion() uint64 {
+	m.Lock()
+	defer m.Unlock()
+
+	return m.value.MultipartVersion
+}
+
+func (m *metadata) setMultipartVersion(batch *gorocksdb.WriteBatch, version uint64) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.value.MultipartVersion = version
+	m.save(batch)
+}
+
+func (m *metadata) save(batch *gorocksdb.WriteBatch) {
+	batch.Put(metadataKeyFmt.Encode(), cbor.Marshal(m.value))
+}
+
+// updatedNode is an element of the root updated nodes key.
+//
+// NOTE: Public fields of this structure are part of the on-disk format.
+type updatedNode struct {
+	_ struct{} `cbor:",toarray"` // nolint
+
+	Removed bool
+	Hash    hash.Hash
+}
+
+// rootsMetadata manages the roots metadata for a given version.
+//
+// NOTE: Public fields of this structure are part of the on-disk format.
+type rootsMetadata struct {
+	_ struct{} `cbor:",toarray"`
+
+	// Roots is the map of a root created in a version to any derived roots (in this or later versions).
+	Roots map[hash.Hash][]hash.Hash
+
+	// version is the version this metadata is for.
+	version uint64
+}
+
+// loadRootsMetadata loads the roots metadata for the given version from the database.
+func (d *rocksdbNodeDB) loadRootsMetadata(version uint64) (*rootsMetadata, error) {
+	rootsMeta := &rootsMetadata{version: version}
+	data, err := d.db.GetBytes(d.readOpts, rootsMetadataKeyFmt.Encode(version))
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("mkvs/rocksdb: error reading roots metadata: %w", err)
+	case data == nil:
+		rootsMeta.Roots = make(map[hash.Hash][]hash.Hash)
+	default:
+		if err = cbor.Unmarshal(data, &rootsMeta); err != nil {
+			return nil, fmt.Errorf("mkvs/rocksdb: error reading roots metadata: %w", err)
+		}
+	}
+	return rootsMeta, nil
+}
+
+// save saves the roots metadata to the given batch.
+func (rm *rootsMetadata) save(batch *gorocksdb.WriteBatch) {
+	batch.Put(rootsMetadataKeyFmt.Encode(rm.version), cbor.Marshal(rm))
+}
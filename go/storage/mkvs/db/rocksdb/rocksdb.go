@@ -0,0 +1,1121 @@
+// +build rocksdb
+
+package rocksdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/tecbot/gorocksdb"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
+)
+
+const (
+	dbVersion = 1
+
+	// multipartVersionNone is the value used for the multipart version in metadata
+	// when no multipart restore is in progress.
+	multipartVersionNone uint64 = 0
+)
+
+var (
+	// nodeKeyFmt is the key format for nodes (node hash).
+	//
+	// Value is serialized node.
+	nodeKeyFmt = keyformat.New(0x00, &hash.Hash{})
+	// writeLogKeyFmt is the key format for write logs (version, new root,
+	// old root).
+	//
+	// Value is CBOR-serialized write log.
+	writeLogKeyFmt = keyformat.New(0x01, uint64(0), &hash.Hash{}, &hash.Hash{})
+	// rootsMetadataKeyFmt is the key format for roots metadata. The key format is (version).
+	//
+	// Value is CBOR-serialized rootsMetadata.
+	rootsMetadataKeyFmt = keyformat.New(0x02, uint64(0))
+	// rootUpdatedNodesKeyFmt is the key format for the pending updated nodes for the
+	// given root that need to be removed only in case the given root is not among
+	// the finalized roots. They key format is (version, root).
+	//
+	// Value is CBOR-serialized []updatedNode.
+	rootUpdatedNodesKeyFmt = keyformat.New(0x03, uint64(0), &hash.Hash{})
+	// metadataKeyFmt is the key format for metadata.
+	//
+	// Value is CBOR-serialized metadata.
+	metadataKeyFmt = keyformat.New(0x04)
+	// multipartRestoreNodeLogKeyFmt is the key format for the nodes inserted during a chunk restore.
+	// Once a set of chunks is fully restored, these entries should be removed. If chunk restoration
+	// is interrupted for any reason, the nodes associated with these keys should be removed, along
+	// with these entries.
+	//
+	// Value is empty.
+	multipartRestoreNodeLogKeyFmt = keyformat.New(0x05, &hash.Hash{})
+	// keyHistoryKeyFmt is the key format for the key history index (key hash, version, root).
+	//
+	// Value is CBOR-serialized hash of the inserted leaf node or nil if the key was removed.
+	keyHistoryKeyFmt = keyformat.New(0x06, &hash.Hash{}, uint64(0), &hash.Hash{})
+	// rootKeyHistoryKeyFmt is the key format for the reverse key history index used to
+	// discover index entries belonging to a given root (version, root, key hash).
+	//
+	// Value is empty.
+	rootKeyHistoryKeyFmt = keyformat.New(0x07, uint64(0), &hash.Hash{}, &hash.Hash{})
+	// removedNodesKeyFmt is the key format for nodes removed by finalized roots in the given
+	// version (version, node hash). As RocksDB does not keep multiple versions of the same key,
+	// such nodes are only deleted once the previous version gets pruned.
+	//
+	// Value is empty.
+	removedNodesKeyFmt = keyformat.New(0x08, uint64(0), &hash.Hash{})
+)
+
+// errStopIteration is the error used to stop iteration early.
+var errStopIteration = errors.New("mkvs/rocksdb: stop iteration")
+
+// New creates a new RocksDB-backed node database.
+func New(cfg *api.Config) (api.NodeDB, error) {
+	db := &rocksdbNodeDB{
+		logger:           logging.GetLogger("mkvs/db/rocksdb"),
+		namespace:        cfg.Namespace,
+		readOnly:         cfg.ReadOnly,
+		discardWriteLogs: cfg.DiscardWriteLogs,
+	}
+
+	blockCacheSize := cfg.RocksDB.BlockCacheSize
+	if blockCacheSize == 0 {
+		blockCacheSize = cfg.MaxCacheSize
+	}
+	db.cache = gorocksdb.NewLRUCache(uint64(blockCacheSize))
+	db.tableOpts = gorocksdb.NewDefaultBlockBasedTableOptions()
+	db.tableOpts.SetBlockCache(db.cache)
+
+	db.opts = gorocksdb.NewDefaultOptions()
+	db.opts.SetCreateIfMissing(true)
+	db.opts.SetCompression(gorocksdb.SnappyCompression)
+	db.opts.SetBlockBasedTableFactory(db.tableOpts)
+	switch cfg.RocksDB.CompactionStyle {
+	case "", CompactionStyleLevel:
+		db.opts.SetCompactionStyle(gorocksdb.LevelCompactionStyle)
+	case CompactionStyleUniversal:
+		db.opts.SetCompactionStyle(gorocksdb.UniversalCompactionStyle)
+	default:
+		db.destroyOptions()
+		return nil, fmt.Errorf("mkvs/rocksdb: unsupported compaction style: '%s'", cfg.RocksDB.CompactionStyle)
+	}
+
+	if cfg.MemoryOnly {
+		db.logger.Warn("using memory-only mode, data will not be persisted")
+		db.env = gorocksdb.NewMemEnv()
+		db.opts.SetEnv(db.env)
+	}
+
+	db.readOpts = gorocksdb.NewDefaultReadOptions()
+	db.writeOpts = gorocksdb.NewDefaultWriteOptions()
+	db.writeOpts.SetSync(!cfg.NoFsync)
+
+	var err error
+	if cfg.ReadOnly {
+		db.db, err = gorocksdb.OpenDbForReadOnly(db.opts, cfg.DB, false)
+	} else {
+		db.db, err = gorocksdb.OpenDb(db.opts, cfg.DB)
+	}
+	if err != nil {
+		db.destroyOptions()
+		return nil, fmt.Errorf("mkvs/rocksdb: failed to open database: %w", err)
+	}
+
+	// Load database metadata.
+	if err = db.load(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("mkvs/rocksdb: failed to load metadata: %w", err)
+	}
+
+	// Cleanup any multipart restore remnants, since they can't be used anymore.
+	if err = db.cleanMultipartLocked(true); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("mkvs/rocksdb: failed to clean leftovers from multipart restore: %w", err)
+	}
+
+	return db, nil
+}
+
+type rocksdbNodeDB struct { // nolint: maligned
+	logger *logging.Logger
+
+	namespace common.Namespace
+
+	readOnly         bool
+	discardWriteLogs bool
+
+	multipartVersion uint64
+
+	db        *gorocksdb.DB
+	opts      *gorocksdb.Options
+	tableOpts *gorocksdb.BlockBasedTableOptions
+	cache     *gorocksdb.Cache
+	env       *gorocksdb.Env
+	readOpts  *gorocksdb.ReadOptions
+	writeOpts *gorocksdb.WriteOptions
+
+	// metaUpdateLock must be held at any point where metadata is read and updated. This is
+	// required because RocksDB does not provide transactions that could detect conflicts.
+	metaUpdateLock sync.Mutex
+	meta           metadata
+
+	closeOnce sync.Once
+}
+
+func (d *rocksdbNodeDB) load() error {
+	// Load metadata.
+	data, err := d.db.GetBytes(d.readOpts, metadataKeyFmt.Encode())
+	switch {
+	case err != nil:
+		return err
+	case data != nil:
+		// Metadata already exists, just load it and verify that it is
+		// compatible with what we have here.
+		if err = cbor.UnmarshalTrusted(data, &d.meta.value); err != nil {
+			return err
+		}
+
+		if d.meta.value.Version != dbVersion {
+			return fmt.Errorf("incompatible database version (expected: %d got: %d)",
+				dbVersion,
+				d.meta.value.Version,
+			)
+		}
+		if !d.meta.value.Namespace.Equal(&d.namespace) {
+			return fmt.Errorf("incompatible namespace (expected: %s got: %s)",
+				d.namespace,
+				d.meta.value.Namespace,
+			)
+		}
+		return nil
+	case d.readOnly:
+		return fmt.Errorf("missing metadata in read-only database")
+	default:
+	}
+
+	// No metadata exists, create some.
+	d.meta.value.Version = dbVersion
+	d.meta.value.Namespace = d.namespace
+
+	batch := gorocksdb.NewWriteBatch()
+	defer batch.Destroy()
+	d.meta.save(batch)
+
+	return d.db.Write(d.writeOpts, batch)
+}
+
+func (d *rocksdbNodeDB) sanityCheckNamespace(ns common.Namespace) error {
+	if !ns.Equal(&d.namespace) {
+		return api.ErrBadNamespace
+	}
+	return nil
+}
+
+// iterate calls the given function for each key-value pair with the given prefix, in key order.
+//
+// Returning errStopIteration from the function stops the iteration without an error.
+func (d *rocksdbNodeDB) iterate(prefix []byte, fn func(key, value []byte) error) error {
+	it := d.db.NewIterator(d.readOpts)
+	defer it.Close()
+
+	for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+		switch err := fn(copySlice(it.Key()), copySlice(it.Value())); err {
+		case nil:
+		case errStopIteration:
+			return nil
+		default:
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// has checks whether the given key exists in the database.
+func (d *rocksdbNodeDB) has(key []byte) (bool, error) {
+	s, err := d.db.Get(d.readOpts, key)
+	if err != nil {
+		return false, err
+	}
+	defer s.Free()
+
+	return s.Exists(), nil
+}
+
+// copySlice returns a copy of the given RocksDB slice data, as the backing memory is only valid
+// until the iterator is moved.
+func copySlice(s *gorocksdb.Slice) []byte {
+	defer s.Free()
+
+	return append([]byte{}, s.Data()...)
+}
+
+// Assumes metaUpdateLock is held when called.
+func (d *rocksdbNodeDB) cleanMultipartLocked(removeNodes bool) error {
+	var version uint64
+
+	if d.multipartVersion != multipartVersionNone {
+		version = d.multipartVersion
+	} else {
+		version = d.meta.getMultipartVersion()
+	}
+	if version == multipartVersionNone {
+		// No multipart in progress, but it's not an error to call in a situation like this.
+		return nil
+	}
+
+	batch := gorocksdb.NewWriteBatch()
+	defer batch.Destroy()
+
+	var logged bool
+	if err := d.iterate(multipartRestoreNodeLogKeyFmt.Encode(), func(key, value []byte) error {
+		if removeNodes {
+			if !logged {
+				d.logger.Info("removing some nodes from a multipart restore")
+				logged = true
+			}
+			var hash hash.Hash
+			if !multipartRestoreNodeLogKeyFmt.Decode(key, &hash) {
+				panic("mkvs/rocksdb: bad iterator")
+			}
+			batch.Delete(nodeKeyFmt.Encode(&hash))
+		}
+		batch.Delete(key)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	d.meta.setMultipartVersion(batch, 0)
+	if err := d.db.Write(d.writeOpts, batch); err != nil {
+		return err
+	}
+
+	d.multipartVersion = multipartVersionNone
+	return nil
+}
+
+func (d *rocksdbNodeDB) GetNode(root node.Root, ptr *node.Pointer) (node.Node, error) {
+	if ptr == nil || !ptr.IsClean() {
+		panic("mkvs/rocksdb: attempted to get invalid pointer from node database")
+	}
+	if err := d.sanityCheckNamespace(root.Namespace); err != nil {
+		return nil, err
+	}
+	// If the version is earlier than the earliest version, we don't have the node (it was pruned).
+	if root.Version < d.meta.getEarliestVersion() {
+		return nil, api.ErrNodeNotFound
+	}
+
+	data, err := d.db.GetBytes(d.readOpts, nodeKeyFmt.Encode(&ptr.Hash))
+	switch {
+	case err != nil:
+		d.logger.Error("failed to Get node from backing store",
+			"err", err,
+		)
+		return nil, fmt.Errorf("mkvs/rocksdb: failed to Get node from backing store: %w", err)
+	case data == nil:
+		return nil, api.ErrNodeNotFound
+	default:
+	}
+
+	n, err := node.UnmarshalBinary(data)
+	if err != nil {
+		d.logger.Error("failed to unmarshal node",
+			"err", err,
+		)
+		return nil, fmt.Errorf("mkvs/rocksdb: failed to unmarshal node: %w", err)
+	}
+
+	return n, nil
+}
+
+func (d *rocksdbNodeDB) GetWriteLog(ctx context.Context, startRoot, endRoot node.Root) (writelog.Iterator, error) {
+	if d.discardWriteLogs {
+		return nil, api.ErrWriteLogNotFound
+	}
+	if !endRoot.Follows(&startRoot) {
+		return nil, api.ErrRootMustFollowOld
+	}
+	if err := d.sanityCheckNamespace(startRoot.Namespace); err != nil {
+		return nil, err
+	}
+	// If the version is earlier than the earliest version, we don't have the roots.
+	if endRoot.Version < d.meta.getEarliestVersion() {
+		return nil, api.ErrWriteLogNotFound
+	}
+
+	// Start at the end root and search towards the start root. This assumes that the
+	// chains are not long and that there is not a lot of forks as in that case performance
+	// would suffer.
+	//
+	// In reality the two common cases are:
+	// - State updates: s -> s' (a single hop)
+	// - I/O updates: empty -> i -> io (two hops)
+	//
+	// For this reason, we currently refuse to traverse more than two hops.
+	const maxAllowedHops = 2
+
+	type wlItem struct {
+		depth       uint8
+		endRootHash hash.Hash
+		logKeys     [][]byte
+		logRoots    []hash.Hash
+	}
+	// NOTE: We could use a proper deque, but as long as we keep the number of hops and
+	//       forks low, this should not be a problem.
+	queue := []*wlItem{{depth: 0, endRootHash: endRoot.Hash}}
+	for len(queue) > 0 {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		curItem := queue[0]
+		queue = queue[1:]
+
+		// Iterate over all write logs that result in the current item.
+		var found *wlItem
+		prefix := writeLogKeyFmt.Encode(endRoot.Version, &curItem.endRootHash)
+		if err := d.iterate(prefix, func(key, value []byte) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			var decVersion uint64
+			var decEndRootHash hash.Hash
+			var decStartRootHash hash.Hash
+
+			if !writeLogKeyFmt.Decode(key, &decVersion, &decEndRootHash, &decStartRootHash) {
+				// This should not happen as the prefix iteration should take care of it.
+				panic("mkvs/rocksdb: bad iterator")
+			}
+
+			nextItem := wlItem{
+				depth:       curItem.depth + 1,
+				endRootHash: decStartRootHash,
+				// Only store log keys to avoid keeping everything in memory while
+				// we are searching for the right path.
+				logKeys:  append(curItem.logKeys, key),
+				logRoots: append(curItem.logRoots, curItem.endRootHash),
+			}
+			if nextItem.endRootHash.Equal(&startRoot.Hash) {
+				// Path has been found.
+				found = &nextItem
+				return errStopIteration
+			}
+
+			if nextItem.depth < maxAllowedHops {
+				queue = append(queue, &nextItem)
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		if found == nil {
+			continue
+		}
+
+		// Deserialize and stream write logs.
+		var index int
+		return api.ReviveHashedDBWriteLogs(ctx,
+			func() (node.Root, api.HashedDBWriteLog, error) {
+				if index >= len(found.logKeys) {
+					return node.Root{}, nil, nil
+				}
+
+				key := found.logKeys[index]
+				root := node.Root{
+					Namespace: endRoot.Namespace,
+					Version:   endRoot.Version,
+					Hash:      found.logRoots[index],
+				}
+
+				data, err := d.db.GetBytes(d.readOpts, key)
+				if err != nil {
+					return node.Root{}, nil, err
+				}
+				if data == nil {
+					return node.Root{}, nil, api.ErrWriteLogNotFound
+				}
+
+				var log api.HashedDBWriteLog
+				if err = cbor.UnmarshalTrusted(data, &log); err != nil {
+					return node.Root{}, nil, err
+				}
+
+				index++
+				return root, log, nil
+			},
+			func(root node.Root, h hash.Hash) (*node.LeafNode, error) {
+				leaf, err := d.GetNode(root, &node.Pointer{Hash: h, Clean: true})
+				if err != nil {
+					return nil, err
+				}
+				return leaf.(*node.LeafNode), nil
+			},
+			func() {},
+		)
+	}
+
+	return nil, api.ErrWriteLogNotFound
+}
+
+func (d *rocksdbNodeDB) GetLatestVersion(ctx context.Context) (uint64, error) {
+	version, _ := d.meta.getLastFinalizedVersion()
+	return version, nil
+}
+
+func (d *rocksdbNodeDB) GetEarliestVersion(ctx context.Context) (uint64, error) {
+	return d.meta.getEarliestVersion(), nil
+}
+
+func (d *rocksdbNodeDB) GetRootsForVersion(ctx context.Context, version uint64) (roots []hash.Hash, err error) {
+	// If the version is earlier than the earliest version, we don't have the roots.
+	if version < d.meta.getEarliestVersion() {
+		return nil, nil
+	}
+
+	rootsMeta, err := d.loadRootsMetadata(version)
+	if err != nil {
+		return nil, err
+	}
+
+	for rootHash := range rootsMeta.Roots {
+		roots = append(roots, rootHash)
+	}
+	return
+}
+
+func (d *rocksdbNodeDB) HasRoot(root node.Root) bool {
+	if err := d.sanityCheckNamespace(root.Namespace); err != nil {
+		return false
+	}
+
+	// An empty root is always implicitly present.
+	if root.Hash.IsEmpty() {
+		return true
+	}
+
+	// If the version is earlier than the earliest version, we don't have the root.
+	if root.Version < d.meta.getEarliestVersion() {
+		return false
+	}
+
+	rootsMeta, err := d.loadRootsMetadata(root.Version)
+	if err != nil {
+		panic(err)
+	}
+	return rootsMeta.Roots[root.Hash] != nil
+}
+
+func (d *rocksdbNodeDB) Finalize(ctx context.Context, version uint64, roots []hash.Hash) error { // nolint: gocyclo
+	if d.readOnly {
+		return api.ErrReadOnly
+	}
+
+	d.metaUpdateLock.Lock()
+	defer d.metaUpdateLock.Unlock()
+
+	if d.multipartVersion != multipartVersionNone && d.multipartVersion != version {
+		return api.ErrInvalidMultipartVersion
+	}
+
+	// Make sure that the previous version has been finalized (if we are not restoring).
+	lastFinalizedVersion, exists := d.meta.getLastFinalizedVersion()
+	if d.multipartVersion == multipartVersionNone && version > 0 && exists && lastFinalizedVersion < (version-1) {
+		return api.ErrNotFinalized
+	}
+	// Make sure that this version has not yet been finalized.
+	if exists && version <= lastFinalizedVersion {
+		return api.ErrAlreadyFinalized
+	}
+
+	batch := gorocksdb.NewWriteBatch()
+	defer batch.Destroy()
+
+	// Determine a set of finalized roots. Finalization is transitive, so if
+	// a parent root is finalized the child should be consider finalized too.
+	finalizedRoots := make(map[hash.Hash]bool)
+	for _, rootHash := range roots {
+		finalizedRoots[rootHash] = true
+	}
+
+	var rootsChanged bool
+	rootsMeta, err := d.loadRootsMetadata(version)
+	if err != nil {
+		return err
+	}
+
+	for updated := true; updated; {
+		updated = false
+
+		for rootHash, derivedRoots := range rootsMeta.Roots {
+			if len(derivedRoots) == 0 {
+				continue
+			}
+
+			for _, nextRoot := range derivedRoots {
+				if !finalizedRoots[rootHash] && finalizedRoots[nextRoot] {
+					finalizedRoots[rootHash] = true
+					updated = true
+				}
+			}
+		}
+	}
+
+	// Go through all roots and prune them based on whether they are finalized or not.
+	maybeLoneNodes := make(map[hash.Hash]bool)
+	removedNodes := make(map[hash.Hash]bool)
+	notLoneNodes := make(map[hash.Hash]bool)
+
+	for rootHash := range rootsMeta.Roots {
+		// Load hashes of nodes added during this version for this root.
+		rootUpdatedNodesKey := rootUpdatedNodesKeyFmt.Encode(version, &rootHash)
+		var data []byte
+		data, err = d.db.GetBytes(d.readOpts, rootUpdatedNodesKey)
+		if err != nil {
+			return fmt.Errorf("mkvs/rocksdb: failed to read root updated nodes index: %w", err)
+		}
+		if data == nil {
+			panic("mkvs/rocksdb: missing root updated nodes index")
+		}
+
+		var updatedNodes []updatedNode
+		if err = cbor.UnmarshalTrusted(data, &updatedNodes); err != nil {
+			panic(fmt.Errorf("mkvs/rocksdb: corrupted root updated nodes index: %w", err))
+		}
+
+		if finalizedRoots[rootHash] {
+			// Make sure not to remove any nodes shared with finalized roots.
+			for _, n := range updatedNodes {
+				if n.Removed {
+					removedNodes[n.Hash] = true
+				} else {
+					notLoneNodes[n.Hash] = true
+				}
+			}
+		} else {
+			// Remove any non-finalized roots. It is safe to remove these nodes
+			// as they can never be resurrected due to the version being part of the
+			// node hash as long as we make sure that these nodes are not shared
+			// with any finalized roots added in the same version.
+			for _, n := range updatedNodes {
+				if !n.Removed {
+					maybeLoneNodes[n.Hash] = true
+				}
+			}
+
+			delete(rootsMeta.Roots, rootHash)
+			rootsChanged = true
+
+			// Remove write logs for the non-finalized root.
+			if !d.discardWriteLogs {
+				if err = d.iterate(writeLogKeyFmt.Encode(version, &rootHash), func(key, value []byte) error {
+					batch.Delete(key)
+					return nil
+				}); err != nil {
+					return err
+				}
+
+				// Remove key history for the non-finalized root.
+				if err = d.removeRootKeyHistory(batch, version, rootHash); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Set of updated nodes no longer needed after finalization.
+		batch.Delete(rootUpdatedNodesKey)
+	}
+
+	// Clean any lone nodes.
+	for h := range maybeLoneNodes {
+		if notLoneNodes[h] {
+			continue
+		}
+		batch.Delete(nodeKeyFmt.Encode(&h))
+	}
+
+	// Nodes removed by finalized roots are still reachable from roots in earlier versions, so
+	// they can only be deleted once the previous version is pruned. In case there are no earlier
+	// versions, they can be deleted immediately.
+	retainRemoved := exists && version > d.meta.getEarliestVersion()
+	for h := range removedNodes {
+		if notLoneNodes[h] {
+			continue
+		}
+		if retainRemoved {
+			batch.Put(removedNodesKeyFmt.Encode(version, &h), []byte{})
+		} else {
+			batch.Delete(nodeKeyFmt.Encode(&h))
+		}
+	}
+
+	// Save roots metadata if changed.
+	if rootsChanged {
+		rootsMeta.save(batch)
+	}
+
+	// Update last finalized version.
+	d.meta.setLastFinalizedVersion(batch, version)
+
+	if err = d.db.Write(d.writeOpts, batch); err != nil {
+		return fmt.Errorf("mkvs/rocksdb: failed to commit finalization: %w", err)
+	}
+
+	// Clean multipart metadata if there is any.
+	if d.multipartVersion != multipartVersionNone {
+		if err = d.cleanMultipartLocked(false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *rocksdbNodeDB) Prune(ctx context.Context, version uint64) error {
+	if d.readOnly {
+		return api.ErrReadOnly
+	}
+
+	d.metaUpdateLock.Lock()
+	defer d.metaUpdateLock.Unlock()
+
+	if d.multipartVersion != multipartVersionNone {
+		return api.ErrMultipartInProgress
+	}
+
+	// Make sure that the version that we try to prune has been finalized.
+	lastFinalizedVersion, exists := d.meta.getLastFinalizedVersion()
+	if !exists || lastFinalizedVersion < version {
+		return api.ErrNotFinalized
+	}
+	// Make sure that the version that we are trying to prune is the earliest version.
+	if version != d.meta.getEarliestVersion() {
+		return api.ErrNotEarliest
+	}
+
+	// Remove all roots in version.
+	batch := gorocksdb.NewWriteBatch()
+	defer batch.Destroy()
+
+	rootsMeta, err := d.loadRootsMetadata(version)
+	if err != nil {
+		return err
+	}
+
+	maybeLoneRoots := make(map[hash.Hash]bool)
+	for rootHash, derivedRoots := range rootsMeta.Roots {
+		if len(derivedRoots) == 0 {
+			// Need to only set the flag iff the flag has not already been set
+			// to either value before.
+			if _, ok := maybeLoneRoots[rootHash]; !ok {
+				maybeLoneRoots[rootHash] = true
+			}
+		} else {
+			maybeLoneRoots[rootHash] = false
+		}
+	}
+	for rootHash, isLone := range maybeLoneRoots {
+		if !isLone {
+			continue
+		}
+
+		// Traverse the root and prune all items created in this version.
+		root := node.Root{Namespace: d.namespace, Version: version, Hash: rootHash}
+		err = api.Visit(ctx, d, root, func(ctx context.Context, n node.Node) bool {
+			if n.GetCreatedVersion() == version {
+				h := n.GetHash()
+				batch.Delete(nodeKeyFmt.Encode(&h))
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Delete roots metadata.
+	batch.Delete(rootsMetadataKeyFmt.Encode(version))
+
+	// Prune all write logs and obsolete key history in version.
+	if !d.discardWriteLogs {
+		if err = d.iterate(writeLogKeyFmt.Encode(version), func(key, value []byte) error {
+			batch.Delete(key)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if err = d.pruneKeyHistory(batch, rootsMeta); err != nil {
+			return fmt.Errorf("mkvs/rocksdb: failed to prune key history: %w", err)
+		}
+	}
+
+	// Nodes removed in the next version are no longer reachable once this version is pruned.
+	if err = d.iterate(removedNodesKeyFmt.Encode(version+1), func(key, value []byte) error {
+		var (
+			decVersion uint64
+			h          hash.Hash
+		)
+		if !removedNodesKeyFmt.Decode(key, &decVersion, &h) {
+			// This should not happen as the prefix iteration should take care of it.
+			panic("mkvs/rocksdb: bad iterator")
+		}
+		batch.Delete(nodeKeyFmt.Encode(&h))
+		batch.Delete(key)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Update metadata.
+	d.meta.setEarliestVersion(batch, version+1)
+
+	if err = d.db.Write(d.writeOpts, batch); err != nil {
+		return fmt.Errorf("mkvs/rocksdb: failed to commit: %w", err)
+	}
+	return nil
+}
+
+func (d *rocksdbNodeDB) StartMultipartInsert(version uint64) error {
+	d.metaUpdateLock.Lock()
+	defer d.metaUpdateLock.Unlock()
+
+	if version == multipartVersionNone {
+		return api.ErrInvalidMultipartVersion
+	}
+
+	if d.multipartVersion != multipartVersionNone {
+		if d.multipartVersion != version {
+			return api.ErrMultipartInProgress
+		}
+		// Multipart already initialized at the same version, so this was
+		// probably called e.g. as part of a further checkpoint restore.
+		return nil
+	}
+
+	batch := gorocksdb.NewWriteBatch()
+	defer batch.Destroy()
+	d.meta.setMultipartVersion(batch, version)
+	if err := d.db.Write(d.writeOpts, batch); err != nil {
+		return err
+	}
+
+	d.multipartVersion = version
+
+	return nil
+}
+
+func (d *rocksdbNodeDB) AbortMultipartInsert() error {
+	d.metaUpdateLock.Lock()
+	defer d.metaUpdateLock.Unlock()
+
+	return d.cleanMultipartLocked(true)
+}
+
+func (d *rocksdbNodeDB) NewBatch(oldRoot node.Root, version uint64, chunk bool) (api.Batch, error) {
+	if d.readOnly {
+		return nil, api.ErrReadOnly
+	}
+
+	d.metaUpdateLock.Lock()
+	defer d.metaUpdateLock.Unlock()
+
+	if d.multipartVersion != multipartVersionNone && d.multipartVersion != version {
+		return nil, api.ErrInvalidMultipartVersion
+	}
+	if chunk != (d.multipartVersion != multipartVersionNone) {
+		return nil, api.ErrMultipartInProgress
+	}
+
+	return &rocksdbBatch{
+		db:        d,
+		multipart: d.multipartVersion != multipartVersionNone,
+		oldRoot:   oldRoot,
+		chunk:     chunk,
+	}, nil
+}
+
+// sizeProperty returns the value of the given integer database property.
+func (d *rocksdbNodeDB) sizeProperty(name string) (int64, error) {
+	value, err := strconv.ParseInt(d.db.GetProperty(name), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("mkvs/rocksdb: malformed property '%s': %w", name, err)
+	}
+	return value, nil
+}
+
+func (d *rocksdbNodeDB) Size() (int64, error) {
+	sst, err := d.sizeProperty("rocksdb.total-sst-files-size")
+	if err != nil {
+		return 0, err
+	}
+	memtables, err := d.sizeProperty("rocksdb.cur-size-all-mem-tables")
+	if err != nil {
+		return 0, err
+	}
+	return sst + memtables, nil
+}
+
+func (d *rocksdbNodeDB) Compact(ctx context.Context) (int64, error) {
+	if d.readOnly {
+		return 0, api.ErrReadOnly
+	}
+
+	sizeBefore, err := d.Size()
+	if err != nil {
+		return 0, err
+	}
+
+	// Compact the full key range, dropping any deleted entries.
+	d.db.CompactRange(gorocksdb.Range{})
+
+	sizeAfter, err := d.Size()
+	if err != nil {
+		return 0, err
+	}
+	if sizeAfter >= sizeBefore {
+		return 0, nil
+	}
+	return sizeBefore - sizeAfter, nil
+}
+
+func (d *rocksdbNodeDB) Sync() error {
+	opts := gorocksdb.NewDefaultFlushOptions()
+	defer opts.Destroy()
+	opts.SetWait(true)
+
+	return d.db.Flush(opts)
+}
+
+func (d *rocksdbNodeDB) Close() {
+	d.closeOnce.Do(func() {
+		d.db.Close()
+		d.destroyOptions()
+	})
+}
+
+// destroyOptions releases all native RocksDB options.
+func (d *rocksdbNodeDB) destroyOptions() {
+	if d.readOpts != nil {
+		d.readOpts.Destroy()
+	}
+	if d.writeOpts != nil {
+		d.writeOpts.Destroy()
+	}
+	d.opts.Destroy()
+	d.tableOpts.Destroy()
+	d.cache.Destroy()
+	if d.env != nil {
+		d.env.Destroy()
+	}
+}
+
+// nodeEntry is a node pending insertion in a batch.
+type nodeEntry struct {
+	key  []byte
+	data []byte
+}
+
+type rocksdbBatch struct {
+	api.BaseBatch
+
+	db        *rocksdbNodeDB
+	multipart bool
+
+	oldRoot node.Root
+	chunk   bool
+
+	nodes          []nodeEntry
+	multipartNodes []hash.Hash
+	writeLog       writelog.WriteLog
+	annotations    writelog.Annotations
+	updatedNodes   []updatedNode
+}
+
+func (ba *rocksdbBatch) MaybeStartSubtree(subtree api.Subtree, depth node.Depth, subtreeRoot *node.Pointer) api.Subtree {
+	if subtree == nil {
+		return &rocksdbSubtree{batch: ba}
+	}
+	return subtree
+}
+
+func (ba *rocksdbBatch) PutWriteLog(writeLog writelog.WriteLog, annotations writelog.Annotations) error {
+	if ba.chunk {
+		return fmt.Errorf("mkvs/rocksdb: cannot put write log in chunk mode")
+	}
+	if ba.db.discardWriteLogs {
+		return nil
+	}
+
+	ba.writeLog = writeLog
+	ba.annotations = annotations
+	return nil
+}
+
+func (ba *rocksdbBatch) RemoveNodes(nodes []node.Node) error {
+	if ba.chunk {
+		return fmt.Errorf("mkvs/rocksdb: cannot remove nodes in chunk mode")
+	}
+
+	for _, n := range nodes {
+		ba.updatedNodes = append(ba.updatedNodes, updatedNode{
+			Removed: true,
+			Hash:    n.GetHash(),
+		})
+	}
+	return nil
+}
+
+func (ba *rocksdbBatch) Commit(root node.Root) error {
+	ba.db.metaUpdateLock.Lock()
+	defer ba.db.metaUpdateLock.Unlock()
+
+	if ba.db.multipartVersion != multipartVersionNone && ba.db.multipartVersion != root.Version {
+		return api.ErrInvalidMultipartVersion
+	}
+
+	if err := ba.db.sanityCheckNamespace(root.Namespace); err != nil {
+		return err
+	}
+	if !root.Follows(&ba.oldRoot) {
+		return api.ErrRootMustFollowOld
+	}
+
+	// Make sure that the version that we try to commit into has not yet been finalized.
+	lastFinalizedVersion, exists := ba.db.meta.getLastFinalizedVersion()
+	if exists && lastFinalizedVersion >= root.Version {
+		return api.ErrAlreadyFinalized
+	}
+
+	// Update the set of roots for this version.
+	rootsMeta, err := ba.db.loadRootsMetadata(root.Version)
+	if err != nil {
+		return err
+	}
+
+	batch := gorocksdb.NewWriteBatch()
+	defer batch.Destroy()
+
+	if rootsMeta.Roots[root.Hash] != nil {
+		// Root already exists, no need to do anything since if the hash matches, everything will
+		// be identical and we would just be duplicating work.
+		//
+		// If we are importing a chunk, there can be multiple commits for the same root.
+		if !ba.chunk {
+			ba.Reset()
+			return ba.BaseBatch.Commit(root)
+		}
+	} else {
+		// Create root with no derived roots.
+		rootsMeta.Roots[root.Hash] = []hash.Hash{}
+	}
+
+	if ba.chunk {
+		// Skip most of metadata updates if we are just importing chunks.
+		batch.Put(rootUpdatedNodesKeyFmt.Encode(root.Version, &root.Hash), cbor.Marshal([]updatedNode{}))
+	} else {
+		// Update the root link for the old root.
+		if !ba.oldRoot.Hash.IsEmpty() {
+			if ba.oldRoot.Version < ba.db.meta.getEarliestVersion() && ba.oldRoot.Version != root.Version {
+				return api.ErrPreviousVersionMismatch
+			}
+
+			// The old root may be in the same version, in which case the roots metadata needs to
+			// be shared as batched writes are not visible to reads.
+			oldRootsMeta := rootsMeta
+			if ba.oldRoot.Version != root.Version {
+				if oldRootsMeta, err = ba.db.loadRootsMetadata(ba.oldRoot.Version); err != nil {
+					return err
+				}
+			}
+
+			if _, ok := oldRootsMeta.Roots[ba.oldRoot.Hash]; !ok {
+				return api.ErrRootNotFound
+			}
+
+			oldRootsMeta.Roots[ba.oldRoot.Hash] = append(oldRootsMeta.Roots[ba.oldRoot.Hash], root.Hash)
+			oldRootsMeta.save(batch)
+		}
+
+		// Store updated nodes (only needed until the version is finalized).
+		batch.Put(rootUpdatedNodesKeyFmt.Encode(root.Version, &root.Hash), cbor.Marshal(ba.updatedNodes))
+
+		// Store write log and update the key history index.
+		if ba.writeLog != nil && ba.annotations != nil {
+			log := api.MakeHashedDBWriteLog(ba.writeLog, ba.annotations)
+			batch.Put(writeLogKeyFmt.Encode(root.Version, &root.Hash, &ba.oldRoot.Hash), cbor.Marshal(log))
+			putKeyHistory(batch, root, log)
+		}
+	}
+	rootsMeta.save(batch)
+
+	// Add node updates.
+	for _, h := range ba.multipartNodes {
+		batch.Put(multipartRestoreNodeLogKeyFmt.Encode(&h), []byte{})
+	}
+	for _, n := range ba.nodes {
+		batch.Put(n.key, n.data)
+	}
+
+	// Commit all updates atomically.
+	if err = ba.db.db.Write(ba.db.writeOpts, batch); err != nil {
+		return fmt.Errorf("mkvs/rocksdb: failed to commit batch: %w", err)
+	}
+
+	ba.Reset()
+
+	return ba.BaseBatch.Commit(root)
+}
+
+func (ba *rocksdbBatch) Reset() {
+	ba.nodes = nil
+	ba.multipartNodes = nil
+	ba.writeLog = nil
+	ba.annotations = nil
+	ba.updatedNodes = nil
+}
+
+type rocksdbSubtree struct {
+	batch *rocksdbBatch
+}
+
+func (s *rocksdbSubtree) PutNode(depth node.Depth, ptr *node.Pointer) error {
+	data, err := ptr.Node.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	h := ptr.Node.GetHash()
+	s.batch.updatedNodes = append(s.batch.updatedNodes, updatedNode{Hash: h})
+	nodeKey := nodeKeyFmt.Encode(&h)
+	if s.batch.multipart {
+		var exists bool
+		if exists, err = s.batch.db.has(nodeKey); err != nil {
+			return err
+		}
+		if !exists {
+			s.batch.multipartNodes = append(s.batch.multipartNodes, h)
+		}
+	}
+	s.batch.nodes = append(s.batch.nodes, nodeEntry{key: nodeKey, data: data})
+	return nil
+}
+
+func (s *rocksdbSubtree) VisitCleanNode(depth node.Depth, ptr *node.Pointer) error {
+	return nil
+}
+
+func (s *rocksdbSubtree) Commit() error {
+	return nil
+}
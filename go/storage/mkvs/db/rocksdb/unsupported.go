@@ -0,0 +1,16 @@
+// +build !rocksdb
+
+package rocksdb
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+)
+
+// New creates a new RocksDB-backed node database.
+//
+// As this binary has been built without RocksDB support, it always returns an error.
+func New(cfg *api.Config) (api.NodeDB, error) {
+	return nil, fmt.Errorf("mkvs/rocksdb: not supported by this build (rebuild with the rocksdb build tag)")
+}
@@ -39,7 +39,20 @@ func (t *tree) SyncIterate(ctx context.Context, request *syncer.IterateRequest)
 	if it.Err() != nil {
 		return nil, it.Err()
 	}
+	var (
+		entries      uint64
+		size         uint64
+		continuation []byte
+	)
 	for i := 0; it.Valid() && i < int(request.Prefetch); i++ {
+		entries++
+		size += uint64(len(it.Key()) + len(it.Value()))
+		if request.ExceedsLimits(entries, size) {
+			// The smallest key larger than the current key is the key with
+			// a zero byte appended.
+			continuation = append(append([]byte{}, it.Key()...), 0x00)
+			break
+		}
 		it.Next()
 	}
 	if it.Err() != nil {
@@ -53,19 +66,25 @@ func (t *tree) SyncIterate(ctx context.Context, request *syncer.IterateRequest)
 	}
 
 	return &syncer.ProofResponse{
-		Proof: *proof,
+		Proof:        *proof,
+		Continuation: continuation,
 	}, nil
 }
 
 func (t *tree) newFetcherSyncIterate(key node.Key, prefetch uint16) readSyncFetcher {
 	return func(ctx context.Context, ptr *node.Pointer, rs syncer.ReadSyncer) (*syncer.Proof, error) {
+		// In case the response gets truncated due to the configured limits,
+		// the iterator will transparently issue another request once it
+		// reaches the nodes that were not included in the response.
 		rsp, err := rs.SyncIterate(ctx, &syncer.IterateRequest{
 			Tree: syncer.TreeID{
 				Root:     t.cache.syncRoot,
 				Position: ptr.Hash,
 			},
-			Key:      key,
-			Prefetch: prefetch,
+			Key:        key,
+			Prefetch:   prefetch,
+			MaxEntries: t.iterateMaxEntries,
+			MaxBytes:   t.iterateMaxBytes,
 		})
 		if err != nil {
 			return nil, err
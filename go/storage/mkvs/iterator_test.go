@@ -113,6 +113,50 @@ func TestIterator(t *testing.T) {
 		require.EqualValues(t, 2, stats.SyncIterateCount, "SyncIterateCount")
 	})
 
+	stats = syncer.NewStatsCollector(tree)
+	remote = NewWithRoot(stats, nil, root, SyncIterateLimits(2, 0))
+	defer remote.Close()
+
+	t.Run("RemoteWithPrefetch10Limits", func(t *testing.T) {
+		rpit := remote.NewIterator(ctx, IteratorPrefetch(10))
+		defer rpit.Close()
+
+		testIterator(t, items, rpit, tests)
+
+		require.EqualValues(t, 0, stats.SyncGetCount, "SyncGetCount")
+		require.EqualValues(t, 0, stats.SyncGetPrefixesCount, "SyncGetPrefixesCount")
+		require.True(t, stats.SyncIterateCount > 1, "SyncIterateCount should reflect truncated responses")
+	})
+
+	t.Run("SyncIterateLimits", func(t *testing.T) {
+		request := syncer.IterateRequest{
+			Tree:     syncer.TreeID{Root: root, Position: root.Hash},
+			Key:      []byte("key"),
+			Prefetch: 10,
+		}
+
+		rsp, err := tree.SyncIterate(ctx, &request)
+		require.NoError(t, err, "SyncIterate")
+		require.Nil(t, rsp.Continuation, "response without limits should not be truncated")
+
+		request.MaxEntries = 2
+		rsp, err = tree.SyncIterate(ctx, &request)
+		require.NoError(t, err, "SyncIterate")
+		require.EqualValues(t, []byte("key 1\x00"), rsp.Continuation, "continuation should follow the last entry")
+
+		request.Key = rsp.Continuation
+		rsp, err = tree.SyncIterate(ctx, &request)
+		require.NoError(t, err, "SyncIterate")
+		require.EqualValues(t, []byte("key 5\x00"), rsp.Continuation, "continuation should follow the last entry")
+
+		request.Key = []byte("key")
+		request.MaxEntries = 0
+		request.MaxBytes = 1
+		rsp, err = tree.SyncIterate(ctx, &request)
+		require.NoError(t, err, "SyncIterate")
+		require.EqualValues(t, []byte("key\x00"), rsp.Continuation, "at least one entry should be included")
+	})
+
 	statsIntermediate := syncer.NewStatsCollector(tree)
 	intermediate := NewWithRoot(statsIntermediate, nil, root)
 	defer intermediate.Close()
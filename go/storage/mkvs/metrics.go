@@ -0,0 +1,33 @@
+package mkvs
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	cacheNodeLookups = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_storage_mkvs_cache_lookups",
+			Help: "Number of in-memory tree cache node lookups.",
+		},
+		[]string{"result"},
+	)
+
+	cacheCollectors = []prometheus.Collector{
+		cacheNodeLookups,
+	}
+
+	labelCacheHit    = prometheus.Labels{"result": "hit"}
+	labelCacheNodeDB = prometheus.Labels{"result": "nodedb"}
+	labelCacheSyncer = prometheus.Labels{"result": "syncer"}
+
+	metricsOnce sync.Once
+)
+
+func initMetrics() {
+	metricsOnce.Do(func() {
+		prometheus.MustRegister(cacheCollectors...)
+	})
+}
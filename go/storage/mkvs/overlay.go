@@ -11,7 +11,7 @@ import (
 var _ OverlayTree = (*treeOverlay)(nil)
 
 type treeOverlay struct {
-	inner   Tree
+	inner   KeyValueTree
 	overlay Tree
 
 	dirty map[string]bool
@@ -23,8 +23,10 @@ type treeOverlay struct {
 // While updates (inserts, removes) are stored in the overlay, reads are not cached in the overlay
 // as the inner tree has its own cache and double caching makes less sense.
 //
+// Overlays can be stacked by using an overlay as the inner tree of another overlay.
+//
 // The overlay is not safe for concurrent use.
-func NewOverlay(inner Tree) OverlayTree {
+func NewOverlay(inner KeyValueTree) OverlayTree {
 	return &treeOverlay{
 		inner:   inner,
 		overlay: New(nil, nil, WithoutWriteLog()),
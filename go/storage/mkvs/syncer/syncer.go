@@ -48,11 +48,36 @@ type IterateRequest struct {
 	Tree     TreeID `json:"tree"`
 	Key      []byte `json:"key"`
 	Prefetch uint16 `json:"prefetch"`
+
+	// MaxEntries is the maximum number of entries that should be included
+	// in the response. Zero means no limit.
+	MaxEntries uint64 `json:"max_entries,omitempty"`
+	// MaxBytes is the maximum total size of keys and values that should be
+	// included in the response. The limit is checked after each entry so
+	// the response may exceed it by at most one entry. Zero means no limit.
+	MaxBytes uint64 `json:"max_bytes,omitempty"`
+}
+
+// ExceedsLimits returns true iff the given number of entries and their total
+// size reach the limits set in the request.
+func (r *IterateRequest) ExceedsLimits(entries, size uint64) bool {
+	if r.MaxEntries > 0 && entries >= r.MaxEntries {
+		return true
+	}
+	if r.MaxBytes > 0 && size >= r.MaxBytes {
+		return true
+	}
+	return false
 }
 
 // ProofResponse is a response for requests that produce proofs.
 type ProofResponse struct {
 	Proof Proof `json:"proof"`
+
+	// Continuation is set in case a SyncIterate response was truncated due
+	// to the request limits. It is the key at which iteration should be
+	// resumed by issuing another request.
+	Continuation []byte `json:"continuation,omitempty"`
 }
 
 // ReadSyncer is the interface for synchronizing the in-memory cache
@@ -17,6 +17,10 @@ type tree struct {
 	// NOTE: This can be a map as updates are commutative.
 	pendingWriteLog map[string]*pendingEntry
 	withoutWriteLog bool
+	// iterateMaxEntries and iterateMaxBytes are the limits used for
+	// SyncIterate requests to the remote syncer.
+	iterateMaxEntries uint64
+	iterateMaxBytes   uint64
 	// pendingRemovedNodes are the nodes that have been removed from the
 	// in-memory tree and should be marked for garbage collection if this
 	// tree is committed to the node database.
@@ -58,6 +62,18 @@ func PersistEverythingFromSyncer(doit bool) Option {
 	}
 }
 
+// SyncIterateLimits sets the entry-count and byte-size limits of each
+// SyncIterate request made to the remote syncer. Iterators transparently
+// issue further requests in case the responses are truncated.
+//
+// If not specified (or zero), requests are not limited.
+func SyncIterateLimits(maxEntries, maxBytes uint64) Option {
+	return func(t *tree) {
+		t.iterateMaxEntries = maxEntries
+		t.iterateMaxBytes = maxBytes
+	}
+}
+
 // WithoutWriteLog disables building a write log when performing operations.
 //
 // Note that this option cannot be used together with specifying a ReadSyncer and trying to use it
@@ -0,0 +1,39 @@
+// +build rocksdb
+
+package mkvs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	rocksDb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/rocksdb"
+)
+
+func TestRocksDBBackend(t *testing.T) {
+	testBackend(t, func(t *testing.T) (NodeDBFactory, func()) {
+		// Create a new random temporary directory under /tmp.
+		dir, err := ioutil.TempDir("", "mkvs.test.rocksdb")
+		require.NoError(t, err, "TempDir")
+
+		// Create a RocksDB-backed Node DB factory.
+		factory := func(ns common.Namespace) (db.NodeDB, error) {
+			return rocksDb.New(&db.Config{
+				DB:           dir,
+				NoFsync:      true,
+				Namespace:    ns,
+				MaxCacheSize: 16 * 1024 * 1024,
+			})
+		}
+
+		cleanup := func() {
+			os.RemoveAll(dir)
+		}
+
+		return factory, cleanup
+	}, nil)
+}
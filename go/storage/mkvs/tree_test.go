@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -1061,6 +1062,41 @@ func testHasRoot(t *testing.T, ndb db.NodeDB, factory NodeDBFactory) {
 	require.True(t, ndb.HasRoot(root), "HasRoot should return true for existing root")
 }
 
+func testVerify(t *testing.T, ndb db.NodeDB, factory NodeDBFactory) {
+	ctx := context.Background()
+
+	// Empty roots are always consistent.
+	root := node.Root{
+		Namespace: testNs,
+		Version:   0,
+	}
+	root.Hash.Empty()
+	err := Verify(ctx, ndb, root)
+	require.NoError(t, err, "Verify should succeed on empty root")
+
+	tree := New(nil, ndb)
+	keys, values := generateKeyValuePairs()
+	for i := 0; i < len(keys); i++ {
+		err = tree.Insert(ctx, keys[i], values[i])
+		require.NoError(t, err, "Insert")
+	}
+	writeLog, rootHash, err := tree.Commit(ctx, testNs, 0)
+	require.NoError(t, err, "Commit")
+	err = ndb.Finalize(ctx, 0, []hash.Hash{rootHash})
+	require.NoError(t, err, "Finalize")
+
+	_, err = writeLog.Validate(nil)
+	require.NoError(t, err, "committed write log should be well-formed")
+
+	root.Hash = rootHash
+	err = Verify(ctx, ndb, root)
+	require.NoError(t, err, "Verify should succeed on existing root")
+
+	root.Hash.FromBytes([]byte("invalid root"))
+	err = Verify(ctx, ndb, root)
+	require.True(t, errors.Is(err, ErrInconsistentRoot), "Verify should fail on non-existing root")
+}
+
 func testGetRootsForVersion(t *testing.T, ndb db.NodeDB, factory NodeDBFactory) {
 	ctx := context.Background()
 
@@ -2057,6 +2093,7 @@ func testBackend(
 		{"CommitNoPersist", testCommitNoPersist},
 		{"MergeWriteLog", testMergeWriteLog},
 		{"HasRoot", testHasRoot},
+		{"Verify", testVerify},
 		{"GetRootsForVersion", testGetRootsForVersion},
 		{"Size", testSize},
 		{"PruneBasic", testPruneBasic},
@@ -0,0 +1,82 @@
+package mkvs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+// ErrInconsistentRoot is the error returned by Verify when the nodes stored under a root are
+// inconsistent.
+var ErrInconsistentRoot = errors.New("mkvs: inconsistent root")
+
+// Verify traverses all nodes reachable from the given root in the node database and checks that
+// they are present and that their hashes are consistent with the pointers referencing them.
+func Verify(ctx context.Context, ndb db.NodeDB, root node.Root) error {
+	if root.Hash.IsEmpty() {
+		return nil
+	}
+	if !ndb.HasRoot(root) {
+		return fmt.Errorf("%w: root %s not found", ErrInconsistentRoot, root)
+	}
+
+	return verifyNode(ctx, ndb, root, &node.Pointer{Clean: true, Hash: root.Hash})
+}
+
+// VerifyLatest verifies all roots stored under the latest finalized version in the node database.
+func VerifyLatest(ctx context.Context, ndb db.NodeDB, namespace common.Namespace) error {
+	version, err := ndb.GetLatestVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("mkvs: failed to get latest version: %w", err)
+	}
+	roots, err := ndb.GetRootsForVersion(ctx, version)
+	if err != nil {
+		return fmt.Errorf("mkvs: failed to get roots for version %d: %w", version, err)
+	}
+	for _, rootHash := range roots {
+		root := node.Root{
+			Namespace: namespace,
+			Version:   version,
+			Hash:      rootHash,
+		}
+		if err = Verify(ctx, ndb, root); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func verifyNode(ctx context.Context, ndb db.NodeDB, root node.Root, ptr *node.Pointer) error {
+	if ptr == nil {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	n, err := ndb.GetNode(root, ptr)
+	if err != nil {
+		return fmt.Errorf("%w: failed to get node %s: %s", ErrInconsistentRoot, ptr.Hash, err)
+	}
+	if h := n.GetHash(); !h.Equal(&ptr.Hash) {
+		return fmt.Errorf("%w: node hash mismatch (expected: %s got: %s)", ErrInconsistentRoot, ptr.Hash, h)
+	}
+
+	switch nd := n.(type) {
+	case *node.InternalNode:
+		// Leaf nodes are embedded in internal nodes and their hashes have already been verified
+		// as part of computing the internal node hash.
+		if err = verifyNode(ctx, ndb, root, nd.Left); err != nil {
+			return err
+		}
+		return verifyNode(ctx, ndb, root, nd.Right)
+	case *node.LeafNode:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown node type %T", ErrInconsistentRoot, n)
+	}
+}
@@ -3,10 +3,26 @@ package writelog
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 )
 
+// ModuleName is the module name.
+const ModuleName = "storage/mkvs/writelog"
+
+var (
+	// ErrKeysNotSorted is the error returned when write log keys are not sorted.
+	ErrKeysNotSorted = errors.New(ModuleName, 1, "writelog: keys not sorted")
+	// ErrDuplicateKey is the error returned when a write log contains duplicate keys.
+	ErrDuplicateKey = errors.New(ModuleName, 2, "writelog: duplicate key")
+	// ErrKeyTooLarge is the error returned when a write log key exceeds the maximum size.
+	ErrKeyTooLarge = errors.New(ModuleName, 3, "writelog: key too large")
+	// ErrValueTooLarge is the error returned when a write log value exceeds the maximum size.
+	ErrValueTooLarge = errors.New(ModuleName, 4, "writelog: value too large")
+)
+
 // WriteLog is a write log.
 //
 // The keys in the write log must be unique.
@@ -25,6 +41,68 @@ func (wl WriteLog) Equal(cmp WriteLog) bool {
 	return true
 }
 
+// Limits are the write log validation limits.
+type Limits struct {
+	// MaxKeySize is the maximum size of a key (in bytes). Zero means no limit.
+	MaxKeySize uint64
+	// MaxValueSize is the maximum size of a value (in bytes). Zero means no limit.
+	MaxValueSize uint64
+}
+
+// Stats are write log size statistics.
+type Stats struct {
+	// NumInserts is the number of insert entries.
+	NumInserts uint64
+	// NumDeletes is the number of delete entries.
+	NumDeletes uint64
+	// KeysSize is the total size of all keys (in bytes).
+	KeysSize uint64
+	// ValuesSize is the total size of all values (in bytes).
+	ValuesSize uint64
+}
+
+// Size returns the total size of all keys and values (in bytes).
+func (s *Stats) Size() uint64 {
+	return s.KeysSize + s.ValuesSize
+}
+
+// Validate checks that the write log is well-formed and within the given
+// limits and returns its size statistics.
+//
+// A well-formed write log has keys sorted in ascending order without any
+// duplicates.
+func (wl WriteLog) Validate(limits *Limits) (*Stats, error) {
+	var stats Stats
+	for i, entry := range wl {
+		if i > 0 {
+			switch bytes.Compare(wl[i-1].Key, entry.Key) {
+			case 0:
+				return nil, fmt.Errorf("%w: entry %d", ErrDuplicateKey, i)
+			case 1:
+				return nil, fmt.Errorf("%w: entry %d", ErrKeysNotSorted, i)
+			}
+		}
+
+		keySize, valueSize := uint64(len(entry.Key)), uint64(len(entry.Value))
+		if limits != nil && limits.MaxKeySize > 0 && keySize > limits.MaxKeySize {
+			return nil, fmt.Errorf("%w: entry %d (%d > %d)", ErrKeyTooLarge, i, keySize, limits.MaxKeySize)
+		}
+		if limits != nil && limits.MaxValueSize > 0 && valueSize > limits.MaxValueSize {
+			return nil, fmt.Errorf("%w: entry %d (%d > %d)", ErrValueTooLarge, i, valueSize, limits.MaxValueSize)
+		}
+
+		switch entry.Type() {
+		case LogInsert:
+			stats.NumInserts++
+		case LogDelete:
+			stats.NumDeletes++
+		}
+		stats.KeysSize += keySize
+		stats.ValuesSize += valueSize
+	}
+	return &stats, nil
+}
+
 // LogEntry is a write log entry.
 type LogEntry struct {
 	_ struct{} `cbor:",toarray"` // nolint
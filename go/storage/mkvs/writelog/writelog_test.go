@@ -0,0 +1,44 @@
+package writelog
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	require := require.New(t)
+
+	wl := WriteLog{
+		{Key: []byte("a"), Value: []byte("value a")},
+		{Key: []byte("b"), Value: nil},
+		{Key: []byte("bb"), Value: []byte("value bb")},
+	}
+	stats, err := wl.Validate(nil)
+	require.NoError(err, "Validate")
+	require.EqualValues(2, stats.NumInserts, "NumInserts")
+	require.EqualValues(1, stats.NumDeletes, "NumDeletes")
+	require.EqualValues(4, stats.KeysSize, "KeysSize")
+	require.EqualValues(15, stats.ValuesSize, "ValuesSize")
+	require.EqualValues(19, stats.Size(), "Size")
+
+	stats, err = WriteLog{}.Validate(nil)
+	require.NoError(err, "Validate should accept an empty write log")
+	require.EqualValues(0, stats.Size(), "Size")
+
+	_, err = WriteLog{wl[1], wl[0]}.Validate(nil)
+	require.True(errors.Is(err, ErrKeysNotSorted), "Validate should reject unsorted keys")
+
+	_, err = WriteLog{wl[0], wl[0]}.Validate(nil)
+	require.True(errors.Is(err, ErrDuplicateKey), "Validate should reject duplicate keys")
+
+	_, err = wl.Validate(&Limits{MaxKeySize: 2, MaxValueSize: 8})
+	require.NoError(err, "Validate should accept entries within limits")
+
+	_, err = wl.Validate(&Limits{MaxKeySize: 1})
+	require.True(errors.Is(err, ErrKeyTooLarge), "Validate should reject keys that are too large")
+
+	_, err = wl.Validate(&Limits{MaxValueSize: 7})
+	require.True(errors.Is(err, ErrValueTooLarge), "Validate should reject values that are too large")
+}
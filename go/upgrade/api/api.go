@@ -137,6 +137,9 @@ type Backend interface {
 	// CancelUpgrade cancels a pending upgrade, unless it is already in progress.
 	CancelUpgrade(context.Context) error
 
+	// PendingUpgrades returns the list of pending upgrades.
+	PendingUpgrades(context.Context) ([]*PendingUpgrade, error)
+
 	// StartupUpgrade performs the startup portion of the upgrade.
 	// It is idempotent with respect to the current upgrade descriptor.
 	StartupUpgrade() error
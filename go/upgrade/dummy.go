@@ -19,6 +19,10 @@ func (u *dummyUpgradeManager) CancelUpgrade(ctx context.Context) error {
 	return nil
 }
 
+func (u *dummyUpgradeManager) PendingUpgrades(ctx context.Context) ([]*api.PendingUpgrade, error) {
+	return []*api.PendingUpgrade{}, nil
+}
+
 func (u *dummyUpgradeManager) StartupUpgrade() error {
 	return nil
 }
@@ -103,6 +103,18 @@ func (u *upgradeManager) CancelUpgrade(ctx context.Context) error {
 	return nil
 }
 
+func (u *upgradeManager) PendingUpgrades(ctx context.Context) ([]*api.PendingUpgrade, error) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	if u.pending == nil {
+		return []*api.PendingUpgrade{}, nil
+	}
+
+	pu := *u.pending
+	return []*api.PendingUpgrade{&pu}, nil
+}
+
 func (u *upgradeManager) checkStatus() error {
 	var err error
 
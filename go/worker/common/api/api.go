@@ -1,6 +1,8 @@
 package api
 
 import (
+	"time"
+
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 )
 
@@ -25,3 +27,35 @@ type Status struct {
 	// Peers is the list of peers in the runtime P2P network.
 	Peers []string `json:"peers"`
 }
+
+// PeerScore is the score of a peer in the runtime committee P2P network.
+type PeerScore struct {
+	// PeerID is the libp2p ID of the peer.
+	PeerID string `json:"peer_id"`
+	// Score is the peer's current penalty score. Peers are banned once the score reaches the
+	// configured ban threshold.
+	Score uint64 `json:"score"`
+
+	// InvalidMessages is the number of invalid messages received from the peer.
+	InvalidMessages uint64 `json:"invalid_messages"`
+	// RateLimitViolations is the number of messages from the peer that exceeded the rate limit.
+	RateLimitViolations uint64 `json:"rate_limit_violations"`
+}
+
+// PeerBan is a ban of a peer in the runtime committee P2P network.
+type PeerBan struct {
+	// PeerID is the libp2p ID of the banned peer.
+	PeerID string `json:"peer_id"`
+	// Reason is the reason for the ban.
+	Reason string `json:"reason"`
+	// Until is the time when the ban expires.
+	Until time.Time `json:"until"`
+}
+
+// PeerScores is the overview of peer scores and bans in the runtime committee P2P network.
+type PeerScores struct {
+	// Scores are the scores of peers that were penalized and are not banned.
+	Scores []*PeerScore `json:"scores"`
+	// Banned are the currently banned peers.
+	Banned []*PeerBan `json:"banned"`
+}
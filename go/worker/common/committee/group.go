@@ -165,6 +165,22 @@ func (e *EpochSnapshot) IsTransactionScheduler(round uint64) bool {
 	return scheduler.PublicKey.Equal(e.identity.NodeSigner.Public())
 }
 
+// IsBackupTransactionScheduler checks if the current node is a backup transaction scheduler
+// at the specific round.
+//
+// The backup transaction scheduler proposes batches for rounds that have been escalated to the
+// backup workers after a proposer timeout.
+func (e *EpochSnapshot) IsBackupTransactionScheduler(round uint64) bool {
+	if e.executorCommittee == nil || e.executorCommittee.Committee == nil {
+		return false
+	}
+	scheduler, err := commitment.GetBackupTransactionScheduler(e.executorCommittee.Committee, round)
+	if err != nil {
+		return false
+	}
+	return scheduler.PublicKey.Equal(e.identity.NodeSigner.Public())
+}
+
 // GetStorageCommittee returns the current storage committee.
 func (e *EpochSnapshot) GetStorageCommittee() *CommitteeInfo {
 	return e.storageCommittee
@@ -224,6 +240,22 @@ func (e *EpochSnapshot) VerifyTxnSchedulerSignature(sig signature.Signature, rou
 	return nil
 }
 
+// VerifyBackupTxnSchedulerSignature verifies that the given signatures comes from
+// the backup transaction scheduler at provided round.
+func (e *EpochSnapshot) VerifyBackupTxnSchedulerSignature(sig signature.Signature, round uint64) error {
+	if e.executorCommittee == nil || e.executorCommittee.Committee == nil {
+		return fmt.Errorf("epoch: no active transaction scheduler")
+	}
+	scheduler, err := commitment.GetBackupTransactionScheduler(e.executorCommittee.Committee, round)
+	if err != nil {
+		return fmt.Errorf("epoch: error getting backup transaction scheduler: %w", err)
+	}
+	if !scheduler.PublicKey.Equal(sig.PublicKey) {
+		return fmt.Errorf("epoch: signature is not from the backup transaction scheduler at round: %d", round)
+	}
+	return nil
+}
+
 // Group encapsulates communication with a group of nodes in the runtime committees.
 type Group struct {
 	sync.RWMutex
@@ -549,6 +581,10 @@ func (g *Group) Storage() storage.Backend {
 	return g.storage
 }
 
+// storageClientMaxWrittenRoots is the maximum number of recently written roots
+// tracked by the storage client.
+const storageClientMaxWrittenRoots = 128
+
 // NewGroup creates a new group.
 func NewGroup(
 	ctx context.Context,
@@ -563,6 +599,15 @@ func NewGroup(
 		return nil, fmt.Errorf("group: failed to create node watcher: %w", err)
 	}
 
+	scOpts := []storageClient.Option{
+		// Route reads for roots written by the workers (e.g., the state root of the
+		// previous round) to storage nodes that have them.
+		storageClient.WithReadYourWrites(storageClientMaxWrittenRoots),
+	}
+	if nodeCache := runtime.StorageNodeCache(); nodeCache != nil {
+		scOpts = append(scOpts, storageClient.WithNodeCache(nodeCache))
+	}
+
 	// TODO: If the current node is a storage node, always include self (oasis-core#3251).
 	sc, err := storageClient.NewForCommittee(
 		ctx,
@@ -570,6 +615,7 @@ func NewGroup(
 		identity,
 		committee.NewFilteredNodeLookup(nodes, committee.TagFilter(TagForCommittee(scheduler.KindStorage))),
 		runtime,
+		scOpts...,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("group: failed to create storage client: %w", err)
@@ -3,6 +3,7 @@ package common
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	flag "github.com/spf13/pflag"
@@ -11,6 +12,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
 	ias "github.com/oasisprotocol/oasis-core/go/ias/api"
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	runtimeHost "github.com/oasisprotocol/oasis-core/go/runtime/host"
@@ -39,9 +41,14 @@ var (
 	// runtime IDs to corresponding resource paths (type of the resource depends on the
 	// provisioner).
 	CfgRuntimePaths = "worker.runtime.paths"
+	// CfgRuntimeVersionPaths configures the paths for specific versions of supported runtimes. The
+	// value should be a map of <runtime ID>@<version> to corresponding resource paths. When the
+	// registered runtime version changes to one of the configured versions, the node switches to
+	// the corresponding runtime resource.
+	CfgRuntimeVersionPaths = "worker.runtime.version_paths"
 	// CfgRuntimeSGXSignatures configures signatures for supported runtimes.
-	// The value should be a map of runtime IDs to corresponding resource
-	// paths.
+	// The value should be a map of runtime IDs (or <runtime ID>@<version> for
+	// specific runtime versions) to corresponding resource paths.
 	CfgRuntimeSGXSignatures = "worker.runtime.sgx.signatures"
 
 	cfgSandboxBinary        = "worker.runtime.sandbox_binary"
@@ -89,6 +96,52 @@ type RuntimeHostConfig struct {
 	// Runtimes contains per-runtime provisioning configuration. Some fields may be omitted as they
 	// are provided when the runtime is provisioned.
 	Runtimes map[common.Namespace]runtimeHost.Config
+
+	// RuntimeVersions contains per-runtime provisioning configuration for specific runtime
+	// versions. If the registered runtime version has an entry, it takes precedence over the
+	// configuration in Runtimes.
+	RuntimeVersions map[common.Namespace]map[version.Version]runtimeHost.Config
+
+	// LoaderVersion is the version of the configured SGX runtime loader (if any).
+	LoaderVersion string
+}
+
+// GetRuntimeConfig returns the provisioning configuration for the given runtime version.
+func (c *RuntimeHostConfig) GetRuntimeConfig(id common.Namespace, ver version.Version) (runtimeHost.Config, bool) {
+	if cfg, ok := c.RuntimeVersions[id][ver]; ok {
+		return cfg, true
+	}
+	cfg, ok := c.Runtimes[id]
+	return cfg, ok
+}
+
+// HasRuntimeVersion returns true iff a provisioning configuration exists for the given runtime
+// version.
+func (c *RuntimeHostConfig) HasRuntimeVersion(id common.Namespace, ver version.Version) bool {
+	_, ok := c.RuntimeVersions[id][ver]
+	return ok
+}
+
+func newRuntimeHostConfig(id common.Namespace, path, sigPath string) runtimeHost.Config {
+	runtimeHostCfg := runtimeHost.Config{
+		RuntimeID: id,
+		Path:      path,
+	}
+
+	// This config is SGX specific, but that's all that's supported
+	// right now that needs this anyway, the non-SGX provisioner
+	// currently ignores this.
+	if sigPath != "" {
+		runtimeHostCfg.Extra = &hostSgx.RuntimeExtra{
+			SignaturePath: sigPath,
+		}
+	} else {
+		// HACK HACK HACK: Allow dummy SIGSTRUCT generation.
+		runtimeHostCfg.Extra = &hostSgx.RuntimeExtra{
+			UnsafeDebugGenerateSigstruct: true,
+		}
+	}
+	return runtimeHostCfg
 }
 
 // GetNodeAddresses returns worker node addresses.
@@ -182,8 +235,19 @@ func NewConfig(ias ias.Endpoint) (*Config, error) {
 				return nil, fmt.Errorf("failed to create runtime provisioner: %w", err)
 			}
 
+			loaderPath := viper.GetString(CfgRuntimeSGXLoader)
+			if loaderPath != "" {
+				// Failure to determine the loader version should not prevent the node from starting
+				// as the version is only used for reporting.
+				if rh.LoaderVersion, err = hostSgx.GetLoaderVersion(loaderPath); err != nil {
+					cfg.logger.Warn("failed to determine runtime loader version",
+						"err", err,
+					)
+				}
+			}
+
 			rh.Provisioners[node.TEEHardwareIntelSGX], err = hostSgx.New(hostSgx.Config{
-				LoaderPath:        viper.GetString(CfgRuntimeSGXLoader),
+				LoaderPath:        loaderPath,
 				IAS:               ias,
 				SandboxBinaryPath: sandboxBinary,
 				InsecureNoSandbox: insecureNoSandbox,
@@ -204,29 +268,36 @@ func NewConfig(ias ias.Endpoint) (*Config, error) {
 				return nil, fmt.Errorf("bad runtime identifier '%s': %w", runtimeID, err)
 			}
 
-			runtimeHostCfg := runtimeHost.Config{
-				RuntimeID: id,
-				Path:      path,
+			rh.Runtimes[id] = newRuntimeHostConfig(id, path, runtimeSGXSignatures[runtimeID])
+		}
+		if len(rh.Runtimes) == 0 {
+			return nil, fmt.Errorf("no runtimes configured")
+		}
+
+		// Configure specific runtime versions.
+		rh.RuntimeVersions = make(map[common.Namespace]map[version.Version]runtimeHost.Config)
+		for key, path := range viper.GetStringMapString(CfgRuntimeVersionPaths) {
+			split := strings.SplitN(key, "@", 2)
+			if len(split) != 2 {
+				return nil, fmt.Errorf("malformed runtime version '%s': expected <runtime ID>@<version>", key)
 			}
 
-			// This config is SGX specific, but that's all that's supported
-			// right now that needs this anyway, the non-SGX provisioner
-			// currently ignores this.
-			if sigPath := runtimeSGXSignatures[runtimeID]; sigPath != "" {
-				runtimeHostCfg.Extra = &hostSgx.RuntimeExtra{
-					SignaturePath: sigPath,
-				}
-			} else {
-				// HACK HACK HACK: Allow dummy SIGSTRUCT generation.
-				runtimeHostCfg.Extra = &hostSgx.RuntimeExtra{
-					UnsafeDebugGenerateSigstruct: true,
-				}
+			var id common.Namespace
+			if err = id.UnmarshalHex(split[0]); err != nil {
+				return nil, fmt.Errorf("bad runtime identifier '%s': %w", split[0], err)
+			}
+			if _, ok := rh.Runtimes[id]; !ok {
+				return nil, fmt.Errorf("runtime version configured for unsupported runtime '%s'", id)
+			}
+			var ver version.Version
+			if ver, err = version.FromString(split[1]); err != nil {
+				return nil, fmt.Errorf("bad runtime version '%s': %w", split[1], err)
 			}
 
-			rh.Runtimes[id] = runtimeHostCfg
-		}
-		if len(rh.Runtimes) == 0 {
-			return nil, fmt.Errorf("no runtimes configured")
+			if rh.RuntimeVersions[id] == nil {
+				rh.RuntimeVersions[id] = make(map[version.Version]runtimeHost.Config)
+			}
+			rh.RuntimeVersions[id][ver] = newRuntimeHostConfig(id, path, runtimeSGXSignatures[key])
 		}
 
 		cfg.RuntimeHost = &rh
@@ -243,6 +314,7 @@ func init() {
 	Flags.String(CfgRuntimeProvisioner, RuntimeProvisionerSandboxed, "Runtime provisioner to use")
 	Flags.String(CfgRuntimeSGXLoader, "", "(for SGX runtimes) Path to SGXS runtime loader binary")
 	Flags.StringToString(CfgRuntimePaths, nil, "Paths to runtime resources (format: <rt1-ID>=<path>,<rt2-ID>=<path>)")
+	Flags.StringToString(CfgRuntimeVersionPaths, nil, "Paths to resources for specific runtime versions (format: <rt1-ID>@<version>=<path>,<rt2-ID>@<version>=<path>)")
 	Flags.StringToString(CfgRuntimeSGXSignatures, nil, "(for SGX runtimes) Paths to signatures (format: <rt1-ID>=<path>,<rt2-ID>=<path>")
 
 	Flags.String(cfgSandboxBinary, "/usr/bin/bwrap", "Path to the sandbox binary (bubblewrap)")
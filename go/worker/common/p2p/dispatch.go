@@ -85,6 +85,22 @@ func (h *topicHandler) topicMessageValidator(ctx context.Context, unused core.Pe
 		"received_from", envelope.ReceivedFrom,
 	)
 
+	isOwn := peerID == h.p2p.host.ID()
+	if !isOwn {
+		if h.p2p.scorer.isBanned(peerID) {
+			h.logger.Debug("dropping message from banned peer",
+				"peer_id", peerID,
+			)
+			return false
+		}
+		if !h.p2p.scorer.allowMessage(peerID) {
+			h.logger.Warn("dropping message from peer, rate limit exceeded",
+				"peer_id", peerID,
+			)
+			return false
+		}
+	}
+
 	id, err := peerIDToPublicKey(peerID)
 	if err != nil {
 		h.logger.Error("error while extracting public key from peer ID",
@@ -100,6 +116,9 @@ func (h *topicHandler) topicMessageValidator(ctx context.Context, unused core.Pe
 			"err", err,
 			"peer_id", peerID,
 		)
+		if !isOwn {
+			h.p2p.scorer.invalidMessage(peerID)
+		}
 		return false
 	}
 
@@ -1,6 +1,8 @@
 package p2p
 
 import (
+	"time"
+
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
@@ -18,6 +20,13 @@ const (
 	CfgP2PPeerOutboundQueueSize = "worker.p2p.peer_outbound_queue_size"
 	// CfgP2PValidateQueueSize sets the libp2p gossipsub buffer size of the validate queue.
 	CfgP2PValidateQueueSize = "worker.p2p.validate_queue_size"
+
+	// CfgP2PPeerBanThreshold sets the penalty score at which a peer gets banned.
+	CfgP2PPeerBanThreshold = "worker.p2p.peer_ban_threshold"
+	// CfgP2PPeerBanDuration sets the duration of peer bans.
+	CfgP2PPeerBanDuration = "worker.p2p.peer_ban_duration"
+	// CfgP2PPeerRateLimit sets the maximum number of messages per second accepted from a peer.
+	CfgP2PPeerRateLimit = "worker.p2p.peer_rate_limit"
 )
 
 // Enabled reads our enabled flag from viper.
@@ -34,6 +43,9 @@ func init() {
 	Flags.StringSlice(cfgP2pAddresses, []string{}, "Address/port(s) to use for P2P connections when registering this node (if not set, all non-loopback local interfaces will be used)")
 	Flags.Int64(CfgP2PPeerOutboundQueueSize, 32, "Set libp2p gossipsub buffer size for outbound messages")
 	Flags.Int64(CfgP2PValidateQueueSize, 32, "Set libp2p gossipsub buffer size of the validate queue")
+	Flags.Uint64(CfgP2PPeerBanThreshold, 100, "Penalty score at which a peer gets banned")
+	Flags.Duration(CfgP2PPeerBanDuration, 1*time.Hour, "Duration of peer bans")
+	Flags.Uint64(CfgP2PPeerRateLimit, 100, "Maximum number of messages per second accepted from a peer (0 disables)")
 
 	_ = viper.BindPFlags(Flags)
 }
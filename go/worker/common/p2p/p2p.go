@@ -24,9 +24,11 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	registryAPI "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/worker/common/api"
 	"github.com/oasisprotocol/oasis-core/go/worker/common/configparser"
 )
 
@@ -48,6 +50,7 @@ type P2P struct {
 
 	host   core.Host
 	pubsub *pubsub.PubSub
+	scorer *peerScorer
 
 	registerAddresses []multiaddr.Multiaddr
 	topics            map[common.Namespace]*topicHandler
@@ -150,6 +153,17 @@ func (p *P2P) RegisterHandler(runtimeID common.Namespace, handler Handler) {
 	}
 }
 
+// PeerScores returns the overview of peer scores and bans.
+func (p *P2P) PeerScores() *api.PeerScores {
+	return p.scorer.peerScores()
+}
+
+// UnbanPeer removes the ban on the peer with the given libp2p ID and resets
+// its penalty score.
+func (p *P2P) UnbanPeer(id string) error {
+	return p.scorer.unban(id)
+}
+
 func (p *P2P) handleConnection(conn core.Conn) {
 	if conn.Stat().Direction != network.DirInbound {
 		return
@@ -169,7 +183,14 @@ func (p *P2P) topicIDForRuntime(runtimeID common.Namespace) string {
 }
 
 // New creates a new P2P node.
-func New(ctx context.Context, identity *identity.Identity, consensus consensus.Backend) (*P2P, error) {
+//
+// The common store may be nil in which case the peer ban list is not persisted.
+func New(
+	ctx context.Context,
+	identity *identity.Identity,
+	consensus consensus.Backend,
+	store *persistent.CommonStore,
+) (*P2P, error) {
 	// Instantiate the libp2p host.
 	addresses, err := configparser.ParseAddressList(viper.GetStringSlice(cfgP2pAddresses))
 	if err != nil {
@@ -187,6 +208,16 @@ func New(ctx context.Context, identity *identity.Identity, consensus consensus.B
 		registerAddresses = append(registerAddresses, mAddr)
 	}
 
+	scorer, err := newPeerScorer(
+		store,
+		viper.GetUint64(CfgP2PPeerBanThreshold),
+		viper.GetDuration(CfgP2PPeerBanDuration),
+		viper.GetUint64(CfgP2PPeerRateLimit),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	sourceMultiAddr, _ := multiaddr.NewMultiaddr(
 		fmt.Sprintf("/ip4/0.0.0.0/tcp/%d", port),
 	)
@@ -199,10 +230,12 @@ func New(ctx context.Context, identity *identity.Identity, consensus consensus.B
 		ctx,
 		libp2p.ListenAddrs(sourceMultiAddr),
 		libp2p.Identity(signerToPrivKey(identity.P2PSigner)),
+		libp2p.ConnectionGater(scorer),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("worker/common/p2p: failed to initialize libp2p host: %w", err)
 	}
+	scorer.host = host
 
 	// Initialize the gossipsub router.
 	pubsub, err := pubsub.NewGossipSub(
@@ -233,6 +266,7 @@ func New(ctx context.Context, identity *identity.Identity, consensus consensus.B
 		chainContext:      doc.ChainContext(),
 		host:              host,
 		pubsub:            pubsub,
+		scorer:            scorer,
 		registerAddresses: registerAddresses,
 		topics:            make(map[common.Namespace]*topicHandler),
 		logger:            logging.GetLogger("worker/common/p2p"),
@@ -0,0 +1,329 @@
+package p2p
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	core "github.com/libp2p/go-libp2p-core"
+	"github.com/libp2p/go-libp2p-core/control"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
+	"github.com/oasisprotocol/oasis-core/go/worker/common/api"
+)
+
+const (
+	// p2pDBBucketName is the name of the persistent store bucket used by the P2P layer.
+	p2pDBBucketName = "worker/common/p2p"
+
+	// penaltyInvalidMessage is the score penalty for a message that cannot be decoded.
+	penaltyInvalidMessage = 10
+	// penaltyRateLimit is the score penalty for a message that exceeds the rate limit.
+	penaltyRateLimit = 1
+
+	// rateLimitWindow is the window over which the per-peer message rate limit is enforced.
+	rateLimitWindow = time.Second
+)
+
+// bansKey is the persistent store key under which the ban list is kept.
+var bansKey = []byte("bans")
+
+type peerScore struct {
+	score               uint64
+	invalidMessages     uint64
+	rateLimitViolations uint64
+	lastPenalty         time.Time
+
+	windowStart time.Time
+	windowCount uint64
+}
+
+// peerScorer tracks misbehaviour of committee P2P peers and bans peers whose
+// penalty score reaches the ban threshold.
+//
+// The ban list is persisted across restarts in case a persistent store is
+// available. Peers that have not been penalized for the ban duration have their
+// score reset.
+type peerScorer struct {
+	sync.Mutex
+
+	host  core.Host
+	store *persistent.ServiceStore
+
+	scores map[core.PeerID]*peerScore
+	bans   map[core.PeerID]*api.PeerBan
+
+	banThreshold uint64
+	banDuration  time.Duration
+	rateLimit    uint64
+
+	logger *logging.Logger
+}
+
+// isBanned returns true iff the given peer is currently banned.
+func (s *peerScorer) isBanned(peerID core.PeerID) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.isBannedLocked(peerID)
+}
+
+func (s *peerScorer) isBannedLocked(peerID core.PeerID) bool {
+	ban := s.bans[peerID]
+	if ban == nil {
+		return false
+	}
+	if time.Now().After(ban.Until) {
+		delete(s.bans, peerID)
+		s.persistLocked()
+		return false
+	}
+	return true
+}
+
+// allowMessage accounts a message from the given peer against the rate limit
+// and returns false in case the peer exceeded the limit.
+func (s *peerScorer) allowMessage(peerID core.PeerID) bool {
+	if s.rateLimit == 0 {
+		return true
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	ps := s.getScoreLocked(peerID)
+	now := time.Now()
+	if now.Sub(ps.windowStart) >= rateLimitWindow {
+		ps.windowStart = now
+		ps.windowCount = 0
+	}
+	ps.windowCount++
+	if ps.windowCount <= s.rateLimit {
+		return true
+	}
+
+	ps.rateLimitViolations++
+	s.penalizeLocked(peerID, ps, penaltyRateLimit, "rate limit exceeded")
+	return false
+}
+
+// invalidMessage penalizes the given peer for sending an invalid message.
+func (s *peerScorer) invalidMessage(peerID core.PeerID) {
+	s.Lock()
+	defer s.Unlock()
+
+	ps := s.getScoreLocked(peerID)
+	ps.invalidMessages++
+	s.penalizeLocked(peerID, ps, penaltyInvalidMessage, "invalid messages")
+}
+
+func (s *peerScorer) getScoreLocked(peerID core.PeerID) *peerScore {
+	ps := s.scores[peerID]
+	if ps == nil {
+		ps = &peerScore{}
+		s.scores[peerID] = ps
+	}
+	return ps
+}
+
+func (s *peerScorer) penalizeLocked(peerID core.PeerID, ps *peerScore, penalty uint64, reason string) {
+	now := time.Now()
+	if now.Sub(ps.lastPenalty) >= s.banDuration {
+		ps.score = 0
+	}
+	ps.score += penalty
+	ps.lastPenalty = now
+
+	if ps.score < s.banThreshold {
+		return
+	}
+
+	s.logger.Warn("banning peer",
+		"peer_id", peerID,
+		"reason", reason,
+		"score", ps.score,
+		"invalid_messages", ps.invalidMessages,
+		"rate_limit_violations", ps.rateLimitViolations,
+	)
+
+	delete(s.scores, peerID)
+	s.bans[peerID] = &api.PeerBan{
+		PeerID: peerID.Pretty(),
+		Reason: reason,
+		Until:  now.Add(s.banDuration),
+	}
+	s.persistLocked()
+
+	if s.host != nil {
+		go func() {
+			_ = s.host.Network().ClosePeer(peerID)
+		}()
+	}
+}
+
+// unban removes the ban on the given peer and resets its score.
+func (s *peerScorer) unban(id string) error {
+	peerID, err := peer.Decode(id)
+	if err != nil {
+		return fmt.Errorf("worker/common/p2p: malformed peer ID: %w", err)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.scores, peerID)
+	if _, ok := s.bans[peerID]; !ok {
+		return nil
+	}
+	delete(s.bans, peerID)
+	s.persistLocked()
+
+	s.logger.Info("peer unbanned",
+		"peer_id", peerID,
+	)
+
+	return nil
+}
+
+// peerScores returns the overview of peer scores and bans.
+func (s *peerScorer) peerScores() *api.PeerScores {
+	s.Lock()
+	defer s.Unlock()
+
+	ps := &api.PeerScores{
+		Scores: []*api.PeerScore{},
+		Banned: []*api.PeerBan{},
+	}
+	for peerID, score := range s.scores {
+		if score.score == 0 {
+			continue
+		}
+		ps.Scores = append(ps.Scores, &api.PeerScore{
+			PeerID:              peerID.Pretty(),
+			Score:               score.score,
+			InvalidMessages:     score.invalidMessages,
+			RateLimitViolations: score.rateLimitViolations,
+		})
+	}
+	for peerID := range s.bans {
+		if !s.isBannedLocked(peerID) {
+			continue
+		}
+		ban := *s.bans[peerID]
+		ps.Banned = append(ps.Banned, &ban)
+	}
+	sort.Slice(ps.Scores, func(i, j int) bool { return ps.Scores[i].PeerID < ps.Scores[j].PeerID })
+	sort.Slice(ps.Banned, func(i, j int) bool { return ps.Banned[i].PeerID < ps.Banned[j].PeerID })
+
+	return ps
+}
+
+func (s *peerScorer) persistLocked() {
+	if s.store == nil {
+		return
+	}
+
+	bans := make([]*api.PeerBan, 0, len(s.bans))
+	for _, ban := range s.bans {
+		bans = append(bans, ban)
+	}
+	if err := s.store.PutCBOR(bansKey, bans); err != nil {
+		s.logger.Error("failed to persist peer ban list",
+			"err", err,
+		)
+	}
+}
+
+func (s *peerScorer) load() error {
+	if s.store == nil {
+		return nil
+	}
+
+	var bans []*api.PeerBan
+	switch err := s.store.GetCBOR(bansKey, &bans); err {
+	case nil:
+	case persistent.ErrNotFound:
+		return nil
+	default:
+		return fmt.Errorf("worker/common/p2p: failed to load peer ban list: %w", err)
+	}
+
+	now := time.Now()
+	for _, ban := range bans {
+		if now.After(ban.Until) {
+			continue
+		}
+		peerID, err := peer.Decode(ban.PeerID)
+		if err != nil {
+			s.logger.Warn("skipping malformed peer ID in persisted ban list",
+				"err", err,
+				"peer_id", ban.PeerID,
+			)
+			continue
+		}
+		s.bans[peerID] = ban
+	}
+
+	s.logger.Info("loaded persisted peer ban list",
+		"num_bans", len(s.bans),
+	)
+
+	return nil
+}
+
+// Implements connmgr.ConnectionGater.
+func (s *peerScorer) InterceptPeerDial(peerID core.PeerID) bool {
+	return !s.isBanned(peerID)
+}
+
+// Implements connmgr.ConnectionGater.
+func (s *peerScorer) InterceptAddrDial(peerID core.PeerID, addr multiaddr.Multiaddr) bool {
+	return !s.isBanned(peerID)
+}
+
+// Implements connmgr.ConnectionGater.
+func (s *peerScorer) InterceptAccept(addrs network.ConnMultiaddrs) bool {
+	return true
+}
+
+// Implements connmgr.ConnectionGater.
+func (s *peerScorer) InterceptSecured(dir network.Direction, peerID core.PeerID, addrs network.ConnMultiaddrs) bool {
+	return !s.isBanned(peerID)
+}
+
+// Implements connmgr.ConnectionGater.
+func (s *peerScorer) InterceptUpgraded(conn network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
+
+func newPeerScorer(
+	store *persistent.CommonStore,
+	banThreshold uint64,
+	banDuration time.Duration,
+	rateLimit uint64,
+) (*peerScorer, error) {
+	s := &peerScorer{
+		scores:       make(map[core.PeerID]*peerScore),
+		bans:         make(map[core.PeerID]*api.PeerBan),
+		banThreshold: banThreshold,
+		banDuration:  banDuration,
+		rateLimit:    rateLimit,
+		logger:       logging.GetLogger("worker/common/p2p/scoring"),
+	}
+	if store != nil {
+		var err error
+		if s.store, err = store.GetServiceStore(p2pDBBucketName); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
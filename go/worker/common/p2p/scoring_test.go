@@ -0,0 +1,53 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+)
+
+func TestPeerScorer(t *testing.T) {
+	require := require.New(t)
+
+	s, err := newPeerScorer(nil, 3*penaltyInvalidMessage, time.Hour, 2)
+	require.NoError(err, "newPeerScorer")
+
+	peerID, err := publicKeyToPeerID(signature.NewPublicKey("47aadd91516ac548decdb436fde957992610facc09ba2f850da0fe1b2be96119"))
+	require.NoError(err, "publicKeyToPeerID")
+
+	// Rate limit.
+	require.True(s.allowMessage(peerID), "first message should be allowed")
+	require.True(s.allowMessage(peerID), "second message should be allowed")
+	require.False(s.allowMessage(peerID), "third message should exceed the rate limit")
+	require.False(s.isBanned(peerID), "peer should not be banned yet")
+
+	// Invalid messages.
+	s.invalidMessage(peerID)
+	s.invalidMessage(peerID)
+	require.False(s.isBanned(peerID), "peer should not be banned yet")
+	scores := s.peerScores()
+	require.Len(scores.Scores, 1)
+	require.EqualValues(2*penaltyInvalidMessage+penaltyRateLimit, scores.Scores[0].Score)
+	require.EqualValues(2, scores.Scores[0].InvalidMessages)
+	require.EqualValues(1, scores.Scores[0].RateLimitViolations)
+
+	s.invalidMessage(peerID)
+	require.True(s.isBanned(peerID), "peer should be banned")
+	require.False(s.InterceptPeerDial(peerID), "dials to banned peer should be rejected")
+	scores = s.peerScores()
+	require.Len(scores.Scores, 0)
+	require.Len(scores.Banned, 1)
+	require.Equal(peerID.Pretty(), scores.Banned[0].PeerID)
+
+	// Unban.
+	err = s.unban(scores.Banned[0].PeerID)
+	require.NoError(err, "unban")
+	require.False(s.isBanned(peerID), "peer should no longer be banned")
+	require.True(s.InterceptPeerDial(peerID), "dials to unbanned peer should be allowed")
+
+	err = s.unban("not a peer id")
+	require.Error(err, "unban should fail with malformed peer ID")
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
 	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
@@ -18,11 +20,15 @@ type RuntimeHostNode struct {
 	factory  RuntimeHostHandlerFactory
 	notifier protocol.Notifier
 
-	runtime host.Runtime
+	runtime        host.Runtime
+	runtimeVersion version.Version
 }
 
 // ProvisionHostedRuntime provisions the configured runtime.
 //
+// In case a runtime resource is configured for the currently registered runtime version, that
+// resource is used. Otherwise the default runtime resource is used.
+//
 // This method may return before the runtime is fully provisioned. The returned runtime will not be
 // started automatically, you must call Start explicitly.
 func (n *RuntimeHostNode) ProvisionHostedRuntime(ctx context.Context) (host.Runtime, protocol.Notifier, error) {
@@ -37,7 +43,7 @@ func (n *RuntimeHostNode) ProvisionHostedRuntime(ctx context.Context) (host.Runt
 	}
 
 	// Get a copy of the configuration template for the given runtime and apply updates.
-	cfg, ok := n.cfg.Runtimes[rt.ID]
+	cfg, ok := n.cfg.GetRuntimeConfig(rt.ID, rt.Version.Version)
 	if !ok {
 		return nil, nil, fmt.Errorf("missing runtime host configuration for runtime '%s'", rt.ID)
 	}
@@ -52,9 +58,13 @@ func (n *RuntimeHostNode) ProvisionHostedRuntime(ctx context.Context) (host.Runt
 
 	n.Lock()
 	n.runtime = prt
+	n.runtimeVersion = rt.Version.Version
 	n.notifier = notifier
 	n.Unlock()
 
+	// Make the hosted runtime available to other local services (e.g., the runtime client).
+	n.factory.GetRuntime().SetHostedRuntime(prt)
+
 	return prt, notifier, nil
 }
 
@@ -66,6 +76,19 @@ func (n *RuntimeHostNode) GetHostedRuntime() host.Runtime {
 	return rt
 }
 
+// IsRuntimeUpgradeAvailable returns true iff the given registry descriptor has a different runtime
+// version than the currently provisioned runtime and a runtime resource is configured for the new
+// version, so that the hosted runtime can be switched over.
+func (n *RuntimeHostNode) IsRuntimeUpgradeAvailable(rt *registry.Runtime) bool {
+	n.Lock()
+	defer n.Unlock()
+
+	if n.runtime == nil || n.runtimeVersion == rt.Version.Version {
+		return false
+	}
+	return n.cfg.HasRuntimeVersion(rt.ID, rt.Version.Version)
+}
+
 // RuntimeHostHandlerFactory is an interface that can be used to create new runtime handlers and
 // notifiers when provisioning hosted runtimes.
 type RuntimeHostHandlerFactory interface {
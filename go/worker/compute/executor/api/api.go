@@ -1,6 +1,77 @@
+// Package api defines the executor worker API.
 package api
 
+import (
+	"context"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+)
+
+// ModuleName is the executor worker module name.
+const ModuleName = "worker/executor"
+
+var (
+	// ErrRuntimeNotFound is the error returned when the caller references an unknown runtime.
+	ErrRuntimeNotFound = errors.New(ModuleName, 1, "worker/executor: runtime not found")
+
+	// ErrNoBatch is the error returned when the given round did not process a batch.
+	ErrNoBatch = errors.New(ModuleName, 2, "worker/executor: round did not process a batch")
+)
+
 // Tx is a runtime transaction being sent to the executor node.
 type Tx struct {
 	Data []byte `json:"data"`
 }
+
+// ExecutorWorker is the executor worker control API interface.
+type ExecutorWorker interface {
+	// ReplayBatch replays the input batch of a historical round through the locally
+	// hosted runtime and reports any divergence from the recorded results.
+	ReplayBatch(ctx context.Context, request *ReplayBatchRequest) (*ReplayBatchResponse, error)
+}
+
+// ReplayBatchRequest is a ReplayBatch request.
+type ReplayBatchRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	Round     uint64           `json:"round"`
+}
+
+// ReplayBatchResponse is a ReplayBatch response.
+type ReplayBatchResponse struct {
+	// Round is the replayed round.
+	Round uint64 `json:"round"`
+	// BatchSize is the number of transactions in the replayed batch.
+	BatchSize uint64 `json:"batch_size"`
+
+	// InputIORoot is the I/O root containing only the inputs of the replayed batch.
+	InputIORoot hash.Hash `json:"input_io_root"`
+	// InputStateRoot is the state root the batch was executed against.
+	InputStateRoot hash.Hash `json:"input_state_root"`
+
+	// RecordedIORoot is the I/O root recorded in the round's block.
+	RecordedIORoot hash.Hash `json:"recorded_io_root"`
+	// RecordedStateRoot is the state root recorded in the round's block.
+	RecordedStateRoot hash.Hash `json:"recorded_state_root"`
+
+	// ComputedIORoot is the I/O root computed by the local runtime.
+	ComputedIORoot hash.Hash `json:"computed_io_root"`
+	// ComputedStateRoot is the state root computed by the local runtime.
+	ComputedStateRoot hash.Hash `json:"computed_state_root"`
+}
+
+// IORootDiverged returns true iff the computed I/O root differs from the recorded one.
+func (r *ReplayBatchResponse) IORootDiverged() bool {
+	return !r.ComputedIORoot.Equal(&r.RecordedIORoot)
+}
+
+// StateRootDiverged returns true iff the computed state root differs from the recorded one.
+func (r *ReplayBatchResponse) StateRootDiverged() bool {
+	return !r.ComputedStateRoot.Equal(&r.RecordedStateRoot)
+}
+
+// Diverged returns true iff any of the computed roots differ from the recorded ones.
+func (r *ReplayBatchResponse) Diverged() bool {
+	return r.IORootDiverged() || r.StateRootDiverged()
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+)
+
+var (
+	// serviceName is the gRPC service name.
+	serviceName = cmnGrpc.NewServiceName("ExecutorWorker")
+
+	// methodReplayBatch is the ReplayBatch method.
+	methodReplayBatch = serviceName.NewMethod("ReplayBatch", &ReplayBatchRequest{})
+
+	// serviceDesc is the gRPC service descriptor.
+	serviceDesc = grpc.ServiceDesc{
+		ServiceName: string(serviceName),
+		HandlerType: (*ExecutorWorker)(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: methodReplayBatch.ShortName(),
+				Handler:    handlerReplayBatch,
+			},
+		},
+		Streams: []grpc.StreamDesc{},
+	}
+)
+
+func handlerReplayBatch( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	rq := new(ReplayBatchRequest)
+	if err := dec(rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorWorker).ReplayBatch(ctx, rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodReplayBatch.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorWorker).ReplayBatch(ctx, req.(*ReplayBatchRequest))
+	}
+	return interceptor(ctx, rq, info, handler)
+}
+
+// RegisterService registers a new executor worker service with the given gRPC server.
+func RegisterService(server *grpc.Server, service ExecutorWorker) {
+	server.RegisterService(&serviceDesc, service)
+}
+
+type executorWorkerClient struct {
+	conn *grpc.ClientConn
+}
+
+func (c *executorWorkerClient) ReplayBatch(ctx context.Context, req *ReplayBatchRequest) (*ReplayBatchResponse, error) {
+	var rsp ReplayBatchResponse
+	if err := c.conn.Invoke(ctx, methodReplayBatch.FullName(), req, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+// NewExecutorWorkerClient creates a new gRPC executor worker client service.
+func NewExecutorWorkerClient(c *grpc.ClientConn) ExecutorWorker {
+	return &executorWorkerClient{c}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/tracing"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	epochtime "github.com/oasisprotocol/oasis-core/go/epochtime/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
@@ -58,6 +59,10 @@ var (
 
 	// Duration to wait before submitting the propose timeout request.
 	proposeTimeoutDelay = 2 * time.Second
+
+	// Number of times the age of queued transactions is checked per configured
+	// batch flush transaction age.
+	txAgeChecksPerFlushTxAge = 4
 )
 
 var (
@@ -151,7 +156,8 @@ type Node struct { // nolint: maligned
 
 	// Guarded by .commonNode.CrossNode.
 	proposingTimeout bool
-	prevEpochWorker  bool
+	roundEscalated   bool
+	prevEpochMember  bool
 
 	commonNode   *committee.Node
 	commonCfg    commonWorker.Config
@@ -245,8 +251,11 @@ func (n *Node) HandlePeerMessage(ctx context.Context, message *p2p.Message, isOw
 		// Note: if an epoch transition is just about to happen we can be out of
 		// the committee by the time we queue the transaction, but this is fine
 		// as scheduling is aware of this.
-		if !n.commonNode.Group.GetEpochSnapshot().IsExecutorWorker() {
-			n.logger.Debug("unable to handle transaction message, not execution worker",
+		//
+		// Backup workers also queue transactions as they may need to propose
+		// a batch in case a round is escalated after a proposer timeout.
+		if !n.commonNode.Group.GetEpochSnapshot().IsExecutorMember() {
+			n.logger.Debug("unable to handle transaction message, not execution committee member",
 				"current_epoch", n.commonNode.Group.GetEpochSnapshot().GetEpochNumber(),
 			)
 			return true, nil
@@ -281,13 +290,14 @@ func (n *Node) HandlePeerMessage(ctx context.Context, message *p2p.Message, isOw
 		epoch := n.commonNode.Group.GetEpochSnapshot()
 		n.commonNode.CrossNode.Lock()
 		round := n.commonNode.CurrentBlock.Header.Round
+		escalated := n.isRoundEscalatedLocked()
 		n.commonNode.CrossNode.Unlock()
 
 		// Before opening the signed dispatch message, verify that it was
-		// actually signed by the current transaction scheduler.
-		if err := epoch.VerifyTxnSchedulerSignature(sbd.Signature, round); err != nil {
-			// Not signed by a current txn scheduler!
-			return false, errMsgFromNonTxnSched
+		// actually signed by the current transaction scheduler (or by the
+		// backup transaction scheduler in case the round has been escalated).
+		if err := verifyTxnSchedulerSignature(epoch, sbd.Signature, round, escalated); err != nil {
+			return false, err
 		}
 
 		// Transaction scheduler checks out, open the signed dispatch message
@@ -347,14 +357,10 @@ func (n *Node) queueBatchBlocking(
 		return errInvalidReceipt
 	}
 
-	receiptBody := storage.ReceiptBody{
-		Version:   1,
-		Namespace: hdr.Namespace,
-		Round:     hdr.Round + 1,
-		Roots:     []hash.Hash{ioRootHash},
-	}
-	if !signature.VerifyManyToOne(storage.ReceiptSignatureContext, cbor.Marshal(receiptBody), storageSignatures) {
-		n.logger.Warn("received invalid storage receipt signature in external batch")
+	if err = commitment.VerifyStorageReceiptSignatures(hdr.Namespace, hdr.Round+1, []hash.Hash{ioRootHash}, storageSignatures); err != nil {
+		n.logger.Warn("received invalid storage receipt signature in external batch",
+			"err", err,
+		)
 		return errInvalidReceipt
 	}
 
@@ -448,19 +454,20 @@ func (n *Node) HandleEpochTransitionLocked(epoch *committee.EpochSnapshot) {
 	}
 
 	switch {
-	case epoch.IsExecutorWorker():
-		if !n.prevEpochWorker {
+	case epoch.IsExecutorMember():
+		if !n.prevEpochMember {
 			// Clear incoming queue and cache of any stale transactions in case
 			// we were not part of the compute committee in previous epoch.
 			n.clearQueuedTxs()
 		}
-		fallthrough
-	case epoch.IsExecutorBackupWorker():
 		n.transitionLocked(StateWaitingForBatch{})
 	default:
 		n.transitionLocked(StateNotReady{})
 	}
-	n.prevEpochWorker = epoch.IsExecutorWorker()
+	n.prevEpochMember = epoch.IsExecutorMember()
+
+	// Make sure any pending runtime upgrades are checked.
+	n.bumpReselect()
 }
 
 // HandleNewBlockEarlyLocked implements NodeHooks.
@@ -515,6 +522,11 @@ func (n *Node) HandleNewBlockLocked(blk *block.Block) {
 			"round", header.Round,
 			"header_hash", header.EncodedHash(),
 		)
+		if header.HeaderType == block.Normal && header.IORoot.Equal(&state.batch.ioRoot.Hash) {
+			// Remove the batch processed by the workers from our queue so that it does not
+			// get proposed again in case we become the backup transaction scheduler.
+			go n.removeFinalizedBatch(n.ctx, state.batch)
+		}
 		n.transitionLocked(StateWaitingForBatch{})
 	case StateWaitingForFinalize:
 		func() {
@@ -556,8 +568,10 @@ func (n *Node) HandleNewBlockLocked(blk *block.Block) {
 		}()
 	}
 
-	// Clear the potentially set "is proposing timeout" flag from the previous round.
+	// Clear the potentially set "is proposing timeout" and "round escalated" flags from the
+	// previous round.
 	n.proposingTimeout = false
+	n.roundEscalated = false
 
 	// Check if we are a proposer and if so try to immediately schedule a new batch.
 	if n.commonNode.Group.GetEpochSnapshot().IsTransactionScheduler(blk.Header.Round) {
@@ -673,6 +687,30 @@ func (n *Node) removeTxBatch(batch [][]byte) error {
 	return nil
 }
 
+// removeFinalizedBatch resolves a batch finalized by the other executor committee
+// members and removes it from the incoming queue.
+func (n *Node) removeFinalizedBatch(ctx context.Context, batch *unresolvedBatch) {
+	resolvedBatch, err := batch.resolve(ctx, n.commonNode.Group.Storage())
+	if err != nil {
+		n.logger.Warn("failed to resolve finalized batch",
+			"err", err,
+			"batch", batch,
+		)
+		return
+	}
+
+	n.logger.Debug("removing finalized batch from queue",
+		"batch", resolvedBatch,
+		"io_root", batch.ioRoot,
+	)
+	if err = n.removeTxBatch(resolvedBatch); err != nil {
+		n.logger.Warn("failed removing finalized batch from queue",
+			"err", err,
+			"batch", resolvedBatch,
+		)
+	}
+}
+
 func (n *Node) proposeTimeoutLocked() error {
 	// Do not propose a timeout if we are already proposing it.
 	// The flag will get cleared on the next round or if the propose timeout
@@ -767,6 +805,9 @@ func (n *Node) Dispatch(batch transaction.RawBatch) error {
 		switch {
 		case epoch.IsTransactionScheduler(round):
 			// Continues bellow.
+		case epoch.IsBackupTransactionScheduler(round) && n.isRoundEscalatedLocked():
+			// Round has been escalated to the backup workers after a proposer
+			// timeout, the backup transaction scheduler proposes the batch.
 		case epoch.IsExecutorWorker():
 			// If we are an executor and not a scheduler try proposing a timeout.
 			err := n.proposeTimeoutLocked()
@@ -920,6 +961,36 @@ func (n *Node) Dispatch(batch transaction.RawBatch) error {
 	return nil
 }
 
+// isRoundEscalatedLocked returns true iff the current round has been escalated to
+// the backup workers after a proposer timeout.
+//
+// Guarded by n.commonNode.CrossNode.
+func (n *Node) isRoundEscalatedLocked() bool {
+	return n.roundEscalated
+}
+
+// txnSchedulerSignatureVerifier verifies transaction scheduler signatures.
+type txnSchedulerSignatureVerifier interface {
+	VerifyTxnSchedulerSignature(sig signature.Signature, round uint64) error
+	VerifyBackupTxnSchedulerSignature(sig signature.Signature, round uint64) error
+}
+
+// verifyTxnSchedulerSignature verifies that the given signature comes from the transaction
+// scheduler at the given round. In case the round has been escalated after a proposer timeout,
+// only signatures of the backup transaction scheduler are accepted, matching what the commitment
+// pool accepts once backup scheduling is enabled.
+func verifyTxnSchedulerSignature(v txnSchedulerSignatureVerifier, sig signature.Signature, round uint64, escalated bool) error {
+	verify := v.VerifyTxnSchedulerSignature
+	if escalated {
+		verify = v.VerifyBackupTxnSchedulerSignature
+	}
+	if err := verify(sig, round); err != nil {
+		// Not signed by a current txn scheduler!
+		return errMsgFromNonTxnSched
+	}
+	return nil
+}
+
 // Guarded by n.commonNode.CrossNode.
 func (n *Node) maybeStartProcessingBatchLocked(batch *unresolvedBatch) {
 	epoch := n.commonNode.Group.GetEpochSnapshot()
@@ -1238,6 +1309,12 @@ func (n *Node) HandleNewEventLocked(ev *roothash.Event) {
 
 		discrepancyDetectedCount.With(n.getMetricLabels()).Inc()
 
+		if ev.ExecutionDiscrepancyDetected.ProposerTimeout {
+			// Batches proposed by the backup transaction scheduler are only accepted
+			// from now on until the end of the round.
+			n.roundEscalated = true
+		}
+
 		if !n.commonNode.Group.GetEpochSnapshot().IsExecutorBackupWorker() {
 			return
 		}
@@ -1249,6 +1326,16 @@ func (n *Node) HandleNewEventLocked(ev *roothash.Event) {
 			// record the received event and keep waiting for the batch.
 			s.pendingEvent = ev.ExecutionDiscrepancyDetected
 			n.transitionLocked(s)
+
+			// In case the round has been escalated after a proposer timeout and
+			// we are the backup transaction scheduler, propose a batch.
+			round := n.commonNode.CurrentBlock.Header.Round
+			if s.pendingEvent.ProposerTimeout && n.commonNode.Group.GetEpochSnapshot().IsBackupTransactionScheduler(round) {
+				n.logger.Info("round escalated, proposing a batch as backup transaction scheduler",
+					"round", round,
+				)
+				go n.scheduler.Flush(false)
+			}
 			return
 		case StateWaitingForEvent:
 			state = s
@@ -1401,38 +1488,17 @@ func (n *Node) worker() {
 	n.logger.Info("starting committee node")
 
 	// Provision the hosted runtime.
-	hrt, hrtNotifier, err := n.ProvisionHostedRuntime(n.ctx)
+	hrt, err := n.startHostedRuntime()
 	if err != nil {
-		n.logger.Error("failed to provision hosted runtime",
-			"err", err,
-		)
-		return
-	}
-
-	hrtEventCh, hrtSub, err := hrt.WatchEvents(n.ctx)
-	if err != nil {
-		n.logger.Error("failed to subscribe to hosted runtime events",
-			"err", err,
-		)
-		return
-	}
-	defer hrtSub.Close()
-
-	if err = hrt.Start(); err != nil {
 		n.logger.Error("failed to start hosted runtime",
 			"err", err,
 		)
 		return
 	}
-	defer hrt.Stop()
-
-	if err = hrtNotifier.Start(); err != nil {
-		n.logger.Error("failed to start runtime notifier",
-			"err", err,
-		)
-		return
-	}
-	defer hrtNotifier.Stop()
+	defer func() {
+		// The hosted runtime may be replaced on upgrades, so make sure to stop the current one.
+		hrt.stop()
+	}()
 
 	// Initialize transaction scheduling algorithm.
 	runtime, err := n.commonNode.Runtime.RegistryDescriptor(n.ctx)
@@ -1465,6 +1531,13 @@ func (n *Node) worker() {
 	// Check incoming queue every FlushTimeout.
 	txnScheduleTicker := time.NewTicker(runtime.TxnScheduler.BatchFlushTimeout)
 	defer txnScheduleTicker.Stop()
+	// Check the age of queued transactions in case it is used as a flush trigger.
+	var txAgeCheckCh <-chan time.Time
+	if txAge := runtime.TxnScheduler.BatchFlushTxAge; txAge > 0 {
+		txAgeCheckTicker := time.NewTicker(txAge / txAgeChecksPerFlushTxAge)
+		defer txAgeCheckTicker.Stop()
+		txAgeCheckCh = txAgeCheckTicker.C
+	}
 
 	// Watch runtime descriptor updates.
 	rtCh, rtSub, err := n.commonNode.Runtime.WatchRegistryDescriptor()
@@ -1479,6 +1552,7 @@ func (n *Node) worker() {
 	// We are initialized.
 	close(n.initCh)
 
+	var pendingUpgrade *runtimeUpgrade
 	for {
 		// Check if we are currently processing a batch. In this case, we also
 		// need to select over the result channel.
@@ -1497,7 +1571,7 @@ func (n *Node) worker() {
 		case <-n.stopCh:
 			n.logger.Info("termination requested")
 			return
-		case ev := <-hrtEventCh:
+		case ev := <-hrt.eventCh:
 			n.handleRuntimeHostEvent(ev)
 		case batch := <-processingDoneCh:
 			// Batch processing has finished.
@@ -1515,13 +1589,117 @@ func (n *Node) worker() {
 				)
 				return
 			}
+
+			// Schedule a switch to the new runtime version in case one is available.
+			if n.IsRuntimeUpgradeAvailable(runtime) {
+				pendingUpgrade = &runtimeUpgrade{
+					version: runtime.Version.Version,
+					epoch:   n.commonNode.Group.GetEpochSnapshot().GetEpochNumber() + 1,
+				}
+
+				n.logger.Info("scheduled hosted runtime upgrade",
+					"version", pendingUpgrade.version,
+					"activation_epoch", pendingUpgrade.epoch,
+				)
+			}
 		case <-txnScheduleTicker.C:
 			// Flush a batch from algorithm.
 			n.scheduler.Flush(true)
+		case <-txAgeCheckCh:
+			// Flush a batch from algorithm in case any flush trigger fired.
+			n.scheduler.Flush(false)
 		case <-n.reselect:
 			// Recalculate select set.
 		}
+
+		if pendingUpgrade != nil && n.isRuntimeUpgradeReady(pendingUpgrade) {
+			n.logger.Info("upgrading hosted runtime",
+				"version", pendingUpgrade.version,
+			)
+
+			var newHrt *hostedRuntime
+			if newHrt, err = n.startHostedRuntime(); err != nil {
+				// Keep running the current runtime, the upgrade will be retried once the registry
+				// descriptor is updated again.
+				n.logger.Error("failed to start upgraded hosted runtime",
+					"err", err,
+					"version", pendingUpgrade.version,
+				)
+				pendingUpgrade = nil
+				continue
+			}
+			hrt.stop()
+			hrt = newHrt
+			pendingUpgrade = nil
+		}
+	}
+}
+
+// runtimeUpgrade is a pending hosted runtime upgrade.
+type runtimeUpgrade struct {
+	// version is the runtime version to upgrade to.
+	version version.Version
+	// epoch is the epoch at which the upgrade becomes active.
+	epoch epochtime.EpochTime
+}
+
+// hostedRuntime is a started hosted runtime.
+type hostedRuntime struct {
+	rt       host.Runtime
+	notifier protocol.Notifier
+	eventCh  <-chan *host.Event
+	sub      pubsub.ClosableSubscription
+}
+
+func (h *hostedRuntime) stop() {
+	h.notifier.Stop()
+	h.sub.Close()
+	h.rt.Stop()
+}
+
+// startHostedRuntime provisions and starts the hosted runtime.
+func (n *Node) startHostedRuntime() (*hostedRuntime, error) {
+	rt, notifier, err := n.ProvisionHostedRuntime(n.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to provision hosted runtime: %w", err)
+	}
+
+	eventCh, sub, err := rt.WatchEvents(n.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to hosted runtime events: %w", err)
 	}
+
+	if err = rt.Start(); err != nil {
+		sub.Close()
+		return nil, fmt.Errorf("failed to start hosted runtime: %w", err)
+	}
+
+	if err = notifier.Start(); err != nil {
+		sub.Close()
+		rt.Stop()
+		return nil, fmt.Errorf("failed to start runtime notifier: %w", err)
+	}
+
+	return &hostedRuntime{
+		rt:       rt,
+		notifier: notifier,
+		eventCh:  eventCh,
+		sub:      sub,
+	}, nil
+}
+
+// isRuntimeUpgradeReady checks whether the hosted runtime can be switched to the new version.
+func (n *Node) isRuntimeUpgradeReady(upgrade *runtimeUpgrade) bool {
+	if n.commonNode.Group.GetEpochSnapshot().GetEpochNumber() < upgrade.epoch {
+		return false
+	}
+
+	n.commonNode.CrossNode.Lock()
+	defer n.commonNode.CrossNode.Unlock()
+
+	// Wait for any in-flight batch to be processed before switching.
+	_, processing := n.state.(StateProcessingBatch)
+	return !processing
 }
 
 // NewNode initializes a new executor node.
@@ -0,0 +1,58 @@
+package committee
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+)
+
+type testTxnSchedulerVerifier struct {
+	scheduler       signature.PublicKey
+	backupScheduler signature.PublicKey
+}
+
+func (v *testTxnSchedulerVerifier) VerifyTxnSchedulerSignature(sig signature.Signature, round uint64) error {
+	if !sig.PublicKey.Equal(v.scheduler) {
+		return fmt.Errorf("not the transaction scheduler at round %d", round)
+	}
+	return nil
+}
+
+func (v *testTxnSchedulerVerifier) VerifyBackupTxnSchedulerSignature(sig signature.Signature, round uint64) error {
+	if !sig.PublicKey.Equal(v.backupScheduler) {
+		return fmt.Errorf("not the backup transaction scheduler at round %d", round)
+	}
+	return nil
+}
+
+func TestVerifyTxnSchedulerSignature(t *testing.T) {
+	require := require.New(t)
+
+	v := &testTxnSchedulerVerifier{
+		scheduler:       memorySigner.NewTestSigner("executor test scheduler").Public(),
+		backupScheduler: memorySigner.NewTestSigner("executor test backup scheduler").Public(),
+	}
+	schedulerSig := signature.Signature{PublicKey: v.scheduler}
+	backupSchedulerSig := signature.Signature{PublicKey: v.backupScheduler}
+	otherSig := signature.Signature{PublicKey: memorySigner.NewTestSigner("executor test other").Public()}
+
+	// Round that has not been escalated.
+	err := verifyTxnSchedulerSignature(v, schedulerSig, 1, false)
+	require.NoError(err, "batches from the transaction scheduler should be accepted")
+	err = verifyTxnSchedulerSignature(v, backupSchedulerSig, 1, false)
+	require.Equal(errMsgFromNonTxnSched, err, "batches from the backup transaction scheduler should be rejected")
+	err = verifyTxnSchedulerSignature(v, otherSig, 1, false)
+	require.Equal(errMsgFromNonTxnSched, err, "batches from other nodes should be rejected")
+
+	// Round that has been escalated after a proposer timeout.
+	err = verifyTxnSchedulerSignature(v, schedulerSig, 1, true)
+	require.Equal(errMsgFromNonTxnSched, err, "batches from the primary transaction scheduler should be rejected")
+	err = verifyTxnSchedulerSignature(v, backupSchedulerSig, 1, true)
+	require.NoError(err, "batches from the backup transaction scheduler should be accepted")
+	err = verifyTxnSchedulerSignature(v, otherSig, 1, true)
+	require.Equal(errMsgFromNonTxnSched, err, "batches from other nodes should be rejected")
+}
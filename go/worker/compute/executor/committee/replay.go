@@ -0,0 +1,125 @@
+package committee
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/worker/compute/executor/api"
+)
+
+// ReplayBatch replays the input batch of the given historical round through the locally hosted
+// runtime, using the state of the parent block, and reports any divergence from the roots that
+// were recorded in the round's block.
+//
+// The computed results are not committed to storage.
+func (n *Node) ReplayBatch(ctx context.Context, round uint64) (*api.ReplayBatchResponse, error) {
+	if round == 0 {
+		return nil, api.ErrNoBatch
+	}
+
+	rt := n.GetHostedRuntime()
+	if rt == nil {
+		return nil, fmt.Errorf("executor: hosted runtime is not yet initialized")
+	}
+
+	history := n.commonNode.Runtime.History()
+	blk, err := history.GetBlock(ctx, round)
+	if err != nil {
+		return nil, fmt.Errorf("executor: failed to get block for round %d: %w", round, err)
+	}
+	if blk.Header.HeaderType != block.Normal || blk.Header.IORoot.IsEmpty() {
+		return nil, api.ErrNoBatch
+	}
+	parentBlk, err := history.GetBlock(ctx, round-1)
+	if err != nil {
+		return nil, fmt.Errorf("executor: failed to get parent block for round %d: %w", round, err)
+	}
+
+	// Fetch the inputs from the recorded I/O root.
+	ioTree := transaction.NewTree(n.commonNode.Group.Storage(), storage.Root{
+		Namespace: blk.Header.Namespace,
+		Version:   blk.Header.Round,
+		Hash:      blk.Header.IORoot,
+	})
+	defer ioTree.Close()
+
+	batch, err := ioTree.GetInputBatch(ctx, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("executor: failed to fetch inputs from storage: %w", err)
+	}
+	if len(batch) == 0 {
+		return nil, api.ErrNoBatch
+	}
+
+	// Reconstruct the I/O root containing only the inputs, the same way as the
+	// transaction scheduler does when dispatching a batch.
+	emptyRoot := storage.Root{
+		Namespace: blk.Header.Namespace,
+		Version:   blk.Header.Round,
+	}
+	emptyRoot.Hash.Empty()
+
+	inputTree := transaction.NewTree(nil, emptyRoot)
+	defer inputTree.Close()
+
+	for idx, tx := range batch {
+		if err = inputTree.AddTransaction(ctx, transaction.Transaction{Input: tx, BatchOrder: uint32(idx)}, nil); err != nil {
+			return nil, fmt.Errorf("executor: failed to create I/O tree: %w", err)
+		}
+	}
+	_, inputIORoot, err := inputTree.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("executor: failed to create I/O tree: %w", err)
+	}
+
+	n.logger.Info("replaying batch",
+		"round", round,
+		"batch_size", len(batch),
+		"input_io_root", inputIORoot,
+		"input_state_root", parentBlk.Header.StateRoot,
+	)
+
+	rsp, err := rt.Call(ctx, &protocol.Body{
+		RuntimeExecuteTxBatchRequest: &protocol.RuntimeExecuteTxBatchRequest{
+			IORoot: inputIORoot,
+			Inputs: batch,
+			Block:  *parentBlk,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("executor: failed to replay batch: %w", err)
+	}
+	if rsp.RuntimeExecuteTxBatchResponse == nil {
+		return nil, fmt.Errorf("executor: malformed response from runtime")
+	}
+	hdr := rsp.RuntimeExecuteTxBatchResponse.Batch.Header
+	if hdr.IORoot == nil || hdr.StateRoot == nil {
+		return nil, fmt.Errorf("executor: runtime did not compute roots")
+	}
+
+	result := &api.ReplayBatchResponse{
+		Round:             round,
+		BatchSize:         uint64(len(batch)),
+		InputIORoot:       inputIORoot,
+		InputStateRoot:    parentBlk.Header.StateRoot,
+		RecordedIORoot:    blk.Header.IORoot,
+		RecordedStateRoot: blk.Header.StateRoot,
+		ComputedIORoot:    *hdr.IORoot,
+		ComputedStateRoot: *hdr.StateRoot,
+	}
+	if result.Diverged() {
+		n.logger.Warn("replayed batch diverged from recorded results",
+			"round", round,
+			"recorded_io_root", result.RecordedIORoot,
+			"computed_io_root", result.ComputedIORoot,
+			"recorded_state_root", result.RecordedStateRoot,
+			"computed_state_root", result.ComputedStateRoot,
+		)
+	}
+
+	return result, nil
+}
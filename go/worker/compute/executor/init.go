@@ -4,6 +4,7 @@ import (
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/oasisprotocol/oasis-core/go/common/grpc"
 	workerCommon "github.com/oasisprotocol/oasis-core/go/worker/common"
 	"github.com/oasisprotocol/oasis-core/go/worker/compute"
 	"github.com/oasisprotocol/oasis-core/go/worker/registration"
@@ -24,11 +25,13 @@ var Flags = flag.NewFlagSet("", flag.ContinueOnError)
 // New creates a new executor worker.
 func New(
 	dataDir string,
+	grpcInternal *grpc.Server,
 	commonWorker *workerCommon.Worker,
 	registration *registration.Worker,
 ) (*Worker, error) {
 	return newWorker(
 		dataDir,
+		grpcInternal,
 		compute.Enabled(),
 		commonWorker,
 		registration,
@@ -5,10 +5,12 @@ import (
 	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	workerCommon "github.com/oasisprotocol/oasis-core/go/worker/common"
 	committeeCommon "github.com/oasisprotocol/oasis-core/go/worker/common/committee"
+	"github.com/oasisprotocol/oasis-core/go/worker/compute/executor/api"
 	"github.com/oasisprotocol/oasis-core/go/worker/compute/executor/committee"
 	"github.com/oasisprotocol/oasis-core/go/worker/registration"
 )
@@ -137,6 +139,16 @@ func (w *Worker) GetRuntime(id common.Namespace) *committee.Node {
 	return w.runtimes[id]
 }
 
+// ReplayBatch implements api.ExecutorWorker.
+func (w *Worker) ReplayBatch(ctx context.Context, request *api.ReplayBatchRequest) (*api.ReplayBatchResponse, error) {
+	rt := w.runtimes[request.RuntimeID]
+	if rt == nil {
+		return nil, api.ErrRuntimeNotFound
+	}
+
+	return rt.ReplayBatch(ctx, request.Round)
+}
+
 func (w *Worker) registerRuntime(commonNode *committeeCommon.Node) error {
 	id := commonNode.Runtime.ID()
 	w.logger.Info("registering new runtime",
@@ -166,6 +178,7 @@ func (w *Worker) registerRuntime(commonNode *committeeCommon.Node) error {
 
 func newWorker(
 	dataDir string,
+	grpcInternal *grpc.Server,
 	enabled bool,
 	commonWorker *workerCommon.Worker,
 	registration *registration.Worker,
@@ -201,6 +214,9 @@ func newWorker(
 				return nil, err
 			}
 		}
+
+		// Attach the executor worker's internal gRPC interface.
+		api.RegisterService(grpcInternal.Server(), w)
 	}
 
 	return w, nil
@@ -4,6 +4,8 @@ package keymanager
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"time"
 
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -29,6 +31,15 @@ const (
 	CfgRuntimeID = "worker.keymanager.runtime.id"
 	// CfgMayGenerate allows the enclave to generate a master secret.
 	CfgMayGenerate = "worker.keymanager.may_generate"
+
+	// CfgAnomalyWindow configures the window over which key manager requests are counted for
+	// anomaly detection.
+	CfgAnomalyWindow = "worker.keymanager.anomaly.window"
+	// CfgAnomalyThreshold configures the maximum number of key manager requests a runtime may make
+	// during the anomaly detection window before an anomaly is reported (0 disables detection).
+	CfgAnomalyThreshold = "worker.keymanager.anomaly.threshold"
+	// CfgAnomalyRuntimeThresholds configures per-runtime overrides of the anomaly threshold.
+	CfgAnomalyRuntimeThresholds = "worker.keymanager.anomaly.runtime_threshold"
 )
 
 // Flags has the configuration flags.
@@ -39,6 +50,26 @@ func Enabled() bool {
 	return viper.GetBool(CfgEnabled)
 }
 
+func parseRequestRatePolicy() (*requestRatePolicy, error) {
+	policy := &requestRatePolicy{
+		window:           viper.GetDuration(CfgAnomalyWindow),
+		defaultThreshold: viper.GetUint64(CfgAnomalyThreshold),
+		thresholds:       make(map[common.Namespace]uint64),
+	}
+	for rawID, rawThreshold := range viper.GetStringMapString(CfgAnomalyRuntimeThresholds) {
+		var runtimeID common.Namespace
+		if err := runtimeID.UnmarshalHex(rawID); err != nil {
+			return nil, fmt.Errorf("malformed runtime ID '%s': %w", rawID, err)
+		}
+		threshold, err := strconv.ParseUint(rawThreshold, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed threshold for runtime %s: %w", runtimeID, err)
+		}
+		policy.thresholds[runtimeID] = threshold
+	}
+	return policy, nil
+}
+
 // New constructs a new key manager worker.
 func New(
 	dataDir string,
@@ -74,6 +105,12 @@ func New(
 			return nil, fmt.Errorf("worker/keymanager: failed to parse runtime ID: %w", err)
 		}
 
+		ratePolicy, err := parseRequestRatePolicy()
+		if err != nil {
+			return nil, fmt.Errorf("worker/keymanager: failed to parse anomaly detection configuration: %w", err)
+		}
+		w.requestMonitor = newRequestMonitor(w.logger, ratePolicy)
+
 		// Create local storage for the key manager.
 		path, err := runtimeRegistry.EnsureRuntimeStateDir(dataDir, runtimeID)
 		if err != nil {
@@ -115,5 +152,9 @@ func init() {
 	Flags.String(CfgRuntimeID, "", "Key manager Runtime ID")
 	Flags.Bool(CfgMayGenerate, false, "Key manager may generate new master secret")
 
+	Flags.Duration(CfgAnomalyWindow, time.Minute, "Key manager request anomaly detection window")
+	Flags.Uint64(CfgAnomalyThreshold, 0, "Maximum number of key manager requests per runtime during the anomaly detection window (0 disables detection)")
+	Flags.StringToString(CfgAnomalyRuntimeThresholds, map[string]string{}, "Per-runtime anomaly detection thresholds (<runtime_id>=<threshold>)")
+
 	_ = viper.BindPFlags(Flags)
 }
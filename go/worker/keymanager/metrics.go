@@ -0,0 +1,214 @@
+package keymanager
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	enclaverpc "github.com/oasisprotocol/oasis-core/go/runtime/enclaverpc/api"
+)
+
+const (
+	// methodSession is the method label used for requests that do not carry a plaintext method
+	// (e.g., secure session handshakes and encrypted requests).
+	methodSession = "session"
+	// methodUnknown is the method label used for requests that could not be decoded.
+	methodUnknown = "unknown"
+	// methodGetPublicKey is the plaintext public key request method, see
+	// `keymanager-runtime/src/methods.rs`.
+	methodGetPublicKey = "get_public_key"
+
+	// runtimeUnknown is the runtime label used for requests from runtimes that are not known to
+	// use this key manager.
+	runtimeUnknown = "unknown"
+)
+
+var (
+	keyRequestCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_keymanager_request_count",
+			Help: "Number of key manager enclave requests.",
+		},
+		[]string{"runtime", "method"},
+	)
+	keyRequestAnomalyCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_keymanager_request_anomaly_count",
+			Help: "Number of times the key manager request rate threshold was exceeded.",
+		},
+		[]string{"runtime"},
+	)
+	keymanagerCollectors = []prometheus.Collector{
+		keyRequestCount,
+		keyRequestAnomalyCount,
+	}
+
+	metricsOnce sync.Once
+)
+
+// RequestAnomaly describes a calling runtime exceeding its key manager request rate threshold.
+type RequestAnomaly struct {
+	// RuntimeID is the identifier of the calling runtime.
+	//
+	// Requests from runtimes that are not known to use this key manager are accounted together
+	// and reported under the zero runtime identifier.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// Requests is the number of requests observed during the window.
+	Requests uint64 `json:"requests"`
+	// Threshold is the maximum number of requests allowed during the window.
+	Threshold uint64 `json:"threshold"`
+	// Window is the duration of the observation window.
+	Window time.Duration `json:"window"`
+}
+
+// RequestAnomalyHook is a function invoked when a request rate anomaly is detected.
+//
+// Hooks are invoked synchronously on the request path and must not block.
+type RequestAnomalyHook func(anomaly *RequestAnomaly)
+
+// requestRatePolicy configures request rate thresholds.
+type requestRatePolicy struct {
+	// window is the duration of the observation window.
+	window time.Duration
+	// defaultThreshold is the threshold applied to runtimes without an explicit threshold.
+	// Zero disables anomaly detection for such runtimes.
+	defaultThreshold uint64
+	// thresholds are the per-runtime thresholds.
+	thresholds map[common.Namespace]uint64
+}
+
+func (p *requestRatePolicy) thresholdFor(runtimeID common.Namespace) uint64 {
+	if th, ok := p.thresholds[runtimeID]; ok {
+		return th
+	}
+	return p.defaultThreshold
+}
+
+type requestWindow struct {
+	start    time.Time
+	requests uint64
+	alerted  bool
+}
+
+// requestMonitor counts key manager requests per calling runtime and reports anomalous request
+// rates.
+type requestMonitor struct {
+	sync.Mutex
+
+	logger *logging.Logger
+
+	policy   *requestRatePolicy
+	runtimes map[common.Namespace]bool
+	windows  map[common.Namespace]*requestWindow
+	hooks    []RequestAnomalyHook
+
+	now func() time.Time
+}
+
+func (m *requestMonitor) addHook(hook RequestAnomalyHook) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.hooks = append(m.hooks, hook)
+}
+
+// addKnownRuntime marks the given runtime as known to use this key manager.
+//
+// Requests are only attributed to known runtimes in order to bound the number of tracked
+// runtimes, as the caller-provided runtime identifier is not authenticated for all requests.
+func (m *requestMonitor) addKnownRuntime(runtimeID common.Namespace) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.runtimes[runtimeID] = true
+}
+
+// observe records a request made by the given runtime and returns the detected anomaly (if any).
+//
+// An anomaly is reported at most once per observation window.
+func (m *requestMonitor) observe(runtimeID common.Namespace, method string) *RequestAnomaly {
+	m.Lock()
+	runtimeLabel := runtimeID.String()
+	if !m.runtimes[runtimeID] {
+		runtimeID = common.Namespace{}
+		runtimeLabel = runtimeUnknown
+	}
+	keyRequestCount.With(prometheus.Labels{"runtime": runtimeLabel, "method": method}).Inc()
+
+	threshold := m.policy.thresholdFor(runtimeID)
+	if threshold == 0 || m.policy.window <= 0 {
+		m.Unlock()
+		return nil
+	}
+
+	now := m.now()
+	win := m.windows[runtimeID]
+	if win == nil || now.Sub(win.start) >= m.policy.window {
+		win = &requestWindow{start: now}
+		m.windows[runtimeID] = win
+	}
+	win.requests++
+	if win.requests <= threshold || win.alerted {
+		m.Unlock()
+		return nil
+	}
+	win.alerted = true
+
+	anomaly := &RequestAnomaly{
+		RuntimeID: runtimeID,
+		Requests:  win.requests,
+		Threshold: threshold,
+		Window:    m.policy.window,
+	}
+	hooks := append([]RequestAnomalyHook{}, m.hooks...)
+	m.Unlock()
+
+	keyRequestAnomalyCount.With(prometheus.Labels{"runtime": runtimeLabel}).Inc()
+	m.logger.Warn("anomalous key manager request rate",
+		"runtime_id", runtimeLabel,
+		"requests", anomaly.Requests,
+		"threshold", anomaly.Threshold,
+		"window", anomaly.Window,
+	)
+	for _, hook := range hooks {
+		hook(anomaly)
+	}
+
+	return anomaly
+}
+
+func newRequestMonitor(logger *logging.Logger, policy *requestRatePolicy) *requestMonitor {
+	metricsOnce.Do(func() {
+		prometheus.MustRegister(keymanagerCollectors...)
+	})
+
+	return &requestMonitor{
+		logger:   logger,
+		policy:   policy,
+		runtimes: make(map[common.Namespace]bool),
+		windows:  make(map[common.Namespace]*requestWindow),
+		now:      time.Now,
+	}
+}
+
+// requestMethod returns the method label for the given CBOR-serialized EnclaveRPC frame.
+func requestMethod(payload []byte) string {
+	var frame enclaverpc.Frame
+	if err := cbor.Unmarshal(payload, &frame); err != nil {
+		return methodUnknown
+	}
+
+	switch frame.UntrustedPlaintext {
+	case "":
+		return methodSession
+	case methodGetPublicKey:
+		return frame.UntrustedPlaintext
+	default:
+		// Do not use arbitrary caller-provided strings as metric labels.
+		return methodUnknown
+	}
+}
@@ -20,5 +20,7 @@ func (w *Worker) AuthFunc(ctx context.Context, fullMethodName string, req interf
 
 // CallEnclave sends the request bytes to the target enclave.
 func (w *Worker) CallEnclave(ctx context.Context, request *api.CallEnclaveRequest) ([]byte, error) {
+	w.requestMonitor.observe(request.RuntimeID, requestMethod(request.Payload))
+
 	return w.callLocal(ctx, request.Payload)
 }
@@ -74,7 +74,8 @@ type Worker struct { // nolint: maligned
 	enclaveStatus *api.SignedInitResponse
 	backend       api.Backend
 
-	grpcPolicy *policy.DynamicRuntimePolicyChecker
+	grpcPolicy     *policy.DynamicRuntimePolicyChecker
+	requestMonitor *requestMonitor
 
 	enabled     bool
 	mayGenerate bool
@@ -128,6 +129,15 @@ func (w *Worker) Initialized() <-chan struct{} {
 	return w.initCh
 }
 
+// AddRequestAnomalyHook registers a hook that is invoked whenever a runtime exceeds its
+// configured key manager request rate threshold.
+func (w *Worker) AddRequestAnomalyHook(hook RequestAnomalyHook) {
+	if !w.enabled {
+		return
+	}
+	w.requestMonitor.addHook(hook)
+}
+
 // Implements workerCommon.RuntimeHostHandlerFactory.
 func (w *Worker) GetRuntime() runtimeRegistry.Runtime {
 	return w.runtime
@@ -426,6 +436,7 @@ func (w *Worker) startClientRuntimeWatcher(rt *registry.Runtime, status *api.Sta
 	}()
 
 	w.clientRuntimes[rt.ID] = crw
+	w.requestMonitor.addKnownRuntime(rt.ID)
 
 	return nil
 }
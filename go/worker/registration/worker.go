@@ -22,12 +22,14 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/persistent"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	control "github.com/oasisprotocol/oasis-core/go/control/api"
 	epochtime "github.com/oasisprotocol/oasis-core/go/epochtime/api"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
+	sentryAPI "github.com/oasisprotocol/oasis-core/go/sentry/api"
 	sentryClient "github.com/oasisprotocol/oasis-core/go/sentry/client"
 	workerCommon "github.com/oasisprotocol/oasis-core/go/worker/common"
 	"github.com/oasisprotocol/oasis-core/go/worker/common/p2p"
@@ -196,7 +198,11 @@ func (w *Worker) registrationLoop() { // nolint: gocyclo
 				if err != nil {
 					return err
 				}
-				return nil
+
+				// Make sure that the sentry node is configured correctly before we start
+				// advertising its addresses.
+				_, err = w.verifySentry(client, sentryAddr)
+				return err
 			}
 
 			sched := backoff.WithMaxRetries(backoff.NewConstantBackOff(1*time.Second), 60)
@@ -649,6 +655,24 @@ func (w *Worker) gatherTLSAddresses(sentryTLSAddrs []node.TLSAddress) ([]node.TL
 	return validatedAddrs, nil
 }
 
+func (w *Worker) getSoftwareVersion(nodeDesc *node.Node) *node.SoftwareVersion {
+	sv := node.SoftwareVersion{
+		OasisCore:           version.SoftwareVersion,
+		ConsensusProtocol:   version.ConsensusProtocol,
+		RuntimeHostProtocol: version.RuntimeHostProtocol,
+	}
+	if rh := w.workerCommonCfg.RuntimeHost; rh != nil {
+		sv.RuntimeLoader = rh.LoaderVersion
+	}
+	for _, rt := range nodeDesc.Runtimes {
+		if rt.Capabilities.TEE != nil {
+			sv.TEEHardware = rt.Capabilities.TEE.Hardware
+			break
+		}
+	}
+	return &sv
+}
+
 func (w *Worker) registerNode(epoch epochtime.EpochTime, hook RegisterNodeHook) error {
 	identityPublic := w.identity.NodeSigner.Public()
 	w.logger.Info("performing node (re-)registration",
@@ -682,6 +706,8 @@ func (w *Worker) registerNode(epoch epochtime.EpochTime, hook RegisterNodeHook)
 		return err
 	}
 
+	nodeDesc.SoftwareVersion = w.getSoftwareVersion(&nodeDesc)
+
 	// Sanity check to prevent an invalid registration when no role provider added any runtimes but
 	// runtimes are required due to the specified role.
 	if nodeDesc.HasRoles(registry.RuntimesRequiredRoles) && len(nodeDesc.Runtimes) == 0 {
@@ -777,23 +803,23 @@ func (w *Worker) querySentries() ([]node.ConsensusAddress, []node.TLSAddress) {
 		}
 		defer client.Close()
 
-		// Query sentry node for addresses.
-		sentryAddresses, err := client.GetAddresses(w.ctx)
+		// Keep sentries updated with our latest TLS certificates.
+		err = client.SetUpstreamTLSPubKeys(w.ctx, pubKeys)
 		if err != nil {
-			w.logger.Warn("failed to obtain addresses from sentry node",
+			w.logger.Warn("failed to provide upstream TLS certificates to sentry node",
 				"err", err,
 				"sentry_address", sentryAddr,
 			)
-			continue
 		}
 
-		// Keep sentries updated with our latest TLS certificates.
-		err = client.SetUpstreamTLSPubKeys(w.ctx, pubKeys)
+		// Query sentry node for addresses.
+		sentryAddresses, err := w.verifySentry(client, sentryAddr)
 		if err != nil {
-			w.logger.Warn("failed to provide upstream TLS certificates to sentry node",
+			w.logger.Warn("failed to obtain verified addresses from sentry node",
 				"err", err,
 				"sentry_address", sentryAddr,
 			)
+			continue
 		}
 
 		consensusAddrs = append(consensusAddrs, sentryAddresses.Consensus...)
@@ -814,6 +840,78 @@ func (w *Worker) querySentries() ([]node.ConsensusAddress, []node.TLSAddress) {
 	return consensusAddrs, tlsAddrs
 }
 
+// verifySentry queries the sentry node for its addresses and verifies them against the identity
+// of the sentry node that was used to authenticate the connection.
+func (w *Worker) verifySentry(client *sentryClient.Client, sentryAddr node.TLSAddress) (*sentryAPI.SentryAddresses, error) {
+	sentryAddresses, err := client.GetAddresses(w.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain addresses: %w", err)
+	}
+
+	return w.verifySentryAddresses(sentryAddresses, sentryAddr)
+}
+
+// verifySentryAddresses filters the addresses returned by the given sentry node, only keeping the
+// ones that can be advertised. It fails in case no consensus or no TLS address remains.
+func (w *Worker) verifySentryAddresses(sentryAddresses *sentryAPI.SentryAddresses, sentryAddr node.TLSAddress) (*sentryAPI.SentryAddresses, error) {
+	var verified sentryAPI.SentryAddresses
+	for _, addr := range sentryAddresses.Consensus {
+		if !addr.ID.IsValid() {
+			w.logger.Warn("skipping sentry consensus address due to invalid ID",
+				"addr", addr,
+				"sentry_address", sentryAddr,
+			)
+			continue
+		}
+		if addr.ID.Equal(w.identity.P2PSigner.Public()) {
+			w.logger.Warn("skipping sentry consensus address with our own ID",
+				"addr", addr,
+				"sentry_address", sentryAddr,
+			)
+			continue
+		}
+		if err := registry.VerifyAddress(addr.Address, allowUnroutableAddresses); err != nil {
+			w.logger.Warn("skipping sentry consensus address due to invalid address",
+				"addr", addr,
+				"sentry_address", sentryAddr,
+				"err", err,
+			)
+			continue
+		}
+		verified.Consensus = append(verified.Consensus, addr)
+	}
+
+	// The sentry node must advertise its TLS addresses under the identity that we used to
+	// authenticate it, otherwise clients would not be able to connect to it.
+	for _, addr := range sentryAddresses.TLS {
+		if !addr.PubKey.Equal(sentryAddr.PubKey) {
+			w.logger.Warn("skipping sentry TLS address not matching sentry public key",
+				"addr", addr,
+				"sentry_address", sentryAddr,
+			)
+			continue
+		}
+		if err := registry.VerifyAddress(addr.Address, allowUnroutableAddresses); err != nil {
+			w.logger.Warn("skipping sentry TLS address due to invalid address",
+				"addr", addr,
+				"sentry_address", sentryAddr,
+				"err", err,
+			)
+			continue
+		}
+		verified.TLS = append(verified.TLS, addr)
+	}
+
+	if len(verified.Consensus) == 0 {
+		return nil, fmt.Errorf("no valid consensus addresses")
+	}
+	if len(verified.TLS) == 0 {
+		return nil, fmt.Errorf("no valid TLS addresses matching sentry public key %s", sentryAddr.PubKey)
+	}
+
+	return &verified, nil
+}
+
 // RequestDeregistration requests that the node not register itself in the next epoch.
 func (w *Worker) RequestDeregistration() error {
 	if !atomic.CompareAndSwapUint32(&w.deregRequested, 0, 1) {
@@ -958,6 +1056,12 @@ func New(
 		return nil, fmt.Errorf("node TLS certificate rotation must not be enabled if using pre-generated TLS certificates")
 	}
 
+	if len(workerCommonCfg.SentryAddresses) > 0 && len(workerCommonCfg.ClientAddresses) > 0 {
+		logger.Warn("sentry nodes are configured, configured client addresses will not be advertised",
+			"client_addresses", workerCommonCfg.ClientAddresses,
+		)
+	}
+
 	w := &Worker{
 		workerCommonCfg:    workerCommonCfg,
 		store:              serviceStore,
@@ -0,0 +1,118 @@
+package registration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/identity"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	sentryAPI "github.com/oasisprotocol/oasis-core/go/sentry/api"
+)
+
+func TestVerifySentryAddresses(t *testing.T) {
+	ownP2PSigner := memorySigner.NewTestSigner("registration test own p2p")
+	w := &Worker{
+		identity: &identity.Identity{P2PSigner: ownP2PSigner},
+		logger:   logging.GetLogger("worker/registration/test"),
+	}
+
+	sentryTLSKey := memorySigner.NewTestSigner("registration test sentry tls").Public()
+	otherTLSKey := memorySigner.NewTestSigner("registration test other tls").Public()
+	sentryP2PKey := memorySigner.NewTestSigner("registration test sentry p2p").Public()
+
+	newAddress := func(addr string) node.Address {
+		var a node.Address
+		require.NoError(t, a.UnmarshalText([]byte(addr)), "UnmarshalText")
+		return a
+	}
+	newConsensusAddress := func(id signature.PublicKey, addr string) node.ConsensusAddress {
+		return node.ConsensusAddress{ID: id, Address: newAddress(addr)}
+	}
+	newTLSAddress := func(pk signature.PublicKey, addr string) node.TLSAddress {
+		return node.TLSAddress{PubKey: pk, Address: newAddress(addr)}
+	}
+
+	sentryAddr := newTLSAddress(sentryTLSKey, "8.8.8.8:9100")
+	validConsensus := newConsensusAddress(sentryP2PKey, "8.8.8.8:26656")
+	validTLS := newTLSAddress(sentryTLSKey, "8.8.8.8:9100")
+
+	for _, tc := range []struct {
+		name      string
+		addresses *sentryAPI.SentryAddresses
+		expected  *sentryAPI.SentryAddresses
+	}{
+		{
+			name: "Valid",
+			addresses: &sentryAPI.SentryAddresses{
+				Consensus: []node.ConsensusAddress{validConsensus},
+				TLS:       []node.TLSAddress{validTLS},
+			},
+			expected: &sentryAPI.SentryAddresses{
+				Consensus: []node.ConsensusAddress{validConsensus},
+				TLS:       []node.TLSAddress{validTLS},
+			},
+		},
+		{
+			name: "FilterInvalid",
+			addresses: &sentryAPI.SentryAddresses{
+				Consensus: []node.ConsensusAddress{
+					newConsensusAddress(signature.PublicKey{}, "8.8.8.8:26656"),
+					newConsensusAddress(ownP2PSigner.Public(), "8.8.8.8:26656"),
+					newConsensusAddress(sentryP2PKey, "127.0.0.1:26656"),
+					validConsensus,
+				},
+				TLS: []node.TLSAddress{
+					newTLSAddress(otherTLSKey, "8.8.8.8:9100"),
+					newTLSAddress(sentryTLSKey, "127.0.0.1:9100"),
+					validTLS,
+				},
+			},
+			expected: &sentryAPI.SentryAddresses{
+				Consensus: []node.ConsensusAddress{validConsensus},
+				TLS:       []node.TLSAddress{validTLS},
+			},
+		},
+		{
+			name: "NoTLSAddresses",
+			addresses: &sentryAPI.SentryAddresses{
+				Consensus: []node.ConsensusAddress{validConsensus},
+			},
+		},
+		{
+			name: "NoMatchingTLSAddresses",
+			addresses: &sentryAPI.SentryAddresses{
+				Consensus: []node.ConsensusAddress{validConsensus},
+				TLS:       []node.TLSAddress{newTLSAddress(otherTLSKey, "8.8.8.8:9100")},
+			},
+		},
+		{
+			name: "NoConsensusAddresses",
+			addresses: &sentryAPI.SentryAddresses{
+				TLS: []node.TLSAddress{validTLS},
+			},
+		},
+		{
+			name: "NoValidConsensusAddresses",
+			addresses: &sentryAPI.SentryAddresses{
+				Consensus: []node.ConsensusAddress{newConsensusAddress(ownP2PSigner.Public(), "8.8.8.8:26656")},
+				TLS:       []node.TLSAddress{validTLS},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+
+			verified, err := w.verifySentryAddresses(tc.addresses, sentryAddr)
+			if tc.expected == nil {
+				require.Error(err, "verifySentryAddresses should fail")
+				return
+			}
+			require.NoError(err, "verifySentryAddresses")
+			require.EqualValues(tc.expected, verified, "only valid addresses should be kept")
+		})
+	}
+}
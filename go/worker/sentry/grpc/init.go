@@ -31,6 +31,9 @@ const (
 	CfgUpstreamAddress = "worker.sentry.grpc.upstream.address"
 	// CfgUpstreamID is the node ID of the upstream node.
 	CfgUpstreamID = "worker.sentry.grpc.upstream.id"
+	// CfgUpstreamRoutes are the routes to upstream nodes selected based on the TLS server name
+	// requested by clients.
+	CfgUpstreamRoutes = "worker.sentry.grpc.upstream.route"
 
 	// CfgClientAddresses are addresses on which the gRPC endpoint is reachable.
 	CfgClientAddresses = "worker.sentry.grpc.client.address"
@@ -50,29 +53,15 @@ func GetNodeAddresses() ([]node.Address, error) {
 	return clientAddresses, nil
 }
 
-func initConnection(ctx context.Context, logger *logging.Logger, ident *identity.Identity, backend sentry.LocalBackend) (*grpc.ClientConn, error) {
-	var err error
-
-	addr := viper.GetString(CfgUpstreamAddress)
-
-	upstreamAddrs, err := configparser.ParseAddressList([]string{addr})
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse address: %s: %w", addr, err)
-	}
-
-	upstreamNodeIDRaw := viper.GetString(CfgUpstreamID)
-	var upstreamNodeID signature.PublicKey
-	err = upstreamNodeID.UnmarshalText([]byte(upstreamNodeIDRaw))
-	if err != nil {
-		return nil, fmt.Errorf("malformed upstream node ID: %s: %w", upstreamNodeIDRaw, err)
-	}
-
-	logger.Info("upstream node ID is valid",
-		"upstream_node_id", upstreamNodeIDRaw,
-	)
-
+func initConnection(
+	ctx context.Context,
+	logger *logging.Logger,
+	ident *identity.Identity,
+	backend sentry.LocalBackend,
+	route *upstreamRoute,
+) (*grpc.ClientConn, error) {
 	// Get upstream node's TLS public keys.
-	upstreamPubKeys, err := backend.GetUpstreamTLSPubKeys(ctx)
+	upstreamPubKeys, err := backend.GetUpstreamTLSPubKeysFor(ctx, route.upstream)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get upstream node's TLS public keys: %w", err)
 	}
@@ -80,6 +69,7 @@ func initConnection(ctx context.Context, logger *logging.Logger, ident *identity
 		return nil, fmt.Errorf("upstream node has no defined TLS public keys")
 	}
 	logger.Info("found public keys for upstream node",
+		"server_name", route.serverName,
 		"num_keys", len(upstreamPubKeys),
 	)
 
@@ -111,7 +101,7 @@ func initConnection(ctx context.Context, logger *logging.Logger, ident *identity
 		return nil, fmt.Errorf("error dialing node: %w", err)
 	}
 	var resolverState resolver.State
-	for _, addr := range upstreamAddrs {
+	for _, addr := range route.addresses {
 		resolverState.Addresses = append(resolverState.Addresses, resolver.Address{Addr: addr.String()})
 	}
 	manualResolver.UpdateState(resolverState)
@@ -119,6 +109,70 @@ func initConnection(ctx context.Context, logger *logging.Logger, ident *identity
 	return conn, nil
 }
 
+func newUpstreamRouter(logger *logging.Logger, ident *identity.Identity, backend sentry.LocalBackend) (*router, error) {
+	var routes []*upstreamRoute
+
+	// Default upstream node.
+	if addr := viper.GetString(CfgUpstreamAddress); addr != "" {
+		upstreamAddrs, err := configparser.ParseAddressList([]string{addr})
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse address: %s: %w", addr, err)
+		}
+
+		upstreamNodeIDRaw := viper.GetString(CfgUpstreamID)
+		var upstreamNodeID signature.PublicKey
+		if err = upstreamNodeID.UnmarshalText([]byte(upstreamNodeIDRaw)); err != nil {
+			return nil, fmt.Errorf("malformed upstream node ID: %s: %w", upstreamNodeIDRaw, err)
+		}
+
+		logger.Info("upstream node ID is valid",
+			"upstream_node_id", upstreamNodeIDRaw,
+		)
+
+		routes = append(routes, &upstreamRoute{
+			upstream:  sentry.DefaultUpstream,
+			addresses: upstreamAddrs,
+		})
+	}
+
+	// Upstream nodes selected by TLS server name.
+	for _, raw := range viper.GetStringSlice(CfgUpstreamRoutes) {
+		route, err := parseUpstreamRoute(raw)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, route)
+	}
+
+	if len(routes) == 0 {
+		return nil, fmt.Errorf("no upstream nodes configured")
+	}
+
+	r := newRouter()
+	for _, route := range routes {
+		route := route // Make sure the dialer captures the correct route.
+		upstreamDialer := func(ctx context.Context) (*grpc.ClientConn, error) {
+			upstreamConn, err := initConnection(ctx, logger, ident, backend, route)
+			if err != nil {
+				return nil, fmt.Errorf("gRPC sentry worker initializing upstream connection failure: %w", err)
+			}
+			return upstreamConn, nil
+		}
+		route.handler = proxy.Handler(upstreamDialer)
+
+		if err := r.addRoute(route); err != nil {
+			return nil, err
+		}
+
+		logger.Info("configured upstream route",
+			"server_name", route.serverName,
+			"addresses", route.addresses,
+		)
+	}
+
+	return r, nil
+}
+
 // New creates a new sentry grpc worker.
 func New(backend sentry.LocalBackend, identity *identity.Identity) (*Worker, error) {
 	logger := logging.GetLogger("sentry/grpc/worker")
@@ -142,13 +196,11 @@ func New(backend sentry.LocalBackend, identity *identity.Identity) (*Worker, err
 	if g.enabled {
 		logger.Info("Initializing gRPC sentry worker")
 
-		upstreamDialer := func(ctx context.Context) (*grpc.ClientConn, error) {
-			upstreamConn, err := initConnection(ctx, logger, identity, backend)
-			if err != nil {
-				return nil, fmt.Errorf("gRPC sentry worker initializing upstream connection failure: %w", err)
-			}
-			return upstreamConn, nil
+		router, err := newUpstreamRouter(logger, identity, backend)
+		if err != nil {
+			return nil, err
 		}
+		g.router = router
 
 		// Create externally-accessible proxy gRPC server.
 		serverConfig := &cmnGrpc.ServerConfig{
@@ -157,8 +209,9 @@ func New(backend sentry.LocalBackend, identity *identity.Identity) (*Worker, err
 			Identity: identity,
 			AuthFunc: g.authFunction(),
 			CustomOptions: []grpc.ServerOption{
-				// All unknown requests will be proxied to the upstream grpc server.
-				grpc.UnknownServiceHandler(proxy.Handler(upstreamDialer)),
+				// All unknown requests will be proxied to the upstream grpc server selected
+				// based on the TLS server name requested by the client.
+				grpc.UnknownServiceHandler(router.handler),
 			},
 		}
 		grpcServer, err := cmnGrpc.NewServer(serverConfig)
@@ -175,6 +228,7 @@ func init() {
 	Flags.Bool(CfgEnabled, false, "Enable Sentry gRPC worker (NOTE: This should only be enabled on gRPC Sentry nodes.)")
 	Flags.String(CfgUpstreamAddress, "", "Address of the upstream node")
 	Flags.String(CfgUpstreamID, "", "ID of the upstream node")
+	Flags.StringSlice(CfgUpstreamRoutes, []string{}, "Upstream node route(s) of the form <server name>=<upstream control public key>@<address>")
 	Flags.StringSlice(CfgClientAddresses, []string{}, "Address/port(s) to use for client connections for accessing this node")
 	Flags.Uint16(CfgClientPort, 9100, "Port to use for incoming gRPC client connections")
 
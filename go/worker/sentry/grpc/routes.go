@@ -0,0 +1,127 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	sentry "github.com/oasisprotocol/oasis-core/go/sentry/api"
+	"github.com/oasisprotocol/oasis-core/go/worker/common/configparser"
+)
+
+// upstreamRoute is a route to an upstream node.
+type upstreamRoute struct {
+	// serverName is the TLS server name requested by clients that should be
+	// routed to the upstream node. It is empty for the default route.
+	serverName string
+	// upstream is the identifier of the upstream node in the sentry backend.
+	upstream signature.PublicKey
+	// addresses are the gRPC addresses of the upstream node.
+	addresses []node.Address
+
+	// handler is the proxy handler forwarding requests to the upstream node.
+	handler grpc.StreamHandler
+}
+
+// parseUpstreamRoute parses an upstream route of the form
+// <server name>=<upstream control public key>@<address>.
+func parseUpstreamRoute(raw string) (*upstreamRoute, error) {
+	spl := strings.SplitN(raw, "=", 2)
+	if len(spl) != 2 || spl[0] == "" {
+		return nil, fmt.Errorf("malformed upstream route: %s", raw)
+	}
+	serverName := strings.ToLower(spl[0])
+
+	spl = strings.SplitN(spl[1], "@", 2)
+	if len(spl) != 2 {
+		return nil, fmt.Errorf("malformed upstream route: %s", raw)
+	}
+	var upstream signature.PublicKey
+	if err := upstream.UnmarshalText([]byte(spl[0])); err != nil {
+		return nil, fmt.Errorf("malformed upstream route public key: %s: %w", raw, err)
+	}
+	if upstream.Equal(sentry.DefaultUpstream) {
+		return nil, fmt.Errorf("malformed upstream route public key: %s", raw)
+	}
+	addresses, err := configparser.ParseAddressList([]string{spl[1]})
+	if err != nil {
+		return nil, fmt.Errorf("malformed upstream route address: %s: %w", raw, err)
+	}
+
+	return &upstreamRoute{
+		serverName: serverName,
+		upstream:   upstream,
+		addresses:  addresses,
+	}, nil
+}
+
+// serverNameFromContext returns the TLS server name requested by the client
+// of the connection in the gRPC context.
+func serverNameFromContext(ctx context.Context) string {
+	peer, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	tlsAuth, ok := peer.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return ""
+	}
+	return strings.ToLower(tlsAuth.State.ServerName)
+}
+
+// router routes requests to upstream nodes based on the TLS server name
+// requested by the client.
+type router struct {
+	defaultRoute *upstreamRoute
+	routes       map[string]*upstreamRoute
+}
+
+func (r *router) addRoute(route *upstreamRoute) error {
+	if route.serverName == "" {
+		if r.defaultRoute != nil {
+			return fmt.Errorf("duplicate default upstream route")
+		}
+		r.defaultRoute = route
+		return nil
+	}
+	if _, exists := r.routes[route.serverName]; exists {
+		return fmt.Errorf("duplicate upstream route for server name: %s", route.serverName)
+	}
+	r.routes[route.serverName] = route
+	return nil
+}
+
+// routeFromContext returns the upstream route for the connection in the
+// gRPC context, falling back to the default route if one is configured.
+func (r *router) routeFromContext(ctx context.Context) (*upstreamRoute, error) {
+	serverName := serverNameFromContext(ctx)
+	if route, ok := r.routes[serverName]; ok {
+		return route, nil
+	}
+	if r.defaultRoute != nil {
+		return r.defaultRoute, nil
+	}
+	return nil, fmt.Errorf("no upstream route for server name: '%s'", serverName)
+}
+
+func (r *router) handler(srv interface{}, stream grpc.ServerStream) error {
+	route, err := r.routeFromContext(stream.Context())
+	if err != nil {
+		return status.Errorf(codes.Unavailable, err.Error())
+	}
+	return route.handler(srv, stream)
+}
+
+func newRouter() *router {
+	return &router{
+		routes: make(map[string]*upstreamRoute),
+	}
+}
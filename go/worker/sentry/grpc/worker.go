@@ -37,6 +37,7 @@ type Worker struct { // nolint: maligned
 	logger *logging.Logger
 
 	grpc     *cmnGrpc.Server
+	router   *router
 	identity *identity.Identity
 }
 
@@ -73,10 +74,18 @@ func (g *Worker) authFunction() auth.AuthenticationFunction {
 		// services that do not provide at least a single policy checker.
 		// This is not the case in either of currently supported upstreams
 		// (storage and keymanager).
-		policyChecker, err := g.backend.GetPolicyChecker(ctx, serviceName)
+		route, err := g.router.routeFromContext(ctx)
+		if err != nil {
+			g.logger.Error("no upstream route for request",
+				"err", err,
+			)
+			return status.Errorf(codes.PermissionDenied, "not allowed")
+		}
+		policyChecker, err := g.backend.GetPolicyChecker(ctx, route.upstream, serviceName)
 		if err != nil {
 			g.logger.Error("no policy checker defined for service",
 				"service_name", serviceName,
+				"server_name", route.serverName,
 			)
 			return status.Errorf(codes.PermissionDenied, "not allowed")
 		}
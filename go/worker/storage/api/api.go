@@ -5,6 +5,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/common/service"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 )
 
@@ -21,6 +22,10 @@ type StorageWorker interface {
 
 	// ForceFinalize forces finalization of a specific round.
 	ForceFinalize(ctx context.Context, request *ForceFinalizeRequest) error
+
+	// Compact immediately runs garbage collection on the runtime's local storage database,
+	// regardless of the configured GC windows.
+	Compact(ctx context.Context, request *CompactRequest) (*CompactResponse, error)
 }
 
 // GetLastSyncedRoundRequest is a GetLastSyncedRound request.
@@ -41,8 +46,26 @@ type ForceFinalizeRequest struct {
 	Round     uint64           `json:"round"`
 }
 
+// CompactRequest is a Compact request.
+type CompactRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+}
+
+// CompactResponse is a Compact response.
+type CompactResponse struct {
+	// Reclaimed is the number of bytes reclaimed by garbage collection.
+	Reclaimed int64 `json:"reclaimed"`
+}
+
 // Status is the storage worker status.
 type Status struct {
 	// LastFinalizedRound is the last synced and finalized round.
 	LastFinalizedRound uint64 `json:"last_finalized_round"`
+
+	// Health is the health state of syncing the runtime.
+	Health service.HealthState `json:"health"`
+	// FetchFailures is the number of consecutive failed storage diff fetches.
+	FetchFailures uint64 `json:"fetch_failures,omitempty"`
+	// LastFetchError is the error of the last failed storage diff fetch (if any).
+	LastFetchError string `json:"last_fetch_error,omitempty"`
 }
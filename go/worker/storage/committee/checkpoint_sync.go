@@ -323,13 +323,7 @@ resultLoop:
 	}
 
 	// Prepare the list: sort and deduplicate.
-	sort.Slice(list, func(i, j int) bool {
-		// Descending!
-		if list[j].Root.Version == list[i].Root.Version {
-			return bytes.Compare(list[j].Root.Hash[:], list[i].Root.Hash[:]) < 0
-		}
-		return list[j].Root.Version < list[i].Root.Version
-	})
+	sortCheckpoints(list)
 	retList := make([]*checkpoint.Metadata, len(list))
 	var prevCheckpoint *checkpoint.Metadata
 	cursor := 0
@@ -343,6 +337,17 @@ resultLoop:
 	return retList[:cursor], nil
 }
 
+// sortCheckpoints sorts the given checkpoints from most recent backwards.
+func sortCheckpoints(list []*checkpoint.Metadata) {
+	sort.Slice(list, func(i, j int) bool {
+		// Descending!
+		if list[j].Root.Version == list[i].Root.Version {
+			return bytes.Compare(list[j].Root.Hash[:], list[i].Root.Hash[:]) < 0
+		}
+		return list[j].Root.Version < list[i].Root.Version
+	})
+}
+
 func (n *Node) checkCheckpointUsable(cp *checkpoint.Metadata, remainingMask outstandingMask) outstandingMask {
 	namespace := n.commonNode.Runtime.ID()
 	if !namespace.Equal(&cp.Root.Namespace) {
@@ -376,11 +381,6 @@ func (n *Node) checkCheckpointUsable(cp *checkpoint.Metadata, remainingMask outs
 }
 
 func (n *Node) syncCheckpoints() (*blockSummary, error) {
-	// Store roots and round info for checkpoints that finished syncing.
-	// Round and namespace info will get overwritten as rounds are skipped
-	// for errors, driven by remainingRoots.
-	var syncState blockSummary
-
 	// Start following the storage committee.
 	committeeWatcher, err := committee.NewWatcher(
 		n.ctx,
@@ -410,6 +410,55 @@ func (n *Node) syncCheckpoints() (*blockSummary, error) {
 		return nil, fmt.Errorf("can't get checkpoint list from storage committee: %w", err)
 	}
 
+	return n.restoreCheckpoints(metadata, func(check *checkpoint.Metadata) (int, error) {
+		return n.handleCheckpoint(check, committeeClient, descriptor.Storage.GroupSize)
+	})
+}
+
+// syncCheckpointsFromBucket performs the initial sync from checkpoints that have been exported to
+// the configured object storage bucket.
+func (n *Node) syncCheckpointsFromBucket() (*blockSummary, error) {
+	provider := checkpoint.NewBucketChunkProvider(n.checkpointSyncBucket)
+	metadata, err := provider.GetCheckpoints(n.ctx, &checkpoint.GetCheckpointsRequest{
+		Version:   1,
+		Namespace: n.commonNode.Runtime.ID(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't get checkpoint list from bucket: %w", err)
+	}
+	sortCheckpoints(metadata)
+
+	return n.restoreCheckpoints(metadata, func(check *checkpoint.Metadata) (int, error) {
+		restorer := n.localStorage.Checkpointer()
+		if err := restorer.StartRestore(n.ctx, check); err != nil {
+			return checkpointStatusBail, fmt.Errorf("can't start checkpoint restore: %w", err)
+		}
+
+		err := checkpoint.RestoreFromProvider(n.ctx, provider, restorer, check)
+		switch {
+		case err == nil:
+			return checkpointStatusDone, nil
+		case errors.Is(err, checkpoint.ErrChunkNotFound),
+			errors.Is(err, checkpoint.ErrChunkCorrupted),
+			errors.Is(err, checkpoint.ErrChunkProofVerificationFailed):
+			return checkpointStatusNext, err
+		default:
+			return checkpointStatusBail, err
+		}
+	})
+}
+
+// restoreCheckpoints tries to restore the given checkpoints (sorted from most recent backwards)
+// using the given checkpoint handler until both the state and I/O roots of a round are restored.
+func (n *Node) restoreCheckpoints(
+	metadata []*checkpoint.Metadata,
+	handle func(*checkpoint.Metadata) (int, error),
+) (*blockSummary, error) {
+	// Store roots and round info for checkpoints that finished syncing.
+	// Round and namespace info will get overwritten as rounds are skipped
+	// for errors, driven by remainingRoots.
+	var syncState blockSummary
+
 	// Try all the checkpoints now, from most recent backwards.
 	var prevVersion uint64
 	var mask outstandingMask
@@ -432,7 +481,7 @@ func (n *Node) syncCheckpoints() (*blockSummary, error) {
 			doneRoots = []hash.Hash{}
 		}
 
-		status, err := n.handleCheckpoint(check, committeeClient, descriptor.Storage.GroupSize)
+		status, err := handle(check)
 		switch status {
 		case checkpointStatusDone:
 			n.logger.Info("successfully restored from checkpoint", "root", check.Root, "mask", mask)
@@ -8,7 +8,9 @@ import (
 	"math"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/eapache/channels"
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -18,7 +20,9 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/grpc/policy"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/objectstore"
 	"github.com/oasisprotocol/oasis-core/go/common/persistent"
+	"github.com/oasisprotocol/oasis-core/go/common/service"
 	"github.com/oasisprotocol/oasis-core/go/common/workerpool"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	registryApi "github.com/oasisprotocol/oasis-core/go/registry/api"
@@ -82,6 +86,16 @@ const (
 	RoundLatest = math.MaxUint64
 
 	defaultUndefinedRound = ^uint64(0)
+
+	// fetchRetryInitialInterval is the initial interval between retries of failed storage
+	// diff fetches.
+	fetchRetryInitialInterval = 1 * time.Second
+	// fetchRetryMaxInterval is the maximum interval between retries of failed storage diff
+	// fetches.
+	fetchRetryMaxInterval = 1 * time.Minute
+	// degradedFetchFailures is the number of consecutive failed storage diff fetches after
+	// which syncing is considered degraded.
+	degradedFetchFailures = 5
 )
 
 // outstandingMask records which storage roots still need to be synced or need to be retried.
@@ -180,6 +194,12 @@ type watcherState struct {
 	LastBlock blockSummary `json:"last_block"`
 }
 
+// syncHealth is the (non-persistent) sync health state.
+type syncHealth struct {
+	fetchFailures  uint64
+	lastFetchError error
+}
+
 // Node watches blocks for storage changes.
 type Node struct {
 	commonNode *committee.Node
@@ -201,9 +221,11 @@ type Node struct {
 
 	checkpointer           checkpoint.Checkpointer
 	checkpointSyncDisabled bool
+	checkpointSyncBucket   objectstore.Bucket
 
 	syncedLock  sync.RWMutex
 	syncedState watcherState
+	syncHealth  syncHealth
 
 	blockCh    *channels.InfiniteChannel
 	diffCh     chan *fetchedDiff
@@ -222,13 +244,14 @@ type Node struct {
 func NewNode(
 	commonNode *committee.Node,
 	grpcPolicy *policy.DynamicRuntimePolicyChecker,
-	fetchPool *workerpool.Pool,
+	fetcherCount uint,
 	store *persistent.ServiceStore,
 	roleProvider registration.RoleProvider,
 	workerCommonCfg workerCommon.Config,
 	localStorage storageApi.LocalBackend,
 	checkpointerCfg *checkpoint.CheckpointerConfig,
 	checkpointSyncDisabled bool,
+	checkpointSyncBucket objectstore.Bucket,
 ) (*Node, error) {
 	node := &Node{
 		commonNode: commonNode,
@@ -242,11 +265,10 @@ func NewNode(
 		localStorage: localStorage,
 		grpcPolicy:   grpcPolicy,
 
-		fetchPool: fetchPool,
-
 		stateStore: store,
 
 		checkpointSyncDisabled: checkpointSyncDisabled,
+		checkpointSyncBucket:   checkpointSyncBucket,
 
 		blockCh:    channels.NewInfiniteChannel(),
 		diffCh:     make(chan *fetchedDiff),
@@ -260,6 +282,12 @@ func NewNode(
 
 	node.syncedState.LastBlock.Round = defaultUndefinedRound
 	rtID := commonNode.Runtime.ID()
+
+	// Each runtime uses its own fetcher pool so that a stalled runtime can't delay syncing of
+	// the other runtimes.
+	node.fetchPool = workerpool.New("storage_fetch/" + rtID.String())
+	node.fetchPool.Resize(fetcherCount)
+
 	err := store.GetCBOR(rtID[:], &node.syncedState)
 	if err != nil && err != persistent.ErrNotFound {
 		return nil, fmt.Errorf("storage worker: failed to restore sync state: %w", err)
@@ -312,6 +340,7 @@ func NewNode(
 					blk.Header.StateRoot,
 				}, nil
 			},
+			Exporter: checkpointerCfg.Exporter,
 		}
 		node.checkpointer, err = checkpoint.NewCheckpointer(node.ctx, localStorage.NodeDB(), localStorage.Checkpointer(), *checkpointerCfg)
 		if err != nil {
@@ -371,9 +400,48 @@ func (n *Node) GetStatus(ctx context.Context) (*api.Status, error) {
 	n.syncedLock.RLock()
 	defer n.syncedLock.RUnlock()
 
-	return &api.Status{
+	status := &api.Status{
 		LastFinalizedRound: n.syncedState.LastBlock.Round,
-	}, nil
+		Health:             n.healthLocked(),
+		FetchFailures:      n.syncHealth.fetchFailures,
+	}
+	if n.syncHealth.lastFetchError != nil {
+		status.LastFetchError = n.syncHealth.lastFetchError.Error()
+	}
+	return status, nil
+}
+
+// Health returns the health state of syncing the runtime.
+func (n *Node) Health() service.HealthState {
+	n.syncedLock.RLock()
+	defer n.syncedLock.RUnlock()
+
+	return n.healthLocked()
+}
+
+func (n *Node) healthLocked() service.HealthState {
+	select {
+	case <-n.initCh:
+	default:
+		return service.HealthInitializing
+	}
+	if n.syncHealth.fetchFailures >= degradedFetchFailures {
+		return service.HealthDegraded
+	}
+	return service.HealthReady
+}
+
+func (n *Node) recordFetchResult(err error) {
+	n.syncedLock.Lock()
+	defer n.syncedLock.Unlock()
+
+	switch err {
+	case nil:
+		n.syncHealth = syncHealth{}
+	default:
+		n.syncHealth.fetchFailures++
+		n.syncHealth.lastFetchError = err
+	}
 }
 
 func (n *Node) getMetricLabels() prometheus.Labels {
@@ -426,6 +494,12 @@ func (n *Node) GetLastSynced() (uint64, mkvsNode.Root, mkvsNode.Root) {
 	return n.syncedState.LastBlock.Round, n.syncedState.LastBlock.IORoot, n.syncedState.LastBlock.StateRoot
 }
 
+// Compact immediately runs garbage collection on the local node database and
+// returns the number of reclaimed bytes.
+func (n *Node) Compact(ctx context.Context) (int64, error) {
+	return n.localStorage.NodeDB().Compact(ctx)
+}
+
 // ForceFinalize forces a storage finalization for the given round.
 func (n *Node) ForceFinalize(ctx context.Context, round uint64) error {
 	n.logger.Debug("forcing round finalization",
@@ -601,7 +675,7 @@ func (n *Node) updateExternalServicePolicy(rtComputeNodes runtimeCommittee.NodeD
 
 	// TODO: Query registry only for storage nodes after
 	// https://github.com/oasisprotocol/oasis-core/issues/1923 is implemented.
-	nodes, err := n.commonNode.Consensus.Registry().GetNodes(n.ctx, consensus.HeightLatest)
+	nodes, err := n.commonNode.Consensus.Registry().GetNodes(n.ctx, &registryApi.GetNodesQuery{Height: consensus.HeightLatest})
 	if err != nil {
 		n.logger.Error("couldn't get nodes from registry", "err", err)
 	}
@@ -693,6 +767,7 @@ func (n *Node) watchQuit() {
 func (n *Node) worker() { // nolint: gocyclo
 	defer close(n.workerQuitCh)
 	defer close(n.diffCh)
+	defer n.fetchPool.Stop()
 
 	// Wait for the common node to be initialized.
 	select {
@@ -776,7 +851,17 @@ func (n *Node) worker() { // nolint: gocyclo
 	// Try to perform initial sync from state and io checkpoints.
 	if !n.checkpointSyncDisabled {
 		var summary *blockSummary
-		summary, err = n.syncCheckpoints()
+		if n.checkpointSyncBucket != nil {
+			// Prefer checkpoints exported to object storage if configured, falling back to the
+			// storage committee in case this fails.
+			summary, err = n.syncCheckpointsFromBucket()
+			if err != nil {
+				n.logger.Info("checkpoint sync from bucket failed, trying storage committee", "err", err)
+			}
+		}
+		if summary == nil {
+			summary, err = n.syncCheckpoints()
+		}
 		if err != nil {
 			// Try syncing again. The main reason for this is the sync failing due to a
 			// checkpoint pruning race condition (where nodes list a checkpoint which is
@@ -796,6 +881,70 @@ func (n *Node) worker() { // nolint: gocyclo
 	}
 	close(n.initCh)
 
+	// syncRounds schedules storage diff fetches for all rounds up to the latest round that are
+	// not yet being fetched, including ones awaiting a retry after a failed fetch.
+	var latestRound uint64
+	syncRounds := func() {
+		for i := lastFullyAppliedRound + 1; i <= latestRound; i++ {
+			syncing, ok := syncingRounds[i]
+			if ok && syncing.outstanding == maskAll {
+				continue
+			}
+
+			if !ok {
+				syncing = &inFlight{
+					outstanding:   maskNone,
+					awaitingRetry: maskAll,
+				}
+				syncingRounds[i] = syncing
+
+				if i == latestRound {
+					storageWorkerLastPendingRound.With(n.getMetricLabels()).Set(float64(i))
+				}
+			}
+			n.logger.Debug("preparing round sync",
+				"round", i,
+				"outstanding_mask", syncing.outstanding,
+				"awaiting_retry", syncing.awaitingRetry,
+			)
+
+			prev := hashCache[i-1] // Closures take refs, so they need new variables here.
+			this := hashCache[i]
+			prevIORoot := mkvsNode.Root{ // IO roots aren't chained, so clear it (but leave cache intact).
+				Namespace: this.IORoot.Namespace,
+				Version:   this.IORoot.Version,
+			}
+			prevIORoot.Hash.Empty()
+
+			if (syncing.outstanding&maskIO) == 0 && (syncing.awaitingRetry&maskIO) != 0 {
+				syncing.outstanding |= maskIO
+				syncing.awaitingRetry &= ^maskIO
+				fetcherGroup.Add(1)
+				n.fetchPool.Submit(func() {
+					defer fetcherGroup.Done()
+					n.fetchDiff(this.Round, &prevIORoot, &this.IORoot, maskIO)
+				})
+			}
+			if (syncing.outstanding&maskState) == 0 && (syncing.awaitingRetry&maskState) != 0 {
+				syncing.outstanding |= maskState
+				syncing.awaitingRetry &= ^maskState
+				fetcherGroup.Add(1)
+				n.fetchPool.Submit(func() {
+					defer fetcherGroup.Done()
+					n.fetchDiff(this.Round, &prev.StateRoot, &this.StateRoot, maskState)
+				})
+			}
+		}
+	}
+
+	// Failed fetches are retried with an exponential backoff which is independent for each
+	// runtime and is reset after any successful fetch.
+	retryBackoff := backoff.NewExponentialBackOff()
+	retryBackoff.InitialInterval = fetchRetryInitialInterval
+	retryBackoff.MaxInterval = fetchRetryMaxInterval
+	retryBackoff.MaxElapsedTime = 0
+	var retryCh <-chan time.Time
+
 	// Main processing loop. When a new block comes in, its state and io roots are inspected and their
 	// writelogs fetched from remote storage nodes in case we don't have them locally yet. Fetches are
 	// asynchronous and, once complete, trigger local Apply operations. These are serialized
@@ -916,56 +1065,8 @@ mainLoop:
 				hashCache[blk.Header.Round] = summaryFromBlock(blk)
 			}
 
-			for i := lastFullyAppliedRound + 1; i <= blk.Header.Round; i++ {
-				syncing, ok := syncingRounds[i]
-				if ok && syncing.outstanding == maskAll {
-					continue
-				}
-
-				if !ok {
-					syncing = &inFlight{
-						outstanding:   maskNone,
-						awaitingRetry: maskAll,
-					}
-					syncingRounds[i] = syncing
-
-					if i == blk.Header.Round {
-						storageWorkerLastPendingRound.With(n.getMetricLabels()).Set(float64(i))
-					}
-				}
-				n.logger.Debug("preparing round sync",
-					"round", i,
-					"outstanding_mask", syncing.outstanding,
-					"awaiting_retry", syncing.awaitingRetry,
-				)
-
-				prev := hashCache[i-1] // Closures take refs, so they need new variables here.
-				this := hashCache[i]
-				prevIORoot := mkvsNode.Root{ // IO roots aren't chained, so clear it (but leave cache intact).
-					Namespace: this.IORoot.Namespace,
-					Version:   this.IORoot.Version,
-				}
-				prevIORoot.Hash.Empty()
-
-				if (syncing.outstanding&maskIO) == 0 && (syncing.awaitingRetry&maskIO) != 0 {
-					syncing.outstanding |= maskIO
-					syncing.awaitingRetry &= ^maskIO
-					fetcherGroup.Add(1)
-					n.fetchPool.Submit(func() {
-						defer fetcherGroup.Done()
-						n.fetchDiff(this.Round, &prevIORoot, &this.IORoot, maskIO)
-					})
-				}
-				if (syncing.outstanding&maskState) == 0 && (syncing.awaitingRetry&maskState) != 0 {
-					syncing.outstanding |= maskState
-					syncing.awaitingRetry &= ^maskState
-					fetcherGroup.Add(1)
-					n.fetchPool.Submit(func() {
-						defer fetcherGroup.Done()
-						n.fetchDiff(this.Round, &prev.StateRoot, &this.StateRoot, maskState)
-					})
-				}
-			}
+			latestRound = blk.Header.Round
+			syncRounds()
 
 		case item := <-n.diffCh:
 			if item.err != nil {
@@ -978,10 +1079,21 @@ mainLoop:
 				)
 				syncingRounds[item.round].outstanding &= ^item.fetchMask
 				syncingRounds[item.round].awaitingRetry |= item.fetchMask
+				n.recordFetchResult(item.err)
+
+				if retryCh == nil {
+					retryCh = time.After(retryBackoff.NextBackOff())
+				}
 			} else {
 				heap.Push(outOfOrderDiffs, item)
+				n.recordFetchResult(nil)
+				retryBackoff.Reset()
 			}
 
+		case <-retryCh:
+			retryCh = nil
+			syncRounds()
+
 		case finalized := <-n.finalizeCh:
 			// No further sync or out of order handling needed here, since
 			// only one finalize at a time is triggered (for round cachedLastRound+1)
@@ -12,10 +12,13 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
+	"github.com/oasisprotocol/oasis-core/go/common/objectstore"
 
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	"github.com/oasisprotocol/oasis-core/go/storage/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/database"
+	nodedb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/rocksdb"
 )
 
 const (
@@ -28,8 +31,20 @@ const (
 	// CfgWorkerCheckpointCheckInterval configures the checkpointer check interval.
 	CfgWorkerCheckpointCheckInterval = "worker.storage.checkpointer.check_interval"
 
+	// CfgWorkerCheckpointExportURL configures the object storage bucket URL that created
+	// checkpoints are exported to.
+	CfgWorkerCheckpointExportURL = "worker.storage.checkpointer.export_url"
+
 	// CfgCheckpointSyncDisabled disables syncing from checkpoints on worker startup.
 	CfgWorkerCheckpointSyncDisabled = "worker.storage.checkpoint_sync.disabled"
+	// CfgWorkerCheckpointSyncBucketURL configures the object storage bucket URL that exported
+	// checkpoints are synced from on worker startup.
+	CfgWorkerCheckpointSyncBucketURL = "worker.storage.checkpoint_sync.bucket_url"
+
+	// CfgObjectStoreEndpoint configures the object storage endpoint override.
+	CfgObjectStoreEndpoint = "worker.storage.object_store.endpoint"
+	// CfgObjectStoreRegion configures the object storage region.
+	CfgObjectStoreRegion = "worker.storage.object_store.region"
 
 	// CfgWorkerDebugIgnoreApply is a debug option that makes the worker ignore
 	// all apply operations.
@@ -44,6 +59,19 @@ const (
 	// CfgMaxCacheSize configures the maximum in-memory cache size.
 	CfgMaxCacheSize = "worker.storage.max_cache_size"
 
+	// CfgGCWindows configures the daily UTC time windows during which periodic storage garbage
+	// collection is allowed to run.
+	CfgGCWindows = "worker.storage.gc_windows"
+
+	// CfgRocksDBBlockCacheSize configures the block cache size of the RocksDB backend.
+	CfgRocksDBBlockCacheSize = "worker.storage.rocksdb.block_cache_size"
+
+	// CfgRocksDBCompactionStyle configures the compaction style of the RocksDB backend.
+	CfgRocksDBCompactionStyle = "worker.storage.rocksdb.compaction_style"
+
+	// CfgWorkerDebugVerifyOnStartup enables verification of the latest storage roots on startup.
+	CfgWorkerDebugVerifyOnStartup = "worker.storage.debug.verify_on_startup"
+
 	cfgCrashEnabled       = "worker.storage.crash.enabled"
 	cfgInsecureSkipChecks = "worker.storage.debug.insecure_skip_checks"
 )
@@ -65,6 +93,9 @@ func NewLocalBackend(
 		InsecureSkipChecks: viper.GetBool(cfgInsecureSkipChecks) && cmdFlags.DebugDontBlameOasis(),
 		Namespace:          namespace,
 		MaxCacheSize:       int64(viper.GetSizeInBytes(CfgMaxCacheSize)),
+		VerifyOnStartup:    viper.GetBool(CfgWorkerDebugVerifyOnStartup),
+		GCWindows:          viper.GetStringSlice(CfgGCWindows),
+		RocksDB:            RocksDBConfig(),
 	}
 
 	var (
@@ -72,7 +103,7 @@ func NewLocalBackend(
 		impl api.Backend
 	)
 	switch cfg.Backend {
-	case database.BackendNameBadgerDB:
+	case database.BackendNameBadgerDB, database.BackendNameRocksDB, database.BackendNameMemory:
 		cfg.DB = filepath.Join(cfg.DB, database.DefaultFileName(cfg.Backend))
 		impl, err = database.New(cfg)
 	default:
@@ -90,12 +121,36 @@ func NewLocalBackend(
 	return api.NewMetricsWrapper(impl), nil
 }
 
+// RocksDBConfig returns the RocksDB backend specific configuration based on the configuration
+// flags.
+func RocksDBConfig() nodedb.RocksDBConfig {
+	return nodedb.RocksDBConfig{
+		BlockCacheSize:  int64(viper.GetSizeInBytes(CfgRocksDBBlockCacheSize)),
+		CompactionStyle: strings.ToLower(viper.GetString(CfgRocksDBCompactionStyle)),
+	}
+}
+
+func newBucket(url string) (objectstore.Bucket, error) {
+	if url == "" {
+		return nil, nil
+	}
+	return objectstore.New(&objectstore.Config{
+		URL:      url,
+		Endpoint: viper.GetString(CfgObjectStoreEndpoint),
+		Region:   viper.GetString(CfgObjectStoreRegion),
+	})
+}
+
 func init() {
 	Flags.Bool(CfgWorkerEnabled, false, "Enable storage worker")
-	Flags.Uint(cfgWorkerFetcherCount, 4, "Number of concurrent storage diff fetchers")
+	Flags.Uint(cfgWorkerFetcherCount, 4, "Number of concurrent storage diff fetchers per runtime")
 	Flags.Bool(CfgWorkerCheckpointerDisabled, false, "Disable the storage checkpointer")
 	Flags.Duration(CfgWorkerCheckpointCheckInterval, 1*time.Minute, "Storage checkpointer check interval")
+	Flags.String(CfgWorkerCheckpointExportURL, "", "Export created checkpoints to the given object storage bucket (s3://<bucket>[/<prefix>] or gs://<bucket>[/<prefix>])")
 	Flags.Bool(CfgWorkerCheckpointSyncDisabled, false, "Disable initial storage sync from checkpoints")
+	Flags.String(CfgWorkerCheckpointSyncBucketURL, "", "Perform initial storage sync from checkpoints exported to the given object storage bucket")
+	Flags.String(CfgObjectStoreEndpoint, "", "Object storage endpoint override (e.g., for S3-compatible services)")
+	Flags.String(CfgObjectStoreRegion, "", "Object storage bucket region")
 
 	Flags.Bool(CfgWorkerDebugIgnoreApply, false, "Ignore Apply operations (for debugging purposes)")
 	_ = Flags.MarkHidden(CfgWorkerDebugIgnoreApply)
@@ -104,11 +159,16 @@ func init() {
 	Flags.Bool(cfgCrashEnabled, false, "Enable the crashing storage wrapper")
 	Flags.Int(CfgLRUSlots, 1000, "How many LRU slots to use for Apply call locks in the MKVS tree root cache")
 	Flags.String(CfgMaxCacheSize, "64mb", "Maximum in-memory cache size")
+	Flags.StringSlice(CfgGCWindows, []string{}, "Daily UTC time windows (HH:MM-HH:MM) during which periodic storage garbage collection may run (if not set, it may run at any time)")
+	Flags.String(CfgRocksDBBlockCacheSize, "0", "RocksDB backend block cache size (if zero, the maximum cache size is used)")
+	Flags.String(CfgRocksDBCompactionStyle, rocksdb.CompactionStyleLevel, "RocksDB backend compaction style (level, universal)")
 
 	Flags.Bool(cfgInsecureSkipChecks, false, "INSECURE: Skip known root checks")
+	Flags.Bool(CfgWorkerDebugVerifyOnStartup, false, "Verify consistency of the latest storage roots on startup")
 
 	_ = Flags.MarkHidden(cfgInsecureSkipChecks)
 	_ = Flags.MarkHidden(cfgCrashEnabled)
+	_ = Flags.MarkHidden(CfgWorkerDebugVerifyOnStartup)
 
 	_ = viper.BindPFlags(Flags)
 }
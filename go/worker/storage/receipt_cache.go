@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cache/lru"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/storage/api"
+)
+
+// receiptCacheCapacity is the maximum number of cached Apply receipts.
+const receiptCacheCapacity = 1024
+
+// receiptCacheKey identifies an Apply request by its source and destination roots.
+//
+// Identical write logs submitted by different executor nodes in the same round
+// produce the same destination root, so the receipt only depends on the roots.
+type receiptCacheKey struct {
+	namespace common.Namespace
+	srcRound  uint64
+	srcRoot   hash.Hash
+	dstRound  uint64
+	dstRoot   hash.Hash
+}
+
+// receiptCache is a cache of receipts for already applied write logs.
+type receiptCache struct {
+	cache *lru.Cache
+}
+
+func (rc *receiptCache) key(request *api.ApplyRequest) receiptCacheKey {
+	return receiptCacheKey{
+		namespace: request.Namespace,
+		srcRound:  request.SrcRound,
+		srcRoot:   request.SrcRoot,
+		dstRound:  request.DstRound,
+		dstRoot:   request.DstRoot,
+	}
+}
+
+// get returns the cached receipts for an identical Apply request, if any.
+func (rc *receiptCache) get(request *api.ApplyRequest) ([]*api.Receipt, bool) {
+	v, ok := rc.cache.Get(rc.key(request))
+	if !ok {
+		return nil, false
+	}
+	return v.([]*api.Receipt), true
+}
+
+// put caches the receipts returned for a successful Apply request.
+func (rc *receiptCache) put(request *api.ApplyRequest, receipts []*api.Receipt) {
+	_ = rc.cache.Put(rc.key(request), receipts)
+}
+
+func newReceiptCache(capacity uint64) *receiptCache {
+	cache, _ := lru.New(lru.Capacity(capacity, false))
+	return &receiptCache{cache: cache}
+}
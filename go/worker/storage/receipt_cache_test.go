@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/storage/api"
+)
+
+func TestReceiptCache(t *testing.T) {
+	require := require.New(t)
+
+	ns := common.NewTestNamespaceFromSeed([]byte("receipt cache test ns"), 0)
+	signer := memorySigner.NewTestSigner("receipt cache test signer")
+
+	var srcRoot, dstRoot hash.Hash
+	srcRoot.Empty()
+	dstRoot.FromBytes([]byte("receipt cache test dst root"))
+
+	request := &api.ApplyRequest{
+		Namespace: ns,
+		SrcRound:  1,
+		SrcRoot:   srcRoot,
+		DstRound:  2,
+		DstRoot:   dstRoot,
+		WriteLog:  api.WriteLog{{Key: []byte("key"), Value: []byte("value")}},
+	}
+	receipt, err := api.SignReceipt(signer, ns, request.DstRound, []hash.Hash{dstRoot})
+	require.NoError(err, "SignReceipt")
+	receipts := []*api.Receipt{receipt}
+
+	rc := newReceiptCache(2)
+	_, ok := rc.get(request)
+	require.False(ok, "empty cache should not contain receipts")
+
+	rc.put(request, receipts)
+	cached, ok := rc.get(request)
+	require.True(ok, "cache should contain receipts for the applied request")
+	require.Equal(receipts, cached)
+
+	// Identical write logs submitted by another node should hit the cache.
+	dup := *request
+	dup.WriteLog = api.WriteLog{{Key: []byte("key"), Value: []byte("value")}}
+	cached, ok = rc.get(&dup)
+	require.True(ok, "cache should contain receipts for an identical request")
+	require.Equal(receipts, cached)
+
+	// Requests for different roots should not hit the cache.
+	other := *request
+	other.DstRoot.FromBytes([]byte("receipt cache test other dst root"))
+	_, ok = rc.get(&other)
+	require.False(ok, "cache should not contain receipts for a different destination root")
+	other = *request
+	other.DstRound = 3
+	_, ok = rc.get(&other)
+	require.False(ok, "cache should not contain receipts for a different destination round")
+
+	// The cache should be bounded.
+	for i := uint64(3); i < 5; i++ {
+		other = *request
+		other.DstRound = i
+		rc.put(&other, receipts)
+	}
+	_, ok = rc.get(request)
+	require.False(ok, "oldest receipts should be evicted")
+}
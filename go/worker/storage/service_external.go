@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/grpc/auth"
@@ -12,6 +15,7 @@ import (
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
 )
 
 var (
@@ -19,16 +23,61 @@ var (
 	_ auth.ServerAuth = (*storageService)(nil)
 
 	errDebugRejectUpdates = errors.New("storage: (debug) rejecting update operations")
+
+	storageApplyWriteLogEntries = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "oasis_worker_storage_apply_write_log_entries",
+			Help: "Number of entries in write logs submitted for Apply.",
+		},
+		[]string{"runtime"},
+	)
+	storageApplyWriteLogSize = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "oasis_worker_storage_apply_write_log_size",
+			Help: "Size of write logs submitted for Apply (bytes).",
+		},
+		[]string{"runtime"},
+	)
+
+	storageApplyReceiptCacheHits = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_storage_apply_receipt_cache_hits",
+			Help: "Number of Apply requests served from the receipt cache.",
+		},
+		[]string{"runtime"},
+	)
+
+	storageServiceCollectors = []prometheus.Collector{
+		storageApplyWriteLogEntries,
+		storageApplyWriteLogSize,
+		storageApplyReceiptCacheHits,
+	}
+
+	storageServiceMetricsOnce sync.Once
 )
 
 // storageService is the service exposed to external clients via gRPC.
 type storageService struct {
-	w       *Worker
-	storage api.Backend
+	w        *Worker
+	storage  api.Backend
+	receipts *receiptCache
 
 	debugRejectUpdates bool
 }
 
+func newStorageService(w *Worker, storage api.Backend, debugRejectUpdates bool) *storageService {
+	storageServiceMetricsOnce.Do(func() {
+		prometheus.MustRegister(storageServiceCollectors...)
+	})
+
+	return &storageService{
+		w:                  w,
+		storage:            storage,
+		receipts:           newReceiptCache(receiptCacheCapacity),
+		debugRejectUpdates: debugRejectUpdates,
+	}
+}
+
 func (s *storageService) AuthFunc(ctx context.Context, fullMethodName string, req interface{}) error {
 	return policy.GRPCAuthenticationFunction(s.w.grpcPolicy)(ctx, fullMethodName, req)
 }
@@ -55,6 +104,29 @@ func (s *storageService) getConfig(ctx context.Context, ns common.Namespace) (*r
 	return &rtDesc.Storage, nil
 }
 
+// validateWriteLog checks that a write log submitted for Apply is well-formed and within the
+// configured limits and updates the write log size metrics.
+func (s *storageService) validateWriteLog(ns common.Namespace, cfg *registry.StorageParameters, wl writelog.WriteLog) error {
+	// Limit maximum number of entries in a write log.
+	if uint64(len(wl)) > cfg.MaxApplyWriteLogEntries {
+		return api.ErrLimitReached
+	}
+
+	stats, err := wl.Validate(&writelog.Limits{
+		MaxKeySize:   cfg.MaxApplyKeySize,
+		MaxValueSize: cfg.MaxApplyValueSize,
+	})
+	if err != nil {
+		return fmt.Errorf("storage: malformed write log: %w", err)
+	}
+
+	labels := prometheus.Labels{"runtime": ns.String()}
+	storageApplyWriteLogEntries.With(labels).Observe(float64(len(wl)))
+	storageApplyWriteLogSize.With(labels).Observe(float64(stats.Size()))
+
+	return nil
+}
+
 func (s *storageService) SyncGet(ctx context.Context, request *api.GetRequest) (*api.ProofResponse, error) {
 	if err := s.ensureInitialized(ctx); err != nil {
 		return nil, err
@@ -84,16 +156,27 @@ func (s *storageService) Apply(ctx context.Context, request *api.ApplyRequest) (
 		return nil, errDebugRejectUpdates
 	}
 
-	// Limit maximum number of entries in a write log.
 	cfg, err := s.getConfig(ctx, request.Namespace)
 	if err != nil {
 		return nil, err
 	}
-	if uint64(len(request.WriteLog)) > cfg.MaxApplyWriteLogEntries {
-		return nil, api.ErrLimitReached
+	if err = s.validateWriteLog(request.Namespace, cfg, request.WriteLog); err != nil {
+		return nil, err
 	}
 
-	return s.storage.Apply(ctx, request)
+	// Executor nodes in the same committee submit identical write logs during normal rounds, so
+	// avoid redundant work by returning the receipt for an already applied request.
+	if receipts, ok := s.receipts.get(request); ok {
+		storageApplyReceiptCacheHits.With(prometheus.Labels{"runtime": request.Namespace.String()}).Inc()
+		return receipts, nil
+	}
+
+	receipts, err := s.storage.Apply(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	s.receipts.put(request, receipts)
+	return receipts, nil
 }
 
 func (s *storageService) ApplyBatch(ctx context.Context, request *api.ApplyBatchRequest) ([]*api.Receipt, error) {
@@ -112,10 +195,9 @@ func (s *storageService) ApplyBatch(ctx context.Context, request *api.ApplyBatch
 	if uint64(len(request.Ops)) > cfg.MaxApplyOps {
 		return nil, api.ErrLimitReached
 	}
-	// Limit maximum number of entries in a write log.
 	for _, op := range request.Ops {
-		if uint64(len(op.WriteLog)) > cfg.MaxApplyWriteLogEntries {
-			return nil, api.ErrLimitReached
+		if err = s.validateWriteLog(request.Namespace, cfg, op.WriteLog); err != nil {
+			return nil, err
 		}
 	}
 
@@ -30,3 +30,18 @@ func (w *Worker) ForceFinalize(ctx context.Context, request *api.ForceFinalizeRe
 
 	return node.ForceFinalize(ctx, request.Round)
 }
+
+func (w *Worker) Compact(ctx context.Context, request *api.CompactRequest) (*api.CompactResponse, error) {
+	node := w.runtimes[request.RuntimeID]
+	if node == nil {
+		return nil, api.ErrRuntimeNotFound
+	}
+
+	reclaimed, err := node.Compact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &api.CompactResponse{
+		Reclaimed: reclaimed,
+	}, nil
+}
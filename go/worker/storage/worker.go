@@ -10,8 +10,9 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/grpc/policy"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/objectstore"
 	"github.com/oasisprotocol/oasis-core/go/common/persistent"
-	"github.com/oasisprotocol/oasis-core/go/common/workerpool"
+	"github.com/oasisprotocol/oasis-core/go/common/service"
 	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	"github.com/oasisprotocol/oasis-core/go/runtime/registry"
@@ -44,9 +45,11 @@ type Worker struct {
 
 	runtimes   map[common.Namespace]*committee.Node
 	watchState *persistent.ServiceStore
-	fetchPool  *workerpool.Pool
 
 	grpcPolicy *policy.DynamicRuntimePolicyChecker
+
+	checkpointExportBucket objectstore.Bucket
+	checkpointSyncBucket   objectstore.Bucket
 }
 
 // New constructs a new storage worker.
@@ -70,9 +73,6 @@ func New(
 	if s.enabled {
 		var err error
 
-		s.fetchPool = workerpool.New("storage_fetch")
-		s.fetchPool.Resize(viper.GetUint(cfgWorkerFetcherCount))
-
 		s.watchState, err = commonStore.GetServiceStore(workerStorageDBBucketName)
 		if err != nil {
 			return nil, err
@@ -80,17 +80,27 @@ func New(
 
 		// Attach storage interface to gRPC server.
 		s.grpcPolicy = policy.NewDynamicRuntimePolicyChecker(api.ServiceName, s.commonWorker.GrpcPolicyWatcher)
-		api.RegisterService(s.commonWorker.Grpc.Server(), &storageService{
-			w:                  s,
-			storage:            s.commonWorker.RuntimeRegistry.StorageRouter(),
-			debugRejectUpdates: viper.GetBool(CfgWorkerDebugIgnoreApply) && flags.DebugDontBlameOasis(),
-		})
+		api.RegisterService(s.commonWorker.Grpc.Server(), newStorageService(
+			s,
+			s.commonWorker.RuntimeRegistry.StorageRouter(),
+			viper.GetBool(CfgWorkerDebugIgnoreApply) && flags.DebugDontBlameOasis(),
+		))
 
 		var checkpointerCfg *checkpoint.CheckpointerConfig
 		if !viper.GetBool(CfgWorkerCheckpointerDisabled) {
 			checkpointerCfg = &checkpoint.CheckpointerConfig{
 				CheckInterval: viper.GetDuration(CfgWorkerCheckpointCheckInterval),
 			}
+
+			s.checkpointExportBucket, err = newBucket(viper.GetString(CfgWorkerCheckpointExportURL))
+			if err != nil {
+				return nil, fmt.Errorf("storage worker: failed to configure checkpoint export: %w", err)
+			}
+		}
+
+		s.checkpointSyncBucket, err = newBucket(viper.GetString(CfgWorkerCheckpointSyncBucketURL))
+		if err != nil {
+			return nil, fmt.Errorf("storage worker: failed to configure checkpoint sync bucket: %w", err)
 		}
 
 		// Start storage node for every runtime.
@@ -129,16 +139,23 @@ func (s *Worker) registerRuntime(dataDir string, commonNode *committeeCommon.Nod
 	}
 	commonNode.Runtime.RegisterStorage(localStorage)
 
+	if checkpointerCfg != nil && s.checkpointExportBucket != nil {
+		cfg := *checkpointerCfg
+		cfg.Exporter = checkpoint.NewExporter(s.checkpointExportBucket, localStorage.Checkpointer())
+		checkpointerCfg = &cfg
+	}
+
 	node, err := committee.NewNode(
 		commonNode,
 		s.grpcPolicy,
-		s.fetchPool,
+		viper.GetUint(cfgWorkerFetcherCount),
 		s.watchState,
 		rp,
 		s.commonWorker.GetConfig(),
 		localStorage,
 		checkpointerCfg,
 		viper.GetBool(CfgWorkerCheckpointSyncDisabled),
+		s.checkpointSyncBucket,
 	)
 	if err != nil {
 		return err
@@ -187,9 +204,6 @@ func (s *Worker) Start() error {
 		for _, r := range s.runtimes {
 			<-r.Quit()
 		}
-		if s.fetchPool != nil {
-			<-s.fetchPool.Quit()
-		}
 	}()
 
 	// Start all runtimes and wait for initialization.
@@ -225,9 +239,6 @@ func (s *Worker) Stop() {
 	for _, r := range s.runtimes {
 		r.Stop()
 	}
-	if s.fetchPool != nil {
-		s.fetchPool.Stop()
-	}
 	if s.watchState != nil {
 		s.watchState.Close()
 	}
@@ -238,6 +249,18 @@ func (s *Worker) Quit() <-chan struct{} {
 	return s.quitCh
 }
 
+// Health returns the health state of the storage worker.
+//
+// The worker is degraded in case syncing of any of its runtimes is degraded.
+func (s *Worker) Health() service.HealthState {
+	for _, r := range s.runtimes {
+		if r.Health() == service.HealthDegraded {
+			return service.HealthDegraded
+		}
+	}
+	return service.HealthReady
+}
+
 // Cleanup performs the service specific post-termination cleanup.
 func (s *Worker) Cleanup() {
 }
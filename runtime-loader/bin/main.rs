@@ -9,6 +9,7 @@ use oasis_core_runtime_loader::{ElfLoader, Loader, SgxsLoader};
 
 fn main() {
     let matches = App::new("Oasis runtime loader")
+        .version(crate_version!())
         .arg(
             Arg::with_name("type")
                 .long("type")
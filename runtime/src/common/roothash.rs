@@ -10,6 +10,7 @@ use serde_repr::*;
 use super::{
     cbor,
     crypto::{hash::Hash, signature::SignatureBundle},
+    staking,
 };
 
 /// Runtime block.
@@ -51,9 +52,101 @@ impl Default for HeaderType {
     }
 }
 
-/// Roothash message.
-#[derive(Clone, Debug, PartialEq, Eq, Hash, Serialize, Deserialize)]
-pub enum Message {}
+/// Roothash message that can be sent by a runtime.
+///
+/// Exactly one of the fields must be set.
+///
+/// # Note
+///
+/// This should be kept in sync with go/roothash/api/block/message.go.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct Message {
+    /// Staking operation performed on behalf of the runtime account.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub staking: Option<StakingMessage>,
+}
+
+/// Runtime message that performs a staking operation on behalf of the runtime account.
+///
+/// Exactly one of the operation fields must be set.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct StakingMessage {
+    /// Message version.
+    #[serde(default)]
+    pub v: u16,
+    /// Transfers tokens from the runtime account.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub transfer: Option<staking::Transfer>,
+    /// Escrows tokens from the runtime account.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub add_escrow: Option<staking::Escrow>,
+    /// Reclaims escrowed tokens to the runtime account.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub reclaim_escrow: Option<staking::ReclaimEscrow>,
+}
+
+/// Result of executing a runtime message.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct MessageResult {
+    /// Module of the error in case message execution failed.
+    #[serde(default, skip_serializing_if = "String::is_empty")]
+    pub module: String,
+    /// Error code in case message execution failed. Zero indicates success.
+    #[serde(default, skip_serializing_if = "is_zero")]
+    pub code: u32,
+}
+
+impl MessageResult {
+    /// Returns true iff the message was executed successfully.
+    pub fn is_success(&self) -> bool {
+        self.code == 0
+    }
+}
+
+fn is_zero(v: &u32) -> bool {
+    *v == 0
+}
+
+/// Maximum number of tags that can be attached to a round.
+pub const MAX_TAGS: usize = 16;
+/// Maximum size of a tag key in bytes.
+pub const MAX_TAG_KEY_SIZE: usize = 64;
+/// Maximum size of a tag value in bytes.
+pub const MAX_TAG_VALUE_SIZE: usize = 256;
+
+/// Key/value tag attached by a runtime to the results of a round.
+///
+/// Tags are stored in roothash state and can be indexed by the consensus layer.
+///
+/// # Note
+///
+/// This should be kept in sync with go/roothash/api/block/tag.go.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct Tag {
+    /// Tag key. It may only contain ASCII letters, digits, '_', '-' and '.'.
+    pub key: String,
+    /// Tag value. It may only contain printable ASCII characters.
+    pub value: String,
+}
+
+impl Tag {
+    /// Returns true iff the tag is valid.
+    pub fn is_valid(&self) -> bool {
+        if self.key.is_empty() || self.key.len() > MAX_TAG_KEY_SIZE {
+            return false;
+        }
+        if !self
+            .key
+            .bytes()
+            .all(|c| c.is_ascii_alphanumeric() || c == b'_' || c == b'-' || c == b'.')
+        {
+            return false;
+        }
+
+        self.value.len() <= MAX_TAG_VALUE_SIZE
+            && self.value.bytes().all(|c| (0x20..=0x7e).contains(&c))
+    }
+}
 
 /// Block header.
 #[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
@@ -76,6 +169,9 @@ pub struct Header {
     pub state_root: Hash,
     /// Messages sent this round.
     pub messages: Option<Vec<Message>>,
+    /// Results of executing the messages sent in the previous round.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub message_results: Option<Vec<MessageResult>>,
     /// Storage receipt signatures.
     pub storage_signatures: Option<Vec<SignatureBundle>>,
 }
@@ -110,6 +206,9 @@ pub struct ComputeResultsHeader {
     /// Messages sent from this batch.
     #[serde(skip_serializing_if = "Vec::is_empty")]
     pub messages: Vec<Message>,
+    /// Tags attached to this round.
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub tags: Vec<Tag>,
 }
 
 impl ComputeResultsHeader {
@@ -164,6 +263,7 @@ mod tests {
             io_root: Some(Hash::empty_hash()),
             state_root: Some(Hash::empty_hash()),
             messages: Vec::new(),
+            tags: Vec::new(),
         };
         assert_eq!(
             populated.encoded_hash(),
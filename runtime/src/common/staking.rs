@@ -0,0 +1,73 @@
+//! Consensus staking structures.
+//!
+//! # Note
+//!
+//! This **MUST** be kept in sync with go/staking/api.
+//!
+use serde::{Deserialize, Serialize};
+use serde_bytes;
+
+impl_bytes!(Address, 21, "A staking account address.");
+
+/// An arbitrary precision unsigned integer.
+///
+/// The value is stored as a big-endian byte string without leading zeros.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct Quantity(#[serde(with = "serde_bytes")] Vec<u8>);
+
+impl From<u64> for Quantity {
+    fn from(v: u64) -> Self {
+        let bytes = v.to_be_bytes();
+        let start = bytes.iter().position(|b| *b != 0).unwrap_or(bytes.len());
+        Quantity(bytes[start..].to_vec())
+    }
+}
+
+impl AsRef<[u8]> for Quantity {
+    fn as_ref(&self) -> &[u8] {
+        &self.0
+    }
+}
+
+/// A stake transfer.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct Transfer {
+    /// Destination account address.
+    pub to: Address,
+    /// Amount of base units to transfer.
+    pub amount: Quantity,
+}
+
+/// A stake escrow.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct Escrow {
+    /// Escrow account address.
+    pub account: Address,
+    /// Amount of base units to escrow.
+    pub amount: Quantity,
+    /// Optional account that should be credited with the resulting shares.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub beneficiary: Option<Address>,
+}
+
+/// A reclamation of stake from an escrow.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct ReclaimEscrow {
+    /// Escrow account address.
+    pub account: Address,
+    /// Amount of shares to reclaim.
+    pub shares: Quantity,
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_quantity_from_u64() {
+        assert!(Quantity::from(0).as_ref().is_empty());
+        assert_eq!(Quantity::from(1).as_ref(), &[1]);
+        assert_eq!(Quantity::from(0x0100).as_ref(), &[1, 0]);
+        assert_eq!(Quantity::from(u64::MAX).as_ref(), &[0xff; 8]);
+    }
+}
@@ -337,7 +337,7 @@ impl Dispatcher {
                     )
                     .unwrap();
             }
-            Ok((mut outputs, mut tags, messages)) => {
+            Ok((mut outputs, mut tags, mut gas_used, messages, block_tags)) => {
                 if check_only {
                     debug!(self.logger, "Transaction batch check complete");
 
@@ -391,11 +391,19 @@ impl Dispatcher {
                 );
                     }
 
-                    for (tx_hash, (output, tags)) in
-                        hashes.drain(..).zip(outputs.drain(..).zip(tags.drain(..)))
-                    {
+                    for (tx_hash, (output, (tags, gas_used))) in hashes.drain(..).zip(
+                        outputs
+                            .drain(..)
+                            .zip(tags.drain(..).zip(gas_used.drain(..))),
+                    ) {
                         txn_tree
-                            .add_output(Context::create_child(&ctx), tx_hash, output, tags)
+                            .add_output(
+                                Context::create_child(&ctx),
+                                tx_hash,
+                                output,
+                                gas_used,
+                                tags,
+                            )
                             .expect("add transaction must succeed");
                     }
 
@@ -409,6 +417,7 @@ impl Dispatcher {
                         io_root: Some(io_root),
                         state_root: Some(new_state_root),
                         messages,
+                        tags: block_tags,
                     };
 
                     debug!(self.logger, "Transaction batch execution complete";
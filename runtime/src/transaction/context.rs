@@ -3,8 +3,10 @@ use std::{any::Any, sync::Arc};
 
 use io_context::Context as IoContext;
 
+use anyhow::{anyhow, Result};
+
 use super::tags::{Tag, Tags};
-use crate::common::roothash::{Header, Message};
+use crate::common::roothash::{self, Header, Message};
 
 struct NoRuntimeContext;
 
@@ -24,8 +26,14 @@ pub struct Context<'a> {
     /// List of emitted tags for each transaction.
     tags: Vec<Tags>,
 
+    /// Amount of gas used by each transaction.
+    gas_used: Vec<u64>,
+
     /// List of messages emitted.
     messages: Vec<Message>,
+
+    /// List of tags attached to the block.
+    block_tags: Vec<roothash::Tag>,
 }
 
 impl<'a> Context<'a> {
@@ -37,18 +45,22 @@ impl<'a> Context<'a> {
             runtime: Box::new(NoRuntimeContext),
             check_only,
             tags: Vec::new(),
+            gas_used: Vec::new(),
             messages: Vec::new(),
+            block_tags: Vec::new(),
         }
     }
 
     /// Start a new transaction.
     pub fn start_transaction(&mut self) {
         self.tags.push(Tags::new());
+        self.gas_used.push(0);
     }
 
-    /// Close the context and return the emitted tags and sent roothash messages.
-    pub fn close(self) -> (Vec<Tags>, Vec<Message>) {
-        (self.tags, self.messages)
+    /// Close the context and return the emitted tags, per-transaction gas used,
+    /// sent roothash messages and block tags.
+    pub fn close(self) -> (Vec<Tags>, Vec<u64>, Vec<Message>, Vec<roothash::Tag>) {
+        (self.tags, self.gas_used, self.messages, self.block_tags)
     }
 
     /// Emit a runtime-specific indexable tag refering to the specific
@@ -77,6 +89,56 @@ impl<'a> Context<'a> {
             .push(Tag::new(key.as_ref().to_vec(), value.as_ref().to_vec()))
     }
 
+    /// Report the amount of gas used by the transaction which is being
+    /// processed.
+    ///
+    /// The reported amount is stored together with the transaction output
+    /// and is made available to clients as part of transaction results.
+    ///
+    /// # Panics
+    ///
+    /// Calling this method outside of a transaction will panic.
+    ///
+    pub fn set_txn_gas_used(&mut self, gas_used: u64) {
+        *self
+            .gas_used
+            .last_mut()
+            .expect("must only be called inside a transaction") = gas_used;
+    }
+
+    /// Attach a tag to the block that contains this transaction.
+    ///
+    /// Block tags are included in the compute results and stored by the
+    /// consensus layer, where they can be indexed without knowledge of the
+    /// runtime. If a tag with the same key has already been attached, its
+    /// value is replaced.
+    ///
+    /// An error is returned if the tag is invalid or if the maximum number
+    /// of block tags has been reached.
+    pub fn emit_block_tag<K, V>(&mut self, key: K, value: V) -> Result<()>
+    where
+        K: Into<String>,
+        V: Into<String>,
+    {
+        let tag = roothash::Tag {
+            key: key.into(),
+            value: value.into(),
+        };
+        if !tag.is_valid() {
+            return Err(anyhow!("invalid block tag"));
+        }
+
+        if let Some(existing) = self.block_tags.iter_mut().find(|t| t.key == tag.key) {
+            existing.value = tag.value;
+            return Ok(());
+        }
+        if self.block_tags.len() >= roothash::MAX_TAGS {
+            return Err(anyhow!("too many block tags"));
+        }
+        self.block_tags.push(tag);
+        Ok(())
+    }
+
     /// Send a roothash message as part of the block that contains this transaction.
     /// See RFC 0065 for information on roothash messages.
     pub fn send_roothash_message(&mut self, message: Message) {
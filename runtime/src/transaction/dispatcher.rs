@@ -16,7 +16,11 @@ use super::{
     tags::Tags,
     types::{TxnBatch, TxnCall, TxnCheckResult, TxnOutput},
 };
-use crate::common::{cbor, crypto::hash::Hash, roothash::Message as RoothashMessage};
+use crate::common::{
+    cbor,
+    crypto::hash::Hash,
+    roothash::{Message as RoothashMessage, Tag as RoothashTag},
+};
 
 /// Dispatch error.
 #[derive(Error, Debug)]
@@ -174,11 +178,20 @@ impl Method {
 /// to process transactions.
 pub trait Dispatcher {
     /// Dispatches a batch of runtime requests.
+    ///
+    /// Returns the outputs, emitted tags and gas used for each request together
+    /// with the sent roothash messages and block tags.
     fn dispatch_batch(
         &self,
         batch: &TxnBatch,
         ctx: Context,
-    ) -> Result<(TxnBatch, Vec<Tags>, Vec<RoothashMessage>)>;
+    ) -> Result<(
+        TxnBatch,
+        Vec<Tags>,
+        Vec<u64>,
+        Vec<RoothashMessage>,
+        Vec<RoothashTag>,
+    )>;
     /// Invoke the finalizer (if any).
     fn finalize(&self, new_storage_root: Hash);
     /// Configure abort batch flag.
@@ -202,10 +215,16 @@ impl Dispatcher for NoopDispatcher {
         &self,
         _batch: &TxnBatch,
         ctx: Context,
-    ) -> Result<(TxnBatch, Vec<Tags>, Vec<RoothashMessage>)> {
+    ) -> Result<(
+        TxnBatch,
+        Vec<Tags>,
+        Vec<u64>,
+        Vec<RoothashMessage>,
+        Vec<RoothashTag>,
+    )> {
         let outputs = TxnBatch::new(Vec::new());
-        let (tags, roothash_messages) = ctx.close();
-        Ok((outputs, tags, roothash_messages))
+        let (tags, gas_used, roothash_messages, block_tags) = ctx.close();
+        Ok((outputs, tags, gas_used, roothash_messages, block_tags))
     }
 
     fn finalize(&self, _new_storage_root: Hash) {
@@ -307,7 +326,13 @@ impl Dispatcher for MethodDispatcher {
         &self,
         batch: &TxnBatch,
         mut ctx: Context,
-    ) -> Result<(TxnBatch, Vec<Tags>, Vec<RoothashMessage>)> {
+    ) -> Result<(
+        TxnBatch,
+        Vec<Tags>,
+        Vec<u64>,
+        Vec<RoothashMessage>,
+        Vec<RoothashTag>,
+    )> {
         if let Some(ref ctx_init) = self.ctx_initializer {
             ctx_init.init(&mut ctx);
         }
@@ -338,8 +363,8 @@ impl Dispatcher for MethodDispatcher {
             handler.end_batch(&mut ctx);
         }
 
-        let (tags, roothash_messages) = ctx.close();
-        Ok((outputs, tags, roothash_messages))
+        let (tags, gas_used, roothash_messages, block_tags) = ctx.close();
+        Ok((outputs, tags, gas_used, roothash_messages, block_tags))
     }
 
     fn finalize(&self, new_storage_root: Hash) {
@@ -128,11 +128,17 @@ impl serde::Serialize for InputArtifacts {
 /// The output transaction artifacts.
 ///
 /// These are the artifacts that are stored CBOR-serialized in the Merkle tree.
+///
+/// In order to remain compatible with existing trees, the gas used element is
+/// only serialized when it is non-zero.
 #[derive(Clone, Debug, PartialEq, Deserialize)]
 struct OutputArtifacts {
     /// Transaction output.
     #[serde(with = "serde_bytes")]
     pub output: Vec<u8>,
+    /// Amount of gas used by the transaction.
+    #[serde(default)]
+    pub gas_used: u64,
 }
 
 impl serde::Serialize for OutputArtifacts {
@@ -140,8 +146,15 @@ impl serde::Serialize for OutputArtifacts {
     where
         S: Serializer,
     {
-        let mut seq = serializer.serialize_seq(Some(1))?;
+        if self.gas_used == 0 {
+            let mut seq = serializer.serialize_seq(Some(1))?;
+            seq.serialize_element(&Bytes::new(&self.output))?;
+            return seq.end();
+        }
+
+        let mut seq = serializer.serialize_seq(Some(2))?;
         seq.serialize_element(&Bytes::new(&self.output))?;
+        seq.serialize_element(&self.gas_used)?;
         seq.end()
     }
 }
@@ -182,12 +195,14 @@ impl Tree {
         Ok(())
     }
 
-    /// Add an output transaction artifact.
+    /// Add an output transaction artifact together with the amount of gas
+    /// used by the transaction (zero if not metered).
     pub fn add_output(
         &mut self,
         ctx: Context,
         tx_hash: Hash,
         output: Vec<u8>,
+        gas_used: u64,
         tags: Tags,
     ) -> Result<()> {
         let ctx = ctx.freeze();
@@ -199,7 +214,7 @@ impl Tree {
                 kind: ArtifactKind::Output,
             }
             .encode(),
-            &cbor::to_vec(&OutputArtifacts { output }),
+            &cbor::to_vec(&OutputArtifacts { output, gas_used }),
         )?;
 
         // Add tags if specified.
@@ -251,6 +266,7 @@ mod test {
             Context::background(),
             tx_hash,
             b"and this comes out".to_vec(),
+            0,
             vec![Tag::new(b"tag1".to_vec(), b"value1".to_vec())],
         )
         .unwrap();
@@ -264,6 +280,7 @@ mod test {
                 Context::background(),
                 tx_hash,
                 b"and this comes out".to_vec(),
+                0,
                 vec![
                     Tag::new(b"tagA".to_vec(), b"valueA".to_vec()),
                     Tag::new(b"tagB".to_vec(), b"valueB".to_vec()),
@@ -279,4 +296,23 @@ mod test {
             "c65f4e8bd5314c26f245337a859ad244f4b1544acf60ef334cf0d0eadb47363b",
         );
     }
+
+    #[test]
+    fn test_output_artifacts_gas_used() {
+        let legacy = OutputArtifacts {
+            output: b"and this comes out".to_vec(),
+            gas_used: 0,
+        };
+        let enc = cbor::to_vec(&legacy);
+        assert_eq!(enc, cbor::to_vec(&vec![Bytes::new(&legacy.output)]));
+        let dec: OutputArtifacts = cbor::from_slice(&enc).unwrap();
+        assert_eq!(dec, legacy);
+
+        let metered = OutputArtifacts {
+            output: b"and this comes out".to_vec(),
+            gas_used: 42,
+        };
+        let dec: OutputArtifacts = cbor::from_slice(&cbor::to_vec(&metered)).unwrap();
+        assert_eq!(dec, metered);
+    }
 }
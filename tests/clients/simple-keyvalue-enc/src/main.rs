@@ -37,11 +37,19 @@ fn main() {
                 .required(true),
         )
         .arg(Arg::with_name("seed").long("seed").takes_value(true))
+        .arg(
+            Arg::with_name("mode")
+                .long("mode")
+                .takes_value(true)
+                .possible_values(&["full", "insert", "get"])
+                .default_value("full"),
+        )
         .get_matches();
 
     let node_address = matches.value_of("node-address").unwrap();
     let runtime_id = value_t_or_exit!(matches, "runtime-id", RuntimeId);
     let k = matches.value_of("key").unwrap();
+    let mode = matches.value_of("mode").unwrap();
     let nonce_seed = matches
         .value_of("seed")
         .unwrap_or("seeeeeeeeeeeeeeeeeeeeeeeeeeeeeed")
@@ -66,12 +74,14 @@ fn main() {
         key: k.to_owned(),
         nonce: rng.gen(),
     };
-    println!(
-        "Storing \"{}\" as key and \"{}\" as value to database...",
-        kv.key, kv.value
-    );
-    let r: Option<String> = rt.block_on(kv_client.enc_insert(kv)).unwrap();
-    assert_eq!(r, None); // key should not exist in db before
+    if mode != "get" {
+        println!(
+            "Storing \"{}\" as key and \"{}\" as value to database...",
+            kv.key, kv.value
+        );
+        let r: Option<String> = rt.block_on(kv_client.enc_insert(kv)).unwrap();
+        assert_eq!(r, None); // key should not exist in db before
+    }
 
     println!("Getting \"{}\"...", key.key);
     let r = rt.block_on(kv_client.enc_get(key.clone())).unwrap();
@@ -87,22 +97,26 @@ fn main() {
     }
     key.nonce = rng.gen();
 
-    println!("Removing \"{}\" record from database...", key.key);
-    let r = rt.block_on(kv_client.enc_remove(key.clone())).unwrap();
-    assert_eq!(r, Some("hello_value".to_string())); // key should exist in db while removing it
-    key.nonce = rng.gen();
+    // Only remove the key in full mode so that other invocations can check
+    // that the (encrypted) state remains accessible.
+    if mode == "full" {
+        println!("Removing \"{}\" record from database...", key.key);
+        let r = rt.block_on(kv_client.enc_remove(key.clone())).unwrap();
+        assert_eq!(r, Some("hello_value".to_string())); // key should exist in db while removing it
+        key.nonce = rng.gen();
 
-    println!(
-        "Getting \"{}\" to check whether it still exists...",
-        key.key
-    );
-    let r = rt.block_on(kv_client.enc_get(key.clone())).unwrap();
-    match r {
-        Some(_) => println!("Key still exists."),
-        None => println!("Key not found anymore"),
+        println!(
+            "Getting \"{}\" to check whether it still exists...",
+            key.key
+        );
+        let r = rt.block_on(kv_client.enc_get(key.clone())).unwrap();
+        match r {
+            Some(_) => println!("Key still exists."),
+            None => println!("Key not found anymore"),
+        }
+        assert_eq!(r, None, "key should not exist anymore");
+        key.nonce = rng.gen();
     }
-    assert_eq!(r, None, "key should not exist anymore");
-    key.nonce = rng.gen();
 
     // Test that key manager connection via EnclaveRPC works.
     println!("Testing key manager connection via gRPC transport...");
@@ -231,8 +231,10 @@ fn main() {
         conditions: vec![QueryCondition {
             key: b"kv_op".to_vec(),
             values: vec![ByteBuf::from(b"insert".to_vec())],
+            match_kind: 0,
         }],
         limit: 0,
+        cursor: None,
     };
     let txns = rt
         .block_on(kv_client.txn_client().query_txs(query))